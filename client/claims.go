@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package client // import "go.opentelemetry.io/collector/client"
+
+// Claims are the structured claims about an authenticated client, returned by the server
+// authenticators implementing auth.ClaimsServer.
+type Claims struct {
+	// Subject identifies the authenticated client, such as its username.
+	Subject string
+
+	// Tenant is the tenant the client belongs to, empty if the authenticator has no notion of tenancy.
+	Tenant string
+
+	// Scopes are the scopes granted to the client.
+	Scopes []string
+
+	// Attributes are the other claims about the client, named and typed by the authenticator.
+	Attributes map[string]any
+}
+
+// HasScope returns whether the claims grant the scope, the nil claims grant none.
+func (c *Claims) HasScope(scope string) bool {
+	if c == nil {
+		return false
+	}
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
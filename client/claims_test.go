@@ -0,0 +1,20 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimsHasScope(t *testing.T) {
+	claims := &Claims{Subject: "agent", Tenant: "tenant-a", Scopes: []string{"traces:write", "metrics:write"}}
+	assert.True(t, claims.HasScope("traces:write"))
+	assert.True(t, claims.HasScope("metrics:write"))
+	assert.False(t, claims.HasScope("logs:write"))
+
+	var noClaims *Claims
+	assert.False(t, noClaims.HasScope("traces:write"))
+}
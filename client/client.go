@@ -42,6 +42,9 @@
 //
 // - rate limit client calls based on IP addresses
 //
+// - route data points or enforce quotas based on the identity of the clients
+// verified with their TLS certificates (mTLS)
+//
 // Processors and exporters relying on the existence of data from the
 // client.Info, especially client.AuthData, should clearly document this as part
 // of the component's README file. The expected pattern for consuming data is to
@@ -97,6 +100,15 @@ type Info struct {
 	// this connection.
 	Auth AuthData
 
+	// Claims are the structured claims about the client, such as its tenant and its scopes, returned by
+	// the auth.ClaimsServer authenticator tied to the receiver for this connection.
+	Claims *Claims
+
+	// Identity is the identity of the client verified with its TLS certificate, available when the
+	// receiver requires the clients to present a certificate, as done by configtls.TLSServerSetting
+	// when client_ca_file or spiffe is set.
+	Identity *Identity
+
 	// Metadata is the request metadata from the client connecting to this connector.
 	// Experimental: *NOTE* this structure is subject to change or removal in the future.
 	Metadata Metadata
@@ -170,3 +182,24 @@ func (m Metadata) Get(key string) []string {
 
 	return ret
 }
+
+// Select returns a copy of the metadata holding only the given keys, which are looked up
+// regardless of their case. The values are stored under the given keys.
+func (m Metadata) Select(keys ...string) Metadata {
+	data := make(map[string][]string, len(keys))
+	for _, key := range keys {
+		vals, ok := m.data[key]
+		if !ok {
+			for k, v := range m.data {
+				if strings.EqualFold(key, k) {
+					vals, ok = v, true
+					break
+				}
+			}
+		}
+		if ok && len(vals) > 0 {
+			data[key] = append([]string(nil), vals...)
+		}
+	}
+	return Metadata{data: data}
+}
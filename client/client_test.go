@@ -89,3 +89,19 @@ func TestMetadata(t *testing.T) {
 
 	assert.Empty(t, md.Get("non-existent-key"))
 }
+
+func TestMetadataSelect(t *testing.T) {
+	source := map[string][]string{"X-Tenant": {"acme"}, "authorization": {"secret"}, "x-region": {"eu", "us"}}
+	md := NewMetadata(source).Select("x-tenant", "x-region", "x-missing")
+	assert.Equal(t, []string{"acme"}, md.Get("x-tenant"))
+	assert.Equal(t, []string{"eu", "us"}, md.Get("x-region"))
+	assert.Empty(t, md.Get("authorization"))
+	assert.Empty(t, md.Get("x-missing"))
+	assert.Len(t, md.data, 2)
+
+	// The selected values are copied.
+	source["x-region"][0] = "ap"
+	assert.Equal(t, []string{"eu", "us"}, md.Get("x-region"))
+
+	assert.Empty(t, Metadata{}.Select("x-tenant").Get("x-tenant"))
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package client // import "go.opentelemetry.io/collector/client"
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+)
+
+// spiffeScheme is the scheme of the URIs which are SPIFFE IDs.
+const spiffeScheme = "spiffe"
+
+// Identity is the identity of a client verified with its TLS certificate.
+type Identity struct {
+	// Subject is the distinguished name of the subject of the certificate.
+	Subject string
+
+	// CommonName is the common name of the subject of the certificate.
+	CommonName string
+
+	// DNSNames are the DNS names of the subject alternative names of the certificate.
+	DNSNames []string
+
+	// EmailAddresses are the email addresses of the subject alternative names of the certificate.
+	EmailAddresses []string
+
+	// IPAddresses are the IP addresses of the subject alternative names of the certificate.
+	IPAddresses []net.IP
+
+	// URIs are the URIs of the subject alternative names of the certificate.
+	URIs []string
+
+	// SPIFFEID is the first URI of the subject alternative names with the spiffe scheme, empty if none.
+	SPIFFEID string
+}
+
+// IdentityFromTLS returns the identity of the client from the state of its TLS connection, or nil if the
+// certificate of the client was not verified.
+func IdentityFromTLS(state *tls.ConnectionState) *Identity {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return identityFromCert(state.VerifiedChains[0][0])
+}
+
+// IdentityFromPeerCertificate returns the identity of the client from the first certificate it presented, or
+// nil if it presented none. It must only be used when the server verified the certificate itself, in its
+// VerifyPeerCertificate callback, since the handshake then records no verified chains, as with SPIFFE.
+func IdentityFromPeerCertificate(state *tls.ConnectionState) *Identity {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return nil
+	}
+	return identityFromCert(state.PeerCertificates[0])
+}
+
+func identityFromCert(cert *x509.Certificate) *Identity {
+	id := &Identity{
+		Subject:        cert.Subject.String(),
+		CommonName:     cert.Subject.CommonName,
+		DNSNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+		IPAddresses:    cert.IPAddresses,
+	}
+	for _, uri := range cert.URIs {
+		id.URIs = append(id.URIs, uri.String())
+		if id.SPIFFEID == "" && uri.Scheme == spiffeScheme {
+			id.SPIFFEID = uri.String()
+		}
+	}
+	return id
+}
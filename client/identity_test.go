@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityFromTLS(t *testing.T) {
+	spiffeID, _ := url.Parse("spiffe://example.org/ns/tenant-a/sa/agent")
+	other, _ := url.Parse("https://example.org/agent")
+	cert := &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "agent", Organization: []string{"Tenant A"}},
+		DNSNames:       []string{"agent.example.org"},
+		EmailAddresses: []string{"agent@example.org"},
+		IPAddresses:    []net.IP{net.IPv4(10, 0, 0, 1)},
+		URIs:           []*url.URL{other, spiffeID},
+	}
+
+	assert.Equal(t, &Identity{
+		Subject:        "CN=agent,O=Tenant A",
+		CommonName:     "agent",
+		DNSNames:       []string{"agent.example.org"},
+		EmailAddresses: []string{"agent@example.org"},
+		IPAddresses:    []net.IP{net.IPv4(10, 0, 0, 1)},
+		URIs:           []string{"https://example.org/agent", "spiffe://example.org/ns/tenant-a/sa/agent"},
+		SPIFFEID:       "spiffe://example.org/ns/tenant-a/sa/agent",
+	}, IdentityFromTLS(&tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}))
+}
+
+func TestIdentityFromTLSNotVerified(t *testing.T) {
+	assert.Nil(t, IdentityFromTLS(nil))
+	// The certificates presented by the client are ignored if they were not verified.
+	assert.Nil(t, IdentityFromTLS(&tls.ConnectionState{PeerCertificates: []*x509.Certificate{{}}}))
+}
+
+func TestIdentityFromPeerCertificate(t *testing.T) {
+	spiffeID, _ := url.Parse("spiffe://example.org/ns/tenant-a/sa/agent")
+	cert := &x509.Certificate{URIs: []*url.URL{spiffeID}}
+
+	assert.Nil(t, IdentityFromPeerCertificate(nil))
+	assert.Nil(t, IdentityFromPeerCertificate(&tls.ConnectionState{}))
+	assert.Equal(t, &Identity{
+		URIs:     []string{"spiffe://example.org/ns/tenant-a/sa/agent"},
+		SPIFFEID: "spiffe://example.org/ns/tenant-a/sa/agent",
+	}, IdentityFromPeerCertificate(&tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}))
+}
@@ -27,6 +27,8 @@ type Config struct {
 	SkipCompilation bool   `mapstructure:"-"`
 	SkipGetModules  bool   `mapstructure:"-"`
 	LDFlags         string `mapstructure:"-"`
+	// Policy is the organization policy the distribution must comply with, loaded from the policy file
+	Policy *Policy `mapstructure:"-"`
 
 	Distribution Distribution `mapstructure:"dist"`
 	Exporters    []Module     `mapstructure:"exporters"`
@@ -49,6 +51,8 @@ type Distribution struct {
 	Version          string `mapstructure:"version"`
 	BuildTags        string `mapstructure:"build_tags"`
 	DebugCompilation bool   `mapstructure:"debug_compilation"`
+	Reproducible     bool   `mapstructure:"reproducible"`
+	SBOM             bool   `mapstructure:"sbom"`
 }
 
 // Module represents a receiver, exporter, processor or extension for the distribution
@@ -89,9 +93,20 @@ func (c *Config) Validate() error {
 		validateModules(c.Exporters),
 		validateModules(c.Processors),
 		validateModules(c.Connectors),
+		c.validatePolicy(),
 	)
 }
 
+func (c *Config) validatePolicy() error {
+	if c.Policy == nil {
+		return nil
+	}
+	if err := c.Policy.Validate(); err != nil {
+		return fmt.Errorf("invalid policy: %w", err)
+	}
+	return nil
+}
+
 // SetGoPath sets go path
 func (c *Config) SetGoPath() error {
 	if !c.SkipCompilation || !c.SkipGetModules {
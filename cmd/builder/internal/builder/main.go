@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"text/template"
 	"time"
 
@@ -31,6 +32,11 @@ func GenerateAndCompile(cfg Config) error {
 		return err
 	}
 
+	// check the licenses of the modules and write the SBOM
+	if err := checkSupplyChain(cfg); err != nil {
+		return err
+	}
+
 	return Compile(cfg)
 }
 
@@ -89,6 +95,11 @@ func Compile(cfg Config) error {
 	} else if len(cfg.LDFlags) > 0 {
 		ldflags += " " + cfg.LDFlags
 	}
+	if cfg.Distribution.Reproducible {
+		// the build ID depends on the paths and the environment of the build, the VCS status on the checkout
+		ldflags = strings.TrimSpace(ldflags + " -buildid=")
+		args = append(args, "-buildvcs=false")
+	}
 	args = append(args, "-ldflags="+ldflags)
 	if cfg.Distribution.BuildTags != "" {
 		args = append(args, "-tags", cfg.Distribution.BuildTags)
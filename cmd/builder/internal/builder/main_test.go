@@ -57,8 +57,16 @@ func TestGenerateAndCompile(t *testing.T) {
 	workspaceDir := filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(thisFile)))))
 	replaces := []string{fmt.Sprintf("go.opentelemetry.io/collector => %s", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/component => %s/component", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/config/configauth => %s/config/configauth", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/config/configcompression => %s/config/configcompression", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/config/configgrpc => %s/config/configgrpc", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/config/confighttp => %s/config/confighttp", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/config/configmiddleware => %s/config/configmiddleware", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/config/confignet => %s/config/confignet", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/config/configopaque => %s/config/configopaque", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/config/configtelemetry => %s/config/configtelemetry", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/config/configtls => %s/config/configtls", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/config/internal => %s/config/internal", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/confmap => %s/confmap", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/consumer => %s/consumer", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/connector => %s/connector", workspaceDir),
@@ -67,6 +75,7 @@ func TestGenerateAndCompile(t *testing.T) {
 		fmt.Sprintf("go.opentelemetry.io/collector/exporter/otlpexporter => %s/exporter/otlpexporter", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/exporter/otlphttpexporter => %s/exporter/otlphttpexporter", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/extension => %s/extension", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/extension/middleware => %s/extension/middleware", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/extension/ballastextension => %s/extension/ballastextension", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/extension/zpagesextension => %s/extension/zpagesextension", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/featuregate => %s/featuregate", workspaceDir),
@@ -112,6 +121,18 @@ func TestGenerateAndCompile(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			testCase: "Reproducible Compilation With SBOM",
+			cfgBuilder: func(t *testing.T) Config {
+				cfg := NewDefaultConfig()
+				cfg.Distribution.OutputPath = t.TempDir()
+				cfg.Replaces = append(cfg.Replaces, replaces...)
+				cfg.Distribution.Reproducible = true
+				cfg.Distribution.SBOM = true
+				cfg.Policy = &Policy{DeniedLicenses: []string{"AGPL-3.0"}}
+				return cfg
+			},
+		},
 	}
 
 	for _, tt := range testCases {
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder // import "go.opentelemetry.io/collector/cmd/builder/internal/builder"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+var (
+	// ErrModuleNotAllowed indicates a module not allowed by the policy
+	ErrModuleNotAllowed = errors.New("module not allowed by the policy")
+	// ErrLicenseDenied indicates a module whose license is denied by the policy
+	ErrLicenseDenied = errors.New("license denied by the policy")
+	// ErrLicenseUnknown indicates a module whose license could not be identified, while the policy denies licenses
+	ErrLicenseUnknown = errors.New("license not identified, it may be denied by the policy")
+)
+
+// Policy holds the rules of an organization the distributions must comply with
+type Policy struct {
+	// AllowedModules are the patterns of the Go modules allowed as components: a module path, or a
+	// path followed by "/..." which matches the module and all the modules under it. All the modules
+	// are allowed if empty.
+	AllowedModules []string `mapstructure:"allowed_modules"`
+	// DeniedLicenses are the SPDX identifiers of the licenses denied for all the Go modules of the
+	// distribution, including the indirect dependencies, e.g. "AGPL-3.0".
+	DeniedLicenses []string `mapstructure:"denied_licenses"`
+	// AllowUnknownLicenses allows the Go modules whose license cannot be identified when licenses are denied,
+	// they are denied by default as they may use a denied license.
+	AllowUnknownLicenses bool `mapstructure:"allow_unknown_licenses"`
+	// Replaces are "replace" directives pinning modules, taking precedence over the ones of the configuration.
+	Replaces []string `mapstructure:"replaces"`
+}
+
+// Validate checks whether the policy is valid
+func (p *Policy) Validate() error {
+	var errs error
+	for _, pattern := range p.AllowedModules {
+		if strings.TrimSuffix(pattern, "/...") == "" {
+			errs = multierr.Append(errs, fmt.Errorf("invalid allowed module pattern %q", pattern))
+		}
+	}
+	for _, replace := range p.Replaces {
+		if _, _, ok := strings.Cut(replace, "=>"); !ok {
+			errs = multierr.Append(errs, fmt.Errorf("invalid replace %q, expected \"<module> => <replacement>\"", replace))
+		}
+	}
+	return errs
+}
+
+// ApplyPolicy checks that the modules of the components are allowed by the policy, and pins the replaces of the policy
+func (c *Config) ApplyPolicy() error {
+	if c.Policy == nil {
+		return nil
+	}
+	var errs error
+	for _, mods := range [][]Module{c.Extensions, c.Receivers, c.Exporters, c.Processors, c.Connectors} {
+		for _, mod := range mods {
+			path := strings.Fields(mod.GoMod)[0]
+			if !c.Policy.allowsModule(path) {
+				errs = multierr.Append(errs, fmt.Errorf("module %q: %w", path, ErrModuleNotAllowed))
+			}
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	c.Replaces = pinReplaces(c.Replaces, c.Policy.Replaces)
+	return nil
+}
+
+func (p *Policy) allowsModule(path string) bool {
+	if len(p.AllowedModules) == 0 {
+		return true
+	}
+	for _, pattern := range p.AllowedModules {
+		if strings.HasSuffix(pattern, "/...") {
+			prefix := strings.TrimSuffix(pattern, "/...")
+			if path == prefix || strings.HasPrefix(path, prefix+"/") {
+				return true
+			}
+		} else if path == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLicenses checks that none of the modules uses a license denied by the policy, nor a license which
+// could not be identified unless the policy allows them
+func (p *Policy) checkLicenses(mods []goModule) error {
+	if len(p.DeniedLicenses) == 0 {
+		return nil
+	}
+	denied := make(map[string]bool, len(p.DeniedLicenses))
+	for _, license := range p.DeniedLicenses {
+		denied[strings.ToLower(license)] = true
+	}
+	var errs error
+	for _, mod := range mods {
+		switch {
+		case denied[strings.ToLower(mod.License)]:
+			errs = multierr.Append(errs, fmt.Errorf("module %q uses %s: %w", mod.Path, mod.License, ErrLicenseDenied))
+		case mod.License == noAssertion && !mod.Main && !p.AllowUnknownLicenses:
+			errs = multierr.Append(errs, fmt.Errorf("module %q: %w", mod.Path, ErrLicenseUnknown))
+		}
+	}
+	return errs
+}
+
+// pinReplaces returns the replaces, with the replaces of the modules pinned by the policy replaced by the pinned ones
+func pinReplaces(replaces []string, pinned []string) []string {
+	pinnedModules := make(map[string]bool, len(pinned))
+	for _, replace := range pinned {
+		pinnedModules[replacedModule(replace)] = true
+	}
+	var result []string
+	for _, replace := range replaces {
+		if !pinnedModules[replacedModule(replace)] {
+			result = append(result, replace)
+		}
+	}
+	return append(result, pinned...)
+}
+
+// replacedModule returns the module path of the left side of a replace, without its version
+func replacedModule(replace string) string {
+	old, _, _ := strings.Cut(replace, "=>")
+	fields := strings.Fields(old)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyValidate(t *testing.T) {
+	assert.NoError(t, (&Policy{
+		AllowedModules: []string{"go.opentelemetry.io/collector/...", "github.com/org/repo"},
+		Replaces:       []string{"github.com/org/repo => github.com/org/repo v1.2.3"},
+	}).Validate())
+
+	err := (&Policy{
+		AllowedModules: []string{"/..."},
+		Replaces:       []string{"github.com/org/repo v1.2.3"},
+	}).Validate()
+	assert.ErrorContains(t, err, `invalid allowed module pattern "/..."`)
+	assert.ErrorContains(t, err, `invalid replace "github.com/org/repo v1.2.3"`)
+
+	cfg := NewDefaultConfig()
+	cfg.Policy = &Policy{AllowedModules: []string{""}}
+	assert.ErrorContains(t, cfg.Validate(), "invalid policy")
+}
+
+func TestApplyPolicyAllowedModules(t *testing.T) {
+	policy := &Policy{AllowedModules: []string{
+		"go.opentelemetry.io/collector/...",
+		"github.com/org/repo",
+	}}
+	cfg := Config{
+		Policy: policy,
+		Receivers: []Module{
+			{GoMod: "go.opentelemetry.io/collector/receiver/otlpreceiver v0.80.0"},
+			{GoMod: "github.com/org/repo v0.1.2"},
+		},
+		Exporters: []Module{{GoMod: "go.opentelemetry.io/collector v0.80.0"}},
+	}
+	assert.NoError(t, cfg.ApplyPolicy())
+
+	cfg.Processors = []Module{
+		{GoMod: "github.com/org/repo/processor v0.1.2"},
+		{GoMod: "go.opentelemetry.io/collectorcontrib v0.1.2"},
+	}
+	err := cfg.ApplyPolicy()
+	assert.ErrorIs(t, err, ErrModuleNotAllowed)
+	assert.ErrorContains(t, err, `"github.com/org/repo/processor"`)
+	assert.ErrorContains(t, err, `"go.opentelemetry.io/collectorcontrib"`)
+}
+
+func TestApplyPolicyWithoutPolicy(t *testing.T) {
+	cfg := Config{
+		Receivers: []Module{{GoMod: "github.com/org/repo v0.1.2"}},
+		Replaces:  []string{"github.com/org/repo => ../repo"},
+	}
+	require.NoError(t, cfg.ApplyPolicy())
+	assert.Equal(t, []string{"github.com/org/repo => ../repo"}, cfg.Replaces)
+}
+
+func TestApplyPolicyPinsReplaces(t *testing.T) {
+	cfg := Config{
+		Policy: &Policy{Replaces: []string{
+			"github.com/org/pinned => github.com/org/pinned v1.2.3",
+		}},
+		Replaces: []string{
+			"github.com/org/pinned v1.0.0 => github.com/fork/pinned v1.0.1",
+			"github.com/org/other => ../other",
+		},
+	}
+	require.NoError(t, cfg.ApplyPolicy())
+	assert.Equal(t, []string{
+		"github.com/org/other => ../other",
+		"github.com/org/pinned => github.com/org/pinned v1.2.3",
+	}, cfg.Replaces)
+}
+
+func TestPolicyCheckLicenses(t *testing.T) {
+	mods := []goModule{
+		{Path: "github.com/org/apache", License: "Apache-2.0"},
+		{Path: "github.com/org/agpl", License: "AGPL-3.0"},
+		{Path: "github.com/org/unknown", License: noAssertion},
+		{Path: "github.com/org/distribution", License: noAssertion, Main: true},
+	}
+	assert.NoError(t, (&Policy{}).checkLicenses(mods))
+	assert.NoError(t, (&Policy{DeniedLicenses: []string{"GPL-3.0"}, AllowUnknownLicenses: true}).checkLicenses(mods))
+
+	err := (&Policy{DeniedLicenses: []string{"agpl-3.0"}, AllowUnknownLicenses: true}).checkLicenses(mods)
+	assert.ErrorIs(t, err, ErrLicenseDenied)
+	assert.ErrorContains(t, err, `module "github.com/org/agpl" uses AGPL-3.0`)
+
+	// The modules whose license is not identified are denied unless allowed, except the distribution itself.
+	err = (&Policy{DeniedLicenses: []string{"GPL-3.0"}}).checkLicenses(mods)
+	assert.ErrorIs(t, err, ErrLicenseUnknown)
+	assert.EqualError(t, err, `module "github.com/org/unknown": license not identified, it may be denied by the policy`)
+}
@@ -0,0 +1,295 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder // import "go.opentelemetry.io/collector/cmd/builder/internal/builder"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// sbomFile is the name of the SBOM written in the output path
+	sbomFile = "sbom.cdx.json"
+	// noAssertion is the license of the modules whose license could not be identified
+	noAssertion = "NOASSERTION"
+)
+
+// goModule is a module of the distribution, as listed by "go list -json"
+type goModule struct {
+	Path    string
+	Version string
+	Main    bool
+	Dir     string
+	Replace *goModule
+	// License is the SPDX identifier of the license of the module, noAssertion if unknown
+	License string `json:"-"`
+}
+
+// listModules lists the modules providing the packages built in the distribution, and identifies their license
+func listModules(cfg Config) ([]goModule, error) {
+	args := []string{"list", "-deps", "-json=Module"}
+	if cfg.Distribution.BuildTags != "" {
+		args = append(args, "-tags", cfg.Distribution.BuildTags)
+	}
+	// #nosec G204 -- cfg.Distribution.Go is trusted to be a safe path
+	cmd := exec.Command(cfg.Distribution.Go, append(args, "./...")...)
+	cmd.Dir = cfg.Distribution.OutputPath
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the go modules: %w. Output:\n%s", err, stderr.Bytes())
+	}
+	return parseModuleList(out)
+}
+
+// parseModuleList parses the output of "go list -deps -json=Module", the stream of the JSON objects of
+// the packages, and returns their modules sorted by path
+func parseModuleList(out []byte) ([]goModule, error) {
+	mods := make(map[string]goModule)
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var pkg struct {
+			Module *goModule
+		}
+		if err := dec.Decode(&pkg); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse the go modules: %w", err)
+		}
+		// the packages of the standard library have no module
+		if pkg.Module == nil {
+			continue
+		}
+		if _, ok := mods[pkg.Module.Path]; ok {
+			continue
+		}
+		mod := *pkg.Module
+		dir := mod.Dir
+		if mod.Replace != nil && mod.Replace.Dir != "" {
+			dir = mod.Replace.Dir
+		}
+		mod.License = detectLicense(dir)
+		if mod.License == noAssertion && mod.Replace != nil && mod.Replace.Dir != "" && mod.Replace.Version == "" {
+			mod.License = detectRepositoryLicense(dir)
+		}
+		mods[mod.Path] = mod
+	}
+
+	list := make([]goModule, 0, len(mods))
+	for _, mod := range mods {
+		list = append(list, mod)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Path < list[j].Path
+	})
+	return list, nil
+}
+
+var licenseFiles = []string{"LICENSE", "LICENSE.md", "LICENSE.txt", "LICENCE", "COPYING", "COPYING.md", "COPYING.txt"}
+
+// detectLicense identifies the license of the module in dir from its license file, noAssertion if it cannot
+func detectLicense(dir string) string {
+	if dir == "" {
+		return noAssertion
+	}
+	for _, name := range licenseFiles {
+		data, err := os.ReadFile(filepath.Clean(filepath.Join(dir, name)))
+		if err != nil {
+			continue
+		}
+		return identifyLicense(string(data))
+	}
+	return noAssertion
+}
+
+// detectRepositoryLicense identifies the license of the module in the local directory dir from the license file
+// of the closest parent directory having one, as the modules of a repository share its license. The parent
+// directories are searched up to the root of the repository, noAssertion if there is no such license file
+func detectRepositoryLicense(dir string) string {
+	for {
+		if license := detectLicense(dir); license != noAssertion {
+			return license
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return noAssertion
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return noAssertion
+		}
+		dir = parent
+	}
+}
+
+// licenseMatchers identify the licenses from their text, the first matching one wins
+var licenseMatchers = []struct {
+	id      string
+	pattern *regexp.Regexp
+}{
+	{"AGPL-3.0", regexp.MustCompile(`GNU AFFERO GENERAL PUBLIC LICENSE\s+Version 3`)},
+	{"LGPL-3.0", regexp.MustCompile(`GNU LESSER GENERAL PUBLIC LICENSE\s+Version 3`)},
+	{"LGPL-2.1", regexp.MustCompile(`GNU LESSER GENERAL PUBLIC LICENSE\s+Version 2\.1`)},
+	{"GPL-3.0", regexp.MustCompile(`GNU GENERAL PUBLIC LICENSE\s+Version 3`)},
+	{"GPL-2.0", regexp.MustCompile(`GNU GENERAL PUBLIC LICENSE\s+Version 2`)},
+	{"MPL-2.0", regexp.MustCompile(`Mozilla Public License(,)?\s+(Version|v\.)\s*2\.0`)},
+	{"Apache-2.0", regexp.MustCompile(`Apache License\s+Version 2\.0`)},
+	{"BSD-3-Clause", regexp.MustCompile(`(?s)Redistribution and use in source and binary forms.*Neither the name`)},
+	{"BSD-2-Clause", regexp.MustCompile(`Redistribution and use in source and binary forms`)},
+	{"ISC", regexp.MustCompile(`Permission to use, copy, modify, and(/or)? distribute this software for any`)},
+	{"MIT", regexp.MustCompile(`Permission is hereby granted, free of charge`)},
+}
+
+func identifyLicense(text string) string {
+	for _, m := range licenseMatchers {
+		if m.pattern.MatchString(text) {
+			return m.id
+		}
+	}
+	return noAssertion
+}
+
+// cycloneDXBOM is a CycloneDX 1.4 software bill of materials, limited to the fields written by the builder
+type cycloneDXBOM struct {
+	BOMFormat   string               `json:"bomFormat"`
+	SpecVersion string               `json:"specVersion"`
+	Version     int                  `json:"version"`
+	Metadata    cycloneDXMetadata    `json:"metadata"`
+	Components  []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Tools     []cycloneDXTool    `json:"tools"`
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXTool struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type cycloneDXComponent struct {
+	Type     string             `json:"type"`
+	Name     string             `json:"name"`
+	Version  string             `json:"version,omitempty"`
+	PURL     string             `json:"purl,omitempty"`
+	Licenses []cycloneDXLicense `json:"licenses,omitempty"`
+}
+
+type cycloneDXLicense struct {
+	License cycloneDXLicenseID `json:"license"`
+}
+
+type cycloneDXLicenseID struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// writeSBOM writes the CycloneDX SBOM of the modules of the distribution in the output path. The SBOM has
+// no timestamp nor serial number, so that it is reproducible.
+func writeSBOM(cfg Config, mods []goModule) error {
+	bom := cycloneDXBOM{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.4",
+		Version:     1,
+		Metadata: cycloneDXMetadata{
+			Tools: []cycloneDXTool{{Name: "ocb", Version: defaultOtelColVersion}},
+			Component: cycloneDXComponent{
+				Type:    "application",
+				Name:    cfg.Distribution.Name,
+				Version: cfg.Distribution.Version,
+			},
+		},
+		Components: []cycloneDXComponent{},
+	}
+	for _, mod := range mods {
+		if mod.Main {
+			continue
+		}
+		path, version := mod.Path, mod.Version
+		if mod.Replace != nil && mod.Replace.Version != "" {
+			path, version = mod.Replace.Path, mod.Replace.Version
+		}
+		component := cycloneDXComponent{
+			Type:    "library",
+			Name:    path,
+			Version: version,
+		}
+		if version != "" {
+			component.PURL = fmt.Sprintf("pkg:golang/%s@%s", path, version)
+		}
+		if mod.License == noAssertion {
+			component.Licenses = []cycloneDXLicense{{License: cycloneDXLicenseID{Name: noAssertion}}}
+		} else {
+			component.Licenses = []cycloneDXLicense{{License: cycloneDXLicenseID{ID: mod.License}}}
+		}
+		bom.Components = append(bom.Components, component)
+	}
+
+	data, err := json.MarshalIndent(bom, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(cfg.Distribution.OutputPath, sbomFile)
+	if err = os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write the SBOM: %w", err)
+	}
+	cfg.Logger.Info("SBOM created", zap.String("path", path))
+	return nil
+}
+
+// withoutLicense returns the modules whose license could not be identified
+func withoutLicense(mods []goModule) []string {
+	var paths []string
+	for _, mod := range mods {
+		if !mod.Main && mod.License == noAssertion {
+			paths = append(paths, mod.Path)
+		}
+	}
+	return paths
+}
+
+// checkSupplyChain lists the modules of the distribution once the modules are retrieved, checks their
+// license against the policy, and writes the SBOM
+func checkSupplyChain(cfg Config) error {
+	checkLicenses := cfg.Policy != nil && len(cfg.Policy.DeniedLicenses) > 0
+	if !checkLicenses && !cfg.Distribution.SBOM {
+		return nil
+	}
+	if cfg.SkipGetModules {
+		if checkLicenses {
+			return errors.New("the licenses of the policy cannot be checked when skipping the retrieval of the Go modules")
+		}
+		cfg.Logger.Info("Skipping the SBOM, the Go modules are not retrieved.")
+		return nil
+	}
+
+	mods, err := listModules(cfg)
+	if err != nil {
+		return err
+	}
+	if unknown := withoutLicense(mods); len(unknown) > 0 {
+		cfg.Logger.Warn("Could not identify the license of some modules", zap.String("modules", strings.Join(unknown, ", ")))
+	}
+	if checkLicenses {
+		if err = cfg.Policy.checkLicenses(mods); err != nil {
+			return err
+		}
+	}
+	if cfg.Distribution.SBOM {
+		return writeSBOM(cfg, mods)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestIdentifyLicense(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "                                 Apache License\n                           Version 2.0, January 2004", want: "Apache-2.0"},
+		{text: "MIT License\n\nPermission is hereby granted, free of charge, to any person", want: "MIT"},
+		{text: "Redistribution and use in source and binary forms, with or without\n...\n   * Neither the name of Google Inc.", want: "BSD-3-Clause"},
+		{text: "Redistribution and use in source and binary forms, with or without", want: "BSD-2-Clause"},
+		{text: "Permission to use, copy, modify, and/or distribute this software for any", want: "ISC"},
+		{text: "Mozilla Public License Version 2.0", want: "MPL-2.0"},
+		{text: "                    GNU AFFERO GENERAL PUBLIC LICENSE\n                       Version 3, 19 November 2007", want: "AGPL-3.0"},
+		{text: "                    GNU GENERAL PUBLIC LICENSE\n                       Version 3, 29 June 2007", want: "GPL-3.0"},
+		{text: "                    GNU GENERAL PUBLIC LICENSE\n                       Version 2, June 1991", want: "GPL-2.0"},
+		{text: "                   GNU LESSER GENERAL PUBLIC LICENSE\n                       Version 3, 29 June 2007", want: "LGPL-3.0"},
+		{text: "All rights reserved.", want: noAssertion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, identifyLicense(tt.text))
+		})
+	}
+}
+
+func TestDetectLicense(t *testing.T) {
+	dir := t.TempDir()
+	assert.Equal(t, noAssertion, detectLicense(""))
+	assert.Equal(t, noAssertion, detectLicense(dir))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "LICENSE.md"), []byte("Permission is hereby granted, free of charge"), 0600))
+	assert.Equal(t, "MIT", detectLicense(dir))
+
+	// The modules of a repository share the license at its root.
+	module := filepath.Join(dir, "repo", "module")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "repo", ".git"), 0700))
+	require.NoError(t, os.MkdirAll(module, 0700))
+	assert.Equal(t, noAssertion, detectRepositoryLicense(module))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "repo", "LICENSE"), []byte("Apache License\nVersion 2.0"), 0600))
+	assert.Equal(t, "Apache-2.0", detectRepositoryLicense(module))
+}
+
+func TestParseModuleList(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "LICENSE"), []byte("Apache License\nVersion 2.0"), 0600))
+
+	out := []byte(`{}
+{
+	"Module": {
+		"Path": "github.com/org/repo",
+		"Version": "v0.1.2",
+		"Dir": "` + filepath.ToSlash(dir) + `"
+	}
+}
+{
+	"Module": {
+		"Path": "github.com/org/distribution",
+		"Main": true,
+		"Dir": "/tmp/distribution"
+	}
+}
+{
+	"Module": {
+		"Path": "github.com/org/replaced",
+		"Version": "v1.0.0",
+		"Replace": {
+			"Path": "github.com/fork/replaced",
+			"Version": "v1.0.1"
+		}
+	}
+}
+{
+	"Module": {
+		"Path": "github.com/org/repo",
+		"Version": "v0.1.2",
+		"Dir": "` + filepath.ToSlash(dir) + `"
+	}
+}
+`)
+	mods, err := parseModuleList(out)
+	require.NoError(t, err)
+	require.Len(t, mods, 3)
+	assert.True(t, mods[0].Main)
+	assert.Equal(t, "github.com/org/distribution", mods[0].Path)
+	assert.Equal(t, "github.com/fork/replaced", mods[1].Replace.Path)
+	assert.Equal(t, noAssertion, mods[1].License)
+	assert.Equal(t, "github.com/org/repo", mods[2].Path)
+	assert.Equal(t, "Apache-2.0", mods[2].License)
+	assert.Equal(t, []string{"github.com/org/replaced"}, withoutLicense(mods))
+
+	_, err = parseModuleList([]byte("{"))
+	assert.ErrorContains(t, err, "failed to parse the go modules")
+}
+
+func TestWriteSBOM(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Logger = zap.NewNop()
+	cfg.Distribution.OutputPath = t.TempDir()
+	cfg.Distribution.Name = "otelcol-custom"
+	cfg.Distribution.Version = "1.0.0"
+	mods := []goModule{
+		{Path: "github.com/org/distribution", Main: true},
+		{Path: "github.com/org/repo", Version: "v0.1.2", License: "Apache-2.0"},
+		{Path: "github.com/org/replaced", Version: "v1.0.0", License: noAssertion,
+			Replace: &goModule{Path: "github.com/fork/replaced", Version: "v1.0.1"}},
+		{Path: "github.com/org/local", License: "MIT", Replace: &goModule{Path: "../local"}},
+	}
+	require.NoError(t, writeSBOM(cfg, mods))
+
+	data, err := os.ReadFile(filepath.Join(cfg.Distribution.OutputPath, sbomFile))
+	require.NoError(t, err)
+	var bom cycloneDXBOM
+	require.NoError(t, json.Unmarshal(data, &bom))
+	assert.Equal(t, "CycloneDX", bom.BOMFormat)
+	assert.Equal(t, cycloneDXComponent{Type: "application", Name: "otelcol-custom", Version: "1.0.0"}, bom.Metadata.Component)
+	assert.Equal(t, []cycloneDXComponent{
+		{
+			Type:     "library",
+			Name:     "github.com/org/repo",
+			Version:  "v0.1.2",
+			PURL:     "pkg:golang/github.com/org/repo@v0.1.2",
+			Licenses: []cycloneDXLicense{{License: cycloneDXLicenseID{ID: "Apache-2.0"}}},
+		},
+		{
+			Type:     "library",
+			Name:     "github.com/fork/replaced",
+			Version:  "v1.0.1",
+			PURL:     "pkg:golang/github.com/fork/replaced@v1.0.1",
+			Licenses: []cycloneDXLicense{{License: cycloneDXLicenseID{Name: noAssertion}}},
+		},
+		{
+			Type:     "library",
+			Name:     "github.com/org/local",
+			Licenses: []cycloneDXLicense{{License: cycloneDXLicenseID{ID: "MIT"}}},
+		},
+	}, bom.Components)
+
+	// the SBOM is reproducible
+	require.NoError(t, writeSBOM(cfg, mods))
+	again, err := os.ReadFile(filepath.Join(cfg.Distribution.OutputPath, sbomFile))
+	require.NoError(t, err)
+	assert.Equal(t, data, again)
+}
+
+func TestCheckSupplyChainSkipGetModules(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Logger = zap.NewNop()
+	cfg.SkipGetModules = true
+	assert.NoError(t, checkSupplyChain(cfg))
+
+	cfg.Distribution.SBOM = true
+	assert.NoError(t, checkSupplyChain(cfg))
+
+	cfg.Policy = &Policy{DeniedLicenses: []string{"AGPL-3.0"}}
+	assert.ErrorContains(t, checkSupplyChain(cfg), "the licenses of the policy cannot be checked")
+}
@@ -31,12 +31,14 @@ const (
 	distributionOutputPathFlag     = "output-path"
 	distributionGoFlag             = "go"
 	distributionModuleFlag         = "module"
+	policyFlag                     = "policy"
 )
 
 var (
-	cfgFile string
-	cfg     = builder.NewDefaultConfig()
-	k       = koanf.New(".")
+	cfgFile    string
+	policyFile string
+	cfg        = builder.NewDefaultConfig()
+	k          = koanf.New(".")
 )
 
 // Command is the main entrypoint for this application
@@ -68,11 +70,16 @@ configuration is provided, ocb will generate a default Collector.
 				return fmt.Errorf("invalid module configuration: %w", err)
 			}
 
+			if err := cfg.ApplyPolicy(); err != nil {
+				return fmt.Errorf("the configuration does not comply with the policy: %w", err)
+			}
+
 			return builder.GenerateAndCompile(cfg)
 		},
 	}
 
 	cmd.Flags().StringVar(&cfgFile, "config", "", "build configuration file")
+	cmd.Flags().StringVar(&policyFile, policyFlag, "", "organization policy file the distribution must comply with")
 
 	// the distribution parameters, which we accept as CLI flags as well
 	cmd.Flags().BoolVar(&cfg.SkipGenerate, skipGenerateFlag, false, "Whether builder should skip generating go code (default false)")
@@ -150,9 +157,30 @@ func initConfig(flags *flag.FlagSet) error {
 		cfg.Logger.Info("Using config file", zap.String("path", cfgFile))
 	}
 
+	if policyFile != "" {
+		policy, err := loadPolicy(policyFile)
+		if err != nil {
+			return err
+		}
+		cfg.Policy = policy
+		cfg.Logger.Info("Using policy file", zap.String("path", policyFile))
+	}
+
 	return nil
 }
 
+func loadPolicy(path string) (*builder.Policy, error) {
+	pk := koanf.New(".")
+	if err := pk.Load(file.Provider(path), yaml.Parser()); err != nil {
+		return nil, fmt.Errorf("failed to load policy file: %w", err)
+	}
+	policy := &builder.Policy{}
+	if err := pk.UnmarshalWithConf("", policy, koanf.UnmarshalConf{Tag: "mapstructure"}); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal policy: %w", err)
+	}
+	return policy, nil
+}
+
 func applyCfgFromFile(flags *flag.FlagSet, cfgFromFile builder.Config) {
 	cfg.Exporters = cfgFromFile.Exporters
 	cfg.Extensions = cfgFromFile.Extensions
@@ -193,4 +221,6 @@ func applyCfgFromFile(flags *flag.FlagSet, cfgFromFile builder.Config) {
 		cfg.Distribution.Module = cfgFromFile.Distribution.Module
 	}
 	cfg.Distribution.DebugCompilation = cfgFromFile.Distribution.DebugCompilation
+	cfg.Distribution.Reproducible = cfgFromFile.Distribution.Reproducible
+	cfg.Distribution.SBOM = cfgFromFile.Distribution.SBOM
 }
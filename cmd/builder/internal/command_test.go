@@ -4,12 +4,15 @@
 package internal
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/cmd/builder/internal/builder"
@@ -262,3 +265,25 @@ func Test_initConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_loadPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+allowed_modules:
+  - go.opentelemetry.io/collector/...
+denied_licenses: [AGPL-3.0]
+replaces:
+  - github.com/org/repo => github.com/org/repo v1.2.3
+`), 0600))
+
+	policy, err := loadPolicy(path)
+	require.NoError(t, err)
+	assert.Equal(t, &builder.Policy{
+		AllowedModules: []string{"go.opentelemetry.io/collector/..."},
+		DeniedLicenses: []string{"AGPL-3.0"},
+		Replaces:       []string{"github.com/org/repo => github.com/org/repo v1.2.3"},
+	}, policy)
+
+	_, err = loadPolicy(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to load policy file")
+}
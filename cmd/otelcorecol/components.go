@@ -5,18 +5,29 @@ package main
 import (
 	"go.opentelemetry.io/collector/connector"
 	forwardconnector "go.opentelemetry.io/collector/connector/forwardconnector"
+	routingconnector "go.opentelemetry.io/collector/connector/routingconnector"
 	"go.opentelemetry.io/collector/exporter"
+	fileexporter "go.opentelemetry.io/collector/exporter/fileexporter"
 	loggingexporter "go.opentelemetry.io/collector/exporter/loggingexporter"
 	otlpexporter "go.opentelemetry.io/collector/exporter/otlpexporter"
 	otlphttpexporter "go.opentelemetry.io/collector/exporter/otlphttpexporter"
 	"go.opentelemetry.io/collector/extension"
 	ballastextension "go.opentelemetry.io/collector/extension/ballastextension"
+	bearertokenauthextension "go.opentelemetry.io/collector/extension/bearertokenauthextension"
+	filestorageextension "go.opentelemetry.io/collector/extension/filestorageextension"
+	healthcheckextension "go.opentelemetry.io/collector/extension/healthcheckextension"
+	oauth2clientauthextension "go.opentelemetry.io/collector/extension/oauth2clientauthextension"
+	oidcauthextension "go.opentelemetry.io/collector/extension/oidcauthextension"
 	zpagesextension "go.opentelemetry.io/collector/extension/zpagesextension"
 	"go.opentelemetry.io/collector/otelcol"
 	"go.opentelemetry.io/collector/processor"
 	batchprocessor "go.opentelemetry.io/collector/processor/batchprocessor"
+	logsanitizerprocessor "go.opentelemetry.io/collector/processor/logsanitizerprocessor"
 	memorylimiterprocessor "go.opentelemetry.io/collector/processor/memorylimiterprocessor"
+	schemaprocessor "go.opentelemetry.io/collector/processor/schemaprocessor"
+	transformliteprocessor "go.opentelemetry.io/collector/processor/transformliteprocessor"
 	"go.opentelemetry.io/collector/receiver"
+	filereceiver "go.opentelemetry.io/collector/receiver/filereceiver"
 	otlpreceiver "go.opentelemetry.io/collector/receiver/otlpreceiver"
 )
 
@@ -26,6 +37,11 @@ func components() (otelcol.Factories, error) {
 
 	factories.Extensions, err = extension.MakeFactoryMap(
 		ballastextension.NewFactory(),
+		bearertokenauthextension.NewFactory(),
+		filestorageextension.NewFactory(),
+		healthcheckextension.NewFactory(),
+		oauth2clientauthextension.NewFactory(),
+		oidcauthextension.NewFactory(),
 		zpagesextension.NewFactory(),
 	)
 	if err != nil {
@@ -33,6 +49,7 @@ func components() (otelcol.Factories, error) {
 	}
 
 	factories.Receivers, err = receiver.MakeFactoryMap(
+		filereceiver.NewFactory(),
 		otlpreceiver.NewFactory(),
 	)
 	if err != nil {
@@ -40,6 +57,7 @@ func components() (otelcol.Factories, error) {
 	}
 
 	factories.Exporters, err = exporter.MakeFactoryMap(
+		fileexporter.NewFactory(),
 		loggingexporter.NewFactory(),
 		otlpexporter.NewFactory(),
 		otlphttpexporter.NewFactory(),
@@ -50,7 +68,10 @@ func components() (otelcol.Factories, error) {
 
 	factories.Processors, err = processor.MakeFactoryMap(
 		batchprocessor.NewFactory(),
+		logsanitizerprocessor.NewFactory(),
 		memorylimiterprocessor.NewFactory(),
+		schemaprocessor.NewFactory(),
+		transformliteprocessor.NewFactory(),
 	)
 	if err != nil {
 		return otelcol.Factories{}, err
@@ -58,6 +79,7 @@ func components() (otelcol.Factories, error) {
 
 	factories.Connectors, err = connector.MakeFactoryMap(
 		forwardconnector.NewFactory(),
+		routingconnector.NewFactory(),
 	)
 	if err != nil {
 		return otelcol.Factories{}, err
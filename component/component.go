@@ -96,6 +96,22 @@ const (
 	KindConnector
 )
 
+func (k Kind) String() string {
+	switch k {
+	case KindReceiver:
+		return "Receiver"
+	case KindProcessor:
+		return "Processor"
+	case KindExporter:
+		return "Exporter"
+	case KindExtension:
+		return "Extension"
+	case KindConnector:
+		return "Connector"
+	}
+	return ""
+}
+
 // StabilityLevel represents the stability level of the component created by the factory.
 // The stability level is used to determine if the component should be used in production
 // or not. For more details see:
@@ -166,6 +182,26 @@ type Factory interface {
 	// 'componenttest.CheckConfigStruct'. It is recommended to have these checks in the
 	// tests of any implementation of the Factory interface.
 	CreateDefaultConfig() Config
+
+	// Capabilities gets the capabilities declared for all the components created by this factory.
+	Capabilities() FactoryCapabilities
+}
+
+// FactoryCapabilities are the capabilities declared by a factory for all the components it creates.
+// The service uses them to verify and optimize the pipelines, before the components are created.
+type FactoryCapabilities struct {
+	// MutatesData is set if the components modify the data they consume. The service then gives them
+	// their own copy of the data, even if their consumer.Capabilities do not report it.
+	MutatesData bool
+
+	// SingleInstance is set if the factory cannot create more than one component, e.g. because the
+	// components bind a fixed resource. The service rejects the pipelines needing more than one.
+	SingleInstance bool
+
+	// Stateful is set if the components keep state across the data they consume, e.g. to aggregate it.
+	// The service warns when the data of a component is split across several instances, each with its
+	// own state, e.g. for a processor used by several pipelines.
+	Stateful bool
 }
 
 // CreateDefaultConfigFunc is the equivalent of Factory.CreateDefaultConfig().
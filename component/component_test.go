@@ -19,3 +19,13 @@ func TestStabilityLevelString(t *testing.T) {
 	assert.EqualValues(t, "Stable", StabilityLevelStable.String())
 	assert.EqualValues(t, "", StabilityLevel(100).String())
 }
+
+func TestKindString(t *testing.T) {
+	assert.EqualValues(t, "", Kind(0).String())
+	assert.EqualValues(t, "Receiver", KindReceiver.String())
+	assert.EqualValues(t, "Processor", KindProcessor.String())
+	assert.EqualValues(t, "Exporter", KindExporter.String())
+	assert.EqualValues(t, "Extension", KindExtension.String())
+	assert.EqualValues(t, "Connector", KindConnector.String())
+	assert.EqualValues(t, "", Kind(100).String())
+}
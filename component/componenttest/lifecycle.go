@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package componenttest // import "go.opentelemetry.io/collector/component/componenttest"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// lifecycleTimeout is the time the operations of the component must complete within.
+const lifecycleTimeout = 10 * time.Second
+
+// errNext is the error returned by the next consumer in the error propagation scenario.
+var errNext = errors.New("error from the next consumer")
+
+// CheckLifecycleParams configures CheckLifecycle.
+type CheckLifecycleParams struct {
+	T *testing.T
+	// Create creates a new instance of the component. The consumers the component passes its
+	// data to must return nextErr, the component does not pass data if nil is returned.
+	Create func(nextErr error) (component.Component, error)
+	// Host passed to Start, NewNopHost is used if nil.
+	Host component.Host
+	// Consume sends data to the component, it may be nil for the components not consuming data.
+	// It is called concurrently from multiple goroutines.
+	Consume func(ctx context.Context, c component.Component) error
+	// PropagatesErrors is whether the component returns the errors of its next consumers from Consume,
+	// which is not the case for the components consuming the data asynchronously.
+	PropagatesErrors bool
+}
+
+// CheckLifecycle checks that the component follows the lifecycle contract of the components:
+//   - Shutdown can be called without Start having been called, and more than once.
+//   - The component keeps working when the context passed to Start is canceled after Start returned.
+//   - Start and Shutdown return promptly when their context is canceled.
+//   - The errors of the next consumers are returned from Consume if PropagatesErrors is set.
+//   - Consume can be called concurrently with Shutdown without panicking or blocking it.
+func CheckLifecycle(params CheckLifecycleParams) {
+	host := params.Host
+	if host == nil {
+		host = NewNopHost()
+	}
+	t := params.T
+	create := func(t *testing.T, nextErr error) component.Component {
+		c, err := params.Create(nextErr)
+		require.NoError(t, err)
+		require.NotNil(t, c)
+		return c
+	}
+
+	t.Run("shutdown_without_start", func(t *testing.T) {
+		c := create(t, nil)
+		assert.NoError(t, withTimeout(t, "Shutdown", func() error { return c.Shutdown(context.Background()) }))
+	})
+
+	t.Run("shutdown_twice", func(t *testing.T) {
+		c := create(t, nil)
+		require.NoError(t, withTimeout(t, "Start", func() error { return c.Start(context.Background(), host) }))
+		assert.NoError(t, withTimeout(t, "Shutdown", func() error { return c.Shutdown(context.Background()) }))
+		assert.NoError(t, withTimeout(t, "Shutdown", func() error { return c.Shutdown(context.Background()) }))
+	})
+
+	t.Run("start_context_canceled_after_start", func(t *testing.T) {
+		c := create(t, nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		require.NoError(t, withTimeout(t, "Start", func() error { return c.Start(ctx, host) }))
+		cancel()
+		if params.Consume != nil {
+			assert.NoError(t, withTimeout(t, "Consume", func() error { return params.Consume(context.Background(), c) }))
+		}
+		assert.NoError(t, withTimeout(t, "Shutdown", func() error { return c.Shutdown(context.Background()) }))
+	})
+
+	t.Run("canceled_context", func(t *testing.T) {
+		c := create(t, nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		// The component may fail to start or shutdown with a canceled context, but must not block.
+		_ = withTimeout(t, "Start", func() error { return c.Start(ctx, host) })
+		_ = withTimeout(t, "Shutdown", func() error { return c.Shutdown(ctx) })
+		assert.NoError(t, withTimeout(t, "Shutdown", func() error { return c.Shutdown(context.Background()) }))
+	})
+
+	if params.Consume == nil {
+		return
+	}
+
+	if params.PropagatesErrors {
+		t.Run("error_propagation", func(t *testing.T) {
+			c := create(t, errNext)
+			require.NoError(t, withTimeout(t, "Start", func() error { return c.Start(context.Background(), host) }))
+			assert.Error(t, withTimeout(t, "Consume", func() error { return params.Consume(context.Background(), c) }))
+			assert.NoError(t, withTimeout(t, "Shutdown", func() error { return c.Shutdown(context.Background()) }))
+		})
+	}
+
+	t.Run("consume_during_shutdown", func(t *testing.T) {
+		c := create(t, nil)
+		require.NoError(t, withTimeout(t, "Start", func() error { return c.Start(context.Background(), host) }))
+
+		stop := make(chan struct{})
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					// The data consumed during or after Shutdown may be rejected.
+					_ = params.Consume(context.Background(), c)
+				}
+			}()
+		}
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(t, withTimeout(t, "Shutdown", func() error { return c.Shutdown(context.Background()) }))
+		close(stop)
+		assert.NoError(t, withTimeout(t, "Consume", func() error {
+			wg.Wait()
+			return nil
+		}))
+	})
+}
+
+// withTimeout returns the result of the operation, and fails the test if it does not complete within lifecycleTimeout.
+func withTimeout(t *testing.T, name string, op func() error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- op()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(lifecycleTimeout):
+		t.Fatalf("%s did not complete within %v", name, lifecycleTimeout)
+		return nil
+	}
+}
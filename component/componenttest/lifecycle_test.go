@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package componenttest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// lifecycleComponent is a component following the lifecycle contract, passing the data to a next consumer
+// returning nextErr from a background goroutine.
+type lifecycleComponent struct {
+	nextErr error
+
+	mu       sync.Mutex
+	started  bool
+	stopped  bool
+	requests chan chan error
+	done     chan struct{}
+}
+
+func (c *lifecycleComponent) Start(context.Context, component.Host) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.started = true
+	c.requests = make(chan chan error)
+	c.done = make(chan struct{})
+	go func() {
+		defer close(c.done)
+		for req := range c.requests {
+			req <- c.nextErr
+		}
+	}()
+	return nil
+}
+
+func (c *lifecycleComponent) Shutdown(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.started || c.stopped {
+		return nil
+	}
+	c.stopped = true
+	close(c.requests)
+	<-c.done
+	return nil
+}
+
+func (c *lifecycleComponent) consume() error {
+	c.mu.Lock()
+	if !c.started || c.stopped {
+		c.mu.Unlock()
+		return errors.New("not running")
+	}
+	req := make(chan error, 1)
+	c.requests <- req
+	c.mu.Unlock()
+	return <-req
+}
+
+func TestCheckLifecycle(t *testing.T) {
+	CheckLifecycle(CheckLifecycleParams{
+		T: t,
+		Create: func(nextErr error) (component.Component, error) {
+			return &lifecycleComponent{nextErr: nextErr}, nil
+		},
+		Consume: func(_ context.Context, c component.Component) error {
+			return c.(*lifecycleComponent).consume()
+		},
+		PropagatesErrors: true,
+	})
+}
+
+func TestCheckLifecycleWithoutConsume(t *testing.T) {
+	CheckLifecycle(CheckLifecycleParams{
+		T: t,
+		Create: func(error) (component.Component, error) {
+			return &lifecycleComponent{}, nil
+		},
+		Host: NewNopHost(),
+	})
+}
@@ -111,7 +111,7 @@ func callValidateIfPossible(v reflect.Value) error {
 type Type string
 
 // DataType is a special Type that represents the data types supported by the collector. We currently support
-// collecting metrics, traces and logs, this can expand in the future.
+// collecting metrics, traces, logs and profiles, this can expand in the future.
 type DataType = Type
 
 // Currently supported data types. Add new data types here when new types are supported in the future.
@@ -124,4 +124,7 @@ const (
 
 	// DataTypeLogs is the data type tag for logs.
 	DataTypeLogs DataType = "logs"
+
+	// DataTypeProfiles is the data type tag for profiles.
+	DataTypeProfiles DataType = "profiles"
 )
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package component // import "go.opentelemetry.io/collector/component"
+
+import "runtime/debug"
+
+// PanicRecoverer is implemented by the Host of the service when it isolates the panics of the components,
+// according to the policy configured in the service.
+type PanicRecoverer interface {
+	Host
+
+	// RecoverPanic handles a panic recovered in a call to the component, with the stack of the goroutine
+	// which panicked, and returns the error the call must return instead. It panics again with the
+	// recovered value if the panics of the component must not be isolated.
+	RecoverPanic(recovered any, stack []byte) error
+}
+
+// Restartable is implemented by the components which can be started again after they were shut down, which
+// the Component interface does not allow otherwise. The service only restarts the components which panicked
+// if they implement it.
+type Restartable interface {
+	Component
+
+	// Restartable reports whether the component can be started again after it was shut down.
+	Restartable() bool
+}
+
+// RecoverPanic lets the host handle a panic recovered by the component in one of its own goroutines, such as
+// a scrape. It must be called by the deferred function which recovered the panic, so that the stack of the
+// panic is reported. It panics again with the recovered value if the host does not isolate panics.
+func RecoverPanic(host Host, recovered any) error {
+	if pr, ok := host.(PanicRecoverer); ok {
+		return pr.RecoverPanic(recovered, debug.Stack())
+	}
+	panic(recovered)
+}
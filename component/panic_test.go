@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type panicHost struct {
+	hostWithoutStatus
+	recovered any
+	stack     []byte
+}
+
+func (h *panicHost) RecoverPanic(recovered any, stack []byte) error {
+	h.recovered, h.stack = recovered, stack
+	return errors.New("recovered")
+}
+
+func panicking() {
+	panic("boom")
+}
+
+func TestRecoverPanic(t *testing.T) {
+	h := &panicHost{}
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = RecoverPanic(h, r)
+			}
+		}()
+		panicking()
+	}()
+	require.EqualError(t, err, "recovered")
+	assert.Equal(t, "boom", h.recovered)
+	assert.Contains(t, string(h.stack), "panicking")
+
+	assert.PanicsWithValue(t, "boom", func() {
+		defer func() {
+			if r := recover(); r != nil {
+				_ = RecoverPanic(hostWithoutStatus{}, r)
+			}
+		}()
+		panicking()
+	})
+}
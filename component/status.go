@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package component // import "go.opentelemetry.io/collector/component"
+
+import (
+	"time"
+)
+
+// Status represents the state of a component instance.
+type Status int32
+
+const (
+	// StatusNone is the status of a component instance which has not reported any status yet.
+	StatusNone Status = iota
+	// StatusStarting is reported by the host before starting the component.
+	StatusStarting
+	// StatusOK is reported by the host once the component started, and by the component
+	// when it recovers from an error.
+	StatusOK
+	// StatusRecoverableError is reported by the component when it encounters an error it
+	// expects to recover from, e.g. an exporter unable to reach its destination.
+	StatusRecoverableError
+	// StatusPermanentError is reported by the component when it encounters an error it cannot
+	// recover from without a change of configuration or a restart. It is reported by the host
+	// when the component fails to start or reports a fatal error.
+	StatusPermanentError
+	// StatusStopping is reported by the host before shutting down the component.
+	StatusStopping
+	// StatusStopped is reported by the host once the component is shut down.
+	StatusStopped
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusNone:
+		return "None"
+	case StatusStarting:
+		return "Starting"
+	case StatusOK:
+		return "OK"
+	case StatusRecoverableError:
+		return "RecoverableError"
+	case StatusPermanentError:
+		return "PermanentError"
+	case StatusStopping:
+		return "Stopping"
+	case StatusStopped:
+		return "Stopped"
+	}
+	return ""
+}
+
+// StatusEvent is the status of a component instance at a point in time.
+type StatusEvent struct {
+	status    Status
+	err       error
+	timestamp time.Time
+}
+
+// NewStatusEvent returns a StatusEvent with the given status, timestamped with the current time.
+// The err is only kept for StatusRecoverableError and StatusPermanentError.
+func NewStatusEvent(status Status, err error) *StatusEvent {
+	ev := &StatusEvent{status: status, timestamp: time.Now()}
+	if status == StatusRecoverableError || status == StatusPermanentError {
+		ev.err = err
+	}
+	return ev
+}
+
+// Status returns the status of the event.
+func (ev *StatusEvent) Status() Status {
+	return ev.status
+}
+
+// Err returns the error of the event, nil for the statuses which are not errors.
+func (ev *StatusEvent) Err() error {
+	return ev.err
+}
+
+// Timestamp returns the time the event was created.
+func (ev *StatusEvent) Timestamp() time.Time {
+	return ev.timestamp
+}
+
+// InstanceID identifies a component instance of the service. A receiver or an exporter used by
+// several pipelines of the same data type is a single instance, part of all these pipelines.
+type InstanceID struct {
+	ID          ID
+	Kind        Kind
+	PipelineIDs []ID
+}
+
+// StatusReporter is implemented by the Host of the service, to let the components report their status.
+// The host knows which component instance it was given to, so the events do not identify the component.
+//
+// The host reports StatusStarting, StatusOK, StatusStopping and StatusStopped around the calls to Start
+// and Shutdown. Components should report StatusRecoverableError and StatusPermanentError when they
+// encounter errors while running, and StatusOK when they recover from a recoverable error.
+type StatusReporter interface {
+	Host
+
+	// ReportStatus reports a change of the status of the component.
+	ReportStatus(event *StatusEvent)
+}
+
+// ReportStatus reports the status of the component to the host, if it supports status reporting.
+func ReportStatus(host Host, event *StatusEvent) {
+	if sr, ok := host.(StatusReporter); ok {
+		sr.ReportStatus(event)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusString(t *testing.T) {
+	assert.EqualValues(t, "None", StatusNone.String())
+	assert.EqualValues(t, "Starting", StatusStarting.String())
+	assert.EqualValues(t, "OK", StatusOK.String())
+	assert.EqualValues(t, "RecoverableError", StatusRecoverableError.String())
+	assert.EqualValues(t, "PermanentError", StatusPermanentError.String())
+	assert.EqualValues(t, "Stopping", StatusStopping.String())
+	assert.EqualValues(t, "Stopped", StatusStopped.String())
+	assert.EqualValues(t, "", Status(100).String())
+}
+
+func TestNewStatusEvent(t *testing.T) {
+	err := errors.New("connection refused")
+	before := time.Now()
+
+	ev := NewStatusEvent(StatusRecoverableError, err)
+	assert.Equal(t, StatusRecoverableError, ev.Status())
+	assert.Equal(t, err, ev.Err())
+	assert.False(t, ev.Timestamp().Before(before))
+
+	assert.Equal(t, err, NewStatusEvent(StatusPermanentError, err).Err())
+	assert.NoError(t, NewStatusEvent(StatusOK, err).Err())
+}
+
+type statusHost struct {
+	Host
+	events []*StatusEvent
+}
+
+func (h *statusHost) ReportStatus(ev *StatusEvent) {
+	h.events = append(h.events, ev)
+}
+
+type hostWithoutStatus struct {
+	Host
+}
+
+func TestReportStatus(t *testing.T) {
+	h := &statusHost{}
+	ev := NewStatusEvent(StatusOK, nil)
+	ReportStatus(h, ev)
+	assert.Equal(t, []*StatusEvent{ev}, h.events)
+
+	assert.NotPanics(t, func() { ReportStatus(hostWithoutStatus{}, ev) })
+}
@@ -7,9 +7,11 @@
 package configauth // import "go.opentelemetry.io/collector/config/configauth"
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension/auth"
 )
@@ -18,27 +20,71 @@ var (
 	errAuthenticatorNotFound = errors.New("authenticator not found")
 	errNotClient             = errors.New("requested authenticator is not a client authenticator")
 	errNotServer             = errors.New("requested authenticator is not a server authenticator")
+	errNotClaimsServer       = errors.New("required_scopes requires an authenticator returning the claims about the clients")
+
+	// ErrPermissionDenied is returned by the server authenticators when the authenticated client is not
+	// granted one of the required scopes.
+	ErrPermissionDenied = errors.New("permission denied")
 )
 
 // Authentication defines the auth settings for the receiver.
 type Authentication struct {
 	// AuthenticatorID specifies the name of the extension to use in order to authenticate the incoming data point.
 	AuthenticatorID component.ID `mapstructure:"authenticator"`
+
+	// RequiredScopes are the scopes which must be granted to the authenticated clients, the requests of the
+	// clients lacking one of them are rejected. It requires an authenticator implementing auth.ClaimsServer.
+	RequiredScopes []string `mapstructure:"required_scopes"`
 }
 
 // GetServerAuthenticator attempts to select the appropriate auth.Server from the list of extensions,
 // based on the requested extension name. If an authenticator is not found, an error is returned.
+// When the authenticator implements auth.ClaimsServer, the returned auth.Server attaches the claims
+// about the clients to their client.Info, and rejects the requests of the clients lacking one of the
+// required scopes with an error wrapping ErrPermissionDenied.
 func (a Authentication) GetServerAuthenticator(extensions map[component.ID]component.Component) (auth.Server, error) {
 	if ext, found := extensions[a.AuthenticatorID]; found {
-		if server, ok := ext.(auth.Server); ok {
-			return server, nil
+		server, ok := ext.(auth.Server)
+		if !ok {
+			return nil, errNotServer
+		}
+		if claimsServer, ok := server.(auth.ClaimsServer); ok {
+			return &authorizingServer{ClaimsServer: claimsServer, requiredScopes: a.RequiredScopes}, nil
 		}
-		return nil, errNotServer
+		if len(a.RequiredScopes) > 0 {
+			return nil, fmt.Errorf("authenticator %q: %w", a.AuthenticatorID, errNotClaimsServer)
+		}
+		return server, nil
 	}
 
 	return nil, fmt.Errorf("failed to resolve authenticator %q: %w", a.AuthenticatorID, errAuthenticatorNotFound)
 }
 
+// authorizingServer attaches the claims about the authenticated clients to their client.Info,
+// and authorizes their requests against the required scopes.
+type authorizingServer struct {
+	auth.ClaimsServer
+	requiredScopes []string
+}
+
+func (s *authorizingServer) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	ctx, claims, err := s.AuthenticateClaims(ctx, headers)
+	if err != nil {
+		return ctx, err
+	}
+	if claims != nil {
+		cl := client.FromContext(ctx)
+		cl.Claims = claims
+		ctx = client.NewContext(ctx, cl)
+	}
+	for _, scope := range s.requiredScopes {
+		if !claims.HasScope(scope) {
+			return ctx, fmt.Errorf("%w: the scope %q is required", ErrPermissionDenied, scope)
+		}
+	}
+	return ctx, nil
+}
+
 // GetClientAuthenticator attempts to select the appropriate auth.Client from the list of extensions,
 // based on the component id of the extension. If an authenticator is not found, an error is returned.
 // This should be only used by HTTP clients.
@@ -4,10 +4,13 @@
 package configauth
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/auth"
@@ -64,6 +67,77 @@ func TestGetServerFails(t *testing.T) {
 	assert.Nil(t, authenticator)
 }
 
+func TestGetServerClaims(t *testing.T) {
+	claims := &client.Claims{Subject: "agent", Tenant: "tenant-a", Scopes: []string{"traces:write"}}
+	ext := map[component.ID]component.Component{
+		component.NewID("mock"): auth.NewServer(auth.WithServerAuthenticateClaims(
+			func(ctx context.Context, headers map[string][]string) (context.Context, *client.Claims, error) {
+				return ctx, claims, nil
+			})),
+	}
+
+	testCases := []struct {
+		desc           string
+		requiredScopes []string
+		expectedErr    string
+	}{
+		{
+			desc: "no required scope",
+		},
+		{
+			desc:           "granted scope",
+			requiredScopes: []string{"traces:write"},
+		},
+		{
+			desc:           "missing scope",
+			requiredScopes: []string{"traces:write", "metrics:write"},
+			expectedErr:    `permission denied: the scope "metrics:write" is required`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := &Authentication{
+				AuthenticatorID: component.NewID("mock"),
+				RequiredScopes:  tC.requiredScopes,
+			}
+			authenticator, err := cfg.GetServerAuthenticator(ext)
+			require.NoError(t, err)
+
+			ctx, err := authenticator.Authenticate(context.Background(), map[string][]string{})
+			assert.Equal(t, claims, client.FromContext(ctx).Claims)
+			if tC.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrPermissionDenied)
+				assert.EqualError(t, err, tC.expectedErr)
+			}
+		})
+	}
+}
+
+func TestGetServerRequiredScopesWithoutClaims(t *testing.T) {
+	cfg := &Authentication{
+		AuthenticatorID: component.NewID("mock"),
+		RequiredScopes:  []string{"traces:write"},
+	}
+	// The authenticator returns no claims, the requests are rejected.
+	authenticator, err := cfg.GetServerAuthenticator(map[component.ID]component.Component{
+		component.NewID("mock"): auth.NewServer(auth.WithServerAuthenticateClaims(
+			func(ctx context.Context, headers map[string][]string) (context.Context, *client.Claims, error) {
+				return ctx, nil, nil
+			})),
+	})
+	require.NoError(t, err)
+	_, err = authenticator.Authenticate(context.Background(), map[string][]string{})
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	// The authenticator cannot return claims.
+	_, err = cfg.GetServerAuthenticator(map[component.ID]component.Component{
+		component.NewID("mock"): auth.NewServer(),
+	})
+	assert.ErrorIs(t, err, errNotClaimsServer)
+}
+
 func TestGetClient(t *testing.T) {
 	testCases := []struct {
 		desc          string
@@ -41,6 +41,11 @@ func TestUnmarshalText(t *testing.T) {
 			compressionName: []byte("zstd"),
 			shouldError:     false,
 		},
+		{
+			name:            "ValidLz4",
+			compressionName: []byte("lz4"),
+			shouldError:     false,
+		},
 		{
 			name:            "ValidEmpty",
 			compressionName: []byte(""),
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configcompression // import "go.opentelemetry.io/collector/config/configcompression"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Codec implements a compression type registered by a distribution, in addition to the built-in ones.
+// The registered compression types are supported by the HTTP clients and servers of confighttp,
+// and by the gRPC clients and servers of configgrpc.
+type Codec struct {
+	// ContentEncoding is the content coding identifying the compression type in the
+	// "Content-Encoding" and "Accept-Encoding" HTTP headers, and in the "grpc-encoding" gRPC header.
+	// The name of the compression type is used if empty.
+	ContentEncoding string
+
+	// MaxLevel is the highest compression level of the codec, 0 if the codec has no levels.
+	// The levels of a codec are between 1 and MaxLevel.
+	MaxLevel int
+
+	// NewWriter returns a writer compressing the data written to it into w, with the level,
+	// or the default level of the codec if the level is 0. The compressed data must be flushed into w
+	// when the writer is closed.
+	NewWriter func(w io.Writer, level int) (io.WriteCloser, error)
+
+	// NewReader returns a reader decompressing the data read from r.
+	NewReader func(r io.Reader) (io.ReadCloser, error)
+}
+
+var (
+	errEmptyCompressionType = errors.New("the compression type must not be empty")
+	errMissingFunc          = errors.New("the codec must define NewWriter and NewReader")
+	errNegativeMaxLevel     = errors.New("the maximum level of the codec must not be negative")
+)
+
+// builtinTypes lists the compression types supported without registration.
+var builtinTypes = []CompressionType{Gzip, Zlib, Deflate, Snappy, Zstd, Lz4, none, empty}
+
+var registry = struct {
+	sync.RWMutex
+	codecs map[CompressionType]Codec
+}{codecs: map[CompressionType]Codec{}}
+
+// RegisterCodec registers the codec of a compression type, which can then be used in the configurations
+// like the built-in compression types. The codecs are expected to be registered when the distribution
+// starts, for instance in an init function, since the codecs registered after the gRPC clients and
+// servers were created are not supported by gRPC.
+// An error is returned if the compression type or its content coding is already used.
+func RegisterCodec(compressionType CompressionType, codec Codec) error {
+	if compressionType == empty {
+		return errEmptyCompressionType
+	}
+	if codec.NewWriter == nil || codec.NewReader == nil {
+		return errMissingFunc
+	}
+	if codec.MaxLevel < 0 {
+		return errNegativeMaxLevel
+	}
+	if codec.ContentEncoding == "" {
+		codec.ContentEncoding = string(compressionType)
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	for _, typ := range builtinTypes {
+		if compressionType == typ {
+			return fmt.Errorf("compression type %q is built in", compressionType)
+		}
+		if codec.ContentEncoding == string(typ) {
+			return fmt.Errorf("content coding %q is used by the built-in compression type %q", codec.ContentEncoding, typ)
+		}
+	}
+	if codec.ContentEncoding == "identity" {
+		return errors.New(`content coding "identity" cannot be used by a codec`)
+	}
+	if _, ok := registry.codecs[compressionType]; ok {
+		return fmt.Errorf("compression type %q is already registered", compressionType)
+	}
+	for typ, c := range registry.codecs {
+		if c.ContentEncoding == codec.ContentEncoding {
+			return fmt.Errorf("content coding %q is already used by the compression type %q", codec.ContentEncoding, typ)
+		}
+	}
+	registry.codecs[compressionType] = codec
+	return nil
+}
+
+// LookupCodec returns the codec registered for the compression type,
+// false if the compression type is built in or not registered.
+func LookupCodec(compressionType CompressionType) (Codec, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	codec, ok := registry.codecs[compressionType]
+	return codec, ok
+}
+
+// LookupContentEncoding returns the registered compression type identified by the content coding, and its codec,
+// false if no registered codec uses the content coding.
+func LookupContentEncoding(encoding string) (CompressionType, Codec, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	for typ, codec := range registry.codecs {
+		if codec.ContentEncoding == encoding {
+			return typ, codec, true
+		}
+	}
+	return empty, Codec{}, false
+}
+
+// RegisteredTypes returns the registered compression types, sorted by name.
+func RegisteredTypes() []CompressionType {
+	registry.RLock()
+	defer registry.RUnlock()
+	types := make([]CompressionType, 0, len(registry.codecs))
+	for typ := range registry.codecs {
+		types = append(types, typ)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// ContentEncoding returns the content coding identifying the compression type in the HTTP and gRPC headers.
+func ContentEncoding(compressionType CompressionType) string {
+	if codec, ok := LookupCodec(compressionType); ok {
+		return codec.ContentEncoding
+	}
+	return string(compressionType)
+}
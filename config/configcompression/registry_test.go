@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configcompression
+
+import (
+	"compress/flate"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCodec = Codec{
+	ContentEncoding: "x-flate",
+	MaxLevel:        flate.BestCompression,
+	NewWriter: func(w io.Writer, level int) (io.WriteCloser, error) {
+		if level == 0 {
+			level = flate.DefaultCompression
+		}
+		return flate.NewWriter(w, level)
+	},
+	NewReader: func(r io.Reader) (io.ReadCloser, error) {
+		return flate.NewReader(r), nil
+	},
+}
+
+// unregisterCodec removes the codec of the compression type.
+func unregisterCodec(compressionType CompressionType) {
+	registry.Lock()
+	defer registry.Unlock()
+	delete(registry.codecs, compressionType)
+}
+
+func TestRegisterCodec(t *testing.T) {
+	require.NoError(t, RegisterCodec("flate", testCodec))
+	t.Cleanup(func() { unregisterCodec("flate") })
+
+	codec, ok := LookupCodec("flate")
+	require.True(t, ok)
+	assert.Equal(t, "x-flate", codec.ContentEncoding)
+	assert.Equal(t, "x-flate", ContentEncoding("flate"))
+	assert.Equal(t, "gzip", ContentEncoding(Gzip))
+
+	typ, _, ok := LookupContentEncoding("x-flate")
+	require.True(t, ok)
+	assert.Equal(t, CompressionType("flate"), typ)
+	_, _, ok = LookupContentEncoding("flate")
+	assert.False(t, ok)
+
+	assert.Equal(t, []CompressionType{"flate"}, RegisteredTypes())
+
+	var ct CompressionType
+	require.NoError(t, ct.UnmarshalText([]byte("flate")))
+	assert.Equal(t, CompressionType("flate"), ct)
+}
+
+func TestRegisterCodecDefaultContentEncoding(t *testing.T) {
+	codec := testCodec
+	codec.ContentEncoding = ""
+	require.NoError(t, RegisterCodec("flate", codec))
+	t.Cleanup(func() { unregisterCodec("flate") })
+
+	assert.Equal(t, "flate", ContentEncoding("flate"))
+}
+
+func TestRegisterCodecErrors(t *testing.T) {
+	require.NoError(t, RegisterCodec("flate", testCodec))
+	t.Cleanup(func() { unregisterCodec("flate") })
+
+	withEncoding := func(encoding string) Codec {
+		codec := testCodec
+		codec.ContentEncoding = encoding
+		return codec
+	}
+	tests := []struct {
+		name            string
+		compressionType CompressionType
+		codec           Codec
+		expectedErr     string
+	}{
+		{
+			name:            "empty",
+			compressionType: "",
+			codec:           testCodec,
+			expectedErr:     "the compression type must not be empty",
+		},
+		{
+			name:            "missing functions",
+			compressionType: "other",
+			codec:           Codec{},
+			expectedErr:     "the codec must define NewWriter and NewReader",
+		},
+		{
+			name:            "negative max level",
+			compressionType: "other",
+			codec:           Codec{MaxLevel: -1, NewWriter: testCodec.NewWriter, NewReader: testCodec.NewReader},
+			expectedErr:     "the maximum level of the codec must not be negative",
+		},
+		{
+			name:            "built in",
+			compressionType: Gzip,
+			codec:           withEncoding("x-gzip"),
+			expectedErr:     `compression type "gzip" is built in`,
+		},
+		{
+			name:            "built-in content coding",
+			compressionType: "other",
+			codec:           withEncoding("zstd"),
+			expectedErr:     `content coding "zstd" is used by the built-in compression type "zstd"`,
+		},
+		{
+			name:            "identity",
+			compressionType: "other",
+			codec:           withEncoding("identity"),
+			expectedErr:     `content coding "identity" cannot be used by a codec`,
+		},
+		{
+			name:            "already registered",
+			compressionType: "flate",
+			codec:           withEncoding("x-other"),
+			expectedErr:     `compression type "flate" is already registered`,
+		},
+		{
+			name:            "content coding already used",
+			compressionType: "other",
+			codec:           testCodec,
+			expectedErr:     `content coding "x-flate" is already used by the compression type "flate"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, RegisterCodec(tt.compressionType, tt.codec), tt.expectedErr)
+		})
+	}
+	assert.Equal(t, []CompressionType{"flate"}, RegisteredTypes())
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"io"
+	"sync"
+
+	"google.golang.org/grpc/encoding"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+)
+
+// codecCompressor adapts a codec registered with configcompression to a gRPC compressor,
+// named after the content coding of the codec. The default level of the codec is used.
+type codecCompressor struct {
+	codec configcompression.Codec
+}
+
+var _ encoding.Compressor = (*codecCompressor)(nil)
+
+func (c *codecCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return c.codec.NewWriter(w, 0)
+}
+
+func (c *codecCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	return c.codec.NewReader(r)
+}
+
+func (c *codecCompressor) Name() string {
+	return c.codec.ContentEncoding
+}
+
+var registerCodecsOnce sync.Once
+
+// registerCodecCompressors registers the gRPC compressors of the codecs registered with configcompression,
+// before the first gRPC client or server is created. The gRPC compressors must not be registered while
+// the clients and servers are in use, so the codecs registered afterwards are not supported by gRPC.
+func registerCodecCompressors() {
+	registerCodecsOnce.Do(func() {
+		for _, compressionType := range configcompression.RegisteredTypes() {
+			codec, _ := configcompression.LookupCodec(compressionType)
+			if encoding.GetCompressor(codec.ContentEncoding) == nil {
+				encoding.RegisterCompressor(&codecCompressor{codec: codec})
+			}
+		}
+	})
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc
+
+import (
+	"compress/flate"
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+// flateReads counts the requests decompressed with flateCodec.
+var flateReads atomic.Int64
+
+var flateCodec = configcompression.Codec{
+	ContentEncoding: "x-flate",
+	MaxLevel:        flate.BestCompression,
+	NewWriter: func(w io.Writer, level int) (io.WriteCloser, error) {
+		if level == 0 {
+			level = flate.DefaultCompression
+		}
+		return flate.NewWriter(w, level)
+	},
+	NewReader: func(r io.Reader) (io.ReadCloser, error) {
+		flateReads.Add(1)
+		return flate.NewReader(r), nil
+	},
+}
+
+func init() {
+	if err := configcompression.RegisterCodec("flate", flateCodec); err != nil {
+		panic(err)
+	}
+}
+
+func TestRegisteredCodecCompression(t *testing.T) {
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:0",
+			Transport: "tcp",
+		},
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	srv, err := gss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	ptraceotlp.RegisterGRPCServer(srv, &grpcTraceServer{})
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+
+	gcs := &GRPCClientSettings{
+		Endpoint:    ln.Addr().String(),
+		Compression: "flate",
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, grpcClientConn.Close()) })
+
+	reads := flateReads.Load()
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFunc()
+	_, err = ptraceotlp.NewGRPCClient(grpcClientConn).Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	require.NoError(t, err)
+	// The server decompressed the request, and the client the response.
+	assert.Equal(t, reads+2, flateReads.Load())
+}
+
+func TestCodecRegisteredAfterFirstUse(t *testing.T) {
+	registerCodecCompressors()
+	if _, ok := configcompression.LookupCodec("late"); !ok {
+		codec := flateCodec
+		codec.ContentEncoding = "x-late"
+		require.NoError(t, configcompression.RegisterCodec("late", codec))
+	}
+
+	_, err := getGRPCCompressionName("late")
+	assert.EqualError(t, err, `compression type "late" was registered after the creation of the first gRPC client or server`)
+}
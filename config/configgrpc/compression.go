@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	zstdlib "github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configgrpc/zstddict"
+)
+
+// maxZstdLevel is the highest zstd compression level.
+const maxZstdLevel = 22
+
+var errDictionaryRequiresZstd = errors.New("compression_dictionary_file requires zstd compression")
+
+// levelCompressor compresses the messages of a client connection with the level configured for the
+// compression type, under the name the servers know the compression type by.
+type levelCompressor struct {
+	name     string
+	compress func(w io.Writer, p []byte) error
+}
+
+var _ grpc.Compressor = (*levelCompressor)(nil) //nolint:staticcheck // SA1019 the registered compressors cannot be configured per connection.
+
+func (c *levelCompressor) Do(w io.Writer, p []byte) error {
+	return c.compress(w, p)
+}
+
+func (c *levelCompressor) Type() string {
+	return c.name
+}
+
+// compressionOptions returns the dial options compressing the requests with the compression type,
+// the compression level and the compression dictionary of the settings.
+func (gcs *GRPCClientSettings) compressionOptions() ([]grpc.DialOption, error) {
+	if gcs.CompressionDictionaryFile != "" {
+		if gcs.Compression != configcompression.Zstd {
+			return nil, errDictionaryRequiresZstd
+		}
+		if gcs.Pool > 1 {
+			// The dictionary is negotiated for each connection by the compressor shared by the pool.
+			return nil, errors.New("compression_dictionary_file cannot be used with a pool of connections")
+		}
+		if err := validateCompressionLevel(gcs.Compression, gcs.CompressionLevel, maxZstdLevel); err != nil {
+			return nil, err
+		}
+		dict, err := zstddict.LoadDictionary(gcs.CompressionDictionaryFile)
+		if err != nil {
+			return nil, err
+		}
+		cc, err := zstddict.NewClientCompressor(gcs.CompressionLevel)
+		if err != nil {
+			return nil, err
+		}
+		if err = cc.SetDictionary(dict); err != nil {
+			return nil, err
+		}
+		return []grpc.DialOption{
+			grpc.WithCompressor(cc), //nolint:staticcheck // SA1019 see zstddict.ClientCompressor.
+			grpc.WithChainUnaryInterceptor(cc.UnaryClientInterceptor()),
+		}, nil
+	}
+
+	if gcs.CompressionLevel == 0 {
+		cp, err := getGRPCCompressionName(gcs.Compression)
+		if err != nil {
+			return nil, err
+		}
+		return []grpc.DialOption{grpc.WithDefaultCallOptions(grpc.UseCompressor(cp))}, nil
+	}
+	cp, err := newLevelCompressor(gcs.Compression, gcs.CompressionLevel)
+	if err != nil {
+		return nil, err
+	}
+	return []grpc.DialOption{grpc.WithCompressor(cp)}, nil //nolint:staticcheck // SA1019 see levelCompressor.
+}
+
+// newLevelCompressor returns the compressor of the compression type with the level.
+func newLevelCompressor(compressionType configcompression.CompressionType, level int) (*levelCompressor, error) {
+	switch compressionType {
+	case configcompression.Gzip:
+		if err := validateCompressionLevel(compressionType, level, gzip.BestCompression); err != nil {
+			return nil, err
+		}
+		pool := sync.Pool{New: func() any { gw, _ := gzip.NewWriterLevel(nil, level); return gw }}
+		return &levelCompressor{name: string(configcompression.Gzip), compress: func(w io.Writer, p []byte) error {
+			gw := pool.Get().(*gzip.Writer)
+			defer pool.Put(gw)
+			gw.Reset(w)
+			if _, err := gw.Write(p); err != nil {
+				return err
+			}
+			return gw.Close()
+		}}, nil
+	case configcompression.Zstd:
+		if err := validateCompressionLevel(compressionType, level, maxZstdLevel); err != nil {
+			return nil, err
+		}
+		encoder, err := zstdlib.NewWriter(nil, zstdlib.WithEncoderLevel(zstdlib.EncoderLevelFromZstd(level)))
+		if err != nil {
+			return nil, err
+		}
+		return &levelCompressor{name: string(configcompression.Zstd), compress: func(w io.Writer, p []byte) error {
+			_, err := w.Write(encoder.EncodeAll(p, nil))
+			return err
+		}}, nil
+	}
+	codec, ok := configcompression.LookupCodec(compressionType)
+	if !ok || codec.MaxLevel == 0 {
+		return nil, fmt.Errorf("compression type %q has no compression levels", compressionType)
+	}
+	if err := validateCompressionLevel(compressionType, level, codec.MaxLevel); err != nil {
+		return nil, err
+	}
+	return &levelCompressor{name: codec.ContentEncoding, compress: func(w io.Writer, p []byte) error {
+		cw, err := codec.NewWriter(w, level)
+		if err != nil {
+			return err
+		}
+		if _, err = cw.Write(p); err != nil {
+			return err
+		}
+		return cw.Close()
+	}}, nil
+}
+
+func validateCompressionLevel(compressionType configcompression.CompressionType, level int, maxLevel int) error {
+	if level < 0 || level > maxLevel {
+		return fmt.Errorf("invalid %s compression level %d, must be between 1 and %d", compressionType, level, maxLevel)
+	}
+	return nil
+}
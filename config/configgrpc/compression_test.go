@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configgrpc/zstddict"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+// startTraceServer starts a gRPC server receiving traces and returns its address.
+func startTraceServer(t *testing.T, acceptDictionaries bool) string {
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:0",
+			Transport: "tcp",
+		},
+		AcceptCompressionDictionaries: acceptDictionaries,
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	srv, err := gss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	ptraceotlp.RegisterGRPCServer(srv, &grpcTraceServer{})
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+	return ln.Addr().String()
+}
+
+// exportTraces sends the requests with the client settings.
+func exportTraces(t *testing.T, gcs *GRPCClientSettings, requests int) {
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, grpcClientConn.Close()) })
+
+	client := ptraceotlp.NewGRPCClient(grpcClientConn)
+	for i := 0; i < requests; i++ {
+		td := ptrace.NewTraces()
+		td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(fmt.Sprintf("span-%d", i))
+		ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+		_, err = client.Export(ctx, ptraceotlp.NewExportRequestFromTraces(td), grpc.WaitForReady(true))
+		cancelFunc()
+		require.NoError(t, err)
+	}
+}
+
+func TestCompressionLevel(t *testing.T) {
+	endpoint := startTraceServer(t, false)
+	for _, tt := range []struct {
+		compression configcompression.CompressionType
+		level       int
+	}{
+		{compression: configcompression.Gzip, level: 1},
+		{compression: configcompression.Zstd, level: 19},
+		{compression: "flate", level: 9},
+	} {
+		t.Run(string(tt.compression), func(t *testing.T) {
+			exportTraces(t, &GRPCClientSettings{
+				Endpoint:         endpoint,
+				Compression:      tt.compression,
+				CompressionLevel: tt.level,
+				TLSSetting:       configtls.TLSClientSetting{Insecure: true},
+			}, 2)
+		})
+	}
+}
+
+func TestCompressionDictionaryFile(t *testing.T) {
+	trainer := zstddict.NewTrainer(zstddict.NewDefaultConfig())
+	for i := 0; trainer.WantsSample(); i++ {
+		trainer.AddSample([]byte(fmt.Sprintf(`{"resource":{"service.name":"checkout","host.name":"node-%d"},"spans":[{"trace_id":"%016x","name":"GET /api/cart","attempt":%d}]}`, i%3, uint64(i)*0x9E3779B97F4A7C15, i)))
+	}
+	dict, err := trainer.Train()
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "dictionary")
+	require.NoError(t, os.WriteFile(path, dict, 0600))
+
+	for _, acceptDictionaries := range []bool{true, false} {
+		t.Run(fmt.Sprintf("accept=%v", acceptDictionaries), func(t *testing.T) {
+			// The dictionary is offered with the first request, and used by the next ones once acknowledged.
+			// The requests are sent with the standard zstd compression to the servers not accepting it.
+			exportTraces(t, &GRPCClientSettings{
+				Endpoint:                  startTraceServer(t, acceptDictionaries),
+				Compression:               configcompression.Zstd,
+				CompressionLevel:          3,
+				CompressionDictionaryFile: path,
+				TLSSetting:                configtls.TLSClientSetting{Insecure: true},
+			}, 3)
+		})
+	}
+}
+
+func TestCompressionErrors(t *testing.T) {
+	invalidDictionary := filepath.Join(t.TempDir(), "dictionary")
+	require.NoError(t, os.WriteFile(invalidDictionary, []byte("invalid"), 0600))
+	tests := []struct {
+		name        string
+		settings    GRPCClientSettings
+		expectedErr string
+	}{
+		{
+			name:        "gzip level too high",
+			settings:    GRPCClientSettings{Compression: configcompression.Gzip, CompressionLevel: 10},
+			expectedErr: "invalid gzip compression level 10, must be between 1 and 9",
+		},
+		{
+			name:        "negative zstd level",
+			settings:    GRPCClientSettings{Compression: configcompression.Zstd, CompressionLevel: -1},
+			expectedErr: "invalid zstd compression level -1, must be between 1 and 22",
+		},
+		{
+			name:        "snappy level",
+			settings:    GRPCClientSettings{Compression: configcompression.Snappy, CompressionLevel: 1},
+			expectedErr: `compression type "snappy" has no compression levels`,
+		},
+		{
+			name:        "dictionary without compression",
+			settings:    GRPCClientSettings{CompressionDictionaryFile: invalidDictionary},
+			expectedErr: "compression_dictionary_file requires zstd compression",
+		},
+		{
+			name:        "dictionary with gzip",
+			settings:    GRPCClientSettings{Compression: configcompression.Gzip, CompressionDictionaryFile: invalidDictionary},
+			expectedErr: "compression_dictionary_file requires zstd compression",
+		},
+		{
+			name:        "dictionary with pool",
+			settings:    GRPCClientSettings{Compression: configcompression.Zstd, CompressionDictionaryFile: invalidDictionary, Pool: 2},
+			expectedErr: "compression_dictionary_file cannot be used with a pool of connections",
+		},
+		{
+			name:        "invalid dictionary",
+			settings:    GRPCClientSettings{Compression: configcompression.Zstd, CompressionDictionaryFile: invalidDictionary},
+			expectedErr: fmt.Sprintf("failed to load the compression dictionary %q: invalid zstd dictionary", invalidDictionary),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.settings.toDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
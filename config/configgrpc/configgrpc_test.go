@@ -6,7 +6,10 @@ package configgrpc
 import (
 	"context"
 	"errors"
+	"io"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -18,19 +21,23 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configmiddleware"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/extension/auth"
 	"go.opentelemetry.io/collector/extension/auth/authtest"
+	"go.opentelemetry.io/collector/extension/middleware"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 )
@@ -330,6 +337,17 @@ func TestGRPCClientSettingsError(t *testing.T) {
 			},
 			host: &mockHost{},
 		},
+		{
+			err: "unsupported proxy_url scheme \"ftp\", must be one of http, https or socks5",
+			settings: GRPCClientSettings{
+				Endpoint: "localhost:1234",
+				TLSSetting: configtls.TLSClientSetting{
+					Insecure: true,
+				},
+				ProxyURL: "ftp://proxy:21",
+			},
+			host: &mockHost{},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.err, func(t *testing.T) {
@@ -628,6 +646,99 @@ func TestHttpReception(t *testing.T) {
 	}
 }
 
+func TestClientIdentity(t *testing.T) {
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:0",
+			Transport: "tcp",
+		},
+		TLSSetting: &configtls.TLSServerSetting{
+			TLSSetting: configtls.TLSSetting{
+				CertFile: filepath.Join("testdata", "server.crt"),
+				KeyFile:  filepath.Join("testdata", "server.key"),
+			},
+			ClientCAFile: filepath.Join("testdata", "ca.crt"),
+		},
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	s, err := gss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	traceServer := &grpcTraceServer{}
+	ptraceotlp.RegisterGRPCServer(s, traceServer)
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	t.Cleanup(s.Stop)
+
+	exportTraces(t, &GRPCClientSettings{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{
+			TLSSetting: configtls.TLSSetting{
+				CAFile:   filepath.Join("testdata", "ca.crt"),
+				CertFile: filepath.Join("testdata", "client.crt"),
+				KeyFile:  filepath.Join("testdata", "client.key"),
+			},
+			ServerName: "localhost",
+		},
+	}, 1)
+
+	identity := client.FromContext(traceServer.recordedContext).Identity
+	require.NotNil(t, identity)
+	assert.Equal(t, "MyCommonName", identity.CommonName)
+	assert.Equal(t, "CN=MyCommonName,O=MyOrgName,L=Sydney,ST=Australia,C=AU", identity.Subject)
+	assert.Equal(t, []string{"localhost"}, identity.DNSNames)
+}
+
+func TestClientIdentitySPIFFE(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	ca := newSPIFFECA(t, "example.org")
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:0",
+			Transport: "tcp",
+		},
+		TLSSetting: &configtls.TLSServerSetting{
+			TLSSetting: configtls.TLSSetting{
+				SPIFFE: &configtls.SPIFFESetting{
+					WorkloadAPIAddress: startWorkloadAPI(t, ca, "spiffe://example.org/server"),
+					AuthorizedIDs:      []string{"spiffe://example.org/client"},
+				},
+			},
+		},
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	s, err := gss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	traceServer := &grpcTraceServer{}
+	ptraceotlp.RegisterGRPCServer(s, traceServer)
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	t.Cleanup(s.Stop)
+
+	exportTraces(t, &GRPCClientSettings{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{
+			TLSSetting: configtls.TLSSetting{
+				SPIFFE: &configtls.SPIFFESetting{
+					WorkloadAPIAddress: startWorkloadAPI(t, ca, "spiffe://example.org/client"),
+					AuthorizedIDs:      []string{"spiffe://example.org/server"},
+				},
+			},
+		},
+	}, 1)
+
+	// The SPIFFE authorizer verifies the certificate of the client without recording the verified chains.
+	identity := client.FromContext(traceServer.recordedContext).Identity
+	require.NotNil(t, identity)
+	assert.Equal(t, "spiffe://example.org/client", identity.SPIFFEID)
+	assert.Equal(t, []string{"spiffe://example.org/client"}, identity.URIs)
+}
+
 func TestReceiveOnUnixDomainSocket(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("skipping test on windows")
@@ -670,6 +781,134 @@ func TestReceiveOnUnixDomainSocket(t *testing.T) {
 	srv.Stop()
 }
 
+func TestServerMiddlewares(t *testing.T) {
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:0",
+			Transport: "tcp",
+		},
+		Middlewares: []configmiddleware.Middleware{
+			{MiddlewareID: component.NewID("logger")},
+			{MiddlewareID: component.NewID("quota")},
+		},
+	}
+	var calls []string
+	errQuota := status.Error(codes.ResourceExhausted, "quota exceeded")
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			component.NewID("logger"): middleware.NewServer(middleware.WithUnaryServerInterceptor(
+				func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+					calls = append(calls, "logger")
+					return handler(ctx, req)
+				})),
+			component.NewID("quota"): middleware.NewServer(middleware.WithUnaryServerInterceptor(
+				func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+					calls = append(calls, "quota")
+					if len(calls) > 2 {
+						return nil, errQuota
+					}
+					return handler(ctx, req)
+				})),
+		},
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	srv, err := gss.ToServer(host, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	ptraceotlp.RegisterGRPCServer(srv, &grpcTraceServer{})
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	defer srv.Stop()
+
+	gcs := &GRPCClientSettings{
+		Endpoint:   ln.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{Insecure: true},
+	}
+	conn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, conn.Close()) }()
+	c := ptraceotlp.NewGRPCClient(conn)
+
+	_, err = c.Export(context.Background(), ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	require.NoError(t, err)
+	_, err = c.Export(context.Background(), ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []string{"logger", "quota", "logger", "quota"}, calls)
+}
+
+func TestServerMiddlewareNotFound(t *testing.T) {
+	gss := &GRPCServerSettings{
+		Middlewares: []configmiddleware.Middleware{{MiddlewareID: component.NewID("logger")}},
+	}
+	_, err := gss.ToServer(&mockHost{}, componenttest.NewNopTelemetrySettings())
+	assert.EqualError(t, err, `failed to resolve middleware "logger": middleware not found`)
+}
+
+func TestSendThroughProxy(t *testing.T) {
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:0",
+			Transport: "tcp",
+		},
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	srv, err := gss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	ptraceotlp.RegisterGRPCServer(srv, &grpcTraceServer{})
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	defer srv.Stop()
+
+	// The proxy tunnels the connections to the server, whatever the requested address.
+	connectHosts := make(chan string, 1)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serverConn, dialErr := net.Dial("tcp", ln.Addr().String())
+		if dialErr != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		clientConn, _, hijackErr := w.(http.Hijacker).Hijack()
+		if hijackErr != nil {
+			serverConn.Close()
+			return
+		}
+		select {
+		case connectHosts <- r.Host:
+		default:
+		}
+		_, _ = clientConn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		go func() {
+			defer serverConn.Close()
+			_, _ = io.Copy(serverConn, clientConn)
+		}()
+		go func() {
+			defer clientConn.Close()
+			_, _ = io.Copy(clientConn, serverConn)
+		}()
+	}))
+	defer proxy.Close()
+
+	gcs := &GRPCClientSettings{
+		Endpoint: "backend.invalid:4317",
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+		ProxyURL: proxy.URL,
+	}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	defer grpcClientConn.Close()
+	c := ptraceotlp.NewGRPCClient(grpcClientConn)
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFunc()
+	_, err = c.Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	assert.NoError(t, err)
+	assert.Equal(t, "backend.invalid:4317", <-connectHosts)
+}
+
 func TestContextWithClient(t *testing.T) {
 	testCases := []struct {
 		desc       string
@@ -768,7 +1007,7 @@ func TestContextWithClient(t *testing.T) {
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
-			cl := client.FromContext(contextWithClient(tC.input, tC.doMetadata))
+			cl := client.FromContext(contextWithClient(tC.input, tC.doMetadata, false))
 			assert.Equal(t, tC.expected, cl)
 		})
 	}
@@ -791,7 +1030,7 @@ func TestStreamInterceptorEnhancesClient(t *testing.T) {
 	}
 
 	// test
-	err := enhanceStreamWithClientInformation(false)(nil, stream, nil, handler)
+	err := enhanceStreamWithClientInformation(false, false)(nil, stream, nil, handler)
 
 	// verify
 	assert.NoError(t, err)
@@ -940,6 +1179,31 @@ func TestDefaultUnaryInterceptorAuthFailure(t *testing.T) {
 	assert.True(t, authCalled)
 }
 
+func TestDefaultUnaryInterceptorPermissionDenied(t *testing.T) {
+	handler := func(ctx context.Context, req any) (any, error) {
+		assert.FailNow(t, "the handler should not have been called on authorization failure!")
+		return nil, nil
+	}
+	authFunc := func(ctx context.Context, _ map[string][]string) (context.Context, *client.Claims, error) {
+		return ctx, &client.Claims{Tenant: "tenant-a", Scopes: []string{"metrics:write"}}, nil
+	}
+	authentication := configauth.Authentication{
+		AuthenticatorID: component.NewID("mock"),
+		RequiredScopes:  []string{"traces:write"},
+	}
+	server, err := authentication.GetServerAuthenticator(map[component.ID]component.Component{
+		component.NewID("mock"): auth.NewServer(auth.WithServerAuthenticateClaims(authFunc)),
+	})
+	require.NoError(t, err)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "some-auth-data"))
+
+	// test
+	_, err = authUnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler, server)
+
+	// verify
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
 func TestDefaultUnaryInterceptorMissingMetadata(t *testing.T) {
 	// prepare
 	authFunc := func(context.Context, map[string][]string) (context.Context, error) {
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// KeepaliveProfileLAN sends frequent keepalive pings, to detect broken connections quickly
+	// between hosts of the same network.
+	KeepaliveProfileLAN = "lan"
+
+	// KeepaliveProfileWAN sends keepalive pings often enough to keep the connections open through
+	// the proxies and load balancers closing idle connections, without being refused by servers.
+	KeepaliveProfileWAN = "wan"
+)
+
+// KeepaliveClientProfile returns the client keepalive parameters of the given profile.
+// The servers must accept pings as often as the client sends them, for instance by using the
+// server keepalive parameters of the same profile.
+func KeepaliveClientProfile(profile string) (KeepaliveClientConfig, error) {
+	switch profile {
+	case KeepaliveProfileLAN:
+		return KeepaliveClientConfig{
+			Time:                30 * time.Second,
+			Timeout:             5 * time.Second,
+			PermitWithoutStream: true,
+		}, nil
+	case KeepaliveProfileWAN:
+		return KeepaliveClientConfig{
+			Time:    time.Minute,
+			Timeout: 20 * time.Second,
+		}, nil
+	}
+	return KeepaliveClientConfig{}, fmt.Errorf("unknown keepalive profile %q, must be either %q or %q", profile, KeepaliveProfileLAN, KeepaliveProfileWAN)
+}
+
+// KeepaliveServerProfile returns the server keepalive parameters of the given profile.
+func KeepaliveServerProfile(profile string) (KeepaliveServerConfig, error) {
+	switch profile {
+	case KeepaliveProfileLAN:
+		return KeepaliveServerConfig{
+			ServerParameters: &KeepaliveServerParameters{
+				Time:    30 * time.Second,
+				Timeout: 5 * time.Second,
+			},
+			EnforcementPolicy: &KeepaliveEnforcementPolicy{
+				MinTime:             10 * time.Second,
+				PermitWithoutStream: true,
+			},
+		}, nil
+	case KeepaliveProfileWAN:
+		return KeepaliveServerConfig{
+			ServerParameters: &KeepaliveServerParameters{
+				Time:    time.Minute,
+				Timeout: 20 * time.Second,
+			},
+			EnforcementPolicy: &KeepaliveEnforcementPolicy{
+				MinTime: 30 * time.Second,
+			},
+		}, nil
+	}
+	return KeepaliveServerConfig{}, fmt.Errorf("unknown keepalive profile %q, must be either %q or %q", profile, KeepaliveProfileLAN, KeepaliveProfileWAN)
+}
+
+// Validate checks if the keepalive profile is valid.
+func (kcc *KeepaliveClientConfig) Validate() error {
+	_, err := kcc.withProfile()
+	return err
+}
+
+// withProfile returns the keepalive parameters of the profile, overridden by the ones which are set.
+func (kcc *KeepaliveClientConfig) withProfile() (KeepaliveClientConfig, error) {
+	if kcc.Profile == "" {
+		return *kcc, nil
+	}
+	cfg, err := KeepaliveClientProfile(kcc.Profile)
+	if err != nil {
+		return cfg, err
+	}
+	if kcc.Time != 0 {
+		cfg.Time = kcc.Time
+	}
+	if kcc.Timeout != 0 {
+		cfg.Timeout = kcc.Timeout
+	}
+	if kcc.PermitWithoutStream {
+		cfg.PermitWithoutStream = true
+	}
+	return cfg, nil
+}
+
+// Validate checks if the keepalive profile is valid.
+func (ksc *KeepaliveServerConfig) Validate() error {
+	_, err := ksc.withProfile()
+	return err
+}
+
+// withProfile returns the keepalive parameters of the profile, overridden by the ones which are set.
+func (ksc *KeepaliveServerConfig) withProfile() (KeepaliveServerConfig, error) {
+	if ksc.Profile == "" {
+		return *ksc, nil
+	}
+	cfg, err := KeepaliveServerProfile(ksc.Profile)
+	if err != nil {
+		return cfg, err
+	}
+	if sp := ksc.ServerParameters; sp != nil {
+		params := cfg.ServerParameters
+		if sp.MaxConnectionIdle != 0 {
+			params.MaxConnectionIdle = sp.MaxConnectionIdle
+		}
+		if sp.MaxConnectionAge != 0 {
+			params.MaxConnectionAge = sp.MaxConnectionAge
+		}
+		if sp.MaxConnectionAgeGrace != 0 {
+			params.MaxConnectionAgeGrace = sp.MaxConnectionAgeGrace
+		}
+		if sp.Time != 0 {
+			params.Time = sp.Time
+		}
+		if sp.Timeout != 0 {
+			params.Timeout = sp.Timeout
+		}
+	}
+	if ep := ksc.EnforcementPolicy; ep != nil {
+		if ep.MinTime != 0 {
+			cfg.EnforcementPolicy.MinTime = ep.MinTime
+		}
+		if ep.PermitWithoutStream {
+			cfg.EnforcementPolicy.PermitWithoutStream = true
+		}
+	}
+	return cfg, nil
+}
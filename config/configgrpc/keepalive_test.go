@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+func TestKeepaliveProfiles(t *testing.T) {
+	for _, profile := range []string{KeepaliveProfileLAN, KeepaliveProfileWAN} {
+		t.Run(profile, func(t *testing.T) {
+			client, err := KeepaliveClientProfile(profile)
+			require.NoError(t, err)
+			server, err := KeepaliveServerProfile(profile)
+			require.NoError(t, err)
+			// The servers accept the pings sent by the clients of the same profile.
+			assert.GreaterOrEqual(t, client.Time, server.EnforcementPolicy.MinTime)
+			assert.Equal(t, client.PermitWithoutStream, server.EnforcementPolicy.PermitWithoutStream)
+		})
+	}
+
+	_, err := KeepaliveClientProfile("satellite")
+	assert.EqualError(t, err, `unknown keepalive profile "satellite", must be either "lan" or "wan"`)
+	_, err = KeepaliveServerProfile("satellite")
+	assert.EqualError(t, err, `unknown keepalive profile "satellite", must be either "lan" or "wan"`)
+}
+
+func TestKeepaliveClientConfigWithProfile(t *testing.T) {
+	kcc := &KeepaliveClientConfig{
+		Profile: KeepaliveProfileWAN,
+		Timeout: 10 * time.Second,
+	}
+	require.NoError(t, kcc.Validate())
+	cfg, err := kcc.withProfile()
+	require.NoError(t, err)
+	assert.Equal(t, KeepaliveClientConfig{Time: time.Minute, Timeout: 10 * time.Second}, cfg)
+
+	kcc = &KeepaliveClientConfig{Time: time.Second}
+	cfg, err = kcc.withProfile()
+	require.NoError(t, err)
+	assert.Equal(t, *kcc, cfg)
+
+	kcc = &KeepaliveClientConfig{Profile: "satellite"}
+	assert.Error(t, kcc.Validate())
+	gcs := &GRPCClientSettings{
+		Endpoint:  "localhost:1234",
+		Keepalive: kcc,
+	}
+	_, err = gcs.toDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	assert.Error(t, err)
+}
+
+func TestKeepaliveServerConfigWithProfile(t *testing.T) {
+	ksc := &KeepaliveServerConfig{
+		Profile: KeepaliveProfileLAN,
+		ServerParameters: &KeepaliveServerParameters{
+			MaxConnectionAge: time.Hour,
+		},
+		EnforcementPolicy: &KeepaliveEnforcementPolicy{
+			MinTime: time.Second,
+		},
+	}
+	require.NoError(t, ksc.Validate())
+	cfg, err := ksc.withProfile()
+	require.NoError(t, err)
+	assert.Equal(t, KeepaliveServerConfig{
+		ServerParameters: &KeepaliveServerParameters{
+			MaxConnectionAge: time.Hour,
+			Time:             30 * time.Second,
+			Timeout:          5 * time.Second,
+		},
+		EnforcementPolicy: &KeepaliveEnforcementPolicy{
+			MinTime:             time.Second,
+			PermitWithoutStream: true,
+		},
+	}, cfg)
+
+	ksc = &KeepaliveServerConfig{Profile: "satellite"}
+	assert.Error(t, ksc.Validate())
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:0",
+			Transport: "tcp",
+		},
+		Keepalive: ksc,
+	}
+	_, err = gss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	assert.Error(t, err)
+}
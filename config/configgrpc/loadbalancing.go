@@ -0,0 +1,281 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/attributes"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/balancer/roundrobin"
+	_ "google.golang.org/grpc/health" // Registers the client side health checking.
+	"google.golang.org/grpc/resolver"
+
+	"go.opentelemetry.io/collector/config/internal"
+)
+
+const (
+	// endpointsScheme is the scheme of the target of the connections balanced among several endpoints.
+	endpointsScheme = "otel-endpoints"
+
+	failoverBalancerName     = "otel_failover"
+	leastPendingBalancerName = "otel_least_pending"
+
+	defaultDNSRefreshInterval = 30 * time.Second
+)
+
+func init() {
+	balancer.Register(base.NewBalancerBuilder(failoverBalancerName, failoverPickerBuilder{}, base.Config{HealthCheck: true}))
+	balancer.Register(base.NewBalancerBuilder(leastPendingBalancerName, leastPendingPickerBuilder{}, base.Config{HealthCheck: true}))
+}
+
+// LoadBalancingSettings balances the RPCs among several endpoints.
+type LoadBalancingSettings struct {
+	// Endpoints are the "host:port" endpoints the RPCs are balanced among, after the endpoint of the client.
+	// The host names of the endpoints are resolved, the RPCs are balanced among their addresses.
+	Endpoints []string `mapstructure:"endpoints"`
+
+	// Policy balances the RPCs among the addresses of the endpoints, among "failover", the default,
+	// "round_robin" and "least_pending". The addresses which cannot be connected to are skipped.
+	Policy string `mapstructure:"policy"`
+
+	// DNSRefreshInterval is the interval at which the host names of the endpoints are resolved again, 30s if 0.
+	DNSRefreshInterval time.Duration `mapstructure:"dns_refresh_interval"`
+
+	// HealthCheck enables the health checking of the addresses with the grpc.health.v1.Health service,
+	// the RPCs are not sent to the unhealthy addresses.
+	HealthCheck *HealthCheckSettings `mapstructure:"health_check"`
+}
+
+// HealthCheckSettings defines the health checking of the addresses of the endpoints.
+type HealthCheckSettings struct {
+	// ServiceName is the name of the service whose health is checked, the health of the server if empty.
+	// The servers which do not implement the grpc.health.v1.Health service are healthy.
+	ServiceName string `mapstructure:"service_name"`
+}
+
+// Validate checks if the load balancing settings are valid.
+func (lbs *LoadBalancingSettings) Validate() error {
+	if err := internal.ValidateLoadBalancingPolicy(lbs.Policy); err != nil {
+		return err
+	}
+	for _, endpoint := range lbs.Endpoints {
+		if endpoint == "" {
+			return errors.New("the endpoints must not be empty")
+		}
+	}
+	if lbs.DNSRefreshInterval < 0 {
+		return errors.New("dns_refresh_interval must not be negative")
+	}
+	return nil
+}
+
+// dialTarget returns the target the client connects to.
+func (gcs *GRPCClientSettings) dialTarget() string {
+	if gcs.LoadBalancing == nil {
+		return gcs.SanitizedEndpoint()
+	}
+	return endpointsScheme + ":///" + gcs.SanitizedEndpoint()
+}
+
+// loadBalancingOptions returns the dial options balancing the RPCs among the endpoints.
+func (gcs *GRPCClientSettings) loadBalancingOptions() ([]grpc.DialOption, error) {
+	lbs := gcs.LoadBalancing
+	if err := lbs.Validate(); err != nil {
+		return nil, err
+	}
+	if gcs.BalancerName != "" {
+		return nil, errors.New("balancer_name cannot be used with load_balancing")
+	}
+
+	var endpoints []string
+	if gcs.Endpoint != "" {
+		endpoints = append(endpoints, gcs.SanitizedEndpoint())
+	}
+	for _, endpoint := range lbs.Endpoints {
+		endpoint = strings.TrimPrefix(strings.TrimPrefix(endpoint, "http://"), "https://")
+		endpoints = append(endpoints, strings.TrimPrefix(endpoint, "dns:///"))
+	}
+	if len(endpoints) == 0 {
+		return nil, errors.New("load_balancing requires at least one endpoint")
+	}
+	refreshInterval := lbs.DNSRefreshInterval
+	if refreshInterval == 0 {
+		refreshInterval = defaultDNSRefreshInterval
+	}
+
+	balancerName := failoverBalancerName
+	switch lbs.Policy {
+	case internal.PolicyRoundRobin:
+		balancerName = roundrobin.Name
+	case internal.PolicyLeastPending:
+		balancerName = leastPendingBalancerName
+	}
+	serviceConfig := map[string]any{
+		"loadBalancingConfig": []any{map[string]any{balancerName: map[string]any{}}},
+	}
+	if lbs.HealthCheck != nil {
+		serviceConfig["healthCheckConfig"] = map[string]any{"serviceName": lbs.HealthCheck.ServiceName}
+	}
+	sc, err := json.Marshal(serviceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the service config: %w", err)
+	}
+	return []grpc.DialOption{
+		grpc.WithResolvers(&endpointsResolverBuilder{endpoints: endpoints, refreshInterval: refreshInterval}),
+		grpc.WithDefaultServiceConfig(string(sc)),
+	}, nil
+}
+
+// priorityKey is the key of the balancer attribute holding the rank of an address in the order of the endpoints.
+type priorityKey struct{}
+
+// endpointsResolverBuilder builds the resolvers of the addresses of the endpoints.
+type endpointsResolverBuilder struct {
+	endpoints       []string
+	refreshInterval time.Duration
+}
+
+var _ resolver.Builder = (*endpointsResolverBuilder)(nil)
+
+func (b *endpointsResolverBuilder) Build(_ resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	r := &endpointsResolver{
+		builder:    b,
+		cc:         cc,
+		resolveNow: make(chan struct{}, 1),
+		done:       make(chan struct{}),
+	}
+	r.wg.Add(1)
+	go r.run()
+	return r, nil
+}
+
+func (b *endpointsResolverBuilder) Scheme() string {
+	return endpointsScheme
+}
+
+// endpointsResolver resolves the host names of the endpoints periodically and when gRPC requests it.
+type endpointsResolver struct {
+	builder    *endpointsResolverBuilder
+	cc         resolver.ClientConn
+	resolveNow chan struct{}
+	done       chan struct{}
+	wg         sync.WaitGroup
+}
+
+var _ resolver.Resolver = (*endpointsResolver)(nil)
+
+func (r *endpointsResolver) run() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.builder.refreshInterval)
+	defer ticker.Stop()
+	for {
+		r.resolve()
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+		case <-r.resolveNow:
+		}
+	}
+}
+
+func (r *endpointsResolver) resolve() {
+	ctx, cancel := context.WithTimeout(context.Background(), r.builder.refreshInterval)
+	defer cancel()
+	resolved := internal.ResolveEndpoints(ctx, r.builder.endpoints)
+	addresses := make([]resolver.Address, 0, len(resolved))
+	for i, address := range resolved {
+		addresses = append(addresses, resolver.Address{
+			Addr:               address.Address,
+			ServerName:         address.ServerName,
+			BalancerAttributes: attributes.New(priorityKey{}, i),
+		})
+	}
+	_ = r.cc.UpdateState(resolver.State{Addresses: addresses})
+}
+
+func (r *endpointsResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.resolveNow <- struct{}{}:
+	default:
+	}
+}
+
+func (r *endpointsResolver) Close() {
+	close(r.done)
+	r.wg.Wait()
+}
+
+// failoverPickerBuilder builds the pickers sending the RPCs to the ready address ranked first.
+type failoverPickerBuilder struct{}
+
+func (failoverPickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	var picked balancer.SubConn
+	var pickedPriority int
+	for sc, sci := range info.ReadySCs {
+		priority, _ := sci.Address.BalancerAttributes.Value(priorityKey{}).(int)
+		if picked == nil || priority < pickedPriority {
+			picked, pickedPriority = sc, priority
+		}
+	}
+	if picked == nil {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+	return &failoverPicker{subConn: picked}
+}
+
+type failoverPicker struct {
+	subConn balancer.SubConn
+}
+
+func (p *failoverPicker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	return balancer.PickResult{SubConn: p.subConn}, nil
+}
+
+// leastPendingPickerBuilder builds the pickers sending the RPCs to the ready address with the fewest pending RPCs.
+type leastPendingPickerBuilder struct{}
+
+func (leastPendingPickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+	p := &leastPendingPicker{}
+	for sc := range info.ReadySCs {
+		p.subConns = append(p.subConns, sc)
+	}
+	p.pending = make([]atomic.Int64, len(p.subConns))
+	return p
+}
+
+type leastPendingPicker struct {
+	subConns []balancer.SubConn
+	pending  []atomic.Int64
+	// next rotates the first address considered, so that the RPCs are spread among the idle addresses.
+	next atomic.Uint32
+}
+
+func (p *leastPendingPicker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	start := int(p.next.Add(1))
+	picked := start % len(p.subConns)
+	for i := 1; i < len(p.subConns); i++ {
+		if idx := (start + i) % len(p.subConns); p.pending[idx].Load() < p.pending[picked].Load() {
+			picked = idx
+		}
+	}
+	p.pending[picked].Add(1)
+	return balancer.PickResult{
+		SubConn: p.subConns[picked],
+		Done:    func(balancer.DoneInfo) { p.pending[picked].Add(-1) },
+	}, nil
+}
@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+// countingTraceServer counts the requests it receives.
+type countingTraceServer struct {
+	ptraceotlp.UnimplementedGRPCServer
+	requests atomic.Int64
+}
+
+func (s *countingTraceServer) Export(context.Context, ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	s.requests.Add(1)
+	return ptraceotlp.NewExportResponse(), nil
+}
+
+// startCountingServer starts a gRPC server counting the traces requests and serving the health service,
+// and returns its address.
+func startCountingServer(t *testing.T) (string, *countingTraceServer, *health.Server) {
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:0",
+			Transport: "tcp",
+		},
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	srv, err := gss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	traceServer := &countingTraceServer{}
+	ptraceotlp.RegisterGRPCServer(srv, traceServer)
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(srv, healthServer)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+	return ln.Addr().String(), traceServer, healthServer
+}
+
+func TestLoadBalancingValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		settings    LoadBalancingSettings
+		expectedErr string
+	}{
+		{
+			name:     "default",
+			settings: LoadBalancingSettings{Endpoints: []string{"localhost:4317"}},
+		},
+		{
+			name:        "unsupported policy",
+			settings:    LoadBalancingSettings{Policy: "random"},
+			expectedErr: `unsupported load balancing policy "random", must be one of "failover", "round_robin" or "least_pending"`,
+		},
+		{
+			name:        "empty endpoint",
+			settings:    LoadBalancingSettings{Endpoints: []string{""}},
+			expectedErr: "the endpoints must not be empty",
+		},
+		{
+			name:        "negative refresh interval",
+			settings:    LoadBalancingSettings{DNSRefreshInterval: -time.Second},
+			expectedErr: "dns_refresh_interval must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestLoadBalancingErrors(t *testing.T) {
+	_, err := (&GRPCClientSettings{
+		BalancerName:  "round_robin",
+		LoadBalancing: &LoadBalancingSettings{Endpoints: []string{"localhost:4317"}},
+	}).ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	assert.EqualError(t, err, "balancer_name cannot be used with load_balancing")
+
+	_, err = (&GRPCClientSettings{
+		LoadBalancing: &LoadBalancingSettings{},
+	}).ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	assert.EqualError(t, err, "load_balancing requires at least one endpoint")
+}
+
+func TestLoadBalancingFailover(t *testing.T) {
+	first, firstServer, _ := startCountingServer(t)
+	second, secondServer, _ := startCountingServer(t)
+
+	gcs := &GRPCClientSettings{
+		Endpoint:      first,
+		LoadBalancing: &LoadBalancingSettings{Endpoints: []string{second}},
+		TLSSetting:    configtls.TLSClientSetting{Insecure: true},
+	}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, grpcClientConn.Close()) })
+	client := ptraceotlp.NewGRPCClient(grpcClientConn)
+	export := func() {
+		_, err := client.Export(context.Background(), ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+		require.NoError(t, err)
+	}
+
+	// The requests may be sent to the second endpoint until the connection to the first one is ready.
+	assert.Eventually(t, func() bool {
+		export()
+		return firstServer.requests.Load() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	sent := secondServer.requests.Load()
+	for i := 0; i < 4; i++ {
+		export()
+	}
+	assert.Equal(t, sent, secondServer.requests.Load())
+}
+
+func TestLoadBalancingFailoverUnavailableEndpoint(t *testing.T) {
+	// The first endpoint does not listen, the requests are sent to the second one.
+	second, secondServer, _ := startCountingServer(t)
+	gss := &GRPCServerSettings{NetAddr: confignet.NetAddr{Endpoint: "localhost:0", Transport: "tcp"}}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	unavailable := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	exportTraces(t, &GRPCClientSettings{
+		LoadBalancing: &LoadBalancingSettings{Endpoints: []string{unavailable, second}},
+		TLSSetting:    configtls.TLSClientSetting{Insecure: true},
+	}, 2)
+	assert.EqualValues(t, 2, secondServer.requests.Load())
+}
+
+func TestLoadBalancingRoundRobin(t *testing.T) {
+	first, firstServer, _ := startCountingServer(t)
+	second, secondServer, _ := startCountingServer(t)
+
+	gcs := &GRPCClientSettings{
+		Endpoint:      first,
+		LoadBalancing: &LoadBalancingSettings{Endpoints: []string{second}, Policy: "round_robin"},
+		TLSSetting:    configtls.TLSClientSetting{Insecure: true},
+	}
+	// The requests are spread among the addresses once both connections are ready.
+	assert.Eventually(t, func() bool {
+		exportTraces(t, gcs, 2)
+		return firstServer.requests.Load() > 0 && secondServer.requests.Load() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestLoadBalancingLeastPending(t *testing.T) {
+	first, firstServer, _ := startCountingServer(t)
+	second, secondServer, _ := startCountingServer(t)
+
+	gcs := &GRPCClientSettings{
+		Endpoint:      first,
+		LoadBalancing: &LoadBalancingSettings{Endpoints: []string{second}, Policy: "least_pending"},
+		TLSSetting:    configtls.TLSClientSetting{Insecure: true},
+	}
+	assert.Eventually(t, func() bool {
+		exportTraces(t, gcs, 4)
+		return firstServer.requests.Load() > 0 && secondServer.requests.Load() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestLoadBalancingHealthCheck(t *testing.T) {
+	first, firstServer, firstHealth := startCountingServer(t)
+	second, secondServer, secondHealth := startCountingServer(t)
+	firstHealth.SetServingStatus("traces", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	secondHealth.SetServingStatus("traces", grpc_health_v1.HealthCheckResponse_SERVING)
+
+	exportTraces(t, &GRPCClientSettings{
+		Endpoint: first,
+		LoadBalancing: &LoadBalancingSettings{
+			Endpoints:   []string{second},
+			HealthCheck: &HealthCheckSettings{ServiceName: "traces"},
+		},
+		TLSSetting: configtls.TLSClientSetting{Insecure: true},
+	}, 2)
+	assert.Zero(t, firstServer.requests.Load())
+	assert.EqualValues(t, 2, secondServer.requests.Load())
+}
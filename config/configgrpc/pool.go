@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.uber.org/multierr"
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var _ grpc.ClientConnInterface = (*ClientConnPool)(nil)
+
+// ClientConnPool sends the RPCs to a pool of client connections to the same target in turn,
+// so that the RPCs are not all multiplexed on a single HTTP/2 connection, which limits the
+// throughput and blocks all the RPCs when a large message is being sent.
+type ClientConnPool struct {
+	conns []*grpc.ClientConn
+	next  atomic.Uint32
+}
+
+// ToClientConnPool creates a pool of client connections to the given target, with as many
+// connections as set by Pool, or a single one if Pool is not set. Each connection is created
+// as done by ToClientConn.
+func (gcs *GRPCClientSettings) ToClientConnPool(ctx context.Context, host component.Host, settings component.TelemetrySettings, extraOpts ...grpc.DialOption) (*ClientConnPool, error) {
+	opts, err := gcs.toDialOptions(host, settings)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, extraOpts...)
+
+	size := gcs.Pool
+	if size < 1 {
+		size = 1
+	}
+	pool := &ClientConnPool{conns: make([]*grpc.ClientConn, 0, size)}
+	for i := 0; i < size; i++ {
+		conn, err := grpc.DialContext(ctx, gcs.dialTarget(), opts...)
+		if err != nil {
+			_ = pool.Close()
+			return nil, err
+		}
+		pool.conns = append(pool.conns, conn)
+	}
+	return pool, nil
+}
+
+// Invoke performs a unary RPC on the next connection of the pool.
+func (p *ClientConnPool) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	return p.pick().Invoke(ctx, method, args, reply, opts...)
+}
+
+// NewStream begins a streaming RPC on the next connection of the pool.
+func (p *ClientConnPool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return p.pick().NewStream(ctx, desc, method, opts...)
+}
+
+// Close closes all the connections of the pool.
+func (p *ClientConnPool) Close() error {
+	var errs error
+	for _, conn := range p.conns {
+		errs = multierr.Append(errs, conn.Close())
+	}
+	return errs
+}
+
+// pick returns the connections of the pool in turn.
+func (p *ClientConnPool) pick() *grpc.ClientConn {
+	if len(p.conns) == 1 {
+		return p.conns[0]
+	}
+	return p.conns[(p.next.Add(1)-1)%uint32(len(p.conns))]
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+type peerRecordingTraceServer struct {
+	ptraceotlp.UnimplementedGRPCServer
+
+	mu    sync.Mutex
+	peers map[string]int
+}
+
+func (s *peerRecordingTraceServer) Export(ctx context.Context, _ ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	p, _ := peer.FromContext(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.peers[p.Addr.String()]++
+	return ptraceotlp.NewExportResponse(), nil
+}
+
+func TestClientConnPool(t *testing.T) {
+	tests := []struct {
+		name      string
+		pool      int
+		wantConns int
+	}{
+		{
+			name:      "default",
+			wantConns: 1,
+		},
+		{
+			name:      "pool",
+			pool:      3,
+			wantConns: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gss := &GRPCServerSettings{
+				NetAddr: confignet.NetAddr{
+					Endpoint:  "localhost:0",
+					Transport: "tcp",
+				},
+			}
+			ln, err := gss.ToListener()
+			require.NoError(t, err)
+			srv, err := gss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			traceServer := &peerRecordingTraceServer{peers: map[string]int{}}
+			ptraceotlp.RegisterGRPCServer(srv, traceServer)
+			go func() {
+				_ = srv.Serve(ln)
+			}()
+			t.Cleanup(srv.Stop)
+
+			gcs := &GRPCClientSettings{
+				Endpoint: ln.Addr().String(),
+				TLSSetting: configtls.TLSClientSetting{
+					Insecure: true,
+				},
+				Pool: tt.pool,
+			}
+			pool, err := gcs.ToClientConnPool(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			assert.Len(t, pool.conns, tt.wantConns)
+
+			c := ptraceotlp.NewGRPCClient(pool)
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			for i := 0; i < 2*tt.wantConns; i++ {
+				_, err = c.Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+				require.NoError(t, err)
+			}
+			require.NoError(t, pool.Close())
+
+			traceServer.mu.Lock()
+			defer traceServer.mu.Unlock()
+			// Each connection of the pool received the same number of requests.
+			assert.Len(t, traceServer.peers, tt.wantConns)
+			for _, count := range traceServer.peers {
+				assert.Equal(t, 2, count)
+			}
+		})
+	}
+}
+
+func TestClientConnPoolError(t *testing.T) {
+	gcs := &GRPCClientSettings{
+		Endpoint:    "localhost:1234",
+		Compression: "lz4",
+		Pool:        2,
+	}
+	_, err := gcs.ToClientConnPool(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	assert.EqualError(t, err, `unsupported compression type "lz4"`)
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package zstddict // import "go.opentelemetry.io/collector/config/configgrpc/zstddict"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// standardName is the name of the standard zstd compression, which the servers decode without dictionary.
+const standardName = "zstd"
+
+type dictEncoder struct {
+	id      uint32
+	dict    []byte
+	encoder *zstd.Encoder
+}
+
+// ClientCompressor compresses the messages of a single client connection. Messages
+// are compressed without dictionary, with the standard zstd compression, until the server
+// acknowledges a dictionary, so that the servers which do not support the dictionaries
+// still decode them. It must be passed to the connection with grpc.WithCompressor, the
+// encoding registry of gRPC having no per connection compressors, and its UnaryClientInterceptor
+// must be used by the connection.
+type ClientCompressor struct {
+	level   zstd.EncoderLevel
+	active  atomic.Pointer[dictEncoder]
+	pending atomic.Pointer[dictEncoder]
+
+	// negotiated is set once the server acknowledged a dictionary, the messages are then sent
+	// with the Name compression.
+	negotiated atomic.Bool
+	// standardCalls counts the calls in flight started before negotiated was set, which send
+	// their messages with the standard zstd compression.
+	standardCalls atomic.Int64
+
+	mu sync.Mutex
+	// acknowledged is the last acknowledged dictionary, which is used once standardCalls is 0.
+	acknowledged *dictEncoder
+}
+
+var _ grpc.Compressor = (*ClientCompressor)(nil) //nolint:staticcheck // SA1019 the per connection compressor is needed to track dictionaries per server.
+
+// NewClientCompressor returns a ClientCompressor without dictionary, compressing with the zstd level,
+// between 1 (best speed) and 22 (best compression) and mapped to the closest level supported by the encoder,
+// or with the default level if 0.
+func NewClientCompressor(level int) (*ClientCompressor, error) {
+	c := &ClientCompressor{level: zstd.SpeedDefault}
+	if level != 0 {
+		c.level = zstd.EncoderLevelFromZstd(level)
+	}
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(c.level))
+	if err != nil {
+		return nil, err
+	}
+	c.active.Store(&dictEncoder{encoder: enc})
+	return c, nil
+}
+
+// Do compresses p into w using the last acknowledged dictionary.
+func (c *ClientCompressor) Do(w io.Writer, p []byte) error {
+	_, err := w.Write(c.active.Load().encoder.EncodeAll(p, nil))
+	return err
+}
+
+// Type returns the name of the compressor registered on the server side: the standard zstd
+// compression until the server acknowledges a dictionary, Name afterwards.
+func (c *ClientCompressor) Type() string {
+	if c.negotiated.Load() {
+		return Name
+	}
+	return standardName
+}
+
+// SetDictionary offers a new dictionary to the server. It is used for compression
+// only after the server acknowledges it.
+func (c *ClientCompressor) SetDictionary(dict []byte) error {
+	id, err := dictionaryID(dict)
+	if err != nil {
+		return err
+	}
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(c.level), zstd.WithEncoderDict(dict))
+	if err != nil {
+		return err
+	}
+	if old := c.pending.Swap(&dictEncoder{id: id, dict: dict, encoder: enc}); old != nil {
+		_ = old.encoder.Close()
+	}
+	return nil
+}
+
+// OutgoingMetadata returns the metadata to attach to the next request. It carries
+// the pending dictionary, if any, and is empty otherwise.
+func (c *ClientCompressor) OutgoingMetadata() metadata.MD {
+	pending := c.pending.Load()
+	if pending == nil {
+		return nil
+	}
+	return metadata.Pairs(DictionaryHeader, string(pending.dict))
+}
+
+// HandleResponseHeader activates the pending dictionary if the server acknowledged it. The first
+// dictionary is only activated once the calls sent with the standard zstd compression completed,
+// as the servers cannot decode their messages if they are compressed with a dictionary.
+func (c *ClientCompressor) HandleResponseHeader(md metadata.MD) {
+	pending := c.pending.Load()
+	if pending == nil {
+		return
+	}
+	for _, ack := range md.Get(AckHeader) {
+		if id, err := strconv.ParseUint(ack, 10, 32); err == nil && uint32(id) == pending.id {
+			if c.pending.CompareAndSwap(pending, nil) {
+				c.mu.Lock()
+				defer c.mu.Unlock()
+				c.negotiated.Store(true)
+				c.acknowledged = pending
+				c.activateAcknowledged()
+			}
+			return
+		}
+	}
+}
+
+// activateAcknowledged activates the acknowledged dictionary once no call sends its messages
+// with the standard zstd compression. c.mu must be held.
+func (c *ClientCompressor) activateAcknowledged() {
+	if c.acknowledged == nil || c.standardCalls.Load() != 0 {
+		return
+	}
+	// The previous encoder may still be used by in-flight requests, let it be garbage collected.
+	c.active.Store(c.acknowledged)
+	c.acknowledged = nil
+}
+
+// UnaryClientInterceptor offers the pending dictionary with the requests and activates it once the server
+// acknowledges it. It must be used by the connection the ClientCompressor is passed to.
+func (c *ClientCompressor) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		// The call is counted before negotiated is checked, so that the first dictionary is not
+		// activated before the calls which saw the standard zstd compression complete.
+		c.standardCalls.Add(1)
+		standard := !c.negotiated.Load()
+		if !standard {
+			c.standardCalls.Add(-1)
+		}
+		for key, values := range c.OutgoingMetadata() {
+			for _, value := range values {
+				ctx = metadata.AppendToOutgoingContext(ctx, key, value)
+			}
+		}
+		var header metadata.MD
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...)
+		c.HandleResponseHeader(header)
+		if standard {
+			c.standardCalls.Add(-1)
+			c.mu.Lock()
+			c.activateAcknowledged()
+			c.mu.Unlock()
+		}
+		return err
+	}
+}
+
+// LoadDictionary reads a pre-trained zstd dictionary, e.g. trained with `zstd --train`, from the file.
+func LoadDictionary(path string) ([]byte, error) {
+	dict, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	if _, err = dictionaryID(dict); err != nil {
+		return nil, fmt.Errorf("failed to load the compression dictionary %q: %w", path, err)
+	}
+	return dict, nil
+}
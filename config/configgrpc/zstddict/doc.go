@@ -0,0 +1,15 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package zstddict implements zstd compression with dictionaries trained from
+// recent payloads for gRPC clients and servers.
+//
+// A client trains a dictionary from samples of the requests it sends and
+// offers it to the server through the DictionaryHeader request metadata. Once
+// the server acknowledges the dictionary through the AckHeader response
+// metadata, subsequent requests are compressed with it. Servers keep the last
+// few dictionaries they received, so in-flight requests compressed with a
+// previous dictionary still decode after a refresh.
+//
+// Experimental: *NOTE* this package is subject to change or removal in the future.
+package zstddict // import "go.opentelemetry.io/collector/config/configgrpc/zstddict"
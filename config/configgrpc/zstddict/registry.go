@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package zstddict // import "go.opentelemetry.io/collector/config/configgrpc/zstddict"
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+)
+
+const (
+	// Name is the name registered for the dictionary aware zstd compressor.
+	Name = "zstd-dict"
+
+	// DictionaryHeader is the request metadata key used to offer a dictionary to the server.
+	DictionaryHeader = "otlp-zstd-dict-bin"
+
+	// AckHeader is the response metadata key used by the server to acknowledge
+	// the ID of the dictionary it received.
+	AckHeader = "otlp-zstd-dict-ack"
+
+	// maxKnownDictionaries is the number of dictionaries a server keeps.
+	maxKnownDictionaries = 8
+
+	// MaxDictionarySize is the maximum size of the dictionaries accepted by the servers.
+	MaxDictionarySize = 1 << 20
+
+	// maxDecompressedSize bounds the memory used to decompress a payload, so that a small
+	// payload cannot expand into a huge buffer.
+	maxDecompressedSize = 256 << 20
+)
+
+var errDictionaryTooLarge = fmt.Errorf("the zstd dictionary exceeds %d bytes", MaxDictionarySize)
+
+func init() {
+	encoding.RegisterCompressor(&compressor{})
+}
+
+// knownDictionaries holds the dictionaries that can be used to decode incoming payloads. The compressor
+// is registered for the whole process, so are the dictionaries, which are only added by the servers
+// accepting them.
+var knownDictionaries = &registry{}
+
+type registryState struct {
+	dicts   [][]byte
+	decoder *zstd.Decoder
+}
+
+type registry struct {
+	mu    sync.Mutex
+	state atomic.Pointer[registryState]
+}
+
+// add registers the dictionary, evicting the least recently offered one if too many are known.
+func (r *registry) add(dict []byte) error {
+	if len(dict) > MaxDictionarySize {
+		return errDictionaryTooLarge
+	}
+	id, err := dictionaryID(dict)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var dicts [][]byte
+	if cur := r.state.Load(); cur != nil {
+		for i, d := range cur.dicts {
+			if curID, _ := dictionaryID(d); curID == id {
+				if i == len(cur.dicts)-1 {
+					return nil
+				}
+				// The dictionary offered again is kept the longest, the decoder is unchanged.
+				dicts = append(append(append(make([][]byte, 0, len(cur.dicts)), cur.dicts[:i]...), cur.dicts[i+1:]...), d)
+				r.state.Store(&registryState{dicts: dicts, decoder: cur.decoder})
+				return nil
+			}
+		}
+		dicts = append(dicts, cur.dicts...)
+	}
+	dicts = append(dicts, dict)
+	if len(dicts) > maxKnownDictionaries {
+		dicts = dicts[len(dicts)-maxKnownDictionaries:]
+	}
+
+	dec, err := newDecoder(zstd.WithDecoderDicts(dicts...))
+	if err != nil {
+		return err
+	}
+	// The previous decoder may still be used by in-flight payloads, let it be garbage collected.
+	r.state.Store(&registryState{dicts: dicts, decoder: dec})
+	return nil
+}
+
+func (r *registry) decoder() (*zstd.Decoder, error) {
+	if cur := r.state.Load(); cur != nil {
+		return cur.decoder, nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cur := r.state.Load(); cur != nil {
+		return cur.decoder, nil
+	}
+	dec, err := newDecoder()
+	if err != nil {
+		return nil, err
+	}
+	r.state.Store(&registryState{decoder: dec})
+	return dec, nil
+}
+
+// newDecoder returns a decoder refusing to decompress the payloads larger than maxDecompressedSize.
+func newDecoder(opts ...zstd.DOption) (*zstd.Decoder, error) {
+	return zstd.NewReader(nil, append(opts, zstd.WithDecoderMaxMemory(maxDecompressedSize))...)
+}
+
+// compressor implements encoding.Compressor. Outgoing payloads are compressed
+// without a dictionary, the per connection ClientCompressor is used by clients.
+type compressor struct {
+	encoderOnce sync.Once
+	encoder     *zstd.Encoder
+	encoderErr  error
+}
+
+var _ encoding.Compressor = (*compressor)(nil)
+
+func (c *compressor) Name() string {
+	return Name
+}
+
+func (c *compressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	c.encoderOnce.Do(func() {
+		c.encoder, c.encoderErr = zstd.NewWriter(nil)
+	})
+	if c.encoderErr != nil {
+		return nil, c.encoderErr
+	}
+	return &bufferedWriter{w: w, encoder: c.encoder}, nil
+}
+
+func (c *compressor) Decompress(r io.Reader) (io.Reader, error) {
+	dec, err := knownDictionaries.decoder()
+	if err != nil {
+		return nil, err
+	}
+	compressed, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	out, err := dec.DecodeAll(compressed, nil)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(out), nil
+}
+
+// bufferedWriter buffers the whole message and encodes it as a single frame on Close.
+type bufferedWriter struct {
+	w       io.Writer
+	encoder *zstd.Encoder
+	buf     bytes.Buffer
+}
+
+func (bw *bufferedWriter) Write(p []byte) (int, error) {
+	return bw.buf.Write(p)
+}
+
+func (bw *bufferedWriter) Close() error {
+	_, err := bw.w.Write(bw.encoder.EncodeAll(bw.buf.Bytes(), nil))
+	return err
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package zstddict // import "go.opentelemetry.io/collector/config/configgrpc/zstddict"
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerInterceptor registers the dictionaries offered by clients and acknowledges them.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if dicts := md.Get(DictionaryHeader); len(dicts) > 0 {
+				acknowledge(ctx, []byte(dicts[len(dicts)-1]))
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+func acknowledge(ctx context.Context, dict []byte) {
+	id, err := dictionaryID(dict)
+	if err != nil {
+		return
+	}
+	if err = knownDictionaries.add(dict); err != nil {
+		return
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(AckHeader, strconv.FormatUint(uint64(id), 10)))
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package zstddict // import "go.opentelemetry.io/collector/config/configgrpc/zstddict"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// dictMagic is the magic number identifying a zstd dictionary.
+	dictMagic = 0xEC30A437
+
+	// minUserDictionaryID is the lowest dictionary ID not reserved by the zstd format.
+	minUserDictionaryID = 32768
+
+	// minSamples is the minimum number of samples required to train a dictionary.
+	minSamples = 8
+)
+
+var errNotEnoughSamples = errors.New("not enough samples to train a compression dictionary")
+
+// Config defines the settings used to train compression dictionaries.
+type Config struct {
+	// MaxSamples is the maximum number of recent payloads used to train a dictionary.
+	MaxSamples int `mapstructure:"max_samples"`
+
+	// MaxSize is the maximum size in bytes of the trained dictionary.
+	MaxSize int `mapstructure:"max_size"`
+
+	// RefreshInterval is the interval at which a new dictionary is trained from recent payloads.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+// NewDefaultConfig returns the default settings for dictionary training.
+func NewDefaultConfig() Config {
+	return Config{
+		MaxSamples:      256,
+		MaxSize:         64 * 1024,
+		RefreshInterval: 5 * time.Minute,
+	}
+}
+
+// Validate checks if the dictionary training configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MaxSamples < minSamples {
+		return fmt.Errorf("max_samples must be at least %d", minSamples)
+	}
+	if cfg.MaxSize <= 0 {
+		return errors.New("max_size must be positive")
+	}
+	if cfg.MaxSize > MaxDictionarySize {
+		return fmt.Errorf("max_size must be at most %d", MaxDictionarySize)
+	}
+	if cfg.RefreshInterval <= 0 {
+		return errors.New("refresh_interval must be positive")
+	}
+	return nil
+}
+
+// Trainer collects samples of recent payloads and trains dictionaries from them.
+type Trainer struct {
+	cfg Config
+
+	mu      sync.Mutex
+	samples [][]byte
+	rand    *rand.Rand
+}
+
+// NewTrainer returns a Trainer using the given configuration.
+func NewTrainer(cfg Config) *Trainer {
+	return &Trainer{
+		cfg:  cfg,
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())), // #nosec G404 -- dictionary IDs do not need to be unpredictable
+	}
+}
+
+// WantsSample returns true if the Trainer has not collected enough samples for the next dictionary.
+// Callers should use it to avoid serializing payloads that would be discarded.
+func (t *Trainer) WantsSample() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.samples) < t.cfg.MaxSamples
+}
+
+// AddSample records a serialized payload to be used for the next dictionary.
+func (t *Trainer) AddSample(payload []byte) {
+	if len(payload) == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.samples) < t.cfg.MaxSamples {
+		t.samples = append(t.samples, payload)
+	}
+}
+
+// Train builds a new dictionary from the collected samples and resets them.
+func (t *Trainer) Train() (dict []byte, err error) {
+	t.mu.Lock()
+	samples := t.samples
+	t.samples = nil
+	id := uint32(minUserDictionaryID + t.rand.Int31n(1<<31-1-minUserDictionaryID))
+	t.mu.Unlock()
+
+	if len(samples) < minSamples {
+		return nil, errNotEnoughSamples
+	}
+
+	// The history is made of the most recent samples, which are the most representative.
+	var history []byte
+	for i := len(samples) - 1; i >= 0 && len(history) < t.cfg.MaxSize; i-- {
+		sample := samples[i]
+		if remaining := t.cfg.MaxSize - len(history); len(sample) > remaining {
+			sample = sample[len(sample)-remaining:]
+		}
+		history = append(sample[:len(sample):len(sample)], history...)
+	}
+
+	// BuildDict panics when the samples contain too few sequences to build the tables of the dictionary.
+	defer func() {
+		if r := recover(); r != nil {
+			dict, err = nil, fmt.Errorf("failed to build compression dictionary: %v", r)
+		}
+	}()
+	return zstd.BuildDict(zstd.BuildDictOptions{
+		ID:       id,
+		Contents: samples,
+		History:  history,
+		Offsets:  [3]int{1, 4, 8},
+		Level:    zstd.SpeedDefault,
+	})
+}
+
+// dictionaryID returns the ID stored in the header of a zstd dictionary.
+func dictionaryID(dict []byte) (uint32, error) {
+	if len(dict) < 8 || binary.LittleEndian.Uint32(dict[:4]) != dictMagic {
+		return 0, errors.New("invalid zstd dictionary")
+	}
+	return binary.LittleEndian.Uint32(dict[4:8]), nil
+}
@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package zstddict
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+)
+
+func samplePayload(i int) []byte {
+	return []byte(fmt.Sprintf(`{"resource":{"service.name":"checkout","host.name":"node-%d"},"spans":[{"trace_id":"%016x","name":"GET /api/cart","status":"ok","attempt":%d}]}`, i%3, uint64(i)*0x9E3779B97F4A7C15, i))
+}
+
+func trainDictionary(t *testing.T) []byte {
+	trainer := NewTrainer(NewDefaultConfig())
+	for i := 0; trainer.WantsSample(); i++ {
+		trainer.AddSample(samplePayload(i))
+	}
+	dict, err := trainer.Train()
+	require.NoError(t, err)
+	return dict
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := NewDefaultConfig()
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MaxSamples = 1
+	assert.Error(t, cfg.Validate())
+
+	cfg = NewDefaultConfig()
+	cfg.MaxSize = 0
+	assert.Error(t, cfg.Validate())
+
+	cfg = NewDefaultConfig()
+	cfg.MaxSize = MaxDictionarySize + 1
+	assert.Error(t, cfg.Validate())
+
+	cfg = NewDefaultConfig()
+	cfg.RefreshInterval = 0
+	assert.Error(t, cfg.Validate())
+}
+
+func TestTrainerNotEnoughSamples(t *testing.T) {
+	trainer := NewTrainer(NewDefaultConfig())
+	trainer.AddSample(samplePayload(0))
+	_, err := trainer.Train()
+	assert.ErrorIs(t, err, errNotEnoughSamples)
+}
+
+func TestTrainerTooFewSequences(t *testing.T) {
+	trainer := NewTrainer(NewDefaultConfig())
+	for i := 0; i < minSamples; i++ {
+		trainer.AddSample(samplePayload(0))
+	}
+	_, err := trainer.Train()
+	assert.Error(t, err)
+}
+
+func TestTrainerDictionaryID(t *testing.T) {
+	dict := trainDictionary(t)
+	id, err := dictionaryID(dict)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, id, uint32(minUserDictionaryID))
+}
+
+func TestDictionaryRoundTrip(t *testing.T) {
+	dict := trainDictionary(t)
+	id, err := dictionaryID(dict)
+	require.NoError(t, err)
+
+	cc, err := NewClientCompressor(0)
+	require.NoError(t, err)
+	assert.Equal(t, standardName, cc.Type())
+	assert.Nil(t, cc.OutgoingMetadata())
+
+	require.NoError(t, cc.SetDictionary(dict))
+	md := cc.OutgoingMetadata()
+	assert.Equal(t, []string{string(dict)}, md.Get(DictionaryHeader))
+
+	// Not acknowledged yet, payloads must be decodable without the dictionary.
+	cc.HandleResponseHeader(metadata.Pairs(AckHeader, "1"))
+	assert.NotNil(t, cc.OutgoingMetadata())
+	assertRoundTrip(t, cc, samplePayload(100))
+
+	require.NoError(t, knownDictionaries.add(dict))
+	cc.HandleResponseHeader(metadata.Pairs(AckHeader, strconv.FormatUint(uint64(id), 10)))
+	assert.Nil(t, cc.OutgoingMetadata())
+	assert.Equal(t, Name, cc.Type())
+	assert.Nil(t, cc.acknowledged)
+	assert.Equal(t, id, cc.active.Load().id)
+	assertRoundTrip(t, cc, samplePayload(101))
+}
+
+func TestClientCompressorLevel(t *testing.T) {
+	cc, err := NewClientCompressor(19)
+	require.NoError(t, err)
+	require.NoError(t, cc.SetDictionary(trainDictionary(t)))
+	assertRoundTrip(t, cc, samplePayload(100))
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	dict := trainDictionary(t)
+	id, err := dictionaryID(dict)
+	require.NoError(t, err)
+	cc, err := NewClientCompressor(0)
+	require.NoError(t, err)
+	require.NoError(t, cc.SetDictionary(dict))
+
+	var offered []string
+	invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		offered = md.Get(DictionaryHeader)
+		for _, opt := range opts {
+			if header, ok := opt.(grpc.HeaderCallOption); ok {
+				*header.HeaderAddr = metadata.Pairs(AckHeader, strconv.FormatUint(uint64(id), 10))
+			}
+		}
+		return nil
+	}
+	interceptor := cc.UnaryClientInterceptor()
+
+	require.NoError(t, interceptor(context.Background(), "/test", nil, nil, nil, invoker))
+	assert.Equal(t, []string{string(dict)}, offered)
+	// The dictionary was acknowledged, it is no longer offered.
+	require.NoError(t, interceptor(context.Background(), "/test", nil, nil, nil, invoker))
+	assert.Empty(t, offered)
+}
+
+func TestUnaryClientInterceptorWaitsForStandardCalls(t *testing.T) {
+	dict := trainDictionary(t)
+	id, err := dictionaryID(dict)
+	require.NoError(t, err)
+	cc, err := NewClientCompressor(0)
+	require.NoError(t, err)
+	require.NoError(t, cc.SetDictionary(dict))
+	interceptor := cc.UnaryClientInterceptor()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// A call sent with the standard zstd compression, still in flight when the dictionary is acknowledged.
+		_ = interceptor(context.Background(), "/test", nil, nil, nil, func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	ack := func(_ context.Context, _ string, _, _ any, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+		for _, opt := range opts {
+			if header, ok := opt.(grpc.HeaderCallOption); ok {
+				*header.HeaderAddr = metadata.Pairs(AckHeader, strconv.FormatUint(uint64(id), 10))
+			}
+		}
+		return nil
+	}
+	require.NoError(t, interceptor(context.Background(), "/test", nil, nil, nil, ack))
+	assert.Equal(t, Name, cc.Type())
+	// The dictionary is not used while the standard call is in flight.
+	assert.Nil(t, cc.active.Load().dict)
+
+	close(release)
+	<-done
+	assert.Equal(t, dict, cc.active.Load().dict)
+}
+
+func TestLoadDictionary(t *testing.T) {
+	dict := trainDictionary(t)
+	path := filepath.Join(t.TempDir(), "dictionary")
+	require.NoError(t, os.WriteFile(path, dict, 0600))
+	loaded, err := LoadDictionary(path)
+	require.NoError(t, err)
+	assert.Equal(t, dict, loaded)
+
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0600))
+	_, err = LoadDictionary(path)
+	assert.ErrorContains(t, err, "invalid zstd dictionary")
+
+	_, err = LoadDictionary(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func assertRoundTrip(t *testing.T, cc *ClientCompressor, payload []byte) {
+	var compressed bytes.Buffer
+	require.NoError(t, cc.Do(&compressed, payload))
+
+	comp := encoding.GetCompressor(Name)
+	require.NotNil(t, comp)
+	r, err := comp.Decompress(&compressed)
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, payload, got)
+}
+
+func TestRegistryEvictsOldDictionaries(t *testing.T) {
+	r := &registry{}
+	for i := 0; i < maxKnownDictionaries+2; i++ {
+		require.NoError(t, r.add(trainDictionary(t)))
+	}
+	assert.Len(t, r.state.Load().dicts, maxKnownDictionaries)
+	assert.Error(t, r.add([]byte("invalid")))
+}
+
+func TestRegistryKeepsOfferedDictionaries(t *testing.T) {
+	r := &registry{}
+	first := trainDictionary(t)
+	require.NoError(t, r.add(first))
+	for i := 0; i < maxKnownDictionaries-1; i++ {
+		require.NoError(t, r.add(trainDictionary(t)))
+	}
+	// The first dictionary offered again is evicted last.
+	require.NoError(t, r.add(first))
+	assert.Equal(t, first, r.state.Load().dicts[maxKnownDictionaries-1])
+	require.NoError(t, r.add(trainDictionary(t)))
+	assert.Contains(t, r.state.Load().dicts, first)
+}
+
+func TestRegistryRejectsLargeDictionaries(t *testing.T) {
+	r := &registry{}
+	dict := append(trainDictionary(t), make([]byte, MaxDictionarySize)...)
+	assert.ErrorIs(t, r.add(dict), errDictionaryTooLarge)
+	assert.Nil(t, r.state.Load())
+}
+
+func TestDecompressLimitsMemory(t *testing.T) {
+	enc, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	compressed := enc.EncodeAll(make([]byte, maxDecompressedSize+1), nil)
+
+	_, err = encoding.GetCompressor(Name).Decompress(bytes.NewReader(compressed))
+	assert.Error(t, err)
+}
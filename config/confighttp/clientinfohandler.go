@@ -19,24 +19,33 @@ type clientInfoHandler struct {
 
 	// include client metadata or not
 	includeMetadata bool
+
+	// spiffe is set if the certificates of the clients are verified with SPIFFE
+	spiffe bool
 }
 
 // ServeHTTP intercepts incoming HTTP requests, replacing the request's context with one that contains
 // a client.Info containing the client's IP address.
 func (h *clientInfoHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	req = req.WithContext(contextWithClient(req, h.includeMetadata))
+	req = req.WithContext(contextWithClient(req, h.includeMetadata, h.spiffe))
 	h.next.ServeHTTP(w, req)
 }
 
-// contextWithClient attempts to add the client IP address to the client.Info from the context. When no
-// client.Info exists in the context, one is created.
-func contextWithClient(req *http.Request, includeMetadata bool) context.Context {
+// contextWithClient attempts to add the client IP address and the identity verified with its TLS certificate
+// to the client.Info from the context. When no client.Info exists in the context, one is created. With spiffe,
+// the identity is taken from the certificate presented by the client, which the SPIFFE authorizer verified.
+func contextWithClient(req *http.Request, includeMetadata bool, spiffe bool) context.Context {
 	cl := client.FromContext(req.Context())
 
 	ip := parseIP(req.RemoteAddr)
 	if ip != nil {
 		cl.Addr = ip
 	}
+	if spiffe {
+		cl.Identity = client.IdentityFromPeerCertificate(req.TLS)
+	} else {
+		cl.Identity = client.IdentityFromTLS(req.TLS)
+	}
 
 	if includeMetadata {
 		md := req.Header.Clone()
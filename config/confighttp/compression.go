@@ -9,20 +9,58 @@ import (
 	"bytes"
 	"compress/gzip"
 	"compress/zlib"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
 
 	"go.opentelemetry.io/collector/config/configcompression"
 )
 
+const (
+	headerAcceptEncoding = "Accept-Encoding"
+
+	// zstdDefaultWindowSize is the window size used by default by the zstd encoders.
+	zstdDefaultWindowSize = 8 << 20
+)
+
+// supportedEncodings lists the content codings decompressed by the HTTP servers and clients,
+// including the ones of the registered codecs.
+func supportedEncodings() string {
+	encodings := []string{
+		string(configcompression.Gzip),
+		string(configcompression.Deflate),
+		string(configcompression.Zlib),
+		string(configcompression.Zstd),
+		string(configcompression.Snappy),
+		string(configcompression.Lz4),
+	}
+	for _, ct := range configcompression.RegisteredTypes() {
+		encodings = append(encodings, configcompression.ContentEncoding(ct))
+	}
+	return strings.Join(encodings, ", ")
+}
+
+type errUnsupportedEncoding string
+
+func (e errUnsupportedEncoding) Error() string {
+	return fmt.Sprintf("unsupported Content-Encoding: %q", string(e))
+}
+
 type compressRoundTripper struct {
 	rt              http.RoundTripper
 	compressionType configcompression.CompressionType
 	compressor      *compressor
 }
 
-func newCompressRoundTripper(rt http.RoundTripper, compressionType configcompression.CompressionType) (*compressRoundTripper, error) {
-	encoder, err := newCompressor(compressionType)
+func newCompressRoundTripper(rt http.RoundTripper, compressionType configcompression.CompressionType, levels CompressionLevels) (*compressRoundTripper, error) {
+	encoder, err := newCompressor(compressionType, levels)
 	if err != nil {
 		return nil, err
 	}
@@ -57,15 +95,85 @@ func (r *compressRoundTripper) RoundTrip(req *http.Request) (*http.Response, err
 
 	// Clone the headers and add the encoding header.
 	cReq.Header = req.Header.Clone()
-	cReq.Header.Add(headerContentEncoding, string(r.compressionType))
+	cReq.Header.Add(headerContentEncoding, configcompression.ContentEncoding(r.compressionType))
 
 	return r.rt.RoundTrip(cReq)
 }
 
+// decompressRoundTripper advertises the compression types accepted for the responses
+// in the "Accept-Encoding" header and decompresses the responses accordingly.
+type decompressRoundTripper struct {
+	rt             http.RoundTripper
+	acceptEncoding string
+}
+
+func newDecompressRoundTripper(rt http.RoundTripper, compressionTypes []configcompression.CompressionType) *decompressRoundTripper {
+	var codings []string
+	for _, ct := range compressionTypes {
+		if configcompression.IsCompressed(ct) {
+			codings = append(codings, configcompression.ContentEncoding(ct))
+		}
+	}
+	return &decompressRoundTripper{
+		rt:             rt,
+		acceptEncoding: strings.Join(codings, ", "),
+	}
+}
+
+func (r *decompressRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.acceptEncoding == "" || req.Header.Get(headerAcceptEncoding) != "" {
+		// The responses are left untouched if the caller negotiates the encoding on its own.
+		return r.rt.RoundTrip(req)
+	}
+
+	// Clone the request since the docs say that we cannot modify the "req"
+	// (see https://golang.org/pkg/net/http/#RoundTripper).
+	cReq := req.Clone(req.Context())
+	cReq.Header.Set(headerAcceptEncoding, r.acceptEncoding)
+
+	resp, err := r.rt.RoundTrip(cReq)
+	if err != nil {
+		return resp, err
+	}
+	body, err := newBodyReader(resp.Header.Get(headerContentEncoding), resp.Body, 0)
+	var unsupported errUnsupportedEncoding
+	if errors.As(err, &unsupported) {
+		// Leave the responses which were not compressed as requested to the caller.
+		return resp, nil
+	}
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	if body != nil {
+		resp.Body = &decompressedBody{ReadCloser: body, compressed: resp.Body}
+		resp.Header.Del(headerContentEncoding)
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+	}
+	return resp, nil
+}
+
+// decompressedBody closes the compressed body along with the decompressor reading from it.
+type decompressedBody struct {
+	io.ReadCloser
+	compressed io.Closer
+}
+
+func (b *decompressedBody) Close() error {
+	err := b.ReadCloser.Close()
+	if cerr := b.compressed.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
 type errorHandler func(w http.ResponseWriter, r *http.Request, errorMsg string, statusCode int)
 
 type decompressor struct {
 	errorHandler
+	maxBodySize int64
 }
 
 type decompressorOption func(d *decompressor)
@@ -76,10 +184,20 @@ func withErrorHandlerForDecompressor(e errorHandler) decompressorOption {
 	}
 }
 
+// withMaxBodySizeForDecompressor limits the size of the decompressed bodies, to protect
+// against decompression bombs.
+func withMaxBodySizeForDecompressor(maxBodySize int64) decompressorOption {
+	return func(d *decompressor) {
+		d.maxBodySize = maxBodySize
+	}
+}
+
 // httpContentDecompressor offloads the task of handling compressed HTTP requests
 // by identifying the compression format in the "Content-Encoding" header and re-writing
 // request body so that the handlers further in the chain can work on decompressed data.
-// It supports gzip and deflate/zlib compression.
+// It supports gzip, deflate/zlib, zstd, snappy and lz4 compression, and the registered codecs, the requests using any other
+// compression are rejected with the supported ones listed in the "Accept-Encoding" header.
+// The body is decompressed while it is read, reading more than the maximum body size fails.
 func httpContentDecompressor(h http.Handler, opts ...decompressorOption) http.Handler {
 	d := &decompressor{}
 	for _, o := range opts {
@@ -93,7 +211,13 @@ func httpContentDecompressor(h http.Handler, opts ...decompressorOption) http.Ha
 
 func (d *decompressor) wrap(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		newBody, err := newBodyReader(r)
+		newBody, err := newBodyReader(r.Header.Get(headerContentEncoding), r.Body, d.maxBodySize)
+		var unsupported errUnsupportedEncoding
+		if errors.As(err, &unsupported) {
+			w.Header().Set(headerAcceptEncoding, supportedEncodings())
+			d.errorHandler(w, r, err.Error(), http.StatusUnsupportedMediaType)
+			return
+		}
 		if err != nil {
 			d.errorHandler(w, r, err.Error(), http.StatusBadRequest)
 			return
@@ -107,30 +231,192 @@ func (d *decompressor) wrap(h http.Handler) http.Handler {
 			r.Header.Del("Content-Length")
 			r.ContentLength = -1
 			r.Body = newBody
+			if d.maxBodySize > 0 {
+				r.Body = http.MaxBytesReader(w, newBody, d.maxBodySize)
+			}
 		}
 		h.ServeHTTP(w, r)
 	})
 }
 
-func newBodyReader(r *http.Request) (io.ReadCloser, error) {
-	switch r.Header.Get("Content-Encoding") {
+// newBodyReader returns a reader decompressing the body according to its content encoding,
+// nil if the body is not compressed. The gzip and zstd readers read the concatenated streams
+// as a single body. If maxBodySize is positive, the window of the zstd decoder is limited to it,
+// or to the default window of the encoders if larger, instead of the one announced by the body.
+func newBodyReader(encoding string, body io.Reader, maxBodySize int64) (io.ReadCloser, error) {
+	switch encoding {
+	case "", "identity":
+		return nil, nil
 	case "gzip":
-		gr, err := gzip.NewReader(r.Body)
+		gr, err := gzip.NewReader(body)
 		if err != nil {
 			return nil, err
 		}
 		return gr, nil
 	case "deflate", "zlib":
-		zr, err := zlib.NewReader(r.Body)
+		zr, err := zlib.NewReader(body)
 		if err != nil {
 			return nil, err
 		}
 		return zr, nil
+	case "zstd":
+		opts := []zstd.DOption{zstd.WithDecoderConcurrency(1)}
+		if maxBodySize > 0 {
+			opts = append(opts, zstd.WithDecoderMaxWindow(uint64(maxInt64(maxBodySize, zstdDefaultWindowSize))))
+		}
+		zr, err := zstd.NewReader(body, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	case "snappy":
+		return io.NopCloser(snappy.NewReader(body)), nil
+	case "lz4":
+		return io.NopCloser(lz4.NewReader(body)), nil
+	}
+	if _, codec, ok := configcompression.LookupContentEncoding(encoding); ok {
+		return codec.NewReader(body)
+	}
+	return nil, errUnsupportedEncoding(encoding)
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
 	}
-	return nil, nil
+	return b
 }
 
 // defaultErrorHandler writes the error message in plain text.
 func defaultErrorHandler(w http.ResponseWriter, _ *http.Request, errMsg string, statusCode int) {
 	http.Error(w, errMsg, statusCode)
 }
+
+// httpContentCompressor compresses the responses with the first of the compression types, in order
+// of preference, accepted by the client in the "Accept-Encoding" header of the request.
+func httpContentCompressor(h http.Handler, compressionTypes []configcompression.CompressionType, levels CompressionLevels) (http.Handler, error) {
+	c := &contentCompressor{}
+	for _, ct := range compressionTypes {
+		if !configcompression.IsCompressed(ct) {
+			continue
+		}
+		comp, err := newCompressor(ct, levels)
+		if err != nil {
+			return nil, err
+		}
+		c.compressionTypes = append(c.compressionTypes, ct)
+		c.compressors = append(c.compressors, comp)
+	}
+	if len(c.compressors) == 0 {
+		return h, nil
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", headerAcceptEncoding)
+		compressionType, comp := c.negotiate(r.Header.Get(headerAcceptEncoding))
+		if comp == nil || r.Method == http.MethodHead {
+			h.ServeHTTP(w, r)
+			return
+		}
+		cw := &compressResponseWriter{ResponseWriter: w, compressionType: compressionType, compressor: comp}
+		defer cw.close()
+		h.ServeHTTP(cw, r)
+	}), nil
+}
+
+type contentCompressor struct {
+	compressionTypes []configcompression.CompressionType
+	compressors      []*compressor
+}
+
+// negotiate returns the preferred compression type accepted by the "Accept-Encoding" header,
+// nil if none of them is accepted.
+func (c *contentCompressor) negotiate(acceptEncoding string) (configcompression.CompressionType, *compressor) {
+	if acceptEncoding == "" {
+		return "", nil
+	}
+	accepted := parseAcceptEncoding(acceptEncoding)
+	for i, ct := range c.compressionTypes {
+		q, ok := accepted[configcompression.ContentEncoding(ct)]
+		if !ok {
+			q, ok = accepted["*"]
+		}
+		if ok && q > 0 {
+			return ct, c.compressors[i]
+		}
+	}
+	return "", nil
+}
+
+// parseAcceptEncoding returns the quality value of each content coding of the "Accept-Encoding" header.
+func parseAcceptEncoding(acceptEncoding string) map[string]float64 {
+	accepted := map[string]float64{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			k, v, ok := strings.Cut(param, "=")
+			if !ok || strings.TrimSpace(k) != "q" {
+				continue
+			}
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				q = f
+			}
+		}
+		accepted[coding] = q
+	}
+	return accepted
+}
+
+// compressResponseWriter compresses the body of the response, unless the handler already set its content encoding
+// or the status code does not allow a body.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	compressionType configcompression.CompressionType
+	compressor      *compressor
+
+	writer      io.WriteCloser
+	wroteHeader bool
+}
+
+func (w *compressResponseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		w.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+	w.wroteHeader = true
+	h := w.Header()
+	if h.Get(headerContentEncoding) == "" && statusCode >= http.StatusOK && statusCode != http.StatusNoContent && statusCode != http.StatusNotModified {
+		// The response is sent uncompressed if the writer of a registered codec cannot be created.
+		if writer, err := w.compressor.writer(w.ResponseWriter); err == nil {
+			h.Set(headerContentEncoding, configcompression.ContentEncoding(w.compressionType))
+			// The length of the compressed body is unknown.
+			h.Del("Content-Length")
+			w.writer = writer
+		}
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *compressResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.writer == nil {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.writer.Write(b)
+}
+
+// close flushes the compressed body and releases the compressor.
+func (w *compressResponseWriter) close() {
+	if w.writer == nil {
+		return
+	}
+	// Nothing we can do with the error if we cannot write to the response.
+	_ = w.writer.Close()
+	w.compressor.release(w.writer)
+}
@@ -5,23 +5,49 @@ package confighttp
 
 import (
 	"bytes"
+	"compress/flate"
 	"compress/gzip"
 	"compress/zlib"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/golang/snappy"
 	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/confmap"
 )
 
+// flateCompression is a compression type registered by the tests, identified by the "x-flate" content coding.
+const flateCompression configcompression.CompressionType = "flate"
+
+func init() {
+	err := configcompression.RegisterCodec(flateCompression, configcompression.Codec{
+		ContentEncoding: "x-flate",
+		MaxLevel:        flate.BestCompression,
+		NewWriter: func(w io.Writer, level int) (io.WriteCloser, error) {
+			if level == 0 {
+				level = flate.DefaultCompression
+			}
+			return flate.NewWriter(w, level)
+		},
+		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+			return flate.NewReader(r), nil
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
 func TestHTTPClientCompression(t *testing.T) {
 	testBody := []byte("uncompressed_text")
 	compressedGzipBody := compressGzip(t, testBody)
@@ -29,10 +55,12 @@ func TestHTTPClientCompression(t *testing.T) {
 	compressedDeflateBody := compressZlib(t, testBody)
 	compressedSnappyBody := compressSnappy(t, testBody)
 	compressedZstdBody := compressZstd(t, testBody)
+	compressedLz4Body := compressLz4(t, testBody)
 
 	tests := []struct {
 		name        string
 		encoding    configcompression.CompressionType
+		levels      CompressionLevels
 		reqBody     []byte
 		shouldError bool
 	}{
@@ -78,6 +106,44 @@ func TestHTTPClientCompression(t *testing.T) {
 			reqBody:     compressedZstdBody.Bytes(),
 			shouldError: false,
 		},
+		{
+			name:        "ValidLz4",
+			encoding:    configcompression.Lz4,
+			reqBody:     compressedLz4Body.Bytes(),
+			shouldError: false,
+		},
+		{
+			name:        "ValidGzipLevel",
+			encoding:    configcompression.Gzip,
+			levels:      CompressionLevels{Gzip: gzip.BestCompression},
+			reqBody:     compressGzipLevel(t, testBody, gzip.BestCompression).Bytes(),
+			shouldError: false,
+		},
+		{
+			name:        "InvalidGzipLevel",
+			encoding:    configcompression.Gzip,
+			levels:      CompressionLevels{Gzip: 10},
+			shouldError: true,
+		},
+		{
+			name:        "ValidRegistered",
+			encoding:    flateCompression,
+			reqBody:     compressFlate(t, testBody, flate.DefaultCompression).Bytes(),
+			shouldError: false,
+		},
+		{
+			name:        "ValidRegisteredLevel",
+			encoding:    flateCompression,
+			levels:      CompressionLevels{Registered: map[configcompression.CompressionType]int{flateCompression: flate.BestSpeed}},
+			reqBody:     compressFlate(t, testBody, flate.BestSpeed).Bytes(),
+			shouldError: false,
+		},
+		{
+			name:        "InvalidRegisteredLevel",
+			encoding:    flateCompression,
+			levels:      CompressionLevels{Registered: map[configcompression.CompressionType]int{flateCompression: 10}},
+			shouldError: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -85,6 +151,9 @@ func TestHTTPClientCompression(t *testing.T) {
 				body, err := io.ReadAll(r.Body)
 				require.NoError(t, err, "failed to read request body: %v", err)
 				assert.EqualValues(t, tt.reqBody, body)
+				if configcompression.IsCompressed(tt.encoding) {
+					assert.Equal(t, configcompression.ContentEncoding(tt.encoding), r.Header.Get("Content-Encoding"))
+				}
 				w.WriteHeader(200)
 			}))
 			t.Cleanup(srv.Close)
@@ -95,17 +164,18 @@ func TestHTTPClientCompression(t *testing.T) {
 			require.NoError(t, err, "failed to create request to test handler")
 
 			clientSettings := HTTPClientSettings{
-				Endpoint:    srv.URL,
-				Compression: tt.encoding,
+				Endpoint:          srv.URL,
+				Compression:       tt.encoding,
+				CompressionLevels: tt.levels,
 			}
 			client, err := clientSettings.ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
-			require.NoError(t, err)
-			res, err := client.Do(req)
 			if tt.shouldError {
 				assert.Error(t, err)
 				return
 			}
 			require.NoError(t, err)
+			res, err := client.Do(req)
+			require.NoError(t, err)
 
 			_, err = io.ReadAll(res.Body)
 			require.NoError(t, err)
@@ -141,6 +211,43 @@ func TestHTTPContentDecompressionHandler(t *testing.T) {
 			reqBody:  compressZlib(t, testBody),
 			respCode: 200,
 		},
+		{
+			name:     "ValidZstd",
+			encoding: "zstd",
+			reqBody:  compressZstd(t, testBody),
+			respCode: 200,
+		},
+		{
+			name:     "ValidSnappy",
+			encoding: "snappy",
+			reqBody:  compressSnappy(t, testBody),
+			respCode: 200,
+		},
+		{
+			name:     "ValidRegistered",
+			encoding: "x-flate",
+			reqBody:  compressFlate(t, testBody, flate.DefaultCompression),
+			respCode: 200,
+		},
+		{
+			name:     "ValidLz4",
+			encoding: "lz4",
+			reqBody:  compressLz4(t, testBody),
+			respCode: 200,
+		},
+		{
+			name:     "Identity",
+			encoding: "identity",
+			reqBody:  bytes.NewBuffer(testBody),
+			respCode: 200,
+		},
+		{
+			name:     "UnsupportedEncoding",
+			encoding: "br",
+			reqBody:  bytes.NewBuffer(testBody),
+			respCode: 415,
+			respBody: "unsupported Content-Encoding: \"br\"\n",
+		},
 		{
 			name:     "InvalidGzip",
 			encoding: "gzip",
@@ -175,6 +282,9 @@ func TestHTTPContentDecompressionHandler(t *testing.T) {
 			require.NoError(t, err)
 
 			assert.Equal(t, tt.respCode, res.StatusCode, "test handler returned unexpected status code ")
+			if tt.respCode == http.StatusUnsupportedMediaType {
+				assert.Equal(t, "gzip, deflate, zlib, zstd, snappy, lz4, x-flate", res.Header.Get("Accept-Encoding"))
+			}
 			if tt.respBody != "" {
 				body, err := io.ReadAll(res.Body)
 				require.NoError(t, res.Body.Close(), "failed to close request body: %v", err)
@@ -184,6 +294,322 @@ func TestHTTPContentDecompressionHandler(t *testing.T) {
 	}
 }
 
+func TestHTTPContentDecompressionConcatenatedStreams(t *testing.T) {
+	tests := []struct {
+		encoding string
+		compress func(t testing.TB, body []byte) *bytes.Buffer
+	}{
+		{encoding: "gzip", compress: compressGzip},
+		{encoding: "zstd", compress: compressZstd},
+	}
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			srv := httptest.NewServer(httpContentDecompressor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, "first_streamsecond_stream", string(body))
+			})))
+			t.Cleanup(srv.Close)
+
+			body := tt.compress(t, []byte("first_stream"))
+			_, err := body.ReadFrom(tt.compress(t, []byte("second_stream")))
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, srv.URL, body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Encoding", tt.encoding)
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+		})
+	}
+}
+
+func TestHTTPContentDecompressionMaxBodySize(t *testing.T) {
+	// The decompressed body is a thousand times larger than the compressed one.
+	bomb := bytes.Repeat([]byte{0}, 1024*1024)
+	tests := []struct {
+		encoding string
+		compress func(t testing.TB, body []byte) *bytes.Buffer
+	}{
+		{encoding: "gzip", compress: compressGzip},
+		{encoding: "zlib", compress: compressZlib},
+		{encoding: "zstd", compress: compressZstd},
+		{encoding: "snappy", compress: compressSnappy},
+		{encoding: "lz4", compress: compressLz4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			srv := httptest.NewServer(httpContentDecompressor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, err := io.ReadAll(r.Body)
+				var maxBytesErr *http.MaxBytesError
+				if !assert.ErrorAs(t, err, &maxBytesErr) {
+					return
+				}
+				assert.EqualValues(t, 64*1024, maxBytesErr.Limit)
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+			}), withMaxBodySizeForDecompressor(64*1024)))
+			t.Cleanup(srv.Close)
+
+			req, err := http.NewRequest(http.MethodPost, srv.URL, tt.compress(t, bomb))
+			require.NoError(t, err)
+			req.Header.Set("Content-Encoding", tt.encoding)
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+		})
+	}
+}
+
+func TestHTTPContentCompressionHandler(t *testing.T) {
+	testBody := []byte("uncompressed_text")
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		method         string
+		statusCode     int
+		wantEncoding   string
+	}{
+		{
+			name:           "NoAcceptEncoding",
+			acceptEncoding: "",
+			wantEncoding:   "",
+		},
+		{
+			name:           "PreferredEncoding",
+			acceptEncoding: "gzip, zstd",
+			wantEncoding:   "zstd",
+		},
+		{
+			name:           "AcceptedEncoding",
+			acceptEncoding: "gzip;q=0.5, br",
+			wantEncoding:   "gzip",
+		},
+		{
+			name:           "RefusedEncoding",
+			acceptEncoding: "zstd;q=0, *",
+			wantEncoding:   "gzip",
+		},
+		{
+			name:           "Wildcard",
+			acceptEncoding: "*",
+			wantEncoding:   "zstd",
+		},
+		{
+			name:           "UnsupportedEncoding",
+			acceptEncoding: "br",
+			wantEncoding:   "",
+		},
+		{
+			name:           "HeadRequest",
+			acceptEncoding: "zstd",
+			method:         http.MethodHead,
+			wantEncoding:   "",
+		},
+		{
+			name:           "NoContent",
+			acceptEncoding: "zstd",
+			statusCode:     http.StatusNoContent,
+			wantEncoding:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := httpContentCompressor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.statusCode != 0 {
+					w.WriteHeader(tt.statusCode)
+					return
+				}
+				_, err := w.Write(testBody)
+				assert.NoError(t, err)
+			}), []configcompression.CompressionType{configcompression.Zstd, configcompression.Gzip}, CompressionLevels{Zstd: 3})
+			require.NoError(t, err)
+			srv := httptest.NewServer(handler)
+			t.Cleanup(srv.Close)
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req, err := http.NewRequest(method, srv.URL, nil)
+			require.NoError(t, err)
+			// Setting the header prevents the transport from decompressing gzip responses on its own.
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			assert.Equal(t, tt.wantEncoding, res.Header.Get("Content-Encoding"))
+			assert.Equal(t, "Accept-Encoding", res.Header.Get("Vary"))
+			if method == http.MethodHead || tt.statusCode != 0 {
+				assert.Empty(t, body)
+				return
+			}
+			decoded, err := newBodyReader(tt.wantEncoding, bytes.NewReader(body), 0)
+			require.NoError(t, err)
+			if decoded != nil {
+				body, err = io.ReadAll(decoded)
+				require.NoError(t, err)
+				require.NoError(t, decoded.Close())
+			}
+			assert.Equal(t, testBody, body)
+		})
+	}
+}
+
+func TestHTTPContentCompressionHandlerInvalidLevel(t *testing.T) {
+	_, err := httpContentCompressor(http.NotFoundHandler(), []configcompression.CompressionType{configcompression.Lz4}, CompressionLevels{Lz4: 10})
+	assert.EqualError(t, err, "invalid lz4 compression level 10, must be between 1 and 9")
+}
+
+func TestHTTPResponseCompressionNegotiation(t *testing.T) {
+	testBody := []byte("uncompressed_text")
+	tests := []struct {
+		name           string
+		acceptEncoding []configcompression.CompressionType
+		wantEncoding   configcompression.CompressionType
+	}{
+		{
+			name:         "DefaultGzip",
+			wantEncoding: configcompression.Gzip,
+		},
+		{
+			name:           "Zstd",
+			acceptEncoding: []configcompression.CompressionType{configcompression.Zstd, configcompression.Gzip},
+			wantEncoding:   configcompression.Zstd,
+		},
+		{
+			name:           "Snappy",
+			acceptEncoding: []configcompression.CompressionType{configcompression.Snappy},
+			wantEncoding:   configcompression.Snappy,
+		},
+		{
+			name:           "Lz4",
+			acceptEncoding: []configcompression.CompressionType{configcompression.Lz4},
+			wantEncoding:   configcompression.Lz4,
+		},
+		{
+			name:           "None",
+			acceptEncoding: []configcompression.CompressionType{configcompression.Deflate},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ln, err := net.Listen("tcp", "localhost:0")
+			require.NoError(t, err)
+			hss := HTTPServerSettings{
+				ResponseCompression: []configcompression.CompressionType{
+					configcompression.Zstd,
+					configcompression.Snappy,
+					configcompression.Lz4,
+					configcompression.Gzip,
+				},
+			}
+			var gotEncoding string
+			srv, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, err := w.Write(testBody)
+				assert.NoError(t, err)
+				gotEncoding = w.Header().Get("Content-Encoding")
+			}))
+			require.NoError(t, err)
+			go func() {
+				_ = srv.Serve(ln)
+			}()
+			t.Cleanup(func() {
+				assert.NoError(t, srv.Close())
+			})
+
+			hcs := HTTPClientSettings{
+				Endpoint:       "http://" + ln.Addr().String(),
+				AcceptEncoding: tt.acceptEncoding,
+			}
+			client, err := hcs.ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			res, err := client.Get(hcs.Endpoint)
+			require.NoError(t, err)
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			assert.Equal(t, string(tt.wantEncoding), gotEncoding)
+			assert.Empty(t, res.Header.Get("Content-Encoding"))
+			assert.Equal(t, testBody, body)
+		})
+	}
+}
+
+func TestCompressionLevelsValidate(t *testing.T) {
+	levels := CompressionLevels{Gzip: 9, Zlib: 1, Zstd: 22, Lz4: 9}
+	assert.NoError(t, levels.Validate())
+	assert.NoError(t, (&CompressionLevels{}).Validate())
+	assert.EqualError(t, (&CompressionLevels{Gzip: 10}).Validate(), "invalid gzip compression level 10, must be between 1 and 9")
+	assert.EqualError(t, (&CompressionLevels{Zlib: -1}).Validate(), "invalid zlib compression level -1, must be between 1 and 9")
+	assert.EqualError(t, (&CompressionLevels{Zstd: 23}).Validate(), "invalid zstd compression level 23, must be between 1 and 22")
+	assert.EqualError(t, (&CompressionLevels{Lz4: 10}).Validate(), "invalid lz4 compression level 10, must be between 1 and 9")
+	assert.NoError(t, (&CompressionLevels{Registered: map[configcompression.CompressionType]int{flateCompression: 9}}).Validate())
+	assert.EqualError(t, (&CompressionLevels{Registered: map[configcompression.CompressionType]int{flateCompression: 10}}).Validate(),
+		"invalid flate compression level 10, must be between 1 and 9")
+	assert.EqualError(t, (&CompressionLevels{Registered: map[configcompression.CompressionType]int{"brotli": 5}}).Validate(),
+		`unsupported compression type "brotli"`)
+}
+
+func TestCompressionLevelsUnmarshal(t *testing.T) {
+	cm := confmap.NewFromStringMap(map[string]any{
+		"gzip":  6,
+		"flate": 1,
+	})
+	var levels CompressionLevels
+	require.NoError(t, cm.Unmarshal(&levels))
+	assert.Equal(t, CompressionLevels{
+		Gzip:       6,
+		Registered: map[configcompression.CompressionType]int{flateCompression: 1},
+	}, levels)
+
+	cm = confmap.NewFromStringMap(map[string]any{"brotli": 5})
+	assert.Error(t, cm.Unmarshal(&CompressionLevels{}))
+}
+
+func TestHTTPContentCompressionHandlerRegistered(t *testing.T) {
+	testBody := []byte("uncompressed_text")
+	handler, err := httpContentCompressor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write(testBody)
+		assert.NoError(t, err)
+	}), []configcompression.CompressionType{flateCompression, configcompression.Gzip},
+		CompressionLevels{Registered: map[configcompression.CompressionType]int{flateCompression: flate.BestCompression}})
+	require.NoError(t, err)
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	clientSettings := HTTPClientSettings{
+		Endpoint:       srv.URL,
+		AcceptEncoding: []configcompression.CompressionType{flateCompression},
+	}
+	client, err := clientSettings.ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	res, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	assert.True(t, res.Uncompressed)
+	assert.Equal(t, testBody, body)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept-Encoding", "x-flate")
+	res, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	body, err = io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	assert.Equal(t, "x-flate", res.Header.Get("Content-Encoding"))
+	assert.Equal(t, compressFlate(t, testBody, flate.BestCompression).Bytes(), body)
+}
+
 func TestHTTPContentCompressionRequestWithNilBody(t *testing.T) {
 	compressedGzipBody := compressGzip(t, []byte{})
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -198,7 +624,7 @@ func TestHTTPContentCompressionRequestWithNilBody(t *testing.T) {
 	require.NoError(t, err, "failed to create request to test handler")
 
 	client := http.Client{}
-	client.Transport, err = newCompressRoundTripper(http.DefaultTransport, configcompression.Gzip)
+	client.Transport, err = newCompressRoundTripper(http.DefaultTransport, configcompression.Gzip, CompressionLevels{})
 	require.NoError(t, err)
 	res, err := client.Do(req)
 	require.NoError(t, err)
@@ -229,7 +655,7 @@ func TestHTTPContentCompressionCopyError(t *testing.T) {
 	require.NoError(t, err)
 
 	client := http.Client{}
-	client.Transport, err = newCompressRoundTripper(http.DefaultTransport, configcompression.Gzip)
+	client.Transport, err = newCompressRoundTripper(http.DefaultTransport, configcompression.Gzip, CompressionLevels{})
 	require.NoError(t, err)
 	_, err = client.Do(req)
 	require.Error(t, err)
@@ -253,7 +679,7 @@ func TestHTTPContentCompressionRequestBodyCloseError(t *testing.T) {
 	require.NoError(t, err)
 
 	client := http.Client{}
-	client.Transport, err = newCompressRoundTripper(http.DefaultTransport, configcompression.Gzip)
+	client.Transport, err = newCompressRoundTripper(http.DefaultTransport, configcompression.Gzip, CompressionLevels{})
 	require.NoError(t, err)
 	_, err = client.Do(req)
 	require.Error(t, err)
@@ -268,6 +694,16 @@ func compressGzip(t testing.TB, body []byte) *bytes.Buffer {
 	return &buf
 }
 
+func compressGzipLevel(t testing.TB, body []byte, level int) *bytes.Buffer {
+	var buf bytes.Buffer
+	gw, err := gzip.NewWriterLevel(&buf, level)
+	require.NoError(t, err)
+	_, err = gw.Write(body)
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	return &buf
+}
+
 func compressZlib(t testing.TB, body []byte) *bytes.Buffer {
 	var buf bytes.Buffer
 	zw := zlib.NewWriter(&buf)
@@ -294,3 +730,22 @@ func compressZstd(t testing.TB, body []byte) *bytes.Buffer {
 	require.NoError(t, zw.Close())
 	return &buf
 }
+
+func compressLz4(t testing.TB, body []byte) *bytes.Buffer {
+	var buf bytes.Buffer
+	lw := lz4.NewWriter(&buf)
+	_, err := lw.Write(body)
+	require.NoError(t, err)
+	require.NoError(t, lw.Close())
+	return &buf
+}
+
+func compressFlate(t testing.TB, body []byte, level int) *bytes.Buffer {
+	var buf bytes.Buffer
+	fw, err := flate.NewWriter(&buf, level)
+	require.NoError(t, err)
+	_, err = fw.Write(body)
+	require.NoError(t, err)
+	require.NoError(t, fw.Close())
+	return &buf
+}
@@ -8,11 +8,13 @@ import (
 	"compress/gzip"
 	"compress/zlib"
 	"errors"
+	"fmt"
 	"io"
 	"sync"
 
 	"github.com/golang/snappy"
 	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
 
 	"go.opentelemetry.io/collector/config/configcompression"
 )
@@ -31,32 +33,160 @@ var (
 	zStdPool                    = &compressor{pool: sync.Pool{New: func() any { zw, _ := zstd.NewWriter(nil); return zw }}}
 	_          writeCloserReset = (*zlib.Writer)(nil)
 	zLibPool                    = &compressor{pool: sync.Pool{New: func() any { return zlib.NewWriter(nil) }}}
+	_          writeCloserReset = (*lz4.Writer)(nil)
+	lz4Pool                     = &compressor{pool: sync.Pool{New: func() any { return lz4.NewWriter(nil) }}}
 )
 
+var lz4Levels = [...]lz4.CompressionLevel{lz4.Level1, lz4.Level2, lz4.Level3, lz4.Level4, lz4.Level5, lz4.Level6, lz4.Level7, lz4.Level8, lz4.Level9}
+
+// CompressionLevels defines the compression level of each compression type.
+// The default level of a compression type is used when its level is 0.
+type CompressionLevels struct {
+	// Gzip is the gzip compression level, between 1 (best speed) and 9 (best compression).
+	Gzip int `mapstructure:"gzip"`
+
+	// Zlib is the zlib and deflate compression level, between 1 (best speed) and 9 (best compression).
+	Zlib int `mapstructure:"zlib"`
+
+	// Zstd is the zstd compression level, between 1 (best speed) and 22 (best compression),
+	// mapped to the closest level supported by the encoder.
+	Zstd int `mapstructure:"zstd"`
+
+	// Lz4 is the lz4 compression level, between 1 (best speed) and 9 (best compression).
+	Lz4 int `mapstructure:"lz4"`
+
+	// Registered is the compression level of each compression type registered with configcompression.RegisterCodec,
+	// between 1 and the maximum level of its codec.
+	Registered map[configcompression.CompressionType]int `mapstructure:",remain"`
+}
+
+// Validate checks if the compression levels are valid.
+func (cl *CompressionLevels) Validate() error {
+	if err := validateLevel(configcompression.Gzip, cl.Gzip, gzip.BestCompression); err != nil {
+		return err
+	}
+	if err := validateLevel(configcompression.Zlib, cl.Zlib, zlib.BestCompression); err != nil {
+		return err
+	}
+	if err := validateLevel(configcompression.Zstd, cl.Zstd, 22); err != nil {
+		return err
+	}
+	if err := validateLevel(configcompression.Lz4, cl.Lz4, len(lz4Levels)); err != nil {
+		return err
+	}
+	for compressionType, level := range cl.Registered {
+		codec, ok := configcompression.LookupCodec(compressionType)
+		if !ok {
+			return fmt.Errorf("unsupported compression type %q", compressionType)
+		}
+		if codec.MaxLevel == 0 {
+			return fmt.Errorf("compression type %q has no compression levels", compressionType)
+		}
+		if err := validateLevel(compressionType, level, codec.MaxLevel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateLevel(compressionType configcompression.CompressionType, level int, maxLevel int) error {
+	if level < 0 || level > maxLevel {
+		return fmt.Errorf("invalid %s compression level %d, must be between 1 and %d", compressionType, level, maxLevel)
+	}
+	return nil
+}
+
+// level returns the level configured for the compression type, 0 if the compression type has no levels.
+func (cl *CompressionLevels) level(compressionType configcompression.CompressionType) int {
+	switch compressionType {
+	case configcompression.Gzip:
+		return cl.Gzip
+	case configcompression.Zlib, configcompression.Deflate:
+		return cl.Zlib
+	case configcompression.Zstd:
+		return cl.Zstd
+	case configcompression.Lz4:
+		return cl.Lz4
+	}
+	return cl.Registered[compressionType]
+}
+
+// compressor provides the writers of a compression type. The writers of the built-in compression types
+// are pooled, the ones of the registered codecs are created for each body.
 type compressor struct {
-	pool sync.Pool
+	pool      sync.Pool
+	newWriter func(w io.Writer) (io.WriteCloser, error)
 }
 
-// writerFactory defines writer field in CompressRoundTripper.
-// The validity of input is already checked when NewCompressRoundTripper was called in confighttp,
-func newCompressor(compressionType configcompression.CompressionType) (*compressor, error) {
+// newCompressor returns the compressor of the compression type, using the configured level.
+// The compressors using the default levels are shared.
+func newCompressor(compressionType configcompression.CompressionType, levels CompressionLevels) (*compressor, error) {
+	if err := levels.Validate(); err != nil {
+		return nil, err
+	}
+	level := levels.level(compressionType)
 	switch compressionType {
 	case configcompression.Gzip:
-		return gZipPool, nil
+		if level == 0 {
+			return gZipPool, nil
+		}
+		return &compressor{pool: sync.Pool{New: func() any { gw, _ := gzip.NewWriterLevel(nil, level); return gw }}}, nil
 	case configcompression.Snappy:
 		return snappyPool, nil
 	case configcompression.Zstd:
-		return zStdPool, nil
+		if level == 0 {
+			return zStdPool, nil
+		}
+		encoderLevel := zstd.EncoderLevelFromZstd(level)
+		return &compressor{pool: sync.Pool{New: func() any {
+			zw, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(encoderLevel))
+			return zw
+		}}}, nil
 	case configcompression.Zlib, configcompression.Deflate:
-		return zLibPool, nil
+		if level == 0 {
+			return zLibPool, nil
+		}
+		return &compressor{pool: sync.Pool{New: func() any { zw, _ := zlib.NewWriterLevel(nil, level); return zw }}}, nil
+	case configcompression.Lz4:
+		if level == 0 {
+			return lz4Pool, nil
+		}
+		lz4Level := lz4Levels[level-1]
+		return &compressor{pool: sync.Pool{New: func() any {
+			lw := lz4.NewWriter(nil)
+			_ = lw.Apply(lz4.CompressionLevelOption(lz4Level))
+			return lw
+		}}}, nil
+	}
+	if codec, ok := configcompression.LookupCodec(compressionType); ok {
+		return &compressor{newWriter: func(w io.Writer) (io.WriteCloser, error) { return codec.NewWriter(w, level) }}, nil
 	}
 	return nil, errors.New("unsupported compression type, ")
 }
 
-func (p *compressor) compress(buf *bytes.Buffer, body io.ReadCloser) error {
+// writer returns a writer compressing into w, which must be released once closed.
+func (p *compressor) writer(w io.Writer) (io.WriteCloser, error) {
+	if p.newWriter != nil {
+		return p.newWriter(w)
+	}
 	writer := p.pool.Get().(writeCloserReset)
-	defer p.pool.Put(writer)
-	writer.Reset(buf)
+	writer.Reset(w)
+	return writer, nil
+}
+
+// release returns a closed writer to the pool.
+func (p *compressor) release(writer io.WriteCloser) {
+	if p.newWriter == nil {
+		p.pool.Put(writer)
+	}
+}
+
+func (p *compressor) compress(buf *bytes.Buffer, body io.ReadCloser) error {
+	writer, err := p.writer(buf)
+	if err != nil {
+		return err
+	}
+	defer p.release(writer)
 
 	if body != nil {
 		_, copyErr := io.Copy(writer, body)
@@ -4,6 +4,7 @@
 package confighttp
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 
@@ -26,11 +28,13 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configmiddleware"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/extension/auth"
 	"go.opentelemetry.io/collector/extension/auth/authtest"
+	"go.opentelemetry.io/collector/extension/middleware"
 )
 
 type customRoundTripper struct {
@@ -203,6 +207,53 @@ func TestDefaultHTTPClientSettings(t *testing.T) {
 	assert.EqualValues(t, 90*time.Second, *httpClientSettings.IdleConnTimeout)
 }
 
+func TestHTTPClientSettingsConnectionReuse(t *testing.T) {
+	forceAttemptHTTP2 := false
+	settings := HTTPClientSettings{
+		Endpoint:          "localhost:1234",
+		DisableKeepAlives: true,
+		ForceAttemptHTTP2: &forceAttemptHTTP2,
+	}
+	tt := componenttest.NewNopTelemetrySettings()
+	tt.TracerProvider = nil
+	client, err := settings.ToClient(componenttest.NewNopHost(), tt)
+	require.NoError(t, err)
+	transport := client.Transport.(*http.Transport)
+	assert.True(t, transport.DisableKeepAlives)
+	assert.False(t, transport.ForceAttemptHTTP2)
+
+	settings.HTTP2ReadIdleTimeout = time.Second
+	_, err = settings.ToClient(componenttest.NewNopHost(), tt)
+	assert.EqualError(t, err, "http2_read_idle_timeout cannot be used when force_attempt_http2 is false")
+}
+
+func TestHTTPClientSettingsHTTP2HealthCheck(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, r.Proto)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	settings := HTTPClientSettings{
+		Endpoint:             server.URL,
+		TLSSetting:           configtls.TLSClientSetting{InsecureSkipVerify: true},
+		HTTP2ReadIdleTimeout: 10 * time.Second,
+		HTTP2PingTimeout:     time.Second,
+	}
+	tt := componenttest.NewNopTelemetrySettings()
+	tt.TracerProvider = nil
+	client, err := settings.ToClient(componenttest.NewNopHost(), tt)
+	require.NoError(t, err)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "HTTP/2.0", string(body))
+}
+
 func TestHTTPClientSettingsError(t *testing.T) {
 	host := &mockHost{
 		ext: map[component.ID]component.Component{},
@@ -244,6 +295,13 @@ func TestHTTPClientSettingsError(t *testing.T) {
 				Auth:     &configauth.Authentication{AuthenticatorID: component.NewID("dummy")},
 			},
 		},
+		{
+			err: "^unsupported proxy_url scheme \"ftp\", must be one of http, https or socks5",
+			settings: HTTPClientSettings{
+				Endpoint: "https://localhost:1234/v1/traces",
+				ProxyURL: "ftp://proxy:21",
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.err, func(t *testing.T) {
@@ -434,6 +492,40 @@ func TestHTTPServerSettingsError(t *testing.T) {
 	}
 }
 
+func TestHTTPServerUnixSocket(t *testing.T) {
+	hss := &HTTPServerSettings{
+		Endpoint:  filepath.Join(t.TempDir(), "otlp.sock"),
+		Transport: "unix",
+	}
+	ln, err := hss.ToListener()
+	require.NoError(t, err)
+
+	s, err := hss.ToServer(
+		componenttest.NewNopHost(),
+		componenttest.NewNopTelemetrySettings(),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, errWrite := fmt.Fprint(w, "test")
+			assert.NoError(t, errWrite)
+		}))
+	require.NoError(t, err)
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	defer func() { assert.NoError(t, s.Close()) }()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", hss.Endpoint)
+		},
+	}}
+	resp, err := client.Get("http://localhost/")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, "test", string(body))
+}
+
 func TestHTTPServerWarning(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -452,6 +544,13 @@ func TestHTTPServerWarning(t *testing.T) {
 			},
 			len: 0,
 		},
+		{
+			settings: HTTPServerSettings{
+				Endpoint:  "0.0.0.0",
+				Transport: "unix",
+			},
+			len: 0,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -801,6 +900,47 @@ func TestHttpCorsWithSettings(t *testing.T) {
 	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
 }
 
+func TestHttpCorsRoutes(t *testing.T) {
+	hss := &HTTPServerSettings{
+		Endpoint: "localhost:0",
+		CORS: &CORSSettings{
+			AllowedOrigins: []string{"https://server.com"},
+			Routes: []CORSRoute{
+				{Path: "/v1", AllowedOrigins: []string{"https://v1.com"}},
+				{Path: "/v1/logs", AllowedOrigins: []string{"https://rum.com"}, MaxAge: 600, AllowCredentials: new(bool)},
+				{Path: "/v1/metrics"},
+			},
+		},
+	}
+
+	srv, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	require.NoError(t, err)
+
+	preflight := func(path string, origin string) *http.Response {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodOptions, path, nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		srv.Handler.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+
+	resp := preflight("/v1/logs", "https://rum.com")
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "https://rum.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "600", resp.Header.Get("Access-Control-Max-Age"))
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Credentials"))
+	assert.Empty(t, preflight("/v1/logs", "https://v1.com").Header.Get("Access-Control-Allow-Origin"))
+
+	resp = preflight("/v1/traces", "https://v1.com")
+	assert.Equal(t, "https://v1.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", resp.Header.Get("Access-Control-Allow-Credentials"))
+
+	// CORS is not enabled for a route without origins.
+	assert.Equal(t, http.StatusOK, preflight("/v1/metrics", "https://server.com").StatusCode)
+	assert.Equal(t, "https://server.com", preflight("/other", "https://server.com").Header.Get("Access-Control-Allow-Origin"))
+}
+
 func TestHttpServerHeaders(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -937,6 +1077,73 @@ func ExampleHTTPServerSettings() {
 	}
 }
 
+func TestTLSListenerClose(t *testing.T) {
+	hss := &HTTPServerSettings{
+		Endpoint: "localhost:0",
+		TLSSetting: &configtls.TLSServerSetting{
+			TLSSetting: configtls.TLSSetting{
+				CertFile:       filepath.Join("testdata", "server.crt"),
+				KeyFile:        filepath.Join("testdata", "server.key"),
+				ReloadOnChange: true,
+			},
+		},
+	}
+	ln, err := hss.ToListener()
+	require.NoError(t, err)
+	// Closing the listener also stops watching the TLS files.
+	assert.NoError(t, ln.Close())
+	assert.Error(t, ln.Close())
+}
+
+func TestClientIdentity(t *testing.T) {
+	hss := &HTTPServerSettings{
+		Endpoint: "localhost:0",
+		TLSSetting: &configtls.TLSServerSetting{
+			TLSSetting: configtls.TLSSetting{
+				CertFile: filepath.Join("testdata", "server.crt"),
+				KeyFile:  filepath.Join("testdata", "server.key"),
+			},
+			ClientCAFile: filepath.Join("testdata", "ca.crt"),
+		},
+	}
+	ln, err := hss.ToListener()
+	require.NoError(t, err)
+
+	identities := make(chan *client.Identity, 1)
+	s, err := hss.ToServer(
+		componenttest.NewNopHost(),
+		componenttest.NewNopTelemetrySettings(),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identities <- client.FromContext(r.Context()).Identity
+		}))
+	require.NoError(t, err)
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	t.Cleanup(func() { assert.NoError(t, s.Close()) })
+
+	hcs := &HTTPClientSettings{
+		Endpoint: "https://localhost:" + strconv.Itoa(ln.Addr().(*net.TCPAddr).Port),
+		TLSSetting: configtls.TLSClientSetting{
+			TLSSetting: configtls.TLSSetting{
+				CAFile:   filepath.Join("testdata", "ca.crt"),
+				CertFile: filepath.Join("testdata", "client.crt"),
+				KeyFile:  filepath.Join("testdata", "client.key"),
+			},
+		},
+	}
+	c, err := hcs.ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	resp, err := c.Get(hcs.Endpoint)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	identity := <-identities
+	require.NotNil(t, identity)
+	assert.Equal(t, "MyCommonName", identity.CommonName)
+	assert.Equal(t, []string{"localhost"}, identity.DNSNames)
+}
+
 func TestHttpClientHeaders(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -976,6 +1183,54 @@ func TestHttpClientHeaders(t *testing.T) {
 	}
 }
 
+func TestHttpClientProxy(t *testing.T) {
+	var gotRequestURI string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequestURI = r.RequestURI
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	tests := []struct {
+		name           string
+		noProxy        string
+		wantRequestURI string
+		wantErr        bool
+	}{
+		{
+			name:           "proxy",
+			wantRequestURI: "http://backend.invalid/v1/traces",
+		},
+		{
+			name:    "no_proxy",
+			noProxy: "backend.invalid",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRequestURI = ""
+			setting := HTTPClientSettings{
+				Endpoint: "http://backend.invalid/v1/traces",
+				ProxyURL: proxy.URL,
+				NoProxy:  tt.noProxy,
+				Timeout:  time.Second,
+			}
+			client, err := setting.ToClient(componenttest.NewNopHost(), component.TelemetrySettings{})
+			require.NoError(t, err)
+			resp, err := client.Get(setting.Endpoint)
+			if tt.wantErr {
+				// The request is sent directly to the backend, which doesn't exist.
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.NoError(t, resp.Body.Close())
+			}
+			assert.Equal(t, tt.wantRequestURI, gotRequestURI)
+		})
+	}
+}
+
 func TestContextWithClient(t *testing.T) {
 	testCases := []struct {
 		desc       string
@@ -1031,12 +1286,120 @@ func TestContextWithClient(t *testing.T) {
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
-			ctx := contextWithClient(tC.input, tC.doMetadata)
+			ctx := contextWithClient(tC.input, tC.doMetadata, false)
 			assert.Equal(t, tC.expected, client.FromContext(ctx))
 		})
 	}
 }
 
+func TestServerMaxRequestBodySize(t *testing.T) {
+	tests := []struct {
+		name               string
+		maxRequestBodySize int64
+		bodySize           int
+		encoding           string
+		tooLarge           bool
+	}{
+		{
+			name:     "default",
+			bodySize: defaultMaxRequestBodySize,
+		},
+		{
+			name:     "default too large",
+			bodySize: defaultMaxRequestBodySize + 1,
+			tooLarge: true,
+		},
+		{
+			name:               "configured too large",
+			maxRequestBodySize: 1024,
+			bodySize:           1025,
+			tooLarge:           true,
+		},
+		{
+			name:     "decompressed default too large",
+			bodySize: defaultMaxRequestBodySize + 1,
+			encoding: "gzip",
+			tooLarge: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hss := HTTPServerSettings{MaxRequestBodySize: tt.maxRequestBodySize}
+			var readErr error
+			srv, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, readErr = io.ReadAll(r.Body)
+			}))
+			require.NoError(t, err)
+
+			body := bytes.Repeat([]byte{'a'}, tt.bodySize)
+			if tt.encoding != "" {
+				body = compressGzip(t, body).Bytes()
+			}
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+			if tt.encoding != "" {
+				req.Header.Set("Content-Encoding", tt.encoding)
+			}
+			srv.Handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			var maxBytesErr *http.MaxBytesError
+			assert.Equal(t, tt.tooLarge, errors.As(readErr, &maxBytesErr))
+		})
+	}
+}
+
+func TestServerMiddlewares(t *testing.T) {
+	var calls []string
+	record := func(name string, status int) middleware.WrapHTTPHandlerFunc {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, name)
+				if r.Header.Get("X-Tenant") == name {
+					w.WriteHeader(status)
+					return
+				}
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			component.NewID("logger"): middleware.NewServer(middleware.WithWrapHTTPHandler(record("logger", http.StatusOK))),
+			component.NewID("quota"):  middleware.NewServer(middleware.WithWrapHTTPHandler(record("quota", http.StatusTooManyRequests))),
+		},
+	}
+	hss := HTTPServerSettings{
+		Middlewares: []configmiddleware.Middleware{
+			{MiddlewareID: component.NewID("logger")},
+			{MiddlewareID: component.NewID("quota")},
+		},
+	}
+	srv, err := hss.ToServer(host, componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "handler")
+	}))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"logger", "quota", "handler"}, calls)
+
+	calls = nil
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("X-Tenant", "quota")
+	srv.Handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, []string{"logger", "quota"}, calls)
+}
+
+func TestServerMiddlewareNotFound(t *testing.T) {
+	hss := HTTPServerSettings{
+		Middlewares: []configmiddleware.Middleware{{MiddlewareID: component.NewID("logger")}},
+	}
+	_, err := hss.ToServer(&mockHost{}, componenttest.NewNopTelemetrySettings(), http.NotFoundHandler())
+	assert.EqualError(t, err, `failed to resolve middleware "logger": middleware not found`)
+}
+
 func TestServerAuth(t *testing.T) {
 	// prepare
 	authCalled := false
@@ -1116,6 +1479,48 @@ func TestFailedServerAuth(t *testing.T) {
 	assert.Equal(t, response.Result().Status, fmt.Sprintf("%v %s", http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized)))
 }
 
+func TestServerAuthRequiredScopes(t *testing.T) {
+	hss := HTTPServerSettings{
+		Endpoint: "localhost:0",
+		Auth: &configauth.Authentication{
+			AuthenticatorID: component.NewID("mock"),
+			RequiredScopes:  []string{"traces:write"},
+		},
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			component.NewID("mock"): auth.NewServer(
+				auth.WithServerAuthenticateClaims(func(ctx context.Context, headers map[string][]string) (context.Context, *client.Claims, error) {
+					return ctx, &client.Claims{Tenant: headers["Tenant"][0], Scopes: headers["Scope"]}, nil
+				}),
+			),
+		},
+	}
+	var tenant string
+	srv, err := hss.ToServer(host, componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant = client.FromContext(r.Context()).Claims.Tenant
+	}))
+	require.NoError(t, err)
+
+	// test
+	granted := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Tenant", "tenant-a")
+	req.Header.Set("Scope", "traces:write")
+	srv.Handler.ServeHTTP(granted, req)
+
+	denied := httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Tenant", "tenant-b")
+	req.Header.Set("Scope", "metrics:write")
+	srv.Handler.ServeHTTP(denied, req)
+
+	// verify
+	assert.Equal(t, http.StatusOK, granted.Code)
+	assert.Equal(t, "tenant-a", tenant)
+	assert.Equal(t, http.StatusForbidden, denied.Code)
+}
+
 type mockHost struct {
 	component.Host
 	ext map[component.ID]component.Component
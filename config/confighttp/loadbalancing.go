@@ -0,0 +1,470 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/config/internal"
+)
+
+const (
+	defaultDNSRefreshInterval  = 30 * time.Second
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultHealthCheckPath     = "/"
+)
+
+// LoadBalancingSettings balances the requests among several endpoints.
+type LoadBalancingSettings struct {
+	// Endpoints are the URLs of the endpoints the requests are balanced among, after the endpoint of the client.
+	// Only their scheme and host are used, the requests keep their path. The host names of the endpoints are
+	// resolved, the requests are balanced among their addresses.
+	Endpoints []string `mapstructure:"endpoints"`
+
+	// Policy balances the requests among the addresses of the endpoints, among "failover", the default,
+	// "round_robin" and "least_pending". A request which fails to be sent to an address is sent to the next one,
+	// and the address is skipped until it is healthy again.
+	Policy string `mapstructure:"policy"`
+
+	// DNSRefreshInterval is the interval at which the host names of the endpoints are resolved again, 30s if 0.
+	DNSRefreshInterval time.Duration `mapstructure:"dns_refresh_interval"`
+
+	// HealthCheck enables the health checking of the addresses with a GET request,
+	// the requests are not sent to the unhealthy addresses.
+	HealthCheck *HealthCheckSettings `mapstructure:"health_check"`
+}
+
+// HealthCheckSettings defines the health checking of the addresses of the endpoints.
+type HealthCheckSettings struct {
+	// Path is the path of the GET request checking the health of an address, which is healthy if the
+	// response has a 2xx status, "/" if empty.
+	Path string `mapstructure:"path"`
+
+	// Interval is the interval between the health checks, 10s if 0.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// Validate checks if the load balancing settings are valid.
+func (lbs *LoadBalancingSettings) Validate() error {
+	if err := internal.ValidateLoadBalancingPolicy(lbs.Policy); err != nil {
+		return err
+	}
+	for _, endpoint := range lbs.Endpoints {
+		if _, err := parseBalancedEndpoint(endpoint); err != nil {
+			return err
+		}
+	}
+	if lbs.DNSRefreshInterval < 0 {
+		return errors.New("dns_refresh_interval must not be negative")
+	}
+	if lbs.HealthCheck != nil && lbs.HealthCheck.Interval < 0 {
+		return errors.New("the interval of the health check must not be negative")
+	}
+	return nil
+}
+
+// balancedEndpoint is the scheme and the "host:port" of an endpoint.
+type balancedEndpoint struct {
+	scheme string
+	host   string
+}
+
+func parseBalancedEndpoint(endpoint string) (balancedEndpoint, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return balancedEndpoint{}, fmt.Errorf("invalid endpoint %q, must be an http or https URL", endpoint)
+	}
+	host := u.Host
+	if u.Port() == "" {
+		port := "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+		host = net.JoinHostPort(u.Hostname(), port)
+	}
+	return balancedEndpoint{scheme: u.Scheme, host: host}, nil
+}
+
+// balancedTarget is an address of an endpoint the requests are sent to.
+type balancedTarget struct {
+	endpoint  balancedEndpoint
+	address   string
+	transport *http.Transport
+	pending   atomic.Int64
+	// unhealthy is set when the health check of the address failed, or when a request failed to be sent to
+	// the address until it is health checked again.
+	unhealthy atomic.Bool
+	// ejectedUntil is the time in Unix nanoseconds until which the address is skipped after a request failed
+	// to be sent to it, when the addresses are not health checked.
+	ejectedUntil atomic.Int64
+	// removed is set once the address is not an address of the endpoints anymore, the connections of its
+	// transport are then closed once idle.
+	removed atomic.Bool
+}
+
+func (t *balancedTarget) healthy(now time.Time) bool {
+	return !t.unhealthy.Load() && now.UnixNano() >= t.ejectedUntil.Load()
+}
+
+// balancingRoundTripper sends the requests to the endpoints to the addresses of the endpoints, according to
+// the policy. The host names of the endpoints are resolved again and the addresses are health checked while
+// the requests are sent.
+type balancingRoundTripper struct {
+	hcs       *HTTPClientSettings
+	tlsCfg    *tls.Config
+	endpoints []balancedEndpoint
+	// hosts are the hosts of the requests sent to the endpoints, the other requests are sent with transport.
+	hosts     map[string]bool
+	transport *http.Transport
+
+	policy          string
+	refreshInterval time.Duration
+	healthCheck     *HealthCheckSettings
+	next            atomic.Uint64
+
+	// resolved is closed once the host names of the endpoints were resolved for the first time.
+	resolved chan struct{}
+
+	mu          sync.Mutex
+	targets     []*balancedTarget
+	resolvedAt  time.Time
+	checkedAt   time.Time
+	refreshing  bool
+	checking    bool
+	initialized bool
+}
+
+var _ http.RoundTripper = (*balancingRoundTripper)(nil)
+
+func (hcs *HTTPClientSettings) newBalancingRoundTripper(transport *http.Transport, tlsCfg *tls.Config) (*balancingRoundTripper, error) {
+	lbs := hcs.LoadBalancing
+	if err := lbs.Validate(); err != nil {
+		return nil, err
+	}
+	rt := &balancingRoundTripper{
+		hcs:             hcs,
+		tlsCfg:          tlsCfg,
+		hosts:           map[string]bool{},
+		transport:       transport,
+		policy:          lbs.Policy,
+		refreshInterval: lbs.DNSRefreshInterval,
+		healthCheck:     lbs.HealthCheck,
+		resolved:        make(chan struct{}),
+	}
+	if rt.refreshInterval == 0 {
+		rt.refreshInterval = defaultDNSRefreshInterval
+	}
+	endpoints := lbs.Endpoints
+	if hcs.Endpoint != "" {
+		endpoints = append([]string{hcs.Endpoint}, endpoints...)
+	}
+	if len(endpoints) == 0 {
+		return nil, errors.New("load_balancing requires at least one endpoint")
+	}
+	for _, endpoint := range endpoints {
+		be, err := parseBalancedEndpoint(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		rt.endpoints = append(rt.endpoints, be)
+		u, _ := url.Parse(endpoint)
+		rt.hosts[u.Host] = true
+	}
+	return rt, nil
+}
+
+// RoundTrip sends the request to the addresses of the endpoints in the order of the policy, until one of them
+// responds. The request is only sent to the next address if its body can be sent again.
+func (rt *balancingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !rt.hosts[req.URL.Host] {
+		return rt.transport.RoundTrip(req)
+	}
+	targets := rt.pick(req.Context())
+	if len(targets) == 0 {
+		// The request was canceled while the host names of the endpoints were resolved for the first time.
+		return nil, req.Context().Err()
+	}
+	var errs error
+	for i, target := range targets {
+		if i > 0 {
+			if req.Body != nil && req.Body != http.NoBody {
+				if req.GetBody == nil {
+					break
+				}
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, multierr.Append(errs, err)
+				}
+				req.Body = body
+			}
+		}
+		resp, err := rt.send(req, target)
+		if err == nil {
+			return resp, nil
+		}
+		errs = multierr.Append(errs, err)
+		if req.Context().Err() != nil {
+			break
+		}
+	}
+	return nil, errs
+}
+
+func (rt *balancingRoundTripper) send(req *http.Request, target *balancedTarget) (*http.Response, error) {
+	outReq := req.Clone(req.Context())
+	outReq.URL.Scheme = target.endpoint.scheme
+	outReq.URL.Host = target.address
+	outReq.Host = target.endpoint.host
+	if req.Host != "" && req.Host != req.URL.Host {
+		outReq.Host = req.Host
+	}
+
+	target.pending.Add(1)
+	resp, err := target.transport.RoundTrip(outReq)
+	target.pending.Add(-1)
+	if err != nil {
+		switch {
+		case req.Context().Err() != nil:
+		case rt.healthCheck != nil:
+			target.unhealthy.Store(true)
+		default:
+			target.ejectedUntil.Store(time.Now().Add(rt.refreshInterval).UnixNano())
+		}
+		return nil, err
+	}
+	if target.transport != rt.transport {
+		resp.Body = &targetBody{ReadCloser: resp.Body, target: target}
+	}
+	return resp, nil
+}
+
+// targetBody closes the idle connections of the transport of the address once the body of the response is
+// closed, if the address was removed while the response was read.
+type targetBody struct {
+	io.ReadCloser
+	target *balancedTarget
+}
+
+func (b *targetBody) Close() error {
+	err := b.ReadCloser.Close()
+	if b.target.removed.Load() {
+		b.target.transport.CloseIdleConnections()
+	}
+	return err
+}
+
+// pick returns the addresses the request is sent to, in the order of the policy. The healthy addresses are
+// returned, or all of them if none is healthy.
+func (rt *balancingRoundTripper) pick(ctx context.Context) []*balancedTarget {
+	targets := rt.currentTargets(ctx)
+	now := time.Now()
+	healthy := make([]*balancedTarget, 0, len(targets))
+	for _, target := range targets {
+		if target.healthy(now) {
+			healthy = append(healthy, target)
+		}
+	}
+	if len(healthy) == 0 {
+		healthy = append(healthy, targets...)
+	}
+	if len(healthy) < 2 || rt.policy == "" || rt.policy == internal.PolicyFailover {
+		return healthy
+	}
+
+	// The addresses are rotated, so that the requests are spread among the addresses with the fewest
+	// pending requests.
+	start := int(rt.next.Add(1) % uint64(len(healthy)))
+	healthy = append(healthy[start:], healthy[:start]...)
+	if rt.policy == internal.PolicyLeastPending {
+		sort.SliceStable(healthy, func(i, j int) bool {
+			return healthy[i].pending.Load() < healthy[j].pending.Load()
+		})
+	}
+	return healthy
+}
+
+// currentTargets returns the addresses of the endpoints, which are resolved in the background on the first
+// request. The requests wait for the first resolution, until they are canceled. The host names are resolved
+// again and the addresses are health checked in the background once their interval elapsed.
+func (rt *balancingRoundTripper) currentTargets(ctx context.Context) []*balancedTarget {
+	rt.mu.Lock()
+	if !rt.initialized {
+		rt.initialized = true
+		rt.refreshing = true
+		go rt.refresh()
+	}
+	rt.mu.Unlock()
+	select {
+	case <-rt.resolved:
+	case <-ctx.Done():
+		return nil
+	}
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	now := time.Now()
+	if !rt.refreshing && now.Sub(rt.resolvedAt) >= rt.refreshInterval {
+		rt.refreshing = true
+		go rt.refresh()
+	}
+	if rt.healthCheck != nil && !rt.checking {
+		interval := rt.healthCheck.Interval
+		if interval == 0 {
+			interval = defaultHealthCheckInterval
+		}
+		if rt.checkedAt.IsZero() || now.Sub(rt.checkedAt) >= interval {
+			rt.checking = true
+			go rt.check(rt.targets)
+		}
+	}
+	return rt.targets
+}
+
+// resolve returns the addresses of the endpoints, keeping the state of the previous targets.
+func (rt *balancingRoundTripper) resolve(ctx context.Context, previous []*balancedTarget) []*balancedTarget {
+	hosts := make([]string, len(rt.endpoints))
+	for i, endpoint := range rt.endpoints {
+		hosts[i] = endpoint.host
+	}
+	existing := map[string]*balancedTarget{}
+	for _, target := range previous {
+		existing[target.endpoint.scheme+"://"+target.address] = target
+	}
+
+	var targets []*balancedTarget
+	for _, address := range internal.ResolveEndpoints(ctx, hosts) {
+		endpoint := rt.endpoints[address.Endpoint]
+		if target, ok := existing[endpoint.scheme+"://"+address.Address]; ok {
+			targets = append(targets, target)
+			continue
+		}
+		transport := rt.transport
+		if address.Address != endpoint.host {
+			// The address is an IP address, the certificate of the server is verified against its host name.
+			var err error
+			if transport, err = rt.hcs.toTransport(rt.serverTLSConfig(address.ServerName)); err != nil {
+				transport = rt.transport
+			}
+		}
+		targets = append(targets, &balancedTarget{endpoint: endpoint, address: address.Address, transport: transport})
+	}
+	return targets
+}
+
+func (rt *balancingRoundTripper) serverTLSConfig(serverName string) *tls.Config {
+	var tlsCfg *tls.Config
+	if rt.tlsCfg != nil {
+		tlsCfg = rt.tlsCfg.Clone()
+	} else {
+		tlsCfg = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if tlsCfg.ServerName == "" {
+		tlsCfg.ServerName = serverName
+	}
+	return tlsCfg
+}
+
+func (rt *balancingRoundTripper) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), rt.refreshInterval)
+	defer cancel()
+	rt.mu.Lock()
+	previous := rt.targets
+	rt.mu.Unlock()
+
+	targets := rt.resolve(ctx, previous)
+
+	rt.mu.Lock()
+	rt.targets = targets
+	rt.resolvedAt = time.Now()
+	rt.refreshing = false
+	select {
+	case <-rt.resolved:
+	default:
+		close(rt.resolved)
+	}
+	rt.mu.Unlock()
+
+	rt.closeRemoved(previous, targets)
+}
+
+// closeRemoved closes the connections of the transports of the addresses which were removed. The connections
+// still used by a request are closed once its response is read.
+func (rt *balancingRoundTripper) closeRemoved(previous, targets []*balancedTarget) {
+	kept := map[*balancedTarget]bool{}
+	for _, target := range targets {
+		kept[target] = true
+	}
+	for _, target := range previous {
+		if !kept[target] && target.transport != rt.transport {
+			target.removed.Store(true)
+			target.transport.CloseIdleConnections()
+		}
+	}
+}
+
+// check health checks the addresses with a GET request.
+func (rt *balancingRoundTripper) check(targets []*balancedTarget) {
+	path := rt.healthCheck.Path
+	if path == "" {
+		path = defaultHealthCheckPath
+	}
+	var wg sync.WaitGroup
+	for _, target := range targets {
+		wg.Add(1)
+		go func(target *balancedTarget) {
+			defer wg.Done()
+			target.unhealthy.Store(!rt.checkTarget(target, path))
+		}(target)
+	}
+	wg.Wait()
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.checkedAt = time.Now()
+	rt.checking = false
+}
+
+func (rt *balancingRoundTripper) checkTarget(target *balancedTarget, path string) bool {
+	timeout := rt.healthCheck.Interval
+	if timeout == 0 {
+		timeout = defaultHealthCheckInterval
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.endpoint.scheme+"://"+target.address+path, nil)
+	if err != nil {
+		return false
+	}
+	req.Host = target.endpoint.host
+	resp, err := target.transport.RoundTrip(req)
+	if err != nil {
+		return false
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	return resp.StatusCode >= 200 && resp.StatusCode < 300
+}
+
+// CloseIdleConnections closes the idle connections to the addresses of the endpoints.
+func (rt *balancingRoundTripper) CloseIdleConnections() {
+	rt.transport.CloseIdleConnections()
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	for _, target := range rt.targets {
+		target.transport.CloseIdleConnections()
+	}
+}
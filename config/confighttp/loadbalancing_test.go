@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/internal"
+)
+
+// countingServer counts the requests it receives and records the host and the body of the last one.
+type countingServer struct {
+	*httptest.Server
+	requests atomic.Int64
+	healthy  atomic.Bool
+	host     atomic.Value
+	body     atomic.Value
+}
+
+func startCountingServer(t *testing.T) *countingServer {
+	cs := &countingServer{}
+	cs.healthy.Store(true)
+	cs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			if !cs.healthy.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		cs.requests.Add(1)
+		cs.host.Store(r.Host)
+		cs.body.Store(string(body))
+	}))
+	t.Cleanup(cs.Close)
+	return cs
+}
+
+func sendRequests(t *testing.T, hcs *HTTPClientSettings, url string, requests int) {
+	client, err := hcs.ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	for i := 0; i < requests; i++ {
+		resp, err := client.Post(url, "text/plain", bytes.NewBufferString("payload"))
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		require.NoError(t, resp.Body.Close())
+	}
+}
+
+func TestLoadBalancingValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		settings    LoadBalancingSettings
+		expectedErr string
+	}{
+		{
+			name:     "default",
+			settings: LoadBalancingSettings{Endpoints: []string{"https://localhost:4318"}},
+		},
+		{
+			name:        "unsupported policy",
+			settings:    LoadBalancingSettings{Policy: "random"},
+			expectedErr: `unsupported load balancing policy "random", must be one of "failover", "round_robin" or "least_pending"`,
+		},
+		{
+			name:        "invalid endpoint",
+			settings:    LoadBalancingSettings{Endpoints: []string{"localhost:4318"}},
+			expectedErr: `invalid endpoint "localhost:4318", must be an http or https URL`,
+		},
+		{
+			name:        "negative refresh interval",
+			settings:    LoadBalancingSettings{DNSRefreshInterval: -time.Second},
+			expectedErr: "dns_refresh_interval must not be negative",
+		},
+		{
+			name:        "negative health check interval",
+			settings:    LoadBalancingSettings{HealthCheck: &HealthCheckSettings{Interval: -time.Second}},
+			expectedErr: "the interval of the health check must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestLoadBalancingNoEndpoint(t *testing.T) {
+	_, err := (&HTTPClientSettings{LoadBalancing: &LoadBalancingSettings{}}).ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	assert.EqualError(t, err, "load_balancing requires at least one endpoint")
+}
+
+func TestLoadBalancingFailover(t *testing.T) {
+	first := startCountingServer(t)
+	second := startCountingServer(t)
+	hcs := &HTTPClientSettings{
+		Endpoint:      first.URL,
+		LoadBalancing: &LoadBalancingSettings{Endpoints: []string{second.URL}},
+	}
+
+	sendRequests(t, hcs, first.URL+"/v1/traces", 2)
+	assert.EqualValues(t, 2, first.requests.Load())
+	assert.Zero(t, second.requests.Load())
+
+	// The requests are sent to the second endpoint, with their body, once the first one is unavailable.
+	first.Close()
+	sendRequests(t, hcs, first.URL+"/v1/traces", 2)
+	assert.EqualValues(t, 2, second.requests.Load())
+	assert.Equal(t, "payload", second.body.Load())
+	assert.Equal(t, strings.TrimPrefix(second.URL, "http://"), second.host.Load())
+}
+
+func TestLoadBalancingRoundRobin(t *testing.T) {
+	first := startCountingServer(t)
+	second := startCountingServer(t)
+	sendRequests(t, &HTTPClientSettings{
+		Endpoint:      first.URL,
+		LoadBalancing: &LoadBalancingSettings{Endpoints: []string{second.URL}, Policy: internal.PolicyRoundRobin},
+	}, first.URL+"/v1/traces", 4)
+	assert.EqualValues(t, 2, first.requests.Load())
+	assert.EqualValues(t, 2, second.requests.Load())
+}
+
+func TestLoadBalancingLeastPending(t *testing.T) {
+	first := startCountingServer(t)
+	second := startCountingServer(t)
+	sendRequests(t, &HTTPClientSettings{
+		Endpoint:      first.URL,
+		LoadBalancing: &LoadBalancingSettings{Endpoints: []string{second.URL}, Policy: internal.PolicyLeastPending},
+	}, first.URL+"/v1/traces", 4)
+	// The addresses have no pending request, the requests are spread among them.
+	assert.EqualValues(t, 2, first.requests.Load())
+	assert.EqualValues(t, 2, second.requests.Load())
+}
+
+func TestLoadBalancingHealthCheck(t *testing.T) {
+	first := startCountingServer(t)
+	second := startCountingServer(t)
+	first.healthy.Store(false)
+	client, err := (&HTTPClientSettings{
+		Endpoint: first.URL,
+		LoadBalancing: &LoadBalancingSettings{
+			Endpoints:   []string{second.URL},
+			HealthCheck: &HealthCheckSettings{Path: "/health", Interval: time.Millisecond},
+		},
+	}).ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	// The first endpoint is skipped once it is health checked.
+	assert.Eventually(t, func() bool {
+		resp, err := client.Post(first.URL+"/v1/traces", "text/plain", bytes.NewBufferString("payload"))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return second.requests.Load() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestLoadBalancingResolvesHostNames(t *testing.T) {
+	server := startCountingServer(t)
+	lookupHost := internal.LookupHost
+	internal.LookupHost = func(_ context.Context, host string) ([]string, error) {
+		assert.Equal(t, "backend.test", host)
+		return []string{"127.0.0.1"}, nil
+	}
+	t.Cleanup(func() { internal.LookupHost = lookupHost })
+
+	port := server.URL[strings.LastIndex(server.URL, ":")+1:]
+	endpoint := "http://backend.test:" + port
+	sendRequests(t, &HTTPClientSettings{
+		Endpoint:      endpoint,
+		LoadBalancing: &LoadBalancingSettings{},
+	}, endpoint+"/v1/traces", 1)
+	assert.EqualValues(t, 1, server.requests.Load())
+	assert.Equal(t, "backend.test:"+port, server.host.Load())
+}
+
+func TestLoadBalancingFirstResolutionCanceled(t *testing.T) {
+	server := startCountingServer(t)
+	release := make(chan struct{})
+	lookupHost := internal.LookupHost
+	internal.LookupHost = func(context.Context, string) ([]string, error) {
+		<-release
+		return []string{"127.0.0.1"}, nil
+	}
+	t.Cleanup(func() { internal.LookupHost = lookupHost })
+
+	port := server.URL[strings.LastIndex(server.URL, ":")+1:]
+	endpoint := "http://backend.test:" + port
+	client, err := (&HTTPClientSettings{
+		Endpoint:      endpoint,
+		LoadBalancing: &LoadBalancingSettings{},
+	}).ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	// The request waiting for the first resolution is canceled.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/v1/traces", bytes.NewBufferString("payload"))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	if err == nil {
+		require.NoError(t, resp.Body.Close())
+	}
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	resp, err = client.Post(endpoint+"/v1/traces", "text/plain", bytes.NewBufferString("payload"))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.EqualValues(t, 1, server.requests.Load())
+}
+
+func TestLoadBalancingRefreshRemovesAddresses(t *testing.T) {
+	var ips atomic.Value
+	ips.Store([]string{"127.0.0.1"})
+	lookupHost := internal.LookupHost
+	internal.LookupHost = func(context.Context, string) ([]string, error) {
+		return ips.Load().([]string), nil
+	}
+	t.Cleanup(func() { internal.LookupHost = lookupHost })
+
+	hcs := &HTTPClientSettings{Endpoint: "http://backend.test:4318", LoadBalancing: &LoadBalancingSettings{}}
+	transport, err := hcs.toTransport(nil)
+	require.NoError(t, err)
+	rt, err := hcs.newBalancingRoundTripper(transport, nil)
+	require.NoError(t, err)
+
+	previous := rt.currentTargets(context.Background())
+	require.Len(t, previous, 1)
+	assert.Equal(t, "127.0.0.1:4318", previous[0].address)
+
+	ips.Store([]string{"127.0.0.2"})
+	rt.refresh()
+	targets := rt.currentTargets(context.Background())
+	require.Len(t, targets, 1)
+	assert.Equal(t, "127.0.0.2:4318", targets[0].address)
+	assert.True(t, previous[0].removed.Load())
+	assert.False(t, targets[0].removed.Load())
+}
+
+func TestLoadBalancingOtherHosts(t *testing.T) {
+	balanced := startCountingServer(t)
+	other := startCountingServer(t)
+	sendRequests(t, &HTTPClientSettings{
+		Endpoint:      balanced.URL,
+		LoadBalancing: &LoadBalancingSettings{},
+	}, other.URL+"/v1/traces", 1)
+	assert.Zero(t, balanced.requests.Load())
+	assert.EqualValues(t, 1, other.requests.Load())
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package configmiddleware implements the configuration settings of the
+// middlewares handling the requests received by the servers of the receivers.
+package configmiddleware // import "go.opentelemetry.io/collector/config/configmiddleware"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/middleware"
+)
+
+var (
+	errMiddlewareNotFound = errors.New("middleware not found")
+	errNotServer          = errors.New("requested extension is not a server middleware")
+)
+
+// Middleware defines a middleware handling the requests of a server.
+type Middleware struct {
+	// MiddlewareID specifies the name of the extension handling the requests.
+	MiddlewareID component.ID `mapstructure:"id"`
+}
+
+// GetServerMiddleware attempts to select the appropriate middleware.Server from the list of extensions,
+// based on the requested extension name. If a middleware is not found, an error is returned.
+func (m Middleware) GetServerMiddleware(extensions map[component.ID]component.Component) (middleware.Server, error) {
+	if ext, found := extensions[m.MiddlewareID]; found {
+		if server, ok := ext.(middleware.Server); ok {
+			return server, nil
+		}
+		return nil, errNotServer
+	}
+
+	return nil, fmt.Errorf("failed to resolve middleware %q: %w", m.MiddlewareID, errMiddlewareNotFound)
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configmiddleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/middleware"
+)
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func TestGetServerMiddleware(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		extension extension.Extension
+		expected  error
+	}{
+		{
+			desc:      "obtain server middleware",
+			extension: middleware.NewServer(),
+			expected:  nil,
+		},
+		{
+			desc:      "not a server middleware",
+			extension: &nopExtension{},
+			expected:  errNotServer,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			// prepare
+			cfg := &Middleware{
+				MiddlewareID: component.NewID("mock"),
+			}
+			ext := map[component.ID]component.Component{
+				component.NewID("mock"): tC.extension,
+			}
+
+			server, err := cfg.GetServerMiddleware(ext)
+
+			// verify
+			if tC.expected != nil {
+				assert.ErrorIs(t, err, tC.expected)
+				assert.Nil(t, server)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, server)
+			}
+		})
+	}
+}
+
+func TestGetServerMiddlewareFails(t *testing.T) {
+	cfg := &Middleware{
+		MiddlewareID: component.NewID("does-not-exist"),
+	}
+
+	server, err := cfg.GetServerMiddleware(map[component.ID]component.Component{})
+	assert.ErrorIs(t, err, errMiddlewareNotFound)
+	assert.Nil(t, server)
+}
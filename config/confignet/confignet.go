@@ -4,9 +4,18 @@
 package confignet // import "go.opentelemetry.io/collector/config/confignet"
 
 import (
+	"errors"
+	"io/fs"
 	"net"
+	"os"
+	"syscall"
 )
 
+// TransportNamedPipe is the transport of the Windows named pipes, whose endpoint has the form `\\.\pipe\name`.
+const TransportNamedPipe = "npipe"
+
+var errNamedPipeUnsupported = errors.New("the npipe transport is only supported on Windows")
+
 // NetAddr represents a network endpoint address.
 type NetAddr struct {
 	// Endpoint configures the address for this network connection.
@@ -17,20 +26,58 @@ type NetAddr struct {
 	Endpoint string `mapstructure:"endpoint"`
 
 	// Transport to use. Known protocols are "tcp", "tcp4" (IPv4-only), "tcp6" (IPv6-only), "udp", "udp4" (IPv4-only),
-	// "udp6" (IPv6-only), "ip", "ip4" (IPv4-only), "ip6" (IPv6-only), "unix", "unixgram" and "unixpacket".
+	// "udp6" (IPv6-only), "ip", "ip4" (IPv4-only), "ip6" (IPv6-only), "unix", "unixgram", "unixpacket" and "npipe"
+	// (Windows named pipe). For the unix and npipe transports the endpoint is the path of the socket or of the pipe.
 	Transport string `mapstructure:"transport"`
 }
 
 // Dial equivalent with net.Dial for this address.
 func (na *NetAddr) Dial() (net.Conn, error) {
+	if na.Transport == TransportNamedPipe {
+		return dialNamedPipe(na.Endpoint)
+	}
 	return net.Dial(na.Transport, na.Endpoint)
 }
 
 // Listen equivalent with net.Listen for this address.
+// The unix socket left over by a previous run of the collector at the endpoint, if any, is removed
+// when nothing listens on it anymore.
 func (na *NetAddr) Listen() (net.Listener, error) {
+	switch na.Transport {
+	case TransportNamedPipe:
+		return listenNamedPipe(na.Endpoint)
+	case "unix", "unixpacket":
+		if err := removeUnixSocket(na.Transport, na.Endpoint); err != nil {
+			return nil, err
+		}
+	}
 	return net.Listen(na.Transport, na.Endpoint)
 }
 
+// removeUnixSocket removes the unix socket at path if no process listens on it anymore, that is if
+// connecting to it is refused. Sockets in use and other kinds of files are kept, so that they are not
+// deleted by a misconfigured endpoint and net.Listen reports the address as already in use.
+func removeUnixSocket(transport, path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&fs.ModeSocket == 0 {
+		return nil
+	}
+	conn, err := net.Dial(transport, path)
+	if err == nil {
+		return conn.Close()
+	}
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		return nil
+	}
+	return os.Remove(path)
+}
+
 // TCPAddr represents a TCP endpoint address.
 type TCPAddr struct {
 	// Endpoint configures the address for this network connection.
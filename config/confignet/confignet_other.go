@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"net"
+)
+
+func dialNamedPipe(string) (net.Conn, error) {
+	return nil, errNamedPipeUnsupported
+}
+
+func listenNamedPipe(string) (net.Listener, error) {
+	return nil, errNamedPipeUnsupported
+}
@@ -0,0 +1,20 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package confignet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamedPipeUnsupported(t *testing.T) {
+	na := &NetAddr{Endpoint: `\\.\pipe\otlp`, Transport: TransportNamedPipe}
+	_, err := na.Listen()
+	assert.ErrorIs(t, err, errNamedPipeUnsupported)
+	_, err = na.Dial()
+	assert.ErrorIs(t, err, errNamedPipeUnsupported)
+}
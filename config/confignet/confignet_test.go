@@ -5,9 +5,12 @@ package confignet
 
 import (
 	"net"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNetAddr(t *testing.T) {
@@ -77,3 +80,74 @@ func TestTcpAddr(t *testing.T) {
 	<-done
 	assert.NoError(t, ln.Close())
 }
+
+func TestNetAddrUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "otlp.sock")
+	na := &NetAddr{Endpoint: path, Transport: "unix"}
+
+	// A socket left over by a previous run does not prevent listening.
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	ln, err := na.Listen()
+	require.NoError(t, err)
+	done := make(chan bool, 1)
+	go func() {
+		conn, errGo := ln.Accept()
+		assert.NoError(t, errGo)
+		buf := make([]byte, 10)
+		var numChr int
+		numChr, errGo = conn.Read(buf)
+		assert.NoError(t, errGo)
+		assert.Equal(t, "test", string(buf[:numChr]))
+		assert.NoError(t, conn.Close())
+		done <- true
+	}()
+
+	conn, err := na.Dial()
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("test"))
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Close())
+	<-done
+	assert.NoError(t, ln.Close())
+	assert.NoFileExists(t, path)
+}
+
+func TestNetAddrUnixKeepsSocketInUse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "otlp.sock")
+	na := &NetAddr{Endpoint: path, Transport: "unix"}
+
+	ln, err := na.Listen()
+	require.NoError(t, err)
+	go func() {
+		for {
+			conn, errGo := ln.Accept()
+			if errGo != nil {
+				return
+			}
+			assert.NoError(t, conn.Close())
+		}
+	}()
+
+	_, err = na.Listen()
+	assert.Error(t, err)
+	assert.FileExists(t, path)
+
+	// The socket still accepts connections.
+	conn, err := na.Dial()
+	require.NoError(t, err)
+	assert.NoError(t, conn.Close())
+	assert.NoError(t, ln.Close())
+}
+
+func TestNetAddrUnixKeepsOtherFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "otlp.sock")
+	require.NoError(t, os.WriteFile(path, []byte("data"), 0600))
+
+	_, err := (&NetAddr{Endpoint: path, Transport: "unix"}).Listen()
+	assert.Error(t, err)
+	assert.FileExists(t, path)
+}
@@ -0,0 +1,20 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"net"
+
+	"github.com/Microsoft/go-winio"
+)
+
+func dialNamedPipe(path string) (net.Conn, error) {
+	return winio.DialPipe(path, nil)
+}
+
+func listenNamedPipe(path string) (net.Listener, error) {
+	return winio.ListenPipe(path, nil)
+}
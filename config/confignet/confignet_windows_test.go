@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package confignet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetAddrNamedPipe(t *testing.T) {
+	na := &NetAddr{Endpoint: `\\.\pipe\otelcol-confignet-test`, Transport: TransportNamedPipe}
+	ln, err := na.Listen()
+	require.NoError(t, err)
+	done := make(chan bool, 1)
+	go func() {
+		conn, errGo := ln.Accept()
+		assert.NoError(t, errGo)
+		buf := make([]byte, 10)
+		var numChr int
+		numChr, errGo = conn.Read(buf)
+		assert.NoError(t, errGo)
+		assert.Equal(t, "test", string(buf[:numChr]))
+		assert.NoError(t, conn.Close())
+		done <- true
+	}()
+
+	conn, err := na.Dial()
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("test"))
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Close())
+	<-done
+	assert.NoError(t, ln.Close())
+}
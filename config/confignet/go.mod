@@ -2,10 +2,16 @@ module go.opentelemetry.io/collector/config/confignet
 
 go 1.19
 
-require github.com/stretchr/testify v1.8.4
+require (
+	github.com/Microsoft/go-winio v0.6.1
+	github.com/stretchr/testify v1.8.4
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/mod v0.8.0 // indirect
+	golang.org/x/sys v0.9.0 // indirect
+	golang.org/x/tools v0.6.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
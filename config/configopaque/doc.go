@@ -6,5 +6,6 @@
 // opaque string as `[REDACTED]`.
 //
 // This ensure that no sensitive information is leaked when printing the
-// full Collector configurations.
+// full Collector configurations, or when the value is formatted with the fmt
+// package or logged, e.g. in an error message.
 package configopaque // import "go.opentelemetry.io/collector/config/configopaque"
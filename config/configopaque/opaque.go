@@ -5,16 +5,46 @@ package configopaque // import "go.opentelemetry.io/collector/config/configopaqu
 
 import (
 	"encoding"
+	"fmt"
+	"io"
 )
 
-// String alias that is marshaled in an opaque way.
+// String alias that is marshaled and printed in an opaque way.
 type String string
 
 const maskedString = "[REDACTED]"
 
-var _ encoding.TextMarshaler = String("")
+var (
+	_ encoding.TextMarshaler   = String("")
+	_ encoding.BinaryMarshaler = String("")
+	_ fmt.Stringer             = String("")
+	_ fmt.GoStringer           = String("")
+	_ fmt.Formatter            = String("")
+)
 
 // MarshalText marshals the string as `[REDACTED]`.
 func (s String) MarshalText() ([]byte, error) {
 	return []byte(maskedString), nil
 }
+
+// MarshalBinary marshals the string as `[REDACTED]`.
+func (s String) MarshalBinary() ([]byte, error) {
+	return s.MarshalText()
+}
+
+// String formats the string as `[REDACTED]`, so that it is masked in the logs
+// and in the error messages. Use a conversion to string to get the value.
+func (s String) String() string {
+	return maskedString
+}
+
+// GoString formats the string as `[REDACTED]`, for the %#v verb.
+func (s String) GoString() string {
+	return maskedString
+}
+
+// Format formats the string as `[REDACTED]` for all the verbs, including
+// the ones which are not valid for strings and would print the value.
+func (s String) Format(f fmt.State, _ rune) {
+	_, _ = io.WriteString(f, maskedString)
+}
@@ -1,9 +1,11 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package configopaque // import "go.opentelemetry.io/collector/config/configopaque"
+package configopaque
 
 import (
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -18,3 +20,51 @@ func TestStringMarshalText(t *testing.T) {
 		assert.Equal(t, "[REDACTED]", string(opaque))
 	}
 }
+
+func TestStringMarshalBinary(t *testing.T) {
+	opaque, err := String("opaque").MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, "[REDACTED]", string(opaque))
+}
+
+func TestStringFormat(t *testing.T) {
+	s := String("opaque")
+	assert.Equal(t, "[REDACTED]", s.String())
+	assert.Equal(t, "[REDACTED]", fmt.Sprint(s))
+	assert.Equal(t, "[REDACTED]", fmt.Sprintf("%#v", s))
+	assert.Equal(t, "[REDACTED]", fmt.Sprintf("%d", s))
+	assert.Equal(t, `{Token:[REDACTED]}`, fmt.Sprintf("%+v", struct{ Token String }{Token: s}))
+	assert.Equal(t, "invalid token [REDACTED]", fmt.Errorf("invalid token %v", s).Error())
+	assert.Equal(t, "opaque", string(s))
+}
+
+type opaqueStruct struct {
+	Token   String
+	Headers map[string]String
+	Ptr     *String
+}
+
+// FuzzStringFormat checks that the output of the formatting and marshaling functions
+// does not depend on the value, so that no secret can leak through them.
+func FuzzStringFormat(f *testing.F) {
+	for _, seed := range []string{"", "s", "opaque", "[REDACTED]", "%v", "\x00\xff", "5b52"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, value string) {
+		secret := String(value)
+		masked := String("other")
+		for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x", "%X", "%d", "%10.3s"} {
+			assert.Equal(t, fmt.Sprintf(verb, masked), fmt.Sprintf(verb, secret), verb)
+			assert.Equal(t,
+				fmt.Sprintf(verb, opaqueStruct{Token: masked, Headers: map[string]String{"key": masked}}),
+				fmt.Sprintf(verb, opaqueStruct{Token: secret, Headers: map[string]String{"key": secret}}), verb)
+		}
+		assert.Equal(t, fmt.Errorf("invalid %v: %w", masked, assert.AnError).Error(), fmt.Errorf("invalid %v: %w", secret, assert.AnError).Error())
+
+		secretJSON, err := json.Marshal(opaqueStruct{Token: secret, Ptr: &secret})
+		require.NoError(t, err)
+		maskedJSON, err := json.Marshal(opaqueStruct{Token: masked, Ptr: &masked})
+		require.NoError(t, err)
+		assert.Equal(t, string(maskedJSON), string(secretJSON))
+	})
+}
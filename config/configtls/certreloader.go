@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configtls // import "go.opentelemetry.io/collector/config/configtls"
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// certReloader is a wrapper object for certificate reloading
+// Its GetCertificate method will either return the current certificate or reload from disk
+// if the last reload happened more than ReloadInterval ago.
+// The certificate, key and CA files are also reloaded as soon as they are modified once
+// the reloader is watching them.
+type certReloader struct {
+	nextReload      time.Time
+	cert            *tls.Certificate
+	caPool          *x509.CertPool
+	lastReloadError error
+	lock            sync.RWMutex
+	tls             TLSSetting
+	watcher         *fsnotify.Watcher
+}
+
+func (c TLSSetting) newCertReloader(caPool *x509.CertPool) (*certReloader, error) {
+	cert, err := c.loadCertificate()
+	if err != nil {
+		return nil, err
+	}
+	return &certReloader{
+		tls:        c,
+		nextReload: time.Now().Add(c.ReloadInterval),
+		cert:       &cert,
+		caPool:     caPool,
+	}, nil
+}
+
+func (r *certReloader) GetCertificate() (*tls.Certificate, error) {
+	if err := r.reloadIfExpired(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.cert, nil
+}
+
+func (r *certReloader) getCAPool() (*x509.CertPool, error) {
+	if err := r.reloadIfExpired(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.caPool, nil
+}
+
+// reloadIfExpired reloads the files if the last reload happened more than ReloadInterval ago.
+func (r *certReloader) reloadIfExpired() error {
+	if r.tls.ReloadInterval == 0 {
+		return nil
+	}
+	now := time.Now()
+	// Read locking here before we do the time comparison
+	// If a reload is in progress this will block and we will skip reloading in the current
+	// call once we can continue
+	r.lock.RLock()
+	expired := r.nextReload.Before(now)
+	r.lock.RUnlock()
+	if !expired {
+		return nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.nextReload.Before(now) {
+		// Reloaded by a concurrent call.
+		return nil
+	}
+	if err := r.reloadLocked(); err != nil {
+		return err
+	}
+	r.nextReload = now.Add(r.tls.ReloadInterval)
+	return nil
+}
+
+// reload reloads the files after a modification, the current certificate and CAs are kept on failure.
+func (r *certReloader) reload() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.lastReloadError = r.reloadLocked()
+	if r.lastReloadError == nil {
+		r.nextReload = time.Now().Add(r.tls.ReloadInterval)
+	}
+}
+
+func (r *certReloader) reloadLocked() error {
+	var cert *tls.Certificate
+	if r.tls.hasCertFile() || r.tls.hasKeyFile() {
+		loaded, err := r.tls.loadCertificate()
+		if err != nil {
+			return fmt.Errorf("failed to load TLS cert and key: %w", err)
+		}
+		cert = &loaded
+	}
+	caPool := r.caPool
+	if r.tls.reloadsCA() {
+		var err error
+		if caPool, err = r.tls.loadCACertPool(); err != nil {
+			return err
+		}
+	}
+	if cert != nil {
+		r.cert = cert
+	}
+	r.caPool = caPool
+	return nil
+}
+
+func (r *certReloader) getLastError() error {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.lastReloadError
+}
+
+// files returns the certificate, key and CA files which are reloaded.
+func (r *certReloader) files() []string {
+	var files []string
+	if r.tls.hasCertFile() {
+		files = append(files, r.tls.CertFile)
+	}
+	if r.tls.hasKeyFile() {
+		files = append(files, r.tls.KeyFile)
+	}
+	if r.tls.hasCAFile() {
+		files = append(files, r.tls.CAFile)
+	}
+	return files
+}
+
+// startWatching reloads the files as soon as they are modified. The directories of the files are
+// watched, so that the files replaced by a rename, as done for the Kubernetes secrets, are reloaded.
+func (r *certReloader) startWatching() error {
+	files := r.files()
+	if len(files) == 0 {
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create watcher to reload TLS cert, key and CA files: %w", err)
+	}
+	watched := map[string]bool{}
+	for _, file := range files {
+		watched[filepath.Clean(file)] = true
+		if err = watcher.Add(filepath.Dir(file)); err != nil {
+			_ = watcher.Close()
+			return fmt.Errorf("failed to add the directory of %s to watcher: %w", file, err)
+		}
+	}
+	r.watcher = watcher
+
+	go r.handleWatcherEvents(watched)
+	return nil
+}
+
+func (r *certReloader) handleWatcherEvents(watched map[string]bool) {
+	for {
+		select {
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			// The Kubernetes secrets and configmaps replace a symlink to a directory,
+			// any change in the directories is considered.
+			if event.Op == fsnotify.Chmod && !watched[filepath.Clean(event.Name)] {
+				continue
+			}
+			r.reload()
+		case _, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+func (r *certReloader) stopWatching() error {
+	if r.watcher == nil {
+		return errors.New("TLS files watcher is not running")
+	}
+	return r.watcher.Close()
+}
+
+// verifyServerCertificate returns the function verifying the server certificate chain with
+// the current CAs, and its name with the server name override or the name of the dialed host.
+func (r *certReloader) verifyServerCertificate(serverName string) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return errors.New("tls: the server did not provide a certificate")
+		}
+		name := serverName
+		if name == "" {
+			name = cs.ServerName
+		}
+		if name == "" {
+			// The server name is not sent for the IP addresses, which must be set as server name override.
+			return errors.New("tls: the server name is unknown, server_name_override must be set to verify the server certificate with reloaded CAs")
+		}
+		caPool, err := r.getCAPool()
+		if err != nil {
+			return err
+		}
+		opts := x509.VerifyOptions{
+			Roots:         caPool,
+			DNSName:       name,
+			Intermediates: x509.NewCertPool(),
+		}
+		for _, cert := range cs.PeerCertificates[1:] {
+			opts.Intermediates.AddCert(cert)
+		}
+		_, err = cs.PeerCertificates[0].Verify(opts)
+		return err
+	}
+}
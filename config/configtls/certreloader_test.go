@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configtls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func copyFile(t *testing.T, src, dst string) {
+	data, err := os.ReadFile(src)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(dst, data, 0600))
+}
+
+// replaceFile replaces the file by a rename, as done for the Kubernetes secrets.
+func replaceFile(t *testing.T, src, dst string) {
+	tmp := dst + ".tmp"
+	copyFile(t, src, tmp)
+	require.NoError(t, os.Rename(tmp, dst))
+}
+
+func certDNSName(t *testing.T, cfg *tls.Config) string {
+	cert, err := cfg.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	pCert, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	return pCert.DNSNames[0]
+}
+
+func TestCertReloaderReloadOnChange(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	copyFile(t, filepath.Join("testdata", "client-1.crt"), certFile)
+	copyFile(t, filepath.Join("testdata", "client-1.key"), keyFile)
+
+	options := TLSSetting{
+		CertFile:       certFile,
+		KeyFile:        keyFile,
+		ReloadOnChange: true,
+	}
+	cfg, reloader, err := options.loadTLSConfigAndReloader()
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, reloader.stopWatching()) }()
+	assert.Equal(t, "example1", certDNSName(t, cfg))
+
+	// The files are modified.
+	copyFile(t, filepath.Join("testdata", "client-2.crt"), certFile)
+	copyFile(t, filepath.Join("testdata", "client-2.key"), keyFile)
+	assert.Eventually(t, func() bool {
+		return certDNSName(t, cfg) == "example2" && reloader.getLastError() == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The files are replaced.
+	replaceFile(t, filepath.Join("testdata", "client-1.key"), keyFile)
+	replaceFile(t, filepath.Join("testdata", "client-1.crt"), certFile)
+	assert.Eventually(t, func() bool {
+		return certDNSName(t, cfg) == "example1" && reloader.getLastError() == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The current certificate is kept when the files are invalid.
+	copyFile(t, filepath.Join("testdata", "testCA-bad.txt"), certFile)
+	assert.Eventually(t, func() bool {
+		return reloader.getLastError() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "example1", certDNSName(t, cfg))
+}
+
+func TestCertReloaderStopWatching(t *testing.T) {
+	reloader, err := TLSSetting{}.newCertReloader(nil)
+	require.NoError(t, err)
+	// There are no files to watch.
+	require.NoError(t, reloader.startWatching())
+	assert.EqualError(t, reloader.stopWatching(), "TLS files watcher is not running")
+}
+
+func TestLoadTLSConfigWithShutdown(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	copyFile(t, filepath.Join("testdata", "client-1.crt"), certFile)
+	copyFile(t, filepath.Join("testdata", "client-1.key"), keyFile)
+
+	server := TLSServerSetting{
+		TLSSetting: TLSSetting{
+			CertFile:       certFile,
+			KeyFile:        keyFile,
+			ReloadOnChange: true,
+		},
+		ClientCAFile: filepath.Join("testdata", "ca-1.crt"),
+	}
+	cfg, shutdown, err := server.LoadTLSConfigWithShutdown()
+	require.NoError(t, err)
+	assert.Equal(t, "example1", certDNSName(t, cfg))
+	require.NoError(t, shutdown())
+
+	// The files are not watched anymore.
+	copyFile(t, filepath.Join("testdata", "client-2.crt"), certFile)
+	copyFile(t, filepath.Join("testdata", "client-2.key"), keyFile)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, "example1", certDNSName(t, cfg))
+
+	client := TLSClientSetting{
+		TLSSetting: TLSSetting{
+			CertFile:       certFile,
+			KeyFile:        keyFile,
+			ReloadOnChange: true,
+		},
+	}
+	_, shutdown, err = client.LoadTLSConfigWithShutdown()
+	require.NoError(t, err)
+	require.NoError(t, shutdown())
+
+	// Nothing is watched without reload_on_change.
+	client.ReloadOnChange = false
+	_, shutdown, err = client.LoadTLSConfigWithShutdown()
+	require.NoError(t, err)
+	assert.NoError(t, shutdown())
+}
+
+// testCA issues the server certificates of the name "example.com".
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (ca *testCA) serverCertificate(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"example.com"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, key.Public(), ca.key)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// dialTLS returns the error of the handshake with a server using the given certificate.
+func dialTLS(t *testing.T, cert tls.Certificate, clientCfg *tls.Config) error {
+	ln, err := tls.Listen("tcp", "localhost:0", &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12})
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		_ = conn.(*tls.Conn).Handshake()
+		conn.Close()
+	}()
+	conn, err := tls.Dial("tcp", ln.Addr().String(), clientCfg)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func TestClientCAReload(t *testing.T) {
+	ca1 := newTestCA(t)
+	ca2 := newTestCA(t)
+	server1 := ca1.serverCertificate(t)
+	server2 := ca2.serverCertificate(t)
+
+	tests := []struct {
+		name    string
+		setting TLSSetting
+		reload  func(t *testing.T, caFile string)
+	}{
+		{
+			name:    "reload_on_change",
+			setting: TLSSetting{ReloadOnChange: true},
+			reload: func(t *testing.T, caFile string) {
+				require.NoError(t, os.WriteFile(caFile, ca2.pem, 0600))
+			},
+		},
+		{
+			name:    "reload_interval",
+			setting: TLSSetting{ReloadInterval: time.Millisecond},
+			reload: func(t *testing.T, caFile string) {
+				require.NoError(t, os.WriteFile(caFile, ca2.pem, 0600))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caFile := filepath.Join(t.TempDir(), "ca.pem")
+			require.NoError(t, os.WriteFile(caFile, ca1.pem, 0600))
+			setting := TLSClientSetting{TLSSetting: tt.setting, ServerName: "example.com"}
+			setting.CAFile = caFile
+			clientCfg, err := setting.LoadTLSConfig()
+			require.NoError(t, err)
+
+			assert.NoError(t, dialTLS(t, server1, clientCfg))
+			assert.Error(t, dialTLS(t, server2, clientCfg))
+
+			tt.reload(t, caFile)
+			assert.Eventually(t, func() bool {
+				return dialTLS(t, server2, clientCfg) == nil
+			}, 5*time.Second, 10*time.Millisecond)
+			assert.Error(t, dialTLS(t, server1, clientCfg))
+		})
+	}
+}
+
+func TestClientCAReloadServerName(t *testing.T) {
+	ca := newTestCA(t)
+	server := ca.serverCertificate(t)
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, ca.pem, 0600))
+
+	setting := TLSClientSetting{TLSSetting: TLSSetting{CAFile: caFile, ReloadInterval: time.Hour}}
+	clientCfg, err := setting.LoadTLSConfig()
+	require.NoError(t, err)
+
+	// The server name cannot be verified without server name override when dialing an IP address.
+	assert.EqualError(t, dialTLS(t, server, clientCfg), "tls: the server name is unknown, server_name_override must be set to verify the server certificate with reloaded CAs")
+
+	// The server name is the one of the dialed host otherwise.
+	clientCfg.ServerName = "example.org"
+	assert.ErrorContains(t, dialTLS(t, server, clientCfg), "certificate is valid for example.com, not example.org")
+	clientCfg.ServerName = "example.com"
+	assert.NoError(t, dialTLS(t, server, clientCfg))
+
+	// The server certificate is not verified if insecure_skip_verify is set.
+	setting.InsecureSkipVerify = true
+	clientCfg, err = setting.LoadTLSConfig()
+	require.NoError(t, err)
+	assert.Nil(t, clientCfg.VerifyConnection)
+	assert.NoError(t, dialTLS(t, server, clientCfg))
+}
@@ -85,12 +85,12 @@ func (r *clientCAsFileReloader) startWatching() error {
 	if err != nil {
 		return fmt.Errorf("failed to create watcher to reload client CA CertPool: %w", err)
 	}
-	r.watcher = watcher
-
 	err = watcher.Add(r.clientCAsFile)
 	if err != nil {
+		_ = watcher.Close()
 		return fmt.Errorf("failed to add client CA file to watcher: %w", err)
 	}
+	r.watcher = watcher
 
 	r.shutdownCH = make(chan bool)
 	go r.handleWatcherEvents()
@@ -6,10 +6,10 @@ package configtls // import "go.opentelemetry.io/collector/config/configtls"
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/config/configopaque"
@@ -54,9 +54,17 @@ type TLSSetting struct {
 	// If not set, refer to crypto/tls for defaults. (optional)
 	MaxVersion string `mapstructure:"max_version"`
 
-	// ReloadInterval specifies the duration after which the certificate will be reloaded
+	// ReloadInterval specifies the duration after which the certificate, key and CA files will be reloaded
 	// If not set, it will never be reloaded (optional)
 	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+
+	// ReloadOnChange reloads the certificate, key and CA files as soon as they are modified,
+	// ReloadInterval can be set as a fallback in case a modification is missed. (optional)
+	ReloadOnChange bool `mapstructure:"reload_on_change"`
+
+	// SPIFFE enables the mutual TLS authentication with the SVIDs fetched from the SPIFFE Workload API,
+	// instead of the CA, certificate and key. (optional)
+	SPIFFE *SPIFFESetting `mapstructure:"spiffe"`
 }
 
 // TLSClientSetting contains TLS configurations that are specific to client
@@ -102,78 +110,45 @@ type TLSServerSetting struct {
 	ReloadClientCAFile bool `mapstructure:"client_ca_file_reload"`
 }
 
-// certReloader is a wrapper object for certificate reloading
-// Its GetCertificate method will either return the current certificate or reload from disk
-// if the last reload happened more than ReloadInterval ago
-type certReloader struct {
-	nextReload time.Time
-	cert       *tls.Certificate
-	lock       sync.RWMutex
-	tls        TLSSetting
+// loadTLSConfig loads TLS certificates and returns a tls.Config.
+// This will set the RootCAs and Certificates of a tls.Config.
+func (c TLSSetting) loadTLSConfig() (*tls.Config, error) {
+	tlsCfg, _, err := c.loadTLSConfigAndReloader()
+	return tlsCfg, err
 }
 
-func (c TLSSetting) newCertReloader() (*certReloader, error) {
-	cert, err := c.loadCertificate()
+// loadTLSConfigAndReloader loads TLS certificates and returns a tls.Config, and the reloader
+// of the certificate and CA files if they are reloaded.
+func (c TLSSetting) loadTLSConfigAndReloader() (*tls.Config, *certReloader, error) {
+	certPool, err := c.loadCACertPool()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return &certReloader{
-		tls:        c,
-		nextReload: time.Now().Add(c.ReloadInterval),
-		cert:       &cert,
-	}, nil
-}
 
-func (r *certReloader) GetCertificate() (*tls.Certificate, error) {
-	now := time.Now()
-	// Read locking here before we do the time comparison
-	// If a reload is in progress this will block and we will skip reloading in the current
-	// call once we can continue
-	r.lock.RLock()
-	if r.tls.ReloadInterval != 0 && r.nextReload.Before(now) && (r.tls.hasCertFile() || r.tls.hasKeyFile()) {
-		// Need to release the read lock, otherwise we deadlock
-		r.lock.RUnlock()
-		r.lock.Lock()
-		defer r.lock.Unlock()
-		cert, err := r.tls.loadCertificate()
+	var reloader *certReloader
+	if c.hasCert() || c.hasKey() || c.reloadsCA() {
+		reloader, err = c.newCertReloader(certPool)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load TLS cert and key: %w", err)
+			return nil, nil, fmt.Errorf("failed to load TLS cert and key: %w", err)
 		}
-		r.cert = &cert
-		r.nextReload = now.Add(r.tls.ReloadInterval)
-		return r.cert, nil
-	}
-	defer r.lock.RUnlock()
-	return r.cert, nil
-}
-
-// loadTLSConfig loads TLS certificates and returns a tls.Config.
-// This will set the RootCAs and Certificates of a tls.Config.
-func (c TLSSetting) loadTLSConfig() (*tls.Config, error) {
-	certPool, err := c.loadCACertPool()
-	if err != nil {
-		return nil, err
 	}
 
 	var getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)
 	var getClientCertificate func(*tls.CertificateRequestInfo) (*tls.Certificate, error)
 	if c.hasCert() || c.hasKey() {
-		var certReloader *certReloader
-		certReloader, err = c.newCertReloader()
-		if err != nil {
-			return nil, fmt.Errorf("failed to load TLS cert and key: %w", err)
-		}
-		getCertificate = func(chi *tls.ClientHelloInfo) (*tls.Certificate, error) { return certReloader.GetCertificate() }
-		getClientCertificate = func(cri *tls.CertificateRequestInfo) (*tls.Certificate, error) { return certReloader.GetCertificate() }
+		getCertificate = func(chi *tls.ClientHelloInfo) (*tls.Certificate, error) { return reloader.GetCertificate() }
+		getClientCertificate = func(cri *tls.CertificateRequestInfo) (*tls.Certificate, error) { return reloader.GetCertificate() }
 	}
 
-	minTLS, err := convertVersion(c.MinVersion, defaultMinTLSVersion)
+	minTLS, maxTLS, err := c.versions()
 	if err != nil {
-		return nil, fmt.Errorf("invalid TLS min_version: %w", err)
+		return nil, nil, err
 	}
-	maxTLS, err := convertVersion(c.MaxVersion, defaultMaxTLSVersion)
-	if err != nil {
-		return nil, fmt.Errorf("invalid TLS max_version: %w", err)
+
+	if reloader != nil && c.ReloadOnChange {
+		if err = reloader.startWatching(); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return &tls.Config{
@@ -182,7 +157,20 @@ func (c TLSSetting) loadTLSConfig() (*tls.Config, error) {
 		GetClientCertificate: getClientCertificate,
 		MinVersion:           minTLS,
 		MaxVersion:           maxTLS,
-	}, nil
+	}, reloader, nil
+}
+
+// versions returns the minimum and maximum TLS versions.
+func (c TLSSetting) versions() (uint16, uint16, error) {
+	minTLS, err := convertVersion(c.MinVersion, defaultMinTLSVersion)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid TLS min_version: %w", err)
+	}
+	maxTLS, err := convertVersion(c.MaxVersion, defaultMaxTLSVersion)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid TLS max_version: %w", err)
+	}
+	return minTLS, maxTLS, nil
 }
 
 func (c TLSSetting) loadCACertPool() (*x509.CertPool, error) {
@@ -282,49 +270,118 @@ func (c TLSSetting) loadCert(caPath string) (*x509.CertPool, error) {
 }
 
 // LoadTLSConfig loads the TLS configuration.
+// The files reloaded on change are watched until the process exits, see LoadTLSConfigWithShutdown.
 func (c TLSClientSetting) LoadTLSConfig() (*tls.Config, error) {
+	tlsCfg, _, err := c.LoadTLSConfigWithShutdown()
+	return tlsCfg, err
+}
+
+// LoadTLSConfigWithShutdown loads the TLS configuration, and returns the function stopping the watching of
+// the files reloaded on change, to call on shutdown once the configuration is not used anymore.
+func (c TLSClientSetting) LoadTLSConfigWithShutdown() (*tls.Config, func() error, error) {
+	if c.SPIFFE != nil {
+		if c.Insecure || c.InsecureSkipVerify {
+			return nil, nil, errors.New("failed to load TLS config: the SPIFFE settings cannot be used with insecure connections")
+		}
+		tlsCfg, err := c.loadSPIFFETLSConfig(false)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		return tlsCfg, noShutdown, nil
+	}
 	if c.Insecure && !c.hasCA() {
-		return nil, nil
+		return nil, noShutdown, nil
 	}
 
-	tlsCfg, err := c.TLSSetting.loadTLSConfig()
+	tlsCfg, reloader, err := c.TLSSetting.loadTLSConfigAndReloader()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		return nil, nil, fmt.Errorf("failed to load TLS config: %w", err)
 	}
 	tlsCfg.ServerName = c.ServerName
 	tlsCfg.InsecureSkipVerify = c.InsecureSkipVerify
-	return tlsCfg, nil
+	if c.reloadsCA() && !c.InsecureSkipVerify {
+		// The RootCAs cannot be modified once the configuration is used, the server certificates are
+		// verified with the reloaded CAs instead of by crypto/tls.
+		tlsCfg.InsecureSkipVerify = true
+		tlsCfg.VerifyConnection = reloader.verifyServerCertificate(c.ServerName)
+	}
+	return tlsCfg, c.TLSSetting.shutdownFunc(reloader), nil
 }
 
 // LoadTLSConfig loads the TLS configuration.
+// The files reloaded on change are watched until the process exits, see LoadTLSConfigWithShutdown.
 func (c TLSServerSetting) LoadTLSConfig() (*tls.Config, error) {
-	tlsCfg, err := c.loadTLSConfig()
+	tlsCfg, _, err := c.LoadTLSConfigWithShutdown()
+	return tlsCfg, err
+}
+
+// LoadTLSConfigWithShutdown loads the TLS configuration, and returns the function stopping the watching of
+// the files reloaded on change, to call on shutdown once the configuration is not used anymore.
+func (c TLSServerSetting) LoadTLSConfigWithShutdown() (*tls.Config, func() error, error) {
+	if c.SPIFFE != nil {
+		if c.ClientCAFile != "" {
+			return nil, nil, errors.New("failed to load TLS config: provide either the SPIFFE settings or the client CA file, but not both")
+		}
+		tlsCfg, err := c.loadSPIFFETLSConfig(true)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		return tlsCfg, noShutdown, nil
+	}
+	tlsCfg, reloader, err := c.loadTLSConfigAndReloader()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		return nil, nil, fmt.Errorf("failed to load TLS config: %w", err)
 	}
+	shutdown := c.shutdownFunc(reloader)
 	if c.ClientCAFile != "" {
-		reloader, err := newClientCAsReloader(c.ClientCAFile, &c)
+		caReloader, err := newClientCAsReloader(c.ClientCAFile, &c)
 		if err != nil {
-			return nil, err
+			_ = shutdown()
+			return nil, nil, err
 		}
-		if c.ReloadClientCAFile {
-			err = reloader.startWatching()
+		if c.ReloadClientCAFile || c.ReloadOnChange {
+			err = caReloader.startWatching()
 			if err != nil {
-				return nil, err
+				_ = shutdown()
+				return nil, nil, err
+			}
+			tlsCfg.GetConfigForClient = func(t *tls.ClientHelloInfo) (*tls.Config, error) { return caReloader.getClientConfig(tlsCfg) }
+			certShutdown := shutdown
+			shutdown = func() error {
+				err := caReloader.shutdown()
+				if certErr := certShutdown(); certErr != nil {
+					return certErr
+				}
+				return err
 			}
-			tlsCfg.GetConfigForClient = func(t *tls.ClientHelloInfo) (*tls.Config, error) { return reloader.getClientConfig(tlsCfg) }
 		}
-		tlsCfg.ClientCAs = reloader.certPool
+		tlsCfg.ClientCAs = caReloader.certPool
 		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
 	}
-	return tlsCfg, nil
+	return tlsCfg, shutdown, nil
+}
+
+// noShutdown is returned when no files are watched.
+func noShutdown() error { return nil }
+
+// shutdownFunc returns the function stopping the watching of the files by the reloader, if they are watched.
+func (c TLSSetting) shutdownFunc(reloader *certReloader) func() error {
+	if reloader == nil || !c.ReloadOnChange || len(reloader.files()) == 0 {
+		return noShutdown
+	}
+	return reloader.stopWatching
 }
 
 func (c TLSServerSetting) loadClientCAFile() (*x509.CertPool, error) {
 	return c.loadCert(c.ClientCAFile)
 }
 
-func (c TLSSetting) hasCA() bool   { return c.hasCAFile() || c.hasCAPem() }
+func (c TLSSetting) hasCA() bool { return c.hasCAFile() || c.hasCAPem() }
+
+// reloadsCA returns whether the CA file is reloaded.
+func (c TLSSetting) reloadsCA() bool {
+	return c.hasCAFile() && (c.ReloadInterval != 0 || c.ReloadOnChange)
+}
 func (c TLSSetting) hasCert() bool { return c.hasCertFile() || c.hasCertPem() }
 func (c TLSSetting) hasKey() bool  { return c.hasKeyFile() || c.hasKeyPem() }
 
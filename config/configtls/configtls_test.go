@@ -627,3 +627,38 @@ func TestMinMaxTLSVersions(t *testing.T) {
 		})
 	}
 }
+
+// FuzzLoadTLSConfigPemRedacted checks that the PEM-encoded key and CA do not leak
+// in the errors returned when they cannot be loaded, nor in the formatted settings.
+func FuzzLoadTLSConfigPemRedacted(f *testing.F) {
+	certPem, err := os.ReadFile(filepath.Join("testdata", "server-1.crt"))
+	require.NoError(f, err)
+	keyPem, err := os.ReadFile(filepath.Join("testdata", "server-1.key"))
+	require.NoError(f, err)
+	otherKeyPem, err := os.ReadFile(filepath.Join("testdata", "client-1.key"))
+	require.NoError(f, err)
+
+	f.Add(string(keyPem), string(certPem))
+	f.Add(string(otherKeyPem), string(certPem))
+	f.Add(string(keyPem[:len(keyPem)/2]), "not a certificate, but long enough to be checked")
+	f.Add("-----BEGIN SECRET-----\nc2VjcmV0IHZhbHVlIHRoYXQgbXVzdCBub3QgbGVhaw==\n-----END SECRET-----\n", string(certPem))
+	f.Fuzz(func(t *testing.T, key string, ca string) {
+		settings := TLSSetting{
+			CAPem:   configopaque.String(ca),
+			CertPem: configopaque.String(certPem),
+			KeyPem:  configopaque.String(key),
+		}
+		_, err := TLSClientSetting{TLSSetting: settings}.LoadTLSConfig()
+		formatted := fmt.Sprintf("%+v %#v", settings, settings)
+		// Short values may be part of the fixed messages by chance.
+		for _, secret := range []string{key, ca} {
+			if len(secret) < 32 {
+				continue
+			}
+			if err != nil {
+				assert.NotContains(t, err.Error(), secret)
+			}
+			assert.NotContains(t, formatted, secret)
+		}
+	})
+}
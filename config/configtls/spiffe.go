@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configtls // import "go.opentelemetry.io/collector/config/configtls"
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+)
+
+// spiffeSourceTimeout is the maximum duration to wait for the first SVID from the Workload API.
+const spiffeSourceTimeout = 30 * time.Second
+
+// SPIFFESetting configures the mutual TLS authentication with the X.509 SVIDs and trust bundles
+// fetched from the SPIFFE Workload API, for instance exposed by a SPIRE agent.
+// The SVIDs and bundles are rotated automatically when updated by the Workload API.
+type SPIFFESetting struct {
+	// WorkloadAPIAddress is the address of the Workload API (e.g.: unix:///run/spire/sockets/agent.sock).
+	// If not set, the SPIFFE_ENDPOINT_SOCKET environment variable is used. (optional)
+	WorkloadAPIAddress string `mapstructure:"workload_api_address"`
+
+	// AuthorizedIDs is the list of the SPIFFE IDs of the peers which are authorized. (optional)
+	AuthorizedIDs []string `mapstructure:"authorized_ids"`
+
+	// TrustDomain authorizes all the peers of the trust domain, if AuthorizedIDs is not set.
+	// If neither is set, all the peers with an SVID of a trusted trust domain are authorized. (optional)
+	TrustDomain string `mapstructure:"trust_domain"`
+}
+
+// spiffeSource provides the X.509 SVIDs and trust bundles.
+type spiffeSource interface {
+	x509svid.Source
+	x509bundle.Source
+}
+
+// newSPIFFESource connects to the Workload API, it is replaced in tests.
+var newSPIFFESource = func(ctx context.Context, address string) (spiffeSource, error) {
+	var opts []workloadapi.X509SourceOption
+	if address != "" {
+		opts = append(opts, workloadapi.WithClientOptions(workloadapi.WithAddr(address)))
+	}
+	return workloadapi.NewX509Source(ctx, opts...)
+}
+
+// spiffeSources are the sources of each Workload API address, shared by all the TLS configurations
+// for the lifetime of the process, since the TLS configurations are never released.
+var spiffeSources = struct {
+	sync.Mutex
+	byAddress map[string]spiffeSource
+}{byAddress: map[string]spiffeSource{}}
+
+// source returns the source of the Workload API address, connecting to it on first use.
+func (s SPIFFESetting) source() (spiffeSource, error) {
+	spiffeSources.Lock()
+	defer spiffeSources.Unlock()
+	if source, ok := spiffeSources.byAddress[s.WorkloadAPIAddress]; ok {
+		return source, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), spiffeSourceTimeout)
+	defer cancel()
+	source, err := newSPIFFESource(ctx, s.WorkloadAPIAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the X.509 SVID from the SPIFFE Workload API: %w", err)
+	}
+	spiffeSources.byAddress[s.WorkloadAPIAddress] = source
+	return source, nil
+}
+
+// authorizer returns the authorizer of the peer SVIDs.
+func (s SPIFFESetting) authorizer() (tlsconfig.Authorizer, error) {
+	switch {
+	case len(s.AuthorizedIDs) > 0 && s.TrustDomain != "":
+		return nil, errors.New("provide either the SPIFFE authorized IDs or the trust domain, but not both")
+	case len(s.AuthorizedIDs) > 0:
+		ids := make([]spiffeid.ID, 0, len(s.AuthorizedIDs))
+		for _, authorizedID := range s.AuthorizedIDs {
+			id, err := spiffeid.FromString(authorizedID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SPIFFE ID %q: %w", authorizedID, err)
+			}
+			ids = append(ids, id)
+		}
+		return tlsconfig.AuthorizeOneOf(ids...), nil
+	case s.TrustDomain != "":
+		td, err := spiffeid.TrustDomainFromString(s.TrustDomain)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SPIFFE trust domain %q: %w", s.TrustDomain, err)
+		}
+		return tlsconfig.AuthorizeMemberOf(td), nil
+	}
+	return tlsconfig.AuthorizeAny(), nil
+}
+
+// loadSPIFFETLSConfig returns the mutual TLS configuration of a client or a server using the SVIDs.
+func (c TLSSetting) loadSPIFFETLSConfig(isServer bool) (*tls.Config, error) {
+	if c.hasCA() || c.hasCert() || c.hasKey() {
+		return nil, errors.New("provide either the SPIFFE settings or the CA, certificate and key, but not both")
+	}
+	minTLS, maxTLS, err := c.versions()
+	if err != nil {
+		return nil, err
+	}
+	authorizer, err := c.SPIFFE.authorizer()
+	if err != nil {
+		return nil, err
+	}
+	source, err := c.SPIFFE.source()
+	if err != nil {
+		return nil, err
+	}
+
+	var tlsCfg *tls.Config
+	if isServer {
+		tlsCfg = tlsconfig.MTLSServerConfig(source, source, authorizer)
+	} else {
+		tlsCfg = tlsconfig.MTLSClientConfig(source, source, authorizer)
+	}
+	tlsCfg.MinVersion = minTLS
+	tlsCfg.MaxVersion = maxTLS
+	return tlsCfg, nil
+}
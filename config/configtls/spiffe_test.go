@@ -0,0 +1,311 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configtls
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticSPIFFESource struct {
+	svid   *x509svid.SVID
+	bundle *x509bundle.Bundle
+}
+
+func (s *staticSPIFFESource) GetX509SVID() (*x509svid.SVID, error) {
+	return s.svid, nil
+}
+
+func (s *staticSPIFFESource) GetX509BundleForTrustDomain(td spiffeid.TrustDomain) (*x509bundle.Bundle, error) {
+	if td != s.bundle.TrustDomain() {
+		return nil, errors.New("unknown trust domain")
+	}
+	return s.bundle, nil
+}
+
+// spiffeCA issues the SVIDs of a trust domain.
+type spiffeCA struct {
+	td   spiffeid.TrustDomain
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func newSPIFFECA(t *testing.T, trustDomain string) *spiffeCA {
+	td := spiffeid.RequireTrustDomainFromString(trustDomain)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: trustDomain},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		URIs:                  []*url.URL{td.ID().URL()},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &spiffeCA{td: td, cert: cert, key: key}
+}
+
+// source returns a source of an SVID of the given ID issued by the CA, trusting the CA.
+func (ca *spiffeCA) source(t *testing.T, id string) *staticSPIFFESource {
+	spiffeID := spiffeid.RequireFromString(id)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		URIs:         []*url.URL{spiffeID.URL()},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, key.Public(), ca.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &staticSPIFFESource{
+		svid:   &x509svid.SVID{ID: spiffeID, Certificates: []*x509.Certificate{cert}, PrivateKey: key},
+		bundle: x509bundle.FromX509Authorities(ca.td, []*x509.Certificate{ca.cert}),
+	}
+}
+
+// setSPIFFESources replaces the Workload API by the given sources of each address.
+func setSPIFFESources(t *testing.T, sources map[string]spiffeSource) *int {
+	calls := 0
+	oldNewSPIFFESource := newSPIFFESource
+	newSPIFFESource = func(_ context.Context, address string) (spiffeSource, error) {
+		calls++
+		source, ok := sources[address]
+		if !ok {
+			return nil, errors.New("connection refused")
+		}
+		return source, nil
+	}
+	spiffeSources.byAddress = map[string]spiffeSource{}
+	t.Cleanup(func() {
+		newSPIFFESource = oldNewSPIFFESource
+		spiffeSources.byAddress = map[string]spiffeSource{}
+	})
+	return &calls
+}
+
+func handshake(t *testing.T, clientCfg, serverCfg *tls.Config) (clientErr, serverErr error) {
+	ln, err := tls.Listen("tcp", "localhost:0", serverCfg)
+	require.NoError(t, err)
+	defer ln.Close()
+	serverDone := make(chan error, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			serverDone <- err
+			return
+		}
+		defer conn.Close()
+		serverDone <- conn.(*tls.Conn).Handshake()
+	}()
+
+	conn, err := tls.Dial("tcp", ln.Addr().String(), clientCfg)
+	if err == nil {
+		// With TLS 1.3 the client certificate is verified by the server after the client handshake,
+		// the client receives either the alert of the server or the end of the connection when reading.
+		serverErr = <-serverDone
+		_, err = conn.Read(make([]byte, 1))
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+		conn.Close()
+		return err, serverErr
+	}
+	return err, <-serverDone
+}
+
+func TestSPIFFEHandshake(t *testing.T) {
+	ca := newSPIFFECA(t, "example.org")
+	setSPIFFESources(t, map[string]spiffeSource{
+		"unix:///client.sock": ca.source(t, "spiffe://example.org/client"),
+		"unix:///server.sock": ca.source(t, "spiffe://example.org/server"),
+	})
+
+	tests := []struct {
+		name          string
+		client        SPIFFESetting
+		server        SPIFFESetting
+		wantClientErr bool
+		wantServerErr bool
+	}{
+		{
+			name:   "authorize_any",
+			client: SPIFFESetting{},
+			server: SPIFFESetting{},
+		},
+		{
+			name:   "authorized_ids",
+			client: SPIFFESetting{AuthorizedIDs: []string{"spiffe://example.org/server"}},
+			server: SPIFFESetting{AuthorizedIDs: []string{"spiffe://example.org/other", "spiffe://example.org/client"}},
+		},
+		{
+			name:   "trust_domain",
+			client: SPIFFESetting{TrustDomain: "example.org"},
+			server: SPIFFESetting{TrustDomain: "example.org"},
+		},
+		{
+			name:          "server_unauthorized",
+			client:        SPIFFESetting{AuthorizedIDs: []string{"spiffe://example.org/other"}},
+			wantClientErr: true,
+			wantServerErr: true,
+		},
+		{
+			name:          "client_unauthorized",
+			server:        SPIFFESetting{TrustDomain: "other.org"},
+			wantClientErr: true,
+			wantServerErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.client.WorkloadAPIAddress = "unix:///client.sock"
+			tt.server.WorkloadAPIAddress = "unix:///server.sock"
+			clientCfg, err := TLSClientSetting{TLSSetting: TLSSetting{SPIFFE: &tt.client}}.LoadTLSConfig()
+			require.NoError(t, err)
+			serverCfg, err := TLSServerSetting{TLSSetting: TLSSetting{SPIFFE: &tt.server}}.LoadTLSConfig()
+			require.NoError(t, err)
+
+			clientErr, serverErr := handshake(t, clientCfg, serverCfg)
+			assert.Equal(t, tt.wantClientErr, clientErr != nil, "client error: %v", clientErr)
+			assert.Equal(t, tt.wantServerErr, serverErr != nil, "server error: %v", serverErr)
+		})
+	}
+}
+
+func TestSPIFFESourceShared(t *testing.T) {
+	ca := newSPIFFECA(t, "example.org")
+	calls := setSPIFFESources(t, map[string]spiffeSource{
+		"": ca.source(t, "spiffe://example.org/collector"),
+	})
+
+	_, err := TLSClientSetting{TLSSetting: TLSSetting{SPIFFE: &SPIFFESetting{}}}.LoadTLSConfig()
+	require.NoError(t, err)
+	_, err = TLSServerSetting{TLSSetting: TLSSetting{SPIFFE: &SPIFFESetting{}}}.LoadTLSConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 1, *calls)
+}
+
+func TestSPIFFEVersions(t *testing.T) {
+	ca := newSPIFFECA(t, "example.org")
+	setSPIFFESources(t, map[string]spiffeSource{
+		"": ca.source(t, "spiffe://example.org/collector"),
+	})
+
+	tlsCfg, err := TLSClientSetting{TLSSetting: TLSSetting{SPIFFE: &SPIFFESetting{}, MinVersion: "1.3"}}.LoadTLSConfig()
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), tlsCfg.MinVersion)
+}
+
+func TestSPIFFEErrors(t *testing.T) {
+	setSPIFFESources(t, map[string]spiffeSource{})
+
+	tests := []struct {
+		name   string
+		client TLSClientSetting
+		server TLSServerSetting
+		err    string
+	}{
+		{
+			name: "unavailable_workload_api",
+			client: TLSClientSetting{
+				TLSSetting: TLSSetting{SPIFFE: &SPIFFESetting{}},
+			},
+			server: TLSServerSetting{
+				TLSSetting: TLSSetting{SPIFFE: &SPIFFESetting{}},
+			},
+			err: "failed to load TLS config: failed to fetch the X.509 SVID from the SPIFFE Workload API: connection refused",
+		},
+		{
+			name: "ids_and_trust_domain",
+			client: TLSClientSetting{
+				TLSSetting: TLSSetting{SPIFFE: &SPIFFESetting{AuthorizedIDs: []string{"spiffe://example.org/server"}, TrustDomain: "example.org"}},
+			},
+			server: TLSServerSetting{
+				TLSSetting: TLSSetting{SPIFFE: &SPIFFESetting{AuthorizedIDs: []string{"spiffe://example.org/client"}, TrustDomain: "example.org"}},
+			},
+			err: "failed to load TLS config: provide either the SPIFFE authorized IDs or the trust domain, but not both",
+		},
+		{
+			name: "invalid_id",
+			client: TLSClientSetting{
+				TLSSetting: TLSSetting{SPIFFE: &SPIFFESetting{AuthorizedIDs: []string{"https://example.org/server"}}},
+			},
+			server: TLSServerSetting{
+				TLSSetting: TLSSetting{SPIFFE: &SPIFFESetting{AuthorizedIDs: []string{"https://example.org/server"}}},
+			},
+			err: `failed to load TLS config: invalid SPIFFE ID "https://example.org/server": scheme is missing or invalid`,
+		},
+		{
+			name: "invalid_trust_domain",
+			client: TLSClientSetting{
+				TLSSetting: TLSSetting{SPIFFE: &SPIFFESetting{TrustDomain: "Example.org"}},
+			},
+			server: TLSServerSetting{
+				TLSSetting: TLSSetting{SPIFFE: &SPIFFESetting{TrustDomain: "Example.org"}},
+			},
+			err: `failed to load TLS config: invalid SPIFFE trust domain "Example.org": trust domain characters are limited to lowercase letters, numbers, dots, dashes, and underscores`,
+		},
+		{
+			name: "with_cert",
+			client: TLSClientSetting{
+				TLSSetting: TLSSetting{SPIFFE: &SPIFFESetting{}, CertFile: "cert.pem", KeyFile: "key.pem"},
+			},
+			server: TLSServerSetting{
+				TLSSetting: TLSSetting{SPIFFE: &SPIFFESetting{}, CAFile: "ca.pem"},
+			},
+			err: "failed to load TLS config: provide either the SPIFFE settings or the CA, certificate and key, but not both",
+		},
+		{
+			name: "invalid_version",
+			client: TLSClientSetting{
+				TLSSetting: TLSSetting{SPIFFE: &SPIFFESetting{}, MinVersion: "1.4"},
+			},
+			server: TLSServerSetting{
+				TLSSetting: TLSSetting{SPIFFE: &SPIFFESetting{}, MinVersion: "1.4"},
+			},
+			err: `failed to load TLS config: invalid TLS min_version: unsupported TLS version: "1.4"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.client.LoadTLSConfig()
+			assert.EqualError(t, err, tt.err)
+			_, err = tt.server.LoadTLSConfig()
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+
+	_, err := TLSClientSetting{TLSSetting: TLSSetting{SPIFFE: &SPIFFESetting{}}, Insecure: true}.LoadTLSConfig()
+	assert.EqualError(t, err, "failed to load TLS config: the SPIFFE settings cannot be used with insecure connections")
+	_, err = TLSServerSetting{TLSSetting: TLSSetting{SPIFFE: &SPIFFESetting{}}, ClientCAFile: "ca.pem"}.LoadTLSConfig()
+	assert.EqualError(t, err, "failed to load TLS config: provide either the SPIFFE settings or the client CA file, but not both")
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/config/internal"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+)
+
+// The policies balancing the requests among the addresses of the endpoints.
+const (
+	// PolicyFailover sends the requests to the first healthy address, in the order of the endpoints.
+	PolicyFailover = "failover"
+	// PolicyRoundRobin sends the requests to the healthy addresses in turn.
+	PolicyRoundRobin = "round_robin"
+	// PolicyLeastPending sends the requests to the healthy address with the fewest pending requests.
+	PolicyLeastPending = "least_pending"
+)
+
+// ValidateLoadBalancingPolicy checks that the policy is supported, the empty policy is the failover one.
+func ValidateLoadBalancingPolicy(policy string) error {
+	switch policy {
+	case "", PolicyFailover, PolicyRoundRobin, PolicyLeastPending:
+		return nil
+	}
+	return fmt.Errorf("unsupported load balancing policy %q, must be one of %q, %q or %q",
+		policy, PolicyFailover, PolicyRoundRobin, PolicyLeastPending)
+}
+
+// LookupHost resolves the host names of the endpoints, it is replaced by the tests.
+var LookupHost = net.DefaultResolver.LookupHost
+
+// ResolvedAddress is an address of the host of an endpoint.
+type ResolvedAddress struct {
+	// Endpoint is the index of the endpoint of the address.
+	Endpoint int
+	// Host is the "host:port" of the endpoint.
+	Host string
+	// ServerName is the host name of the endpoint.
+	ServerName string
+	// Address is the "ip:port" address, or the "host:port" of the endpoint if its host name cannot be resolved.
+	Address string
+}
+
+// ResolveEndpoints resolves the host names of the "host:port" endpoints into their addresses, in the order of
+// the endpoints. The endpoints whose host name cannot be resolved are kept as is, so that their host name is
+// resolved when the connections are established.
+func ResolveEndpoints(ctx context.Context, endpoints []string) []ResolvedAddress {
+	var addresses []ResolvedAddress
+	for i, endpoint := range endpoints {
+		host, port, err := net.SplitHostPort(endpoint)
+		if err != nil || net.ParseIP(host) != nil {
+			addresses = append(addresses, ResolvedAddress{Endpoint: i, Host: endpoint, ServerName: host, Address: endpoint})
+			continue
+		}
+		ips, err := LookupHost(ctx, host)
+		if err != nil || len(ips) == 0 {
+			addresses = append(addresses, ResolvedAddress{Endpoint: i, Host: endpoint, ServerName: host, Address: endpoint})
+			continue
+		}
+		sort.Strings(ips)
+		for _, ip := range ips {
+			addresses = append(addresses, ResolvedAddress{Endpoint: i, Host: endpoint, ServerName: host, Address: net.JoinHostPort(ip, port)})
+		}
+	}
+	return addresses
+}
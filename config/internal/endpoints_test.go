@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateLoadBalancingPolicy(t *testing.T) {
+	for _, policy := range []string{"", PolicyFailover, PolicyRoundRobin, PolicyLeastPending} {
+		assert.NoError(t, ValidateLoadBalancingPolicy(policy))
+	}
+	assert.EqualError(t, ValidateLoadBalancingPolicy("random"),
+		`unsupported load balancing policy "random", must be one of "failover", "round_robin" or "least_pending"`)
+}
+
+func TestResolveEndpoints(t *testing.T) {
+	lookupHost := LookupHost
+	t.Cleanup(func() { LookupHost = lookupHost })
+	LookupHost = func(_ context.Context, host string) ([]string, error) {
+		if host == "collector" {
+			return []string{"10.0.0.2", "10.0.0.1"}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	assert.Equal(t, []ResolvedAddress{
+		{Endpoint: 0, Host: "collector:4317", ServerName: "collector", Address: "10.0.0.1:4317"},
+		{Endpoint: 0, Host: "collector:4317", ServerName: "collector", Address: "10.0.0.2:4317"},
+		{Endpoint: 1, Host: "127.0.0.1:4317", ServerName: "127.0.0.1", Address: "127.0.0.1:4317"},
+		{Endpoint: 2, Host: "unknown:4317", ServerName: "unknown", Address: "unknown:4317"},
+	}, ResolveEndpoints(context.Background(), []string{"collector:4317", "127.0.0.1:4317", "unknown:4317"}))
+}
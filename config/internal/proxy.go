@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/config/internal"
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/net/proxy"
+)
+
+// ValidateProxyURL checks that the proxy URL, if set, is a http, https or socks5 URL.
+func ValidateProxyURL(proxyURL string) error {
+	if proxyURL == "" {
+		return nil
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return errors.New("invalid proxy_url, must be a URL such as http://proxy:3128")
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("unsupported proxy_url scheme %q, must be one of http, https or socks5", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.New("invalid proxy_url, the host is missing")
+	}
+	return nil
+}
+
+// ProxyFunc returns the function selecting the proxy used to reach a URL, as expected by
+// http.Transport.Proxy. The proxyURL and noProxy take precedence over the HTTP_PROXY,
+// HTTPS_PROXY and NO_PROXY environment variables, which are used when they are not set.
+func ProxyFunc(proxyURL, noProxy string) func(*url.URL) (*url.URL, error) {
+	cfg := httpproxy.FromEnvironment()
+	if proxyURL != "" {
+		cfg.HTTPProxy = proxyURL
+		cfg.HTTPSProxy = proxyURL
+	}
+	if noProxy != "" {
+		cfg.NoProxy = noProxy
+	}
+	return cfg.ProxyFunc()
+}
+
+// ProxyDialer returns the function dialing TCP connections through the proxy selected by
+// ProxyFunc, as expected by grpc.WithContextDialer. The connections are tunneled with the
+// CONNECT method through the http and https proxies.
+func ProxyDialer(proxyURL, noProxy string) func(ctx context.Context, addr string) (net.Conn, error) {
+	proxyFunc := ProxyFunc(proxyURL, noProxy)
+	dialer := &net.Dialer{}
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		u, err := proxyFunc(&url.URL{Scheme: "https", Host: addr})
+		if err != nil {
+			return nil, err
+		}
+		if u == nil {
+			return dialer.DialContext(ctx, "tcp", addr)
+		}
+		if u.Scheme == "socks5" {
+			return dialSOCKS5(ctx, dialer, u, addr)
+		}
+		return dialConnect(ctx, dialer, u, addr)
+	}
+}
+
+func dialSOCKS5(ctx context.Context, dialer *net.Dialer, u *url.URL, addr string) (net.Conn, error) {
+	var auth *proxy.Auth
+	if u.User != nil {
+		password, _ := u.User.Password()
+		auth = &proxy.Auth{User: u.User.Username(), Password: password}
+	}
+	d, err := proxy.SOCKS5("tcp", proxyAddr(u), auth, dialer)
+	if err != nil {
+		return nil, err
+	}
+	return d.(proxy.ContextDialer).DialContext(ctx, "tcp", addr)
+}
+
+func dialConnect(ctx context.Context, dialer *net.Dialer, u *url.URL, addr string) (_ net.Conn, err error) {
+	conn, err := dialer.DialContext(ctx, "tcp", proxyAddr(u))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = conn.Close()
+		}
+	}()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = conn.SetDeadline(time.Time{})
+		}()
+	}
+	if u.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12})
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Host: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if u.User != nil {
+		password, _ := u.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(u.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err = req.Write(conn); err != nil {
+		return nil, fmt.Errorf("failed to send the CONNECT request to the proxy: %w", err)
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CONNECT response of the proxy: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("the proxy refused the connection to %s: %s", addr, resp.Status)
+	}
+	return &bufferedConn{Conn: conn, r: br}, nil
+}
+
+// proxyAddr returns the address of the proxy, with the default port of its scheme if not set.
+func proxyAddr(u *url.URL) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	port := "80"
+	switch u.Scheme {
+	case "https":
+		port = "443"
+	case "socks5":
+		port = "1080"
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// bufferedConn reads the data received after the CONNECT response, which may already be buffered.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
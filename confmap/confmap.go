@@ -7,6 +7,7 @@ import (
 	"encoding"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/knadh/koanf"
 	"github.com/knadh/koanf/maps"
@@ -38,6 +39,8 @@ func NewFromStringMap(data map[string]any) *Conf {
 // The confmap.Conf can be unmarshalled into the Collector's config using the "service" package.
 type Conf struct {
 	k *koanf.Koanf
+	// positions are the positions of the keys in the retrieved configurations.
+	positions map[string]Position
 }
 
 // AllKeys returns all keys holding a value, regardless of where they are set.
@@ -109,10 +112,25 @@ func (l *Conf) IsSet(key string) bool {
 	return l.k.Exists(key)
 }
 
+// Delete removes the key and its sub-keys from the config.
+func (l *Conf) Delete(key string) {
+	l.k.Delete(key)
+	prefix := key + KeyDelimiter
+	for k := range l.positions {
+		if k == key || strings.HasPrefix(k, prefix) {
+			delete(l.positions, k)
+		}
+	}
+}
+
 // Merge merges the input given configuration into the existing config.
 // Note that the given map may be modified.
 func (l *Conf) Merge(in *Conf) error {
-	return l.k.Merge(in.k)
+	if err := l.k.Merge(in.k); err != nil {
+		return err
+	}
+	l.setPositions(in.positions)
+	return nil
 }
 
 // Sub returns new Conf instance representing a sub-config of this instance.
@@ -125,7 +143,14 @@ func (l *Conf) Sub(key string) (*Conf, error) {
 	}
 
 	if v, ok := data.(map[string]any); ok {
-		return NewFromStringMap(v), nil
+		sub := NewFromStringMap(v)
+		prefix := key + KeyDelimiter
+		for k, pos := range l.positions {
+			if strings.HasPrefix(k, prefix) {
+				sub.setPositions(map[string]Position{k[len(prefix):]: pos})
+			}
+		}
+		return sub, nil
 	}
 
 	return nil, fmt.Errorf("unexpected sub-config value kind for key:%s value:%v kind:%v)", key, data, reflect.TypeOf(data).Kind())
@@ -144,6 +169,8 @@ func (l *Conf) ToStringMap() map[string]any {
 // Decodes time.Duration from strings. Allows custom unmarshaling for structs implementing
 // encoding.TextUnmarshaler. Allows custom unmarshaling for structs implementing confmap.Unmarshaler.
 func decodeConfig(m *Conf, result any, errorUnused bool) error {
+	strict := errorUnused && StrictUnmarshalFeatureGate.IsEnabled()
+	var md mapstructure.Metadata
 	dc := &mapstructure.DecoderConfig{
 		ErrorUnused:      errorUnused,
 		Result:           result,
@@ -159,11 +186,19 @@ func decodeConfig(m *Conf, result any, errorUnused bool) error {
 			unmarshalerHookFunc(result),
 		),
 	}
+	if strict {
+		// The unknown keys are collected to report them with the closest valid keys and their positions.
+		dc.ErrorUnused = false
+		dc.Metadata = &md
+	}
 	decoder, err := mapstructure.NewDecoder(dc)
 	if err != nil {
 		return err
 	}
-	return decoder.Decode(m.ToStringMap())
+	if err = decoder.Decode(m.ToStringMap()); err != nil || !strict || len(md.Unused) == 0 {
+		return err
+	}
+	return unknownKeysError(m, result, md.Unused)
 }
 
 // encoderConfig returns a default encoder.EncoderConfig that includes
@@ -410,3 +410,17 @@ func TestUnmarshalerErr(t *testing.T) {
 	assert.EqualError(t, cfgMap.Unmarshal(tc), expectErr)
 	assert.Empty(t, tc.Err.Foo)
 }
+
+func TestDelete(t *testing.T) {
+	conf := NewFromStringMap(map[string]any{
+		"receivers": map[string]any{"otlp": map[string]any{"endpoint": "localhost:4317"}, "nop": nil},
+	})
+	conf.setPositions(map[string]Position{"receivers::otlp": {Line: 2, Column: 3}, "receivers::nop": {Line: 4, Column: 3}})
+
+	conf.Delete("receivers::otlp")
+	assert.Equal(t, map[string]any{"receivers": map[string]any{"nop": nil}}, conf.ToStringMap())
+	assert.Equal(t, map[string]Position{"receivers::nop": {Line: 4, Column: 3}}, conf.positions)
+
+	conf.Delete("exporters")
+	assert.Equal(t, map[string]any{"receivers": map[string]any{"nop": nil}}, conf.ToStringMap())
+}
@@ -5,6 +5,9 @@ package confmap // import "go.opentelemetry.io/collector/confmap"
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 )
 
 // Converter is a converter interface for the confmap.Conf that allows distributions
@@ -13,3 +16,146 @@ type Converter interface {
 	// Convert applies the conversion logic to the given "conf".
 	Convert(ctx context.Context, conf *Conf) error
 }
+
+// ConverterChain is a Converter applying the converters registered by name, in the order of their
+// registration unless they are registered to be applied before or after other converters. The errors
+// of the converters are prefixed with their names.
+type ConverterChain struct {
+	entries []chainEntry
+	// order is the order the entries are applied in, as indexes of entries.
+	order []int
+}
+
+var _ Converter = (*ConverterChain)(nil)
+
+type chainEntry struct {
+	name      string
+	converter Converter
+	after     []string
+	before    []string
+}
+
+// ConverterOption is an option of a converter registered in a ConverterChain.
+type ConverterOption func(*chainEntry)
+
+// WithConvertAfter applies the converter after the converters with the given names.
+// The names of the converters which are not registered are ignored.
+func WithConvertAfter(names ...string) ConverterOption {
+	return func(e *chainEntry) {
+		e.after = append(e.after, names...)
+	}
+}
+
+// WithConvertBefore applies the converter before the converters with the given names.
+// The names of the converters which are not registered are ignored.
+func WithConvertBefore(names ...string) ConverterOption {
+	return func(e *chainEntry) {
+		e.before = append(e.before, names...)
+	}
+}
+
+// NewConverterChain returns an empty ConverterChain.
+func NewConverterChain() *ConverterChain {
+	return &ConverterChain{}
+}
+
+// Register adds the converter to the chain with the given name. It returns an error if the name is
+// already registered, or if the order of the converters cannot satisfy the converter options.
+func (c *ConverterChain) Register(name string, converter Converter, opts ...ConverterOption) error {
+	if name == "" {
+		return errors.New("the name of the converter must not be empty")
+	}
+	for _, e := range c.entries {
+		if e.name == name {
+			return fmt.Errorf("converter %q is already registered", name)
+		}
+	}
+	entry := chainEntry{name: name, converter: converter}
+	for _, opt := range opts {
+		opt(&entry)
+	}
+	entries := append(c.entries[:len(c.entries):len(c.entries)], entry)
+	order, err := sortConverters(entries)
+	if err != nil {
+		return err
+	}
+	c.entries, c.order = entries, order
+	return nil
+}
+
+// Names returns the names of the converters, in the order they are applied.
+func (c *ConverterChain) Names() []string {
+	names := make([]string, len(c.order))
+	for i, idx := range c.order {
+		names[i] = c.entries[idx].name
+	}
+	return names
+}
+
+// Convert applies the converters in order, and stops at the first error.
+func (c *ConverterChain) Convert(ctx context.Context, conf *Conf) error {
+	for _, idx := range c.order {
+		e := c.entries[idx]
+		if err := e.converter.Convert(ctx, conf); err != nil {
+			return fmt.Errorf("converter %q: %w", e.name, err)
+		}
+	}
+	return nil
+}
+
+// sortConverters returns the order of the entries satisfying their before and after constraints,
+// keeping the order of registration of the entries which are not constrained relative to each other.
+func sortConverters(entries []chainEntry) ([]int, error) {
+	index := make(map[string]int, len(entries))
+	for i, e := range entries {
+		index[e.name] = i
+	}
+	// deps[i] are the entries which must be applied before the entry i.
+	deps := make([][]int, len(entries))
+	for i, e := range entries {
+		for _, name := range e.after {
+			if j, ok := index[name]; ok {
+				deps[i] = append(deps[i], j)
+			}
+		}
+		for _, name := range e.before {
+			if j, ok := index[name]; ok {
+				deps[j] = append(deps[j], i)
+			}
+		}
+	}
+
+	order := make([]int, 0, len(entries))
+	done := make([]bool, len(entries))
+	for len(order) < len(entries) {
+		// Apply the first entry in registration order whose dependencies are all applied.
+		next := -1
+		for i := range entries {
+			if !done[i] && allDone(deps[i], done) {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			var cycle []string
+			for i, e := range entries {
+				if !done[i] {
+					cycle = append(cycle, fmt.Sprintf("%q", e.name))
+				}
+			}
+			return nil, fmt.Errorf("the order of the converters %s is cyclic", strings.Join(cycle, ", "))
+		}
+		done[next] = true
+		order = append(order, next)
+	}
+	return order, nil
+}
+
+func allDone(deps []int, done []bool) bool {
+	for _, d := range deps {
+		if !done[d] {
+			return false
+		}
+	}
+	return true
+}
@@ -6,13 +6,20 @@ package expandconverter // import "go.opentelemetry.io/collector/confmap/convert
 import (
 	"context"
 	"os"
+	"regexp"
+	"strconv"
 
 	"go.opentelemetry.io/collector/confmap"
 )
 
+// envRefRegexp matches a value made of a single environment variable, `$VAR` or `${VAR}`.
+var envRefRegexp = regexp.MustCompile(`^\$(\{[A-Za-z_][A-Za-z0-9_]*\}|[A-Za-z_][A-Za-z0-9_]*)$`)
+
 type converter struct{}
 
 // New returns a confmap.Converter, that expands all environment variables for a given confmap.Conf.
+// A value made of a single environment variable holding an integer is kept as an integer, the other
+// values are strings. "$$" is an escaped "$".
 //
 // Notice: This API is experimental.
 func New() confmap.Converter {
@@ -30,6 +37,10 @@ func (converter) Convert(_ context.Context, conf *confmap.Conf) error {
 func expandStringValues(value any) any {
 	switch v := value.(type) {
 	case string:
+		if envRefRegexp.MatchString(v) {
+			// The value is a single environment variable, keep the type of its value.
+			return typedValue(expandEnv(v))
+		}
 		return expandEnv(v)
 	case []any:
 		nslice := make([]any, 0, len(v))
@@ -48,6 +59,17 @@ func expandStringValues(value any) any {
 	}
 }
 
+// typedValue returns s as an int if it is the canonical representation of one, otherwise s. The integers
+// are decoded back to s by the string fields, and the strings are decoded by the other fields of the
+// configurations. Other types are not converted: the booleans are decoded as "1" or "0" by the string
+// fields, and the floats cannot be told apart from strings like versions.
+func typedValue(s string) any {
+	if i, err := strconv.Atoi(s); err == nil && strconv.Itoa(i) == s {
+		return i
+	}
+	return s
+}
+
 func expandEnv(s string) string {
 	return os.Expand(s, func(str string) string {
 		// This allows escaping environment variable substitution via $$, e.g.
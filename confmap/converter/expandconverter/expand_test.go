@@ -159,3 +159,52 @@ func TestNewExpandConverterHostPort(t *testing.T) {
 		})
 	}
 }
+
+func TestNewExpandConverterTypedValues(t *testing.T) {
+	t.Setenv("INT", "4317")
+	t.Setenv("BOOL", "true")
+	t.Setenv("LEADING_ZERO", "0123")
+	t.Setenv("FLOAT", "1.10")
+
+	conf := confmap.NewFromStringMap(map[string]any{
+		"int":          "${INT}",
+		"bool":         "$BOOL",
+		"leading_zero": "${LEADING_ZERO}",
+		"float":        "${FLOAT}",
+		"embedded":     "port_${INT}",
+		"escaped":      "$${env:INT}",
+	})
+	require.NoError(t, New().Convert(context.Background(), conf))
+	assert.Equal(t, map[string]any{
+		"int":          4317,
+		"bool":         "true",
+		"leading_zero": "0123",
+		"float":        "1.10",
+		"embedded":     "port_4317",
+		"escaped":      "${env:INT}",
+	}, conf.ToStringMap())
+}
+
+func TestNewExpandConverterTypedValuesDecoded(t *testing.T) {
+	t.Setenv("INT", "4317")
+	t.Setenv("BOOL", "true")
+
+	conf := confmap.NewFromStringMap(map[string]any{
+		"int":         "${INT}",
+		"int_string":  "${INT}",
+		"bool":        "${BOOL}",
+		"bool_string": "${BOOL}",
+	})
+	require.NoError(t, New().Convert(context.Background(), conf))
+	var cfg struct {
+		Int        int    `mapstructure:"int"`
+		IntString  string `mapstructure:"int_string"`
+		Bool       bool   `mapstructure:"bool"`
+		BoolString string `mapstructure:"bool_string"`
+	}
+	require.NoError(t, conf.Unmarshal(&cfg))
+	assert.Equal(t, 4317, cfg.Int)
+	assert.Equal(t, "4317", cfg.IntString)
+	assert.True(t, cfg.Bool)
+	assert.Equal(t, "true", cfg.BoolString)
+}
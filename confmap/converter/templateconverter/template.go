@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package templateconverter // import "go.opentelemetry.io/collector/confmap/converter/templateconverter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	// templatesKey is the top-level key of the templates, removed once they are instantiated.
+	templatesKey = "templates"
+
+	configKey    = "config"
+	instancesKey = "instances"
+)
+
+type converter struct{}
+
+// New returns a confmap.Converter, that instantiates the templates of the "templates" section of a
+// confmap.Conf, and removes the section. Every template holds a "config", merged into the confmap.Conf
+// once for each of its "instances", with the text/template actions of its keys and string values
+// executed with the parameters of the instance, e.g. "traces/{{ .tenant }}".
+//
+// Notice: This API is experimental.
+func New() confmap.Converter {
+	return converter{}
+}
+
+type templateConfig struct {
+	Config    map[string]any   `mapstructure:"config"`
+	Instances []map[string]any `mapstructure:"instances"`
+}
+
+func (converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	if !conf.IsSet(templatesKey) {
+		return nil
+	}
+	sub, err := conf.Sub(templatesKey)
+	if err != nil {
+		return confmap.NewKeyError(conf, templatesKey, err)
+	}
+	var templates map[string]templateConfig
+	if err = sub.Unmarshal(&templates, confmap.WithErrorUnused()); err != nil {
+		return confmap.NewKeyError(conf, templatesKey, err)
+	}
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		tmpl := templates[name]
+		key := templatesKey + confmap.KeyDelimiter + name
+		if tmpl.Config == nil {
+			return confmap.NewKeyError(conf, key, errors.New("the template has no config"))
+		}
+		for i, params := range tmpl.Instances {
+			rendered, err := render(tmpl.Config, params)
+			if err != nil {
+				return confmap.NewKeyError(conf, key, fmt.Errorf("instance %d: %w", i, err))
+			}
+			instance := confmap.NewFromStringMap(rendered.(map[string]any))
+			if err = checkConflicts(conf, instance); err != nil {
+				return confmap.NewKeyError(conf, key, fmt.Errorf("instance %d: %w", i, err))
+			}
+			if err = conf.Merge(instance); err != nil {
+				return err
+			}
+		}
+	}
+	conf.Delete(templatesKey)
+	return nil
+}
+
+// render executes the text/template actions of the keys and string values of value with params.
+func render(value any, params map[string]any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		return renderString(v, params)
+	case []any:
+		nslice := make([]any, 0, len(v))
+		for _, vint := range v {
+			val, err := render(vint, params)
+			if err != nil {
+				return nil, err
+			}
+			nslice = append(nslice, val)
+		}
+		return nslice, nil
+	case map[string]any:
+		nmap := make(map[string]any, len(v))
+		for mk, mv := range v {
+			key, err := renderString(mk, params)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := nmap[key]; ok {
+				return nil, fmt.Errorf("several keys are rendered as %q", key)
+			}
+			if nmap[key], err = render(mv, params); err != nil {
+				return nil, err
+			}
+		}
+		return nmap, nil
+	}
+	return value, nil
+}
+
+func renderString(s string, params map[string]any) (string, error) {
+	if !strings.Contains(s, "{{") {
+		return s, nil
+	}
+	tmpl, err := template.New("").Option("missingkey=error").Parse(s)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	if err = tmpl.Execute(&sb, params); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// checkConflicts returns an error if the instance sets a key of conf to a different value.
+func checkConflicts(conf *confmap.Conf, instance *confmap.Conf) error {
+	for _, k := range instance.AllKeys() {
+		if conf.IsSet(k) && !reflect.DeepEqual(conf.Get(k), instance.Get(k)) {
+			return fmt.Errorf("%q is already set to a different value", k)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package templateconverter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestConvert(t *testing.T) {
+	conf, err := confmaptest.LoadConf(filepath.Join("testdata", "templates.yaml"))
+	require.NoError(t, err)
+	expected, err := confmaptest.LoadConf(filepath.Join("testdata", "instantiated.yaml"))
+	require.NoError(t, err)
+
+	require.NoError(t, New().Convert(context.Background(), conf))
+	assert.Equal(t, expected.ToStringMap(), conf.ToStringMap())
+}
+
+func TestConvertWithoutTemplates(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{"receivers": map[string]any{"otlp/{{ .tenant }}": nil}})
+	require.NoError(t, New().Convert(context.Background(), conf))
+	assert.Equal(t, map[string]any{"receivers": map[string]any{"otlp/{{ .tenant }}": nil}}, conf.ToStringMap())
+}
+
+func TestConvertErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		templates   any
+		conf        map[string]any
+		expectedErr string
+	}{
+		{
+			name:        "invalid section",
+			templates:   []any{"tenant"},
+			expectedErr: "templates: unexpected sub-config value kind for key:templates value:[tenant] kind:slice)",
+		},
+		{
+			name:        "unknown key",
+			templates:   map[string]any{"tenant": map[string]any{"parameters": []any{"tenant"}}},
+			expectedErr: "templates: 1 error(s) decoding:\n\n* '[tenant]' has invalid keys: parameters",
+		},
+		{
+			name:        "missing config",
+			templates:   map[string]any{"tenant": map[string]any{"instances": []any{map[string]any{"tenant": "a"}}}},
+			expectedErr: "templates::tenant: the template has no config",
+		},
+		{
+			name: "missing parameter",
+			templates: map[string]any{"tenant": map[string]any{
+				"config":    map[string]any{"exporters": map[string]any{"otlp/{{ .tenant }}": nil}},
+				"instances": []any{map[string]any{"tenant": "a"}, map[string]any{"name": "b"}},
+			}},
+			expectedErr: `templates::tenant: instance 1: template: :1:8: executing "" at <.tenant>: map has no entry for key "tenant"`,
+		},
+		{
+			name: "invalid template",
+			templates: map[string]any{"tenant": map[string]any{
+				"config":    map[string]any{"exporters": map[string]any{"otlp/{{ .tenant": nil}},
+				"instances": []any{map[string]any{"tenant": "a"}},
+			}},
+			expectedErr: `templates::tenant: instance 0: template: :1: unclosed action`,
+		},
+		{
+			name: "duplicate keys",
+			templates: map[string]any{"tenant": map[string]any{
+				"config":    map[string]any{"exporters": map[string]any{"otlp/{{ .tenant }}": nil, "otlp/a": nil}},
+				"instances": []any{map[string]any{"tenant": "a"}},
+			}},
+			expectedErr: `templates::tenant: instance 0: several keys are rendered as "otlp/a"`,
+		},
+		{
+			name: "conflict",
+			templates: map[string]any{"tenant": map[string]any{
+				"config":    map[string]any{"exporters": map[string]any{"otlp/{{ .tenant }}": map[string]any{"endpoint": "{{ .endpoint }}"}}},
+				"instances": []any{map[string]any{"tenant": "a", "endpoint": "b:4317"}},
+			}},
+			conf:        map[string]any{"exporters": map[string]any{"otlp/a": map[string]any{"endpoint": "a:4317"}}},
+			expectedErr: `templates::tenant: instance 0: "exporters::otlp/a::endpoint" is already set to a different value`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]any{"templates": tt.templates}
+			for k, v := range tt.conf {
+				raw[k] = v
+			}
+			assert.EqualError(t, New().Convert(context.Background(), confmap.NewFromStringMap(raw)), tt.expectedErr)
+		})
+	}
+}
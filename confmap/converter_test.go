@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// appendConverter appends its name to the "applied" key.
+type appendConverter string
+
+func (c appendConverter) Convert(_ context.Context, conf *Conf) error {
+	applied, _ := conf.Get("applied").([]any)
+	return conf.Merge(NewFromStringMap(map[string]any{"applied": append(applied, string(c))}))
+}
+
+// failingConverter returns its error.
+type failingConverter struct {
+	err error
+}
+
+func (c failingConverter) Convert(context.Context, *Conf) error {
+	return c.err
+}
+
+func TestConverterChain(t *testing.T) {
+	chain := NewConverterChain()
+	require.NoError(t, chain.Register("expand", appendConverter("expand")))
+	require.NoError(t, chain.Register("legacy", appendConverter("legacy"), WithConvertBefore("expand", "unknown")))
+	require.NoError(t, chain.Register("normalize", appendConverter("normalize")))
+	require.NoError(t, chain.Register("defaults", appendConverter("defaults"), WithConvertAfter("normalize"), WithConvertBefore("expand")))
+	assert.Equal(t, []string{"legacy", "normalize", "defaults", "expand"}, chain.Names())
+
+	conf := New()
+	require.NoError(t, chain.Convert(context.Background(), conf))
+	assert.Equal(t, []any{"legacy", "normalize", "defaults", "expand"}, conf.Get("applied"))
+}
+
+func TestConverterChainErrors(t *testing.T) {
+	chain := NewConverterChain()
+	require.NoError(t, chain.Register("first", appendConverter("first")))
+	require.NoError(t, chain.Register("second", appendConverter("second"), WithConvertAfter("first")))
+
+	assert.EqualError(t, chain.Register("", appendConverter("")), "the name of the converter must not be empty")
+	assert.EqualError(t, chain.Register("first", appendConverter("first")), `converter "first" is already registered`)
+	assert.EqualError(t, chain.Register("third", appendConverter("third"), WithConvertAfter("second"), WithConvertBefore("first")),
+		`the order of the converters "first", "second", "third" is cyclic`)
+	// The chain is unchanged by a failed registration.
+	assert.Equal(t, []string{"first", "second"}, chain.Names())
+
+	errConvert := errors.New("legacy key")
+	require.NoError(t, chain.Register("failing", failingConverter{err: errConvert}, WithConvertBefore("second")))
+	err := chain.Convert(context.Background(), New())
+	assert.EqualError(t, err, `converter "failing": legacy key`)
+	assert.ErrorIs(t, err, errConvert)
+}
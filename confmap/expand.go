@@ -84,8 +84,8 @@ func findURI(input string) string {
 	remaining := input[closeIndex+1:]
 	openIndex := strings.LastIndex(input[:closeIndex+1], "${")
 
-	// if there is a missing "${" or the uri does not contain ":", check the next URI.
-	if openIndex < 0 || !strings.Contains(input[openIndex:closeIndex+1], ":") {
+	// if there is a missing "${", the "${" is escaped or the uri does not contain ":", check the next URI.
+	if openIndex < 0 || isEscaped(input, openIndex) || !strings.Contains(input[openIndex:closeIndex+1], ":") {
 		// if remaining does not contain "}", there are no URIs left: stop recursion.
 		if !strings.Contains(remaining, "}") {
 			return ""
@@ -96,6 +96,37 @@ func findURI(input string) string {
 	return input[openIndex : closeIndex+1]
 }
 
+// isEscaped reports whether the "${" at index i of input is escaped by a preceding "$".
+// As "$$" is an escaped "$", the "${" is escaped when preceded by an odd number of "$".
+func isEscaped(input string, i int) bool {
+	n := 0
+	for j := i - 1; j >= 0 && input[j] == '$'; j-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// replaceURI replaces the occurrences of uri in input which are not escaped.
+func replaceURI(input, uri, repl string) string {
+	var sb strings.Builder
+	last := 0
+	for i := 0; ; {
+		j := strings.Index(input[i:], uri)
+		if j < 0 {
+			break
+		}
+		j += i
+		if !isEscaped(input, j) {
+			sb.WriteString(input[last:j])
+			sb.WriteString(repl)
+			last = j + len(uri)
+		}
+		i = j + len(uri)
+	}
+	sb.WriteString(input[last:])
+	return sb.String()
+}
+
 // findAndExpandURI attempts to find and expand the first occurrence of an expandable URI in input. If an expandable URI is found it
 // returns the input with the URI expanded, true and nil. Otherwise, it returns the unchanged input, false and the expanding error.
 func (mr *Resolver) findAndExpandURI(ctx context.Context, input string) (any, bool, error) {
@@ -117,7 +148,7 @@ func (mr *Resolver) findAndExpandURI(ctx context.Context, input string) (any, bo
 	if err != nil {
 		return input, false, err
 	}
-	return strings.ReplaceAll(input, uri, repl), changed, err
+	return replaceURI(input, uri, repl), changed, err
 }
 
 // toString attempts to convert input to a string.
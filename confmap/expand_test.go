@@ -232,6 +232,27 @@ func TestResolverExpandStringValues(t *testing.T) {
 			input:  "${test:localhost:${PORT}}",
 			output: "${test:localhost:${PORT}}",
 		},
+		// Escaped.
+		{
+			name:   "Escaped",
+			input:  "$${env:HOST}",
+			output: "$${env:HOST}",
+		},
+		{
+			name:   "EscapedAndExpanded",
+			input:  "$${env:HOST}:${env:HOST}",
+			output: "$${env:HOST}:localhost",
+		},
+		{
+			name:   "EscapedDollarBeforeURI",
+			input:  "$$${env:HOST}",
+			output: "$$localhost",
+		},
+		{
+			name:   "EscapedTwice",
+			input:  "$$$${env:HOST}",
+			output: "$$$${env:HOST}",
+		},
 		// Partial expand.
 		{
 			name:   "PartialMatchMissingOpeningBracketFirst",
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap // import "go.opentelemetry.io/collector/confmap"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// includeKey is the key of the maps including the configurations retrieved from one or more URIs.
+const includeKey = "$include"
+
+// resolveIncludes replaces the maps of conf with an includeKey by the configurations retrieved from
+// its URIs merged in order, with the other keys of the map merged over them. uri is the location conf
+// is retrieved from.
+func (mr *Resolver) resolveIncludes(ctx context.Context, conf *Conf, uri location) (*Conf, error) {
+	positions := make(map[string]Position)
+	val, changed, err := mr.includeValue(ctx, conf.ToStringMap(), "", []string{uri.asString()}, positions)
+	if err != nil || !changed {
+		return conf, err
+	}
+	ret := NewFromStringMap(val.(map[string]any))
+	// The positions of the keys of conf override the positions of the included keys.
+	ret.setPositions(positions)
+	ret.setPositions(conf.positions)
+	return ret, nil
+}
+
+// includeValue resolves the includes of value, found at path. stack holds the locations of the
+// configurations being included, to detect the cycles. The positions of the included keys are added
+// to positions, unless it is nil.
+func (mr *Resolver) includeValue(ctx context.Context, value any, path string, stack []string, positions map[string]Position) (any, bool, error) {
+	switch v := value.(type) {
+	case []any:
+		nslice := make([]any, 0, len(v))
+		nchanged := false
+		for _, vint := range v {
+			// The keys of the elements of the lists have no position.
+			val, changed, err := mr.includeValue(ctx, vint, "", stack, nil)
+			if err != nil {
+				return nil, false, err
+			}
+			nslice = append(nslice, val)
+			nchanged = nchanged || changed
+		}
+		return nslice, nchanged, nil
+	case map[string]any:
+		nmap := make(map[string]any, len(v))
+		nchanged := false
+		for mk, mv := range v {
+			if mk == includeKey {
+				continue
+			}
+			val, changed, err := mr.includeValue(ctx, mv, path+mk+KeyDelimiter, stack, positions)
+			if err != nil {
+				return nil, false, err
+			}
+			nmap[mk] = val
+			nchanged = nchanged || changed
+		}
+		include, ok := v[includeKey]
+		if !ok {
+			return nmap, nchanged, nil
+		}
+		uris, err := includeURIs(include)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid %s at %q: %w", includeKey, strings.TrimSuffix(path, KeyDelimiter), err)
+		}
+		merged := New()
+		for _, uri := range uris {
+			included, err := mr.include(ctx, uri, path, stack, positions)
+			if err != nil {
+				return nil, false, err
+			}
+			if err = merged.Merge(NewFromStringMap(included)); err != nil {
+				return nil, false, err
+			}
+		}
+		if err = merged.Merge(NewFromStringMap(nmap)); err != nil {
+			return nil, false, err
+		}
+		return merged.ToStringMap(), true, nil
+	}
+	return value, false, nil
+}
+
+// include returns the configuration retrieved from uri, with its includes resolved.
+func (mr *Resolver) include(ctx context.Context, uri string, path string, stack []string, positions map[string]Position) (map[string]any, error) {
+	lURI, err := newConfigLocation(uri, mr.providers)
+	if err != nil {
+		return nil, fmt.Errorf("cannot include %q: %w", uri, err)
+	}
+	for i, s := range stack {
+		if s == lURI.asString() {
+			return nil, fmt.Errorf("cyclic include: %s", strings.Join(append(stack[i:len(stack):len(stack)], s), " -> "))
+		}
+	}
+	ret, err := mr.retrieveValue(ctx, lURI)
+	if err != nil {
+		return nil, fmt.Errorf("cannot include %q: %w", uri, err)
+	}
+	mr.closers = append(mr.closers, ret.Close)
+	raw, err := ret.AsRaw()
+	if err != nil {
+		return nil, fmt.Errorf("cannot include %q: %w", uri, err)
+	}
+	if raw == nil {
+		return map[string]any{}, nil
+	}
+	included, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("cannot include %q: retrieved value (type=%T) is not a map", uri, raw)
+	}
+	if positions != nil {
+		for k, pos := range retrievedPositions(ret, lURI, path) {
+			positions[k] = pos
+		}
+	}
+	val, _, err := mr.includeValue(ctx, included, path, append(stack[:len(stack):len(stack)], lURI.asString()), positions)
+	if err != nil {
+		return nil, err
+	}
+	return val.(map[string]any), nil
+}
+
+// includeURIs returns the URIs of the value of an includeKey, a URI or a list of URIs.
+func includeURIs(value any) ([]string, error) {
+	switch v := value.(type) {
+	case string:
+		return []string{v}, nil
+	case []any:
+		uris := make([]string, 0, len(v))
+		for _, e := range v {
+			uri, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("the URI %v is not a string", e)
+			}
+			uris = append(uris, uri)
+		}
+		return uris, nil
+	}
+	return nil, fmt.Errorf("the value must be a URI or a list of URIs, got %T", value)
+}
+
+// retrievedPositions returns the positions of the keys of ret, retrieved from uri, prefixed with path.
+// The positions without a URI are set to uri.
+func retrievedPositions(ret *Retrieved, uri location, path string) map[string]Position {
+	positions := make(map[string]Position, len(ret.positions))
+	for k, pos := range ret.positions {
+		if pos.URI == "" {
+			pos.URI = uri.asString()
+		}
+		positions[path+k] = pos
+	}
+	return positions
+}
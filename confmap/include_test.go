@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolverInclude(t *testing.T) {
+	resolver, err := NewResolver(ResolverSettings{
+		URIs:      []string{"file:testdata/include-main.yaml"},
+		Providers: makeMapProvidersMap(newFileProvider(t)),
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+
+	// The keys of the including map override the included keys.
+	assert.Equal(t, map[string]any{
+		"exporters": map[string]any{
+			"otlp":    map[string]any{"endpoint": "main:4317", "timeout": "10s"},
+			"logging": nil,
+		},
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"traces": map[string]any{"receivers": []any{"otlp"}, "exporters": []any{"otlp"}},
+				"logs":   map[string]any{"receivers": []any{"otlp"}, "exporters": []any{"logging"}},
+			},
+		},
+	}, conf.ToStringMap())
+}
+
+func TestResolverIncludePositions(t *testing.T) {
+	main := map[string]any{"exporters": map[string]any{includeKey: "included:", "otlp": map[string]any{"endpoint": "main:4317"}}}
+	included := map[string]any{"otlp": map[string]any{"endpoint": "common:4317", "timeout": "10s"}}
+	resolver, err := NewResolver(ResolverSettings{
+		URIs: []string{"main:"},
+		Providers: makeMapProvidersMap(
+			newFakeProvider("main", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(main, WithRetrievedPositions(map[string]Position{
+					"exporters":                 {Line: 1, Column: 1},
+					"exporters::otlp::endpoint": {Line: 4, Column: 5},
+				}))
+			}),
+			newFakeProvider("included", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(included, WithRetrievedPositions(map[string]Position{
+					"otlp::endpoint": {Line: 2, Column: 3},
+					"otlp::timeout":  {Line: 3, Column: 3},
+				}))
+			})),
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+
+	pos, ok := conf.Position("exporters::otlp::endpoint")
+	require.True(t, ok)
+	assert.Equal(t, Position{URI: "main:", Line: 4, Column: 5}, pos)
+	pos, ok = conf.Position("exporters::otlp::timeout")
+	require.True(t, ok)
+	assert.Equal(t, Position{URI: "included:", Line: 3, Column: 3}, pos)
+}
+
+func TestResolverIncludeErrors(t *testing.T) {
+	provider := func(scheme string, conf any) Provider {
+		return newFakeProvider(scheme, func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+			return NewRetrieved(conf)
+		})
+	}
+	tests := []struct {
+		name        string
+		uri         string
+		expectedErr string
+	}{
+		{
+			name:        "cycle",
+			uri:         "file:testdata/include-cycle-a.yaml",
+			expectedErr: "cyclic include: file:testdata/include-cycle-a.yaml -> file:testdata/include-cycle-b.yaml -> file:testdata/include-cycle-a.yaml",
+		},
+		{
+			name:        "invalid value",
+			uri:         "invalid:",
+			expectedErr: `invalid $include at "receivers": the value must be a URI or a list of URIs, got int`,
+		},
+		{
+			name:        "invalid list",
+			uri:         "invalidlist:",
+			expectedErr: `invalid $include at "": the URI 1 is not a string`,
+		},
+		{
+			name:        "not a map",
+			uri:         "list:",
+			expectedErr: `cannot include "scalar:": retrieved value (type=string) is not a map`,
+		},
+		{
+			name:        "unsupported scheme",
+			uri:         "unsupported:",
+			expectedErr: `cannot include "s3:bucket": unsupported scheme on URI "s3:bucket"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver, err := NewResolver(ResolverSettings{
+				URIs: []string{tt.uri},
+				Providers: makeMapProvidersMap(
+					newFileProvider(t),
+					provider("invalid", map[string]any{"receivers": map[string]any{includeKey: 1}}),
+					provider("invalidlist", map[string]any{includeKey: []any{1}}),
+					provider("list", map[string]any{"receivers": []any{map[string]any{includeKey: "scalar:"}}}),
+					provider("scalar", "value"),
+					provider("unsupported", map[string]any{includeKey: "s3:bucket"})),
+			})
+			require.NoError(t, err)
+			_, err = resolver.Resolve(context.Background())
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap // import "go.opentelemetry.io/collector/confmap"
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Position is the position of a key in the configuration it is retrieved from.
+type Position struct {
+	// URI is the URI of the configuration, empty if unknown.
+	URI string
+	// Line and Column start at 1.
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	if p.URI == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.URI, p.Line, p.Column)
+}
+
+// WithRetrievedPositions sets the positions of the keys of the retrieved configuration, by key with
+// KeyDelimiter separators, which the Resolver keeps in the resolved Conf. The Resolver sets the URI of
+// the positions without one to the URI the configuration is retrieved from.
+func WithRetrievedPositions(positions map[string]Position) RetrievedOption {
+	return func(settings *retrievedSettings) {
+		settings.positions = positions
+	}
+}
+
+// Position returns the position of the key, or of its closest parent with a known position.
+// The positions are only known for the keys of the configurations retrieved with positions,
+// they are kept when the configurations are merged.
+func (l *Conf) Position(key string) (Position, bool) {
+	for {
+		if pos, ok := l.positions[key]; ok {
+			return pos, true
+		}
+		i := strings.LastIndex(key, KeyDelimiter)
+		if i < 0 {
+			return Position{}, false
+		}
+		key = key[:i]
+	}
+}
+
+// setPositions sets the positions of the keys, overriding the previous positions of the same keys.
+func (l *Conf) setPositions(positions map[string]Position) {
+	if len(positions) == 0 {
+		return
+	}
+	if l.positions == nil {
+		l.positions = make(map[string]Position, len(positions))
+	}
+	for k, pos := range positions {
+		l.positions[k] = pos
+	}
+}
+
+// KeyError is an error about a key of the configuration, with the position of the key if known.
+type KeyError struct {
+	Key      string
+	Position *Position
+	Err      error
+}
+
+// NewKeyError returns a KeyError about the key of conf, with its position in conf.
+func NewKeyError(conf *Conf, key string, err error) *KeyError {
+	ke := &KeyError{Key: key, Err: err}
+	if pos, ok := conf.Position(key); ok {
+		ke.Position = &pos
+	}
+	return ke
+}
+
+func (e *KeyError) Error() string {
+	if e.Position == nil {
+		return fmt.Sprintf("%s: %v", e.Key, e.Err)
+	}
+	return fmt.Sprintf("%s: %s: %v", e.Position, e.Key, e.Err)
+}
+
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPositionString(t *testing.T) {
+	assert.Equal(t, "3:5", Position{Line: 3, Column: 5}.String())
+	assert.Equal(t, "file:config.yaml:3:5", Position{URI: "file:config.yaml", Line: 3, Column: 5}.String())
+}
+
+func TestResolverPositions(t *testing.T) {
+	provider := func(scheme string, positions map[string]Position) Provider {
+		return newFakeProvider(scheme, func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+			return NewRetrieved(map[string]any{
+				"receivers": map[string]any{"otlp": map[string]any{"endpoint": "localhost:4317"}},
+			}, WithRetrievedPositions(positions))
+		})
+	}
+	resolver, err := NewResolver(ResolverSettings{
+		URIs: []string{"first:config", "second:config"},
+		Providers: makeMapProvidersMap(
+			provider("first", map[string]Position{
+				"receivers":                   {Line: 1, Column: 1},
+				"receivers::otlp":             {Line: 2, Column: 3},
+				"receivers::otlp::endpoint":   {Line: 3, Column: 5},
+				"receivers::otlp::unresolved": {URI: "other", Line: 4, Column: 5},
+			}),
+			provider("second", map[string]Position{"receivers::otlp::endpoint": {Line: 7, Column: 5}})),
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+
+	pos, ok := conf.Position("receivers::otlp")
+	require.True(t, ok)
+	assert.Equal(t, Position{URI: "first:config", Line: 2, Column: 3}, pos)
+	// The positions of the configurations merged last override the previous ones.
+	pos, ok = conf.Position("receivers::otlp::endpoint")
+	require.True(t, ok)
+	assert.Equal(t, Position{URI: "second:config", Line: 7, Column: 5}, pos)
+	pos, ok = conf.Position("receivers::otlp::unresolved")
+	require.True(t, ok)
+	assert.Equal(t, Position{URI: "other", Line: 4, Column: 5}, pos)
+	// The unknown keys have the position of their closest parent.
+	pos, ok = conf.Position("receivers::otlp::tls::insecure")
+	require.True(t, ok)
+	assert.Equal(t, Position{URI: "first:config", Line: 2, Column: 3}, pos)
+	_, ok = conf.Position("exporters")
+	assert.False(t, ok)
+
+	sub, err := conf.Sub("receivers")
+	require.NoError(t, err)
+	pos, ok = sub.Position("otlp")
+	require.True(t, ok)
+	assert.Equal(t, Position{URI: "first:config", Line: 2, Column: 3}, pos)
+	_, ok = sub.Position("receivers")
+	assert.False(t, ok)
+}
+
+func TestKeyError(t *testing.T) {
+	conf := NewFromStringMap(map[string]any{"receivers": map[string]any{"otlp": nil}})
+	conf.setPositions(map[string]Position{"receivers::otlp": {URI: "file:config.yaml", Line: 2, Column: 3}})
+	errUnsupported := errors.New("unsupported")
+
+	err := NewKeyError(conf, "receivers::otlp::endpoint", errUnsupported)
+	assert.EqualError(t, err, "file:config.yaml:2:3: receivers::otlp::endpoint: unsupported")
+	assert.ErrorIs(t, err, errUnsupported)
+
+	assert.EqualError(t, NewKeyError(conf, "exporters", errUnsupported), "exporters: unsupported")
+}
@@ -77,10 +77,12 @@ type ChangeEvent struct {
 type Retrieved struct {
 	rawConf   any
 	closeFunc CloseFunc
+	positions map[string]Position
 }
 
 type retrievedSettings struct {
 	closeFunc CloseFunc
+	positions map[string]Position
 }
 
 // RetrievedOption options to customize Retrieved values.
@@ -107,7 +109,7 @@ func NewRetrieved(rawConf any, opts ...RetrievedOption) (*Retrieved, error) {
 	for _, opt := range opts {
 		opt(&set)
 	}
-	return &Retrieved{rawConf: rawConf, closeFunc: set.closeFunc}, nil
+	return &Retrieved{rawConf: rawConf, closeFunc: set.closeFunc, positions: set.positions}, nil
 }
 
 // AsConf returns the retrieved configuration parsed as a Conf.
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awssmprovider // import "go.opentelemetry.io/collector/confmap/provider/awssmprovider"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/secretsprovider"
+)
+
+const (
+	schemeName = "awssm"
+
+	serviceName = "secretsmanager"
+)
+
+type secretsManager struct {
+	region   string
+	endpoint string
+	creds    credentials
+	client   *http.Client
+	now      func() time.Time
+}
+
+// New returns a new confmap.Provider that reads secrets from AWS Secrets Manager.
+//
+// This Provider supports "awssm" scheme, and can be called with a selector:
+// `awssm:SECRET_ID` or `awssm:SECRET_ID#KEY`, e.g. `${awssm:otelcol/api#key}`, where SECRET_ID
+// is the name or the ARN of the secret. When KEY is set, the secret string must be a JSON object
+// and the value of KEY is used, otherwise the whole secret string is used.
+//
+// The provider is configured with the environment variables used by the AWS CLI:
+// AWS_REGION or AWS_DEFAULT_REGION, AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN.
+// AWS_ENDPOINT_URL_SECRETS_MANAGER overrides the endpoint of the region.
+func New() confmap.Provider {
+	return secretsprovider.New(newSecretsManager())
+}
+
+func newSecretsManager() *secretsManager {
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	endpoint := os.Getenv("AWS_ENDPOINT_URL_SECRETS_MANAGER")
+	if endpoint == "" && region != "" {
+		endpoint = "https://" + serviceName + "." + region + ".amazonaws.com"
+	}
+	return &secretsManager{
+		region:   region,
+		endpoint: endpoint,
+		creds: credentials{
+			accessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			secretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			sessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		},
+		client: http.DefaultClient,
+		now:    time.Now,
+	}
+}
+
+func (*secretsManager) Scheme() string {
+	return schemeName
+}
+
+// getSecretValueOutput is the subset of the response of the GetSecretValue API used by the provider.
+type getSecretValueOutput struct {
+	SecretString *string `json:"SecretString"`
+}
+
+func (sm *secretsManager) GetSecret(ctx context.Context, selector string) (string, error) {
+	secretID, key, hasKey := strings.Cut(selector, "#")
+	if secretID == "" || (hasKey && key == "") {
+		return "", errors.New("uri must have the form awssm:SECRET_ID or awssm:SECRET_ID#KEY")
+	}
+	if sm.region == "" {
+		return "", errors.New("AWS_REGION or AWS_DEFAULT_REGION must be set")
+	}
+	if sm.creds.accessKeyID == "" || sm.creds.secretAccessKey == "" {
+		return "", errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set")
+	}
+
+	body, err := json.Marshal(map[string]string{"SecretId": secretID})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sm.endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	signRequest(req, body, sm.creds, sm.region, serviceName, sm.now())
+
+	resp, err := sm.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	var out getSecretValueOutput
+	if err = json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", err
+	}
+	if out.SecretString == nil {
+		return "", errors.New("binary secrets are not supported")
+	}
+	if !hasKey {
+		return *out.SecretString, nil
+	}
+
+	var fields map[string]any
+	if err = json.Unmarshal([]byte(*out.SecretString), &fields); err != nil {
+		// Do not wrap the error, it may contain a part of the secret.
+		return "", errors.New("secret string is not a JSON object")
+	}
+	value, ok := fields[key]
+	if !ok {
+		return "", fmt.Errorf("secret has no key %q", key)
+	}
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+	return fmt.Sprint(value), nil
+}
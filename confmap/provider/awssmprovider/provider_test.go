@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awssmprovider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecrets = map[string]string{
+	"otelcol/plain": "s3cr3t",
+	"otelcol/json":  `{"key": "value", "port": 4317}`,
+	"otelcol/text":  "not json",
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var in struct {
+			SecretID string `json:"SecretId"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		value, ok := testSecrets[in.SecretID]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]string{"SecretString": value}))
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestGetSecret(t *testing.T) {
+	ts := newTestServer(t)
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_ENDPOINT_URL_SECRETS_MANAGER", ts.URL)
+	sm := newSecretsManager()
+
+	tests := []struct {
+		selector string
+		value    string
+		err      string
+	}{
+		{selector: "otelcol/plain", value: "s3cr3t"},
+		{selector: "otelcol/json#key", value: "value"},
+		{selector: "otelcol/json#port", value: "4317"},
+		{selector: "otelcol/json#missing", err: `secret has no key "missing"`},
+		{selector: "otelcol/text#key", err: "secret string is not a JSON object"},
+		{selector: "otelcol/missing", err: "unexpected status code 400"},
+		{selector: "otelcol/json#", err: "uri must have the form awssm:SECRET_ID or awssm:SECRET_ID#KEY"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			value, err := sm.GetSecret(context.Background(), tt.selector)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, value)
+		})
+	}
+}
+
+func TestGetSecretMissingSettings(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	_, err := newSecretsManager().GetSecret(context.Background(), "otelcol/plain")
+	assert.EqualError(t, err, "AWS_REGION or AWS_DEFAULT_REGION must be set")
+
+	t.Setenv("AWS_DEFAULT_REGION", "eu-west-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	sm := newSecretsManager()
+	assert.Equal(t, "https://secretsmanager.eu-west-1.amazonaws.com", sm.endpoint)
+	_, err = sm.GetSecret(context.Background(), "otelcol/plain")
+	assert.EqualError(t, err, "AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set")
+}
+
+func TestRetrieve(t *testing.T) {
+	ts := newTestServer(t)
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_ENDPOINT_URL_SECRETS_MANAGER", ts.URL)
+
+	p := New()
+	assert.Equal(t, "awssm", p.Scheme())
+	ret, err := p.Retrieve(context.Background(), "awssm:otelcol/json#key", nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "value", raw)
+}
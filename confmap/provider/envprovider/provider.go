@@ -13,7 +13,10 @@ import (
 	"go.opentelemetry.io/collector/confmap/provider/internal"
 )
 
-const schemeName = "env"
+const (
+	schemeName       = "env"
+	defaultSeparator = ":-"
+)
 
 type provider struct{}
 
@@ -21,6 +24,9 @@ type provider struct{}
 //
 // This Provider supports "env" scheme, and can be called with a selector:
 // `env:NAME_OF_ENVIRONMENT_VARIABLE`
+//
+// A default value, used when the variable is unset or empty, can be given after ":-":
+// `env:NAME_OF_ENVIRONMENT_VARIABLE:-default`
 func New() confmap.Provider {
 	return &provider{}
 }
@@ -30,7 +36,12 @@ func (emp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFu
 		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
 	}
 
-	return internal.NewRetrievedFromYAML([]byte(os.Getenv(uri[len(schemeName)+1:])))
+	name, defaultValue, hasDefault := strings.Cut(uri[len(schemeName)+1:], defaultSeparator)
+	val := os.Getenv(name)
+	if val == "" && hasDefault {
+		val = defaultValue
+	}
+	return internal.NewRetrievedFromYAML([]byte(val))
 }
 
 func (*provider) Scheme() string {
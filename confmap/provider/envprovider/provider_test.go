@@ -68,3 +68,36 @@ func TestEnv(t *testing.T) {
 
 	assert.NoError(t, env.Shutdown(context.Background()))
 }
+
+func TestEnvDefault(t *testing.T) {
+	const envName = "default-value"
+	env := New()
+
+	ret, err := env.Retrieve(context.Background(), envSchemePrefix+envName+":-localhost:4317", nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:4317", raw)
+
+	ret, err = env.Retrieve(context.Background(), envSchemePrefix+envName+":-4317", nil)
+	require.NoError(t, err)
+	raw, err = ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, 4317, raw)
+
+	t.Setenv(envName, "")
+	ret, err = env.Retrieve(context.Background(), envSchemePrefix+envName+":-true", nil)
+	require.NoError(t, err)
+	raw, err = ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, true, raw)
+
+	t.Setenv(envName, "set")
+	ret, err = env.Retrieve(context.Background(), envSchemePrefix+envName+":-default", nil)
+	require.NoError(t, err)
+	raw, err = ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "set", raw)
+
+	assert.NoError(t, env.Shutdown(context.Background()))
+}
@@ -4,16 +4,87 @@
 package httpsprovider // import "go.opentelemetry.io/collector/confmap/provider/httpsprovider"
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/provider/internal/configurablehttpprovider"
 )
 
+// Option configures the https provider.
+type Option func(*configurablehttpprovider.Settings)
+
+// WithCACertificate adds the CA certificate in caFile to the system pool used to verify the server.
+func WithCACertificate(caFile string) Option {
+	return func(set *configurablehttpprovider.Settings) {
+		set.CAFile = caFile
+	}
+}
+
+// WithClientCertificate configures the client certificate presented to the server.
+func WithClientCertificate(certFile, keyFile string) Option {
+	return func(set *configurablehttpprovider.Settings) {
+		set.CertFile = certFile
+		set.KeyFile = keyFile
+	}
+}
+
+// WithHeaders adds the given headers to every request.
+func WithHeaders(headers map[string]string) Option {
+	return func(set *configurablehttpprovider.Settings) {
+		set.Headers = headers
+	}
+}
+
+// WithBearerToken authenticates the requests with the given bearer token.
+func WithBearerToken(token string) Option {
+	return func(set *configurablehttpprovider.Settings) {
+		set.BearerToken = token
+	}
+}
+
+// WithBasicAuth authenticates the requests with the given username and password.
+func WithBasicAuth(username, password string) Option {
+	return func(set *configurablehttpprovider.Settings) {
+		set.Username = username
+		set.Password = password
+	}
+}
+
+// WithRetry retries the failed requests up to maxAttempts times, waiting initialInterval
+// before the first retry and doubling the wait on each retry up to maxInterval.
+// Only network errors, 429 and 5xx responses are retried.
+func WithRetry(maxAttempts int, initialInterval, maxInterval time.Duration) Option {
+	return func(set *configurablehttpprovider.Settings) {
+		set.Retry = configurablehttpprovider.RetrySettings{
+			MaxAttempts:     maxAttempts,
+			InitialInterval: initialInterval,
+			MaxInterval:     maxInterval,
+		}
+	}
+}
+
+// WithCacheDir persists the retrieved configurations in dir. The persisted configurations are
+// revalidated with the server using their ETag, and are used when the server cannot be reached.
+func WithCacheDir(dir string) Option {
+	return func(set *configurablehttpprovider.Settings) {
+		set.CacheDir = dir
+	}
+}
+
 // New returns a new confmap.Provider that reads the configuration from a https server.
 //
 // This Provider supports "https" scheme. One example of an HTTPS URI is: https://localhost:3333/getConfig
 //
-// To add extra CA certificates you need to install certificates in the system pool. This procedure is operating system
-// dependent. E.g.: on Linux please refer to the `update-ca-trust` command.
-func New() confmap.Provider {
-	return configurablehttpprovider.New(configurablehttpprovider.HTTPSScheme)
+// The configurations are cached in memory using their ETag, so retrieving an unchanged
+// configuration again does not transfer it.
+//
+// To add extra CA certificates you need to install certificates in the system pool, or use WithCACertificate.
+// Installing certificates in the system pool is operating system dependent. E.g.: on Linux please refer to the
+// `update-ca-trust` command.
+func New(opts ...Option) confmap.Provider {
+	var set configurablehttpprovider.Settings
+	for _, opt := range opts {
+		opt(&set)
+	}
+	return configurablehttpprovider.NewWithSettings(configurablehttpprovider.HTTPSScheme, set)
 }
@@ -4,7 +4,9 @@
 package httpsprovider
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -13,3 +15,16 @@ func TestSupportedScheme(t *testing.T) {
 	fp := New()
 	assert.Equal(t, "https", fp.Scheme())
 }
+
+func TestOptions(t *testing.T) {
+	fp := New(
+		WithCACertificate("ca.crt"),
+		WithClientCertificate("client.crt", "client.key"),
+		WithHeaders(map[string]string{"X-Fleet": "edge"}),
+		WithBearerToken("token"),
+		WithRetry(3, time.Second, 10*time.Second),
+		WithCacheDir("cache"),
+	)
+	assert.Equal(t, "https", fp.Scheme())
+	assert.NoError(t, fp.Shutdown(context.Background()))
+}
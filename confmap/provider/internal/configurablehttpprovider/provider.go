@@ -5,14 +5,18 @@ package configurablehttpprovider // import "go.opentelemetry.io/collector/confma
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/provider/internal"
@@ -27,8 +31,58 @@ const (
 
 type provider struct {
 	scheme             SchemeType
+	settings           Settings
 	caCertPath         string // Used for tests
 	insecureSkipVerify bool   // Used for tests
+
+	mu    sync.Mutex
+	cache map[string]*cacheEntry
+}
+
+// Settings holds the optional settings of the provider.
+type Settings struct {
+	// CAFile is the path of an additional CA certificate used to verify the server, only used by the https scheme.
+	CAFile string
+
+	// CertFile and KeyFile are the paths of the client certificate and key, only used by the https scheme.
+	CertFile string
+	KeyFile  string
+
+	// Headers are added to every request.
+	Headers map[string]string
+
+	// BearerToken, if set, is sent in the Authorization header.
+	BearerToken string
+
+	// Username and Password, if set, are sent using basic authentication. Ignored when BearerToken is set.
+	Username string
+	Password string
+
+	// Retry configures the retries of the failed requests.
+	Retry RetrySettings
+
+	// CacheDir, if set, is the directory where the last retrieved configurations are persisted.
+	// A persisted configuration is used to revalidate the configuration with the server and
+	// is returned when the server cannot be reached.
+	CacheDir string
+}
+
+// RetrySettings configures the retries of the failed requests.
+type RetrySettings struct {
+	// MaxAttempts is the maximum number of attempts. Zero and one disable the retries.
+	MaxAttempts int
+
+	// InitialInterval is the duration to wait before the first retry, doubled on each retry.
+	InitialInterval time.Duration
+
+	// MaxInterval is the maximum duration to wait between two attempts.
+	MaxInterval time.Duration
+}
+
+// cacheEntry is a configuration previously retrieved from the server.
+type cacheEntry struct {
+	etag string
+	body []byte
 }
 
 // New returns a new provider that reads the configuration from http server using the configured transport mechanism
@@ -39,7 +93,17 @@ type provider struct {
 // One example for https-uri: https://localhost:3333/getConfig
 // This is used by the http and https external implementations.
 func New(scheme SchemeType) confmap.Provider {
-	return &provider{scheme: scheme}
+	return NewWithSettings(scheme, Settings{})
+}
+
+// NewWithSettings returns a new provider like New, configured with the given settings.
+func NewWithSettings(scheme SchemeType, set Settings) confmap.Provider {
+	return &provider{
+		scheme:     scheme,
+		settings:   set,
+		caCertPath: set.CAFile,
+		cache:      make(map[string]*cacheEntry),
+	}
 }
 
 // Create the client based on the type of scheme that was selected.
@@ -66,12 +130,22 @@ func (fmp *provider) createClient() (*http.Client, error) {
 			}
 		}
 
+		tlsCfg := &tls.Config{
+			InsecureSkipVerify: fmp.insecureSkipVerify,
+			RootCAs:            pool,
+		}
+
+		if fmp.settings.CertFile != "" || fmp.settings.KeyFile != "" {
+			cert, err := tls.LoadX509KeyPair(fmp.settings.CertFile, fmp.settings.KeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to load the client certificate: %w", err)
+			}
+			tlsCfg.Certificates = []tls.Certificate{cert}
+		}
+
 		return &http.Client{
 			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: fmp.insecureSkipVerify,
-					RootCAs:            pool,
-				},
+				TLSClientConfig: tlsCfg,
 			},
 		}, nil
 	default:
@@ -79,7 +153,7 @@ func (fmp *provider) createClient() (*http.Client, error) {
 	}
 }
 
-func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+func (fmp *provider) Retrieve(ctx context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
 
 	if !strings.HasPrefix(uri, string(fmp.scheme)+":") {
 		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, string(fmp.scheme))
@@ -91,25 +165,133 @@ func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFu
 		return nil, fmt.Errorf("unable to configure http transport layer: %w", err)
 	}
 
+	cached := fmp.loadCache(uri)
+	body, err := fmp.fetchWithRetry(ctx, client, uri, cached)
+	if err != nil {
+		if cached == nil || fmp.settings.CacheDir == "" {
+			return nil, err
+		}
+		// Fall back to the last retrieved configuration, the server may be temporarily unavailable.
+		body = cached.body
+	}
+
+	return internal.NewRetrievedFromYAML(body)
+}
+
+// fetchWithRetry fetches the configuration, retrying the failed attempts with an exponential backoff.
+func (fmp *provider) fetchWithRetry(ctx context.Context, client *http.Client, uri string, cached *cacheEntry) ([]byte, error) {
+	interval := fmp.settings.Retry.InitialInterval
+	for attempt := 1; ; attempt++ {
+		body, retryable, err := fmp.fetch(ctx, client, uri, cached)
+		if err == nil || !retryable || attempt >= fmp.settings.Retry.MaxAttempts {
+			return body, err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%w, last error: %v", ctx.Err(), err)
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if maxInterval := fmp.settings.Retry.MaxInterval; maxInterval > 0 && interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// fetch sends a single request, it returns whether the request can be retried if it fails.
+func (fmp *provider) fetch(ctx context.Context, client *http.Client, uri string, cached *cacheEntry) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to create the request for uri %q: %w", uri, err)
+	}
+	for k, v := range fmp.settings.Headers {
+		req.Header.Set(k, v)
+	}
+	switch {
+	case fmp.settings.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+fmp.settings.BearerToken)
+	case fmp.settings.Username != "":
+		req.SetBasicAuth(fmp.settings.Username, fmp.settings.Password)
+	}
+	if cached != nil && cached.etag != "" {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
 	// send a HTTP GET request
-	resp, err := client.Get(uri)
+	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("unable to download the file via HTTP GET for uri %q: %w ", uri, err)
+		return nil, true, fmt.Errorf("unable to download the file via HTTP GET for uri %q: %w ", uri, err)
 	}
 	defer resp.Body.Close()
 
+	// the cached configuration is still valid
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return cached.body, false, nil
+	}
+
 	// check the HTTP status code
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to load resource from uri %q. status code: %d", uri, resp.StatusCode)
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return nil, retryable, fmt.Errorf("failed to load resource from uri %q. status code: %d", uri, resp.StatusCode)
 	}
 
 	// read the response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("fail to read the response body from uri %q: %w", uri, err)
+		return nil, true, fmt.Errorf("fail to read the response body from uri %q: %w", uri, err)
 	}
 
-	return internal.NewRetrievedFromYAML(body)
+	fmp.storeCache(uri, &cacheEntry{etag: resp.Header.Get("ETag"), body: body})
+	return body, false, nil
+}
+
+// loadCache returns the configuration previously retrieved from uri, if any.
+func (fmp *provider) loadCache(uri string) *cacheEntry {
+	fmp.mu.Lock()
+	defer fmp.mu.Unlock()
+	if entry, ok := fmp.cache[uri]; ok {
+		return entry
+	}
+	if fmp.settings.CacheDir == "" {
+		return nil
+	}
+	path := fmp.cachePath(uri)
+	body, err := os.ReadFile(path + ".yaml")
+	if err != nil {
+		return nil
+	}
+	// The ETag is optional, the cached configuration is still used as a fallback without it.
+	etag, _ := os.ReadFile(path + ".etag")
+	entry := &cacheEntry{etag: string(etag), body: body}
+	fmp.cache[uri] = entry
+	return entry
+}
+
+// storeCache keeps the configuration retrieved from uri, persisting it if CacheDir is set.
+func (fmp *provider) storeCache(uri string, entry *cacheEntry) {
+	fmp.mu.Lock()
+	defer fmp.mu.Unlock()
+	fmp.cache[uri] = entry
+	if fmp.settings.CacheDir == "" {
+		return
+	}
+	// Persisting the cache is best effort, a failure only disables the fallback.
+	path := fmp.cachePath(uri)
+	if err := os.MkdirAll(fmp.settings.CacheDir, 0700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path+".yaml", entry.body, 0600)
+	_ = os.WriteFile(path+".etag", []byte(entry.etag), 0600)
+}
+
+// cachePath returns the path, without extension, of the files caching the configuration of uri.
+func (fmp *provider) cachePath(uri string) string {
+	sum := sha256.Sum256([]byte(uri))
+	return filepath.Join(fmp.settings.CacheDir, hex.EncodeToString(sum[:]))
 }
 
 func (fmp *provider) Scheme() string {
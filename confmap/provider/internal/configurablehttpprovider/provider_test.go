@@ -17,6 +17,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -302,3 +303,117 @@ func TestInvalidTransport(t *testing.T) {
 	_, err := fp.Retrieve(context.Background(), "foo://..", nil)
 	assert.Error(t, err)
 }
+
+func TestAuthenticationHeaders(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings Settings
+		expected string
+	}{
+		{name: "bearer", settings: Settings{BearerToken: "token"}, expected: "Bearer token"},
+		{name: "basic", settings: Settings{Username: "user", Password: "pass"}, expected: "Basic dXNlcjpwYXNz"},
+		{name: "bearer over basic", settings: Settings{BearerToken: "token", Username: "user"}, expected: "Bearer token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.settings.Headers = map[string]string{"X-Fleet": "edge"}
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != tt.expected || r.Header.Get("X-Fleet") != "edge" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				answerGet(w, r)
+			}))
+			defer ts.Close()
+			fp := NewWithSettings(HTTPScheme, tt.settings)
+			_, err := fp.Retrieve(context.Background(), ts.URL, nil)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestClientCertificateNotFound(t *testing.T) {
+	fp := NewWithSettings(HTTPSScheme, Settings{CertFile: "no_certificate", KeyFile: "no_key"})
+	_, err := fp.Retrieve(context.Background(), "https://localhost", nil)
+	assert.ErrorContains(t, err, "unable to load the client certificate")
+}
+
+func TestETagCaching(t *testing.T) {
+	var requests, notModified atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		answerGet(w, r)
+	}))
+	defer ts.Close()
+
+	fp := NewWithSettings(HTTPScheme, Settings{})
+	first, err := fp.Retrieve(context.Background(), ts.URL, nil)
+	require.NoError(t, err)
+	second, err := fp.Retrieve(context.Background(), ts.URL, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, int32(1), notModified.Load())
+	firstConf, err := first.AsConf()
+	require.NoError(t, err)
+	secondConf, err := second.AsConf()
+	require.NoError(t, err)
+	assert.Equal(t, firstConf.ToStringMap(), secondConf.ToStringMap())
+}
+
+func TestRetry(t *testing.T) {
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if requests.Load() < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		answerGet(w, r)
+	}))
+	defer ts.Close()
+
+	fp := NewWithSettings(HTTPScheme, Settings{Retry: RetrySettings{MaxAttempts: 3, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond}})
+	_, err := fp.Retrieve(context.Background(), ts.URL, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+
+	// Client errors are not retried.
+	requests.Store(0)
+	ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	})
+	_, err = fp.Retrieve(context.Background(), ts.URL, nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestCacheDirFallback(t *testing.T) {
+	cacheDir := t.TempDir()
+	ts := httptest.NewServer(http.HandlerFunc(answerGet))
+
+	fp := NewWithSettings(HTTPScheme, Settings{CacheDir: cacheDir})
+	_, err := fp.Retrieve(context.Background(), ts.URL, nil)
+	require.NoError(t, err)
+	ts.Close()
+
+	// A new provider, e.g. after a restart, falls back to the persisted configuration.
+	fp = NewWithSettings(HTTPScheme, Settings{CacheDir: cacheDir})
+	ret, err := fp.Retrieve(context.Background(), ts.URL, nil)
+	require.NoError(t, err)
+	conf, err := ret.AsConf()
+	require.NoError(t, err)
+	assert.NotEmpty(t, conf.ToStringMap())
+
+	// Without a cache directory the error is returned.
+	fp = NewWithSettings(HTTPScheme, Settings{})
+	_, err = fp.Retrieve(context.Background(), ts.URL, nil)
+	assert.Error(t, err)
+}
@@ -9,13 +9,38 @@ import (
 	"go.opentelemetry.io/collector/confmap"
 )
 
-// NewRetrievedFromYAML returns a new Retrieved instance that contains the deserialized data from the yaml bytes.
+// NewRetrievedFromYAML returns a new Retrieved instance that contains the deserialized data from the yaml bytes,
+// with the positions of its keys.
 // * yamlBytes the yaml bytes that will be deserialized.
 // * opts specifies options associated with this Retrieved value, such as CloseFunc.
 func NewRetrievedFromYAML(yamlBytes []byte, opts ...confmap.RetrievedOption) (*confmap.Retrieved, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(yamlBytes, &node); err != nil {
+		return nil, err
+	}
 	var rawConf any
-	if err := yaml.Unmarshal(yamlBytes, &rawConf); err != nil {
+	if err := node.Decode(&rawConf); err != nil {
 		return nil, err
 	}
-	return confmap.NewRetrieved(rawConf, opts...)
+	positions := make(map[string]confmap.Position)
+	if len(node.Content) > 0 {
+		keyPositions(node.Content[0], "", positions)
+	}
+	return confmap.NewRetrieved(rawConf, append([]confmap.RetrievedOption{confmap.WithRetrievedPositions(positions)}, opts...)...)
+}
+
+// keyPositions adds the positions of the keys of the mapping node to positions, prefixed with prefix.
+func keyPositions(node *yaml.Node, prefix string, positions map[string]confmap.Position) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Tag == "!!merge" {
+			continue
+		}
+		path := prefix + key.Value
+		positions[path] = confmap.Position{Line: key.Line, Column: key.Column}
+		keyPositions(value, path+confmap.KeyDelimiter, positions)
+	}
 }
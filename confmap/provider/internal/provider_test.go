@@ -45,3 +45,51 @@ func TestNewRetrievedFromYAMLInvalidAsMap(t *testing.T) {
 	_, err = ret.AsConf()
 	assert.Error(t, err)
 }
+
+func TestNewRetrievedFromYAMLPositions(t *testing.T) {
+	ret, err := NewRetrievedFromYAML([]byte(`defaults: &defaults
+  timeout: 5s
+receivers:
+  otlp:
+    <<: *defaults
+    endpoint: localhost:4317
+`))
+	require.NoError(t, err)
+
+	resolver, err := confmap.NewResolver(confmap.ResolverSettings{
+		URIs:      []string{"mock:config"},
+		Providers: map[string]confmap.Provider{"mock": &mockProvider{ret: ret}},
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "5s", conf.Get("receivers::otlp::timeout"))
+
+	for key, expected := range map[string]confmap.Position{
+		"defaults::timeout":          {URI: "mock:config", Line: 2, Column: 3},
+		"receivers::otlp":            {URI: "mock:config", Line: 4, Column: 3},
+		"receivers::otlp::endpoint":  {URI: "mock:config", Line: 6, Column: 5},
+		"receivers::otlp::timeout":   {URI: "mock:config", Line: 4, Column: 3},
+		"receivers::otlp::unset::id": {URI: "mock:config", Line: 4, Column: 3},
+	} {
+		pos, ok := conf.Position(key)
+		require.True(t, ok, key)
+		assert.Equal(t, expected, pos, key)
+	}
+}
+
+type mockProvider struct {
+	ret *confmap.Retrieved
+}
+
+func (p *mockProvider) Retrieve(context.Context, string, confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	return p.ret, nil
+}
+
+func (p *mockProvider) Scheme() string {
+	return "mock"
+}
+
+func (p *mockProvider) Shutdown(context.Context) error {
+	return nil
+}
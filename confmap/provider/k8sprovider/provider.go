@@ -0,0 +1,312 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sprovider // import "go.opentelemetry.io/collector/confmap/provider/k8sprovider"
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/internal"
+)
+
+const (
+	schemeName = "k8s"
+
+	kindConfigMap = "configmap"
+	kindSecret    = "secret"
+
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+)
+
+// errGone is returned when the resource version of a watch is too old, the object must be read again.
+var errGone = errors.New("the resource version is too old")
+
+// rewatchDelay is the delay before a watch ended by the API server is resumed.
+var rewatchDelay = time.Second
+
+type provider struct {
+	// host is the URL of the API server, e.g. https://10.0.0.1:443.
+	host      string
+	tokenPath string
+	caPath    string
+	client    *http.Client // Used for tests
+}
+
+// New returns a new confmap.Provider that reads the configuration from a Kubernetes ConfigMap or Secret.
+//
+// This Provider supports "k8s" scheme, and can be called with a selector:
+// `k8s:configmap/NAMESPACE/NAME/KEY` or `k8s:secret/NAMESPACE/NAME/KEY`,
+// where KEY is the entry of the ConfigMap or Secret holding the YAML configuration.
+//
+// The provider must run inside the cluster, it uses the service account of the pod to
+// authenticate to the API server. The service account needs the "get" and "watch"
+// permissions on the ConfigMap or Secret.
+//
+// When a watcher is given, the ConfigMap or Secret is watched and the watcher is called
+// when the configuration changes, so the collector reloads it without a restart.
+func New() confmap.Provider {
+	return &provider{
+		host:      "https://" + net.JoinHostPort(os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")),
+		tokenPath: filepath.Join(serviceAccountDir, "token"),
+		caPath:    filepath.Join(serviceAccountDir, "ca.crt"),
+	}
+}
+
+// object identifies a ConfigMap or Secret entry.
+type object struct {
+	kind      string
+	namespace string
+	name      string
+	key       string
+}
+
+func parseURI(uri string) (object, error) {
+	parts := strings.SplitN(strings.TrimPrefix(uri, schemeName+":"), "/", 4)
+	if len(parts) != 4 || parts[1] == "" || parts[2] == "" || parts[3] == "" {
+		return object{}, fmt.Errorf("%q uri must have the form k8s:(configmap|secret)/NAMESPACE/NAME/KEY", uri)
+	}
+	obj := object{kind: parts[0], namespace: parts[1], name: parts[2], key: parts[3]}
+	if obj.kind != kindConfigMap && obj.kind != kindSecret {
+		return object{}, fmt.Errorf("%q uri references unsupported kind %q, must be %q or %q", uri, obj.kind, kindConfigMap, kindSecret)
+	}
+	return obj, nil
+}
+
+// resource is the subset of a ConfigMap or Secret used by the provider.
+type resource struct {
+	Metadata struct {
+		ResourceVersion string `json:"resourceVersion"`
+	} `json:"metadata"`
+	Data map[string]string `json:"data"`
+	// Code is the HTTP status code of the Status object of the ERROR events.
+	Code int `json:"code"`
+}
+
+// value returns the decoded value of the entry of obj.
+func (r resource) value(obj object) ([]byte, error) {
+	val, ok := r.Data[obj.key]
+	if !ok {
+		return nil, fmt.Errorf("%s %s/%s has no key %q", obj.kind, obj.namespace, obj.name, obj.key)
+	}
+	if obj.kind == kindSecret {
+		return base64.StdEncoding.DecodeString(val)
+	}
+	return []byte(val), nil
+}
+
+// watchEvent is an event of the watch API.
+type watchEvent struct {
+	Type   string   `json:"type"`
+	Object resource `json:"object"`
+}
+
+func (fmp *provider) Retrieve(ctx context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if !strings.HasPrefix(uri, schemeName+":") {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+	}
+	obj, err := parseURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := fmp.createClient()
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure the Kubernetes client: %w", err)
+	}
+
+	res, val, err := fmp.get(ctx, client, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if watcher == nil {
+		return internal.NewRetrievedFromYAML(val)
+	}
+
+	watchCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fmp.watch(watchCtx, client, obj, res.Metadata.ResourceVersion, val, watcher)
+	}()
+	return internal.NewRetrievedFromYAML(val, confmap.WithRetrievedClose(func(context.Context) error {
+		cancel()
+		<-done
+		return nil
+	}))
+}
+
+// get reads the ConfigMap or Secret of obj and the value of its entry.
+func (fmp *provider) get(ctx context.Context, client *http.Client, obj object) (resource, []byte, error) {
+	var res resource
+	if err := fmp.do(ctx, client, fmp.objectURL(obj), func(resp *http.Response) error {
+		return json.NewDecoder(resp.Body).Decode(&res)
+	}); err != nil {
+		return res, nil, fmt.Errorf("unable to get %s %s/%s: %w", obj.kind, obj.namespace, obj.name, err)
+	}
+	val, err := res.value(obj)
+	return res, val, err
+}
+
+// watch calls watcher when the entry of obj changes from current, or when the watch fails. The watches
+// ended by the API server are resumed from the last resource version seen, and the object is read again
+// when that version is too old.
+func (fmp *provider) watch(ctx context.Context, client *http.Client, obj object, resourceVersion string, current []byte, watcher confmap.WatcherFunc) {
+	for {
+		changed, err := fmp.watchOnce(ctx, client, obj, &resourceVersion, current)
+		if errors.Is(err, errGone) {
+			var res resource
+			var val []byte
+			if res, val, err = fmp.get(ctx, client, obj); err == nil {
+				changed = string(val) != string(current)
+				resourceVersion = res.Metadata.ResourceVersion
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			watcher(&confmap.ChangeEvent{Error: fmt.Errorf("watch of %s %s/%s failed: %w", obj.kind, obj.namespace, obj.name, err)})
+			return
+		}
+		if changed {
+			watcher(&confmap.ChangeEvent{})
+			return
+		}
+		select {
+		case <-time.After(rewatchDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// watchOnce watches obj from resourceVersion, which it updates with the versions of the events, until
+// the entry of obj changes from current or the watch ends. An end of the watch by the API server is not an error.
+func (fmp *provider) watchOnce(ctx context.Context, client *http.Client, obj object, resourceVersion *string, current []byte) (bool, error) {
+	query := url.Values{
+		"watch":               {"true"},
+		"allowWatchBookmarks": {"true"},
+		"fieldSelector":       {"metadata.name=" + obj.name},
+		"resourceVersion":     {*resourceVersion},
+	}
+	changed := false
+	err := fmp.do(ctx, client, fmp.collectionURL(obj)+"?"+query.Encode(), func(resp *http.Response) error {
+		decoder := json.NewDecoder(resp.Body)
+		for {
+			var event watchEvent
+			if err := decoder.Decode(&event); err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				return err
+			}
+			switch event.Type {
+			case "ERROR":
+				if event.Object.Code == http.StatusGone {
+					return errGone
+				}
+				return fmt.Errorf("watch error with status code %d", event.Object.Code)
+			case "DELETED":
+				return fmt.Errorf("%s %s/%s was deleted", obj.kind, obj.namespace, obj.name)
+			case "ADDED", "MODIFIED":
+				val, err := event.Object.value(obj)
+				if err != nil {
+					return err
+				}
+				*resourceVersion = event.Object.Metadata.ResourceVersion
+				if string(val) != string(current) {
+					changed = true
+					return nil
+				}
+			case "BOOKMARK":
+				*resourceVersion = event.Object.Metadata.ResourceVersion
+			}
+		}
+	})
+	return changed, err
+}
+
+// do sends an authenticated GET request to the API server and passes the successful response to handle.
+func (fmp *provider) do(ctx context.Context, client *http.Client, u string, handle func(*http.Response) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	if fmp.tokenPath != "" {
+		token, err := os.ReadFile(filepath.Clean(fmp.tokenPath))
+		if err != nil {
+			return fmt.Errorf("unable to read the service account token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusGone {
+		return errGone
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return handle(resp)
+}
+
+func (fmp *provider) createClient() (*http.Client, error) {
+	if fmp.client != nil {
+		return fmp.client, nil
+	}
+	if fmp.host == "https://:" {
+		return nil, errors.New("KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT must be set, the provider must run inside the cluster")
+	}
+	ca, err := os.ReadFile(filepath.Clean(fmp.caPath))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the cluster CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("unable to add the cluster CA from %q into the cert pool", fmp.caPath)
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+		},
+	}, nil
+}
+
+func (fmp *provider) collectionURL(obj object) string {
+	collection := "configmaps"
+	if obj.kind == kindSecret {
+		collection = "secrets"
+	}
+	return fmt.Sprintf("%s/api/v1/namespaces/%s/%s", fmp.host, url.PathEscape(obj.namespace), collection)
+}
+
+func (fmp *provider) objectURL(obj object) string {
+	return fmp.collectionURL(obj) + "/" + url.PathEscape(obj.name)
+}
+
+func (*provider) Scheme() string {
+	return schemeName
+}
+
+func (*provider) Shutdown(context.Context) error {
+	return nil
+}
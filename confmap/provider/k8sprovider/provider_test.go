@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sprovider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func newTestProvider(t *testing.T, ts *httptest.Server) *provider {
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("token\n"), 0600))
+	return &provider{host: ts.URL, tokenPath: tokenPath, client: ts.Client()}
+}
+
+func newResource(resourceVersion string, data map[string]string) resource {
+	var res resource
+	res.Metadata.ResourceVersion = resourceVersion
+	res.Data = data
+	return res
+}
+
+func TestParseURI(t *testing.T) {
+	obj, err := parseURI("k8s:configmap/monitoring/otelcol/config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, object{kind: kindConfigMap, namespace: "monitoring", name: "otelcol", key: "config.yaml"}, obj)
+
+	_, err = parseURI("k8s:configmap/monitoring/otelcol")
+	assert.Error(t, err)
+	_, err = parseURI("k8s:pod/monitoring/otelcol/config.yaml")
+	assert.Error(t, err)
+}
+
+func TestRetrieveConfigMap(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "/api/v1/namespaces/monitoring/configmaps/otelcol", r.URL.Path)
+		assert.NoError(t, json.NewEncoder(w).Encode(newResource("1", map[string]string{"config.yaml": "processors:\n  batch:\n"})))
+	}))
+	defer ts.Close()
+
+	fp := newTestProvider(t, ts)
+	ret, err := fp.Retrieve(context.Background(), "k8s:configmap/monitoring/otelcol/config.yaml", nil)
+	require.NoError(t, err)
+	conf, err := ret.AsConf()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"processors": map[string]any{"batch": nil}}, conf.ToStringMap())
+
+	_, err = fp.Retrieve(context.Background(), "k8s:configmap/monitoring/otelcol/missing.yaml", nil)
+	assert.EqualError(t, err, `configmap monitoring/otelcol has no key "missing.yaml"`)
+	assert.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestRetrieveSecret(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/namespaces/monitoring/secrets/otelcol", r.URL.Path)
+		value := base64.StdEncoding.EncodeToString([]byte("key: value\n"))
+		assert.NoError(t, json.NewEncoder(w).Encode(newResource("1", map[string]string{"config.yaml": value})))
+	}))
+	defer ts.Close()
+
+	ret, err := newTestProvider(t, ts).Retrieve(context.Background(), "k8s:secret/monitoring/otelcol/config.yaml", nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"key": "value"}, raw)
+}
+
+func TestRetrieveNotFound(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	_, err := newTestProvider(t, ts).Retrieve(context.Background(), "k8s:configmap/monitoring/otelcol/config.yaml", nil)
+	assert.EqualError(t, err, "unable to get configmap monitoring/otelcol: unexpected status code 404")
+}
+
+func TestWatch(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("watch") != "true" {
+			assert.NoError(t, json.NewEncoder(w).Encode(newResource("1", map[string]string{"config.yaml": "key: value"})))
+			return
+		}
+		assert.Equal(t, "/api/v1/namespaces/monitoring/configmaps", r.URL.Path)
+		assert.Equal(t, "metadata.name=otelcol", r.URL.Query().Get("fieldSelector"))
+		assert.Equal(t, "1", r.URL.Query().Get("resourceVersion"))
+		enc := json.NewEncoder(w)
+		// An update of another key does not change the configuration.
+		assert.NoError(t, enc.Encode(watchEvent{Type: "MODIFIED", Object: newResource("2", map[string]string{"config.yaml": "key: value", "other": "x"})}))
+		w.(http.Flusher).Flush()
+		assert.NoError(t, enc.Encode(watchEvent{Type: "MODIFIED", Object: newResource("3", map[string]string{"config.yaml": "key: changed"})}))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	events := make(chan *confmap.ChangeEvent, 1)
+	ret, err := newTestProvider(t, ts).Retrieve(context.Background(), "k8s:configmap/monitoring/otelcol/config.yaml", func(event *confmap.ChangeEvent) {
+		events <- event
+	})
+	require.NoError(t, err)
+
+	select {
+	case event := <-events:
+		assert.NoError(t, event.Error)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the watcher was not called")
+	}
+	assert.NoError(t, ret.Close(context.Background()))
+}
+
+func TestWatchDeleted(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("watch") != "true" {
+			assert.NoError(t, json.NewEncoder(w).Encode(newResource("1", map[string]string{"config.yaml": "key: value"})))
+			return
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(watchEvent{Type: "DELETED"}))
+	}))
+	defer ts.Close()
+
+	events := make(chan *confmap.ChangeEvent, 1)
+	ret, err := newTestProvider(t, ts).Retrieve(context.Background(), "k8s:configmap/monitoring/otelcol/config.yaml", func(event *confmap.ChangeEvent) {
+		events <- event
+	})
+	require.NoError(t, err)
+	event := <-events
+	assert.EqualError(t, event.Error, "watch of configmap monitoring/otelcol failed: configmap monitoring/otelcol was deleted")
+	assert.NoError(t, ret.Close(context.Background()))
+}
+
+func TestWatchResumed(t *testing.T) {
+	rewatchDelay = time.Millisecond
+	t.Cleanup(func() { rewatchDelay = time.Second })
+	var watches atomic.Int32
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("watch") != "true" {
+			assert.NoError(t, json.NewEncoder(w).Encode(newResource("1", map[string]string{"config.yaml": "key: value"})))
+			return
+		}
+		enc := json.NewEncoder(w)
+		switch watches.Add(1) {
+		case 1:
+			// The API server ends the watch, which is resumed from the last version seen.
+			assert.Equal(t, "1", r.URL.Query().Get("resourceVersion"))
+			assert.NoError(t, enc.Encode(watchEvent{Type: "BOOKMARK", Object: newResource("2", nil)}))
+		case 2:
+			assert.Equal(t, "2", r.URL.Query().Get("resourceVersion"))
+			assert.NoError(t, enc.Encode(watchEvent{Type: "MODIFIED", Object: newResource("3", map[string]string{"config.yaml": "key: changed"})}))
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer ts.Close()
+
+	events := make(chan *confmap.ChangeEvent, 1)
+	ret, err := newTestProvider(t, ts).Retrieve(context.Background(), "k8s:configmap/monitoring/otelcol/config.yaml", func(event *confmap.ChangeEvent) {
+		events <- event
+	})
+	require.NoError(t, err)
+
+	select {
+	case event := <-events:
+		assert.NoError(t, event.Error)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the watcher was not called")
+	}
+	assert.Equal(t, int32(2), watches.Load())
+	assert.NoError(t, ret.Close(context.Background()))
+}
+
+func TestWatchGone(t *testing.T) {
+	rewatchDelay = time.Millisecond
+	t.Cleanup(func() { rewatchDelay = time.Second })
+	var gets, watches atomic.Int32
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("watch") != "true" {
+			// The object is read again once its resource version expired.
+			if gets.Add(1) == 1 {
+				assert.NoError(t, json.NewEncoder(w).Encode(newResource("1", map[string]string{"config.yaml": "key: value"})))
+			} else {
+				assert.NoError(t, json.NewEncoder(w).Encode(newResource("5", map[string]string{"config.yaml": "key: value"})))
+			}
+			return
+		}
+		switch watches.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusGone)
+		case 2:
+			assert.Equal(t, "5", r.URL.Query().Get("resourceVersion"))
+			status := newResource("", nil)
+			status.Code = http.StatusGone
+			assert.NoError(t, json.NewEncoder(w).Encode(watchEvent{Type: "ERROR", Object: status}))
+		default:
+			assert.NoError(t, json.NewEncoder(w).Encode(watchEvent{Type: "DELETED"}))
+		}
+	}))
+	defer ts.Close()
+
+	events := make(chan *confmap.ChangeEvent, 1)
+	ret, err := newTestProvider(t, ts).Retrieve(context.Background(), "k8s:configmap/monitoring/otelcol/config.yaml", func(event *confmap.ChangeEvent) {
+		events <- event
+	})
+	require.NoError(t, err)
+	// The expired versions are not reported, the watch fails once the object is deleted.
+	event := <-events
+	assert.EqualError(t, event.Error, "watch of configmap monitoring/otelcol failed: configmap monitoring/otelcol was deleted")
+	assert.Equal(t, int32(3), gets.Load())
+	assert.NoError(t, ret.Close(context.Background()))
+}
+
+func TestNotInCluster(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "")
+	_, err := New().Retrieve(context.Background(), "k8s:configmap/monitoring/otelcol/config.yaml", nil)
+	assert.ErrorContains(t, err, "the provider must run inside the cluster")
+}
+
+func TestUnsupportedScheme(t *testing.T) {
+	_, err := New().Retrieve(context.Background(), "file:config.yaml", nil)
+	assert.Error(t, err)
+}
+
+func TestScheme(t *testing.T) {
+	assert.Equal(t, "k8s", New().Scheme())
+}
+
+func TestValidateProviderScheme(t *testing.T) {
+	assert.NoError(t, confmaptest.ValidateProviderScheme(New()))
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secretsprovider // import "go.opentelemetry.io/collector/confmap/provider/secretsprovider"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// RedactedValue replaces the secrets in the redacted output.
+const RedactedValue = "[REDACTED]"
+
+// SecretsProvider reads secrets from a secret store, such as Vault or AWS Secrets Manager.
+type SecretsProvider interface {
+	// Scheme returns the scheme of the URIs resolved by this provider, e.g. "vault".
+	Scheme() string
+
+	// GetSecret returns the value of the secret identified by selector,
+	// which is the URI without the "<scheme>:" prefix.
+	GetSecret(ctx context.Context, selector string) (string, error)
+}
+
+type provider struct {
+	secrets SecretsProvider
+}
+
+// New returns a new confmap.Provider resolving the URIs of the scheme of secrets to the value of the secret.
+//
+// The value is returned as a string, and is recorded so that it can be removed from
+// the configuration printed for debugging by Redact.
+//
+// The secrets are read once when the configuration is loaded, watching them for changes
+// is not supported.
+func New(secrets SecretsProvider) confmap.Provider {
+	return &provider{secrets: secrets}
+}
+
+func (p *provider) Retrieve(ctx context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	scheme := p.secrets.Scheme()
+	if !strings.HasPrefix(uri, scheme+":") {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, scheme)
+	}
+	// The secret must not be part of the error, the URI is enough to identify it.
+	value, err := p.secrets.GetSecret(ctx, uri[len(scheme)+1:])
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the secret %q: %w", uri, err)
+	}
+	registerSecret(value)
+	return confmap.NewRetrieved(value)
+}
+
+func (p *provider) Scheme() string {
+	return p.secrets.Scheme()
+}
+
+func (*provider) Shutdown(context.Context) error {
+	return nil
+}
+
+var (
+	secretsMu sync.RWMutex
+	// secrets holds the values resolved by the providers, longest first so
+	// that a secret containing another one is redacted as a whole.
+	secrets []string
+)
+
+func registerSecret(value string) {
+	if value == "" {
+		return
+	}
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	for _, s := range secrets {
+		if s == value {
+			return
+		}
+	}
+	secrets = append(secrets, value)
+	sort.SliceStable(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+}
+
+// Redact returns s with every secret resolved by the providers replaced by RedactedValue.
+func Redact(s string) string {
+	secretsMu.RLock()
+	defer secretsMu.RUnlock()
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, RedactedValue)
+	}
+	return s
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secretsprovider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapSecrets map[string]string
+
+func (mapSecrets) Scheme() string {
+	return "test"
+}
+
+func (m mapSecrets) GetSecret(_ context.Context, selector string) (string, error) {
+	value, ok := m[selector]
+	if !ok {
+		return "", errors.New("secret not found")
+	}
+	return value, nil
+}
+
+func TestRetrieve(t *testing.T) {
+	p := New(mapSecrets{"api_key": "s3cr3t"})
+	assert.Equal(t, "test", p.Scheme())
+
+	ret, err := p.Retrieve(context.Background(), "test:api_key", nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", raw)
+
+	_, err = p.Retrieve(context.Background(), "test:missing", nil)
+	assert.EqualError(t, err, `unable to get the secret "test:missing": secret not found`)
+	_, err = p.Retrieve(context.Background(), "other:api_key", nil)
+	assert.Error(t, err)
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestRedact(t *testing.T) {
+	p := New(mapSecrets{"short": "abc", "long": "abcdef"})
+	_, err := p.Retrieve(context.Background(), "test:short", nil)
+	require.NoError(t, err)
+	_, err = p.Retrieve(context.Background(), "test:long", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "token: [REDACTED], key: [REDACTED]", Redact("token: abcdef, key: abc"))
+	assert.Equal(t, "nothing to hide", Redact("nothing to hide"))
+}
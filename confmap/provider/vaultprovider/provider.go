@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package vaultprovider // import "go.opentelemetry.io/collector/confmap/provider/vaultprovider"
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/secretsprovider"
+)
+
+const (
+	schemeName = "vault"
+
+	defaultAddress = "https://127.0.0.1:8200"
+)
+
+type vault struct {
+	address   string
+	token     string
+	namespace string
+	caPath    string
+	client    *http.Client // Used for tests
+}
+
+// New returns a new confmap.Provider that reads secrets from HashiCorp Vault.
+//
+// This Provider supports "vault" scheme, and can be called with a selector:
+// `vault:PATH#FIELD`, e.g. `${vault:secret/data/otelcol#api_key}`, where PATH is the path
+// of the secret without the "v1/" prefix, and FIELD is the field of the secret to use.
+// Both the KV version 1 and version 2 secrets engines are supported.
+//
+// The provider is configured with the environment variables used by the Vault CLI:
+// VAULT_ADDR (defaults to https://127.0.0.1:8200), VAULT_TOKEN, VAULT_NAMESPACE and VAULT_CACERT.
+func New() confmap.Provider {
+	return secretsprovider.New(newVault())
+}
+
+func newVault() *vault {
+	address := os.Getenv("VAULT_ADDR")
+	if address == "" {
+		address = defaultAddress
+	}
+	return &vault{
+		address:   strings.TrimSuffix(address, "/"),
+		token:     os.Getenv("VAULT_TOKEN"),
+		namespace: os.Getenv("VAULT_NAMESPACE"),
+		caPath:    os.Getenv("VAULT_CACERT"),
+	}
+}
+
+func (*vault) Scheme() string {
+	return schemeName
+}
+
+// secret is the subset of the response of the Vault read API used by the provider.
+type secret struct {
+	Data map[string]any `json:"data"`
+}
+
+func (v *vault) GetSecret(ctx context.Context, selector string) (string, error) {
+	path, field, ok := strings.Cut(selector, "#")
+	if !ok || path == "" || field == "" {
+		return "", errors.New("uri must have the form vault:PATH#FIELD")
+	}
+	if v.token == "" {
+		return "", errors.New("VAULT_TOKEN must be set")
+	}
+
+	client, err := v.createClient()
+	if err != nil {
+		return "", fmt.Errorf("unable to configure the Vault client: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.address+"/v1/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	if v.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	var s secret
+	if err = json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return "", err
+	}
+
+	data := s.Data
+	// The KV version 2 secrets engine nests the fields with the metadata of the version.
+	if inner, ok := data["data"].(map[string]any); ok {
+		if _, ok = data["metadata"]; ok {
+			data = inner
+		}
+	}
+	value, ok := data[field]
+	if !ok {
+		return "", fmt.Errorf("secret has no field %q", field)
+	}
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+	return fmt.Sprint(value), nil
+}
+
+func (v *vault) createClient() (*http.Client, error) {
+	if v.client != nil {
+		return v.client, nil
+	}
+	if v.caPath == "" {
+		return http.DefaultClient, nil
+	}
+	ca, err := os.ReadFile(filepath.Clean(v.caPath))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the Vault CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("unable to add the Vault CA from %q into the cert pool", v.caPath)
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+		},
+	}, nil
+}
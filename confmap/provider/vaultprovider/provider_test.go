@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package vaultprovider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/otelcol":
+			_, err := w.Write([]byte(`{"data": {"data": {"api_key": "kv2"}, "metadata": {"version": 1}}}`))
+			assert.NoError(t, err)
+		case "/v1/kv/otelcol":
+			_, err := w.Write([]byte(`{"data": {"api_key": "kv1", "port": 4317}}`))
+			assert.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestGetSecret(t *testing.T) {
+	ts := newTestServer(t)
+	v := &vault{address: ts.URL, token: "token", client: ts.Client()}
+
+	tests := []struct {
+		selector string
+		value    string
+		err      string
+	}{
+		{selector: "secret/data/otelcol#api_key", value: "kv2"},
+		{selector: "kv/otelcol#api_key", value: "kv1"},
+		{selector: "kv/otelcol#port", value: "4317"},
+		{selector: "kv/otelcol#missing", err: `secret has no field "missing"`},
+		{selector: "kv/missing#api_key", err: "unexpected status code 404"},
+		{selector: "kv/otelcol", err: "uri must have the form vault:PATH#FIELD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			value, err := v.GetSecret(context.Background(), tt.selector)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, value)
+		})
+	}
+}
+
+func TestGetSecretForbidden(t *testing.T) {
+	ts := newTestServer(t)
+	v := &vault{address: ts.URL, token: "other", client: ts.Client()}
+	_, err := v.GetSecret(context.Background(), "kv/otelcol#api_key")
+	assert.EqualError(t, err, "unexpected status code 403")
+
+	v.token = ""
+	_, err = v.GetSecret(context.Background(), "kv/otelcol#api_key")
+	assert.EqualError(t, err, "VAULT_TOKEN must be set")
+}
+
+func TestRetrieve(t *testing.T) {
+	ts := newTestServer(t)
+	t.Setenv("VAULT_ADDR", ts.URL+"/")
+	t.Setenv("VAULT_TOKEN", "token")
+
+	p := New()
+	assert.Equal(t, "vault", p.Scheme())
+	ret, err := p.Retrieve(context.Background(), "vault:secret/data/otelcol#api_key", nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "kv2", raw)
+}
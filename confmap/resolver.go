@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"regexp"
 	"strings"
 
@@ -27,9 +28,10 @@ var _ = featuregate.GlobalRegistry().MustRegister(
 
 // Resolver resolves a configuration as a Conf.
 type Resolver struct {
-	uris       []location
-	providers  map[string]Provider
-	converters []Converter
+	uris          []location
+	providers     map[string]Provider
+	converters    []Converter
+	mergeStrategy MergeStrategy
 
 	closers []CloseFunc
 	watcher chan error
@@ -47,8 +49,24 @@ type ResolverSettings struct {
 
 	// MapConverters is a slice of Converter.
 	Converters []Converter
+
+	// MergeStrategy is the strategy merging the lists of the configurations retrieved from URIs,
+	// MergeReplace if empty.
+	MergeStrategy MergeStrategy
 }
 
+// MergeStrategy is the strategy merging the lists of the configurations retrieved by the Resolver.
+// The maps are always merged key by key.
+type MergeStrategy string
+
+const (
+	// MergeReplace replaces the lists of the previous configurations with the lists of the later ones.
+	MergeReplace MergeStrategy = "replace"
+	// MergeAppend appends the elements of the lists of the later configurations to the lists of the
+	// previous ones, skipping the elements they already contain.
+	MergeAppend MergeStrategy = "append"
+)
+
 // NewResolver returns a new Resolver that resolves configuration from multiple URIs.
 //
 // To resolve a configuration the following steps will happen:
@@ -77,23 +95,22 @@ func NewResolver(set ResolverSettings) (*Resolver, error) {
 		return nil, errors.New("invalid map resolver config: no Providers")
 	}
 
+	mergeStrategy := set.MergeStrategy
+	switch mergeStrategy {
+	case "":
+		mergeStrategy = MergeReplace
+	case MergeReplace, MergeAppend:
+	default:
+		return nil, fmt.Errorf("invalid map resolver config: unsupported merge strategy %q", mergeStrategy)
+	}
+
 	// Safe copy, ensures the slices and maps cannot be changed from the caller.
 	uris := make([]location, len(set.URIs))
 	for i, uri := range set.URIs {
-		// For backwards compatibility:
-		// - empty url scheme means "file".
-		// - "^[A-z]:" also means "file"
-		if driverLetterRegexp.MatchString(uri) || !strings.Contains(uri, ":") {
-			uris[i] = location{scheme: "file", opaqueValue: uri}
-			continue
-		}
-		lURI, err := newLocation(uri)
+		lURI, err := newConfigLocation(uri, set.Providers)
 		if err != nil {
 			return nil, err
 		}
-		if _, ok := set.Providers[lURI.scheme]; !ok {
-			return nil, fmt.Errorf("unsupported scheme on URI %q", uri)
-		}
 		uris[i] = lURI
 	}
 	providersCopy := make(map[string]Provider, len(set.Providers))
@@ -104,13 +121,32 @@ func NewResolver(set ResolverSettings) (*Resolver, error) {
 	copy(convertersCopy, set.Converters)
 
 	return &Resolver{
-		uris:       uris,
-		providers:  providersCopy,
-		converters: convertersCopy,
-		watcher:    make(chan error, 1),
+		uris:          uris,
+		providers:     providersCopy,
+		converters:    convertersCopy,
+		mergeStrategy: mergeStrategy,
+		watcher:       make(chan error, 1),
 	}, nil
 }
 
+// newConfigLocation returns the location of the configuration retrieved from uri, by one of the providers.
+func newConfigLocation(uri string, providers map[string]Provider) (location, error) {
+	// For backwards compatibility:
+	// - empty url scheme means "file".
+	// - "^[A-z]:" also means "file"
+	if driverLetterRegexp.MatchString(uri) || !strings.Contains(uri, ":") {
+		return location{scheme: "file", opaqueValue: uri}, nil
+	}
+	lURI, err := newLocation(uri)
+	if err != nil {
+		return location{}, err
+	}
+	if _, ok := providers[lURI.scheme]; !ok {
+		return location{}, fmt.Errorf("unsupported scheme on URI %q", uri)
+	}
+	return lURI, nil
+}
+
 // Resolve returns the configuration as a Conf, or error otherwise.
 //
 // Should never be called concurrently with itself, Watch or Shutdown.
@@ -132,10 +168,21 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 		if err != nil {
 			return nil, err
 		}
+		retCfgMap.setPositions(retrievedPositions(ret, uri, ""))
+		if retCfgMap, err = mr.resolveIncludes(ctx, retCfgMap, uri); err != nil {
+			return nil, err
+		}
+		keepMaps(retMap, retCfgMap)
+		if mr.mergeStrategy == MergeAppend {
+			if err = appendLists(retMap, retCfgMap); err != nil {
+				return nil, err
+			}
+		}
 		if err = retMap.Merge(retCfgMap); err != nil {
 			return nil, err
 		}
 	}
+	removeExtensionSections(retMap)
 
 	cfgMap := make(map[string]any)
 	for _, k := range retMap.AllKeys() {
@@ -145,7 +192,9 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 		}
 		cfgMap[k] = val
 	}
+	positions := retMap.positions
 	retMap = NewFromStringMap(cfgMap)
+	retMap.setPositions(positions)
 
 	// Apply the converters in the given order.
 	for _, confConv := range mr.converters {
@@ -197,6 +246,66 @@ func (mr *Resolver) closeIfNeeded(ctx context.Context) error {
 	return err
 }
 
+// keepMaps removes the null values of the configuration merged over conf where conf has a map,
+// so that an empty key does not discard the map set by the previous configurations.
+func keepMaps(conf *Conf, merged *Conf) {
+	for _, k := range merged.AllKeys() {
+		if merged.Get(k) != nil {
+			continue
+		}
+		if m, ok := conf.Get(k).(map[string]any); ok && len(m) > 0 {
+			merged.k.Delete(k)
+		}
+	}
+}
+
+// appendLists sets the lists of the configuration merged over conf to the lists of conf at the same keys,
+// followed by the elements of the merged lists which they do not contain.
+func appendLists(conf *Conf, merged *Conf) error {
+	for _, k := range merged.AllKeys() {
+		later, ok := merged.Get(k).([]any)
+		if !ok {
+			continue
+		}
+		previous, ok := conf.Get(k).([]any)
+		if !ok {
+			continue
+		}
+		list := append([]any(nil), previous...)
+		for _, v := range later {
+			if !containsValue(previous, v) {
+				list = append(list, v)
+			}
+		}
+		if err := merged.k.Set(k, list); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsValue(list []any, v any) bool {
+	for _, e := range list {
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// extensionSectionPrefix is the prefix of the top-level keys removed from the resolved configuration,
+// which hold the values shared through YAML anchors.
+const extensionSectionPrefix = "x-"
+
+// removeExtensionSections removes the top-level keys starting with extensionSectionPrefix.
+func removeExtensionSections(conf *Conf) {
+	for k := range conf.ToStringMap() {
+		if strings.HasPrefix(k, extensionSectionPrefix) {
+			conf.k.Delete(k)
+		}
+	}
+}
+
 func (mr *Resolver) retrieveValue(ctx context.Context, uri location) (*Retrieved, error) {
 	p, ok := mr.providers[uri.scheme]
 	if !ok {
@@ -347,3 +347,74 @@ func TestResolverShutdownClosesWatch(t *testing.T) {
 	assert.NoError(t, resolver.Shutdown(context.Background()))
 	watcherWG.Wait()
 }
+
+func TestResolverAnchors(t *testing.T) {
+	resolver, err := NewResolver(ResolverSettings{
+		URIs:      []string{filepath.Join("testdata", "anchors-base.yaml"), filepath.Join("testdata", "anchors-override.yaml")},
+		Providers: makeMapProvidersMap(newFileProvider(t)),
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+
+	// The keys set explicitly override the keys of the merge keys, the later configurations override the
+	// previous ones, and an empty key keeps the map set by the previous configurations.
+	assert.Equal(t, map[string]any{
+		"exporters": map[string]any{
+			"otlp/first": map[string]any{
+				"endpoint":         "first:4317",
+				"timeout":          "5s",
+				"retry_on_failure": map[string]any{"enabled": true, "max_elapsed_time": "60s"},
+			},
+			"otlp/second": map[string]any{
+				"endpoint":         "second:4317",
+				"timeout":          "10s",
+				"retry_on_failure": map[string]any{"enabled": false, "max_elapsed_time": "60s"},
+			},
+		},
+	}, conf.ToStringMap())
+}
+
+func TestResolverMergeStrategy(t *testing.T) {
+	provider := func(scheme string, receivers []any) Provider {
+		return newFakeProvider(scheme, func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+			return NewRetrieved(map[string]any{
+				"pipelines": map[string]any{"traces": map[string]any{"receivers": receivers}},
+			})
+		})
+	}
+	providers := makeMapProvidersMap(
+		provider("base", []any{"otlp"}),
+		provider("first", []any{"kafka"}),
+		provider("second", []any{"otlp", "zipkin"}))
+
+	tests := []struct {
+		strategy MergeStrategy
+		expected []any
+	}{
+		{
+			strategy: "",
+			expected: []any{"otlp", "zipkin"},
+		},
+		{
+			strategy: MergeReplace,
+			expected: []any{"otlp", "zipkin"},
+		},
+		{
+			strategy: MergeAppend,
+			expected: []any{"otlp", "kafka", "zipkin"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			resolver, err := NewResolver(ResolverSettings{URIs: []string{"base:", "first:", "second:"}, Providers: providers, MergeStrategy: tt.strategy})
+			require.NoError(t, err)
+			conf, err := resolver.Resolve(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, conf.Get("pipelines::traces::receivers"))
+		})
+	}
+
+	_, err := NewResolver(ResolverSettings{URIs: []string{"base:"}, Providers: providers, MergeStrategy: "prepend"})
+	assert.EqualError(t, err, `invalid map resolver config: unsupported merge strategy "prepend"`)
+}
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap // import "go.opentelemetry.io/collector/confmap"
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+// StrictUnmarshalFeatureGate is the feature gate reporting the unknown keys of the configurations unmarshalled
+// WithErrorUnused with the closest valid keys and the positions of the unknown keys.
+var StrictUnmarshalFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"confmap.strictUnmarshal",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("controls whether the unknown keys of the configuration are reported with "+
+		"the closest valid key and their position, instead of the list of the invalid keys of each section."))
+
+// unknownKeysError returns the errors about the unknown keys of conf, reported by mapstructure in the
+// metadata of the decoding of conf into result.
+func unknownKeysError(conf *Conf, result any, unused []string) error {
+	sort.Strings(unused)
+	var errs error
+	for _, name := range unused {
+		path, listed := splitDecodedName(name)
+		key := strings.Join(path, KeyDelimiter)
+		msg := "unknown key"
+		if !listed {
+			if suggestion := closestKey(path[len(path)-1], validKeys(reflect.TypeOf(result), path[:len(path)-1])); suggestion != "" {
+				msg = fmt.Sprintf("unknown key, did you mean %q?", suggestion)
+			}
+		}
+		errs = multierr.Append(errs, NewKeyError(conf, key, errors.New(msg)))
+	}
+	return errs
+}
+
+// splitDecodedName returns the keys of a name of the mapstructure metadata, e.g. "protocols.grpc.endpoint"
+// or "headers[name]". The keys after a list index are dropped, listed is then true.
+func splitDecodedName(name string) (path []string, listed bool) {
+	for len(name) > 0 {
+		if name[0] == '.' {
+			name = name[1:]
+			continue
+		}
+		if name[0] == '[' {
+			end := strings.IndexByte(name, ']')
+			if end < 0 {
+				break
+			}
+			segment := name[1:end]
+			name = name[end+1:]
+			if isIndex(segment) {
+				return path, true
+			}
+			path = append(path, segment)
+			continue
+		}
+		end := strings.IndexAny(name, ".[")
+		if end < 0 {
+			end = len(name)
+		}
+		path = append(path, name[:end])
+		name = name[end:]
+	}
+	return path, false
+}
+
+func isIndex(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// validKeys returns the keys of the struct found at path in typ, following the mapstructure tags,
+// or nil if path does not lead to a struct.
+func validKeys(typ reflect.Type, path []string) []string {
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ == nil {
+		return nil
+	}
+	if len(path) == 0 {
+		if typ.Kind() != reflect.Struct {
+			return nil
+		}
+		var keys []string
+		forEachField(typ, func(name string, _ reflect.Type) bool {
+			keys = append(keys, name)
+			return true
+		})
+		return keys
+	}
+	switch typ.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return validKeys(typ.Elem(), path[1:])
+	case reflect.Struct:
+		var next reflect.Type
+		forEachField(typ, func(name string, fieldType reflect.Type) bool {
+			if name == path[0] {
+				next = fieldType
+				return false
+			}
+			return true
+		})
+		return validKeys(next, path[1:])
+	}
+	return nil
+}
+
+// forEachField calls fn with the mapstructure name and the type of the fields of the struct, including the
+// fields of the squashed structs, until fn returns false. It returns false if fn returned false.
+func forEachField(typ reflect.Type, fn func(name string, fieldType reflect.Type) bool) bool {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("mapstructure")
+		name, opts, _ := strings.Cut(tag, ",")
+		squash := strings.Contains(opts, "squash")
+		if name == "-" || (!field.IsExported() && !squash) {
+			continue
+		}
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if squash && fieldType.Kind() == reflect.Struct {
+			if !forEachField(fieldType, fn) {
+				return false
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if !fn(name, field.Type) {
+			return false
+		}
+	}
+	return true
+}
+
+// closestKey returns the valid key closest to key, or an empty string if none is close enough,
+// i.e. at an edit distance greater than a third of the length of key plus one.
+func closestKey(key string, valid []string) string {
+	best, bestDistance := "", len(key)/3+2
+	for _, v := range valid {
+		if d := editDistance(key, v); d < bestDistance || (d == bestDistance && best != "" && v < best) {
+			best, bestDistance = v, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if d := prev[j] + 1; d < curr[j] {
+				curr[j] = d
+			}
+			if d := curr[j-1] + 1; d < curr[j] {
+				curr[j] = d
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
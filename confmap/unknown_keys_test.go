@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+type strictTLSConfig struct {
+	Insecure bool   `mapstructure:"insecure"`
+	CAFile   string `mapstructure:"ca_file"`
+}
+
+type strictEmbeddedConfig struct {
+	Timeout string `mapstructure:"timeout"`
+}
+
+type strictConfig struct {
+	strictEmbeddedConfig `mapstructure:",squash"`
+	Endpoint             string                     `mapstructure:"endpoint"`
+	TLS                  *strictTLSConfig           `mapstructure:"tls"`
+	Servers              map[string]strictTLSConfig `mapstructure:"servers"`
+	Headers              map[string]string          `mapstructure:"headers"`
+	Queues               []strictTLSConfig          `mapstructure:"queues"`
+}
+
+func TestStrictUnmarshal(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(StrictUnmarshalFeatureGate.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(StrictUnmarshalFeatureGate.ID(), false))
+	})
+
+	conf := NewFromStringMap(map[string]any{
+		"endpont": "localhost:4317",
+		"timout":  "5s",
+		"tls":     map[string]any{"insecur": true},
+		"servers": map[string]any{"first": map[string]any{"ca_fil": "ca.pem"}},
+		"queues":  []any{map[string]any{"unknown": true}},
+		"other":   true,
+	})
+	conf.setPositions(map[string]Position{
+		"endpont":      {URI: "file:config.yaml", Line: 1, Column: 1},
+		"tls":          {URI: "file:config.yaml", Line: 3, Column: 1},
+		"tls::insecur": {URI: "file:config.yaml", Line: 4, Column: 3},
+	})
+
+	err := conf.Unmarshal(&strictConfig{}, WithErrorUnused())
+	require.Error(t, err)
+	assert.Equal(t, []string{
+		`file:config.yaml:1:1: endpont: unknown key, did you mean "endpoint"?`,
+		`other: unknown key`,
+		`queues: unknown key`,
+		`servers::first::ca_fil: unknown key, did you mean "ca_file"?`,
+		`timout: unknown key, did you mean "timeout"?`,
+		`file:config.yaml:4:3: tls::insecur: unknown key, did you mean "insecure"?`,
+	}, errorMessages(err))
+
+	var keyErr *KeyError
+	require.ErrorAs(t, err, &keyErr)
+	assert.Equal(t, "endpont", keyErr.Key)
+
+	// The unknown keys are ignored without WithErrorUnused.
+	require.NoError(t, conf.Unmarshal(&strictConfig{}))
+}
+
+func TestStrictUnmarshalDisabled(t *testing.T) {
+	conf := NewFromStringMap(map[string]any{"endpont": "localhost:4317"})
+	err := conf.Unmarshal(&strictConfig{}, WithErrorUnused())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has invalid keys: endpont")
+}
+
+func TestClosestKey(t *testing.T) {
+	valid := []string{"endpoint", "tls", "timeout"}
+	assert.Equal(t, "endpoint", closestKey("endpoit", valid))
+	assert.Equal(t, "tls", closestKey("tsl", valid))
+	assert.Equal(t, "", closestKey("compression", valid))
+	assert.Equal(t, "", closestKey("a", valid))
+}
+
+func errorMessages(err error) []string {
+	var msgs []string
+	for _, e := range multierr.Errors(err) {
+		msgs = append(msgs, e.Error())
+	}
+	return msgs
+}
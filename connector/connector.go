@@ -320,6 +320,7 @@ type factory struct {
 	logsToTracesStabilityLevel  component.StabilityLevel
 	logsToMetricsStabilityLevel component.StabilityLevel
 	logsToLogsStabilityLevel    component.StabilityLevel
+	capabilities                component.FactoryCapabilities
 }
 
 // Type returns the type of component.
@@ -329,6 +330,10 @@ func (f *factory) Type() component.Type {
 
 func (f *factory) unexportedFactoryFunc() {}
 
+func (f *factory) Capabilities() component.FactoryCapabilities {
+	return f.capabilities
+}
+
 // WithTracesToTraces overrides the default "error not supported" implementation for WithTracesToTraces and the default "undefined" stability level.
 func WithTracesToTraces(createTracesToTraces CreateTracesToTracesFunc, sl component.StabilityLevel) FactoryOption {
 	return factoryOptionFunc(func(o *factory) {
@@ -437,6 +442,13 @@ func (f factory) LogsToLogsStability() component.StabilityLevel {
 	return f.logsToLogsStabilityLevel
 }
 
+// WithCapabilities declares the capabilities of the connectors created by the factory.
+func WithCapabilities(capabilities component.FactoryCapabilities) FactoryOption {
+	return factoryOptionFunc(func(o *factory) {
+		o.capabilities = capabilities
+	})
+}
+
 // NewFactory returns a Factory.
 func NewFactory(cfgType component.Type, createDefaultConfig component.CreateDefaultConfigFunc, options ...FactoryOption) Factory {
 	f := &factory{
@@ -622,6 +634,11 @@ func (b *Builder) IsConfigured(componentID component.ID) bool {
 	return ok
 }
 
+// Config returns the configuration of the connector with the given ID, or nil if it is not configured.
+func (b *Builder) Config(id component.ID) component.Config {
+	return b.cfgs[id]
+}
+
 func (b *Builder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
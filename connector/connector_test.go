@@ -182,6 +182,17 @@ func TestNewFactoryWithAllTypes(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestNewFactoryWithCapabilities(t *testing.T) {
+	const typeStr = "test"
+	defaultCfg := struct{}{}
+	factory := NewFactory(typeStr, func() component.Config { return &defaultCfg })
+	assert.Equal(t, component.FactoryCapabilities{}, factory.Capabilities())
+
+	capabilities := component.FactoryCapabilities{MutatesData: true, SingleInstance: true, Stateful: true}
+	factory = NewFactory(typeStr, func() component.Config { return &defaultCfg }, WithCapabilities(capabilities))
+	assert.Equal(t, capabilities, factory.Capabilities())
+}
+
 func TestMakeFactoryMap(t *testing.T) {
 	type testCase struct {
 		name string
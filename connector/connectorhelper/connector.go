@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package connectorhelper // import "go.opentelemetry.io/collector/connector/connectorhelper"
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+// FlushFunc emits the data aggregated since the previous call. It is called at the end of
+// every aggregation window and one last time during shutdown.
+type FlushFunc func(context.Context) error
+
+// Option apply changes to internalOptions.
+type Option func(*baseSettings)
+
+// WithStart overrides the default Start function for a connector.
+// The default start function does nothing and always returns nil.
+func WithStart(start component.StartFunc) Option {
+	return func(o *baseSettings) {
+		o.StartFunc = start
+	}
+}
+
+// WithShutdown overrides the default Shutdown function for a connector.
+// The default shutdown function does nothing and always returns nil.
+func WithShutdown(shutdown component.ShutdownFunc) Option {
+	return func(o *baseSettings) {
+		o.ShutdownFunc = shutdown
+	}
+}
+
+// WithCapabilities overrides the default GetCapabilities function for a connector.
+// The default GetCapabilities function returns immutable capabilities.
+func WithCapabilities(capabilities consumer.Capabilities) Option {
+	return func(o *baseSettings) {
+		o.consumerOptions = append(o.consumerOptions, consumer.WithCapabilities(capabilities))
+	}
+}
+
+// WithFlushInterval turns the connector into an aggregating one: flush is called every interval,
+// and once more during shutdown after the last incoming data was consumed and before the
+// Shutdown function configured via WithShutdown is called. If interval is not positive, flush is only
+// called during shutdown.
+// The consume function and flush are never called concurrently, so the aggregated state does not
+// need its own synchronization.
+func WithFlushInterval(interval time.Duration, flush FlushFunc) Option {
+	return func(o *baseSettings) {
+		o.flushInterval = interval
+		o.flushFunc = flush
+	}
+}
+
+type baseSettings struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumerOptions []consumer.Option
+	flushInterval   time.Duration
+	flushFunc       FlushFunc
+}
+
+// fromOptions returns the internal settings starting from the default and applying all options.
+func fromOptions(options []Option) *baseSettings {
+	// Start from the default options:
+	opts := &baseSettings{
+		consumerOptions: []consumer.Option{consumer.WithCapabilities(consumer.Capabilities{MutatesData: false})},
+	}
+
+	for _, op := range options {
+		op(opts)
+	}
+
+	return opts
+}
+
+// baseConnector implements the component lifecycle shared by all connectors created by this package,
+// including the optional aggregation window.
+type baseConnector struct {
+	*baseSettings
+	logger *zap.Logger
+
+	// mu serializes the consume function with flushFunc, it is only used when flushFunc is set.
+	mu       sync.Mutex
+	started  bool
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+func newBaseConnector(set connector.CreateSettings, options []Option) *baseConnector {
+	return &baseConnector{
+		baseSettings: fromOptions(options),
+		logger:       set.Logger,
+		stopCh:       make(chan struct{}),
+		doneCh:       make(chan struct{}),
+	}
+}
+
+// Start implements component.Component.
+func (bc *baseConnector) Start(ctx context.Context, host component.Host) error {
+	if err := bc.StartFunc.Start(ctx, host); err != nil {
+		return err
+	}
+	bc.started = true
+	if bc.flushFunc == nil || bc.flushInterval <= 0 {
+		close(bc.doneCh)
+		return nil
+	}
+	go bc.flushLoop()
+	return nil
+}
+
+// Shutdown implements component.Component.
+// It stops the aggregation window, flushes the remaining data and then calls the configured
+// Shutdown function. It is safe to call Shutdown without calling Start first.
+func (bc *baseConnector) Shutdown(ctx context.Context) error {
+	var errs error
+	bc.stopOnce.Do(func() {
+		close(bc.stopCh)
+		if bc.flushFunc == nil {
+			return
+		}
+		if bc.started {
+			select {
+			case <-bc.doneCh:
+			case <-ctx.Done():
+				errs = ctx.Err()
+				return
+			}
+		}
+		errs = bc.flush(ctx)
+	})
+	return multierr.Append(errs, bc.ShutdownFunc.Shutdown(ctx))
+}
+
+// consume calls fn while holding the lock shared with flushFunc.
+func (bc *baseConnector) consume(fn func() error) error {
+	if bc.flushFunc == nil {
+		return fn()
+	}
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	return fn()
+}
+
+func (bc *baseConnector) flush(ctx context.Context) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	return bc.flushFunc(ctx)
+}
+
+func (bc *baseConnector) flushLoop() {
+	defer close(bc.doneCh)
+	ticker := time.NewTicker(bc.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := bc.flush(context.Background()); err != nil {
+				bc.logger.Warn("Failed to flush aggregated data", zap.Error(err))
+			}
+		case <-bc.stopCh:
+			return
+		}
+	}
+}
+
+// routeKey returns a key that is identical for all permutations of the same set of pipelines.
+func routeKey(ids []component.ID) string {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = id.String()
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
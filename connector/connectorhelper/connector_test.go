@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package connectorhelper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestDefaultOptions(t *testing.T) {
+	tc, err := NewTraces(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, consumertest.NewNop().ConsumeTraces)
+	require.NoError(t, err)
+	assert.Equal(t, consumer.Capabilities{MutatesData: false}, tc.Capabilities())
+	assert.NoError(t, tc.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, tc.Shutdown(context.Background()))
+}
+
+func TestWithOptions(t *testing.T) {
+	want := errors.New("my_error")
+	tc, err := NewTraces(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, consumertest.NewNop().ConsumeTraces,
+		WithStart(func(context.Context, component.Host) error { return want }),
+		WithShutdown(func(context.Context) error { return want }),
+		WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	require.NoError(t, err)
+	assert.Equal(t, consumer.Capabilities{MutatesData: true}, tc.Capabilities())
+	assert.Equal(t, want, tc.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, want, tc.Shutdown(context.Background()))
+}
+
+// spanCounter is a traces to metrics aggregation counting the spans seen in every window.
+type spanCounter struct {
+	next  consumer.Metrics
+	spans int
+}
+
+func (sc *spanCounter) consume(_ context.Context, td ptrace.Traces) error {
+	sc.spans += td.SpanCount()
+	return nil
+}
+
+func (sc *spanCounter) flush(ctx context.Context) error {
+	if sc.spans == 0 {
+		return nil
+	}
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("span.count")
+	m.SetEmptySum().DataPoints().AppendEmpty().SetIntValue(int64(sc.spans))
+	sc.spans = 0
+	return sc.next.ConsumeMetrics(ctx, md)
+}
+
+func TestWithFlushInterval(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	sc := &spanCounter{next: sink}
+	tc, err := NewTraces(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, sc.consume,
+		WithFlushInterval(10*time.Millisecond, sc.flush))
+	require.NoError(t, err)
+	require.NoError(t, tc.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.Eventually(t, func() bool {
+		return sink.DataPointCount() == 1
+	}, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, tc.Shutdown(context.Background()))
+}
+
+func TestWithFlushInterval_FlushOnShutdown(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	sc := &spanCounter{next: sink}
+	var order []string
+	tc, err := NewTraces(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, sc.consume,
+		WithFlushInterval(time.Hour, func(ctx context.Context) error {
+			order = append(order, "flush")
+			return sc.flush(ctx)
+		}),
+		WithShutdown(func(context.Context) error {
+			order = append(order, "shutdown")
+			return nil
+		}))
+	require.NoError(t, err)
+	require.NoError(t, tc.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 0, sink.DataPointCount())
+
+	require.NoError(t, tc.Shutdown(context.Background()))
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, int64(2), sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, []string{"flush", "shutdown"}, order)
+
+	// A second shutdown does not flush again.
+	require.NoError(t, tc.Shutdown(context.Background()))
+	assert.Equal(t, []string{"flush", "shutdown", "shutdown"}, order)
+}
+
+func TestWithFlushInterval_FlushError(t *testing.T) {
+	want := errors.New("my_error")
+	tc, err := NewTraces(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, consumertest.NewNop().ConsumeTraces,
+		WithFlushInterval(time.Millisecond, func(context.Context) error { return want }))
+	require.NoError(t, err)
+	require.NoError(t, tc.Start(context.Background(), componenttest.NewNopHost()))
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, want, tc.Shutdown(context.Background()))
+}
+
+func TestWithFlushInterval_NoConcurrentCalls(t *testing.T) {
+	var active, maxActive int
+	var mu sync.Mutex
+	enter := func() {
+		mu.Lock()
+		active++
+		if active > maxActive {
+			maxActive = active
+		}
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		active--
+		mu.Unlock()
+	}
+	tc, err := NewTraces(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{},
+		func(context.Context, ptrace.Traces) error {
+			enter()
+			return nil
+		},
+		WithFlushInterval(time.Millisecond, func(context.Context) error {
+			enter()
+			return nil
+		}))
+	require.NoError(t, err)
+	require.NoError(t, tc.Start(context.Background(), componenttest.NewNopHost()))
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				assert.NoError(t, tc.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+			}
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, tc.Shutdown(context.Background()))
+	assert.Equal(t, 1, maxActive)
+}
+
+func TestShutdownWithoutStart(t *testing.T) {
+	flushed := false
+	tc, err := NewTraces(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, consumertest.NewNop().ConsumeTraces,
+		WithFlushInterval(time.Millisecond, func(context.Context) error {
+			flushed = true
+			return nil
+		}))
+	require.NoError(t, err)
+	assert.NoError(t, tc.Shutdown(context.Background()))
+	assert.True(t, flushed)
+}
+
+func TestRouteKey(t *testing.T) {
+	a := component.NewIDWithName(component.DataTypeTraces, "a")
+	b := component.NewIDWithName(component.DataTypeTraces, "b")
+	assert.Equal(t, routeKey([]component.ID{a, b}), routeKey([]component.ID{b, a}))
+	assert.NotEqual(t, routeKey([]component.ID{a}), routeKey([]component.ID{a, b}))
+}
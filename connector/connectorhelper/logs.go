@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package connectorhelper // import "go.opentelemetry.io/collector/connector/connectorhelper"
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// RouteLogsFunc returns the pipelines the given ResourceLogs must be sent to.
+// ResourceLogs for which no pipeline is returned are dropped.
+type RouteLogsFunc func(context.Context, plog.ResourceLogs) []component.ID
+
+type logsConnector struct {
+	*baseConnector
+	consumer.Logs
+}
+
+// NewLogs creates a connector.Logs that calls logsFunc for every incoming batch.
+// logsFunc is responsible for converting the data and sending it to the next consumer of the
+// connector, or for aggregating it when used together with WithFlushInterval.
+func NewLogs(
+	_ context.Context,
+	set connector.CreateSettings,
+	_ component.Config,
+	logsFunc consumer.ConsumeLogsFunc,
+	options ...Option,
+) (connector.Logs, error) {
+	if logsFunc == nil {
+		return nil, errors.New("nil logsFunc")
+	}
+
+	bc := newBaseConnector(set, options)
+	logsConsumer, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		return bc.consume(func() error {
+			return logsFunc(ctx, ld)
+		})
+	}, bc.consumerOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logsConnector{
+		baseConnector: bc,
+		Logs:          logsConsumer,
+	}, nil
+}
+
+// NewLogsRouter creates a connector.Logs that sends every ResourceLogs of the incoming data to
+// the pipelines returned by routeFunc. ResourceLogs routed to the same set of pipelines are
+// grouped together and sent in a single batch.
+func NewLogsRouter(
+	ctx context.Context,
+	set connector.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+	routeFunc RouteLogsFunc,
+	options ...Option,
+) (connector.Logs, error) {
+	if routeFunc == nil {
+		return nil, errors.New("nil routeFunc")
+	}
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+
+	return NewLogs(ctx, set, cfg, func(ctx context.Context, ld plog.Logs) error {
+		type group struct {
+			ids []component.ID
+			ld  plog.Logs
+		}
+		var groups []*group
+		index := make(map[string]*group)
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			rl := rls.At(i)
+			ids := routeFunc(ctx, rl)
+			if len(ids) == 0 {
+				continue
+			}
+			key := routeKey(ids)
+			g, ok := index[key]
+			if !ok {
+				g = &group{ids: ids, ld: plog.NewLogs()}
+				index[key] = g
+				groups = append(groups, g)
+			}
+			rl.CopyTo(g.ld.ResourceLogs().AppendEmpty())
+		}
+
+		var errs error
+		for _, g := range groups {
+			next, err := LogsConsumer(nextConsumer, g.ids...)
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				continue
+			}
+			errs = multierr.Append(errs, next.ConsumeLogs(ctx, g.ld))
+		}
+		return errs
+	}, options...)
+}
+
+// LogsConsumer returns the consumer.Logs feeding the given pipelines.
+// If nextConsumer is not a connector.LogsRouter, or no pipeline is given, nextConsumer is returned.
+func LogsConsumer(nextConsumer consumer.Logs, pipelineIDs ...component.ID) (consumer.Logs, error) {
+	router, ok := nextConsumer.(connector.LogsRouter)
+	if !ok || len(pipelineIDs) == 0 {
+		return nextConsumer, nil
+	}
+	return router.Consumer(pipelineIDs...)
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package connectorhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestNewLogs(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	tc, err := NewLogs(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, sink.ConsumeLogs)
+	require.NoError(t, err)
+
+	assert.False(t, tc.Capabilities().MutatesData)
+	assert.NoError(t, tc.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, tc.ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.NoError(t, tc.Shutdown(context.Background()))
+	assert.Len(t, sink.AllLogs(), 1)
+}
+
+func TestNewLogs_NilRequiredFields(t *testing.T) {
+	_, err := NewLogs(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, nil)
+	assert.Error(t, err)
+}
+
+func TestNewLogs_ReturnError(t *testing.T) {
+	want := errors.New("my_error")
+	tc, err := NewLogs(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, func(context.Context, plog.Logs) error {
+		return want
+	})
+	require.NoError(t, err)
+	assert.Equal(t, want, tc.ConsumeLogs(context.Background(), plog.NewLogs()))
+}
+
+func TestNewLogsRouter(t *testing.T) {
+	logsA := component.NewIDWithName(component.DataTypeLogs, "a")
+	logsB := component.NewIDWithName(component.DataTypeLogs, "b")
+	sinkA := new(consumertest.LogsSink)
+	sinkB := new(consumertest.LogsSink)
+	router := connectortest.NewLogsRouter(
+		connectortest.WithLogsSink(logsA, sinkA),
+		connectortest.WithLogsSink(logsB, sinkB),
+	)
+
+	tc, err := NewLogsRouter(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, router.(consumer.Logs),
+		func(_ context.Context, rl plog.ResourceLogs) []component.ID {
+			dest, _ := rl.Resource().Attributes().Get("dest")
+			switch dest.Str() {
+			case "a":
+				return []component.ID{logsA}
+			case "b":
+				return []component.ID{logsB}
+			case "all":
+				return []component.ID{logsA, logsB}
+			}
+			return nil
+		})
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	for _, dest := range []string{"a", "b", "all", "none", "a", "all"} {
+		ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("dest", dest)
+	}
+	require.NoError(t, tc.ConsumeLogs(context.Background(), ld))
+
+	// One batch per distinct set of pipelines.
+	require.Len(t, sinkA.AllLogs(), 2)
+	require.Len(t, sinkB.AllLogs(), 2)
+	assert.Equal(t, 2, sinkA.AllLogs()[0].ResourceLogs().Len())
+	assert.Equal(t, 2, sinkA.AllLogs()[1].ResourceLogs().Len())
+	assert.Equal(t, 1, sinkB.AllLogs()[0].ResourceLogs().Len())
+	assert.Equal(t, 2, sinkB.AllLogs()[1].ResourceLogs().Len())
+	// The incoming data is left untouched.
+	assert.Equal(t, 6, ld.ResourceLogs().Len())
+}
+
+func TestNewLogsRouter_UnknownPipeline(t *testing.T) {
+	router := connectortest.NewLogsRouter(connectortest.WithNopLogs(component.NewID(component.DataTypeLogs)))
+	tc, err := NewLogsRouter(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, router.(consumer.Logs),
+		func(context.Context, plog.ResourceLogs) []component.ID {
+			return []component.ID{component.NewIDWithName(component.DataTypeLogs, "unknown")}
+		})
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty()
+	assert.Error(t, tc.ConsumeLogs(context.Background(), ld))
+}
+
+func TestNewLogsRouter_NilRequiredFields(t *testing.T) {
+	route := func(context.Context, plog.ResourceLogs) []component.ID { return nil }
+	_, err := NewLogsRouter(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, consumertest.NewNop(), nil)
+	assert.Error(t, err)
+	_, err = NewLogsRouter(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, nil, route)
+	assert.Equal(t, component.ErrNilNextConsumer, err)
+}
+
+func TestLogsConsumer(t *testing.T) {
+	nop := consumertest.NewNop()
+	next, err := LogsConsumer(nop, component.NewID(component.DataTypeLogs))
+	require.NoError(t, err)
+	assert.Equal(t, consumer.Logs(nop), next)
+
+	router := connectortest.NewLogsRouter(connectortest.WithNopLogs(component.NewID(component.DataTypeLogs)))
+	next, err = LogsConsumer(router.(consumer.Logs))
+	require.NoError(t, err)
+	assert.Equal(t, router, next)
+	_, err = LogsConsumer(router.(consumer.Logs), component.NewID(component.DataTypeLogs))
+	assert.NoError(t, err)
+	_, err = LogsConsumer(router.(consumer.Logs), component.NewIDWithName(component.DataTypeLogs, "unknown"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package connectorhelper // import "go.opentelemetry.io/collector/connector/connectorhelper"
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// RouteMetricsFunc returns the pipelines the given ResourceMetrics must be sent to.
+// ResourceMetrics for which no pipeline is returned are dropped.
+type RouteMetricsFunc func(context.Context, pmetric.ResourceMetrics) []component.ID
+
+type metricsConnector struct {
+	*baseConnector
+	consumer.Metrics
+}
+
+// NewMetrics creates a connector.Metrics that calls metricsFunc for every incoming batch.
+// metricsFunc is responsible for converting the data and sending it to the next consumer of the
+// connector, or for aggregating it when used together with WithFlushInterval.
+func NewMetrics(
+	_ context.Context,
+	set connector.CreateSettings,
+	_ component.Config,
+	metricsFunc consumer.ConsumeMetricsFunc,
+	options ...Option,
+) (connector.Metrics, error) {
+	if metricsFunc == nil {
+		return nil, errors.New("nil metricsFunc")
+	}
+
+	bc := newBaseConnector(set, options)
+	metricsConsumer, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		return bc.consume(func() error {
+			return metricsFunc(ctx, md)
+		})
+	}, bc.consumerOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &metricsConnector{
+		baseConnector: bc,
+		Metrics:       metricsConsumer,
+	}, nil
+}
+
+// NewMetricsRouter creates a connector.Metrics that sends every ResourceMetrics of the incoming data to
+// the pipelines returned by routeFunc. ResourceMetrics routed to the same set of pipelines are
+// grouped together and sent in a single batch.
+func NewMetricsRouter(
+	ctx context.Context,
+	set connector.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+	routeFunc RouteMetricsFunc,
+	options ...Option,
+) (connector.Metrics, error) {
+	if routeFunc == nil {
+		return nil, errors.New("nil routeFunc")
+	}
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+
+	return NewMetrics(ctx, set, cfg, func(ctx context.Context, md pmetric.Metrics) error {
+		type group struct {
+			ids []component.ID
+			md  pmetric.Metrics
+		}
+		var groups []*group
+		index := make(map[string]*group)
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			rm := rms.At(i)
+			ids := routeFunc(ctx, rm)
+			if len(ids) == 0 {
+				continue
+			}
+			key := routeKey(ids)
+			g, ok := index[key]
+			if !ok {
+				g = &group{ids: ids, md: pmetric.NewMetrics()}
+				index[key] = g
+				groups = append(groups, g)
+			}
+			rm.CopyTo(g.md.ResourceMetrics().AppendEmpty())
+		}
+
+		var errs error
+		for _, g := range groups {
+			next, err := MetricsConsumer(nextConsumer, g.ids...)
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				continue
+			}
+			errs = multierr.Append(errs, next.ConsumeMetrics(ctx, g.md))
+		}
+		return errs
+	}, options...)
+}
+
+// MetricsConsumer returns the consumer.Metrics feeding the given pipelines.
+// If nextConsumer is not a connector.MetricsRouter, or no pipeline is given, nextConsumer is returned.
+func MetricsConsumer(nextConsumer consumer.Metrics, pipelineIDs ...component.ID) (consumer.Metrics, error) {
+	router, ok := nextConsumer.(connector.MetricsRouter)
+	if !ok || len(pipelineIDs) == 0 {
+		return nextConsumer, nil
+	}
+	return router.Consumer(pipelineIDs...)
+}
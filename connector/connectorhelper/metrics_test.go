@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package connectorhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestNewMetrics(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	tc, err := NewMetrics(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, sink.ConsumeMetrics)
+	require.NoError(t, err)
+
+	assert.False(t, tc.Capabilities().MutatesData)
+	assert.NoError(t, tc.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, tc.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	assert.NoError(t, tc.Shutdown(context.Background()))
+	assert.Len(t, sink.AllMetrics(), 1)
+}
+
+func TestNewMetrics_NilRequiredFields(t *testing.T) {
+	_, err := NewMetrics(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, nil)
+	assert.Error(t, err)
+}
+
+func TestNewMetrics_ReturnError(t *testing.T) {
+	want := errors.New("my_error")
+	tc, err := NewMetrics(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, func(context.Context, pmetric.Metrics) error {
+		return want
+	})
+	require.NoError(t, err)
+	assert.Equal(t, want, tc.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+}
+
+func TestNewMetricsRouter(t *testing.T) {
+	metricsA := component.NewIDWithName(component.DataTypeMetrics, "a")
+	metricsB := component.NewIDWithName(component.DataTypeMetrics, "b")
+	sinkA := new(consumertest.MetricsSink)
+	sinkB := new(consumertest.MetricsSink)
+	router := connectortest.NewMetricsRouter(
+		connectortest.WithMetricsSink(metricsA, sinkA),
+		connectortest.WithMetricsSink(metricsB, sinkB),
+	)
+
+	tc, err := NewMetricsRouter(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, router.(consumer.Metrics),
+		func(_ context.Context, rm pmetric.ResourceMetrics) []component.ID {
+			dest, _ := rm.Resource().Attributes().Get("dest")
+			switch dest.Str() {
+			case "a":
+				return []component.ID{metricsA}
+			case "b":
+				return []component.ID{metricsB}
+			case "all":
+				return []component.ID{metricsA, metricsB}
+			}
+			return nil
+		})
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	for _, dest := range []string{"a", "b", "all", "none", "a", "all"} {
+		md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("dest", dest)
+	}
+	require.NoError(t, tc.ConsumeMetrics(context.Background(), md))
+
+	// One batch per distinct set of pipelines.
+	require.Len(t, sinkA.AllMetrics(), 2)
+	require.Len(t, sinkB.AllMetrics(), 2)
+	assert.Equal(t, 2, sinkA.AllMetrics()[0].ResourceMetrics().Len())
+	assert.Equal(t, 2, sinkA.AllMetrics()[1].ResourceMetrics().Len())
+	assert.Equal(t, 1, sinkB.AllMetrics()[0].ResourceMetrics().Len())
+	assert.Equal(t, 2, sinkB.AllMetrics()[1].ResourceMetrics().Len())
+	// The incoming data is left untouched.
+	assert.Equal(t, 6, md.ResourceMetrics().Len())
+}
+
+func TestNewMetricsRouter_UnknownPipeline(t *testing.T) {
+	router := connectortest.NewMetricsRouter(connectortest.WithNopMetrics(component.NewID(component.DataTypeMetrics)))
+	tc, err := NewMetricsRouter(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, router.(consumer.Metrics),
+		func(context.Context, pmetric.ResourceMetrics) []component.ID {
+			return []component.ID{component.NewIDWithName(component.DataTypeMetrics, "unknown")}
+		})
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty()
+	assert.Error(t, tc.ConsumeMetrics(context.Background(), md))
+}
+
+func TestNewMetricsRouter_NilRequiredFields(t *testing.T) {
+	route := func(context.Context, pmetric.ResourceMetrics) []component.ID { return nil }
+	_, err := NewMetricsRouter(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, consumertest.NewNop(), nil)
+	assert.Error(t, err)
+	_, err = NewMetricsRouter(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, nil, route)
+	assert.Equal(t, component.ErrNilNextConsumer, err)
+}
+
+func TestMetricsConsumer(t *testing.T) {
+	nop := consumertest.NewNop()
+	next, err := MetricsConsumer(nop, component.NewID(component.DataTypeMetrics))
+	require.NoError(t, err)
+	assert.Equal(t, consumer.Metrics(nop), next)
+
+	router := connectortest.NewMetricsRouter(connectortest.WithNopMetrics(component.NewID(component.DataTypeMetrics)))
+	next, err = MetricsConsumer(router.(consumer.Metrics))
+	require.NoError(t, err)
+	assert.Equal(t, router, next)
+	_, err = MetricsConsumer(router.(consumer.Metrics), component.NewID(component.DataTypeMetrics))
+	assert.NoError(t, err)
+	_, err = MetricsConsumer(router.(consumer.Metrics), component.NewIDWithName(component.DataTypeMetrics, "unknown"))
+	assert.Error(t, err)
+}
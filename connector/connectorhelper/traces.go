@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package connectorhelper // import "go.opentelemetry.io/collector/connector/connectorhelper"
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// RouteTracesFunc returns the pipelines the given ResourceSpans must be sent to.
+// ResourceSpans for which no pipeline is returned are dropped.
+type RouteTracesFunc func(context.Context, ptrace.ResourceSpans) []component.ID
+
+type tracesConnector struct {
+	*baseConnector
+	consumer.Traces
+}
+
+// NewTraces creates a connector.Traces that calls tracesFunc for every incoming batch.
+// tracesFunc is responsible for converting the data and sending it to the next consumer of the
+// connector, or for aggregating it when used together with WithFlushInterval.
+func NewTraces(
+	_ context.Context,
+	set connector.CreateSettings,
+	_ component.Config,
+	tracesFunc consumer.ConsumeTracesFunc,
+	options ...Option,
+) (connector.Traces, error) {
+	if tracesFunc == nil {
+		return nil, errors.New("nil tracesFunc")
+	}
+
+	bc := newBaseConnector(set, options)
+	tracesConsumer, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		return bc.consume(func() error {
+			return tracesFunc(ctx, td)
+		})
+	}, bc.consumerOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracesConnector{
+		baseConnector: bc,
+		Traces:        tracesConsumer,
+	}, nil
+}
+
+// NewTracesRouter creates a connector.Traces that sends every ResourceSpans of the incoming data to
+// the pipelines returned by routeFunc. ResourceSpans routed to the same set of pipelines are
+// grouped together and sent in a single batch.
+func NewTracesRouter(
+	ctx context.Context,
+	set connector.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+	routeFunc RouteTracesFunc,
+	options ...Option,
+) (connector.Traces, error) {
+	if routeFunc == nil {
+		return nil, errors.New("nil routeFunc")
+	}
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+
+	return NewTraces(ctx, set, cfg, func(ctx context.Context, td ptrace.Traces) error {
+		type group struct {
+			ids []component.ID
+			td  ptrace.Traces
+		}
+		var groups []*group
+		index := make(map[string]*group)
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			rs := rss.At(i)
+			ids := routeFunc(ctx, rs)
+			if len(ids) == 0 {
+				continue
+			}
+			key := routeKey(ids)
+			g, ok := index[key]
+			if !ok {
+				g = &group{ids: ids, td: ptrace.NewTraces()}
+				index[key] = g
+				groups = append(groups, g)
+			}
+			rs.CopyTo(g.td.ResourceSpans().AppendEmpty())
+		}
+
+		var errs error
+		for _, g := range groups {
+			next, err := TracesConsumer(nextConsumer, g.ids...)
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				continue
+			}
+			errs = multierr.Append(errs, next.ConsumeTraces(ctx, g.td))
+		}
+		return errs
+	}, options...)
+}
+
+// TracesConsumer returns the consumer.Traces feeding the given pipelines.
+// If nextConsumer is not a connector.TracesRouter, or no pipeline is given, nextConsumer is returned.
+func TracesConsumer(nextConsumer consumer.Traces, pipelineIDs ...component.ID) (consumer.Traces, error) {
+	router, ok := nextConsumer.(connector.TracesRouter)
+	if !ok || len(pipelineIDs) == 0 {
+		return nextConsumer, nil
+	}
+	return router.Consumer(pipelineIDs...)
+}
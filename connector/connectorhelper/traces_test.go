@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package connectorhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestNewTraces(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	tc, err := NewTraces(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, sink.ConsumeTraces)
+	require.NoError(t, err)
+
+	assert.False(t, tc.Capabilities().MutatesData)
+	assert.NoError(t, tc.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, tc.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.NoError(t, tc.Shutdown(context.Background()))
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
+func TestNewTraces_NilRequiredFields(t *testing.T) {
+	_, err := NewTraces(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, nil)
+	assert.Error(t, err)
+}
+
+func TestNewTraces_ReturnError(t *testing.T) {
+	want := errors.New("my_error")
+	tc, err := NewTraces(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, func(context.Context, ptrace.Traces) error {
+		return want
+	})
+	require.NoError(t, err)
+	assert.Equal(t, want, tc.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+}
+
+func TestNewTracesRouter(t *testing.T) {
+	tracesA := component.NewIDWithName(component.DataTypeTraces, "a")
+	tracesB := component.NewIDWithName(component.DataTypeTraces, "b")
+	sinkA := new(consumertest.TracesSink)
+	sinkB := new(consumertest.TracesSink)
+	router := connectortest.NewTracesRouter(
+		connectortest.WithTracesSink(tracesA, sinkA),
+		connectortest.WithTracesSink(tracesB, sinkB),
+	)
+
+	tc, err := NewTracesRouter(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, router.(consumer.Traces),
+		func(_ context.Context, rs ptrace.ResourceSpans) []component.ID {
+			dest, _ := rs.Resource().Attributes().Get("dest")
+			switch dest.Str() {
+			case "a":
+				return []component.ID{tracesA}
+			case "b":
+				return []component.ID{tracesB}
+			case "all":
+				return []component.ID{tracesA, tracesB}
+			}
+			return nil
+		})
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	for _, dest := range []string{"a", "b", "all", "none", "a", "all"} {
+		td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("dest", dest)
+	}
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+
+	// One batch per distinct set of pipelines.
+	require.Len(t, sinkA.AllTraces(), 2)
+	require.Len(t, sinkB.AllTraces(), 2)
+	assert.Equal(t, 2, sinkA.AllTraces()[0].ResourceSpans().Len())
+	assert.Equal(t, 2, sinkA.AllTraces()[1].ResourceSpans().Len())
+	assert.Equal(t, 1, sinkB.AllTraces()[0].ResourceSpans().Len())
+	assert.Equal(t, 2, sinkB.AllTraces()[1].ResourceSpans().Len())
+	// The incoming data is left untouched.
+	assert.Equal(t, 6, td.ResourceSpans().Len())
+}
+
+func TestNewTracesRouter_UnknownPipeline(t *testing.T) {
+	router := connectortest.NewTracesRouter(connectortest.WithNopTraces(component.NewID(component.DataTypeTraces)))
+	tc, err := NewTracesRouter(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, router.(consumer.Traces),
+		func(context.Context, ptrace.ResourceSpans) []component.ID {
+			return []component.ID{component.NewIDWithName(component.DataTypeTraces, "unknown")}
+		})
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty()
+	assert.Error(t, tc.ConsumeTraces(context.Background(), td))
+}
+
+func TestNewTracesRouter_NilRequiredFields(t *testing.T) {
+	route := func(context.Context, ptrace.ResourceSpans) []component.ID { return nil }
+	_, err := NewTracesRouter(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, consumertest.NewNop(), nil)
+	assert.Error(t, err)
+	_, err = NewTracesRouter(context.Background(), connectortest.NewNopCreateSettings(), &struct{}{}, nil, route)
+	assert.Equal(t, component.ErrNilNextConsumer, err)
+}
+
+func TestTracesConsumer(t *testing.T) {
+	nop := consumertest.NewNop()
+	next, err := TracesConsumer(nop, component.NewID(component.DataTypeTraces))
+	require.NoError(t, err)
+	assert.Equal(t, consumer.Traces(nop), next)
+
+	router := connectortest.NewTracesRouter(connectortest.WithNopTraces(component.NewID(component.DataTypeTraces)))
+	next, err = TracesConsumer(router.(consumer.Traces))
+	require.NoError(t, err)
+	assert.Equal(t, router, next)
+	_, err = TracesConsumer(router.(consumer.Traces), component.NewID(component.DataTypeTraces))
+	assert.NoError(t, err)
+	_, err = TracesConsumer(router.(consumer.Traces), component.NewIDWithName(component.DataTypeTraces, "unknown"))
+	assert.Error(t, err)
+}
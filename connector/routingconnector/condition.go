@@ -0,0 +1,481 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "go.opentelemetry.io/collector/connector/routingconnector"
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// condition is a parsed routing condition, evaluated against the resource of the routed data.
+//
+// The grammar is a small subset of the OpenTelemetry Transformation Language:
+//
+//	condition  = or
+//	or         = and { "or" and }
+//	and        = not { "and" not }
+//	not        = "not" not | primary
+//	primary    = "(" condition ")" | "IsMatch" "(" operand "," string ")" | operand [ ( "==" | "!=" ) operand ]
+//	operand    = path | string | number | "true" | "false" | "nil"
+//	path       = "resource.attributes" "[" string "]" { "[" string "]" }
+//
+// Comparing a path with nil checks whether the attribute exists.
+type condition interface {
+	eval(res pcommon.Resource) bool
+}
+
+type boolCondition bool
+
+func (c boolCondition) eval(pcommon.Resource) bool {
+	return bool(c)
+}
+
+type notCondition struct {
+	cond condition
+}
+
+func (c notCondition) eval(res pcommon.Resource) bool {
+	return !c.cond.eval(res)
+}
+
+type andCondition []condition
+
+func (c andCondition) eval(res pcommon.Resource) bool {
+	for _, cond := range c {
+		if !cond.eval(res) {
+			return false
+		}
+	}
+	return true
+}
+
+type orCondition []condition
+
+func (c orCondition) eval(res pcommon.Resource) bool {
+	for _, cond := range c {
+		if cond.eval(res) {
+			return true
+		}
+	}
+	return false
+}
+
+type compareCondition struct {
+	left   operand
+	right  operand
+	negate bool
+}
+
+func (c compareCondition) eval(res pcommon.Resource) bool {
+	return equal(c.left.value(res), c.right.value(res)) != c.negate
+}
+
+type matchCondition struct {
+	target  operand
+	pattern *regexp.Regexp
+}
+
+func (c matchCondition) eval(res pcommon.Resource) bool {
+	switch v := c.target.value(res).(type) {
+	case nil:
+		return false
+	case string:
+		return c.pattern.MatchString(v)
+	default:
+		return c.pattern.MatchString(fmt.Sprint(v))
+	}
+}
+
+// operand returns a string, int64, float64, bool or nil value.
+type operand interface {
+	value(res pcommon.Resource) any
+}
+
+type literal struct {
+	val any
+}
+
+func (l literal) value(pcommon.Resource) any {
+	return l.val
+}
+
+// attributePath is a path to a resource attribute, following nested maps.
+type attributePath []string
+
+func (p attributePath) value(res pcommon.Resource) any {
+	m := res.Attributes()
+	for i, key := range p {
+		v, ok := m.Get(key)
+		if !ok {
+			return nil
+		}
+		if i == len(p)-1 {
+			return rawValue(v)
+		}
+		if v.Type() != pcommon.ValueTypeMap {
+			return nil
+		}
+		m = v.Map()
+	}
+	return nil
+}
+
+func rawValue(v pcommon.Value) any {
+	switch v.Type() {
+	case pcommon.ValueTypeEmpty:
+		return nil
+	case pcommon.ValueTypeStr:
+		return v.Str()
+	case pcommon.ValueTypeInt:
+		return v.Int()
+	case pcommon.ValueTypeDouble:
+		return v.Double()
+	case pcommon.ValueTypeBool:
+		return v.Bool()
+	default:
+		return v.AsString()
+	}
+}
+
+// equal compares two operand values. Integers and floats are compared numerically,
+// values of any other different types are never equal.
+func equal(a, b any) bool {
+	switch av := a.(type) {
+	case int64:
+		switch bv := b.(type) {
+		case int64:
+			return av == bv
+		case float64:
+			return float64(av) == bv
+		}
+		return false
+	case float64:
+		switch bv := b.(type) {
+		case int64:
+			return av == float64(bv)
+		case float64:
+			return av == bv
+		}
+		return false
+	default:
+		// Remaining values are strings, booleans and nil, which are all comparable.
+		return a == b
+	}
+}
+
+const resourceAttributesPath = "resource.attributes"
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenComma
+	tokenEq
+	tokenNe
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of condition"
+	}
+	return fmt.Sprintf("%q at position %d", t.text, t.pos)
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case c == '[':
+			tokens = append(tokens, token{kind: tokenLBracket, text: "[", pos: i})
+			i++
+		case c == ']':
+			tokens = append(tokens, token{kind: tokenRBracket, text: "]", pos: i})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: i})
+			i++
+		case strings.HasPrefix(s[i:], "=="):
+			tokens = append(tokens, token{kind: tokenEq, text: "==", pos: i})
+			i += 2
+		case strings.HasPrefix(s[i:], "!="):
+			tokens = append(tokens, token{kind: tokenNe, text: "!=", pos: i})
+			i += 2
+		case c == '"':
+			end := i + 1
+			for ; end < len(s) && s[end] != '"'; end++ {
+				if s[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			str, err := strconv.Unquote(s[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at position %d: %w", i, err)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: str, pos: i})
+			i = end + 1
+		case isDigit(c) || (c == '-' && i+1 < len(s) && isDigit(s[i+1])):
+			end := i + 1
+			for end < len(s) && (isDigit(s[end]) || s[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: s[i:end], pos: i})
+			i = end
+		case isIdentChar(c):
+			end := i
+			for end < len(s) && (isIdentChar(s[end]) || isDigit(s[end]) || s[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: s[i:end], pos: i})
+			i = end
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(s)}), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parseCondition parses the given condition.
+func parseCondition(s string) (condition, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	cond, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %v", tok)
+	}
+	return cond, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	tok := p.next()
+	if tok.kind != kind {
+		return tok, fmt.Errorf("expected %s, got %v", what, tok)
+	}
+	return tok, nil
+}
+
+func (p *parser) peekKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == tokenIdent && tok.text == keyword
+}
+
+func (p *parser) parseOr() (condition, error) {
+	cond, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	conds := orCondition{cond}
+	for p.peekKeyword("or") {
+		p.next()
+		if cond, err = p.parseAnd(); err != nil {
+			return nil, err
+		}
+		conds = append(conds, cond)
+	}
+	if len(conds) == 1 {
+		return conds[0], nil
+	}
+	return conds, nil
+}
+
+func (p *parser) parseAnd() (condition, error) {
+	cond, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	conds := andCondition{cond}
+	for p.peekKeyword("and") {
+		p.next()
+		if cond, err = p.parseNot(); err != nil {
+			return nil, err
+		}
+		conds = append(conds, cond)
+	}
+	if len(conds) == 1 {
+		return conds[0], nil
+	}
+	return conds, nil
+}
+
+func (p *parser) parseNot() (condition, error) {
+	if !p.peekKeyword("not") {
+		return p.parsePrimary()
+	}
+	p.next()
+	cond, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	return notCondition{cond: cond}, nil
+}
+
+func (p *parser) parsePrimary() (condition, error) {
+	if p.peek().kind == tokenLParen {
+		p.next()
+		cond, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err = p.expect(tokenRParen, `")"`); err != nil {
+			return nil, err
+		}
+		return cond, nil
+	}
+	if p.peekKeyword("IsMatch") {
+		return p.parseIsMatch()
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	switch p.peek().kind {
+	case tokenEq, tokenNe:
+		negate := p.next().kind == tokenNe
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return compareCondition{left: left, right: right, negate: negate}, nil
+	}
+	if l, ok := left.(literal); ok {
+		if b, ok := l.val.(bool); ok {
+			return boolCondition(b), nil
+		}
+	}
+	return nil, fmt.Errorf(`expected "==" or "!=", got %v`, p.peek())
+}
+
+func (p *parser) parseIsMatch() (condition, error) {
+	p.next()
+	if _, err := p.expect(tokenLParen, `"("`); err != nil {
+		return nil, err
+	}
+	target, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = p.expect(tokenComma, `","`); err != nil {
+		return nil, err
+	}
+	tok, err := p.expect(tokenString, "regular expression string")
+	if err != nil {
+		return nil, err
+	}
+	pattern, err := regexp.Compile(tok.text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression at position %d: %w", tok.pos, err)
+	}
+	if _, err = p.expect(tokenRParen, `")"`); err != nil {
+		return nil, err
+	}
+	return matchCondition{target: target, pattern: pattern}, nil
+}
+
+func (p *parser) parseOperand() (operand, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenString:
+		return literal{val: tok.text}, nil
+	case tokenNumber:
+		if i, err := strconv.ParseInt(tok.text, 10, 64); err == nil {
+			return literal{val: i}, nil
+		}
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %v", tok)
+		}
+		return literal{val: f}, nil
+	case tokenIdent:
+		switch tok.text {
+		case "nil":
+			return literal{val: nil}, nil
+		case "true":
+			return literal{val: true}, nil
+		case "false":
+			return literal{val: false}, nil
+		case resourceAttributesPath:
+			return p.parseAttributePath()
+		}
+		return nil, fmt.Errorf("unsupported path %v, only %q is supported", tok, resourceAttributesPath)
+	}
+	return nil, fmt.Errorf("expected a value, got %v", tok)
+}
+
+func (p *parser) parseAttributePath() (operand, error) {
+	var path attributePath
+	for len(path) == 0 || p.peek().kind == tokenLBracket {
+		if _, err := p.expect(tokenLBracket, `"["`); err != nil {
+			return nil, err
+		}
+		key, err := p.expect(tokenString, "attribute key string")
+		if err != nil {
+			return nil, err
+		}
+		if _, err = p.expect(tokenRBracket, `"]"`); err != nil {
+			return nil, err
+		}
+		path = append(path, key.text)
+	}
+	return path, nil
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func testResource() pcommon.Resource {
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("tenant", "acme")
+	res.Attributes().PutStr("service.name", "checkout-api")
+	res.Attributes().PutInt("shard", 3)
+	res.Attributes().PutDouble("ratio", 0.5)
+	res.Attributes().PutBool("canary", true)
+	res.Attributes().PutEmpty("empty")
+	res.Attributes().PutEmptyMap("k8s").PutStr("namespace", "prod")
+	return res
+}
+
+func TestCondition(t *testing.T) {
+	tests := []struct {
+		condition string
+		expected  bool
+	}{
+		{condition: `true`, expected: true},
+		{condition: `false`, expected: false},
+		{condition: `resource.attributes["tenant"] == "acme"`, expected: true},
+		{condition: `resource.attributes["tenant"] != "acme"`, expected: false},
+		{condition: `"acme" == resource.attributes["tenant"]`, expected: true},
+		{condition: `resource.attributes["tenant"] == "other"`, expected: false},
+		{condition: `resource.attributes["shard"] == 3`, expected: true},
+		{condition: `resource.attributes["shard"] == 3.0`, expected: true},
+		{condition: `resource.attributes["shard"] == "3"`, expected: false},
+		{condition: `resource.attributes["ratio"] == 0.5`, expected: true},
+		{condition: `resource.attributes["canary"] == true`, expected: true},
+		{condition: `resource.attributes["k8s"]["namespace"] == "prod"`, expected: true},
+		{condition: `resource.attributes["tenant"]["namespace"] == nil`, expected: true},
+		{condition: `resource.attributes["tenant"] != nil`, expected: true},
+		{condition: `resource.attributes["missing"] != nil`, expected: false},
+		{condition: `resource.attributes["missing"] == nil`, expected: true},
+		{condition: `resource.attributes["empty"] == nil`, expected: true},
+		{condition: `IsMatch(resource.attributes["service.name"], "^checkout-")`, expected: true},
+		{condition: `IsMatch(resource.attributes["service.name"], "^cart-")`, expected: false},
+		{condition: `IsMatch(resource.attributes["shard"], "^[0-9]+$")`, expected: true},
+		{condition: `IsMatch(resource.attributes["missing"], ".*")`, expected: false},
+		{condition: `IsMatch(resource.attributes["escaped"], "\\d")`, expected: false},
+		{condition: `not resource.attributes["tenant"] == "acme"`, expected: false},
+		{condition: `not not resource.attributes["tenant"] == "acme"`, expected: true},
+		{condition: `resource.attributes["tenant"] == "acme" and resource.attributes["shard"] == 4`, expected: false},
+		{condition: `resource.attributes["tenant"] == "acme" or resource.attributes["shard"] == 4`, expected: true},
+		{condition: `false or true and false`, expected: false},
+		{condition: `(false or true) and true`, expected: true},
+		{condition: `not (resource.attributes["tenant"] == "other" or resource.attributes["missing"] != nil)`, expected: true},
+	}
+	res := testResource()
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			cond, err := parseCondition(tt.condition)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cond.eval(res))
+		})
+	}
+}
+
+func TestConditionErrors(t *testing.T) {
+	tests := []struct {
+		condition string
+		expected  string
+	}{
+		{condition: ``, expected: `expected a value, got end of condition`},
+		{condition: `resource.attributes["tenant"] = "acme"`, expected: `unexpected character '=' at position 30`},
+		{condition: `resource.attributes["tenant"]`, expected: `expected "==" or "!=", got end of condition`},
+		{condition: `resource.attributes["tenant] == "acme"`, expected: `unterminated string at position 37`},
+		{condition: `resource.attributes["tenant" == "acme"`, expected: `expected "]", got "==" at position 29`},
+		{condition: `resource.attributes == "acme"`, expected: `expected "[", got "==" at position 20`},
+		{condition: `span.attributes["tenant"] == "acme"`, expected: `unsupported path "span.attributes" at position 0, only "resource.attributes" is supported`},
+		{condition: `IsMatch(resource.attributes["tenant"], "[")`, expected: "invalid regular expression at position 39: error parsing regexp: missing closing ]: `[`"},
+		{condition: `IsMatch(resource.attributes["tenant"], resource.attributes["other"])`, expected: `expected regular expression string, got "resource.attributes" at position 39`},
+		{condition: `IsMatch(resource.attributes["tenant"] "acme")`, expected: `expected ",", got "acme" at position 38`},
+		{condition: `(true`, expected: `expected ")", got end of condition`},
+		{condition: `true true`, expected: `unexpected "true" at position 5`},
+		{condition: `true and`, expected: `expected a value, got end of condition`},
+		{condition: `"unterminated`, expected: `unterminated string at position 0`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			_, err := parseCondition(tt.condition)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "go.opentelemetry.io/collector/connector/routingconnector"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	errNoTableItems = errors.New("the routing table is empty")
+	errNoCondition  = errors.New("no condition specified")
+	errNoPipelines  = errors.New("no pipelines specified")
+)
+
+// Config defines configuration for the routing connector.
+type Config struct {
+	// DefaultPipelines contains the pipelines to which data is routed when no route matches.
+	// Data that does not match any route is dropped when no default pipeline is configured.
+	DefaultPipelines []component.ID `mapstructure:"default_pipelines"`
+
+	// MatchOnce stops the evaluation of the routing table at the first matching route.
+	// By default data is sent to the pipelines of all the matching routes.
+	MatchOnce bool `mapstructure:"match_once"`
+
+	// Table contains the routes, evaluated in order for every resource.
+	Table []RoutingTableItem `mapstructure:"table"`
+}
+
+// RoutingTableItem is a route of the routing table.
+type RoutingTableItem struct {
+	// Condition selects the resources sent to Pipelines, e.g. `resource.attributes["tenant"] == "acme"`.
+	Condition string `mapstructure:"condition"`
+
+	// Pipelines contains the pipelines to which the matching data is routed.
+	Pipelines []component.ID `mapstructure:"pipelines"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the connector configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.Table) == 0 {
+		return errNoTableItems
+	}
+	for i, item := range cfg.Table {
+		if item.Condition == "" {
+			return fmt.Errorf("table[%d]: %w", i, errNoCondition)
+		}
+		if len(item.Pipelines) == 0 {
+			return fmt.Errorf("table[%d]: %w", i, errNoPipelines)
+		}
+		if _, err := parseCondition(item.Condition); err != nil {
+			return fmt.Errorf("table[%d]: invalid condition %q: %w", i, item.Condition, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr error
+	}{
+		{
+			id: component.NewID(typeStr),
+			expected: &Config{
+				DefaultPipelines: []component.ID{
+					component.NewIDWithName(component.DataTypeTraces, "default"),
+					component.NewIDWithName(component.DataTypeMetrics, "default"),
+				},
+				MatchOnce: true,
+				Table: []RoutingTableItem{
+					{
+						Condition: `resource.attributes["tenant"] == "acme"`,
+						Pipelines: []component.ID{
+							component.NewIDWithName(component.DataTypeTraces, "acme"),
+							component.NewIDWithName(component.DataTypeMetrics, "acme"),
+						},
+					},
+					{
+						Condition: `IsMatch(resource.attributes["service.name"], "^checkout-.*") and resource.attributes["env"] != nil`,
+						Pipelines: []component.ID{component.NewIDWithName(component.DataTypeTraces, "checkout")},
+					},
+				},
+			},
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "empty_table"),
+			expectedErr: errNoTableItems,
+		},
+		{
+			id: component.NewIDWithName(typeStr, "invalid_condition"),
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "no_pipelines"),
+			expectedErr: errNoPipelines,
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "no_condition"),
+			expectedErr: errNoCondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expected == nil {
+				err = component.ValidateConfig(cfg)
+				require.Error(t, err)
+				if tt.expectedErr != nil {
+					assert.ErrorIs(t, err, tt.expectedErr)
+				}
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
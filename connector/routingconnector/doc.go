@@ -0,0 +1,5 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package routingconnector routes signals to pipelines based on conditions on their resource.
+package routingconnector // import "go.opentelemetry.io/collector/connector/routingconnector"
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "go.opentelemetry.io/collector/connector/routingconnector"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectorhelper"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	typeStr = "routing"
+)
+
+// NewFactory returns a connector.Factory.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		connector.WithTracesToTraces(createTracesToTraces, component.StabilityLevelDevelopment),
+		connector.WithMetricsToMetrics(createMetricsToMetrics, component.StabilityLevelDevelopment),
+		connector.WithLogsToLogs(createLogsToLogs, component.StabilityLevelDevelopment),
+	)
+}
+
+// createDefaultConfig creates the default configuration.
+func createDefaultConfig() component.Config {
+	return &Config{}
+}
+
+// createTracesToTraces creates a traces connector routing to traces pipelines.
+func createTracesToTraces(
+	ctx context.Context,
+	set connector.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (connector.Traces, error) {
+	var available []component.ID
+	if tr, ok := nextConsumer.(connector.TracesRouter); ok {
+		available = tr.PipelineIDs()
+	}
+	r, err := newRouter(cfg.(*Config), component.DataTypeTraces, available)
+	if err != nil {
+		return nil, err
+	}
+	return connectorhelper.NewTracesRouter(ctx, set, cfg, nextConsumer,
+		func(ctx context.Context, rs ptrace.ResourceSpans) []component.ID {
+			return r.route(ctx, rs.Resource())
+		})
+}
+
+// createMetricsToMetrics creates a metrics connector routing to metrics pipelines.
+func createMetricsToMetrics(
+	ctx context.Context,
+	set connector.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (connector.Metrics, error) {
+	var available []component.ID
+	if mr, ok := nextConsumer.(connector.MetricsRouter); ok {
+		available = mr.PipelineIDs()
+	}
+	r, err := newRouter(cfg.(*Config), component.DataTypeMetrics, available)
+	if err != nil {
+		return nil, err
+	}
+	return connectorhelper.NewMetricsRouter(ctx, set, cfg, nextConsumer,
+		func(ctx context.Context, rm pmetric.ResourceMetrics) []component.ID {
+			return r.route(ctx, rm.Resource())
+		})
+}
+
+// createLogsToLogs creates a logs connector routing to logs pipelines.
+func createLogsToLogs(
+	ctx context.Context,
+	set connector.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (connector.Logs, error) {
+	var available []component.ID
+	if lr, ok := nextConsumer.(connector.LogsRouter); ok {
+		available = lr.PipelineIDs()
+	}
+	r, err := newRouter(cfg.(*Config), component.DataTypeLogs, available)
+	if err != nil {
+		return nil, err
+	}
+	return connectorhelper.NewLogsRouter(ctx, set, cfg, nextConsumer,
+		func(ctx context.Context, rl plog.ResourceLogs) []component.ID {
+			return r.route(ctx, rl.Resource())
+		})
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	tracesDefault  = component.NewIDWithName(component.DataTypeTraces, "default")
+	tracesAcme     = component.NewIDWithName(component.DataTypeTraces, "acme")
+	metricsDefault = component.NewIDWithName(component.DataTypeMetrics, "default")
+	metricsAcme    = component.NewIDWithName(component.DataTypeMetrics, "acme")
+	logsDefault    = component.NewIDWithName(component.DataTypeLogs, "default")
+	logsAcme       = component.NewIDWithName(component.DataTypeLogs, "acme")
+)
+
+func testConfig() *Config {
+	return &Config{
+		DefaultPipelines: []component.ID{tracesDefault, metricsDefault, logsDefault},
+		Table: []RoutingTableItem{
+			{
+				Condition: `resource.attributes["tenant"] == "acme"`,
+				Pipelines: []component.ID{tracesAcme, metricsAcme, logsAcme},
+			},
+		},
+	}
+}
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig()
+	assert.Equal(t, &Config{}, cfg)
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestTracesRouting(t *testing.T) {
+	defaultSink := new(consumertest.TracesSink)
+	acmeSink := new(consumertest.TracesSink)
+	router := connectortest.NewTracesRouter(
+		connectortest.WithTracesSink(tracesDefault, defaultSink),
+		connectortest.WithTracesSink(tracesAcme, acmeSink),
+	)
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(), connectortest.NewNopCreateSettings(), testConfig(), router.(consumer.Traces))
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, conn.Shutdown(context.Background())) }()
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("tenant", "acme")
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("tenant", "other")
+	td.ResourceSpans().AppendEmpty()
+	require.NoError(t, conn.ConsumeTraces(context.Background(), td))
+
+	require.Len(t, acmeSink.AllTraces(), 1)
+	assert.Equal(t, 1, acmeSink.AllTraces()[0].ResourceSpans().Len())
+	require.Len(t, defaultSink.AllTraces(), 1)
+	assert.Equal(t, 2, defaultSink.AllTraces()[0].ResourceSpans().Len())
+}
+
+func TestMetricsRouting(t *testing.T) {
+	defaultSink := new(consumertest.MetricsSink)
+	acmeSink := new(consumertest.MetricsSink)
+	router := connectortest.NewMetricsRouter(
+		connectortest.WithMetricsSink(metricsDefault, defaultSink),
+		connectortest.WithMetricsSink(metricsAcme, acmeSink),
+	)
+	conn, err := NewFactory().CreateMetricsToMetrics(context.Background(), connectortest.NewNopCreateSettings(), testConfig(), router.(consumer.Metrics))
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, conn.Shutdown(context.Background())) }()
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("tenant", "acme")
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("tenant", "other")
+	require.NoError(t, conn.ConsumeMetrics(context.Background(), md))
+
+	require.Len(t, acmeSink.AllMetrics(), 1)
+	assert.Equal(t, 1, acmeSink.AllMetrics()[0].ResourceMetrics().Len())
+	require.Len(t, defaultSink.AllMetrics(), 1)
+	assert.Equal(t, 1, defaultSink.AllMetrics()[0].ResourceMetrics().Len())
+}
+
+func TestLogsRouting(t *testing.T) {
+	defaultSink := new(consumertest.LogsSink)
+	acmeSink := new(consumertest.LogsSink)
+	router := connectortest.NewLogsRouter(
+		connectortest.WithLogsSink(logsDefault, defaultSink),
+		connectortest.WithLogsSink(logsAcme, acmeSink),
+	)
+	conn, err := NewFactory().CreateLogsToLogs(context.Background(), connectortest.NewNopCreateSettings(), testConfig(), router.(consumer.Logs))
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, conn.Shutdown(context.Background())) }()
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("tenant", "acme")
+	ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("tenant", "acme")
+	require.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+
+	require.Len(t, acmeSink.AllLogs(), 1)
+	assert.Equal(t, 2, acmeSink.AllLogs()[0].ResourceLogs().Len())
+	assert.Len(t, defaultSink.AllLogs(), 0)
+}
+
+func TestCreateWithUnknownPipeline(t *testing.T) {
+	router := connectortest.NewTracesRouter(connectortest.WithNopTraces(tracesDefault))
+	_, err := NewFactory().CreateTracesToTraces(context.Background(), connectortest.NewNopCreateSettings(), testConfig(), router.(consumer.Traces))
+	assert.EqualError(t, err, `pipeline "traces/acme" is not connected to the routing connector`)
+}
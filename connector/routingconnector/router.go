@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "go.opentelemetry.io/collector/connector/routingconnector"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+type route struct {
+	cond      condition
+	pipelines []component.ID
+}
+
+// router selects the pipelines of a single data type a resource is routed to.
+type router struct {
+	routes           []route
+	defaultPipelines []component.ID
+	matchOnce        bool
+}
+
+// newRouter creates a router for the given data type. Pipelines of other data types are ignored,
+// which allows using the same routing table for all the signals. When available is not nil, all the
+// pipelines referenced by the routing table must be part of it.
+func newRouter(cfg *Config, dataType component.DataType, available []component.ID) (*router, error) {
+	known := make(map[component.ID]bool, len(available))
+	for _, id := range available {
+		known[id] = true
+	}
+	filter := func(ids []component.ID) ([]component.ID, error) {
+		var filtered []component.ID
+		for _, id := range ids {
+			if id.Type() != dataType {
+				continue
+			}
+			if available != nil && !known[id] {
+				return nil, fmt.Errorf("pipeline %q is not connected to the routing connector", id)
+			}
+			filtered = append(filtered, id)
+		}
+		return filtered, nil
+	}
+
+	r := &router{matchOnce: cfg.MatchOnce}
+	var err error
+	if r.defaultPipelines, err = filter(cfg.DefaultPipelines); err != nil {
+		return nil, err
+	}
+	for _, item := range cfg.Table {
+		cond, err := parseCondition(item.Condition)
+		if err != nil {
+			return nil, fmt.Errorf("invalid condition %q: %w", item.Condition, err)
+		}
+		pipelines, err := filter(item.Pipelines)
+		if err != nil {
+			return nil, err
+		}
+		if len(pipelines) == 0 {
+			continue
+		}
+		r.routes = append(r.routes, route{cond: cond, pipelines: pipelines})
+	}
+	return r, nil
+}
+
+// route returns the pipelines the given resource is routed to.
+func (r *router) route(_ context.Context, res pcommon.Resource) []component.ID {
+	var matched []component.ID
+	for _, rt := range r.routes {
+		if !rt.cond.eval(res) {
+			continue
+		}
+		matched = appendUnique(matched, rt.pipelines)
+		if r.matchOnce {
+			break
+		}
+	}
+	if len(matched) == 0 {
+		return r.defaultPipelines
+	}
+	return matched
+}
+
+func appendUnique(ids []component.ID, add []component.ID) []component.ID {
+	for _, id := range add {
+		found := false
+		for _, existing := range ids {
+			if existing == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestRouterRoute(t *testing.T) {
+	tracesA := component.NewIDWithName(component.DataTypeTraces, "a")
+	tracesB := component.NewIDWithName(component.DataTypeTraces, "b")
+	tracesC := component.NewIDWithName(component.DataTypeTraces, "c")
+	metricsA := component.NewIDWithName(component.DataTypeMetrics, "a")
+	cfg := &Config{
+		DefaultPipelines: []component.ID{tracesC, metricsA},
+		Table: []RoutingTableItem{
+			{Condition: `resource.attributes["a"] != nil`, Pipelines: []component.ID{tracesA, metricsA}},
+			{Condition: `resource.attributes["b"] != nil`, Pipelines: []component.ID{tracesA, tracesB}},
+			{Condition: `true`, Pipelines: []component.ID{metricsA}},
+		},
+	}
+
+	res := func(keys ...string) pcommon.Resource {
+		r := pcommon.NewResource()
+		for _, k := range keys {
+			r.Attributes().PutBool(k, true)
+		}
+		return r
+	}
+
+	r, err := newRouter(cfg, component.DataTypeTraces, nil)
+	require.NoError(t, err)
+	// The last route has no traces pipeline and is never evaluated.
+	assert.Len(t, r.routes, 2)
+	assert.Equal(t, []component.ID{tracesA}, r.route(context.Background(), res("a")))
+	assert.Equal(t, []component.ID{tracesA, tracesB}, r.route(context.Background(), res("a", "b")))
+	assert.Equal(t, []component.ID{tracesC}, r.route(context.Background(), res()))
+
+	cfg.MatchOnce = true
+	r, err = newRouter(cfg, component.DataTypeTraces, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []component.ID{tracesA}, r.route(context.Background(), res("a", "b")))
+	assert.Equal(t, []component.ID{tracesA, tracesB}, r.route(context.Background(), res("b")))
+
+	r, err = newRouter(cfg, component.DataTypeLogs, nil)
+	require.NoError(t, err)
+	assert.Empty(t, r.route(context.Background(), res("a")))
+
+	_, err = newRouter(cfg, component.DataTypeTraces, []component.ID{tracesA, tracesB})
+	assert.EqualError(t, err, `pipeline "traces/c" is not connected to the routing connector`)
+}
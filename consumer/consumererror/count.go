@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+// Counts is the number of items of the data which were not accepted by a consumer.
+type Counts struct {
+	// Permanent is the number of items rejected with a permanent error, which must not be sent again.
+	Permanent int
+	// Retryable is the number of items which failed with a retryable error.
+	Retryable int
+}
+
+// Failed returns the number of items which were not accepted.
+func (c Counts) Failed() int {
+	return c.Permanent + c.Retryable
+}
+
+// Count returns the number of items, out of the total number of items of the data, which were not
+// accepted because of err, the error returned when consuming the data:
+//   - the items rejected by a PartialError, which are rejected permanently,
+//   - the items of the data carried by a Traces, Metrics, Logs or Profiles error,
+//   - all the items for any other error.
+//
+// When err aggregates the errors of several consumers which received the same data, such as the
+// exporters of a pipeline, an item failing in several consumers is counted once.
+func Count(err error, total int) Counts {
+	if err == nil {
+		return Counts{}
+	}
+	c := count(err, total, false)
+	if c.Failed() > total {
+		c.Retryable = total - c.Permanent
+	}
+	return c
+}
+
+func count(err error, total int, perm bool) Counts {
+	for {
+		switch e := err.(type) {
+		case PartialError:
+			return newCounts(e.Rejected(), total, true)
+		case Traces:
+			return newCounts(e.Data().SpanCount(), total, perm || IsPermanent(e))
+		case Metrics:
+			return newCounts(e.Data().DataPointCount(), total, perm || IsPermanent(e))
+		case Logs:
+			return newCounts(e.Data().LogRecordCount(), total, perm || IsPermanent(e))
+		case Profiles:
+			return newCounts(e.Data().SampleCount(), total, perm || IsPermanent(e))
+		case permanent:
+			perm = true
+			err = e.err
+		case interface{ Unwrap() []error }:
+			// The consumers received the same data, the items failing in a consumer may fail in the others.
+			var c Counts
+			for _, wrapped := range e.Unwrap() {
+				wc := count(wrapped, total, perm)
+				if wc.Permanent > c.Permanent {
+					c.Permanent = wc.Permanent
+				}
+				if wc.Retryable > c.Retryable {
+					c.Retryable = wc.Retryable
+				}
+			}
+			return c
+		case interface{ Unwrap() error }:
+			if err = e.Unwrap(); err == nil {
+				return newCounts(total, total, perm)
+			}
+		default:
+			return newCounts(total, total, perm)
+		}
+	}
+}
+
+func newCounts(items, total int, perm bool) Counts {
+	if items < 0 {
+		items = 0
+	} else if items > total {
+		items = total
+	}
+	if perm {
+		return Counts{Permanent: items}
+	}
+	return Counts{Retryable: items}
+}
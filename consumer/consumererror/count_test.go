@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestCount(t *testing.T) {
+	errFailed := errors.New("failed")
+	for _, tt := range []struct {
+		name string
+		err  error
+		want Counts
+	}{
+		{name: "nil"},
+		{name: "retryable", err: errFailed, want: Counts{Retryable: 10}},
+		{name: "permanent", err: fmt.Errorf("export: %w", NewPermanent(errFailed)), want: Counts{Permanent: 10}},
+		{name: "partial", err: NewPartial(errFailed, 3), want: Counts{Permanent: 3}},
+		{name: "partial_larger_than_total", err: NewPartial(errFailed, 30), want: Counts{Permanent: 10}},
+		{name: "traces", err: NewTraces(errFailed, testdata.GenerateTraces(2)), want: Counts{Retryable: 2}},
+		{name: "metrics", err: NewMetrics(errFailed, testdata.GenerateMetrics(2)), want: Counts{Retryable: 4}},
+		{name: "logs", err: NewLogs(errFailed, testdata.GenerateLogs(2)), want: Counts{Retryable: 2}},
+		{name: "permanent_logs", err: NewPermanent(NewLogs(errFailed, testdata.GenerateLogs(2))), want: Counts{Permanent: 2}},
+		{name: "profiles", err: NewProfiles(NewPermanent(errFailed), testdata.GenerateProfiles(1)), want: Counts{Permanent: testdata.GenerateProfiles(1).SampleCount()}},
+		{
+			name: "aggregated",
+			err:  multierr.Combine(NewPartial(errFailed, 3), NewPartial(errFailed, 1), NewTraces(errFailed, testdata.GenerateTraces(2))),
+			want: Counts{Permanent: 3, Retryable: 2},
+		},
+		{
+			name: "aggregated_with_failure",
+			err:  multierr.Combine(NewPartial(errFailed, 3), errFailed),
+			want: Counts{Permanent: 3, Retryable: 7},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Count(tt.err, 10)
+			assert.Equal(t, tt.want, c)
+			assert.Equal(t, tt.want.Permanent+tt.want.Retryable, c.Failed())
+		})
+	}
+}
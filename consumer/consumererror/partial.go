@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+// PartialError is an error indicating that the destination rejected a part of the data,
+// and accepted the rest of it.
+type PartialError struct {
+	err        error
+	rejected   int
+	rejections []Rejection
+}
+
+// Rejection is a number of items of the data rejected for the same reason.
+type Rejection struct {
+	// Reason describes why the items were rejected, such as "too old".
+	Reason string
+	// Count is the number of items rejected.
+	Count int
+}
+
+// NewPartial creates a permanent error indicating that the destination rejected the given
+// number of items of the data, spans, metric points or log records, and accepted the rest of it.
+// The rejected items are not identified, so the data must not be retried.
+func NewPartial(err error, rejected int) error {
+	return NewPermanent(PartialError{err: err, rejected: rejected})
+}
+
+// NewPartialWithReasons creates a permanent error like NewPartial, detailing why the items were rejected.
+// The number of rejected items is the sum of the counts of the rejections.
+func NewPartialWithReasons(err error, rejections ...Rejection) error {
+	rejected := 0
+	for _, r := range rejections {
+		rejected += r.Count
+	}
+	return NewPermanent(PartialError{err: err, rejected: rejected, rejections: rejections})
+}
+
+func (p PartialError) Error() string {
+	return p.err.Error()
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard package errors.
+func (p PartialError) Unwrap() error {
+	return p.err
+}
+
+// Rejected returns the number of items rejected by the destination.
+func (p PartialError) Rejected() int {
+	return p.rejected
+}
+
+// Reasons returns why the items were rejected, nil if the reasons are unknown.
+func (p PartialError) Reasons() []Rejection {
+	return p.rejections
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartial(t *testing.T) {
+	cause := errors.New("3 spans are too old")
+	err := fmt.Errorf("export failed: %w", NewPartial(cause, 3))
+
+	assert.True(t, IsPermanent(err))
+	assert.ErrorIs(t, err, cause)
+	assert.EqualError(t, err, "export failed: Permanent error: 3 spans are too old")
+
+	var partialErr PartialError
+	require.True(t, errors.As(err, &partialErr))
+	assert.Equal(t, 3, partialErr.Rejected())
+	assert.False(t, errors.As(NewPermanent(cause), &partialErr))
+}
+
+func TestPartialWithReasons(t *testing.T) {
+	cause := errors.New("5 spans were rejected")
+	err := NewPartialWithReasons(cause, Rejection{Reason: "too old", Count: 3}, Rejection{Reason: "too large", Count: 2})
+
+	assert.True(t, IsPermanent(err))
+	var partialErr PartialError
+	require.True(t, errors.As(err, &partialErr))
+	assert.Equal(t, 5, partialErr.Rejected())
+	assert.Equal(t, []Rejection{{Reason: "too old", Count: 3}, {Reason: "too large", Count: 2}}, partialErr.Reasons())
+
+	require.True(t, errors.As(NewPartial(cause, 5), &partialErr))
+	assert.Nil(t, partialErr.Reasons())
+}
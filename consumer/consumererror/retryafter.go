@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+import "time"
+
+// retryAfter is an error asking to retry the data after a delay.
+type retryAfter struct {
+	err   error
+	delay time.Duration
+}
+
+// NewRetryAfter wraps an error to indicate that the data can be sent again after the given delay,
+// for instance because the destination is throttling the requests. The receivers pass the delay
+// to their clients when their protocol supports it.
+func NewRetryAfter(err error, delay time.Duration) error {
+	return retryAfter{err: err, delay: delay}
+}
+
+func (r retryAfter) Error() string {
+	return r.err.Error()
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard package errors.
+func (r retryAfter) Unwrap() error {
+	return r.err
+}
+
+// RetryAfter returns the delay after which the data can be sent again, if err was wrapped with
+// NewRetryAfter. When err aggregates the errors of several consumers, the longest delay is returned.
+func RetryAfter(err error) (time.Duration, bool) {
+	var delay time.Duration
+	found := false
+	each(err, func(err error) {
+		if r, ok := err.(retryAfter); ok && (!found || r.delay > delay) {
+			delay = r.delay
+			found = true
+		}
+	})
+	return delay, found
+}
+
+// each calls fn with err and every error it wraps, including the errors aggregated by multierr.
+func each(err error, fn func(error)) {
+	for err != nil {
+		fn(err)
+		switch e := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, wrapped := range e.Unwrap() {
+				each(wrapped, fn)
+			}
+			return
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/multierr"
+)
+
+func TestRetryAfter(t *testing.T) {
+	cause := errors.New("throttled")
+	err := fmt.Errorf("export failed: %w", NewRetryAfter(cause, time.Second))
+
+	assert.EqualError(t, err, "export failed: throttled")
+	assert.ErrorIs(t, err, cause)
+	assert.False(t, IsPermanent(err))
+	delay, ok := RetryAfter(err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, delay)
+
+	_, ok = RetryAfter(cause)
+	assert.False(t, ok)
+	_, ok = RetryAfter(nil)
+	assert.False(t, ok)
+}
+
+func TestRetryAfterAggregated(t *testing.T) {
+	err := multierr.Combine(
+		NewRetryAfter(errors.New("first"), time.Second),
+		errors.New("second"),
+		fmt.Errorf("third: %w", NewRetryAfter(errors.New("throttled"), 3*time.Second)),
+	)
+
+	delay, ok := RetryAfter(err)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, delay)
+}
@@ -6,10 +6,11 @@ package consumererror // import "go.opentelemetry.io/collector/consumer/consumer
 import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
-type retryable[V ptrace.Traces | pmetric.Metrics | plog.Logs] struct {
+type retryable[V ptrace.Traces | pmetric.Metrics | plog.Logs | pprofile.Profiles] struct {
 	error
 	data V
 }
@@ -71,3 +72,19 @@ func NewMetrics(err error, data pmetric.Metrics) error {
 		},
 	}
 }
+
+// Profiles is an error that may carry associated Profiles data for a subset of received data
+// that failed to be processed or sent.
+type Profiles struct {
+	retryable[pprofile.Profiles]
+}
+
+// NewProfiles creates a Profiles that can encapsulate received data that failed to be processed or sent.
+func NewProfiles(err error, data pprofile.Profiles) error {
+	return Profiles{
+		retryable: retryable[pprofile.Profiles]{
+			error: err,
+			data:  data,
+		},
+	}
+}
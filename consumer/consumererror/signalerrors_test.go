@@ -84,3 +84,27 @@ func TestMetrics_Unwrap(t *testing.T) {
 	require.True(t, errors.As(metricErr, &target))
 	require.Equal(t, err, target)
 }
+
+func TestProfiles(t *testing.T) {
+	td := testdata.GenerateProfiles(1)
+	err := errors.New("some error")
+	profilesErr := NewProfiles(err, td)
+	assert.Equal(t, err.Error(), profilesErr.Error())
+	var target Profiles
+	assert.False(t, errors.As(nil, &target))
+	assert.False(t, errors.As(err, &target))
+	assert.True(t, errors.As(profilesErr, &target))
+	assert.Equal(t, td, target.Data())
+}
+
+func TestProfiles_Unwrap(t *testing.T) {
+	td := testdata.GenerateProfiles(1)
+	var err error = testErrorType{"some error"}
+	// Wrapping err with error Profiles.
+	profilesErr := NewProfiles(err, td)
+	target := testErrorType{}
+	require.NotEqual(t, err, target)
+	// Unwrapping profilesErr for err and assigning to target.
+	require.True(t, errors.As(profilesErr, &target))
+	require.Equal(t, err, target)
+}
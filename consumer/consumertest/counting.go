@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumertest // import "go.opentelemetry.io/collector/consumer/consumertest"
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// CountingSink is a Consumer that counts the received data without storing it, to write
+// performance tests against the consumer API. The items are the spans, metric data points,
+// log records and profile samples. All its methods are safe for concurrent use.
+//
+// The zero value drops the received data, NewCountingSink returns a CountingSink passing
+// the data to the next consumer and measuring its latency.
+type CountingSink struct {
+	next Consumer
+
+	mu               sync.Mutex
+	batchCount       int
+	itemCount        int
+	byteSize         int
+	itemsPerResource map[string]int
+	totalLatency     time.Duration
+	maxLatency       time.Duration
+	first            time.Time
+	last             time.Time
+}
+
+var _ Consumer = (*CountingSink)(nil)
+
+// NewCountingSink returns a CountingSink passing the received data to next.
+func NewCountingSink(next Consumer) *CountingSink {
+	return &CountingSink{next: next}
+}
+
+// Capabilities returns the capabilities of the next consumer.
+func (cs *CountingSink) Capabilities() consumer.Capabilities {
+	if cs.next == nil {
+		return consumer.Capabilities{MutatesData: false}
+	}
+	return cs.next.Capabilities()
+}
+
+// ConsumeTraces counts the traces and passes them to the next consumer.
+func (cs *CountingSink) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	// Count before passing the data, the next consumer may modify it.
+	items := make(map[string]int, td.ResourceSpans().Len())
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		n := 0
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			n += rs.ScopeSpans().At(j).Spans().Len()
+		}
+		items[resourceKey(rs.Resource())] += n
+	}
+	size := (&ptrace.ProtoMarshaler{}).TracesSize(td)
+	return cs.consume(items, size, func() error {
+		if cs.next == nil {
+			return nil
+		}
+		return cs.next.ConsumeTraces(ctx, td)
+	})
+}
+
+// ConsumeMetrics counts the metrics and passes them to the next consumer.
+func (cs *CountingSink) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	items := make(map[string]int, md.ResourceMetrics().Len())
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		n := 0
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			ms := rm.ScopeMetrics().At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				n += dataPointCount(ms.At(k))
+			}
+		}
+		items[resourceKey(rm.Resource())] += n
+	}
+	size := (&pmetric.ProtoMarshaler{}).MetricsSize(md)
+	return cs.consume(items, size, func() error {
+		if cs.next == nil {
+			return nil
+		}
+		return cs.next.ConsumeMetrics(ctx, md)
+	})
+}
+
+// ConsumeLogs counts the logs and passes them to the next consumer.
+func (cs *CountingSink) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	items := make(map[string]int, ld.ResourceLogs().Len())
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		n := 0
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			n += rl.ScopeLogs().At(j).LogRecords().Len()
+		}
+		items[resourceKey(rl.Resource())] += n
+	}
+	size := (&plog.ProtoMarshaler{}).LogsSize(ld)
+	return cs.consume(items, size, func() error {
+		if cs.next == nil {
+			return nil
+		}
+		return cs.next.ConsumeLogs(ctx, ld)
+	})
+}
+
+// ConsumeProfiles counts the profiles and passes them to the next consumer.
+func (cs *CountingSink) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	items := make(map[string]int, pd.ResourceProfiles().Len())
+	for i := 0; i < pd.ResourceProfiles().Len(); i++ {
+		rp := pd.ResourceProfiles().At(i)
+		n := 0
+		for j := 0; j < rp.ScopeProfiles().Len(); j++ {
+			pcs := rp.ScopeProfiles().At(j).Profiles()
+			for k := 0; k < pcs.Len(); k++ {
+				n += pcs.At(k).Profile().Sample().Len()
+			}
+		}
+		items[resourceKey(rp.Resource())] += n
+	}
+	size := (&pprofile.ProtoMarshaler{}).ProfilesSize(pd)
+	return cs.consume(items, size, func() error {
+		if cs.next == nil {
+			return nil
+		}
+		return cs.next.ConsumeProfiles(ctx, pd)
+	})
+}
+
+// consume records a batch of the given items per resource and size, passed to the next consumer with next.
+func (cs *CountingSink) consume(items map[string]int, size int, next func() error) error {
+	start := time.Now()
+	err := next()
+	end := time.Now()
+	latency := end.Sub(start)
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.itemsPerResource == nil {
+		cs.itemsPerResource = make(map[string]int)
+	}
+	cs.batchCount++
+	cs.byteSize += size
+	for resource, n := range items {
+		cs.itemCount += n
+		cs.itemsPerResource[resource] += n
+	}
+	cs.totalLatency += latency
+	if latency > cs.maxLatency {
+		cs.maxLatency = latency
+	}
+	if cs.first.IsZero() || start.Before(cs.first) {
+		cs.first = start
+	}
+	if end.After(cs.last) {
+		cs.last = end
+	}
+	return err
+}
+
+// BatchCount returns the number of batches received since last Reset.
+func (cs *CountingSink) BatchCount() int {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.batchCount
+}
+
+// ItemCount returns the number of items received since last Reset.
+func (cs *CountingSink) ItemCount() int {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.itemCount
+}
+
+// ByteSize returns the size of the data received since last Reset, encoded as OTLP protobuf.
+func (cs *CountingSink) ByteSize() int {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.byteSize
+}
+
+// ItemCountPerResource returns the number of items received since last Reset per resource,
+// identified by its attributes formatted as "key=value" pairs sorted by key and separated by commas.
+func (cs *CountingSink) ItemCountPerResource() map[string]int {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	items := make(map[string]int, len(cs.itemsPerResource))
+	for resource, n := range cs.itemsPerResource {
+		items[resource] = n
+	}
+	return items
+}
+
+// MeanLatency returns the mean time the next consumer took to consume a batch since last Reset.
+func (cs *CountingSink) MeanLatency() time.Duration {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.batchCount == 0 {
+		return 0
+	}
+	return cs.totalLatency / time.Duration(cs.batchCount)
+}
+
+// MaxLatency returns the maximum time the next consumer took to consume a batch since last Reset.
+func (cs *CountingSink) MaxLatency() time.Duration {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.maxLatency
+}
+
+// Throughput returns the number of items received per second, from the start of the first batch
+// received since last Reset to the end of the last one.
+func (cs *CountingSink) Throughput() float64 {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	elapsed := cs.last.Sub(cs.first)
+	if cs.batchCount == 0 || elapsed <= 0 {
+		return 0
+	}
+	return float64(cs.itemCount) / elapsed.Seconds()
+}
+
+// Reset deletes the counts.
+func (cs *CountingSink) Reset() {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.batchCount = 0
+	cs.itemCount = 0
+	cs.byteSize = 0
+	cs.itemsPerResource = nil
+	cs.totalLatency = 0
+	cs.maxLatency = 0
+	cs.first = time.Time{}
+	cs.last = time.Time{}
+}
+
+func (cs *CountingSink) unexported() {}
+
+func dataPointCount(m pmetric.Metric) int {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		return m.Summary().DataPoints().Len()
+	}
+	return 0
+}
+
+// resourceKey identifies the resource by its attributes.
+func resourceKey(res pcommon.Resource) string {
+	pairs := make([]string, 0, res.Attributes().Len())
+	res.Attributes().Range(func(k string, v pcommon.Value) bool {
+		pairs = append(pairs, k+"="+v.AsString())
+		return true
+	})
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
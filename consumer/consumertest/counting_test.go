@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumertest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestCountingSink(t *testing.T) {
+	sink := new(CountingSink)
+	td := testdata.GenerateTraces(2)
+	md := testdata.GenerateMetrics(2)
+	ld := testdata.GenerateLogs(3)
+	pd := testdata.GenerateProfiles(2)
+	ld.ResourceLogs().At(0).Resource().Attributes().PutStr("service.name", "svc")
+	require.NoError(t, sink.ConsumeTraces(context.Background(), td))
+	require.NoError(t, sink.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, sink.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, sink.ConsumeProfiles(context.Background(), pd))
+
+	assert.Equal(t, 4, sink.BatchCount())
+	assert.Equal(t, 2+4+3+2, sink.ItemCount())
+	assert.Equal(t, (&ptrace.ProtoMarshaler{}).TracesSize(td)+(&pmetric.ProtoMarshaler{}).MetricsSize(md)+
+		(&plog.ProtoMarshaler{}).LogsSize(ld)+(&pprofile.ProtoMarshaler{}).ProfilesSize(pd), sink.ByteSize())
+	assert.Equal(t, map[string]int{
+		"resource-attr=resource-attr-val-1":                  8,
+		"resource-attr=resource-attr-val-1,service.name=svc": 3,
+	}, sink.ItemCountPerResource())
+	assert.Greater(t, sink.Throughput(), float64(0))
+	assert.False(t, sink.Capabilities().MutatesData)
+
+	sink.Reset()
+	assert.Equal(t, 0, sink.BatchCount())
+	assert.Equal(t, 0, sink.ItemCount())
+	assert.Equal(t, 0, sink.ByteSize())
+	assert.Empty(t, sink.ItemCountPerResource())
+	assert.Equal(t, time.Duration(0), sink.MeanLatency())
+	assert.Equal(t, float64(0), sink.Throughput())
+}
+
+func TestCountingSinkNext(t *testing.T) {
+	next := new(TracesSink)
+	slow := &baseConsumer{
+		ConsumeTracesFunc: func(ctx context.Context, td ptrace.Traces) error {
+			time.Sleep(10 * time.Millisecond)
+			return next.ConsumeTraces(ctx, td)
+		},
+	}
+	sink := NewCountingSink(slow)
+	require.NoError(t, sink.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Equal(t, 1, next.SpanCount())
+	assert.GreaterOrEqual(t, sink.MeanLatency(), 10*time.Millisecond)
+	assert.GreaterOrEqual(t, sink.MaxLatency(), 10*time.Millisecond)
+	// The throughput accounts for the latency of the next consumer.
+	assert.LessOrEqual(t, sink.Throughput(), float64(100))
+
+	errSink := NewCountingSink(NewErr(errors.New("error")))
+	assert.Error(t, errSink.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	assert.Equal(t, 1, errSink.ItemCount())
+}
+
+func TestCountingSinkConcurrent(t *testing.T) {
+	sink := new(CountingSink)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				assert.NoError(t, sink.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+				sink.ItemCountPerResource()
+				sink.Throughput()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1000, sink.BatchCount())
+	assert.Equal(t, 1000, sink.ItemCount())
+}
@@ -8,14 +8,16 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 // NewNop returns a Consumer that just drops all received data and returns no error.
 func NewNop() Consumer {
 	return &baseConsumer{
-		ConsumeTracesFunc:  func(ctx context.Context, td ptrace.Traces) error { return nil },
-		ConsumeMetricsFunc: func(ctx context.Context, md pmetric.Metrics) error { return nil },
-		ConsumeLogsFunc:    func(ctx context.Context, ld plog.Logs) error { return nil },
+		ConsumeTracesFunc:   func(ctx context.Context, td ptrace.Traces) error { return nil },
+		ConsumeMetricsFunc:  func(ctx context.Context, md pmetric.Metrics) error { return nil },
+		ConsumeLogsFunc:     func(ctx context.Context, ld plog.Logs) error { return nil },
+		ConsumeProfilesFunc: func(ctx context.Context, pd pprofile.Profiles) error { return nil },
 	}
 }
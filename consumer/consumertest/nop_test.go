@@ -12,6 +12,7 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
@@ -22,4 +23,5 @@ func TestNop(t *testing.T) {
 	assert.NoError(t, nc.ConsumeLogs(context.Background(), plog.NewLogs()))
 	assert.NoError(t, nc.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
 	assert.NoError(t, nc.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.NoError(t, nc.ConsumeProfiles(context.Background(), pprofile.NewProfiles()))
 }
@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
@@ -60,3 +61,18 @@ func TestLogsSink(t *testing.T) {
 	assert.Equal(t, 0, len(sink.AllLogs()))
 	assert.Equal(t, 0, sink.LogRecordCount())
 }
+
+func TestProfilesSink(t *testing.T) {
+	sink := new(ProfilesSink)
+	pd := testdata.GenerateProfiles(2)
+	want := make([]pprofile.Profiles, 0, 7)
+	for i := 0; i < 7; i++ {
+		require.NoError(t, sink.ConsumeProfiles(context.Background(), pd))
+		want = append(want, pd)
+	}
+	assert.Equal(t, want, sink.AllProfiles())
+	assert.Equal(t, 2*len(want), sink.SampleCount())
+	sink.Reset()
+	assert.Equal(t, 0, len(sink.AllProfiles()))
+	assert.Equal(t, 0, sink.SampleCount())
+}
@@ -31,6 +31,12 @@ type Logs interface {
 	consumer.Logs
 }
 
+// Profiles is an exporter that can consume profiles.
+type Profiles interface {
+	component.Component
+	consumer.Profiles
+}
+
 // CreateSettings configures exporter creators.
 type CreateSettings struct {
 	// ID returns the ID of the component that will be created.
@@ -73,6 +79,14 @@ type Factory interface {
 	// LogsExporterStability gets the stability level of the LogsExporter.
 	LogsExporterStability() component.StabilityLevel
 
+	// CreateProfilesExporter creates a ProfilesExporter based on the config.
+	// If the exporter type does not support profiles or if the config is not valid,
+	// an error will be returned instead.
+	CreateProfilesExporter(ctx context.Context, set CreateSettings, cfg component.Config) (Profiles, error)
+
+	// ProfilesExporterStability gets the stability level of the ProfilesExporter.
+	ProfilesExporterStability() component.StabilityLevel
+
 	unexportedFactoryFunc()
 }
 
@@ -124,6 +138,17 @@ func (f CreateLogsFunc) CreateLogsExporter(ctx context.Context, set CreateSettin
 	return f(ctx, set, cfg)
 }
 
+// CreateProfilesFunc is the equivalent of Factory.CreateProfiles.
+type CreateProfilesFunc func(context.Context, CreateSettings, component.Config) (Profiles, error)
+
+// CreateProfilesExporter implements Factory.CreateProfilesExporter().
+func (f CreateProfilesFunc) CreateProfilesExporter(ctx context.Context, set CreateSettings, cfg component.Config) (Profiles, error) {
+	if f == nil {
+		return nil, component.ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg)
+}
+
 type factory struct {
 	cfgType component.Type
 	component.CreateDefaultConfigFunc
@@ -133,6 +158,9 @@ type factory struct {
 	metricsStabilityLevel component.StabilityLevel
 	CreateLogsFunc
 	logsStabilityLevel component.StabilityLevel
+	CreateProfilesFunc
+	profilesStabilityLevel component.StabilityLevel
+	capabilities           component.FactoryCapabilities
 }
 
 func (f *factory) Type() component.Type {
@@ -141,6 +169,10 @@ func (f *factory) Type() component.Type {
 
 func (f *factory) unexportedFactoryFunc() {}
 
+func (f *factory) Capabilities() component.FactoryCapabilities {
+	return f.capabilities
+}
+
 func (f *factory) TracesExporterStability() component.StabilityLevel {
 	return f.tracesStabilityLevel
 }
@@ -153,6 +185,10 @@ func (f *factory) LogsExporterStability() component.StabilityLevel {
 	return f.logsStabilityLevel
 }
 
+func (f *factory) ProfilesExporterStability() component.StabilityLevel {
+	return f.profilesStabilityLevel
+}
+
 // WithTraces overrides the default "error not supported" implementation for CreateTracesExporter and the default "undefined" stability level.
 func WithTraces(createTraces CreateTracesFunc, sl component.StabilityLevel) FactoryOption {
 	return factoryOptionFunc(func(o *factory) {
@@ -177,6 +213,21 @@ func WithLogs(createLogs CreateLogsFunc, sl component.StabilityLevel) FactoryOpt
 	})
 }
 
+// WithProfiles overrides the default "error not supported" implementation for CreateProfilesExporter and the default "undefined" stability level.
+func WithProfiles(createProfiles CreateProfilesFunc, sl component.StabilityLevel) FactoryOption {
+	return factoryOptionFunc(func(o *factory) {
+		o.profilesStabilityLevel = sl
+		o.CreateProfilesFunc = createProfiles
+	})
+}
+
+// WithCapabilities declares the capabilities of the exporters created by the factory.
+func WithCapabilities(capabilities component.FactoryCapabilities) FactoryOption {
+	return factoryOptionFunc(func(o *factory) {
+		o.capabilities = capabilities
+	})
+}
+
 // NewFactory returns a Factory.
 func NewFactory(cfgType component.Type, createDefaultConfig component.CreateDefaultConfigFunc, options ...FactoryOption) Factory {
 	f := &factory{
@@ -261,6 +312,27 @@ func (b *Builder) CreateLogs(ctx context.Context, set CreateSettings) (Logs, err
 	return f.CreateLogsExporter(ctx, set, cfg)
 }
 
+// CreateProfiles creates a Profiles exporter based on the settings and config.
+func (b *Builder) CreateProfiles(ctx context.Context, set CreateSettings) (Profiles, error) {
+	cfg, existsCfg := b.cfgs[set.ID]
+	if !existsCfg {
+		return nil, fmt.Errorf("exporter %q is not configured", set.ID)
+	}
+
+	f, existsFactory := b.factories[set.ID.Type()]
+	if !existsFactory {
+		return nil, fmt.Errorf("exporter factory not available for: %q", set.ID)
+	}
+
+	logStabilityLevel(set.Logger, f.ProfilesExporterStability())
+	return f.CreateProfilesExporter(ctx, set, cfg)
+}
+
+// Config returns the configuration of the exporter with the given ID, or nil if it is not configured.
+func (b *Builder) Config(id component.ID) component.Config {
+	return b.cfgs[id]
+}
+
 func (b *Builder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
@@ -29,6 +29,19 @@ func TestNewFactory(t *testing.T) {
 	assert.Error(t, err)
 	_, err = factory.CreateLogsExporter(context.Background(), CreateSettings{}, &defaultCfg)
 	assert.Error(t, err)
+	_, err = factory.CreateProfilesExporter(context.Background(), CreateSettings{}, &defaultCfg)
+	assert.Error(t, err)
+}
+
+func TestNewFactoryWithCapabilities(t *testing.T) {
+	const typeStr = "test"
+	defaultCfg := struct{}{}
+	factory := NewFactory(typeStr, func() component.Config { return &defaultCfg })
+	assert.Equal(t, component.FactoryCapabilities{}, factory.Capabilities())
+
+	capabilities := component.FactoryCapabilities{MutatesData: true, SingleInstance: true, Stateful: true}
+	factory = NewFactory(typeStr, func() component.Config { return &defaultCfg }, WithCapabilities(capabilities))
+	assert.Equal(t, capabilities, factory.Capabilities())
 }
 
 func TestNewFactoryWithOptions(t *testing.T) {
@@ -39,7 +52,8 @@ func TestNewFactoryWithOptions(t *testing.T) {
 		func() component.Config { return &defaultCfg },
 		WithTraces(createTraces, component.StabilityLevelDevelopment),
 		WithMetrics(createMetrics, component.StabilityLevelAlpha),
-		WithLogs(createLogs, component.StabilityLevelDeprecated))
+		WithLogs(createLogs, component.StabilityLevelDeprecated),
+		WithProfiles(createProfiles, component.StabilityLevelDevelopment))
 	assert.EqualValues(t, typeStr, factory.Type())
 	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
 
@@ -54,6 +68,10 @@ func TestNewFactoryWithOptions(t *testing.T) {
 	assert.Equal(t, component.StabilityLevelDeprecated, factory.LogsExporterStability())
 	_, err = factory.CreateLogsExporter(context.Background(), CreateSettings{}, &defaultCfg)
 	assert.NoError(t, err)
+
+	assert.Equal(t, component.StabilityLevelDevelopment, factory.ProfilesExporterStability())
+	_, err = factory.CreateProfilesExporter(context.Background(), CreateSettings{}, &defaultCfg)
+	assert.NoError(t, err)
 }
 
 func TestMakeFactoryMap(t *testing.T) {
@@ -104,6 +122,7 @@ func TestBuilder(t *testing.T) {
 			WithTraces(createTraces, component.StabilityLevelDevelopment),
 			WithMetrics(createMetrics, component.StabilityLevelAlpha),
 			WithLogs(createLogs, component.StabilityLevelDeprecated),
+			WithProfiles(createProfiles, component.StabilityLevelDevelopment),
 		),
 	}...)
 	require.NoError(t, err)
@@ -164,6 +183,15 @@ func TestBuilder(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, nopInstance, le)
 			}
+
+			pe, err := b.CreateProfiles(context.Background(), createSettings(tt.id))
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				assert.Nil(t, pe)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, nopInstance, pe)
+			}
 		})
 	}
 }
@@ -177,6 +205,7 @@ func TestBuilderMissingConfig(t *testing.T) {
 			WithTraces(createTraces, component.StabilityLevelDevelopment),
 			WithMetrics(createMetrics, component.StabilityLevelAlpha),
 			WithLogs(createLogs, component.StabilityLevelDeprecated),
+			WithProfiles(createProfiles, component.StabilityLevelDevelopment),
 		),
 	}...)
 
@@ -196,6 +225,10 @@ func TestBuilderMissingConfig(t *testing.T) {
 	le, err := bErr.CreateLogs(context.Background(), createSettings(missingID))
 	assert.EqualError(t, err, "exporter \"all/missing\" is not configured")
 	assert.Nil(t, le)
+
+	pe, err := bErr.CreateProfiles(context.Background(), createSettings(missingID))
+	assert.EqualError(t, err, "exporter \"all/missing\" is not configured")
+	assert.Nil(t, pe)
 }
 
 func TestBuilderFactory(t *testing.T) {
@@ -232,6 +265,10 @@ func createLogs(context.Context, CreateSettings, component.Config) (Logs, error)
 	return nopInstance, nil
 }
 
+func createProfiles(context.Context, CreateSettings, component.Config) (Profiles, error) {
+	return nopInstance, nil
+}
+
 func createSettings(id component.ID) CreateSettings {
 	return CreateSettings{
 		ID:                id,
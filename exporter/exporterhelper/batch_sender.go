@@ -0,0 +1,344 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+// maxBatchSpanLinks is the maximum number of requests a batch links to, the default limit of links per span of the SDK.
+const maxBatchSpanLinks = 128
+
+// errTooManyBatchers is returned when the MetadataCardinalityLimit has been reached.
+var errTooManyBatchers = consumererror.NewPermanent(errors.New("too many batcher metadata-value combinations"))
+
+// BatcherSettings defines configuration for batching the requests before sending them.
+type BatcherSettings struct {
+	// Enabled indicates whether to batch the requests before sending them.
+	Enabled bool `mapstructure:"enabled"`
+	// FlushTimeout is the time after which a batch is sent regardless of its size, 0 means that
+	// the requests are sent as soon as they are received, only split according to MaxSize.
+	FlushTimeout time.Duration `mapstructure:"flush_timeout"`
+	// MinSize is the number of items (spans, metric data points or log records) at which a batch is
+	// sent regardless of the timeout, 0 means that the batches are sent on timeout only.
+	MinSize int `mapstructure:"min_size"`
+	// MaxSize is the maximum number of items of the batches, above which they are split, 0 means no limit.
+	MaxSize int `mapstructure:"max_size"`
+	// MaxBytes is the estimated size in bytes of the batches at which they are sent, 0 means no limit.
+	// The size of a batch is estimated as the size of its protobuf encoding, and a request larger
+	// than MaxBytes is sent in its own batch.
+	MaxBytes int `mapstructure:"max_bytes"`
+	// MetadataKeys is a list of client.Metadata keys used to form distinct batchers, as the batch processor does.
+	// The batches are sent with a context containing the values of these keys only.
+	MetadataKeys []string `mapstructure:"metadata_keys"`
+	// MetadataCardinalityLimit indicates the maximum number of batcher instances that will be created
+	// through a distinct combination of MetadataKeys.
+	MetadataCardinalityLimit uint32 `mapstructure:"metadata_cardinality_limit"`
+}
+
+// NewDefaultBatcherSettings returns the default settings for BatcherSettings.
+func NewDefaultBatcherSettings() BatcherSettings {
+	return BatcherSettings{
+		Enabled:                  false,
+		FlushTimeout:             200 * time.Millisecond,
+		MinSize:                  8192,
+		MetadataCardinalityLimit: 1000,
+	}
+}
+
+// Validate checks if the BatcherSettings configuration is valid
+func (bCfg *BatcherSettings) Validate() error {
+	if !bCfg.Enabled {
+		return nil
+	}
+
+	if bCfg.FlushTimeout < 0 {
+		return errors.New("batch flush timeout must not be negative")
+	}
+
+	if bCfg.MinSize < 0 || bCfg.MaxSize < 0 || bCfg.MaxBytes < 0 {
+		return errors.New("batch min size, max size and max bytes must not be negative")
+	}
+
+	if bCfg.MaxSize != 0 && bCfg.MaxSize < bCfg.MinSize {
+		return errors.New("batch max size must be greater or equal to min size")
+	}
+
+	uniq := map[string]bool{}
+	for _, k := range bCfg.MetadataKeys {
+		l := strings.ToLower(k)
+		if _, has := uniq[l]; has {
+			return fmt.Errorf("duplicate entry in batch metadata_keys: %q (case-insensitive)", l)
+		}
+		uniq[l] = true
+	}
+
+	return nil
+}
+
+// batch accumulates the data of the requests of a signal.
+type batch interface {
+	// add moves the data of the request into the batch.
+	add(req internal.Request)
+
+	// itemCount returns the number of items of the batch.
+	itemCount() int
+
+	// requests returns the requests sending the data of the batch with the given context, of at most
+	// maxSize items each if maxSize is not 0, and empties the batch.
+	requests(ctx context.Context, maxSize int) []internal.Request
+}
+
+// batchSender is a requestSender which batches the requests before sending them to the next sender.
+//
+// The batches are sent with any of the following conditions:
+// - the number of items reaches MinSize
+// - the estimated size in bytes reaches MaxBytes
+// - FlushTimeout is elapsed since the previous batch was sent
+type batchSender struct {
+	cfg              BatcherSettings
+	nextSender       requestSender
+	batchFunc        func() batch
+	onEnqueueFailure func(ctx context.Context, count int64)
+	logger           *zap.Logger
+
+	// metadataKeys is the sorted, lower-cased list of metadata keys. When empty, a single shard is used.
+	metadataKeys []string
+
+	shutdownC  chan struct{}
+	goroutines sync.WaitGroup
+
+	// single is the shard used when metadataKeys is empty.
+	single *batchShard
+	shards sync.Map
+
+	// Guards the number of shards to ensure no more than MetadataCardinalityLimit are created.
+	lock sync.Mutex
+	size int
+}
+
+func newBatchSender(cfg BatcherSettings, batchFunc func() batch, nextSender requestSender, onEnqueueFailure func(context.Context, int64), logger *zap.Logger) *batchSender {
+	// use lower-case, to be consistent with http/2 headers.
+	mks := make([]string, len(cfg.MetadataKeys))
+	for i, k := range cfg.MetadataKeys {
+		mks[i] = strings.ToLower(k)
+	}
+	sort.Strings(mks)
+	return &batchSender{
+		cfg:              cfg,
+		nextSender:       nextSender,
+		batchFunc:        batchFunc,
+		onEnqueueFailure: onEnqueueFailure,
+		logger:           logger,
+		metadataKeys:     mks,
+		shutdownC:        make(chan struct{}),
+	}
+}
+
+// start is invoked during service startup.
+func (bs *batchSender) start() {
+	if len(bs.metadataKeys) == 0 {
+		bs.single = bs.newShard(context.Background())
+	}
+}
+
+// shutdown sends the pending batches, it is invoked during service shutdown before the queue is stopped.
+func (bs *batchSender) shutdown() {
+	close(bs.shutdownC)
+	// Wait until all goroutines are done.
+	bs.goroutines.Wait()
+}
+
+// send implements the requestSender interface
+func (bs *batchSender) send(req internal.Request) error {
+	if bs.single != nil {
+		bs.single.newItem <- req
+		return nil
+	}
+
+	// Get each metadata key value, form the corresponding
+	// attribute set for use as a map lookup key.
+	info := client.FromContext(req.Context())
+	md := map[string][]string{}
+	var attrs []attribute.KeyValue
+	for _, k := range bs.metadataKeys {
+		vs := info.Metadata.Get(k)
+		md[k] = vs
+		if len(vs) == 1 {
+			attrs = append(attrs, attribute.String(k, vs[0]))
+		} else {
+			attrs = append(attrs, attribute.StringSlice(k, vs))
+		}
+	}
+	aset := attribute.NewSet(attrs...)
+
+	b, ok := bs.shards.Load(aset)
+	if !ok {
+		bs.lock.Lock()
+		b, ok = bs.shards.Load(aset)
+		if !ok {
+			if bs.cfg.MetadataCardinalityLimit != 0 && bs.size >= int(bs.cfg.MetadataCardinalityLimit) {
+				bs.lock.Unlock()
+				return errTooManyBatchers
+			}
+			b = bs.newShard(client.NewContext(context.Background(), client.Info{
+				Metadata: client.NewMetadata(md),
+			}))
+			bs.shards.Store(aset, b)
+			bs.size++
+		}
+		bs.lock.Unlock()
+	}
+	b.(*batchShard).newItem <- req
+	return nil
+}
+
+// batchShard is a single instance of the batch logic. When metadata keys are in use,
+// one of these is created per distinct combination of values.
+type batchShard struct {
+	sender *batchSender
+
+	// exportCtx is a context with the metadata key-values corresponding with this shard.
+	exportCtx context.Context
+
+	// timer informs the shard to send a batch.
+	timer *time.Timer
+
+	// newItem is used to receive the requests from producers.
+	newItem chan internal.Request
+
+	batch batch
+	// bytes is the estimated size in bytes of the batch, only tracked if MaxBytes is set.
+	bytes int
+	// spanContexts are the span contexts of the requests of the batch, the spans of the batch link to them.
+	spanContexts []trace.SpanContext
+}
+
+func (bs *batchSender) newShard(exportCtx context.Context) *batchShard {
+	b := &batchShard{
+		sender:    bs,
+		exportCtx: exportCtx,
+		newItem:   make(chan internal.Request, runtime.NumCPU()),
+		batch:     bs.batchFunc(),
+	}
+	bs.goroutines.Add(1)
+	go b.start()
+	return b
+}
+
+func (b *batchShard) start() {
+	defer b.sender.goroutines.Done()
+
+	// timerCh ensures we only block when there is a
+	// timer, since <- from a nil channel is blocking.
+	var timerCh <-chan time.Time
+	if b.sender.cfg.FlushTimeout != 0 {
+		b.timer = time.NewTimer(b.sender.cfg.FlushTimeout)
+		timerCh = b.timer.C
+	}
+	for {
+		select {
+		case <-b.sender.shutdownC:
+		DONE:
+			for {
+				select {
+				case req := <-b.newItem:
+					b.processRequest(req)
+				default:
+					break DONE
+				}
+			}
+			if b.batch.itemCount() > 0 {
+				b.sendBatch()
+			}
+			return
+		case req := <-b.newItem:
+			b.processRequest(req)
+		case <-timerCh:
+			if b.batch.itemCount() > 0 {
+				b.sendBatch()
+			}
+			b.resetTimer()
+		}
+	}
+}
+
+func (b *batchShard) processRequest(req internal.Request) {
+	cfg := b.sender.cfg
+	sent := false
+	reqBytes := 0
+	if cfg.MaxBytes != 0 {
+		reqBytes = req.BytesSize()
+		// Send the current batch first if the request does not fit in it.
+		if b.batch.itemCount() > 0 && b.bytes+reqBytes > cfg.MaxBytes {
+			b.sendBatch()
+			sent = true
+		}
+	}
+
+	b.batch.add(req)
+	b.bytes += reqBytes
+	if sc := trace.SpanContextFromContext(req.Context()); sc.IsValid() && len(b.spanContexts) < maxBatchSpanLinks {
+		b.spanContexts = append(b.spanContexts, sc)
+	}
+	if b.batch.itemCount() > 0 && (!b.hasTimer() ||
+		cfg.MinSize != 0 && b.batch.itemCount() >= cfg.MinSize ||
+		cfg.MaxBytes != 0 && b.bytes >= cfg.MaxBytes) {
+		b.sendBatch()
+		sent = true
+	}
+
+	if sent {
+		b.stopTimer()
+		b.resetTimer()
+	}
+}
+
+func (b *batchShard) hasTimer() bool {
+	return b.timer != nil
+}
+
+func (b *batchShard) stopTimer() {
+	if b.hasTimer() && !b.timer.Stop() {
+		<-b.timer.C
+	}
+}
+
+func (b *batchShard) resetTimer() {
+	if b.hasTimer() {
+		b.timer.Reset(b.sender.cfg.FlushTimeout)
+	}
+}
+
+func (b *batchShard) sendBatch() {
+	reqs := b.batch.requests(obsreport.ContextWithSpanLinks(b.exportCtx, b.spanContexts...), b.sender.cfg.MaxSize)
+	b.bytes = 0
+	b.spanContexts = b.spanContexts[:0]
+	for _, req := range reqs {
+		err := b.sender.nextSender.send(req)
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, errSendingQueueIsFull) {
+			b.sender.onEnqueueFailure(req.Context(), int64(req.Count()))
+		}
+		b.sender.logger.Warn("Sending batch failed", zap.Error(err), zap.Int("items", req.Count()))
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func getTestSpanName(requestNum, index int) string {
+	return fmt.Sprintf("test-span-%d-%d", requestNum, index)
+}
+
+func getTestMetricName(requestNum, index int) string {
+	return fmt.Sprintf("test-metric-int-%d-%d", requestNum, index)
+}
+
+func getTestLogSeverityText(requestNum, index int) string {
+	return fmt.Sprintf("test-log-int-%d-%d", requestNum, index)
+}
+
+func TestBatcherSettings_Validate(t *testing.T) {
+	cfg := NewDefaultBatcherSettings()
+	assert.NoError(t, cfg.Validate())
+	cfg.Enabled = true
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MaxSize = cfg.MinSize - 1
+	assert.EqualError(t, cfg.Validate(), "batch max size must be greater or equal to min size")
+
+	cfg = NewDefaultBatcherSettings()
+	cfg.Enabled = true
+	cfg.FlushTimeout = -time.Second
+	assert.EqualError(t, cfg.Validate(), "batch flush timeout must not be negative")
+
+	cfg = NewDefaultBatcherSettings()
+	cfg.Enabled = true
+	cfg.MaxBytes = -1
+	assert.EqualError(t, cfg.Validate(), "batch min size, max size and max bytes must not be negative")
+
+	cfg = NewDefaultBatcherSettings()
+	cfg.Enabled = true
+	cfg.MetadataKeys = []string{"Tenant", "tenant"}
+	assert.EqualError(t, cfg.Validate(), `duplicate entry in batch metadata_keys: "tenant" (case-insensitive)`)
+}
+
+// tracesPushSink records the traces and the contexts passed to the pusher.
+type tracesPushSink struct {
+	mu       sync.Mutex
+	traces   []ptrace.Traces
+	contexts []context.Context
+}
+
+func (s *tracesPushSink) push(ctx context.Context, td ptrace.Traces) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.traces = append(s.traces, td)
+	s.contexts = append(s.contexts, ctx)
+	return nil
+}
+
+func (s *tracesPushSink) spanCounts() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := make([]int, len(s.traces))
+	for i, td := range s.traces {
+		counts[i] = td.SpanCount()
+	}
+	return counts
+}
+
+func newBatchedTracesExporter(t *testing.T, cfg BatcherSettings, sink *tracesPushSink) exporter.Traces {
+	cfg.Enabled = true
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeTracesExporterConfig, sink.push, WithBatcher(cfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	return te
+}
+
+func TestBatchSender_MinSize(t *testing.T) {
+	sink := &tracesPushSink{}
+	te := newBatchedTracesExporter(t, BatcherSettings{FlushTimeout: time.Hour, MinSize: 10}, sink)
+	assert.True(t, te.Capabilities().MutatesData)
+
+	for i := 0; i < 7; i++ {
+		require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	}
+	assert.Eventually(t, func() bool { return len(sink.spanCounts()) == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, []int{10}, sink.spanCounts())
+
+	// The pending batch is sent on shutdown.
+	require.NoError(t, te.Shutdown(context.Background()))
+	assert.Equal(t, []int{10, 4}, sink.spanCounts())
+}
+
+func TestBatchSender_FlushTimeout(t *testing.T) {
+	sink := &tracesPushSink{}
+	te := newBatchedTracesExporter(t, BatcherSettings{FlushTimeout: 10 * time.Millisecond, MinSize: 100}, sink)
+	t.Cleanup(func() {
+		assert.NoError(t, te.Shutdown(context.Background()))
+	})
+
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	assert.Eventually(t, func() bool { return len(sink.spanCounts()) == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, []int{5}, sink.spanCounts())
+}
+
+func TestBatchSender_MaxSize(t *testing.T) {
+	sink := &tracesPushSink{}
+	te := newBatchedTracesExporter(t, BatcherSettings{MaxSize: 3}, sink)
+
+	// Without timeout, the requests are only split.
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(7)))
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	require.NoError(t, te.Shutdown(context.Background()))
+	assert.Equal(t, []int{3, 3, 1, 2}, sink.spanCounts())
+}
+
+func TestBatchSender_MaxBytes(t *testing.T) {
+	sink := &tracesPushSink{}
+	size := (&ptrace.ProtoMarshaler{}).TracesSize(testdata.GenerateTraces(2))
+	te := newBatchedTracesExporter(t, BatcherSettings{FlushTimeout: time.Hour, MinSize: 100, MaxBytes: 2*size + 1}, sink)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	}
+	// The third request does not fit in the first batch.
+	assert.Eventually(t, func() bool { return len(sink.spanCounts()) == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, []int{4}, sink.spanCounts())
+
+	// A request larger than the limit is sent in its own batch.
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(10)))
+	assert.Eventually(t, func() bool { return len(sink.spanCounts()) == 3 }, time.Second, time.Millisecond)
+	assert.Equal(t, []int{4, 2, 10}, sink.spanCounts())
+	require.NoError(t, te.Shutdown(context.Background()))
+}
+
+func TestBatchSender_MetadataKeys(t *testing.T) {
+	sink := &tracesPushSink{}
+	te := newBatchedTracesExporter(t, BatcherSettings{FlushTimeout: time.Hour, MinSize: 100, MetadataKeys: []string{"Tenant"}, MetadataCardinalityLimit: 2}, sink)
+
+	tenantCtx := func(tenant string) context.Context {
+		return client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"tenant": {tenant}, "other": {tenant}}),
+		})
+	}
+	require.NoError(t, te.ConsumeTraces(tenantCtx("a"), testdata.GenerateTraces(1)))
+	require.NoError(t, te.ConsumeTraces(tenantCtx("b"), testdata.GenerateTraces(2)))
+	require.NoError(t, te.ConsumeTraces(tenantCtx("a"), testdata.GenerateTraces(3)))
+	// The cardinality limit is reached.
+	assert.ErrorIs(t, te.ConsumeTraces(tenantCtx("c"), testdata.GenerateTraces(1)), errTooManyBatchers)
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	require.Len(t, sink.traces, 2)
+	spansByTenant := map[string]int{}
+	for i, ctx := range sink.contexts {
+		info := client.FromContext(ctx)
+		require.Len(t, info.Metadata.Get("tenant"), 1)
+		// Only the metadata keys are kept.
+		assert.Empty(t, info.Metadata.Get("other"))
+		spansByTenant[info.Metadata.Get("tenant")[0]] = sink.traces[i].SpanCount()
+	}
+	assert.Equal(t, map[string]int{"a": 4, "b": 2}, spansByTenant)
+}
+
+func TestBatchSender_SpanLinks(t *testing.T) {
+	sr := new(tracetest.SpanRecorder)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	set := exportertest.NewNopCreateSettings()
+	set.ID = fakeTracesExporterName
+	set.TracerProvider = tp
+	sink := &tracesPushSink{}
+	te, err := NewTracesExporter(context.Background(), set, &fakeTracesExporterConfig, sink.push,
+		WithBatcher(BatcherSettings{Enabled: true, FlushTimeout: time.Hour, MinSize: 3}))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+	var parents []trace.SpanContext
+	for i := 0; i < 3; i++ {
+		ctx, span := tp.Tracer("test").Start(context.Background(), "request")
+		require.NoError(t, te.ConsumeTraces(ctx, testdata.GenerateTraces(1)))
+		span.End()
+		parents = append(parents, span.SpanContext())
+	}
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	var exportSpans []sdktrace.ReadOnlySpan
+	for _, span := range sr.Ended() {
+		if span.Name() == "exporter/"+fakeTracesExporterName.String()+"/traces" {
+			exportSpans = append(exportSpans, span)
+		}
+	}
+	require.Len(t, exportSpans, 1)
+	// The span of the batch is not the child of any request, it links to all of them.
+	assert.False(t, exportSpans[0].Parent().IsValid())
+	var linked []trace.SpanContext
+	for _, link := range exportSpans[0].Links() {
+		linked = append(linked, link.SpanContext)
+	}
+	assert.Equal(t, parents, linked)
+}
+
+func TestBatchSender_MetricsAndLogs(t *testing.T) {
+	var mu sync.Mutex
+	var dataPoints, logRecords []int
+	me, err := NewMetricsExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeMetricsExporterConfig,
+		func(_ context.Context, md pmetric.Metrics) error {
+			mu.Lock()
+			defer mu.Unlock()
+			dataPoints = append(dataPoints, md.DataPointCount())
+			return nil
+		}, WithBatcher(BatcherSettings{Enabled: true, FlushTimeout: time.Hour, MinSize: 6, MaxSize: 6}))
+	require.NoError(t, err)
+	le, err := NewLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeLogsExporterConfig,
+		func(_ context.Context, ld plog.Logs) error {
+			mu.Lock()
+			defer mu.Unlock()
+			logRecords = append(logRecords, ld.LogRecordCount())
+			return nil
+		}, WithBatcher(BatcherSettings{Enabled: true, FlushTimeout: time.Hour, MinSize: 6, MaxSize: 6}))
+	require.NoError(t, err)
+	require.NoError(t, me.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, me.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(2)))
+		require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogs(4)))
+	}
+	require.NoError(t, me.Shutdown(context.Background()))
+	require.NoError(t, le.Shutdown(context.Background()))
+
+	// testdata.GenerateMetrics generates 2 data points per metric.
+	assert.Equal(t, []int{6, 2}, dataPoints)
+	assert.Equal(t, []int{6, 2}, logRecords)
+}
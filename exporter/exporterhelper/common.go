@@ -5,10 +5,12 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
 	"go.opentelemetry.io/collector/obsreport"
@@ -64,6 +66,8 @@ type baseSettings struct {
 	TimeoutSettings
 	QueueSettings
 	RetrySettings
+	DeadLetterSettings
+	BatcherSettings
 }
 
 // fromOptions returns the internal options starting from the default and applying all configured options.
@@ -127,6 +131,26 @@ func WithQueue(queueSettings QueueSettings) Option {
 	}
 }
 
+// WithDeadLetter overrides the default DeadLetterSettings for an exporter.
+// The default DeadLetterSettings is to drop the batches which failed permanently.
+func WithDeadLetter(deadLetterSettings DeadLetterSettings) Option {
+	return func(o *baseSettings) {
+		o.DeadLetterSettings = deadLetterSettings
+	}
+}
+
+// WithBatcher overrides the default BatcherSettings for an exporter.
+// The default BatcherSettings is to disable batching.
+// Since the data of the requests is moved into the batches, the exporter mutates the data when batching is enabled.
+func WithBatcher(batcherSettings BatcherSettings) Option {
+	return func(o *baseSettings) {
+		o.BatcherSettings = batcherSettings
+		if batcherSettings.Enabled {
+			o.consumerOptions = append(o.consumerOptions, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+		}
+	}
+}
+
 // WithCapabilities overrides the default Capabilities() function for a Consumer.
 // The default is non-mutable data.
 // TODO: Verify if we can change the default to be mutable as we do for processors.
@@ -140,13 +164,20 @@ func WithCapabilities(capabilities consumer.Capabilities) Option {
 type baseExporter struct {
 	component.StartFunc
 	component.ShutdownFunc
-	obsrep   *obsExporter
-	sender   requestSender
-	qrSender *queuedRetrySender
+	obsrep      *obsExporter
+	sender      requestSender
+	qrSender    *queuedRetrySender
+	batchSender *batchSender
+
+	// deadLetterMaxAge is the max age of the replayed dead letter files, 0 means no limit.
+	deadLetterMaxAge time.Duration
 }
 
 func newBaseExporter(set exporter.CreateSettings, bs *baseSettings, signal component.DataType, reqUnmarshaler internal.RequestUnmarshaler) (*baseExporter, error) {
 	be := &baseExporter{}
+	if bs.DeadLetterSettings.Enabled {
+		be.deadLetterMaxAge = bs.DeadLetterSettings.MaxAge
+	}
 
 	var err error
 	be.obsrep, err = newObsExporter(obsreport.ExporterSettings{ExporterID: set.ID, ExporterCreateSettings: set}, globalInstruments)
@@ -154,8 +185,11 @@ func newBaseExporter(set exporter.CreateSettings, bs *baseSettings, signal compo
 		return nil, err
 	}
 
-	be.qrSender = newQueuedRetrySender(set.ID, signal, bs.QueueSettings, bs.RetrySettings, reqUnmarshaler, &timeoutSender{cfg: bs.TimeoutSettings}, set.Logger)
+	be.qrSender = newQueuedRetrySender(set.ID, signal, bs.QueueSettings, bs.RetrySettings, bs.DeadLetterSettings, reqUnmarshaler, &timeoutSender{cfg: bs.TimeoutSettings}, set.Logger)
 	be.sender = be.qrSender
+	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
+		return &latencySender{obsrep: be.obsrep, nextSender: nextSender}
+	})
 	be.StartFunc = func(ctx context.Context, host component.Host) error {
 		// First start the wrapped exporter.
 		if err := bs.StartFunc.Start(ctx, host); err != nil {
@@ -163,17 +197,61 @@ func newBaseExporter(set exporter.CreateSettings, bs *baseSettings, signal compo
 		}
 
 		// If no error then start the queuedRetrySender.
-		return be.qrSender.start(ctx, host)
+		if err := be.qrSender.start(ctx, host); err != nil {
+			return err
+		}
+
+		// Last start the batchSender, sending the batches to the queuedRetrySender.
+		if be.batchSender != nil {
+			be.batchSender.start()
+		}
+		return nil
 	}
+	// The exporter is only shut down once, Shutdown may be called again.
+	var shutdownOnce sync.Once
 	be.ShutdownFunc = func(ctx context.Context) error {
-		// First shutdown the queued retry sender
-		be.qrSender.shutdown()
-		// Last shutdown the wrapped exporter itself.
-		return bs.ShutdownFunc.Shutdown(ctx)
+		var err error
+		shutdownOnce.Do(func() {
+			// First send the pending batches
+			if be.batchSender != nil {
+				be.batchSender.shutdown()
+			}
+			// Then shutdown the queued retry sender
+			be.qrSender.shutdown(ctx)
+			// Last shutdown the wrapped exporter itself.
+			err = bs.ShutdownFunc.Shutdown(ctx)
+		})
+		return err
 	}
 	return be, nil
 }
 
+// initBatchSender batches the requests before sending them to the queuedRetrySender, if batching is enabled.
+func (be *baseExporter) initBatchSender(set exporter.CreateSettings, bs *baseSettings, batchFunc func() batch, onEnqueueFailure func(context.Context, int64)) {
+	if !bs.BatcherSettings.Enabled {
+		return
+	}
+	bCfg := bs.BatcherSettings
+	// Batch the requests of each partition of the queue separately, so that its batches are not mixed.
+	if len(bCfg.MetadataKeys) == 0 && bs.QueueSettings.Enabled {
+		bCfg.MetadataKeys = bs.QueueSettings.MetadataKeys
+	}
+	be.batchSender = newBatchSender(bCfg, batchFunc, be.qrSender, onEnqueueFailure, set.Logger)
+	be.sender = be.batchSender
+}
+
+// send sends the request through the batcher and the sending queue, or directly if it replays a dead letter file.
+func (be *baseExporter) send(req internal.Request) error {
+	written, replayed := deadLetterReplayTime(req.Context())
+	if !replayed {
+		return be.sender.send(req)
+	}
+	if be.deadLetterMaxAge != 0 && time.Since(written) > be.deadLetterMaxAge {
+		return consumererror.NewPermanent(ErrDeadLetterExpired)
+	}
+	return be.qrSender.consumerSender.send(req)
+}
+
 // wrapConsumerSender wraps the consumer sender (the sender that uses retries and timeout) with the given wrapper.
 // This can be used to wrap with observability (create spans, record metrics) the consumer sender.
 func (be *baseExporter) wrapConsumerSender(f func(consumer requestSender) requestSender) {
@@ -41,6 +41,23 @@ func TestBaseExporter(t *testing.T) {
 	require.NoError(t, be.Shutdown(context.Background()))
 }
 
+func TestBaseExporterShutdownTwice(t *testing.T) {
+	shutdowns := 0
+	be, err := newBaseExporter(
+		defaultSettings,
+		fromOptions(
+			WithShutdown(func(ctx context.Context) error { shutdowns++; return nil }),
+			WithQueue(NewDefaultQueueSettings())),
+		"",
+		nopRequestUnmarshaler(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, be.Shutdown(context.Background()))
+	require.NoError(t, be.Shutdown(context.Background()))
+	require.Equal(t, 1, shutdowns)
+}
+
 func TestBaseExporterWithOptions(t *testing.T) {
 	want := errors.New("my error")
 	be, err := newBaseExporter(
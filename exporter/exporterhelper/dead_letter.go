@@ -0,0 +1,327 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/otlpfile"
+)
+
+// deadLetterFileExtension is the extension of the dead letter files, which contain an OTLP file record
+// of an export request encoded in protobuf.
+const deadLetterFileExtension = ".binpb"
+
+// ErrDeadLetterExpired is returned by the exporters replaying a dead letter file older than the max age
+// of their dead letter settings, which is not sent.
+var ErrDeadLetterExpired = errors.New("the dead letter file exceeds the max age")
+
+type deadLetterReplayKey struct{}
+
+// NewDeadLetterReplayContext returns a context to replay, with an exporter, the batch of a dead letter file written
+// at the given time. The exporters created by exporterhelper send the batches consumed with the context before
+// returning, bypassing the batcher and the sending queue, so that the file can be removed once they return no error.
+// The batches failing are not kept in the dead letter queue again, and the batches of the files older than the
+// max age of the dead letter settings are not sent, ErrDeadLetterExpired is returned instead.
+func NewDeadLetterReplayContext(ctx context.Context, written time.Time) context.Context {
+	return context.WithValue(ctx, deadLetterReplayKey{}, written)
+}
+
+// deadLetterReplayTime returns the time at which the dead letter file replayed with ctx was written, if any.
+func deadLetterReplayTime(ctx context.Context) (time.Time, bool) {
+	written, ok := ctx.Value(deadLetterReplayKey{}).(time.Time)
+	return written, ok
+}
+
+// DeadLetterSettings defines configuration for keeping the batches which failed permanently,
+// instead of dropping them.
+type DeadLetterSettings struct {
+	// Enabled indicates whether to keep the batches which failed permanently.
+	Enabled bool `mapstructure:"enabled"`
+	// Directory if not empty, writes the batches to OTLP protobuf files in the given directory, which must exist.
+	Directory string `mapstructure:"directory"`
+	// Exporter if not nil, sends the batches to the given exporter, which must be part of a pipeline
+	// of the same signal.
+	Exporter *component.ID `mapstructure:"exporter"`
+	// MaxBytes is the maximum size of the files kept in Directory by the exporter for a signal,
+	// above which the oldest files are removed, 0 means no limit.
+	MaxBytes int64 `mapstructure:"max_bytes"`
+	// MaxAge is the maximum age of the files kept in Directory, 0 means no limit.
+	MaxAge time.Duration `mapstructure:"max_age"`
+}
+
+// NewDefaultDeadLetterSettings returns the default settings for DeadLetterSettings.
+func NewDefaultDeadLetterSettings() DeadLetterSettings {
+	return DeadLetterSettings{
+		Enabled: false,
+	}
+}
+
+// Validate checks if the DeadLetterSettings configuration is valid
+func (dlCfg *DeadLetterSettings) Validate() error {
+	if !dlCfg.Enabled {
+		return nil
+	}
+
+	if (dlCfg.Directory == "") == (dlCfg.Exporter == nil) {
+		return errors.New("exactly one of dead letter directory and exporter must be set")
+	}
+
+	if dlCfg.MaxBytes < 0 || dlCfg.MaxAge < 0 {
+		return errors.New("dead letter max bytes and max age must not be negative")
+	}
+
+	return nil
+}
+
+// DeadLetterFile is a file written by the dead letter queue of an exporter.
+type DeadLetterFile struct {
+	// Path is the path of the file.
+	Path string
+	// Exporter is the ID of the exporter which wrote the file, with the characters
+	// which are not safe in a file name replaced.
+	Exporter string
+	// Signal is the signal of the OTLP export request contained in the file.
+	Signal component.DataType
+	// Time is the time at which the file was written.
+	Time time.Time
+	// Size is the size of the file in bytes.
+	Size int64
+}
+
+// ListDeadLetterFiles returns the dead letter files of the given directory, from the oldest to the newest.
+func ListDeadLetterFiles(directory string) ([]DeadLetterFile, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+	var files []DeadLetterFile
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		file, ok := parseDeadLetterFileName(entry.Name())
+		if !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			// The file was removed since the directory was read.
+			continue
+		}
+		file.Path = filepath.Join(directory, entry.Name())
+		file.Size = info.Size()
+		files = append(files, file)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Time.Before(files[j].Time)
+	})
+	return files, nil
+}
+
+// deadLetterFileName returns the name of a dead letter file, formatted as
+// <exporter>_<signal>_<unix nanoseconds>_<sequence number>.binpb.
+func deadLetterFileName(exporter string, signal component.DataType, t time.Time, seq uint64) string {
+	return fmt.Sprintf("%s_%s_%d_%d%s", exporter, signal, t.UnixNano(), seq, deadLetterFileExtension)
+}
+
+func parseDeadLetterFileName(name string) (DeadLetterFile, bool) {
+	if !strings.HasSuffix(name, deadLetterFileExtension) {
+		return DeadLetterFile{}, false
+	}
+	base := strings.TrimSuffix(name, deadLetterFileExtension)
+	// The exporter may contain underscores, but the signal, time and sequence number do not.
+	parts := strings.Split(base, "_")
+	if len(parts) < 4 {
+		return DeadLetterFile{}, false
+	}
+	n := len(parts)
+	signal := component.DataType(parts[n-3])
+	switch signal {
+	case component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs, component.DataTypeProfiles:
+	default:
+		return DeadLetterFile{}, false
+	}
+	nanos, err := strconv.ParseInt(parts[n-2], 10, 64)
+	if err != nil {
+		return DeadLetterFile{}, false
+	}
+	if _, err = strconv.ParseUint(parts[n-1], 10, 64); err != nil {
+		return DeadLetterFile{}, false
+	}
+	return DeadLetterFile{
+		Exporter: strings.Join(parts[:n-3], "_"),
+		Signal:   signal,
+		Time:     time.Unix(0, nanos),
+	}, true
+}
+
+// deadLetterQueue keeps the requests which failed permanently, either in files or by sending them
+// to another exporter.
+type deadLetterQueue struct {
+	id       component.ID
+	signal   component.DataType
+	cfg      DeadLetterSettings
+	exporter string
+	logger   *zap.Logger
+
+	// mu serializes the writes and the removal of the files exceeding the retention limits.
+	mu  sync.Mutex
+	seq uint64
+
+	next component.Component
+}
+
+func newDeadLetterQueue(id component.ID, signal component.DataType, cfg DeadLetterSettings, logger *zap.Logger) *deadLetterQueue {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &deadLetterQueue{
+		id:       id,
+		signal:   signal,
+		cfg:      cfg,
+		exporter: sanitizeFileName(id.String()),
+		logger:   logger,
+	}
+}
+
+// start resolves the exporter which the requests are sent to, or removes the files exceeding the retention limits.
+func (dlq *deadLetterQueue) start(host component.Host) error {
+	if dlq == nil {
+		return nil
+	}
+	if dlq.cfg.Exporter == nil {
+		dlq.mu.Lock()
+		defer dlq.mu.Unlock()
+		return dlq.enforceRetention()
+	}
+
+	if *dlq.cfg.Exporter == dlq.id {
+		return errors.New("dead letter exporter cannot be the exporter itself")
+	}
+	next, found := host.GetExporters()[dlq.signal][*dlq.cfg.Exporter] //nolint:staticcheck
+	if !found {
+		return fmt.Errorf("dead letter exporter %q not found in a %s pipeline", dlq.cfg.Exporter, dlq.signal)
+	}
+	switch dlq.signal {
+	case component.DataTypeTraces:
+		_, found = next.(consumer.Traces)
+	case component.DataTypeMetrics:
+		_, found = next.(consumer.Metrics)
+	case component.DataTypeLogs:
+		_, found = next.(consumer.Logs)
+	case component.DataTypeProfiles:
+		_, found = next.(consumer.Profiles)
+	}
+	if !found {
+		return fmt.Errorf("dead letter exporter %q does not support %s", dlq.cfg.Exporter, dlq.signal)
+	}
+	dlq.next = next
+	return nil
+}
+
+// write keeps the request, which failed permanently.
+func (dlq *deadLetterQueue) write(req internal.Request) error {
+	if dlq.next != nil {
+		return dlq.forward(req)
+	}
+
+	data, err := req.Marshal()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var buf bytes.Buffer
+	if err = otlpfile.NewWriter(&buf).Write(otlpfile.Record{
+		Signal:   dlq.signal,
+		Time:     now,
+		Encoding: otlpfile.EncodingProto,
+		Data:     data,
+	}); err != nil {
+		return err
+	}
+
+	dlq.mu.Lock()
+	defer dlq.mu.Unlock()
+	dlq.seq++
+	name := deadLetterFileName(dlq.exporter, dlq.signal, now, dlq.seq)
+	path := filepath.Join(dlq.cfg.Directory, name)
+	// Write to a temporary file first, so that an incomplete file is never replayed.
+	tmpPath := path + ".tmp"
+	if err = os.WriteFile(tmpPath, buf.Bytes(), 0600); err != nil {
+		return err
+	}
+	if err = os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return dlq.enforceRetention()
+}
+
+// forward sends the request to the dead letter exporter.
+func (dlq *deadLetterQueue) forward(req internal.Request) error {
+	switch r := req.(type) {
+	case *tracesRequest:
+		return dlq.next.(consumer.Traces).ConsumeTraces(req.Context(), r.td)
+	case *metricsRequest:
+		return dlq.next.(consumer.Metrics).ConsumeMetrics(req.Context(), r.md)
+	case *logsRequest:
+		return dlq.next.(consumer.Logs).ConsumeLogs(req.Context(), r.ld)
+	case *profilesRequest:
+		return dlq.next.(consumer.Profiles).ConsumeProfiles(req.Context(), r.pd)
+	}
+	return fmt.Errorf("unsupported request type %T", req)
+}
+
+// enforceRetention removes the oldest files of the exporter for the signal, exceeding MaxAge or MaxBytes.
+func (dlq *deadLetterQueue) enforceRetention() error {
+	if dlq.cfg.MaxAge == 0 && dlq.cfg.MaxBytes == 0 {
+		return nil
+	}
+	files, err := ListDeadLetterFiles(dlq.cfg.Directory)
+	if err != nil {
+		return err
+	}
+
+	var owned []DeadLetterFile
+	var totalBytes int64
+	for _, file := range files {
+		if file.Exporter == dlq.exporter && file.Signal == dlq.signal {
+			owned = append(owned, file)
+			totalBytes += file.Size
+		}
+	}
+
+	var errs error
+	for _, file := range owned {
+		expired := dlq.cfg.MaxAge != 0 && time.Since(file.Time) > dlq.cfg.MaxAge
+		if !expired && (dlq.cfg.MaxBytes == 0 || totalBytes <= dlq.cfg.MaxBytes) {
+			break
+		}
+		if err = os.Remove(file.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		totalBytes -= file.Size
+		dlq.logger.Warn("Removed dead letter file exceeding the retention limits", zap.String("path", file.Path))
+	}
+	return errs
+}
@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/otlpfile"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var errPermanent = consumererror.NewPermanent(errors.New("rejected by the backend"))
+
+func TestDeadLetterSettings_Validate(t *testing.T) {
+	id := component.NewID("backup")
+	tests := []struct {
+		name    string
+		cfg     DeadLetterSettings
+		wantErr bool
+	}{
+		{name: "disabled", cfg: NewDefaultDeadLetterSettings()},
+		{name: "directory", cfg: DeadLetterSettings{Enabled: true, Directory: "dlq", MaxBytes: 1024, MaxAge: time.Hour}},
+		{name: "exporter", cfg: DeadLetterSettings{Enabled: true, Exporter: &id}},
+		{name: "none", cfg: DeadLetterSettings{Enabled: true}, wantErr: true},
+		{name: "both", cfg: DeadLetterSettings{Enabled: true, Directory: "dlq", Exporter: &id}, wantErr: true},
+		{name: "negative_max_bytes", cfg: DeadLetterSettings{Enabled: true, Directory: "dlq", MaxBytes: -1}, wantErr: true},
+		{name: "negative_max_age", cfg: DeadLetterSettings{Enabled: true, Directory: "dlq", MaxAge: -time.Second}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr {
+				assert.Error(t, tt.cfg.Validate())
+			} else {
+				assert.NoError(t, tt.cfg.Validate())
+			}
+		})
+	}
+}
+
+func TestParseDeadLetterFileName(t *testing.T) {
+	now := time.Unix(0, 1689000000123456789)
+	file, ok := parseDeadLetterFileName(deadLetterFileName("otlp_backend", component.DataTypeMetrics, now, 3))
+	require.True(t, ok)
+	assert.Equal(t, "otlp_backend", file.Exporter)
+	assert.Equal(t, component.DataTypeMetrics, file.Signal)
+	assert.True(t, now.Equal(file.Time))
+
+	for _, name := range []string{
+		"otlp_traces_1_1.binpb.tmp",
+		"otlp_traces_1_1.json",
+		"otlp_events_1_1.binpb",
+		"otlp_traces_time_1.binpb",
+		"traces_1_1.binpb",
+	} {
+		_, ok = parseDeadLetterFileName(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestDeadLetterDirectory(t *testing.T) {
+	dir := t.TempDir()
+	dlCfg := DeadLetterSettings{Enabled: true, Directory: dir}
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeTracesExporterConfig, newTraceDataPusher(errPermanent), WithDeadLetter(dlCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, te.Shutdown(context.Background()))
+	})
+
+	td := testdata.GenerateTraces(2)
+	assert.ErrorIs(t, te.ConsumeTraces(context.Background(), td), errPermanent)
+
+	files, err := ListDeadLetterFiles(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, sanitizeFileName(exportertest.NewNopCreateSettings().ID.String()), files[0].Exporter)
+	assert.Equal(t, component.DataTypeTraces, files[0].Signal)
+
+	f, err := os.Open(files[0].Path)
+	require.NoError(t, err)
+	defer f.Close()
+	rec, err := otlpfile.NewReader(f).Next()
+	require.NoError(t, err)
+	assert.Equal(t, component.DataTypeTraces, rec.Signal)
+	assert.Equal(t, otlpfile.EncodingProto, rec.Encoding)
+	assert.Equal(t, otlpfile.CompressionNone, rec.Compression)
+	assert.Equal(t, files[0].Time, rec.Time)
+	got, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(rec.Data)
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+}
+
+func TestDeadLetterSkipsPartialErrors(t *testing.T) {
+	dir := t.TempDir()
+	dlCfg := DeadLetterSettings{Enabled: true, Directory: dir}
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeTracesExporterConfig,
+		newTraceDataPusher(consumererror.NewPartial(errors.New("rejected span"), 1)), WithDeadLetter(dlCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, te.Shutdown(context.Background()))
+	})
+
+	assert.Error(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	files, err := ListDeadLetterFiles(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestDeadLetterRetention(t *testing.T) {
+	dir := t.TempDir()
+	id := exportertest.NewNopCreateSettings().ID
+	// Files of other exporters are never removed.
+	otherFile := filepath.Join(dir, deadLetterFileName("other", component.DataTypeTraces, time.Now().Add(-2*time.Hour), 1))
+	require.NoError(t, os.WriteFile(otherFile, []byte("data"), 0600))
+	expiredFile := filepath.Join(dir, deadLetterFileName(sanitizeFileName(id.String()), component.DataTypeTraces, time.Now().Add(-2*time.Hour), 1))
+	require.NoError(t, os.WriteFile(expiredFile, []byte("data"), 0600))
+
+	td := testdata.GenerateTraces(2)
+	// Every file contains a record of the request.
+	size := int64(otlpfile.Record{Data: make([]byte, (&ptrace.ProtoMarshaler{}).TracesSize(td))}.Size())
+	dlCfg := DeadLetterSettings{Enabled: true, Directory: dir, MaxAge: time.Hour, MaxBytes: 2 * size}
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeTracesExporterConfig, newTraceDataPusher(errPermanent), WithDeadLetter(dlCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, te.Shutdown(context.Background()))
+	})
+	// The expired file is removed on start.
+	assert.NoFileExists(t, expiredFile)
+
+	for i := 0; i < 3; i++ {
+		assert.Error(t, te.ConsumeTraces(context.Background(), td))
+	}
+	files, err := ListDeadLetterFiles(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	assert.Equal(t, otherFile, files[0].Path)
+	// Only the two newest files fit in the limit.
+	for _, file := range files[1:] {
+		assert.Equal(t, size, file.Size)
+	}
+}
+
+type deadLetterHost struct {
+	component.Host
+	exporters map[component.DataType]map[component.ID]component.Component
+}
+
+func (h *deadLetterHost) GetExporters() map[component.DataType]map[component.ID]component.Component {
+	return h.exporters
+}
+
+type tracesSinkExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	*consumertest.TracesSink
+}
+
+func TestDeadLetterExporter(t *testing.T) {
+	backupID := component.NewID("backup")
+	sink := new(consumertest.TracesSink)
+	host := &deadLetterHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[component.DataType]map[component.ID]component.Component{
+			component.DataTypeTraces: {backupID: &tracesSinkExporter{TracesSink: sink}},
+		},
+	}
+
+	dlCfg := DeadLetterSettings{Enabled: true, Exporter: &backupID}
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeTracesExporterConfig, newTraceDataPusher(errPermanent), WithDeadLetter(dlCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, te.Shutdown(context.Background()))
+	})
+
+	td := testdata.GenerateTraces(2)
+	assert.Error(t, te.ConsumeTraces(context.Background(), td))
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, td, sink.AllTraces()[0])
+}
+
+func TestDeadLetterExporterNotFound(t *testing.T) {
+	backupID := component.NewID("backup")
+	dlCfg := DeadLetterSettings{Enabled: true, Exporter: &backupID}
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeTracesExporterConfig, newTraceDataPusher(errPermanent), WithDeadLetter(dlCfg))
+	require.NoError(t, err)
+	assert.Error(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The exporter cannot be its own dead letter exporter.
+	ownID := exportertest.NewNopCreateSettings().ID
+	dlCfg.Exporter = &ownID
+	te, err = NewTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeTracesExporterConfig, newTraceDataPusher(errPermanent), WithDeadLetter(dlCfg))
+	require.NoError(t, err)
+	assert.Error(t, te.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestDeadLetterReplay(t *testing.T) {
+	dir := t.TempDir()
+	var pushErr error
+	sink := new(consumertest.TracesSink)
+	pusher := func(ctx context.Context, td ptrace.Traces) error {
+		if pushErr != nil {
+			return pushErr
+		}
+		return sink.ConsumeTraces(ctx, td)
+	}
+	qCfg := NewDefaultQueueSettings()
+	// The queue is never consumed, the replayed batches must bypass it.
+	qCfg.NumConsumers = 0
+	dlCfg := DeadLetterSettings{Enabled: true, Directory: dir, MaxAge: time.Hour}
+	// The exporter of the other queue tests reports the queue metrics.
+	te, err := NewTracesExporter(context.Background(), defaultSettings, &fakeTracesExporterConfig, pusher, WithQueue(qCfg), WithDeadLetter(dlCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, te.Shutdown(context.Background()))
+	})
+
+	td := testdata.GenerateTraces(2)
+	require.NoError(t, te.ConsumeTraces(NewDeadLetterReplayContext(context.Background(), time.Now()), td))
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, td, sink.AllTraces()[0])
+
+	// The batches of the expired files are not sent.
+	err = te.ConsumeTraces(NewDeadLetterReplayContext(context.Background(), time.Now().Add(-2*time.Hour)), td)
+	assert.ErrorIs(t, err, ErrDeadLetterExpired)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Len(t, sink.AllTraces(), 1)
+
+	// The batches failing are not kept again in the dead letter queue.
+	pushErr = errPermanent
+	assert.ErrorIs(t, te.ConsumeTraces(NewDeadLetterReplayContext(context.Background(), time.Now()), td), errPermanent)
+	files, err := ListDeadLetterFiles(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
@@ -0,0 +1,216 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// AdaptiveConsumersSettings configures the scaling of the number of consumers of a queue.
+type AdaptiveConsumersSettings struct {
+	// MinConsumers is the minimum number of consumers processing items concurrently.
+	MinConsumers int
+	// Interval is the interval between the adjustments of the number of consumers.
+	Interval time.Duration
+	// TargetLatency is the average processing time of the items above which the number of
+	// consumers is decreased, 0 means that the processing time is not considered.
+	TargetLatency time.Duration
+}
+
+// adaptiveConsumersQueue scales the number of consumers processing the items of a queue concurrently,
+// between MinConsumers and the number of consumers it is started with.
+//
+// Every interval, the number of consumers is halved if the average processing time exceeds the
+// target latency, since the destination is likely overloaded. Otherwise, it is doubled when items
+// are waiting to be processed, and decreased by one when the consumers were not all busy.
+type adaptiveConsumersQueue struct {
+	ProducerConsumerQueue
+	settings AdaptiveConsumersSettings
+	logger   *zap.Logger
+	limiter  *consumersLimiter
+
+	maxConsumers int
+	latencySum   atomic.Int64
+	latencyCount atomic.Int64
+	// saturated reports whether an item waited for a consumer since the last adjustment.
+	saturated atomic.Bool
+
+	stopCh   chan struct{}
+	stopWG   sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// NewAdaptiveConsumersQueue wraps queue so that the number of its consumers processing items
+// concurrently is adjusted to the load.
+func NewAdaptiveConsumersQueue(queue ProducerConsumerQueue, settings AdaptiveConsumersSettings, logger *zap.Logger) ProducerConsumerQueue {
+	return &adaptiveConsumersQueue{
+		ProducerConsumerQueue: queue,
+		settings:              settings,
+		logger:                logger,
+		limiter:               newConsumersLimiter(settings.MinConsumers),
+		stopCh:                make(chan struct{}),
+	}
+}
+
+// StartConsumers starts numWorkers goroutines consuming items from the queue, of which
+// between MinConsumers and numWorkers pass the items into the consumer callback concurrently.
+func (q *adaptiveConsumersQueue) StartConsumers(numWorkers int, callback func(item Request)) {
+	q.maxConsumers = numWorkers
+	if q.settings.MinConsumers > numWorkers {
+		q.limiter.setLimit(numWorkers)
+	}
+	q.ProducerConsumerQueue.StartConsumers(numWorkers, func(item Request) {
+		if !q.limiter.tryAcquire() {
+			q.saturated.Store(true)
+			q.limiter.acquire()
+		}
+		defer q.limiter.release()
+		start := time.Now()
+		callback(item)
+		q.latencySum.Add(int64(time.Since(start)))
+		q.latencyCount.Add(1)
+	})
+
+	q.stopWG.Add(1)
+	go q.adjustLoop()
+}
+
+func (q *adaptiveConsumersQueue) adjustLoop() {
+	defer q.stopWG.Done()
+	ticker := time.NewTicker(q.settings.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			q.adjust()
+		case <-q.stopCh:
+			return
+		}
+	}
+}
+
+// adjust updates the number of consumers from the processing time and the items queued
+// since the last adjustment.
+func (q *adaptiveConsumersQueue) adjust() {
+	count := q.latencyCount.Swap(0)
+	sum := q.latencySum.Swap(0)
+	saturated := q.saturated.Swap(false)
+
+	current := q.limiter.getLimit()
+	next := current
+	switch {
+	case q.settings.TargetLatency > 0 && count > 0 && time.Duration(sum/count) > q.settings.TargetLatency:
+		next = current / 2
+	case saturated || q.Size() > 0:
+		next = current * 2
+	case q.limiter.getActive() < current:
+		next = current - 1
+	}
+	if next < q.settings.MinConsumers {
+		next = q.settings.MinConsumers
+	}
+	if next > q.maxConsumers {
+		next = q.maxConsumers
+	}
+	if next < 1 {
+		next = 1
+	}
+	if next != current {
+		q.limiter.setLimit(next)
+		q.logger.Debug("Adjusted the number of queue consumers", zap.Int("consumers", next), zap.Int("previous", current))
+	}
+}
+
+// Consumers returns the number of consumers allowed to process items concurrently.
+func (q *adaptiveConsumersQueue) Consumers() int {
+	return q.limiter.getLimit()
+}
+
+// Stop stops adjusting the number of consumers, lets all the consumers drain the queue and stops it.
+func (q *adaptiveConsumersQueue) Stop() {
+	q.stopOnce.Do(func() {
+		close(q.stopCh)
+		q.stopWG.Wait()
+		q.limiter.stop()
+	})
+	q.ProducerConsumerQueue.Stop()
+}
+
+// consumersLimiter limits the number of consumers processing items concurrently, the limit can be
+// changed while the consumers are running.
+type consumersLimiter struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	limit   int
+	active  int
+	stopped bool
+}
+
+func newConsumersLimiter(limit int) *consumersLimiter {
+	if limit < 1 {
+		limit = 1
+	}
+	l := &consumersLimiter{limit: limit}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+// tryAcquire acquires a permit if one is available without waiting.
+func (l *consumersLimiter) tryAcquire() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.stopped && l.active >= l.limit {
+		return false
+	}
+	l.active++
+	return true
+}
+
+// acquire waits for a permit, or for the limiter to be stopped.
+func (l *consumersLimiter) acquire() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for !l.stopped && l.active >= l.limit {
+		l.cond.Wait()
+	}
+	l.active++
+}
+
+func (l *consumersLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.active--
+	l.cond.Signal()
+}
+
+func (l *consumersLimiter) setLimit(limit int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit = limit
+	l.cond.Broadcast()
+}
+
+func (l *consumersLimiter) getLimit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
+
+func (l *consumersLimiter) getActive() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.active
+}
+
+// stop lets all the consumers process items, so that the queue can be drained.
+func (l *consumersLimiter) stop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stopped = true
+	l.cond.Broadcast()
+}
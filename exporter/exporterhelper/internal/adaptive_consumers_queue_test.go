@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newTestAdaptiveConsumersQueue(capacity int, settings AdaptiveConsumersSettings) *adaptiveConsumersQueue {
+	// the adjustments are triggered by the tests
+	settings.Interval = time.Hour
+	return NewAdaptiveConsumersQueue(NewBoundedMemoryQueue(capacity), settings, zap.NewNop()).(*adaptiveConsumersQueue)
+}
+
+func TestAdaptiveConsumersQueueScaling(t *testing.T) {
+	q := newTestAdaptiveConsumersQueue(100, AdaptiveConsumersSettings{MinConsumers: 1})
+
+	var running, maxRunning atomic.Int32
+	unblock := make(chan struct{})
+	q.StartConsumers(8, func(Request) {
+		n := running.Add(1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		<-unblock
+		running.Add(-1)
+	})
+	assert.Equal(t, 1, q.Consumers())
+
+	for i := 0; i < 20; i++ {
+		assert.True(t, q.Produce(newStringRequest("a")))
+	}
+	assert.Eventually(t, func() bool { return running.Load() == 1 }, time.Second, time.Millisecond)
+
+	// the number of consumers doubles while items are waiting, up to the number of workers
+	for _, want := range []int{2, 4, 8, 8} {
+		q.adjust()
+		assert.Equal(t, want, q.Consumers())
+	}
+	assert.Eventually(t, func() bool { return running.Load() == 8 }, time.Second, time.Millisecond)
+
+	close(unblock)
+	assert.Eventually(t, func() bool { return q.Size() == 0 && running.Load() == 0 }, time.Second, time.Millisecond)
+	assert.EqualValues(t, 8, maxRunning.Load())
+
+	// the number of consumers decreases by one while they are not all busy, down to the minimum
+	q.adjust()
+	q.adjust()
+	assert.Equal(t, 6, q.Consumers())
+	for i := 0; i < 10; i++ {
+		q.adjust()
+	}
+	assert.Equal(t, 1, q.Consumers())
+	q.Stop()
+}
+
+func TestAdaptiveConsumersQueueLatency(t *testing.T) {
+	q := newTestAdaptiveConsumersQueue(100, AdaptiveConsumersSettings{MinConsumers: 2, TargetLatency: time.Millisecond})
+	q.StartConsumers(8, func(Request) {
+		time.Sleep(5 * time.Millisecond)
+	})
+	q.limiter.setLimit(8)
+
+	// the number of consumers is halved when the processing time exceeds the target latency,
+	// even though items are waiting
+	for i := 0; i < 20; i++ {
+		assert.True(t, q.Produce(newStringRequest("a")))
+	}
+	assert.Eventually(t, func() bool { return q.latencyCount.Load() > 0 }, time.Second, time.Millisecond)
+	q.adjust()
+	assert.Equal(t, 4, q.Consumers())
+
+	for i := 0; i < 5; i++ {
+		assert.True(t, q.Produce(newStringRequest("a")))
+		assert.Eventually(t, func() bool { return q.latencyCount.Load() > 0 }, time.Second, time.Millisecond)
+		q.adjust()
+	}
+	assert.Equal(t, 2, q.Consumers())
+	q.Stop()
+}
+
+func TestAdaptiveConsumersQueueStopDrains(t *testing.T) {
+	q := newTestAdaptiveConsumersQueue(100, AdaptiveConsumersSettings{MinConsumers: 1})
+
+	var consumed sync.WaitGroup
+	consumed.Add(10)
+	unblock := make(chan struct{})
+	q.StartConsumers(4, func(Request) {
+		<-unblock
+		consumed.Done()
+	})
+	for i := 0; i < 10; i++ {
+		assert.True(t, q.Produce(newStringRequest("a")))
+	}
+	close(unblock)
+
+	// the items waiting for a consumer are all processed on stop
+	q.Stop()
+	consumed.Wait()
+}
+
+func TestConsumersLimiter(t *testing.T) {
+	l := newConsumersLimiter(0)
+	assert.Equal(t, 1, l.getLimit())
+	assert.True(t, l.tryAcquire())
+	assert.False(t, l.tryAcquire())
+
+	acquired := make(chan struct{})
+	go func() {
+		l.acquire()
+		close(acquired)
+	}()
+	l.setLimit(2)
+	<-acquired
+	assert.Equal(t, 2, l.getActive())
+
+	l.release()
+	l.release()
+	l.setLimit(1)
+	assert.True(t, l.tryAcquire())
+	l.stop()
+	// the limit is ignored once stopped
+	assert.True(t, l.tryAcquire())
+	l.acquire()
+	assert.Equal(t, 3, l.getActive())
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// bytesLimitedQueue limits the estimated size in bytes of the requests held by a queue,
+// in addition to its capacity in number of requests.
+//
+// The requests restored by a persistent queue on restart were not produced through this queue,
+// they are accounted when the queue is wrapped.
+type bytesLimitedQueue struct {
+	ProducerConsumerQueue
+	logger   *zap.Logger
+	maxBytes int64
+	bytes    atomic.Int64
+}
+
+// NewBytesLimitedQueue wraps queue so that the requests are rejected once the estimated size
+// of the queued requests would exceed maxBytes.
+func NewBytesLimitedQueue(queue ProducerConsumerQueue, maxBytes int64, logger *zap.Logger) ProducerConsumerQueue {
+	q := &bytesLimitedQueue{
+		ProducerConsumerQueue: queue,
+		logger:                logger,
+		maxBytes:              maxBytes,
+	}
+	if restored, ok := queue.(interface{ RestoredBytes() int64 }); ok {
+		q.bytes.Store(restored.RestoredBytes())
+	}
+	return q
+}
+
+// StartConsumers starts a given number of goroutines consuming items from the queue
+// and passing them into the consumer callback.
+func (q *bytesLimitedQueue) StartConsumers(numWorkers int, callback func(item Request)) {
+	q.ProducerConsumerQueue.StartConsumers(numWorkers, func(item Request) {
+		q.release(int64(item.BytesSize()))
+		callback(item)
+	})
+}
+
+// Produce is used by the producer to submit new item to the queue. Returns false if the item wasn't added
+// to the queue because the queue is full or the bytes limit is reached.
+func (q *bytesLimitedQueue) Produce(item Request) bool {
+	size := int64(item.BytesSize())
+	for {
+		current := q.bytes.Load()
+		if current+size > q.maxBytes {
+			return false
+		}
+		if q.bytes.CompareAndSwap(current, current+size) {
+			break
+		}
+	}
+	if !q.ProducerConsumerQueue.Produce(item) {
+		q.release(size)
+		return false
+	}
+	return true
+}
+
+// Bytes returns the estimated size in bytes of the queued requests.
+func (q *bytesLimitedQueue) Bytes() int64 {
+	return q.bytes.Load()
+}
+
+// release subtracts the size of a request produced through the queue from the queued bytes. The queued bytes
+// are clamped at zero if the size was not accounted, e.g. when the estimated size of a restored request changed.
+func (q *bytesLimitedQueue) release(size int64) {
+	for {
+		current := q.bytes.Load()
+		released := current - size
+		if released < 0 {
+			released = 0
+		}
+		if !q.bytes.CompareAndSwap(current, released) {
+			continue
+		}
+		if current < size {
+			q.logger.Warn("Released more bytes than queued, the queued bytes are reset to zero",
+				zap.Int64("queued_bytes", current), zap.Int64("released_bytes", size))
+		}
+		return
+	}
+}
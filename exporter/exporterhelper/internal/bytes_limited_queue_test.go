@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type sizedRequest struct {
+	Request
+	size int
+}
+
+func (r sizedRequest) BytesSize() int {
+	return r.size
+}
+
+func TestBytesLimitedQueue(t *testing.T) {
+	q := NewBytesLimitedQueue(NewBoundedMemoryQueue(10), 100, zap.NewNop()).(*bytesLimitedQueue)
+
+	assert.True(t, q.Produce(sizedRequest{size: 60}))
+	assert.True(t, q.Produce(sizedRequest{size: 40}))
+	assert.EqualValues(t, 100, q.Bytes())
+	// the limit is reached even though the queue has room for more requests
+	assert.False(t, q.Produce(sizedRequest{size: 1}))
+	assert.EqualValues(t, 100, q.Bytes())
+	assert.Equal(t, 2, q.Size())
+
+	var consumed sync.WaitGroup
+	consumed.Add(2)
+	q.StartConsumers(1, func(Request) {
+		consumed.Done()
+	})
+	consumed.Wait()
+	assert.EqualValues(t, 0, q.Bytes())
+
+	// a request larger than the limit is never accepted
+	assert.False(t, q.Produce(sizedRequest{size: 101}))
+	q.Stop()
+}
+
+func TestBytesLimitedQueueRejected(t *testing.T) {
+	q := NewBytesLimitedQueue(NewBoundedMemoryQueue(1), 100, zap.NewNop()).(*bytesLimitedQueue)
+
+	assert.True(t, q.Produce(sizedRequest{size: 10}))
+	// the bytes are released when the wrapped queue is full
+	assert.False(t, q.Produce(sizedRequest{size: 10}))
+	assert.EqualValues(t, 10, q.Bytes())
+	q.Stop()
+}
+
+func TestBytesLimitedQueueReleasedTwice(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	q := NewBytesLimitedQueue(NewBoundedMemoryQueue(10), 100, zap.New(core)).(*bytesLimitedQueue)
+
+	assert.True(t, q.Produce(sizedRequest{size: 10}))
+	q.release(10)
+	assert.Equal(t, 0, logs.Len())
+	// the queued bytes are clamped at zero instead of becoming negative
+	q.release(10)
+	assert.EqualValues(t, 0, q.Bytes())
+	assert.Equal(t, 1, logs.Len())
+	assert.True(t, q.Produce(sizedRequest{size: 100}))
+	q.Stop()
+}
+
+func TestBytesLimitedQueueRestored(t *testing.T) {
+	client := createTestClient(createStorageExtension(""))
+	req := newFakeTracesRequest(newTraces(1, 10))
+	pq := NewPersistentQueue(context.Background(), "foo", component.DataTypeTraces, 10, zap.NewNop(), client, newFakeTracesRequestUnmarshalerFunc())
+	require.True(t, pq.Produce(req))
+	pq.Stop()
+
+	// the requests restored by the persistent queue are accounted
+	pq = NewPersistentQueue(context.Background(), "foo", component.DataTypeTraces, 10, zap.NewNop(), client, newFakeTracesRequestUnmarshalerFunc())
+	q := NewBytesLimitedQueue(pq, 1000, zap.NewNop()).(*bytesLimitedQueue)
+	assert.EqualValues(t, req.BytesSize(), q.Bytes())
+
+	var consumed sync.WaitGroup
+	consumed.Add(1)
+	q.StartConsumers(1, func(Request) {
+		consumed.Done()
+	})
+	consumed.Wait()
+	assert.EqualValues(t, 0, q.Bytes())
+	q.Stop()
+}
@@ -0,0 +1,274 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+const (
+	mib = 1 << 20
+
+	fileOpenTimeout = time.Second
+	// compactionMaxTransactionSize is the maximum number of items copied in a single transaction of the compaction.
+	compactionMaxTransactionSize = 65536
+	// compactionNeededSize is the size of the file above which it is compacted once the queue shrank.
+	compactionNeededSize = 100 * mib
+	// compactionTriggerSize is the size of the data of the file below which it is compacted, if needed.
+	compactionTriggerSize = 10 * mib
+	// compactionCheckInterval is how often the sizes of the file are checked for a compaction.
+	compactionCheckInterval = 5 * time.Second
+)
+
+var (
+	fileBucket = []byte("default")
+
+	errFileClientClosed   = errors.New("file client is closed")
+	errUnknownOperation   = errors.New("unknown storage operation")
+	errBucketNotAvailable = errors.New("storage bucket is not available")
+)
+
+// fileClient is a storage.Client keeping the persistent queue of an exporter in a bbolt file, in the same
+// format as the file storage extension. The file is compacted when opened, and once its data shrank.
+type fileClient struct {
+	logger   *zap.Logger
+	filename string
+	fsync    bool
+
+	// mu is held for writing while the file is compacted, since the database is replaced.
+	mu     sync.RWMutex
+	db     *bbolt.DB
+	closed bool
+
+	// compactionNeeded is set once the file is big enough to be compacted when its data shrinks.
+	compactionNeeded bool
+	stopCompaction   context.CancelFunc
+	compactionDone   chan struct{}
+}
+
+var _ storage.Client = (*fileClient)(nil)
+
+// NewFileClient opens the file, compacts it, and returns a client keeping its data in it. The writes are
+// synced to disk if fsync is set.
+func NewFileClient(filename string, fsync bool, logger *zap.Logger) (storage.Client, error) {
+	logger = logger.With(zap.String("filename", filename))
+	db, err := openFileDB(filename, fsync)
+	if err != nil {
+		return nil, err
+	}
+	c := &fileClient{logger: logger, filename: filename, fsync: fsync, db: db}
+	if err = c.compact(); err != nil {
+		logger.Error("Failed to compact the persistent queue file", zap.Error(err))
+	}
+	var ctx context.Context
+	ctx, c.stopCompaction = context.WithCancel(context.Background())
+	c.compactionDone = make(chan struct{})
+	go c.compactOnRebound(ctx)
+	return c, nil
+}
+
+func openFileDB(filename string, fsync bool) (*bbolt.DB, error) {
+	db, err := bbolt.Open(filename, 0600, &bbolt.Options{
+		Timeout:        fileOpenTimeout,
+		NoSync:         !fsync,
+		NoFreelistSync: true,
+		FreelistType:   bbolt.FreelistMapType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the persistent queue file %q: %w", filename, err)
+	}
+	if err = db.Update(func(tx *bbolt.Tx) error {
+		_, bucketErr := tx.CreateBucketIfNotExists(fileBucket)
+		return bucketErr
+	}); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Get returns the value of the key, nil if the key is not found.
+func (c *fileClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	if err := c.Batch(ctx, op); err != nil {
+		return nil, err
+	}
+	return op.Value, nil
+}
+
+// Set stores the value of the key.
+func (c *fileClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+// Delete deletes the key.
+func (c *fileClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+// Batch applies the operations in a single transaction.
+func (c *fileClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return errFileClientClosed
+	}
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(fileBucket)
+		if bucket == nil {
+			return errBucketNotAvailable
+		}
+		for _, op := range ops {
+			var err error
+			switch op.Type {
+			case storage.Get:
+				// The value is only valid during the transaction.
+				if value := bucket.Get([]byte(op.Key)); value != nil {
+					op.Value = append([]byte(nil), value...)
+				} else {
+					op.Value = nil
+				}
+			case storage.Set:
+				err = bucket.Put([]byte(op.Key), op.Value)
+			case storage.Delete:
+				err = bucket.Delete([]byte(op.Key))
+			default:
+				err = errUnknownOperation
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Close stops the compaction and closes the file.
+func (c *fileClient) Close(context.Context) error {
+	c.stopCompaction()
+	<-c.compactionDone
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	return c.db.Close()
+}
+
+// compact copies the data to a new file next to the file, which then replaces it, releasing the space
+// of the deleted data.
+func (c *fileClient) compact() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return errFileClientClosed
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.filename), "compaction-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	compacted, err := bbolt.Open(tmpName, 0600, &bbolt.Options{Timeout: fileOpenTimeout, NoSync: true})
+	if err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+	if err = bbolt.Compact(compacted, c.db, compactionMaxTransactionSize); err != nil {
+		_ = compacted.Close()
+		_ = os.Remove(tmpName)
+		return err
+	}
+	if err = compacted.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+
+	if err = c.db.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+	renameErr := os.Rename(tmpName, c.filename)
+	if renameErr != nil {
+		_ = os.Remove(tmpName)
+	}
+	// The file is opened again even if it could not be replaced, so that the client keeps working.
+	if c.db, err = openFileDB(c.filename, c.fsync); err != nil {
+		c.closed = true
+		return multierr.Append(renameErr, err)
+	}
+	return renameErr
+}
+
+// compactOnRebound compacts the file once its size rose above compactionNeededSize, and the size
+// of its data then fell below compactionTriggerSize.
+func (c *fileClient) compactOnRebound(ctx context.Context) {
+	defer close(c.compactionDone)
+	ticker := time.NewTicker(compactionCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if !c.shouldCompact() {
+			continue
+		}
+		c.logger.Info("Compacting the persistent queue file")
+		if err := c.compact(); err != nil {
+			c.logger.Error("Failed to compact the persistent queue file", zap.Error(err))
+		}
+	}
+}
+
+// shouldCompact returns whether the file must be compacted.
+func (c *fileClient) shouldCompact() bool {
+	totalSize, dataSize, err := c.sizes()
+	if err != nil {
+		c.logger.Warn("Failed to get the size of the persistent queue file", zap.Error(err))
+		return false
+	}
+	if totalSize >= compactionNeededSize {
+		c.compactionNeeded = true
+	}
+	if c.compactionNeeded && dataSize < compactionTriggerSize {
+		c.compactionNeeded = false
+		return true
+	}
+	return false
+}
+
+// sizes returns the size of the file, and the size of the pages which are in use.
+func (c *fileClient) sizes() (totalSize, dataSize int64, err error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return 0, 0, errFileClientClosed
+	}
+	err = c.db.View(func(tx *bbolt.Tx) error {
+		// The statistics are read in the transaction, which prevents the file from being remapped.
+		stats := tx.DB().Stats()
+		totalSize = tx.Size()
+		dataSize = totalSize - int64(stats.FreePageN+stats.PendingPageN)*int64(tx.DB().Info().PageSize)
+		return nil
+	})
+	return totalSize, dataSize, err
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func TestFileClient(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "queue")
+	client, err := NewFileClient(filename, true, zap.NewNop())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, client.Set(ctx, "kept", []byte("value")))
+	require.NoError(t, client.Batch(ctx, storage.SetOperation("deleted", []byte("value")), storage.DeleteOperation("deleted")))
+	value, err := client.Get(ctx, "deleted")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	require.NoError(t, client.Close(ctx))
+	assert.ErrorIs(t, client.Set(ctx, "closed", nil), errFileClientClosed)
+
+	// The data is kept across restarts, the file being compacted when opened.
+	client, err = NewFileClient(filename, false, zap.NewNop())
+	require.NoError(t, err)
+	value, err = client.Get(ctx, "kept")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	require.NoError(t, client.Close(ctx))
+
+	_, err = NewFileClient(filepath.Join(t.TempDir(), "missing", "queue"), false, zap.NewNop())
+	assert.ErrorContains(t, err, "failed to open the persistent queue file")
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+// partitionedQueue limits the number of requests held by a queue for each partition, formed by
+// the distinct combinations of values of client.Metadata keys of the context of the requests,
+// so that a partition cannot fill the queue on its own. It cannot wrap a persistent queue, whose
+// requests restored on restart have no metadata.
+type partitionedQueue struct {
+	ProducerConsumerQueue
+	metadataKeys []string
+	maxSize      int
+
+	mu    sync.Mutex
+	sizes map[string]int
+}
+
+// NewPartitionedQueue wraps queue so that the requests are rejected once the number of queued
+// requests of their partition reaches maxSize.
+func NewPartitionedQueue(queue ProducerConsumerQueue, metadataKeys []string, maxSize int) ProducerConsumerQueue {
+	mks := make([]string, len(metadataKeys))
+	for i, k := range metadataKeys {
+		mks[i] = strings.ToLower(k)
+	}
+	return &partitionedQueue{
+		ProducerConsumerQueue: queue,
+		metadataKeys:          mks,
+		maxSize:               maxSize,
+		sizes:                 map[string]int{},
+	}
+}
+
+// StartConsumers starts a given number of goroutines consuming items from the queue
+// and passing them into the consumer callback.
+func (q *partitionedQueue) StartConsumers(numWorkers int, callback func(item Request)) {
+	q.ProducerConsumerQueue.StartConsumers(numWorkers, func(item Request) {
+		q.release(q.partition(item))
+		callback(item)
+	})
+}
+
+// Produce is used by the producer to submit new item to the queue. Returns false if the item wasn't added
+// to the queue because the queue is full or the partition of the item is full.
+func (q *partitionedQueue) Produce(item Request) bool {
+	key := q.partition(item)
+	q.mu.Lock()
+	if q.sizes[key] >= q.maxSize {
+		q.mu.Unlock()
+		return false
+	}
+	q.sizes[key]++
+	q.mu.Unlock()
+
+	if !q.ProducerConsumerQueue.Produce(item) {
+		q.release(key)
+		return false
+	}
+	return true
+}
+
+// PartitionSize returns the number of queued requests of the partition of the given request.
+func (q *partitionedQueue) PartitionSize(item Request) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.sizes[q.partition(item)]
+}
+
+// partition returns the key identifying the partition of the item.
+func (q *partitionedQueue) partition(item Request) string {
+	info := client.FromContext(item.Context())
+	var sb strings.Builder
+	for _, k := range q.metadataKeys {
+		for _, v := range info.Metadata.Get(k) {
+			sb.WriteString(v)
+			// The values are separated by a character which is not allowed in header values.
+			sb.WriteByte(0)
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// release decrements the number of queued requests of the partition. Every request is released once,
+// after being produced, releasing more requests than produced is a bug.
+func (q *partitionedQueue) release(key string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	switch size := q.sizes[key]; {
+	case size > 1:
+		q.sizes[key] = size - 1
+	case size == 1:
+		delete(q.sizes, key)
+	default:
+		panic("partitioned queue released a request which was not produced")
+	}
+}
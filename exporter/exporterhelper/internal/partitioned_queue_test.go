@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+type tenantRequest struct {
+	Request
+	ctx context.Context
+}
+
+func (r tenantRequest) Context() context.Context {
+	return r.ctx
+}
+
+func newTenantRequest(tenant string) Request {
+	md := map[string][]string{}
+	if tenant != "" {
+		md["tenant"] = []string{tenant}
+	}
+	return tenantRequest{ctx: client.NewContext(context.Background(), client.Info{Metadata: client.NewMetadata(md)})}
+}
+
+func TestPartitionedQueue(t *testing.T) {
+	q := NewPartitionedQueue(NewBoundedMemoryQueue(10), []string{"Tenant"}, 2).(*partitionedQueue)
+
+	assert.True(t, q.Produce(newTenantRequest("a")))
+	assert.True(t, q.Produce(newTenantRequest("a")))
+	// the partition is full even though the queue has room for more requests
+	assert.False(t, q.Produce(newTenantRequest("a")))
+	assert.True(t, q.Produce(newTenantRequest("b")))
+	// the requests without metadata form their own partition
+	assert.True(t, q.Produce(newTenantRequest("")))
+	assert.Equal(t, 2, q.PartitionSize(newTenantRequest("a")))
+	assert.Equal(t, 1, q.PartitionSize(newTenantRequest("b")))
+	assert.Equal(t, 4, q.Size())
+
+	var consumed sync.WaitGroup
+	consumed.Add(4)
+	q.StartConsumers(1, func(Request) {
+		consumed.Done()
+	})
+	consumed.Wait()
+	assert.Equal(t, 0, q.PartitionSize(newTenantRequest("a")))
+	assert.Empty(t, q.sizes)
+	q.Stop()
+}
+
+func TestPartitionedQueueRejected(t *testing.T) {
+	q := NewPartitionedQueue(NewBoundedMemoryQueue(1), []string{"tenant"}, 2).(*partitionedQueue)
+
+	assert.True(t, q.Produce(newTenantRequest("a")))
+	// the partition size is released when the wrapped queue is full
+	assert.False(t, q.Produce(newTenantRequest("b")))
+	assert.Equal(t, 0, q.PartitionSize(newTenantRequest("b")))
+	q.Stop()
+}
+
+func TestPartitionedQueueReleasedTwice(t *testing.T) {
+	q := NewPartitionedQueue(NewBoundedMemoryQueue(10), []string{"tenant"}, 2).(*partitionedQueue)
+
+	assert.True(t, q.Produce(newTenantRequest("a")))
+	key := q.partition(newTenantRequest("a"))
+	q.release(key)
+	assert.Panics(t, func() { q.release(key) })
+	q.Stop()
+}
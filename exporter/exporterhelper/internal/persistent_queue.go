@@ -81,3 +81,8 @@ func (pq *persistentQueue) Stop() {
 func (pq *persistentQueue) Size() int {
 	return int(pq.storage.size())
 }
+
+// RestoredBytes returns the estimated size in bytes of the requests restored from the storage on start.
+func (pq *persistentQueue) RestoredBytes() int64 {
+	return pq.storage.restoredBytes
+}
@@ -57,6 +57,8 @@ type persistentContiguousStorage struct {
 	currentlyDispatchedItems []itemIndex
 
 	itemsCount *atomic.Uint64
+	// restoredBytes is the estimated size in bytes of the requests found in the storage on start.
+	restoredBytes int64
 }
 
 type itemIndex uint64
@@ -105,6 +107,8 @@ func newPersistentContiguousStorage(ctx context.Context, queueName string, capac
 		pcs.putChan <- struct{}{}
 	}
 
+	pcs.restoredBytes = pcs.queuedBytes(ctx)
+
 	// start the loop which moves items from storage to the outbound channel
 	go pcs.loop()
 
@@ -134,6 +138,10 @@ func initPersistentContiguousStorage(ctx context.Context, pcs *persistentContigu
 		}
 		pcs.readIndex = 0
 		pcs.writeIndex = 0
+		// Both indexes are stored, so that the items put before any is read are found after a restart.
+		if _, err = newBatch(pcs).setItemIndex(readIndexKey, pcs.readIndex).setItemIndex(writeIndexKey, pcs.writeIndex).execute(ctx); err != nil {
+			pcs.logger.Error("Failed storing read/write index", zap.String(zapQueueNameKey, pcs.queueName), zap.Error(err))
+		}
 	} else {
 		pcs.readIndex = readIndex
 		pcs.writeIndex = writeIndex
@@ -142,6 +150,25 @@ func initPersistentContiguousStorage(ctx context.Context, pcs *persistentContigu
 	pcs.itemsCount.Store(uint64(pcs.writeIndex - pcs.readIndex))
 }
 
+// queuedBytes returns the estimated size in bytes of the queued requests, ignoring the ones which
+// cannot be retrieved, as they are skipped when they are read.
+func (pcs *persistentContiguousStorage) queuedBytes(ctx context.Context) int64 {
+	pcs.mu.Lock()
+	defer pcs.mu.Unlock()
+
+	var bytes int64
+	for index := pcs.readIndex; index != pcs.writeIndex; index++ {
+		batch, err := newBatch(pcs).get(pcs.itemKey(index)).execute(ctx)
+		if err != nil {
+			continue
+		}
+		if req, err := batch.getRequestResult(pcs.itemKey(index)); err == nil {
+			bytes += int64(req.BytesSize())
+		}
+	}
+	return bytes
+}
+
 func (pcs *persistentContiguousStorage) enqueueNotDispatchedReqs(reqs []Request) {
 	if len(reqs) > 0 {
 		errCount := 0
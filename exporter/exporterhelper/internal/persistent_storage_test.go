@@ -61,6 +61,11 @@ func (fd *fakeTracesRequest) Marshal() ([]byte, error) {
 	return marshaler.MarshalTraces(fd.td)
 }
 
+func (fd *fakeTracesRequest) BytesSize() int {
+	marshaler := &ptrace.ProtoMarshaler{}
+	return marshaler.TracesSize(fd.td)
+}
+
 func (fd *fakeTracesRequest) OnProcessingFinished() {
 	if fd.processingFinishedCallback != nil {
 		fd.processingFinishedCallback()
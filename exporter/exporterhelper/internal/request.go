@@ -22,6 +22,9 @@ type Request interface {
 	// Count returns the count of spans/metric points or log records.
 	Count() int
 
+	// BytesSize returns the estimated size of the request in bytes, which is the size of its protobuf encoding.
+	BytesSize() int
+
 	// Marshal serializes the current request into a byte stream
 	Marshal() ([]byte, error)
 
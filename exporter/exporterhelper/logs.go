@@ -62,6 +62,46 @@ func (req *logsRequest) Count() int {
 	return req.ld.LogRecordCount()
 }
 
+func (req *logsRequest) BytesSize() int {
+	return logsMarshaler.LogsSize(req.ld)
+}
+
+// logsBatch accumulates the data of logs requests.
+type logsBatch struct {
+	ld     plog.Logs
+	count  int
+	pusher consumer.ConsumeLogsFunc
+}
+
+func newLogsBatch(pusher consumer.ConsumeLogsFunc) batch {
+	return &logsBatch{
+		ld:     plog.NewLogs(),
+		pusher: pusher,
+	}
+}
+
+func (b *logsBatch) add(req internal.Request) {
+	r := req.(*logsRequest)
+	b.count += r.Count()
+	r.ld.ResourceLogs().MoveAndAppendTo(b.ld.ResourceLogs())
+}
+
+func (b *logsBatch) itemCount() int {
+	return b.count
+}
+
+func (b *logsBatch) requests(ctx context.Context, maxSize int) []internal.Request {
+	var reqs []internal.Request
+	for maxSize > 0 && b.count > maxSize {
+		reqs = append(reqs, newLogsRequest(ctx, splitLogs(maxSize, b.ld), b.pusher))
+		b.count -= maxSize
+	}
+	reqs = append(reqs, newLogsRequest(ctx, b.ld, b.pusher))
+	b.ld = plog.NewLogs()
+	b.count = 0
+	return reqs
+}
+
 type logsExporter struct {
 	*baseExporter
 	consumer.Logs
@@ -98,10 +138,11 @@ func NewLogsExporter(
 			nextSender: nextSender,
 		}
 	})
+	be.initBatchSender(set, bs, func() batch { return newLogsBatch(pusher) }, be.obsrep.recordLogsEnqueueFailure)
 
 	lc, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
 		req := newLogsRequest(ctx, ld, pusher)
-		serr := be.sender.send(req)
+		serr := be.send(req)
 		if errors.Is(serr, errSendingQueueIsFull) {
 			be.obsrep.recordLogsEnqueueFailure(req.Context(), int64(req.Count()))
 		}
@@ -47,6 +47,14 @@ func TestLogsRequest(t *testing.T) {
 	)
 }
 
+func TestLogsRequestBytesSize(t *testing.T) {
+	ld := testdata.GenerateLogs(1)
+	req := newLogsRequest(context.Background(), ld, nil)
+	buf, err := logsMarshaler.MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t, len(buf), req.BytesSize())
+}
+
 func TestLogsExporter_InvalidName(t *testing.T) {
 	le, err := NewLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), nil, newPushLogsData(nil))
 	require.Nil(t, le)
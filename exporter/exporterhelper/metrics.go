@@ -63,6 +63,46 @@ func (req *metricsRequest) Count() int {
 	return req.md.DataPointCount()
 }
 
+func (req *metricsRequest) BytesSize() int {
+	return metricsMarshaler.MetricsSize(req.md)
+}
+
+// metricsBatch accumulates the data of metrics requests.
+type metricsBatch struct {
+	md     pmetric.Metrics
+	count  int
+	pusher consumer.ConsumeMetricsFunc
+}
+
+func newMetricsBatch(pusher consumer.ConsumeMetricsFunc) batch {
+	return &metricsBatch{
+		md:     pmetric.NewMetrics(),
+		pusher: pusher,
+	}
+}
+
+func (b *metricsBatch) add(req internal.Request) {
+	r := req.(*metricsRequest)
+	b.count += r.Count()
+	r.md.ResourceMetrics().MoveAndAppendTo(b.md.ResourceMetrics())
+}
+
+func (b *metricsBatch) itemCount() int {
+	return b.count
+}
+
+func (b *metricsBatch) requests(ctx context.Context, maxSize int) []internal.Request {
+	var reqs []internal.Request
+	for maxSize > 0 && b.count > maxSize {
+		reqs = append(reqs, newMetricsRequest(ctx, splitMetrics(maxSize, b.md), b.pusher))
+		b.count -= maxSize
+	}
+	reqs = append(reqs, newMetricsRequest(ctx, b.md, b.pusher))
+	b.md = pmetric.NewMetrics()
+	b.count = 0
+	return reqs
+}
+
 type metricsExporter struct {
 	*baseExporter
 	consumer.Metrics
@@ -99,10 +139,11 @@ func NewMetricsExporter(
 			nextSender: nextSender,
 		}
 	})
+	be.initBatchSender(set, bs, func() batch { return newMetricsBatch(pusher) }, be.obsrep.recordMetricsEnqueueFailure)
 
 	mc, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
 		req := newMetricsRequest(ctx, md, pusher)
-		serr := be.sender.send(req)
+		serr := be.send(req)
 		if errors.Is(serr, errSendingQueueIsFull) {
 			be.obsrep.recordMetricsEnqueueFailure(req.Context(), int64(req.Count()))
 		}
@@ -47,6 +47,14 @@ func TestMetricsRequest(t *testing.T) {
 	)
 }
 
+func TestMetricsRequestBytesSize(t *testing.T) {
+	md := testdata.GenerateMetrics(1)
+	req := newMetricsRequest(context.Background(), md, nil)
+	buf, err := metricsMarshaler.MarshalMetrics(md)
+	require.NoError(t, err)
+	assert.Equal(t, len(buf), req.BytesSize())
+}
+
 func TestMetricsExporter_InvalidName(t *testing.T) {
 	me, err := NewMetricsExporter(context.Background(), exportertest.NewNopCreateSettings(), nil, newPushMetricsData(nil))
 	require.Nil(t, me)
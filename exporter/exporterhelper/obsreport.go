@@ -5,11 +5,14 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 
 import (
 	"context"
+	"time"
 
 	"go.opencensus.io/metric"
 	"go.opencensus.io/metric/metricdata"
 	"go.opencensus.io/metric/metricproducer"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/obsreport"
 )
@@ -18,6 +21,9 @@ import (
 //       into existing `obsreport` package once its functionally is not exposed
 //       as public API. For now this part is kept private.
 
+// dataTypeKey is the label of the signal of the exporter metrics reported per signal.
+const dataTypeKey = "data_type"
+
 var (
 	globalInstruments = newInstruments(metric.NewRegistry())
 )
@@ -33,6 +39,12 @@ type instruments struct {
 	failedToEnqueueTraceSpans   *metric.Int64Cumulative
 	failedToEnqueueMetricPoints *metric.Int64Cumulative
 	failedToEnqueueLogRecords   *metric.Int64Cumulative
+	failedToEnqueueSamples      *metric.Int64Cumulative
+	shutdownDroppedTraceSpans   *metric.Int64Cumulative
+	shutdownDroppedMetricPoints *metric.Int64Cumulative
+	shutdownDroppedLogRecords   *metric.Int64Cumulative
+	shutdownDroppedSamples      *metric.Int64Cumulative
+	throttledTime               *metric.Int64Cumulative
 }
 
 func newInstruments(registry *metric.Registry) *instruments {
@@ -69,15 +81,77 @@ func newInstruments(registry *metric.Registry) *instruments {
 		metric.WithLabelKeys(obsmetrics.ExporterKey),
 		metric.WithUnit(metricdata.UnitDimensionless))
 
+	insts.failedToEnqueueSamples, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/enqueue_failed_samples",
+		metric.WithDescription("Number of profile samples failed to be added to the sending queue."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.shutdownDroppedTraceSpans, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/shutdown_dropped_spans",
+		metric.WithDescription("Number of spans dropped from the sending queue because it was not drained before the shutdown deadline."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.shutdownDroppedMetricPoints, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/shutdown_dropped_metric_points",
+		metric.WithDescription("Number of metric points dropped from the sending queue because it was not drained before the shutdown deadline."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.shutdownDroppedLogRecords, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/shutdown_dropped_log_records",
+		metric.WithDescription("Number of log records dropped from the sending queue because it was not drained before the shutdown deadline."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.shutdownDroppedSamples, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/shutdown_dropped_samples",
+		metric.WithDescription("Number of profile samples dropped from the sending queue because it was not drained before the shutdown deadline."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.throttledTime, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/throttled_time",
+		metric.WithDescription("Time during which the exporter paused sending because the destination asked to retry later."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey, dataTypeKey),
+		metric.WithUnit(metricdata.UnitMilliseconds))
+
 	return insts
 }
 
+// throttledTimeEntry returns the entry counting the time during which the exporter paused sending the given signal.
+func (insts *instruments) throttledTimeEntry(exporter component.ID, signal component.DataType) *metric.Int64CumulativeEntry {
+	entry, _ := insts.throttledTime.GetEntry(metricdata.NewLabelValue(exporter.String()), metricdata.NewLabelValue(string(signal)))
+	return entry
+}
+
+// shutdownDroppedEntry returns the entry counting the items of the given signal dropped by the exporter at shutdown.
+func (insts *instruments) shutdownDroppedEntry(exporter component.ID, signal component.DataType) *metric.Int64CumulativeEntry {
+	var cumulative *metric.Int64Cumulative
+	switch signal {
+	case component.DataTypeTraces:
+		cumulative = insts.shutdownDroppedTraceSpans
+	case component.DataTypeMetrics:
+		cumulative = insts.shutdownDroppedMetricPoints
+	case component.DataTypeLogs:
+		cumulative = insts.shutdownDroppedLogRecords
+	case component.DataTypeProfiles:
+		cumulative = insts.shutdownDroppedSamples
+	default:
+		return nil
+	}
+	entry, _ := cumulative.GetEntry(metricdata.NewLabelValue(exporter.String()))
+	return entry
+}
+
 // obsExporter is a helper to add observability to an exporter.
 type obsExporter struct {
 	*obsreport.Exporter
 	failedToEnqueueTraceSpansEntry   *metric.Int64CumulativeEntry
 	failedToEnqueueMetricPointsEntry *metric.Int64CumulativeEntry
 	failedToEnqueueLogRecordsEntry   *metric.Int64CumulativeEntry
+	failedToEnqueueSamplesEntry      *metric.Int64CumulativeEntry
 }
 
 // newObsExporter creates a new observability exporter.
@@ -86,6 +160,7 @@ func newObsExporter(cfg obsreport.ExporterSettings, insts *instruments) (*obsExp
 	failedToEnqueueTraceSpansEntry, _ := insts.failedToEnqueueTraceSpans.GetEntry(labelValue)
 	failedToEnqueueMetricPointsEntry, _ := insts.failedToEnqueueMetricPoints.GetEntry(labelValue)
 	failedToEnqueueLogRecordsEntry, _ := insts.failedToEnqueueLogRecords.GetEntry(labelValue)
+	failedToEnqueueSamplesEntry, _ := insts.failedToEnqueueSamples.GetEntry(labelValue)
 
 	exp, err := obsreport.NewExporter(cfg)
 	if err != nil {
@@ -97,6 +172,7 @@ func newObsExporter(cfg obsreport.ExporterSettings, insts *instruments) (*obsExp
 		failedToEnqueueTraceSpansEntry:   failedToEnqueueTraceSpansEntry,
 		failedToEnqueueMetricPointsEntry: failedToEnqueueMetricPointsEntry,
 		failedToEnqueueLogRecordsEntry:   failedToEnqueueLogRecordsEntry,
+		failedToEnqueueSamplesEntry:      failedToEnqueueSamplesEntry,
 	}, nil
 }
 
@@ -114,3 +190,21 @@ func (eor *obsExporter) recordMetricsEnqueueFailure(_ context.Context, numMetric
 func (eor *obsExporter) recordLogsEnqueueFailure(_ context.Context, numLogRecords int64) {
 	eor.failedToEnqueueLogRecordsEntry.Inc(numLogRecords)
 }
+
+// recordProfilesEnqueueFailure records number of profile samples that failed to be added to the sending queue.
+func (eor *obsExporter) recordProfilesEnqueueFailure(_ context.Context, numSamples int64) {
+	eor.failedToEnqueueSamplesEntry.Inc(numSamples)
+}
+
+// latencySender is a requestSender that records the duration of every attempt to send a request.
+type latencySender struct {
+	obsrep     *obsExporter
+	nextSender requestSender
+}
+
+func (ls *latencySender) send(req internal.Request) error {
+	start := time.Now()
+	err := ls.nextSender.send(req)
+	ls.obsrep.RecordLatency(req.Context(), time.Since(start), err)
+	return err
+}
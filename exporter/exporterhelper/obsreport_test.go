@@ -40,6 +40,10 @@ func TestExportEnqueueFailure(t *testing.T) {
 	metricPoints := int64(21)
 	obsrep.recordMetricsEnqueueFailure(context.Background(), metricPoints)
 	checkExporterEnqueueFailedMetricsStats(t, insts, exporter, metricPoints)
+
+	samples := int64(5)
+	obsrep.recordProfilesEnqueueFailure(context.Background(), samples)
+	checkExporterEnqueueFailedProfilesStats(t, insts, exporter, samples)
 }
 
 // checkExporterEnqueueFailedTracesStats checks that reported number of spans failed to enqueue match given values.
@@ -60,6 +64,12 @@ func checkExporterEnqueueFailedLogsStats(t *testing.T, insts *instruments, expor
 	checkValueForProducer(t, insts.registry, tagsForExporterView(exporter), logRecords, "exporter/enqueue_failed_log_records")
 }
 
+// checkExporterEnqueueFailedProfilesStats checks that reported number of profile samples failed to enqueue match given values.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func checkExporterEnqueueFailedProfilesStats(t *testing.T, insts *instruments, exporter component.ID, samples int64) {
+	checkValueForProducer(t, insts.registry, tagsForExporterView(exporter), samples, "exporter/enqueue_failed_samples")
+}
+
 // tagsForExporterView returns the tags that are needed for the exporter views.
 func tagsForExporterView(exporter component.ID) []tag.Tag {
 	return []tag.Tag{
@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package otlpfile reads and writes files of OTLP export requests of any signal, every request being
+// written as a record tagged with its signal, the time at which it was written, its encoding and its
+// compression. It is the format of the file exporter, the dead letter files and the recordings.
+package otlpfile // import "go.opentelemetry.io/collector/exporter/exporterhelper/otlpfile"
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// headerSize is the size of the header of a record: its signal, encoding, compression, time and data length.
+const headerSize = 1 + 1 + 1 + 8 + 4
+
+// MaxRecordSize bounds the size of the data of a record, to detect corrupted files.
+const MaxRecordSize = 1 << 30
+
+// Encoding is the encoding of the OTLP export request of a record.
+type Encoding byte
+
+const (
+	// EncodingProto is the OTLP protobuf encoding.
+	EncodingProto Encoding = 1
+	// EncodingJSON is the OTLP JSON encoding.
+	EncodingJSON Encoding = 2
+)
+
+// Compression is the compression of the data of a record, which is applied by the writer of the file
+// and must be reverted by its readers.
+type Compression byte
+
+const (
+	// CompressionNone is used when the data is not compressed.
+	CompressionNone Compression = 0
+	// CompressionGzip is used when the data is compressed with gzip.
+	CompressionGzip Compression = 1
+	// CompressionZstd is used when the data is compressed with zstd.
+	CompressionZstd Compression = 2
+)
+
+var (
+	// ErrInvalidRecord is returned when reading a record which is not valid, such as the records of a file
+	// which is not in this format.
+	ErrInvalidRecord = errors.New("invalid OTLP file record")
+
+	signals = []component.DataType{
+		component.DataTypeTraces,
+		component.DataTypeMetrics,
+		component.DataTypeLogs,
+		component.DataTypeProfiles,
+	}
+)
+
+// Record is an OTLP export request written to a file.
+type Record struct {
+	// Signal is the type of the data.
+	Signal component.DataType
+	// Time is the time at which the record was written.
+	Time time.Time
+	// Encoding is the encoding of the export request.
+	Encoding Encoding
+	// Compression is the compression of Data.
+	Compression Compression
+	// Data is the export request, encoded with Encoding then compressed with Compression.
+	Data []byte
+}
+
+// Size returns the size of the record once written.
+func (rec Record) Size() int {
+	return headerSize + len(rec.Data)
+}
+
+// Writer writes records to a file.
+type Writer struct {
+	w io.Writer
+}
+
+// NewWriter returns a Writer writing records to w. A record is written with a single call to w.Write.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write writes the record.
+func (w *Writer) Write(rec Record) error {
+	code := signalCode(rec.Signal)
+	if code == 0 {
+		return fmt.Errorf("unsupported signal %q", rec.Signal)
+	}
+	if len(rec.Data) > MaxRecordSize {
+		return fmt.Errorf("record of %d bytes is too large", len(rec.Data))
+	}
+	buf := make([]byte, headerSize, rec.Size())
+	buf[0] = code
+	buf[1] = byte(rec.Encoding)
+	buf[2] = byte(rec.Compression)
+	binary.BigEndian.PutUint64(buf[3:11], uint64(rec.Time.UnixNano()))
+	binary.BigEndian.PutUint32(buf[11:15], uint32(len(rec.Data)))
+	buf = append(buf, rec.Data...)
+	_, err := w.w.Write(buf)
+	return err
+}
+
+// Reader reads the records of a file.
+type Reader struct {
+	r *bufio.Reader
+}
+
+// NewReader returns a Reader reading records from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Next returns the next record, or io.EOF once all the records were read. The error wraps
+// io.ErrUnexpectedEOF when the last record is truncated, for instance because it is still being written.
+func (r *Reader) Next() (Record, error) {
+	var header [headerSize]byte
+	if _, err := io.ReadFull(r.r, header[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return Record{}, io.EOF
+		}
+		return Record{}, fmt.Errorf("truncated record: %w", err)
+	}
+	rec := Record{
+		Signal:      signalFromCode(header[0]),
+		Encoding:    Encoding(header[1]),
+		Compression: Compression(header[2]),
+		Time:        time.Unix(0, int64(binary.BigEndian.Uint64(header[3:11]))),
+	}
+	if rec.Signal == "" {
+		return Record{}, fmt.Errorf("%w: unsupported signal code %d", ErrInvalidRecord, header[0])
+	}
+	if rec.Encoding != EncodingProto && rec.Encoding != EncodingJSON {
+		return Record{}, fmt.Errorf("%w: unsupported encoding %d", ErrInvalidRecord, header[1])
+	}
+	if rec.Compression > CompressionZstd {
+		return Record{}, fmt.Errorf("%w: unsupported compression %d", ErrInvalidRecord, header[2])
+	}
+	size := binary.BigEndian.Uint32(header[11:15])
+	if size > MaxRecordSize {
+		return Record{}, fmt.Errorf("%w: record of %d bytes is too large", ErrInvalidRecord, size)
+	}
+	rec.Data = make([]byte, size)
+	if _, err := io.ReadFull(r.r, rec.Data); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return Record{}, fmt.Errorf("truncated record: %w", err)
+	}
+	return rec, nil
+}
+
+func signalCode(signal component.DataType) byte {
+	for i, s := range signals {
+		if s == signal {
+			return byte(i + 1)
+		}
+	}
+	return 0
+}
+
+func signalFromCode(code byte) component.DataType {
+	if code == 0 || int(code) > len(signals) {
+		return ""
+	}
+	return signals[code-1]
+}
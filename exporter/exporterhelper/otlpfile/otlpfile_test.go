@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfile
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestWriteRead(t *testing.T) {
+	records := []Record{
+		{Signal: component.DataTypeTraces, Time: time.Unix(1, 2), Encoding: EncodingProto, Data: []byte("traces")},
+		{Signal: component.DataTypeMetrics, Time: time.Unix(3, 4), Encoding: EncodingJSON, Compression: CompressionGzip, Data: []byte("metrics")},
+		{Signal: component.DataTypeLogs, Time: time.Unix(5, 6), Encoding: EncodingProto, Compression: CompressionZstd},
+		{Signal: component.DataTypeProfiles, Time: time.Unix(7, 8), Encoding: EncodingProto, Data: []byte("profiles")},
+	}
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, rec := range records {
+		require.NoError(t, w.Write(rec))
+	}
+	assert.Equal(t, records[0].Size()+records[1].Size()+records[2].Size()+records[3].Size(), buf.Len())
+
+	r := NewReader(&buf)
+	for _, want := range records {
+		rec, err := r.Next()
+		require.NoError(t, err)
+		if want.Data == nil {
+			want.Data = []byte{}
+		}
+		assert.Equal(t, want, rec)
+	}
+	_, err := r.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestWriteUnsupportedSignal(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Error(t, NewWriter(&buf).Write(Record{Signal: "unknown", Encoding: EncodingProto}))
+	assert.Zero(t, buf.Len())
+}
+
+func TestReadInvalid(t *testing.T) {
+	valid := func() []byte {
+		var buf bytes.Buffer
+		require.NoError(t, NewWriter(&buf).Write(Record{Signal: component.DataTypeTraces, Encoding: EncodingProto, Data: []byte("data")}))
+		return buf.Bytes()
+	}
+	for _, tt := range []struct {
+		name    string
+		corrupt func([]byte) []byte
+	}{
+		{name: "signal", corrupt: func(b []byte) []byte { b[0] = 9; return b }},
+		{name: "encoding", corrupt: func(b []byte) []byte { b[1] = 0; return b }},
+		{name: "compression", corrupt: func(b []byte) []byte { b[2] = 9; return b }},
+		{name: "size", corrupt: func(b []byte) []byte { b[11] = 0xff; return b }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReader(bytes.NewReader(tt.corrupt(valid()))).Next()
+			assert.ErrorIs(t, err, ErrInvalidRecord)
+		})
+	}
+
+	for _, size := range []int{1, headerSize, headerSize + 1} {
+		_, err := NewReader(bytes.NewReader(valid()[:size])).Next()
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.False(t, errors.Is(err, io.EOF))
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+)
+
+var profilesMarshaler = &pprofile.ProtoMarshaler{}
+var profilesUnmarshaler = &pprofile.ProtoUnmarshaler{}
+
+type profilesRequest struct {
+	baseRequest
+	pd     pprofile.Profiles
+	pusher consumer.ConsumeProfilesFunc
+}
+
+func newProfilesRequest(ctx context.Context, pd pprofile.Profiles, pusher consumer.ConsumeProfilesFunc) internal.Request {
+	return &profilesRequest{
+		baseRequest: baseRequest{ctx: ctx},
+		pd:          pd,
+		pusher:      pusher,
+	}
+}
+
+func newProfilesRequestUnmarshalerFunc(pusher consumer.ConsumeProfilesFunc) internal.RequestUnmarshaler {
+	return func(bytes []byte) (internal.Request, error) {
+		profiles, err := profilesUnmarshaler.UnmarshalProfiles(bytes)
+		if err != nil {
+			return nil, err
+		}
+		return newProfilesRequest(context.Background(), profiles, pusher), nil
+	}
+}
+
+func (req *profilesRequest) OnError(err error) internal.Request {
+	var profileError consumererror.Profiles
+	if errors.As(err, &profileError) {
+		return newProfilesRequest(req.ctx, profileError.Data(), req.pusher)
+	}
+	return req
+}
+
+func (req *profilesRequest) Export(ctx context.Context) error {
+	return req.pusher(ctx, req.pd)
+}
+
+func (req *profilesRequest) Marshal() ([]byte, error) {
+	return profilesMarshaler.MarshalProfiles(req.pd)
+}
+
+func (req *profilesRequest) Count() int {
+	return req.pd.SampleCount()
+}
+
+func (req *profilesRequest) BytesSize() int {
+	return profilesMarshaler.ProfilesSize(req.pd)
+}
+
+// profilesBatch accumulates the data of profiles requests.
+type profilesBatch struct {
+	pd     pprofile.Profiles
+	count  int
+	pusher consumer.ConsumeProfilesFunc
+}
+
+func newProfilesBatch(pusher consumer.ConsumeProfilesFunc) batch {
+	return &profilesBatch{
+		pd:     pprofile.NewProfiles(),
+		pusher: pusher,
+	}
+}
+
+func (b *profilesBatch) add(req internal.Request) {
+	r := req.(*profilesRequest)
+	b.count += r.Count()
+	r.pd.ResourceProfiles().MoveAndAppendTo(b.pd.ResourceProfiles())
+}
+
+func (b *profilesBatch) itemCount() int {
+	return b.count
+}
+
+// requests returns the batch as a single request: the profiles are not split to honor maxSize, because
+// their samples reference the tables of the profile which contains them.
+func (b *profilesBatch) requests(ctx context.Context, _ int) []internal.Request {
+	req := newProfilesRequest(ctx, b.pd, b.pusher)
+	b.pd = pprofile.NewProfiles()
+	b.count = 0
+	return []internal.Request{req}
+}
+
+type profilesExporter struct {
+	*baseExporter
+	consumer.Profiles
+}
+
+// NewProfilesExporter creates an exporter.Profiles that records observability metrics and wraps every request with a Span.
+func NewProfilesExporter(
+	_ context.Context,
+	set exporter.CreateSettings,
+	cfg component.Config,
+	pusher consumer.ConsumeProfilesFunc,
+	options ...Option,
+) (exporter.Profiles, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+
+	if set.Logger == nil {
+		return nil, errNilLogger
+	}
+
+	if pusher == nil {
+		return nil, errNilPushProfilesData
+	}
+
+	bs := fromOptions(options...)
+	be, err := newBaseExporter(set, bs, component.DataTypeProfiles, newProfilesRequestUnmarshalerFunc(pusher))
+	if err != nil {
+		return nil, err
+	}
+	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
+		return &profilesExporterWithObservability{
+			obsrep:     be.obsrep,
+			nextSender: nextSender,
+		}
+	})
+	be.initBatchSender(set, bs, func() batch { return newProfilesBatch(pusher) }, be.obsrep.recordProfilesEnqueueFailure)
+
+	pc, err := consumer.NewProfiles(func(ctx context.Context, pd pprofile.Profiles) error {
+		req := newProfilesRequest(ctx, pd, pusher)
+		serr := be.send(req)
+		if errors.Is(serr, errSendingQueueIsFull) {
+			be.obsrep.recordProfilesEnqueueFailure(req.Context(), int64(req.Count()))
+		}
+		return serr
+	}, bs.consumerOptions...)
+
+	return &profilesExporter{
+		baseExporter: be,
+		Profiles:     pc,
+	}, err
+}
+
+type profilesExporterWithObservability struct {
+	obsrep     *obsExporter
+	nextSender requestSender
+}
+
+func (pewo *profilesExporterWithObservability) send(req internal.Request) error {
+	req.SetContext(pewo.obsrep.StartProfilesOp(req.Context()))
+	err := pewo.nextSender.send(req)
+	pewo.obsrep.EndProfilesOp(req.Context(), req.Count(), err)
+	return err
+}
@@ -0,0 +1,259 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+)
+
+const (
+	fakeProfilesParentSpanName = "fake_profiles_parent_span_name"
+)
+
+var (
+	fakeProfilesExporterName   = component.NewIDWithName("fake_profiles_exporter", "with_name")
+	fakeProfilesExporterConfig = struct{}{}
+)
+
+func TestProfilesRequest(t *testing.T) {
+	pr := newProfilesRequest(context.Background(), testdata.GenerateProfiles(1), nil)
+
+	profileErr := consumererror.NewProfiles(errors.New("some error"), pprofile.NewProfiles())
+	assert.EqualValues(
+		t,
+		newProfilesRequest(context.Background(), pprofile.NewProfiles(), nil),
+		pr.OnError(profileErr),
+	)
+}
+
+func TestProfilesRequestBytesSize(t *testing.T) {
+	pd := testdata.GenerateProfiles(1)
+	req := newProfilesRequest(context.Background(), pd, nil)
+	buf, err := profilesMarshaler.MarshalProfiles(pd)
+	require.NoError(t, err)
+	assert.Equal(t, len(buf), req.BytesSize())
+}
+
+func TestProfilesExporter_InvalidName(t *testing.T) {
+	pe, err := NewProfilesExporter(context.Background(), exportertest.NewNopCreateSettings(), nil, newPushProfilesData(nil))
+	require.Nil(t, pe)
+	require.Equal(t, errNilConfig, err)
+}
+
+func TestProfilesExporter_NilLogger(t *testing.T) {
+	pe, err := NewProfilesExporter(context.Background(), exporter.CreateSettings{}, &fakeProfilesExporterConfig, newPushProfilesData(nil))
+	require.Nil(t, pe)
+	require.Equal(t, errNilLogger, err)
+}
+
+func TestProfilesExporter_NilPushProfilesData(t *testing.T) {
+	pe, err := NewProfilesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeProfilesExporterConfig, nil)
+	require.Nil(t, pe)
+	require.Equal(t, errNilPushProfilesData, err)
+}
+
+func TestProfilesExporter_Default(t *testing.T) {
+	pd := pprofile.NewProfiles()
+	pe, err := NewProfilesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeProfilesExporterConfig, newPushProfilesData(nil))
+	assert.NotNil(t, pe)
+	assert.NoError(t, err)
+
+	assert.Equal(t, consumer.Capabilities{MutatesData: false}, pe.Capabilities())
+	assert.NoError(t, pe.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, pe.ConsumeProfiles(context.Background(), pd))
+	assert.NoError(t, pe.Shutdown(context.Background()))
+}
+
+func TestProfilesExporter_WithCapabilities(t *testing.T) {
+	capabilities := consumer.Capabilities{MutatesData: true}
+	pe, err := NewProfilesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeProfilesExporterConfig, newPushProfilesData(nil), WithCapabilities(capabilities))
+	require.NoError(t, err)
+	require.NotNil(t, pe)
+
+	assert.Equal(t, capabilities, pe.Capabilities())
+}
+
+func TestProfilesExporter_Default_ReturnError(t *testing.T) {
+	pd := pprofile.NewProfiles()
+	want := errors.New("my_error")
+	pe, err := NewProfilesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeProfilesExporterConfig, newPushProfilesData(want))
+	require.NoError(t, err)
+	require.NotNil(t, pe)
+	require.Equal(t, want, pe.ConsumeProfiles(context.Background(), pd))
+}
+
+func TestProfilesExporter_WithRecordProfiles(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(fakeProfilesExporterName)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	pe, err := NewProfilesExporter(context.Background(), tt.ToExporterCreateSettings(), &fakeProfilesExporterConfig, newPushProfilesData(nil))
+	require.NoError(t, err)
+	require.NotNil(t, pe)
+
+	checkRecordedMetricsForProfilesExporter(t, tt, pe, nil)
+}
+
+func TestProfilesExporter_WithRecordProfiles_ReturnError(t *testing.T) {
+	want := errors.New("my_error")
+	tt, err := obsreporttest.SetupTelemetry(fakeProfilesExporterName)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	pe, err := NewProfilesExporter(context.Background(), tt.ToExporterCreateSettings(), &fakeProfilesExporterConfig, newPushProfilesData(want))
+	require.Nil(t, err)
+	require.NotNil(t, pe)
+
+	checkRecordedMetricsForProfilesExporter(t, tt, pe, want)
+}
+
+func TestProfilesExporter_WithRecordEnqueueFailedMetrics(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(fakeProfilesExporterName)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	rCfg := NewDefaultRetrySettings()
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.QueueSize = 2
+	wantErr := errors.New("some-error")
+	te, err := NewProfilesExporter(context.Background(), tt.ToExporterCreateSettings(), &fakeProfilesExporterConfig, newPushProfilesData(wantErr), WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NotNil(t, te)
+
+	md := testdata.GenerateProfiles(3)
+	const numBatches = 7
+	for i := 0; i < numBatches; i++ {
+		// errors are checked in the checkExporterEnqueueFailedProfilesStats function below.
+		_ = te.ConsumeProfiles(context.Background(), md)
+	}
+
+	// 2 batched must be in queue, and 5 batches (15 samples) rejected due to queue overflow
+	checkExporterEnqueueFailedProfilesStats(t, globalInstruments, fakeProfilesExporterName, int64(15))
+}
+
+func TestProfilesExporter_WithSpan(t *testing.T) {
+	set := exportertest.NewNopCreateSettings()
+	sr := new(tracetest.SpanRecorder)
+	set.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	otel.SetTracerProvider(set.TracerProvider)
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	pe, err := NewProfilesExporter(context.Background(), set, &fakeProfilesExporterConfig, newPushProfilesData(nil))
+	require.Nil(t, err)
+	require.NotNil(t, pe)
+	checkWrapSpanForProfilesExporter(t, sr, set.TracerProvider.Tracer("test"), pe, nil, 1)
+}
+
+func TestProfilesExporter_WithSpan_ReturnError(t *testing.T) {
+	set := exportertest.NewNopCreateSettings()
+	sr := new(tracetest.SpanRecorder)
+	set.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	otel.SetTracerProvider(set.TracerProvider)
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	want := errors.New("my_error")
+	pe, err := NewProfilesExporter(context.Background(), set, &fakeProfilesExporterConfig, newPushProfilesData(want))
+	require.Nil(t, err)
+	require.NotNil(t, pe)
+	checkWrapSpanForProfilesExporter(t, sr, set.TracerProvider.Tracer("test"), pe, want, 1)
+}
+
+func TestProfilesExporter_WithShutdown(t *testing.T) {
+	shutdownCalled := false
+	shutdown := func(context.Context) error { shutdownCalled = true; return nil }
+
+	pe, err := NewProfilesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeProfilesExporterConfig, newPushProfilesData(nil), WithShutdown(shutdown))
+	assert.NotNil(t, pe)
+	assert.NoError(t, err)
+
+	assert.Nil(t, pe.Shutdown(context.Background()))
+	assert.True(t, shutdownCalled)
+}
+
+func TestProfilesExporter_WithShutdown_ReturnError(t *testing.T) {
+	want := errors.New("my_error")
+	shutdownErr := func(context.Context) error { return want }
+
+	pe, err := NewProfilesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeProfilesExporterConfig, newPushProfilesData(nil), WithShutdown(shutdownErr))
+	assert.NotNil(t, pe)
+	assert.NoError(t, err)
+
+	assert.Equal(t, pe.Shutdown(context.Background()), want)
+}
+
+func newPushProfilesData(retError error) consumer.ConsumeProfilesFunc {
+	return func(ctx context.Context, td pprofile.Profiles) error {
+		return retError
+	}
+}
+
+func checkRecordedMetricsForProfilesExporter(t *testing.T, tt obsreporttest.TestTelemetry, pe exporter.Profiles, wantError error) {
+	pd := testdata.GenerateProfiles(2)
+	const numBatches = 7
+	for i := 0; i < numBatches; i++ {
+		require.Equal(t, wantError, pe.ConsumeProfiles(context.Background(), pd))
+	}
+
+	// TODO: When the new metrics correctly count partial dropped fix this.
+	if wantError != nil {
+		require.NoError(t, tt.CheckExporterProfiles(0, int64(numBatches*pd.SampleCount())))
+	} else {
+		require.NoError(t, tt.CheckExporterProfiles(int64(numBatches*pd.SampleCount()), 0))
+	}
+}
+
+func generateProfilesTraffic(t *testing.T, tracer trace.Tracer, pe exporter.Profiles, numRequests int, wantError error) {
+	pd := testdata.GenerateProfiles(1)
+	ctx, span := tracer.Start(context.Background(), fakeProfilesParentSpanName)
+	defer span.End()
+	for i := 0; i < numRequests; i++ {
+		require.Equal(t, wantError, pe.ConsumeProfiles(ctx, pd))
+	}
+}
+
+func checkWrapSpanForProfilesExporter(t *testing.T, sr *tracetest.SpanRecorder, tracer trace.Tracer, pe exporter.Profiles, wantError error, numSamples int64) {
+	const numRequests = 5
+	generateProfilesTraffic(t, tracer, pe, numRequests, wantError)
+
+	// Inspection time!
+	gotSpanData := sr.Ended()
+	require.Equal(t, numRequests+1, len(gotSpanData))
+
+	parentSpan := gotSpanData[numRequests]
+	require.Equalf(t, fakeProfilesParentSpanName, parentSpan.Name(), "SpanData %v", parentSpan)
+	for _, sd := range gotSpanData[:numRequests] {
+		require.Equalf(t, parentSpan.SpanContext(), sd.Parent(), "Exporter span not a child\nSpanData %v", sd)
+		checkStatus(t, sd, wantError)
+
+		sentSamples := numSamples
+		var failedToSendSamples int64
+		if wantError != nil {
+			sentSamples = 0
+			failedToSendSamples = numSamples
+		}
+		require.Containsf(t, sd.Attributes(), attribute.KeyValue{Key: obsmetrics.SentSamplesKey, Value: attribute.Int64Value(sentSamples)}, "SpanData %v", sd)
+		require.Containsf(t, sd.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSamplesKey, Value: attribute.Int64Value(failedToSendSamples)}, "SpanData %v", sd)
+	}
+}
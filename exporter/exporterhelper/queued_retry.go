@@ -7,9 +7,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"go.opencensus.io/metric"
 	"go.opencensus.io/metric/metricdata"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -18,12 +23,18 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
-const defaultQueueSize = 1000
+const (
+	defaultQueueSize         = 1000
+	defaultAdaptiveInterval  = time.Second
+	defaultAdaptiveConsumers = 1
+	drainPollInterval        = 10 * time.Millisecond
+)
 
 var (
 	errSendingQueueIsFull = errors.New("sending_queue is full")
@@ -35,13 +46,48 @@ var (
 type QueueSettings struct {
 	// Enabled indicates whether to not enqueue batches before sending to the consumerSender.
 	Enabled bool `mapstructure:"enabled"`
-	// NumConsumers is the number of consumers from the queue.
+	// NumConsumers is the number of consumers from the queue, or their maximum number if AdaptiveConsumers is set.
 	NumConsumers int `mapstructure:"num_consumers"`
+	// AdaptiveConsumers if not nil, scales the number of consumers processing batches concurrently
+	// between MinConsumers and NumConsumers, depending on the queue depth and on the export latency.
+	AdaptiveConsumers *AdaptiveConsumersSettings `mapstructure:"adaptive_consumers"`
 	// QueueSize is the maximum number of batches allowed in queue at a given time.
 	QueueSize int `mapstructure:"queue_size"`
+	// MaxBytes is the maximum estimated size in bytes of the batches allowed in queue at a given time,
+	// 0 means no limit. The size of a batch is estimated as the size of its protobuf encoding.
+	MaxBytes int64 `mapstructure:"max_bytes"`
+	// MetadataKeys is a list of client.Metadata keys partitioning the queue, so that the batches of a
+	// distinct combination of their values, such as a tenant, cannot fill the queue on their own.
+	MetadataKeys []string `mapstructure:"metadata_keys"`
+	// PartitionQueueSize is the maximum number of batches of a partition allowed in queue at a given time
+	// when MetadataKeys is set, QueueSize if not set.
+	PartitionQueueSize int `mapstructure:"partition_queue_size"`
 	// StorageID if not empty, enables the persistent storage and uses the component specified
 	// as a storage extension for the persistent queue
 	StorageID *component.ID `mapstructure:"storage"`
+	// File if not nil, enables the persistent storage and keeps the persistent queue in a file
+	// of the given directory, without requiring a storage extension.
+	File *FileQueueSettings `mapstructure:"file"`
+}
+
+// AdaptiveConsumersSettings defines configuration for scaling the number of consumers from the queue.
+type AdaptiveConsumersSettings struct {
+	// MinConsumers is the minimum number of consumers processing batches concurrently, 1 if not set.
+	MinConsumers int `mapstructure:"min_consumers"`
+	// Interval is the interval between the adjustments of the number of consumers, 1s if not set.
+	Interval time.Duration `mapstructure:"interval"`
+	// TargetLatency is the average time to export a batch, including the retries, above which the number
+	// of consumers is halved. If not set, the number of consumers only depends on the queue depth.
+	TargetLatency time.Duration `mapstructure:"target_latency"`
+}
+
+// FileQueueSettings defines configuration for the persistent queue kept in a file, in the format
+// of the file storage extension.
+type FileQueueSettings struct {
+	// Directory is the directory where the queue file is kept, it must exist.
+	Directory string `mapstructure:"directory"`
+	// Fsync syncs the file to disk after each write, at the cost of a lower throughput.
+	Fsync bool `mapstructure:"fsync"`
 }
 
 // NewDefaultQueueSettings returns the default settings for QueueSettings.
@@ -66,6 +112,45 @@ func (qCfg *QueueSettings) Validate() error {
 		return errors.New("queue size must be positive")
 	}
 
+	if qCfg.MaxBytes < 0 {
+		return errors.New("queue max bytes must not be negative")
+	}
+
+	if qCfg.PartitionQueueSize < 0 || qCfg.PartitionQueueSize > qCfg.QueueSize {
+		return errors.New("partition queue size must be between 0 and queue size")
+	}
+
+	uniq := map[string]bool{}
+	for _, k := range qCfg.MetadataKeys {
+		l := strings.ToLower(k)
+		if _, has := uniq[l]; has {
+			return fmt.Errorf("duplicate entry in queue metadata_keys: %q (case-insensitive)", l)
+		}
+		uniq[l] = true
+	}
+	// The requests restored by a persistent queue have no client metadata, their partition is unknown.
+	if len(qCfg.MetadataKeys) != 0 && (qCfg.StorageID != nil || qCfg.File != nil) {
+		return errors.New("queue metadata_keys cannot be used with a persistent queue")
+	}
+
+	if qCfg.AdaptiveConsumers != nil {
+		if qCfg.AdaptiveConsumers.MinConsumers < 0 || qCfg.AdaptiveConsumers.MinConsumers > qCfg.NumConsumers {
+			return errors.New("adaptive min consumers must be between 0 and num consumers")
+		}
+		if qCfg.AdaptiveConsumers.Interval < 0 || qCfg.AdaptiveConsumers.TargetLatency < 0 {
+			return errors.New("adaptive interval and target latency must not be negative")
+		}
+	}
+
+	if qCfg.File != nil {
+		if qCfg.StorageID != nil {
+			return errors.New("storage and file cannot be both set")
+		}
+		if qCfg.File.Directory == "" {
+			return errors.New("file directory must be set")
+		}
+	}
+
 	return nil
 }
 
@@ -81,9 +166,18 @@ type queuedRetrySender struct {
 	logger             *zap.Logger
 	requeuingEnabled   bool
 	requestUnmarshaler internal.RequestUnmarshaler
+	deadLetter         *deadLetterQueue
+	statusSender       *statusSender
+
+	// inFlight is the number of requests taken from the queue which are being sent.
+	inFlight atomic.Int64
+	// dropping is set when the queue was not drained before the shutdown deadline,
+	// the requests still in the queue are then dropped instead of being sent.
+	dropping        atomic.Bool
+	shutdownDropped *metric.Int64CumulativeEntry
 }
 
-func newQueuedRetrySender(id component.ID, signal component.DataType, qCfg QueueSettings, rCfg RetrySettings, reqUnmarshaler internal.RequestUnmarshaler, nextSender requestSender, logger *zap.Logger) *queuedRetrySender {
+func newQueuedRetrySender(id component.ID, signal component.DataType, qCfg QueueSettings, rCfg RetrySettings, dlCfg DeadLetterSettings, reqUnmarshaler internal.RequestUnmarshaler, nextSender requestSender, logger *zap.Logger) *queuedRetrySender {
 	retryStopCh := make(chan struct{})
 	sampledLogger := createSampledLogger(logger)
 	traceAttr := attribute.String(obsmetrics.ExporterKey, id.String())
@@ -97,26 +191,64 @@ func newQueuedRetrySender(id component.ID, signal component.DataType, qCfg Queue
 		traceAttribute:     traceAttr,
 		logger:             sampledLogger,
 		requestUnmarshaler: reqUnmarshaler,
+		deadLetter:         newDeadLetterQueue(id, signal, dlCfg, sampledLogger),
+		shutdownDropped:    globalInstruments.shutdownDroppedEntry(id, signal),
 	}
 
-	qrs.consumerSender = &retrySender{
+	qrs.statusSender = &statusSender{nextSender: &retrySender{
 		traceAttribute: traceAttr,
 		cfg:            rCfg,
+		budget:         newRetryBudget(rCfg.Budget),
+		throttle:       &throttle{throttledTime: globalInstruments.throttledTimeEntry(id, signal)},
 		nextSender:     nextSender,
 		stopCh:         retryStopCh,
 		logger:         sampledLogger,
 		// Following three functions actually depend on queuedRetrySender
 		onTemporaryFailure: qrs.onTemporaryFailure,
-	}
+		onPermanentFailure: qrs.onPermanentFailure,
+	}}
+	qrs.consumerSender = qrs.statusSender
 
-	if qCfg.StorageID == nil {
-		qrs.queue = internal.NewBoundedMemoryQueue(qrs.cfg.QueueSize)
+	if qCfg.StorageID == nil && qCfg.File == nil {
+		qrs.queue = qrs.wrapQueue(internal.NewBoundedMemoryQueue(qrs.cfg.QueueSize))
 	}
 	// The Persistent Queue is initialized separately as it needs extra information about the component
 
 	return qrs
 }
 
+// wrapQueue limits the size in bytes of the batches held by queue if MaxBytes is set,
+// the number of batches of each partition if MetadataKeys is set, and scales its consumers
+// if AdaptiveConsumers is set.
+func (qrs *queuedRetrySender) wrapQueue(queue internal.ProducerConsumerQueue) internal.ProducerConsumerQueue {
+	// The bytes limit wraps the queue first, to account for the requests restored by a persistent queue.
+	if qrs.cfg.MaxBytes != 0 {
+		queue = internal.NewBytesLimitedQueue(queue, qrs.cfg.MaxBytes, qrs.logger)
+	}
+	if ac := qrs.cfg.AdaptiveConsumers; ac != nil {
+		set := internal.AdaptiveConsumersSettings{
+			MinConsumers:  ac.MinConsumers,
+			Interval:      ac.Interval,
+			TargetLatency: ac.TargetLatency,
+		}
+		if set.MinConsumers == 0 {
+			set.MinConsumers = defaultAdaptiveConsumers
+		}
+		if set.Interval == 0 {
+			set.Interval = defaultAdaptiveInterval
+		}
+		queue = internal.NewAdaptiveConsumersQueue(queue, set, qrs.logger)
+	}
+	if len(qrs.cfg.MetadataKeys) != 0 {
+		maxSize := qrs.cfg.PartitionQueueSize
+		if maxSize == 0 {
+			maxSize = qrs.cfg.QueueSize
+		}
+		queue = internal.NewPartitionedQueue(queue, qrs.cfg.MetadataKeys, maxSize)
+	}
+	return queue
+}
+
 func getStorageExtension(extensions map[component.ID]component.Component, storageID component.ID) (storage.Extension, error) {
 	if ext, found := extensions[storageID]; found {
 		if storageExt, ok := ext.(storage.Extension); ok {
@@ -141,18 +273,40 @@ func toStorageClient(ctx context.Context, storageID component.ID, host component
 	return client, err
 }
 
+// toFileClient opens the file keeping the persistent queue of the exporter for its signal, in the format
+// of the file storage extension, compacted on start and once the queue shrank.
+func (qrs *queuedRetrySender) toFileClient() (storage.Client, error) {
+	name := fmt.Sprintf("exporter_%s_%s", sanitizeFileName(qrs.id.String()), qrs.signal)
+	return internal.NewFileClient(filepath.Join(qrs.cfg.File.Directory, name), qrs.cfg.File.Fsync, qrs.logger)
+}
+
+// sanitizeFileName replaces the characters of name which are not safe in a file name.
+func sanitizeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
 // initializePersistentQueue uses extra information for initialization available from component.Host
 func (qrs *queuedRetrySender) initializePersistentQueue(ctx context.Context, host component.Host) error {
-	if qrs.cfg.StorageID == nil {
+	var storageClient storage.Client
+	var err error
+	switch {
+	case qrs.cfg.StorageID != nil:
+		storageClient, err = toStorageClient(ctx, *qrs.cfg.StorageID, host, qrs.id, qrs.signal)
+	case qrs.cfg.File != nil:
+		storageClient, err = qrs.toFileClient()
+	default:
 		return nil
 	}
-
-	storageClient, err := toStorageClient(ctx, *qrs.cfg.StorageID, host, qrs.id, qrs.signal)
 	if err != nil {
 		return err
 	}
 
-	qrs.queue = internal.NewPersistentQueue(ctx, qrs.fullName, qrs.signal, qrs.cfg.QueueSize, qrs.logger, storageClient, qrs.requestUnmarshaler)
+	qrs.queue = qrs.wrapQueue(internal.NewPersistentQueue(ctx, qrs.fullName, qrs.signal, qrs.cfg.QueueSize, qrs.logger, storageClient, qrs.requestUnmarshaler))
 
 	// TODO: this can be further exposed as a config param rather than relying on a type of queue
 	qrs.requeuingEnabled = true
@@ -161,7 +315,14 @@ func (qrs *queuedRetrySender) initializePersistentQueue(ctx context.Context, hos
 }
 
 func (qrs *queuedRetrySender) onTemporaryFailure(logger *zap.Logger, req internal.Request, err error) error {
+	// The replayed dead letter files are kept by the caller if they are not sent.
+	if _, replayed := deadLetterReplayTime(req.Context()); replayed {
+		return err
+	}
 	if !qrs.requeuingEnabled || qrs.queue == nil {
+		if qrs.dropping.Load() {
+			qrs.recordShutdownDrop(req)
+		}
 		logger.Error(
 			"Exporting failed. No more retries left. Dropping data.",
 			zap.Error(err),
@@ -185,14 +346,58 @@ func (qrs *queuedRetrySender) onTemporaryFailure(logger *zap.Logger, req interna
 	return err
 }
 
+// onPermanentFailure keeps the request in the dead letter queue if it is enabled. The requests partially
+// accepted by the destination are not kept, since the items which were rejected are not identified.
+func (qrs *queuedRetrySender) onPermanentFailure(logger *zap.Logger, req internal.Request, err error) error {
+	if qrs.deadLetter == nil || errors.As(err, &consumererror.PartialError{}) {
+		return err
+	}
+	if _, replayed := deadLetterReplayTime(req.Context()); replayed {
+		return err
+	}
+
+	if dlErr := qrs.deadLetter.write(req); dlErr != nil {
+		logger.Error(
+			"Exporting failed. Dead letter queue did not accept the request. Dropping data.",
+			zap.Error(dlErr),
+			zap.Int("dropped_items", req.Count()),
+		)
+		return err
+	}
+	logger.Warn(
+		"Exporting failed. Kept data in the dead letter queue.",
+		zap.Error(err),
+		zap.Int("kept_items", req.Count()),
+	)
+	return err
+}
+
 // start is invoked during service startup.
 func (qrs *queuedRetrySender) start(ctx context.Context, host component.Host) error {
+	qrs.statusSender.start(host)
+
+	if err := qrs.deadLetter.start(host); err != nil {
+		return err
+	}
+
 	if err := qrs.initializePersistentQueue(ctx, host); err != nil {
 		return err
 	}
 
 	qrs.queue.StartConsumers(qrs.cfg.NumConsumers, func(item internal.Request) {
+		// The requests of a persistent queue are kept for the next start instead of being dropped.
+		if qrs.dropping.Load() && !qrs.requeuingEnabled {
+			qrs.recordShutdownDrop(item)
+			qrs.logger.Error(
+				"Dropping data because the sending queue was not drained before the shutdown deadline.",
+				zap.Int("dropped_items", item.Count()),
+			)
+			item.OnProcessingFinished()
+			return
+		}
+		qrs.inFlight.Add(1)
 		_ = qrs.consumerSender.send(item)
+		qrs.inFlight.Add(-1)
 		item.OnProcessingFinished()
 	})
 
@@ -215,8 +420,15 @@ func (qrs *queuedRetrySender) start(ctx context.Context, host component.Host) er
 	return nil
 }
 
-// shutdown is invoked during service shutdown.
-func (qrs *queuedRetrySender) shutdown() {
+// shutdown is invoked during service shutdown. If ctx has a drain timeout, the queue is drained with the
+// retries enabled until the timeout, or the deadline of ctx, after which the requests left in the queue are dropped.
+func (qrs *queuedRetrySender) shutdown(ctx context.Context) {
+	if timeout := exporter.DrainTimeoutFromContext(ctx); timeout > 0 && qrs.queue != nil {
+		drainCtx, cancel := context.WithTimeout(ctx, timeout)
+		qrs.drain(drainCtx)
+		cancel()
+	}
+
 	// Cleanup queue metrics reporting
 	if qrs.cfg.Enabled {
 		_ = globalInstruments.queueSize.UpsertEntry(func() int64 {
@@ -234,6 +446,30 @@ func (qrs *queuedRetrySender) shutdown() {
 	}
 }
 
+// drain waits until the queue is empty and no request is being sent, or until ctx is done.
+func (qrs *queuedRetrySender) drain(ctx context.Context) {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for qrs.queue.Size() > 0 || qrs.inFlight.Load() > 0 {
+		select {
+		case <-ctx.Done():
+			qrs.dropping.Store(true)
+			qrs.logger.Warn(
+				"Sending queue not drained before the shutdown deadline, dropping the remaining data.",
+				zap.Int("queue_size", qrs.queue.Size()),
+			)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (qrs *queuedRetrySender) recordShutdownDrop(req internal.Request) {
+	if qrs.shutdownDropped != nil {
+		qrs.shutdownDropped.Inc(int64(req.Count()))
+	}
+}
+
 // RetrySettings defines configuration for retrying batches in case of export failure.
 // The current supported strategy is exponential backoff.
 type RetrySettings struct {
@@ -252,6 +488,33 @@ type RetrySettings struct {
 	// MaxElapsedTime is the maximum amount of time (including retries) spent trying to send a request/batch.
 	// Once this value is reached, the data is discarded.
 	MaxElapsedTime time.Duration `mapstructure:"max_elapsed_time"`
+	// FullJitter if true, waits a random duration between 0 and the backoff interval before retrying,
+	// instead of randomizing the interval with RandomizationFactor.
+	FullJitter bool `mapstructure:"full_jitter"`
+	// Budget if not nil, limits the number of retries relative to the number of requests, so that
+	// a failing destination does not receive much more requests than usual.
+	Budget *RetryBudgetSettings `mapstructure:"budget"`
+	// Policies defines how each class of errors is handled.
+	Policies RetryPolicies `mapstructure:"policies"`
+}
+
+// Validate checks if the RetrySettings configuration is valid
+func (rCfg *RetrySettings) Validate() error {
+	if !rCfg.Enabled {
+		return nil
+	}
+
+	for _, p := range []RetryPolicy{rCfg.Policies.Retryable, rCfg.Policies.Throttled, rCfg.Policies.Permanent} {
+		if err := p.validate(); err != nil {
+			return err
+		}
+	}
+
+	if rCfg.Budget != nil {
+		return rCfg.Budget.validate()
+	}
+
+	return nil
 }
 
 // NewDefaultRetrySettings returns the default settings for RetrySettings.
@@ -305,7 +568,7 @@ func (qrs *queuedRetrySender) send(req internal.Request) error {
 	span := trace.SpanFromContext(req.Context())
 	if !qrs.queue.Produce(req) {
 		qrs.logger.Error(
-			"Dropping data because sending_queue is full. Try increasing queue_size.",
+			"Dropping data because sending_queue is full. Try increasing queue_size, max_bytes or partition_queue_size.",
 			zap.Int("dropped_items", req.Count()),
 		)
 		span.AddEvent("Dropped item, sending_queue is full.", trace.WithAttributes(qrs.traceAttribute))
@@ -330,10 +593,11 @@ func (t throttleRetry) Unwrap() error {
 	return t.err
 }
 
-// NewThrottleRetry creates a new throttle retry error.
+// NewThrottleRetry creates a new throttle retry error. The delay is also reported by consumererror.RetryAfter,
+// so that it reaches the receivers when the error is returned to them.
 func NewThrottleRetry(err error, delay time.Duration) error {
 	return throttleRetry{
-		err:   err,
+		err:   consumererror.NewRetryAfter(err, delay),
 		delay: delay,
 	}
 }
@@ -343,30 +607,40 @@ type onRequestHandlingFinishedFunc func(*zap.Logger, internal.Request, error) er
 type retrySender struct {
 	traceAttribute     attribute.KeyValue
 	cfg                RetrySettings
+	budget             *retryBudget
+	throttle           *throttle
 	nextSender         requestSender
 	stopCh             chan struct{}
 	logger             *zap.Logger
 	onTemporaryFailure onRequestHandlingFinishedFunc
+	onPermanentFailure onRequestHandlingFinishedFunc
 }
 
 // send implements the requestSender interface
 func (rs *retrySender) send(req internal.Request) error {
 	if !rs.cfg.Enabled {
-		err := rs.nextSender.send(req)
+		err := rs.sendThrottled(req)
 		if err != nil {
 			rs.logger.Error(
 				"Exporting failed. Try enabling retry_on_failure config option to retry on retryable errors",
 				zap.Error(err),
 			)
+			if consumererror.IsPermanent(err) {
+				return rs.onPermanentFailure(rs.logger, req, err)
+			}
 		}
 		return err
 	}
 
+	randomizationFactor := rs.cfg.RandomizationFactor
+	if rs.cfg.FullJitter {
+		randomizationFactor = 0
+	}
 	// Do not use NewExponentialBackOff since it calls Reset and the code here must
 	// call Reset after changing the InitialInterval (this saves an unnecessary call to Now).
 	expBackoff := backoff.ExponentialBackOff{
 		InitialInterval:     rs.cfg.InitialInterval,
-		RandomizationFactor: rs.cfg.RandomizationFactor,
+		RandomizationFactor: randomizationFactor,
 		Multiplier:          rs.cfg.Multiplier,
 		MaxInterval:         rs.cfg.MaxInterval,
 		MaxElapsedTime:      rs.cfg.MaxElapsedTime,
@@ -374,6 +648,7 @@ func (rs *retrySender) send(req internal.Request) error {
 		Clock:               backoff.SystemClock,
 	}
 	expBackoff.Reset()
+	rs.budget.deposit()
 	span := trace.SpanFromContext(req.Context())
 	retryNum := int64(0)
 	for {
@@ -381,19 +656,31 @@ func (rs *retrySender) send(req internal.Request) error {
 			"Sending request.",
 			trace.WithAttributes(rs.traceAttribute, attribute.Int64("retry_num", retryNum)))
 
-		err := rs.nextSender.send(req)
+		err := rs.sendThrottled(req)
 		if err == nil {
 			return nil
 		}
 
-		// Immediately drop data on permanent errors.
-		if consumererror.IsPermanent(err) {
+		// The destination may ask to retry later, either with NewThrottleRetry or consumererror.NewRetryAfter.
+		throttleDelay, isThrottle := consumererror.RetryAfter(err)
+
+		// Immediately drop data on permanent errors, or on the errors which must not be retried.
+		if rs.policy(err, isThrottle) == RetryPolicyDrop {
+			droppedItems := req.Count()
+			// Only the rejected items are lost on partial successes.
+			partialErr := consumererror.PartialError{}
+			if errors.As(err, &partialErr) {
+				droppedItems = partialErr.Rejected()
+			}
 			rs.logger.Error(
 				"Exporting failed. The error is not retryable. Dropping data.",
 				zap.Error(err),
-				zap.Int("dropped_items", req.Count()),
+				zap.Int("dropped_items", droppedItems),
 			)
-			return err
+			if !consumererror.IsPermanent(err) {
+				err = consumererror.NewPermanent(err)
+			}
+			return rs.onPermanentFailure(rs.logger, req, err)
 		}
 
 		// Give the request a chance to extract signal data to retry if only some data
@@ -407,10 +694,16 @@ func (rs *retrySender) send(req internal.Request) error {
 			return rs.onTemporaryFailure(rs.logger, req, err)
 		}
 
-		throttleErr := throttleRetry{}
-		isThrottle := errors.As(err, &throttleErr)
+		if rs.cfg.FullJitter {
+			// #nosec G404 -- the jitter does not need a cryptographically secure random number
+			backoffDelay = time.Duration(rand.Int63n(int64(backoffDelay) + 1))
+		}
 		if isThrottle {
-			backoffDelay = max(backoffDelay, throttleErr.delay)
+			backoffDelay = max(backoffDelay, throttleDelay)
+		}
+
+		if !rs.budget.tryWithdraw() {
+			return rs.onTemporaryFailure(rs.logger, req, fmt.Errorf("retry budget exhausted: %w", err))
 		}
 
 		backoffDelayStr := backoffDelay.String()
@@ -438,6 +731,46 @@ func (rs *retrySender) send(req internal.Request) error {
 	}
 }
 
+// sendThrottled sends req once the sending is no longer paused, and pauses the sending of the
+// following requests if the destination asks to retry later.
+func (rs *retrySender) sendThrottled(req internal.Request) error {
+	if rs.throttle == nil {
+		return rs.nextSender.send(req)
+	}
+	if err := rs.throttle.wait(req.Context(), rs.stopCh); err != nil {
+		return fmt.Errorf("Request is cancelled or timed out while throttled %w", err)
+	}
+	err := rs.nextSender.send(req)
+	if delay, ok := consumererror.RetryAfter(err); ok && rs.policy(err, true) != RetryPolicyDrop {
+		rs.throttle.pause(delay)
+	}
+	return err
+}
+
+// policy returns the policy applied to err.
+func (rs *retrySender) policy(err error, isThrottle bool) RetryPolicy {
+	switch {
+	case errors.As(err, &consumererror.PartialError{}):
+		// The rejected items are not identified, so retrying would duplicate the accepted ones.
+		return RetryPolicyDrop
+	case consumererror.IsPermanent(err):
+		if rs.cfg.Policies.Permanent == "" {
+			return RetryPolicyDrop
+		}
+		return rs.cfg.Policies.Permanent
+	case isThrottle:
+		if rs.cfg.Policies.Throttled == "" {
+			return RetryPolicyRetry
+		}
+		return rs.cfg.Policies.Throttled
+	default:
+		if rs.cfg.Policies.Retryable == "" {
+			return RetryPolicyRetry
+		}
+		return rs.cfg.Policies.Retryable
+	}
+}
+
 // max returns the larger of x or y.
 func max(x, y time.Duration) time.Duration {
 	if x < y {
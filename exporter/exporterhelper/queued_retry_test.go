@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -15,13 +16,17 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric"
 	"go.opencensus.io/metric/metricdata"
 	"go.opencensus.io/metric/metricproducer"
 	"go.opencensus.io/tag"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/extension/extensiontest"
@@ -147,6 +152,49 @@ func TestQueuedRetry_StopWhileWaiting(t *testing.T) {
 	// require.Zero(t, be.qrSender.queue.OtlpProtoSize())
 }
 
+func TestQueuedRetry_DrainOnShutdown(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = 10 * time.Millisecond
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	mockR := newMockRequest(context.Background(), 2, errors.New("transient error"))
+	ocs.run(func() {
+		require.NoError(t, be.sender.send(mockR))
+	})
+
+	// The retries are still enabled while the queue is drained.
+	assert.NoError(t, be.Shutdown(exporter.ContextWithDrainTimeout(context.Background(), 10*time.Second)))
+	mockR.checkNumRequests(t, 2)
+	ocs.checkSendItemsCount(t, 2)
+	ocs.checkDroppedItemsCount(t, 0)
+}
+
+func TestQueuedRetry_DropOnShutdownDeadline(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = time.Minute
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), component.DataTypeTraces, nopRequestUnmarshaler())
+	require.NoError(t, err)
+	insts := newInstruments(metric.NewRegistry())
+	be.qrSender.shutdownDropped = insts.shutdownDroppedEntry(defaultID, component.DataTypeTraces)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The first request waits to be retried while the second one is queued.
+	require.NoError(t, be.sender.send(newErrorRequest(context.Background())))
+	require.NoError(t, be.sender.send(newErrorRequest(context.Background())))
+
+	assert.NoError(t, be.Shutdown(exporter.ContextWithDrainTimeout(context.Background(), 50*time.Millisecond)))
+	assert.Zero(t, be.qrSender.queue.Size())
+	assert.True(t, checkValueForProducer(t, insts.registry, defaultExporterTags, 14, "exporter/shutdown_dropped_spans"))
+}
+
 func TestQueuedRetry_DoNotPreserveCancellation(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	qCfg.NumConsumers = 1
@@ -255,6 +303,43 @@ func TestQueuedRetry_ThrottleError(t *testing.T) {
 	require.Zero(t, be.qrSender.queue.Size())
 }
 
+func TestQueuedRetry_RetryAfterError(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = 10 * time.Millisecond
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	mockR := newMockRequest(context.Background(), 2, consumererror.NewRetryAfter(errors.New("throttle error"), 100*time.Millisecond))
+	start := time.Now()
+	ocs.run(func() {
+		require.NoError(t, be.sender.send(mockR))
+	})
+	ocs.awaitAsyncProcessing()
+
+	// The delay asked by the destination is honored like the delay of NewThrottleRetry.
+	assert.True(t, 100*time.Millisecond < time.Since(start))
+
+	mockR.checkNumRequests(t, 2)
+	ocs.checkSendItemsCount(t, 2)
+	ocs.checkDroppedItemsCount(t, 0)
+}
+
+func TestThrottleRetryReportsRetryAfter(t *testing.T) {
+	err := NewThrottleRetry(errors.New("throttle error"), time.Minute)
+	assert.EqualError(t, err, "Throttle (1m0s), error: throttle error")
+	delay, ok := consumererror.RetryAfter(err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, delay)
+}
+
 func TestQueuedRetry_RetryOnError(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	qCfg.NumConsumers = 1
@@ -284,6 +369,125 @@ func TestQueuedRetry_RetryOnError(t *testing.T) {
 	require.Zero(t, be.qrSender.queue.Size())
 }
 
+func TestQueuedRetry_RetryPolicies(t *testing.T) {
+	tests := []struct {
+		name         string
+		policies     RetryPolicies
+		err          error
+		wantRequests int
+		wantSent     int
+		wantDropped  int
+	}{
+		{
+			name:         "drop_retryable",
+			policies:     RetryPolicies{Retryable: RetryPolicyDrop},
+			err:          errors.New("transient error"),
+			wantRequests: 1,
+			wantDropped:  2,
+		},
+		{
+			name:         "drop_throttled",
+			policies:     RetryPolicies{Throttled: RetryPolicyDrop},
+			err:          NewThrottleRetry(errors.New("throttle error"), time.Millisecond),
+			wantRequests: 1,
+			wantDropped:  2,
+		},
+		{
+			name:         "retry_permanent",
+			policies:     RetryPolicies{Permanent: RetryPolicyRetry},
+			err:          consumererror.NewPermanent(errors.New("bad data")),
+			wantRequests: 2,
+			wantSent:     2,
+		},
+		{
+			name:         "partial_never_retried",
+			policies:     RetryPolicies{Permanent: RetryPolicyRetry},
+			err:          consumererror.NewPartial(errors.New("bad item"), 1),
+			wantRequests: 1,
+			wantDropped:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qCfg := NewDefaultQueueSettings()
+			qCfg.NumConsumers = 1
+			rCfg := NewDefaultRetrySettings()
+			rCfg.InitialInterval = 0
+			rCfg.Policies = tt.policies
+			be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+			require.NoError(t, err)
+			ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+			be.qrSender.consumerSender = ocs
+			require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() {
+				assert.NoError(t, be.Shutdown(context.Background()))
+			})
+
+			mockR := newMockRequest(context.Background(), 2, tt.err)
+			ocs.run(func() {
+				require.NoError(t, be.sender.send(mockR))
+			})
+			ocs.awaitAsyncProcessing()
+
+			mockR.checkNumRequests(t, tt.wantRequests)
+			ocs.checkSendItemsCount(t, tt.wantSent)
+			ocs.checkDroppedItemsCount(t, tt.wantDropped)
+		})
+	}
+}
+
+func TestQueuedRetry_RetryBudgetExhausted(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = 10 * time.Millisecond
+	rCfg.FullJitter = true
+	rCfg.Budget = &RetryBudgetSettings{MaxBurst: 1}
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// The first request uses the only retry of the budget, the second one is dropped.
+	first := newMockRequest(context.Background(), 2, errors.New("transient error"))
+	second := newMockRequest(context.Background(), 3, errors.New("transient error"))
+	ocs.run(func() {
+		require.NoError(t, be.sender.send(first))
+	})
+	ocs.run(func() {
+		require.NoError(t, be.sender.send(second))
+	})
+	ocs.awaitAsyncProcessing()
+
+	first.checkNumRequests(t, 2)
+	second.checkNumRequests(t, 1)
+	ocs.checkSendItemsCount(t, 2)
+	ocs.checkDroppedItemsCount(t, 3)
+}
+
+func TestRetrySettings_Validate(t *testing.T) {
+	rCfg := NewDefaultRetrySettings()
+	assert.NoError(t, rCfg.Validate())
+
+	rCfg.Policies.Throttled = "ignore"
+	assert.EqualError(t, rCfg.Validate(), `unknown retry policy "ignore", must be "retry" or "drop"`)
+	rCfg.Policies.Throttled = RetryPolicyDrop
+
+	rCfg.Budget = &RetryBudgetSettings{MaxRetryRatio: -1}
+	assert.EqualError(t, rCfg.Validate(), "retry budget max retry ratio must not be negative")
+
+	rCfg.Budget = &RetryBudgetSettings{MaxRetryRatio: 0.1, MaxBurst: -1}
+	assert.EqualError(t, rCfg.Validate(), "retry budget max burst must not be negative")
+
+	// Confirm Validate doesn't return error with invalid config when feature is disabled
+	rCfg.Enabled = false
+	assert.NoError(t, rCfg.Validate())
+}
+
 func TestQueuedRetry_DropOnFull(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	qCfg.QueueSize = 0
@@ -301,6 +505,51 @@ func TestQueuedRetry_DropOnFull(t *testing.T) {
 	})
 }
 
+func TestQueuedRetry_DropOnMaxBytes(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 0 // to make every request go straight to the queue
+	qCfg.MaxBytes = 10
+	rCfg := NewDefaultRetrySettings()
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// the size of the mock requests is their count
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 6, nil)))
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 4, nil)))
+	assert.ErrorIs(t, be.sender.send(newMockRequest(context.Background(), 1, nil)), errSendingQueueIsFull)
+	assert.Equal(t, 2, be.qrSender.queue.Size())
+}
+
+func TestQueuedRetry_PartitionQueueSize(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 0 // to make every request go straight to the queue
+	qCfg.MetadataKeys = []string{"tenant"}
+	qCfg.PartitionQueueSize = 2
+	rCfg := NewDefaultRetrySettings()
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	tenantCtx := func(tenant string) context.Context {
+		return client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"tenant": {tenant}}),
+		})
+	}
+	require.NoError(t, be.sender.send(newMockRequest(tenantCtx("a"), 1, nil)))
+	require.NoError(t, be.sender.send(newMockRequest(tenantCtx("a"), 1, nil)))
+	assert.ErrorIs(t, be.sender.send(newMockRequest(tenantCtx("a"), 1, nil)), errSendingQueueIsFull)
+	// the other partitions are not affected
+	require.NoError(t, be.sender.send(newMockRequest(tenantCtx("b"), 1, nil)))
+	assert.Equal(t, 3, be.qrSender.queue.Size())
+}
+
 func TestQueuedRetryHappyPath(t *testing.T) {
 	tt, err := obsreporttest.SetupTelemetry(defaultID)
 	require.NoError(t, err)
@@ -340,6 +589,29 @@ func TestQueuedRetryHappyPath(t *testing.T) {
 	ocs.checkDroppedItemsCount(t, 0)
 }
 
+func TestQueuedRetryAdaptiveConsumers(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.AdaptiveConsumers = &AdaptiveConsumersSettings{Interval: time.Millisecond}
+	rCfg := NewDefaultRetrySettings()
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	for i := 0; i < 10; i++ {
+		ocs.run(func() {
+			require.NoError(t, be.sender.send(newMockRequest(context.Background(), 2, nil)))
+		})
+	}
+	ocs.awaitAsyncProcessing()
+	ocs.checkSendItemsCount(t, 20)
+	ocs.checkDroppedItemsCount(t, 0)
+}
+
 func TestQueuedRetry_QueueMetricsReported(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	qCfg.NumConsumers = 0 // to make every request go straight to the queue
@@ -378,6 +650,32 @@ func TestQueueSettings_Validate(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	assert.NoError(t, qCfg.Validate())
 
+	qCfg.AdaptiveConsumers = &AdaptiveConsumersSettings{MinConsumers: qCfg.NumConsumers + 1}
+	assert.EqualError(t, qCfg.Validate(), "adaptive min consumers must be between 0 and num consumers")
+
+	qCfg.AdaptiveConsumers = &AdaptiveConsumersSettings{TargetLatency: -time.Second}
+	assert.EqualError(t, qCfg.Validate(), "adaptive interval and target latency must not be negative")
+	qCfg.AdaptiveConsumers = nil
+
+	qCfg.MetadataKeys = []string{"Tenant", "tenant"}
+	assert.EqualError(t, qCfg.Validate(), `duplicate entry in queue metadata_keys: "tenant" (case-insensitive)`)
+	qCfg.MetadataKeys = []string{"tenant"}
+	qCfg.File = &FileQueueSettings{Directory: "dir"}
+	assert.EqualError(t, qCfg.Validate(), "queue metadata_keys cannot be used with a persistent queue")
+	qCfg.File = nil
+	storageID := component.NewID("file_storage")
+	qCfg.StorageID = &storageID
+	assert.EqualError(t, qCfg.Validate(), "queue metadata_keys cannot be used with a persistent queue")
+	qCfg.StorageID = nil
+	qCfg.MetadataKeys = nil
+
+	qCfg.PartitionQueueSize = qCfg.QueueSize + 1
+	assert.EqualError(t, qCfg.Validate(), "partition queue size must be between 0 and queue size")
+	qCfg.PartitionQueueSize = 0
+
+	qCfg.MaxBytes = -1
+	assert.EqualError(t, qCfg.Validate(), "queue max bytes must not be negative")
+
 	qCfg.QueueSize = 0
 	assert.EqualError(t, qCfg.Validate(), "queue size must be positive")
 
@@ -386,6 +684,43 @@ func TestQueueSettings_Validate(t *testing.T) {
 	assert.NoError(t, qCfg.Validate())
 }
 
+func TestQueueSettings_ValidateFile(t *testing.T) {
+	storageID := component.NewID("file_storage")
+	tests := []struct {
+		name      string
+		storageID *component.ID
+		file      FileQueueSettings
+		err       string
+	}{
+		{
+			name: "valid",
+			file: FileQueueSettings{Directory: "dir", Fsync: true},
+		},
+		{
+			name:      "storage",
+			storageID: &storageID,
+			file:      FileQueueSettings{Directory: "dir"},
+			err:       "storage and file cannot be both set",
+		},
+		{
+			name: "directory",
+			err:  "file directory must be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qCfg := NewDefaultQueueSettings()
+			qCfg.StorageID = tt.storageID
+			qCfg.File = &tt.file
+			if tt.err == "" {
+				assert.NoError(t, qCfg.Validate())
+			} else {
+				assert.EqualError(t, qCfg.Validate(), tt.err)
+			}
+		})
+	}
+}
+
 func TestGetRetrySettings(t *testing.T) {
 	getStorageClientError := errors.New("unable to create storage client")
 	testCases := []struct {
@@ -571,6 +906,50 @@ func TestQueuedRetryPersistenceEnabledStorageError(t *testing.T) {
 	require.Error(t, be.Start(context.Background(), host), "could not get storage client")
 }
 
+func TestQueuedRetryPersistenceFile(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(defaultID)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	dir := t.TempDir()
+	qCfg := NewDefaultQueueSettings()
+	qCfg.File = &FileQueueSettings{Directory: dir}
+	rCfg := NewDefaultRetrySettings()
+	set := tt.ToExporterCreateSettings()
+	be, err := newBaseExporter(set, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+
+	// no storage extension is needed
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, be.Shutdown(context.Background()))
+	assert.FileExists(t, filepath.Join(dir, "exporter_test_"))
+
+	// the queued requests are kept across restarts
+	qCfg.NumConsumers = 0
+	be, err = newBaseExporter(set, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, be.send(newTracesRequest(context.Background(), testdata.GenerateTraces(2), nopTracePusher())))
+	require.NoError(t, be.Shutdown(context.Background()))
+	qCfg.NumConsumers = 1
+	sink := new(consumertest.TracesSink)
+	be, err = newBaseExporter(set, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", newTraceRequestUnmarshalerFunc(sink.ConsumeTraces))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool { return sink.SpanCount() == 2 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	// the directory must exist
+	qCfg.File.Directory = filepath.Join(dir, "missing")
+	be, err = newBaseExporter(set, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	require.Error(t, be.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	assert.Equal(t, "otlp_a_b.c-d", sanitizeFileName("otlp/a b.c-d"))
+}
+
 func TestQueuedRetryPersistentEnabled_shutdown_dataIsRequeued(t *testing.T) {
 
 	produceCounter := &atomic.Uint32{}
@@ -596,8 +975,8 @@ func TestQueuedRetryPersistentEnabled_shutdown_dataIsRequeued(t *testing.T) {
 	be.qrSender.requeuingEnabled = true
 
 	// replace nextSender inside retrySender to always return error so it doesn't exit send loop
-	castedSender, ok := be.qrSender.consumerSender.(*retrySender)
-	require.True(t, ok, "consumerSender should be a retrySender type")
+	castedSender, ok := be.qrSender.statusSender.nextSender.(*retrySender)
+	require.True(t, ok, "statusSender should send to a retrySender")
 	castedSender.nextSender = &errorRequestSender{
 		errToReturn: errors.New("some error"),
 	}
@@ -637,6 +1016,10 @@ func (mer *mockErrorRequest) Count() int {
 	return 7
 }
 
+func (mer *mockErrorRequest) BytesSize() int {
+	return 0
+}
+
 func newErrorRequest(ctx context.Context) internal.Request {
 	return &mockErrorRequest{
 		baseRequest: baseRequest{ctx: ctx},
@@ -688,6 +1071,10 @@ func (m *mockRequest) Count() int {
 	return m.cnt
 }
 
+func (m *mockRequest) BytesSize() int {
+	return m.cnt
+}
+
 func newMockRequest(ctx context.Context, cnt int, consumeError error) *mockRequest {
 	return &mockRequest{
 		baseRequest:  baseRequest{ctx: ctx},
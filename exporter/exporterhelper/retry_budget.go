@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const defaultRetryBudgetMaxBurst = 10
+
+// RetryPolicy defines how the requests failing with a class of errors are handled.
+type RetryPolicy string
+
+const (
+	// RetryPolicyRetry retries the requests with an exponential backoff.
+	RetryPolicyRetry RetryPolicy = "retry"
+	// RetryPolicyDrop drops the requests without retrying them.
+	RetryPolicyDrop RetryPolicy = "drop"
+)
+
+func (p RetryPolicy) validate() error {
+	switch p {
+	case "", RetryPolicyRetry, RetryPolicyDrop:
+		return nil
+	}
+	return fmt.Errorf("unknown retry policy %q, must be %q or %q", p, RetryPolicyRetry, RetryPolicyDrop)
+}
+
+// RetryPolicies defines the policy applied to each class of errors.
+type RetryPolicies struct {
+	// Retryable is the policy for the errors which are neither permanent nor throttling, "retry" if not set.
+	Retryable RetryPolicy `mapstructure:"retryable"`
+	// Throttled is the policy for the errors asking to retry after a delay, "retry" if not set.
+	Throttled RetryPolicy `mapstructure:"throttled"`
+	// Permanent is the policy for the permanent errors, "drop" if not set.
+	Permanent RetryPolicy `mapstructure:"permanent"`
+}
+
+// RetryBudgetSettings defines configuration for limiting the number of retries relative to the number of requests.
+type RetryBudgetSettings struct {
+	// MaxRetryRatio is the maximum ratio between the number of retries and the number of requests.
+	MaxRetryRatio float64 `mapstructure:"max_retry_ratio"`
+	// MaxBurst is the number of retries which can be made in a row, before any request is sent, 10 if not set.
+	MaxBurst int `mapstructure:"max_burst"`
+}
+
+// retryBudget is a token bucket in which each request deposits MaxRetryRatio tokens,
+// and from which each retry withdraws one token.
+type retryBudget struct {
+	mu        sync.Mutex
+	ratio     float64
+	maxTokens float64
+	tokens    float64
+}
+
+func newRetryBudget(cfg *RetryBudgetSettings) *retryBudget {
+	if cfg == nil {
+		return nil
+	}
+	maxBurst := cfg.MaxBurst
+	if maxBurst == 0 {
+		maxBurst = defaultRetryBudgetMaxBurst
+	}
+	return &retryBudget{
+		ratio:     cfg.MaxRetryRatio,
+		maxTokens: float64(maxBurst),
+		tokens:    float64(maxBurst),
+	}
+}
+
+// deposit records a request.
+func (b *retryBudget) deposit() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens += b.ratio
+	if b.tokens > b.maxTokens {
+		b.tokens = b.maxTokens
+	}
+}
+
+// tryWithdraw records a retry, and returns false if the budget is exhausted.
+func (b *retryBudget) tryWithdraw() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (cfg *RetryBudgetSettings) validate() error {
+	if cfg.MaxRetryRatio < 0 {
+		return errors.New("retry budget max retry ratio must not be negative")
+	}
+	if cfg.MaxBurst < 0 {
+		return errors.New("retry budget max burst must not be negative")
+	}
+	return nil
+}
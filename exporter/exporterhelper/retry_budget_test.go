@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryBudget(t *testing.T) {
+	b := newRetryBudget(&RetryBudgetSettings{MaxRetryRatio: 0.5, MaxBurst: 2})
+
+	// the burst is available before any request
+	assert.True(t, b.tryWithdraw())
+	assert.True(t, b.tryWithdraw())
+	assert.False(t, b.tryWithdraw())
+
+	// two requests allow one retry
+	b.deposit()
+	assert.False(t, b.tryWithdraw())
+	b.deposit()
+	assert.True(t, b.tryWithdraw())
+
+	// the tokens do not exceed the burst
+	for i := 0; i < 10; i++ {
+		b.deposit()
+	}
+	assert.True(t, b.tryWithdraw())
+	assert.True(t, b.tryWithdraw())
+	assert.False(t, b.tryWithdraw())
+}
+
+func TestRetryBudgetDefault(t *testing.T) {
+	b := newRetryBudget(&RetryBudgetSettings{})
+	for i := 0; i < defaultRetryBudgetMaxBurst; i++ {
+		assert.True(t, b.tryWithdraw())
+	}
+	assert.False(t, b.tryWithdraw())
+}
+
+func TestRetryBudgetDisabled(t *testing.T) {
+	b := newRetryBudget(nil)
+	b.deposit()
+	assert.True(t, b.tryWithdraw())
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+)
+
+// statusSender reports the status of the exporter to the host from the outcome of the requests:
+// StatusRecoverableError when a request fails after all its retries, and StatusOK when the
+// requests succeed again. Requests rejected with a permanent error do not change the status,
+// the destination is reachable but refused the data.
+type statusSender struct {
+	nextSender requestSender
+	host       atomic.Pointer[hostRef]
+	failing    atomic.Bool
+}
+
+type hostRef struct {
+	component.Host
+}
+
+func (ss *statusSender) start(host component.Host) {
+	ss.host.Store(&hostRef{Host: host})
+}
+
+func (ss *statusSender) send(req internal.Request) error {
+	err := ss.nextSender.send(req)
+	switch {
+	case err == nil:
+		if ss.failing.CompareAndSwap(true, false) {
+			ss.report(component.NewStatusEvent(component.StatusOK, nil))
+		}
+	case consumererror.IsPermanent(err):
+	default:
+		ss.failing.Store(true)
+		ss.report(component.NewStatusEvent(component.StatusRecoverableError, err))
+	}
+	return err
+}
+
+func (ss *statusSender) report(event *component.StatusEvent) {
+	if ref := ss.host.Load(); ref != nil {
+		component.ReportStatus(ref.Host, event)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+)
+
+type statusHost struct {
+	component.Host
+	events []*component.StatusEvent
+}
+
+func (h *statusHost) ReportStatus(event *component.StatusEvent) {
+	h.events = append(h.events, event)
+}
+
+type funcRequestSender func() error
+
+func (f funcRequestSender) send(internal.Request) error {
+	return f()
+}
+
+func TestStatusSender(t *testing.T) {
+	var errToReturn error
+	ss := &statusSender{nextSender: funcRequestSender(func() error { return errToReturn })}
+	req := newMockRequest(context.Background(), 1, nil)
+
+	// The statuses are only reported once started.
+	errToReturn = errors.New("unavailable")
+	assert.Error(t, ss.send(req))
+
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	ss.start(host)
+	errToReturn = nil
+	assert.NoError(t, ss.send(req))
+	errToReturn = consumererror.NewPermanent(errors.New("bad data"))
+	assert.Error(t, ss.send(req))
+	errToReturn = errors.New("still unavailable")
+	assert.Error(t, ss.send(req))
+	assert.Error(t, ss.send(req))
+	errToReturn = nil
+	assert.NoError(t, ss.send(req))
+	assert.NoError(t, ss.send(req))
+
+	var statuses []component.Status
+	for _, ev := range host.events {
+		statuses = append(statuses, ev.Status())
+	}
+	assert.Equal(t, []component.Status{
+		component.StatusOK,
+		component.StatusRecoverableError,
+		component.StatusRecoverableError,
+		component.StatusOK,
+	}, statuses)
+	assert.EqualError(t, host.events[1].Err(), "still unavailable")
+}
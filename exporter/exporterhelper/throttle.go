@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.opencensus.io/metric"
+)
+
+// throttle pauses the sending of all the requests of an exporter signal while the destination
+// asks to retry later, instead of letting every queue consumer hit it again.
+type throttle struct {
+	// until is the time, in Unix nanoseconds, until which the sending is paused.
+	until atomic.Int64
+	// throttledTime counts the milliseconds during which the sending was paused.
+	throttledTime *metric.Int64CumulativeEntry
+}
+
+// pause pauses the sending for delay, unless it is already paused for longer.
+func (t *throttle) pause(delay time.Duration) {
+	if delay <= 0 {
+		return
+	}
+	now := time.Now().UnixNano()
+	until := now + int64(delay)
+	for {
+		current := t.until.Load()
+		if current >= until {
+			return
+		}
+		if !t.until.CompareAndSwap(current, until) {
+			continue
+		}
+		if t.throttledTime != nil {
+			if current < now {
+				current = now
+			}
+			t.throttledTime.Inc(time.Duration(until - current).Milliseconds())
+		}
+		return
+	}
+}
+
+// wait blocks until the sending is no longer paused, or until stopCh is closed so that the requests
+// are sent once at shutdown. It returns ctx.Err() if ctx is done before.
+func (t *throttle) wait(ctx context.Context, stopCh <-chan struct{}) error {
+	for {
+		delay := time.Until(time.Unix(0, t.until.Load()))
+		if delay <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-stopCh:
+			timer.Stop()
+			return nil
+		case <-timer.C:
+			// The pause may have been extended in the meantime.
+		}
+	}
+}
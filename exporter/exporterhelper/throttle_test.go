@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric"
+	"go.opencensus.io/metric/metricdata"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+)
+
+func TestThrottlePause(t *testing.T) {
+	insts := newInstruments(metric.NewRegistry())
+	th := &throttle{throttledTime: insts.throttledTimeEntry(defaultID, component.DataTypeLogs)}
+
+	th.pause(time.Second)
+	assert.EqualValues(t, 1000, throttledTime(t, insts))
+	until := th.until.Load()
+
+	// A shorter pause does not shorten the current one.
+	th.pause(time.Millisecond)
+	th.pause(0)
+	assert.Equal(t, until, th.until.Load())
+	assert.EqualValues(t, 1000, throttledTime(t, insts))
+
+	// A longer pause only counts the time it adds.
+	th.pause(3 * time.Second)
+	assert.Greater(t, th.until.Load(), until)
+	assert.InDelta(t, 3000, throttledTime(t, insts), 100)
+}
+
+func TestThrottleWait(t *testing.T) {
+	th := &throttle{}
+	assert.NoError(t, th.wait(context.Background(), nil))
+
+	th.pause(50 * time.Millisecond)
+	start := time.Now()
+	assert.NoError(t, th.wait(context.Background(), nil))
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	// The requests are cancelled while they wait.
+	th.pause(time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, th.wait(ctx, nil), context.Canceled)
+
+	// The requests are sent once at shutdown.
+	stopCh := make(chan struct{})
+	close(stopCh)
+	assert.NoError(t, th.wait(context.Background(), stopCh))
+}
+
+type senderFunc func(req internal.Request) error
+
+func (f senderFunc) send(req internal.Request) error {
+	return f(req)
+}
+
+func TestRetrySenderThrottlesFollowingRequests(t *testing.T) {
+	var sent []time.Time
+	rs := &retrySender{
+		cfg:      RetrySettings{Enabled: false},
+		throttle: &throttle{},
+		nextSender: senderFunc(func(req internal.Request) error {
+			sent = append(sent, time.Now())
+			if len(sent) == 1 {
+				return NewThrottleRetry(errors.New("throttled"), 100*time.Millisecond)
+			}
+			return nil
+		}),
+		stopCh: make(chan struct{}),
+		logger: zap.NewNop(),
+	}
+
+	assert.Error(t, rs.send(newMockRequest(context.Background(), 1, nil)))
+	require.NoError(t, rs.send(newMockRequest(context.Background(), 1, nil)))
+	require.Len(t, sent, 2)
+	assert.GreaterOrEqual(t, sent[1].Sub(sent[0]), 90*time.Millisecond)
+}
+
+func TestRetrySenderThrottleDropPolicy(t *testing.T) {
+	rs := &retrySender{
+		cfg:      RetrySettings{Enabled: true, Policies: RetryPolicies{Throttled: RetryPolicyDrop}},
+		throttle: &throttle{},
+		nextSender: senderFunc(func(req internal.Request) error {
+			return NewThrottleRetry(errors.New("throttled"), time.Hour)
+		}),
+		stopCh:             make(chan struct{}),
+		logger:             zap.NewNop(),
+		onPermanentFailure: func(_ *zap.Logger, _ internal.Request, err error) error { return err },
+	}
+
+	// The dropped requests do not pause the sending of the following ones.
+	assert.Error(t, rs.send(newMockRequest(context.Background(), 1, nil)))
+	assert.Zero(t, rs.throttle.until.Load())
+}
+
+// throttledTime returns the value of the exporter/throttled_time metric of the logs of the default exporter.
+func throttledTime(t *testing.T, insts *instruments) int64 {
+	for _, m := range insts.registry.Read() {
+		if m.Descriptor.Name != "exporter/throttled_time" {
+			continue
+		}
+		for _, ts := range m.TimeSeries {
+			if ts.LabelValues[0] == metricdata.NewLabelValue(defaultID.String()) && ts.LabelValues[1] == metricdata.NewLabelValue(string(component.DataTypeLogs)) {
+				return ts.Points[len(ts.Points)-1].Value.(int64)
+			}
+		}
+	}
+	require.Fail(t, "exporter/throttled_time not found")
+	return 0
+}
@@ -63,6 +63,46 @@ func (req *tracesRequest) Count() int {
 	return req.td.SpanCount()
 }
 
+func (req *tracesRequest) BytesSize() int {
+	return tracesMarshaler.TracesSize(req.td)
+}
+
+// tracesBatch accumulates the data of traces requests.
+type tracesBatch struct {
+	td     ptrace.Traces
+	count  int
+	pusher consumer.ConsumeTracesFunc
+}
+
+func newTracesBatch(pusher consumer.ConsumeTracesFunc) batch {
+	return &tracesBatch{
+		td:     ptrace.NewTraces(),
+		pusher: pusher,
+	}
+}
+
+func (b *tracesBatch) add(req internal.Request) {
+	r := req.(*tracesRequest)
+	b.count += r.Count()
+	r.td.ResourceSpans().MoveAndAppendTo(b.td.ResourceSpans())
+}
+
+func (b *tracesBatch) itemCount() int {
+	return b.count
+}
+
+func (b *tracesBatch) requests(ctx context.Context, maxSize int) []internal.Request {
+	var reqs []internal.Request
+	for maxSize > 0 && b.count > maxSize {
+		reqs = append(reqs, newTracesRequest(ctx, splitTraces(maxSize, b.td), b.pusher))
+		b.count -= maxSize
+	}
+	reqs = append(reqs, newTracesRequest(ctx, b.td, b.pusher))
+	b.td = ptrace.NewTraces()
+	b.count = 0
+	return reqs
+}
+
 type traceExporter struct {
 	*baseExporter
 	consumer.Traces
@@ -99,10 +139,11 @@ func NewTracesExporter(
 			nextSender: nextSender,
 		}
 	})
+	be.initBatchSender(set, bs, func() batch { return newTracesBatch(pusher) }, be.obsrep.recordTracesEnqueueFailure)
 
 	tc, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
 		req := newTracesRequest(ctx, td, pusher)
-		serr := be.sender.send(req)
+		serr := be.send(req)
 		if errors.Is(serr, errSendingQueueIsFull) {
 			be.obsrep.recordTracesEnqueueFailure(req.Context(), int64(req.Count()))
 		}
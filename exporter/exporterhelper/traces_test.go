@@ -43,6 +43,14 @@ func TestTracesRequest(t *testing.T) {
 	assert.EqualValues(t, newTracesRequest(context.Background(), ptrace.NewTraces(), nil), mr.OnError(traceErr))
 }
 
+func TestTracesRequestBytesSize(t *testing.T) {
+	td := testdata.GenerateTraces(1)
+	req := newTracesRequest(context.Background(), td, nil)
+	buf, err := tracesMarshaler.MarshalTraces(td)
+	require.NoError(t, err)
+	assert.Equal(t, len(buf), req.BytesSize())
+}
+
 func TestTracesExporter_InvalidName(t *testing.T) {
 	te, err := NewTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), nil, newTraceDataPusher(nil))
 	require.Nil(t, te)
@@ -118,6 +126,24 @@ func TestTracesExporter_WithRecordMetrics_ReturnError(t *testing.T) {
 	checkRecordedMetricsForTracesExporter(t, tt, te, want)
 }
 
+func TestTracesExporter_WithRecordLatency(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(fakeTracesExporterName)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	te, err := NewTracesExporter(context.Background(), tt.ToExporterCreateSettings(), &fakeTracesExporterConfig, newTraceDataPusher(nil))
+	require.NoError(t, err)
+	require.NoError(t, te.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	require.NoError(t, te.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+
+	te, err = NewTracesExporter(context.Background(), tt.ToExporterCreateSettings(), &fakeTracesExporterConfig, newTraceDataPusher(context.DeadlineExceeded))
+	require.NoError(t, err)
+	require.Error(t, te.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+
+	require.NoError(t, tt.CheckExporterLatency("none", 2))
+	require.NoError(t, tt.CheckExporterLatency("timeout", 1))
+}
+
 func TestTracesExporter_WithRecordEnqueueFailedMetrics(t *testing.T) {
 	tt, err := obsreporttest.SetupTelemetry(fakeTracesExporterName)
 	require.NoError(t, err)
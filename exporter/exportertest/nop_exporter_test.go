@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
@@ -41,6 +42,12 @@ func TestNewNopFactory(t *testing.T) {
 	assert.NoError(t, logs.Start(context.Background(), componenttest.NewNopHost()))
 	assert.NoError(t, logs.ConsumeLogs(context.Background(), plog.NewLogs()))
 	assert.NoError(t, logs.Shutdown(context.Background()))
+
+	profiles, err := factory.CreateProfilesExporter(context.Background(), NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	assert.NoError(t, profiles.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, profiles.ConsumeProfiles(context.Background(), pprofile.NewProfiles()))
+	assert.NoError(t, profiles.Shutdown(context.Background()))
 }
 
 func TestNewNopBuilder(t *testing.T) {
@@ -69,4 +76,10 @@ func TestNewNopBuilder(t *testing.T) {
 	bLogs, err := builder.CreateLogs(context.Background(), set)
 	require.NoError(t, err)
 	assert.IsType(t, logs, bLogs)
+
+	profiles, err := factory.CreateProfilesExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	bProfiles, err := builder.CreateProfiles(context.Background(), set)
+	require.NoError(t, err)
+	assert.IsType(t, profiles, bProfiles)
 }
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "go.opentelemetry.io/collector/exporter/fileexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configrotate"
+)
+
+const (
+	formatTypeJSON  = "json"
+	formatTypeProto = "proto"
+)
+
+// Config defines configuration for the file exporter.
+type Config struct {
+	// Path of the file to write to.
+	Path string `mapstructure:"path"`
+
+	// Format is the format of the data written to the file, json (default) writes one OTLP JSON
+	// document per line, proto writes OTLP protobuf messages prefixed by their size.
+	Format string `mapstructure:"format"`
+
+	// Rotation defines how the file is rotated once it reaches a maximum size.
+	Rotation configrotate.Config `mapstructure:"rotation"`
+
+	// Compression compresses every batch written to the file, gzip and zstd are supported.
+	// The compressed batches are prefixed by their size, whatever the format.
+	Compression configcompression.CompressionType `mapstructure:"compression"`
+
+	// FlushInterval is the interval at which the buffered data is written to the file.
+	// Zero writes every batch as soon as it is exported.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be non-empty")
+	}
+	if cfg.Format != formatTypeJSON && cfg.Format != formatTypeProto {
+		return fmt.Errorf("format type %q is not supported", cfg.Format)
+	}
+	switch cfg.Compression {
+	case "", configcompression.Gzip, configcompression.Zstd:
+	default:
+		return fmt.Errorf("compression %q is not supported", cfg.Compression)
+	}
+	if cfg.FlushInterval < 0 {
+		return errors.New("flush_interval must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configrotate"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr string
+	}{
+		{
+			id: component.NewID(typeStr),
+			expected: &Config{
+				Path:          "./filename.json",
+				Format:        formatTypeJSON,
+				FlushInterval: time.Second,
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "proto"),
+			expected: &Config{
+				Path:   "./filename.binpb",
+				Format: formatTypeProto,
+				Rotation: configrotate.Config{
+					Enabled:      true,
+					MaxMegabytes: 10,
+					MaxDays:      3,
+					MaxBackups:   3,
+				},
+				Compression:   configcompression.Zstd,
+				FlushInterval: 5 * time.Second,
+			},
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "no_path"),
+			expectedErr: "path must be non-empty",
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "invalid_format"),
+			expectedErr: `format type "text" is not supported`,
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "invalid_compression"),
+			expectedErr: `compression "snappy" is not supported`,
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "negative_flush_interval"),
+			expectedErr: "flush_interval must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := createDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
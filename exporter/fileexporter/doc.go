@@ -0,0 +1,5 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fileexporter exports data to a file as OTLP JSON lines or length-prefixed OTLP protobuf.
+package fileexporter // import "go.opentelemetry.io/collector/exporter/fileexporter"
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "go.opentelemetry.io/collector/exporter/fileexporter"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/internal/sharedcomponent"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr              = "file"
+	defaultFlushInterval = time.Second
+)
+
+// NewFactory creates a factory for the file exporter.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporter.WithTraces(createTracesExporter, component.StabilityLevelDevelopment),
+		exporter.WithMetrics(createMetricsExporter, component.StabilityLevelDevelopment),
+		exporter.WithLogs(createLogsExporter, component.StabilityLevelDevelopment),
+		exporter.WithProfiles(createProfilesExporter, component.StabilityLevelDevelopment),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Format:        formatTypeJSON,
+		FlushInterval: defaultFlushInterval,
+	}
+}
+
+// exporters shares the file of a configuration between the exporters of the different signals,
+// so that they do not write to it concurrently.
+var exporters = sharedcomponent.NewSharedComponents[*Config, *fileExporter]()
+
+func getOrCreateExporter(cfg *Config) (*sharedcomponent.SharedComponent[*fileExporter], error) {
+	return exporters.GetOrAdd(cfg, func() (*fileExporter, error) {
+		return newFileExporter(cfg)
+	})
+}
+
+func exporterOptions(fe *sharedcomponent.SharedComponent[*fileExporter]) []exporterhelper.Option {
+	return []exporterhelper.Option{
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		// Disable Timeout/RetryOnFailure and SendingQueue, writing to the file does not need them.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(exporterhelper.RetrySettings{Enabled: false}),
+		exporterhelper.WithQueue(exporterhelper.QueueSettings{Enabled: false}),
+		exporterhelper.WithStart(fe.Start),
+		exporterhelper.WithShutdown(fe.Shutdown),
+	}
+}
+
+func createTracesExporter(ctx context.Context, set exporter.CreateSettings, config component.Config) (exporter.Traces, error) {
+	fe, err := getOrCreateExporter(config.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewTracesExporter(ctx, set, config, fe.Unwrap().consumeTraces, exporterOptions(fe)...)
+}
+
+func createMetricsExporter(ctx context.Context, set exporter.CreateSettings, config component.Config) (exporter.Metrics, error) {
+	fe, err := getOrCreateExporter(config.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewMetricsExporter(ctx, set, config, fe.Unwrap().consumeMetrics, exporterOptions(fe)...)
+}
+
+func createLogsExporter(ctx context.Context, set exporter.CreateSettings, config component.Config) (exporter.Logs, error) {
+	fe, err := getOrCreateExporter(config.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewLogsExporter(ctx, set, config, fe.Unwrap().consumeLogs, exporterOptions(fe)...)
+}
+
+func createProfilesExporter(ctx context.Context, set exporter.CreateSettings, config component.Config) (exporter.Profiles, error) {
+	fe, err := getOrCreateExporter(config.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewProfilesExporter(ctx, set, config, fe.Unwrap().consumeProfiles, exporterOptions(fe)...)
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestCreateExporters(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Path = filepath.Join(t.TempDir(), "data.json")
+	set := exportertest.NewNopCreateSettings()
+
+	te, err := factory.CreateTracesExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	me, err := factory.CreateMetricsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	le, err := factory.CreateLogsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	pe, err := factory.CreateProfilesExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+
+	// The exporters of the same configuration share the file.
+	exporters := []component.Component{te, me, le, pe}
+	for _, exp := range exporters {
+		require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	}
+	for _, exp := range exporters {
+		require.NoError(t, exp.Shutdown(context.Background()))
+	}
+	assert.FileExists(t, cfg.Path)
+}
+
+func TestLifecycle(t *testing.T) {
+	factory := NewFactory()
+	componenttest.CheckLifecycle(componenttest.CheckLifecycleParams{
+		T: t,
+		Create: func(error) (component.Component, error) {
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.Path = filepath.Join(t.TempDir(), "data.json")
+			return factory.CreateTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+		},
+		Consume: func(ctx context.Context, c component.Component) error {
+			return c.(exporter.Traces).ConsumeTraces(ctx, testdata.GenerateTraces(2))
+		},
+	})
+}
@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "go.opentelemetry.io/collector/exporter/fileexporter"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/otlpfile"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// maxBufferSize is the size above which the buffered data is written to the file without waiting for the flush interval.
+const maxBufferSize = 64 << 10
+
+// fileExporter writes the exported data to a file, it is shared by the exporters of all the signals.
+type fileExporter struct {
+	cfg         *Config
+	marshaler   marshaler
+	compress    func([]byte) ([]byte, error)
+	compression otlpfile.Compression
+
+	mu   sync.Mutex
+	file io.WriteCloser
+	// buf holds the complete batches not written to the file yet. The batches are written together,
+	// so the rotation of the file never splits them.
+	buf bytes.Buffer
+	// writer writes the records to buf.
+	writer *otlpfile.Writer
+
+	stopFlush chan struct{}
+	flushDone sync.WaitGroup
+}
+
+// marshaler marshals the data of every signal in the configured format.
+type marshaler struct {
+	traces   ptrace.Marshaler
+	metrics  pmetric.Marshaler
+	logs     plog.Marshaler
+	profiles pprofile.Marshaler
+}
+
+func newFileExporter(cfg *Config) (*fileExporter, error) {
+	fe := &fileExporter{cfg: cfg}
+	fe.writer = otlpfile.NewWriter(&fe.buf)
+	if cfg.Format == formatTypeProto {
+		fe.marshaler = marshaler{&ptrace.ProtoMarshaler{}, &pmetric.ProtoMarshaler{}, &plog.ProtoMarshaler{}, &pprofile.ProtoMarshaler{}}
+	} else {
+		fe.marshaler = marshaler{&ptrace.JSONMarshaler{}, &pmetric.JSONMarshaler{}, &plog.JSONMarshaler{}, &pprofile.JSONMarshaler{}}
+	}
+	switch cfg.Compression {
+	case configcompression.Gzip:
+		fe.compress = compressGzip
+		fe.compression = otlpfile.CompressionGzip
+	case configcompression.Zstd:
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		fe.compress = func(buf []byte) ([]byte, error) {
+			return encoder.EncodeAll(buf, nil), nil
+		}
+		fe.compression = otlpfile.CompressionZstd
+	}
+	return fe, nil
+}
+
+func compressGzip(buf []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	if _, err := w.Write(buf); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return compressed.Bytes(), nil
+}
+
+func (fe *fileExporter) Start(_ context.Context, _ component.Host) error {
+	if err := os.MkdirAll(filepath.Dir(fe.cfg.Path), 0755); err != nil {
+		return err
+	}
+	file, err := fe.cfg.Rotation.NewWriter(fe.cfg.Path)
+	if err != nil {
+		return err
+	}
+	fe.file = file
+
+	if fe.cfg.FlushInterval > 0 {
+		fe.stopFlush = make(chan struct{})
+		fe.flushDone.Add(1)
+		go fe.flushPeriodically()
+	}
+	return nil
+}
+
+// flushPeriodically writes the buffered data to the file every flush interval, until Shutdown.
+func (fe *fileExporter) flushPeriodically() {
+	defer fe.flushDone.Done()
+	ticker := time.NewTicker(fe.cfg.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			fe.mu.Lock()
+			// The data of a failed flush is dropped, there is no exporter call to return the error to.
+			_ = fe.flush()
+			fe.mu.Unlock()
+		case <-fe.stopFlush:
+			return
+		}
+	}
+}
+
+func (fe *fileExporter) Shutdown(context.Context) error {
+	if fe.file == nil {
+		return nil
+	}
+	if fe.stopFlush != nil {
+		close(fe.stopFlush)
+		fe.flushDone.Wait()
+	}
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+	return multierr.Append(fe.flush(), fe.file.Close())
+}
+
+func (fe *fileExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	buf, err := fe.marshaler.traces.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+	return fe.write(component.DataTypeTraces, buf)
+}
+
+func (fe *fileExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	buf, err := fe.marshaler.metrics.MarshalMetrics(md)
+	if err != nil {
+		return err
+	}
+	return fe.write(component.DataTypeMetrics, buf)
+}
+
+func (fe *fileExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	buf, err := fe.marshaler.logs.MarshalLogs(ld)
+	if err != nil {
+		return err
+	}
+	return fe.write(component.DataTypeLogs, buf)
+}
+
+func (fe *fileExporter) consumeProfiles(_ context.Context, pd pprofile.Profiles) error {
+	buf, err := fe.marshaler.profiles.MarshalProfiles(pd)
+	if err != nil {
+		return err
+	}
+	return fe.write(component.DataTypeProfiles, buf)
+}
+
+// write writes a marshaled batch of the signal to the file, as a line for uncompressed JSON, which tells
+// its signal, or as an OTLP file record tagged with its signal otherwise.
+func (fe *fileExporter) write(signal component.DataType, buf []byte) error {
+	var rec *otlpfile.Record
+	if fe.cfg.Format == formatTypeProto || fe.compress != nil {
+		rec = &otlpfile.Record{Signal: signal, Time: time.Now(), Encoding: otlpfile.EncodingJSON, Compression: fe.compression}
+		if fe.cfg.Format == formatTypeProto {
+			rec.Encoding = otlpfile.EncodingProto
+		}
+		if fe.compress != nil {
+			var err error
+			if buf, err = fe.compress(buf); err != nil {
+				return err
+			}
+		}
+		rec.Data = buf
+	}
+
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+	if rec != nil {
+		if err := fe.writer.Write(*rec); err != nil {
+			return err
+		}
+	} else {
+		fe.buf.Write(buf)
+		fe.buf.WriteByte('\n')
+	}
+	if fe.cfg.FlushInterval == 0 || fe.buf.Len() >= maxBufferSize {
+		return fe.flush()
+	}
+	return nil
+}
+
+// flush writes the buffered data to the file, the caller must hold the lock.
+func (fe *fileExporter) flush() error {
+	if fe.buf.Len() == 0 {
+		return nil
+	}
+	_, err := fe.file.Write(fe.buf.Bytes())
+	fe.buf.Reset()
+	return err
+}
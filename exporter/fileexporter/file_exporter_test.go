@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configrotate"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/otlpfile"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTraces(name string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(name)
+	return td
+}
+
+func startExporter(t *testing.T, cfg *Config) *fileExporter {
+	fe, err := newFileExporter(cfg)
+	require.NoError(t, err)
+	require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	return fe
+}
+
+// readRecords reads the records of the file.
+func readRecords(t *testing.T, path string) []otlpfile.Record {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var records []otlpfile.Record
+	r := otlpfile.NewReader(bufio.NewReader(f))
+	for {
+		rec, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return records
+		}
+		require.NoError(t, err)
+		records = append(records, rec)
+	}
+}
+
+func TestFileExporterJSON(t *testing.T) {
+	cfg := &Config{Path: filepath.Join(t.TempDir(), "sub", "data.json"), Format: formatTypeJSON}
+	fe := startExporter(t, cfg)
+	require.NoError(t, fe.consumeTraces(context.Background(), newTraces("first")))
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("metric")
+	require.NoError(t, fe.consumeMetrics(context.Background(), md))
+	require.NoError(t, fe.consumeLogs(context.Background(), plog.NewLogs()))
+	require.NoError(t, fe.Shutdown(context.Background()))
+
+	f, err := os.Open(cfg.Path)
+	require.NoError(t, err)
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	require.True(t, scanner.Scan())
+	td, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(scanner.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, newTraces("first"), td)
+	require.True(t, scanner.Scan())
+	got, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(scanner.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, md, got)
+	require.True(t, scanner.Scan())
+	assert.False(t, scanner.Scan())
+}
+
+func TestFileExporterProto(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		compression configcompression.CompressionType
+		encoding    otlpfile.Encoding
+		recCompr    otlpfile.Compression
+		decode      func(t *testing.T, msg []byte) ptrace.Traces
+	}{
+		{
+			name:     "proto",
+			format:   formatTypeProto,
+			encoding: otlpfile.EncodingProto,
+			decode: func(t *testing.T, msg []byte) ptrace.Traces {
+				td, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(msg)
+				require.NoError(t, err)
+				return td
+			},
+		},
+		{
+			name:        "proto_gzip",
+			format:      formatTypeProto,
+			compression: configcompression.Gzip,
+			encoding:    otlpfile.EncodingProto,
+			recCompr:    otlpfile.CompressionGzip,
+			decode: func(t *testing.T, msg []byte) ptrace.Traces {
+				r, err := gzip.NewReader(bytes.NewReader(msg))
+				require.NoError(t, err)
+				buf, err := io.ReadAll(r)
+				require.NoError(t, err)
+				td, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(buf)
+				require.NoError(t, err)
+				return td
+			},
+		},
+		{
+			name:        "json_zstd",
+			format:      formatTypeJSON,
+			compression: configcompression.Zstd,
+			encoding:    otlpfile.EncodingJSON,
+			recCompr:    otlpfile.CompressionZstd,
+			decode: func(t *testing.T, msg []byte) ptrace.Traces {
+				decoder, err := zstd.NewReader(nil)
+				require.NoError(t, err)
+				defer decoder.Close()
+				buf, err := decoder.DecodeAll(msg, nil)
+				require.NoError(t, err)
+				td, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(buf)
+				require.NoError(t, err)
+				return td
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Path: filepath.Join(t.TempDir(), "data"), Format: tt.format, Compression: tt.compression}
+			fe := startExporter(t, cfg)
+			require.NoError(t, fe.consumeTraces(context.Background(), newTraces("first")))
+			require.NoError(t, fe.consumeTraces(context.Background(), newTraces("second")))
+			require.NoError(t, fe.Shutdown(context.Background()))
+
+			records := readRecords(t, cfg.Path)
+			require.Len(t, records, 2)
+			for _, rec := range records {
+				assert.Equal(t, component.DataTypeTraces, rec.Signal)
+				assert.Equal(t, tt.encoding, rec.Encoding)
+				assert.Equal(t, tt.recCompr, rec.Compression)
+			}
+			assert.Equal(t, newTraces("first"), tt.decode(t, records[0].Data))
+			assert.Equal(t, newTraces("second"), tt.decode(t, records[1].Data))
+		})
+	}
+}
+
+func TestFileExporterFlushInterval(t *testing.T) {
+	cfg := &Config{Path: filepath.Join(t.TempDir(), "data.json"), Format: formatTypeJSON, FlushInterval: 10 * time.Millisecond}
+	fe := startExporter(t, cfg)
+	defer func() { assert.NoError(t, fe.Shutdown(context.Background())) }()
+	require.NoError(t, fe.consumeTraces(context.Background(), newTraces("span")))
+
+	// The data is buffered until the flush interval expires.
+	assert.Eventually(t, func() bool {
+		info, err := os.Stat(cfg.Path)
+		return err == nil && info.Size() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFileExporterRotation(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &Config{
+		Path:     filepath.Join(dir, "data.binpb"),
+		Format:   formatTypeProto,
+		Rotation: configrotate.Config{Enabled: true, MaxMegabytes: 1, MaxBackups: 10},
+	}
+	fe := startExporter(t, cfg)
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i := 0; i < 1000; i++ {
+		spans.AppendEmpty().SetName("a span with a name long enough to fill the file quickly")
+	}
+	for i := 0; i < 50; i++ {
+		require.NoError(t, fe.consumeTraces(context.Background(), td))
+	}
+	require.NoError(t, fe.Shutdown(context.Background()))
+
+	files, err := filepath.Glob(filepath.Join(dir, "data*.binpb"))
+	require.NoError(t, err)
+	require.Greater(t, len(files), 1)
+	// The rotation does not split the batches.
+	total := 0
+	for _, file := range files {
+		for _, rec := range readRecords(t, file) {
+			got, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(rec.Data)
+			require.NoError(t, err)
+			assert.Equal(t, 1000, got.SpanCount())
+			total++
+		}
+	}
+	assert.Equal(t, 50, total)
+}
@@ -4,20 +4,31 @@
 package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexporter"
 
 import (
+	"errors"
 	"fmt"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configgrpc/zstddict"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
 
 // Config defines configuration for OTLP exporter.
 type Config struct {
-	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
-	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
-	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+	exporterhelper.TimeoutSettings    `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings      `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings      `mapstructure:"retry_on_failure"`
+	exporterhelper.DeadLetterSettings `mapstructure:"dead_letter_queue"`
+	exporterhelper.BatcherSettings    `mapstructure:"batch"`
 
 	configgrpc.GRPCClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// CompressionDictionary enables zstd compression with dictionaries trained from recent payloads.
+	// The dictionaries are offered to the receiving collector and used once it acknowledges them.
+	// Requires the `zstd` compression.
+	// Experimental: *NOTE* this option is subject to change or removal in the future.
+	CompressionDictionary *zstddict.Config `mapstructure:"compression_dictionary"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -28,5 +39,18 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("queue settings has invalid configuration: %w", err)
 	}
 
+	if cfg.CompressionDictionary != nil {
+		if cfg.Compression != configcompression.Zstd {
+			return errors.New("compression_dictionary requires zstd compression")
+		}
+		if cfg.Pool > 1 {
+			// The dictionaries are negotiated for each connection by the compressor shared by the pool.
+			return errors.New("compression_dictionary cannot be used with a pool of connections")
+		}
+		if err := cfg.CompressionDictionary.Validate(); err != nil {
+			return fmt.Errorf("compression_dictionary has invalid configuration: %w", err)
+		}
+	}
+
 	return nil
 }
@@ -13,7 +13,9 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configgrpc/zstddict"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap"
@@ -52,6 +54,7 @@ func TestUnmarshalConfig(t *testing.T) {
 				NumConsumers: 2,
 				QueueSize:    10,
 			},
+			BatcherSettings: exporterhelper.NewDefaultBatcherSettings(),
 			GRPCClientSettings: configgrpc.GRPCClientSettings{
 				Headers: map[string]configopaque.String{
 					"can you have a . here?": "F0000000-0000-0000-0000-000000000000",
@@ -77,3 +80,20 @@ func TestUnmarshalConfig(t *testing.T) {
 			},
 		}, cfg)
 }
+
+func TestValidateCompressionDictionary(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	dictCfg := zstddict.NewDefaultConfig()
+	cfg.CompressionDictionary = &dictCfg
+	assert.EqualError(t, cfg.Validate(), "compression_dictionary requires zstd compression")
+
+	cfg.Compression = configcompression.Zstd
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Pool = 2
+	assert.EqualError(t, cfg.Validate(), "compression_dictionary cannot be used with a pool of connections")
+	cfg.Pool = 1
+
+	cfg.CompressionDictionary.RefreshInterval = 0
+	assert.Error(t, cfg.Validate())
+}
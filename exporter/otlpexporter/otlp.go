@@ -8,8 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"sync"
 	"time"
 
+	"go.uber.org/zap"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -17,6 +19,8 @@ import (
 	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configgrpc/zstddict"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -24,6 +28,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 )
@@ -33,17 +39,24 @@ type baseExporter struct {
 	config *Config
 
 	// gRPC clients and connection.
-	traceExporter  ptraceotlp.GRPCClient
-	metricExporter pmetricotlp.GRPCClient
-	logExporter    plogotlp.GRPCClient
-	clientConn     *grpc.ClientConn
-	metadata       metadata.MD
-	callOptions    []grpc.CallOption
+	traceExporter   ptraceotlp.GRPCClient
+	metricExporter  pmetricotlp.GRPCClient
+	logExporter     plogotlp.GRPCClient
+	profileExporter pprofileotlp.GRPCClient
+	clientConn      *configgrpc.ClientConnPool
+	metadata        metadata.MD
+	callOptions     []grpc.CallOption
 
 	settings component.TelemetrySettings
 
 	// Default user-agent header.
 	userAgent string
+
+	// Compression dictionary training, only set if enabled.
+	dictTrainer    *zstddict.Trainer
+	dictCompressor *zstddict.ClientCompressor
+	dictDone       chan struct{}
+	dictWG         sync.WaitGroup
 }
 
 // Crete new exporter and start it. The exporter will begin connecting but
@@ -64,12 +77,41 @@ func newExporter(cfg component.Config, set exporter.CreateSettings) (*baseExport
 // start actually creates the gRPC connection. The client construction is deferred till this point as this
 // is the only place we get hold of Extensions which are required to construct auth round tripper.
 func (e *baseExporter) start(ctx context.Context, host component.Host) (err error) {
-	if e.clientConn, err = e.config.GRPCClientSettings.ToClientConn(ctx, host, e.settings, grpc.WithUserAgent(e.userAgent)); err != nil {
+	clientSettings := e.config.GRPCClientSettings
+	dialOpts := []grpc.DialOption{grpc.WithUserAgent(e.userAgent)}
+	if e.config.CompressionDictionary != nil {
+		if e.dictCompressor, err = zstddict.NewClientCompressor(clientSettings.CompressionLevel); err != nil {
+			return err
+		}
+		// The pre-trained dictionary is used until a dictionary is trained from the requests.
+		if clientSettings.CompressionDictionaryFile != "" {
+			var dict []byte
+			if dict, err = zstddict.LoadDictionary(clientSettings.CompressionDictionaryFile); err != nil {
+				return err
+			}
+			if err = e.dictCompressor.SetDictionary(dict); err != nil {
+				return err
+			}
+		}
+		// The dictionary aware compressor replaces the one configured for the connection.
+		clientSettings.Compression = ""
+		clientSettings.CompressionLevel = 0
+		clientSettings.CompressionDictionaryFile = ""
+		dialOpts = append(dialOpts,
+			grpc.WithCompressor(e.dictCompressor), //nolint:staticcheck // SA1019 see zstddict.ClientCompressor.
+			grpc.WithChainUnaryInterceptor(e.dictCompressor.UnaryClientInterceptor()))
+		e.dictTrainer = zstddict.NewTrainer(*e.config.CompressionDictionary)
+		e.dictDone = make(chan struct{})
+		e.dictWG.Add(1)
+		go e.refreshDictionaries(e.config.CompressionDictionary.RefreshInterval)
+	}
+	if e.clientConn, err = clientSettings.ToClientConnPool(ctx, host, e.settings, dialOpts...); err != nil {
 		return err
 	}
 	e.traceExporter = ptraceotlp.NewGRPCClient(e.clientConn)
 	e.metricExporter = pmetricotlp.NewGRPCClient(e.clientConn)
 	e.logExporter = plogotlp.NewGRPCClient(e.clientConn)
+	e.profileExporter = pprofileotlp.NewGRPCClient(e.clientConn)
 	headers := map[string]string{}
 	for k, v := range e.config.GRPCClientSettings.Headers {
 		headers[k] = string(v)
@@ -83,47 +125,100 @@ func (e *baseExporter) start(ctx context.Context, host component.Host) (err erro
 }
 
 func (e *baseExporter) shutdown(context.Context) error {
+	if e.dictDone != nil {
+		close(e.dictDone)
+		e.dictWG.Wait()
+	}
 	if e.clientConn != nil {
 		return e.clientConn.Close()
 	}
 	return nil
 }
 
+// refreshDictionaries periodically trains a new compression dictionary from the sampled requests.
+func (e *baseExporter) refreshDictionaries(interval time.Duration) {
+	defer e.dictWG.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.dictDone:
+			return
+		case <-ticker.C:
+			dict, err := e.dictTrainer.Train()
+			if err != nil {
+				e.settings.Logger.Debug("Skipping compression dictionary refresh", zap.Error(err))
+				continue
+			}
+			if err = e.dictCompressor.SetDictionary(dict); err != nil {
+				e.settings.Logger.Warn("Failed to use the trained compression dictionary", zap.Error(err))
+			}
+		}
+	}
+}
+
+// sampleForDictionary records the serialized request to train the next compression dictionary, if needed.
+func (e *baseExporter) sampleForDictionary(marshal func() ([]byte, error)) {
+	if e.dictTrainer == nil || !e.dictTrainer.WantsSample() {
+		return
+	}
+	if buf, err := marshal(); err == nil {
+		e.dictTrainer.AddSample(buf)
+	}
+}
+
 func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 	req := ptraceotlp.NewExportRequestFromTraces(td)
+	e.sampleForDictionary(req.MarshalProto)
 	resp, respErr := e.traceExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
 	if err := processError(respErr); err != nil {
 		return err
 	}
 	partialSuccess := resp.PartialSuccess()
 	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedSpans() == 0) {
-		return consumererror.NewPermanent(fmt.Errorf("OTLP partial success: \"%s\" (%d rejected)", resp.PartialSuccess().ErrorMessage(), resp.PartialSuccess().RejectedSpans()))
+		return consumererror.NewPartial(fmt.Errorf("OTLP partial success: \"%s\" (%d rejected)", partialSuccess.ErrorMessage(), partialSuccess.RejectedSpans()), int(partialSuccess.RejectedSpans()))
 	}
 	return nil
 }
 
 func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
 	req := pmetricotlp.NewExportRequestFromMetrics(md)
+	e.sampleForDictionary(req.MarshalProto)
 	resp, respErr := e.metricExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
 	if err := processError(respErr); err != nil {
 		return err
 	}
 	partialSuccess := resp.PartialSuccess()
 	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedDataPoints() == 0) {
-		return consumererror.NewPermanent(fmt.Errorf("OTLP partial success: \"%s\" (%d rejected)", resp.PartialSuccess().ErrorMessage(), resp.PartialSuccess().RejectedDataPoints()))
+		return consumererror.NewPartial(fmt.Errorf("OTLP partial success: \"%s\" (%d rejected)", partialSuccess.ErrorMessage(), partialSuccess.RejectedDataPoints()), int(partialSuccess.RejectedDataPoints()))
 	}
 	return nil
 }
 
 func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 	req := plogotlp.NewExportRequestFromLogs(ld)
+	e.sampleForDictionary(req.MarshalProto)
 	resp, respErr := e.logExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
 	if err := processError(respErr); err != nil {
 		return err
 	}
 	partialSuccess := resp.PartialSuccess()
 	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedLogRecords() == 0) {
-		return consumererror.NewPermanent(fmt.Errorf("OTLP partial success: \"%s\" (%d rejected)", resp.PartialSuccess().ErrorMessage(), resp.PartialSuccess().RejectedLogRecords()))
+		return consumererror.NewPartial(fmt.Errorf("OTLP partial success: \"%s\" (%d rejected)", partialSuccess.ErrorMessage(), partialSuccess.RejectedLogRecords()), int(partialSuccess.RejectedLogRecords()))
+	}
+	return nil
+}
+
+func (e *baseExporter) pushProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	req := pprofileotlp.NewExportRequestFromProfiles(pd)
+	e.sampleForDictionary(req.MarshalProto)
+	resp, respErr := e.profileExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+	if err := processError(respErr); err != nil {
+		return err
+	}
+	partialSuccess := resp.PartialSuccess()
+	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedProfiles() == 0) {
+		return consumererror.NewPartial(fmt.Errorf("OTLP partial success: \"%s\" (%d rejected)", partialSuccess.ErrorMessage(), partialSuccess.RejectedProfiles()), int(partialSuccess.RejectedProfiles()))
 	}
 	return nil
 }
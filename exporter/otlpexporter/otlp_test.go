@@ -27,6 +27,7 @@ import (
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/internal/testdata"
@@ -34,6 +35,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 )
@@ -172,6 +175,55 @@ func otlpLogsReceiverOnGRPCServer(ln net.Listener) *mockLogsReceiver {
 	return rcv
 }
 
+type mockProfilesReceiver struct {
+	pprofileotlp.UnimplementedGRPCServer
+	mockReceiver
+	exportResponse func() pprofileotlp.ExportResponse
+	lastRequest    pprofile.Profiles
+}
+
+func (r *mockProfilesReceiver) Export(ctx context.Context, req pprofileotlp.ExportRequest) (pprofileotlp.ExportResponse, error) {
+	r.requestCount.Add(int32(1))
+	pd := req.Profiles()
+	r.totalItems.Add(int32(pd.SampleCount()))
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.lastRequest = pd
+	r.metadata, _ = metadata.FromIncomingContext(ctx)
+	return r.exportResponse(), r.exportError
+}
+
+func (r *mockProfilesReceiver) getLastRequest() pprofile.Profiles {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return r.lastRequest
+}
+
+func (r *mockProfilesReceiver) setExportResponse(fn func() pprofileotlp.ExportResponse) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.exportResponse = fn
+}
+
+func otlpProfilesReceiverOnGRPCServer(ln net.Listener) *mockProfilesReceiver {
+	rcv := &mockProfilesReceiver{
+		mockReceiver: mockReceiver{
+			srv:          grpc.NewServer(),
+			requestCount: &atomic.Int32{},
+			totalItems:   &atomic.Int32{},
+		},
+		exportResponse: pprofileotlp.NewExportResponse,
+	}
+
+	// Now run it as a gRPC server
+	pprofileotlp.RegisterGRPCServer(rcv.srv, rcv)
+	go func() {
+		_ = rcv.srv.Serve(ln)
+	}()
+
+	return rcv
+}
+
 type mockMetricsReceiver struct {
 	pmetricotlp.UnimplementedGRPCServer
 	mockReceiver
@@ -311,6 +363,9 @@ func TestSendTraces(t *testing.T) {
 
 	err = exp.ConsumeTraces(context.Background(), td)
 	assert.Error(t, err)
+	var partialErr consumererror.PartialError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, 1, partialErr.Rejected())
 }
 
 func TestSendTracesWhenEndpointHasHttpScheme(t *testing.T) {
@@ -484,7 +539,11 @@ func TestSendMetrics(t *testing.T) {
 
 	// Send two metrics.
 	md = testdata.GenerateMetrics(2)
-	assert.Error(t, exp.ConsumeMetrics(context.Background(), md))
+	err = exp.ConsumeMetrics(context.Background(), md)
+	assert.Error(t, err)
+	var partialErr consumererror.PartialError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, 1, partialErr.Rejected())
 }
 
 func TestSendTraceDataServerDownAndUp(t *testing.T) {
@@ -771,4 +830,107 @@ func TestSendLogData(t *testing.T) {
 
 	err = exp.ConsumeLogs(context.Background(), ld)
 	assert.Error(t, err)
+	var partialErr consumererror.PartialError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, 1, partialErr.Rejected())
+}
+
+func TestSendProfileData(t *testing.T) {
+	// Start an OTLP-compatible receiver.
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err, "Failed to find an available address to run the gRPC server: %v", err)
+	rcv := otlpProfilesReceiverOnGRPCServer(ln)
+	// Also closes the connection.
+	defer rcv.srv.GracefulStop()
+
+	// Start an OTLP exporter and point to the receiver.
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	// Disable queuing to ensure that we execute the request when calling ConsumeProfiles
+	// otherwise we will not see any errors.
+	cfg.QueueSettings.Enabled = false
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	set := exportertest.NewNopCreateSettings()
+	set.BuildInfo.Description = "Collector"
+	set.BuildInfo.Version = "1.2.3test"
+	exp, err := factory.CreateProfilesExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, exp)
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	host := componenttest.NewNopHost()
+
+	assert.NoError(t, exp.Start(context.Background(), host))
+
+	// Ensure that initially there is no data in the receiver.
+	assert.EqualValues(t, 0, rcv.requestCount.Load())
+
+	// Send empty request.
+	pd := pprofile.NewProfiles()
+	assert.NoError(t, exp.ConsumeProfiles(context.Background(), pd))
+
+	// Wait until it is received.
+	assert.Eventually(t, func() bool {
+		return rcv.requestCount.Load() > 0
+	}, 10*time.Second, 5*time.Millisecond)
+
+	// Ensure it was received empty.
+	assert.EqualValues(t, 0, rcv.totalItems.Load())
+
+	// A request with 2 samples.
+	pd = testdata.GenerateProfiles(2)
+
+	err = exp.ConsumeProfiles(context.Background(), pd)
+	assert.NoError(t, err)
+
+	// Wait until it is received.
+	assert.Eventually(t, func() bool {
+		return rcv.requestCount.Load() > 1
+	}, 10*time.Second, 5*time.Millisecond)
+
+	// Verify received profiles.
+	assert.EqualValues(t, 2, rcv.requestCount.Load())
+	assert.EqualValues(t, 2, rcv.totalItems.Load())
+	assert.EqualValues(t, pd, rcv.getLastRequest())
+
+	md := rcv.getMetadata()
+	require.Equal(t, len(md.Get("User-Agent")), 1)
+	require.Contains(t, md.Get("User-Agent")[0], "Collector/1.2.3test")
+
+	st := status.New(codes.InvalidArgument, "Invalid argument")
+	rcv.setExportError(st.Err())
+
+	// A request with 2 samples.
+	pd = testdata.GenerateProfiles(2)
+
+	err = exp.ConsumeProfiles(context.Background(), pd)
+	assert.Error(t, err)
+
+	rcv.setExportError(nil)
+
+	// Return partial success
+	rcv.setExportResponse(func() pprofileotlp.ExportResponse {
+		response := pprofileotlp.NewExportResponse()
+		partialSuccess := response.PartialSuccess()
+		partialSuccess.SetErrorMessage("Some profiles were not ingested")
+		partialSuccess.SetRejectedProfiles(1)
+
+		return response
+	})
+
+	// A request with 2 samples.
+	pd = testdata.GenerateProfiles(2)
+
+	err = exp.ConsumeProfiles(context.Background(), pd)
+	assert.Error(t, err)
+	var partialErr consumererror.PartialError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, 1, partialErr.Rejected())
 }
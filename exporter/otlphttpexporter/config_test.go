@@ -50,6 +50,7 @@ func TestUnmarshalConfig(t *testing.T) {
 				NumConsumers: 2,
 				QueueSize:    10,
 			},
+			BatcherSettings: exporterhelper.NewDefaultBatcherSettings(),
 			HTTPClientSettings: confighttp.HTTPClientSettings{
 				Headers: map[string]configopaque.String{
 					"can you have a . here?": "F0000000-0000-0000-0000-000000000000",
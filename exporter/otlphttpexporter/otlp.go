@@ -27,25 +27,29 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 )
 
 type baseExporter struct {
 	// Input configuration.
-	config     *Config
-	client     *http.Client
-	tracesURL  string
-	metricsURL string
-	logsURL    string
-	logger     *zap.Logger
-	settings   component.TelemetrySettings
+	config      *Config
+	client      *http.Client
+	tracesURL   string
+	metricsURL  string
+	logsURL     string
+	profilesURL string
+	logger      *zap.Logger
+	settings    component.TelemetrySettings
 	// Default user-agent header.
 	userAgent string
 }
 
 const (
 	headerRetryAfter         = "Retry-After"
+	protobufContentType      = "application/x-protobuf"
 	maxHTTPResponseReadBytes = 64 * 1024
 )
 
@@ -90,7 +94,7 @@ func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 		return consumererror.NewPermanent(err)
 	}
 
-	return e.export(ctx, e.tracesURL, request)
+	return e.export(ctx, e.tracesURL, request, tracesPartialSuccessHandler)
 }
 
 func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
@@ -99,7 +103,7 @@ func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) erro
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
-	return e.export(ctx, e.metricsURL, request)
+	return e.export(ctx, e.metricsURL, request, metricsPartialSuccessHandler)
 }
 
 func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
@@ -109,16 +113,26 @@ func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 		return consumererror.NewPermanent(err)
 	}
 
-	return e.export(ctx, e.logsURL, request)
+	return e.export(ctx, e.logsURL, request, logsPartialSuccessHandler)
 }
 
-func (e *baseExporter) export(ctx context.Context, url string, request []byte) error {
+func (e *baseExporter) pushProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	tr := pprofileotlp.NewExportRequestFromProfiles(pd)
+	request, err := tr.MarshalProto()
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+
+	return e.export(ctx, e.profilesURL, request, profilesPartialSuccessHandler)
+}
+
+func (e *baseExporter) export(ctx context.Context, url string, request []byte, partialSuccessHandler partialSuccessHandler) error {
 	e.logger.Debug("Preparing to make HTTP request", zap.String("url", url))
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(request))
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
-	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Type", protobufContentType)
 	req.Header.Set("User-Agent", e.userAgent)
 
 	resp, err := e.client.Do(req)
@@ -133,8 +147,8 @@ func (e *baseExporter) export(ctx context.Context, url string, request []byte) e
 	}()
 
 	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-		// Request is successful.
-		return nil
+		// Request is successful, but some items may have been rejected.
+		return handlePartialSuccessResponse(resp, partialSuccessHandler)
 	}
 
 	respStatus := readResponse(resp)
@@ -154,23 +168,106 @@ func (e *baseExporter) export(ctx context.Context, url string, request []byte) e
 	if isRetryableStatusCode(resp.StatusCode) {
 		// A retry duration of 0 seconds will trigger the default backoff policy
 		// of our caller (retry handler).
-		retryAfter := 0
+		var retryAfter time.Duration
 
 		// Check if the server is overwhelmed.
 		// See spec https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#otlphttp-throttling
 		isThrottleError := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
 		if val := resp.Header.Get(headerRetryAfter); isThrottleError && val != "" {
-			if seconds, err2 := strconv.Atoi(val); err2 == nil {
-				retryAfter = seconds
-			}
+			retryAfter = parseRetryAfter(val, time.Now(), e.config.RetrySettings.MaxElapsedTime)
 		}
 
-		return exporterhelper.NewThrottleRetry(formattedErr, time.Duration(retryAfter)*time.Second)
+		// The exporter pauses sending the requests of this signal for the duration.
+		return exporterhelper.NewThrottleRetry(formattedErr, retryAfter)
 	}
 
 	return consumererror.NewPermanent(formattedErr)
 }
 
+// partialSuccessHandler returns an error if the Protobuf-encoded export response reports a partial success.
+type partialSuccessHandler func(protoBytes []byte) error
+
+// handlePartialSuccessResponse reads the body of a successful response, and passes it to the handler if it
+// is a Protobuf-encoded export response. Responses which cannot be read are considered as full successes.
+func handlePartialSuccessResponse(resp *http.Response, handler partialSuccessHandler) error {
+	if resp.ContentLength == 0 || resp.Header.Get("Content-Type") != protobufContentType {
+		return nil
+	}
+	protoBytes, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPResponseReadBytes))
+	if err != nil || len(protoBytes) == 0 {
+		return nil
+	}
+	return handler(protoBytes)
+}
+
+func tracesPartialSuccessHandler(protoBytes []byte) error {
+	exportResponse := ptraceotlp.NewExportResponse()
+	if err := exportResponse.UnmarshalProto(protoBytes); err != nil {
+		return nil
+	}
+	partialSuccess := exportResponse.PartialSuccess()
+	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedSpans() == 0) {
+		return consumererror.NewPartial(fmt.Errorf("OTLP partial success: \"%s\" (%d rejected)", partialSuccess.ErrorMessage(), partialSuccess.RejectedSpans()), int(partialSuccess.RejectedSpans()))
+	}
+	return nil
+}
+
+func metricsPartialSuccessHandler(protoBytes []byte) error {
+	exportResponse := pmetricotlp.NewExportResponse()
+	if err := exportResponse.UnmarshalProto(protoBytes); err != nil {
+		return nil
+	}
+	partialSuccess := exportResponse.PartialSuccess()
+	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedDataPoints() == 0) {
+		return consumererror.NewPartial(fmt.Errorf("OTLP partial success: \"%s\" (%d rejected)", partialSuccess.ErrorMessage(), partialSuccess.RejectedDataPoints()), int(partialSuccess.RejectedDataPoints()))
+	}
+	return nil
+}
+
+func logsPartialSuccessHandler(protoBytes []byte) error {
+	exportResponse := plogotlp.NewExportResponse()
+	if err := exportResponse.UnmarshalProto(protoBytes); err != nil {
+		return nil
+	}
+	partialSuccess := exportResponse.PartialSuccess()
+	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedLogRecords() == 0) {
+		return consumererror.NewPartial(fmt.Errorf("OTLP partial success: \"%s\" (%d rejected)", partialSuccess.ErrorMessage(), partialSuccess.RejectedLogRecords()), int(partialSuccess.RejectedLogRecords()))
+	}
+	return nil
+}
+
+func profilesPartialSuccessHandler(protoBytes []byte) error {
+	exportResponse := pprofileotlp.NewExportResponse()
+	if err := exportResponse.UnmarshalProto(protoBytes); err != nil {
+		return nil
+	}
+	partialSuccess := exportResponse.PartialSuccess()
+	if !(partialSuccess.ErrorMessage() == "" && partialSuccess.RejectedProfiles() == 0) {
+		return consumererror.NewPartial(fmt.Errorf("OTLP partial success: \"%s\" (%d rejected)", partialSuccess.ErrorMessage(), partialSuccess.RejectedProfiles()), int(partialSuccess.RejectedProfiles()))
+	}
+	return nil
+}
+
+// parseRetryAfter returns the duration to wait before retrying indicated by the value of a
+// Retry-After header, either a number of seconds or an HTTP date, 0 if it cannot be parsed.
+// The duration is capped at maxDelay if not zero, so that a server cannot pause the exporter for longer
+// than it retries the data.
+func parseRetryAfter(val string, now time.Time, maxDelay time.Duration) time.Duration {
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(val); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(val); err == nil && date.After(now) {
+		delay = date.Sub(now)
+	}
+	if maxDelay > 0 && delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
 // Determine if the status code is retryable according to the specification.
 // For more, see https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#failures-1
 func isRetryableStatusCode(code int) bool {
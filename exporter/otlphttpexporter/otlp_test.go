@@ -236,6 +236,49 @@ func TestLogsRoundTrip(t *testing.T) {
 	}
 }
 
+func TestProfilesRoundTrip(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+
+	tests := []struct {
+		name        string
+		baseURL     string
+		overrideURL string
+	}{
+		{
+			name:        "wrongbase",
+			baseURL:     "http://wronghostname",
+			overrideURL: fmt.Sprintf("http://%s/v1experimental/profiles", addr),
+		},
+		{
+			name:        "onlybase",
+			baseURL:     fmt.Sprintf("http://%s", addr),
+			overrideURL: "",
+		},
+		{
+			name:        "override",
+			baseURL:     "",
+			overrideURL: fmt.Sprintf("http://%s/v1experimental/profiles", addr),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sink := new(consumertest.ProfilesSink)
+			startProfilesReceiver(t, addr, sink)
+			exp := startProfilesExporter(t, test.baseURL, test.overrideURL)
+
+			pd := testdata.GenerateProfiles(1)
+			assert.NoError(t, exp.ConsumeProfiles(context.Background(), pd))
+			require.Eventually(t, func() bool {
+				return sink.SampleCount() > 0
+			}, 1*time.Second, 10*time.Millisecond)
+			allProfiles := sink.AllProfiles()
+			require.Len(t, allProfiles, 1)
+			assert.EqualValues(t, pd, allProfiles[0])
+		})
+	}
+}
+
 func TestIssue_4221(t *testing.T) {
 	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() { assert.NoError(t, r.Body.Close()) }()
@@ -324,6 +367,16 @@ func startLogsExporter(t *testing.T, baseURL string, overrideURL string) exporte
 	return exp
 }
 
+func startProfilesExporter(t *testing.T, baseURL string, overrideURL string) exporter.Profiles {
+	factory := NewFactory()
+	cfg := createExporterConfig(baseURL, factory.CreateDefaultConfig())
+	cfg.ProfilesEndpoint = overrideURL
+	exp, err := factory.CreateProfilesExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	startAndCleanup(t, exp)
+	return exp
+}
+
 func createExporterConfig(baseURL string, defaultCfg component.Config) *Config {
 	cfg := defaultCfg.(*Config)
 	cfg.Endpoint = baseURL
@@ -356,6 +409,14 @@ func startLogsReceiver(t *testing.T, addr string, next consumer.Logs) {
 	startAndCleanup(t, recv)
 }
 
+func startProfilesReceiver(t *testing.T, addr string, next consumer.Profiles) {
+	factory := otlpreceiver.NewFactory()
+	cfg := createReceiverConfig(addr, factory.CreateDefaultConfig())
+	recv, err := factory.CreateProfilesReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	startAndCleanup(t, recv)
+}
+
 func createReceiverConfig(addr string, defaultCfg component.Config) *otlpreceiver.Config {
 	cfg := defaultCfg.(*otlpreceiver.Config)
 	cfg.HTTP.Endpoint = addr
@@ -529,6 +590,62 @@ func TestErrorResponses(t *testing.T) {
 	}
 }
 
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		val      string
+		maxDelay time.Duration
+		want     time.Duration
+	}{
+		{name: "seconds", val: "30", want: 30 * time.Second},
+		{name: "zero", val: "0", want: 0},
+		{name: "negative", val: "-1", want: 0},
+		{name: "date", val: "Thu, 01 Jun 2023 12:01:30 GMT", want: 90 * time.Second},
+		{name: "past_date", val: "Thu, 01 Jun 2023 11:59:00 GMT", want: 0},
+		{name: "invalid", val: "soon", want: 0},
+		{name: "seconds_capped", val: "86400", maxDelay: 5 * time.Minute, want: 5 * time.Minute},
+		{name: "date_capped", val: "Fri, 02 Jun 2023 12:00:00 GMT", maxDelay: 5 * time.Minute, want: 5 * time.Minute},
+		{name: "below_cap", val: "30", maxDelay: 5 * time.Minute, want: 30 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseRetryAfter(tt.val, now, tt.maxDelay))
+		})
+	}
+}
+
+func TestPartialSuccess(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := ptraceotlp.NewExportResponse()
+		partial := response.PartialSuccess()
+		partial.SetErrorMessage("invalid span")
+		partial.SetRejectedSpans(1)
+		protoBytes, err := response.MarshalProto()
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write(protoBytes)
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	cfg := &Config{
+		TracesEndpoint: srv.URL + "/v1/traces",
+		// Create without QueueSettings and RetrySettings so that ConsumeTraces
+		// returns the errors that we want to check immediately.
+	}
+	exp, err := createTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	startAndCleanup(t, exp)
+
+	err = exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(2))
+	assert.True(t, consumererror.IsPermanent(err))
+	var partialErr consumererror.PartialError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, 1, partialErr.Rejected())
+}
+
 func TestUserAgent(t *testing.T) {
 	addr := testutil.GetAvailableLocalAddress(t)
 	set := exportertest.NewNopCreateSettings()
@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter // import "go.opentelemetry.io/collector/exporter"
+
+import (
+	"context"
+	"time"
+)
+
+type drainTimeoutKey struct{}
+
+// ContextWithDrainTimeout returns a context to shut down an exporter, which is given the timeout
+// to send its queued data before dropping it.
+func ContextWithDrainTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, drainTimeoutKey{}, timeout)
+}
+
+// DrainTimeoutFromContext returns the drain timeout of the context an exporter is shut down with,
+// 0 if it is not set, in which case the queued data is not drained.
+func DrainTimeoutFromContext(ctx context.Context) time.Duration {
+	timeout, _ := ctx.Value(drainTimeoutKey{}).(time.Duration)
+	return timeout
+}
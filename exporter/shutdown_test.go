@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainTimeoutFromContext(t *testing.T) {
+	assert.Zero(t, DrainTimeoutFromContext(context.Background()))
+	ctx := ContextWithDrainTimeout(context.Background(), time.Second)
+	assert.Equal(t, time.Second, DrainTimeoutFromContext(ctx))
+}
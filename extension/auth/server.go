@@ -6,6 +6,7 @@ package auth // import "go.opentelemetry.io/collector/extension/auth"
 import (
 	"context"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
 )
@@ -29,10 +30,34 @@ type Server interface {
 	Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error)
 }
 
+// ClaimsServer is a Server which returns the structured claims about the clients it authenticates, such as their
+// tenant and their scopes. The claims are attached to the client.Info of the requests by the receivers, which
+// authorize the requests against them, see configauth.Authentication.
+type ClaimsServer interface {
+	Server
+
+	// AuthenticateClaims authenticates the request as done by Authenticate, and returns the claims about the
+	// authenticated client, nil if there are none.
+	AuthenticateClaims(ctx context.Context, headers map[string][]string) (context.Context, *client.Claims, error)
+}
+
 type defaultServer struct {
 	ServerAuthenticateFunc
 	component.StartFunc
 	component.ShutdownFunc
+	authenticateClaims ServerAuthenticateClaimsFunc
+}
+
+// defaultClaimsServer is the Server returned by NewServer when WithServerAuthenticateClaims is given.
+type defaultClaimsServer struct {
+	*defaultServer
+}
+
+var _ ClaimsServer = (*defaultClaimsServer)(nil)
+
+// AuthenticateClaims authenticates the request with the function given to WithServerAuthenticateClaims.
+func (s *defaultClaimsServer) AuthenticateClaims(ctx context.Context, headers map[string][]string) (context.Context, *client.Claims, error) {
+	return s.authenticateClaims(ctx, headers)
 }
 
 // ServerOption represents the possible options for NewServer.
@@ -56,6 +81,23 @@ func WithServerAuthenticate(authFunc ServerAuthenticateFunc) ServerOption {
 	}
 }
 
+// ServerAuthenticateClaimsFunc defines the signature for the function responsible for performing the authentication
+// based on the given headers map, and returning the claims about the client. See ClaimsServer.AuthenticateClaims.
+type ServerAuthenticateClaimsFunc func(ctx context.Context, headers map[string][]string) (context.Context, *client.Claims, error)
+
+// WithServerAuthenticateClaims specifies which function to use to perform the authentication and return the claims
+// about the client. It is used by both Authenticate and AuthenticateClaims, the Server returned by NewServer
+// implements ClaimsServer only if it is given.
+func WithServerAuthenticateClaims(authFunc ServerAuthenticateClaimsFunc) ServerOption {
+	return func(o *defaultServer) {
+		o.authenticateClaims = authFunc
+		o.ServerAuthenticateFunc = func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+			ctx, _, err := authFunc(ctx, headers)
+			return ctx, err
+		}
+	}
+}
+
 // WithServerStart overrides the default `Start` function for a component.Component.
 // The default always returns nil.
 func WithServerStart(startFunc component.StartFunc) ServerOption {
@@ -72,7 +114,8 @@ func WithServerShutdown(shutdownFunc component.ShutdownFunc) ServerOption {
 	}
 }
 
-// NewServer returns a Server configured with the provided options.
+// NewServer returns a Server configured with the provided options, which is a ClaimsServer
+// if WithServerAuthenticateClaims is given.
 func NewServer(options ...ServerOption) Server {
 	bc := &defaultServer{}
 
@@ -80,5 +123,8 @@ func NewServer(options ...ServerOption) Server {
 		op(bc)
 	}
 
+	if bc.authenticateClaims != nil {
+		return &defaultClaimsServer{defaultServer: bc}
+	}
 	return bc
 }
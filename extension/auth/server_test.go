@@ -5,10 +5,12 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 )
@@ -53,6 +55,42 @@ func TestWithServerAuthenticateFunc(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestWithServerAuthenticateClaimsFunc(t *testing.T) {
+	// prepare
+	expected := &client.Claims{Subject: "agent", Tenant: "tenant-a", Scopes: []string{"traces:write"}}
+	e := NewServer(
+		WithServerAuthenticateClaims(func(ctx context.Context, headers map[string][]string) (context.Context, *client.Claims, error) {
+			if len(headers["authorization"]) == 0 {
+				return ctx, nil, errors.New("missing the authorization header")
+			}
+			return ctx, expected, nil
+		}),
+	)
+	claimsServer, ok := e.(ClaimsServer)
+	assert.True(t, ok)
+
+	// test
+	_, claims, err := claimsServer.AuthenticateClaims(context.Background(), map[string][]string{"authorization": {"token"}})
+
+	// verify
+	assert.NoError(t, err)
+	assert.Equal(t, expected, claims)
+	_, err = e.Authenticate(context.Background(), map[string][]string{})
+	assert.EqualError(t, err, "missing the authorization header")
+}
+
+func TestNewServerWithoutClaims(t *testing.T) {
+	e := NewServer(
+		WithServerAuthenticate(func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+			return ctx, nil
+		}),
+	)
+
+	// The claims are only returned by the servers given WithServerAuthenticateClaims.
+	_, ok := e.(ClaimsServer)
+	assert.False(t, ok)
+}
+
 func TestWithServerStart(t *testing.T) {
 	called := false
 	e := NewServer(WithServerStart(func(c context.Context, h component.Host) error {
@@ -22,6 +22,8 @@ type memoryBallast struct {
 }
 
 func (m *memoryBallast) Start(_ context.Context, _ component.Host) error {
+	m.logger.Warn("The memory_ballast extension is deprecated, use service::soft_memory_limit instead")
+
 	// absolute value supersedes percentage setting
 	if m.cfg.SizeMiB > 0 {
 		m.ballastSizeBytes = m.cfg.SizeMiB * megaBytes
@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bearertokenauthextension // import "go.opentelemetry.io/collector/extension/bearertokenauthextension"
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/auth"
+)
+
+var (
+	errNoTokens      = errors.New("no token found in the file")
+	errMissingHeader = errors.New("missing authorization header")
+	errInvalidToken  = errors.New("invalid bearer token")
+)
+
+var (
+	_ auth.Client = (*bearerTokenAuth)(nil)
+	_ auth.Server = (*bearerTokenAuth)(nil)
+)
+
+// bearerTokenAuth authenticates the requests with the tokens read from a file,
+// which is read again whenever its content changes.
+type bearerTokenAuth struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	tokens atomic.Pointer[[]string]
+
+	// content is the last content read from the file, only accessed by Start and the reload loop.
+	content []byte
+
+	shutdownCh chan struct{}
+	wg         sync.WaitGroup
+}
+
+func newBearerTokenAuth(cfg *Config, logger *zap.Logger) *bearerTokenAuth {
+	return &bearerTokenAuth{
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+func (b *bearerTokenAuth) Start(_ context.Context, _ component.Host) error {
+	if _, err := b.reload(); err != nil {
+		return fmt.Errorf("failed to read the tokens from %q: %w", b.cfg.Filename, err)
+	}
+
+	b.shutdownCh = make(chan struct{})
+	b.wg.Add(1)
+	go b.reloadLoop()
+	return nil
+}
+
+func (b *bearerTokenAuth) Shutdown(context.Context) error {
+	if b.shutdownCh == nil {
+		return nil
+	}
+	close(b.shutdownCh)
+	b.wg.Wait()
+	b.shutdownCh = nil
+	return nil
+}
+
+func (b *bearerTokenAuth) reloadLoop() {
+	defer b.wg.Done()
+	ticker := time.NewTicker(b.cfg.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			changed, err := b.reload()
+			if err != nil {
+				// Keep the current tokens, the file may be in the middle of being rotated.
+				b.logger.Warn("Failed to reload the bearer tokens, keeping the current ones", zap.String("filename", b.cfg.Filename), zap.Error(err))
+				continue
+			}
+			if changed {
+				b.logger.Info("Reloaded the bearer tokens", zap.String("filename", b.cfg.Filename))
+			}
+		case <-b.shutdownCh:
+			return
+		}
+	}
+}
+
+// reload reads the file and replaces the tokens if its content changed.
+func (b *bearerTokenAuth) reload() (bool, error) {
+	content, err := os.ReadFile(b.cfg.Filename)
+	if err != nil {
+		return false, err
+	}
+	if b.tokens.Load() != nil && bytes.Equal(content, b.content) {
+		return false, nil
+	}
+	tokens := parseTokens(content)
+	if len(tokens) == 0 {
+		return false, errNoTokens
+	}
+	b.content = content
+	b.tokens.Store(&tokens)
+	return true, nil
+}
+
+// parseTokens returns the non-empty lines of content.
+func parseTokens(content []byte) []string {
+	var tokens []string
+	for _, line := range strings.Split(string(content), "\n") {
+		if token := strings.TrimSpace(line); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// authorization returns the value of the authorization header sent by the client.
+func (b *bearerTokenAuth) authorization() string {
+	tokens := b.tokens.Load()
+	if tokens == nil {
+		return ""
+	}
+	return b.cfg.Scheme + " " + (*tokens)[0]
+}
+
+// Authenticate checks that the authorization header holds one of the tokens.
+func (b *bearerTokenAuth) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	values, ok := headers["authorization"]
+	if !ok {
+		values, ok = headers["Authorization"]
+	}
+	if !ok || len(values) == 0 {
+		return ctx, errMissingHeader
+	}
+
+	tokens := b.tokens.Load()
+	if tokens == nil {
+		return ctx, errInvalidToken
+	}
+	for _, token := range *tokens {
+		if subtle.ConstantTimeCompare([]byte(values[0]), []byte(b.cfg.Scheme+" "+token)) == 1 {
+			return ctx, nil
+		}
+	}
+	return ctx, errInvalidToken
+}
+
+// RoundTripper returns a RoundTripper adding the authorization header to the HTTP requests.
+func (b *bearerTokenAuth) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return &bearerAuthRoundTripper{base: base, auth: b}, nil
+}
+
+// PerRPCCredentials returns the credentials adding the authorization header to the gRPC requests.
+func (b *bearerTokenAuth) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
+	return &perRPCAuth{auth: b}, nil
+}
+
+type bearerAuthRoundTripper struct {
+	base http.RoundTripper
+	auth *bearerTokenAuth
+}
+
+func (rt *bearerAuthRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the request.
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", rt.auth.authorization())
+	return rt.base.RoundTrip(req)
+}
+
+type perRPCAuth struct {
+	auth *bearerTokenAuth
+}
+
+func (c *perRPCAuth) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": c.auth.authorization()}, nil
+}
+
+func (c *perRPCAuth) RequireTransportSecurity() bool {
+	return true
+}
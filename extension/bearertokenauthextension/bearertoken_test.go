@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bearertokenauthextension
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func writeTokens(t *testing.T, filename, content string) {
+	require.NoError(t, os.WriteFile(filename, []byte(content), 0600))
+}
+
+func newTestAuth(t *testing.T, content string) (*bearerTokenAuth, string) {
+	filename := filepath.Join(t.TempDir(), "token")
+	writeTokens(t, filename, content)
+	b := newBearerTokenAuth(&Config{Scheme: defaultScheme, Filename: filename, ReloadInterval: 10 * time.Millisecond}, zap.NewNop())
+	require.NoError(t, b.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, b.Shutdown(context.Background())) })
+	return b, filename
+}
+
+func TestStartMissingFile(t *testing.T) {
+	b := newBearerTokenAuth(&Config{Scheme: defaultScheme, Filename: filepath.Join(t.TempDir(), "missing"), ReloadInterval: time.Second}, zap.NewNop())
+	assert.Error(t, b.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, b.Shutdown(context.Background()))
+}
+
+func TestStartEmptyFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "token")
+	writeTokens(t, filename, "\n  \n")
+	b := newBearerTokenAuth(&Config{Scheme: defaultScheme, Filename: filename, ReloadInterval: time.Second}, zap.NewNop())
+	assert.ErrorIs(t, b.Start(context.Background(), componenttest.NewNopHost()), errNoTokens)
+}
+
+func TestAuthenticate(t *testing.T) {
+	b, _ := newTestAuth(t, "current\nprevious\n")
+
+	tests := []struct {
+		name    string
+		headers map[string][]string
+		err     error
+	}{
+		{
+			name:    "grpc",
+			headers: map[string][]string{"authorization": {"Bearer current"}},
+		},
+		{
+			name:    "http",
+			headers: map[string][]string{"Authorization": {"Bearer current"}},
+		},
+		{
+			name:    "previous",
+			headers: map[string][]string{"authorization": {"Bearer previous"}},
+		},
+		{
+			name:    "missing",
+			headers: map[string][]string{},
+			err:     errMissingHeader,
+		},
+		{
+			name:    "invalid",
+			headers: map[string][]string{"authorization": {"Bearer other"}},
+			err:     errInvalidToken,
+		},
+		{
+			name:    "scheme",
+			headers: map[string][]string{"authorization": {"Basic current"}},
+			err:     errInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := b.Authenticate(context.Background(), tt.headers)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	b, filename := newTestAuth(t, "first")
+
+	_, err := b.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer first"}})
+	require.NoError(t, err)
+
+	writeTokens(t, filename, "second")
+	assert.Eventually(t, func() bool {
+		_, err = b.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer second"}})
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = b.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer first"}})
+	assert.ErrorIs(t, err, errInvalidToken)
+
+	// The current tokens are kept while the file is missing.
+	require.NoError(t, os.Remove(filename))
+	time.Sleep(50 * time.Millisecond)
+	_, err = b.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer second"}})
+	assert.NoError(t, err)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRoundTripper(t *testing.T) {
+	b, filename := newTestAuth(t, "first\nsecond")
+
+	var got string
+	rt, err := b.RoundTripper(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		got = req.Header.Get("Authorization")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}))
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer first", got)
+	assert.Empty(t, req.Header.Get("Authorization"))
+
+	writeTokens(t, filename, "rotated")
+	assert.Eventually(t, func() bool {
+		_, err = rt.RoundTrip(req)
+		return err == nil && got == "Bearer rotated"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestPerRPCCredentials(t *testing.T) {
+	b, _ := newTestAuth(t, "token")
+
+	creds, err := b.PerRPCCredentials()
+	require.NoError(t, err)
+	assert.True(t, creds.RequireTransportSecurity())
+	md, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer token"}, md)
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bearertokenauthextension // import "go.opentelemetry.io/collector/extension/bearertokenauthextension"
+
+import (
+	"errors"
+	"time"
+)
+
+// Config has the configuration for the bearer token authenticator extension.
+type Config struct {
+	// Scheme is the authentication scheme sent before the token, "Bearer" by default.
+	Scheme string `mapstructure:"scheme"`
+
+	// Filename is the path of the file holding the tokens, one per line.
+	// The client sends the first token, the server accepts any of them.
+	Filename string `mapstructure:"filename"`
+
+	// ReloadInterval is how often the file is checked for changes.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Filename == "" {
+		return errors.New("filename must be specified")
+	}
+	if cfg.ReloadInterval <= 0 {
+		return errors.New("reload_interval must be positive")
+	}
+	return nil
+}
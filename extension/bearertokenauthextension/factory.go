@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bearertokenauthextension // import "go.opentelemetry.io/collector/extension/bearertokenauthextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+const (
+	// The value of extension "type" in configuration.
+	typeStr = "bearertokenauth"
+
+	defaultScheme         = "Bearer"
+	defaultReloadInterval = 10 * time.Second
+)
+
+// NewFactory creates a factory for the bearer token authenticator extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(typeStr, createDefaultConfig, createExtension, component.StabilityLevelAlpha)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Scheme:         defaultScheme,
+		ReloadInterval: defaultReloadInterval,
+	}
+}
+
+func createExtension(_ context.Context, set extension.CreateSettings, cfg component.Config) (extension.Extension, error) {
+	return newBearerTokenAuth(cfg.(*Config), set.TelemetrySettings.Logger), nil
+}
@@ -32,6 +32,33 @@ type PipelineWatcher interface {
 	NotReady() error
 }
 
+// StatusWatcher is an extra interface for Extension hosted by the OpenTelemetry
+// Collector that is to be implemented by extensions interested in the status of the
+// components and of the pipelines, e.g.: a health check.
+//
+// The methods are called synchronously in the order the statuses change, so they must
+// return quickly and must not report statuses themselves.
+type StatusWatcher interface {
+	// ComponentStatusChanged notifies the Extension that a component instance reported a status.
+	ComponentStatusChanged(source *component.InstanceID, event *component.StatusEvent)
+
+	// PipelineStatusChanged notifies the Extension that the status of a pipeline, aggregated
+	// from the statuses of its components, changed.
+	PipelineStatusChanged(pipelineID component.ID, event *component.StatusEvent)
+}
+
+// Dependent is an extra interface for Extension hosted by the OpenTelemetry Collector that
+// is to be implemented by extensions using other extensions, e.g.: an authenticator reading
+// its credentials from a secrets extension.
+//
+// The service starts the extensions an extension depends on before it, and shuts them
+// down after it.
+type Dependent interface {
+	// Dependencies returns the IDs of the extensions the Extension depends on, which must
+	// be enabled in the service.
+	Dependencies() []component.ID
+}
+
 // CreateSettings is passed to Factory.Create(...) function.
 type CreateSettings struct {
 	// ID returns the ID of the component that will be created.
@@ -76,6 +103,11 @@ func (f *factory) Type() component.Type {
 
 func (f *factory) unexportedFactoryFunc() {}
 
+// Capabilities returns no capabilities, the extensions are not part of the pipelines.
+func (f *factory) Capabilities() component.FactoryCapabilities {
+	return component.FactoryCapabilities{}
+}
+
 func (f *factory) ExtensionStability() component.StabilityLevel {
 	return f.extensionStability
 }
@@ -139,6 +171,11 @@ func (b *Builder) Create(ctx context.Context, set CreateSettings) (Extension, er
 	return f.CreateExtension(ctx, set, cfg)
 }
 
+// Config returns the configuration of the extension with the given ID, or nil if it is not configured.
+func (b *Builder) Config(id component.ID) component.Config {
+	return b.cfgs[id]
+}
+
 func (b *Builder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
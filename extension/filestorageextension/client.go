@@ -0,0 +1,262 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+const mib = 1 << 20
+
+var (
+	defaultBucket = []byte("default")
+
+	errClientClosed       = errors.New("storage client is closed")
+	errUnknownOperation   = errors.New("unknown storage operation")
+	errBucketNotAvailable = errors.New("storage bucket is not available")
+)
+
+// fileStorageClient is a storage.Client keeping its data in a bbolt file.
+type fileStorageClient struct {
+	logger   *zap.Logger
+	filename string
+	cfg      *Config
+
+	// mu is held for writing while the file is compacted, since the database is replaced.
+	mu     sync.RWMutex
+	db     *bbolt.DB
+	closed bool
+
+	// compactionNeeded is set once the file is big enough to be compacted on rebound.
+	compactionNeeded bool
+	stopCompaction   context.CancelFunc
+	compactionDone   chan struct{}
+}
+
+var _ storage.Client = (*fileStorageClient)(nil)
+
+func newClient(logger *zap.Logger, filename string, cfg *Config) (*fileStorageClient, error) {
+	db, err := openDB(filename, cfg)
+	if err != nil {
+		return nil, err
+	}
+	client := &fileStorageClient{logger: logger, filename: filename, cfg: cfg, db: db}
+	if cfg.Compaction.OnRebound {
+		var ctx context.Context
+		ctx, client.stopCompaction = context.WithCancel(context.Background())
+		client.compactionDone = make(chan struct{})
+		go client.compactOnRebound(ctx)
+	}
+	return client, nil
+}
+
+func openDB(filename string, cfg *Config) (*bbolt.DB, error) {
+	db, err := bbolt.Open(filename, 0600, &bbolt.Options{
+		Timeout:        cfg.Timeout,
+		NoSync:         !cfg.FSync,
+		NoFreelistSync: true,
+		FreelistType:   bbolt.FreelistMapType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the storage file %q: %w", filename, err)
+	}
+	if err = db.Update(func(tx *bbolt.Tx) error {
+		_, bucketErr := tx.CreateBucketIfNotExists(defaultBucket)
+		return bucketErr
+	}); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Get returns the value of the key, nil if the key is not found.
+func (c *fileStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	if err := c.Batch(ctx, op); err != nil {
+		return nil, err
+	}
+	return op.Value, nil
+}
+
+// Set stores the value of the key.
+func (c *fileStorageClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+// Delete deletes the key.
+func (c *fileStorageClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+// Batch applies the operations in a single transaction.
+func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return errClientClosed
+	}
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(defaultBucket)
+		if bucket == nil {
+			return errBucketNotAvailable
+		}
+		for _, op := range ops {
+			var err error
+			switch op.Type {
+			case storage.Get:
+				// The value is only valid during the transaction.
+				if value := bucket.Get([]byte(op.Key)); value != nil {
+					op.Value = append([]byte(nil), value...)
+				} else {
+					op.Value = nil
+				}
+			case storage.Set:
+				err = bucket.Put([]byte(op.Key), op.Value)
+			case storage.Delete:
+				err = bucket.Delete([]byte(op.Key))
+			default:
+				err = errUnknownOperation
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Close stops the compaction on rebound and closes the file.
+func (c *fileStorageClient) Close(context.Context) error {
+	if c.stopCompaction != nil {
+		c.stopCompaction()
+		<-c.compactionDone
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	return c.db.Close()
+}
+
+// compact copies the data to a new file in the compaction directory, which then replaces the file,
+// releasing the space of the deleted data.
+func (c *fileStorageClient) compact() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return errClientClosed
+	}
+
+	dir := c.cfg.Compaction.Directory
+	if dir == "" {
+		dir = c.cfg.Directory
+	}
+	tmp, err := os.CreateTemp(dir, "compaction-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	compacted, err := bbolt.Open(tmpName, 0600, &bbolt.Options{Timeout: c.cfg.Timeout, NoSync: true})
+	if err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+	if err = bbolt.Compact(compacted, c.db, c.cfg.Compaction.MaxTransactionSize); err != nil {
+		_ = compacted.Close()
+		_ = os.Remove(tmpName)
+		return err
+	}
+	if err = compacted.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+
+	if err = c.db.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+	renameErr := os.Rename(tmpName, c.filename)
+	if renameErr != nil {
+		_ = os.Remove(tmpName)
+	}
+	// The file is opened again even if it could not be replaced, so that the client keeps working.
+	if c.db, err = openDB(c.filename, c.cfg); err != nil {
+		c.closed = true
+		return multierr.Append(renameErr, err)
+	}
+	return renameErr
+}
+
+// compactOnRebound compacts the file once its size rose above the needed threshold, and the size
+// of its data then fell below the trigger threshold, checking the sizes at every check interval.
+func (c *fileStorageClient) compactOnRebound(ctx context.Context) {
+	defer close(c.compactionDone)
+	ticker := time.NewTicker(c.cfg.Compaction.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if !c.shouldCompact() {
+			continue
+		}
+		c.logger.Info("Compacting the file of the storage client on rebound")
+		if err := c.compact(); err != nil {
+			c.logger.Error("Failed to compact the file of the storage client", zap.Error(err))
+		}
+	}
+}
+
+// shouldCompact returns whether the file must be compacted on rebound.
+func (c *fileStorageClient) shouldCompact() bool {
+	totalSize, dataSize, err := c.sizes()
+	if err != nil {
+		c.logger.Warn("Failed to get the size of the storage file", zap.Error(err))
+		return false
+	}
+	if totalSize >= c.cfg.Compaction.ReboundNeededThresholdMiB*mib {
+		c.compactionNeeded = true
+	}
+	if c.compactionNeeded && dataSize < c.cfg.Compaction.ReboundTriggerThresholdMiB*mib {
+		c.compactionNeeded = false
+		return true
+	}
+	return false
+}
+
+// sizes returns the size of the file, and the size of the pages which are in use.
+func (c *fileStorageClient) sizes() (totalSize, dataSize int64, err error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return 0, 0, errClientClosed
+	}
+	err = c.db.View(func(tx *bbolt.Tx) error {
+		// The statistics are read in the transaction, which prevents the file from being remapped.
+		stats := tx.DB().Stats()
+		totalSize = tx.Size()
+		dataSize = totalSize - int64(stats.FreePageN+stats.PendingPageN)*int64(tx.DB().Info().PageSize)
+		return nil
+	})
+	return totalSize, dataSize, err
+}
@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func newTestClient(t *testing.T, modify func(cfg *Config)) *fileStorageClient {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	modify(cfg)
+	client, err := newClient(zap.NewNop(), filepath.Join(cfg.Directory, "test"), cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, client.Close(context.Background())) })
+	return client
+}
+
+// fillAndDelete writes about 2MiB of data in the client and deletes it, except for the "kept" key.
+func fillAndDelete(t *testing.T, client *fileStorageClient) {
+	ctx := context.Background()
+	value := make([]byte, 1024)
+	for i := 0; i < 2048; i++ {
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("key%d", i), value))
+	}
+	require.NoError(t, client.Set(ctx, "kept", []byte("value")))
+	for i := 0; i < 2048; i++ {
+		require.NoError(t, client.Delete(ctx, fmt.Sprintf("key%d", i)))
+	}
+}
+
+func fileSize(t *testing.T, filename string) int64 {
+	info, err := os.Stat(filename)
+	require.NoError(t, err)
+	return info.Size()
+}
+
+func TestClientOperations(t *testing.T) {
+	client := newTestClient(t, func(*Config) {})
+	ctx := context.Background()
+
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	require.NoError(t, client.Delete(ctx, "key"))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestClientBatch(t *testing.T) {
+	client := newTestClient(t, func(*Config) {})
+	ctx := context.Background()
+	require.NoError(t, client.Set(ctx, "deleted", []byte("value")))
+
+	get := storage.GetOperation("set")
+	getDeleted := storage.GetOperation("deleted")
+	require.NoError(t, client.Batch(ctx,
+		storage.SetOperation("set", []byte("value")),
+		storage.DeleteOperation("deleted"),
+		get,
+		getDeleted))
+	assert.Equal(t, []byte("value"), get.Value)
+	assert.Nil(t, getDeleted.Value)
+}
+
+func TestClientClosed(t *testing.T) {
+	client := newTestClient(t, func(*Config) {})
+	ctx := context.Background()
+	require.NoError(t, client.Close(ctx))
+
+	_, err := client.Get(ctx, "key")
+	assert.ErrorIs(t, err, errClientClosed)
+	assert.ErrorIs(t, client.Set(ctx, "key", nil), errClientClosed)
+	assert.ErrorIs(t, client.compact(), errClientClosed)
+}
+
+func TestClientCompact(t *testing.T) {
+	compactionDir := t.TempDir()
+	client := newTestClient(t, func(cfg *Config) { cfg.Compaction.Directory = compactionDir })
+	fillAndDelete(t, client)
+	sizeBefore := fileSize(t, client.filename)
+
+	require.NoError(t, client.compact())
+	assert.Less(t, fileSize(t, client.filename), sizeBefore)
+
+	value, err := client.Get(context.Background(), "kept")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	entries, err := os.ReadDir(compactionDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestClientCompactsOnRebound(t *testing.T) {
+	client := newTestClient(t, func(*Config) {})
+	fillAndDelete(t, client)
+	require.NoError(t, client.Close(context.Background()))
+	sizeBefore := fileSize(t, client.filename)
+
+	cfg := *client.cfg
+	cfg.Compaction.OnRebound = true
+	cfg.Compaction.ReboundNeededThresholdMiB = 1
+	cfg.Compaction.ReboundTriggerThresholdMiB = 1
+	cfg.Compaction.CheckInterval = 10 * time.Millisecond
+	client, err := newClient(zap.NewNop(), client.filename, &cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, client.Close(context.Background())) })
+
+	// The file is above the needed threshold, and its data is below the trigger threshold.
+	assert.Eventually(t, func() bool {
+		return fileSize(t, client.filename) < sizeBefore
+	}, 5*time.Second, 10*time.Millisecond)
+
+	value, err := client.Get(context.Background(), "kept")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestClientShouldCompact(t *testing.T) {
+	client := newTestClient(t, func(cfg *Config) {
+		cfg.Compaction.ReboundNeededThresholdMiB = 1
+		cfg.Compaction.ReboundTriggerThresholdMiB = 1
+	})
+	// The compaction is not needed while the file is below the needed threshold.
+	assert.False(t, client.shouldCompact())
+
+	ctx := context.Background()
+	value := make([]byte, 1024)
+	for i := 0; i < 2048; i++ {
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("key%d", i), value))
+	}
+	// The compaction is needed, but the data is above the trigger threshold.
+	assert.False(t, client.shouldCompact())
+	assert.True(t, client.compactionNeeded)
+
+	for i := 0; i < 2048; i++ {
+		require.NoError(t, client.Delete(ctx, fmt.Sprintf("key%d", i)))
+	}
+	assert.True(t, client.shouldCompact())
+	assert.False(t, client.compactionNeeded)
+}
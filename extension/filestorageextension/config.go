@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Config defines the configuration of the file storage extension.
+type Config struct {
+	// Directory is the directory the files of the storage clients are kept in. It must exist.
+	Directory string `mapstructure:"directory"`
+
+	// Timeout is how long opening the file of a client waits for the lock of the file.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Compaction configures the compaction of the files, which releases the space of the deleted data.
+	Compaction CompactionConfig `mapstructure:"compaction"`
+
+	// FSync syncs the file to disk after every write. It protects the data against a crash of the
+	// operating system at the expense of the throughput.
+	FSync bool `mapstructure:"fsync"`
+}
+
+// CompactionConfig defines when and how the files are compacted.
+type CompactionConfig struct {
+	// OnStart compacts the files when the clients are created.
+	OnStart bool `mapstructure:"on_start"`
+
+	// OnRebound compacts the files while the collector runs, once their size rose above
+	// ReboundNeededThresholdMiB and the size of their data fell below ReboundTriggerThresholdMiB.
+	OnRebound bool `mapstructure:"on_rebound"`
+
+	// Directory is the directory the compacted files are written to before they replace the files.
+	// It must exist, it should be on the same file system as the storage directory, which it defaults to.
+	Directory string `mapstructure:"directory"`
+
+	// MaxTransactionSize is the maximum number of items copied in a single transaction of the compaction,
+	// 0 means no limit.
+	MaxTransactionSize int64 `mapstructure:"max_transaction_size"`
+
+	// ReboundNeededThresholdMiB is the size of the file above which a compaction on rebound is needed.
+	ReboundNeededThresholdMiB int64 `mapstructure:"rebound_needed_threshold_mib"`
+
+	// ReboundTriggerThresholdMiB is the size of the data below which the needed compaction on rebound happens.
+	ReboundTriggerThresholdMiB int64 `mapstructure:"rebound_trigger_threshold_mib"`
+
+	// CheckInterval is how often the sizes of the files are checked for a compaction on rebound.
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+}
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if err := checkDirectory(cfg.Directory); err != nil {
+		return fmt.Errorf("directory: %w", err)
+	}
+	if cfg.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	return cfg.Compaction.validate()
+}
+
+func (cfg *CompactionConfig) validate() error {
+	if (cfg.OnStart || cfg.OnRebound) && cfg.Directory != "" {
+		if err := checkDirectory(cfg.Directory); err != nil {
+			return fmt.Errorf("compaction::directory: %w", err)
+		}
+	}
+	if cfg.MaxTransactionSize < 0 {
+		return errors.New("compaction::max_transaction_size must not be negative")
+	}
+	if !cfg.OnRebound {
+		return nil
+	}
+	if cfg.ReboundNeededThresholdMiB <= 0 || cfg.ReboundTriggerThresholdMiB <= 0 {
+		return errors.New("compaction::rebound_needed_threshold_mib and compaction::rebound_trigger_threshold_mib must be positive")
+	}
+	if cfg.ReboundTriggerThresholdMiB > cfg.ReboundNeededThresholdMiB {
+		return errors.New("compaction::rebound_trigger_threshold_mib must not be greater than compaction::rebound_needed_threshold_mib")
+	}
+	if cfg.CheckInterval <= 0 {
+		return errors.New("compaction::check_interval must be positive")
+	}
+	return nil
+}
+
+func checkDirectory(dir string) error {
+	if dir == "" {
+		return errors.New("must be specified")
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%q is not a directory", dir)
+	}
+	return nil
+}
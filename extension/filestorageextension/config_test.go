@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			Directory: "/var/lib/otelcol/storage",
+			Timeout:   2 * time.Second,
+			FSync:     true,
+			Compaction: CompactionConfig{
+				OnStart:                    true,
+				OnRebound:                  true,
+				Directory:                  "/tmp",
+				MaxTransactionSize:         1024,
+				ReboundNeededThresholdMiB:  50,
+				ReboundTriggerThresholdMiB: 5,
+				CheckInterval:              10 * time.Second,
+			},
+		}, cfg)
+}
+
+func TestConfigValidate(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0600))
+
+	tests := []struct {
+		name        string
+		modify      func(cfg *Config)
+		expectedErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name: "compaction",
+			modify: func(cfg *Config) {
+				cfg.Compaction.OnStart = true
+				cfg.Compaction.OnRebound = true
+				cfg.Compaction.Directory = dir
+			},
+		},
+		{
+			name:        "missing directory",
+			modify:      func(cfg *Config) { cfg.Directory = "" },
+			expectedErr: "directory: must be specified",
+		},
+		{
+			name:        "directory is a file",
+			modify:      func(cfg *Config) { cfg.Directory = file },
+			expectedErr: "directory: " + `"` + file + `" is not a directory`,
+		},
+		{
+			name:        "negative timeout",
+			modify:      func(cfg *Config) { cfg.Timeout = -time.Second },
+			expectedErr: "timeout must not be negative",
+		},
+		{
+			name: "compaction directory is a file",
+			modify: func(cfg *Config) {
+				cfg.Compaction.OnStart = true
+				cfg.Compaction.Directory = file
+			},
+			expectedErr: "compaction::directory: " + `"` + file + `" is not a directory`,
+		},
+		{
+			name:        "negative max transaction size",
+			modify:      func(cfg *Config) { cfg.Compaction.MaxTransactionSize = -1 },
+			expectedErr: "compaction::max_transaction_size must not be negative",
+		},
+		{
+			name: "zero threshold",
+			modify: func(cfg *Config) {
+				cfg.Compaction.OnRebound = true
+				cfg.Compaction.ReboundTriggerThresholdMiB = 0
+			},
+			expectedErr: "compaction::rebound_needed_threshold_mib and compaction::rebound_trigger_threshold_mib must be positive",
+		},
+		{
+			name: "trigger threshold greater than needed threshold",
+			modify: func(cfg *Config) {
+				cfg.Compaction.OnRebound = true
+				cfg.Compaction.ReboundTriggerThresholdMiB = 200
+			},
+			expectedErr: "compaction::rebound_trigger_threshold_mib must not be greater than compaction::rebound_needed_threshold_mib",
+		},
+		{
+			name: "zero check interval",
+			modify: func(cfg *Config) {
+				cfg.Compaction.OnRebound = true
+				cfg.Compaction.CheckInterval = 0
+			},
+			expectedErr: "compaction::check_interval must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Directory = dir
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+// localFileStorage is a storage extension keeping the data of every client in its own bbolt file.
+type localFileStorage struct {
+	cfg    *Config
+	logger *zap.Logger
+}
+
+var _ storage.Extension = (*localFileStorage)(nil)
+
+func newLocalFileStorage(cfg *Config, logger *zap.Logger) *localFileStorage {
+	return &localFileStorage{cfg: cfg, logger: logger}
+}
+
+// Start does nothing, the files are opened when the clients are created.
+func (lfs *localFileStorage) Start(context.Context, component.Host) error {
+	return nil
+}
+
+// Shutdown does nothing, the components close the clients they created.
+func (lfs *localFileStorage) Shutdown(context.Context) error {
+	return nil
+}
+
+// GetClient returns a client keeping its data in the file of the component and of the storage name,
+// which is compacted first if the compaction on start is enabled.
+func (lfs *localFileStorage) GetClient(_ context.Context, kind component.Kind, id component.ID, storageName string) (storage.Client, error) {
+	name := fmt.Sprintf("%s_%s", kindString(kind), id.Type())
+	if id.Name() != "" {
+		name += "_" + id.Name()
+	}
+	if storageName != "" {
+		name += "_" + storageName
+	}
+	filename := filepath.Join(lfs.cfg.Directory, sanitize(name))
+	logger := lfs.logger.With(zap.String("filename", filename))
+
+	client, err := newClient(logger, filename, lfs.cfg)
+	if err != nil {
+		return nil, err
+	}
+	if lfs.cfg.Compaction.OnStart {
+		logger.Info("Compacting the file of the storage client on start")
+		if err = client.compact(); err != nil {
+			logger.Error("Failed to compact the file of the storage client", zap.Error(err))
+		}
+	}
+	return client, nil
+}
+
+func kindString(kind component.Kind) string {
+	switch kind {
+	case component.KindReceiver:
+		return "receiver"
+	case component.KindProcessor:
+		return "processor"
+	case component.KindExporter:
+		return "exporter"
+	case component.KindExtension:
+		return "extension"
+	case component.KindConnector:
+		return "connector"
+	}
+	return "other"
+}
+
+// sanitize escapes the characters of the name which are not safe in a file name, as '~' followed by
+// their hexadecimal code, so that different names are kept in different files.
+func sanitize(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			b.WriteRune(r)
+		default:
+			fmt.Fprintf(&b, "~%04X", r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func newTestExtension(t *testing.T) *localFileStorage {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	ext := newLocalFileStorage(cfg, zap.NewNop())
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, ext.Shutdown(context.Background())) })
+	return ext
+}
+
+func TestGetClientFiles(t *testing.T) {
+	ext := newTestExtension(t)
+	ctx := context.Background()
+
+	clients := []struct {
+		kind        component.Kind
+		id          component.ID
+		storageName string
+	}{
+		{kind: component.KindReceiver, id: component.NewID("filelog")},
+		{kind: component.KindExporter, id: component.NewIDWithName("otlp", "backup")},
+		{kind: component.KindExporter, id: component.NewIDWithName("otlp", "backup"), storageName: "traces"},
+		{kind: component.KindProcessor, id: component.NewIDWithName("dedup", "a/b"), storageName: "../keys"},
+	}
+	for _, c := range clients {
+		client, err := ext.GetClient(ctx, c.kind, c.id, c.storageName)
+		require.NoError(t, err)
+		require.NoError(t, client.Close(ctx))
+	}
+
+	entries, err := os.ReadDir(ext.cfg.Directory)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.ElementsMatch(t, []string{
+		"receiver_filelog",
+		"exporter_otlp_backup",
+		"exporter_otlp_backup_traces",
+		"processor_dedup_a~002Fb_..~002Fkeys",
+	}, names)
+}
+
+func TestGetClientPersistence(t *testing.T) {
+	ext := newTestExtension(t)
+	ctx := context.Background()
+	id := component.NewID("otlp")
+
+	client, err := ext.GetClient(ctx, component.KindExporter, id, "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	require.NoError(t, client.Close(ctx))
+
+	client, err = ext.GetClient(ctx, component.KindExporter, id, "")
+	require.NoError(t, err)
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	// The clients of other components do not share the data.
+	other, err := ext.GetClient(ctx, component.KindReceiver, id, "")
+	require.NoError(t, err)
+	value, err = other.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, client.Close(ctx))
+	require.NoError(t, other.Close(ctx))
+}
+
+func TestGetClientCompactsOnStart(t *testing.T) {
+	ext := newTestExtension(t)
+	ext.cfg.Compaction.OnStart = true
+	ctx := context.Background()
+	id := component.NewID("otlp")
+
+	client, err := ext.GetClient(ctx, component.KindExporter, id, "")
+	require.NoError(t, err)
+	fillAndDelete(t, client.(*fileStorageClient))
+	require.NoError(t, client.Close(ctx))
+	filename := filepath.Join(ext.cfg.Directory, "exporter_otlp")
+	sizeBefore := fileSize(t, filename)
+
+	client, err = ext.GetClient(ctx, component.KindExporter, id, "")
+	require.NoError(t, err)
+	assert.Less(t, fileSize(t, filename), sizeBefore)
+	value, err := client.Get(ctx, "kept")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestSanitize(t *testing.T) {
+	assert.Equal(t, "exporter_otlp-1.a", sanitize("exporter_otlp-1.a"))
+	assert.Equal(t, "a~002Fb~005Cc~003A~00E9", sanitize(`a/b\c:é`))
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+const (
+	// The value of extension "type" in configuration.
+	typeStr = "local_file_storage"
+
+	defaultTimeout                    = time.Second
+	defaultMaxTransactionSize         = 65536
+	defaultReboundNeededThresholdMiB  = 100
+	defaultReboundTriggerThresholdMiB = 10
+	defaultCheckInterval              = 5 * time.Second
+)
+
+// NewFactory creates a factory for the file storage extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(typeStr, createDefaultConfig, createExtension, component.StabilityLevelDevelopment)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Directory: defaultDirectory(),
+		Timeout:   defaultTimeout,
+		Compaction: CompactionConfig{
+			MaxTransactionSize:         defaultMaxTransactionSize,
+			ReboundNeededThresholdMiB:  defaultReboundNeededThresholdMiB,
+			ReboundTriggerThresholdMiB: defaultReboundTriggerThresholdMiB,
+			CheckInterval:              defaultCheckInterval,
+		},
+	}
+}
+
+// defaultDirectory returns the default storage directory of the operating system.
+func defaultDirectory() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("ProgramData"), "Otelcol", "LocalFileStorage")
+	}
+	return "/var/lib/otelcol/local_file_storage"
+}
+
+func createExtension(_ context.Context, set extension.CreateSettings, cfg component.Config) (extension.Extension, error) {
+	return newLocalFileStorage(cfg.(*Config), set.TelemetrySettings.Logger), nil
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.Equal(t, &Config{
+		Directory: defaultDirectory(),
+		Timeout:   defaultTimeout,
+		Compaction: CompactionConfig{
+			MaxTransactionSize:         defaultMaxTransactionSize,
+			ReboundNeededThresholdMiB:  defaultReboundNeededThresholdMiB,
+			ReboundTriggerThresholdMiB: defaultReboundTriggerThresholdMiB,
+			CheckInterval:              defaultCheckInterval,
+		},
+	}, cfg)
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestFactory_CreateExtension(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	ext, err := createExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	assert.Implements(t, (*storage.Extension)(nil), ext)
+}
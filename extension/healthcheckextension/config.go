@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension // import "go.opentelemetry.io/collector/extension/healthcheckextension"
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+var (
+	errNoEndpoint        = errors.New("\"endpoint\" is required when using the \"healthcheck\" extension")
+	errInvalidPath       = errors.New("\"liveness_path\" and \"readiness_path\" must start with \"/\"")
+	errSamePaths         = errors.New("\"liveness_path\" and \"readiness_path\" must be different")
+	errNegativeThreshold = errors.New("\"recoverable_error_threshold\" must not be negative")
+)
+
+// Config has the configuration of the health check extension.
+type Config struct {
+	confighttp.HTTPServerSettings `mapstructure:",squash"`
+
+	// LivenessPath is the path serving the liveness of the collector.
+	LivenessPath string `mapstructure:"liveness_path"`
+
+	// ReadinessPath is the path serving the readiness of the collector.
+	ReadinessPath string `mapstructure:"readiness_path"`
+
+	// RecoverableErrorThreshold is how long a component can report a recoverable error
+	// before the collector, and the pipelines of the component, are not ready anymore.
+	// Zero makes them not ready as soon as the error is reported.
+	RecoverableErrorThreshold time.Duration `mapstructure:"recoverable_error_threshold"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errNoEndpoint
+	}
+	if !strings.HasPrefix(cfg.LivenessPath, "/") || !strings.HasPrefix(cfg.ReadinessPath, "/") {
+		return errInvalidPath
+	}
+	if cfg.LivenessPath == cfg.ReadinessPath {
+		return errSamePaths
+	}
+	if cfg.RecoverableErrorThreshold < 0 {
+		return errNegativeThreshold
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			HTTPServerSettings: confighttp.HTTPServerSettings{
+				Endpoint: "localhost:13134",
+			},
+			LivenessPath:              "/healthz",
+			ReadinessPath:             "/readyz",
+			RecoverableErrorThreshold: 30 * time.Second,
+		}, cfg)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(*Config)
+		expected error
+	}{
+		{
+			name:   "default",
+			mutate: func(*Config) {},
+		},
+		{
+			name:     "no endpoint",
+			mutate:   func(cfg *Config) { cfg.Endpoint = "" },
+			expected: errNoEndpoint,
+		},
+		{
+			name:     "relative path",
+			mutate:   func(cfg *Config) { cfg.ReadinessPath = "ready" },
+			expected: errInvalidPath,
+		},
+		{
+			name:     "same paths",
+			mutate:   func(cfg *Config) { cfg.ReadinessPath = cfg.LivenessPath },
+			expected: errSamePaths,
+		},
+		{
+			name:     "negative threshold",
+			mutate:   func(cfg *Config) { cfg.RecoverableErrorThreshold = -time.Second },
+			expected: errNegativeThreshold,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.mutate(cfg)
+			assert.Equal(t, tt.expected, component.ValidateConfig(cfg))
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package healthcheckextension implements an extension serving the liveness and
+// readiness of the collector, derived from the statuses reported by the components.
+package healthcheckextension // import "go.opentelemetry.io/collector/extension/healthcheckextension"
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension // import "go.opentelemetry.io/collector/extension/healthcheckextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension"
+)
+
+const (
+	// The value of extension "type" in configuration.
+	typeStr = "healthcheck"
+
+	defaultEndpoint                  = "0.0.0.0:13133"
+	defaultLivenessPath              = "/live"
+	defaultReadinessPath             = "/ready"
+	defaultRecoverableErrorThreshold = 5 * time.Minute
+)
+
+// NewFactory creates a factory for the health check extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(typeStr, createDefaultConfig, createExtension, component.StabilityLevelDevelopment)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: defaultEndpoint,
+		},
+		LivenessPath:              defaultLivenessPath,
+		ReadinessPath:             defaultReadinessPath,
+		RecoverableErrorThreshold: defaultRecoverableErrorThreshold,
+	}
+}
+
+// createExtension creates the extension based on this config.
+func createExtension(_ context.Context, set extension.CreateSettings, cfg component.Config) (extension.Extension, error) {
+	return newHealthCheck(cfg.(*Config), set.TelemetrySettings), nil
+}
@@ -0,0 +1,280 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension // import "go.opentelemetry.io/collector/extension/healthcheckextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+type componentState struct {
+	id     *component.InstanceID
+	status component.Status
+	// since is the time the component reported its current status for the first time.
+	since time.Time
+	err   error
+}
+
+type healthCheckExtension struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+	now       func() time.Time
+
+	mu         sync.Mutex
+	ready      bool
+	components map[*component.InstanceID]*componentState
+	pipelines  map[component.ID]*component.StatusEvent
+
+	server *http.Server
+	stopCh chan struct{}
+}
+
+var _ extension.PipelineWatcher = (*healthCheckExtension)(nil)
+var _ extension.StatusWatcher = (*healthCheckExtension)(nil)
+
+func newHealthCheck(config *Config, telemetry component.TelemetrySettings) *healthCheckExtension {
+	return &healthCheckExtension{
+		config:     config,
+		telemetry:  telemetry,
+		now:        time.Now,
+		components: make(map[*component.InstanceID]*componentState),
+		pipelines:  make(map[component.ID]*component.StatusEvent),
+	}
+}
+
+func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(hc.config.LivenessPath, hc.handleLiveness)
+	mux.HandleFunc(hc.config.ReadinessPath, hc.handleReadiness)
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := hc.config.ToListener()
+	if err != nil {
+		return err
+	}
+	hc.server, err = hc.config.ToServer(host, hc.telemetry, mux)
+	if err != nil {
+		_ = ln.Close()
+		return err
+	}
+
+	hc.telemetry.Logger.Info("Starting health check extension", zap.Any("config", hc.config))
+	hc.stopCh = make(chan struct{})
+	go func() {
+		defer close(hc.stopCh)
+		if errHTTP := hc.server.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+func (hc *healthCheckExtension) Shutdown(context.Context) error {
+	if hc.server == nil {
+		return nil
+	}
+	err := hc.server.Close()
+	if hc.stopCh != nil {
+		<-hc.stopCh
+	}
+	return err
+}
+
+// Ready implements extension.PipelineWatcher.
+func (hc *healthCheckExtension) Ready() error {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.ready = true
+	return nil
+}
+
+// NotReady implements extension.PipelineWatcher.
+func (hc *healthCheckExtension) NotReady() error {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.ready = false
+	return nil
+}
+
+// ComponentStatusChanged implements extension.StatusWatcher.
+func (hc *healthCheckExtension) ComponentStatusChanged(source *component.InstanceID, event *component.StatusEvent) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	if event.Status() == component.StatusStopped {
+		delete(hc.components, source)
+		return
+	}
+	state, ok := hc.components[source]
+	if !ok {
+		state = &componentState{id: source}
+		hc.components[source] = state
+	}
+	if !ok || state.status != event.Status() {
+		state.status = event.Status()
+		state.since = event.Timestamp()
+	}
+	state.err = event.Err()
+}
+
+// PipelineStatusChanged implements extension.StatusWatcher.
+func (hc *healthCheckExtension) PipelineStatusChanged(pipelineID component.ID, event *component.StatusEvent) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	if event.Status() == component.StatusStopped {
+		delete(hc.pipelines, pipelineID)
+		return
+	}
+	hc.pipelines[pipelineID] = event
+}
+
+type statusResponse struct {
+	Status  string    `json:"status"`
+	Since   time.Time `json:"since"`
+	Error   string    `json:"error,omitempty"`
+	Healthy bool      `json:"healthy"`
+}
+
+type pipelineResponse struct {
+	statusResponse
+	Components map[string]*statusResponse `json:"components"`
+}
+
+type healthResponse struct {
+	Live       bool                         `json:"live"`
+	Ready      bool                         `json:"ready"`
+	Pipelines  map[string]*pipelineResponse `json:"pipelines"`
+	Extensions map[string]*statusResponse   `json:"extensions,omitempty"`
+}
+
+func (hc *healthCheckExtension) handleLiveness(w http.ResponseWriter, r *http.Request) {
+	resp, ok := hc.health(r.URL.Query().Get("pipeline"))
+	if !ok {
+		http.Error(w, "unknown pipeline", http.StatusNotFound)
+		return
+	}
+	writeResponse(w, resp, resp.Live)
+}
+
+func (hc *healthCheckExtension) handleReadiness(w http.ResponseWriter, r *http.Request) {
+	resp, ok := hc.health(r.URL.Query().Get("pipeline"))
+	if !ok {
+		http.Error(w, "unknown pipeline", http.StatusNotFound)
+		return
+	}
+	writeResponse(w, resp, resp.Ready)
+}
+
+func writeResponse(w http.ResponseWriter, resp *healthResponse, healthy bool) {
+	w.Header().Set("Content-Type", "application/json")
+	if healthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// health computes the health of the collector, or of a single pipeline if pipeline is not empty.
+// It returns false if the pipeline is unknown.
+func (hc *healthCheckExtension) health(pipeline string) (*healthResponse, bool) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	var onlyPipeline *component.ID
+	if pipeline != "" {
+		id := component.ID{}
+		if err := id.UnmarshalText([]byte(pipeline)); err != nil {
+			return nil, false
+		}
+		if _, ok := hc.pipelines[id]; !ok {
+			return nil, false
+		}
+		onlyPipeline = &id
+	}
+
+	now := hc.now()
+	resp := &healthResponse{
+		Live:      true,
+		Ready:     hc.ready,
+		Pipelines: make(map[string]*pipelineResponse),
+	}
+	for id, event := range hc.pipelines {
+		if onlyPipeline != nil && id != *onlyPipeline {
+			continue
+		}
+		resp.Pipelines[id.String()] = &pipelineResponse{
+			statusResponse: statusResponse{
+				Status:  event.Status().String(),
+				Since:   event.Timestamp(),
+				Error:   errString(event.Err()),
+				Healthy: true,
+			},
+			Components: make(map[string]*statusResponse),
+		}
+	}
+
+	for _, state := range hc.components {
+		healthy := hc.healthy(state, now)
+		sr := &statusResponse{
+			Status:  state.status.String(),
+			Since:   state.since,
+			Error:   errString(state.err),
+			Healthy: healthy,
+		}
+		inScope := onlyPipeline == nil
+		for _, pipelineID := range state.id.PipelineIDs {
+			pr, ok := resp.Pipelines[pipelineID.String()]
+			if !ok {
+				continue
+			}
+			inScope = true
+			pr.Components[strings.ToLower(state.id.Kind.String())+":"+state.id.ID.String()] = sr
+			pr.Healthy = pr.Healthy && healthy
+		}
+		if state.id.Kind == component.KindExtension && onlyPipeline == nil {
+			if resp.Extensions == nil {
+				resp.Extensions = make(map[string]*statusResponse)
+			}
+			resp.Extensions[state.id.ID.String()] = sr
+		}
+		if !inScope {
+			continue
+		}
+		if state.status == component.StatusPermanentError {
+			resp.Live = false
+		}
+		resp.Ready = resp.Ready && healthy
+	}
+	return resp, true
+}
+
+// healthy returns false if the component reported a permanent error, or a recoverable error
+// for longer than the configured threshold.
+func (hc *healthCheckExtension) healthy(state *componentState, now time.Time) bool {
+	switch state.status {
+	case component.StatusPermanentError:
+		return false
+	case component.StatusRecoverableError:
+		return now.Sub(state.since) < hc.config.RecoverableErrorThreshold
+	}
+	return true
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+var (
+	tracesID  = component.NewID("traces")
+	metricsID = component.NewID("metrics")
+
+	receiverID = &component.InstanceID{
+		ID:          component.NewID("otlp"),
+		Kind:        component.KindReceiver,
+		PipelineIDs: []component.ID{metricsID, tracesID},
+	}
+	tracesExporterID = &component.InstanceID{
+		ID:          component.NewID("otlp"),
+		Kind:        component.KindExporter,
+		PipelineIDs: []component.ID{tracesID},
+	}
+	metricsExporterID = &component.InstanceID{
+		ID:          component.NewID("prometheus"),
+		Kind:        component.KindExporter,
+		PipelineIDs: []component.ID{metricsID},
+	}
+	extensionID = &component.InstanceID{
+		ID:   component.NewID("healthcheck"),
+		Kind: component.KindExtension,
+	}
+)
+
+func newTestHealthCheck(t *testing.T) *healthCheckExtension {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	hc := newHealthCheck(cfg, componenttest.NewNopTelemetrySettings())
+	for _, id := range []*component.InstanceID{extensionID, receiverID, tracesExporterID, metricsExporterID} {
+		hc.ComponentStatusChanged(id, component.NewStatusEvent(component.StatusOK, nil))
+	}
+	hc.PipelineStatusChanged(tracesID, component.NewStatusEvent(component.StatusOK, nil))
+	hc.PipelineStatusChanged(metricsID, component.NewStatusEvent(component.StatusOK, nil))
+	require.NoError(t, hc.Ready())
+	return hc
+}
+
+func get(t *testing.T, hc *healthCheckExtension, target string) (int, *healthResponse) {
+	rec := httptest.NewRecorder()
+	mux := http.NewServeMux()
+	mux.HandleFunc(hc.config.LivenessPath, hc.handleLiveness)
+	mux.HandleFunc(hc.config.ReadinessPath, hc.handleReadiness)
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	if rec.Code == http.StatusNotFound {
+		return rec.Code, nil
+	}
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	resp := &healthResponse{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), resp))
+	return rec.Code, resp
+}
+
+func TestHealthCheckHealthy(t *testing.T) {
+	hc := newTestHealthCheck(t)
+
+	code, resp := get(t, hc, "/live")
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, resp.Live)
+
+	code, resp = get(t, hc, "/ready")
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, resp.Ready)
+	require.Len(t, resp.Pipelines, 2)
+	traces := resp.Pipelines["traces"]
+	assert.Equal(t, "OK", traces.Status)
+	assert.True(t, traces.Healthy)
+	assert.Len(t, traces.Components, 2)
+	assert.Equal(t, "OK", traces.Components["receiver:otlp"].Status)
+	assert.Equal(t, "OK", traces.Components["exporter:otlp"].Status)
+	assert.Contains(t, resp.Pipelines["metrics"].Components, "exporter:prometheus")
+	assert.Equal(t, "OK", resp.Extensions["healthcheck"].Status)
+}
+
+func TestHealthCheckNotReady(t *testing.T) {
+	hc := newTestHealthCheck(t)
+	require.NoError(t, hc.NotReady())
+
+	code, resp := get(t, hc, "/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, resp.Ready)
+	code, _ = get(t, hc, "/live")
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestHealthCheckRecoverableError(t *testing.T) {
+	hc := newTestHealthCheck(t)
+	now := time.Now()
+	hc.now = func() time.Time { return now }
+
+	hc.ComponentStatusChanged(tracesExporterID, component.NewStatusEvent(component.StatusRecoverableError, errors.New("connection refused")))
+	hc.PipelineStatusChanged(tracesID, component.NewStatusEvent(component.StatusRecoverableError, errors.New("connection refused")))
+
+	code, resp := get(t, hc, "/ready")
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, resp.Pipelines["traces"].Healthy)
+	exporter := resp.Pipelines["traces"].Components["exporter:otlp"]
+	assert.Equal(t, "RecoverableError", exporter.Status)
+	assert.Equal(t, "connection refused", exporter.Error)
+	assert.True(t, exporter.Healthy)
+
+	// A newer error keeps the time of the first one.
+	hc.ComponentStatusChanged(tracesExporterID, component.NewStatusEvent(component.StatusRecoverableError, errors.New("timeout")))
+	now = now.Add(6 * time.Minute)
+
+	code, resp = get(t, hc, "/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, resp.Pipelines["traces"].Healthy)
+	assert.True(t, resp.Pipelines["metrics"].Healthy)
+	assert.Equal(t, "timeout", resp.Pipelines["traces"].Components["exporter:otlp"].Error)
+
+	code, _ = get(t, hc, "/live")
+	assert.Equal(t, http.StatusOK, code)
+
+	code, resp = get(t, hc, "/ready?pipeline=metrics")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, resp.Pipelines, 1)
+	assert.Empty(t, resp.Extensions)
+	code, _ = get(t, hc, "/ready?pipeline=traces")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	hc.ComponentStatusChanged(tracesExporterID, component.NewStatusEvent(component.StatusOK, nil))
+	code, _ = get(t, hc, "/ready")
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestHealthCheckPermanentError(t *testing.T) {
+	hc := newTestHealthCheck(t)
+	hc.ComponentStatusChanged(metricsExporterID, component.NewStatusEvent(component.StatusPermanentError, errors.New("invalid endpoint")))
+
+	code, resp := get(t, hc, "/live")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, resp.Live)
+	code, _ = get(t, hc, "/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	code, resp = get(t, hc, "/live?pipeline=traces")
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, resp.Live)
+	code, _ = get(t, hc, "/ready?pipeline=traces")
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestHealthCheckStopped(t *testing.T) {
+	hc := newTestHealthCheck(t)
+	hc.ComponentStatusChanged(tracesExporterID, component.NewStatusEvent(component.StatusStopped, nil))
+	hc.PipelineStatusChanged(metricsID, component.NewStatusEvent(component.StatusStopped, nil))
+
+	_, resp := get(t, hc, "/ready")
+	assert.NotContains(t, resp.Pipelines, "metrics")
+	assert.NotContains(t, resp.Pipelines["traces"].Components, "exporter:otlp")
+}
+
+func TestHealthCheckUnknownPipeline(t *testing.T) {
+	hc := newTestHealthCheck(t)
+	code, _ := get(t, hc, "/ready?pipeline=logs")
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = get(t, hc, "/live?pipeline=/")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestHealthCheckStartShutdown(t *testing.T) {
+	hc := newTestHealthCheck(t)
+	require.NoError(t, hc.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, hc.Shutdown(context.Background())) })
+
+	resp, err := http.Get("http://" + hc.config.Endpoint + "/ready")
+	require.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestHealthCheckStartPortInUse(t *testing.T) {
+	hc := newTestHealthCheck(t)
+	require.NoError(t, hc.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, hc.Shutdown(context.Background())) })
+
+	other := newHealthCheck(hc.config, componenttest.NewNopTelemetrySettings())
+	assert.Error(t, other.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, other.Shutdown(context.Background()))
+}
@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package middleware defines the extensions handling the requests
+// received by the HTTP and gRPC servers before the receivers do.
+package middleware // import "go.opentelemetry.io/collector/extension/middleware"
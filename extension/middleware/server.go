@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware // import "go.opentelemetry.io/collector/extension/middleware"
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+// Server is an Extension that can be used as a middleware for the configmiddleware.Middleware option.
+// Middlewares handle the requests received by the HTTP and gRPC servers of the receivers before the receivers do,
+// for example to log them, to enforce quotas or to authenticate them. They see the requests once authenticated by the
+// configured authenticator, with the client.Info in their context.
+type Server interface {
+	extension.Extension
+
+	// WrapHTTPHandler returns the handler serving the HTTP requests, which passes them to next unless it responds
+	// to them itself.
+	WrapHTTPHandler(next http.Handler) http.Handler
+
+	// UnaryServerInterceptor returns the interceptor of the unary gRPC calls, nil if they are not intercepted.
+	UnaryServerInterceptor() grpc.UnaryServerInterceptor
+
+	// StreamServerInterceptor returns the interceptor of the streaming gRPC calls, nil if they are not intercepted.
+	StreamServerInterceptor() grpc.StreamServerInterceptor
+}
+
+type defaultServer struct {
+	WrapHTTPHandlerFunc
+	unaryInterceptor  grpc.UnaryServerInterceptor
+	streamInterceptor grpc.StreamServerInterceptor
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+// ServerOption represents the possible options for NewServer.
+type ServerOption func(*defaultServer)
+
+// WrapHTTPHandlerFunc defines the signature for the function wrapping the HTTP handlers. See Server.WrapHTTPHandler.
+type WrapHTTPHandlerFunc func(next http.Handler) http.Handler
+
+func (f WrapHTTPHandlerFunc) WrapHTTPHandler(next http.Handler) http.Handler {
+	if f == nil {
+		return next
+	}
+	return f(next)
+}
+
+func (s *defaultServer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return s.unaryInterceptor
+}
+
+func (s *defaultServer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return s.streamInterceptor
+}
+
+// WithWrapHTTPHandler specifies which function to use to wrap the HTTP handlers.
+// The default returns the handlers unchanged.
+func WithWrapHTTPHandler(wrapFunc WrapHTTPHandlerFunc) ServerOption {
+	return func(o *defaultServer) {
+		o.WrapHTTPHandlerFunc = wrapFunc
+	}
+}
+
+// WithUnaryServerInterceptor specifies the interceptor of the unary gRPC calls.
+// The default does not intercept them.
+func WithUnaryServerInterceptor(interceptor grpc.UnaryServerInterceptor) ServerOption {
+	return func(o *defaultServer) {
+		o.unaryInterceptor = interceptor
+	}
+}
+
+// WithStreamServerInterceptor specifies the interceptor of the streaming gRPC calls.
+// The default does not intercept them.
+func WithStreamServerInterceptor(interceptor grpc.StreamServerInterceptor) ServerOption {
+	return func(o *defaultServer) {
+		o.streamInterceptor = interceptor
+	}
+}
+
+// WithServerStart overrides the default `Start` function for a component.Component.
+// The default always returns nil.
+func WithServerStart(startFunc component.StartFunc) ServerOption {
+	return func(o *defaultServer) {
+		o.StartFunc = startFunc
+	}
+}
+
+// WithServerShutdown overrides the default `Shutdown` function for a component.Component.
+// The default always returns nil.
+func WithServerShutdown(shutdownFunc component.ShutdownFunc) ServerOption {
+	return func(o *defaultServer) {
+		o.ShutdownFunc = shutdownFunc
+	}
+}
+
+// NewServer returns a Server configured with the provided options.
+func NewServer(options ...ServerOption) Server {
+	bc := &defaultServer{}
+
+	for _, op := range options {
+		op(bc)
+	}
+
+	return bc
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestDefaultValues(t *testing.T) {
+	// prepare
+	e := NewServer()
+
+	// test
+	t.Run("start", func(t *testing.T) {
+		err := e.Start(context.Background(), componenttest.NewNopHost())
+		assert.NoError(t, err)
+	})
+
+	t.Run("http", func(t *testing.T) {
+		next := http.NotFoundHandler()
+		rec := httptest.NewRecorder()
+		e.WrapHTTPHandler(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("grpc", func(t *testing.T) {
+		assert.Nil(t, e.UnaryServerInterceptor())
+		assert.Nil(t, e.StreamServerInterceptor())
+	})
+
+	t.Run("shutdown", func(t *testing.T) {
+		err := e.Shutdown(context.Background())
+		assert.NoError(t, err)
+	})
+}
+
+func TestWithWrapHTTPHandler(t *testing.T) {
+	e := NewServer(WithWrapHTTPHandler(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+	}))
+
+	rec := httptest.NewRecorder()
+	e.WrapHTTPHandler(http.NotFoundHandler()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
+
+func TestWithInterceptors(t *testing.T) {
+	errQuota := errors.New("quota exceeded")
+	e := NewServer(
+		WithUnaryServerInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			return nil, errQuota
+		}),
+		WithStreamServerInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return errQuota
+		}),
+	)
+
+	_, err := e.UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{}, nil)
+	assert.ErrorIs(t, err, errQuota)
+	assert.ErrorIs(t, e.StreamServerInterceptor()(nil, nil, &grpc.StreamServerInfo{}, nil), errQuota)
+}
+
+func TestWithServerStartAndShutdown(t *testing.T) {
+	var started, stopped bool
+	e := NewServer(
+		WithServerStart(func(context.Context, component.Host) error {
+			started = true
+			return nil
+		}),
+		WithServerShutdown(func(context.Context) error {
+			stopped = true
+			return nil
+		}),
+	)
+
+	assert.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, e.Shutdown(context.Background()))
+	assert.True(t, started)
+	assert.True(t, stopped)
+}
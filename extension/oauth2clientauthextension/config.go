@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth2clientauthextension // import "go.opentelemetry.io/collector/extension/oauth2clientauthextension"
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// Config has the configuration for the OAuth2 client credentials authenticator extension.
+type Config struct {
+	// ClientID is the application's ID.
+	ClientID string `mapstructure:"client_id"`
+
+	// ClientSecret is the application's secret.
+	ClientSecret configopaque.String `mapstructure:"client_secret"`
+
+	// TokenURL is the resource server's token endpoint URL.
+	TokenURL string `mapstructure:"token_url"`
+
+	// Scopes optionally specifies a list of requested permission scopes.
+	Scopes []string `mapstructure:"scopes"`
+
+	// EndpointParams specifies additional parameters for requests to the token endpoint.
+	EndpointParams url.Values `mapstructure:"endpoint_params"`
+
+	// TLSSetting struct exposes TLS client configuration for the requests to the token endpoint.
+	TLSSetting configtls.TLSClientSetting `mapstructure:"tls"`
+
+	// Timeout is the timeout of the requests to the token endpoint.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.ClientID == "" {
+		return errors.New("client_id must be specified")
+	}
+	if cfg.ClientSecret == "" {
+		return errors.New("client_secret must be specified")
+	}
+	if cfg.TokenURL == "" {
+		return errors.New("token_url must be specified")
+	}
+	if cfg.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth2clientauthextension
+
+import (
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			ClientID:       "collector",
+			ClientSecret:   "secret",
+			TokenURL:       "https://auth.example.com/oauth2/token",
+			Scopes:         []string{"api.metrics", "api.traces"},
+			EndpointParams: url.Values{"audience": {"otlp"}},
+			Timeout:        2 * time.Second,
+			TLSSetting: configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{CAFile: "/etc/collector/ca.pem"},
+			},
+		}, cfg)
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "client_id must be specified")
+
+	cfg.ClientID = "collector"
+	assert.EqualError(t, cfg.Validate(), "client_secret must be specified")
+
+	cfg.ClientSecret = "secret"
+	assert.EqualError(t, cfg.Validate(), "token_url must be specified")
+
+	cfg.TokenURL = "https://auth.example.com/oauth2/token"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Timeout = -time.Second
+	assert.EqualError(t, cfg.Validate(), "timeout must not be negative")
+}
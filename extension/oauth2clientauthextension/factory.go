@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth2clientauthextension // import "go.opentelemetry.io/collector/extension/oauth2clientauthextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+const (
+	// The value of extension "type" in configuration.
+	typeStr = "oauth2client"
+
+	defaultTimeout = 5 * time.Second
+)
+
+// NewFactory creates a factory for the OAuth2 client credentials authenticator extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(typeStr, createDefaultConfig, createExtension, component.StabilityLevelAlpha)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Timeout: defaultTimeout,
+	}
+}
+
+func createExtension(_ context.Context, _ extension.CreateSettings, cfg component.Config) (extension.Extension, error) {
+	return newClientAuthenticator(cfg.(*Config))
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth2clientauthextension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.Equal(t, &Config{Timeout: defaultTimeout}, cfg)
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestFactory_CreateExtension(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientID = "collector"
+	cfg.ClientSecret = "secret"
+	cfg.TokenURL = "https://auth.example.com/oauth2/token"
+	ext, err := createExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	assert.Implements(t, (*auth.Client)(nil), ext)
+}
+
+func TestFactory_CreateExtensionInvalidTLS(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TLSSetting.CAFile = "/nonexistent/ca.pem"
+	_, err := createExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	assert.ErrorContains(t, err, "failed to load the TLS configuration of the token endpoint")
+}
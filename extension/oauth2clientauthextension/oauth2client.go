@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth2clientauthextension // import "go.opentelemetry.io/collector/extension/oauth2clientauthextension"
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"google.golang.org/grpc/credentials"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/auth"
+)
+
+var _ auth.Client = (*clientAuthenticator)(nil)
+
+// clientAuthenticator authenticates the requests with the access tokens obtained with the
+// OAuth2 client credentials flow. The tokens are cached until they expire.
+type clientAuthenticator struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	tokenSource oauth2.TokenSource
+}
+
+func newClientAuthenticator(cfg *Config) (*clientAuthenticator, error) {
+	tlsCfg, err := cfg.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the TLS configuration of the token endpoint: %w", err)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+
+	clientCredentials := &clientcredentials.Config{
+		ClientID:       cfg.ClientID,
+		ClientSecret:   string(cfg.ClientSecret),
+		TokenURL:       cfg.TokenURL,
+		Scopes:         cfg.Scopes,
+		EndpointParams: cfg.EndpointParams,
+	}
+	// The token source keeps the context to request the next tokens, it holds the client used to
+	// reach the token endpoint.
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{
+		Transport: transport,
+		Timeout:   cfg.Timeout,
+	})
+	return &clientAuthenticator{
+		tokenSource: clientCredentials.TokenSource(ctx),
+	}, nil
+}
+
+// RoundTripper returns a RoundTripper adding the access token to the HTTP requests.
+func (o *clientAuthenticator) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return &oauth2.Transport{
+		Source: o.tokenSource,
+		Base:   base,
+	}, nil
+}
+
+// PerRPCCredentials returns the credentials adding the access token to the gRPC requests.
+func (o *clientAuthenticator) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
+	return &perRPCAuth{tokenSource: o.tokenSource}, nil
+}
+
+type perRPCAuth struct {
+	tokenSource oauth2.TokenSource
+}
+
+func (c *perRPCAuth) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	token, err := c.tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the OAuth2 access token: %w", err)
+	}
+	return map[string]string{"authorization": token.Type() + " " + token.AccessToken}, nil
+}
+
+func (c *perRPCAuth) RequireTransportSecurity() bool {
+	return true
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth2clientauthextension
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTokenServer returns a token endpoint issuing a new token, valid for expiresIn seconds, on each request.
+func newTokenServer(t *testing.T, expiresIn int) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "api.metrics", r.PostForm.Get("scope"))
+		assert.Equal(t, "otlp", r.PostForm.Get("audience"))
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "collector" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "token-" + strconv.Itoa(int(n)),
+			"token_type":   "Bearer",
+			"expires_in":   expiresIn,
+		}))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func newTestConfig(tokenURL string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientID = "collector"
+	cfg.ClientSecret = "secret"
+	cfg.TokenURL = tokenURL
+	cfg.Scopes = []string{"api.metrics"}
+	cfg.EndpointParams = map[string][]string{"audience": {"otlp"}}
+	return cfg
+}
+
+func TestRoundTripper(t *testing.T) {
+	tokenServer, requests := newTokenServer(t, 3600)
+	var gotAuth []string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = append(gotAuth, r.Header.Get("Authorization"))
+	}))
+	defer backend.Close()
+
+	oauth, err := newClientAuthenticator(newTestConfig(tokenServer.URL))
+	require.NoError(t, err)
+	rt, err := oauth.RoundTripper(http.DefaultTransport)
+	require.NoError(t, err)
+	client := &http.Client{Transport: rt}
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(backend.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	// The token is cached until it expires.
+	assert.Equal(t, []string{"Bearer token-1", "Bearer token-1", "Bearer token-1"}, gotAuth)
+	assert.EqualValues(t, 1, requests.Load())
+}
+
+func TestPerRPCCredentials(t *testing.T) {
+	// The tokens expire immediately, a new one is requested each time.
+	tokenServer, requests := newTokenServer(t, 1)
+	oauth, err := newClientAuthenticator(newTestConfig(tokenServer.URL))
+	require.NoError(t, err)
+	creds, err := oauth.PerRPCCredentials()
+	require.NoError(t, err)
+	assert.True(t, creds.RequireTransportSecurity())
+
+	md, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer token-1"}, md)
+
+	md, err = creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer token-2"}, md)
+	assert.EqualValues(t, 2, requests.Load())
+}
+
+func TestInvalidCredentials(t *testing.T) {
+	tokenServer, _ := newTokenServer(t, 3600)
+	cfg := newTestConfig(tokenServer.URL)
+	cfg.ClientSecret = "invalid"
+	oauth, err := newClientAuthenticator(cfg)
+	require.NoError(t, err)
+
+	creds, err := oauth.PerRPCCredentials()
+	require.NoError(t, err)
+	_, err = creds.GetRequestMetadata(context.Background())
+	assert.ErrorContains(t, err, "failed to get the OAuth2 access token")
+
+	rt, err := oauth.RoundTripper(http.DefaultTransport)
+	require.NoError(t, err)
+	_, err = (&http.Client{Transport: rt}).Get("http://backend.invalid")
+	assert.ErrorContains(t, err, "401")
+}
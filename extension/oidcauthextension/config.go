@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcauthextension // import "go.opentelemetry.io/collector/extension/oidcauthextension"
+
+import (
+	"errors"
+)
+
+// Config has the configuration for the OIDC authenticator extension.
+type Config struct {
+	// IssuerURL is the base URL of the OIDC provider, its configuration is discovered from
+	// the /.well-known/openid-configuration document.
+	IssuerURL string `mapstructure:"issuer_url"`
+
+	// IssuerCAPath is the path of the CA certificates verifying the certificate of the OIDC provider.
+	// The system CAs are used if not set. (optional)
+	IssuerCAPath string `mapstructure:"issuer_ca_path"`
+
+	// Audience is the audience of the tokens, usually the client ID of the collector in the OIDC provider.
+	Audience string `mapstructure:"audience"`
+
+	// Attribute is the header holding the token, "authorization" by default.
+	Attribute string `mapstructure:"attribute"`
+
+	// UsernameClaim is the claim holding the username, the subject of the token is used if not set. (optional)
+	UsernameClaim string `mapstructure:"username_claim"`
+
+	// GroupsClaim is the claim holding the groups of the subject. (optional)
+	GroupsClaim string `mapstructure:"groups_claim"`
+
+	// TenantClaim is the claim holding the tenant of the subject, returned in its claims. (optional)
+	TenantClaim string `mapstructure:"tenant_claim"`
+
+	// ScopesClaim is the claim holding the scopes granted to the subject, either as a space separated
+	// string or a list of strings, "scope" by default.
+	ScopesClaim string `mapstructure:"scopes_claim"`
+}
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.IssuerURL == "" {
+		return errors.New("issuer_url must be specified")
+	}
+	if cfg.Audience == "" {
+		return errors.New("audience must be specified")
+	}
+	if cfg.Attribute == "" {
+		return errors.New("attribute must be specified")
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcauthextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			IssuerURL:     "https://auth.example.com/realms/collector",
+			IssuerCAPath:  "/etc/collector/issuer-ca.pem",
+			Audience:      "otel-collector",
+			Attribute:     "x-auth-token",
+			UsernameClaim: "email",
+			GroupsClaim:   "groups",
+			TenantClaim:   "tenant",
+			ScopesClaim:   "scp",
+		}, cfg)
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "issuer_url must be specified")
+
+	cfg.IssuerURL = "https://auth.example.com/realms/collector"
+	assert.EqualError(t, cfg.Validate(), "audience must be specified")
+
+	cfg.Audience = "otel-collector"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Attribute = ""
+	assert.EqualError(t, cfg.Validate(), "attribute must be specified")
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcauthextension // import "go.opentelemetry.io/collector/extension/oidcauthextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+const (
+	// The value of extension "type" in configuration.
+	typeStr = "oidc"
+
+	defaultAttribute = "authorization"
+
+	defaultScopesClaim = "scope"
+)
+
+// NewFactory creates a factory for the OIDC authenticator extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(typeStr, createDefaultConfig, createExtension, component.StabilityLevelAlpha)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Attribute:   defaultAttribute,
+		ScopesClaim: defaultScopesClaim,
+	}
+}
+
+func createExtension(_ context.Context, _ extension.CreateSettings, cfg component.Config) (extension.Extension, error) {
+	return newOIDCAuth(cfg.(*Config)), nil
+}
@@ -0,0 +1,210 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcauthextension // import "go.opentelemetry.io/collector/extension/oidcauthextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/extension/auth"
+)
+
+// issuerTimeout is the timeout of the requests to the OIDC provider.
+const issuerTimeout = 10 * time.Second
+
+var (
+	errMissingHeader       = errors.New("missing authorization header")
+	errInvalidHeader       = errors.New("authorization header does not hold a bearer token")
+	errNotStarted          = errors.New("the OIDC authenticator is not started")
+	errInvalidUsernameType = errors.New("the username claim is not a string")
+	errInvalidGroupsType   = errors.New("the groups claim is neither a string nor a list of strings")
+	errInvalidTenantType   = errors.New("the tenant claim is not a string")
+	errInvalidScopesType   = errors.New("the scopes claim is neither a string nor a list of strings")
+)
+
+var _ auth.ClaimsServer = (*oidcAuth)(nil)
+
+// oidcAuth authenticates the requests with the JWT tokens issued by an OIDC provider.
+// The signing keys of the provider are fetched again when a token is signed by an unknown key.
+type oidcAuth struct {
+	cfg *Config
+
+	verifier *oidc.IDTokenVerifier
+}
+
+func newOIDCAuth(cfg *Config) *oidcAuth {
+	return &oidcAuth{cfg: cfg}
+}
+
+// Start discovers the configuration of the OIDC provider.
+func (o *oidcAuth) Start(ctx context.Context, _ component.Host) error {
+	tlsSetting := configtls.TLSClientSetting{TLSSetting: configtls.TLSSetting{CAFile: o.cfg.IssuerCAPath}}
+	tlsCfg, err := tlsSetting.LoadTLSConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load the CA of the OIDC provider: %w", err)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+
+	// The client is kept by the provider to fetch the signing keys.
+	ctx = oidc.ClientContext(ctx, &http.Client{Transport: transport, Timeout: issuerTimeout})
+	provider, err := oidc.NewProvider(ctx, o.cfg.IssuerURL)
+	if err != nil {
+		return fmt.Errorf("failed to get the configuration of the OIDC provider %q: %w", o.cfg.IssuerURL, err)
+	}
+	o.verifier = provider.Verifier(&oidc.Config{ClientID: o.cfg.Audience})
+	return nil
+}
+
+func (o *oidcAuth) Shutdown(context.Context) error {
+	return nil
+}
+
+// Authenticate verifies the bearer token and adds the subject, username and groups of the token
+// to the client.Info of the context.
+func (o *oidcAuth) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	ctx, _, err := o.AuthenticateClaims(ctx, headers)
+	return ctx, err
+}
+
+// AuthenticateClaims authenticates the request as done by Authenticate, and returns the subject, the tenant,
+// the scopes and the other claims of the token.
+func (o *oidcAuth) AuthenticateClaims(ctx context.Context, headers map[string][]string) (context.Context, *client.Claims, error) {
+	if o.verifier == nil {
+		return ctx, nil, errNotStarted
+	}
+	raw, err := o.bearerToken(headers)
+	if err != nil {
+		return ctx, nil, err
+	}
+	idToken, err := o.verifier.Verify(ctx, raw)
+	if err != nil {
+		return ctx, nil, fmt.Errorf("failed to verify the token: %w", err)
+	}
+
+	var claims map[string]any
+	if err = idToken.Claims(&claims); err != nil {
+		return ctx, nil, fmt.Errorf("failed to get the claims of the token: %w", err)
+	}
+	data := &authData{raw: raw, subject: idToken.Subject, username: idToken.Subject}
+	if o.cfg.UsernameClaim != "" {
+		username, ok := claims[o.cfg.UsernameClaim].(string)
+		if !ok {
+			return ctx, nil, errInvalidUsernameType
+		}
+		data.username = username
+	}
+	if o.cfg.GroupsClaim != "" {
+		if data.membership, err = groups(claims[o.cfg.GroupsClaim]); err != nil {
+			return ctx, nil, err
+		}
+	}
+
+	tokenClaims := &client.Claims{Subject: data.username, Attributes: claims}
+	if o.cfg.TenantClaim != "" {
+		tenant, ok := claims[o.cfg.TenantClaim].(string)
+		if !ok {
+			return ctx, nil, errInvalidTenantType
+		}
+		tokenClaims.Tenant = tenant
+	}
+	if o.cfg.ScopesClaim != "" {
+		if tokenClaims.Scopes, err = scopes(claims[o.cfg.ScopesClaim]); err != nil {
+			return ctx, nil, err
+		}
+	}
+
+	cl := client.FromContext(ctx)
+	cl.Auth = data
+	return client.NewContext(ctx, cl), tokenClaims, nil
+}
+
+// bearerToken returns the token of the header, which is looked up regardless of its case.
+func (o *oidcAuth) bearerToken(headers map[string][]string) (string, error) {
+	var values []string
+	for name, v := range headers {
+		if strings.EqualFold(name, o.cfg.Attribute) {
+			values = v
+			break
+		}
+	}
+	if len(values) == 0 {
+		return "", errMissingHeader
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", errInvalidHeader
+	}
+	return token, nil
+}
+
+// groups returns the groups of a claim holding either a single group or a list of groups.
+func groups(claim any) ([]string, error) {
+	switch v := claim.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []any:
+		groups := make([]string, 0, len(v))
+		for _, group := range v {
+			s, ok := group.(string)
+			if !ok {
+				return nil, errInvalidGroupsType
+			}
+			groups = append(groups, s)
+		}
+		return groups, nil
+	}
+	return nil, errInvalidGroupsType
+}
+
+// scopes returns the scopes of a claim holding either a space separated string or a list of strings.
+func scopes(claim any) ([]string, error) {
+	if s, ok := claim.(string); ok {
+		return strings.Fields(s), nil
+	}
+	scopes, err := groups(claim)
+	if err != nil {
+		return nil, errInvalidScopesType
+	}
+	return scopes, nil
+}
+
+var _ client.AuthData = (*authData)(nil)
+
+// authData exposes the "subject", "username", "membership" and "raw" attributes of the token.
+type authData struct {
+	raw        string
+	subject    string
+	username   string
+	membership []string
+}
+
+func (a *authData) GetAttribute(name string) any {
+	switch name {
+	case "subject":
+		return a.subject
+	case "username":
+		return a.username
+	case "membership":
+		return a.membership
+	case "raw":
+		return a.raw
+	}
+	return nil
+}
+
+func (*authData) GetAttributeNames() []string {
+	return []string{"subject", "username", "membership", "raw"}
+}
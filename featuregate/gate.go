@@ -14,6 +14,8 @@ type Gate struct {
 	fromVersion  string
 	toVersion    string
 	stage        Stage
+	owner        string
+	mutable      bool
 	enabled      *atomic.Bool
 }
 
@@ -51,3 +53,13 @@ func (g *Gate) FromVersion() string {
 func (g *Gate) ToVersion() string {
 	return g.toVersion
 }
+
+// Owner returns the owner of the Gate, e.g. the component or the team responsible for the feature.
+func (g *Gate) Owner() string {
+	return g.owner
+}
+
+// IsMutable returns true if the Gate can be enabled or disabled while the collector is running.
+func (g *Gate) IsMutable() bool {
+	return g.mutable
+}
@@ -21,6 +21,8 @@ func TestGate(t *testing.T) {
 		referenceURL: "http://example.com",
 		fromVersion:  "v0.61.0",
 		toVersion:    "v0.64.0",
+		owner:        "collector",
+		mutable:      true,
 	}
 
 	assert.Equal(t, "test", g.ID())
@@ -30,4 +32,6 @@ func TestGate(t *testing.T) {
 	assert.Equal(t, "http://example.com", g.ReferenceURL())
 	assert.Equal(t, "v0.61.0", g.FromVersion())
 	assert.Equal(t, "v0.64.0", g.ToVersion())
+	assert.Equal(t, "collector", g.Owner())
+	assert.True(t, g.IsMutable())
 }
@@ -68,6 +68,23 @@ func WithRegisterToVersion(toVersion string) RegisterOption {
 	})
 }
 
+// WithRegisterOwner sets the owner of the Gate, e.g. the component or the team responsible for the feature.
+func WithRegisterOwner(owner string) RegisterOption {
+	return registerOptionFunc(func(g *Gate) {
+		g.owner = owner
+	})
+}
+
+// WithRegisterMutable allows the Gate to be enabled or disabled while the collector is running,
+// see Registry.SetAtRuntime. Only the features checking the Gate every time they are used,
+// instead of once at startup, should be registered as mutable.
+// Only alpha and beta gates can be mutable.
+func WithRegisterMutable() RegisterOption {
+	return registerOptionFunc(func(g *Gate) {
+		g.mutable = true
+	})
+}
+
 // MustRegister like Register but panics if an invalid ID or gate options are provided.
 func (r *Registry) MustRegister(id string, stage Stage, opts ...RegisterOption) *Gate {
 	g, err := r.Register(id, stage, opts...)
@@ -99,6 +116,9 @@ func (r *Registry) Register(id string, stage Stage, opts ...RegisterOption) (*Ga
 	if (g.stage == StageStable || g.stage == StageDeprecated) && g.toVersion == "" {
 		return nil, fmt.Errorf("no removal version set for %v gate %q", g.stage.String(), id)
 	}
+	if g.mutable && g.stage != StageAlpha && g.stage != StageBeta {
+		return nil, fmt.Errorf("%v gate %q cannot be mutable", g.stage.String(), id)
+	}
 	if _, loaded := r.gates.LoadOrStore(id, g); loaded {
 		return nil, fmt.Errorf("attempted to add pre-existing gate %q", id)
 	}
@@ -130,6 +150,21 @@ func (r *Registry) Set(id string, enabled bool) error {
 	return nil
 }
 
+// SetAtRuntime sets the enabled value for a mutable Gate identified by the given id while the
+// collector is running. It returns an error if the Gate is not mutable.
+func (r *Registry) SetAtRuntime(id string, enabled bool) error {
+	v, ok := r.gates.Load(id)
+	if !ok {
+		return fmt.Errorf("no such feature gate %q", id)
+	}
+	g := v.(*Gate)
+	if !g.mutable {
+		return fmt.Errorf("feature gate %q is not mutable, can not be changed at runtime", id)
+	}
+	g.enabled.Store(enabled)
+	return nil
+}
+
 // VisitAll visits all the gates in lexicographical order, calling fn for each.
 func (r *Registry) VisitAll(fn func(*Gate)) {
 	var gates []*Gate
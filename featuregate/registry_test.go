@@ -67,6 +67,22 @@ func TestRegistryApply(t *testing.T) {
 	assert.True(t, fooGate.IsEnabled())
 }
 
+func TestRegistrySetAtRuntime(t *testing.T) {
+	r := NewRegistry()
+	assert.Error(t, r.SetAtRuntime("foo", true))
+
+	immutableGate := r.MustRegister("immutable", StageAlpha)
+	assert.Error(t, r.SetAtRuntime(immutableGate.ID(), true))
+	assert.False(t, immutableGate.IsEnabled())
+
+	mutableGate := r.MustRegister("mutable", StageBeta, WithRegisterMutable())
+	assert.True(t, mutableGate.IsMutable())
+	assert.NoError(t, r.SetAtRuntime(mutableGate.ID(), false))
+	assert.False(t, mutableGate.IsEnabled())
+	assert.NoError(t, r.SetAtRuntime(mutableGate.ID(), true))
+	assert.True(t, mutableGate.IsEnabled())
+}
+
 func TestRegisterGateLifecycle(t *testing.T) {
 	for _, tc := range []struct {
 		name      string
@@ -91,6 +107,8 @@ func TestRegisterGateLifecycle(t *testing.T) {
 				WithRegisterDescription("test-gate"),
 				WithRegisterReferenceURL("http://example.com/issue/1"),
 				WithRegisterToVersion(""),
+				WithRegisterOwner("collector"),
+				WithRegisterMutable(),
 			},
 			enabled:   false,
 			shouldErr: false,
@@ -140,6 +158,16 @@ func TestRegisterGateLifecycle(t *testing.T) {
 			stage:     StageDeprecated,
 			shouldErr: true,
 		},
+		{
+			name:  "StageStable gate mutable",
+			id:    "test-gate",
+			stage: StageStable,
+			opts: []RegisterOption{
+				WithRegisterToVersion("next"),
+				WithRegisterMutable(),
+			},
+			shouldErr: true,
+		},
 		{
 			name:      "Duplicate gate",
 			id:        "existing-gate",
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package componenterror identifies the component causing an error, so that it can be reported
+// without parsing the error message.
+package componenterror // import "go.opentelemetry.io/collector/internal/componenterror"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Error is an error caused by a component. Its message is the message of the wrapped error.
+type Error struct {
+	Kind component.Kind
+	ID   component.ID
+	Err  error
+}
+
+// New returns err identifying the component of the given kind and ID as its cause, or nil if err is nil.
+// If err is already identifying a component, it is returned unchanged.
+func New(kind component.Kind, id component.ID, err error) error {
+	if err == nil {
+		return nil
+	}
+	var ce *Error
+	if errors.As(err, &ce) {
+		return err
+	}
+	return &Error{Kind: kind, ID: id, Err: err}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// KindString returns the name of the kind as used in the configuration.
+func KindString(kind component.Kind) string {
+	switch kind {
+	case component.KindReceiver:
+		return "receiver"
+	case component.KindProcessor:
+		return "processor"
+	case component.KindExporter:
+		return "exporter"
+	case component.KindExtension:
+		return "extension"
+	case component.KindConnector:
+		return "connector"
+	}
+	return ""
+}
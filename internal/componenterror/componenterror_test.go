@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package componenterror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestNew(t *testing.T) {
+	assert.NoError(t, New(component.KindReceiver, component.NewID("otlp"), nil))
+
+	cause := errors.New("address already in use")
+	err := fmt.Errorf("cannot start pipelines: %w", New(component.KindReceiver, component.NewID("otlp"), cause))
+	assert.EqualError(t, err, "cannot start pipelines: address already in use")
+	assert.ErrorIs(t, err, cause)
+
+	var ce *Error
+	require.True(t, errors.As(err, &ce))
+	assert.Equal(t, component.KindReceiver, ce.Kind)
+	assert.Equal(t, component.NewID("otlp"), ce.ID)
+
+	// The innermost component is kept.
+	wrapped := New(component.KindConnector, component.NewID("forward"), err)
+	require.True(t, errors.As(wrapped, &ce))
+	assert.Equal(t, component.KindReceiver, ce.Kind)
+}
+
+func TestKindString(t *testing.T) {
+	assert.Equal(t, "receiver", KindString(component.KindReceiver))
+	assert.Equal(t, "processor", KindString(component.KindProcessor))
+	assert.Equal(t, "exporter", KindString(component.KindExporter))
+	assert.Equal(t, "extension", KindString(component.KindExtension))
+	assert.Equal(t, "connector", KindString(component.KindConnector))
+	assert.Equal(t, "", KindString(component.Kind(0)))
+}
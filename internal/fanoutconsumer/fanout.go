@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fanoutconsumer // import "go.opentelemetry.io/collector/internal/fanoutconsumer"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+// ErrorPolicy defines how the errors of the consumers are reported when the data is sent to them concurrently.
+type ErrorPolicy int
+
+const (
+	// BestEffort waits for all the consumers, and returns the errors of all of them.
+	BestEffort ErrorPolicy = iota
+	// FailFast returns the first error as soon as a consumer fails, and cancels the context of the others.
+	// Each consumer is sent its own copy of the data, since the others may still use it.
+	FailFast
+)
+
+// Option configures the fan out.
+type Option func(*settings)
+
+// WithParallel sends the data to the consumers concurrently instead of one after the other,
+// so that a slow consumer does not delay the others.
+func WithParallel() Option {
+	return func(s *settings) {
+		s.parallel = true
+	}
+}
+
+// WithTimeout bounds the time the consumers have to consume the data when it is sent to them concurrently.
+// The context of the consumers expires after the timeout, and the consumers which did not return are reported
+// as failed with context.DeadlineExceeded. Each consumer is sent its own copy of the data, since the consumers
+// which did not return may still use it. Zero means no timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *settings) {
+		s.timeout = timeout
+	}
+}
+
+// WithErrorPolicy sets how the errors of the consumers are reported when the data is sent to them concurrently.
+// The default is BestEffort.
+func WithErrorPolicy(policy ErrorPolicy) Option {
+	return func(s *settings) {
+		s.policy = policy
+	}
+}
+
+type settings struct {
+	parallel bool
+	timeout  time.Duration
+	policy   ErrorPolicy
+}
+
+func newSettings(options []Option) settings {
+	var s settings
+	for _, op := range options {
+		op(&s)
+	}
+	return s
+}
+
+// returnsEarly tells whether send may return before all the consumers return, with the FailFast policy or
+// a timeout. The consumers must then not share the data of the caller, which owns it again once send returns.
+func (s settings) returnsEarly() bool {
+	return s.parallel && (s.policy == FailFast || s.timeout > 0)
+}
+
+// send calls the consume functions, and returns their errors.
+func (s settings) send(ctx context.Context, calls []func(context.Context) error) error {
+	if !s.parallel {
+		var errs error
+		for _, call := range calls {
+			errs = multierr.Append(errs, call(ctx))
+		}
+		return errs
+	}
+
+	// The consumers still running when returning are canceled.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var timeoutC <-chan time.Time
+	if s.timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, s.timeout)
+		defer cancelTimeout()
+		timer := time.NewTimer(s.timeout)
+		defer timer.Stop()
+		timeoutC = timer.C
+	}
+
+	results := make(chan error, len(calls))
+	for _, call := range calls {
+		go func(call func(context.Context) error) {
+			results <- call(ctx)
+		}(call)
+	}
+	var errs error
+	for pending := len(calls); pending > 0; pending-- {
+		select {
+		case err := <-results:
+			if err == nil {
+				continue
+			}
+			if s.policy == FailFast {
+				return err
+			}
+			errs = multierr.Append(errs, err)
+		case <-timeoutC:
+			return multierr.Append(errs, fmt.Errorf("%d consumers did not complete within %v: %w", pending, s.timeout, context.DeadlineExceeded))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fanoutconsumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// blockingTraces returns a consumer which blocks until release is closed or its context is done.
+func blockingTraces(release <-chan struct{}) consumer.Traces {
+	tc, _ := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	return tc
+}
+
+func TestTracesParallelSingleConsumer(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	tfc := NewTraces([]consumer.Traces{sink}, WithParallel())
+	assert.NotSame(t, sink, tfc)
+
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
+func TestTracesParallelDoesNotWaitSequentially(t *testing.T) {
+	release := make(chan struct{})
+	sink := new(consumertest.TracesSink)
+	done := make(chan struct{})
+	tc, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		defer close(done)
+		return sink.ConsumeTraces(ctx, td)
+	})
+	require.NoError(t, err)
+
+	// The blocking consumer comes first, a sequential fan out would never reach the second one.
+	tfc := NewTraces([]consumer.Traces{blockingTraces(release), tc}, WithParallel())
+	result := make(chan error, 1)
+	go func() {
+		result <- tfc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	}()
+
+	<-done
+	assert.Equal(t, 1, sink.SpanCount())
+	close(release)
+	assert.NoError(t, <-result)
+}
+
+func TestTracesParallelMutating(t *testing.T) {
+	p1 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	p2 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	p3 := new(consumertest.TracesSink)
+
+	tfc := NewTraces([]consumer.Traces{p1, p2, p3}, WithParallel())
+	td := testdata.GenerateTraces(1)
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+
+	assert.True(t, td != p1.AllTraces()[0])
+	assert.True(t, td != p2.AllTraces()[0])
+	assert.True(t, td == p3.AllTraces()[0])
+	assert.EqualValues(t, td, p1.AllTraces()[0])
+	assert.EqualValues(t, td, p2.AllTraces()[0])
+}
+
+func TestTracesParallelBestEffort(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	tfc := NewTraces([]consumer.Traces{
+		consumertest.NewErr(errors.New("first")),
+		sink,
+		consumertest.NewErr(errors.New("second")),
+	}, WithParallel())
+
+	err := tfc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	assert.ErrorContains(t, err, "first")
+	assert.ErrorContains(t, err, "second")
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
+func TestTracesParallelFailFast(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	errFailed := errors.New("failed")
+	tfc := NewTraces([]consumer.Traces{blockingTraces(release), consumertest.NewErr(errFailed)},
+		WithParallel(), WithErrorPolicy(FailFast))
+
+	assert.Equal(t, errFailed, tfc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+}
+
+func TestTracesParallelTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	sink := new(consumertest.TracesSink)
+	tfc := NewTraces([]consumer.Traces{blockingTraces(release), sink},
+		WithParallel(), WithTimeout(10*time.Millisecond))
+
+	err := tfc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
+func TestTracesParallelReturnsEarlyCopies(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		options []Option
+	}{
+		{name: "fail_fast", options: []Option{WithErrorPolicy(FailFast)}},
+		{name: "timeout", options: []Option{WithTimeout(10 * time.Millisecond)}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			received := make(chan ptrace.Traces, 2)
+			blocking, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+				received <- td
+				<-release
+				return nil
+			})
+			require.NoError(t, err)
+			sink := new(consumertest.TracesSink)
+			tfc := NewTraces([]consumer.Traces{blocking, blocking, sink, consumertest.NewErr(errors.New("failed"))},
+				append(tt.options, WithParallel())...)
+
+			td := testdata.GenerateTraces(1)
+			assert.Error(t, tfc.ConsumeTraces(context.Background(), td))
+			// The consumers still running do not share the data of the caller.
+			for i := 0; i < 2; i++ {
+				got := <-received
+				assert.True(t, td != got)
+				assert.EqualValues(t, td, got)
+			}
+			close(release)
+		})
+	}
+}
+
+func TestTracesSequentialIgnoresPolicy(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	tfc := NewTraces([]consumer.Traces{consumertest.NewErr(errors.New("failed")), sink}, WithErrorPolicy(FailFast))
+
+	assert.Error(t, tfc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
+func TestTracesAggregatedErrorDetails(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		options []Option
+	}{
+		{name: "sequential"},
+		{name: "parallel", options: []Option{WithParallel()}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tfc := NewTraces([]consumer.Traces{
+				consumertest.NewErr(consumererror.NewPartial(errors.New("rejected"), 2)),
+				consumertest.NewErr(consumererror.NewRetryAfter(errors.New("throttled"), time.Second)),
+				new(consumertest.TracesSink),
+			}, tt.options...)
+
+			err := tfc.ConsumeTraces(context.Background(), testdata.GenerateTraces(5))
+			assert.Equal(t, consumererror.Counts{Permanent: 2, Retryable: 3}, consumererror.Count(err, 5))
+			delay, ok := consumererror.RetryAfter(err)
+			assert.True(t, ok)
+			assert.Equal(t, time.Second, delay)
+		})
+	}
+}
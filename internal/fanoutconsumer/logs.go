@@ -20,36 +20,29 @@ import (
 // NewLogs wraps multiple log consumers in a single one.
 // It fanouts the incoming data to all the consumers, and does smart routing:
 //   - Clones only to the consumer that needs to mutate the data.
-//   - If all consumers needs to mutate the data one will get the original data.
-func NewLogs(lcs []consumer.Logs) consumer.Logs {
-	if len(lcs) == 1 {
+//   - If all consumers needs to mutate the data one will get the original mutable data.
+//   - If more than one consumer doesn't mutate the data, the data is marked as read-only and shared.
+func NewLogs(lcs []consumer.Logs, options ...Option) consumer.Logs {
+	set := newSettings(options)
+	if len(lcs) == 1 && !set.parallel {
 		// Don't wrap if no need to do it.
 		return lcs[0]
 	}
-	var pass []consumer.Logs
-	var clone []consumer.Logs
-	for i := 0; i < len(lcs)-1; i++ {
-		if !lcs[i].Capabilities().MutatesData {
-			pass = append(pass, lcs[i])
+	lc := &logsConsumer{settings: set}
+	for i := 0; i < len(lcs); i++ {
+		if lcs[i].Capabilities().MutatesData {
+			lc.mutable = append(lc.mutable, lcs[i])
 		} else {
-			clone = append(clone, lcs[i])
+			lc.readonly = append(lc.readonly, lcs[i])
 		}
 	}
-	// Give the original data to the last consumer if no other read-only consumer,
-	// otherwise put it in the right bucket. Never share the same data between
-	// a mutating and a non-mutating consumer since the non-mutating consumer may process
-	// data async and the mutating consumer may change the data before that.
-	if len(pass) == 0 || !lcs[len(lcs)-1].Capabilities().MutatesData {
-		pass = append(pass, lcs[len(lcs)-1])
-	} else {
-		clone = append(clone, lcs[len(lcs)-1])
-	}
-	return &logsConsumer{pass: pass, clone: clone}
+	return lc
 }
 
 type logsConsumer struct {
-	pass  []consumer.Logs
-	clone []consumer.Logs
+	settings settings
+	mutable  []consumer.Logs
+	readonly []consumer.Logs
 }
 
 func (lsc *logsConsumer) Capabilities() consumer.Capabilities {
@@ -58,19 +51,58 @@ func (lsc *logsConsumer) Capabilities() consumer.Capabilities {
 
 // ConsumeLogs exports the plog.Logs to all consumers wrapped by the current one.
 func (lsc *logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
-	var errs error
-	// Initially pass to clone exporter to avoid the case where the optimization of sending
-	// the incoming data to a mutating consumer is used that may change the incoming data before
-	// cloning.
-	for _, lc := range lsc.clone {
-		clonedLogs := plog.NewLogs()
-		ld.CopyTo(clonedLogs)
-		errs = multierr.Append(errs, lc.ConsumeLogs(ctx, clonedLogs))
+	// The data of every consumer is prepared before calling any of them, since they may be called concurrently.
+	calls := make([]func(context.Context) error, 0, len(lsc.mutable)+len(lsc.readonly))
+
+	if lsc.settings.returnsEarly() {
+		// The consumers may still be running once the caller owns the data again, each gets its own copy.
+		for _, lc := range lsc.mutable {
+			calls = append(calls, consumeLogs(lc, cloneLogs(ld)))
+		}
+		for _, lc := range lsc.readonly {
+			calls = append(calls, consumeLogs(lc, cloneLogs(ld)))
+		}
+		return lsc.settings.send(ctx, calls)
 	}
-	for _, lc := range lsc.pass {
-		errs = multierr.Append(errs, lc.ConsumeLogs(ctx, ld))
+
+	if len(lsc.mutable) > 0 {
+		// Clone the data before sending to all mutating consumers except the last one.
+		for i := 0; i < len(lsc.mutable)-1; i++ {
+			calls = append(calls, consumeLogs(lsc.mutable[i], cloneLogs(ld)))
+		}
+		// Send the data as is to the last mutating consumer only if there are no non-mutating consumers
+		// and the data is mutable. Never share the same data between a mutating and a non-mutating consumer
+		// since the non-mutating consumer may process data async and the mutating consumer may change the
+		// data before that.
+		lastConsumer := lsc.mutable[len(lsc.mutable)-1]
+		if len(lsc.readonly) == 0 && !ld.IsReadOnly() {
+			calls = append(calls, consumeLogs(lastConsumer, ld))
+		} else {
+			calls = append(calls, consumeLogs(lastConsumer, cloneLogs(ld)))
+		}
 	}
-	return errs
+
+	// Mark the data as read-only if it is shared by more than one non-mutating consumer.
+	if len(lsc.readonly) > 1 && !ld.IsReadOnly() {
+		ld.MarkReadOnly()
+	}
+	for _, lc := range lsc.readonly {
+		calls = append(calls, consumeLogs(lc, ld))
+	}
+
+	return lsc.settings.send(ctx, calls)
+}
+
+func consumeLogs(next consumer.Logs, ld plog.Logs) func(context.Context) error {
+	return func(ctx context.Context) error {
+		return next.ConsumeLogs(ctx, ld)
+	}
+}
+
+func cloneLogs(ld plog.Logs) plog.Logs {
+	clonedLogs := plog.NewLogs()
+	ld.CopyTo(clonedLogs)
+	return clonedLogs
 }
 
 var _ connector.LogsRouter = (*logsRouter)(nil)
@@ -57,6 +57,9 @@ func TestLogsMultiplexingNonMutating(t *testing.T) {
 	assert.True(t, ld == p3.AllLogs()[1])
 	assert.EqualValues(t, ld, p3.AllLogs()[0])
 	assert.EqualValues(t, ld, p3.AllLogs()[1])
+
+	// The data is shared by the non-mutating consumers.
+	assert.True(t, ld.IsReadOnly())
 }
 
 func TestLogsMultiplexingMutating(t *testing.T) {
@@ -93,6 +96,41 @@ func TestLogsMultiplexingMutating(t *testing.T) {
 	assert.EqualValues(t, ld, p3.AllLogs()[1])
 }
 
+func TestLogsMultiplexingReadOnlyMutating(t *testing.T) {
+	p1 := &mutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
+	p2 := &mutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
+	p3 := &mutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
+
+	lfc := NewLogs([]consumer.Logs{p1, p2, p3})
+	assert.False(t, lfc.Capabilities().MutatesData)
+	ld := testdata.GenerateLogs(1)
+	ld.MarkReadOnly()
+
+	for i := 0; i < 2; i++ {
+		err := lfc.ConsumeLogs(context.Background(), ld)
+		if err != nil {
+			t.Errorf("Wanted nil got error")
+			return
+		}
+	}
+
+	assert.True(t, ld != p1.AllLogs()[0])
+	assert.True(t, ld != p1.AllLogs()[1])
+	assert.False(t, p1.AllLogs()[0].IsReadOnly())
+	assert.False(t, p1.AllLogs()[1].IsReadOnly())
+
+	assert.True(t, ld != p2.AllLogs()[0])
+	assert.True(t, ld != p2.AllLogs()[1])
+	assert.False(t, p2.AllLogs()[0].IsReadOnly())
+	assert.False(t, p2.AllLogs()[1].IsReadOnly())
+
+	// The read-only data is cloned for all the mutating consumers.
+	assert.True(t, ld != p3.AllLogs()[0])
+	assert.True(t, ld != p3.AllLogs()[1])
+	assert.False(t, p3.AllLogs()[0].IsReadOnly())
+	assert.False(t, p3.AllLogs()[1].IsReadOnly())
+}
+
 func TestLogsMultiplexingMixLastMutating(t *testing.T) {
 	p1 := &mutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
 	p2 := new(consumertest.LogsSink)
@@ -57,6 +57,9 @@ func TestMetricsMultiplexingNonMutating(t *testing.T) {
 	assert.True(t, md == p3.AllMetrics()[1])
 	assert.EqualValues(t, md, p3.AllMetrics()[0])
 	assert.EqualValues(t, md, p3.AllMetrics()[1])
+
+	// The data is shared by the non-mutating consumers.
+	assert.True(t, md.IsReadOnly())
 }
 
 func TestMetricsMultiplexingMutating(t *testing.T) {
@@ -93,6 +96,41 @@ func TestMetricsMultiplexingMutating(t *testing.T) {
 	assert.EqualValues(t, md, p3.AllMetrics()[1])
 }
 
+func TestMetricsMultiplexingReadOnlyMutating(t *testing.T) {
+	p1 := &mutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
+	p2 := &mutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
+	p3 := &mutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
+
+	mfc := NewMetrics([]consumer.Metrics{p1, p2, p3})
+	assert.False(t, mfc.Capabilities().MutatesData)
+	md := testdata.GenerateMetrics(1)
+	md.MarkReadOnly()
+
+	for i := 0; i < 2; i++ {
+		err := mfc.ConsumeMetrics(context.Background(), md)
+		if err != nil {
+			t.Errorf("Wanted nil got error")
+			return
+		}
+	}
+
+	assert.True(t, md != p1.AllMetrics()[0])
+	assert.True(t, md != p1.AllMetrics()[1])
+	assert.False(t, p1.AllMetrics()[0].IsReadOnly())
+	assert.False(t, p1.AllMetrics()[1].IsReadOnly())
+
+	assert.True(t, md != p2.AllMetrics()[0])
+	assert.True(t, md != p2.AllMetrics()[1])
+	assert.False(t, p2.AllMetrics()[0].IsReadOnly())
+	assert.False(t, p2.AllMetrics()[1].IsReadOnly())
+
+	// The read-only data is cloned for all the mutating consumers.
+	assert.True(t, md != p3.AllMetrics()[0])
+	assert.True(t, md != p3.AllMetrics()[1])
+	assert.False(t, p3.AllMetrics()[0].IsReadOnly())
+	assert.False(t, p3.AllMetrics()[1].IsReadOnly())
+}
+
 func TestMetricsMultiplexingMixLastMutating(t *testing.T) {
 	p1 := &mutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
 	p2 := new(consumertest.MetricsSink)
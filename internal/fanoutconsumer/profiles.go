@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fanoutconsumer // import "go.opentelemetry.io/collector/internal/fanoutconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+)
+
+// NewProfiles wraps multiple profile consumers in a single one.
+// It fanouts the incoming data to all the consumers, and does smart routing:
+//   - Clones only to the consumer that needs to mutate the data.
+//   - If all consumers needs to mutate the data one will get the original mutable data.
+//   - If more than one consumer doesn't mutate the data, the data is marked as read-only and shared.
+func NewProfiles(pcs []consumer.Profiles, options ...Option) consumer.Profiles {
+	set := newSettings(options)
+	if len(pcs) == 1 && !set.parallel {
+		// Don't wrap if no need to do it.
+		return pcs[0]
+	}
+	pc := &profilesConsumer{settings: set}
+	for i := 0; i < len(pcs); i++ {
+		if pcs[i].Capabilities().MutatesData {
+			pc.mutable = append(pc.mutable, pcs[i])
+		} else {
+			pc.readonly = append(pc.readonly, pcs[i])
+		}
+	}
+	return pc
+}
+
+type profilesConsumer struct {
+	settings settings
+	mutable  []consumer.Profiles
+	readonly []consumer.Profiles
+}
+
+func (psc *profilesConsumer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeProfiles exports the pprofile.Profiles to all consumers wrapped by the current one.
+func (psc *profilesConsumer) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	// The data of every consumer is prepared before calling any of them, since they may be called concurrently.
+	calls := make([]func(context.Context) error, 0, len(psc.mutable)+len(psc.readonly))
+
+	if psc.settings.returnsEarly() {
+		// The consumers may still be running once the caller owns the data again, each gets its own copy.
+		for _, pc := range psc.mutable {
+			calls = append(calls, consumeProfiles(pc, cloneProfiles(pd)))
+		}
+		for _, pc := range psc.readonly {
+			calls = append(calls, consumeProfiles(pc, cloneProfiles(pd)))
+		}
+		return psc.settings.send(ctx, calls)
+	}
+
+	if len(psc.mutable) > 0 {
+		// Clone the data before sending to all mutating consumers except the last one.
+		for i := 0; i < len(psc.mutable)-1; i++ {
+			calls = append(calls, consumeProfiles(psc.mutable[i], cloneProfiles(pd)))
+		}
+		// Send the data as is to the last mutating consumer only if there are no non-mutating consumers
+		// and the data is mutable. Never share the same data between a mutating and a non-mutating consumer
+		// since the non-mutating consumer may process data async and the mutating consumer may change the
+		// data before that.
+		lastConsumer := psc.mutable[len(psc.mutable)-1]
+		if len(psc.readonly) == 0 && !pd.IsReadOnly() {
+			calls = append(calls, consumeProfiles(lastConsumer, pd))
+		} else {
+			calls = append(calls, consumeProfiles(lastConsumer, cloneProfiles(pd)))
+		}
+	}
+
+	// Mark the data as read-only if it is shared by more than one non-mutating consumer.
+	if len(psc.readonly) > 1 && !pd.IsReadOnly() {
+		pd.MarkReadOnly()
+	}
+	for _, pc := range psc.readonly {
+		calls = append(calls, consumeProfiles(pc, pd))
+	}
+
+	return psc.settings.send(ctx, calls)
+}
+
+func consumeProfiles(next consumer.Profiles, pd pprofile.Profiles) func(context.Context) error {
+	return func(ctx context.Context) error {
+		return next.ConsumeProfiles(ctx, pd)
+	}
+}
+
+func cloneProfiles(pd pprofile.Profiles) pprofile.Profiles {
+	clonedProfiles := pprofile.NewProfiles()
+	pd.CopyTo(clonedProfiles)
+	return clonedProfiles
+}
@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fanoutconsumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestProfilesNotMultiplexing(t *testing.T) {
+	nop := consumertest.NewNop()
+	pfc := NewProfiles([]consumer.Profiles{nop})
+	assert.Same(t, nop, pfc)
+}
+
+func TestProfilesMultiplexingNonMutating(t *testing.T) {
+	p1 := new(consumertest.ProfilesSink)
+	p2 := new(consumertest.ProfilesSink)
+	p3 := new(consumertest.ProfilesSink)
+
+	pfc := NewProfiles([]consumer.Profiles{p1, p2, p3})
+	assert.False(t, pfc.Capabilities().MutatesData)
+	pd := testdata.GenerateProfiles(1)
+
+	for i := 0; i < 2; i++ {
+		err := pfc.ConsumeProfiles(context.Background(), pd)
+		if err != nil {
+			t.Errorf("Wanted nil got error")
+			return
+		}
+	}
+
+	assert.True(t, pd == p1.AllProfiles()[0])
+	assert.True(t, pd == p1.AllProfiles()[1])
+	assert.EqualValues(t, pd, p1.AllProfiles()[0])
+	assert.EqualValues(t, pd, p1.AllProfiles()[1])
+
+	assert.True(t, pd == p2.AllProfiles()[0])
+	assert.True(t, pd == p2.AllProfiles()[1])
+	assert.EqualValues(t, pd, p2.AllProfiles()[0])
+	assert.EqualValues(t, pd, p2.AllProfiles()[1])
+
+	assert.True(t, pd == p3.AllProfiles()[0])
+	assert.True(t, pd == p3.AllProfiles()[1])
+	assert.EqualValues(t, pd, p3.AllProfiles()[0])
+	assert.EqualValues(t, pd, p3.AllProfiles()[1])
+
+	// The data is shared by the non-mutating consumers.
+	assert.True(t, pd.IsReadOnly())
+}
+
+func TestProfilesMultiplexingMutating(t *testing.T) {
+	p1 := &mutatingProfilesSink{ProfilesSink: new(consumertest.ProfilesSink)}
+	p2 := &mutatingProfilesSink{ProfilesSink: new(consumertest.ProfilesSink)}
+	p3 := &mutatingProfilesSink{ProfilesSink: new(consumertest.ProfilesSink)}
+
+	pfc := NewProfiles([]consumer.Profiles{p1, p2, p3})
+	assert.False(t, pfc.Capabilities().MutatesData)
+	pd := testdata.GenerateProfiles(1)
+
+	for i := 0; i < 2; i++ {
+		err := pfc.ConsumeProfiles(context.Background(), pd)
+		if err != nil {
+			t.Errorf("Wanted nil got error")
+			return
+		}
+	}
+
+	assert.True(t, pd != p1.AllProfiles()[0])
+	assert.True(t, pd != p1.AllProfiles()[1])
+	assert.EqualValues(t, pd, p1.AllProfiles()[0])
+	assert.EqualValues(t, pd, p1.AllProfiles()[1])
+
+	assert.True(t, pd != p2.AllProfiles()[0])
+	assert.True(t, pd != p2.AllProfiles()[1])
+	assert.EqualValues(t, pd, p2.AllProfiles()[0])
+	assert.EqualValues(t, pd, p2.AllProfiles()[1])
+
+	// For this consumer, will receive the initial data.
+	assert.True(t, pd == p3.AllProfiles()[0])
+	assert.True(t, pd == p3.AllProfiles()[1])
+	assert.EqualValues(t, pd, p3.AllProfiles()[0])
+	assert.EqualValues(t, pd, p3.AllProfiles()[1])
+}
+
+func TestProfilesMultiplexingReadOnlyMutating(t *testing.T) {
+	p1 := &mutatingProfilesSink{ProfilesSink: new(consumertest.ProfilesSink)}
+	p2 := &mutatingProfilesSink{ProfilesSink: new(consumertest.ProfilesSink)}
+	p3 := &mutatingProfilesSink{ProfilesSink: new(consumertest.ProfilesSink)}
+
+	pfc := NewProfiles([]consumer.Profiles{p1, p2, p3})
+	assert.False(t, pfc.Capabilities().MutatesData)
+	pd := testdata.GenerateProfiles(1)
+	pd.MarkReadOnly()
+
+	for i := 0; i < 2; i++ {
+		err := pfc.ConsumeProfiles(context.Background(), pd)
+		if err != nil {
+			t.Errorf("Wanted nil got error")
+			return
+		}
+	}
+
+	assert.True(t, pd != p1.AllProfiles()[0])
+	assert.True(t, pd != p1.AllProfiles()[1])
+	assert.False(t, p1.AllProfiles()[0].IsReadOnly())
+	assert.False(t, p1.AllProfiles()[1].IsReadOnly())
+
+	assert.True(t, pd != p2.AllProfiles()[0])
+	assert.True(t, pd != p2.AllProfiles()[1])
+	assert.False(t, p2.AllProfiles()[0].IsReadOnly())
+	assert.False(t, p2.AllProfiles()[1].IsReadOnly())
+
+	// The read-only data is cloned for all the mutating consumers.
+	assert.True(t, pd != p3.AllProfiles()[0])
+	assert.True(t, pd != p3.AllProfiles()[1])
+	assert.False(t, p3.AllProfiles()[0].IsReadOnly())
+	assert.False(t, p3.AllProfiles()[1].IsReadOnly())
+}
+
+func TestProfilesMultiplexingMixLastMutating(t *testing.T) {
+	p1 := &mutatingProfilesSink{ProfilesSink: new(consumertest.ProfilesSink)}
+	p2 := new(consumertest.ProfilesSink)
+	p3 := &mutatingProfilesSink{ProfilesSink: new(consumertest.ProfilesSink)}
+
+	pfc := NewProfiles([]consumer.Profiles{p1, p2, p3})
+	assert.False(t, pfc.Capabilities().MutatesData)
+	pd := testdata.GenerateProfiles(1)
+
+	for i := 0; i < 2; i++ {
+		err := pfc.ConsumeProfiles(context.Background(), pd)
+		if err != nil {
+			t.Errorf("Wanted nil got error")
+			return
+		}
+	}
+
+	assert.True(t, pd != p1.AllProfiles()[0])
+	assert.True(t, pd != p1.AllProfiles()[1])
+	assert.EqualValues(t, pd, p1.AllProfiles()[0])
+	assert.EqualValues(t, pd, p1.AllProfiles()[1])
+
+	// For this consumer, will receive the initial data.
+	assert.True(t, pd == p2.AllProfiles()[0])
+	assert.True(t, pd == p2.AllProfiles()[1])
+	assert.EqualValues(t, pd, p2.AllProfiles()[0])
+	assert.EqualValues(t, pd, p2.AllProfiles()[1])
+
+	// For this consumer, will clone the initial data.
+	assert.True(t, pd != p3.AllProfiles()[0])
+	assert.True(t, pd != p3.AllProfiles()[1])
+	assert.EqualValues(t, pd, p3.AllProfiles()[0])
+	assert.EqualValues(t, pd, p3.AllProfiles()[1])
+}
+
+func TestProfilesMultiplexingMixLastNonMutating(t *testing.T) {
+	p1 := &mutatingProfilesSink{ProfilesSink: new(consumertest.ProfilesSink)}
+	p2 := &mutatingProfilesSink{ProfilesSink: new(consumertest.ProfilesSink)}
+	p3 := new(consumertest.ProfilesSink)
+
+	pfc := NewProfiles([]consumer.Profiles{p1, p2, p3})
+	assert.False(t, pfc.Capabilities().MutatesData)
+	pd := testdata.GenerateProfiles(1)
+
+	for i := 0; i < 2; i++ {
+		err := pfc.ConsumeProfiles(context.Background(), pd)
+		if err != nil {
+			t.Errorf("Wanted nil got error")
+			return
+		}
+	}
+
+	assert.True(t, pd != p1.AllProfiles()[0])
+	assert.True(t, pd != p1.AllProfiles()[1])
+	assert.EqualValues(t, pd, p1.AllProfiles()[0])
+	assert.EqualValues(t, pd, p1.AllProfiles()[1])
+
+	assert.True(t, pd != p2.AllProfiles()[0])
+	assert.True(t, pd != p2.AllProfiles()[1])
+	assert.EqualValues(t, pd, p2.AllProfiles()[0])
+	assert.EqualValues(t, pd, p2.AllProfiles()[1])
+
+	// For this consumer, will receive the initial data.
+	assert.True(t, pd == p3.AllProfiles()[0])
+	assert.True(t, pd == p3.AllProfiles()[1])
+	assert.EqualValues(t, pd, p3.AllProfiles()[0])
+	assert.EqualValues(t, pd, p3.AllProfiles()[1])
+}
+
+func TestProfilesWhenErrors(t *testing.T) {
+	p1 := mutatingErr{Consumer: consumertest.NewErr(errors.New("my error"))}
+	p2 := consumertest.NewErr(errors.New("my error"))
+	p3 := new(consumertest.ProfilesSink)
+
+	pfc := NewProfiles([]consumer.Profiles{p1, p2, p3})
+	pd := testdata.GenerateProfiles(1)
+
+	for i := 0; i < 2; i++ {
+		assert.Error(t, pfc.ConsumeProfiles(context.Background(), pd))
+	}
+
+	assert.True(t, pd == p3.AllProfiles()[0])
+	assert.True(t, pd == p3.AllProfiles()[1])
+	assert.EqualValues(t, pd, p3.AllProfiles()[0])
+	assert.EqualValues(t, pd, p3.AllProfiles()[1])
+}
+
+type mutatingProfilesSink struct {
+	*consumertest.ProfilesSink
+}
+
+func (mts *mutatingProfilesSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
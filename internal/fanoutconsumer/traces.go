@@ -18,36 +18,29 @@ import (
 // NewTraces wraps multiple trace consumers in a single one.
 // It fanouts the incoming data to all the consumers, and does smart routing:
 //   - Clones only to the consumer that needs to mutate the data.
-//   - If all consumers needs to mutate the data one will get the original data.
-func NewTraces(tcs []consumer.Traces) consumer.Traces {
-	if len(tcs) == 1 {
+//   - If all consumers needs to mutate the data one will get the original mutable data.
+//   - If more than one consumer doesn't mutate the data, the data is marked as read-only and shared.
+func NewTraces(tcs []consumer.Traces, options ...Option) consumer.Traces {
+	set := newSettings(options)
+	if len(tcs) == 1 && !set.parallel {
 		// Don't wrap if no need to do it.
 		return tcs[0]
 	}
-	var pass []consumer.Traces
-	var clone []consumer.Traces
-	for i := 0; i < len(tcs)-1; i++ {
-		if !tcs[i].Capabilities().MutatesData {
-			pass = append(pass, tcs[i])
+	tc := &tracesConsumer{settings: set}
+	for i := 0; i < len(tcs); i++ {
+		if tcs[i].Capabilities().MutatesData {
+			tc.mutable = append(tc.mutable, tcs[i])
 		} else {
-			clone = append(clone, tcs[i])
+			tc.readonly = append(tc.readonly, tcs[i])
 		}
 	}
-	// Give the original data to the last consumer if no other read-only consumer,
-	// otherwise put it in the right bucket. Never share the same data between
-	// a mutating and a non-mutating consumer since the non-mutating consumer may process
-	// data async and the mutating consumer may change the data before that.
-	if len(pass) == 0 || !tcs[len(tcs)-1].Capabilities().MutatesData {
-		pass = append(pass, tcs[len(tcs)-1])
-	} else {
-		clone = append(clone, tcs[len(tcs)-1])
-	}
-	return &tracesConsumer{pass: pass, clone: clone}
+	return tc
 }
 
 type tracesConsumer struct {
-	pass  []consumer.Traces
-	clone []consumer.Traces
+	settings settings
+	mutable  []consumer.Traces
+	readonly []consumer.Traces
 }
 
 func (tsc *tracesConsumer) Capabilities() consumer.Capabilities {
@@ -56,19 +49,58 @@ func (tsc *tracesConsumer) Capabilities() consumer.Capabilities {
 
 // ConsumeTraces exports the ptrace.Traces to all consumers wrapped by the current one.
 func (tsc *tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
-	var errs error
-	// Initially pass to clone exporter to avoid the case where the optimization of sending
-	// the incoming data to a mutating consumer is used that may change the incoming data before
-	// cloning.
-	for _, tc := range tsc.clone {
-		clonedTraces := ptrace.NewTraces()
-		td.CopyTo(clonedTraces)
-		errs = multierr.Append(errs, tc.ConsumeTraces(ctx, clonedTraces))
+	// The data of every consumer is prepared before calling any of them, since they may be called concurrently.
+	calls := make([]func(context.Context) error, 0, len(tsc.mutable)+len(tsc.readonly))
+
+	if tsc.settings.returnsEarly() {
+		// The consumers may still be running once the caller owns the data again, each gets its own copy.
+		for _, tc := range tsc.mutable {
+			calls = append(calls, consumeTraces(tc, cloneTraces(td)))
+		}
+		for _, tc := range tsc.readonly {
+			calls = append(calls, consumeTraces(tc, cloneTraces(td)))
+		}
+		return tsc.settings.send(ctx, calls)
 	}
-	for _, tc := range tsc.pass {
-		errs = multierr.Append(errs, tc.ConsumeTraces(ctx, td))
+
+	if len(tsc.mutable) > 0 {
+		// Clone the data before sending to all mutating consumers except the last one.
+		for i := 0; i < len(tsc.mutable)-1; i++ {
+			calls = append(calls, consumeTraces(tsc.mutable[i], cloneTraces(td)))
+		}
+		// Send the data as is to the last mutating consumer only if there are no non-mutating consumers
+		// and the data is mutable. Never share the same data between a mutating and a non-mutating consumer
+		// since the non-mutating consumer may process data async and the mutating consumer may change the
+		// data before that.
+		lastConsumer := tsc.mutable[len(tsc.mutable)-1]
+		if len(tsc.readonly) == 0 && !td.IsReadOnly() {
+			calls = append(calls, consumeTraces(lastConsumer, td))
+		} else {
+			calls = append(calls, consumeTraces(lastConsumer, cloneTraces(td)))
+		}
 	}
-	return errs
+
+	// Mark the data as read-only if it is shared by more than one non-mutating consumer.
+	if len(tsc.readonly) > 1 && !td.IsReadOnly() {
+		td.MarkReadOnly()
+	}
+	for _, tc := range tsc.readonly {
+		calls = append(calls, consumeTraces(tc, td))
+	}
+
+	return tsc.settings.send(ctx, calls)
+}
+
+func consumeTraces(next consumer.Traces, td ptrace.Traces) func(context.Context) error {
+	return func(ctx context.Context) error {
+		return next.ConsumeTraces(ctx, td)
+	}
+}
+
+func cloneTraces(td ptrace.Traces) ptrace.Traces {
+	clonedTraces := ptrace.NewTraces()
+	td.CopyTo(clonedTraces)
+	return clonedTraces
 }
 
 var _ connector.TracesRouter = (*tracesRouter)(nil)
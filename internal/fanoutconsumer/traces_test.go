@@ -57,6 +57,9 @@ func TestTracesMultiplexingNonMutating(t *testing.T) {
 	assert.True(t, td == p3.AllTraces()[1])
 	assert.EqualValues(t, td, p3.AllTraces()[0])
 	assert.EqualValues(t, td, p3.AllTraces()[1])
+
+	// The data is shared by the non-mutating consumers.
+	assert.True(t, td.IsReadOnly())
 }
 
 func TestTracesMultiplexingMutating(t *testing.T) {
@@ -93,6 +96,41 @@ func TestTracesMultiplexingMutating(t *testing.T) {
 	assert.EqualValues(t, td, p3.AllTraces()[1])
 }
 
+func TestTracesMultiplexingReadOnlyMutating(t *testing.T) {
+	p1 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	p2 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	p3 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+
+	tfc := NewTraces([]consumer.Traces{p1, p2, p3})
+	assert.False(t, tfc.Capabilities().MutatesData)
+	td := testdata.GenerateTraces(1)
+	td.MarkReadOnly()
+
+	for i := 0; i < 2; i++ {
+		err := tfc.ConsumeTraces(context.Background(), td)
+		if err != nil {
+			t.Errorf("Wanted nil got error")
+			return
+		}
+	}
+
+	assert.True(t, td != p1.AllTraces()[0])
+	assert.True(t, td != p1.AllTraces()[1])
+	assert.False(t, p1.AllTraces()[0].IsReadOnly())
+	assert.False(t, p1.AllTraces()[1].IsReadOnly())
+
+	assert.True(t, td != p2.AllTraces()[0])
+	assert.True(t, td != p2.AllTraces()[1])
+	assert.False(t, p2.AllTraces()[0].IsReadOnly())
+	assert.False(t, p2.AllTraces()[1].IsReadOnly())
+
+	// The read-only data is cloned for all the mutating consumers.
+	assert.True(t, td != p3.AllTraces()[0])
+	assert.True(t, td != p3.AllTraces()[1])
+	assert.False(t, p3.AllTraces()[0].IsReadOnly())
+	assert.False(t, p3.AllTraces()[1].IsReadOnly())
+}
+
 func TestTracesMultiplexingMixLastMutating(t *testing.T) {
 	p1 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
 	p2 := new(consumertest.TracesSink)
@@ -26,6 +26,20 @@ const (
 	SentLogRecordsKey = "sent_log_records"
 	// FailedToSendLogRecordsKey used to track logs that failed to be sent by exporters.
 	FailedToSendLogRecordsKey = "send_failed_log_records"
+
+	// SentSamplesKey used to track profile samples sent by exporters.
+	SentSamplesKey = "sent_samples"
+	// FailedToSendSamplesKey used to track profile samples that failed to be sent by exporters.
+	FailedToSendSamplesKey = "send_failed_samples"
+
+	// RejectedSpansKey used to track spans rejected by the destination in partial successes.
+	RejectedSpansKey = "rejected_spans"
+	// RejectedMetricPointsKey used to track metric points rejected by the destination in partial successes.
+	RejectedMetricPointsKey = "rejected_metric_points"
+	// RejectedLogRecordsKey used to track logs rejected by the destination in partial successes.
+	RejectedLogRecordsKey = "rejected_log_records"
+	// RejectedSamplesKey used to track profile samples rejected by the destination in partial successes.
+	RejectedSamplesKey = "rejected_samples"
 )
 
 var (
@@ -35,6 +49,7 @@ var (
 	ExportTraceDataOperationSuffix = NameSep + "traces"
 	ExportMetricsOperationSuffix   = NameSep + "metrics"
 	ExportLogsOperationSuffix      = NameSep + "logs"
+	ExportProfilesOperationSuffix  = NameSep + "profiles"
 
 	// Exporter metrics. Any count of data items below is in the final format
 	// that they were sent, reasoning: reconciliation is easier if measurements
@@ -65,4 +80,32 @@ var (
 		ExporterPrefix+FailedToSendLogRecordsKey,
 		"Number of log records in failed attempts to send to destination.",
 		stats.UnitDimensionless)
+	ExporterSentSamples = stats.Int64(
+		ExporterPrefix+SentSamplesKey,
+		"Number of profile samples successfully sent to destination.",
+		stats.UnitDimensionless)
+	ExporterFailedToSendSamples = stats.Int64(
+		ExporterPrefix+FailedToSendSamplesKey,
+		"Number of profile samples in failed attempts to send to destination.",
+		stats.UnitDimensionless)
+	ExporterRejectedSpans = stats.Int64(
+		ExporterPrefix+RejectedSpansKey,
+		"Number of spans rejected by the destination in partial successes.",
+		stats.UnitDimensionless)
+	ExporterRejectedMetricPoints = stats.Int64(
+		ExporterPrefix+RejectedMetricPointsKey,
+		"Number of metric points rejected by the destination in partial successes.",
+		stats.UnitDimensionless)
+	ExporterRejectedLogRecords = stats.Int64(
+		ExporterPrefix+RejectedLogRecordsKey,
+		"Number of log records rejected by the destination in partial successes.",
+		stats.UnitDimensionless)
+	ExporterRejectedSamples = stats.Int64(
+		ExporterPrefix+RejectedSamplesKey,
+		"Number of profile samples rejected by the destination in partial successes.",
+		stats.UnitDimensionless)
+	ExporterLatency = stats.Float64(
+		ExporterPrefix+LatencyKey,
+		"Duration of the attempts to send the data to the destination.",
+		stats.UnitMilliseconds)
 )
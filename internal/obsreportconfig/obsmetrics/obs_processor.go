@@ -65,4 +65,8 @@ var (
 		ProcessorPrefix+DroppedLogRecordsKey,
 		"Number of log records that were dropped.",
 		stats.UnitDimensionless)
+	ProcessorLatency = stats.Float64(
+		ProcessorPrefix+LatencyKey,
+		"Duration of the processing of the data by the processor.",
+		stats.UnitMilliseconds)
 )
@@ -32,6 +32,12 @@ const (
 	// RefusedLogRecordsKey used to identify log records refused (ie.: not ingested) by the
 	// Collector.
 	RefusedLogRecordsKey = "refused_log_records"
+
+	// AcceptedSamplesKey used to identify profile samples accepted by the Collector.
+	AcceptedSamplesKey = "accepted_samples"
+	// RefusedSamplesKey used to identify profile samples refused (ie.: not ingested) by the
+	// Collector.
+	RefusedSamplesKey = "refused_samples"
 )
 
 var (
@@ -42,6 +48,7 @@ var (
 	ReceiveTraceDataOperationSuffix = NameSep + "TraceDataReceived"
 	ReceiverMetricsOperationSuffix  = NameSep + "MetricsReceived"
 	ReceiverLogsOperationSuffix     = NameSep + "LogsReceived"
+	ReceiverProfilesOperationSuffix = NameSep + "ProfilesReceived"
 
 	// Receiver metrics. Any count of data items below is in the original format
 	// that they were received, reasoning: reconciliation is easier if measurement
@@ -72,4 +79,12 @@ var (
 		ReceiverPrefix+RefusedLogRecordsKey,
 		"Number of log records that could not be pushed into the pipeline.",
 		stats.UnitDimensionless)
+	ReceiverAcceptedSamples = stats.Int64(
+		ReceiverPrefix+AcceptedSamplesKey,
+		"Number of profile samples successfully pushed into the pipeline.",
+		stats.UnitDimensionless)
+	ReceiverRefusedSamples = stats.Int64(
+		ReceiverPrefix+RefusedSamplesKey,
+		"Number of profile samples that could not be pushed into the pipeline.",
+		stats.UnitDimensionless)
 )
@@ -18,6 +18,11 @@ const (
 	// ErroredMetricPointsKey used to identify metric points errored (i.e.
 	// unable to be scraped) by the Collector.
 	ErroredMetricPointsKey = "errored_metric_points"
+	// MissedScrapesKey used to identify the scrapes skipped because the previous
+	// scrape was still running.
+	MissedScrapesKey = "missed_scrapes"
+	// LateScrapesKey used to identify the scrapes exceeding their duration budget.
+	LateScrapesKey = "late_scrapes"
 )
 
 const (
@@ -36,4 +41,12 @@ var (
 		ScraperPrefix+ErroredMetricPointsKey,
 		"Number of metric points that were unable to be scraped.",
 		stats.UnitDimensionless)
+	ScraperMissedScrapes = stats.Int64(
+		ScraperPrefix+MissedScrapesKey,
+		"Number of scrapes skipped because the previous scrape was still running.",
+		stats.UnitDimensionless)
+	ScraperLateScrapes = stats.Int64(
+		ScraperPrefix+LateScrapesKey,
+		"Number of scrapes exceeding their duration budget.",
+		stats.UnitDimensionless)
 )
@@ -6,6 +6,22 @@
 // in the future
 package obsmetrics // import "go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 
+import (
+	"go.opencensus.io/tag"
+)
+
 const (
 	NameSep = "/"
+
+	// LatencyKey used to identify the duration of the operations of the components.
+	LatencyKey = "latency"
+	// ErrorClassKey used to identify the class of the error returned by an operation.
+	ErrorClassKey = "error_class"
+)
+
+var (
+	TagKeyErrorClass, _ = tag.NewKey(ErrorClassKey)
+
+	// LatencyBounds are the bounds, in milliseconds, of the buckets of the latency histograms.
+	LatencyBounds = []float64{5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
 )
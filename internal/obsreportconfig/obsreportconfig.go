@@ -60,6 +60,12 @@ func AllViews(level configtelemetry.Level) []*view.View {
 		obsmetrics.ExporterFailedToSendMetricPoints,
 		obsmetrics.ExporterSentLogRecords,
 		obsmetrics.ExporterFailedToSendLogRecords,
+		obsmetrics.ExporterSentSamples,
+		obsmetrics.ExporterFailedToSendSamples,
+		obsmetrics.ExporterRejectedSpans,
+		obsmetrics.ExporterRejectedMetricPoints,
+		obsmetrics.ExporterRejectedLogRecords,
+		obsmetrics.ExporterRejectedSamples,
 	}
 	tagKeys = []tag.Key{obsmetrics.TagKeyExporter}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
@@ -87,9 +93,50 @@ func AllViews(level configtelemetry.Level) []*view.View {
 	tagKeys = []tag.Key{obsmetrics.TagKeyProcessor}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
 
+	// Latency views.
+	views = append(views, latencyViews()...)
+
 	return views
 }
 
+// AllViewsWithLatencyBounds returns the views of AllViews, the latency views aggregating the durations
+// in buckets of the given bounds instead of obsmetrics.LatencyBounds.
+func AllViewsWithLatencyBounds(level configtelemetry.Level, bounds []float64) []*view.View {
+	views := AllViews(level)
+	if len(bounds) == 0 {
+		return views
+	}
+	aggregation := view.Distribution(bounds...)
+	for i, v := range views {
+		if v.Aggregation == latencyAggregation {
+			withBounds := *v
+			withBounds.Aggregation = aggregation
+			views[i] = &withBounds
+		}
+	}
+	return views
+}
+
+// latencyAggregation is shared by the latency views: OpenCensus compares the aggregations of the views registered
+// again, and two distributions created separately are never equal.
+var latencyAggregation = view.Distribution(obsmetrics.LatencyBounds...)
+
+func latencyViews() []*view.View {
+	latencyView := func(measure *stats.Float64Measure, tagKey tag.Key) *view.View {
+		return &view.View{
+			Name:        measure.Name(),
+			Description: measure.Description(),
+			TagKeys:     []tag.Key{tagKey, obsmetrics.TagKeyErrorClass},
+			Measure:     measure,
+			Aggregation: latencyAggregation,
+		}
+	}
+	return []*view.View{
+		latencyView(obsmetrics.ProcessorLatency, obsmetrics.TagKeyProcessor),
+		latencyView(obsmetrics.ExporterLatency, obsmetrics.TagKeyExporter),
+	}
+}
+
 func receiverViews() []*view.View {
 	if UseOtelForInternalMetricsfeatureGate.IsEnabled() {
 		return nil
@@ -102,6 +149,8 @@ func receiverViews() []*view.View {
 		obsmetrics.ReceiverRefusedMetricPoints,
 		obsmetrics.ReceiverAcceptedLogRecords,
 		obsmetrics.ReceiverRefusedLogRecords,
+		obsmetrics.ReceiverAcceptedSamples,
+		obsmetrics.ReceiverRefusedSamples,
 	}
 	tagKeys := []tag.Key{
 		obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport,
@@ -118,6 +167,8 @@ func scraperViews() []*view.View {
 	measures := []*stats.Int64Measure{
 		obsmetrics.ScraperScrapedMetricPoints,
 		obsmetrics.ScraperErroredMetricPoints,
+		obsmetrics.ScraperMissedScrapes,
+		obsmetrics.ScraperLateScrapes,
 	}
 	tagKeys := []tag.Key{obsmetrics.TagKeyReceiver, obsmetrics.TagKeyScraper}
 
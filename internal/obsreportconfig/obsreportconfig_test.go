@@ -24,17 +24,17 @@ func TestConfigure(t *testing.T) {
 		{
 			name:         "basic",
 			level:        configtelemetry.LevelBasic,
-			wantViewsLen: 24,
+			wantViewsLen: 36,
 		},
 		{
 			name:         "normal",
 			level:        configtelemetry.LevelNormal,
-			wantViewsLen: 24,
+			wantViewsLen: 36,
 		},
 		{
 			name:         "detailed",
 			level:        configtelemetry.LevelDetailed,
-			wantViewsLen: 24,
+			wantViewsLen: 36,
 		},
 	}
 	for _, tt := range tests {
@@ -43,3 +43,28 @@ func TestConfigure(t *testing.T) {
 		})
 	}
 }
+
+func TestAllViewsWithLatencyBounds(t *testing.T) {
+	assert.Equal(t, AllViews(configtelemetry.LevelBasic), AllViewsWithLatencyBounds(configtelemetry.LevelBasic, nil))
+
+	bounds := []float64{1, 10, 100}
+	views := AllViewsWithLatencyBounds(configtelemetry.LevelBasic, bounds)
+	assert.Len(t, views, 36)
+	latencyViews := 0
+	for _, v := range views {
+		if v.Aggregation == latencyAggregation {
+			t.Errorf("view %q uses the default latency buckets", v.Name)
+		}
+		if v.Name == "processor/latency" || v.Name == "exporter/latency" {
+			latencyViews++
+			assert.Equal(t, bounds, v.Aggregation.Buckets)
+		}
+	}
+	assert.Equal(t, 2, latencyViews)
+	// The views shared with AllViews are left untouched.
+	for _, v := range AllViews(configtelemetry.LevelBasic) {
+		if v.Name == "processor/latency" {
+			assert.Equal(t, latencyAggregation, v.Aggregation)
+		}
+	}
+}
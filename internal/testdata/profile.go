@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testdata
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+)
+
+var (
+	profileStartTimestamp = pcommon.NewTimestampFromTime(time.Date(2020, 2, 11, 20, 26, 12, 321, time.UTC))
+	profileEndTimestamp   = pcommon.NewTimestampFromTime(time.Date(2020, 2, 11, 20, 26, 13, 789, time.UTC))
+)
+
+// GenerateProfiles returns a single CPU profile with count samples.
+func GenerateProfiles(count int) pprofile.Profiles {
+	pd := pprofile.NewProfiles()
+	initResource(pd.ResourceProfiles().AppendEmpty().Resource())
+	pc := pd.ResourceProfiles().At(0).ScopeProfiles().AppendEmpty().Profiles().AppendEmpty()
+	pc.SetProfileID([16]byte{0x01, 0x02, 0x03, 0x04})
+	pc.SetStartTimestamp(profileStartTimestamp)
+	pc.SetEndTimestamp(profileEndTimestamp)
+	fillProfile(pc.Profile())
+	samples := pc.Profile().Sample()
+	samples.EnsureCapacity(count)
+	for i := 0; i < count; i++ {
+		sample := samples.AppendEmpty()
+		sample.LocationIndex().Append(uint64(i % 2))
+		sample.Value().Append(int64(i+1) * 10000000)
+	}
+	return pd
+}
+
+func fillProfile(profile pprofile.Profile) {
+	profile.StringTable().Append("", "cpu", "nanoseconds", "main", "main.go", "handle")
+	profile.SetTime(profileStartTimestamp)
+	profile.SetDurationNanos(int64(profileEndTimestamp - profileStartTimestamp))
+	profile.PeriodType().SetType(1)
+	profile.PeriodType().SetUnit(2)
+	profile.SetPeriod(10000000)
+
+	sampleType := profile.SampleType().AppendEmpty()
+	sampleType.SetType(1)
+	sampleType.SetUnit(2)
+	sampleType.SetAggregationTemporality(pprofile.AggregationTemporalityDelta)
+
+	for i, name := range []int64{3, 5} {
+		function := profile.Function().AppendEmpty()
+		function.SetID(uint64(i + 1))
+		function.SetName(name)
+		function.SetFilename(4)
+
+		location := profile.Location().AppendEmpty()
+		location.SetID(uint64(i + 1))
+		line := location.Line().AppendEmpty()
+		line.SetFunctionIndex(uint64(i))
+		line.SetLine(int64(10 * (i + 1)))
+	}
+}
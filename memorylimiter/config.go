@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorylimiter // import "go.opentelemetry.io/collector/memorylimiter"
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	errCheckIntervalOutOfRange = errors.New("check_interval must be greater than zero")
+
+	errLimitOutOfRange = errors.New("limit_mib or limit_percentage must be greater than zero")
+
+	errMemSpikeLimitOutOfRange = errors.New("spike_limit_mib must be smaller than limit_mib")
+
+	errPercentageLimitOutOfRange = errors.New(
+		"limit_percentage and spike_limit_percentage must be greater than zero and less than or equal to hundred")
+)
+
+// Config defines configuration for the memory limiter, shared by the service-wide memory limiter
+// and the memory_limiter processor.
+type Config struct {
+	// CheckInterval is the time between measurements of memory usage for the
+	// purposes of avoiding going over the limits.
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+
+	// MemoryLimitMiB is the maximum amount of memory, in MiB, targeted to be
+	// allocated by the process.
+	MemoryLimitMiB uint32 `mapstructure:"limit_mib"`
+
+	// MemorySpikeLimitMiB is the maximum, in MiB, spike expected between the
+	// measurements of memory usage.
+	MemorySpikeLimitMiB uint32 `mapstructure:"spike_limit_mib"`
+
+	// MemoryLimitPercentage is the maximum amount of memory, in %, targeted to be
+	// allocated by the process. The fixed memory settings MemoryLimitMiB has a higher precedence.
+	MemoryLimitPercentage uint32 `mapstructure:"limit_percentage"`
+
+	// MemorySpikePercentage is the maximum, in percents against the total memory,
+	// spike expected between the measurements of memory usage.
+	MemorySpikePercentage uint32 `mapstructure:"spike_limit_percentage"`
+}
+
+// Validate checks if the memory limiter configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.CheckInterval <= 0 {
+		return errCheckIntervalOutOfRange
+	}
+	if cfg.MemoryLimitMiB == 0 && cfg.MemoryLimitPercentage == 0 {
+		return errLimitOutOfRange
+	}
+	if cfg.MemoryLimitMiB != 0 {
+		if cfg.MemorySpikeLimitMiB >= cfg.MemoryLimitMiB {
+			return errMemSpikeLimitOutOfRange
+		}
+		return nil
+	}
+	if cfg.MemoryLimitPercentage > 100 || cfg.MemorySpikePercentage > 100 || cfg.MemorySpikePercentage == 0 {
+		return errPercentageLimitOutOfRange
+	}
+	return nil
+}
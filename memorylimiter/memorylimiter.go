@@ -0,0 +1,263 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package memorylimiter provides the service-wide memory limiter, which checks the memory usage
+// of the process against the configured limits and signals the receivers to refuse data,
+// before it is read, while the usage is above the soft limit.
+package memorylimiter // import "go.opentelemetry.io/collector/memorylimiter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/iruntime"
+)
+
+const (
+	mibBytes = 1024 * 1024
+
+	// Minimum interval between forced GC when in soft limited mode. We don't want to
+	// do GCs too frequently since it is a CPU-heavy operation.
+	minGCIntervalWhenSoftLimited = 10 * time.Second
+)
+
+// ErrDataRefused is returned by the receivers to indicate that data is being refused due to
+// high memory usage. It is not permanent, the clients are expected to retry later.
+var ErrDataRefused = errors.New("data refused due to high memory usage")
+
+// make it overridable by tests
+var getMemoryFn = iruntime.TotalMemory
+
+// Host is implemented by the component.Host of the service, to give the receivers access to the
+// service-wide memory limiter.
+type Host interface {
+	component.Host
+
+	// GetMemoryLimiter returns the memory limiter of the service, nil if it is not configured.
+	GetMemoryLimiter() *MemoryLimiter
+}
+
+// FromHost returns the memory limiter of the service hosting the component, nil if the host does
+// not provide one. The methods of a nil *MemoryLimiter never refuse data.
+func FromHost(host component.Host) *MemoryLimiter {
+	if h, ok := host.(Host); ok {
+		return h.GetMemoryLimiter()
+	}
+	return nil
+}
+
+// MemoryLimiter checks the memory usage of the process at a regular interval, forcing a GC above
+// the hard limit, and signals that data must be refused while the usage is above the soft limit.
+type MemoryLimiter struct {
+	usageChecker memUsageChecker
+
+	checkInterval time.Duration
+	ballastSize   uint64
+
+	// mustRefuse is used to indicate when data should be refused.
+	mustRefuse atomic.Bool
+
+	ticker *time.Ticker
+	doneC  chan struct{}
+
+	lastGCDone time.Time
+
+	// The function to read the mem values is set as a reference to help with
+	// testing different values.
+	readMemStatsFn func(m *runtime.MemStats)
+
+	// Fields used for logging.
+	logger                 *zap.Logger
+	configMismatchedLogged bool
+}
+
+// New returns a new MemoryLimiter, which starts checking the memory usage once started.
+func New(cfg *Config, logger *zap.Logger) (*MemoryLimiter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	usageChecker, err := getMemUsageChecker(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Memory limiter configured",
+		zap.Uint64("limit_mib", usageChecker.memAllocLimit/mibBytes),
+		zap.Uint64("spike_limit_mib", usageChecker.memSpikeLimit/mibBytes),
+		zap.Duration("check_interval", cfg.CheckInterval))
+
+	return &MemoryLimiter{
+		usageChecker:   *usageChecker,
+		checkInterval:  cfg.CheckInterval,
+		readMemStatsFn: runtime.ReadMemStats,
+		logger:         logger,
+	}, nil
+}
+
+func getMemUsageChecker(cfg *Config, logger *zap.Logger) (*memUsageChecker, error) {
+	memAllocLimit := uint64(cfg.MemoryLimitMiB) * mibBytes
+	memSpikeLimit := uint64(cfg.MemorySpikeLimitMiB) * mibBytes
+	if cfg.MemoryLimitMiB != 0 {
+		return newFixedMemUsageChecker(memAllocLimit, memSpikeLimit)
+	}
+	totalMemory, err := getMemoryFn()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total memory, use fixed memory settings (limit_mib): %w", err)
+	}
+	logger.Info("Using percentage memory limiter",
+		zap.Uint64("total_memory_mib", totalMemory/mibBytes),
+		zap.Uint32("limit_percentage", cfg.MemoryLimitPercentage),
+		zap.Uint32("spike_limit_percentage", cfg.MemorySpikePercentage))
+	return newPercentageMemUsageChecker(totalMemory, uint64(cfg.MemoryLimitPercentage), uint64(cfg.MemorySpikePercentage))
+}
+
+// Start takes the size of the memory ballast into account, if any, and starts checking the memory usage.
+// It must be called after the extensions are started.
+func (ml *MemoryLimiter) Start(_ context.Context, host component.Host) error {
+	for _, extension := range host.GetExtensions() {
+		if ext, ok := extension.(interface{ GetBallastSize() uint64 }); ok {
+			ml.ballastSize = ext.GetBallastSize()
+			break
+		}
+	}
+	ticker := time.NewTicker(ml.checkInterval)
+	doneC := make(chan struct{})
+	ml.ticker, ml.doneC = ticker, doneC
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				ml.checkMemLimits()
+			case <-doneC:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops checking the memory usage.
+func (ml *MemoryLimiter) Shutdown(context.Context) error {
+	if ml.ticker != nil {
+		ml.ticker.Stop()
+		close(ml.doneC)
+		ml.ticker = nil
+	}
+	return nil
+}
+
+// MustRefuse returns whether data must be refused because the memory usage is above the soft limit.
+// The receivers are expected to check it before reading the data and to return ErrDataRefused,
+// or the equivalent retryable status of their protocol.
+func (ml *MemoryLimiter) MustRefuse() bool {
+	return ml != nil && ml.mustRefuse.Load()
+}
+
+func (ml *MemoryLimiter) readMemStats() *runtime.MemStats {
+	ms := &runtime.MemStats{}
+	ml.readMemStatsFn(ms)
+	// If proper configured ms.Alloc should be at least ml.ballastSize but since
+	// a misconfiguration is possible check for that here.
+	if ms.Alloc >= ml.ballastSize {
+		ms.Alloc -= ml.ballastSize
+	} else if !ml.configMismatchedLogged {
+		// This indicates misconfiguration. Log it once.
+		ml.configMismatchedLogged = true
+		ml.logger.Warn(`"size_mib" in ballast extension is likely incorrectly configured.`)
+	}
+
+	return ms
+}
+
+func memstatToZapField(ms *runtime.MemStats) zap.Field {
+	return zap.Uint64("cur_mem_mib", ms.Alloc/mibBytes)
+}
+
+func (ml *MemoryLimiter) doGCandReadMemStats() *runtime.MemStats {
+	runtime.GC()
+	ml.lastGCDone = time.Now()
+	ms := ml.readMemStats()
+	ml.logger.Info("Memory usage after GC.", memstatToZapField(ms))
+	return ms
+}
+
+func (ml *MemoryLimiter) checkMemLimits() {
+	ms := ml.readMemStats()
+
+	ml.logger.Debug("Currently used memory.", memstatToZapField(ms))
+
+	if ml.usageChecker.aboveHardLimit(ms) {
+		ml.logger.Warn("Memory usage is above hard limit. Forcing a GC.", memstatToZapField(ms))
+		ms = ml.doGCandReadMemStats()
+	}
+
+	// Remember current state.
+	wasRefusing := ml.mustRefuse.Load()
+
+	// Check if the memory usage is above the soft limit.
+	mustRefuse := ml.usageChecker.aboveSoftLimit(ms)
+
+	if wasRefusing && !mustRefuse {
+		// Was previously refusing but enough memory is available now, no need to limit.
+		ml.logger.Info("Memory usage back within limits. Resuming normal operation.", memstatToZapField(ms))
+	}
+
+	if !wasRefusing && mustRefuse {
+		// We are above soft limit, do a GC if it wasn't done recently and see if
+		// it brings memory usage below the soft limit.
+		if time.Since(ml.lastGCDone) > minGCIntervalWhenSoftLimited {
+			ml.logger.Info("Memory usage is above soft limit. Forcing a GC.", memstatToZapField(ms))
+			ms = ml.doGCandReadMemStats()
+			// Check the limit again to see if GC helped.
+			mustRefuse = ml.usageChecker.aboveSoftLimit(ms)
+		}
+
+		if mustRefuse {
+			ml.logger.Warn("Memory usage is above soft limit. Refusing data.", memstatToZapField(ms))
+		}
+	}
+
+	ml.mustRefuse.Store(mustRefuse)
+}
+
+type memUsageChecker struct {
+	memAllocLimit uint64
+	memSpikeLimit uint64
+}
+
+func (d memUsageChecker) aboveSoftLimit(ms *runtime.MemStats) bool {
+	return ms.Alloc >= d.memAllocLimit-d.memSpikeLimit
+}
+
+func (d memUsageChecker) aboveHardLimit(ms *runtime.MemStats) bool {
+	return ms.Alloc >= d.memAllocLimit
+}
+
+func newFixedMemUsageChecker(memAllocLimit, memSpikeLimit uint64) (*memUsageChecker, error) {
+	if memSpikeLimit >= memAllocLimit {
+		return nil, errMemSpikeLimitOutOfRange
+	}
+	if memSpikeLimit == 0 {
+		// If spike limit is unspecified use 20% of mem limit.
+		memSpikeLimit = memAllocLimit / 5
+	}
+	return &memUsageChecker{
+		memAllocLimit: memAllocLimit,
+		memSpikeLimit: memSpikeLimit,
+	}, nil
+}
+
+func newPercentageMemUsageChecker(totalMemory uint64, percentageLimit, percentageSpike uint64) (*memUsageChecker, error) {
+	if percentageLimit > 100 || percentageLimit <= 0 || percentageSpike > 100 || percentageSpike <= 0 {
+		return nil, errPercentageLimitOutOfRange
+	}
+	return newFixedMemUsageChecker(percentageLimit*totalMemory/100, percentageSpike*totalMemory/100)
+}
@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorylimiter
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/iruntime"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		err  error
+	}{
+		{
+			name: "fixed",
+			cfg:  Config{CheckInterval: time.Second, MemoryLimitMiB: 100, MemorySpikeLimitMiB: 20},
+		},
+		{
+			name: "percentage",
+			cfg:  Config{CheckInterval: time.Second, MemoryLimitPercentage: 80, MemorySpikePercentage: 20},
+		},
+		{
+			name: "check_interval",
+			cfg:  Config{MemoryLimitMiB: 100},
+			err:  errCheckIntervalOutOfRange,
+		},
+		{
+			name: "no_limit",
+			cfg:  Config{CheckInterval: time.Second},
+			err:  errLimitOutOfRange,
+		},
+		{
+			name: "spike_limit",
+			cfg:  Config{CheckInterval: time.Second, MemoryLimitMiB: 100, MemorySpikeLimitMiB: 100},
+			err:  errMemSpikeLimitOutOfRange,
+		},
+		{
+			name: "percentage_limit",
+			cfg:  Config{CheckInterval: time.Second, MemoryLimitPercentage: 101, MemorySpikePercentage: 20},
+			err:  errPercentageLimitOutOfRange,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.err, tt.cfg.Validate())
+		})
+	}
+}
+
+func TestGetDecision(t *testing.T) {
+	t.Cleanup(func() {
+		getMemoryFn = iruntime.TotalMemory
+	})
+	getMemoryFn = func() (uint64, error) {
+		return 100 * mibBytes, nil
+	}
+	d, err := getMemUsageChecker(&Config{MemoryLimitPercentage: 50, MemorySpikePercentage: 10}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, &memUsageChecker{
+		memAllocLimit: 50 * mibBytes,
+		memSpikeLimit: 10 * mibBytes,
+	}, d)
+
+	d, err = getMemUsageChecker(&Config{MemoryLimitMiB: 100}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, &memUsageChecker{
+		memAllocLimit: 100 * mibBytes,
+		memSpikeLimit: 20 * mibBytes,
+	}, d)
+}
+
+func TestRefuseDecision(t *testing.T) {
+	decison1000Limit30Spike30, err := newPercentageMemUsageChecker(1000, 60, 30)
+	require.NoError(t, err)
+	decison1000Limit60Spike50, err := newPercentageMemUsageChecker(1000, 60, 50)
+	require.NoError(t, err)
+	decison1000Limit40Spike20, err := newPercentageMemUsageChecker(1000, 40, 20)
+	require.NoError(t, err)
+	decison1000Limit40Spike60, err := newPercentageMemUsageChecker(1000, 40, 60)
+	require.Error(t, err)
+	assert.Nil(t, decison1000Limit40Spike60)
+
+	tests := []struct {
+		name         string
+		usageChecker memUsageChecker
+		ms           *runtime.MemStats
+		shouldRefuse bool
+	}{
+		{
+			name:         "should refuse over limit",
+			usageChecker: *decison1000Limit30Spike30,
+			ms:           &runtime.MemStats{Alloc: 600},
+			shouldRefuse: true,
+		},
+		{
+			name:         "should not refuse",
+			usageChecker: *decison1000Limit30Spike30,
+			ms:           &runtime.MemStats{Alloc: 100},
+			shouldRefuse: false,
+		},
+		{
+			name: "should not refuse spike, fixed usageChecker",
+			usageChecker: memUsageChecker{
+				memAllocLimit: 600,
+				memSpikeLimit: 500,
+			},
+			ms:           &runtime.MemStats{Alloc: 300},
+			shouldRefuse: true,
+		},
+		{
+			name:         "should refuse, spike, percentage usageChecker",
+			usageChecker: *decison1000Limit60Spike50,
+			ms:           &runtime.MemStats{Alloc: 300},
+			shouldRefuse: true,
+		},
+		{
+			name:         "should refuse, spike, percentage usageChecker",
+			usageChecker: *decison1000Limit40Spike20,
+			ms:           &runtime.MemStats{Alloc: 250},
+			shouldRefuse: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			shouldRefuse := test.usageChecker.aboveSoftLimit(test.ms)
+			assert.Equal(t, test.shouldRefuse, shouldRefuse)
+		})
+	}
+}
+
+func TestMemoryPressureResponse(t *testing.T) {
+	var currentMemAlloc uint64
+	ml := &MemoryLimiter{
+		usageChecker: memUsageChecker{
+			memAllocLimit: 1024,
+		},
+		readMemStatsFn: func(ms *runtime.MemStats) {
+			ms.Alloc = currentMemAlloc
+		},
+		logger: zap.NewNop(),
+	}
+
+	// Below memAllocLimit.
+	currentMemAlloc = 800
+	ml.checkMemLimits()
+	assert.False(t, ml.MustRefuse())
+
+	// Above memAllocLimit.
+	currentMemAlloc = 1800
+	ml.checkMemLimits()
+	assert.True(t, ml.MustRefuse())
+
+	// Check ballast effect
+	ml.ballastSize = 1000
+
+	// Below memAllocLimit accounting for ballast.
+	currentMemAlloc = 800 + ml.ballastSize
+	ml.checkMemLimits()
+	assert.False(t, ml.MustRefuse())
+
+	// Above soft limit.
+	ml.usageChecker.memSpikeLimit = 512
+	currentMemAlloc = 700 + ml.ballastSize
+	ml.checkMemLimits()
+	assert.True(t, ml.MustRefuse())
+}
+
+func TestNilMemoryLimiter(t *testing.T) {
+	var ml *MemoryLimiter
+	assert.False(t, ml.MustRefuse())
+	assert.Nil(t, FromHost(componenttest.NewNopHost()))
+}
+
+type ballastHost struct {
+	component.Host
+	ml *MemoryLimiter
+}
+
+func (h ballastHost) GetExtensions() map[component.ID]component.Component {
+	return map[component.ID]component.Component{
+		component.NewID("ballast"): ballastExtension{},
+	}
+}
+
+func (h ballastHost) GetMemoryLimiter() *MemoryLimiter {
+	return h.ml
+}
+
+type ballastExtension struct {
+	component.Component
+}
+
+func (ballastExtension) GetBallastSize() uint64 {
+	return 113
+}
+
+func TestStartShutdown(t *testing.T) {
+	ml, err := New(&Config{CheckInterval: time.Millisecond, MemoryLimitMiB: 1 << 20}, zap.NewNop())
+	require.NoError(t, err)
+	host := ballastHost{Host: componenttest.NewNopHost(), ml: ml}
+	assert.Same(t, ml, FromHost(host))
+
+	require.NoError(t, ml.Start(context.Background(), host))
+	assert.Equal(t, uint64(113), ml.ballastSize)
+	// The memory usage of the tests is far below the limit.
+	time.Sleep(10 * time.Millisecond)
+	assert.False(t, ml.MustRefuse())
+	require.NoError(t, ml.Shutdown(context.Background()))
+	require.NoError(t, ml.Shutdown(context.Background()))
+}
+
+func TestNewError(t *testing.T) {
+	_, err := New(&Config{CheckInterval: time.Second}, zap.NewNop())
+	assert.ErrorIs(t, err, errLimitOutOfRange)
+}
@@ -4,8 +4,13 @@
 package obsreport // import "go.opentelemetry.io/collector/obsreport"
 
 import (
+	"context"
+	"errors"
+
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
 )
 
 const (
@@ -14,8 +19,78 @@ const (
 	nameSep = "/"
 )
 
+// The classes of the errors returned by the operations of the components, the
+// values of the error_class label of the latency metrics.
+const (
+	errorClassNone      = "none"
+	errorClassPermanent = "permanent"
+	errorClassTimeout   = "timeout"
+	errorClassCanceled  = "canceled"
+	errorClassTransient = "transient"
+)
+
+type spanLinksKey struct{}
+
+// ContextWithSpanLinks returns a copy of ctx carrying links to the given spans. The next span started from
+// the returned context by a Receiver or an Exporter operation links to them. It connects the self-traces of
+// the requests handled after leaving their own context, e.g. merged into a batch, to the traces of the
+// incoming requests they came with. The invalid span contexts are ignored.
+func ContextWithSpanLinks(ctx context.Context, spanContexts ...trace.SpanContext) context.Context {
+	existing := spanLinks(ctx)
+	links := make([]trace.Link, len(existing), len(existing)+len(spanContexts))
+	copy(links, existing)
+	for _, sc := range spanContexts {
+		if sc.IsValid() {
+			links = append(links, trace.Link{SpanContext: sc})
+		}
+	}
+	if len(links) == len(existing) {
+		return ctx
+	}
+	return context.WithValue(ctx, spanLinksKey{}, links)
+}
+
+// spanLinks returns the links carried by ctx.
+func spanLinks(ctx context.Context) []trace.Link {
+	links, _ := ctx.Value(spanLinksKey{}).([]trace.Link)
+	return links
+}
+
+// withoutSpanLinks returns a copy of ctx without links, so they are only used by a single span.
+func withoutSpanLinks(ctx context.Context) context.Context {
+	if len(spanLinks(ctx)) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, spanLinksKey{}, []trace.Link(nil))
+}
+
+// startSpan starts a span with the links carried by ctx.
+func startSpan(ctx context.Context, tracer trace.Tracer, spanName string) (context.Context, trace.Span) {
+	links := spanLinks(ctx)
+	if len(links) == 0 {
+		return tracer.Start(ctx, spanName)
+	}
+	return tracer.Start(withoutSpanLinks(ctx), spanName, trace.WithLinks(links...))
+}
+
 func recordError(span trace.Span, err error) {
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 	}
 }
+
+// errorClass returns the class of the error returned by an operation.
+func errorClass(err error) string {
+	switch {
+	case err == nil:
+		return errorClassNone
+	case consumererror.IsPermanent(err):
+		return errorClassPermanent
+	case errors.Is(err, context.DeadlineExceeded):
+		return errorClassTimeout
+	case errors.Is(err, context.Canceled):
+		return errorClassCanceled
+	default:
+		return errorClassTransient
+	}
+}
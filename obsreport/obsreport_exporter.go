@@ -5,6 +5,8 @@ package obsreport // import "go.opentelemetry.io/collector/obsreport"
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
@@ -16,6 +18,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
@@ -43,6 +46,13 @@ type Exporter struct {
 	failedToSendMetricPoints metric.Int64Counter
 	sentLogRecords           metric.Int64Counter
 	failedToSendLogRecords   metric.Int64Counter
+	rejectedSpans            metric.Int64Counter
+	rejectedMetricPoints     metric.Int64Counter
+	rejectedLogRecords       metric.Int64Counter
+	sentSamples              metric.Int64Counter
+	failedToSendSamples      metric.Int64Counter
+	rejectedSamples          metric.Int64Counter
+	latency                  metric.Float64Histogram
 }
 
 // ExporterSettings are settings for creating an Exporter.
@@ -121,6 +131,48 @@ func (exp *Exporter) createOtelMetrics(cfg ExporterSettings) error {
 		metric.WithUnit("1"))
 	errors = multierr.Append(errors, err)
 
+	exp.rejectedSpans, err = meter.Int64Counter(
+		obsmetrics.ExporterPrefix+obsmetrics.RejectedSpansKey,
+		metric.WithDescription("Number of spans rejected by the destination in partial successes."),
+		metric.WithUnit("1"))
+	errors = multierr.Append(errors, err)
+
+	exp.rejectedMetricPoints, err = meter.Int64Counter(
+		obsmetrics.ExporterPrefix+obsmetrics.RejectedMetricPointsKey,
+		metric.WithDescription("Number of metric points rejected by the destination in partial successes."),
+		metric.WithUnit("1"))
+	errors = multierr.Append(errors, err)
+
+	exp.rejectedLogRecords, err = meter.Int64Counter(
+		obsmetrics.ExporterPrefix+obsmetrics.RejectedLogRecordsKey,
+		metric.WithDescription("Number of log records rejected by the destination in partial successes."),
+		metric.WithUnit("1"))
+	errors = multierr.Append(errors, err)
+
+	exp.sentSamples, err = meter.Int64Counter(
+		obsmetrics.ExporterPrefix+obsmetrics.SentSamplesKey,
+		metric.WithDescription("Number of profile samples successfully sent to destination."),
+		metric.WithUnit("1"))
+	errors = multierr.Append(errors, err)
+
+	exp.failedToSendSamples, err = meter.Int64Counter(
+		obsmetrics.ExporterPrefix+obsmetrics.FailedToSendSamplesKey,
+		metric.WithDescription("Number of profile samples in failed attempts to send to destination."),
+		metric.WithUnit("1"))
+	errors = multierr.Append(errors, err)
+
+	exp.rejectedSamples, err = meter.Int64Counter(
+		obsmetrics.ExporterPrefix+obsmetrics.RejectedSamplesKey,
+		metric.WithDescription("Number of profile samples rejected by the destination in partial successes."),
+		metric.WithUnit("1"))
+	errors = multierr.Append(errors, err)
+
+	exp.latency, err = meter.Float64Histogram(
+		obsmetrics.ExporterPrefix+obsmetrics.LatencyKey,
+		metric.WithDescription("Duration of the attempts to send the data to the destination."),
+		metric.WithUnit("ms"))
+	errors = multierr.Append(errors, err)
+
 	return errors
 }
 
@@ -133,8 +185,8 @@ func (exp *Exporter) StartTracesOp(ctx context.Context) context.Context {
 
 // EndTracesOp completes the export operation that was started with StartTracesOp.
 func (exp *Exporter) EndTracesOp(ctx context.Context, numSpans int, err error) {
-	numSent, numFailedToSend := toNumItems(numSpans, err)
-	exp.recordMetrics(ctx, component.DataTypeTraces, numSent, numFailedToSend)
+	numSent, numFailedToSend, numRejected := toNumItems(numSpans, err)
+	exp.recordMetrics(ctx, component.DataTypeTraces, numSent, numFailedToSend, numRejected)
 	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentSpansKey, obsmetrics.FailedToSendSpansKey)
 }
 
@@ -148,8 +200,8 @@ func (exp *Exporter) StartMetricsOp(ctx context.Context) context.Context {
 // EndMetricsOp completes the export operation that was started with
 // StartMetricsOp.
 func (exp *Exporter) EndMetricsOp(ctx context.Context, numMetricPoints int, err error) {
-	numSent, numFailedToSend := toNumItems(numMetricPoints, err)
-	exp.recordMetrics(ctx, component.DataTypeMetrics, numSent, numFailedToSend)
+	numSent, numFailedToSend, numRejected := toNumItems(numMetricPoints, err)
+	exp.recordMetrics(ctx, component.DataTypeMetrics, numSent, numFailedToSend, numRejected)
 	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentMetricPointsKey, obsmetrics.FailedToSendMetricPointsKey)
 }
 
@@ -162,74 +214,121 @@ func (exp *Exporter) StartLogsOp(ctx context.Context) context.Context {
 
 // EndLogsOp completes the export operation that was started with StartLogsOp.
 func (exp *Exporter) EndLogsOp(ctx context.Context, numLogRecords int, err error) {
-	numSent, numFailedToSend := toNumItems(numLogRecords, err)
-	exp.recordMetrics(ctx, component.DataTypeLogs, numSent, numFailedToSend)
+	numSent, numFailedToSend, numRejected := toNumItems(numLogRecords, err)
+	exp.recordMetrics(ctx, component.DataTypeLogs, numSent, numFailedToSend, numRejected)
 	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentLogRecordsKey, obsmetrics.FailedToSendLogRecordsKey)
 }
 
+// StartProfilesOp is called at the start of an Export operation.
+// The returned context should be used in other calls to the Exporter functions
+// dealing with the same export operation.
+func (exp *Exporter) StartProfilesOp(ctx context.Context) context.Context {
+	return exp.startOp(ctx, obsmetrics.ExportProfilesOperationSuffix)
+}
+
+// EndProfilesOp completes the export operation that was started with StartProfilesOp.
+func (exp *Exporter) EndProfilesOp(ctx context.Context, numSamples int, err error) {
+	numSent, numFailedToSend, numRejected := toNumItems(numSamples, err)
+	exp.recordMetrics(ctx, component.DataTypeProfiles, numSent, numFailedToSend, numRejected)
+	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentSamplesKey, obsmetrics.FailedToSendSamplesKey)
+}
+
+// RecordLatency records the duration of an attempt to send the data to the
+// destination, labeled with the class of the error it returned.
+func (exp *Exporter) RecordLatency(ctx context.Context, duration time.Duration, err error) {
+	if exp.level == configtelemetry.LevelNone {
+		return
+	}
+	latency := float64(duration) / float64(time.Millisecond)
+	class := errorClass(err)
+	if exp.useOtelForMetrics {
+		attrs := append([]attribute.KeyValue{attribute.String(obsmetrics.ErrorClassKey, class)}, exp.otelAttrs...)
+		exp.latency.Record(ctx, latency, metric.WithAttributes(attrs...))
+		return
+	}
+	// ignore the error for now; should not happen
+	_ = stats.RecordWithTags(
+		ctx,
+		append([]tag.Mutator{tag.Upsert(obsmetrics.TagKeyErrorClass, class, tag.WithTTL(tag.TTLNoPropagation))}, exp.mutators...),
+		obsmetrics.ExporterLatency.M(latency))
+}
+
 // startOp creates the span used to trace the operation. Returning
 // the updated context and the created span.
 func (exp *Exporter) startOp(ctx context.Context, operationSuffix string) context.Context {
 	spanName := exp.spanNamePrefix + operationSuffix
-	ctx, _ = exp.tracer.Start(ctx, spanName)
+	ctx, _ = startSpan(ctx, exp.tracer, spanName)
 	return ctx
 }
 
-func (exp *Exporter) recordMetrics(ctx context.Context, dataType component.DataType, numSent, numFailed int64) {
+func (exp *Exporter) recordMetrics(ctx context.Context, dataType component.DataType, numSent, numFailed, numRejected int64) {
 	if exp.level == configtelemetry.LevelNone {
 		return
 	}
 	if exp.useOtelForMetrics {
-		exp.recordWithOtel(ctx, dataType, numSent, numFailed)
+		exp.recordWithOtel(ctx, dataType, numSent, numFailed, numRejected)
 	} else {
-		exp.recordWithOC(ctx, dataType, numSent, numFailed)
+		exp.recordWithOC(ctx, dataType, numSent, numFailed, numRejected)
 	}
 }
 
-func (exp *Exporter) recordWithOtel(ctx context.Context, dataType component.DataType, sent int64, failed int64) {
-	var sentMeasure, failedMeasure metric.Int64Counter
+func (exp *Exporter) recordWithOtel(ctx context.Context, dataType component.DataType, sent int64, failed int64, rejected int64) {
+	var sentMeasure, failedMeasure, rejectedMeasure metric.Int64Counter
 	switch dataType {
 	case component.DataTypeTraces:
 		sentMeasure = exp.sentSpans
 		failedMeasure = exp.failedToSendSpans
+		rejectedMeasure = exp.rejectedSpans
 	case component.DataTypeMetrics:
 		sentMeasure = exp.sentMetricPoints
 		failedMeasure = exp.failedToSendMetricPoints
+		rejectedMeasure = exp.rejectedMetricPoints
 	case component.DataTypeLogs:
 		sentMeasure = exp.sentLogRecords
 		failedMeasure = exp.failedToSendLogRecords
+		rejectedMeasure = exp.rejectedLogRecords
+	case component.DataTypeProfiles:
+		sentMeasure = exp.sentSamples
+		failedMeasure = exp.failedToSendSamples
+		rejectedMeasure = exp.rejectedSamples
 	}
 
 	sentMeasure.Add(ctx, sent, metric.WithAttributes(exp.otelAttrs...))
 	failedMeasure.Add(ctx, failed, metric.WithAttributes(exp.otelAttrs...))
+	if rejected > 0 {
+		rejectedMeasure.Add(ctx, rejected, metric.WithAttributes(exp.otelAttrs...))
+	}
 }
 
-func (exp *Exporter) recordWithOC(ctx context.Context, dataType component.DataType, sent int64, failed int64) {
-	var sentMeasure, failedMeasure *stats.Int64Measure
+func (exp *Exporter) recordWithOC(ctx context.Context, dataType component.DataType, sent int64, failed int64, rejected int64) {
+	var sentMeasure, failedMeasure, rejectedMeasure *stats.Int64Measure
 	switch dataType {
 	case component.DataTypeTraces:
 		sentMeasure = obsmetrics.ExporterSentSpans
 		failedMeasure = obsmetrics.ExporterFailedToSendSpans
+		rejectedMeasure = obsmetrics.ExporterRejectedSpans
 	case component.DataTypeMetrics:
 		sentMeasure = obsmetrics.ExporterSentMetricPoints
 		failedMeasure = obsmetrics.ExporterFailedToSendMetricPoints
+		rejectedMeasure = obsmetrics.ExporterRejectedMetricPoints
 	case component.DataTypeLogs:
 		sentMeasure = obsmetrics.ExporterSentLogRecords
 		failedMeasure = obsmetrics.ExporterFailedToSendLogRecords
+		rejectedMeasure = obsmetrics.ExporterRejectedLogRecords
+	case component.DataTypeProfiles:
+		sentMeasure = obsmetrics.ExporterSentSamples
+		failedMeasure = obsmetrics.ExporterFailedToSendSamples
+		rejectedMeasure = obsmetrics.ExporterRejectedSamples
 	}
 
+	measurements := []stats.Measurement{sentMeasure.M(sent)}
 	if failed > 0 {
-		_ = stats.RecordWithTags(
-			ctx,
-			exp.mutators,
-			sentMeasure.M(sent),
-			failedMeasure.M(failed))
-	} else {
-		_ = stats.RecordWithTags(
-			ctx,
-			exp.mutators,
-			sentMeasure.M(sent))
+		measurements = append(measurements, failedMeasure.M(failed))
 	}
+	if rejected > 0 {
+		measurements = append(measurements, rejectedMeasure.M(rejected))
+	}
+	_ = stats.RecordWithTags(ctx, exp.mutators, measurements...)
 }
 
 func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sentItemsKey, failedToSendItemsKey string) {
@@ -245,9 +344,21 @@ func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sen
 	span.End()
 }
 
-func toNumItems(numExportedItems int, err error) (int64, int64) {
-	if err != nil {
-		return 0, int64(numExportedItems)
+// toNumItems returns the number of items sent, failed to be sent, and rejected by the destination
+// in a partial success, which are also counted as failed to be sent.
+func toNumItems(numExportedItems int, err error) (int64, int64, int64) {
+	if err == nil {
+		return int64(numExportedItems), 0, 0
+	}
+	var partialErr consumererror.PartialError
+	if errors.As(err, &partialErr) {
+		rejected := partialErr.Rejected()
+		if rejected < 0 {
+			rejected = 0
+		} else if rejected > numExportedItems {
+			rejected = numExportedItems
+		}
+		return int64(numExportedItems - rejected), int64(rejected), int64(rejected)
 	}
-	return int64(numExportedItems), 0
+	return 0, int64(numExportedItems), 0
 }
@@ -6,6 +6,7 @@ package obsreport // import "go.opentelemetry.io/collector/obsreport"
 import (
 	"context"
 	"strings"
+	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
@@ -59,6 +60,7 @@ type Processor struct {
 	acceptedLogRecordsCounter   metric.Int64Counter
 	refusedLogRecordsCounter    metric.Int64Counter
 	droppedLogRecordsCounter    metric.Int64Counter
+	latency                     metric.Float64Histogram
 }
 
 // ProcessorSettings are settings for creating a Processor.
@@ -160,6 +162,13 @@ func (por *Processor) createOtelMetrics(cfg ProcessorSettings) error {
 	)
 	errors = multierr.Append(errors, err)
 
+	por.latency, err = meter.Float64Histogram(
+		obsmetrics.ProcessorPrefix+obsmetrics.LatencyKey,
+		metric.WithDescription("Duration of the processing of the data by the processor."),
+		metric.WithUnit("ms"),
+	)
+	errors = multierr.Append(errors, err)
+
 	return errors
 }
 
@@ -283,3 +292,23 @@ func (por *Processor) LogsDropped(ctx context.Context, numRecords int) {
 		por.recordData(ctx, component.DataTypeLogs, int64(0), int64(0), int64(numRecords))
 	}
 }
+
+// RecordLatency records the duration of the processing of the data by the
+// processor, labeled with the class of the error it returned.
+func (por *Processor) RecordLatency(ctx context.Context, duration time.Duration, err error) {
+	if por.level == configtelemetry.LevelNone {
+		return
+	}
+	latency := float64(duration) / float64(time.Millisecond)
+	class := errorClass(err)
+	if por.useOtelForMetrics {
+		attrs := append([]attribute.KeyValue{attribute.String(obsmetrics.ErrorClassKey, class)}, por.otelAttrs...)
+		por.latency.Record(ctx, latency, metric.WithAttributes(attrs...))
+		return
+	}
+	// ignore the error for now; should not happen
+	_ = stats.RecordWithTags(
+		ctx,
+		append([]tag.Mutator{tag.Upsert(obsmetrics.TagKeyErrorClass, class, tag.WithTTL(tag.TTLNoPropagation))}, por.mutators...),
+		obsmetrics.ProcessorLatency.M(latency))
+}
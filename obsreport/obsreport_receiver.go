@@ -16,6 +16,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/receiver"
@@ -47,6 +48,8 @@ type Receiver struct {
 	refusedMetricPointsCounter  metric.Int64Counter
 	acceptedLogRecordsCounter   metric.Int64Counter
 	refusedLogRecordsCounter    metric.Int64Counter
+	acceptedSamplesCounter      metric.Int64Counter
+	refusedSamplesCounter       metric.Int64Counter
 }
 
 // ReceiverSettings are settings for creating an Receiver.
@@ -144,6 +147,20 @@ func (rec *Receiver) createOtelMetrics() error {
 	)
 	errors = multierr.Append(errors, err)
 
+	rec.acceptedSamplesCounter, err = rec.meter.Int64Counter(
+		obsmetrics.ReceiverPrefix+obsmetrics.AcceptedSamplesKey,
+		metric.WithDescription("Number of profile samples successfully pushed into the pipeline."),
+		metric.WithUnit("1"),
+	)
+	errors = multierr.Append(errors, err)
+
+	rec.refusedSamplesCounter, err = rec.meter.Int64Counter(
+		obsmetrics.ReceiverPrefix+obsmetrics.RefusedSamplesKey,
+		metric.WithDescription("Number of profile samples that could not be pushed into the pipeline."),
+		metric.WithUnit("1"),
+	)
+	errors = multierr.Append(errors, err)
+
 	return errors
 }
 
@@ -201,6 +218,24 @@ func (rec *Receiver) EndMetricsOp(
 	rec.endOp(receiverCtx, format, numReceivedPoints, err, component.DataTypeMetrics)
 }
 
+// StartProfilesOp is called when a request is received from a client.
+// The returned context should be used in other calls to the obsreport functions
+// dealing with the same receive operation.
+func (rec *Receiver) StartProfilesOp(operationCtx context.Context) context.Context {
+	return rec.startOp(operationCtx, obsmetrics.ReceiverProfilesOperationSuffix)
+}
+
+// EndProfilesOp completes the receive operation that was started with
+// StartProfilesOp.
+func (rec *Receiver) EndProfilesOp(
+	receiverCtx context.Context,
+	format string,
+	numReceivedSamples int,
+	err error,
+) {
+	rec.endOp(receiverCtx, format, numReceivedSamples, err, component.DataTypeProfiles)
+}
+
 // startOp creates the span used to trace the operation. Returning
 // the updated context with the created span.
 func (rec *Receiver) startOp(receiverCtx context.Context, operationSuffix string) context.Context {
@@ -208,16 +243,15 @@ func (rec *Receiver) startOp(receiverCtx context.Context, operationSuffix string
 	var span trace.Span
 	spanName := rec.spanNamePrefix + operationSuffix
 	if !rec.longLivedCtx {
-		ctx, span = rec.tracer.Start(ctx, spanName)
+		ctx, span = startSpan(ctx, rec.tracer, spanName)
 	} else {
 		// Since the receiverCtx is long lived do not use it to start the span.
 		// This way this trace ends when the EndTracesOp is called.
 		// Here is safe to ignore the returned context since it is not used below.
-		_, span = rec.tracer.Start(context.Background(), spanName, trace.WithLinks(trace.Link{
-			SpanContext: trace.SpanContextFromContext(receiverCtx),
-		}))
+		links := append([]trace.Link{{SpanContext: trace.SpanContextFromContext(receiverCtx)}}, spanLinks(receiverCtx)...)
+		_, span = rec.tracer.Start(context.Background(), spanName, trace.WithLinks(links...))
 
-		ctx = trace.ContextWithSpan(ctx, span)
+		ctx = trace.ContextWithSpan(withoutSpanLinks(ctx), span)
 	}
 
 	if rec.transport != "" {
@@ -234,12 +268,9 @@ func (rec *Receiver) endOp(
 	err error,
 	dataType component.DataType,
 ) {
-	numAccepted := numReceivedItems
-	numRefused := 0
-	if err != nil {
-		numAccepted = 0
-		numRefused = numReceivedItems
-	}
+	// Only the items which failed are refused on partial successes.
+	numRefused := consumererror.Count(err, numReceivedItems).Failed()
+	numAccepted := numReceivedItems - numRefused
 
 	span := trace.SpanFromContext(receiverCtx)
 
@@ -260,6 +291,9 @@ func (rec *Receiver) endOp(
 		case component.DataTypeLogs:
 			acceptedItemsKey = obsmetrics.AcceptedLogRecordsKey
 			refusedItemsKey = obsmetrics.RefusedLogRecordsKey
+		case component.DataTypeProfiles:
+			acceptedItemsKey = obsmetrics.AcceptedSamplesKey
+			refusedItemsKey = obsmetrics.RefusedSamplesKey
 		}
 
 		span.SetAttributes(
@@ -292,6 +326,9 @@ func (rec *Receiver) recordWithOtel(receiverCtx context.Context, dataType compon
 	case component.DataTypeLogs:
 		acceptedMeasure = rec.acceptedLogRecordsCounter
 		refusedMeasure = rec.refusedLogRecordsCounter
+	case component.DataTypeProfiles:
+		acceptedMeasure = rec.acceptedSamplesCounter
+		refusedMeasure = rec.refusedSamplesCounter
 	}
 
 	acceptedMeasure.Add(receiverCtx, int64(numAccepted), metric.WithAttributes(rec.otelAttrs...))
@@ -310,6 +347,9 @@ func (rec *Receiver) recordWithOC(receiverCtx context.Context, dataType componen
 	case component.DataTypeLogs:
 		acceptedMeasure = obsmetrics.ReceiverAcceptedLogRecords
 		refusedMeasure = obsmetrics.ReceiverRefusedLogRecords
+	case component.DataTypeProfiles:
+		acceptedMeasure = obsmetrics.ReceiverAcceptedSamples
+		refusedMeasure = obsmetrics.ReceiverRefusedSamples
 	}
 
 	stats.Record(
@@ -42,6 +42,8 @@ type Scraper struct {
 	otelAttrs            []attribute.KeyValue
 	scrapedMetricsPoints metric.Int64Counter
 	erroredMetricsPoints metric.Int64Counter
+	missedScrapes        metric.Int64Counter
+	lateScrapes          metric.Int64Counter
 }
 
 // ScraperSettings are settings for creating a Scraper.
@@ -103,6 +105,20 @@ func (s *Scraper) createOtelMetrics(cfg ScraperSettings) error {
 	)
 	errors = multierr.Append(errors, err)
 
+	s.missedScrapes, err = meter.Int64Counter(
+		obsmetrics.ScraperPrefix+obsmetrics.MissedScrapesKey,
+		metric.WithDescription("Number of scrapes skipped because the previous scrape was still running."),
+		metric.WithUnit("1"),
+	)
+	errors = multierr.Append(errors, err)
+
+	s.lateScrapes, err = meter.Int64Counter(
+		obsmetrics.ScraperPrefix+obsmetrics.LateScrapesKey,
+		metric.WithDescription("Number of scrapes exceeding their duration budget."),
+		metric.WithUnit("1"),
+	)
+	errors = multierr.Append(errors, err)
+
 	return errors
 }
 
@@ -165,3 +181,30 @@ func (s *Scraper) recordMetrics(scraperCtx context.Context, numScrapedMetrics, n
 			obsmetrics.ScraperErroredMetricPoints.M(int64(numErroredMetrics)))
 	}
 }
+
+// RecordMissedScrapes records the number of scrapes skipped because the previous
+// scrape was still running when they were due.
+func (s *Scraper) RecordMissedScrapes(ctx context.Context, numMissedScrapes int) {
+	if s.level == configtelemetry.LevelNone || numMissedScrapes == 0 {
+		return
+	}
+	if s.useOtelForMetrics {
+		s.missedScrapes.Add(ctx, int64(numMissedScrapes), metric.WithAttributes(s.otelAttrs...))
+		return
+	}
+	ctx, _ = tag.New(ctx, s.mutators...)
+	stats.Record(ctx, obsmetrics.ScraperMissedScrapes.M(int64(numMissedScrapes)))
+}
+
+// RecordLateScrape records a scrape exceeding its duration budget.
+func (s *Scraper) RecordLateScrape(ctx context.Context) {
+	if s.level == configtelemetry.LevelNone {
+		return
+	}
+	if s.useOtelForMetrics {
+		s.lateScrapes.Add(ctx, 1, metric.WithAttributes(s.otelAttrs...))
+		return
+	}
+	ctx, _ = tag.New(ctx, s.mutators...)
+	stats.Record(ctx, obsmetrics.ScraperLateScrapes.M(1))
+}
@@ -6,15 +6,20 @@ package obsreport
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/receiver/scrapererror"
 )
@@ -104,6 +109,25 @@ func TestReceiveTraceDataOp(t *testing.T) {
 	})
 }
 
+func TestReceivePartialSuccess(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 10, consumererror.NewPartial(errFake, 4))
+		ctx = rec.StartLogsOp(context.Background())
+		rec.EndLogsOp(ctx, format, 30, consumererror.NewLogs(errFake, testdata.GenerateLogs(5)))
+
+		require.NoError(t, tt.CheckReceiverTraces(transport, 6, 4))
+		require.NoError(t, tt.CheckReceiverLogs(transport, 25, 5))
+	})
+}
+
 func TestReceiveLogsOp(t *testing.T) {
 	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
 		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
@@ -152,6 +176,54 @@ func TestReceiveLogsOp(t *testing.T) {
 	})
 }
 
+func TestReceiveProfilesOp(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
+
+		params := []testParams{
+			{items: 13, err: errFake},
+			{items: 42, err: nil},
+		}
+		for i, param := range params {
+			rec, err := newReceiver(ReceiverSettings{
+				ReceiverID:             receiverID,
+				Transport:              transport,
+				ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			}, useOtel)
+			require.NoError(t, err)
+
+			ctx := rec.StartProfilesOp(parentCtx)
+			assert.NotNil(t, ctx)
+			rec.EndProfilesOp(ctx, format, params[i].items, param.err)
+		}
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, len(params), len(spans))
+
+		var acceptedSamples, refusedSamples int
+		for i, span := range spans {
+			assert.Equal(t, "receiver/"+receiverID.String()+"/ProfilesReceived", span.Name())
+			switch {
+			case params[i].err == nil:
+				acceptedSamples += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedSamplesKey, Value: attribute.Int64Value(int64(params[i].items))})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSamplesKey, Value: attribute.Int64Value(0)})
+				assert.Equal(t, codes.Unset, span.Status().Code)
+			case errors.Is(params[i].err, errFake):
+				refusedSamples += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedSamplesKey, Value: attribute.Int64Value(0)})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSamplesKey, Value: attribute.Int64Value(int64(params[i].items))})
+				assert.Equal(t, codes.Error, span.Status().Code)
+				assert.Equal(t, params[i].err.Error(), span.Status().Description)
+			default:
+				t.Fatalf("unexpected param: %v", params[i])
+			}
+		}
+		require.NoError(t, tt.CheckReceiverProfiles(transport, int64(acceptedSamples), int64(refusedSamples)))
+	})
+}
+
 func TestReceiveMetricsOp(t *testing.T) {
 	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
 		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
@@ -258,6 +330,24 @@ func TestScrapeMetricsDataOp(t *testing.T) {
 	})
 }
 
+func TestScrapeScheduleOp(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		scrp, err := newScraper(ScraperSettings{
+			ReceiverID:             receiverID,
+			Scraper:                scraperID,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		scrp.RecordMissedScrapes(context.Background(), 3)
+		scrp.RecordMissedScrapes(context.Background(), 0)
+		scrp.RecordLateScrape(context.Background())
+		scrp.RecordLateScrape(context.Background())
+
+		require.NoError(t, obsreporttest.CheckScraperScheduleMetrics(tt, receiverID, scraperID, 3, 2))
+	})
+}
+
 func TestExportTraceDataOp(t *testing.T) {
 	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
 		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
@@ -306,6 +396,34 @@ func TestExportTraceDataOp(t *testing.T) {
 	})
 }
 
+func TestExportPartialSuccess(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		partialErr := consumererror.NewPartial(errFake, 4)
+		ctx := obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 10, partialErr)
+		ctx = obsrep.StartMetricsOp(context.Background())
+		obsrep.EndMetricsOp(ctx, 20, partialErr)
+		ctx = obsrep.StartLogsOp(context.Background())
+		obsrep.EndLogsOp(ctx, 30, partialErr)
+		// The rejected items do not exceed the exported ones.
+		ctx = obsrep.StartLogsOp(context.Background())
+		obsrep.EndLogsOp(ctx, 2, partialErr)
+
+		require.NoError(t, tt.CheckExporterTraces(6, 4))
+		require.NoError(t, tt.CheckExporterRejectedSpans(4))
+		require.NoError(t, tt.CheckExporterMetrics(16, 4))
+		require.NoError(t, tt.CheckExporterRejectedMetricPoints(4))
+		require.NoError(t, tt.CheckExporterLogs(26, 6))
+		require.NoError(t, tt.CheckExporterRejectedLogRecords(6))
+	})
+}
+
 func TestExportMetricsOp(t *testing.T) {
 	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
 		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
@@ -404,6 +522,55 @@ func TestExportLogsOp(t *testing.T) {
 	})
 }
 
+func TestExportProfilesOp(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
+
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		params := []testParams{
+			{items: 17, err: nil},
+			{items: 23, err: errFake},
+		}
+		for i := range params {
+			ctx := obsrep.StartProfilesOp(parentCtx)
+			assert.NotNil(t, ctx)
+
+			obsrep.EndProfilesOp(ctx, params[i].items, params[i].err)
+		}
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, len(params), len(spans))
+
+		var sentSamples, failedToSendSamples int
+		for i, span := range spans {
+			assert.Equal(t, "exporter/"+exporterID.String()+"/profiles", span.Name())
+			switch {
+			case params[i].err == nil:
+				sentSamples += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentSamplesKey, Value: attribute.Int64Value(int64(params[i].items))})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSamplesKey, Value: attribute.Int64Value(0)})
+				assert.Equal(t, codes.Unset, span.Status().Code)
+			case errors.Is(params[i].err, errFake):
+				failedToSendSamples += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentSamplesKey, Value: attribute.Int64Value(0)})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSamplesKey, Value: attribute.Int64Value(int64(params[i].items))})
+				assert.Equal(t, codes.Error, span.Status().Code)
+				assert.Equal(t, params[i].err.Error(), span.Status().Description)
+			default:
+				t.Fatalf("unexpected error: %v", params[i].err)
+			}
+		}
+
+		require.NoError(t, tt.CheckExporterProfiles(int64(sentSamples), int64(failedToSendSamples)))
+	})
+}
+
 func TestReceiveWithLongLivedCtx(t *testing.T) {
 	tt, err := obsreporttest.SetupTelemetry(receiverID)
 	require.NoError(t, err)
@@ -458,6 +625,55 @@ func TestReceiveWithLongLivedCtx(t *testing.T) {
 	}
 }
 
+func TestSpanLinks(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(exporterID)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	tracer := tt.TracerProvider.Tracer("test")
+	_, first := tracer.Start(context.Background(), "first")
+	first.End()
+	_, second := tracer.Start(context.Background(), "second")
+	second.End()
+	ctx := ContextWithSpanLinks(context.Background(), first.SpanContext(), trace.SpanContext{})
+	ctx = ContextWithSpanLinks(ctx, second.SpanContext())
+
+	exp, err := NewExporter(ExporterSettings{ExporterID: exporterID, ExporterCreateSettings: tt.ToExporterCreateSettings()})
+	require.NoError(t, err)
+	rec, err := NewReceiver(ReceiverSettings{ReceiverID: receiverID, Transport: transport, ReceiverCreateSettings: tt.ToReceiverCreateSettings()})
+	require.NoError(t, err)
+
+	expCtx := exp.StartTracesOp(ctx)
+	// The links are only used by the first span started from the context.
+	recCtx := rec.StartTracesOp(expCtx)
+	rec.EndTracesOp(recCtx, format, 1, nil)
+	exp.EndTracesOp(expCtx, 1, nil)
+
+	spans := tt.SpanRecorder.Ended()
+	require.Len(t, spans, 4)
+	recSpan, expSpan := spans[2], spans[3]
+	assert.Equal(t, "exporter/"+exporterID.String()+"/traces", expSpan.Name())
+	require.Len(t, expSpan.Links(), 2)
+	assert.Equal(t, first.SpanContext(), expSpan.Links()[0].SpanContext)
+	assert.Equal(t, second.SpanContext(), expSpan.Links()[1].SpanContext)
+	assert.Equal(t, expSpan.SpanContext(), recSpan.Parent())
+	assert.Empty(t, recSpan.Links())
+
+	// A receiver with a long lived context links to it and to the links it carries.
+	longLivedCtx, parentSpan := tracer.Start(ContextWithSpanLinks(context.Background(), first.SpanContext()), t.Name())
+	defer parentSpan.End()
+	rec, err = NewReceiver(ReceiverSettings{ReceiverID: receiverID, Transport: transport, LongLivedCtx: true, ReceiverCreateSettings: tt.ToReceiverCreateSettings()})
+	require.NoError(t, err)
+	recCtx = rec.StartTracesOp(longLivedCtx)
+	rec.EndTracesOp(recCtx, format, 1, nil)
+
+	spans = tt.SpanRecorder.Ended()
+	require.Len(t, spans, 5)
+	require.Len(t, spans[4].Links(), 2)
+	assert.Equal(t, parentSpan.SpanContext(), spans[4].Links()[0].SpanContext)
+	assert.Equal(t, first.SpanContext(), spans[4].Links()[1].SpanContext)
+}
+
 func TestProcessorTraceData(t *testing.T) {
 	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
 		const acceptedSpans = 27
@@ -495,6 +711,48 @@ func TestProcessorMetricsData(t *testing.T) {
 	})
 }
 
+func TestProcessorLatency(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		obsrep.RecordLatency(context.Background(), 3*time.Millisecond, nil)
+		obsrep.RecordLatency(context.Background(), 30*time.Millisecond, nil)
+		obsrep.RecordLatency(context.Background(), time.Millisecond, consumererror.NewPermanent(errFake))
+
+		require.NoError(t, tt.CheckProcessorLatency("none", 2))
+		require.NoError(t, tt.CheckProcessorLatency("permanent", 1))
+	})
+}
+
+func TestExporterLatency(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		obsrep.RecordLatency(context.Background(), 3*time.Millisecond, nil)
+		obsrep.RecordLatency(context.Background(), time.Second, context.DeadlineExceeded)
+		obsrep.RecordLatency(context.Background(), 5*time.Millisecond, errFake)
+		obsrep.RecordLatency(context.Background(), 5*time.Millisecond, errFake)
+
+		require.NoError(t, tt.CheckExporterLatency("none", 1))
+		require.NoError(t, tt.CheckExporterLatency("timeout", 1))
+		require.NoError(t, tt.CheckExporterLatency("transient", 2))
+	})
+}
+
+func TestErrorClass(t *testing.T) {
+	assert.Equal(t, "none", errorClass(nil))
+	assert.Equal(t, "permanent", errorClass(consumererror.NewPermanent(errFake)))
+	assert.Equal(t, "timeout", errorClass(fmt.Errorf("send: %w", context.DeadlineExceeded)))
+	assert.Equal(t, "canceled", errorClass(context.Canceled))
+	assert.Equal(t, "transient", errorClass(errFake))
+}
+
 func TestBuildProcessorCustomMetricName(t *testing.T) {
 	tests := []struct {
 		name string
@@ -35,11 +35,12 @@ const (
 	// Changes to metric names or labels can break alerting, dashboards, etc
 	// that are used to monitor the Collector in production deployments.
 	// DO NOT SWITCH THE VARIABLES BELOW TO SIMILAR ONES DEFINED ON THE PACKAGE.
-	receiverTag  = "receiver"
-	scraperTag   = "scraper"
-	transportTag = "transport"
-	exporterTag  = "exporter"
-	processorTag = "processor"
+	receiverTag   = "receiver"
+	scraperTag    = "scraper"
+	transportTag  = "transport"
+	exporterTag   = "exporter"
+	processorTag  = "processor"
+	errorClassTag = "error_class"
 )
 
 type TestTelemetry struct {
@@ -95,6 +96,40 @@ func (tts *TestTelemetry) CheckExporterLogs(sentLogRecords, sendFailedLogRecords
 	return tts.otelPrometheusChecker.checkExporterLogs(tts.id, sentLogRecords, sendFailedLogRecords)
 }
 
+// CheckExporterProfiles checks that for the current exported values for profiles exporter metrics match given values.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func (tts *TestTelemetry) CheckExporterProfiles(sentSamples, sendFailedSamples int64) error {
+	return tts.otelPrometheusChecker.checkExporterProfiles(tts.id, sentSamples, sendFailedSamples)
+}
+
+// CheckExporterRejectedSpans checks that for the current exported value of the spans rejected by
+// the destination in partial successes matches the given value.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func (tts *TestTelemetry) CheckExporterRejectedSpans(rejectedSpans int64) error {
+	return tts.otelPrometheusChecker.checkExporterRejected(tts.id, "exporter_rejected_spans", rejectedSpans)
+}
+
+// CheckExporterRejectedMetricPoints checks that for the current exported value of the metric points rejected by
+// the destination in partial successes matches the given value.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func (tts *TestTelemetry) CheckExporterRejectedMetricPoints(rejectedMetricPoints int64) error {
+	return tts.otelPrometheusChecker.checkExporterRejected(tts.id, "exporter_rejected_metric_points", rejectedMetricPoints)
+}
+
+// CheckExporterRejectedLogRecords checks that for the current exported value of the log records rejected by
+// the destination in partial successes matches the given value.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func (tts *TestTelemetry) CheckExporterRejectedLogRecords(rejectedLogRecords int64) error {
+	return tts.otelPrometheusChecker.checkExporterRejected(tts.id, "exporter_rejected_log_records", rejectedLogRecords)
+}
+
+// CheckExporterRejectedSamples checks that for the current exported value of the profile samples rejected by
+// the destination in partial successes matches the given value.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func (tts *TestTelemetry) CheckExporterRejectedSamples(rejectedSamples int64) error {
+	return tts.otelPrometheusChecker.checkExporterRejected(tts.id, "exporter_rejected_samples", rejectedSamples)
+}
+
 // CheckProcessorTraces checks that for the current exported values for trace exporter metrics match given values.
 // When this function is called it is required to also call SetupTelemetry as first thing.
 func (tts *TestTelemetry) CheckProcessorTraces(acceptedSpans, refusedSpans, droppedSpans int64) error {
@@ -113,6 +148,18 @@ func (tts *TestTelemetry) CheckProcessorLogs(acceptedLogRecords, refusedLogRecor
 	return tts.otelPrometheusChecker.checkProcessorLogs(tts.id, acceptedLogRecords, refusedLogRecords, droppedLogRecords)
 }
 
+// CheckProcessorLatency checks that the number of processing durations recorded by the processor with the given error
+// class matches the given value. When this function is called it is required to also call SetupTelemetry as first thing.
+func (tts *TestTelemetry) CheckProcessorLatency(errorClass string, count int64) error {
+	return tts.otelPrometheusChecker.checkProcessorLatency(tts.id, errorClass, count)
+}
+
+// CheckExporterLatency checks that the number of send attempt durations recorded by the exporter with the given error
+// class matches the given value. When this function is called it is required to also call SetupTelemetry as first thing.
+func (tts *TestTelemetry) CheckExporterLatency(errorClass string, count int64) error {
+	return tts.otelPrometheusChecker.checkExporterLatency(tts.id, errorClass, count)
+}
+
 // CheckReceiverTraces checks that for the current exported values for trace receiver metrics match given values.
 // When this function is called it is required to also call SetupTelemetry as first thing.
 func (tts *TestTelemetry) CheckReceiverTraces(protocol string, acceptedSpans, droppedSpans int64) error {
@@ -125,6 +172,12 @@ func (tts *TestTelemetry) CheckReceiverLogs(protocol string, acceptedLogRecords,
 	return tts.otelPrometheusChecker.checkReceiverLogs(tts.id, protocol, acceptedLogRecords, droppedLogRecords)
 }
 
+// CheckReceiverProfiles checks that for the current exported values for profiles receiver metrics match given values.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func (tts *TestTelemetry) CheckReceiverProfiles(protocol string, acceptedSamples, droppedSamples int64) error {
+	return tts.otelPrometheusChecker.checkReceiverProfiles(tts.id, protocol, acceptedSamples, droppedSamples)
+}
+
 // CheckReceiverMetrics checks that for the current exported values for metrics receiver metrics match given values.
 // When this function is called it is required to also call SetupTelemetry as first thing.
 func (tts *TestTelemetry) CheckReceiverMetrics(protocol string, acceptedMetricPoints, droppedMetricPoints int64) error {
@@ -192,3 +245,10 @@ func SetupTelemetry(id component.ID) (TestTelemetry, error) {
 func CheckScraperMetrics(tts TestTelemetry, receiver component.ID, scraper component.ID, scrapedMetricPoints, erroredMetricPoints int64) error {
 	return tts.otelPrometheusChecker.checkScraperMetrics(receiver, scraper, scrapedMetricPoints, erroredMetricPoints)
 }
+
+// CheckScraperScheduleMetrics checks that for the current exported values for the missed and late scrapes
+// of a scraper match given values. When this function is called it is required to also call SetupTelemetry
+// as first thing.
+func CheckScraperScheduleMetrics(tts TestTelemetry, receiver component.ID, scraper component.ID, missedScrapes, lateScrapes int64) error {
+	return tts.otelPrometheusChecker.checkScraperScheduleMetrics(receiver, scraper, missedScrapes, lateScrapes)
+}
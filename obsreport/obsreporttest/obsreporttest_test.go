@@ -111,6 +111,27 @@ func TestCheckReceiverLogsViews(t *testing.T) {
 	assert.Error(t, tt.CheckReceiverLogs(transport, 0, 7))
 }
 
+func TestCheckReceiverProfilesViews(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(receiver)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	rec, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             receiver,
+		Transport:              transport,
+		ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+	})
+	require.NoError(t, err)
+	ctx := rec.StartProfilesOp(context.Background())
+	require.NotNil(t, ctx)
+	rec.EndProfilesOp(ctx, format, 7, nil)
+
+	assert.NoError(t, tt.CheckReceiverProfiles(transport, 7, 0))
+	assert.Error(t, tt.CheckReceiverProfiles(transport, 7, 7))
+	assert.Error(t, tt.CheckReceiverProfiles(transport, 0, 0))
+	assert.Error(t, tt.CheckReceiverProfiles(transport, 0, 7))
+}
+
 func TestCheckProcessorTracesViews(t *testing.T) {
 	tt, err := obsreporttest.SetupTelemetry(processor)
 	require.NoError(t, err)
@@ -245,3 +266,23 @@ func TestCheckExporterLogsViews(t *testing.T) {
 	assert.Error(t, tt.CheckExporterLogs(0, 0))
 	assert.Error(t, tt.CheckExporterLogs(0, 7))
 }
+
+func TestCheckExporterProfilesViews(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(exporter)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	obsrep, err := obsreport.NewExporter(obsreport.ExporterSettings{
+		ExporterID:             exporter,
+		ExporterCreateSettings: tt.ToExporterCreateSettings(),
+	})
+	require.NoError(t, err)
+	ctx := obsrep.StartProfilesOp(context.Background())
+	require.NotNil(t, ctx)
+	obsrep.EndProfilesOp(ctx, 7, nil)
+
+	assert.NoError(t, tt.CheckExporterProfiles(7, 0))
+	assert.Error(t, tt.CheckExporterProfiles(7, 7))
+	assert.Error(t, tt.CheckExporterProfiles(0, 0))
+	assert.Error(t, tt.CheckExporterProfiles(0, 7))
+}
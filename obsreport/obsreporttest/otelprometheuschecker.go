@@ -30,6 +30,13 @@ func (pc *prometheusChecker) checkScraperMetrics(receiver component.ID, scraper
 		pc.checkCounter("scraper_errored_metric_points", erroredMetricPoints, scraperAttrs))
 }
 
+func (pc *prometheusChecker) checkScraperScheduleMetrics(receiver component.ID, scraper component.ID, missedScrapes, lateScrapes int64) error {
+	scraperAttrs := attributesForScraperMetrics(receiver, scraper)
+	return multierr.Combine(
+		pc.checkCounter("scraper_missed_scrapes", missedScrapes, scraperAttrs),
+		pc.checkCounter("scraper_late_scrapes", lateScrapes, scraperAttrs))
+}
+
 func (pc *prometheusChecker) checkReceiverTraces(receiver component.ID, protocol string, acceptedSpans, droppedSpans int64) error {
 	receiverAttrs := attributesForReceiverMetrics(receiver, protocol)
 	return multierr.Combine(
@@ -44,6 +51,13 @@ func (pc *prometheusChecker) checkReceiverLogs(receiver component.ID, protocol s
 		pc.checkCounter("receiver_refused_log_records", droppedLogRecords, receiverAttrs))
 }
 
+func (pc *prometheusChecker) checkReceiverProfiles(receiver component.ID, protocol string, acceptedSamples, droppedSamples int64) error {
+	receiverAttrs := attributesForReceiverMetrics(receiver, protocol)
+	return multierr.Combine(
+		pc.checkCounter("receiver_accepted_samples", acceptedSamples, receiverAttrs),
+		pc.checkCounter("receiver_refused_samples", droppedSamples, receiverAttrs))
+}
+
 func (pc *prometheusChecker) checkReceiverMetrics(receiver component.ID, protocol string, acceptedMetricPoints, droppedMetricPoints int64) error {
 	receiverAttrs := attributesForReceiverMetrics(receiver, protocol)
 	return multierr.Combine(
@@ -97,6 +111,17 @@ func (pc *prometheusChecker) checkExporterLogs(exporter component.ID, sentLogRec
 		pc.checkCounter("exporter_sent_log_records", sentLogRecords, exporterAttrs))
 }
 
+func (pc *prometheusChecker) checkExporterProfiles(exporter component.ID, sentSamples, sendFailedSamples int64) error {
+	exporterAttrs := attributesForExporterMetrics(exporter)
+	if sendFailedSamples > 0 {
+		return multierr.Combine(
+			pc.checkCounter("exporter_sent_samples", sentSamples, exporterAttrs),
+			pc.checkCounter("exporter_send_failed_samples", sendFailedSamples, exporterAttrs))
+	}
+	return multierr.Combine(
+		pc.checkCounter("exporter_sent_samples", sentSamples, exporterAttrs))
+}
+
 func (pc *prometheusChecker) checkExporterMetrics(exporter component.ID, sentMetricPoints, sendFailedMetricPoints int64) error {
 	exporterAttrs := attributesForExporterMetrics(exporter)
 	if sendFailedMetricPoints > 0 {
@@ -108,6 +133,20 @@ func (pc *prometheusChecker) checkExporterMetrics(exporter component.ID, sentMet
 		pc.checkCounter("exporter_sent_metric_points", sentMetricPoints, exporterAttrs))
 }
 
+func (pc *prometheusChecker) checkExporterRejected(exporter component.ID, expectedMetric string, rejected int64) error {
+	return pc.checkCounter(expectedMetric, rejected, attributesForExporterMetrics(exporter))
+}
+
+func (pc *prometheusChecker) checkProcessorLatency(processor component.ID, errorClass string, count int64) error {
+	attrs := append(attributesForProcessorMetrics(processor), attribute.String(errorClassTag, errorClass))
+	return pc.checkHistogramCount("processor_latency", count, attrs)
+}
+
+func (pc *prometheusChecker) checkExporterLatency(exporter component.ID, errorClass string, count int64) error {
+	attrs := append(attributesForExporterMetrics(exporter), attribute.String(errorClassTag, errorClass))
+	return pc.checkHistogramCount("exporter_latency", count, attrs)
+}
+
 func (pc *prometheusChecker) checkCounter(expectedMetric string, value int64, attrs []attribute.KeyValue) error {
 	// Forces a flush for the opencensus view data.
 	_, _ = view.RetrieveData(expectedMetric)
@@ -125,6 +164,22 @@ func (pc *prometheusChecker) checkCounter(expectedMetric string, value int64, at
 	return nil
 }
 
+func (pc *prometheusChecker) checkHistogramCount(expectedMetric string, count int64, attrs []attribute.KeyValue) error {
+	// Forces a flush for the opencensus view data.
+	_, _ = view.RetrieveData(expectedMetric)
+
+	ts, err := pc.getMetric(expectedMetric, io_prometheus_client.MetricType_HISTOGRAM, attrs)
+	if err != nil {
+		return err
+	}
+
+	if uint64(count) != ts.GetHistogram().GetSampleCount() {
+		return fmt.Errorf("sample count for metric '%s' did no match, expected '%d' got '%d'", expectedMetric, count, ts.GetHistogram().GetSampleCount())
+	}
+
+	return nil
+}
+
 // getMetric returns the metric time series that matches the given name, type and set of attributes
 // it fetches data from the prometheus endpoint and parse them, ideally OTel Go should provide a MeterRecorder of some kind.
 func (pc *prometheusChecker) getMetric(expectedName string, expectedType io_prometheus_client.MetricType, expectedAttrs []attribute.KeyValue) (*io_prometheus_client.Metric, error) {
@@ -9,10 +9,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
+	"sync"
 	"sync/atomic"
 	"syscall"
+	"time"
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -25,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service"
+	"go.opentelemetry.io/collector/service/opamp"
 )
 
 // State defines Collector's state.
@@ -74,6 +78,13 @@ type CollectorSettings struct {
 
 	// SkipSettingGRPCLogger avoids setting the grpc logger
 	SkipSettingGRPCLogger bool
+
+	// CrashReportPath is the file where a JSON crash report is written when the collector
+	// exits because of a fatal error. No report is written if empty.
+	CrashReportPath string
+
+	// configURIs are the locations of the configuration, reported in the crash report.
+	configURIs []string
 }
 
 // (Internal note) Collector Lifecycle:
@@ -87,12 +98,27 @@ type CollectorSettings struct {
 // - Upon shutdown, pipelines are notified, then pipelines and extensions are shut down.
 // - Users can call (*Collector).Shutdown anytime to shut down the collector.
 
+var errNotRunning = errors.New("the collector is not running")
+
 // Collector represents a server providing the OpenTelemetry Collector service.
 type Collector struct {
 	set CollectorSettings
 
-	service *service.Service
-	state   *atomic.Int32
+	// serviceMu is held while the service is set up, reloaded or shut down, and while it is used
+	// by the methods called by the programs embedding the collector.
+	serviceMu sync.RWMutex
+	service   *service.Service
+	state     *atomic.Int32
+
+	// subscribers are called with the new state whenever the state changes.
+	subscribersMu    sync.Mutex
+	subscribers      map[int]func(State)
+	nextSubscriberID int
+
+	// reloadRequests receives the requests to reload the configuration, with the channel their result is sent to.
+	reloadRequests chan chan error
+	// runDone is closed once Run returns.
+	runDone chan struct{}
 
 	// shutdownChan is used to terminate the collector.
 	shutdownChan chan struct{}
@@ -100,6 +126,11 @@ type Collector struct {
 	signalsChannel chan os.Signal
 	// asyncErrorChannel is used to signal a fatal error from any component.
 	asyncErrorChannel chan error
+
+	// opamp if not nil, reports the state of the collector to the OpAMP server of opampCfg.
+	opamp     *opamp.Client
+	opampCfg  *opamp.Config
+	startTime time.Time
 }
 
 // NewCollector creates and returns a new instance of Collector.
@@ -118,6 +149,9 @@ func NewCollector(set CollectorSettings) (*Collector, error) {
 		// the number of signals getting notified on is recommended.
 		signalsChannel:    make(chan os.Signal, 3),
 		asyncErrorChannel: make(chan error),
+		reloadRequests:    make(chan chan error),
+		runDone:           make(chan struct{}),
+		startTime:         time.Now(),
 	}, nil
 }
 
@@ -152,6 +186,7 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	effectiveCfg, effectiveCfgErr := effectiveConfig(cfg)
 	col.service, err = service.New(ctx, service.Settings{
 		BuildInfo:         col.set.BuildInfo,
 		Receivers:         receiver.NewBuilder(cfg.Receivers, col.set.Factories.Receivers),
@@ -161,10 +196,14 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		Extensions:        extension.NewBuilder(cfg.Extensions, col.set.Factories.Extensions),
 		AsyncErrorChannel: col.asyncErrorChannel,
 		LoggingOptions:    col.set.LoggingOptions,
+		EffectiveConfig:   effectiveCfg,
 	}, cfg.Service)
 	if err != nil {
 		return err
 	}
+	if effectiveCfgErr != nil {
+		col.service.Logger().Warn("The effective configuration is not available", zap.Error(effectiveCfgErr))
+	}
 
 	if !col.set.SkipSettingGRPCLogger {
 		grpclog.SetLogger(col.service.Logger(), cfg.Service.Telemetry.Logs.Level)
@@ -173,12 +212,23 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 	if err = col.service.Start(ctx); err != nil {
 		return multierr.Combine(err, col.service.Shutdown(ctx))
 	}
+	if err = col.startOpAMP(ctx, cfg.Service.OpAMP); err != nil {
+		return multierr.Combine(err, col.service.Shutdown(ctx))
+	}
+	if col.opamp != nil {
+		col.opamp.SetEffectiveConfig(effectiveCfg)
+	}
 	col.setCollectorState(StateRunning)
 	return nil
 }
 
 func (col *Collector) reloadConfiguration(ctx context.Context) error {
-	col.service.Logger().Warn("Config updated, restart service")
+	err := col.reloadService(ctx)
+	if err == nil {
+		col.service.Logger().Info("Config updated, reloaded service")
+		return nil
+	}
+	col.service.Logger().Warn("Config updated, restart service", zap.Error(err))
 	col.setCollectorState(StateClosing)
 
 	if err := col.service.Shutdown(ctx); err != nil {
@@ -192,6 +242,49 @@ func (col *Collector) reloadConfiguration(ctx context.Context) error {
 	return nil
 }
 
+// reloadService applies the updated configuration to the running service,
+// restarting only the components affected by the change.
+func (col *Collector) reloadService(ctx context.Context) error {
+	cfg, err := col.set.ConfigProvider.Get(ctx, col.set.Factories)
+	if err != nil {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+
+	if err = cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	effectiveCfg, effectiveCfgErr := effectiveConfig(cfg)
+	if effectiveCfgErr != nil {
+		col.service.Logger().Warn("The effective configuration is not available", zap.Error(effectiveCfgErr))
+	}
+	err = col.service.Reload(ctx, service.Settings{
+		BuildInfo:         col.set.BuildInfo,
+		Receivers:         receiver.NewBuilder(cfg.Receivers, col.set.Factories.Receivers),
+		Processors:        processor.NewBuilder(cfg.Processors, col.set.Factories.Processors),
+		Exporters:         exporter.NewBuilder(cfg.Exporters, col.set.Factories.Exporters),
+		Connectors:        connector.NewBuilder(cfg.Connectors, col.set.Factories.Connectors),
+		Extensions:        extension.NewBuilder(cfg.Extensions, col.set.Factories.Extensions),
+		AsyncErrorChannel: col.asyncErrorChannel,
+		LoggingOptions:    col.set.LoggingOptions,
+		EffectiveConfig:   effectiveCfg,
+	}, cfg.Service)
+	if err != nil {
+		return err
+	}
+
+	if !col.set.SkipSettingGRPCLogger {
+		grpclog.SetLogger(col.service.Logger(), cfg.Service.Telemetry.Logs.Level)
+	}
+	if err = col.startOpAMP(ctx, cfg.Service.OpAMP); err != nil {
+		return err
+	}
+	if col.opamp != nil {
+		col.opamp.SetEffectiveConfig(effectiveCfg)
+	}
+	return nil
+}
+
 func (col *Collector) DryRun(ctx context.Context) error {
 	cfg, err := col.set.ConfigProvider.Get(ctx, col.set.Factories)
 	if err != nil {
@@ -204,11 +297,19 @@ func (col *Collector) DryRun(ctx context.Context) error {
 // Run starts the collector according to the given configuration, and waits for it to complete.
 // Consecutive calls to Run are not allowed, Run shouldn't be called once a collector is shut down.
 func (col *Collector) Run(ctx context.Context) error {
-	if err := col.setupConfigurationComponents(ctx); err != nil {
+	defer close(col.runDone)
+	if err := col.withService(func() error { return col.setupConfigurationComponents(ctx) }); err != nil {
 		col.setCollectorState(StateClosed)
-		return err
+		return multierr.Append(err, col.writeCrashReport(crashPhaseStartup, err))
 	}
 
+	// The OpAMP client is shut down on shutdown, and when the collector stops because of a failed reload.
+	defer func() {
+		if err := col.shutdownOpAMP(context.Background()); err != nil && col.service != nil {
+			col.service.Logger().Warn("Failed to shut down the OpAMP client", zap.Error(err))
+		}
+	}()
+
 	// Always notify with SIGHUP for configuration reloading.
 	signal.Notify(col.signalsChannel, syscall.SIGHUP)
 	defer signal.Stop(col.signalsChannel)
@@ -224,20 +325,34 @@ LOOP:
 		case err := <-col.set.ConfigProvider.Watch():
 			if err != nil {
 				col.service.Logger().Error("Config watch failed", zap.Error(err))
+				col.reportCrash(crashPhaseRuntime, err)
 				break LOOP
 			}
-			if err = col.reloadConfiguration(ctx); err != nil {
+			if err = col.withService(func() error { return col.reloadConfiguration(ctx) }); err != nil {
+				col.reportCrash(crashPhaseReload, err)
+				return err
+			}
+		case done := <-col.reloadRequests:
+			if err := col.withService(func() error { return col.handleReloadRequest(ctx, done) }); err != nil {
+				col.reportCrash(crashPhaseReload, err)
+				return err
+			}
+		case rc := <-col.remoteConfigs():
+			if err := col.withService(func() error { return col.applyRemoteConfig(ctx, rc) }); err != nil {
+				col.reportCrash(crashPhaseReload, err)
 				return err
 			}
 		case err := <-col.asyncErrorChannel:
 			col.service.Logger().Error("Asynchronous error received, terminating process", zap.Error(err))
+			col.reportCrash(crashPhaseRuntime, err)
 			break LOOP
 		case s := <-col.signalsChannel:
 			col.service.Logger().Info("Received signal from OS", zap.String("signal", s.String()))
 			if s != syscall.SIGHUP {
 				break LOOP
 			}
-			if err := col.reloadConfiguration(ctx); err != nil {
+			if err := col.withService(func() error { return col.reloadConfiguration(ctx) }); err != nil {
+				col.reportCrash(crashPhaseReload, err)
 				return err
 			}
 		case <-col.shutdownChan:
@@ -253,11 +368,17 @@ LOOP:
 }
 
 func (col *Collector) shutdown(ctx context.Context) error {
+	col.serviceMu.Lock()
+	defer col.serviceMu.Unlock()
 	col.setCollectorState(StateClosing)
 
 	// Accumulate errors and proceed with shutting down remaining components.
 	var errs error
 
+	if err := col.shutdownOpAMP(ctx); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown the OpAMP client: %w", err))
+	}
+
 	if err := col.set.ConfigProvider.Shutdown(ctx); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown config provider: %w", err))
 	}
@@ -274,5 +395,89 @@ func (col *Collector) shutdown(ctx context.Context) error {
 
 // setCollectorState provides current state of the collector
 func (col *Collector) setCollectorState(state State) {
-	col.state.Store(int32(state))
+	if State(col.state.Swap(int32(state))) == state {
+		return
+	}
+	if col.opamp != nil {
+		col.opamp.SetHealth(state == StateRunning, col.startTime, state.String())
+	}
+	col.subscribersMu.Lock()
+	subscribers := make([]func(State), 0, len(col.subscribers))
+	for _, fn := range col.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	col.subscribersMu.Unlock()
+	for _, fn := range subscribers {
+		fn(state)
+	}
+}
+
+// withService calls fn while holding the lock of the service.
+func (col *Collector) withService(fn func() error) error {
+	col.serviceMu.Lock()
+	defer col.serviceMu.Unlock()
+	return fn()
+}
+
+// Subscribe calls fn with the new state of the collector whenever its state changes, until the
+// returned function is called. fn is called by the goroutine running the collector, it must not
+// block nor call the other methods of the collector, except GetState.
+func (col *Collector) Subscribe(fn func(State)) (unsubscribe func()) {
+	col.subscribersMu.Lock()
+	defer col.subscribersMu.Unlock()
+	if col.subscribers == nil {
+		col.subscribers = make(map[int]func(State))
+	}
+	id := col.nextSubscriberID
+	col.nextSubscriberID++
+	col.subscribers[id] = fn
+	return func() {
+		col.subscribersMu.Lock()
+		defer col.subscribersMu.Unlock()
+		delete(col.subscribers, id)
+	}
+}
+
+// Reload reloads the configuration from the ConfigProvider and applies it, as when the collector
+// receives SIGHUP, and returns once it is applied. An invalid configuration is not applied, its
+// error is returned and the collector keeps running. The collector stops if a valid configuration
+// cannot be applied. Reload waits for Run to start, and fails once Run returned.
+func (col *Collector) Reload(ctx context.Context) error {
+	done := make(chan error, 1)
+	select {
+	case col.reloadRequests <- done:
+	case <-col.runDone:
+		return errNotRunning
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// handleReloadRequest reloads the configuration if it is valid, and sends the result to done.
+// The returned error is fatal.
+func (col *Collector) handleReloadRequest(ctx context.Context, done chan<- error) error {
+	if err := col.DryRun(ctx); err != nil {
+		done <- fmt.Errorf("invalid configuration: %w", err)
+		return nil
+	}
+	err := col.reloadConfiguration(ctx)
+	done <- err
+	return err
+}
+
+// WriteGraph writes the graph of the running pipelines in the given format, service.GraphFormatDOT
+// or service.GraphFormatMermaid, with the edges labeled with the amount of data sent along them.
+func (col *Collector) WriteGraph(w io.Writer, format string) error {
+	col.serviceMu.RLock()
+	defer col.serviceMu.RUnlock()
+	if col.GetState() != StateRunning {
+		return errNotRunning
+	}
+	return col.service.WriteGraph(w, format)
 }
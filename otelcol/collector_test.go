@@ -5,6 +5,7 @@
 package otelcol
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"path/filepath"
@@ -17,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service"
 )
 
 func TestStateString(t *testing.T) {
@@ -378,3 +380,55 @@ func startCollector(ctx context.Context, t *testing.T, col *Collector) *sync.Wai
 	}()
 	return wg
 }
+
+func TestCollectorEmbedded(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	provider := NewConfProvider(nopConf())
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: provider,
+	})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var states []State
+	unsubscribe := col.Subscribe(func(state State) {
+		mu.Lock()
+		defer mu.Unlock()
+		states = append(states, state)
+	})
+	assert.ErrorIs(t, col.WriteGraph(&bytes.Buffer{}, service.GraphFormatDOT), errNotRunning)
+
+	wg := startCollector(context.Background(), t, col)
+	require.NoError(t, col.Reload(context.Background()))
+	assert.Equal(t, StateRunning, col.GetState())
+
+	var graph bytes.Buffer
+	require.NoError(t, col.WriteGraph(&graph, service.GraphFormatDOT))
+	assert.Contains(t, graph.String(), "receiver nop")
+	assert.NotContains(t, graph.String(), "processor nop")
+
+	// An invalid configuration is not applied, the collector keeps running.
+	provider.Set(nopConf("invalid"))
+	assert.Error(t, col.Reload(context.Background()))
+	assert.Equal(t, StateRunning, col.GetState())
+
+	provider.Set(nopConf("nop"))
+	require.NoError(t, col.Reload(context.Background()))
+	graph.Reset()
+	require.NoError(t, col.WriteGraph(&graph, service.GraphFormatDOT))
+	assert.Contains(t, graph.String(), "processor nop")
+
+	col.Shutdown()
+	wg.Wait()
+	assert.ErrorIs(t, col.Reload(context.Background()), errNotRunning)
+
+	mu.Lock()
+	defer mu.Unlock()
+	// The collector is starting once created, the subscribers are only notified of the changes.
+	assert.Equal(t, []State{StateRunning, StateClosing, StateClosed}, states)
+	unsubscribe()
+}
@@ -24,24 +24,37 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if getPrintEffectiveConfigFlag(flagSet) {
+				return col.printEffectiveConfig(cmd.Context(), cmd.OutOrStdout())
+			}
 			return col.Run(cmd.Context())
 		},
 	}
 	rootCmd.AddCommand(newComponentsCommand(set))
 	rootCmd.AddCommand(newValidateSubCommand(set, flagSet))
+	rootCmd.AddCommand(newGraphSubCommand(set, flagSet))
+	rootCmd.AddCommand(newPrintDefaultConfigCommand(set))
+	rootCmd.AddCommand(newSchemaCommand(set))
+	rootCmd.AddCommand(newReplayDeadLetterCommand(set, flagSet))
+	rootCmd.AddCommand(newReplayCommand(set, flagSet))
+	rootCmd.AddCommand(newMigrateConfigCommand(flagSet))
 	rootCmd.Flags().AddGoFlagSet(flagSet)
 	return rootCmd
 }
 
 func newCollectorWithFlags(set CollectorSettings, flags *flag.FlagSet) (*Collector, error) {
+	if path := getCrashReportFlag(flags); path != "" {
+		set.CrashReportPath = path
+	}
 	if set.ConfigProvider == nil {
 		configFlags := getConfigFlag(flags)
+		set.configURIs = configFlags
 		if len(configFlags) == 0 {
 			return nil, errors.New("at least one config flag must be provided")
 		}
 
 		var err error
-		set.ConfigProvider, err = NewConfigProvider(newDefaultConfigProviderSettings(configFlags))
+		set.ConfigProvider, err = NewConfigProvider(configProviderSettings(flags))
 		if err != nil {
 			return nil, err
 		}
@@ -5,6 +5,7 @@ package otelcol
 
 import (
 	"bytes"
+	"encoding/json"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -51,3 +52,57 @@ func TestNewBuildSubCommand(t *testing.T) {
 	// line that makes the test fail.
 	assert.Equal(t, strings.Trim(string(ExpectedOutput), "\n"), strings.Trim(b.String(), "\n"))
 }
+
+func TestPrintDefaultConfigCommand(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cmd := NewCommand(CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: factories,
+	})
+	cmd.SetArgs([]string{"print-default-config"})
+
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, cmd.Execute())
+
+	assert.True(t, strings.HasPrefix(b.String(), "# Default configuration of otelcol "))
+	var out map[string]any
+	require.NoError(t, yaml.Unmarshal(b.Bytes(), &out))
+	assert.Contains(t, out, "receivers")
+	assert.Contains(t, out, "exporters")
+	assert.Contains(t, out, "service")
+}
+
+func TestSchemaCommand(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cmd := NewCommand(CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: factories,
+	})
+	cmd.SetArgs([]string{"schema"})
+
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, cmd.Execute())
+
+	var out struct {
+		Schema     string `json:"$schema"`
+		Title      string `json:"title"`
+		Properties map[string]struct {
+			PatternProperties map[string]any `json:"patternProperties"`
+			Properties        map[string]any `json:"properties"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &out))
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", out.Schema)
+	assert.Equal(t, "Configuration of otelcol latest", out.Title)
+	for _, section := range []string{"receivers", "processors", "exporters", "connectors", "extensions"} {
+		assert.Contains(t, out.Properties[section].PatternProperties, "^nop(/.+)?$", section)
+	}
+	assert.Contains(t, out.Properties["service"].Properties, "pipelines")
+	assert.Contains(t, out.Properties["service"].Properties, "telemetry")
+}
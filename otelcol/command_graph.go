@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/service"
+)
+
+// newGraphSubCommand constructs a new graph sub command using the given CollectorSettings.
+func newGraphSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var format string
+	graphCmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Outputs the graph of the pipelines of the config without running the collector",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if set.ConfigProvider == nil {
+				var err error
+
+				configFlags := getConfigFlag(flagSet)
+				if len(configFlags) == 0 {
+					return errors.New("at least one config flag must be provided")
+				}
+
+				set.ConfigProvider, err = NewConfigProvider(configProviderSettings(flagSet))
+				if err != nil {
+					return err
+				}
+			}
+			cfg, err := set.ConfigProvider.Get(cmd.Context(), set.Factories)
+			if err != nil {
+				return fmt.Errorf("failed to get config: %w", err)
+			}
+			if err = cfg.Validate(); err != nil {
+				return err
+			}
+			return service.WriteGraph(cmd.OutOrStdout(), service.Settings{
+				BuildInfo:  set.BuildInfo,
+				Connectors: connector.NewBuilder(cfg.Connectors, set.Factories.Connectors),
+			}, cfg.Service, format)
+		},
+	}
+	graphCmd.Flags().StringVar(&format, "format", service.GraphFormatDOT,
+		fmt.Sprintf("Format of the graph, %q or %q.", service.GraphFormatDOT, service.GraphFormatMermaid))
+	graphCmd.Flags().AddGoFlagSet(flagSet)
+	return graphCmd
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/converter/expandconverter"
+	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func newGraphTestConfigProvider(t *testing.T, file string) ConfigProvider {
+	cfgProvider, err := NewConfigProvider(
+		ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				URIs:       []string{filepath.Join("testdata", file)},
+				Providers:  map[string]confmap.Provider{"file": fileprovider.New()},
+				Converters: []confmap.Converter{expandconverter.New()},
+			},
+		})
+	require.NoError(t, err)
+	return cfgProvider
+}
+
+func TestGraphSubCommandNoConfig(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cmd := newGraphSubCommand(CollectorSettings{Factories: factories}, flags(featuregate.GlobalRegistry()))
+	err = cmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "at least one config flag must be provided")
+}
+
+func TestGraphSubCommandInvalidComponents(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	set := CollectorSettings{Factories: factories, ConfigProvider: newGraphTestConfigProvider(t, "otelcol-invalid-components.yaml")}
+	cmd := newGraphSubCommand(set, flags(featuregate.GlobalRegistry()))
+	err = cmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown type: \"nosuchprocessor\"")
+}
+
+func TestGraphSubCommand(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	set := CollectorSettings{Factories: factories, ConfigProvider: newGraphTestConfigProvider(t, "otelcol-nop.yaml")}
+	for _, format := range []string{"dot", "mermaid"} {
+		t.Run(format, func(t *testing.T) {
+			cmd := newGraphSubCommand(set, flags(featuregate.GlobalRegistry()))
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+			cmd.SetArgs([]string{"--format", format})
+			require.NoError(t, cmd.Execute())
+			assert.Contains(t, out.String(), "connector nop/con [traces to logs]")
+			assert.Equal(t, 1, strings.Count(out.String(), "receiver nop [traces]"))
+		})
+	}
+
+	cmd := newGraphSubCommand(set, flags(featuregate.GlobalRegistry()))
+	cmd.SetArgs([]string{"--format", "svg"})
+	assert.EqualError(t, cmd.Execute(), `unknown graph format "svg", supported formats are "dot" and "mermaid"`)
+}
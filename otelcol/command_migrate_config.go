@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/collector/otelcol/internal/configmigration"
+)
+
+// newMigrateConfigCommand constructs a new migrate-config command.
+func newMigrateConfigCommand(flagSet *flag.FlagSet) *cobra.Command {
+	var fromVersion string
+	var write bool
+	migrateCmd := &cobra.Command{
+		Use:   "migrate-config",
+		Short: "Rewrites the deprecated settings of the config files to the settings replacing them",
+		Long: `Rewrites the deprecated settings of the config files to the settings replacing them, and outputs
+the diff of the changes. The files are only rewritten with --write. Only the config files can be migrated,
+the placeholders, e.g. ${env:NAME}, are kept as is.`,
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFlags := getConfigFlag(flagSet)
+			if len(configFlags) == 0 {
+				return errors.New("at least one config flag must be provided")
+			}
+			for _, uri := range configFlags {
+				path, err := configFilePath(uri)
+				if err != nil {
+					return err
+				}
+				if err = migrateConfigFile(cmd, path, fromVersion, write); err != nil {
+					return fmt.Errorf("failed to migrate %q: %w", path, err)
+				}
+			}
+			return nil
+		},
+	}
+	migrateCmd.Flags().StringVar(&fromVersion, "from-version", "",
+		"Version of the collector the config was written for, e.g. v0.62.0. Only the settings deprecated after it are migrated, all of them if not set.")
+	migrateCmd.Flags().BoolVar(&write, "write", false, "Rewrite the config files with the migrated config.")
+	migrateCmd.Flags().AddGoFlagSet(flagSet)
+	return migrateCmd
+}
+
+// configFilePath returns the path of the file of a config URI, which must use the file scheme or be a path.
+func configFilePath(uri string) (string, error) {
+	if strings.HasPrefix(uri, "file:") {
+		return strings.TrimPrefix(uri, "file:"), nil
+	}
+	if scheme, _, ok := strings.Cut(uri, ":"); ok && len(scheme) > 1 && !strings.ContainsAny(scheme, `/\`) {
+		return "", fmt.Errorf("cannot migrate %q, only config files can be migrated", uri)
+	}
+	return uri, nil
+}
+
+func migrateConfigFile(cmd *cobra.Command, path string, fromVersion string, write bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	migrated, applied, err := configmigration.Migrate(data, fromVersion)
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	if len(applied) == 0 {
+		fmt.Fprintf(out, "%s: no deprecated settings\n", path)
+		return nil
+	}
+	for _, rule := range applied {
+		fmt.Fprintf(out, "%s: %s: %s\n", path, rule.Version, rule.Description)
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(data),
+		B:        splitLines(migrated),
+		FromFile: path,
+		ToFile:   path + " (migrated)",
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(out, diff)
+	if !write {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, migrated, info.Mode().Perm())
+}
+
+// splitLines splits the lines of the data, keeping their line feed.
+func splitLines(data []byte) []string {
+	lines := strings.SplitAfter(string(data), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func TestMigrateConfigCommandNoConfig(t *testing.T) {
+	cmd := newMigrateConfigCommand(flags(featuregate.GlobalRegistry()))
+	assert.EqualError(t, cmd.Execute(), "at least one config flag must be provided")
+}
+
+func TestMigrateConfigCommand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("exporters:\n  logging:\n    loglevel: debug\n"), 0600))
+
+	cmd := newMigrateConfigCommand(flags(featuregate.GlobalRegistry()))
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"--config", "file:" + path})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, path+": v0.63.0: exporters::logging::loglevel is replaced by exporters::logging::verbosity\n"+
+		"--- "+path+"\n"+
+		"+++ "+path+" (migrated)\n"+
+		"@@ -1,3 +1,3 @@\n"+
+		" exporters:\n"+
+		"   logging:\n"+
+		"-    loglevel: debug\n"+
+		"+    verbosity: detailed\n", out.String())
+
+	// The file is only rewritten with --write.
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "exporters:\n  logging:\n    loglevel: debug\n", string(data))
+
+	cmd = newMigrateConfigCommand(flags(featuregate.GlobalRegistry()))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--config", path, "--write"})
+	require.NoError(t, cmd.Execute())
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "exporters:\n  logging:\n    verbosity: detailed\n", string(data))
+
+	cmd = newMigrateConfigCommand(flags(featuregate.GlobalRegistry()))
+	out.Reset()
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"--config", path})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, path+": no deprecated settings\n", out.String())
+}
+
+func TestMigrateConfigCommandErrors(t *testing.T) {
+	cmd := newMigrateConfigCommand(flags(featuregate.GlobalRegistry()))
+	cmd.SetArgs([]string{"--config", "env:OTELCOL_CONFIG"})
+	assert.EqualError(t, cmd.Execute(), `cannot migrate "env:OTELCOL_CONFIG", only config files can be migrated`)
+
+	path := filepath.Join(t.TempDir(), "missing.yaml")
+	cmd = newMigrateConfigCommand(flags(featuregate.GlobalRegistry()))
+	cmd.SetArgs([]string{"--config", path})
+	assert.ErrorContains(t, cmd.Execute(), `failed to migrate "`+path+`"`)
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigHeader documents the settings that are not part of the generated configuration.
+const defaultConfigHeader = `# Default configuration of %s %s, assembled from the default configuration of its components.
+#
+# The logs are written to stderr. To write them to a file rotated by size, set a file path
+# in service::telemetry::logs::output_paths, e.g.:
+#
+#   service:
+#     telemetry:
+#       logs:
+#         output_paths: [/var/log/otelcol/otelcol.log]
+#         rotation:
+#           enable: true
+#           max_megabytes: 100
+#           max_days: 7
+#           max_backups: 10
+#           localtime: false
+`
+
+// newPrintDefaultConfigCommand constructs a new print-default-config command using the given CollectorSettings.
+func newPrintDefaultConfigCommand(set CollectorSettings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "print-default-config",
+		Short: "Outputs a runnable configuration assembled from the default configuration of the components",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := DefaultConfig(set.Factories)
+			if err != nil {
+				return err
+			}
+			yamlData, err := yaml.Marshal(conf.ToStringMap())
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), defaultConfigHeader, set.BuildInfo.Command, set.BuildInfo.Version)
+			fmt.Fprint(cmd.OutOrStdout(), string(yamlData))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/otlpfile"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/pipelines"
+	"go.opentelemetry.io/collector/service/recording"
+)
+
+// replayReceiverType is the type of the receiver reading the recording, which replaces the receivers of the replayed pipeline.
+const replayReceiverType component.Type = "replay"
+
+// newReplayCommand constructs a new replay command using the given CollectorSettings.
+func newReplayCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var file, pipeline string
+	var speed float64
+	replayCmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Runs a pipeline of the config with the data of a recording file in place of its receivers",
+		Long: `Runs a pipeline of the config with the data of a recording file, written by the service::recording
+configuration, in place of its receivers. The pipelines the replayed pipeline sends data to through
+connectors are run as well. The collector stops once all the data has been replayed.`,
+		Args: cobra.ExactArgs(0),
+		// The replay errors are not usage errors.
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if file == "" || pipeline == "" {
+				return errors.New("the file and pipeline flags must be provided")
+			}
+			if speed < 0 {
+				return errors.New("the speed flag must not be negative")
+			}
+			pipelineID := component.ID{}
+			if err := pipelineID.UnmarshalText([]byte(pipeline)); err != nil {
+				return err
+			}
+			if _, err := os.Stat(file); err != nil {
+				return err
+			}
+
+			if set.ConfigProvider == nil {
+				var err error
+
+				configFlags := getConfigFlag(flagSet)
+				if len(configFlags) == 0 {
+					return errors.New("at least one config flag must be provided")
+				}
+
+				set.ConfigProvider, err = NewConfigProvider(configProviderSettings(flagSet))
+				if err != nil {
+					return err
+				}
+			}
+			cfg, err := set.ConfigProvider.Get(cmd.Context(), set.Factories)
+			if err != nil {
+				return fmt.Errorf("failed to get config: %w", err)
+			}
+			if cfg, err = replayConfig(cfg, pipelineID); err != nil {
+				return err
+			}
+
+			var col *Collector
+			result := &replayResult{}
+			receivers := make(map[component.Type]receiver.Factory, len(set.Factories.Receivers)+1)
+			for typ, factory := range set.Factories.Receivers {
+				receivers[typ] = factory
+			}
+			receivers[replayReceiverType] = newReplayReceiverFactory(file, speed, func(replayed int, replayErr error) {
+				result.set(replayed, replayErr)
+				col.Shutdown()
+			})
+			set.Factories.Receivers = receivers
+			set.ConfigProvider = staticConfigProvider{cfg: cfg}
+			if col, err = NewCollector(set); err != nil {
+				return err
+			}
+			err = col.Run(cmd.Context())
+			replayed, replayErr := result.get()
+			fmt.Fprintf(cmd.OutOrStdout(), "Replayed %d records of %q\n", replayed, file)
+			return multierr.Append(replayErr, err)
+		},
+	}
+	replayCmd.Flags().StringVar(&file, "file", "", "Recording file to replay.")
+	replayCmd.Flags().StringVar(&pipeline, "pipeline", "", "ID of the pipeline of the config which the recorded data is replayed into.")
+	replayCmd.Flags().Float64Var(&speed, "speed", 1, "Speed of the replay relative to the recording, 0 replays the data as fast as possible.")
+	replayCmd.Flags().AddGoFlagSet(flagSet)
+	return replayCmd
+}
+
+// replayConfig returns a copy of cfg running the pipeline with the replay receiver in place of its receivers,
+// and the pipelines it sends data to through connectors. The other pipelines and the recording are removed.
+func replayConfig(cfg *Config, pipelineID component.ID) (*Config, error) {
+	if _, ok := cfg.Service.Pipelines[pipelineID]; !ok {
+		return nil, fmt.Errorf("pipeline %q is not configured", pipelineID)
+	}
+
+	// Find the pipelines fed by the replayed pipeline, and the connectors they are fed through.
+	kept := map[component.ID]bool{pipelineID: true}
+	exported := map[component.ID]bool{}
+	for queue := []component.ID{pipelineID}; len(queue) > 0; queue = queue[1:] {
+		for _, exp := range cfg.Service.Pipelines[queue[0]].Exporters {
+			if _, ok := cfg.Connectors[exp]; !ok || exported[exp] {
+				continue
+			}
+			exported[exp] = true
+			for id, p := range cfg.Service.Pipelines {
+				if !kept[id] && containsComponentID(p.Receivers, exp) {
+					kept[id] = true
+					queue = append(queue, id)
+				}
+			}
+		}
+	}
+
+	replayID := component.NewID(replayReceiverType)
+	replay := *cfg
+	replay.Receivers = map[component.ID]component.Config{replayID: &replayReceiverConfig{}}
+	replay.Service.Recording = nil
+	replay.Service.Pipelines = make(pipelines.Config, len(kept))
+	for id := range kept {
+		p := *cfg.Service.Pipelines[id]
+		p.Receivers = nil
+		if id == pipelineID {
+			p.Receivers = []component.ID{replayID}
+		} else {
+			for _, recv := range cfg.Service.Pipelines[id].Receivers {
+				if exported[recv] {
+					p.Receivers = append(p.Receivers, recv)
+				}
+			}
+		}
+		replay.Service.Pipelines[id] = &p
+	}
+	return &replay, nil
+}
+
+func containsComponentID(ids []component.ID, id component.ID) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// staticConfigProvider provides a config which never changes.
+type staticConfigProvider struct {
+	cfg *Config
+}
+
+func (p staticConfigProvider) Get(context.Context, Factories) (*Config, error) {
+	return p.cfg, nil
+}
+
+func (p staticConfigProvider) Watch() <-chan error {
+	return nil
+}
+
+func (p staticConfigProvider) Shutdown(context.Context) error {
+	return nil
+}
+
+// replayResult is the outcome of the replay, set by the replay receiver when it is done.
+type replayResult struct {
+	mu       sync.Mutex
+	done     bool
+	replayed int
+	err      error
+}
+
+func (r *replayResult) set(replayed int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.done {
+		r.done, r.replayed, r.err = true, replayed, err
+	}
+}
+
+func (r *replayResult) get() (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.replayed, r.err
+}
+
+type replayReceiverConfig struct{}
+
+// newReplayReceiverFactory returns the factory of the receiver replaying the recording file at path,
+// done is called with the number of records replayed once the replay is finished or stopped.
+func newReplayReceiverFactory(path string, speed float64, done func(int, error)) receiver.Factory {
+	newReceiver := func(consumers recording.Consumers) *replayReceiver {
+		return &replayReceiver{path: path, speed: speed, consumers: consumers, done: done}
+	}
+	return receiver.NewFactory(
+		replayReceiverType,
+		func() component.Config { return &replayReceiverConfig{} },
+		receiver.WithTraces(func(_ context.Context, _ receiver.CreateSettings, _ component.Config, next consumer.Traces) (receiver.Traces, error) {
+			return newReceiver(recording.Consumers{Traces: next}), nil
+		}, component.StabilityLevelDevelopment),
+		receiver.WithMetrics(func(_ context.Context, _ receiver.CreateSettings, _ component.Config, next consumer.Metrics) (receiver.Metrics, error) {
+			return newReceiver(recording.Consumers{Metrics: next}), nil
+		}, component.StabilityLevelDevelopment),
+		receiver.WithLogs(func(_ context.Context, _ receiver.CreateSettings, _ component.Config, next consumer.Logs) (receiver.Logs, error) {
+			return newReceiver(recording.Consumers{Logs: next}), nil
+		}, component.StabilityLevelDevelopment),
+		receiver.WithProfiles(func(_ context.Context, _ receiver.CreateSettings, _ component.Config, next consumer.Profiles) (receiver.Profiles, error) {
+			return newReceiver(recording.Consumers{Profiles: next}), nil
+		}, component.StabilityLevelDevelopment),
+	)
+}
+
+// replayReceiver sends the records of a recording file to the pipeline, in the background once started.
+type replayReceiver struct {
+	path      string
+	speed     float64
+	consumers recording.Consumers
+	done      func(int, error)
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func (r *replayReceiver) Start(_ context.Context, _ component.Host) error {
+	f, err := os.Open(filepath.Clean(r.path))
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		replayed, replayErr := recording.Replay(ctx, otlpfile.NewReader(f), r.speed, r.consumers)
+		r.done(replayed, multierr.Append(replayErr, f.Close()))
+	}()
+	return nil
+}
+
+func (r *replayReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
@@ -0,0 +1,306 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/otlpfile"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/extensions"
+)
+
+// newReplayDeadLetterCommand constructs a new replay-dead-letter command using the given CollectorSettings.
+func newReplayDeadLetterCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var directory, exporterID string
+	replayCmd := &cobra.Command{
+		Use:   "replay-dead-letter",
+		Short: "Sends the batches kept in a dead letter directory to an exporter of the config",
+		Args:  cobra.ExactArgs(0),
+		// The replay errors are not usage errors.
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if directory == "" || exporterID == "" {
+				return errors.New("the directory and exporter flags must be provided")
+			}
+			id := component.ID{}
+			if err := id.UnmarshalText([]byte(exporterID)); err != nil {
+				return err
+			}
+
+			if set.ConfigProvider == nil {
+				var err error
+
+				configFlags := getConfigFlag(flagSet)
+				if len(configFlags) == 0 {
+					return errors.New("at least one config flag must be provided")
+				}
+
+				set.ConfigProvider, err = NewConfigProvider(configProviderSettings(flagSet))
+				if err != nil {
+					return err
+				}
+			}
+			cfg, err := set.ConfigProvider.Get(cmd.Context(), set.Factories)
+			if err != nil {
+				return fmt.Errorf("failed to get config: %w", err)
+			}
+
+			logger, err := zap.NewProduction(set.LoggingOptions...)
+			if err != nil {
+				return err
+			}
+			r, err := newDeadLetterReplayer(set, cfg, id, logger)
+			if err != nil {
+				return err
+			}
+			replayed, expired, total, err := r.replay(cmd.Context(), directory)
+			fmt.Fprintf(cmd.OutOrStdout(), "Replayed %d of %d dead letter files\n", replayed, total)
+			if expired != 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "Removed %d expired dead letter files\n", expired)
+			}
+			return err
+		},
+	}
+	replayCmd.Flags().StringVar(&directory, "directory", "", "Directory containing the dead letter files.")
+	replayCmd.Flags().StringVar(&exporterID, "exporter", "", "ID of the exporter of the config which the batches are sent to.")
+	replayCmd.Flags().AddGoFlagSet(flagSet)
+	return replayCmd
+}
+
+// deadLetterReplayer sends the dead letter files to an exporter, created for each signal on demand.
+// The extensions of the service are started before the first exporter.
+type deadLetterReplayer struct {
+	id         component.ID
+	cfg        component.Config
+	factory    exporter.Factory
+	set        exporter.CreateSettings
+	host       *replayHost
+	extensions *extensions.Extensions
+	exporters  map[component.DataType]component.Component
+}
+
+func newDeadLetterReplayer(set CollectorSettings, cfg *Config, id component.ID, logger *zap.Logger) (*deadLetterReplayer, error) {
+	expCfg, found := cfg.Exporters[id]
+	if !found {
+		return nil, fmt.Errorf("exporter %q is not configured", id)
+	}
+	factory, found := set.Factories.Exporters[id.Type()]
+	if !found {
+		return nil, fmt.Errorf("exporter factory not available for: %q", id.Type())
+	}
+	telemetry := component.TelemetrySettings{
+		Logger:         logger,
+		TracerProvider: trace.NewNoopTracerProvider(),
+		MeterProvider:  noop.NewMeterProvider(),
+		MetricsLevel:   configtelemetry.LevelNone,
+		Resource:       pcommon.NewResource(),
+	}
+	exts, err := extensions.New(context.Background(), extensions.Settings{
+		Telemetry:    telemetry,
+		BuildInfo:    set.BuildInfo,
+		Extensions:   extension.NewBuilder(cfg.Extensions, set.Factories.Extensions),
+		Dependencies: cfg.Service.ExtensionDependencies,
+	}, cfg.Service.Extensions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build extensions: %w", err)
+	}
+	expSet := exporter.CreateSettings{ID: id, TelemetrySettings: telemetry, BuildInfo: set.BuildInfo}
+	expSet.TelemetrySettings.Logger = logger.With(zap.String("kind", "exporter"), zap.String("name", id.String()))
+	r := &deadLetterReplayer{
+		id:         id,
+		cfg:        expCfg,
+		factory:    factory,
+		set:        expSet,
+		extensions: exts,
+		exporters:  map[component.DataType]component.Component{},
+	}
+	r.host = &replayHost{factories: set.Factories, logger: logger, replayer: r}
+	return r, nil
+}
+
+// replay sends the dead letter files of directory, from the oldest to the newest, and removes the files
+// sent by the exporter and the files exceeding the max age of its dead letter settings. It returns the number
+// of files replayed, the number of expired files and the total number of files.
+func (r *deadLetterReplayer) replay(ctx context.Context, directory string) (int, int, int, error) {
+	files, err := exporterhelper.ListDeadLetterFiles(directory)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if err = r.extensions.Start(ctx, r.host); err != nil {
+		return 0, 0, len(files), multierr.Append(err, r.extensions.Shutdown(ctx))
+	}
+
+	replayed, expired := 0, 0
+	var errs error
+	for _, file := range files {
+		err = r.replayFile(ctx, file)
+		if err != nil && !errors.Is(err, exporterhelper.ErrDeadLetterExpired) {
+			errs = multierr.Append(errs, fmt.Errorf("failed to replay %q: %w", file.Path, err))
+			continue
+		}
+		if rmErr := os.Remove(file.Path); rmErr != nil {
+			errs = multierr.Append(errs, rmErr)
+			continue
+		}
+		if err != nil {
+			expired++
+			continue
+		}
+		replayed++
+	}
+
+	for _, exp := range r.exporters {
+		errs = multierr.Append(errs, exp.Shutdown(ctx))
+	}
+	errs = multierr.Append(errs, r.extensions.Shutdown(ctx))
+	return replayed, expired, len(files), errs
+}
+
+func (r *deadLetterReplayer) replayFile(ctx context.Context, file exporterhelper.DeadLetterFile) error {
+	data, err := readDeadLetterFile(file)
+	if err != nil {
+		return err
+	}
+	exp, err := r.exporter(ctx, file.Signal)
+	if err != nil {
+		return err
+	}
+	// The exporters created by exporterhelper send the batch before returning, without queuing it.
+	ctx = exporterhelper.NewDeadLetterReplayContext(ctx, file.Time)
+
+	switch file.Signal {
+	case component.DataTypeTraces:
+		td, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(data)
+		if err != nil {
+			return err
+		}
+		return exp.(exporter.Traces).ConsumeTraces(ctx, td)
+	case component.DataTypeMetrics:
+		md, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(data)
+		if err != nil {
+			return err
+		}
+		return exp.(exporter.Metrics).ConsumeMetrics(ctx, md)
+	case component.DataTypeLogs:
+		ld, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(data)
+		if err != nil {
+			return err
+		}
+		return exp.(exporter.Logs).ConsumeLogs(ctx, ld)
+	case component.DataTypeProfiles:
+		pd, err := (&pprofile.ProtoUnmarshaler{}).UnmarshalProfiles(data)
+		if err != nil {
+			return err
+		}
+		return exp.(exporter.Profiles).ConsumeProfiles(ctx, pd)
+	}
+	return fmt.Errorf("unsupported signal %q", file.Signal)
+}
+
+// exporter returns the started exporter for the signal, creating it on the first call.
+func (r *deadLetterReplayer) exporter(ctx context.Context, signal component.DataType) (component.Component, error) {
+	if exp, found := r.exporters[signal]; found {
+		return exp, nil
+	}
+
+	var exp component.Component
+	var err error
+	switch signal {
+	case component.DataTypeTraces:
+		exp, err = r.factory.CreateTracesExporter(ctx, r.set, r.cfg)
+	case component.DataTypeMetrics:
+		exp, err = r.factory.CreateMetricsExporter(ctx, r.set, r.cfg)
+	case component.DataTypeLogs:
+		exp, err = r.factory.CreateLogsExporter(ctx, r.set, r.cfg)
+	case component.DataTypeProfiles:
+		exp, err = r.factory.CreateProfilesExporter(ctx, r.set, r.cfg)
+	default:
+		err = fmt.Errorf("unsupported signal %q", signal)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %q exporter for %s: %w", r.id, signal, err)
+	}
+	if err = exp.Start(ctx, r.host); err != nil {
+		return nil, fmt.Errorf("failed to start %q exporter for %s: %w", r.id, signal, err)
+	}
+	r.exporters[signal] = exp
+	return exp, nil
+}
+
+// replayHost is the host of the extensions and of the exporters replaying dead letter files,
+// which run without the other exporters of the config.
+type replayHost struct {
+	factories Factories
+	logger    *zap.Logger
+	replayer  *deadLetterReplayer
+}
+
+func (h *replayHost) ReportFatalError(err error) {
+	h.logger.Error("Exporter reported a fatal error", zap.Error(err))
+}
+
+func (h *replayHost) GetFactory(kind component.Kind, componentType component.Type) component.Factory {
+	switch kind {
+	case component.KindReceiver:
+		return h.factories.Receivers[componentType]
+	case component.KindProcessor:
+		return h.factories.Processors[componentType]
+	case component.KindExporter:
+		return h.factories.Exporters[componentType]
+	case component.KindExtension:
+		return h.factories.Extensions[componentType]
+	case component.KindConnector:
+		return h.factories.Connectors[componentType]
+	}
+	return nil
+}
+
+func (h *replayHost) GetExtensions() map[component.ID]component.Component {
+	return h.replayer.extensions.GetExtensions()
+}
+
+func (h *replayHost) GetExporters() map[component.DataType]map[component.ID]component.Component {
+	exporters := map[component.DataType]map[component.ID]component.Component{}
+	for signal, exp := range h.replayer.exporters {
+		exporters[signal] = map[component.ID]component.Component{h.replayer.id: exp}
+	}
+	return exporters
+}
+
+// readDeadLetterFile returns the protobuf export request of the record of a dead letter file.
+func readDeadLetterFile(file exporterhelper.DeadLetterFile) ([]byte, error) {
+	f, err := os.Open(file.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	rec, err := otlpfile.NewReader(f).Next()
+	if err != nil {
+		return nil, err
+	}
+	if rec.Signal != file.Signal || rec.Encoding != otlpfile.EncodingProto || rec.Compression != otlpfile.CompressionNone {
+		return nil, fmt.Errorf("unexpected %s record of encoding %d and compression %d", rec.Signal, rec.Encoding, rec.Compression)
+	}
+	return rec.Data, nil
+}
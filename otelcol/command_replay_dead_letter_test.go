@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/converter/expandconverter"
+	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/otlpfile"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newNopConfigProvider(t *testing.T) ConfigProvider {
+	cfgProvider, err := NewConfigProvider(
+		ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				URIs:       []string{filepath.Join("testdata", "otelcol-nop.yaml")},
+				Providers:  map[string]confmap.Provider{"file": fileprovider.New()},
+				Converters: []confmap.Converter{expandconverter.New()},
+			},
+		})
+	require.NoError(t, err)
+	return cfgProvider
+}
+
+func TestReplayDeadLetterCommandNoFlags(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cmd := newReplayDeadLetterCommand(CollectorSettings{Factories: factories, ConfigProvider: newNopConfigProvider(t)}, flags(featuregate.GlobalRegistry()))
+	cmd.SetArgs([]string{})
+	err = cmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the directory and exporter flags must be provided")
+}
+
+func TestReplayDeadLetterCommandUnknownExporter(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cmd := newReplayDeadLetterCommand(CollectorSettings{Factories: factories, ConfigProvider: newNopConfigProvider(t)}, flags(featuregate.GlobalRegistry()))
+	cmd.SetArgs([]string{"--directory", t.TempDir(), "--exporter", "nop/unknown"})
+	err = cmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "exporter \"nop/unknown\" is not configured")
+}
+
+func writeDeadLetterFile(t *testing.T, path string, td ptrace.Traces) {
+	data, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, otlpfile.NewWriter(&buf).Write(otlpfile.Record{
+		Signal:   component.DataTypeTraces,
+		Time:     time.Unix(0, 1),
+		Encoding: otlpfile.EncodingProto,
+		Data:     data,
+	}))
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+}
+
+func TestReplayDeadLetterCommand(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	replayedFile := filepath.Join(dir, "otlp_traces_1_1.binpb")
+	writeDeadLetterFile(t, replayedFile, td)
+	corruptedFile := filepath.Join(dir, "otlp_traces_2_1.binpb")
+	require.NoError(t, os.WriteFile(corruptedFile, []byte{0xff}, 0600))
+	otherFile := filepath.Join(dir, "README.md")
+	require.NoError(t, os.WriteFile(otherFile, []byte("other"), 0600))
+
+	cmd := newReplayDeadLetterCommand(CollectorSettings{Factories: factories, ConfigProvider: newNopConfigProvider(t)}, flags(featuregate.GlobalRegistry()))
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"--directory", dir, "--exporter", "nop"})
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), corruptedFile)
+	assert.Equal(t, "Replayed 1 of 2 dead letter files\n", out.String())
+
+	assert.NoFileExists(t, replayedFile)
+	assert.FileExists(t, corruptedFile)
+	assert.FileExists(t, otherFile)
+}
+
+func TestReplayDeadLetterHost(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	cfg, err := newNopConfigProvider(t).Get(context.Background(), factories)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	writeDeadLetterFile(t, filepath.Join(dir, "otlp_traces_1_1.binpb"), ptrace.NewTraces())
+
+	r, err := newDeadLetterReplayer(CollectorSettings{Factories: factories}, cfg, component.NewID("nop"), zap.NewNop())
+	require.NoError(t, err)
+	replayed, expired, total, err := r.replay(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, replayed)
+	assert.Equal(t, 0, expired)
+	assert.Equal(t, 1, total)
+
+	// The exporters are given the extensions of the service and the replaying exporters.
+	assert.Contains(t, r.host.GetExtensions(), component.NewID("nop"))
+	assert.Contains(t, r.host.GetExporters()[component.DataTypeTraces], component.NewID("nop"))
+}
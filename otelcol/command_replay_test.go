@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/otlpfile"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/recording"
+)
+
+func TestReplayCommandNoFlags(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cmd := newReplayCommand(CollectorSettings{Factories: factories, ConfigProvider: newNopConfigProvider(t)}, flags(featuregate.GlobalRegistry()))
+	cmd.SetArgs([]string{})
+	err = cmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the file and pipeline flags must be provided")
+}
+
+func TestReplayCommandUnknownPipeline(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "empty"+recording.FileExtension)
+	require.NoError(t, os.WriteFile(file, nil, 0600))
+	cmd := newReplayCommand(CollectorSettings{Factories: factories, ConfigProvider: newNopConfigProvider(t)}, flags(featuregate.GlobalRegistry()))
+	cmd.SetArgs([]string{"--file", file, "--pipeline", "traces/unknown"})
+	err = cmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "pipeline \"traces/unknown\" is not configured")
+}
+
+func TestReplayConfig(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	cfg, err := newNopConfigProvider(t).Get(context.Background(), factories)
+	require.NoError(t, err)
+	cfg.Service.Recording = &recording.Config{Receivers: []component.ID{component.NewID("nop")}, Directory: "recordings"}
+
+	replay, err := replayConfig(cfg, component.NewID("traces"))
+	require.NoError(t, err)
+	replayID := component.NewID(replayReceiverType)
+	assert.Equal(t, map[component.ID]component.Config{replayID: &replayReceiverConfig{}}, replay.Receivers)
+	assert.Nil(t, replay.Service.Recording)
+	require.Len(t, replay.Service.Pipelines, 2)
+	assert.Equal(t, []component.ID{replayID}, replay.Service.Pipelines[component.NewID("traces")].Receivers)
+	// The logs pipeline is fed by the traces pipeline through the connector.
+	assert.Equal(t, []component.ID{component.NewIDWithName("nop", "con")}, replay.Service.Pipelines[component.NewID("logs")].Receivers)
+	// The original config is not modified.
+	assert.Equal(t, []component.ID{component.NewID("nop"), component.NewIDWithName("nop", "con")}, cfg.Service.Pipelines[component.NewID("logs")].Receivers)
+	assert.NotNil(t, cfg.Service.Recording)
+	require.NoError(t, replay.Validate())
+
+	replay, err = replayConfig(cfg, component.NewID("metrics"))
+	require.NoError(t, err)
+	require.Len(t, replay.Service.Pipelines, 1)
+	require.NoError(t, replay.Validate())
+}
+
+func TestReplayCommand(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "nop"+recording.FileExtension)
+	buf := new(bytes.Buffer)
+	w := otlpfile.NewWriter(buf)
+	start := time.Now()
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	data, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, w.Write(otlpfile.Record{Signal: component.DataTypeTraces, Time: start.Add(time.Duration(i) * time.Millisecond), Encoding: otlpfile.EncodingProto, Data: data}))
+	}
+	// The records of other signals are skipped.
+	require.NoError(t, w.Write(otlpfile.Record{Signal: component.DataTypeMetrics, Time: start.Add(3 * time.Millisecond), Encoding: otlpfile.EncodingProto, Data: []byte{}}))
+	require.NoError(t, os.WriteFile(file, buf.Bytes(), 0600))
+
+	cmd := newReplayCommand(CollectorSettings{
+		BuildInfo:             component.NewDefaultBuildInfo(),
+		Factories:             factories,
+		ConfigProvider:        newNopConfigProvider(t),
+		SkipSettingGRPCLogger: true,
+	}, flags(featuregate.GlobalRegistry()))
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"--file", file, "--pipeline", "traces", "--speed", "0"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "Replayed 3 records of \""+file+"\"\n", out.String())
+}
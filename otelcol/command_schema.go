@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// newSchemaCommand constructs a new schema command using the given CollectorSettings.
+func newSchemaCommand(set CollectorSettings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Outputs the JSON Schema of the configuration of the components in this collector distribution",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schema, err := ConfigSchema(set.Factories)
+			if err != nil {
+				return err
+			}
+			schema["title"] = fmt.Sprintf("Configuration of %s %s", set.BuildInfo.Command, set.BuildInfo.Version)
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(schema)
+		},
+	}
+}
@@ -4,19 +4,64 @@
 package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"flag"
+	"fmt"
+	"io"
+	"sort"
 
 	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/processor"
+)
+
+const (
+	validateFormatText = "text"
+	validateFormatJSON = "json"
 )
 
+var errInvalidConfig = errors.New("invalid configuration")
+
+// validationError is an error found by the validate sub command.
+type validationError struct {
+	// Path is the path of the invalid configuration, e.g. "receivers::otlp", if known.
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e validationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// validationResult is the output of the validate sub command in the JSON format.
+type validationResult struct {
+	Valid  bool              `json:"valid"`
+	Errors []validationError `json:"errors"`
+}
+
 // newValidateSubCommand constructs a new validate sub command using the given CollectorSettings.
 func newValidateSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var format string
 	validateCmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validates the config without running the collector",
-		Args:  cobra.ExactArgs(0),
+		Long: `Validates the config without running the collector: the configuration of every component is
+validated, even when it is not used by a pipeline, and so is the support of the data type of the
+pipelines by their components. All the errors found are reported.`,
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != validateFormatText && format != validateFormatJSON {
+				return fmt.Errorf("unsupported format %q", format)
+			}
 			if set.ConfigProvider == nil {
 				var err error
 
@@ -25,18 +70,199 @@ func newValidateSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.
 					return errors.New("at least one config flag must be provided")
 				}
 
-				set.ConfigProvider, err = NewConfigProvider(newDefaultConfigProviderSettings(configFlags))
+				set.ConfigProvider, err = NewConfigProvider(configProviderSettings(flagSet))
 				if err != nil {
 					return err
 				}
 			}
-			col, err := NewCollector(set)
-			if err != nil {
-				return err
+			errs := validateConfig(cmd.Context(), set)
+			if format == validateFormatJSON {
+				if err := writeValidationResult(cmd.OutOrStdout(), errs); err != nil {
+					return err
+				}
+				if len(errs) > 0 {
+					return errInvalidConfig
+				}
+				return nil
+			}
+			var err error
+			for _, e := range errs {
+				err = multierr.Append(err, e)
 			}
-			return col.DryRun(cmd.Context())
+			return err
 		},
 	}
+	validateCmd.Flags().StringVar(&format, "format", validateFormatText,
+		fmt.Sprintf("Format of the errors, %q or %q.", validateFormatText, validateFormatJSON))
 	validateCmd.Flags().AddGoFlagSet(flagSet)
 	return validateCmd
 }
+
+func writeValidationResult(w io.Writer, errs []validationError) error {
+	result := validationResult{Valid: len(errs) == 0, Errors: errs}
+	if result.Errors == nil {
+		result.Errors = []validationError{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
+
+// validateConfig unmarshals and validates the configuration, and returns all the errors found.
+func validateConfig(ctx context.Context, set CollectorSettings) []validationError {
+	cfg, err := set.ConfigProvider.Get(ctx, set.Factories)
+	if err != nil {
+		return []validationError{{Message: fmt.Sprintf("failed to get config: %v", err)}}
+	}
+
+	// The components are validated first to report all their errors, Config.Validate stops at the first one.
+	errs := validateComponentConfigs(cfg)
+	if len(errs) > 0 {
+		return errs
+	}
+	if err = cfg.Validate(); err != nil {
+		return []validationError{{Message: err.Error()}}
+	}
+	return validatePipelineDataTypes(cfg, set.Factories)
+}
+
+func validateComponentConfigs(cfg *Config) []validationError {
+	var errs []validationError
+	validate := func(kind string, cfgs map[component.ID]component.Config) {
+		for _, id := range sortedIDs(cfgs) {
+			if err := component.ValidateConfig(cfgs[id]); err != nil {
+				errs = append(errs, validationError{Path: kind + "::" + id.String(), Message: err.Error()})
+			}
+		}
+	}
+	validate("receivers", cfg.Receivers)
+	validate("processors", cfg.Processors)
+	validate("exporters", cfg.Exporters)
+	validate("connectors", cfg.Connectors)
+	validate("extensions", cfg.Extensions)
+	return errs
+}
+
+// validatePipelineDataTypes checks that the components of the pipelines support the data type of the pipeline,
+// and that the connectors support all the pairs of pipelines they connect.
+func validatePipelineDataTypes(cfg *Config, factories Factories) []validationError {
+	var errs []validationError
+	connectorsAsExporter := make(map[component.ID][]component.ID)
+	connectorsAsReceiver := make(map[component.ID][]component.ID)
+
+	pipelineIDs := make([]component.ID, 0, len(cfg.Service.Pipelines))
+	for id := range cfg.Service.Pipelines {
+		pipelineIDs = append(pipelineIDs, id)
+	}
+	sortIDs(pipelineIDs)
+
+	for _, pipelineID := range pipelineIDs {
+		pipeline := cfg.Service.Pipelines[pipelineID]
+		path := "service::pipelines::" + pipelineID.String()
+		dataType := pipelineID.Type()
+		for _, id := range pipeline.Receivers {
+			if _, ok := cfg.Connectors[id]; ok {
+				connectorsAsReceiver[id] = append(connectorsAsReceiver[id], pipelineID)
+				continue
+			}
+			if f, ok := factories.Receivers[id.Type()]; ok && receiverStability(f, dataType) == component.StabilityLevelUndefined {
+				errs = append(errs, validationError{Path: path, Message: fmt.Sprintf("receiver %q does not support %s", id, dataType)})
+			}
+		}
+		for _, id := range pipeline.Processors {
+			if f, ok := factories.Processors[id.Type()]; ok && processorStability(f, dataType) == component.StabilityLevelUndefined {
+				errs = append(errs, validationError{Path: path, Message: fmt.Sprintf("processor %q does not support %s", id, dataType)})
+			}
+		}
+		for _, id := range pipeline.Exporters {
+			if _, ok := cfg.Connectors[id]; ok {
+				connectorsAsExporter[id] = append(connectorsAsExporter[id], pipelineID)
+				continue
+			}
+			if f, ok := factories.Exporters[id.Type()]; ok && exporterStability(f, dataType) == component.StabilityLevelUndefined {
+				errs = append(errs, validationError{Path: path, Message: fmt.Sprintf("exporter %q does not support %s", id, dataType)})
+			}
+		}
+	}
+
+	for _, connID := range sortedIDs(cfg.Connectors) {
+		f, ok := factories.Connectors[connID.Type()]
+		if !ok {
+			continue
+		}
+		for _, expPipelineID := range connectorsAsExporter[connID] {
+			for _, rcvPipelineID := range connectorsAsReceiver[connID] {
+				if connectorStability(f, expPipelineID.Type(), rcvPipelineID.Type()) == component.StabilityLevelUndefined {
+					errs = append(errs, validationError{
+						Path: "connectors::" + connID.String(),
+						Message: fmt.Sprintf("cannot connect from %s to %s, used as exporter in pipeline %q and as receiver in pipeline %q",
+							expPipelineID.Type(), rcvPipelineID.Type(), expPipelineID, rcvPipelineID),
+					})
+				}
+			}
+		}
+	}
+	return errs
+}
+
+func processorStability(f processor.Factory, dt component.DataType) component.StabilityLevel {
+	switch dt {
+	case component.DataTypeTraces:
+		return f.TracesProcessorStability()
+	case component.DataTypeMetrics:
+		return f.MetricsProcessorStability()
+	case component.DataTypeLogs:
+		return f.LogsProcessorStability()
+	case component.DataTypeProfiles:
+		return f.ProfilesProcessorStability()
+	}
+	return component.StabilityLevelUndefined
+}
+
+func connectorStability(f connector.Factory, from, to component.DataType) component.StabilityLevel {
+	switch from {
+	case component.DataTypeTraces:
+		switch to {
+		case component.DataTypeTraces:
+			return f.TracesToTracesStability()
+		case component.DataTypeMetrics:
+			return f.TracesToMetricsStability()
+		case component.DataTypeLogs:
+			return f.TracesToLogsStability()
+		}
+	case component.DataTypeMetrics:
+		switch to {
+		case component.DataTypeTraces:
+			return f.MetricsToTracesStability()
+		case component.DataTypeMetrics:
+			return f.MetricsToMetricsStability()
+		case component.DataTypeLogs:
+			return f.MetricsToLogsStability()
+		}
+	case component.DataTypeLogs:
+		switch to {
+		case component.DataTypeTraces:
+			return f.LogsToTracesStability()
+		case component.DataTypeMetrics:
+			return f.LogsToMetricsStability()
+		case component.DataTypeLogs:
+			return f.LogsToLogsStability()
+		}
+	}
+	return component.StabilityLevelUndefined
+}
+
+func sortedIDs(cfgs map[component.ID]component.Config) []component.ID {
+	ids := make([]component.ID, 0, len(cfgs))
+	for id := range cfgs {
+		ids = append(ids, id)
+	}
+	sortIDs(ids)
+	return ids
+}
+
+func sortIDs(ids []component.ID) {
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+}
@@ -4,15 +4,24 @@
 package otelcol
 
 import (
+	"bytes"
+	"encoding/json"
 	"path/filepath"
 	"testing"
 
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/converter/expandconverter"
 	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/pipelines"
 )
 
 func TestValidateSubCommandNoConfig(t *testing.T) {
@@ -44,3 +53,87 @@ func TestValidateSubCommandInvalidComponents(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "unknown type: \"nosuchprocessor\"")
 }
+
+func TestValidateSubCommandJSON(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	newCmd := func(file string) *cobra.Command {
+		cfgProvider, err := NewConfigProvider(
+			ConfigProviderSettings{
+				ResolverSettings: confmap.ResolverSettings{
+					URIs:       []string{filepath.Join("testdata", file)},
+					Providers:  map[string]confmap.Provider{"file": fileprovider.New()},
+					Converters: []confmap.Converter{expandconverter.New()},
+				},
+			})
+		require.NoError(t, err)
+		return newValidateSubCommand(CollectorSettings{Factories: factories, ConfigProvider: cfgProvider}, flags(featuregate.GlobalRegistry()))
+	}
+
+	cmd := newCmd("otelcol-nop.yaml")
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"--format", "json"})
+	require.NoError(t, cmd.Execute())
+	assert.JSONEq(t, `{"valid": true, "errors": []}`, out.String())
+
+	cmd = newCmd("otelcol-invalid.yaml")
+	out.Reset()
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"--format", "json"})
+	assert.ErrorIs(t, cmd.Execute(), errInvalidConfig)
+	var result validationResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.False(t, result.Valid)
+	assert.Equal(t, []validationError{{Message: `service::pipelines::traces: references processor "invalid" which is not configured`}}, result.Errors)
+
+	cmd = newCmd("otelcol-nop.yaml")
+	cmd.SetArgs([]string{"--format", "xml"})
+	assert.EqualError(t, cmd.Execute(), `unsupported format "xml"`)
+}
+
+func TestValidateComponentConfigs(t *testing.T) {
+	cfg := generateConfig()
+	assert.Empty(t, validateComponentConfigs(cfg))
+
+	cfg.Receivers[component.NewID("nop")] = &errConfig{validateErr: errInvalidRecvConfig}
+	cfg.Exporters[component.NewIDWithName("nop", "unused")] = &errConfig{validateErr: errInvalidExpConfig}
+	cfg.Extensions[component.NewID("nop")] = &errConfig{validateErr: errInvalidExtConfig}
+	assert.Equal(t, []validationError{
+		{Path: "receivers::nop", Message: errInvalidRecvConfig.Error()},
+		{Path: "exporters::nop/unused", Message: errInvalidExpConfig.Error()},
+		{Path: "extensions::nop", Message: errInvalidExtConfig.Error()},
+	}, validateComponentConfigs(cfg))
+}
+
+func TestValidatePipelineDataTypes(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfg := generateConfig()
+	cfg.Service.Pipelines[component.NewID("metrics")] = &pipelines.PipelineConfig{
+		Receivers: []component.ID{component.NewIDWithName("nop", "conn")},
+		Exporters: []component.ID{component.NewID("nop")},
+	}
+	cfg.Service.Pipelines[component.NewID("traces")].Exporters = append(
+		cfg.Service.Pipelines[component.NewID("traces")].Exporters, component.NewIDWithName("nop", "conn"))
+	require.NoError(t, cfg.Validate())
+	assert.Empty(t, validatePipelineDataTypes(cfg, factories))
+
+	createDefaultConfig := func() component.Config { return &errConfig{} }
+	factories.Receivers["nop"] = receiver.NewFactory("nop", createDefaultConfig,
+		receiver.WithMetrics(nil, component.StabilityLevelDevelopment))
+	factories.Exporters["nop"] = exporter.NewFactory("nop", createDefaultConfig,
+		exporter.WithTraces(nil, component.StabilityLevelDevelopment))
+	factories.Connectors["nop"] = connector.NewFactory("nop", createDefaultConfig,
+		connector.WithTracesToTraces(nil, component.StabilityLevelDevelopment))
+	assert.Equal(t, []validationError{
+		{Path: "service::pipelines::metrics", Message: `exporter "nop" does not support metrics`},
+		{Path: "service::pipelines::traces", Message: `receiver "nop" does not support traces`},
+		{
+			Path:    "connectors::nop/conn",
+			Message: `cannot connect from traces to metrics, used as exporter in pipeline "traces" and as receiver in pipeline "metrics"`,
+		},
+	}, validatePipelineDataTypes(cfg, factories))
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// ConfProvider is a ConfigProvider providing the configuration of an in-memory confmap.Conf,
+// for the programs embedding a collector. The configuration can be replaced while the collector
+// is running, Collector.Reload then applies it.
+type ConfProvider struct {
+	mu           sync.Mutex
+	conf         *confmap.Conf
+	remoteConfig *confmap.Conf
+	watcher      chan error
+	closed       bool
+}
+
+var _ ConfigProvider = (*ConfProvider)(nil)
+
+// NewConfProvider returns a ConfProvider providing the configuration of conf. The references
+// to the environment variables and the other providers of conf are not expanded.
+func NewConfProvider(conf *confmap.Conf) *ConfProvider {
+	return &ConfProvider{conf: conf, watcher: make(chan error)}
+}
+
+// Set replaces the configuration, which is applied by the next reload of the collector.
+func (p *ConfProvider) Set(conf *confmap.Conf) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.conf = conf
+}
+
+// Get returns the service configuration of the configuration map.
+func (p *ConfProvider) Get(_ context.Context, factories Factories) (*Config, error) {
+	p.mu.Lock()
+	// The configuration map is copied, since merging the remote configuration modifies it.
+	conf := confmap.New()
+	err := conf.Merge(p.conf)
+	if err == nil && p.remoteConfig != nil {
+		err = conf.Merge(p.remoteConfig)
+	}
+	p.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("cannot merge the configuration: %w", err)
+	}
+	return newConfig(conf, factories)
+}
+
+// Watch returns a channel which is only closed by Shutdown, the configuration is reloaded by
+// Collector.Reload instead.
+func (p *ConfProvider) Watch() <-chan error {
+	return p.watcher
+}
+
+// Shutdown closes the Watch channel.
+func (p *ConfProvider) Shutdown(context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.closed {
+		p.closed = true
+		close(p.watcher)
+	}
+	return nil
+}
+
+func (p *ConfProvider) getRemoteConfig() *confmap.Conf {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.remoteConfig
+}
+
+func (p *ConfProvider) setRemoteConfig(conf *confmap.Conf) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.remoteConfig = conf
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// nopConf returns the configuration of a traces pipeline made of nop components, with the given processors.
+func nopConf(processors ...string) *confmap.Conf {
+	return confmap.NewFromStringMap(map[string]any{
+		"receivers":  map[string]any{"nop": nil},
+		"processors": map[string]any{"nop": nil},
+		"exporters":  map[string]any{"nop": nil},
+		"service": map[string]any{
+			"telemetry": map[string]any{"metrics": map[string]any{"level": "none"}},
+			"pipelines": map[string]any{
+				"traces": map[string]any{
+					"receivers":  []any{"nop"},
+					"processors": processors,
+					"exporters":  []any{"nop"},
+				},
+			},
+		},
+	})
+}
+
+func TestConfProvider(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	provider := NewConfProvider(nopConf())
+	cfg, err := provider.Get(context.Background(), factories)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Service.Pipelines[component.NewID("traces")].Processors)
+
+	provider.Set(nopConf("nop"))
+	cfg, err = provider.Get(context.Background(), factories)
+	require.NoError(t, err)
+	assert.Equal(t, []component.ID{component.NewID("nop")}, cfg.Service.Pipelines[component.NewID("traces")].Processors)
+
+	require.NoError(t, provider.Shutdown(context.Background()))
+	require.NoError(t, provider.Shutdown(context.Background()))
+	provider.Set(nopConf())
+	_, ok := <-provider.Watch()
+	assert.False(t, ok)
+}
+
+func TestConfProviderInvalidConfig(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfg, err := NewConfProvider(nopConf("invalid")).Get(context.Background(), factories)
+	require.NoError(t, err)
+	assert.Error(t, cfg.Validate())
+}
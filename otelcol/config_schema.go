@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/otelcol/internal/configschema"
+	"go.opentelemetry.io/collector/service"
+)
+
+// ConfigSchema returns the JSON Schema of the configurations of a collector built with the factories,
+// generated from the types of the default configurations of the components and of the service.
+// The defaults of the properties are the values of the default configurations.
+// The keys are only validated once the configuration fragments are included.
+func ConfigSchema(factories Factories) (map[string]any, error) {
+	properties := map[string]any{
+		// The templates are instantiated by the templateconverter before the configuration is unmarshalled.
+		"templates": map[string]any{"type": "object"},
+	}
+	receivers, err := componentSchemas(factories.Receivers)
+	if err != nil {
+		return nil, err
+	}
+	processors, err := componentSchemas(factories.Processors)
+	if err != nil {
+		return nil, err
+	}
+	exporters, err := componentSchemas(factories.Exporters)
+	if err != nil {
+		return nil, err
+	}
+	connectors, err := componentSchemas(factories.Connectors)
+	if err != nil {
+		return nil, err
+	}
+	extensions, err := componentSchemas(factories.Extensions)
+	if err != nil {
+		return nil, err
+	}
+	for name, configs := range map[string]map[string]any{
+		"receivers":  receivers,
+		"processors": processors,
+		"exporters":  exporters,
+		"connectors": connectors,
+		"extensions": extensions,
+	} {
+		properties[name] = map[string]any{
+			"type":                 []string{"object", "null"},
+			"patternProperties":    configs,
+			"additionalProperties": false,
+		}
+	}
+
+	serviceSchema := configschema.FromType(reflect.TypeOf(service.Config{}))
+	conf := confmap.New()
+	if err = conf.Marshal(defaultServiceConfig()); err != nil {
+		return nil, fmt.Errorf("failed to marshal the default service configuration: %w", err)
+	}
+	configschema.SetDefaults(serviceSchema, conf.ToStringMap())
+	properties["service"] = serviceSchema
+
+	return map[string]any{
+		"$schema":    configschema.Version,
+		"type":       "object",
+		"properties": properties,
+		// The top-level keys starting with "x-" hold the YAML anchors, they are removed once the configurations are merged.
+		"patternProperties":    map[string]any{"^x-": map[string]any{}},
+		"additionalProperties": false,
+	}, nil
+}
+
+// componentSchemas returns the schemas of the configurations of the components created by the factories,
+// indexed by the pattern of the IDs of their type.
+func componentSchemas[F component.Factory](factories map[component.Type]F) (map[string]any, error) {
+	schemas := make(map[string]any, len(factories))
+	for typ, factory := range factories {
+		cfg := factory.CreateDefaultConfig()
+		schema := configschema.FromType(reflect.TypeOf(cfg))
+		// A component is configured with its default configuration by an empty value.
+		if t, ok := schema["type"].(string); ok {
+			schema["type"] = []string{t, "null"}
+		}
+		conf := confmap.New()
+		if err := conf.Marshal(cfg); err != nil {
+			return nil, fmt.Errorf("failed to marshal the default configuration of %q: %w", typ, err)
+		}
+		configschema.SetDefaults(schema, conf.ToStringMap())
+		schemas["^"+regexp.QuoteMeta(string(typ))+"(/.+)?$"] = schema
+	}
+	return schemas, nil
+}
@@ -9,10 +9,14 @@ import (
 
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/converter/expandconverter"
+	"go.opentelemetry.io/collector/confmap/converter/templateconverter"
+	"go.opentelemetry.io/collector/confmap/provider/awssmprovider"
 	"go.opentelemetry.io/collector/confmap/provider/envprovider"
 	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
 	"go.opentelemetry.io/collector/confmap/provider/httpprovider"
 	"go.opentelemetry.io/collector/confmap/provider/httpsprovider"
+	"go.opentelemetry.io/collector/confmap/provider/k8sprovider"
+	"go.opentelemetry.io/collector/confmap/provider/vaultprovider"
 	"go.opentelemetry.io/collector/confmap/provider/yamlprovider"
 )
 
@@ -52,6 +56,16 @@ type ConfigProvider interface {
 
 type configProvider struct {
 	mapResolver *confmap.Resolver
+
+	// remoteConfig if not nil, is merged over the resolved configuration.
+	remoteConfig *confmap.Conf
+}
+
+// remoteConfigProvider is implemented by the ConfigProviders which merge the remote configuration,
+// received from the OpAMP server, over the configuration they provide.
+type remoteConfigProvider interface {
+	getRemoteConfig() *confmap.Conf
+	setRemoteConfig(conf *confmap.Conf)
 }
 
 // ConfigProviderSettings are the settings to configure the behavior of the ConfigProvider.
@@ -82,9 +96,19 @@ func (cm *configProvider) Get(ctx context.Context, factories Factories) (*Config
 	if err != nil {
 		return nil, fmt.Errorf("cannot resolve the configuration: %w", err)
 	}
+	if cm.remoteConfig != nil {
+		if err = conf.Merge(cm.remoteConfig); err != nil {
+			return nil, fmt.Errorf("cannot merge the remote configuration: %w", err)
+		}
+	}
+
+	return newConfig(conf, factories)
+}
 
-	var cfg *configSettings
-	if cfg, err = unmarshal(conf, factories); err != nil {
+// newConfig unmarshals the configuration map into the service Config.
+func newConfig(conf *confmap.Conf, factories Factories) (*Config, error) {
+	cfg, err := unmarshal(conf, factories)
+	if err != nil {
 		return nil, fmt.Errorf("cannot unmarshal the configuration: %w", err)
 	}
 
@@ -98,6 +122,14 @@ func (cm *configProvider) Get(ctx context.Context, factories Factories) (*Config
 	}, nil
 }
 
+func (cm *configProvider) getRemoteConfig() *confmap.Conf {
+	return cm.remoteConfig
+}
+
+func (cm *configProvider) setRemoteConfig(conf *confmap.Conf) {
+	cm.remoteConfig = conf
+}
+
 func (cm *configProvider) Watch() <-chan error {
 	return cm.mapResolver.Watch()
 }
@@ -110,8 +142,8 @@ func newDefaultConfigProviderSettings(uris []string) ConfigProviderSettings {
 	return ConfigProviderSettings{
 		ResolverSettings: confmap.ResolverSettings{
 			URIs:       uris,
-			Providers:  makeMapProvidersMap(fileprovider.New(), envprovider.New(), yamlprovider.New(), httpprovider.New(), httpsprovider.New()),
-			Converters: []confmap.Converter{expandconverter.New()},
+			Providers:  makeMapProvidersMap(fileprovider.New(), envprovider.New(), yamlprovider.New(), httpprovider.New(), httpsprovider.New(), k8sprovider.New(), vaultprovider.New(), awssmprovider.New()),
+			Converters: []confmap.Converter{templateconverter.New(), expandconverter.New()},
 		},
 	}
 }
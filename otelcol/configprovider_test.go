@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.opentelemetry.io/collector/service"
@@ -128,3 +129,38 @@ func TestConfigProviderFile(t *testing.T) {
 	require.NoError(t, err)
 	assert.EqualValues(t, configNop, cfg)
 }
+
+func TestConfigProviderTemplates(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	provider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-templates.yaml")}))
+	require.NoError(t, err)
+
+	cfg, err := provider.Get(context.Background(), factories)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	assert.Contains(t, cfg.Receivers, component.NewIDWithName("nop", "a"))
+	assert.Contains(t, cfg.Receivers, component.NewIDWithName("nop", "b"))
+	assert.Equal(t, []component.ID{component.NewIDWithName("nop", "b")}, cfg.Service.Pipelines[component.NewIDWithName("traces", "b")].Receivers)
+}
+
+func TestConfigProviderStrictUnmarshal(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(confmap.StrictUnmarshalFeatureGate.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(confmap.StrictUnmarshalFeatureGate.ID(), false))
+	})
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	file := filepath.Join("testdata", "otelcol-unknown-keys.yaml")
+	provider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{file}))
+	require.NoError(t, err)
+
+	_, err = provider.Get(context.Background(), factories)
+	var keyErr *confmap.KeyError
+	require.ErrorAs(t, err, &keyErr)
+	assert.Equal(t, "service::telemetry::metrics::adress", keyErr.Key)
+	require.NotNil(t, keyErr.Position)
+	assert.Equal(t, 10, keyErr.Position.Line)
+	assert.Equal(t, 7, keyErr.Position.Column)
+	assert.Contains(t, err.Error(), `unknown key, did you mean "address"?`)
+}
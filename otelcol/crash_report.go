@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"runtime/pprof"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/internal/componenterror"
+)
+
+const (
+	crashPhaseStartup = "startup"
+	crashPhaseReload  = "reload"
+	crashPhaseRuntime = "runtime"
+)
+
+// crashReport is written as JSON when the collector exits because of a fatal error.
+type crashReport struct {
+	Time       time.Time             `json:"time"`
+	Command    string                `json:"command"`
+	Version    string                `json:"version"`
+	Phase      string                `json:"phase"`
+	Error      string                `json:"error"`
+	ErrorChain []string              `json:"error_chain"`
+	Component  *crashReportComponent `json:"component,omitempty"`
+	ConfigURIs []string              `json:"config_uris,omitempty"`
+	// GoroutineDump is the path of the file holding the stacks of all the goroutines.
+	GoroutineDump string `json:"goroutine_dump,omitempty"`
+}
+
+type crashReportComponent struct {
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+}
+
+// writeCrashReport writes the crash report for err to the configured path,
+// and the goroutine dump next to it. It does nothing if no path is configured.
+func (col *Collector) writeCrashReport(phase string, err error) error {
+	if col.set.CrashReportPath == "" || err == nil {
+		return nil
+	}
+
+	report := crashReport{
+		Time:       time.Now(),
+		Command:    col.set.BuildInfo.Command,
+		Version:    col.set.BuildInfo.Version,
+		Phase:      phase,
+		Error:      err.Error(),
+		ErrorChain: errorChain(err),
+		ConfigURIs: col.set.configURIs,
+	}
+	var compErr *componenterror.Error
+	if errors.As(err, &compErr) {
+		report.Component = &crashReportComponent{
+			Kind: componenterror.KindString(compErr.Kind),
+			ID:   compErr.ID.String(),
+		}
+	}
+
+	dumpPath := col.set.CrashReportPath + ".goroutines"
+	dumpErr := writeGoroutineDump(dumpPath)
+	if dumpErr == nil {
+		report.GoroutineDump = dumpPath
+	}
+
+	data, jsonErr := json.MarshalIndent(report, "", "  ")
+	if jsonErr != nil {
+		return multierr.Append(dumpErr, jsonErr)
+	}
+	return multierr.Append(dumpErr, os.WriteFile(col.set.CrashReportPath, data, 0600))
+}
+
+// reportCrash writes the crash report for err, logging the failure to write it
+// since the collector is exiting anyway.
+func (col *Collector) reportCrash(phase string, err error) {
+	if reportErr := col.writeCrashReport(phase, err); reportErr != nil {
+		col.service.Logger().Error("Failed to write the crash report", zap.String("path", col.set.CrashReportPath), zap.Error(reportErr))
+	}
+}
+
+// errorChain returns the messages of err and of the errors it wraps.
+func errorChain(err error) []string {
+	var chain []string
+	for ; err != nil; err = errors.Unwrap(err) {
+		// Wrappers which do not add any context repeat the message of the wrapped error.
+		if msg := err.Error(); len(chain) == 0 || chain[len(chain)-1] != msg {
+			chain = append(chain, msg)
+		}
+	}
+	return chain
+}
+
+func writeGoroutineDump(path string) (err error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Append(err, f.Close())
+	}()
+	return pprof.Lookup("goroutine").WriteTo(f, 2)
+}
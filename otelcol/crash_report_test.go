@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/componenterror"
+)
+
+func readCrashReport(t *testing.T, path string) crashReport {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var report crashReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	return report
+}
+
+func TestCrashReportOnStartupError(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfgPath := filepath.Join("testdata", "otelcol-invalid.yaml")
+	reportPath := filepath.Join(t.TempDir(), "crash.json")
+	col, err := newCollectorWithFlags(CollectorSettings{
+		BuildInfo:       component.NewDefaultBuildInfo(),
+		Factories:       factories,
+		CrashReportPath: reportPath,
+	}, flagsWithConfig(t, cfgPath))
+	require.NoError(t, err)
+	runErr := col.Run(context.Background())
+	require.Error(t, runErr)
+
+	report := readCrashReport(t, reportPath)
+	assert.Equal(t, crashPhaseStartup, report.Phase)
+	assert.Equal(t, runErr.Error(), report.Error)
+	assert.NotEmpty(t, report.ErrorChain)
+	assert.Equal(t, []string{cfgPath}, report.ConfigURIs)
+	assert.Nil(t, report.Component)
+	assert.Equal(t, reportPath+".goroutines", report.GoroutineDump)
+	assert.FileExists(t, report.GoroutineDump)
+}
+
+func TestCrashReportOnAsyncError(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
+	require.NoError(t, err)
+
+	reportPath := filepath.Join(t.TempDir(), "crash.json")
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:       component.NewDefaultBuildInfo(),
+		Factories:       factories,
+		ConfigProvider:  cfgProvider,
+		CrashReportPath: reportPath,
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	col.asyncErrorChannel <- componenterror.New(component.KindReceiver, component.NewID("nop"), fmt.Errorf("listener closed: %w", errors.New("err2")))
+
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+
+	report := readCrashReport(t, reportPath)
+	assert.Equal(t, crashPhaseRuntime, report.Phase)
+	assert.Equal(t, "listener closed: err2", report.Error)
+	assert.Equal(t, []string{"listener closed: err2", "err2"}, report.ErrorChain)
+	assert.Equal(t, &crashReportComponent{Kind: "receiver", ID: "nop"}, report.Component)
+}
+
+func TestCrashReportDisabled(t *testing.T) {
+	col := &Collector{set: CollectorSettings{}}
+	assert.NoError(t, col.writeCrashReport(crashPhaseRuntime, errors.New("err")))
+}
+
+func TestCrashReportWriteError(t *testing.T) {
+	col := &Collector{set: CollectorSettings{CrashReportPath: filepath.Join(t.TempDir(), "missing", "crash.json")}}
+	assert.Error(t, col.writeCrashReport(crashPhaseRuntime, errors.New("err")))
+}
+
+func flagsWithConfig(t *testing.T, cfgPath string) *flag.FlagSet {
+	flgs := flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse([]string{"--config=" + cfgPath}))
+	return flgs
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// DefaultConfig assembles a runnable configuration from the default configurations of the factories.
+//
+// Every component is configured with its default configuration. The components whose default configuration
+// is not valid, e.g. because a required endpoint is not set, are omitted, as well as the connectors having the
+// type of a receiver or an exporter. For every data type, a pipeline connects the receivers and the exporters
+// supporting it. The processors, connectors and extensions are configured but not added to the pipelines,
+// except the extensions which are all enabled.
+func DefaultConfig(factories Factories) (*confmap.Conf, error) {
+	receivers, receiverTypes, err := defaultComponentConfigs(factories.Receivers)
+	if err != nil {
+		return nil, err
+	}
+	processors, _, err := defaultComponentConfigs(factories.Processors)
+	if err != nil {
+		return nil, err
+	}
+	exporters, exporterTypes, err := defaultComponentConfigs(factories.Exporters)
+	if err != nil {
+		return nil, err
+	}
+	connectors, _, err := defaultComponentConfigs(factories.Connectors)
+	if err != nil {
+		return nil, err
+	}
+	for typ := range connectors {
+		// A connector cannot have the ID of a receiver or an exporter.
+		_, isReceiver := receivers[typ]
+		_, isExporter := exporters[typ]
+		if isReceiver || isExporter {
+			delete(connectors, typ)
+		}
+	}
+	extensions, extensionTypes, err := defaultComponentConfigs(factories.Extensions)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelines := make(map[string]any)
+	for _, dt := range []component.DataType{component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs, component.DataTypeProfiles} {
+		var pipelineReceivers, pipelineExporters []string
+		for _, typ := range receiverTypes {
+			if receiverStability(factories.Receivers[typ], dt) != component.StabilityLevelUndefined {
+				pipelineReceivers = append(pipelineReceivers, string(typ))
+			}
+		}
+		for _, typ := range exporterTypes {
+			if exporterStability(factories.Exporters[typ], dt) != component.StabilityLevelUndefined {
+				pipelineExporters = append(pipelineExporters, string(typ))
+			}
+		}
+		if len(pipelineReceivers) == 0 || len(pipelineExporters) == 0 {
+			continue
+		}
+		pipelines[string(dt)] = map[string]any{
+			"receivers": pipelineReceivers,
+			"exporters": pipelineExporters,
+		}
+	}
+	if len(pipelines) == 0 {
+		return nil, errors.New("no pipeline can be assembled from the default configurations of the components")
+	}
+
+	serviceConf := confmap.New()
+	if err = serviceConf.Marshal(defaultServiceConfig()); err != nil {
+		return nil, fmt.Errorf("failed to marshal the default service configuration: %w", err)
+	}
+	service := serviceConf.ToStringMap()
+	service["pipelines"] = pipelines
+	if len(extensionTypes) > 0 {
+		extensionIDs := make([]string, 0, len(extensionTypes))
+		for _, typ := range extensionTypes {
+			extensionIDs = append(extensionIDs, string(typ))
+		}
+		service["extensions"] = extensionIDs
+	} else {
+		delete(service, "extensions")
+	}
+
+	cfg := map[string]any{
+		"receivers": receivers,
+		"exporters": exporters,
+		"service":   service,
+	}
+	if len(processors) > 0 {
+		cfg["processors"] = processors
+	}
+	if len(connectors) > 0 {
+		cfg["connectors"] = connectors
+	}
+	if len(extensions) > 0 {
+		cfg["extensions"] = extensions
+	}
+	return confmap.NewFromStringMap(cfg), nil
+}
+
+// defaultComponentConfigs returns the valid default configurations of the factories, indexed by their type,
+// and the sorted list of these types.
+func defaultComponentConfigs[F component.Factory](factories map[component.Type]F) (map[string]any, []component.Type, error) {
+	configs := make(map[string]any, len(factories))
+	types := make([]component.Type, 0, len(factories))
+	for typ, factory := range factories {
+		cfg := factory.CreateDefaultConfig()
+		if component.ValidateConfig(cfg) != nil {
+			continue
+		}
+		conf := confmap.New()
+		if err := conf.Marshal(cfg); err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal the default configuration of %q: %w", typ, err)
+		}
+		configs[string(typ)] = conf.ToStringMap()
+		types = append(types, typ)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return configs, types, nil
+}
+
+func receiverStability(f receiver.Factory, dt component.DataType) component.StabilityLevel {
+	switch dt {
+	case component.DataTypeTraces:
+		return f.TracesReceiverStability()
+	case component.DataTypeMetrics:
+		return f.MetricsReceiverStability()
+	case component.DataTypeLogs:
+		return f.LogsReceiverStability()
+	case component.DataTypeProfiles:
+		return f.ProfilesReceiverStability()
+	}
+	return component.StabilityLevelUndefined
+}
+
+func exporterStability(f exporter.Factory, dt component.DataType) component.StabilityLevel {
+	switch dt {
+	case component.DataTypeTraces:
+		return f.TracesExporterStability()
+	case component.DataTypeMetrics:
+		return f.MetricsExporterStability()
+	case component.DataTypeLogs:
+		return f.LogsExporterStability()
+	case component.DataTypeProfiles:
+		return f.ProfilesExporterStability()
+	}
+	return component.StabilityLevelUndefined
+}
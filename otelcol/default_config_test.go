@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+type invalidConfig struct{}
+
+func (invalidConfig) Validate() error {
+	return errors.New("endpoint must be specified")
+}
+
+func TestDefaultConfig(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	factories.Exporters["invalid"] = exporter.NewFactory("invalid", func() component.Config { return &invalidConfig{} })
+
+	conf, err := DefaultConfig(factories)
+	require.NoError(t, err)
+	assert.False(t, conf.IsSet("exporters::invalid"))
+
+	cfgSettings, err := unmarshal(conf, factories)
+	require.NoError(t, err)
+	cfg := &Config{
+		Receivers:  cfgSettings.Receivers.Configs(),
+		Processors: cfgSettings.Processors.Configs(),
+		Exporters:  cfgSettings.Exporters.Configs(),
+		Connectors: cfgSettings.Connectors.Configs(),
+		Extensions: cfgSettings.Extensions.Configs(),
+		Service:    cfgSettings.Service,
+	}
+	require.NoError(t, cfg.Validate())
+
+	nop := []component.ID{component.NewID("nop")}
+	assert.Equal(t, pipelines.Config{
+		component.NewID("traces"):   {Receivers: nop, Exporters: nop},
+		component.NewID("metrics"):  {Receivers: nop, Exporters: nop},
+		component.NewID("logs"):     {Receivers: nop, Exporters: nop},
+		component.NewID("profiles"): {Receivers: nop, Exporters: nop},
+	}, cfg.Service.Pipelines)
+	assert.Equal(t, nop, []component.ID(cfg.Service.Extensions))
+	defaultTelemetry := defaultServiceConfig().Telemetry
+	assert.Equal(t, defaultTelemetry.Logs.Level, cfg.Service.Telemetry.Logs.Level)
+	assert.Equal(t, defaultTelemetry.Logs.Rotation, cfg.Service.Telemetry.Logs.Rotation)
+	assert.Equal(t, defaultTelemetry.Metrics.Level, cfg.Service.Telemetry.Metrics.Level)
+	assert.Equal(t, defaultTelemetry.Metrics.Address, cfg.Service.Telemetry.Metrics.Address)
+}
+
+func TestDefaultConfigWithoutPipelines(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	factories.Exporters = nil
+
+	_, err = DefaultConfig(factories)
+	assert.EqualError(t, err, "no pipeline can be assembled from the default configurations of the components")
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/secretsprovider"
+)
+
+// effectiveConfig returns the YAML of the resolved configuration. The configopaque values
+// and the values resolved by the secrets providers are redacted.
+func effectiveConfig(cfg *Config) ([]byte, error) {
+	conf := confmap.New()
+	if err := conf.Marshal(cfg); err != nil {
+		return nil, fmt.Errorf("failed to marshal the effective configuration: %w", err)
+	}
+	yamlData, err := yaml.Marshal(conf.ToStringMap())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the effective configuration: %w", err)
+	}
+	return []byte(secretsprovider.Redact(string(yamlData))), nil
+}
+
+// printEffectiveConfig writes the effective configuration to w.
+func (col *Collector) printEffectiveConfig(ctx context.Context, w io.Writer) error {
+	cfg, err := col.set.ConfigProvider.Get(ctx, col.set.Factories)
+	if err != nil {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+	yamlData, err := effectiveConfig(cfg)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(yamlData)
+	return err
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/service"
+)
+
+type opaqueTestConfig struct {
+	Endpoint string              `mapstructure:"endpoint"`
+	APIKey   configopaque.String `mapstructure:"api_key"`
+}
+
+func TestEffectiveConfig(t *testing.T) {
+	cfg := &Config{
+		Receivers: map[component.ID]component.Config{
+			component.NewID("nop"): &opaqueTestConfig{Endpoint: "localhost:4317", APIKey: "s3cr3t"},
+		},
+		Service: service.Config{
+			Extensions: []component.ID{component.NewIDWithName("nop", "ext")},
+		},
+	}
+
+	yamlData, err := effectiveConfig(cfg)
+	require.NoError(t, err)
+	out := string(yamlData)
+	assert.Contains(t, out, "endpoint: localhost:4317")
+	assert.Contains(t, out, "api_key: '[REDACTED]'")
+	assert.Contains(t, out, "- nop/ext")
+	assert.NotContains(t, out, "s3cr3t")
+}
+
+func TestNewCommandPrintEffectiveConfig(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	cmd := NewCommand(CollectorSettings{Factories: factories})
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"--config=" + filepath.Join("testdata", "otelcol-nop.yaml"), "--print-effective-config"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "receivers:")
+	assert.Contains(t, out.String(), "address: localhost:8888")
+}
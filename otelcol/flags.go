@@ -6,14 +6,19 @@ package otelcol // import "go.opentelemetry.io/collector/otelcol"
 import (
 	"errors"
 	"flag"
+	"fmt"
 	"strings"
 
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/featuregate"
 )
 
 const (
-	configFlag       = "config"
-	featureGatesFlag = "feature-gates"
+	configFlag               = "config"
+	configMergeStrategyFlag  = "config-merge-strategy"
+	featureGatesFlag         = "feature-gates"
+	crashReportFlag          = "crash-report"
+	printEffectiveConfigFlag = "print-effective-config"
 )
 
 type configFlagValue struct {
@@ -30,6 +35,23 @@ func (s *configFlagValue) String() string {
 	return "[" + strings.Join(s.values, ", ") + "]"
 }
 
+type mergeStrategyValue struct {
+	strategy confmap.MergeStrategy
+}
+
+func (v *mergeStrategyValue) Set(val string) error {
+	switch strategy := confmap.MergeStrategy(val); strategy {
+	case confmap.MergeReplace, confmap.MergeAppend:
+		v.strategy = strategy
+		return nil
+	}
+	return fmt.Errorf("unsupported merge strategy %q, must be %q or %q", val, confmap.MergeReplace, confmap.MergeAppend)
+}
+
+func (v *mergeStrategyValue) String() string {
+	return string(v.strategy)
+}
+
 func flags(reg *featuregate.Registry) *flag.FlagSet {
 	flagSet := new(flag.FlagSet)
 
@@ -37,9 +59,14 @@ func flags(reg *featuregate.Registry) *flag.FlagSet {
 	flagSet.Var(cfgs, configFlag, "Locations to the config file(s), note that only a"+
 		" single location can be set per flag entry e.g. `--config=file:/path/to/first --config=file:path/to/second`.")
 
+	flagSet.Var(&mergeStrategyValue{strategy: confmap.MergeReplace}, configMergeStrategyFlag,
+		"Strategy merging the lists of the configs, \"replace\" replaces the lists of the previous configs and"+
+			" \"append\" appends the elements of the later lists which the previous lists do not contain. The maps are always joined.")
+
 	flagSet.Func("set",
 		"Set arbitrary component config property. The component has to be defined in the config file and the flag"+
-			" has a higher precedence. Array config properties are overridden and maps are joined. Example --set=processors.batch.timeout=2s",
+			" has a higher precedence. Array config properties are merged according to --config-merge-strategy and maps are joined."+
+			" Example --set=processors.batch.timeout=2s",
 		func(s string) error {
 			idx := strings.Index(s, "=")
 			if idx == -1 {
@@ -53,6 +80,12 @@ func flags(reg *featuregate.Registry) *flag.FlagSet {
 	flagSet.Var(featuregate.NewFlag(reg), featureGatesFlag,
 		"Comma-delimited list of feature gate identifiers. Prefix with '-' to disable the feature. '+' or no prefix will enable the feature.")
 
+	flagSet.String(crashReportFlag, "",
+		"Path of the file where a JSON crash report is written if the collector exits because of a fatal error.")
+
+	flagSet.Bool(printEffectiveConfigFlag, false,
+		"Print the resolved configuration, with the sensitive values redacted, and exit without starting the collector.")
+
 	return flagSet
 }
 
@@ -60,3 +93,22 @@ func getConfigFlag(flagSet *flag.FlagSet) []string {
 	cfv := flagSet.Lookup(configFlag).Value.(*configFlagValue)
 	return append(cfv.values, cfv.sets...)
 }
+
+func getConfigMergeStrategyFlag(flagSet *flag.FlagSet) confmap.MergeStrategy {
+	return flagSet.Lookup(configMergeStrategyFlag).Value.(*mergeStrategyValue).strategy
+}
+
+// configProviderSettings returns the settings of the default ConfigProvider for the config flags.
+func configProviderSettings(flagSet *flag.FlagSet) ConfigProviderSettings {
+	set := newDefaultConfigProviderSettings(getConfigFlag(flagSet))
+	set.ResolverSettings.MergeStrategy = getConfigMergeStrategyFlag(flagSet)
+	return set
+}
+
+func getCrashReportFlag(flagSet *flag.FlagSet) string {
+	return flagSet.Lookup(crashReportFlag).Value.String()
+}
+
+func getPrintEffectiveConfigFlag(flagSet *flag.FlagSet) bool {
+	return flagSet.Lookup(printEffectiveConfigFlag).Value.(flag.Getter).Get().(bool)
+}
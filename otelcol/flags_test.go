@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/featuregate"
 )
 
@@ -69,3 +70,19 @@ func TestSetFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigMergeStrategyFlag(t *testing.T) {
+	flgs := flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse([]string{"--config=file:testdata/otelcol-nop.yaml"}))
+	assert.Equal(t, confmap.MergeReplace, configProviderSettings(flgs).ResolverSettings.MergeStrategy)
+
+	flgs = flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse([]string{"--config=file:testdata/otelcol-nop.yaml", "--config-merge-strategy=append"}))
+	set := configProviderSettings(flgs)
+	assert.Equal(t, confmap.MergeAppend, set.ResolverSettings.MergeStrategy)
+	assert.Equal(t, []string{"file:testdata/otelcol-nop.yaml"}, set.ResolverSettings.URIs)
+
+	flgs = flags(featuregate.NewRegistry())
+	assert.EqualError(t, flgs.Parse([]string{"--config-merge-strategy=prepend"}),
+		`invalid value "prepend" for flag -config-merge-strategy: unsupported merge strategy "prepend", must be "replace" or "append"`)
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package configmigration rewrites the deprecated settings of a collector configuration
+// to the settings replacing them.
+package configmigration // import "go.opentelemetry.io/collector/otelcol/internal/configmigration"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule is a migration of the settings deprecated in a collector version.
+type Rule struct {
+	// Version is the collector version deprecating the settings migrated by the rule.
+	Version string
+	// Description describes the change made by the rule.
+	Description string
+	// migrate rewrites the configuration, and returns true if it changed it.
+	migrate func(root *yaml.Node) (bool, error)
+}
+
+// Rules are the migration rules, ordered by version.
+var Rules = []Rule{
+	{
+		Version:     "v0.63.0",
+		Description: "exporters::logging::loglevel is replaced by exporters::logging::verbosity",
+		migrate:     migrateLoggingLogLevel,
+	},
+	{
+		Version:     "v0.81.0",
+		Description: "the memory_ballast extension is replaced by service::soft_memory_limit",
+		migrate:     migrateMemoryBallast,
+	},
+}
+
+// Migrate applies the rules of the versions after fromVersion to the YAML configuration,
+// all the rules if fromVersion is empty. It returns the migrated configuration and the rules
+// changing it, the configuration is returned as is if no rule changes it.
+func Migrate(data []byte, fromVersion string) ([]byte, []Rule, error) {
+	if fromVersion != "" && !semver.IsValid(fromVersion) {
+		return nil, nil, fmt.Errorf("invalid version %q", fromVersion)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	if len(doc.Content) == 0 {
+		return data, nil, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, errors.New("the configuration is not a map")
+	}
+
+	var applied []Rule
+	for _, rule := range Rules {
+		if fromVersion != "" && semver.Compare(rule.Version, fromVersion) <= 0 {
+			continue
+		}
+		changed, err := rule.migrate(root)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", rule.Version, err)
+		}
+		if changed {
+			applied = append(applied, rule)
+		}
+	}
+	if len(applied) == 0 {
+		return data, nil, nil
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), applied, nil
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configmigration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "deprecated.yaml"))
+	require.NoError(t, err)
+	expected, err := os.ReadFile(filepath.Join("testdata", "migrated.yaml"))
+	require.NoError(t, err)
+
+	migrated, applied, err := Migrate(data, "")
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(migrated))
+	assert.Equal(t, []string{"v0.63.0", "v0.81.0"}, versions(applied))
+
+	// The migrated configuration is left as is.
+	again, applied, err := Migrate(migrated, "")
+	require.NoError(t, err)
+	assert.Equal(t, migrated, again)
+	assert.Empty(t, applied)
+}
+
+func TestMigrateFromVersion(t *testing.T) {
+	data := []byte("exporters:\n  logging:\n    loglevel: info\n")
+
+	migrated, applied, err := Migrate(data, "v0.63.0")
+	require.NoError(t, err)
+	assert.Equal(t, data, migrated)
+	assert.Empty(t, applied)
+
+	migrated, applied, err = Migrate(data, "v0.62.0")
+	require.NoError(t, err)
+	assert.Equal(t, "exporters:\n  logging:\n    verbosity: normal\n", string(migrated))
+	assert.Equal(t, []string{"v0.63.0"}, versions(applied))
+
+	_, _, err = Migrate(data, "0.62")
+	assert.EqualError(t, err, `invalid version "0.62"`)
+}
+
+func TestMigrateErrors(t *testing.T) {
+	_, _, err := Migrate([]byte("exporters: ["), "")
+	assert.Error(t, err)
+
+	_, _, err = Migrate([]byte("- exporters"), "")
+	assert.EqualError(t, err, "the configuration is not a map")
+
+	_, _, err = Migrate([]byte("exporters:\n  logging:\n    loglevel: ${env:LEVEL}\n"), "")
+	assert.EqualError(t, err, `v0.63.0: exporters::logging: cannot migrate loglevel "${env:LEVEL}"`)
+
+	migrated, applied, err := Migrate(nil, "")
+	require.NoError(t, err)
+	assert.Empty(t, migrated)
+	assert.Empty(t, applied)
+}
+
+func TestMigrateLoggingVerbositySet(t *testing.T) {
+	data := []byte("exporters:\n  logging:\n    loglevel: info\n    verbosity: basic\n")
+	migrated, applied, err := Migrate(data, "")
+	require.NoError(t, err)
+	assert.Equal(t, "exporters:\n  logging:\n    verbosity: basic\n", string(migrated))
+	assert.Equal(t, []string{"v0.63.0"}, versions(applied))
+}
+
+func versions(rules []Rule) []string {
+	var v []string
+	for _, r := range rules {
+		v = append(v, r.Version)
+	}
+	return v
+}
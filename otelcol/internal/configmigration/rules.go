@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configmigration // import "go.opentelemetry.io/collector/otelcol/internal/configmigration"
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// logLevelVerbosities maps the values of the loglevel setting of the logging exporter to verbosities.
+var logLevelVerbosities = map[string]string{
+	"debug":  "detailed",
+	"info":   "normal",
+	"warn":   "basic",
+	"error":  "basic",
+	"dpanic": "basic",
+	"panic":  "basic",
+	"fatal":  "basic",
+}
+
+func migrateLoggingLogLevel(root *yaml.Node) (bool, error) {
+	changed := false
+	for _, id := range componentsOfType(root, "exporters", "logging") {
+		cfg := id.value
+		if cfg.Kind != yaml.MappingNode {
+			continue
+		}
+		level := get(cfg, "loglevel")
+		if level == nil {
+			continue
+		}
+		changed = true
+		// The verbosity takes precedence, the collector refuses both settings.
+		if get(cfg, "verbosity") != nil {
+			remove(cfg, "loglevel")
+			continue
+		}
+		verbosity, ok := logLevelVerbosities[strings.ToLower(level.Value)]
+		if !ok {
+			return false, fmt.Errorf("exporters::%s: cannot migrate loglevel %q", id.key.Value, level.Value)
+		}
+		rename(cfg, "loglevel", "verbosity")
+		level.SetString(verbosity)
+	}
+	return changed, nil
+}
+
+func migrateMemoryBallast(root *yaml.Node) (bool, error) {
+	ballasts := componentsOfType(root, "extensions", "memory_ballast")
+	if len(ballasts) == 0 {
+		return false, nil
+	}
+	extensions := get(root, "extensions")
+	for _, id := range ballasts {
+		remove(extensions, id.key.Value)
+	}
+
+	service := get(root, "service")
+	if service == nil || service.Kind != yaml.MappingNode {
+		return true, nil
+	}
+	if enabled := get(service, "extensions"); enabled != nil && enabled.Kind == yaml.SequenceNode {
+		var content []*yaml.Node
+		for _, n := range enabled.Content {
+			if !isOfType(n.Value, "memory_ballast") {
+				content = append(content, n)
+			}
+		}
+		enabled.Content = content
+	}
+	if get(service, "soft_memory_limit") == nil {
+		// The defaults set the soft memory limit to 80% of the total memory.
+		put(service, "soft_memory_limit", &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Style: yaml.FlowStyle})
+	}
+	return true, nil
+}
+
+// keyValue is an entry of a mapping node.
+type keyValue struct {
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+// componentsOfType returns the components of a section of the configuration, e.g. "exporters",
+// whose ID has the given type.
+func componentsOfType(root *yaml.Node, section string, typ string) []keyValue {
+	components := get(root, section)
+	if components == nil || components.Kind != yaml.MappingNode {
+		return nil
+	}
+	var ids []keyValue
+	for i := 0; i+1 < len(components.Content); i += 2 {
+		if isOfType(components.Content[i].Value, typ) {
+			ids = append(ids, keyValue{key: components.Content[i], value: components.Content[i+1]})
+		}
+	}
+	return ids
+}
+
+func isOfType(id string, typ string) bool {
+	return id == typ || strings.HasPrefix(id, typ+"/")
+}
+
+// get returns the value of the key of a mapping node, nil if it is not set.
+func get(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func rename(mapping *yaml.Node, key string, newKey string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i].Value = newKey
+			return
+		}
+	}
+}
+
+func remove(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+func put(mapping *yaml.Node, key string, value *yaml.Node) {
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
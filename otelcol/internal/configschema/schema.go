@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package configschema generates the JSON Schemas of the configurations from their Go types,
+// following the mapstructure tags used to unmarshal them.
+package configschema // import "go.opentelemetry.io/collector/otelcol/internal/configschema"
+
+import (
+	"encoding"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// Version is the URI of the JSON Schema dialect of the generated schemas.
+const Version = "https://json-schema.org/draft/2020-12/schema"
+
+// durationPattern is the pattern of the durations, which are also accepted as integers of nanoseconds.
+const durationPattern = `^(0|-?([0-9]+(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+)$`
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	unmarshalerType     = reflect.TypeOf((*confmap.Unmarshaler)(nil)).Elem()
+)
+
+// FromType returns the JSON Schema of the configurations of type typ.
+// The structs only accept their fields, unless they unmarshal themselves.
+func FromType(typ reflect.Type) map[string]any {
+	return (&generator{visiting: map[reflect.Type]bool{}}).schema(typ)
+}
+
+type generator struct {
+	// visiting holds the structs being generated, a recursive struct accepts any value.
+	visiting map[reflect.Type]bool
+}
+
+func (g *generator) schema(typ reflect.Type) map[string]any {
+	if typ == nil {
+		return map[string]any{}
+	}
+	if typ.Kind() == reflect.Pointer {
+		schema := g.schema(typ.Elem())
+		if t, ok := schema["type"].(string); ok {
+			schema["type"] = []string{t, "null"}
+		}
+		return schema
+	}
+	if typ == durationType {
+		return map[string]any{"type": []string{"string", "integer"}, "pattern": durationPattern}
+	}
+	if reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		return map[string]any{"type": "string"}
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string"}
+		}
+		return map[string]any{"type": "array", "items": g.schema(typ.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schema(typ.Elem())}
+	case reflect.Struct:
+		return g.structSchema(typ)
+	}
+	return map[string]any{}
+}
+
+func (g *generator) structSchema(typ reflect.Type) map[string]any {
+	if g.visiting[typ] {
+		return map[string]any{}
+	}
+	g.visiting[typ] = true
+	defer delete(g.visiting, typ)
+
+	properties := map[string]any{}
+	remain := g.addFields(typ, properties)
+	schema := map[string]any{"type": "object", "properties": properties}
+	switch {
+	case reflect.PointerTo(typ).Implements(unmarshalerType):
+		// The structs unmarshalling themselves may accept any other key.
+	case remain != nil:
+		schema["additionalProperties"] = remain
+	default:
+		schema["additionalProperties"] = false
+	}
+	return schema
+}
+
+// addFields adds the schemas of the fields of the struct to properties, including the fields of the squashed structs.
+// It returns the schema of the values of the other keys if a field holds them, with the remain option, nil otherwise.
+func (g *generator) addFields(typ reflect.Type, properties map[string]any) (remain map[string]any) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		squash := strings.Contains(opts, "squash")
+		if name == "-" || (!field.IsExported() && !squash) {
+			continue
+		}
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if squash && fieldType.Kind() == reflect.Struct {
+			if r := g.addFields(fieldType, properties); r != nil {
+				remain = r
+			}
+			continue
+		}
+		if strings.Contains(opts, "remain") {
+			remain = map[string]any{}
+			if fieldType.Kind() == reflect.Map {
+				remain = g.schema(fieldType.Elem())
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = g.schema(field.Type)
+	}
+	return remain
+}
+
+// SetDefaults sets the values of the marshalled default configuration as the defaults of the properties of schema,
+// the durations are set in their string form.
+func SetDefaults(schema map[string]any, defaults map[string]any) {
+	properties, _ := schema["properties"].(map[string]any)
+	for key, value := range defaults {
+		property, ok := properties[key].(map[string]any)
+		if !ok || value == nil {
+			continue
+		}
+		if nested, isMap := value.(map[string]any); isMap {
+			if _, isStruct := property["properties"]; isStruct {
+				SetDefaults(property, nested)
+				continue
+			}
+		}
+		if rv := reflect.ValueOf(value); rv.Kind() == reflect.Int64 && property["pattern"] == durationPattern {
+			value = time.Duration(rv.Int()).String()
+		}
+		property["default"] = value
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configschema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+type embeddedConfig struct {
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+type tlsConfig struct {
+	Insecure bool `mapstructure:"insecure"`
+}
+
+type recursiveConfig struct {
+	Next *recursiveConfig `mapstructure:"next"`
+}
+
+type levelsConfig struct {
+	Default int            `mapstructure:"default"`
+	Levels  map[string]int `mapstructure:",remain"`
+}
+
+type testConfig struct {
+	embeddedConfig `mapstructure:",squash"`
+	Endpoint       string            `mapstructure:"endpoint"`
+	Port           uint16            `mapstructure:"port"`
+	Ratio          float64           `mapstructure:"ratio"`
+	TLS            *tlsConfig        `mapstructure:"tls"`
+	Headers        map[string]string `mapstructure:"headers"`
+	Exporters      []component.ID    `mapstructure:"exporters"`
+	Payload        []byte            `mapstructure:"payload"`
+	Settings       any               `mapstructure:"settings"`
+	Recursive      recursiveConfig   `mapstructure:"recursive"`
+	Levels         levelsConfig      `mapstructure:"levels"`
+	Ignored        string            `mapstructure:"-"`
+	_              struct{}
+}
+
+type unmarshalerConfig struct {
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+func (cfg *unmarshalerConfig) Unmarshal(conf *confmap.Conf) error {
+	return conf.Unmarshal(cfg)
+}
+
+func TestFromType(t *testing.T) {
+	assert.Equal(t, map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"timeout":  map[string]any{"type": []string{"string", "integer"}, "pattern": durationPattern},
+			"endpoint": map[string]any{"type": "string"},
+			"port":     map[string]any{"type": "integer", "minimum": 0},
+			"ratio":    map[string]any{"type": "number"},
+			"tls": map[string]any{
+				"type":                 []string{"object", "null"},
+				"properties":           map[string]any{"insecure": map[string]any{"type": "boolean"}},
+				"additionalProperties": false,
+			},
+			"headers":   map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+			"exporters": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"payload":   map[string]any{"type": "string"},
+			"settings":  map[string]any{},
+			"recursive": map[string]any{
+				"type":                 "object",
+				"properties":           map[string]any{"next": map[string]any{}},
+				"additionalProperties": false,
+			},
+			// The keys collected by a remain field are accepted with the type of its values.
+			"levels": map[string]any{
+				"type":                 "object",
+				"properties":           map[string]any{"default": map[string]any{"type": "integer"}},
+				"additionalProperties": map[string]any{"type": "integer"},
+			},
+		},
+		"additionalProperties": false,
+	}, FromType(reflect.TypeOf(testConfig{})))
+
+	// The structs unmarshalling themselves may accept other keys.
+	assert.Equal(t, map[string]any{
+		"type":       []string{"object", "null"},
+		"properties": map[string]any{"endpoint": map[string]any{"type": "string"}},
+	}, FromType(reflect.TypeOf(&unmarshalerConfig{})))
+}
+
+func TestSetDefaults(t *testing.T) {
+	schema := FromType(reflect.TypeOf(testConfig{}))
+	SetDefaults(schema, map[string]any{
+		"timeout":  5 * time.Second,
+		"endpoint": "localhost:4317",
+		"tls":      map[string]any{"insecure": true},
+		"headers":  map[string]any{"key": "value"},
+		"unknown":  true,
+		"ratio":    nil,
+	})
+	properties := schema["properties"].(map[string]any)
+	assert.Equal(t, "5s", properties["timeout"].(map[string]any)["default"])
+	assert.Equal(t, "localhost:4317", properties["endpoint"].(map[string]any)["default"])
+	assert.Equal(t, true, properties["tls"].(map[string]any)["properties"].(map[string]any)["insecure"].(map[string]any)["default"])
+	assert.Equal(t, map[string]any{"key": "value"}, properties["headers"].(map[string]any)["default"])
+	assert.NotContains(t, properties["ratio"], "default")
+	assert.NotContains(t, properties, "unknown")
+}
@@ -27,10 +27,19 @@ func (c *Configs[F]) Unmarshal(conf *confmap.Conf) error {
 		return err
 	}
 
+	// The sub configurations of the components are taken by their original keys, to keep the positions of their keys.
+	keys := make(map[component.ID]string, len(rawCfgs))
+	for key := range conf.ToStringMap() {
+		var id component.ID
+		if err := id.UnmarshalText([]byte(key)); err == nil {
+			keys[id] = key
+		}
+	}
+
 	// Prepare resulting map.
 	c.cfgs = make(map[component.ID]component.Config)
 	// Iterate over raw configs and create a config for each.
-	for id, value := range rawCfgs {
+	for id := range rawCfgs {
 		// Find factory based on component kind and type that we read from config source.
 		factory, ok := c.factories[id.Type()]
 		if !ok {
@@ -42,7 +51,11 @@ func (c *Configs[F]) Unmarshal(conf *confmap.Conf) error {
 
 		// Now that the default config struct is created we can Unmarshal into it,
 		// and it will apply user-defined config on top of the default.
-		if err := component.UnmarshalConfig(confmap.NewFromStringMap(value), cfg); err != nil {
+		sub, err := conf.Sub(keys[id])
+		if err != nil {
+			return errorUnmarshalError(id, err)
+		}
+		if err := component.UnmarshalConfig(sub, cfg); err != nil {
 			return errorUnmarshalError(id, err)
 		}
 
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/service/opamp"
+)
+
+var errRemoteConfigUnsupported = errors.New("the config provider does not support remote configurations")
+
+// startOpAMP starts the OpAMP client of the configuration, once the first service is running.
+// The client is kept for the lifetime of the collector, the changes of its configuration are
+// applied when the collector restarts.
+func (col *Collector) startOpAMP(ctx context.Context, cfg *opamp.Config) error {
+	if col.opamp != nil {
+		if !reflect.DeepEqual(cfg, col.opampCfg) {
+			col.service.Logger().Warn("The changes of service::opamp are applied when the collector restarts")
+		}
+		return nil
+	}
+	if cfg == nil {
+		return nil
+	}
+	client, err := opamp.NewClient(*cfg, opamp.Settings{
+		BuildInfo:  col.set.BuildInfo,
+		Logger:     col.service.Logger().With(zap.String("opamp_endpoint", cfg.Endpoint)),
+		InstanceID: col.service.InstanceID(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create the OpAMP client: %w", err)
+	}
+	if err = client.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start the OpAMP client: %w", err)
+	}
+	col.opamp, col.opampCfg = client, cfg
+	col.service.Logger().Info("Started the OpAMP client", zap.String("instance_uid", client.InstanceUID()))
+	return nil
+}
+
+// shutdownOpAMP shuts the OpAMP client down, if it is running.
+func (col *Collector) shutdownOpAMP(ctx context.Context) error {
+	if col.opamp == nil {
+		return nil
+	}
+	err := col.opamp.Shutdown(ctx)
+	col.opamp = nil
+	return err
+}
+
+// remoteConfigs returns the channel of the remote configurations, nil if there is no OpAMP client.
+func (col *Collector) remoteConfigs() <-chan *opamp.RemoteConfig {
+	if col.opamp == nil {
+		return nil
+	}
+	return col.opamp.RemoteConfigs()
+}
+
+// applyRemoteConfig merges the remote configuration over the local configuration and applies it,
+// and reports the status of its application to the OpAMP server. The collector keeps running with
+// its previous configuration if the remote configuration is invalid or cannot be applied. The
+// returned error is fatal, it is only returned if the previous configuration cannot be restored.
+func (col *Collector) applyRemoteConfig(ctx context.Context, rc *opamp.RemoteConfig) error {
+	client := col.opamp
+	fail := func(err error) error {
+		col.service.Logger().Warn("Failed to apply the remote configuration, keeping the previous configuration", zap.Error(err))
+		client.SetRemoteConfigStatus(rc.Hash, err)
+		return nil
+	}
+
+	provider, ok := col.set.ConfigProvider.(remoteConfigProvider)
+	if !ok {
+		return fail(errRemoteConfigUnsupported)
+	}
+	conf, err := remoteConf(rc)
+	if err != nil {
+		return fail(err)
+	}
+	previous := provider.getRemoteConfig()
+	provider.setRemoteConfig(conf)
+	// The configuration is validated before the running service is changed.
+	if err = col.DryRun(ctx); err != nil {
+		provider.setRemoteConfig(previous)
+		return fail(err)
+	}
+	if err = col.reloadConfiguration(ctx); err == nil {
+		col.service.Logger().Info("Applied the remote configuration")
+		client.SetRemoteConfigStatus(rc.Hash, nil)
+		return nil
+	}
+
+	// The service could not be restarted with the remote configuration, it is restarted with the previous one.
+	provider.setRemoteConfig(previous)
+	if rollbackErr := col.setupConfigurationComponents(ctx); rollbackErr != nil {
+		client.SetRemoteConfigStatus(rc.Hash, err)
+		return multierr.Append(err, fmt.Errorf("failed to restore the previous configuration: %w", rollbackErr))
+	}
+	return fail(err)
+}
+
+// remoteConf returns the confmap.Conf of the files of the remote configuration, merged in order.
+func remoteConf(rc *opamp.RemoteConfig) (*confmap.Conf, error) {
+	conf := confmap.New()
+	for _, file := range rc.Files {
+		var raw map[string]any
+		if err := yaml.Unmarshal(file.Body, &raw); err != nil {
+			return nil, fmt.Errorf("cannot parse the remote configuration file %q: %w", file.Name, err)
+		}
+		if err := conf.Merge(confmap.NewFromStringMap(raw)); err != nil {
+			return nil, err
+		}
+	}
+	return conf, nil
+}
@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/opamp"
+)
+
+// fakeOpAMPServer offers a remote configuration to the collector, and records the remote
+// configuration statuses and the effective configurations reported by the collector.
+type fakeOpAMPServer struct {
+	*httptest.Server
+
+	mu              sync.Mutex
+	body            []byte
+	hash            []byte
+	statuses        map[string]opamp.RemoteConfigStatus
+	errors          map[string]string
+	effectiveConfig string
+}
+
+func newFakeOpAMPServer(t *testing.T) *fakeOpAMPServer {
+	srv := &fakeOpAMPServer{statuses: map[string]opamp.RemoteConfigStatus{}, errors: map[string]string{}}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		srv.record(t, body)
+		_, _ = w.Write(srv.response(t))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func (srv *fakeOpAMPServer) offer(body string, hash string) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.body, srv.hash = []byte(body), []byte(hash)
+}
+
+func (srv *fakeOpAMPServer) status(hash string) (opamp.RemoteConfigStatus, string) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return srv.statuses[hash], srv.errors[hash]
+}
+
+func (srv *fakeOpAMPServer) reportedConfig() string {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return srv.effectiveConfig
+}
+
+// response returns a ServerToAgent message holding the remote configuration, in a single file.
+func (srv *fakeOpAMPServer) response(t *testing.T) []byte {
+	msg := &protobufs.ServerToAgent{}
+	if srv.hash != nil {
+		msg.RemoteConfig = &protobufs.AgentRemoteConfig{
+			Config: &protobufs.AgentConfigMap{ConfigMap: map[string]*protobufs.AgentConfigFile{
+				"": {Body: srv.body},
+			}},
+			ConfigHash: srv.hash,
+		}
+	}
+	b, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	return b
+}
+
+// record records the effective configuration and the remote configuration status of an AgentToServer message.
+func (srv *fakeOpAMPServer) record(t *testing.T, b []byte) {
+	msg := &protobufs.AgentToServer{}
+	require.NoError(t, proto.Unmarshal(b, msg))
+	if msg.EffectiveConfig != nil {
+		srv.effectiveConfig = string(msg.EffectiveConfig.GetConfigMap().GetConfigMap()[""].GetBody())
+	}
+	if status := msg.RemoteConfigStatus; status != nil {
+		hash := string(status.LastRemoteConfigHash)
+		srv.statuses[hash] = opamp.RemoteConfigStatus(status.Status)
+		if status.ErrorMessage != "" {
+			srv.errors[hash] = status.ErrorMessage
+		}
+	}
+}
+
+func TestCollectorAppliesRemoteConfig(t *testing.T) {
+	srv := newFakeOpAMPServer(t)
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{
+		filepath.Join("testdata", "otelcol-nop.yaml"),
+		"yaml:service::opamp::endpoint: " + srv.URL,
+		"yaml:service::opamp::polling_interval: 10ms",
+		"yaml:service::opamp::accept_remote_config: true",
+	}))
+	require.NoError(t, err)
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+	})
+	require.NoError(t, err)
+	wg := startCollector(context.Background(), t, col)
+
+	assert.Eventually(t, func() bool {
+		return strings.Contains(srv.reportedConfig(), "level: info")
+	}, 5*time.Second, 10*time.Millisecond)
+
+	srv.offer("service:\n  telemetry:\n    logs:\n      level: debug\n", "valid")
+	assert.Eventually(t, func() bool {
+		status, _ := srv.status("valid")
+		return status == opamp.RemoteConfigStatusApplied
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return strings.Contains(srv.reportedConfig(), "level: debug")
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// An invalid configuration is not applied, the collector keeps its previous configuration.
+	srv.offer("service:\n  pipelines:\n    traces:\n      receivers: [unknown]\n", "invalid")
+	assert.Eventually(t, func() bool {
+		status, _ := srv.status("invalid")
+		return status == opamp.RemoteConfigStatusFailed
+	}, 5*time.Second, 10*time.Millisecond)
+	_, errMsg := srv.status("invalid")
+	assert.Contains(t, errMsg, `references receiver "unknown" which is not configured`)
+	assert.Equal(t, StateRunning, col.GetState())
+	cfg, err := cfgProvider.Get(context.Background(), factories)
+	require.NoError(t, err)
+	assert.Equal(t, "debug", cfg.Service.Telemetry.Logs.Level.String())
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+}
+
+func TestCollectorRemoteConfigUnsupportedProvider(t *testing.T) {
+	srv := newFakeOpAMPServer(t)
+	srv.offer("service:\n  telemetry:\n    logs:\n      level: debug\n", "valid")
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	provider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{
+		filepath.Join("testdata", "otelcol-nop.yaml"),
+		"yaml:service::opamp::endpoint: " + srv.URL,
+		"yaml:service::opamp::polling_interval: 10ms",
+		"yaml:service::opamp::accept_remote_config: true",
+	}))
+	require.NoError(t, err)
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: &mockCfgProvider{ConfigProvider: provider, watcher: make(chan error)},
+	})
+	require.NoError(t, err)
+	wg := startCollector(context.Background(), t, col)
+
+	assert.Eventually(t, func() bool {
+		status, _ := srv.status("valid")
+		return status == opamp.RemoteConfigStatusFailed
+	}, 5*time.Second, 10*time.Millisecond)
+	_, errMsg := srv.status("valid")
+	assert.Equal(t, errRemoteConfigUnsupported.Error(), errMsg)
+
+	col.Shutdown()
+	wg.Wait()
+}
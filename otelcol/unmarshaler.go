@@ -4,6 +4,8 @@
 package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
+	"fmt"
+
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/config/configrotate"
@@ -39,36 +41,72 @@ func unmarshal(v *confmap.Conf, factories Factories) (*configSettings, error) {
 		Connectors: configunmarshaler.NewConfigs(factories.Connectors),
 		Extensions: configunmarshaler.NewConfigs(factories.Extensions),
 		// TODO: Add a component.ServiceFactory to allow this to be defined by the Service.
-		Service: service.Config{
-			Telemetry: telemetry.Config{
-				Logs: telemetry.LogsConfig{
-					Level:       zapcore.InfoLevel,
-					Development: false,
-					Encoding:    "console",
-					Sampling: &telemetry.LogsSamplingConfig{
-						Initial:    100,
-						Thereafter: 100,
-					},
-					Rotation: &configrotate.Config{
-						Enabled:      true,
-						MaxMegabytes: 100,
-						MaxDays:      0,
-						MaxBackups:   100,
-						LocalTime:    false,
-					},
-					OutputPaths:       []string{"stderr"},
-					ErrorOutputPaths:  []string{"stderr"},
-					DisableCaller:     false,
-					DisableStacktrace: false,
-					InitialFields:     map[string]any(nil),
+		Service: defaultServiceConfig(),
+	}
+
+	// The sections of the components are unmarshalled from their sub configurations, which keep the positions
+	// of their keys, the other sections and the unknown sections are unmarshalled from the whole configuration.
+	sections := struct {
+		Receivers  any             `mapstructure:"receivers"`
+		Processors any             `mapstructure:"processors"`
+		Exporters  any             `mapstructure:"exporters"`
+		Connectors any             `mapstructure:"connectors"`
+		Extensions any             `mapstructure:"extensions"`
+		Service    *service.Config `mapstructure:"service"`
+	}{Service: &cfg.Service}
+	if err := v.Unmarshal(&sections, confmap.WithErrorUnused()); err != nil {
+		return cfg, err
+	}
+	for _, section := range []struct {
+		name    string
+		configs confmap.Unmarshaler
+	}{
+		{name: "receivers", configs: cfg.Receivers},
+		{name: "processors", configs: cfg.Processors},
+		{name: "exporters", configs: cfg.Exporters},
+		{name: "connectors", configs: cfg.Connectors},
+		{name: "extensions", configs: cfg.Extensions},
+	} {
+		sub, err := v.Sub(section.name)
+		if err == nil {
+			err = section.configs.Unmarshal(sub)
+		}
+		if err != nil {
+			return cfg, fmt.Errorf("error decoding '%s': %w", section.name, err)
+		}
+	}
+	return cfg, nil
+}
+
+// defaultServiceConfig returns the default service configuration, overridden by the unmarshalled configuration.
+func defaultServiceConfig() service.Config {
+	return service.Config{
+		Telemetry: telemetry.Config{
+			Logs: telemetry.LogsConfig{
+				Level:       zapcore.InfoLevel,
+				Development: false,
+				Encoding:    "console",
+				Sampling: &telemetry.LogsSamplingConfig{
+					Initial:    100,
+					Thereafter: 100,
 				},
-				Metrics: telemetry.MetricsConfig{
-					Level:   configtelemetry.LevelBasic,
-					Address: ":8888",
+				Rotation: &configrotate.Config{
+					Enabled:      true,
+					MaxMegabytes: 100,
+					MaxDays:      0,
+					MaxBackups:   100,
+					LocalTime:    false,
 				},
+				OutputPaths:       []string{"stderr"},
+				ErrorOutputPaths:  []string{"stderr"},
+				DisableCaller:     false,
+				DisableStacktrace: false,
+				InitialFields:     map[string]any(nil),
+			},
+			Metrics: telemetry.MetricsConfig{
+				Level:   configtelemetry.LevelBasic,
+				Address: ":8888",
 			},
 		},
 	}
-
-	return cfg, v.Unmarshal(&cfg, confmap.WithErrorUnused())
 }
@@ -17,6 +17,8 @@ var pcommon = &Package{
 		`"github.com/stretchr/testify/assert"`,
 		``,
 		`"go.opentelemetry.io/collector/pdata/internal"`,
+		`otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"`,
+		`otlpresource "go.opentelemetry.io/collector/pdata/internal/data/protogen/resource/v1"`,
 	},
 	structs: []baseStruct{
 		scope,
@@ -24,6 +26,8 @@ var pcommon = &Package{
 		byteSlice,
 		float64Slice,
 		uInt64Slice,
+		int64Slice,
+		stringSlice,
 	},
 }
 
@@ -167,16 +171,53 @@ var byteSlice = &primitiveSliceStruct{
 	structName:  "ByteSlice",
 	packageName: "pcommon",
 	itemType:    "byte",
+
+	testOrigVal:          "1, 2, 3",
+	testInterfaceOrigVal: []any{1, 2, 3},
+	testSetVal:           "5",
+	testNewVal:           "1, 5, 3",
 }
 
 var float64Slice = &primitiveSliceStruct{
 	structName:  "Float64Slice",
 	packageName: "pcommon",
 	itemType:    "float64",
+
+	testOrigVal:          "1, 2, 3",
+	testInterfaceOrigVal: []any{1, 2, 3},
+	testSetVal:           "5",
+	testNewVal:           "1, 5, 3",
 }
 
 var uInt64Slice = &primitiveSliceStruct{
 	structName:  "UInt64Slice",
 	packageName: "pcommon",
 	itemType:    "uint64",
+
+	testOrigVal:          "1, 2, 3",
+	testInterfaceOrigVal: []any{1, 2, 3},
+	testSetVal:           "5",
+	testNewVal:           "1, 5, 3",
+}
+
+var int64Slice = &primitiveSliceStruct{
+	structName:  "Int64Slice",
+	packageName: "pcommon",
+	itemType:    "int64",
+
+	testOrigVal:          "1, 2, 3",
+	testInterfaceOrigVal: []any{1, 2, 3},
+	testSetVal:           "5",
+	testNewVal:           "1, 5, 3",
+}
+
+var stringSlice = &primitiveSliceStruct{
+	structName:  "StringSlice",
+	packageName: "pcommon",
+	itemType:    "string",
+
+	testOrigVal:          `"a", "b", "c"`,
+	testInterfaceOrigVal: []any{`"a"`, `"b"`, `"c"`},
+	testSetVal:           `"d"`,
+	testNewVal:           `"a", "d", "c"`,
 }
@@ -10,12 +10,16 @@ var plogotlp = &Package{
 	name: "plogotlp",
 	path: filepath.Join("plog", "plogotlp"),
 	imports: []string{
+		`"go.opentelemetry.io/collector/pdata/internal"`,
 		`otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"`,
 	},
 	testImports: []string{
 		`"testing"`,
 		``,
 		`"github.com/stretchr/testify/assert"`,
+		``,
+		`"go.opentelemetry.io/collector/pdata/internal"`,
+		`otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"`,
 	},
 	structs: []baseStruct{
 		exportLogsPartialSuccess,
@@ -11,12 +11,16 @@ var pmetricotlp = &Package{
 	name: "pmetricotlp",
 	path: filepath.Join("pmetric", "pmetricotlp"),
 	imports: []string{
+		`"go.opentelemetry.io/collector/pdata/internal"`,
 		`otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"`,
 	},
 	testImports: []string{
 		`"testing"`,
 		``,
 		`"github.com/stretchr/testify/assert"`,
+		``,
+		`"go.opentelemetry.io/collector/pdata/internal"`,
+		`otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"`,
 	},
 	structs: []baseStruct{
 		exportMetricsPartialSuccess,
@@ -0,0 +1,633 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal/cmd/pdatagen/internal"
+
+var pprofile = &Package{
+	name: "pprofile",
+	path: "pprofile",
+	imports: []string{
+		`"sort"`,
+		``,
+		`"go.opentelemetry.io/collector/pdata/internal"`,
+		`"go.opentelemetry.io/collector/pdata/internal/data"`,
+		`otlpprofiles "go.opentelemetry.io/collector/pdata/internal/data/protogen/profiles/v1experimental"`,
+		`"go.opentelemetry.io/collector/pdata/pcommon"`,
+	},
+	testImports: []string{
+		`"testing"`,
+		`"unsafe"`,
+		``,
+		`"github.com/stretchr/testify/assert"`,
+		``,
+		`"go.opentelemetry.io/collector/pdata/internal"`,
+		`"go.opentelemetry.io/collector/pdata/internal/data"`,
+		`otlpprofiles "go.opentelemetry.io/collector/pdata/internal/data/protogen/profiles/v1experimental"`,
+		`"go.opentelemetry.io/collector/pdata/pcommon"`,
+	},
+	structs: []baseStruct{
+		resourceProfilesSlice,
+		resourceProfiles,
+		scopeProfilesSlice,
+		scopeProfiles,
+		profilesContainersSlice,
+		profileContainer,
+		profile,
+		attributeUnitSlice,
+		attributeUnit,
+		linkSlice,
+		link,
+		valueTypeSlice,
+		valueType,
+		sampleSlice,
+		sample,
+		labelSlice,
+		label,
+		mappingSlice,
+		mapping,
+		locationSlice,
+		location,
+		lineSlice,
+		line,
+		functionSlice,
+		function,
+	},
+}
+
+var resourceProfilesSlice = &sliceOfPtrs{
+	structName: "ResourceProfilesSlice",
+	element:    resourceProfiles,
+}
+
+var resourceProfiles = &messageValueStruct{
+	structName:     "ResourceProfiles",
+	description:    "// ResourceProfiles is a collection of profiles from a Resource.",
+	originFullName: "otlpprofiles.ResourceProfiles",
+	fields: []baseField{
+		resourceField,
+		schemaURLField,
+		&sliceField{
+			fieldName:   "ScopeProfiles",
+			returnSlice: scopeProfilesSlice,
+		},
+	},
+}
+
+var scopeProfilesSlice = &sliceOfPtrs{
+	structName: "ScopeProfilesSlice",
+	element:    scopeProfiles,
+}
+
+var scopeProfiles = &messageValueStruct{
+	structName:     "ScopeProfiles",
+	description:    "// ScopeProfiles is a collection of profiles from a LibraryInstrumentation.",
+	originFullName: "otlpprofiles.ScopeProfiles",
+	fields: []baseField{
+		scopeField,
+		schemaURLField,
+		&sliceField{
+			fieldName:   "Profiles",
+			returnSlice: profilesContainersSlice,
+		},
+	},
+}
+
+var profilesContainersSlice = &sliceOfPtrs{
+	structName: "ProfilesContainersSlice",
+	element:    profileContainer,
+}
+
+var profileContainer = &messageValueStruct{
+	structName: "ProfileContainer",
+	description: "// ProfileContainer is a single profile, along with the metadata shared with the other signals.\n" +
+		"// See ProfileContainer definition in OTLP: https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/profiles/v1experimental/profiles.proto",
+	originFullName: "otlpprofiles.ProfileContainer",
+	fields: []baseField{
+		&primitiveTypedField{
+			fieldName:       "ProfileID",
+			originFieldName: "ProfileId",
+			returnType: &primitiveType{
+				structName: "ProfileID",
+				rawType:    "data.ProfileID",
+				defaultVal: "data.ProfileID([16]byte{})",
+				testVal:    "data.ProfileID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})",
+			},
+		},
+		startTimeField,
+		endTimeField,
+		attributes,
+		droppedAttributesCount,
+		&primitiveField{
+			fieldName:  "OriginalPayloadFormat",
+			returnType: "string",
+			defaultVal: `""`,
+			testVal:    `"pprof"`,
+		},
+		&primitiveSliceField{
+			fieldName:         "OriginalPayload",
+			returnType:        "ByteSlice",
+			returnPackageName: "pcommon",
+			defaultVal:        "[]byte(nil)",
+			rawType:           "[]byte",
+			testVal:           "[]byte{1, 2, 3}",
+		},
+		&messageValueField{
+			fieldName:     "Profile",
+			returnMessage: profile,
+		},
+	},
+}
+
+var profile = &messageValueStruct{
+	structName: "Profile",
+	description: "// Profile is a collection of samples, along with the tables (locations, functions, strings...)\n" +
+		"// their values are referring to, following the pprof data model.",
+	originFullName: "otlpprofiles.Profile",
+	fields: []baseField{
+		&sliceField{
+			fieldName:   "SampleType",
+			returnSlice: valueTypeSlice,
+		},
+		&sliceField{
+			fieldName:   "Sample",
+			returnSlice: sampleSlice,
+		},
+		&sliceField{
+			fieldName:   "Mapping",
+			returnSlice: mappingSlice,
+		},
+		&sliceField{
+			fieldName:   "Location",
+			returnSlice: locationSlice,
+		},
+		&primitiveSliceField{
+			fieldName:         "LocationIndices",
+			returnType:        "Int64Slice",
+			returnPackageName: "pcommon",
+			defaultVal:        "[]int64(nil)",
+			rawType:           "[]int64",
+			testVal:           "[]int64{1, 2, 3}",
+		},
+		&sliceField{
+			fieldName:   "Function",
+			returnSlice: functionSlice,
+		},
+		&sliceField{
+			fieldName:   "AttributeTable",
+			returnSlice: mapStruct,
+		},
+		&sliceField{
+			fieldName:   "AttributeUnits",
+			returnSlice: attributeUnitSlice,
+		},
+		&sliceField{
+			fieldName:   "LinkTable",
+			returnSlice: linkSlice,
+		},
+		&primitiveSliceField{
+			fieldName:         "StringTable",
+			returnType:        "StringSlice",
+			returnPackageName: "pcommon",
+			defaultVal:        "[]string(nil)",
+			rawType:           "[]string",
+			testVal:           `[]string{"", "cpu", "nanoseconds"}`,
+		},
+		&primitiveField{
+			fieldName:  "DropFrames",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(1)",
+		},
+		&primitiveField{
+			fieldName:  "KeepFrames",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(2)",
+		},
+		&primitiveTypedField{
+			fieldName:       "Time",
+			originFieldName: "TimeNanos",
+			returnType: &primitiveType{
+				structName:  "Timestamp",
+				packageName: "pcommon",
+				rawType:     "int64",
+				defaultVal:  "0",
+				testVal:     "1234567890",
+			},
+		},
+		&primitiveField{
+			fieldName:  "DurationNanos",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(1000000000)",
+		},
+		&messageValueField{
+			fieldName:     "PeriodType",
+			returnMessage: valueType,
+		},
+		&primitiveField{
+			fieldName:  "Period",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(10000000)",
+		},
+		&primitiveSliceField{
+			fieldName:         "Comment",
+			returnType:        "Int64Slice",
+			returnPackageName: "pcommon",
+			defaultVal:        "[]int64(nil)",
+			rawType:           "[]int64",
+			testVal:           "[]int64{1, 2}",
+		},
+		&primitiveField{
+			fieldName:  "DefaultSampleType",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(1)",
+		},
+	},
+}
+
+var attributeUnitSlice = &sliceOfPtrs{
+	structName: "AttributeUnitSlice",
+	element:    attributeUnit,
+}
+
+var attributeUnit = &messageValueStruct{
+	structName:     "AttributeUnit",
+	description:    "// AttributeUnit describes the unit of the values of an attribute, both stored in the string table of the Profile.",
+	originFullName: "otlpprofiles.AttributeUnit",
+	fields: []baseField{
+		&primitiveField{
+			fieldName:  "AttributeKey",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(1)",
+		},
+		&primitiveField{
+			fieldName:  "Unit",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(2)",
+		},
+	},
+}
+
+var linkSlice = &sliceOfPtrs{
+	structName: "LinkSlice",
+	element:    link,
+}
+
+var link = &messageValueStruct{
+	structName:     "Link",
+	description:    "// Link is a pointer from a profile sample to a trace span.",
+	originFullName: "otlpprofiles.Link",
+	fields: []baseField{
+		traceIDField,
+		spanIDField,
+	},
+}
+
+var valueTypeSlice = &sliceOfPtrs{
+	structName: "ValueTypeSlice",
+	element:    valueType,
+}
+
+var valueType = &messageValueStruct{
+	structName:     "ValueType",
+	description:    "// ValueType describes the type and units of a value, both stored in the string table of the Profile.",
+	originFullName: "otlpprofiles.ValueType",
+	fields: []baseField{
+		&primitiveField{
+			fieldName:  "Type",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(1)",
+		},
+		&primitiveField{
+			fieldName:  "Unit",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(2)",
+		},
+		&primitiveTypedField{
+			fieldName: "AggregationTemporality",
+			returnType: &primitiveType{
+				structName: "AggregationTemporality",
+				rawType:    "otlpprofiles.AggregationTemporality",
+				defaultVal: "otlpprofiles.AggregationTemporality(0)",
+				testVal:    "otlpprofiles.AggregationTemporality(1)",
+			},
+		},
+	},
+}
+
+var sampleSlice = &sliceOfPtrs{
+	structName: "SampleSlice",
+	element:    sample,
+}
+
+var sample = &messageValueStruct{
+	structName:     "Sample",
+	description:    "// Sample is a set of values recorded for a stack trace, along with its labels and attributes.",
+	originFullName: "otlpprofiles.Sample",
+	fields: []baseField{
+		&primitiveSliceField{
+			fieldName:         "LocationIndex",
+			returnType:        "UInt64Slice",
+			returnPackageName: "pcommon",
+			defaultVal:        "[]uint64(nil)",
+			rawType:           "[]uint64",
+			testVal:           "[]uint64{1, 2, 3}",
+		},
+		&primitiveField{
+			fieldName:  "LocationsStartIndex",
+			returnType: "uint64",
+			defaultVal: "uint64(0)",
+			testVal:    "uint64(1)",
+		},
+		&primitiveField{
+			fieldName:  "LocationsLength",
+			returnType: "uint64",
+			defaultVal: "uint64(0)",
+			testVal:    "uint64(3)",
+		},
+		&primitiveField{
+			fieldName:       "StacktraceIDIndex",
+			originFieldName: "StacktraceIdIndex",
+			returnType:      "uint32",
+			defaultVal:      "uint32(0)",
+			testVal:         "uint32(1)",
+		},
+		&primitiveSliceField{
+			fieldName:         "Value",
+			returnType:        "Int64Slice",
+			returnPackageName: "pcommon",
+			defaultVal:        "[]int64(nil)",
+			rawType:           "[]int64",
+			testVal:           "[]int64{1, 2, 3}",
+		},
+		&sliceField{
+			fieldName:   "Label",
+			returnSlice: labelSlice,
+		},
+		attributeIndicesField,
+		&primitiveField{
+			fieldName:  "Link",
+			returnType: "uint64",
+			defaultVal: "uint64(0)",
+			testVal:    "uint64(1)",
+		},
+		&primitiveSliceField{
+			fieldName:         "TimestampsUnixNano",
+			returnType:        "UInt64Slice",
+			returnPackageName: "pcommon",
+			defaultVal:        "[]uint64(nil)",
+			rawType:           "[]uint64",
+			testVal:           "[]uint64{1234567890}",
+		},
+	},
+}
+
+var labelSlice = &sliceOfPtrs{
+	structName: "LabelSlice",
+	element:    label,
+}
+
+var label = &messageValueStruct{
+	structName:     "Label",
+	description:    "// Label is a pprof label attached to a Sample, with either a string or a numeric value.",
+	originFullName: "otlpprofiles.Label",
+	fields: []baseField{
+		&primitiveField{
+			fieldName:  "Key",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(1)",
+		},
+		&primitiveField{
+			fieldName:  "Str",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(2)",
+		},
+		&primitiveField{
+			fieldName:  "Num",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(3)",
+		},
+		&primitiveField{
+			fieldName:  "NumUnit",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(4)",
+		},
+	},
+}
+
+var mappingSlice = &sliceOfPtrs{
+	structName: "MappingSlice",
+	element:    mapping,
+}
+
+var mapping = &messageValueStruct{
+	structName:     "Mapping",
+	description:    "// Mapping describes a binary or a shared library mapped into the memory of the profiled process.",
+	originFullName: "otlpprofiles.Mapping",
+	fields: []baseField{
+		idField,
+		&primitiveField{
+			fieldName:  "MemoryStart",
+			returnType: "uint64",
+			defaultVal: "uint64(0)",
+			testVal:    "uint64(1)",
+		},
+		&primitiveField{
+			fieldName:  "MemoryLimit",
+			returnType: "uint64",
+			defaultVal: "uint64(0)",
+			testVal:    "uint64(2)",
+		},
+		&primitiveField{
+			fieldName:  "FileOffset",
+			returnType: "uint64",
+			defaultVal: "uint64(0)",
+			testVal:    "uint64(3)",
+		},
+		filenameField,
+		&primitiveField{
+			fieldName:       "BuildID",
+			originFieldName: "BuildId",
+			returnType:      "int64",
+			defaultVal:      "int64(0)",
+			testVal:         "int64(5)",
+		},
+		&primitiveTypedField{
+			fieldName:       "BuildIDKind",
+			originFieldName: "BuildIdKind",
+			returnType: &primitiveType{
+				structName: "BuildIDKind",
+				rawType:    "otlpprofiles.BuildIdKind",
+				defaultVal: "otlpprofiles.BuildIdKind(0)",
+				testVal:    "otlpprofiles.BuildIdKind(1)",
+			},
+		},
+		attributeIndicesField,
+		&primitiveField{
+			fieldName:  "HasFunctions",
+			returnType: "bool",
+			defaultVal: "false",
+			testVal:    "true",
+		},
+		&primitiveField{
+			fieldName:  "HasFilenames",
+			returnType: "bool",
+			defaultVal: "false",
+			testVal:    "true",
+		},
+		&primitiveField{
+			fieldName:  "HasLineNumbers",
+			returnType: "bool",
+			defaultVal: "false",
+			testVal:    "true",
+		},
+		&primitiveField{
+			fieldName:  "HasInlineFrames",
+			returnType: "bool",
+			defaultVal: "false",
+			testVal:    "true",
+		},
+	},
+}
+
+var locationSlice = &sliceOfPtrs{
+	structName: "LocationSlice",
+	element:    location,
+}
+
+var location = &messageValueStruct{
+	structName:     "Location",
+	description:    "// Location describes a function and line table debug information, at a given address.",
+	originFullName: "otlpprofiles.Location",
+	fields: []baseField{
+		idField,
+		&primitiveField{
+			fieldName:  "MappingIndex",
+			returnType: "uint64",
+			defaultVal: "uint64(0)",
+			testVal:    "uint64(1)",
+		},
+		&primitiveField{
+			fieldName:  "Address",
+			returnType: "uint64",
+			defaultVal: "uint64(0)",
+			testVal:    "uint64(2)",
+		},
+		&sliceField{
+			fieldName:   "Line",
+			returnSlice: lineSlice,
+		},
+		&primitiveField{
+			fieldName:  "IsFolded",
+			returnType: "bool",
+			defaultVal: "false",
+			testVal:    "true",
+		},
+		&primitiveField{
+			fieldName:  "TypeIndex",
+			returnType: "uint32",
+			defaultVal: "uint32(0)",
+			testVal:    "uint32(1)",
+		},
+		attributeIndicesField,
+	},
+}
+
+var lineSlice = &sliceOfPtrs{
+	structName: "LineSlice",
+	element:    line,
+}
+
+var line = &messageValueStruct{
+	structName:     "Line",
+	description:    "// Line is a source code line of a Location, with the Function it belongs to.",
+	originFullName: "otlpprofiles.Line",
+	fields: []baseField{
+		&primitiveField{
+			fieldName:  "FunctionIndex",
+			returnType: "uint64",
+			defaultVal: "uint64(0)",
+			testVal:    "uint64(1)",
+		},
+		&primitiveField{
+			fieldName:  "Line",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(2)",
+		},
+		&primitiveField{
+			fieldName:  "Column",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(3)",
+		},
+	},
+}
+
+var functionSlice = &sliceOfPtrs{
+	structName: "FunctionSlice",
+	element:    function,
+}
+
+var function = &messageValueStruct{
+	structName:     "Function",
+	description:    "// Function describes a function, whose names and source file are stored in the string table of the Profile.",
+	originFullName: "otlpprofiles.Function",
+	fields: []baseField{
+		idField,
+		&primitiveField{
+			fieldName:  "Name",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(1)",
+		},
+		&primitiveField{
+			fieldName:  "SystemName",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(2)",
+		},
+		filenameField,
+		&primitiveField{
+			fieldName:  "StartLine",
+			returnType: "int64",
+			defaultVal: "int64(0)",
+			testVal:    "int64(4)",
+		},
+	},
+}
+
+var idField = &primitiveField{
+	fieldName:       "ID",
+	originFieldName: "Id",
+	returnType:      "uint64",
+	defaultVal:      "uint64(0)",
+	testVal:         "uint64(1)",
+}
+
+var filenameField = &primitiveField{
+	fieldName:  "Filename",
+	returnType: "int64",
+	defaultVal: "int64(0)",
+	testVal:    "int64(3)",
+}
+
+var attributeIndicesField = &primitiveSliceField{
+	fieldName:         "Attributes",
+	returnType:        "UInt64Slice",
+	returnPackageName: "pcommon",
+	defaultVal:        "[]uint64(nil)",
+	rawType:           "[]uint64",
+	testVal:           "[]uint64{1, 2}",
+}
@@ -20,10 +20,15 @@ func (ms {{ .structName }}) getOrig() *[]{{ .itemType }} {
 	return internal.GetOrig{{ .structName }}(internal.{{ .structName }}(ms))
 }
 
+func (ms {{ .structName }}) getState() *internal.State {
+	return internal.Get{{ .structName }}State(internal.{{ .structName }}(ms))
+}
+
 // New{{ .structName }} creates a new empty {{ .structName }}.
 func New{{ .structName }}() {{ .structName }} {
 	orig := []{{ .itemType }}(nil)
-	return {{ .structName }}(internal.New{{ .structName }}(&orig))
+	state := internal.StateMutable
+	return {{ .structName }}(internal.New{{ .structName }}(&orig, &state))
 }
 
 // AsRaw returns a copy of the []{{ .itemType }} slice.
@@ -33,6 +38,7 @@ func (ms {{ .structName }}) AsRaw() []{{ .itemType }} {
 
 // FromRaw copies raw []{{ .itemType }} into the slice {{ .structName }}.
 func (ms {{ .structName }}) FromRaw(val []{{ .itemType }}) {
+	ms.getState().AssertMutable()
 	*ms.getOrig() = copy{{ .structName }}(*ms.getOrig(), val)
 }
 
@@ -51,6 +57,7 @@ func (ms {{ .structName }}) At(i int) {{ .itemType }} {
 // SetAt sets {{ .itemType }} item at particular index.
 // Equivalent of {{ .lowerStructName }}[i] = val
 func (ms {{ .structName }}) SetAt(i int, val {{ .itemType }}) {
+	ms.getState().AssertMutable()
 	(*ms.getOrig())[i] = val
 }
 
@@ -61,6 +68,7 @@ func (ms {{ .structName }}) SetAt(i int, val {{ .itemType }}) {
 //	copy(buf, {{ .lowerStructName }})
 //	{{ .lowerStructName }} = buf
 func (ms {{ .structName }}) EnsureCapacity(newCap int) {
+	ms.getState().AssertMutable()
 	oldCap := cap(*ms.getOrig())
 	if newCap <= oldCap {
 		return
@@ -74,18 +82,22 @@ func (ms {{ .structName }}) EnsureCapacity(newCap int) {
 // Append appends extra elements to {{ .structName }}.
 // Equivalent of {{ .lowerStructName }} = append({{ .lowerStructName }}, elms...) 
 func (ms {{ .structName }}) Append(elms ...{{ .itemType }}) {
+	ms.getState().AssertMutable()
 	*ms.getOrig() = append(*ms.getOrig(), elms...)
 }
 
 // MoveTo moves all elements from the current slice overriding the destination and 
 // resetting the current instance to its zero value.
 func (ms {{ .structName }}) MoveTo(dest {{ .structName }}) {
+	ms.getState().AssertMutable()
+	dest.getState().AssertMutable()
 	*dest.getOrig() = *ms.getOrig()
 	*ms.getOrig() = nil
 }
 
 // CopyTo copies all elements from the current slice overriding the destination.
 func (ms {{ .structName }}) CopyTo(dest {{ .structName }}) {
+	dest.getState().AssertMutable()
 	*dest.getOrig() = copy{{ .structName }}(*dest.getOrig(), *ms.getOrig())
 }
 
@@ -97,40 +109,50 @@ func copy{{ .structName }}(dst, src []{{ .itemType }}) []{{ .itemType }} {
 const immutableSliceTestTemplate = `func TestNew{{ .structName }}(t *testing.T) {
 	ms := New{{ .structName }}()
 	assert.Equal(t, 0, ms.Len())
-	ms.FromRaw([]{{ .itemType }}{1, 2, 3})
+	ms.FromRaw([]{{ .itemType }}{ {{ .testOrigVal }} })
 	assert.Equal(t, 3, ms.Len())
-	assert.Equal(t, []{{ .itemType }}{1, 2, 3}, ms.AsRaw())
-	ms.SetAt(1, {{ .itemType }}(5))
-	assert.Equal(t, []{{ .itemType }}{1, 5, 3}, ms.AsRaw())
-	ms.FromRaw([]{{ .itemType }}{3})
+	assert.Equal(t, []{{ .itemType }}{ {{ .testOrigVal }} }, ms.AsRaw())
+	ms.SetAt(1, {{ .itemType }}({{ .testSetVal }}))
+	assert.Equal(t, []{{ .itemType }}{ {{ .testNewVal }} }, ms.AsRaw())
+	ms.FromRaw([]{{ .itemType }}{ {{ index .testInterfaceOrigVal 2 }} })
 	assert.Equal(t, 1, ms.Len())
-	assert.Equal(t, {{ .itemType }}(3), ms.At(0))
+	assert.Equal(t, {{ .itemType }}({{ index .testInterfaceOrigVal 2 }}), ms.At(0))
 	
 	cp := New{{ .structName }}()
 	ms.CopyTo(cp)
-	ms.SetAt(0, {{ .itemType }}(2))
-	assert.Equal(t, {{ .itemType }}(2), ms.At(0))
-	assert.Equal(t, {{ .itemType }}(3), cp.At(0))
+	ms.SetAt(0, {{ .itemType }}({{ index .testInterfaceOrigVal 1 }}))
+	assert.Equal(t, {{ .itemType }}({{ index .testInterfaceOrigVal 1 }}), ms.At(0))
+	assert.Equal(t, {{ .itemType }}({{ index .testInterfaceOrigVal 2 }}), cp.At(0))
 	ms.CopyTo(cp)
-	assert.Equal(t, {{ .itemType }}(2), cp.At(0))
+	assert.Equal(t, {{ .itemType }}({{ index .testInterfaceOrigVal 1 }}), cp.At(0))
 	
 	mv := New{{ .structName }}()
 	ms.MoveTo(mv)
 	assert.Equal(t, 0, ms.Len())
 	assert.Equal(t, 1, mv.Len())
-	assert.Equal(t, {{ .itemType }}(2), mv.At(0))
-	ms.FromRaw([]{{ .itemType }}{1, 2, 3})
+	assert.Equal(t, {{ .itemType }}({{ index .testInterfaceOrigVal 1 }}), mv.At(0))
+	ms.FromRaw([]{{ .itemType }}{ {{ .testOrigVal }} })
 	ms.MoveTo(mv)
 	assert.Equal(t, 3, mv.Len())
-	assert.Equal(t, {{ .itemType }}(1), mv.At(0))
+	assert.Equal(t, {{ .itemType }}({{ index .testInterfaceOrigVal 0 }}), mv.At(0))
+	sharedState := internal.StateReadOnly
+	readOnly := {{ .structName }}(internal.New{{ .structName }}(&[]{{ .itemType }}{ {{ index .testInterfaceOrigVal 0 }} }, &sharedState))
+	assert.Equal(t, {{ .itemType }}({{ index .testInterfaceOrigVal 0 }}), readOnly.At(0))
+	assert.Panics(t, func() { readOnly.SetAt(0, {{ .itemType }}({{ index .testInterfaceOrigVal 1 }})) })
+	assert.Panics(t, func() { readOnly.FromRaw([]{{ .itemType }}{ {{ index .testInterfaceOrigVal 1 }} }) })
+	assert.Panics(t, func() { readOnly.Append({{ .itemType }}({{ index .testInterfaceOrigVal 1 }})) })
+	assert.Panics(t, func() { readOnly.EnsureCapacity(2) })
+	assert.Panics(t, func() { ms.MoveTo(readOnly) })
+	assert.Panics(t, func() { readOnly.MoveTo(ms) })
+	assert.Panics(t, func() { ms.CopyTo(readOnly) })
 }
 
 func Test{{ .structName }}Append(t *testing.T) {
 	ms := New{{ .structName }}()
-	ms.FromRaw([]{{ .itemType }}{1, 2, 3})
-	ms.Append(4, 5)
+	ms.FromRaw([]{{ .itemType }}{ {{ .testOrigVal }} })
+	ms.Append({{ index .testInterfaceOrigVal 2 }}, {{ .testSetVal }})
 	assert.Equal(t, 5, ms.Len())
-	assert.Equal(t, {{ .itemType }}(5), ms.At(4))
+	assert.Equal(t, {{ .itemType }}({{ .testSetVal }}), ms.At(4))
 }
 
 func Test{{ .structName }}EnsureCapacity(t *testing.T) {
@@ -143,15 +165,20 @@ func Test{{ .structName }}EnsureCapacity(t *testing.T) {
 
 const primitiveSliceInternalTemplate = `
 type {{ .structName }} struct {
-	orig *[]{{ .itemType }}
+	orig  *[]{{ .itemType }}
+	state *State
 }
 
 func GetOrig{{ .structName }}(ms {{ .structName }}) *[]{{ .itemType }} {
 	return ms.orig
 }
 
-func New{{ .structName }}(orig *[]{{ .itemType }}) {{ .structName }} {
-	return {{ .structName }}{orig: orig}
+func Get{{ .structName }}State(ms {{ .structName }}) *State {
+	return ms.state
+}
+
+func New{{ .structName }}(orig *[]{{ .itemType }}, state *State) {{ .structName }} {
+	return {{ .structName }}{orig: orig, state: state}
 }`
 
 // primitiveSliceStruct generates a struct for a slice of primitive value elements. The structs are always generated
@@ -160,6 +187,11 @@ type primitiveSliceStruct struct {
 	structName  string
 	packageName string
 	itemType    string
+
+	testOrigVal          string
+	testInterfaceOrigVal []any
+	testSetVal           string
+	testNewVal           string
 }
 
 func (iss *primitiveSliceStruct) getName() string {
@@ -195,8 +227,12 @@ func (iss *primitiveSliceStruct) generateInternal(sb *bytes.Buffer) {
 
 func (iss *primitiveSliceStruct) templateFields() map[string]any {
 	return map[string]any{
-		"structName":      iss.structName,
-		"itemType":        iss.itemType,
-		"lowerStructName": strings.ToLower(iss.structName[:1]) + iss.structName[1:],
+		"structName":           iss.structName,
+		"itemType":             iss.itemType,
+		"lowerStructName":      strings.ToLower(iss.structName[:1]) + iss.structName[1:],
+		"testOrigVal":          iss.testOrigVal,
+		"testInterfaceOrigVal": iss.testInterfaceOrigVal,
+		"testSetVal":           iss.testSetVal,
+		"testNewVal":           iss.testNewVal,
 	}
 }
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package data // import "go.opentelemetry.io/collector/pdata/internal/data"
+
+import (
+	"errors"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+const profileIDSize = 16
+
+var (
+	errMarshalProfileID   = errors.New("marshal: invalid buffer length for ProfileID")
+	errUnmarshalProfileID = errors.New("unmarshal: invalid ProfileID length")
+)
+
+// ProfileID is a custom data type that is used for all profile_id fields in OTLP
+// Protobuf messages.
+type ProfileID [profileIDSize]byte
+
+var _ proto.Sizer = (*ProfileID)(nil)
+
+// Size returns the size of the data to serialize.
+func (pid ProfileID) Size() int {
+	if pid.IsEmpty() {
+		return 0
+	}
+	return profileIDSize
+}
+
+// IsEmpty returns true if id contains at leas one non-zero byte.
+func (pid ProfileID) IsEmpty() bool {
+	return pid == [profileIDSize]byte{}
+}
+
+// MarshalTo converts profile ID into a binary representation. Called by Protobuf serialization.
+func (pid ProfileID) MarshalTo(data []byte) (n int, err error) {
+	if pid.IsEmpty() {
+		return 0, nil
+	}
+
+	if len(data) < profileIDSize {
+		return 0, errMarshalProfileID
+	}
+
+	return copy(data, pid[:]), nil
+}
+
+// Unmarshal inflates this profile ID from binary representation. Called by Protobuf serialization.
+func (pid *ProfileID) Unmarshal(data []byte) error {
+	if len(data) == 0 {
+		*pid = [profileIDSize]byte{}
+		return nil
+	}
+
+	if len(data) != profileIDSize {
+		return errUnmarshalProfileID
+	}
+
+	copy(pid[:], data)
+	return nil
+}
+
+// MarshalJSON converts profile id into a hex string enclosed in quotes.
+func (pid ProfileID) MarshalJSON() ([]byte, error) {
+	if pid.IsEmpty() {
+		return []byte(`""`), nil
+	}
+	return marshalJSON(pid[:])
+}
+
+// UnmarshalJSON inflates profile id from hex string, possibly enclosed in quotes.
+// Called by Protobuf JSON deserialization.
+func (pid *ProfileID) UnmarshalJSON(data []byte) error {
+	*pid = [profileIDSize]byte{}
+	return unmarshalJSON(pid[:], data)
+}
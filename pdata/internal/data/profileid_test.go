@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileID(t *testing.T) {
+	pid := ProfileID([16]byte{})
+	assert.EqualValues(t, [16]byte{}, pid)
+	assert.EqualValues(t, 0, pid.Size())
+
+	b := [16]byte{0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78}
+	pid = b
+	assert.EqualValues(t, b, pid)
+	assert.EqualValues(t, 16, pid.Size())
+}
+
+func TestProfileIDMarshal(t *testing.T) {
+	buf := make([]byte, 20)
+
+	pid := ProfileID([16]byte{})
+	n, err := pid.MarshalTo(buf)
+	assert.EqualValues(t, 0, n)
+	assert.NoError(t, err)
+
+	pid = [16]byte{0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78}
+	n, err = pid.MarshalTo(buf)
+	assert.EqualValues(t, 16, n)
+	assert.EqualValues(t, []byte{0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78}, buf[0:16])
+	assert.NoError(t, err)
+
+	_, err = pid.MarshalTo(buf[0:1])
+	assert.Error(t, err)
+}
+
+func TestProfileIDMarshalJSON(t *testing.T) {
+	pid := ProfileID([16]byte{})
+	json, err := pid.MarshalJSON()
+	assert.EqualValues(t, []byte(`""`), json)
+	assert.NoError(t, err)
+
+	pid = [16]byte{0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78}
+	json, err = pid.MarshalJSON()
+	assert.EqualValues(t, []byte(`"12345678123456781234567812345678"`), json)
+	assert.NoError(t, err)
+}
+
+func TestProfileIDUnmarshal(t *testing.T) {
+	buf := [16]byte{0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78}
+
+	pid := ProfileID{}
+	err := pid.Unmarshal(buf[0:16])
+	assert.NoError(t, err)
+	assert.EqualValues(t, buf, pid)
+
+	err = pid.Unmarshal(buf[0:0])
+	assert.NoError(t, err)
+	assert.EqualValues(t, [16]byte{}, pid)
+
+	err = pid.Unmarshal(nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, [16]byte{}, pid)
+}
+
+func TestProfileIDUnmarshalJSON(t *testing.T) {
+	pid := ProfileID([16]byte{})
+	err := pid.UnmarshalJSON([]byte(`""`))
+	assert.NoError(t, err)
+	assert.EqualValues(t, [16]byte{}, pid)
+
+	err = pid.UnmarshalJSON([]byte(`""""`))
+	assert.Error(t, err)
+
+	pidBytes := [16]byte{0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78}
+	err = pid.UnmarshalJSON([]byte(`"12345678123456781234567812345678"`))
+	assert.NoError(t, err)
+	assert.EqualValues(t, pidBytes, pid)
+
+	err = pid.UnmarshalJSON([]byte(`12345678123456781234567812345678`))
+	assert.NoError(t, err)
+	assert.EqualValues(t, pidBytes, pid)
+
+	err = pid.UnmarshalJSON([]byte(`"nothex"`))
+	assert.Error(t, err)
+
+	err = pid.UnmarshalJSON([]byte(`"1"`))
+	assert.Error(t, err)
+
+	err = pid.UnmarshalJSON([]byte(`"123"`))
+	assert.Error(t, err)
+
+	err = pid.UnmarshalJSON([]byte(`"`))
+	assert.Error(t, err)
+}
@@ -212,34 +212,34 @@ func init() {
 }
 
 var fileDescriptor_8e3bf87aaa43acd4 = []byte{
-	// 430 bytes of a gzipped FileDescriptorProto
+	// 427 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0xc1, 0x6e, 0x13, 0x31,
-	0x10, 0x86, 0xd7, 0x2d, 0xaa, 0x84, 0xd3, 0x02, 0xb2, 0x7a, 0x08, 0x39, 0x2c, 0x55, 0x50, 0x51,
-	0xb8, 0x78, 0x49, 0xb8, 0x70, 0x03, 0x05, 0x71, 0x0b, 0x10, 0x6d, 0x11, 0x07, 0x2e, 0xab, 0xc5,
-	0x19, 0x59, 0x5b, 0x6d, 0x77, 0xdc, 0xb1, 0x13, 0xc1, 0x33, 0x20, 0x24, 0x5e, 0x80, 0x17, 0xe0,
-	0x49, 0x7a, 0xe0, 0xd0, 0x23, 0x27, 0x84, 0x92, 0x17, 0x41, 0x5e, 0x97, 0xb0, 0x0b, 0x39, 0x04,
-	0x4e, 0xbb, 0x1e, 0xcf, 0xff, 0xfd, 0xff, 0xd8, 0x32, 0x7f, 0x84, 0x06, 0x2a, 0x07, 0x25, 0x9c,
-	0x81, 0xa3, 0xf7, 0x89, 0x21, 0x74, 0x98, 0x28, 0x2c, 0x4b, 0x50, 0x0e, 0x29, 0x29, 0x51, 0xdb,
-	0x64, 0x31, 0xac, 0xbf, 0x99, 0x05, 0x5a, 0x14, 0x0a, 0x64, 0xdd, 0x24, 0x8e, 0x5b, 0xca, 0x50,
-	0x94, 0x6b, 0xa5, 0xf4, 0x0a, 0xb9, 0x18, 0xf6, 0x0e, 0x35, 0x6a, 0x0c, 0x58, 0xff, 0x17, 0xfa,
-	0x7a, 0xf7, 0x36, 0xd9, 0x36, 0xcd, 0x42, 0x5f, 0xff, 0x94, 0x77, 0x9f, 0xbd, 0x33, 0x48, 0x6e,
-	0x82, 0xda, 0x9e, 0x04, 0xff, 0x14, 0xce, 0xe7, 0x60, 0x9d, 0x78, 0xc1, 0x0f, 0x08, 0x2c, 0xce,
-	0x49, 0x41, 0xe6, 0x25, 0x5d, 0x76, 0xb4, 0x3b, 0xe8, 0x8c, 0xee, 0xcb, 0x4d, 0xc1, 0xae, 0xe2,
-	0xc8, 0xf4, 0x4a, 0xe1, 0x79, 0xe9, 0x3e, 0x35, 0x56, 0xfd, 0x0f, 0x8c, 0xdf, 0xde, 0x60, 0x66,
-	0x0d, 0x56, 0x16, 0x44, 0xc5, 0x6f, 0x9a, 0x9c, 0x5c, 0x91, 0x97, 0x99, 0x9d, 0x2b, 0x05, 0xd6,
-	0xfb, 0xb1, 0x41, 0x67, 0xf4, 0x58, 0x6e, 0x75, 0x10, 0xf2, 0x37, 0x7a, 0x1a, 0x38, 0x27, 0x01,
-	0x33, 0xbe, 0x76, 0xf1, 0xfd, 0x4e, 0x94, 0xde, 0x30, 0xad, 0x6a, 0xff, 0xbc, 0x39, 0x79, 0x5b,
-	0x21, 0x1e, 0xf0, 0x43, 0x82, 0x53, 0x50, 0x0e, 0x66, 0x7e, 0xf2, 0x8c, 0x40, 0x21, 0xcd, 0x42,
-	0xa0, 0xdd, 0x54, 0xfc, 0xda, 0x9b, 0xa0, 0x4e, 0xc3, 0x8e, 0xb8, 0xcb, 0x0f, 0x80, 0x08, 0x29,
-	0x3b, 0x03, 0x6b, 0x73, 0x0d, 0xdd, 0x9d, 0x23, 0x36, 0xb8, 0x9e, 0xee, 0xd7, 0xc5, 0xe7, 0xa1,
-	0x36, 0xfa, 0xcc, 0x78, 0xa7, 0x31, 0xba, 0xf8, 0xc8, 0xf8, 0x5e, 0xc8, 0x20, 0xfe, 0x7d, 0xc8,
-	0xf6, 0x65, 0xf5, 0x9e, 0xfc, 0x3f, 0x20, 0x5c, 0x40, 0x3f, 0x1a, 0x7f, 0x65, 0x17, 0xcb, 0x98,
-	0x5d, 0x2e, 0x63, 0xf6, 0x63, 0x19, 0xb3, 0x4f, 0xab, 0x38, 0xba, 0x5c, 0xc5, 0xd1, 0xb7, 0x55,
-	0x1c, 0xf1, 0x41, 0x81, 0xdb, 0x19, 0x8c, 0x6f, 0x35, 0xd8, 0x53, 0xdf, 0x33, 0x65, 0x6f, 0x26,
-	0xfa, 0x4f, 0x75, 0xd1, 0x7c, 0x04, 0x66, 0x96, 0xbb, 0x3c, 0x29, 0x2a, 0x07, 0x54, 0xe5, 0x65,
-	0x52, 0xaf, 0x6a, 0xbc, 0x86, 0xea, 0xef, 0xb7, 0xf2, 0x65, 0xe7, 0xf8, 0xa5, 0x81, 0xea, 0xd5,
-	0x9a, 0x55, 0xbb, 0xc8, 0xa7, 0xeb, 0x24, 0x3e, 0x80, 0x7c, 0x3d, 0x7c, 0xbb, 0x57, 0x33, 0x1e,
-	0xfe, 0x0c, 0x00, 0x00, 0xff, 0xff, 0xf0, 0xaf, 0x6c, 0x7d, 0x83, 0x03, 0x00, 0x00,
+	0x10, 0x86, 0xd7, 0x2d, 0xaa, 0x84, 0xd3, 0x02, 0xb2, 0x7a, 0x08, 0x39, 0x2c, 0x55, 0x50, 0xd1,
+	0x72, 0xf1, 0x92, 0x70, 0xe1, 0x06, 0x0a, 0xe2, 0x16, 0x20, 0xda, 0x22, 0x0e, 0x5c, 0x56, 0x8b,
+	0x33, 0xb2, 0xb6, 0xda, 0xee, 0xb8, 0x63, 0x27, 0x82, 0x67, 0x40, 0x48, 0xbc, 0x00, 0x2f, 0xc0,
+	0x93, 0xf4, 0xc0, 0xa1, 0x47, 0x4e, 0x08, 0x25, 0x2f, 0x82, 0xbc, 0x2e, 0x61, 0x03, 0x41, 0x2a,
+	0x3d, 0xed, 0x7a, 0x3c, 0xff, 0xf7, 0xff, 0x63, 0xcb, 0xfc, 0x11, 0x1a, 0xa8, 0x1d, 0x54, 0x70,
+	0x02, 0x8e, 0xde, 0xa7, 0x86, 0xd0, 0x61, 0xaa, 0xb0, 0xaa, 0x40, 0x39, 0xa4, 0xb4, 0x42, 0x6d,
+	0xd3, 0xf9, 0xa0, 0xf9, 0xe6, 0x16, 0x68, 0x5e, 0x2a, 0x90, 0x4d, 0x93, 0x38, 0x5c, 0x53, 0x86,
+	0xa2, 0x5c, 0x29, 0xa5, 0x57, 0xc8, 0xf9, 0xa0, 0xb7, 0xaf, 0x51, 0x63, 0xc0, 0xfa, 0xbf, 0xd0,
+	0xd7, 0xbb, 0xb7, 0xc9, 0xb6, 0x6d, 0x16, 0xfa, 0xfa, 0xc7, 0xbc, 0xfb, 0xec, 0x9d, 0x41, 0x72,
+	0x63, 0xd4, 0xf6, 0x28, 0xf8, 0x67, 0x70, 0x3a, 0x03, 0xeb, 0xc4, 0x0b, 0xbe, 0x47, 0x60, 0x71,
+	0x46, 0x0a, 0x72, 0x2f, 0xe9, 0xb2, 0x83, 0xed, 0xa4, 0x33, 0xbc, 0x2f, 0x37, 0x05, 0xbb, 0x88,
+	0x23, 0xb3, 0x0b, 0x85, 0xe7, 0x65, 0xbb, 0xd4, 0x5a, 0xf5, 0x3f, 0x30, 0x7e, 0x7b, 0x83, 0x99,
+	0x35, 0x58, 0x5b, 0x10, 0x35, 0xbf, 0x69, 0x0a, 0x72, 0x65, 0x51, 0xe5, 0x76, 0xa6, 0x14, 0x58,
+	0xef, 0xc7, 0x92, 0xce, 0xf0, 0xb1, 0xbc, 0xd4, 0x41, 0xc8, 0xdf, 0xe8, 0x49, 0xe0, 0x1c, 0x05,
+	0xcc, 0xe8, 0xda, 0xd9, 0xf7, 0x3b, 0x51, 0x76, 0xc3, 0xac, 0x55, 0xfb, 0xa7, 0xbc, 0xfb, 0x2f,
+	0x85, 0x78, 0xc0, 0xf7, 0x09, 0x8e, 0x41, 0x39, 0x98, 0xfa, 0xc9, 0x73, 0x02, 0x85, 0x34, 0x0d,
+	0x81, 0xb6, 0x33, 0xf1, 0x6b, 0x6f, 0x8c, 0x3a, 0x0b, 0x3b, 0xe2, 0x2e, 0xdf, 0x03, 0x22, 0xa4,
+	0xfc, 0x04, 0xac, 0x2d, 0x34, 0x74, 0xb7, 0x0e, 0x58, 0x72, 0x3d, 0xdb, 0x6d, 0x8a, 0xcf, 0x43,
+	0x6d, 0xf8, 0x99, 0xf1, 0x4e, 0x6b, 0x74, 0xf1, 0x91, 0xf1, 0x9d, 0x90, 0x41, 0xfc, 0xff, 0x90,
+	0xeb, 0x97, 0xd5, 0x7b, 0x72, 0x75, 0x40, 0xb8, 0x80, 0x7e, 0x34, 0xfa, 0xca, 0xce, 0x16, 0x31,
+	0x3b, 0x5f, 0xc4, 0xec, 0xc7, 0x22, 0x66, 0x9f, 0x96, 0x71, 0x74, 0xbe, 0x8c, 0xa3, 0x6f, 0xcb,
+	0x38, 0xe2, 0x49, 0x89, 0x97, 0x33, 0x18, 0xdd, 0x6a, 0xb1, 0x27, 0xbe, 0x67, 0xc2, 0xde, 0x8c,
+	0xf5, 0x9f, 0xea, 0xb2, 0xfd, 0x08, 0xcc, 0xb4, 0x70, 0x45, 0x5a, 0xd6, 0x0e, 0xa8, 0x2e, 0xaa,
+	0xb4, 0x59, 0x35, 0x78, 0x0d, 0xf5, 0xdf, 0x6f, 0xe5, 0xcb, 0xd6, 0xe1, 0x4b, 0x03, 0xf5, 0xab,
+	0x15, 0xab, 0x71, 0x91, 0x4f, 0x57, 0x49, 0x7c, 0x00, 0xf9, 0x7a, 0xf0, 0x76, 0xa7, 0x61, 0x3c,
+	0xfc, 0x39, 0x00, 0xf0, 0xaf, 0x6c, 0x7d, 0x83, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -212,34 +212,34 @@ func init() {
 }
 
 var fileDescriptor_75fb6015e6e64798 = []byte{
-	// 427 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0xbf, 0x8e, 0xd3, 0x30,
-	0x18, 0x8f, 0xef, 0xd0, 0x49, 0xf8, 0xe0, 0x0e, 0x99, 0x1b, 0x4e, 0x05, 0x85, 0x53, 0x58, 0x22,
-	0x81, 0x1c, 0x5a, 0x76, 0x86, 0xc2, 0xb1, 0x9d, 0x1a, 0xa5, 0x88, 0xa1, 0x4b, 0x64, 0xdc, 0x4f,
-	0x51, 0x50, 0x1a, 0x1b, 0xdb, 0xad, 0xe8, 0x5b, 0x30, 0xb0, 0xf0, 0x0a, 0x88, 0x07, 0xe9, 0xd8,
-	0xb1, 0x13, 0x42, 0xed, 0x8b, 0xa0, 0xc4, 0x69, 0xc1, 0x25, 0x43, 0xc5, 0x6d, 0xce, 0xcf, 0xdf,
-	0xef, 0x4f, 0x7e, 0xd6, 0x87, 0x5f, 0x09, 0x09, 0xa5, 0x81, 0x02, 0x26, 0x60, 0xd4, 0x3c, 0x92,
-	0x4a, 0x18, 0x11, 0x71, 0x51, 0x14, 0xc0, 0x8d, 0x50, 0x51, 0x85, 0xe6, 0x5c, 0x47, 0xb3, 0xee,
-	0xf6, 0x98, 0x6a, 0x50, 0xb3, 0x9c, 0x03, 0xad, 0x47, 0x49, 0xe8, 0xf0, 0x2d, 0x48, 0x77, 0x7c,
-	0xda, 0x90, 0xe8, 0xac, 0xdb, 0xb9, 0xc8, 0x44, 0x26, 0xac, 0x7e, 0x75, 0xb2, 0xa3, 0x9d, 0xe7,
-	0x6d, 0xfe, 0xff, 0xba, 0xda, 0xe9, 0x60, 0x8e, 0x1f, 0x5d, 0x7f, 0x96, 0x42, 0x99, 0x1b, 0x0b,
-	0x0f, 0x6d, 0x96, 0x04, 0x3e, 0x4d, 0x41, 0x1b, 0x32, 0xc2, 0x0f, 0x14, 0x68, 0x31, 0x55, 0x1c,
-	0xd2, 0x86, 0x78, 0x89, 0xae, 0x8e, 0xc3, 0xd3, 0x5e, 0x44, 0xdb, 0x72, 0xfe, 0x49, 0x47, 0x93,
-	0x86, 0xd7, 0x08, 0x27, 0xe7, 0xca, 0x05, 0x82, 0xaf, 0x08, 0x3f, 0x6e, 0xf7, 0xd6, 0x52, 0x94,
-	0x1a, 0x88, 0xc1, 0xe7, 0x92, 0x29, 0x93, 0xb3, 0x22, 0xd5, 0x53, 0xce, 0x41, 0x57, 0xde, 0x28,
-	0x3c, 0xed, 0x5d, 0xd3, 0x43, 0x3b, 0xa2, 0x8e, 0x41, 0x6c, 0xd5, 0x86, 0x56, 0xac, 0x7f, 0x67,
-	0xf1, 0xf3, 0x89, 0x97, 0x9c, 0x49, 0x07, 0x0d, 0xcc, 0x5e, 0x23, 0x2e, 0x89, 0xbc, 0xc0, 0x17,
-	0x0a, 0x3e, 0x02, 0x37, 0x30, 0x4e, 0xc7, 0xcc, 0xb0, 0x54, 0x8a, 0xbc, 0x34, 0x36, 0xd9, 0x71,
-	0x42, 0xb6, 0x77, 0x6f, 0x98, 0x61, 0x71, 0x7d, 0x43, 0x9e, 0xe2, 0xfb, 0xa0, 0x94, 0x50, 0xe9,
-	0x04, 0xb4, 0x66, 0x19, 0x5c, 0x1e, 0x5d, 0xa1, 0xf0, 0x6e, 0x72, 0xaf, 0x06, 0x6f, 0x2c, 0xd6,
-	0xfb, 0x81, 0xf0, 0x99, 0x5b, 0x03, 0xf9, 0x86, 0xf0, 0x89, 0x4d, 0x42, 0xfe, 0xf7, 0x87, 0xdd,
-	0xd7, 0xec, 0xbc, 0xbd, 0xad, 0x8c, 0x7d, 0x98, 0xc0, 0xeb, 0xaf, 0xd0, 0x62, 0xed, 0xa3, 0xe5,
-	0xda, 0x47, 0xbf, 0xd6, 0x3e, 0xfa, 0xb2, 0xf1, 0xbd, 0xe5, 0xc6, 0xf7, 0x56, 0x1b, 0xdf, 0xc3,
-	0xcf, 0x72, 0x71, 0xb0, 0x4d, 0xff, 0xa1, 0xeb, 0x10, 0x57, 0x93, 0x31, 0x1a, 0x0d, 0xb2, 0x7d,
-	0x8d, 0xfc, 0xef, 0x1d, 0x92, 0x55, 0xf1, 0x51, 0x5e, 0x1a, 0x50, 0x25, 0x2b, 0xa2, 0xfa, 0xab,
-	0x36, 0xc9, 0xa0, 0x6c, 0x5d, 0xb5, 0xef, 0x47, 0xe1, 0x40, 0x42, 0xf9, 0x6e, 0x27, 0x57, 0x1b,
-	0xd1, 0xd7, 0xbb, 0x48, 0x4d, 0x0c, 0xfa, 0xbe, 0xfb, 0xe1, 0xa4, 0x56, 0x7a, 0xf9, 0x3b, 0x00,
-	0x00, 0xff, 0xff, 0x47, 0xf2, 0x5f, 0x42, 0xc8, 0x03, 0x00, 0x00,
+	// 425 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x93, 0xb1, 0x8e, 0xd3, 0x30,
+	0x1c, 0xc6, 0xe3, 0x3b, 0x74, 0x12, 0x3e, 0xb8, 0x43, 0xe6, 0x86, 0xaa, 0x45, 0xa1, 0x0a, 0x4b,
+	0x24, 0x90, 0x43, 0xcb, 0xce, 0x50, 0x28, 0x5b, 0xd5, 0x28, 0x45, 0x0c, 0x5d, 0x22, 0xe3, 0xfe,
+	0x15, 0x05, 0xa5, 0xb1, 0xb1, 0xdd, 0x8a, 0xbe, 0x05, 0x03, 0x0b, 0xaf, 0x80, 0x78, 0x90, 0x8e,
+	0x1d, 0x3b, 0x21, 0xd4, 0xbe, 0x08, 0x4a, 0x9c, 0x16, 0x02, 0x11, 0xaa, 0x60, 0x73, 0x3e, 0xff,
+	0xbf, 0xef, 0xf7, 0xc5, 0x96, 0xf1, 0x73, 0x21, 0x21, 0x37, 0x90, 0xc1, 0x1c, 0x8c, 0x5a, 0x05,
+	0x52, 0x09, 0x23, 0x02, 0x2e, 0xb2, 0x0c, 0xb8, 0x11, 0x2a, 0x28, 0xd4, 0x94, 0xeb, 0x60, 0xd9,
+	0x3b, 0x2c, 0x63, 0x0d, 0x6a, 0x99, 0x72, 0xa0, 0xe5, 0x28, 0xf1, 0x6b, 0x7e, 0x2b, 0xd2, 0xa3,
+	0x9f, 0x56, 0x26, 0xba, 0xec, 0xb5, 0x6f, 0x12, 0x91, 0x08, 0x9b, 0x5f, 0xac, 0xec, 0x68, 0xfb,
+	0x49, 0x13, 0xff, 0x4f, 0xaa, 0x9d, 0xf6, 0x56, 0xb8, 0x33, 0xfc, 0x20, 0x85, 0x32, 0x23, 0x2b,
+	0x4f, 0x6c, 0x97, 0x08, 0xde, 0x2f, 0x40, 0x1b, 0x32, 0xc5, 0xf7, 0x14, 0x68, 0xb1, 0x50, 0x1c,
+	0xe2, 0xca, 0xd8, 0x42, 0xdd, 0x73, 0xff, 0xb2, 0x1f, 0xd0, 0xa6, 0x9e, 0x3f, 0xdb, 0xd1, 0xa8,
+	0xf2, 0x55, 0xc1, 0xd1, 0xb5, 0xaa, 0x0b, 0xde, 0x27, 0x84, 0x1f, 0x34, 0xb3, 0xb5, 0x14, 0xb9,
+	0x06, 0x62, 0xf0, 0xb5, 0x64, 0xca, 0xa4, 0x2c, 0x8b, 0xf5, 0x82, 0x73, 0xd0, 0x05, 0x1b, 0xf9,
+	0x97, 0xfd, 0x21, 0x3d, 0xf5, 0x8c, 0x68, 0x0d, 0x10, 0xda, 0xb4, 0x89, 0x0d, 0x1b, 0xdc, 0x5a,
+	0x7f, 0x7b, 0xe8, 0x44, 0x57, 0xb2, 0xa6, 0x7a, 0x06, 0x77, 0xfe, 0x62, 0x22, 0x4f, 0xf1, 0x8d,
+	0x82, 0x77, 0xc0, 0x0d, 0xcc, 0xe2, 0x19, 0x33, 0x2c, 0x96, 0x22, 0xcd, 0x8d, 0x6d, 0x76, 0x1e,
+	0x91, 0xc3, 0xde, 0x4b, 0x66, 0x58, 0x58, 0xee, 0x90, 0x47, 0xf8, 0x2e, 0x28, 0x25, 0x54, 0x3c,
+	0x07, 0xad, 0x59, 0x02, 0xad, 0xb3, 0x2e, 0xf2, 0x6f, 0x47, 0x77, 0x4a, 0x71, 0x64, 0xb5, 0xfe,
+	0x57, 0x84, 0xaf, 0xea, 0xc7, 0x40, 0x3e, 0x23, 0x7c, 0x61, 0x9b, 0x90, 0x7f, 0xfd, 0xe1, 0xfa,
+	0x6d, 0xb6, 0x5f, 0xfd, 0x6f, 0x8c, 0xbd, 0x18, 0xcf, 0x19, 0x6c, 0xd1, 0x7a, 0xe7, 0xa2, 0xcd,
+	0xce, 0x45, 0xdf, 0x77, 0x2e, 0xfa, 0xb8, 0x77, 0x9d, 0xcd, 0xde, 0x75, 0xb6, 0x7b, 0xd7, 0xc1,
+	0x8f, 0x53, 0x71, 0x32, 0x66, 0x70, 0xbf, 0x4e, 0x08, 0x8b, 0xc9, 0x10, 0x4d, 0xc7, 0xc9, 0xef,
+	0x19, 0xe9, 0xaf, 0x6f, 0x48, 0x16, 0x07, 0x1f, 0xa4, 0xb9, 0x01, 0x95, 0xb3, 0x2c, 0x28, 0xbf,
+	0x4a, 0x48, 0x02, 0x79, 0xe3, 0x53, 0xfb, 0x72, 0xe6, 0x8f, 0x25, 0xe4, 0xaf, 0x8f, 0x71, 0x25,
+	0x88, 0xbe, 0x38, 0x56, 0xaa, 0x6a, 0xd0, 0x37, 0xbd, 0xb7, 0x17, 0x65, 0xd2, 0xb3, 0x1f, 0x03,
+	0x00, 0x47, 0xf2, 0x5f, 0x42, 0xc8, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
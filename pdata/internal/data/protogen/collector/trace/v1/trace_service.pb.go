@@ -212,33 +212,33 @@ func init() {
 }
 
 var fileDescriptor_192a962890318cf4 = []byte{
-	// 413 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0x4f, 0xeb, 0xd3, 0x30,
-	0x18, 0x6e, 0x36, 0x19, 0x98, 0xfd, 0x11, 0x8b, 0x87, 0xd9, 0x43, 0x1d, 0x15, 0x47, 0x45, 0x48,
-	0xd9, 0xbc, 0x79, 0xb3, 0xe2, 0x71, 0x38, 0xba, 0xe1, 0xc1, 0xcb, 0x88, 0xdd, 0x4b, 0xa9, 0x74,
-	0x4d, 0x4c, 0xb2, 0xa1, 0x5f, 0x42, 0xf4, 0x2b, 0x78, 0xf4, 0x93, 0xec, 0xb8, 0xa3, 0x27, 0x91,
-	0xed, 0x8b, 0x48, 0x12, 0x2d, 0xad, 0xf4, 0x30, 0x7e, 0xbf, 0x5b, 0xf2, 0xf0, 0x3e, 0x7f, 0xde,
-	0x27, 0x04, 0xbf, 0x60, 0x1c, 0x4a, 0x05, 0x05, 0xec, 0x40, 0x89, 0xcf, 0x11, 0x17, 0x4c, 0xb1,
-	0x28, 0x65, 0x45, 0x01, 0xa9, 0x62, 0x22, 0x52, 0x82, 0xa6, 0x10, 0x1d, 0x66, 0xf6, 0xb0, 0x91,
-	0x20, 0x0e, 0x79, 0x0a, 0xc4, 0x8c, 0xb9, 0xd3, 0x06, 0xd7, 0x82, 0xa4, 0xe2, 0x12, 0x43, 0x21,
-	0x87, 0x99, 0xf7, 0x20, 0x63, 0x19, 0xb3, 0xca, 0xfa, 0x64, 0x07, 0xbd, 0xb0, 0xcd, 0xb9, 0xe9,
-	0x67, 0x27, 0x03, 0x86, 0x1f, 0xbe, 0xfe, 0xc4, 0x99, 0x50, 0x6b, 0x0d, 0xae, 0x6c, 0x86, 0x04,
-	0x3e, 0xee, 0x41, 0x2a, 0x37, 0xc1, 0x23, 0x01, 0x92, 0xed, 0x85, 0x8e, 0xc7, 0x69, 0x29, 0xc7,
-	0x68, 0xd2, 0x0d, 0xfb, 0xf3, 0x67, 0xa4, 0x2d, 0xdd, 0xbf, 0x4c, 0x24, 0xf9, 0xcb, 0x59, 0x69,
-	0x4a, 0x32, 0x14, 0xf5, 0x6b, 0xf0, 0x05, 0x61, 0xaf, 0xcd, 0x51, 0x72, 0x56, 0x4a, 0x70, 0x39,
-	0xbe, 0xc7, 0xa9, 0x50, 0x39, 0x2d, 0x36, 0x72, 0x9f, 0xa6, 0x20, 0xb5, 0x27, 0x0a, 0xfb, 0xf3,
-	0x97, 0xe4, 0xba, 0x46, 0x48, 0x4d, 0x7c, 0x69, 0x95, 0x56, 0x56, 0x28, 0xbe, 0x73, 0xfc, 0xf5,
-	0xc8, 0x49, 0x46, 0xbc, 0x81, 0x06, 0x59, 0xa3, 0x81, 0x26, 0xc5, 0x7d, 0xa2, 0x1b, 0xf8, 0x00,
-	0xa9, 0x82, 0x6d, 0xd5, 0x00, 0x0a, 0xbb, 0x7a, 0x29, 0x8b, 0x9a, 0xa5, 0xdc, 0xc7, 0x78, 0x08,
-	0x42, 0x30, 0xb1, 0xd9, 0x81, 0x94, 0x34, 0x83, 0x71, 0x67, 0x82, 0xc2, 0xbb, 0xc9, 0xc0, 0x80,
-	0x0b, 0x8b, 0xcd, 0xbf, 0x23, 0x3c, 0xa8, 0xef, 0xec, 0x7e, 0x43, 0xb8, 0x67, 0xad, 0xdd, 0x9b,
-	0x6c, 0xd7, 0x7c, 0x2c, 0x2f, 0xbe, 0x8d, 0x84, 0x6d, 0x3f, 0x70, 0xe2, 0x13, 0x3a, 0x9e, 0x7d,
-	0x74, 0x3a, 0xfb, 0xe8, 0xf7, 0xd9, 0x47, 0x5f, 0x2f, 0xbe, 0x73, 0xba, 0xf8, 0xce, 0xcf, 0x8b,
-	0xef, 0xe0, 0xa7, 0x39, 0xbb, 0xd2, 0x22, 0xbe, 0x5f, 0x57, 0x5f, 0xea, 0xa9, 0x25, 0x7a, 0xb7,
-	0xc8, 0xfe, 0xe7, 0xe7, 0xf5, 0xef, 0xc0, 0xb7, 0x54, 0xd1, 0x28, 0x2f, 0x15, 0x88, 0x92, 0x16,
-	0x91, 0xb9, 0x19, 0x83, 0x0c, 0xca, 0x96, 0x5f, 0xf3, 0xa3, 0x33, 0x7d, 0xc3, 0xa1, 0x5c, 0x57,
-	0x62, 0xc6, 0x86, 0xbc, 0xaa, 0xc2, 0x98, 0x08, 0xe4, 0xed, 0xec, 0x7d, 0xcf, 0xa8, 0x3c, 0xff,
-	0x13, 0x00, 0x00, 0xff, 0xff, 0x82, 0xce, 0x78, 0xc7, 0x8f, 0x03, 0x00, 0x00,
+	// 411 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0x4f, 0xcb, 0xd3, 0x30,
+	0x18, 0x6f, 0x36, 0x19, 0x98, 0xfd, 0x11, 0x8b, 0x87, 0xad, 0x87, 0x3a, 0x2a, 0x8e, 0x8a, 0x90,
+	0xb2, 0x79, 0xf3, 0x66, 0xc5, 0xe3, 0x70, 0x74, 0xc3, 0x83, 0x97, 0x11, 0xbb, 0x87, 0x52, 0xe9,
+	0x9a, 0x98, 0x64, 0x43, 0xbf, 0x84, 0xe8, 0x57, 0xf0, 0xe8, 0x27, 0xd9, 0x71, 0x47, 0x4f, 0x22,
+	0xdb, 0x17, 0x91, 0x26, 0xae, 0xb4, 0xd2, 0x17, 0xc6, 0xfb, 0xde, 0x92, 0x1f, 0xcf, 0xef, 0xcf,
+	0xf3, 0x0b, 0xc1, 0x2f, 0x19, 0x87, 0x5c, 0x41, 0x06, 0x5b, 0x50, 0xe2, 0x4b, 0xc0, 0x05, 0x53,
+	0x2c, 0x88, 0x59, 0x96, 0x41, 0xac, 0x98, 0x08, 0x94, 0xa0, 0x31, 0x04, 0xfb, 0xa9, 0x39, 0xac,
+	0x25, 0x88, 0x7d, 0x1a, 0x03, 0xd1, 0x63, 0xf6, 0xa4, 0xc6, 0x35, 0x20, 0x29, 0xb9, 0x44, 0x53,
+	0xc8, 0x7e, 0xea, 0x3c, 0x4a, 0x58, 0xc2, 0x8c, 0x72, 0x71, 0x32, 0x83, 0x8e, 0xdf, 0xe4, 0x5c,
+	0xf7, 0x33, 0x93, 0x1e, 0xc3, 0xa3, 0x37, 0x9f, 0x39, 0x13, 0x6a, 0x55, 0x80, 0x4b, 0x93, 0x21,
+	0x82, 0x4f, 0x3b, 0x90, 0xca, 0x8e, 0xf0, 0x40, 0x80, 0x64, 0x3b, 0x51, 0xc4, 0xe3, 0x34, 0x97,
+	0x43, 0x34, 0x6e, 0xfb, 0xdd, 0xd9, 0x73, 0xd2, 0x94, 0xee, 0x92, 0x89, 0x44, 0xff, 0x38, 0xcb,
+	0x82, 0x12, 0xf5, 0x45, 0xf5, 0xea, 0x7d, 0x45, 0xd8, 0x69, 0x72, 0x94, 0x9c, 0xe5, 0x12, 0x6c,
+	0x8e, 0x1f, 0x70, 0x2a, 0x54, 0x4a, 0xb3, 0xb5, 0xdc, 0xc5, 0x31, 0xc8, 0xc2, 0x13, 0xf9, 0xdd,
+	0xd9, 0x2b, 0x72, 0x5d, 0x23, 0xa4, 0x22, 0xbe, 0x30, 0x4a, 0x4b, 0x23, 0x14, 0xde, 0x3b, 0xfc,
+	0x7e, 0x6c, 0x45, 0x03, 0x5e, 0x43, 0xbd, 0x04, 0x8f, 0x6e, 0xa4, 0xd8, 0x4f, 0x8b, 0x06, 0x3e,
+	0x42, 0xac, 0x60, 0x53, 0x36, 0x80, 0xfc, 0x76, 0xd4, 0xbf, 0xa0, 0x7a, 0x29, 0xfb, 0x09, 0xee,
+	0x83, 0x10, 0x4c, 0xac, 0xb7, 0x20, 0x25, 0x4d, 0x60, 0xd8, 0x1a, 0x23, 0xff, 0x7e, 0xd4, 0xd3,
+	0xe0, 0xdc, 0x60, 0xb3, 0x1f, 0x08, 0xf7, 0xaa, 0x3b, 0xdb, 0xdf, 0x11, 0xee, 0x18, 0x6b, 0xfb,
+	0x36, 0xdb, 0xd5, 0x1f, 0xcb, 0x09, 0xef, 0x22, 0x61, 0xda, 0xf7, 0xac, 0xf0, 0x88, 0x0e, 0x27,
+	0x17, 0x1d, 0x4f, 0x2e, 0xfa, 0x73, 0x72, 0xd1, 0xb7, 0xb3, 0x6b, 0x1d, 0xcf, 0xae, 0xf5, 0xeb,
+	0xec, 0x5a, 0xf8, 0x59, 0xca, 0xae, 0xb4, 0x08, 0x1f, 0x56, 0xd5, 0x17, 0xc5, 0xd4, 0x02, 0xbd,
+	0x9f, 0x27, 0xff, 0xf3, 0xd3, 0xea, 0x77, 0xe0, 0x1b, 0xaa, 0x68, 0x90, 0xe6, 0x0a, 0x44, 0x4e,
+	0xb3, 0x40, 0xdf, 0xb4, 0x41, 0x02, 0x79, 0xc3, 0xaf, 0xf9, 0xd9, 0x9a, 0xbc, 0xe5, 0x90, 0xaf,
+	0x4a, 0x31, 0x6d, 0x43, 0x5e, 0x97, 0x61, 0x74, 0x04, 0xf2, 0x6e, 0xfa, 0xa1, 0xa3, 0x55, 0x5e,
+	0xfc, 0x1d, 0x00, 0x82, 0xce, 0x78, 0xc7, 0x8f, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
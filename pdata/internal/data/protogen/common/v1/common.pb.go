@@ -33,7 +33,6 @@ type AnyValue struct {
 	// in which case this AnyValue is considered to be "empty".
 	//
 	// Types that are valid to be assigned to Value:
-	//
 	//	*AnyValue_StringValue
 	//	*AnyValue_BoolValue
 	//	*AnyValue_IntValue
@@ -424,39 +423,39 @@ func init() {
 
 var fileDescriptor_62ba46dcb97aa817 = []byte{
 	// 532 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x4f, 0x6b, 0x13, 0x41,
-	0x14, 0xdf, 0x69, 0xd2, 0xfc, 0x79, 0x1b, 0x41, 0x86, 0x22, 0x41, 0xc8, 0x76, 0x8d, 0x07, 0x57,
-	0x85, 0x5d, 0x52, 0x2f, 0x5e, 0x93, 0x28, 0x44, 0xac, 0x18, 0xb6, 0xda, 0x83, 0x97, 0x30, 0x49,
-	0x86, 0x30, 0x74, 0x33, 0xb3, 0xcc, 0x4e, 0x02, 0xf9, 0x16, 0x7e, 0x0e, 0x2f, 0x7e, 0x8d, 0x5e,
-	0x84, 0x1e, 0x3d, 0x49, 0x49, 0xbe, 0x88, 0xcc, 0x9f, 0x24, 0xb5, 0x87, 0x96, 0x7a, 0x7b, 0xf3,
-	0xfb, 0xf7, 0xde, 0xcb, 0x4c, 0x16, 0x5e, 0x89, 0x9c, 0x72, 0x45, 0x33, 0x3a, 0xa7, 0x4a, 0xae,
-	0x92, 0x5c, 0x0a, 0x25, 0x92, 0x89, 0x98, 0xcf, 0x05, 0x4f, 0x96, 0x1d, 0x57, 0xc5, 0x06, 0xc6,
-	0xad, 0x7f, 0xb4, 0x16, 0x8c, 0x9d, 0x62, 0xd9, 0x79, 0x7a, 0x34, 0x13, 0x33, 0x61, 0x03, 0x74,
-	0x65, 0xf9, 0xf6, 0xf5, 0x01, 0xd4, 0xba, 0x7c, 0x75, 0x4e, 0xb2, 0x05, 0xc5, 0xcf, 0xa1, 0x51,
-	0x28, 0xc9, 0xf8, 0x6c, 0xb4, 0xd4, 0xe7, 0x26, 0x0a, 0x51, 0x54, 0x1f, 0x78, 0xa9, 0x6f, 0x51,
-	0x2b, 0x3a, 0x06, 0x18, 0x0b, 0x91, 0x39, 0xc9, 0x41, 0x88, 0xa2, 0xda, 0xc0, 0x4b, 0xeb, 0x1a,
-	0xb3, 0x82, 0x16, 0xd4, 0x19, 0x57, 0x8e, 0x2f, 0x85, 0x28, 0x2a, 0x0d, 0xbc, 0xb4, 0xc6, 0xb8,
-	0xda, 0x35, 0x99, 0x8a, 0xc5, 0x38, 0xa3, 0x4e, 0x51, 0x0e, 0x51, 0x84, 0x74, 0x13, 0x8b, 0x5a,
-	0xd1, 0x29, 0xf8, 0x44, 0x4a, 0xb2, 0x72, 0x9a, 0xc3, 0x10, 0x45, 0xfe, 0xc9, 0xcb, 0xf8, 0xce,
-	0x0d, 0xe3, 0xae, 0x76, 0x18, 0xff, 0xc0, 0x4b, 0x81, 0xec, 0x4e, 0x78, 0x08, 0x8d, 0x8b, 0x65,
-	0xc6, 0x8a, 0xed, 0x50, 0x15, 0x13, 0xf7, 0xfa, 0x9e, 0xb8, 0x8f, 0xd4, 0xda, 0x4f, 0x59, 0xa1,
-	0xf4, 0x7c, 0x36, 0xc2, 0x26, 0x3e, 0x03, 0x7f, 0xbc, 0x52, 0xb4, 0x70, 0x81, 0xd5, 0x10, 0x45,
-	0x0d, 0xdd, 0xd4, 0x80, 0x46, 0xd2, 0xab, 0xc2, 0xa1, 0x21, 0xdb, 0x67, 0x00, 0xfb, 0xc9, 0xf0,
-	0x7b, 0xa8, 0x18, 0xb8, 0x68, 0xa2, 0xb0, 0x14, 0xf9, 0x27, 0x2f, 0xee, 0x5b, 0xca, 0x5d, 0x4e,
-	0xaf, 0x7c, 0xf9, 0xe7, 0xd8, 0x4b, 0x9d, 0xb9, 0xfd, 0x15, 0x1a, 0x37, 0xe7, 0x7b, 0x70, 0xec,
-	0xd6, 0x7c, 0x2b, 0x96, 0x40, 0x6d, 0xcb, 0xe0, 0xc7, 0x50, 0xba, 0xa0, 0x2b, 0xfb, 0x08, 0x52,
-	0x5d, 0xe2, 0xbe, 0x5b, 0xc9, 0xdc, 0xfa, 0x83, 0x47, 0x77, 0x3f, 0xc7, 0x2f, 0x04, 0x47, 0x1f,
-	0x78, 0xa1, 0xe4, 0x62, 0x4e, 0xb9, 0x22, 0x8a, 0x09, 0x7e, 0x36, 0x11, 0x39, 0xc5, 0x18, 0xca,
-	0x9c, 0xcc, 0xdd, 0xab, 0x4b, 0x4d, 0x8d, 0x9b, 0x50, 0x5d, 0x52, 0x59, 0x30, 0xc1, 0x4d, 0xcf,
-	0x7a, 0xba, 0x3d, 0xe2, 0x4f, 0x00, 0x44, 0x29, 0xc9, 0xc6, 0x0b, 0x45, 0x8b, 0x66, 0xe9, 0x7f,
-	0x96, 0xbe, 0x11, 0x80, 0xdf, 0x42, 0x73, 0x2a, 0x45, 0x9e, 0xd3, 0xe9, 0x68, 0x8f, 0x8e, 0x26,
-	0x62, 0xc1, 0x95, 0x79, 0xa1, 0x8f, 0xd2, 0x27, 0x8e, 0xef, 0xee, 0xe8, 0xbe, 0x66, 0x7b, 0x3f,
-	0xd1, 0xe5, 0x3a, 0x40, 0x57, 0xeb, 0x00, 0x5d, 0xaf, 0x03, 0xf4, 0x7d, 0x13, 0x78, 0x57, 0x9b,
-	0xc0, 0xfb, 0xbd, 0x09, 0x3c, 0x08, 0x99, 0xb8, 0x7b, 0xa2, 0x9e, 0xdf, 0x37, 0xe5, 0x50, 0xc3,
-	0x43, 0xf4, 0xed, 0xdd, 0xec, 0xb6, 0x81, 0xe9, 0xbf, 0x7b, 0x96, 0xd1, 0x89, 0x12, 0x32, 0xc9,
-	0xa7, 0x44, 0x91, 0x84, 0x71, 0x45, 0x25, 0x27, 0x59, 0x62, 0x4e, 0x26, 0x71, 0x46, 0xf9, 0xfe,
-	0xab, 0xf0, 0xe3, 0xa0, 0xf5, 0x39, 0xa7, 0xfc, 0xcb, 0x2e, 0xc3, 0xa4, 0xc7, 0xb6, 0x53, 0x7c,
-	0xde, 0x19, 0x57, 0x8c, 0xe7, 0xcd, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x04, 0x1b, 0xbb, 0x73,
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xcd, 0x6a, 0x1b, 0x3d,
+	0x14, 0x1d, 0xc5, 0xf1, 0xdf, 0x1d, 0x7f, 0xf0, 0x21, 0x42, 0x31, 0x05, 0x4f, 0xa6, 0xee, 0xa2,
+	0xd3, 0x16, 0x66, 0x70, 0xba, 0xe9, 0xd6, 0x76, 0x0b, 0x2e, 0x49, 0xa9, 0x99, 0xb4, 0x59, 0x74,
+	0x63, 0x64, 0x5b, 0x18, 0x91, 0xb1, 0x34, 0x68, 0x64, 0x83, 0xdf, 0xa2, 0xcf, 0xd1, 0x4d, 0x5f,
+	0x23, 0x9b, 0x42, 0x96, 0x5d, 0x95, 0x60, 0xbf, 0x48, 0xd1, 0x8f, 0xed, 0xb4, 0x94, 0x84, 0x74,
+	0x77, 0xef, 0x39, 0xe7, 0x9e, 0x7b, 0x64, 0xc9, 0x03, 0x2f, 0x44, 0x4e, 0xb9, 0xa2, 0x19, 0x9d,
+	0x53, 0x25, 0x57, 0x49, 0x2e, 0x85, 0x12, 0xc9, 0x44, 0xcc, 0xe7, 0x82, 0x27, 0xcb, 0x8e, 0xab,
+	0x62, 0x03, 0xe3, 0xd6, 0x6f, 0x5a, 0x0b, 0xc6, 0x4e, 0xb1, 0xec, 0x3c, 0x3e, 0x9a, 0x89, 0x99,
+	0xb0, 0x06, 0xba, 0xb2, 0x7c, 0xfb, 0xe6, 0x00, 0x6a, 0x5d, 0xbe, 0xba, 0x20, 0xd9, 0x82, 0xe2,
+	0xa7, 0xd0, 0x28, 0x94, 0x64, 0x7c, 0x36, 0x5a, 0xea, 0xbe, 0x89, 0x42, 0x14, 0xd5, 0x07, 0x5e,
+	0xea, 0x5b, 0xd4, 0x8a, 0x8e, 0x01, 0xc6, 0x42, 0x64, 0x4e, 0x72, 0x10, 0xa2, 0xa8, 0x36, 0xf0,
+	0xd2, 0xba, 0xc6, 0xac, 0xa0, 0x05, 0x75, 0xc6, 0x95, 0xe3, 0x4b, 0x21, 0x8a, 0x4a, 0x03, 0x2f,
+	0xad, 0x31, 0xae, 0x76, 0x4b, 0xa6, 0x62, 0x31, 0xce, 0xa8, 0x53, 0x1c, 0x86, 0x28, 0x42, 0x7a,
+	0x89, 0x45, 0xad, 0xe8, 0x0c, 0x7c, 0x22, 0x25, 0x59, 0x39, 0x4d, 0x39, 0x44, 0x91, 0x7f, 0xf2,
+	0x3c, 0xbe, 0xf3, 0x84, 0x71, 0x57, 0x4f, 0x98, 0xf9, 0x81, 0x97, 0x02, 0xd9, 0x75, 0x78, 0x08,
+	0x8d, 0xcb, 0x65, 0xc6, 0x8a, 0x6d, 0xa8, 0x8a, 0xb1, 0x7b, 0x79, 0x8f, 0xdd, 0x29, 0xb5, 0xe3,
+	0x67, 0xac, 0x50, 0x3a, 0x9f, 0xb5, 0xb0, 0x8e, 0x4f, 0xc0, 0x1f, 0xaf, 0x14, 0x2d, 0x9c, 0x61,
+	0x35, 0x44, 0x51, 0x43, 0x2f, 0x35, 0xa0, 0x91, 0xf4, 0xaa, 0x50, 0x36, 0x64, 0xfb, 0x1c, 0x60,
+	0x9f, 0x0c, 0xbf, 0x85, 0x8a, 0x81, 0x8b, 0x26, 0x0a, 0x4b, 0x91, 0x7f, 0xf2, 0xec, 0xbe, 0x43,
+	0xb9, 0xcb, 0xe9, 0x1d, 0x5e, 0xfd, 0x3c, 0xf6, 0x52, 0x37, 0xdc, 0xfe, 0x04, 0x8d, 0xdb, 0xf9,
+	0x1e, 0x6c, 0x7b, 0x4a, 0xff, 0x6a, 0x4b, 0xa0, 0xb6, 0x65, 0xf0, 0xff, 0x50, 0xba, 0xa4, 0x2b,
+	0xfb, 0x08, 0x52, 0x5d, 0xe2, 0x3e, 0x94, 0xf7, 0xb7, 0xfe, 0xe0, 0xe8, 0xee, 0xe7, 0xf8, 0x8e,
+	0xe0, 0xe8, 0x1d, 0x2f, 0x94, 0x5c, 0xcc, 0x29, 0x57, 0x44, 0x31, 0xc1, 0xcf, 0x27, 0x22, 0xa7,
+	0x18, 0xc3, 0x21, 0x27, 0x73, 0xf7, 0xea, 0x52, 0x53, 0xe3, 0x26, 0x54, 0x97, 0x54, 0x16, 0x4c,
+	0x70, 0xb3, 0xb3, 0x9e, 0x6e, 0x5b, 0xfc, 0x1e, 0x80, 0x28, 0x25, 0xd9, 0x78, 0xa1, 0x68, 0xd1,
+	0x2c, 0xfd, 0xcb, 0xa1, 0x6f, 0x19, 0xe0, 0xd7, 0xd0, 0x9c, 0x4a, 0x91, 0xe7, 0x74, 0x3a, 0xda,
+	0xa3, 0xa3, 0x89, 0x58, 0x70, 0x65, 0x5e, 0xe8, 0x7f, 0xe9, 0x23, 0xc7, 0x77, 0x77, 0x74, 0x5f,
+	0xb3, 0xbd, 0x6f, 0xe8, 0x6a, 0x1d, 0xa0, 0xeb, 0x75, 0x80, 0x6e, 0xd6, 0x01, 0xfa, 0xb2, 0x09,
+	0xbc, 0xeb, 0x4d, 0xe0, 0xfd, 0xd8, 0x04, 0x1e, 0x84, 0x4c, 0xdc, 0x9d, 0xa8, 0xe7, 0xf7, 0x4d,
+	0x39, 0xd4, 0xf0, 0x10, 0x7d, 0x7e, 0x33, 0xfb, 0x73, 0x80, 0xe9, 0xbf, 0x7b, 0x96, 0xd1, 0x89,
+	0x12, 0x32, 0xc9, 0xa7, 0x44, 0x91, 0x84, 0x71, 0x45, 0x25, 0x27, 0x59, 0x62, 0x3a, 0xe3, 0x38,
+	0xa3, 0x7c, 0xff, 0x55, 0xf8, 0x7a, 0xd0, 0xfa, 0x90, 0x53, 0xfe, 0x71, 0xe7, 0x61, 0xdc, 0x63,
+	0xbb, 0x29, 0xbe, 0xe8, 0x8c, 0x2b, 0x66, 0xe6, 0xd5, 0xaf, 0x01, 0x00, 0x04, 0x1b, 0xbb, 0x73,
 	0x5d, 0x04, 0x00, 0x00,
 }
 
@@ -125,9 +125,11 @@ func (SeverityNumber) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_d1c030a3ec7e961e, []int{0}
 }
 
-// LogRecordFlags is defined as a protobuf 'uint32' type and is to be used as
-// bit-fields. Each non-zero value defined in this enum is a bit-mask.
-// To extract the bit-field, for example, use an expression like:
+// LogRecordFlags represents constants used to interpret the
+// LogRecord.flags field, which is protobuf 'fixed32' type and is to
+// be used as bit-fields. Each non-zero value defined in this enum is
+// a bit-mask.  To extract the bit-field, for example, use an
+// expression like:
 //
 //	(logRecord.flags & LOG_RECORD_FLAGS_TRACE_FLAGS_MASK)
 type LogRecordFlags int32
@@ -225,6 +227,9 @@ type ResourceLogs struct {
 	Resource v1.Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource"`
 	// A list of ScopeLogs that originate from a resource.
 	ScopeLogs []*ScopeLogs `protobuf:"bytes,2,rep,name=scope_logs,json=scopeLogs,proto3" json:"scope_logs,omitempty"`
+	// The Schema URL, if known. This is the identifier of the Schema that the resource data
+	// is recorded in. To learn more about Schema URL see
+	// https://opentelemetry.io/docs/specs/otel/schemas/#schema-url
 	// This schema_url applies to the data in the "resource" field. It does not apply
 	// to the data in the "scope_logs" field which have their own schema_url field.
 	SchemaUrl string `protobuf:"bytes,3,opt,name=schema_url,json=schemaUrl,proto3" json:"schema_url,omitempty"`
@@ -299,6 +304,9 @@ type ScopeLogs struct {
 	Scope v11.InstrumentationScope `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope"`
 	// A list of log records.
 	LogRecords []*LogRecord `protobuf:"bytes,2,rep,name=log_records,json=logRecords,proto3" json:"log_records,omitempty"`
+	// The Schema URL, if known. This is the identifier of the Schema that the log data
+	// is recorded in. To learn more about Schema URL see
+	// https://opentelemetry.io/docs/specs/otel/schemas/#schema-url
 	// This schema_url applies to all logs in the "logs" field.
 	SchemaUrl string `protobuf:"bytes,3,opt,name=schema_url,json=schemaUrl,proto3" json:"schema_url,omitempty"`
 }
@@ -531,67 +539,67 @@ func init() {
 }
 
 var fileDescriptor_d1c030a3ec7e961e = []byte{
-	// 951 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x96, 0xcf, 0x6e, 0xea, 0x46,
-	0x14, 0xc6, 0x31, 0xe1, 0xef, 0x84, 0x70, 0xa7, 0x73, 0x49, 0xae, 0x9b, 0xa8, 0x84, 0xa6, 0x55,
-	0x4a, 0x53, 0x09, 0x14, 0xa0, 0xd2, 0xed, 0xae, 0x26, 0x98, 0x88, 0x86, 0x40, 0x34, 0x40, 0xaa,
-	0xdc, 0x56, 0xb2, 0x0c, 0x9e, 0x52, 0x4b, 0xc6, 0x63, 0xd9, 0x03, 0x4a, 0xf6, 0x7d, 0x80, 0x3e,
-	0x41, 0xb7, 0x95, 0xfa, 0x1a, 0xed, 0xe2, 0x2e, 0xef, 0xb2, 0xea, 0x22, 0xaa, 0x92, 0x4d, 0xdf,
-	0xa2, 0xd5, 0x0c, 0x86, 0x90, 0xd4, 0x4e, 0x6e, 0x56, 0x99, 0x39, 0xbf, 0xef, 0x7c, 0xe7, 0x8c,
-	0x4f, 0x3c, 0x18, 0xec, 0x53, 0x87, 0xd8, 0x8c, 0x58, 0x64, 0x42, 0x98, 0x7b, 0x55, 0x76, 0x5c,
-	0xca, 0x68, 0xd9, 0xa2, 0x63, 0xaf, 0x3c, 0x3b, 0x14, 0x7f, 0x4b, 0x22, 0x84, 0x76, 0xee, 0xe9,
-	0xe6, 0xc1, 0x92, 0xe0, 0xb3, 0xc3, 0xed, 0xdc, 0x98, 0x8e, 0xe9, 0x3c, 0x95, 0xaf, 0xe6, 0x74,
-	0xfb, 0x20, 0xc8, 0x7a, 0x44, 0x27, 0x13, 0x6a, 0x73, 0xf3, 0xf9, 0xca, 0xd7, 0x96, 0x82, 0xb4,
-	0x2e, 0xf1, 0xe8, 0xd4, 0x1d, 0x11, 0xae, 0x5e, 0xac, 0xe7, 0xfa, 0xbd, 0x37, 0x20, 0xd5, 0xa6,
-	0x63, 0xaf, 0xa1, 0x33, 0x1d, 0x75, 0xc0, 0xc6, 0x82, 0x6a, 0xbc, 0x23, 0x59, 0x2a, 0xac, 0x15,
-	0xd7, 0x2b, 0x9f, 0x97, 0x1e, 0x69, 0xb9, 0x84, 0xfd, 0x0c, 0xee, 0x82, 0x33, 0xee, 0xca, 0x6e,
-	0xef, 0x97, 0x28, 0xc8, 0xac, 0x62, 0xf4, 0x1d, 0xd8, 0x34, 0x88, 0xe3, 0x92, 0x91, 0xce, 0x88,
-	0xa1, 0x79, 0x23, 0xea, 0xf8, 0x85, 0xfe, 0x49, 0x8a, 0x4a, 0xfb, 0x8f, 0x56, 0xea, 0x71, 0xbd,
-	0x28, 0xf3, 0xf2, 0xce, 0x65, 0x19, 0x44, 0x27, 0x20, 0xb5, 0xa8, 0x2e, 0x4b, 0x05, 0x29, 0xb4,
-	0xf1, 0xe5, 0x03, 0x58, 0x69, 0xbe, 0x1e, 0x7b, 0x7b, 0xbd, 0x1b, 0xc1, 0x4b, 0x03, 0xa4, 0x02,
-	0xb0, 0xd2, 0x5e, 0xf4, 0x59, 0xdd, 0xa5, 0xbd, 0x65, 0x4f, 0x1f, 0x71, 0x9b, 0x1f, 0xc9, 0x44,
-	0xd7, 0xa6, 0xae, 0x25, 0xaf, 0x15, 0xa4, 0x62, 0x9a, 0x63, 0x1e, 0x19, 0xb8, 0xd6, 0xde, 0x1f,
-	0x12, 0x48, 0xdf, 0x1d, 0xa0, 0x0b, 0xe2, 0x22, 0xd3, 0xef, 0xbe, 0x1a, 0x58, 0xce, 0x1f, 0xf6,
-	0xec, 0xb0, 0xd4, 0xb2, 0x3d, 0xe6, 0x4e, 0x27, 0xc4, 0x66, 0x3a, 0x33, 0xa9, 0x2d, 0x7c, 0xfc,
-	0x73, 0xcc, 0x7d, 0xd0, 0x31, 0x58, 0xb7, 0xe8, 0x58, 0x73, 0xc9, 0x88, 0xba, 0xc6, 0xfb, 0x9d,
-	0xa2, 0x4d, 0xc7, 0x58, 0xc8, 0x31, 0xb0, 0x16, 0xcb, 0x27, 0x8f, 0xf1, 0x53, 0x1c, 0xa4, 0x97,
-	0x89, 0xe8, 0x53, 0x90, 0x65, 0xe6, 0x84, 0x68, 0x53, 0xdb, 0xbc, 0xd4, 0x6c, 0xdd, 0xa6, 0xe2,
-	0x3c, 0x09, 0x9c, 0xe1, 0xd1, 0x81, 0x6d, 0x5e, 0x76, 0x74, 0x9b, 0xa2, 0x2f, 0xc1, 0x2b, 0x3a,
-	0xf4, 0x88, 0x3b, 0x23, 0x86, 0xf6, 0x40, 0xbe, 0x2e, 0xe4, 0xb9, 0x05, 0xee, 0xaf, 0xa6, 0xf5,
-	0xc1, 0x0b, 0x8f, 0xcc, 0x88, 0x6b, 0xb2, 0x2b, 0xcd, 0x9e, 0x4e, 0x86, 0xc4, 0x95, 0xa3, 0x05,
-	0xa9, 0x98, 0xad, 0x7c, 0xf1, 0xf8, 0x70, 0xfc, 0x9c, 0x8e, 0x48, 0xc1, 0x59, 0xef, 0xde, 0x1e,
-	0x7d, 0x02, 0x36, 0x96, 0xae, 0x8c, 0x5c, 0x32, 0xff, 0x88, 0x99, 0x45, 0xb0, 0x4f, 0x2e, 0x19,
-	0x52, 0x40, 0x6c, 0x48, 0x8d, 0x2b, 0x39, 0x2e, 0xa6, 0xf3, 0xd9, 0x13, 0xd3, 0x51, 0xec, 0xab,
-	0x73, 0xdd, 0x9a, 0x2e, 0x26, 0x22, 0x52, 0xd1, 0x29, 0x00, 0x3a, 0x63, 0xae, 0x39, 0x9c, 0x32,
-	0xe2, 0xc9, 0x09, 0x31, 0x8f, 0xa7, 0x8c, 0x4e, 0xc8, 0x3d, 0xa3, 0x15, 0x03, 0xf4, 0x1a, 0xc8,
-	0x86, 0x4b, 0x1d, 0x87, 0x18, 0xda, 0x5d, 0x54, 0x1b, 0xd1, 0xa9, 0xcd, 0xe4, 0x64, 0x41, 0x2a,
-	0x6e, 0xe0, 0x2d, 0x9f, 0x2b, 0x4b, 0x7c, 0xc4, 0x29, 0xca, 0x81, 0xf8, 0x0f, 0x96, 0x3e, 0xf6,
-	0xe4, 0x54, 0x41, 0x2a, 0x26, 0xf1, 0x7c, 0x83, 0xbe, 0x07, 0x29, 0xe6, 0xea, 0x23, 0xa2, 0x99,
-	0x86, 0x9c, 0x2e, 0x48, 0xc5, 0x4c, 0x5d, 0xe1, 0x35, 0xff, 0xba, 0xde, 0xfd, 0x6a, 0x4c, 0x1f,
-	0xb4, 0x69, 0xf2, 0x1b, 0xc8, 0xb2, 0xc8, 0x88, 0x51, 0xb7, 0xec, 0x18, 0x3a, 0xd3, 0xcb, 0xa6,
-	0xcd, 0x88, 0x6b, 0xeb, 0x56, 0x99, 0xef, 0x4a, 0x7d, 0xee, 0xd4, 0x6a, 0xe0, 0xa4, 0xb0, 0x6c,
-	0x19, 0xe8, 0x02, 0x24, 0x3d, 0x47, 0xb7, 0xb9, 0x39, 0x10, 0xe6, 0x5f, 0xfb, 0xe6, 0xaf, 0x9f,
-	0x6f, 0xde, 0x73, 0x74, 0xbb, 0xd5, 0xc0, 0x09, 0x6e, 0xd8, 0x32, 0xbe, 0x89, 0xa5, 0x62, 0x30,
-	0x7e, 0xf0, 0x7b, 0x1c, 0x64, 0xef, 0x0f, 0x1a, 0xed, 0x82, 0x9d, 0x9e, 0x7a, 0xae, 0xe2, 0x56,
-	0xff, 0x42, 0xeb, 0x0c, 0x4e, 0xeb, 0x2a, 0xd6, 0x06, 0x9d, 0xde, 0x99, 0x7a, 0xd4, 0x6a, 0xb6,
-	0xd4, 0x06, 0x8c, 0xa0, 0x0f, 0xc1, 0xe6, 0x43, 0x41, 0x1f, 0x2b, 0x47, 0x2a, 0x94, 0xd0, 0x36,
-	0xd8, 0x0a, 0x44, 0x15, 0x18, 0x0d, 0x65, 0x55, 0xb8, 0x16, 0xca, 0x6a, 0x30, 0x16, 0x54, 0xae,
-	0xa1, 0xd6, 0x07, 0xc7, 0x30, 0x1e, 0x94, 0x26, 0x50, 0x05, 0x26, 0x42, 0x59, 0x15, 0x26, 0x43,
-	0x59, 0x0d, 0xa6, 0x90, 0x0c, 0x72, 0x0f, 0x59, 0xab, 0xd3, 0xec, 0xc2, 0x74, 0x50, 0x23, 0x9c,
-	0x54, 0x20, 0x08, 0x43, 0x55, 0xb8, 0x1e, 0x86, 0x6a, 0x30, 0x13, 0x54, 0xea, 0x5b, 0x05, 0x77,
-	0xe0, 0x46, 0x50, 0x12, 0x27, 0x15, 0x98, 0x0d, 0x43, 0x55, 0xf8, 0x22, 0x0c, 0xd5, 0x20, 0x0c,
-	0x42, 0x2a, 0xc6, 0x5d, 0x0c, 0x3f, 0x08, 0x7a, 0x18, 0x02, 0x55, 0x20, 0x0a, 0x65, 0x55, 0xf8,
-	0x32, 0x94, 0xd5, 0x60, 0x2e, 0xa8, 0x5c, 0x53, 0xe9, 0x2b, 0x6d, 0xb8, 0x19, 0x94, 0x26, 0x50,
-	0x05, 0x6e, 0x85, 0xb2, 0x2a, 0x7c, 0x15, 0xca, 0x6a, 0x50, 0x3e, 0xb8, 0x00, 0xd9, 0xe5, 0x5d,
-	0xda, 0x14, 0xaf, 0xe5, 0x2e, 0xd8, 0x69, 0x77, 0x8f, 0x35, 0xac, 0x1e, 0x75, 0x71, 0x43, 0x6b,
-	0xb6, 0x95, 0xe3, 0x9e, 0xd6, 0xe8, 0x6a, 0x9d, 0x6e, 0x5f, 0x1b, 0xf4, 0x54, 0x18, 0x41, 0xfb,
-	0xe0, 0xe3, 0xff, 0x09, 0xc4, 0xbf, 0x9c, 0xbf, 0x3e, 0x55, 0x7a, 0x27, 0xf0, 0x5f, 0xa9, 0xfe,
-	0xab, 0xf4, 0xf6, 0x26, 0x2f, 0xbd, 0xbb, 0xc9, 0x4b, 0x7f, 0xdf, 0xe4, 0xa5, 0x9f, 0x6f, 0xf3,
-	0x91, 0x77, 0xb7, 0xf9, 0xc8, 0x9f, 0xb7, 0xf9, 0x08, 0xc8, 0x9b, 0xf4, 0xb1, 0x0b, 0xb4, 0xce,
-	0xef, 0x77, 0xef, 0x8c, 0x87, 0xce, 0xa4, 0x37, 0xf5, 0x67, 0xbf, 0xb0, 0xf3, 0xef, 0x90, 0x31,
-	0xb1, 0x17, 0x5f, 0x44, 0xbf, 0x45, 0x77, 0xba, 0x0e, 0xb1, 0xfb, 0x4b, 0x07, 0xe1, 0xcd, 0x7f,
-	0x7e, 0xbc, 0xd2, 0xf9, 0xe1, 0x30, 0x21, 0xf4, 0xd5, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0x48,
-	0x86, 0x67, 0x14, 0x55, 0x09, 0x00, 0x00,
+	// 949 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x96, 0x41, 0x6f, 0xe2, 0x46,
+	0x14, 0xc7, 0x71, 0x12, 0x02, 0x4c, 0x08, 0x3b, 0x9d, 0x25, 0x59, 0x37, 0x51, 0x09, 0x4d, 0xab,
+	0x94, 0xa6, 0x12, 0x28, 0x40, 0xa5, 0xed, 0xad, 0x26, 0x98, 0x88, 0x86, 0x40, 0x34, 0x40, 0xaa,
+	0x6c, 0x2b, 0x59, 0x06, 0x4f, 0xa9, 0x25, 0xe3, 0xb1, 0xc6, 0x03, 0x4a, 0xee, 0xfd, 0x00, 0xfd,
+	0x04, 0xbd, 0x56, 0xea, 0xd7, 0x68, 0x0f, 0x7b, 0xdc, 0x63, 0xd5, 0xc3, 0xaa, 0x4a, 0x2e, 0xfd,
+	0x16, 0xad, 0x66, 0x30, 0x84, 0xa4, 0x76, 0x76, 0x73, 0xca, 0xcc, 0xfb, 0xfd, 0xdf, 0xff, 0xbd,
+	0xf1, 0x8b, 0x07, 0x83, 0x03, 0xea, 0x11, 0x97, 0x13, 0x87, 0x8c, 0x09, 0x67, 0xd7, 0x25, 0x8f,
+	0x51, 0x4e, 0x4b, 0x0e, 0x1d, 0xf9, 0xa5, 0xe9, 0x91, 0xfc, 0x5b, 0x94, 0x21, 0xb4, 0x7b, 0x4f,
+	0x37, 0x0b, 0x16, 0x25, 0x9f, 0x1e, 0xed, 0x64, 0x47, 0x74, 0x44, 0x67, 0xa9, 0x62, 0x35, 0xa3,
+	0x3b, 0x87, 0x61, 0xd6, 0x43, 0x3a, 0x1e, 0x53, 0x57, 0x98, 0xcf, 0x56, 0x81, 0xb6, 0x18, 0xa6,
+	0x65, 0xc4, 0xa7, 0x13, 0x36, 0x24, 0x42, 0x3d, 0x5f, 0xcf, 0xf4, 0xfb, 0xaf, 0x40, 0xb2, 0x45,
+	0x47, 0x7e, 0xdd, 0xe4, 0x26, 0x6a, 0x83, 0xcd, 0x39, 0x35, 0x44, 0x47, 0xaa, 0x92, 0x5f, 0x2d,
+	0x6c, 0x94, 0x3f, 0x2f, 0x3e, 0xd2, 0x72, 0x11, 0x07, 0x19, 0xc2, 0x05, 0xa7, 0xd9, 0xd2, 0x6e,
+	0xff, 0x97, 0x15, 0x90, 0x5e, 0xc6, 0xe8, 0x3b, 0xb0, 0x65, 0x11, 0x8f, 0x91, 0xa1, 0xc9, 0x89,
+	0x65, 0xf8, 0x43, 0xea, 0x05, 0x85, 0xfe, 0x49, 0xc8, 0x4a, 0x07, 0x8f, 0x56, 0xea, 0x0a, 0xbd,
+	0x2c, 0xf3, 0xfc, 0xce, 0x65, 0x11, 0x44, 0xa7, 0x20, 0x39, 0xaf, 0xae, 0x2a, 0x79, 0x25, 0xb2,
+	0xf1, 0xc5, 0x03, 0x58, 0x6a, 0xbe, 0xb6, 0xf6, 0xfa, 0xed, 0x5e, 0x0c, 0x2f, 0x0c, 0x90, 0x0e,
+	0xc0, 0x52, 0x7b, 0x2b, 0x4f, 0xea, 0x2e, 0xe5, 0x2f, 0x7a, 0xfa, 0x48, 0xd8, 0xfc, 0x48, 0xc6,
+	0xa6, 0x31, 0x61, 0x8e, 0xba, 0x9a, 0x57, 0x0a, 0x29, 0x9c, 0x9a, 0x45, 0xfa, 0xcc, 0xd9, 0xff,
+	0x43, 0x01, 0xa9, 0xbb, 0x03, 0x74, 0x40, 0x5c, 0x66, 0x06, 0xdd, 0x57, 0x42, 0xcb, 0x05, 0xc3,
+	0x9e, 0x1e, 0x15, 0x9b, 0xae, 0xcf, 0xd9, 0x64, 0x4c, 0x5c, 0x6e, 0x72, 0x9b, 0xba, 0xd2, 0x27,
+	0x38, 0xc7, 0xcc, 0x07, 0x9d, 0x80, 0x0d, 0x87, 0x8e, 0x0c, 0x46, 0x86, 0x94, 0x59, 0xef, 0x77,
+	0x8a, 0x16, 0x1d, 0x61, 0x29, 0xc7, 0xc0, 0x99, 0x2f, 0xdf, 0x79, 0x8c, 0x9f, 0xe2, 0x20, 0xb5,
+	0x48, 0x44, 0x9f, 0x82, 0x0c, 0xb7, 0xc7, 0xc4, 0x98, 0xb8, 0xf6, 0x95, 0xe1, 0x9a, 0x2e, 0x95,
+	0xe7, 0x59, 0xc7, 0x69, 0x11, 0xed, 0xbb, 0xf6, 0x55, 0xdb, 0x74, 0x29, 0xfa, 0x12, 0xbc, 0xa0,
+	0x03, 0x9f, 0xb0, 0x29, 0xb1, 0x8c, 0x07, 0xf2, 0x0d, 0x29, 0xcf, 0xce, 0x71, 0x6f, 0x39, 0xad,
+	0x07, 0x9e, 0xf9, 0x64, 0x4a, 0x98, 0xcd, 0xaf, 0x0d, 0x77, 0x32, 0x1e, 0x10, 0xa6, 0xae, 0xe4,
+	0x95, 0x42, 0xa6, 0xfc, 0xc5, 0xe3, 0xc3, 0x09, 0x72, 0xda, 0x32, 0x05, 0x67, 0xfc, 0x7b, 0x7b,
+	0xf4, 0x09, 0xd8, 0x5c, 0xb8, 0x72, 0x72, 0xc5, 0x83, 0x23, 0xa6, 0xe7, 0xc1, 0x1e, 0xb9, 0xe2,
+	0x48, 0x03, 0x6b, 0x03, 0x6a, 0x5d, 0xab, 0x71, 0x39, 0x9d, 0xcf, 0xde, 0x31, 0x1d, 0xcd, 0xbd,
+	0xbe, 0x30, 0x9d, 0xc9, 0x7c, 0x22, 0x32, 0x15, 0x9d, 0x01, 0x60, 0x72, 0xce, 0xec, 0xc1, 0x84,
+	0x13, 0x5f, 0x5d, 0xcf, 0xaf, 0xbe, 0x87, 0xd1, 0x29, 0xb9, 0x67, 0xb4, 0x64, 0x80, 0x5e, 0x02,
+	0xd5, 0x62, 0xd4, 0xf3, 0x88, 0x65, 0xdc, 0x45, 0x8d, 0x21, 0x9d, 0xb8, 0x5c, 0x4d, 0xe4, 0x95,
+	0xc2, 0x26, 0xde, 0x0e, 0xb8, 0xb6, 0xc0, 0xc7, 0x82, 0xa2, 0x2c, 0x88, 0xff, 0xe0, 0x98, 0x23,
+	0x5f, 0x4d, 0xe6, 0x95, 0x42, 0x02, 0xcf, 0x36, 0xe8, 0x7b, 0x90, 0xe4, 0xcc, 0x1c, 0x12, 0xc3,
+	0xb6, 0xd4, 0x54, 0x5e, 0x29, 0xa4, 0x6b, 0x9a, 0xa8, 0xf9, 0xd7, 0xdb, 0xbd, 0xaf, 0x46, 0xf4,
+	0x41, 0x9b, 0xb6, 0xb8, 0x81, 0x1c, 0x87, 0x0c, 0x39, 0x65, 0x25, 0xcf, 0x32, 0xb9, 0x59, 0xb2,
+	0x5d, 0x4e, 0x98, 0x6b, 0x3a, 0x25, 0xb1, 0x2b, 0xf6, 0x84, 0x53, 0xb3, 0x8e, 0x13, 0xd2, 0xb2,
+	0x69, 0xa1, 0x4b, 0x90, 0xf0, 0x3d, 0xd3, 0x15, 0xe6, 0x40, 0x9a, 0x7f, 0x1d, 0x98, 0xbf, 0x7c,
+	0xba, 0x79, 0xd7, 0x33, 0xdd, 0x66, 0x1d, 0xaf, 0x0b, 0xc3, 0xa6, 0xf5, 0xcd, 0x5a, 0x72, 0x0d,
+	0xc6, 0x0f, 0x7f, 0x8f, 0x83, 0xcc, 0xfd, 0x41, 0xa3, 0x3d, 0xb0, 0xdb, 0xd5, 0x2f, 0x74, 0xdc,
+	0xec, 0x5d, 0x1a, 0xed, 0xfe, 0x59, 0x4d, 0xc7, 0x46, 0xbf, 0xdd, 0x3d, 0xd7, 0x8f, 0x9b, 0x8d,
+	0xa6, 0x5e, 0x87, 0x31, 0xf4, 0x21, 0xd8, 0x7a, 0x28, 0xe8, 0x61, 0xed, 0x58, 0x87, 0x0a, 0xda,
+	0x01, 0xdb, 0xa1, 0xa8, 0x0c, 0x57, 0x22, 0x59, 0x05, 0xae, 0x46, 0xb2, 0x2a, 0x5c, 0x0b, 0x2b,
+	0x57, 0xd7, 0x6b, 0xfd, 0x13, 0x18, 0x0f, 0x4b, 0x93, 0xa8, 0x0c, 0xd7, 0x23, 0x59, 0x05, 0x26,
+	0x22, 0x59, 0x15, 0x26, 0x91, 0x0a, 0xb2, 0x0f, 0x59, 0xb3, 0xdd, 0xe8, 0xc0, 0x54, 0x58, 0x23,
+	0x82, 0x94, 0x21, 0x88, 0x42, 0x15, 0xb8, 0x11, 0x85, 0xaa, 0x30, 0x1d, 0x56, 0xea, 0x5b, 0x0d,
+	0xb7, 0xe1, 0x66, 0x58, 0x92, 0x20, 0x65, 0x98, 0x89, 0x42, 0x15, 0xf8, 0x2c, 0x0a, 0x55, 0x21,
+	0x0c, 0x43, 0x3a, 0xc6, 0x1d, 0x0c, 0x3f, 0x08, 0x7b, 0x18, 0x12, 0x95, 0x21, 0x8a, 0x64, 0x15,
+	0xf8, 0x3c, 0x92, 0x55, 0x61, 0x36, 0xac, 0x5c, 0x43, 0xeb, 0x69, 0x2d, 0xb8, 0x15, 0x96, 0x26,
+	0x51, 0x19, 0x6e, 0x47, 0xb2, 0x0a, 0x7c, 0x11, 0xc9, 0xaa, 0x50, 0x3d, 0xbc, 0x04, 0x99, 0xc5,
+	0x5d, 0xda, 0x90, 0xaf, 0xe5, 0x1e, 0xd8, 0x6d, 0x75, 0x4e, 0x0c, 0xac, 0x1f, 0x77, 0x70, 0xdd,
+	0x68, 0xb4, 0xb4, 0x93, 0xae, 0x51, 0xef, 0x18, 0xed, 0x4e, 0xcf, 0xe8, 0x77, 0x75, 0x18, 0x43,
+	0x07, 0xe0, 0xe3, 0xff, 0x09, 0xe4, 0xbf, 0x5c, 0xb0, 0x3e, 0xd3, 0xba, 0xa7, 0xf0, 0x5f, 0xa5,
+	0xf6, 0xab, 0xf2, 0xfa, 0x26, 0xa7, 0xbc, 0xb9, 0xc9, 0x29, 0x7f, 0xdf, 0xe4, 0x94, 0x9f, 0x6f,
+	0x73, 0xb1, 0x37, 0xb7, 0xb9, 0xd8, 0x9f, 0xb7, 0xb9, 0x18, 0xc8, 0xd9, 0xf4, 0xb1, 0x0b, 0xb4,
+	0x26, 0xee, 0x77, 0xff, 0x5c, 0x84, 0xce, 0x95, 0x57, 0xb5, 0x27, 0xbf, 0xb0, 0xb3, 0xef, 0x90,
+	0x11, 0x71, 0xe7, 0x5f, 0x44, 0xbf, 0xad, 0xec, 0x76, 0x3c, 0xe2, 0xf6, 0x16, 0x0e, 0xd2, 0x5b,
+	0xfc, 0xfc, 0xf8, 0xc5, 0x8b, 0xa3, 0xc1, 0xba, 0xd4, 0x57, 0xfe, 0x1b, 0x00, 0x48, 0x86, 0x67,
+	0x14, 0x55, 0x09, 0x00, 0x00,
 }
 
 func (m *LogsData) Marshal() (dAtA []byte, err error) {
@@ -223,6 +223,9 @@ type ResourceMetrics struct {
 	Resource v1.Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource"`
 	// A list of metrics that originate from a resource.
 	ScopeMetrics []*ScopeMetrics `protobuf:"bytes,2,rep,name=scope_metrics,json=scopeMetrics,proto3" json:"scope_metrics,omitempty"`
+	// The Schema URL, if known. This is the identifier of the Schema that the resource data
+	// is recorded in. To learn more about Schema URL see
+	// https://opentelemetry.io/docs/specs/otel/schemas/#schema-url
 	// This schema_url applies to the data in the "resource" field. It does not apply
 	// to the data in the "scope_metrics" field which have their own schema_url field.
 	SchemaUrl string `protobuf:"bytes,3,opt,name=schema_url,json=schemaUrl,proto3" json:"schema_url,omitempty"`
@@ -297,6 +300,9 @@ type ScopeMetrics struct {
 	Scope v11.InstrumentationScope `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope"`
 	// A list of metrics that originate from an instrumentation library.
 	Metrics []*Metric `protobuf:"bytes,2,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	// The Schema URL, if known. This is the identifier of the Schema that the metric data
+	// is recorded in. To learn more about Schema URL see
+	// https://opentelemetry.io/docs/specs/otel/schemas/#schema-url
 	// This schema_url applies to all metrics in the "metrics" field.
 	SchemaUrl string `protobuf:"bytes,3,opt,name=schema_url,json=schemaUrl,proto3" json:"schema_url,omitempty"`
 }
@@ -443,7 +449,7 @@ func (m *ScopeMetrics) GetSchemaUrl() string {
 // when the start time is truly unknown, setting StartTimeUnixNano is
 // strongly encouraged.
 type Metric struct {
-	// name of the metric, including its DNS name prefix. It must be unique.
+	// name of the metric.
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	// description of the metric, which can be used in documentation.
 	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
@@ -461,6 +467,14 @@ type Metric struct {
 	//	*Metric_ExponentialHistogram
 	//	*Metric_Summary
 	Data isMetric_Data `protobuf_oneof:"data"`
+	// Additional metadata attributes that describe the metric. [Optional].
+	// Attributes are non-identifying.
+	// Consumers SHOULD NOT need to be aware of these attributes.
+	// These attributes MAY be used to encode information allowing
+	// for lossless roundtrip translation to / from another data model.
+	// Attribute keys MUST be unique (it is not allowed to have more than one
+	// attribute with the same key).
+	Metadata []v11.KeyValue `protobuf:"bytes,12,rep,name=metadata,proto3" json:"metadata"`
 }
 
 func (m *Metric) Reset()         { *m = Metric{} }
@@ -587,6 +601,13 @@ func (m *Metric) GetSummary() *Summary {
 	return nil
 }
 
+func (m *Metric) GetMetadata() []v11.KeyValue {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Metric) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -901,7 +922,6 @@ type NumberDataPoint struct {
 	// value fields is not present inside this oneof.
 	//
 	// Types that are valid to be assigned to Value:
-	//
 	//	*NumberDataPoint_AsDouble
 	//	*NumberDataPoint_AsInt
 	Value isNumberDataPoint_Value `protobuf_oneof:"value"`
@@ -1067,7 +1087,6 @@ type HistogramDataPoint struct {
 	// see: https://github.com/OpenObservability/OpenMetrics/blob/main/specification/OpenMetrics.md#histogram
 	//
 	// Types that are valid to be assigned to Sum_:
-	//
 	//	*HistogramDataPoint_Sum
 	Sum_ isHistogramDataPoint_Sum_ `protobuf_oneof:"sum_"`
 	// bucket_counts is an optional field contains the count values of histogram
@@ -1101,13 +1120,11 @@ type HistogramDataPoint struct {
 	// min is the minimum value over (start_time, end_time].
 	//
 	// Types that are valid to be assigned to Min_:
-	//
 	//	*HistogramDataPoint_Min
 	Min_ isHistogramDataPoint_Min_ `protobuf_oneof:"min_"`
 	// max is the maximum value over (start_time, end_time].
 	//
 	// Types that are valid to be assigned to Max_:
-	//
 	//	*HistogramDataPoint_Max
 	Max_ isHistogramDataPoint_Max_ `protobuf_oneof:"max_"`
 }
@@ -1315,13 +1332,12 @@ type ExponentialHistogramDataPoint struct {
 	// see: https://github.com/OpenObservability/OpenMetrics/blob/main/specification/OpenMetrics.md#histogram
 	//
 	// Types that are valid to be assigned to Sum_:
-	//
 	//	*ExponentialHistogramDataPoint_Sum
 	Sum_ isExponentialHistogramDataPoint_Sum_ `protobuf_oneof:"sum_"`
 	// scale describes the resolution of the histogram.  Boundaries are
 	// located at powers of the base, where:
 	//
-	//	base = (2^(2^-scale))
+	//   base = (2^(2^-scale))
 	//
 	// The histogram bucket identified by `index`, a signed integer,
 	// contains values that are greater than (base^index) and
@@ -1356,13 +1372,11 @@ type ExponentialHistogramDataPoint struct {
 	// min is the minimum value over (start_time, end_time].
 	//
 	// Types that are valid to be assigned to Min_:
-	//
 	//	*ExponentialHistogramDataPoint_Min
 	Min_ isExponentialHistogramDataPoint_Min_ `protobuf_oneof:"min_"`
 	// max is the maximum value over (start_time, end_time].
 	//
 	// Types that are valid to be assigned to Max_:
-	//
 	//	*ExponentialHistogramDataPoint_Max
 	Max_ isExponentialHistogramDataPoint_Max_ `protobuf_oneof:"max_"`
 	// ZeroThreshold may be optionally set to convey the width of the zero
@@ -1833,7 +1847,6 @@ type Exemplar struct {
 	// inside this oneof.
 	//
 	// Types that are valid to be assigned to Value:
-	//
 	//	*Exemplar_AsDouble
 	//	*Exemplar_AsInt
 	Value isExemplar_Value `protobuf_oneof:"value"`
@@ -1965,105 +1978,105 @@ func init() {
 }
 
 var fileDescriptor_3c3112f9fa006917 = []byte{
-	// 1553 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x58, 0xcd, 0x4f, 0x1b, 0x49,
-	0x16, 0x77, 0xfb, 0xdb, 0xcf, 0x06, 0x9c, 0x5a, 0x96, 0xb4, 0x58, 0xe1, 0x38, 0xce, 0x26, 0xb0,
-	0xd9, 0xc8, 0x5e, 0xc8, 0x6a, 0x3f, 0x0e, 0x91, 0x62, 0x63, 0x03, 0x26, 0x80, 0x49, 0xd9, 0x20,
-	0x25, 0x8a, 0xd2, 0x2a, 0xec, 0xc2, 0xb4, 0xd2, 0xdd, 0xe5, 0xed, 0xae, 0x46, 0xb0, 0xff, 0xc1,
-	0x4a, 0x7b, 0xc8, 0xdf, 0xb1, 0xda, 0xdb, 0x9c, 0xe6, 0x36, 0xc7, 0x1c, 0x33, 0xb7, 0xd1, 0x68,
-	0x14, 0xcd, 0x90, 0xc3, 0x8c, 0x34, 0xff, 0xc4, 0xa8, 0xaa, 0xbb, 0xf1, 0x07, 0x26, 0x26, 0x1f,
-	0x87, 0xe4, 0xd4, 0x55, 0xaf, 0xde, 0xfb, 0xd5, 0xfb, 0xf8, 0x55, 0xbd, 0x52, 0xc3, 0x3d, 0xd6,
-	0xa3, 0x16, 0xa7, 0x06, 0x35, 0x29, 0xb7, 0x4f, 0x4b, 0x3d, 0x9b, 0x71, 0x56, 0x12, 0x63, 0xbd,
-	0xed, 0x94, 0x8e, 0x97, 0x83, 0x61, 0x51, 0x2e, 0xa0, 0xdc, 0x90, 0xb6, 0x27, 0x2c, 0x06, 0x2a,
-	0xc7, 0xcb, 0xf3, 0xb3, 0x5d, 0xd6, 0x65, 0x1e, 0x86, 0x18, 0x79, 0x0a, 0xf3, 0x77, 0xc7, 0xed,
-	0xd1, 0x66, 0xa6, 0xc9, 0x2c, 0xb1, 0x85, 0x37, 0xf2, 0x75, 0x8b, 0xe3, 0x74, 0x6d, 0xea, 0x30,
-	0xd7, 0x6e, 0x53, 0xa1, 0x1d, 0x8c, 0x3d, 0xfd, 0x82, 0x0e, 0xe9, 0x6d, 0x6f, 0xff, 0x2a, 0xe1,
-	0x04, 0x3d, 0x85, 0x6c, 0xa0, 0xa0, 0xf9, 0x7e, 0xa9, 0x4a, 0x3e, 0xb2, 0x94, 0x5e, 0x29, 0x15,
-	0xdf, 0xed, 0x7b, 0x11, 0xfb, 0x76, 0x3e, 0x1c, 0x9e, 0xb1, 0x87, 0x05, 0x85, 0xaf, 0xc3, 0x30,
-	0x33, 0xa2, 0x84, 0xba, 0xa0, 0x76, 0x68, 0xcf, 0xa6, 0x6d, 0xc2, 0x69, 0x47, 0x73, 0xda, 0xac,
-	0xd7, 0xdf, 0xf7, 0x97, 0x84, 0xdc, 0xf8, 0xde, 0xa4, 0x8d, 0x9b, 0xc2, 0x2a, 0xd8, 0x75, 0xae,
-	0x0f, 0x37, 0x28, 0x47, 0x8f, 0x20, 0x19, 0xf8, 0xa3, 0x2a, 0x79, 0x65, 0x29, 0xbd, 0xf2, 0xa7,
-	0xb1, 0xb8, 0xe7, 0xe9, 0x19, 0x88, 0xa8, 0x12, 0x7d, 0xf5, 0xe6, 0x46, 0x08, 0x9f, 0x03, 0xa0,
-	0xc7, 0x30, 0x35, 0xec, 0x6a, 0xf8, 0x03, 0x3c, 0xcd, 0x38, 0x83, 0xfe, 0x2d, 0x00, 0x38, 0xed,
-	0x23, 0x6a, 0x12, 0xcd, 0xb5, 0x0d, 0x35, 0x92, 0x57, 0x96, 0x52, 0x38, 0xe5, 0x49, 0xf6, 0x6c,
-	0xa3, 0xf0, 0x8d, 0x02, 0x99, 0xa1, 0x78, 0x1a, 0x10, 0x93, 0xf6, 0x7e, 0x30, 0xf7, 0xc7, 0x6e,
-	0xed, 0x33, 0xe3, 0x78, 0xb9, 0x58, 0xb7, 0x1c, 0x6e, 0xbb, 0x26, 0xb5, 0x38, 0xe1, 0x3a, 0xb3,
-	0x24, 0x94, 0x1f, 0x96, 0x87, 0x83, 0x1e, 0x42, 0x62, 0x38, 0x9a, 0x3b, 0x93, 0xa2, 0xf1, 0x5c,
-	0xc1, 0x81, 0xd9, 0xa4, 0x10, 0x7e, 0x8a, 0x40, 0xdc, 0x33, 0x41, 0x08, 0xa2, 0x16, 0x31, 0x3d,
-	0xdf, 0x53, 0x58, 0x8e, 0x51, 0x1e, 0xd2, 0x1d, 0xea, 0xb4, 0x6d, 0xbd, 0x27, 0x1c, 0x54, 0xc3,
-	0x72, 0x69, 0x50, 0x24, 0xac, 0x5c, 0x4b, 0xe7, 0x3e, 0xb2, 0x1c, 0xa3, 0x07, 0x10, 0xeb, 0x12,
-	0xb7, 0x4b, 0xd5, 0x98, 0x4c, 0xc3, 0xed, 0x49, 0x3e, 0xaf, 0x0b, 0xe5, 0x8d, 0x10, 0xf6, 0xac,
-	0xd0, 0xdf, 0x21, 0xe2, 0xb8, 0xa6, 0x9a, 0x90, 0xc6, 0xb7, 0x26, 0x96, 0xcf, 0x35, 0x37, 0x42,
-	0x58, 0x58, 0xa0, 0x3a, 0xa4, 0x8e, 0x74, 0x87, 0xb3, 0xae, 0x4d, 0x4c, 0x35, 0xf5, 0x0e, 0x3e,
-	0x0d, 0x98, 0x6f, 0x04, 0x06, 0x1b, 0x21, 0xdc, 0xb7, 0x46, 0x2f, 0xe0, 0xf7, 0xf4, 0xa4, 0xc7,
-	0x2c, 0x6a, 0x71, 0x9d, 0x18, 0x5a, 0x1f, 0x16, 0x24, 0xec, 0x5f, 0x27, 0xc1, 0xd6, 0xfa, 0xc6,
-	0x83, 0x3b, 0xcc, 0xd2, 0x31, 0x72, 0xb4, 0x0a, 0x09, 0xc7, 0x35, 0x4d, 0x62, 0x9f, 0xaa, 0x69,
-	0x09, 0xbf, 0x78, 0x85, 0xa0, 0x85, 0xfa, 0x46, 0x08, 0x07, 0x96, 0x95, 0x38, 0x44, 0x3b, 0x84,
-	0x93, 0xcd, 0x68, 0x32, 0x9a, 0x8d, 0x6d, 0x46, 0x93, 0xf1, 0x6c, 0x62, 0x33, 0x9a, 0x4c, 0x66,
-	0x53, 0x85, 0x27, 0x10, 0x93, 0x19, 0x46, 0xbb, 0x90, 0x16, 0x2a, 0x5a, 0x8f, 0xe9, 0x16, 0xbf,
-	0xf2, 0x15, 0xb2, 0xe3, 0x9a, 0x07, 0xd4, 0x16, 0x17, 0xd1, 0xae, 0xb0, 0xc3, 0xd0, 0x09, 0x86,
-	0x4e, 0xe1, 0x57, 0x05, 0x22, 0x4d, 0xd7, 0xfc, 0xf4, 0xc8, 0x88, 0xc1, 0x75, 0xd2, 0xed, 0xda,
-	0xb4, 0x2b, 0x8f, 0x86, 0xc6, 0xa9, 0xd9, 0x63, 0x36, 0x31, 0x74, 0x7e, 0x2a, 0x59, 0x38, 0xbd,
-	0xf2, 0xb7, 0x49, 0xe8, 0xe5, 0xbe, 0x79, 0xab, 0x6f, 0x8d, 0xe7, 0xc8, 0x58, 0x39, 0xba, 0x09,
-	0x19, 0xdd, 0xd1, 0x4c, 0x66, 0x31, 0xce, 0x2c, 0xbd, 0x2d, 0x09, 0x9d, 0xc4, 0x69, 0xdd, 0xd9,
-	0x0e, 0x44, 0x85, 0x6f, 0x15, 0x48, 0xf5, 0xab, 0xd6, 0x1c, 0x17, 0xf3, 0xca, 0x95, 0xf9, 0xf6,
-	0x79, 0x84, 0x5d, 0xf8, 0x59, 0x81, 0xd9, 0x71, 0x64, 0x45, 0xcf, 0xc7, 0x85, 0xf7, 0xe0, 0x43,
-	0x78, 0xff, 0x99, 0x44, 0xfa, 0x0c, 0x12, 0xfe, 0xb1, 0x41, 0x8f, 0xc7, 0xc5, 0xf6, 0x97, 0x2b,
-	0x1e, 0xba, 0xf1, 0x27, 0xe1, 0x2c, 0x0c, 0x33, 0x23, 0x7c, 0x46, 0xdb, 0x00, 0x84, 0x73, 0x5b,
-	0x3f, 0x70, 0x39, 0x75, 0x54, 0xaf, 0x71, 0x2e, 0x4e, 0xe8, 0x09, 0x8f, 0xe8, 0xe9, 0x3e, 0x31,
-	0xdc, 0xa0, 0x0f, 0x0c, 0x00, 0xa0, 0x12, 0xcc, 0x3a, 0x9c, 0xd8, 0x5c, 0xe3, 0xba, 0x49, 0x35,
-	0xd7, 0xd2, 0x4f, 0x34, 0x8b, 0x58, 0x4c, 0xa6, 0x2b, 0x8e, 0xaf, 0xc9, 0xb5, 0x96, 0x6e, 0xd2,
-	0x3d, 0x4b, 0x3f, 0xd9, 0x21, 0x16, 0x43, 0x7f, 0x84, 0xe9, 0x11, 0xd5, 0x88, 0x54, 0xcd, 0xf0,
-	0x41, 0xad, 0x05, 0x48, 0x11, 0x47, 0xeb, 0x30, 0xf7, 0xc0, 0xa0, 0x6a, 0x34, 0xaf, 0x2c, 0x29,
-	0x1b, 0x21, 0x9c, 0x24, 0x4e, 0x55, 0x4a, 0xd0, 0x75, 0x88, 0x13, 0x47, 0xd3, 0x2d, 0xae, 0xc6,
-	0xf3, 0xca, 0x52, 0x56, 0x5c, 0xd3, 0xc4, 0xa9, 0x5b, 0x1c, 0x6d, 0x41, 0x8a, 0x9e, 0x50, 0xb3,
-	0x67, 0x10, 0xdb, 0x51, 0x63, 0x32, 0xb8, 0xa5, 0xc9, 0xf4, 0xf0, 0x0c, 0xfc, 0xe8, 0xfa, 0x00,
-	0x68, 0x16, 0x62, 0x87, 0x06, 0xe9, 0x3a, 0x6a, 0x32, 0xaf, 0x2c, 0x4d, 0x61, 0x6f, 0x52, 0x49,
-	0x40, 0xec, 0x58, 0x64, 0x63, 0x33, 0x9a, 0x54, 0xb2, 0xe1, 0xc2, 0x0f, 0x11, 0x40, 0x17, 0x69,
-	0x35, 0x92, 0xe7, 0xd4, 0x67, 0x9a, 0xe7, 0x59, 0x88, 0xb5, 0x99, 0x6b, 0x71, 0x99, 0xe3, 0x38,
-	0xf6, 0x26, 0x08, 0x79, 0xcd, 0x2e, 0xe6, 0xe7, 0x5d, 0xf6, 0xb1, 0x5b, 0x30, 0x75, 0xe0, 0xb6,
-	0x5f, 0x50, 0xae, 0x49, 0x1d, 0x47, 0x8d, 0xe7, 0x23, 0x02, 0xce, 0x13, 0xae, 0x4a, 0x19, 0x5a,
-	0x84, 0x19, 0x7a, 0xd2, 0x33, 0xf4, 0xb6, 0xce, 0xb5, 0x03, 0xe6, 0x5a, 0x1d, 0x8f, 0x61, 0x0a,
-	0x9e, 0x0e, 0xc4, 0x15, 0x29, 0x1d, 0xae, 0x53, 0xf2, 0x93, 0xd5, 0x09, 0x06, 0xea, 0x24, 0xa2,
-	0x30, 0x75, 0x4b, 0x76, 0x2f, 0x65, 0x43, 0xc1, 0x62, 0x22, 0x65, 0xe4, 0x44, 0xcd, 0x48, 0x59,
-	0x18, 0x8b, 0x89, 0x68, 0x52, 0x8e, 0x6b, 0x6a, 0xe2, 0x6b, 0xea, 0x96, 0xf7, 0x25, 0x27, 0x9a,
-	0x5f, 0xde, 0xff, 0xc4, 0x61, 0xe1, 0x9d, 0x17, 0xc8, 0x48, 0xa5, 0x95, 0x2f, 0xbe, 0xd2, 0xb3,
-	0xe2, 0xc1, 0x48, 0x0c, 0x2a, 0xcf, 0xd6, 0x35, 0xec, 0x4d, 0xc4, 0x9b, 0xed, 0xdf, 0xd4, 0x66,
-	0x5e, 0xf5, 0xe5, 0x3b, 0x28, 0x8e, 0x53, 0x42, 0x22, 0x4b, 0x8f, 0xba, 0x90, 0xec, 0x31, 0x47,
-	0xe7, 0xfa, 0x31, 0x95, 0xa7, 0x25, 0xbd, 0x52, 0xfb, 0xa8, 0x6b, 0xb9, 0x58, 0x91, 0xbc, 0x72,
-	0x82, 0x17, 0x75, 0x00, 0x2e, 0x36, 0xb2, 0xe4, 0x45, 0x7a, 0x4c, 0xfd, 0xe7, 0xd4, 0xa7, 0xdd,
-	0x28, 0x00, 0xbf, 0x84, 0x54, 0x43, 0xc4, 0x4d, 0x7f, 0x2c, 0x71, 0x7d, 0x8a, 0x66, 0xc6, 0x50,
-	0x74, 0x6a, 0x80, 0xa2, 0xe8, 0x36, 0x4c, 0xcb, 0xe4, 0xf3, 0x23, 0x9b, 0x3a, 0x47, 0xcc, 0xe8,
-	0xa8, 0xd3, 0x62, 0x19, 0x4f, 0x09, 0x69, 0x2b, 0x10, 0xce, 0xaf, 0x41, 0xc2, 0x8f, 0x06, 0xcd,
-	0x41, 0x9c, 0x1d, 0x1e, 0x3a, 0x94, 0xcb, 0xa7, 0xf3, 0x35, 0xec, 0xcf, 0x2e, 0x1e, 0x63, 0xf1,
-	0x84, 0x8f, 0x0e, 0x1f, 0xe3, 0xcb, 0x4e, 0x44, 0xe1, 0xff, 0x11, 0xc8, 0x8e, 0x36, 0x9c, 0x2f,
-	0xa4, 0xa1, 0x8c, 0xa7, 0x7f, 0x76, 0x80, 0xfe, 0x1e, 0xf9, 0x75, 0x98, 0xf9, 0x97, 0x4b, 0x2c,
-	0xae, 0x1b, 0x54, 0x93, 0xb7, 0xbc, 0x77, 0xd1, 0xa5, 0x57, 0x1e, 0xbe, 0x6f, 0x27, 0x2e, 0xca,
-	0x08, 0xcb, 0xfc, 0xb1, 0x0f, 0x87, 0xa7, 0x03, 0x60, 0xb9, 0x70, 0x49, 0x77, 0x99, 0x5f, 0x85,
-	0x99, 0x11, 0x43, 0x34, 0x0f, 0xc9, 0xc0, 0x54, 0x56, 0x53, 0xc1, 0xe7, 0x73, 0x01, 0x22, 0xdd,
-	0x94, 0xf9, 0x51, 0xf0, 0x50, 0x67, 0x7a, 0x19, 0x81, 0x64, 0xc0, 0x3d, 0xf4, 0x1c, 0x7e, 0x77,
-	0xa8, 0x1b, 0x9c, 0xda, 0xb4, 0xa3, 0x7d, 0x6c, 0xbd, 0x50, 0x80, 0x54, 0xee, 0xd7, 0xed, 0x62,
-	0x19, 0xc2, 0x93, 0xfa, 0x7a, 0xe4, 0xea, 0x7d, 0xfd, 0x09, 0x24, 0x9c, 0x1e, 0xb1, 0x34, 0xbd,
-	0x23, 0x0b, 0x98, 0xa9, 0x3c, 0x14, 0x8e, 0x7c, 0xff, 0xe6, 0xc6, 0x3f, 0xba, 0x6c, 0xc4, 0x77,
-	0x9d, 0x95, 0xda, 0xcc, 0x30, 0x68, 0x9b, 0x33, 0xbb, 0xd4, 0x13, 0xaf, 0xa1, 0x92, 0x6e, 0x71,
-	0x6a, 0x5b, 0xc4, 0x28, 0x89, 0x59, 0xb1, 0xd9, 0x23, 0x56, 0xbd, 0x8a, 0xe3, 0x02, 0xb0, 0xde,
-	0x41, 0xcf, 0x20, 0xc9, 0x6d, 0xd2, 0xa6, 0x02, 0x3b, 0x26, 0xb1, 0xcb, 0x3e, 0xf6, 0x3f, 0xdf,
-	0x1f, 0xbb, 0x25, 0x90, 0xea, 0x55, 0x9c, 0x90, 0x90, 0xf5, 0xce, 0xc8, 0x63, 0xe1, 0xee, 0x7f,
-	0x15, 0x98, 0x1b, 0xff, 0x44, 0x44, 0x8b, 0x70, 0xab, 0xbc, 0xbe, 0x8e, 0x6b, 0xeb, 0xe5, 0x56,
-	0xbd, 0xb1, 0xa3, 0xb5, 0x6a, 0xdb, 0xbb, 0x0d, 0x5c, 0xde, 0xaa, 0xb7, 0x9e, 0x68, 0x7b, 0x3b,
-	0xcd, 0xdd, 0xda, 0x6a, 0x7d, 0xad, 0x5e, 0xab, 0x66, 0x43, 0xe8, 0x26, 0x2c, 0x5c, 0xa6, 0x58,
-	0xad, 0x6d, 0xb5, 0xca, 0x59, 0x05, 0xdd, 0x81, 0xc2, 0x65, 0x2a, 0xab, 0x7b, 0xdb, 0x7b, 0x5b,
-	0xe5, 0x56, 0x7d, 0xbf, 0x96, 0x0d, 0xdf, 0x7d, 0x0e, 0xd3, 0xe7, 0x7c, 0x5d, 0x93, 0xf7, 0xdb,
-	0x0d, 0xf8, 0x43, 0xb5, 0xdc, 0x2a, 0x6b, 0xbb, 0x8d, 0xfa, 0x4e, 0x4b, 0x5b, 0xdb, 0x2a, 0xaf,
-	0x37, 0xb5, 0x6a, 0x43, 0xdb, 0x69, 0xb4, 0xb4, 0xbd, 0x66, 0x2d, 0x1b, 0x42, 0x7f, 0x86, 0xc5,
-	0x0b, 0x0a, 0x3b, 0x0d, 0x0d, 0xd7, 0x56, 0x1b, 0xb8, 0x5a, 0xab, 0x6a, 0xfb, 0xe5, 0xad, 0xbd,
-	0x9a, 0xb6, 0x5d, 0x6e, 0x3e, 0xca, 0x2a, 0x95, 0xaf, 0x94, 0x57, 0x67, 0x39, 0xe5, 0xf5, 0x59,
-	0x4e, 0xf9, 0xf1, 0x2c, 0xa7, 0xbc, 0x7c, 0x9b, 0x0b, 0xbd, 0x7e, 0x9b, 0x0b, 0x7d, 0xf7, 0x36,
-	0x17, 0x82, 0x9b, 0x3a, 0x9b, 0x70, 0xa2, 0x2a, 0x19, 0xff, 0x17, 0xc6, 0xae, 0x58, 0xd8, 0x55,
-	0x9e, 0xd6, 0xde, 0xbb, 0x1e, 0xde, 0x5f, 0xad, 0x2e, 0xb5, 0x06, 0x7e, 0xb4, 0xfd, 0x2f, 0x9c,
-	0x6b, 0xf4, 0xa8, 0xd5, 0x3a, 0x07, 0x91, 0xf0, 0xfe, 0x3f, 0x0a, 0xa7, 0xb8, 0xbf, 0x7c, 0x10,
-	0x97, 0x56, 0xf7, 0x7f, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x24, 0xc4, 0x73, 0x45, 0xb2, 0x13, 0x00,
-	0x00,
+	// 1565 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x58, 0x4b, 0x6f, 0x1b, 0xc9,
+	0x11, 0xe6, 0xf0, 0xcd, 0x22, 0x25, 0xd1, 0x1d, 0x45, 0x1e, 0x28, 0x10, 0x4d, 0xd3, 0xb1, 0xa5,
+	0x38, 0x06, 0x19, 0xc9, 0x41, 0x1e, 0x07, 0x03, 0x26, 0x45, 0x4a, 0xa2, 0x2c, 0x89, 0x72, 0x93,
+	0x12, 0x60, 0xc3, 0xf0, 0xa0, 0x45, 0xb6, 0xa8, 0x81, 0x67, 0x7a, 0x98, 0x99, 0x1e, 0x41, 0xca,
+	0x3f, 0x08, 0x90, 0x83, 0x7f, 0x47, 0xe0, 0x5b, 0x4e, 0xb9, 0xe5, 0xe8, 0xa3, 0xf7, 0xb6, 0x58,
+	0x2c, 0x8c, 0x85, 0x7c, 0xd8, 0x05, 0xf6, 0x4f, 0x2c, 0xba, 0x67, 0x46, 0x7c, 0x88, 0x32, 0xe5,
+	0xc7, 0x02, 0xf6, 0x89, 0xdd, 0xd5, 0x55, 0x5f, 0x55, 0x75, 0x7d, 0xd5, 0xdd, 0x1c, 0xb8, 0x67,
+	0xf5, 0x28, 0xe3, 0xd4, 0xa0, 0x26, 0xe5, 0xf6, 0x69, 0xa9, 0x67, 0x5b, 0xdc, 0x2a, 0x89, 0xb1,
+	0xde, 0x76, 0x4a, 0xc7, 0xcb, 0xc1, 0xb0, 0x28, 0x17, 0x50, 0x6e, 0x48, 0xdb, 0x13, 0x16, 0x03,
+	0x95, 0xe3, 0xe5, 0xf9, 0xd9, 0xae, 0xd5, 0xb5, 0x3c, 0x0c, 0x31, 0xf2, 0x14, 0xe6, 0xef, 0x8e,
+	0xf3, 0xd1, 0xb6, 0x4c, 0xd3, 0x62, 0xc2, 0x85, 0x37, 0xf2, 0x75, 0x8b, 0xe3, 0x74, 0x6d, 0xea,
+	0x58, 0xae, 0xdd, 0xa6, 0x42, 0x3b, 0x18, 0x7b, 0xfa, 0x05, 0x1d, 0xd2, 0xdb, 0x9e, 0xff, 0x2a,
+	0xe1, 0x04, 0x3d, 0x85, 0x6c, 0xa0, 0xa0, 0xf9, 0x71, 0xa9, 0x4a, 0x3e, 0xb2, 0x94, 0x5e, 0x29,
+	0x15, 0xdf, 0x1f, 0x7b, 0x11, 0xfb, 0x76, 0x3e, 0x1c, 0x9e, 0xb1, 0x87, 0x05, 0x85, 0xff, 0x85,
+	0x61, 0x66, 0x44, 0x09, 0x75, 0x41, 0xed, 0xd0, 0x9e, 0x4d, 0xdb, 0x84, 0xd3, 0x8e, 0xe6, 0xb4,
+	0xad, 0x5e, 0xdf, 0xef, 0x4f, 0x09, 0xe9, 0xf8, 0xde, 0x24, 0xc7, 0x4d, 0x61, 0x15, 0x78, 0x9d,
+	0xeb, 0xc3, 0x0d, 0xca, 0xd1, 0x23, 0x48, 0x06, 0xf1, 0xa8, 0x4a, 0x5e, 0x59, 0x4a, 0xaf, 0xfc,
+	0x61, 0x2c, 0xee, 0xf9, 0xf6, 0x0c, 0x64, 0x54, 0x89, 0xbe, 0x7e, 0x7b, 0x23, 0x84, 0xcf, 0x01,
+	0xd0, 0x63, 0x98, 0x1a, 0x0e, 0x35, 0xfc, 0x11, 0x91, 0x66, 0x9c, 0xc1, 0xf8, 0x16, 0x00, 0x9c,
+	0xf6, 0x11, 0x35, 0x89, 0xe6, 0xda, 0x86, 0x1a, 0xc9, 0x2b, 0x4b, 0x29, 0x9c, 0xf2, 0x24, 0x7b,
+	0xb6, 0x51, 0xf8, 0xbf, 0x02, 0x99, 0xa1, 0x7c, 0x1a, 0x10, 0x93, 0xf6, 0x7e, 0x32, 0xf7, 0xc7,
+	0xba, 0xf6, 0x99, 0x71, 0xbc, 0x5c, 0xac, 0x33, 0x87, 0xdb, 0xae, 0x49, 0x19, 0x27, 0x5c, 0xb7,
+	0x98, 0x84, 0xf2, 0xd3, 0xf2, 0x70, 0xd0, 0x43, 0x48, 0x0c, 0x67, 0x73, 0x67, 0x52, 0x36, 0x5e,
+	0x28, 0x38, 0x61, 0x5e, 0x2d, 0x85, 0x57, 0x51, 0x88, 0x7b, 0x26, 0x08, 0x41, 0x94, 0x11, 0xd3,
+	0x8b, 0x3d, 0x85, 0xe5, 0x18, 0xe5, 0x21, 0xdd, 0xa1, 0x4e, 0xdb, 0xd6, 0x7b, 0x22, 0x40, 0x35,
+	0x2c, 0x97, 0x06, 0x45, 0xc2, 0xca, 0x65, 0x3a, 0xf7, 0x91, 0xe5, 0x18, 0x3d, 0x80, 0x58, 0x97,
+	0xb8, 0x5d, 0xaa, 0xc6, 0xe4, 0x36, 0xdc, 0x9e, 0x14, 0xf3, 0xba, 0x50, 0xde, 0x08, 0x61, 0xcf,
+	0x0a, 0xfd, 0x15, 0x22, 0x8e, 0x6b, 0xaa, 0x09, 0x69, 0x7c, 0x6b, 0x62, 0xf9, 0x5c, 0x73, 0x23,
+	0x84, 0x85, 0x05, 0xaa, 0x43, 0xea, 0x48, 0x77, 0xb8, 0xd5, 0xb5, 0x89, 0xa9, 0xa6, 0xde, 0xc3,
+	0xa7, 0x01, 0xf3, 0x8d, 0xc0, 0x60, 0x23, 0x84, 0xfb, 0xd6, 0xe8, 0x05, 0xfc, 0x96, 0x9e, 0xf4,
+	0x2c, 0x46, 0x19, 0xd7, 0x89, 0xa1, 0xf5, 0x61, 0x41, 0xc2, 0xfe, 0x79, 0x12, 0x6c, 0xad, 0x6f,
+	0x3c, 0xe8, 0x61, 0x96, 0x8e, 0x91, 0xa3, 0x55, 0x48, 0x38, 0xae, 0x69, 0x12, 0xfb, 0x54, 0x4d,
+	0x4b, 0xf8, 0xc5, 0x2b, 0x24, 0x2d, 0xd4, 0x37, 0x42, 0x38, 0xb0, 0x44, 0x75, 0x48, 0x9a, 0x94,
+	0x93, 0x0e, 0xe1, 0x44, 0xcd, 0xe4, 0x23, 0x97, 0xa2, 0xf4, 0xe9, 0xf7, 0x88, 0x9e, 0xee, 0x13,
+	0xc3, 0x3d, 0xef, 0xa4, 0xc0, 0xbc, 0x12, 0x87, 0xa8, 0xf8, 0xdd, 0x8c, 0x26, 0xa3, 0xd9, 0xd8,
+	0x66, 0x34, 0x19, 0xcf, 0x26, 0x36, 0xa3, 0xc9, 0x64, 0x36, 0x55, 0x78, 0x02, 0x31, 0x59, 0x2c,
+	0xb4, 0x0b, 0x69, 0xa1, 0xa2, 0xf5, 0x2c, 0x9d, 0xf1, 0x2b, 0x9f, 0x46, 0x3b, 0xae, 0x79, 0x40,
+	0x6d, 0x71, 0xa6, 0xed, 0x0a, 0x3b, 0x0c, 0x9d, 0x60, 0xe8, 0x14, 0x7e, 0x56, 0x20, 0xd2, 0x74,
+	0xcd, 0xcf, 0x8f, 0x8c, 0x2c, 0xb8, 0x4e, 0xba, 0x5d, 0x9b, 0x76, 0x65, 0x97, 0x69, 0x9c, 0x9a,
+	0x3d, 0xcb, 0x26, 0x86, 0xce, 0x4f, 0x25, 0xa1, 0xa7, 0x57, 0xfe, 0x32, 0x09, 0xbd, 0xdc, 0x37,
+	0x6f, 0xf5, 0xad, 0xf1, 0x1c, 0x19, 0x2b, 0x47, 0x37, 0x21, 0xa3, 0x3b, 0x9a, 0x69, 0x31, 0x8b,
+	0x5b, 0x4c, 0x6f, 0xcb, 0xde, 0x48, 0xe2, 0xb4, 0xee, 0x6c, 0x07, 0xa2, 0xc2, 0x37, 0x0a, 0xa4,
+	0xfa, 0x04, 0x68, 0x8e, 0xcb, 0x79, 0xe5, 0xca, 0xd4, 0xfd, 0x32, 0xd2, 0x2e, 0xfc, 0xa8, 0xc0,
+	0xec, 0x38, 0xde, 0xa3, 0xe7, 0xe3, 0xd2, 0x7b, 0xf0, 0x31, 0x2d, 0xf4, 0x85, 0x64, 0xfa, 0x0c,
+	0x12, 0x7e, 0x07, 0xa2, 0xc7, 0xe3, 0x72, 0xfb, 0xd3, 0x15, 0xfb, 0x77, 0x7c, 0x27, 0x9c, 0x85,
+	0x61, 0x66, 0x84, 0xcf, 0x68, 0x1b, 0x80, 0x70, 0x6e, 0xeb, 0x07, 0x2e, 0xa7, 0x8e, 0x9a, 0xf8,
+	0x98, 0xfe, 0x1e, 0x00, 0x40, 0x25, 0x98, 0x75, 0x38, 0xb1, 0xb9, 0xc6, 0x75, 0x93, 0x6a, 0x2e,
+	0xd3, 0x4f, 0x34, 0x46, 0x98, 0x25, 0xb7, 0x2b, 0x8e, 0xaf, 0xc9, 0xb5, 0x96, 0x6e, 0xd2, 0x3d,
+	0xa6, 0x9f, 0xec, 0x10, 0x66, 0xa1, 0xdf, 0xc3, 0xf4, 0x88, 0x6a, 0x44, 0xaa, 0x66, 0xf8, 0xa0,
+	0xd6, 0x02, 0xa4, 0x88, 0xa3, 0x75, 0x2c, 0xf7, 0xc0, 0xa0, 0x6a, 0x34, 0xaf, 0x2c, 0x29, 0x1b,
+	0x21, 0x9c, 0x24, 0x4e, 0x55, 0x4a, 0xd0, 0x75, 0x88, 0x13, 0x47, 0xd3, 0x19, 0x57, 0xe3, 0x79,
+	0x65, 0x29, 0x2b, 0x4e, 0x7c, 0xe2, 0xd4, 0x19, 0x47, 0x5b, 0x90, 0xa2, 0x27, 0xd4, 0xec, 0x19,
+	0xc4, 0x76, 0xd4, 0x98, 0x4c, 0x6e, 0x69, 0x32, 0x3d, 0x3c, 0x03, 0x3f, 0xbb, 0x3e, 0x00, 0x9a,
+	0x85, 0xd8, 0xa1, 0x41, 0xba, 0x8e, 0x9a, 0xcc, 0x2b, 0x4b, 0x53, 0xd8, 0x9b, 0x54, 0x12, 0x10,
+	0x3b, 0x16, 0xbb, 0xb1, 0x19, 0x4d, 0x2a, 0xd9, 0x70, 0xe1, 0xfb, 0x08, 0xa0, 0x8b, 0xb4, 0x1a,
+	0xd9, 0xe7, 0xd4, 0x17, 0xba, 0xcf, 0xb3, 0x10, 0x6b, 0x5b, 0x2e, 0xe3, 0x72, 0x8f, 0xe3, 0xd8,
+	0x9b, 0x20, 0xe4, 0xdd, 0x9b, 0x31, 0x7f, 0xdf, 0xc5, 0x04, 0xdd, 0x82, 0xa9, 0x03, 0xb7, 0xfd,
+	0x82, 0x72, 0x4d, 0xea, 0x38, 0x6a, 0x3c, 0x1f, 0x11, 0x70, 0x9e, 0x70, 0x55, 0xca, 0xd0, 0x22,
+	0xcc, 0xd0, 0x93, 0x9e, 0xa1, 0xb7, 0x75, 0xae, 0x1d, 0x58, 0x2e, 0xeb, 0x78, 0x0c, 0x53, 0xf0,
+	0x74, 0x20, 0xae, 0x48, 0xe9, 0x70, 0x9d, 0x92, 0x9f, 0xad, 0x4e, 0x30, 0x50, 0x27, 0x91, 0x85,
+	0xa9, 0x33, 0x79, 0x11, 0x2a, 0x1b, 0x0a, 0x16, 0x13, 0x29, 0x23, 0x27, 0x6a, 0x46, 0xca, 0xc2,
+	0x58, 0x4c, 0xc4, 0x25, 0xe5, 0xb8, 0xa6, 0x26, 0x7e, 0x4d, 0x9d, 0x79, 0xbf, 0xe4, 0x44, 0xf3,
+	0xcb, 0xfb, 0xaf, 0x38, 0x2c, 0xbc, 0xf7, 0x00, 0x19, 0xa9, 0xb4, 0xf2, 0xd5, 0x57, 0x7a, 0x56,
+	0xbc, 0x3d, 0x89, 0x41, 0x65, 0x6f, 0x5d, 0xc3, 0xde, 0x44, 0x3c, 0xff, 0xfe, 0x49, 0x6d, 0xcb,
+	0xab, 0xbe, 0x7c, 0x52, 0xc5, 0x71, 0x4a, 0x48, 0x64, 0xe9, 0x51, 0x17, 0x92, 0x3d, 0xcb, 0xd1,
+	0xb9, 0x7e, 0x4c, 0x65, 0xb7, 0xa4, 0x57, 0x6a, 0x9f, 0x74, 0x2c, 0x17, 0x2b, 0x92, 0x57, 0x4e,
+	0xf0, 0xa4, 0x08, 0xc0, 0x85, 0x23, 0x26, 0x0f, 0xd2, 0x63, 0xaa, 0xa6, 0x7e, 0x05, 0x47, 0x01,
+	0xf8, 0x25, 0xa4, 0x1a, 0x22, 0x6e, 0xfa, 0x53, 0x89, 0xeb, 0x53, 0x34, 0x33, 0x86, 0xa2, 0x53,
+	0x03, 0x14, 0x45, 0xb7, 0x61, 0x5a, 0x6e, 0x3e, 0x3f, 0xb2, 0xa9, 0x73, 0x64, 0x19, 0x1d, 0x75,
+	0x5a, 0x2c, 0xe3, 0x29, 0x21, 0x6d, 0x05, 0xc2, 0xf9, 0x35, 0x48, 0xf8, 0xd9, 0xa0, 0x39, 0x88,
+	0x5b, 0x87, 0x87, 0x0e, 0xe5, 0xf2, 0x15, 0x7e, 0x0d, 0xfb, 0xb3, 0x8b, 0x6d, 0x2c, 0xfe, 0x0d,
+	0x44, 0x87, 0xdb, 0xf8, 0xb2, 0x8e, 0x28, 0xbc, 0x8a, 0x40, 0x76, 0xf4, 0xc2, 0xf9, 0x4a, 0x2e,
+	0x94, 0xf1, 0xf4, 0xcf, 0x0e, 0xd0, 0xdf, 0x23, 0xbf, 0x0e, 0x33, 0xff, 0x70, 0x09, 0xe3, 0xba,
+	0x41, 0x35, 0x79, 0xca, 0x7b, 0x07, 0x5d, 0x7a, 0xe5, 0xe1, 0x87, 0xde, 0xc4, 0x45, 0x99, 0x61,
+	0x99, 0x3f, 0xf6, 0xe1, 0xf0, 0x74, 0x00, 0x2c, 0x17, 0x2e, 0xb9, 0x5d, 0xe6, 0x57, 0x61, 0x66,
+	0xc4, 0x10, 0xcd, 0x43, 0x32, 0x30, 0x95, 0xd5, 0x54, 0xf0, 0xf9, 0x5c, 0x80, 0xc8, 0x30, 0xe5,
+	0xfe, 0x28, 0x78, 0xe8, 0x66, 0x7a, 0x19, 0x81, 0x64, 0xc0, 0x3d, 0xf4, 0x1c, 0x7e, 0x73, 0xa8,
+	0x1b, 0x9c, 0xda, 0xb4, 0xa3, 0x7d, 0x6a, 0xbd, 0x50, 0x80, 0x54, 0xee, 0xd7, 0xed, 0x62, 0x19,
+	0xc2, 0x93, 0xee, 0xf5, 0xc8, 0xd5, 0xef, 0xf5, 0x27, 0x90, 0x70, 0x7a, 0x84, 0x69, 0x7a, 0x47,
+	0x16, 0x30, 0x53, 0x79, 0x28, 0x02, 0xf9, 0xee, 0xed, 0x8d, 0xbf, 0x75, 0xad, 0x91, 0xd8, 0x75,
+	0xf1, 0xf1, 0xc4, 0x30, 0x68, 0x9b, 0x5b, 0x76, 0xa9, 0x27, 0x5e, 0x43, 0x25, 0x9d, 0x71, 0x6a,
+	0x33, 0x62, 0x94, 0xc4, 0xac, 0xd8, 0xec, 0x11, 0x56, 0xaf, 0xe2, 0xb8, 0x00, 0xac, 0x77, 0xd0,
+	0x33, 0x48, 0x72, 0x9b, 0xb4, 0xa9, 0xc0, 0x8e, 0x49, 0xec, 0xb2, 0x8f, 0xfd, 0xf7, 0x0f, 0xc7,
+	0x6e, 0x09, 0xa4, 0x7a, 0x15, 0x27, 0x24, 0x64, 0xbd, 0x33, 0xf2, 0x58, 0xb8, 0xfb, 0x6f, 0x05,
+	0xe6, 0xc6, 0x3f, 0x11, 0xd1, 0x22, 0xdc, 0x2a, 0xaf, 0xaf, 0xe3, 0xda, 0x7a, 0xb9, 0x55, 0x6f,
+	0xec, 0x68, 0xad, 0xda, 0xf6, 0x6e, 0x03, 0x97, 0xb7, 0xea, 0xad, 0x27, 0xda, 0xde, 0x4e, 0x73,
+	0xb7, 0xb6, 0x5a, 0x5f, 0xab, 0xd7, 0xaa, 0xd9, 0x10, 0xba, 0x09, 0x0b, 0x97, 0x29, 0x56, 0x6b,
+	0x5b, 0xad, 0x72, 0x56, 0x41, 0x77, 0xa0, 0x70, 0x99, 0xca, 0xea, 0xde, 0xf6, 0xde, 0x56, 0xb9,
+	0x55, 0xdf, 0xaf, 0x65, 0xc3, 0x77, 0x9f, 0xc3, 0xf4, 0x39, 0x5f, 0xd7, 0xe4, 0xf9, 0x76, 0x03,
+	0x7e, 0x57, 0x2d, 0xb7, 0xca, 0xda, 0x6e, 0xa3, 0xbe, 0xd3, 0xd2, 0xd6, 0xb6, 0xca, 0xeb, 0x4d,
+	0xad, 0xda, 0xd0, 0x76, 0x1a, 0x2d, 0x6d, 0xaf, 0x59, 0xcb, 0x86, 0xd0, 0x1f, 0x61, 0xf1, 0x82,
+	0xc2, 0x4e, 0x43, 0xc3, 0xb5, 0xd5, 0x06, 0xae, 0xd6, 0xaa, 0xda, 0x7e, 0x79, 0x6b, 0xaf, 0xa6,
+	0x6d, 0x97, 0x9b, 0x8f, 0xb2, 0x4a, 0xe5, 0xbf, 0xca, 0xeb, 0xb3, 0x9c, 0xf2, 0xe6, 0x2c, 0xa7,
+	0xfc, 0x70, 0x96, 0x53, 0x5e, 0xbe, 0xcb, 0x85, 0xde, 0xbc, 0xcb, 0x85, 0xbe, 0x7d, 0x97, 0x0b,
+	0xc1, 0x4d, 0xdd, 0x9a, 0xd0, 0x51, 0x95, 0x8c, 0xff, 0x35, 0x64, 0x57, 0x2c, 0xec, 0x2a, 0x4f,
+	0x6b, 0x1f, 0x5c, 0x0f, 0xef, 0x03, 0x59, 0x97, 0xb2, 0x81, 0x6f, 0x76, 0xff, 0x09, 0xe7, 0x1a,
+	0x3d, 0xca, 0x5a, 0xe7, 0x20, 0x12, 0xde, 0xff, 0xdc, 0xe1, 0x14, 0xf7, 0x97, 0x0f, 0xe2, 0xd2,
+	0xea, 0xfe, 0x2f, 0x03, 0x00, 0x00, 0xa3, 0x78, 0x2c, 0xfd, 0x13, 0x00, 0x00,
 }
 
 func (m *MetricsData) Marshal() (dAtA []byte, err error) {
@@ -2247,6 +2260,20 @@ func (m *Metric) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Metadata) > 0 {
+		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintMetrics(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x62
+		}
+	}
 	if m.Data != nil {
 		{
 			size := m.Data.Size()
@@ -3388,6 +3415,12 @@ func (m *Metric) Size() (n int) {
 	if m.Data != nil {
 		n += m.Data.Size()
 	}
+	if len(m.Metadata) > 0 {
+		for _, e := range m.Metadata {
+			l = e.Size()
+			n += 1 + l + sovMetrics(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -4582,6 +4615,40 @@ func (m *Metric) Unmarshal(dAtA []byte) error {
 			}
 			m.Data = &Metric_Summary{v}
 			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetrics
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata, v11.KeyValue{})
+			if err := m.Metadata[len(m.Metadata)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetrics(dAtA[iNdEx:])
@@ -29,6 +29,57 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// SpanFlags represents constants used to interpret the
+// Span.flags field, which is protobuf 'fixed32' type and is to
+// be used as bit-fields. Each non-zero value defined in this enum is
+// a bit-mask.  To extract the bit-field, for example, use an
+// expression like:
+//
+//	(span.flags & SPAN_FLAGS_TRACE_FLAGS_MASK)
+//
+// See https://www.w3.org/TR/trace-context-2/#trace-flags for the flag definitions.
+//
+// Note that Span flags were introduced in version 1.1 of the
+// OpenTelemetry protocol.  Older Span producers do not set this
+// field, consequently consumers should not rely on the absence of a
+// particular flag bit to indicate the presence of a particular feature.
+type SpanFlags int32
+
+const (
+	// The zero value for the enum. Should not be used for comparisons.
+	// Instead use bitwise "and" with the appropriate mask as shown above.
+	SpanFlags_SPAN_FLAGS_DO_NOT_USE SpanFlags = 0
+	// Bits 0-7 are used for trace flags.
+	SpanFlags_SPAN_FLAGS_TRACE_FLAGS_MASK SpanFlags = 255
+	// Bits 8 and 9 are used to indicate that the parent span or link span is remote.
+	// Bit 8 (`HAS_IS_REMOTE`) indicates whether the value is known.
+	// Bit 9 (`IS_REMOTE`) indicates whether the span or link is remote.
+	SpanFlags_SPAN_FLAGS_CONTEXT_HAS_IS_REMOTE_MASK SpanFlags = 256
+	SpanFlags_SPAN_FLAGS_CONTEXT_IS_REMOTE_MASK     SpanFlags = 512
+)
+
+var SpanFlags_name = map[int32]string{
+	0:   "SPAN_FLAGS_DO_NOT_USE",
+	255: "SPAN_FLAGS_TRACE_FLAGS_MASK",
+	256: "SPAN_FLAGS_CONTEXT_HAS_IS_REMOTE_MASK",
+	512: "SPAN_FLAGS_CONTEXT_IS_REMOTE_MASK",
+}
+
+var SpanFlags_value = map[string]int32{
+	"SPAN_FLAGS_DO_NOT_USE":                 0,
+	"SPAN_FLAGS_TRACE_FLAGS_MASK":           255,
+	"SPAN_FLAGS_CONTEXT_HAS_IS_REMOTE_MASK": 256,
+	"SPAN_FLAGS_CONTEXT_IS_REMOTE_MASK":     512,
+}
+
+func (x SpanFlags) String() string {
+	return proto.EnumName(SpanFlags_name, int32(x))
+}
+
+func (SpanFlags) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5c407ac9c675a601, []int{0}
+}
+
 // SpanKind is the type of span. Can be used to specify additional relationships between spans
 // in addition to a parent/child relationship.
 type Span_SpanKind int32
@@ -183,6 +234,9 @@ type ResourceSpans struct {
 	Resource v1.Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource"`
 	// A list of ScopeSpans that originate from a resource.
 	ScopeSpans []*ScopeSpans `protobuf:"bytes,2,rep,name=scope_spans,json=scopeSpans,proto3" json:"scope_spans,omitempty"`
+	// The Schema URL, if known. This is the identifier of the Schema that the resource data
+	// is recorded in. To learn more about Schema URL see
+	// https://opentelemetry.io/docs/specs/otel/schemas/#schema-url
 	// This schema_url applies to the data in the "resource" field. It does not apply
 	// to the data in the "scope_spans" field which have their own schema_url field.
 	SchemaUrl string `protobuf:"bytes,3,opt,name=schema_url,json=schemaUrl,proto3" json:"schema_url,omitempty"`
@@ -257,6 +311,9 @@ type ScopeSpans struct {
 	Scope v11.InstrumentationScope `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope"`
 	// A list of Spans that originate from an instrumentation scope.
 	Spans []*Span `protobuf:"bytes,2,rep,name=spans,proto3" json:"spans,omitempty"`
+	// The Schema URL, if known. This is the identifier of the Schema that the span data
+	// is recorded in. To learn more about Schema URL see
+	// https://opentelemetry.io/docs/specs/otel/schemas/#schema-url
 	// This schema_url applies to all spans and span events in the "spans" field.
 	SchemaUrl string `protobuf:"bytes,3,opt,name=schema_url,json=schemaUrl,proto3" json:"schema_url,omitempty"`
 }
@@ -340,6 +397,28 @@ type Span struct {
 	// The `span_id` of this span's parent span. If this is a root span, then this
 	// field must be empty. The ID is an 8-byte array.
 	ParentSpanId go_opentelemetry_io_collector_pdata_internal_data.SpanID `protobuf:"bytes,4,opt,name=parent_span_id,json=parentSpanId,proto3,customtype=go.opentelemetry.io/collector/pdata/internal/data.SpanID" json:"parent_span_id"`
+	// Flags, a bit field.
+	//
+	// Bits 0-7 (8 least significant bits) are the trace flags as defined in W3C Trace
+	// Context specification. To read the 8-bit W3C trace flag, use
+	// `flags & SPAN_FLAGS_TRACE_FLAGS_MASK`.
+	//
+	// See https://www.w3.org/TR/trace-context-2/#trace-flags for the flag definitions.
+	//
+	// Bits 8 and 9 represent the 3 states of whether a span's parent
+	// is remote. The states are (unknown, is not remote, is remote).
+	// To read whether the value is known, use `(flags & SPAN_FLAGS_CONTEXT_HAS_IS_REMOTE_MASK) != 0`.
+	// To read whether the span is remote, use `(flags & SPAN_FLAGS_CONTEXT_IS_REMOTE_MASK) != 0`.
+	//
+	// When creating span messages, if the message is logically forwarded from another source
+	// with an equivalent flags fields (i.e., usually another OTLP span message), the field SHOULD
+	// be copied as-is. If creating from a source that does not have an equivalent flags field
+	// (such as a runtime representation of an OpenTelemetry span), the high 22 bits MUST
+	// be set to zero.
+	// Readers MUST NOT assume that bits 10-31 (22 most significant bits) will be zero.
+	//
+	// [Optional].
+	Flags uint32 `protobuf:"fixed32,16,opt,name=flags,proto3" json:"flags,omitempty"`
 	// A description of the span's operation.
 	//
 	// For example, the name can be a qualified method name or a file name
@@ -443,6 +522,13 @@ func (m *Span) GetTraceState() string {
 	return ""
 }
 
+func (m *Span) GetFlags() uint32 {
+	if m != nil {
+		return m.Flags
+	}
+	return 0
+}
+
 func (m *Span) GetName() string {
 	if m != nil {
 		return m.Name
@@ -617,6 +703,24 @@ type Span_Link struct {
 	// dropped_attributes_count is the number of dropped attributes. If the value is 0,
 	// then no attributes were dropped.
 	DroppedAttributesCount uint32 `protobuf:"varint,5,opt,name=dropped_attributes_count,json=droppedAttributesCount,proto3" json:"dropped_attributes_count,omitempty"`
+	// Flags, a bit field.
+	//
+	// Bits 0-7 (8 least significant bits) are the trace flags as defined in W3C Trace
+	// Context specification. To read the 8-bit W3C trace flag, use
+	// `flags & SPAN_FLAGS_TRACE_FLAGS_MASK`.
+	//
+	// See https://www.w3.org/TR/trace-context-2/#trace-flags for the flag definitions.
+	//
+	// Bits 8 and 9 represent the 3 states of whether the link is remote.
+	// The states are (unknown, is not remote, is remote).
+	// To read whether the value is known, use `(flags & SPAN_FLAGS_CONTEXT_HAS_IS_REMOTE_MASK) != 0`.
+	// To read whether the link is remote, use `(flags & SPAN_FLAGS_CONTEXT_IS_REMOTE_MASK) != 0`.
+	//
+	// Readers MUST NOT assume that bits 10-31 (22 most significant bits) will be zero.
+	// When creating new spans, bits 10-31 (most-significant 22-bits) MUST be zero.
+	//
+	// [Optional].
+	Flags uint32 `protobuf:"fixed32,6,opt,name=flags,proto3" json:"flags,omitempty"`
 }
 
 func (m *Span_Link) Reset()         { *m = Span_Link{} }
@@ -673,6 +777,13 @@ func (m *Span_Link) GetDroppedAttributesCount() uint32 {
 	return 0
 }
 
+func (m *Span_Link) GetFlags() uint32 {
+	if m != nil {
+		return m.Flags
+	}
+	return 0
+}
+
 // The Status type defines a logical error model that is suitable for different
 // programming environments, including REST APIs and RPC APIs.
 type Status struct {
@@ -730,6 +841,7 @@ func (m *Status) GetCode() Status_StatusCode {
 }
 
 func init() {
+	proto.RegisterEnum("opentelemetry.proto.trace.v1.SpanFlags", SpanFlags_name, SpanFlags_value)
 	proto.RegisterEnum("opentelemetry.proto.trace.v1.Span_SpanKind", Span_SpanKind_name, Span_SpanKind_value)
 	proto.RegisterEnum("opentelemetry.proto.trace.v1.Status_StatusCode", Status_StatusCode_name, Status_StatusCode_value)
 	proto.RegisterType((*TracesData)(nil), "opentelemetry.proto.trace.v1.TracesData")
@@ -746,70 +858,77 @@ func init() {
 }
 
 var fileDescriptor_5c407ac9c675a601 = []byte{
-	// 1007 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x56, 0x4f, 0x6f, 0xe3, 0x44,
-	0x14, 0xcf, 0xa4, 0x4e, 0xda, 0xbe, 0xb6, 0x59, 0x77, 0xe8, 0x56, 0xa6, 0x5a, 0xd2, 0x28, 0x5a,
-	0x89, 0xc0, 0x4a, 0x09, 0xed, 0x5e, 0xca, 0x01, 0xb1, 0x6d, 0x62, 0x24, 0xd3, 0x6e, 0x52, 0x8d,
-	0x93, 0x4a, 0x20, 0x24, 0x33, 0x1b, 0x0f, 0xc5, 0x6a, 0x32, 0xb6, 0xec, 0x49, 0xb5, 0x7b, 0xe3,
-	0x23, 0x70, 0xe5, 0x13, 0x20, 0xc1, 0x9d, 0x1b, 0xf7, 0x15, 0xa7, 0x3d, 0x22, 0x0e, 0x2b, 0xd4,
-	0x5e, 0xe0, 0x5b, 0xa0, 0x99, 0xb1, 0xf3, 0xa7, 0xaa, 0x92, 0xad, 0xc4, 0x5e, 0xf6, 0xd2, 0x4e,
-	0xde, 0x7b, 0xbf, 0x3f, 0xef, 0xcd, 0xb3, 0x65, 0xa8, 0x85, 0x11, 0xe3, 0x82, 0x0d, 0xd8, 0x90,
-	0x89, 0xf8, 0x45, 0x23, 0x8a, 0x43, 0x11, 0x36, 0x44, 0x4c, 0xfb, 0xac, 0x71, 0xb9, 0xa7, 0x0f,
-	0x75, 0x15, 0xc4, 0x0f, 0x66, 0x2a, 0x75, 0xb0, 0xae, 0x0b, 0x2e, 0xf7, 0x76, 0xb6, 0xce, 0xc3,
-	0xf3, 0x50, 0xa3, 0xe5, 0x49, 0xa7, 0x77, 0x3e, 0xbe, 0x8d, 0xbd, 0x1f, 0x0e, 0x87, 0x21, 0x97,
-	0xf4, 0xfa, 0x94, 0xd6, 0xd6, 0x6f, 0xab, 0x8d, 0x59, 0x12, 0x8e, 0x62, 0x6d, 0x26, 0x3b, 0xeb,
-	0xfa, 0xea, 0xb7, 0x00, 0x5d, 0xa9, 0x9e, 0xb4, 0xa8, 0xa0, 0x98, 0x40, 0x29, 0xcb, 0x7b, 0x49,
-	0x44, 0x79, 0x62, 0xa1, 0xca, 0x52, 0x6d, 0x6d, 0xff, 0x51, 0x7d, 0x9e, 0xed, 0x3a, 0x49, 0x31,
-	0xae, 0x84, 0x90, 0x8d, 0x78, 0xfa, 0x67, 0xf5, 0xe7, 0x3c, 0x6c, 0xcc, 0x14, 0x60, 0x0f, 0xb6,
-	0x7d, 0x16, 0xc5, 0xac, 0x4f, 0x05, 0xf3, 0xbd, 0xa4, 0x1f, 0x46, 0x99, 0xda, 0x3f, 0xcb, 0x4a,
-	0xae, 0x36, 0x5f, 0xce, 0x95, 0x08, 0xad, 0xb5, 0x35, 0x21, 0x9a, 0x44, 0xf1, 0x31, 0xac, 0x64,
-	0x1e, 0x2c, 0x54, 0x41, 0xb5, 0xb5, 0xfd, 0x8f, 0x6e, 0x65, 0x1c, 0xcf, 0x62, 0xaa, 0x87, 0x23,
-	0xe3, 0xe5, 0xeb, 0xdd, 0x1c, 0x19, 0x13, 0x60, 0x07, 0xd6, 0xa6, 0x2d, 0xe6, 0xef, 0xe8, 0x10,
-	0x92, 0x89, 0xaf, 0x0f, 0x00, 0x92, 0xfe, 0xf7, 0x6c, 0x48, 0xbd, 0x51, 0x3c, 0xb0, 0x96, 0x2a,
-	0xa8, 0xb6, 0x4a, 0x56, 0x75, 0xa4, 0x17, 0x0f, 0xaa, 0xbf, 0x21, 0x80, 0xa9, 0x2e, 0x3a, 0x50,
-	0x50, 0xd8, 0xb4, 0x85, 0xc7, 0xb7, 0x4a, 0xa6, 0x97, 0x7f, 0xb9, 0x57, 0x77, 0x78, 0x22, 0xe2,
-	0xd1, 0x90, 0x71, 0x41, 0x45, 0x10, 0x72, 0x45, 0x94, 0x36, 0xa3, 0x79, 0xf0, 0x01, 0x14, 0xa6,
-	0x7b, 0xa8, 0x2e, 0xe8, 0x21, 0xa2, 0x9c, 0x68, 0xc0, 0x22, 0xe3, 0x3f, 0x6c, 0x80, 0x21, 0xcb,
-	0xf1, 0x37, 0xb0, 0xa2, 0xf0, 0x5e, 0xe0, 0x2b, 0xd7, 0xeb, 0x47, 0x87, 0xd2, 0xc0, 0x5f, 0xaf,
-	0x77, 0x3f, 0x3d, 0x0f, 0x6f, 0xc8, 0x05, 0x72, 0x87, 0x07, 0x03, 0xd6, 0x17, 0x61, 0xdc, 0x88,
-	0x7c, 0x2a, 0x68, 0x23, 0xe0, 0x82, 0xc5, 0x9c, 0x0e, 0x1a, 0xf2, 0x57, 0x5d, 0xed, 0xa5, 0xd3,
-	0x22, 0xcb, 0x8a, 0xd2, 0xf1, 0xf1, 0x57, 0xb0, 0x2c, 0xed, 0x48, 0xf2, 0xbc, 0x22, 0x7f, 0x92,
-	0x92, 0x1f, 0xdc, 0x9d, 0x5c, 0xda, 0x75, 0x5a, 0xa4, 0x28, 0x09, 0x1d, 0x1f, 0xef, 0xc2, 0x9a,
-	0x36, 0x9e, 0x08, 0x2a, 0x58, 0xda, 0x21, 0xa8, 0x90, 0x2b, 0x23, 0xf8, 0x3b, 0x28, 0x45, 0x34,
-	0x66, 0x5c, 0x78, 0x99, 0x05, 0xe3, 0x7f, 0xb2, 0xb0, 0xae, 0x79, 0x5d, 0x6d, 0x04, 0x83, 0xc1,
-	0xe9, 0x90, 0x59, 0x05, 0xe5, 0x40, 0x9d, 0xf1, 0xe7, 0x60, 0x5c, 0x04, 0xdc, 0xb7, 0x8a, 0x15,
-	0x54, 0x2b, 0x2d, 0x7a, 0x16, 0x25, 0x8f, 0xfa, 0x73, 0x1c, 0x70, 0x9f, 0x28, 0x20, 0x6e, 0xc0,
-	0x56, 0x22, 0x68, 0x2c, 0x3c, 0x11, 0x0c, 0x99, 0x37, 0xe2, 0xc1, 0x73, 0x8f, 0x53, 0x1e, 0x5a,
-	0xcb, 0x15, 0x54, 0x2b, 0x92, 0x4d, 0x95, 0xeb, 0x06, 0x43, 0xd6, 0xe3, 0xc1, 0xf3, 0x36, 0xe5,
-	0x21, 0x7e, 0x04, 0x98, 0x71, 0xff, 0x66, 0xf9, 0x8a, 0x2a, 0xbf, 0xc7, 0xb8, 0x3f, 0x53, 0xfc,
-	0x14, 0x80, 0x0a, 0x11, 0x07, 0xcf, 0x46, 0x82, 0x25, 0xd6, 0xaa, 0xda, 0xad, 0x0f, 0x17, 0x2c,
-	0xeb, 0x31, 0x7b, 0x71, 0x46, 0x07, 0xa3, 0x6c, 0x41, 0xa7, 0x08, 0xf0, 0x01, 0x58, 0x7e, 0x1c,
-	0x46, 0x11, 0xf3, 0xbd, 0x49, 0xd4, 0xeb, 0x87, 0x23, 0x2e, 0x2c, 0xa8, 0xa0, 0xda, 0x06, 0xd9,
-	0x4e, 0xf3, 0x87, 0xe3, 0x74, 0x53, 0x66, 0xf1, 0x13, 0x28, 0xb2, 0x4b, 0xc6, 0x45, 0x62, 0xad,
-	0xbd, 0xd1, 0x43, 0x2a, 0x27, 0x65, 0x4b, 0x00, 0x49, 0x71, 0xf8, 0x13, 0xd8, 0xca, 0xb4, 0x75,
-	0x24, 0xd5, 0x5d, 0x57, 0xba, 0x38, 0xcd, 0x29, 0x4c, 0xaa, 0xf9, 0x19, 0x14, 0x06, 0x01, 0xbf,
-	0x48, 0xac, 0x8d, 0x39, 0x7d, 0xcf, 0x4a, 0x9e, 0x04, 0xfc, 0x82, 0x68, 0x14, 0xae, 0xc3, 0x7b,
-	0x99, 0xa0, 0x0a, 0xa4, 0x7a, 0x25, 0xa5, 0xb7, 0x99, 0xa6, 0x24, 0x20, 0x95, 0x3b, 0x82, 0xa2,
-	0xdc, 0xd0, 0x51, 0x62, 0xdd, 0x53, 0x2f, 0x85, 0x87, 0x0b, 0xf4, 0x54, 0x6d, 0x3a, 0xe4, 0x14,
-	0xb9, 0xf3, 0x07, 0x82, 0x82, 0x6a, 0x01, 0x3f, 0x84, 0xd2, 0x8d, 0x2b, 0x46, 0xea, 0x8a, 0xd7,
-	0xc5, 0xf4, 0xfd, 0x66, 0x2b, 0x99, 0x9f, 0x5a, 0xc9, 0xd9, 0x3b, 0x5f, 0x7a, 0x9b, 0x77, 0x6e,
-	0xcc, 0xbb, 0xf3, 0x9d, 0x7f, 0xf3, 0x60, 0xc8, 0xf9, 0xbc, 0xc3, 0xaf, 0x9e, 0xd9, 0x59, 0x1b,
-	0x6f, 0x73, 0xd6, 0x85, 0x79, 0xb3, 0xae, 0xfe, 0x84, 0x60, 0x25, 0x7b, 0xb3, 0xe0, 0xf7, 0xe1,
-	0xbe, 0x7b, 0x7a, 0xd8, 0xf6, 0x8e, 0x9d, 0x76, 0xcb, 0xeb, 0xb5, 0xdd, 0x53, 0xbb, 0xe9, 0x7c,
-	0xe1, 0xd8, 0x2d, 0x33, 0x87, 0xb7, 0x01, 0x4f, 0x52, 0x4e, 0xbb, 0x6b, 0x93, 0xf6, 0xe1, 0x89,
-	0x89, 0xf0, 0x16, 0x98, 0x93, 0xb8, 0x6b, 0x93, 0x33, 0x9b, 0x98, 0xf9, 0xd9, 0x68, 0xf3, 0xc4,
-	0xb1, 0xdb, 0x5d, 0x73, 0x69, 0x96, 0xe3, 0x94, 0x74, 0x5a, 0xbd, 0xa6, 0x4d, 0x4c, 0x63, 0x36,
-	0xde, 0xec, 0xb4, 0xdd, 0xde, 0x53, 0x9b, 0x98, 0x85, 0xea, 0xef, 0x08, 0x8a, 0x7a, 0xdb, 0xb1,
-	0x05, 0xcb, 0x43, 0x96, 0x24, 0xf4, 0x3c, 0x5b, 0xd9, 0xec, 0x27, 0x6e, 0x82, 0xd1, 0x0f, 0x7d,
-	0x3d, 0xe3, 0xd2, 0x7e, 0xe3, 0x4d, 0x9e, 0x9d, 0xf4, 0x5f, 0x33, 0xf4, 0x19, 0x51, 0xe0, 0x6a,
-	0x1b, 0x60, 0x12, 0xc3, 0xf7, 0x61, 0xd3, 0xed, 0x1e, 0x76, 0x7b, 0xae, 0xd7, 0xec, 0xb4, 0x6c,
-	0x39, 0x08, 0xbb, 0x6b, 0xe6, 0x30, 0x86, 0xd2, 0x74, 0xb8, 0x73, 0x6c, 0xa2, 0x9b, 0xa5, 0x36,
-	0x21, 0x1d, 0x62, 0xe6, 0xbf, 0x34, 0x56, 0x90, 0x99, 0x3f, 0xfa, 0x15, 0xbd, 0xbc, 0x2a, 0xa3,
-	0x57, 0x57, 0x65, 0xf4, 0xf7, 0x55, 0x19, 0xfd, 0x78, 0x5d, 0xce, 0xbd, 0xba, 0x2e, 0xe7, 0xfe,
-	0xbc, 0x2e, 0xe7, 0x60, 0x37, 0x08, 0xe7, 0x3a, 0x3d, 0xd2, 0x5f, 0x70, 0xa7, 0x32, 0x78, 0x8a,
-	0xbe, 0x6e, 0xde, 0x79, 0x23, 0xf5, 0x57, 0xe2, 0x39, 0xe3, 0xe3, 0x4f, 0xd6, 0x5f, 0xf2, 0x0f,
-	0x3a, 0x11, 0xe3, 0xdd, 0x31, 0x85, 0x22, 0xd7, 0x8f, 0x45, 0xfd, 0x6c, 0xef, 0x59, 0x51, 0x21,
-	0x1e, 0xff, 0x17, 0x00, 0x00, 0xff, 0xff, 0xbb, 0xe9, 0x90, 0xbc, 0xf8, 0x0a, 0x00, 0x00,
+	// 1109 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x56, 0xcf, 0x6f, 0x1b, 0x45,
+	0x14, 0xf6, 0x3a, 0x6b, 0x3b, 0x79, 0x49, 0xdc, 0xed, 0xe0, 0x56, 0x4b, 0x28, 0x8e, 0xb1, 0x0a,
+	0x98, 0x56, 0xb2, 0x49, 0x7b, 0x29, 0x07, 0x44, 0x1d, 0x7b, 0x0b, 0x8b, 0x13, 0x3b, 0x9a, 0x5d,
+	0x47, 0x80, 0x90, 0x96, 0xad, 0x77, 0x6a, 0x56, 0xb1, 0x67, 0xad, 0xdd, 0x71, 0xd4, 0xde, 0xf8,
+	0x13, 0xb8, 0x22, 0x71, 0x47, 0x02, 0xce, 0xdc, 0xb8, 0x57, 0x9c, 0x7a, 0x44, 0x1c, 0x2a, 0x94,
+	0x5c, 0xf8, 0x2f, 0x8a, 0x66, 0x66, 0xd7, 0x5e, 0x47, 0x91, 0x93, 0x48, 0xf4, 0xc2, 0x25, 0x99,
+	0x79, 0x3f, 0xbe, 0xef, 0x7b, 0x6f, 0xde, 0x8c, 0x17, 0x6a, 0xc1, 0x84, 0x50, 0x46, 0x46, 0x64,
+	0x4c, 0x58, 0xf8, 0xac, 0x31, 0x09, 0x03, 0x16, 0x34, 0x58, 0xe8, 0x0e, 0x48, 0xe3, 0x78, 0x47,
+	0x2e, 0xea, 0xc2, 0x88, 0x6e, 0x2d, 0x44, 0x4a, 0x63, 0x5d, 0x06, 0x1c, 0xef, 0x6c, 0x95, 0x86,
+	0xc1, 0x30, 0x90, 0xd9, 0x7c, 0x25, 0xdd, 0x5b, 0x77, 0xce, 0x43, 0x1f, 0x04, 0xe3, 0x71, 0x40,
+	0x39, 0xbc, 0x5c, 0xc5, 0xb1, 0xf5, 0xf3, 0x62, 0x43, 0x12, 0x05, 0xd3, 0x50, 0x8a, 0x49, 0xd6,
+	0x32, 0xbe, 0xfa, 0x0d, 0x80, 0xcd, 0xd9, 0xa3, 0xb6, 0xcb, 0x5c, 0x84, 0xa1, 0x98, 0xf8, 0x9d,
+	0x68, 0xe2, 0xd2, 0x48, 0x57, 0x2a, 0x2b, 0xb5, 0xf5, 0x7b, 0x77, 0xeb, 0xcb, 0x64, 0xd7, 0x71,
+	0x9c, 0x63, 0xf1, 0x14, 0xbc, 0x19, 0xa6, 0xb7, 0xd5, 0x9f, 0xb2, 0xb0, 0xb9, 0x10, 0x80, 0x1c,
+	0xb8, 0xe9, 0x91, 0x49, 0x48, 0x06, 0x2e, 0x23, 0x9e, 0x13, 0x0d, 0x82, 0x49, 0xc2, 0xf6, 0x4f,
+	0x41, 0xd0, 0xd5, 0x96, 0xd3, 0x59, 0x3c, 0x43, 0x72, 0x95, 0xe6, 0x40, 0x73, 0x2b, 0xea, 0xc0,
+	0x6a, 0xa2, 0x41, 0x57, 0x2a, 0x4a, 0x6d, 0xfd, 0xde, 0x07, 0xe7, 0x22, 0xce, 0x7a, 0x91, 0xaa,
+	0x61, 0x57, 0x7d, 0xfe, 0x72, 0x3b, 0x83, 0x67, 0x00, 0xc8, 0x84, 0xf5, 0xb4, 0xc4, 0xec, 0x15,
+	0x15, 0x42, 0x34, 0xd7, 0xf5, 0x36, 0x40, 0x34, 0xf8, 0x96, 0x8c, 0x5d, 0x67, 0x1a, 0x8e, 0xf4,
+	0x95, 0x8a, 0x52, 0x5b, 0xc3, 0x6b, 0xd2, 0xd2, 0x0f, 0x47, 0xd5, 0xdf, 0x14, 0x80, 0x54, 0x15,
+	0x3d, 0xc8, 0x89, 0xdc, 0xb8, 0x84, 0xfb, 0xe7, 0x52, 0xc6, 0x87, 0x7f, 0xbc, 0x53, 0x37, 0x69,
+	0xc4, 0xc2, 0xe9, 0x98, 0x50, 0xe6, 0x32, 0x3f, 0xa0, 0x02, 0x28, 0x2e, 0x46, 0xe2, 0xa0, 0x07,
+	0x90, 0x4b, 0xd7, 0x50, 0xbd, 0xa0, 0x86, 0x89, 0x4b, 0x71, 0x2e, 0xba, 0x8c, 0xf0, 0x5f, 0x37,
+	0x41, 0xe5, 0xe1, 0xe8, 0x6b, 0x58, 0x15, 0xf9, 0x8e, 0xef, 0x09, 0xd5, 0x1b, 0xbb, 0x4d, 0x2e,
+	0xe0, 0xaf, 0x97, 0xdb, 0x1f, 0x0d, 0x83, 0x33, 0x74, 0x3e, 0x9f, 0xe1, 0xd1, 0x88, 0x0c, 0x58,
+	0x10, 0x36, 0x26, 0x9e, 0xcb, 0xdc, 0x86, 0x4f, 0x19, 0x09, 0xa9, 0x3b, 0x6a, 0xf0, 0x5d, 0x5d,
+	0xcc, 0xa5, 0xd9, 0xc6, 0x05, 0x01, 0x69, 0x7a, 0xe8, 0x4b, 0x28, 0x70, 0x39, 0x1c, 0x3c, 0x2b,
+	0xc0, 0x1f, 0xc6, 0xe0, 0x0f, 0xae, 0x0e, 0xce, 0xe5, 0x9a, 0x6d, 0x9c, 0xe7, 0x80, 0xa6, 0x87,
+	0xb6, 0x61, 0x5d, 0x0a, 0x8f, 0x98, 0xcb, 0x48, 0x5c, 0x21, 0x08, 0x93, 0xc5, 0x2d, 0xe8, 0x09,
+	0x14, 0x27, 0x6e, 0x48, 0x28, 0x73, 0x12, 0x09, 0xea, 0x7f, 0x24, 0x61, 0x43, 0xe2, 0x5a, 0x52,
+	0x48, 0x09, 0x72, 0x4f, 0x46, 0xee, 0x30, 0xd2, 0xb5, 0x8a, 0x52, 0x2b, 0x60, 0xb9, 0x41, 0x08,
+	0x54, 0xea, 0x8e, 0x89, 0x9e, 0x13, 0xba, 0xc4, 0x1a, 0x7d, 0x02, 0xea, 0x91, 0x4f, 0x3d, 0x3d,
+	0x5f, 0x51, 0x6a, 0xc5, 0x8b, 0x6e, 0x28, 0x47, 0x17, 0x7f, 0x3a, 0x3e, 0xf5, 0xb0, 0x48, 0x44,
+	0x0d, 0x28, 0x45, 0xcc, 0x0d, 0x99, 0xc3, 0xfc, 0x31, 0x71, 0xa6, 0xd4, 0x7f, 0xea, 0x50, 0x97,
+	0x06, 0x7a, 0xa1, 0xa2, 0xd4, 0xf2, 0xf8, 0xba, 0xf0, 0xd9, 0xfe, 0x98, 0xf4, 0xa9, 0xff, 0xb4,
+	0xeb, 0xd2, 0x00, 0xdd, 0x05, 0x44, 0xa8, 0x77, 0x36, 0x7c, 0x55, 0x84, 0x5f, 0x23, 0xd4, 0x5b,
+	0x08, 0xde, 0x07, 0x70, 0x19, 0x0b, 0xfd, 0xc7, 0x53, 0x46, 0x22, 0x7d, 0x4d, 0x4c, 0xdc, 0xfb,
+	0x17, 0x8c, 0x70, 0x87, 0x3c, 0x3b, 0x74, 0x47, 0xd3, 0x64, 0x6c, 0x53, 0x00, 0xe8, 0x01, 0xe8,
+	0x5e, 0x18, 0x4c, 0x26, 0xc4, 0x73, 0xe6, 0x56, 0x67, 0x10, 0x4c, 0x29, 0xd3, 0xa1, 0xa2, 0xd4,
+	0x36, 0xf1, 0xcd, 0xd8, 0xdf, 0x9c, 0xb9, 0x5b, 0xdc, 0x8b, 0x1e, 0x42, 0x9e, 0x1c, 0x13, 0xca,
+	0x22, 0x7d, 0xfd, 0x52, 0x57, 0x97, 0x77, 0xca, 0xe0, 0x09, 0x38, 0xce, 0x43, 0x1f, 0x42, 0x29,
+	0xe1, 0x96, 0x96, 0x98, 0x77, 0x43, 0xf0, 0xa2, 0xd8, 0x27, 0x72, 0x62, 0xce, 0x8f, 0x21, 0x37,
+	0xf2, 0xe9, 0x51, 0xa4, 0x6f, 0x2e, 0xa9, 0x7b, 0x91, 0x72, 0xcf, 0xa7, 0x47, 0x58, 0x66, 0xa1,
+	0x3a, 0xbc, 0x91, 0x10, 0x0a, 0x43, 0xcc, 0x57, 0x14, 0x7c, 0xd7, 0x63, 0x17, 0x4f, 0x88, 0xe9,
+	0x76, 0x21, 0xcf, 0xe7, 0x76, 0x1a, 0xe9, 0xd7, 0xc4, 0x53, 0x71, 0xfb, 0x02, 0x3e, 0x11, 0x1b,
+	0x37, 0x39, 0xce, 0xdc, 0xfa, 0x43, 0x81, 0x9c, 0x28, 0x01, 0xdd, 0x86, 0xe2, 0x99, 0x23, 0x56,
+	0xc4, 0x11, 0x6f, 0xb0, 0xf4, 0xf9, 0x26, 0x23, 0x99, 0x4d, 0x8d, 0xe4, 0xe2, 0x99, 0xaf, 0xbc,
+	0xce, 0x33, 0x57, 0x97, 0x9d, 0xf9, 0xd6, 0xab, 0x2c, 0xa8, 0xbc, 0x3f, 0xff, 0xe3, 0x07, 0x69,
+	0xb1, 0xd7, 0xea, 0xeb, 0xec, 0x75, 0x6e, 0xe9, 0xfd, 0x9a, 0xbd, 0x58, 0xf9, 0xd4, 0x8b, 0x55,
+	0xfd, 0x41, 0x81, 0xd5, 0xe4, 0xbd, 0x41, 0x6f, 0xc2, 0x0d, 0xeb, 0xa0, 0xd9, 0x75, 0x3a, 0x66,
+	0xb7, 0xed, 0xf4, 0xbb, 0xd6, 0x81, 0xd1, 0x32, 0x1f, 0x99, 0x46, 0x5b, 0xcb, 0xa0, 0x9b, 0x80,
+	0xe6, 0x2e, 0xb3, 0x6b, 0x1b, 0xb8, 0xdb, 0xdc, 0xd3, 0x14, 0x54, 0x02, 0x6d, 0x6e, 0xb7, 0x0c,
+	0x7c, 0x68, 0x60, 0x2d, 0xbb, 0x68, 0x6d, 0xed, 0x99, 0x46, 0xd7, 0xd6, 0x56, 0x16, 0x31, 0x0e,
+	0x70, 0xaf, 0xdd, 0x6f, 0x19, 0x58, 0x53, 0x17, 0xed, 0xad, 0x5e, 0xd7, 0xea, 0xef, 0x1b, 0x58,
+	0xcb, 0x55, 0x7f, 0x57, 0x20, 0x2f, 0xef, 0x00, 0xd2, 0xa1, 0x30, 0x26, 0x51, 0xe4, 0x0e, 0x93,
+	0x41, 0x4e, 0xb6, 0xa8, 0x05, 0xea, 0x20, 0xf0, 0x64, 0xe7, 0x8b, 0xf7, 0x1a, 0x97, 0xb9, 0x51,
+	0xf1, 0xbf, 0x56, 0xe0, 0x11, 0x2c, 0x92, 0xab, 0x5d, 0x80, 0xb9, 0x0d, 0xdd, 0x80, 0xeb, 0x96,
+	0xdd, 0xb4, 0xfb, 0x96, 0xd3, 0xea, 0xb5, 0x0d, 0xde, 0x08, 0xc3, 0xd6, 0x32, 0x08, 0x41, 0x31,
+	0x6d, 0xee, 0x75, 0x34, 0xe5, 0x6c, 0xa8, 0x81, 0x71, 0x0f, 0x6b, 0xd9, 0xcf, 0xd5, 0x55, 0x45,
+	0xcb, 0xde, 0xf9, 0x51, 0x81, 0x35, 0xde, 0xdb, 0x47, 0xe2, 0xb7, 0x21, 0x69, 0xee, 0xa3, 0xbd,
+	0xe6, 0xa7, 0x96, 0xd3, 0xee, 0x39, 0xdd, 0x9e, 0xed, 0xf4, 0x2d, 0x43, 0xcb, 0xa0, 0x0a, 0xbc,
+	0x95, 0x72, 0xd9, 0xb8, 0xd9, 0x32, 0xe2, 0xf5, 0x7e, 0xd3, 0xea, 0x68, 0xaf, 0x14, 0x74, 0x07,
+	0xde, 0x4d, 0x45, 0xb4, 0x7a, 0x5d, 0xdb, 0xf8, 0xc2, 0x76, 0x3e, 0x6b, 0x5a, 0x8e, 0x69, 0x39,
+	0xd8, 0xd8, 0xef, 0xd9, 0x86, 0x8c, 0xfd, 0x2e, 0x8b, 0xde, 0x83, 0x77, 0xce, 0x89, 0x3d, 0x1b,
+	0xa7, 0xee, 0xfe, 0xa2, 0x3c, 0x3f, 0x29, 0x2b, 0x2f, 0x4e, 0xca, 0xca, 0xdf, 0x27, 0x65, 0xe5,
+	0xfb, 0xd3, 0x72, 0xe6, 0xc5, 0x69, 0x39, 0xf3, 0xe7, 0x69, 0x39, 0x03, 0xdb, 0x7e, 0xb0, 0xb4,
+	0x91, 0xbb, 0xf2, 0x63, 0xf4, 0x80, 0x1b, 0x0f, 0x94, 0xaf, 0x5a, 0x57, 0xbe, 0x46, 0xf2, 0x83,
+	0x77, 0x48, 0xe8, 0xec, 0xeb, 0xfb, 0xe7, 0xec, 0xad, 0xde, 0x84, 0x50, 0x7b, 0x06, 0x21, 0xc0,
+	0xe5, 0x5d, 0xae, 0x1f, 0xee, 0x3c, 0xce, 0x8b, 0x8c, 0xfb, 0xff, 0x0e, 0x00, 0xfd, 0xbe, 0x84,
+	0xc3, 0xc3, 0x0b, 0x00, 0x00,
 }
 
 func (m *TracesData) Marshal() (dAtA []byte, err error) {
@@ -993,6 +1112,14 @@ func (m *Span) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Flags != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.Flags))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x85
+	}
 	{
 		size, err := m.Status.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -1199,6 +1326,12 @@ func (m *Span_Link) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Flags != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.Flags))
+		i--
+		dAtA[i] = 0x35
+	}
 	if m.DroppedAttributesCount != 0 {
 		i = encodeVarintTrace(dAtA, i, uint64(m.DroppedAttributesCount))
 		i--
@@ -1415,6 +1548,9 @@ func (m *Span) Size() (n int) {
 	}
 	l = m.Status.Size()
 	n += 1 + l + sovTrace(uint64(l))
+	if m.Flags != 0 {
+		n += 6
+	}
 	return n
 }
 
@@ -1466,6 +1602,9 @@ func (m *Span_Link) Size() (n int) {
 	if m.DroppedAttributesCount != 0 {
 		n += 1 + sovTrace(uint64(m.DroppedAttributesCount))
 	}
+	if m.Flags != 0 {
+		n += 5
+	}
 	return n
 }
 
@@ -2330,6 +2469,16 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 16:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flags", wireType)
+			}
+			m.Flags = 0
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Flags = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTrace(dAtA[iNdEx:])
@@ -2676,6 +2825,16 @@ func (m *Span_Link) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flags", wireType)
+			}
+			m.Flags = 0
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Flags = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTrace(dAtA[iNdEx:])
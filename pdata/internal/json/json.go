@@ -20,3 +20,13 @@ var marshaler = &jsonpb.Marshaler{
 func Marshal(out io.Writer, pb proto.Message) error {
 	return marshaler.Marshal(out, pb)
 }
+
+var unmarshaler = &jsonpb.Unmarshaler{
+	// Skip the fields added to newer versions of the protocol.
+	AllowUnknownFields: true,
+}
+
+// Unmarshal decodes the OTLP/JSON encoded pb, for the signals without a dedicated jsoniter decoder.
+func Unmarshal(in io.Reader, pb proto.Message) error {
+	return unmarshaler.Unmarshal(in, pb)
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// lazyProto holds an OTLP payload which is decoded on first access. The payload is retained
+// as is, and returned by the marshalers as long as it has not been decoded, so that the data
+// passed through without being accessed is never decoded nor encoded again.
+type lazyProto struct {
+	once    sync.Once
+	buf     []byte
+	decoded atomic.Bool
+}
+
+// decode decodes the payload with unmarshal on the first call.
+// The payload is validated before the lazyProto is created, unmarshal does not fail on its
+// framing, the items decoded until a malformed item are kept otherwise.
+func (l *lazyProto) decode(unmarshal func([]byte) error) {
+	l.once.Do(func() {
+		_ = unmarshal(l.buf)
+		l.decoded.Store(true)
+	})
+}
+
+// buffer returns the payload if it has not been decoded yet.
+func (l *lazyProto) buffer() ([]byte, bool) {
+	if l == nil || l.decoded.Load() {
+		return nil, false
+	}
+	return l.buf, true
+}
+
+var errInvalidWireType = errors.New("invalid wire type")
+
+// countFields counts the occurrences of the innermost fields of a path of nested messages in buf.
+// Each element of the path holds the numbers of the message fields of one level, the fields of
+// the last level are counted, without being parsed. The framing of the levels of the path is
+// validated, the other fields are skipped.
+func countFields(buf []byte, path ...[]protowire.Number) (int, error) {
+	count := 0
+	for len(buf) > 0 {
+		num, typ, n := protowire.ConsumeTag(buf)
+		if n < 0 {
+			return 0, protowire.ParseError(n)
+		}
+		buf = buf[n:]
+		if !containsNumber(path[0], num) {
+			if n = protowire.ConsumeFieldValue(num, typ, buf); n < 0 {
+				return 0, protowire.ParseError(n)
+			}
+			buf = buf[n:]
+			continue
+		}
+		if typ != protowire.BytesType {
+			return 0, fmt.Errorf("field %d: %w %d", num, errInvalidWireType, typ)
+		}
+		value, n := protowire.ConsumeBytes(buf)
+		if n < 0 {
+			return 0, protowire.ParseError(n)
+		}
+		buf = buf[n:]
+		if len(path) == 1 {
+			count++
+			continue
+		}
+		nested, err := countFields(value, path[1:]...)
+		if err != nil {
+			return 0, err
+		}
+		count += nested
+	}
+	return count, nil
+}
+
+func containsNumber(nums []protowire.Number, num protowire.Number) bool {
+	for _, n := range nums {
+		if n == num {
+			return true
+		}
+	}
+	return false
+}
+
+// The paths of the items of each signal, in the TracesData, MetricsData and LogsData messages,
+// and their ExportServiceRequest counterparts which have the same fields.
+var (
+	spansPath = [][]protowire.Number{
+		{1}, // TracesData.resource_spans
+		{2}, // ResourceSpans.scope_spans
+		{2}, // ScopeSpans.spans
+	}
+	dataPointsPath = [][]protowire.Number{
+		{1},               // MetricsData.resource_metrics
+		{2},               // ResourceMetrics.scope_metrics
+		{2},               // ScopeMetrics.metrics
+		{5, 7, 9, 10, 11}, // Metric.gauge, sum, histogram, exponential_histogram and summary
+		{1},               // data_points
+	}
+	logRecordsPath = [][]protowire.Number{
+		{1}, // LogsData.resource_logs
+		{2}, // ResourceLogs.scope_logs
+		{2}, // ScopeLogs.log_records
+	}
+)
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestCountFields(t *testing.T) {
+	// Two messages of field 1 holding respectively two and one fields 2, and unrelated fields.
+	var first, second, buf []byte
+	first = protowire.AppendTag(first, 2, protowire.BytesType)
+	first = protowire.AppendBytes(first, []byte("a"))
+	first = protowire.AppendTag(first, 3, protowire.VarintType)
+	first = protowire.AppendVarint(first, 10)
+	first = protowire.AppendTag(first, 2, protowire.BytesType)
+	first = protowire.AppendBytes(first, nil)
+	second = protowire.AppendTag(second, 2, protowire.BytesType)
+	second = protowire.AppendBytes(second, []byte("b"))
+	buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+	buf = protowire.AppendBytes(buf, first)
+	buf = protowire.AppendTag(buf, 4, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, 1)
+	buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+	buf = protowire.AppendBytes(buf, second)
+
+	count, err := countFields(buf, []protowire.Number{1}, []protowire.Number{2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	count, err = countFields(buf, []protowire.Number{1, 4})
+	assert.EqualError(t, err, "field 4: invalid wire type 1")
+	assert.Equal(t, 0, count)
+
+	_, err = countFields(buf[:len(buf)-1], []protowire.Number{1}, []protowire.Number{2})
+	assert.Error(t, err)
+
+	count, err = countFields(nil, spansPath...)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestLazyProtoNil(t *testing.T) {
+	_, ok := LazyTracesBuffer(NewTraces(nil, nil))
+	assert.False(t, ok)
+	_, ok = LazyMetricsDataPointsCount(NewMetrics(nil, nil))
+	assert.False(t, ok)
+	_, ok = LazyLogsLogRecordsCount(NewLogs(nil, nil))
+	assert.False(t, ok)
+}
@@ -9,27 +9,69 @@ import (
 )
 
 type Logs struct {
-	orig *otlpcollectorlog.ExportLogsServiceRequest
+	orig  *otlpcollectorlog.ExportLogsServiceRequest
+	state *State
+	// lazy is the payload decoded into orig on first access, if the logs were unmarshaled lazily.
+	lazy *lazyProto
 }
 
 func GetOrigLogs(ms Logs) *otlpcollectorlog.ExportLogsServiceRequest {
+	if ms.lazy != nil {
+		ms.lazy.decode(ms.orig.Unmarshal)
+	}
 	return ms.orig
 }
 
-func NewLogs(orig *otlpcollectorlog.ExportLogsServiceRequest) Logs {
-	return Logs{orig: orig}
+func GetLogsState(ms Logs) *State {
+	return ms.state
+}
+
+func SetLogsState(ms Logs, state State) {
+	*ms.state = state
+}
+
+func NewLogs(orig *otlpcollectorlog.ExportLogsServiceRequest, state *State) Logs {
+	return Logs{orig: orig, state: state}
 }
 
 // LogsToProto internal helper to convert Logs to protobuf representation.
 func LogsToProto(l Logs) otlplogs.LogsData {
 	return otlplogs.LogsData{
-		ResourceLogs: l.orig.ResourceLogs,
+		ResourceLogs: GetOrigLogs(l).ResourceLogs,
 	}
 }
 
 // LogsFromProto internal helper to convert protobuf representation to Logs.
 func LogsFromProto(orig otlplogs.LogsData) Logs {
+	state := StateMutable
 	return Logs{orig: &otlpcollectorlog.ExportLogsServiceRequest{
 		ResourceLogs: orig.ResourceLogs,
-	}}
+	}, state: &state}
+}
+
+// NewLazyLogs returns the Logs decoded from buf on first access. The framing of the log records is
+// validated, their content is only decoded on access. buf is retained and must not be modified.
+func NewLazyLogs(buf []byte) (Logs, error) {
+	if _, err := countFields(buf, logRecordsPath...); err != nil {
+		return Logs{}, err
+	}
+	state := StateMutable
+	return Logs{orig: &otlpcollectorlog.ExportLogsServiceRequest{}, state: &state, lazy: &lazyProto{buf: buf}}, nil
+}
+
+// LazyLogsBuffer returns the payload of Logs unmarshaled lazily, as long as it has not been decoded.
+func LazyLogsBuffer(ms Logs) ([]byte, bool) {
+	return ms.lazy.buffer()
+}
+
+// LazyLogsLogRecordsCount returns the number of log records of Logs unmarshaled lazily without decoding
+// them, as long as they have not been decoded.
+func LazyLogsLogRecordsCount(ms Logs) (int, bool) {
+	buf, ok := ms.lazy.buffer()
+	if !ok {
+		return 0, false
+	}
+	// The payload was validated by NewLazyLogs.
+	count, _ := countFields(buf, logRecordsPath...)
+	return count, true
 }
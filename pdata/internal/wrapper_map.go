@@ -8,20 +8,26 @@ import (
 )
 
 type Map struct {
-	orig *[]otlpcommon.KeyValue
+	orig  *[]otlpcommon.KeyValue
+	state *State
 }
 
 func GetOrigMap(ms Map) *[]otlpcommon.KeyValue {
 	return ms.orig
 }
 
-func NewMap(orig *[]otlpcommon.KeyValue) Map {
-	return Map{orig: orig}
+func GetMapState(ms Map) *State {
+	return ms.state
+}
+
+func NewMap(orig *[]otlpcommon.KeyValue, state *State) Map {
+	return Map{orig: orig, state: state}
 }
 
 func GenerateTestMap() Map {
 	var orig []otlpcommon.KeyValue
-	ms := NewMap(&orig)
+	state := StateMutable
+	ms := NewMap(&orig, &state)
 	FillTestMap(ms)
 	return ms
 }
@@ -9,27 +9,69 @@ import (
 )
 
 type Metrics struct {
-	orig *otlpcollectormetrics.ExportMetricsServiceRequest
+	orig  *otlpcollectormetrics.ExportMetricsServiceRequest
+	state *State
+	// lazy is the payload decoded into orig on first access, if the metrics were unmarshaled lazily.
+	lazy *lazyProto
 }
 
 func GetOrigMetrics(ms Metrics) *otlpcollectormetrics.ExportMetricsServiceRequest {
+	if ms.lazy != nil {
+		ms.lazy.decode(ms.orig.Unmarshal)
+	}
 	return ms.orig
 }
 
-func NewMetrics(orig *otlpcollectormetrics.ExportMetricsServiceRequest) Metrics {
-	return Metrics{orig: orig}
+func GetMetricsState(ms Metrics) *State {
+	return ms.state
+}
+
+func SetMetricsState(ms Metrics, state State) {
+	*ms.state = state
+}
+
+func NewMetrics(orig *otlpcollectormetrics.ExportMetricsServiceRequest, state *State) Metrics {
+	return Metrics{orig: orig, state: state}
 }
 
 // MetricsToProto internal helper to convert Metrics to protobuf representation.
 func MetricsToProto(l Metrics) otlpmetrics.MetricsData {
 	return otlpmetrics.MetricsData{
-		ResourceMetrics: l.orig.ResourceMetrics,
+		ResourceMetrics: GetOrigMetrics(l).ResourceMetrics,
 	}
 }
 
 // MetricsFromProto internal helper to convert protobuf representation to Metrics.
 func MetricsFromProto(orig otlpmetrics.MetricsData) Metrics {
+	state := StateMutable
 	return Metrics{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{
 		ResourceMetrics: orig.ResourceMetrics,
-	}}
+	}, state: &state}
+}
+
+// NewLazyMetrics returns the Metrics decoded from buf on first access. The framing of the data points is
+// validated, their content is only decoded on access. buf is retained and must not be modified.
+func NewLazyMetrics(buf []byte) (Metrics, error) {
+	if _, err := countFields(buf, dataPointsPath...); err != nil {
+		return Metrics{}, err
+	}
+	state := StateMutable
+	return Metrics{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{}, state: &state, lazy: &lazyProto{buf: buf}}, nil
+}
+
+// LazyMetricsBuffer returns the payload of Metrics unmarshaled lazily, as long as it has not been decoded.
+func LazyMetricsBuffer(ms Metrics) ([]byte, bool) {
+	return ms.lazy.buffer()
+}
+
+// LazyMetricsDataPointsCount returns the number of data points of Metrics unmarshaled lazily without decoding
+// them, as long as they have not been decoded.
+func LazyMetricsDataPointsCount(ms Metrics) (int, bool) {
+	buf, ok := ms.lazy.buffer()
+	if !ok {
+		return 0, false
+	}
+	// The payload was validated by NewLazyMetrics.
+	count, _ := countFields(buf, dataPointsPath...)
+	return count, true
 }
@@ -9,27 +9,69 @@ import (
 )
 
 type Traces struct {
-	orig *otlpcollectortrace.ExportTraceServiceRequest
+	orig  *otlpcollectortrace.ExportTraceServiceRequest
+	state *State
+	// lazy is the payload decoded into orig on first access, if the traces were unmarshaled lazily.
+	lazy *lazyProto
 }
 
 func GetOrigTraces(ms Traces) *otlpcollectortrace.ExportTraceServiceRequest {
+	if ms.lazy != nil {
+		ms.lazy.decode(ms.orig.Unmarshal)
+	}
 	return ms.orig
 }
 
-func NewTraces(orig *otlpcollectortrace.ExportTraceServiceRequest) Traces {
-	return Traces{orig: orig}
+func GetTracesState(ms Traces) *State {
+	return ms.state
+}
+
+func SetTracesState(ms Traces, state State) {
+	*ms.state = state
+}
+
+func NewTraces(orig *otlpcollectortrace.ExportTraceServiceRequest, state *State) Traces {
+	return Traces{orig: orig, state: state}
 }
 
 // TracesToProto internal helper to convert Traces to protobuf representation.
 func TracesToProto(l Traces) otlptrace.TracesData {
 	return otlptrace.TracesData{
-		ResourceSpans: l.orig.ResourceSpans,
+		ResourceSpans: GetOrigTraces(l).ResourceSpans,
 	}
 }
 
 // TracesFromProto internal helper to convert protobuf representation to Traces.
 func TracesFromProto(orig otlptrace.TracesData) Traces {
+	state := StateMutable
 	return Traces{orig: &otlpcollectortrace.ExportTraceServiceRequest{
 		ResourceSpans: orig.ResourceSpans,
-	}}
+	}, state: &state}
+}
+
+// NewLazyTraces returns the Traces decoded from buf on first access. The framing of the spans is
+// validated, their content is only decoded on access. buf is retained and must not be modified.
+func NewLazyTraces(buf []byte) (Traces, error) {
+	if _, err := countFields(buf, spansPath...); err != nil {
+		return Traces{}, err
+	}
+	state := StateMutable
+	return Traces{orig: &otlpcollectortrace.ExportTraceServiceRequest{}, state: &state, lazy: &lazyProto{buf: buf}}, nil
+}
+
+// LazyTracesBuffer returns the payload of Traces unmarshaled lazily, as long as it has not been decoded.
+func LazyTracesBuffer(ms Traces) ([]byte, bool) {
+	return ms.lazy.buffer()
+}
+
+// LazyTracesSpansCount returns the number of spans of Traces unmarshaled lazily without decoding
+// them, as long as they have not been decoded.
+func LazyTracesSpansCount(ms Traces) (int, bool) {
+	buf, ok := ms.lazy.buffer()
+	if !ok {
+		return 0, false
+	}
+	// The payload was validated by NewLazyTraces.
+	count, _ := countFields(buf, spansPath...)
+	return count, true
 }
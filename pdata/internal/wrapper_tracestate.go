@@ -4,20 +4,26 @@
 package internal // import "go.opentelemetry.io/collector/pdata/internal"
 
 type TraceState struct {
-	orig *string
+	orig  *string
+	state *State
 }
 
 func GetOrigTraceState(ms TraceState) *string {
 	return ms.orig
 }
 
-func NewTraceState(orig *string) TraceState {
-	return TraceState{orig: orig}
+func GetTraceStateState(ms TraceState) *State {
+	return ms.state
+}
+
+func NewTraceState(orig *string, state *State) TraceState {
+	return TraceState{orig: orig, state: state}
 }
 
 func GenerateTestTraceState() TraceState {
 	var orig string
-	ms := NewTraceState(&orig)
+	state := StateMutable
+	ms := NewTraceState(&orig, &state)
 	FillTestTraceState(ms)
 	return ms
 }
@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parrow // import "go.opentelemetry.io/collector/pdata/parrow"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/ipc"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+	otlpresource "go.opentelemetry.io/collector/pdata/internal/data/protogen/resource/v1"
+)
+
+// The resource and scope columns are the first columns of the schemas of all the signals.
+const (
+	resourceColumn = iota
+	resourceSchemaURLColumn
+	scopeColumn
+	scopeSchemaURLColumn
+	firstSignalColumn
+)
+
+var (
+	traceIDType = &arrow.FixedSizeBinaryType{ByteWidth: 16}
+	spanIDType  = &arrow.FixedSizeBinaryType{ByteWidth: 8}
+	// attributesType maps the attribute keys to their OTLP protobuf AnyValue.
+	attributesType = arrow.MapOf(arrow.BinaryTypes.String, arrow.BinaryTypes.Binary)
+	// protosType is a list of OTLP protobuf messages, e.g. the span events.
+	protosType = arrow.ListOf(arrow.BinaryTypes.Binary)
+
+	errUnexpectedSchema = errors.New("unexpected Arrow schema")
+)
+
+// newSchema returns the schema made of the resource and scope columns followed by the columns of the signal.
+func newSchema(fields ...arrow.Field) *arrow.Schema {
+	return arrow.NewSchema(append([]arrow.Field{
+		// The OTLP protobuf Resource.
+		{Name: "resource", Type: arrow.BinaryTypes.Binary},
+		{Name: "resource_schema_url", Type: arrow.BinaryTypes.String},
+		// The OTLP protobuf InstrumentationScope.
+		{Name: "scope", Type: arrow.BinaryTypes.Binary},
+		{Name: "scope_schema_url", Type: arrow.BinaryTypes.String},
+	}, fields...), nil)
+}
+
+// writeRecord writes the record built by build into an Arrow IPC stream.
+func writeRecord(schema *arrow.Schema, build func(b *array.RecordBuilder) error) ([]byte, error) {
+	mem := memory.NewGoAllocator()
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	if err := build(b); err != nil {
+		return nil, err
+	}
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	buf := bytes.Buffer{}
+	w := ipc.NewWriter(&buf, ipc.WithSchema(schema), ipc.WithAllocator(mem))
+	if err := w.Write(rec); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// readRecords calls read with each record of the Arrow IPC stream, after checking its schema.
+func readRecords(buf []byte, schema *arrow.Schema, read func(rec arrow.Record) error) error {
+	r, err := ipc.NewReader(bytes.NewReader(buf), ipc.WithAllocator(memory.NewGoAllocator()))
+	if err != nil {
+		return err
+	}
+	defer r.Release()
+	if !r.Schema().Equal(schema) {
+		return errUnexpectedSchema
+	}
+	for r.Next() {
+		if err = read(r.Record()); err != nil {
+			return err
+		}
+	}
+	return r.Err()
+}
+
+// scopeEncoder appends the resource and scope columns, their protobuf encoding is shared by all the rows.
+type scopeEncoder struct {
+	resource  []byte
+	resURL    string
+	scope     []byte
+	scopeURL  string
+	resources *array.BinaryBuilder
+	resURLs   *array.StringBuilder
+	scopes    *array.BinaryBuilder
+	scopeURLs *array.StringBuilder
+}
+
+func newScopeEncoder(b *array.RecordBuilder) *scopeEncoder {
+	return &scopeEncoder{
+		resources: b.Field(resourceColumn).(*array.BinaryBuilder),
+		resURLs:   b.Field(resourceSchemaURLColumn).(*array.StringBuilder),
+		scopes:    b.Field(scopeColumn).(*array.BinaryBuilder),
+		scopeURLs: b.Field(scopeSchemaURLColumn).(*array.StringBuilder),
+	}
+}
+
+func (e *scopeEncoder) setResource(res *otlpresource.Resource, schemaURL string) error {
+	var err error
+	e.resource, err = res.Marshal()
+	e.resURL = schemaURL
+	return err
+}
+
+func (e *scopeEncoder) setScope(scope *otlpcommon.InstrumentationScope, schemaURL string) error {
+	var err error
+	e.scope, err = scope.Marshal()
+	e.scopeURL = schemaURL
+	return err
+}
+
+func (e *scopeEncoder) appendRow() {
+	e.resources.Append(e.resource)
+	e.resURLs.Append(e.resURL)
+	e.scopes.Append(e.scope)
+	e.scopeURLs.Append(e.scopeURL)
+}
+
+// scopeDecoder reads the resource and scope columns, the consecutive rows with the same
+// resource and scope are grouped together.
+type scopeDecoder struct {
+	resources *array.Binary
+	resURLs   *array.String
+	scopes    *array.Binary
+	scopeURLs *array.String
+}
+
+func newScopeDecoder(rec arrow.Record) *scopeDecoder {
+	return &scopeDecoder{
+		resources: rec.Column(resourceColumn).(*array.Binary),
+		resURLs:   rec.Column(resourceSchemaURLColumn).(*array.String),
+		scopes:    rec.Column(scopeColumn).(*array.Binary),
+		scopeURLs: rec.Column(scopeSchemaURLColumn).(*array.String),
+	}
+}
+
+// newResource returns whether the row starts a new resource.
+func (d *scopeDecoder) newResource(row int) bool {
+	return row == 0 ||
+		!bytes.Equal(d.resources.Value(row), d.resources.Value(row-1)) ||
+		d.resURLs.Value(row) != d.resURLs.Value(row-1)
+}
+
+// newScope returns whether the row starts a new scope.
+func (d *scopeDecoder) newScope(row int) bool {
+	return d.newResource(row) ||
+		!bytes.Equal(d.scopes.Value(row), d.scopes.Value(row-1)) ||
+		d.scopeURLs.Value(row) != d.scopeURLs.Value(row-1)
+}
+
+func (d *scopeDecoder) resource(row int) (otlpresource.Resource, string, error) {
+	res := otlpresource.Resource{}
+	if err := res.Unmarshal(d.resources.Value(row)); err != nil {
+		return res, "", fmt.Errorf("invalid resource: %w", err)
+	}
+	return res, d.resURLs.Value(row), nil
+}
+
+func (d *scopeDecoder) scope(row int) (otlpcommon.InstrumentationScope, string, error) {
+	scope := otlpcommon.InstrumentationScope{}
+	if err := scope.Unmarshal(d.scopes.Value(row)); err != nil {
+		return scope, "", fmt.Errorf("invalid scope: %w", err)
+	}
+	return scope, d.scopeURLs.Value(row), nil
+}
+
+func appendAttributes(b *array.MapBuilder, attrs []otlpcommon.KeyValue) error {
+	b.Append(true)
+	keys := b.KeyBuilder().(*array.StringBuilder)
+	values := b.ItemBuilder().(*array.BinaryBuilder)
+	for i := range attrs {
+		value, err := attrs[i].Value.Marshal()
+		if err != nil {
+			return err
+		}
+		keys.Append(attrs[i].Key)
+		values.Append(value)
+	}
+	return nil
+}
+
+func readAttributes(arr *array.Map, row int) ([]otlpcommon.KeyValue, error) {
+	start, end := arr.ValueOffsets(row)
+	if start == end {
+		return nil, nil
+	}
+	keys := arr.Keys().(*array.String)
+	values := arr.Items().(*array.Binary)
+	attrs := make([]otlpcommon.KeyValue, end-start)
+	for i := range attrs {
+		attrs[i].Key = keys.Value(int(start) + i)
+		if err := attrs[i].Value.Unmarshal(values.Value(int(start) + i)); err != nil {
+			return nil, fmt.Errorf("invalid attribute %q: %w", attrs[i].Key, err)
+		}
+	}
+	return attrs, nil
+}
+
+// protoMessage is an OTLP protobuf message.
+type protoMessage interface {
+	Marshal() ([]byte, error)
+	Unmarshal([]byte) error
+}
+
+// appendProtos appends the n protobuf messages returned by msg.
+func appendProtos(b *array.ListBuilder, n int, msg func(i int) protoMessage) error {
+	b.Append(true)
+	values := b.ValueBuilder().(*array.BinaryBuilder)
+	for i := 0; i < n; i++ {
+		buf, err := msg(i).Marshal()
+		if err != nil {
+			return err
+		}
+		values.Append(buf)
+	}
+	return nil
+}
+
+// readProtos unmarshals the protobuf messages of the row with newMsg.
+func readProtos(arr *array.List, row int, newMsg func() protoMessage) error {
+	start, end := arr.ValueOffsets(row)
+	values := arr.ListValues().(*array.Binary)
+	for i := start; i < end; i++ {
+		if err := newMsg().Unmarshal(values.Value(int(i))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
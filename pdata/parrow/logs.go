@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parrow // import "go.opentelemetry.io/collector/pdata/parrow"
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	logTimeColumn = firstSignalColumn + iota
+	logObservedTimeColumn
+	logSeverityNumberColumn
+	logSeverityTextColumn
+	logBodyColumn
+	logAttributesColumn
+	logDroppedAttributesCountColumn
+	logFlagsColumn
+	logTraceIDColumn
+	logSpanIDColumn
+)
+
+// LogsSchema is the Arrow schema of the logs, with one row per log record.
+var LogsSchema = newSchema(
+	arrow.Field{Name: "time", Type: arrow.FixedWidthTypes.Timestamp_ns},
+	arrow.Field{Name: "observed_time", Type: arrow.FixedWidthTypes.Timestamp_ns},
+	arrow.Field{Name: "severity_number", Type: arrow.PrimitiveTypes.Int32},
+	arrow.Field{Name: "severity_text", Type: arrow.BinaryTypes.String},
+	// The OTLP protobuf AnyValue.
+	arrow.Field{Name: "body", Type: arrow.BinaryTypes.Binary},
+	arrow.Field{Name: "attributes", Type: attributesType},
+	arrow.Field{Name: "dropped_attributes_count", Type: arrow.PrimitiveTypes.Uint32},
+	arrow.Field{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+	arrow.Field{Name: "trace_id", Type: traceIDType},
+	arrow.Field{Name: "span_id", Type: spanIDType},
+)
+
+var _ plog.Marshaler = (*Marshaler)(nil)
+
+// MarshalLogs marshals the Logs into an Arrow IPC stream of LogsSchema.
+func (*Marshaler) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	pb := internal.LogsToProto(internal.Logs(ld))
+	return writeRecord(LogsSchema, func(b *array.RecordBuilder) error {
+		scopes := newScopeEncoder(b)
+		for _, rl := range pb.ResourceLogs {
+			if err := scopes.setResource(&rl.Resource, rl.SchemaUrl); err != nil {
+				return err
+			}
+			for _, sl := range rl.ScopeLogs {
+				if err := scopes.setScope(&sl.Scope, sl.SchemaUrl); err != nil {
+					return err
+				}
+				for _, lr := range sl.LogRecords {
+					scopes.appendRow()
+					if err := appendLogRecord(b, lr); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func appendLogRecord(b *array.RecordBuilder, lr *otlplogs.LogRecord) error {
+	b.Field(logTimeColumn).(*array.TimestampBuilder).Append(arrow.Timestamp(lr.TimeUnixNano))
+	b.Field(logObservedTimeColumn).(*array.TimestampBuilder).Append(arrow.Timestamp(lr.ObservedTimeUnixNano))
+	b.Field(logSeverityNumberColumn).(*array.Int32Builder).Append(int32(lr.SeverityNumber))
+	b.Field(logSeverityTextColumn).(*array.StringBuilder).Append(lr.SeverityText)
+	body, err := lr.Body.Marshal()
+	if err != nil {
+		return err
+	}
+	b.Field(logBodyColumn).(*array.BinaryBuilder).Append(body)
+	if err = appendAttributes(b.Field(logAttributesColumn).(*array.MapBuilder), lr.Attributes); err != nil {
+		return err
+	}
+	b.Field(logDroppedAttributesCountColumn).(*array.Uint32Builder).Append(lr.DroppedAttributesCount)
+	b.Field(logFlagsColumn).(*array.Uint32Builder).Append(lr.Flags)
+	b.Field(logTraceIDColumn).(*array.FixedSizeBinaryBuilder).Append(lr.TraceId[:])
+	b.Field(logSpanIDColumn).(*array.FixedSizeBinaryBuilder).Append(lr.SpanId[:])
+	return nil
+}
+
+var _ plog.Unmarshaler = (*Unmarshaler)(nil)
+
+// UnmarshalLogs unmarshals an Arrow IPC stream of LogsSchema into Logs.
+func (*Unmarshaler) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	pb := otlplogs.LogsData{}
+	err := readRecords(buf, LogsSchema, func(rec arrow.Record) error {
+		scopes := newScopeDecoder(rec)
+		var rl *otlplogs.ResourceLogs
+		var sl *otlplogs.ScopeLogs
+		for row := 0; row < int(rec.NumRows()); row++ {
+			if scopes.newResource(row) {
+				rl = &otlplogs.ResourceLogs{}
+				var err error
+				if rl.Resource, rl.SchemaUrl, err = scopes.resource(row); err != nil {
+					return err
+				}
+				pb.ResourceLogs = append(pb.ResourceLogs, rl)
+			}
+			if scopes.newScope(row) {
+				sl = &otlplogs.ScopeLogs{}
+				var err error
+				if sl.Scope, sl.SchemaUrl, err = scopes.scope(row); err != nil {
+					return err
+				}
+				rl.ScopeLogs = append(rl.ScopeLogs, sl)
+			}
+			lr, err := readLogRecord(rec, row)
+			if err != nil {
+				return err
+			}
+			sl.LogRecords = append(sl.LogRecords, lr)
+		}
+		return nil
+	})
+	if err != nil {
+		return plog.Logs{}, err
+	}
+	return plog.Logs(internal.LogsFromProto(pb)), nil
+}
+
+func readLogRecord(rec arrow.Record, row int) (*otlplogs.LogRecord, error) {
+	lr := &otlplogs.LogRecord{
+		TimeUnixNano:           uint64(rec.Column(logTimeColumn).(*array.Timestamp).Value(row)),
+		ObservedTimeUnixNano:   uint64(rec.Column(logObservedTimeColumn).(*array.Timestamp).Value(row)),
+		SeverityNumber:         otlplogs.SeverityNumber(rec.Column(logSeverityNumberColumn).(*array.Int32).Value(row)),
+		SeverityText:           rec.Column(logSeverityTextColumn).(*array.String).Value(row),
+		DroppedAttributesCount: rec.Column(logDroppedAttributesCountColumn).(*array.Uint32).Value(row),
+		Flags:                  rec.Column(logFlagsColumn).(*array.Uint32).Value(row),
+	}
+	copy(lr.TraceId[:], rec.Column(logTraceIDColumn).(*array.FixedSizeBinary).Value(row))
+	copy(lr.SpanId[:], rec.Column(logSpanIDColumn).(*array.FixedSizeBinary).Value(row))
+	if err := lr.Body.Unmarshal(rec.Column(logBodyColumn).(*array.Binary).Value(row)); err != nil {
+		return nil, fmt.Errorf("invalid log body: %w", err)
+	}
+	var err error
+	if lr.Attributes, err = readAttributes(rec.Column(logAttributesColumn).(*array.Map), row); err != nil {
+		return nil, err
+	}
+	return lr, nil
+}
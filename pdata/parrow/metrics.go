@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parrow // import "go.opentelemetry.io/collector/pdata/parrow"
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	metricNameColumn = firstSignalColumn + iota
+	metricDescriptionColumn
+	metricUnitColumn
+	metricTypeColumn
+	metricDataColumn
+)
+
+// MetricsSchema is the Arrow schema of the metrics, with one row per metric.
+var MetricsSchema = newSchema(
+	arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "description", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "unit", Type: arrow.BinaryTypes.String},
+	// The pmetric.MetricType of the metric.
+	arrow.Field{Name: "type", Type: arrow.PrimitiveTypes.Int32},
+	// The OTLP protobuf Metric holding only the data points of the metric.
+	arrow.Field{Name: "data", Type: arrow.BinaryTypes.Binary},
+)
+
+var _ pmetric.Marshaler = (*Marshaler)(nil)
+
+// MarshalMetrics marshals the Metrics into an Arrow IPC stream of MetricsSchema.
+func (*Marshaler) MarshalMetrics(md pmetric.Metrics) ([]byte, error) {
+	pb := internal.MetricsToProto(internal.Metrics(md))
+	return writeRecord(MetricsSchema, func(b *array.RecordBuilder) error {
+		scopes := newScopeEncoder(b)
+		for _, rm := range pb.ResourceMetrics {
+			if err := scopes.setResource(&rm.Resource, rm.SchemaUrl); err != nil {
+				return err
+			}
+			for _, sm := range rm.ScopeMetrics {
+				if err := scopes.setScope(&sm.Scope, sm.SchemaUrl); err != nil {
+					return err
+				}
+				for _, m := range sm.Metrics {
+					scopes.appendRow()
+					if err := appendMetric(b, m); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func appendMetric(b *array.RecordBuilder, m *otlpmetrics.Metric) error {
+	b.Field(metricNameColumn).(*array.StringBuilder).Append(m.Name)
+	b.Field(metricDescriptionColumn).(*array.StringBuilder).Append(m.Description)
+	b.Field(metricUnitColumn).(*array.StringBuilder).Append(m.Unit)
+	b.Field(metricTypeColumn).(*array.Int32Builder).Append(int32(metricType(m)))
+	data, err := (&otlpmetrics.Metric{Data: m.Data}).Marshal()
+	if err != nil {
+		return err
+	}
+	b.Field(metricDataColumn).(*array.BinaryBuilder).Append(data)
+	return nil
+}
+
+func metricType(m *otlpmetrics.Metric) pmetric.MetricType {
+	switch m.Data.(type) {
+	case *otlpmetrics.Metric_Gauge:
+		return pmetric.MetricTypeGauge
+	case *otlpmetrics.Metric_Sum:
+		return pmetric.MetricTypeSum
+	case *otlpmetrics.Metric_Histogram:
+		return pmetric.MetricTypeHistogram
+	case *otlpmetrics.Metric_ExponentialHistogram:
+		return pmetric.MetricTypeExponentialHistogram
+	case *otlpmetrics.Metric_Summary:
+		return pmetric.MetricTypeSummary
+	}
+	return pmetric.MetricTypeEmpty
+}
+
+var _ pmetric.Unmarshaler = (*Unmarshaler)(nil)
+
+// UnmarshalMetrics unmarshals an Arrow IPC stream of MetricsSchema into Metrics.
+func (*Unmarshaler) UnmarshalMetrics(buf []byte) (pmetric.Metrics, error) {
+	pb := otlpmetrics.MetricsData{}
+	err := readRecords(buf, MetricsSchema, func(rec arrow.Record) error {
+		scopes := newScopeDecoder(rec)
+		var rm *otlpmetrics.ResourceMetrics
+		var sm *otlpmetrics.ScopeMetrics
+		for row := 0; row < int(rec.NumRows()); row++ {
+			if scopes.newResource(row) {
+				rm = &otlpmetrics.ResourceMetrics{}
+				var err error
+				if rm.Resource, rm.SchemaUrl, err = scopes.resource(row); err != nil {
+					return err
+				}
+				pb.ResourceMetrics = append(pb.ResourceMetrics, rm)
+			}
+			if scopes.newScope(row) {
+				sm = &otlpmetrics.ScopeMetrics{}
+				var err error
+				if sm.Scope, sm.SchemaUrl, err = scopes.scope(row); err != nil {
+					return err
+				}
+				rm.ScopeMetrics = append(rm.ScopeMetrics, sm)
+			}
+			m, err := readMetric(rec, row)
+			if err != nil {
+				return err
+			}
+			sm.Metrics = append(sm.Metrics, m)
+		}
+		return nil
+	})
+	if err != nil {
+		return pmetric.Metrics{}, err
+	}
+	return pmetric.Metrics(internal.MetricsFromProto(pb)), nil
+}
+
+func readMetric(rec arrow.Record, row int) (*otlpmetrics.Metric, error) {
+	m := &otlpmetrics.Metric{}
+	if err := m.Unmarshal(rec.Column(metricDataColumn).(*array.Binary).Value(row)); err != nil {
+		return nil, fmt.Errorf("invalid metric data: %w", err)
+	}
+	m.Name = rec.Column(metricNameColumn).(*array.String).Value(row)
+	m.Description = rec.Column(metricDescriptionColumn).(*array.String).Value(row)
+	m.Unit = rec.Column(metricUnitColumn).(*array.String).Value(row)
+	return m, nil
+}
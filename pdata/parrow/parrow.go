@@ -0,0 +1,15 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package parrow encodes the traces, metrics and logs into Apache Arrow record batches, written as
+// Arrow IPC streams, for an efficient columnar storage and the interoperability with the Arrow tools.
+package parrow // import "go.opentelemetry.io/collector/pdata/parrow"
+
+// Marshaler marshals the traces, metrics and logs into Arrow IPC streams holding one record batch,
+// with one row per span, metric or log record. The resources and scopes without any span, metric
+// or log record are not encoded.
+type Marshaler struct{}
+
+// Unmarshaler unmarshals the Arrow IPC streams written by Marshaler. The consecutive rows with the
+// same resource and scope are grouped together.
+type Unmarshaler struct{}
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parrow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.20.0")
+	rs.Resource().Attributes().PutStr("host.name", "testHost")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope1")
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetParentSpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1})
+	span.TraceState().FromRaw("rojo=00f067aa0ba902b7")
+	span.SetName("span1")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(1684617382541971000)
+	span.SetEndTimestamp(1684623646539558000)
+	span.Attributes().PutStr("http.method", "GET")
+	span.Attributes().PutInt("http.status_code", 500)
+	span.SetDroppedAttributesCount(1)
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.Attributes().PutStr("exception.message", "oops")
+	span.SetDroppedEventsCount(2)
+	link := span.Links().AppendEmpty()
+	link.SetTraceID([16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1})
+	span.SetDroppedLinksCount(3)
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Status().SetMessage("error")
+	ss.Spans().AppendEmpty().SetName("span2")
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope2")
+	ss.SetSchemaUrl("https://opentelemetry.io/schemas/1.20.0")
+	ss.Spans().AppendEmpty().SetName("span3")
+	rs = td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("host.name", "otherHost")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span4")
+	return td
+}
+
+func newMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("host.name", "testHost")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope1")
+	sm.Scope().SetVersion("v1")
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("requests")
+	m.SetDescription("The number of requests")
+	m.SetUnit("1")
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetIntValue(10)
+	dp.SetTimestamp(1684617382541971000)
+	dp.Attributes().PutStr("http.method", "GET")
+	m = sm.Metrics().AppendEmpty()
+	m.SetName("latency")
+	hdp := m.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetCount(2)
+	hdp.SetSum(3.5)
+	hdp.BucketCounts().FromRaw([]uint64{1, 1})
+	hdp.ExplicitBounds().FromRaw([]float64{2})
+	m = sm.Metrics().AppendEmpty()
+	m.SetName("temperature")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(21.5)
+	m = sm.Metrics().AppendEmpty()
+	m.SetName("sizes")
+	m.SetEmptyExponentialHistogram().DataPoints().AppendEmpty().Positive().BucketCounts().FromRaw([]uint64{1, 2})
+	m = sm.Metrics().AppendEmpty()
+	m.SetName("quantiles")
+	m.SetEmptySummary().DataPoints().AppendEmpty().QuantileValues().AppendEmpty().SetQuantile(0.99)
+	sm.Metrics().AppendEmpty().SetName("empty")
+	return md
+}
+
+func newLogs() plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("host.name", "testHost")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("scope1")
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetTimestamp(1684617382541971000)
+	lr.SetObservedTimestamp(1684623646539558000)
+	lr.SetSeverityNumber(plog.SeverityNumberError)
+	lr.SetSeverityText("error")
+	lr.Body().SetEmptyMap().PutStr("message", "oops")
+	lr.Attributes().PutBool("sampled", true)
+	lr.SetDroppedAttributesCount(1)
+	lr.SetFlags(plog.DefaultLogRecordFlags.WithIsSampled(true))
+	lr.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	lr.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	sl.LogRecords().AppendEmpty().Body().SetStr("second")
+	rl = ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("host.name", "otherHost")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("third")
+	return ld
+}
+
+func TestTracesRoundTrip(t *testing.T) {
+	for _, td := range []ptrace.Traces{ptrace.NewTraces(), newTraces()} {
+		buf, err := (&Marshaler{}).MarshalTraces(td)
+		require.NoError(t, err)
+		got, err := (&Unmarshaler{}).UnmarshalTraces(buf)
+		require.NoError(t, err)
+		assert.Equal(t, td, got)
+	}
+}
+
+func TestMetricsRoundTrip(t *testing.T) {
+	for _, md := range []pmetric.Metrics{pmetric.NewMetrics(), newMetrics()} {
+		buf, err := (&Marshaler{}).MarshalMetrics(md)
+		require.NoError(t, err)
+		got, err := (&Unmarshaler{}).UnmarshalMetrics(buf)
+		require.NoError(t, err)
+		assert.Equal(t, md, got)
+	}
+}
+
+func TestLogsRoundTrip(t *testing.T) {
+	for _, ld := range []plog.Logs{plog.NewLogs(), newLogs()} {
+		buf, err := (&Marshaler{}).MarshalLogs(ld)
+		require.NoError(t, err)
+		got, err := (&Unmarshaler{}).UnmarshalLogs(buf)
+		require.NoError(t, err)
+		assert.Equal(t, ld, got)
+	}
+}
+
+func TestMarshalSkipsEmptyScopes(t *testing.T) {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Scope().SetName("empty")
+	buf, err := (&Marshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	got, err := (&Unmarshaler{}).UnmarshalTraces(buf)
+	require.NoError(t, err)
+	assert.Equal(t, ptrace.NewTraces(), got)
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	_, err := (&Unmarshaler{}).UnmarshalTraces([]byte("invalid"))
+	assert.Error(t, err)
+
+	buf, err := (&Marshaler{}).MarshalLogs(newLogs())
+	require.NoError(t, err)
+	_, err = (&Unmarshaler{}).UnmarshalTraces(buf)
+	assert.ErrorIs(t, err, errUnexpectedSchema)
+	_, err = (&Unmarshaler{}).UnmarshalMetrics(buf)
+	assert.ErrorIs(t, err, errUnexpectedSchema)
+
+	buf, err = (&Marshaler{}).MarshalTraces(newTraces())
+	require.NoError(t, err)
+	_, err = (&Unmarshaler{}).UnmarshalLogs(buf)
+	assert.ErrorIs(t, err, errUnexpectedSchema)
+}
+
+func TestUnmarshalInvalidAttribute(t *testing.T) {
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().PutEmptyBytes("key").FromRaw([]byte{1})
+	buf, err := (&Marshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+	// The attribute values are protobuf AnyValue, corrupt the length of the bytes value.
+	buf = corruptValue(t, buf, []byte{0x3a, 0x01, 0x01})
+	_, err = (&Unmarshaler{}).UnmarshalLogs(buf)
+	assert.ErrorContains(t, err, `invalid attribute "key"`)
+}
+
+func corruptValue(t *testing.T, buf []byte, value []byte) []byte {
+	for i := 0; i+len(value) <= len(buf); i++ {
+		if string(buf[i:i+len(value)]) == string(value) {
+			buf[i+1] = 0x7f
+			return buf
+		}
+	}
+	t.Fatalf("value %v not found", value)
+	return nil
+}
+
+func TestSchemaColumns(t *testing.T) {
+	assert.Equal(t, "trace_id", TracesSchema.Field(traceIDColumn).Name)
+	assert.Equal(t, spanStatusMessageColumn+1, len(TracesSchema.Fields()))
+	assert.Equal(t, "name", MetricsSchema.Field(metricNameColumn).Name)
+	assert.Equal(t, metricDataColumn+1, len(MetricsSchema.Fields()))
+	assert.Equal(t, "time", LogsSchema.Field(logTimeColumn).Name)
+	assert.Equal(t, logSpanIDColumn+1, len(LogsSchema.Fields()))
+}
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parrow // import "go.opentelemetry.io/collector/pdata/parrow"
+
+import (
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	traceIDColumn = firstSignalColumn + iota
+	spanIDColumn
+	parentSpanIDColumn
+	traceStateColumn
+	spanNameColumn
+	spanKindColumn
+	spanStartTimeColumn
+	spanEndTimeColumn
+	spanAttributesColumn
+	spanDroppedAttributesCountColumn
+	spanEventsColumn
+	spanDroppedEventsCountColumn
+	spanLinksColumn
+	spanDroppedLinksCountColumn
+	spanStatusCodeColumn
+	spanStatusMessageColumn
+)
+
+// TracesSchema is the Arrow schema of the traces, with one row per span.
+var TracesSchema = newSchema(
+	arrow.Field{Name: "trace_id", Type: traceIDType},
+	arrow.Field{Name: "span_id", Type: spanIDType},
+	arrow.Field{Name: "parent_span_id", Type: spanIDType},
+	arrow.Field{Name: "trace_state", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "kind", Type: arrow.PrimitiveTypes.Int32},
+	arrow.Field{Name: "start_time", Type: arrow.FixedWidthTypes.Timestamp_ns},
+	arrow.Field{Name: "end_time", Type: arrow.FixedWidthTypes.Timestamp_ns},
+	arrow.Field{Name: "attributes", Type: attributesType},
+	arrow.Field{Name: "dropped_attributes_count", Type: arrow.PrimitiveTypes.Uint32},
+	// The OTLP protobuf span events.
+	arrow.Field{Name: "events", Type: protosType},
+	arrow.Field{Name: "dropped_events_count", Type: arrow.PrimitiveTypes.Uint32},
+	// The OTLP protobuf span links.
+	arrow.Field{Name: "links", Type: protosType},
+	arrow.Field{Name: "dropped_links_count", Type: arrow.PrimitiveTypes.Uint32},
+	arrow.Field{Name: "status_code", Type: arrow.PrimitiveTypes.Int32},
+	arrow.Field{Name: "status_message", Type: arrow.BinaryTypes.String},
+)
+
+var _ ptrace.Marshaler = (*Marshaler)(nil)
+
+// MarshalTraces marshals the Traces into an Arrow IPC stream of TracesSchema.
+func (*Marshaler) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	pb := internal.TracesToProto(internal.Traces(td))
+	return writeRecord(TracesSchema, func(b *array.RecordBuilder) error {
+		scopes := newScopeEncoder(b)
+		for _, rs := range pb.ResourceSpans {
+			if err := scopes.setResource(&rs.Resource, rs.SchemaUrl); err != nil {
+				return err
+			}
+			for _, ss := range rs.ScopeSpans {
+				if err := scopes.setScope(&ss.Scope, ss.SchemaUrl); err != nil {
+					return err
+				}
+				for _, span := range ss.Spans {
+					scopes.appendRow()
+					if err := appendSpan(b, span); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func appendSpan(b *array.RecordBuilder, span *otlptrace.Span) error {
+	b.Field(traceIDColumn).(*array.FixedSizeBinaryBuilder).Append(span.TraceId[:])
+	b.Field(spanIDColumn).(*array.FixedSizeBinaryBuilder).Append(span.SpanId[:])
+	b.Field(parentSpanIDColumn).(*array.FixedSizeBinaryBuilder).Append(span.ParentSpanId[:])
+	b.Field(traceStateColumn).(*array.StringBuilder).Append(span.TraceState)
+	b.Field(spanNameColumn).(*array.StringBuilder).Append(span.Name)
+	b.Field(spanKindColumn).(*array.Int32Builder).Append(int32(span.Kind))
+	b.Field(spanStartTimeColumn).(*array.TimestampBuilder).Append(arrow.Timestamp(span.StartTimeUnixNano))
+	b.Field(spanEndTimeColumn).(*array.TimestampBuilder).Append(arrow.Timestamp(span.EndTimeUnixNano))
+	if err := appendAttributes(b.Field(spanAttributesColumn).(*array.MapBuilder), span.Attributes); err != nil {
+		return err
+	}
+	b.Field(spanDroppedAttributesCountColumn).(*array.Uint32Builder).Append(span.DroppedAttributesCount)
+	if err := appendProtos(b.Field(spanEventsColumn).(*array.ListBuilder), len(span.Events), func(i int) protoMessage {
+		return span.Events[i]
+	}); err != nil {
+		return err
+	}
+	b.Field(spanDroppedEventsCountColumn).(*array.Uint32Builder).Append(span.DroppedEventsCount)
+	if err := appendProtos(b.Field(spanLinksColumn).(*array.ListBuilder), len(span.Links), func(i int) protoMessage {
+		return span.Links[i]
+	}); err != nil {
+		return err
+	}
+	b.Field(spanDroppedLinksCountColumn).(*array.Uint32Builder).Append(span.DroppedLinksCount)
+	b.Field(spanStatusCodeColumn).(*array.Int32Builder).Append(int32(span.Status.Code))
+	b.Field(spanStatusMessageColumn).(*array.StringBuilder).Append(span.Status.Message)
+	return nil
+}
+
+var _ ptrace.Unmarshaler = (*Unmarshaler)(nil)
+
+// UnmarshalTraces unmarshals an Arrow IPC stream of TracesSchema into Traces.
+func (*Unmarshaler) UnmarshalTraces(buf []byte) (ptrace.Traces, error) {
+	pb := otlptrace.TracesData{}
+	err := readRecords(buf, TracesSchema, func(rec arrow.Record) error {
+		scopes := newScopeDecoder(rec)
+		var rs *otlptrace.ResourceSpans
+		var ss *otlptrace.ScopeSpans
+		for row := 0; row < int(rec.NumRows()); row++ {
+			if scopes.newResource(row) {
+				rs = &otlptrace.ResourceSpans{}
+				var err error
+				if rs.Resource, rs.SchemaUrl, err = scopes.resource(row); err != nil {
+					return err
+				}
+				pb.ResourceSpans = append(pb.ResourceSpans, rs)
+			}
+			if scopes.newScope(row) {
+				ss = &otlptrace.ScopeSpans{}
+				var err error
+				if ss.Scope, ss.SchemaUrl, err = scopes.scope(row); err != nil {
+					return err
+				}
+				rs.ScopeSpans = append(rs.ScopeSpans, ss)
+			}
+			span, err := readSpan(rec, row)
+			if err != nil {
+				return err
+			}
+			ss.Spans = append(ss.Spans, span)
+		}
+		return nil
+	})
+	if err != nil {
+		return ptrace.Traces{}, err
+	}
+	return ptrace.Traces(internal.TracesFromProto(pb)), nil
+}
+
+func readSpan(rec arrow.Record, row int) (*otlptrace.Span, error) {
+	span := &otlptrace.Span{
+		TraceState:             rec.Column(traceStateColumn).(*array.String).Value(row),
+		Name:                   rec.Column(spanNameColumn).(*array.String).Value(row),
+		Kind:                   otlptrace.Span_SpanKind(rec.Column(spanKindColumn).(*array.Int32).Value(row)),
+		StartTimeUnixNano:      uint64(rec.Column(spanStartTimeColumn).(*array.Timestamp).Value(row)),
+		EndTimeUnixNano:        uint64(rec.Column(spanEndTimeColumn).(*array.Timestamp).Value(row)),
+		DroppedAttributesCount: rec.Column(spanDroppedAttributesCountColumn).(*array.Uint32).Value(row),
+		DroppedEventsCount:     rec.Column(spanDroppedEventsCountColumn).(*array.Uint32).Value(row),
+		DroppedLinksCount:      rec.Column(spanDroppedLinksCountColumn).(*array.Uint32).Value(row),
+		Status: otlptrace.Status{
+			Code:    otlptrace.Status_StatusCode(rec.Column(spanStatusCodeColumn).(*array.Int32).Value(row)),
+			Message: rec.Column(spanStatusMessageColumn).(*array.String).Value(row),
+		},
+	}
+	copy(span.TraceId[:], rec.Column(traceIDColumn).(*array.FixedSizeBinary).Value(row))
+	copy(span.SpanId[:], rec.Column(spanIDColumn).(*array.FixedSizeBinary).Value(row))
+	copy(span.ParentSpanId[:], rec.Column(parentSpanIDColumn).(*array.FixedSizeBinary).Value(row))
+	var err error
+	if span.Attributes, err = readAttributes(rec.Column(spanAttributesColumn).(*array.Map), row); err != nil {
+		return nil, err
+	}
+	if err = readProtos(rec.Column(spanEventsColumn).(*array.List), row, func() protoMessage {
+		event := &otlptrace.Span_Event{}
+		span.Events = append(span.Events, event)
+		return event
+	}); err != nil {
+		return nil, err
+	}
+	if err = readProtos(rec.Column(spanLinksColumn).(*array.List), row, func() protoMessage {
+		link := &otlptrace.Span_Link{}
+		span.Links = append(span.Links, link)
+		return link
+	}); err != nil {
+		return nil, err
+	}
+	return span, nil
+}
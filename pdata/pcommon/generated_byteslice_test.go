@@ -10,6 +10,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/internal"
 )
 
 func TestNewByteSlice(t *testing.T) {
@@ -41,12 +43,22 @@ func TestNewByteSlice(t *testing.T) {
 	ms.MoveTo(mv)
 	assert.Equal(t, 3, mv.Len())
 	assert.Equal(t, byte(1), mv.At(0))
+	sharedState := internal.StateReadOnly
+	readOnly := ByteSlice(internal.NewByteSlice(&[]byte{1}, &sharedState))
+	assert.Equal(t, byte(1), readOnly.At(0))
+	assert.Panics(t, func() { readOnly.SetAt(0, byte(2)) })
+	assert.Panics(t, func() { readOnly.FromRaw([]byte{2}) })
+	assert.Panics(t, func() { readOnly.Append(byte(2)) })
+	assert.Panics(t, func() { readOnly.EnsureCapacity(2) })
+	assert.Panics(t, func() { ms.MoveTo(readOnly) })
+	assert.Panics(t, func() { readOnly.MoveTo(ms) })
+	assert.Panics(t, func() { ms.CopyTo(readOnly) })
 }
 
 func TestByteSliceAppend(t *testing.T) {
 	ms := NewByteSlice()
 	ms.FromRaw([]byte{1, 2, 3})
-	ms.Append(4, 5)
+	ms.Append(3, 5)
 	assert.Equal(t, 5, ms.Len())
 	assert.Equal(t, byte(5), ms.At(4))
 }
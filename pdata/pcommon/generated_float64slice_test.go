@@ -10,6 +10,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/internal"
 )
 
 func TestNewFloat64Slice(t *testing.T) {
@@ -41,12 +43,22 @@ func TestNewFloat64Slice(t *testing.T) {
 	ms.MoveTo(mv)
 	assert.Equal(t, 3, mv.Len())
 	assert.Equal(t, float64(1), mv.At(0))
+	sharedState := internal.StateReadOnly
+	readOnly := Float64Slice(internal.NewFloat64Slice(&[]float64{1}, &sharedState))
+	assert.Equal(t, float64(1), readOnly.At(0))
+	assert.Panics(t, func() { readOnly.SetAt(0, float64(2)) })
+	assert.Panics(t, func() { readOnly.FromRaw([]float64{2}) })
+	assert.Panics(t, func() { readOnly.Append(float64(2)) })
+	assert.Panics(t, func() { readOnly.EnsureCapacity(2) })
+	assert.Panics(t, func() { ms.MoveTo(readOnly) })
+	assert.Panics(t, func() { readOnly.MoveTo(ms) })
+	assert.Panics(t, func() { ms.CopyTo(readOnly) })
 }
 
 func TestFloat64SliceAppend(t *testing.T) {
 	ms := NewFloat64Slice()
 	ms.FromRaw([]float64{1, 2, 3})
-	ms.Append(4, 5)
+	ms.Append(3, 5)
 	assert.Equal(t, 5, ms.Len())
 	assert.Equal(t, float64(5), ms.At(4))
 }
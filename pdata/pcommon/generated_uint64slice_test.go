@@ -10,6 +10,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/internal"
 )
 
 func TestNewUInt64Slice(t *testing.T) {
@@ -41,12 +43,22 @@ func TestNewUInt64Slice(t *testing.T) {
 	ms.MoveTo(mv)
 	assert.Equal(t, 3, mv.Len())
 	assert.Equal(t, uint64(1), mv.At(0))
+	sharedState := internal.StateReadOnly
+	readOnly := UInt64Slice(internal.NewUInt64Slice(&[]uint64{1}, &sharedState))
+	assert.Equal(t, uint64(1), readOnly.At(0))
+	assert.Panics(t, func() { readOnly.SetAt(0, uint64(2)) })
+	assert.Panics(t, func() { readOnly.FromRaw([]uint64{2}) })
+	assert.Panics(t, func() { readOnly.Append(uint64(2)) })
+	assert.Panics(t, func() { readOnly.EnsureCapacity(2) })
+	assert.Panics(t, func() { ms.MoveTo(readOnly) })
+	assert.Panics(t, func() { readOnly.MoveTo(ms) })
+	assert.Panics(t, func() { ms.CopyTo(readOnly) })
 }
 
 func TestUInt64SliceAppend(t *testing.T) {
 	ms := NewUInt64Slice()
 	ms.FromRaw([]uint64{1, 2, 3})
-	ms.Append(4, 5)
+	ms.Append(3, 5)
 	assert.Equal(t, 5, ms.Len())
 	assert.Equal(t, uint64(5), ms.At(4))
 }
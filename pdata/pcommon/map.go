@@ -4,6 +4,8 @@
 package pcommon // import "go.opentelemetry.io/collector/pdata/pcommon"
 
 import (
+	"sort"
+
 	"go.uber.org/multierr"
 
 	"go.opentelemetry.io/collector/pdata/internal"
@@ -16,30 +18,37 @@ type Map internal.Map
 // NewMap creates a Map with 0 elements.
 func NewMap() Map {
 	orig := []otlpcommon.KeyValue(nil)
-	return Map(internal.NewMap(&orig))
+	state := internal.StateMutable
+	return Map(internal.NewMap(&orig, &state))
 }
 
 func (m Map) getOrig() *[]otlpcommon.KeyValue {
 	return internal.GetOrigMap(internal.Map(m))
 }
 
-func newMap(orig *[]otlpcommon.KeyValue) Map {
-	return Map(internal.NewMap(orig))
+func (m Map) getState() *internal.State {
+	return internal.GetMapState(internal.Map(m))
+}
+
+func newMap(orig *[]otlpcommon.KeyValue, state *internal.State) Map {
+	return Map(internal.NewMap(orig, state))
 }
 
 // Clear erases any existing entries in this Map instance.
 func (m Map) Clear() {
+	m.getState().AssertMutable()
 	*m.getOrig() = nil
 }
 
 // EnsureCapacity increases the capacity of this Map instance, if necessary,
 // to ensure that it can hold at least the number of elements specified by the capacity argument.
 func (m Map) EnsureCapacity(capacity int) {
+	m.getState().AssertMutable()
 	if capacity <= cap(*m.getOrig()) {
 		return
 	}
 	oldOrig := *m.getOrig()
-	*m.getOrig() = make([]otlpcommon.KeyValue, 0, capacity)
+	*m.getOrig() = make([]otlpcommon.KeyValue, len(oldOrig), capacity)
 	copy(*m.getOrig(), oldOrig)
 }
 
@@ -54,15 +63,16 @@ func (m Map) Get(key string) (Value, bool) {
 	for i := range *m.getOrig() {
 		akv := &(*m.getOrig())[i]
 		if akv.Key == key {
-			return newValue(&akv.Value), true
+			return newValue(&akv.Value, m.getState()), true
 		}
 	}
-	return newValue(nil), false
+	return newValue(nil, m.getState()), false
 }
 
 // Remove removes the entry associated with the key and returns true if the key
 // was present in the map, otherwise returns false.
 func (m Map) Remove(key string) bool {
+	m.getState().AssertMutable()
 	for i := range *m.getOrig() {
 		akv := &(*m.getOrig())[i]
 		if akv.Key == key {
@@ -76,10 +86,11 @@ func (m Map) Remove(key string) bool {
 
 // RemoveIf removes the entries for which the function in question returns true
 func (m Map) RemoveIf(f func(string, Value) bool) {
+	m.getState().AssertMutable()
 	newLen := 0
 	for i := 0; i < len(*m.getOrig()); i++ {
 		akv := &(*m.getOrig())[i]
-		if f(akv.Key, newValue(&akv.Value)) {
+		if f(akv.Key, newValue(&akv.Value, m.getState())) {
 			continue
 		}
 		if newLen == i {
@@ -93,21 +104,65 @@ func (m Map) RemoveIf(f func(string, Value) bool) {
 	*m.getOrig() = (*m.getOrig())[:newLen]
 }
 
+// RangeAndRemove calls f sequentially for each key and value present in the map, removing the entries
+// once they are visited. If f returns false, the iteration stops and the entries not visited yet are kept.
+// The values must not be used after f returns, this allows moving the entries elsewhere without copying the map.
+func (m Map) RangeAndRemove(f func(k string, v Value) bool) {
+	m.getState().AssertMutable()
+	visited := len(*m.getOrig())
+	for i := range *m.getOrig() {
+		kv := &(*m.getOrig())[i]
+		if !f(kv.Key, newValue(&kv.Value, m.getState())) {
+			visited = i + 1
+			break
+		}
+	}
+	orig := *m.getOrig()
+	n := copy(orig, orig[visited:])
+	for i := n; i < len(orig); i++ {
+		// Release the references of the removed values.
+		orig[i] = otlpcommon.KeyValue{}
+	}
+	*m.getOrig() = orig[:n]
+}
+
+// PutAll inserts or updates the entries of a standard go map, growing the Map once for all the new entries.
+// Errors are returned for the values of unsupported types, the other values are put anyway.
+func (m Map) PutAll(rawMap map[string]any) error {
+	m.getState().AssertMutable()
+	existing := len(*m.getOrig())
+	m.EnsureCapacity(existing + len(rawMap))
+	var errs error
+	for k, iv := range rawMap {
+		if existing > 0 {
+			if av, ok := m.Get(k); ok {
+				errs = multierr.Append(errs, av.FromRaw(iv))
+				continue
+			}
+		}
+		*m.getOrig() = append(*m.getOrig(), otlpcommon.KeyValue{Key: k})
+		errs = multierr.Append(errs, newValue(&(*m.getOrig())[len(*m.getOrig())-1].Value, m.getState()).FromRaw(iv))
+	}
+	return errs
+}
+
 // PutEmpty inserts or updates an empty value to the map under given key
 // and return the updated/inserted value.
 func (m Map) PutEmpty(k string) Value {
+	m.getState().AssertMutable()
 	if av, existing := m.Get(k); existing {
 		av.getOrig().Value = nil
-		return newValue(av.getOrig())
+		return newValue(av.getOrig(), m.getState())
 	}
 	*m.getOrig() = append(*m.getOrig(), otlpcommon.KeyValue{Key: k})
-	return newValue(&(*m.getOrig())[len(*m.getOrig())-1].Value)
+	return newValue(&(*m.getOrig())[len(*m.getOrig())-1].Value, m.getState())
 }
 
 // PutStr performs the Insert or Update action. The Value is
 // inserted to the map that did not originally have the key. The key/value is
 // updated to the map where the key already existed.
 func (m Map) PutStr(k string, v string) {
+	m.getState().AssertMutable()
 	if av, existing := m.Get(k); existing {
 		av.SetStr(v)
 	} else {
@@ -119,6 +174,7 @@ func (m Map) PutStr(k string, v string) {
 // inserted to the map that did not originally have the key. The key/value is
 // updated to the map where the key already existed.
 func (m Map) PutInt(k string, v int64) {
+	m.getState().AssertMutable()
 	if av, existing := m.Get(k); existing {
 		av.SetInt(v)
 	} else {
@@ -130,6 +186,7 @@ func (m Map) PutInt(k string, v int64) {
 // inserted to the map that did not originally have the key. The key/value is
 // updated to the map where the key already existed.
 func (m Map) PutDouble(k string, v float64) {
+	m.getState().AssertMutable()
 	if av, existing := m.Get(k); existing {
 		av.SetDouble(v)
 	} else {
@@ -141,6 +198,7 @@ func (m Map) PutDouble(k string, v float64) {
 // inserted to the map that did not originally have the key. The key/value is
 // updated to the map where the key already existed.
 func (m Map) PutBool(k string, v bool) {
+	m.getState().AssertMutable()
 	if av, existing := m.Get(k); existing {
 		av.SetBool(v)
 	} else {
@@ -150,35 +208,38 @@ func (m Map) PutBool(k string, v bool) {
 
 // PutEmptyBytes inserts or updates an empty byte slice under given key and returns it.
 func (m Map) PutEmptyBytes(k string) ByteSlice {
+	m.getState().AssertMutable()
 	bv := otlpcommon.AnyValue_BytesValue{}
 	if av, existing := m.Get(k); existing {
 		av.getOrig().Value = &bv
 	} else {
 		*m.getOrig() = append(*m.getOrig(), otlpcommon.KeyValue{Key: k, Value: otlpcommon.AnyValue{Value: &bv}})
 	}
-	return ByteSlice(internal.NewByteSlice(&bv.BytesValue))
+	return ByteSlice(internal.NewByteSlice(&bv.BytesValue, m.getState()))
 }
 
 // PutEmptyMap inserts or updates an empty map under given key and returns it.
 func (m Map) PutEmptyMap(k string) Map {
+	m.getState().AssertMutable()
 	kvl := otlpcommon.AnyValue_KvlistValue{KvlistValue: &otlpcommon.KeyValueList{Values: []otlpcommon.KeyValue(nil)}}
 	if av, existing := m.Get(k); existing {
 		av.getOrig().Value = &kvl
 	} else {
 		*m.getOrig() = append(*m.getOrig(), otlpcommon.KeyValue{Key: k, Value: otlpcommon.AnyValue{Value: &kvl}})
 	}
-	return Map(internal.NewMap(&kvl.KvlistValue.Values))
+	return Map(internal.NewMap(&kvl.KvlistValue.Values, m.getState()))
 }
 
 // PutEmptySlice inserts or updates an empty slice under given key and returns it.
 func (m Map) PutEmptySlice(k string) Slice {
+	m.getState().AssertMutable()
 	vl := otlpcommon.AnyValue_ArrayValue{ArrayValue: &otlpcommon.ArrayValue{Values: []otlpcommon.AnyValue(nil)}}
 	if av, existing := m.Get(k); existing {
 		av.getOrig().Value = &vl
 	} else {
 		*m.getOrig() = append(*m.getOrig(), otlpcommon.KeyValue{Key: k, Value: otlpcommon.AnyValue{Value: &vl}})
 	}
-	return Slice(internal.NewSlice(&vl.ArrayValue.Values))
+	return Slice(internal.NewSlice(&vl.ArrayValue.Values, m.getState()))
 }
 
 // Len returns the length of this map.
@@ -199,7 +260,25 @@ func (m Map) Len() int {
 func (m Map) Range(f func(k string, v Value) bool) {
 	for i := range *m.getOrig() {
 		kv := &(*m.getOrig())[i]
-		if !f(kv.Key, Value(internal.NewValue(&kv.Value))) {
+		if !f(kv.Key, Value(internal.NewValue(&kv.Value, m.getState()))) {
+			break
+		}
+	}
+}
+
+// RangeSorted calls f sequentially for each key and value present in the map, in the order of the keys.
+// If f returns false, range stops the iteration. The map is not modified.
+func (m Map) RangeSorted(f func(k string, v Value) bool) {
+	orig := *m.getOrig()
+	indexes := make([]int, len(orig))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return orig[indexes[i]].Key < orig[indexes[j]].Key
+	})
+	for _, i := range indexes {
+		if !f(orig[i].Key, newValue(&orig[i].Value, m.getState())) {
 			break
 		}
 	}
@@ -207,6 +286,7 @@ func (m Map) Range(f func(k string, v Value) bool) {
 
 // CopyTo copies all elements from the current map overriding the destination.
 func (m Map) CopyTo(dest Map) {
+	dest.getState().AssertMutable()
 	newLen := len(*m.getOrig())
 	oldCap := cap(*dest.getOrig())
 	if newLen <= oldCap {
@@ -216,7 +296,7 @@ func (m Map) CopyTo(dest Map) {
 			akv := &(*m.getOrig())[i]
 			destAkv := &(*dest.getOrig())[i]
 			destAkv.Key = akv.Key
-			newValue(&akv.Value).CopyTo(newValue(&destAkv.Value))
+			newValue(&akv.Value, m.getState()).CopyTo(newValue(&destAkv.Value, dest.getState()))
 		}
 		return
 	}
@@ -226,7 +306,7 @@ func (m Map) CopyTo(dest Map) {
 	for i := range *m.getOrig() {
 		akv := &(*m.getOrig())[i]
 		origs[i].Key = akv.Key
-		newValue(&akv.Value).CopyTo(newValue(&origs[i].Value))
+		newValue(&akv.Value, m.getState()).CopyTo(newValue(&origs[i].Value, dest.getState()))
 	}
 	*dest.getOrig() = origs
 }
@@ -243,6 +323,7 @@ func (m Map) AsRaw() map[string]any {
 
 // FromRaw overrides this Map instance from a standard go map.
 func (m Map) FromRaw(rawMap map[string]any) error {
+	m.getState().AssertMutable()
 	if len(rawMap) == 0 {
 		*m.getOrig() = nil
 		return nil
@@ -253,7 +334,7 @@ func (m Map) FromRaw(rawMap map[string]any) error {
 	ix := 0
 	for k, iv := range rawMap {
 		origs[ix].Key = k
-		errs = multierr.Append(errs, newValue(&origs[ix].Value).FromRaw(iv))
+		errs = multierr.Append(errs, newValue(&origs[ix].Value, m.getState()).FromRaw(iv))
 		ix++
 	}
 	*m.getOrig() = origs
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon // import "go.opentelemetry.io/collector/pdata/pcommon"
+
+import (
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+)
+
+// MapBuilder accumulates the entries of a Map, to set all of them with a single allocation.
+// Unlike the Put functions of Map, the builder does not look for existing keys: the appended
+// keys must be unique. The builder can be reused once a map is built, keeping its buffer,
+// which avoids the allocations of the maps built in a loop, such as the attributes of the
+// data points created by a processor.
+//
+// The zero value is ready to use. A MapBuilder must not be used concurrently.
+type MapBuilder struct {
+	state internal.State
+	kvs   []otlpcommon.KeyValue
+}
+
+// EnsureCapacity increases the capacity of the builder, if necessary,
+// to ensure that it can hold at least the number of entries specified by the capacity argument.
+func (mb *MapBuilder) EnsureCapacity(capacity int) {
+	if capacity <= cap(mb.kvs) {
+		return
+	}
+	kvs := make([]otlpcommon.KeyValue, len(mb.kvs), capacity)
+	copy(kvs, mb.kvs)
+	mb.kvs = kvs
+}
+
+// Len returns the number of entries appended since the last map was built.
+func (mb *MapBuilder) Len() int {
+	return len(mb.kvs)
+}
+
+// AppendStr appends a string value under the given key.
+func (mb *MapBuilder) AppendStr(k string, v string) {
+	mb.kvs = append(mb.kvs, newKeyValueString(k, v))
+}
+
+// AppendInt appends an int value under the given key.
+func (mb *MapBuilder) AppendInt(k string, v int64) {
+	mb.kvs = append(mb.kvs, newKeyValueInt(k, v))
+}
+
+// AppendDouble appends a double value under the given key.
+func (mb *MapBuilder) AppendDouble(k string, v float64) {
+	mb.kvs = append(mb.kvs, newKeyValueDouble(k, v))
+}
+
+// AppendBool appends a bool value under the given key.
+func (mb *MapBuilder) AppendBool(k string, v bool) {
+	mb.kvs = append(mb.kvs, newKeyValueBool(k, v))
+}
+
+// AppendCopy appends a copy of the value under the given key.
+func (mb *MapBuilder) AppendCopy(k string, v Value) {
+	mb.kvs = append(mb.kvs, otlpcommon.KeyValue{Key: k})
+	v.CopyTo(newValue(&mb.kvs[len(mb.kvs)-1].Value, &mb.state))
+}
+
+// Build overrides the entries of dest with the appended entries, and resets the builder
+// to build another map.
+func (mb *MapBuilder) Build(dest Map) {
+	dest.getState().AssertMutable()
+	if len(mb.kvs) == 0 {
+		*dest.getOrig() = nil
+		return
+	}
+	orig := *dest.getOrig()
+	if cap(orig) < len(mb.kvs) {
+		orig = make([]otlpcommon.KeyValue, len(mb.kvs))
+	}
+	orig = orig[:len(mb.kvs)]
+	copy(orig, mb.kvs)
+	*dest.getOrig() = orig
+	mb.Reset()
+}
+
+// Reset discards the appended entries, keeping the buffer of the builder.
+func (mb *MapBuilder) Reset() {
+	for i := range mb.kvs {
+		// Release the references of the values, which are owned by the built map.
+		mb.kvs[i] = otlpcommon.KeyValue{}
+	}
+	mb.kvs = mb.kvs[:0]
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+)
+
+func TestMapBuilder(t *testing.T) {
+	var mb MapBuilder
+	mb.EnsureCapacity(5)
+	mb.AppendStr("k_string", "123")
+	mb.AppendInt("k_int", 123)
+	mb.AppendDouble("k_double", 1.23)
+	mb.AppendBool("k_bool", true)
+	nested := NewValueMap()
+	nested.Map().PutStr("k", "v")
+	mb.AppendCopy("k_map", nested)
+	assert.Equal(t, 5, mb.Len())
+
+	am := NewMap()
+	am.PutStr("k_old", "old")
+	mb.Build(am)
+	assert.Equal(t, 0, mb.Len())
+	assert.Equal(t, map[string]any{
+		"k_string": "123",
+		"k_int":    int64(123),
+		"k_double": 1.23,
+		"k_bool":   true,
+		"k_map":    map[string]any{"k": "v"},
+	}, am.AsRaw())
+
+	// The copied values are not shared with the source.
+	nested.Map().PutStr("k", "changed")
+	v, _ := am.Get("k_map")
+	assert.Equal(t, map[string]any{"k": "v"}, v.Map().AsRaw())
+
+	// The builder is reused for another map.
+	mb.AppendStr("k", "v")
+	other := NewMap()
+	mb.Build(other)
+	assert.Equal(t, map[string]any{"k": "v"}, other.AsRaw())
+	assert.Equal(t, 5, am.Len())
+
+	mb.AppendStr("k", "v")
+	mb.Reset()
+	mb.Build(other)
+	assert.Equal(t, 0, other.Len())
+}
+
+func TestMapBuilderReadOnly(t *testing.T) {
+	var mb MapBuilder
+	mb.AppendStr("k", "v")
+	state := internal.StateReadOnly
+	assert.Panics(t, func() { mb.Build(newMap(new([]otlpcommon.KeyValue), &state)) })
+}
+
+func BenchmarkMapBuilder(b *testing.B) {
+	var mb MapBuilder
+	am := NewMap()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		mb.AppendStr("k_string", "123")
+		mb.AppendInt("k_int", 123)
+		mb.AppendBool("k_bool", true)
+		mb.Build(am)
+	}
+}
+
+func BenchmarkMapPut(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		am := NewMap()
+		am.PutStr("k_string", "123")
+		am.PutInt("k_int", 123)
+		am.PutBool("k_bool", true)
+	}
+}
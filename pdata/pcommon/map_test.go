@@ -13,11 +13,12 @@ import (
 )
 
 func TestMap(t *testing.T) {
+	state := internal.StateMutable
 	assert.EqualValues(t, 0, NewMap().Len())
 
 	val, exist := NewMap().Get("test_key")
 	assert.False(t, exist)
-	assert.EqualValues(t, newValue(nil), val)
+	assert.EqualValues(t, newValue(nil, &state), val)
 
 	putString := NewMap()
 	putString.PutStr("k", "v")
@@ -137,6 +138,7 @@ func TestMapPutEmptyBytes(t *testing.T) {
 }
 
 func TestMapWithEmpty(t *testing.T) {
+	state := internal.StateMutable
 	origWithNil := []otlpcommon.KeyValue{
 		{},
 		{
@@ -148,7 +150,7 @@ func TestMapWithEmpty(t *testing.T) {
 			Value: otlpcommon.AnyValue{Value: nil},
 		},
 	}
-	sm := newMap(&origWithNil)
+	sm := newMap(&origWithNil, &state)
 	val, exist := sm.Get("test_key")
 	assert.True(t, exist)
 	assert.EqualValues(t, ValueTypeStr, val.Type())
@@ -373,6 +375,11 @@ func TestMap_EnsureCapacity(t *testing.T) {
 	am.EnsureCapacity(8)
 	assert.Equal(t, 0, am.Len())
 	assert.Equal(t, 8, cap(*am.getOrig()))
+
+	am.PutStr("k", "v")
+	am.EnsureCapacity(10)
+	assert.Equal(t, map[string]any{"k": "v"}, am.AsRaw())
+	assert.Equal(t, 10, cap(*am.getOrig()))
 }
 
 func TestMap_Clear(t *testing.T) {
@@ -412,6 +419,63 @@ func TestMap_RemoveIf(t *testing.T) {
 	assert.True(t, exists)
 }
 
+func TestMap_RangeAndRemove(t *testing.T) {
+	am := NewMap()
+	am.PutStr("k_string", "123")
+	am.PutInt("k_int", int64(123))
+	am.PutBool("k_bool", true)
+
+	var keys []string
+	am.RangeAndRemove(func(k string, v Value) bool {
+		keys = append(keys, k)
+		return k != "k_int"
+	})
+	assert.Equal(t, []string{"k_string", "k_int"}, keys)
+	assert.Equal(t, map[string]any{"k_bool": true}, am.AsRaw())
+
+	am.RangeAndRemove(func(k string, v Value) bool {
+		assert.Equal(t, "k_bool", k)
+		return true
+	})
+	assert.Equal(t, 0, am.Len())
+}
+
+func TestMap_PutAll(t *testing.T) {
+	am := NewMap()
+	assert.NoError(t, am.PutAll(map[string]any{"k_string": "123", "k_int": 123}))
+	assert.Equal(t, map[string]any{"k_string": "123", "k_int": int64(123)}, am.AsRaw())
+
+	assert.NoError(t, am.PutAll(map[string]any{"k_int": 456, "k_bool": true}))
+	assert.Equal(t, map[string]any{"k_string": "123", "k_int": int64(456), "k_bool": true}, am.AsRaw())
+
+	assert.Error(t, am.PutAll(map[string]any{"k_invalid": struct{}{}, "k_double": 1.5}))
+	assert.Equal(t, 5, am.Len())
+	v, ok := am.Get("k_double")
+	assert.True(t, ok)
+	assert.Equal(t, 1.5, v.Double())
+}
+
+func TestMap_RangeSorted(t *testing.T) {
+	am := NewMap()
+	am.PutStr("c", "3")
+	am.PutStr("a", "1")
+	am.PutStr("b", "2")
+
+	var keys []string
+	am.RangeSorted(func(k string, v Value) bool {
+		keys = append(keys, k+"="+v.Str())
+		return k != "b"
+	})
+	assert.Equal(t, []string{"a=1", "b=2"}, keys)
+	// The order of the entries is unchanged.
+	keys = keys[:0]
+	am.Range(func(k string, v Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	assert.Equal(t, []string{"c", "a", "b"}, keys)
+}
+
 func generateTestEmptyMap(t *testing.T) Map {
 	m := NewMap()
 	assert.NoError(t, m.FromRaw(map[string]any{"k": map[string]any(nil)}))
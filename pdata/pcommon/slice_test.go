@@ -13,13 +13,14 @@ import (
 )
 
 func TestSlice(t *testing.T) {
+	state := internal.StateMutable
 	es := NewSlice()
 	assert.Equal(t, 0, es.Len())
-	es = newSlice(&[]otlpcommon.AnyValue{})
+	es = newSlice(&[]otlpcommon.AnyValue{}, &state)
 	assert.Equal(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newValue(&otlpcommon.AnyValue{})
+	emptyVal := newValue(&otlpcommon.AnyValue{}, &state)
 	testVal := Value(internal.GenerateTestValue())
 	assert.Equal(t, 7, cap(*es.getOrig()))
 	for i := 0; i < es.Len(); i++ {
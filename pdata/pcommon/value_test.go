@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
 )
 
@@ -57,6 +58,7 @@ func TestValueType(t *testing.T) {
 }
 
 func TestValueMap(t *testing.T) {
+	state := internal.StateMutable
 	m1 := NewValueMap()
 	assert.Equal(t, ValueTypeMap, m1.Type())
 	assert.Equal(t, NewMap(), m1.Map())
@@ -128,11 +130,12 @@ func TestValueMap(t *testing.T) {
 
 	// Test nil KvlistValue case for Map() func.
 	orig := &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_KvlistValue{KvlistValue: nil}}
-	m1 = newValue(orig)
+	m1 = newValue(orig, &state)
 	assert.EqualValues(t, Map{}, m1.Map())
 }
 
 func TestValueSlice(t *testing.T) {
+	state := internal.StateMutable
 	a1 := NewValueSlice()
 	assert.EqualValues(t, ValueTypeSlice, a1.Type())
 	assert.EqualValues(t, NewSlice(), a1.Slice())
@@ -165,8 +168,8 @@ func TestValueSlice(t *testing.T) {
 	assert.EqualValues(t, "somestr", v.Str())
 
 	// Test nil values case for Slice() func.
-	a1 = newValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: nil}})
-	assert.EqualValues(t, newSlice(nil), a1.Slice())
+	a1 = newValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: nil}}, &state)
+	assert.EqualValues(t, Slice{}, a1.Slice())
 }
 
 func TestNilOrigSetValue(t *testing.T) {
@@ -200,35 +203,37 @@ func TestNilOrigSetValue(t *testing.T) {
 }
 
 func TestValue_CopyTo(t *testing.T) {
+	state := internal.StateMutable
 	// Test nil KvlistValue case for Map() func.
 	dest := NewValueEmpty()
 	orig := &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_KvlistValue{KvlistValue: nil}}
-	newValue(orig).CopyTo(dest)
+	newValue(orig, &state).CopyTo(dest)
 	assert.Nil(t, dest.getOrig().Value.(*otlpcommon.AnyValue_KvlistValue).KvlistValue)
 
 	// Test nil ArrayValue case for Slice() func.
 	dest = NewValueEmpty()
 	orig = &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: nil}}
-	newValue(orig).CopyTo(dest)
+	newValue(orig, &state).CopyTo(dest)
 	assert.Nil(t, dest.getOrig().Value.(*otlpcommon.AnyValue_ArrayValue).ArrayValue)
 
 	// Test copy empty value.
 	orig = &otlpcommon.AnyValue{}
-	newValue(orig).CopyTo(dest)
+	newValue(orig, &state).CopyTo(dest)
 	assert.Nil(t, dest.getOrig().Value)
 
 	av := NewValueEmpty()
 	destVal := otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_IntValue{}}
-	av.CopyTo(newValue(&destVal))
+	av.CopyTo(newValue(&destVal, &state))
 	assert.EqualValues(t, nil, destVal.Value)
 }
 
 func TestSliceWithNilValues(t *testing.T) {
+	state := internal.StateMutable
 	origWithNil := []otlpcommon.AnyValue{
 		{},
 		{Value: &otlpcommon.AnyValue_StringValue{StringValue: "test_value"}},
 	}
-	sm := newSlice(&origWithNil)
+	sm := newSlice(&origWithNil, &state)
 
 	val := sm.At(0)
 	assert.EqualValues(t, ValueTypeEmpty, val.Type())
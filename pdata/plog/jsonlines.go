@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+import (
+	"bytes"
+	"fmt"
+
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	"go.opentelemetry.io/collector/pdata/internal/json"
+)
+
+var _ Marshaler = (*JSONLinesMarshaler)(nil)
+
+// JSONLinesMarshaler marshals Logs into newline-delimited OTLP JSON, also known as JSON Lines:
+// each line is the OTLP JSON LogsData of one resource, so that the logs can be appended to
+// a file and read back one resource at a time.
+type JSONLinesMarshaler struct{}
+
+func (*JSONLinesMarshaler) MarshalLogs(ld Logs) ([]byte, error) {
+	buf := bytes.Buffer{}
+	rls := ld.getOrig().ResourceLogs
+	for i := range rls {
+		pb := otlplogs.LogsData{ResourceLogs: rls[i : i+1]}
+		if err := json.Marshal(&buf, &pb); err != nil {
+			return nil, err
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+var _ Unmarshaler = (*JSONLinesUnmarshaler)(nil)
+
+// JSONLinesUnmarshaler unmarshals newline-delimited OTLP JSON into Logs. Each line holds an OTLP
+// JSON LogsData, the resources of all the lines are merged, the empty lines are skipped.
+type JSONLinesUnmarshaler struct{}
+
+func (*JSONLinesUnmarshaler) UnmarshalLogs(buf []byte) (Logs, error) {
+	ld := NewLogs()
+	unmarshaler := JSONUnmarshaler{}
+	for lineNum := 1; len(buf) > 0; lineNum++ {
+		line := buf
+		if i := bytes.IndexByte(buf, '\n'); i >= 0 {
+			line, buf = buf[:i], buf[i+1:]
+		} else {
+			buf = nil
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		lineData, err := unmarshaler.UnmarshalLogs(line)
+		if err != nil {
+			return Logs{}, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		lineData.ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+	}
+	return ld, nil
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLinesRoundTrip(t *testing.T) {
+	ld := NewLogs()
+	logsOTLP.ResourceLogs().CopyTo(ld.ResourceLogs())
+	logsOTLP.ResourceLogs().At(0).CopyTo(ld.ResourceLogs().AppendEmpty())
+	ld.ResourceLogs().At(1).Resource().Attributes().PutStr("host.name", "otherHost")
+
+	buf, err := (&JSONLinesMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+	lines := bytes.Split(bytes.TrimSuffix(buf, []byte("\n")), []byte("\n"))
+	require.Len(t, lines, 2)
+	assert.Equal(t, logsJSON, string(lines[0]))
+
+	// The empty lines are skipped and the resources of all the lines are merged.
+	got, err := (&JSONLinesUnmarshaler{}).UnmarshalLogs(append([]byte("\n"), buf...))
+	require.NoError(t, err)
+	assert.Equal(t, ld, got)
+}
+
+func TestJSONLinesMarshalEmpty(t *testing.T) {
+	buf, err := (&JSONLinesMarshaler{}).MarshalLogs(NewLogs())
+	require.NoError(t, err)
+	assert.Empty(t, buf)
+
+	got, err := (&JSONLinesUnmarshaler{}).UnmarshalLogs(buf)
+	require.NoError(t, err)
+	assert.Equal(t, NewLogs(), got)
+}
+
+func TestJSONLinesUnmarshalInvalid(t *testing.T) {
+	_, err := (&JSONLinesUnmarshaler{}).UnmarshalLogs([]byte(logsJSON + "\n\n{\"resourceLogs\": 1}\n"))
+	assert.ErrorContains(t, err, "line 3: ")
+}
@@ -13,18 +13,33 @@ import (
 type Logs internal.Logs
 
 func newLogs(orig *otlpcollectorlog.ExportLogsServiceRequest) Logs {
-	return Logs(internal.NewLogs(orig))
+	state := internal.StateMutable
+	return Logs(internal.NewLogs(orig, &state))
 }
 
 func (ms Logs) getOrig() *otlpcollectorlog.ExportLogsServiceRequest {
 	return internal.GetOrigLogs(internal.Logs(ms))
 }
 
+func (ms Logs) getState() *internal.State {
+	return internal.GetLogsState(internal.Logs(ms))
+}
+
 // NewLogs creates a new Logs struct.
 func NewLogs() Logs {
 	return newLogs(&otlpcollectorlog.ExportLogsServiceRequest{})
 }
 
+// IsReadOnly returns true if this Logs instance is read-only.
+func (ms Logs) IsReadOnly() bool {
+	return *ms.getState() == internal.StateReadOnly
+}
+
+// MarkReadOnly marks the Logs as shared so that no further modifications can be done on it.
+func (ms Logs) MarkReadOnly() {
+	internal.SetLogsState(internal.Logs(ms), internal.StateReadOnly)
+}
+
 // CopyTo copies the Logs instance overriding the destination.
 func (ms Logs) CopyTo(dest Logs) {
 	ms.ResourceLogs().CopyTo(dest.ResourceLogs())
@@ -32,6 +47,9 @@ func (ms Logs) CopyTo(dest Logs) {
 
 // LogRecordCount calculates the total number of log records.
 func (ms Logs) LogRecordCount() int {
+	if count, ok := internal.LazyLogsLogRecordsCount(internal.Logs(ms)); ok {
+		return count
+	}
 	logCount := 0
 	rss := ms.ResourceLogs()
 	for i := 0; i < rss.Len(); i++ {
@@ -47,5 +65,5 @@ func (ms Logs) LogRecordCount() int {
 
 // ResourceLogs returns the ResourceLogsSlice associated with this Logs.
 func (ms Logs) ResourceLogs() ResourceLogsSlice {
-	return newResourceLogsSlice(&ms.getOrig().ResourceLogs)
+	return newResourceLogsSlice(&ms.getOrig().ResourceLogs, ms.getState())
 }
@@ -112,3 +112,24 @@ func TestLogsCopyTo(t *testing.T) {
 	logs.CopyTo(logsCopy)
 	assert.EqualValues(t, logs, logsCopy)
 }
+
+func TestReadOnlyLogs(t *testing.T) {
+	logs := NewLogs()
+	fillTestResourceLogsSlice(logs.ResourceLogs())
+	assert.False(t, logs.IsReadOnly())
+	logs.MarkReadOnly()
+	assert.True(t, logs.IsReadOnly())
+
+	// Reading is still allowed.
+	logsCopy := NewLogs()
+	logs.CopyTo(logsCopy)
+	assert.EqualValues(t, logs.getOrig(), logsCopy.getOrig())
+	assert.False(t, logsCopy.IsReadOnly())
+
+	rs := logs.ResourceLogs().At(0)
+	assert.Panics(t, func() { logs.ResourceLogs().AppendEmpty() })
+	assert.Panics(t, func() { rs.Resource().Attributes().PutStr("k", "v") })
+	assert.Panics(t, func() { rs.ScopeLogs().AppendEmpty() })
+	assert.Panics(t, func() { rs.ScopeLogs().At(0).LogRecords().At(0).SetSeverityText("info") })
+	assert.Panics(t, func() { logsCopy.CopyTo(logs) })
+}
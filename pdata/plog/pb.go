@@ -13,20 +13,36 @@ var _ MarshalSizer = (*ProtoMarshaler)(nil)
 type ProtoMarshaler struct{}
 
 func (e *ProtoMarshaler) MarshalLogs(ld Logs) ([]byte, error) {
+	if buf, ok := internal.LazyLogsBuffer(internal.Logs(ld)); ok {
+		return buf, nil
+	}
 	pb := internal.LogsToProto(internal.Logs(ld))
 	return pb.Marshal()
 }
 
 func (e *ProtoMarshaler) LogsSize(ld Logs) int {
+	if buf, ok := internal.LazyLogsBuffer(internal.Logs(ld)); ok {
+		return len(buf)
+	}
 	pb := internal.LogsToProto(internal.Logs(ld))
 	return pb.Size()
 }
 
 var _ Unmarshaler = (*ProtoUnmarshaler)(nil)
 
-type ProtoUnmarshaler struct{}
+type ProtoUnmarshaler struct {
+	// Lazy defers the decoding of the log records to the first access to the Logs. Only the framing of
+	// the log records is validated when unmarshaling, and buf is retained: it must not be modified.
+	// The Logs which are not accessed are marshaled again by returning buf as is, and their
+	// LogRecordCount is computed without decoding them, for the pipelines passing the data through.
+	Lazy bool
+}
 
 func (d *ProtoUnmarshaler) UnmarshalLogs(buf []byte) (Logs, error) {
+	if d.Lazy {
+		ld, err := internal.NewLazyLogs(buf)
+		return Logs(ld), err
+	}
 	pb := otlplogs.LogsData{}
 	err := pb.Unmarshal(buf)
 	return Logs(internal.LogsFromProto(pb)), err
@@ -37,6 +37,35 @@ func TestProtoSizerEmptyLogs(t *testing.T) {
 	assert.Equal(t, 0, sizer.LogsSize(NewLogs()))
 }
 
+func TestProtoLazyUnmarshaler(t *testing.T) {
+	marshaler := &ProtoMarshaler{}
+	ld := generateBenchmarkLogs(3)
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("key", "value")
+	buf, err := marshaler.MarshalLogs(ld)
+	require.NoError(t, err)
+
+	lazy, err := (&ProtoUnmarshaler{Lazy: true}).UnmarshalLogs(buf)
+	require.NoError(t, err)
+
+	// The logs passed through are neither decoded nor encoded again.
+	assert.Equal(t, 3, lazy.LogRecordCount())
+	assert.Equal(t, len(buf), marshaler.LogsSize(lazy))
+	got, err := marshaler.MarshalLogs(lazy)
+	require.NoError(t, err)
+	assert.Same(t, &buf[0], &got[0])
+
+	// The logs are decoded on first access.
+	assert.Equal(t, ld.ResourceLogs(), lazy.ResourceLogs())
+	lazy.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().AppendEmpty()
+	assert.Equal(t, 4, lazy.LogRecordCount())
+	got, err = marshaler.MarshalLogs(lazy)
+	require.NoError(t, err)
+	assert.Greater(t, len(got), len(buf))
+
+	_, err = (&ProtoUnmarshaler{Lazy: true}).UnmarshalLogs(buf[:len(buf)-1])
+	assert.Error(t, err)
+}
+
 func BenchmarkLogsToProto(b *testing.B) {
 	marshaler := &ProtoMarshaler{}
 	logs := generateBenchmarkLogs(128)
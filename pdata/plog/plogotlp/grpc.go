@@ -10,6 +10,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
 	"go.opentelemetry.io/collector/pdata/internal/otlp"
 )
@@ -28,18 +29,27 @@ type GRPCClient interface {
 	unexported()
 }
 
-// NewGRPCClient returns a new GRPCClient connected using the given connection.
-func NewGRPCClient(cc *grpc.ClientConn) GRPCClient {
-	return &grpcClient{rawClient: otlpcollectorlog.NewLogsServiceClient(cc)}
+// NewGRPCClient returns a new GRPCClient connected using the given connection, which can also
+// be a pool of connections such as the ones created by configgrpc.
+func NewGRPCClient(cc grpc.ClientConnInterface) GRPCClient {
+	return &grpcClient{cc: cc}
 }
 
+// exportMethod is the full name of the Export method of the LogsService, as invoked by the generated client.
+const exportMethod = "/opentelemetry.proto.collector.logs.v1.LogsService/Export"
+
 type grpcClient struct {
-	rawClient otlpcollectorlog.LogsServiceClient
+	cc grpc.ClientConnInterface
 }
 
+// Export implements the Client interface.
 func (c *grpcClient) Export(ctx context.Context, request ExportRequest, opts ...grpc.CallOption) (ExportResponse, error) {
-	rsp, err := c.rawClient.Export(ctx, request.orig, opts...)
-	return ExportResponse{orig: rsp}, err
+	rsp := &otlpcollectorlog.ExportLogsServiceResponse{}
+	if err := c.cc.Invoke(ctx, exportMethod, request.orig, rsp, opts...); err != nil {
+		return ExportResponse{}, err
+	}
+	state := internal.StateMutable
+	return ExportResponse{orig: rsp, state: &state}, nil
 }
 
 func (c *grpcClient) unexported() {}
@@ -79,6 +89,7 @@ type rawLogsServer struct {
 
 func (s rawLogsServer) Export(ctx context.Context, request *otlpcollectorlog.ExportLogsServiceRequest) (*otlpcollectorlog.ExportLogsServiceResponse, error) {
 	otlp.MigrateLogs(request.ResourceLogs)
-	rsp, err := s.srv.Export(ctx, ExportRequest{orig: request})
+	state := internal.StateMutable
+	rsp, err := s.srv.Export(ctx, ExportRequest{orig: request, state: &state})
 	return rsp.orig, err
 }
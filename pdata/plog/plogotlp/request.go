@@ -18,19 +18,24 @@ var jsonUnmarshaler = &plog.JSONUnmarshaler{}
 // ExportRequest represents the request for gRPC/HTTP client/server.
 // It's a wrapper for plog.Logs data.
 type ExportRequest struct {
-	orig *otlpcollectorlog.ExportLogsServiceRequest
+	orig  *otlpcollectorlog.ExportLogsServiceRequest
+	state *internal.State
 }
 
 // NewExportRequest returns an empty ExportRequest.
 func NewExportRequest() ExportRequest {
-	return ExportRequest{orig: &otlpcollectorlog.ExportLogsServiceRequest{}}
+	state := internal.StateMutable
+	return ExportRequest{orig: &otlpcollectorlog.ExportLogsServiceRequest{}, state: &state}
 }
 
 // NewExportRequestFromLogs returns a ExportRequest from plog.Logs.
 // Because ExportRequest is a wrapper for plog.Logs,
 // any changes to the provided Logs struct will be reflected in the ExportRequest and vice versa.
 func NewExportRequestFromLogs(ld plog.Logs) ExportRequest {
-	return ExportRequest{orig: internal.GetOrigLogs(internal.Logs(ld))}
+	return ExportRequest{
+		orig:  internal.GetOrigLogs(internal.Logs(ld)),
+		state: internal.GetLogsState(internal.Logs(ld)),
+	}
 }
 
 // MarshalProto marshals ExportRequest into proto bytes.
@@ -40,6 +45,7 @@ func (ms ExportRequest) MarshalProto() ([]byte, error) {
 
 // UnmarshalProto unmarshalls ExportRequest from proto bytes.
 func (ms ExportRequest) UnmarshalProto(data []byte) error {
+	ms.state.AssertMutable()
 	if err := ms.orig.Unmarshal(data); err != nil {
 		return err
 	}
@@ -58,6 +64,7 @@ func (ms ExportRequest) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON unmarshalls ExportRequest from JSON bytes.
 func (ms ExportRequest) UnmarshalJSON(data []byte) error {
+	ms.state.AssertMutable()
 	ld, err := jsonUnmarshaler.UnmarshalLogs(data)
 	if err != nil {
 		return err
@@ -67,5 +74,5 @@ func (ms ExportRequest) UnmarshalJSON(data []byte) error {
 }
 
 func (ms ExportRequest) Logs() plog.Logs {
-	return plog.Logs(internal.NewLogs(ms.orig))
+	return plog.Logs(internal.NewLogs(ms.orig, ms.state))
 }
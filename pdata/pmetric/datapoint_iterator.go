@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// DataPointFilter selects the data points visited by a DataPointIterator. The resources, scopes
+// and metrics are filtered before their data points are visited, so that the data points of the
+// ones which are not selected are skipped entirely. A nil function selects everything.
+type DataPointFilter struct {
+	// Resource selects the resources whose data points are visited.
+	Resource func(ResourceMetrics) bool
+	// Scope selects the scopes whose data points are visited.
+	Scope func(ScopeMetrics) bool
+	// Metric selects the metrics whose data points are visited.
+	Metric func(Metric) bool
+}
+
+// DataPointIterator visits the data points of Metrics, with their resource, scope and metric,
+// without nested loops over the resources, the scopes and the metrics.
+//
+// Example:
+//
+//	it := pmetric.NewDataPointIterator(md, pmetric.DataPointFilter{})
+//	for it.Next() {
+//	    name := it.Metric().Name()
+//	    attrs := it.Attributes()
+//	    ...
+//	}
+type DataPointIterator struct {
+	rms    ResourceMetricsSlice
+	filter DataPointFilter
+
+	// The indexes of the current resource, scope, metric and data point.
+	ri, si, mi, di int
+	// The number of scopes of the current resource, of metrics of the current scope,
+	// and of data points of the current metric.
+	scopesLen, metricsLen, dataPointsLen int
+
+	resource ResourceMetrics
+	scope    ScopeMetrics
+	metric   Metric
+}
+
+// NewDataPointIterator returns an iterator over the data points of md selected by the filter.
+// Next must be called to visit the first data point.
+func NewDataPointIterator(md Metrics, filter DataPointFilter) *DataPointIterator {
+	return &DataPointIterator{
+		rms:    md.ResourceMetrics(),
+		filter: filter,
+		ri:     -1,
+	}
+}
+
+// Next advances the iterator to the next data point, and returns false when all the
+// data points were visited.
+func (it *DataPointIterator) Next() bool {
+	it.di++
+	for it.di >= it.dataPointsLen {
+		if !it.nextMetric() {
+			return false
+		}
+	}
+	return true
+}
+
+// nextMetric advances the iterator to the next selected metric, and returns false if there is none.
+func (it *DataPointIterator) nextMetric() bool {
+	it.di, it.dataPointsLen = 0, 0
+	for {
+		for it.mi+1 < it.metricsLen {
+			it.mi++
+			it.metric = it.scope.Metrics().At(it.mi)
+			if it.filter.Metric == nil || it.filter.Metric(it.metric) {
+				it.dataPointsLen = dataPointsLen(it.metric)
+				return true
+			}
+		}
+		if !it.nextScope() {
+			return false
+		}
+	}
+}
+
+// nextScope advances the iterator to the next selected scope, and returns false if there is none.
+func (it *DataPointIterator) nextScope() bool {
+	for {
+		for it.si+1 < it.scopesLen {
+			it.si++
+			it.scope = it.resource.ScopeMetrics().At(it.si)
+			if it.filter.Scope == nil || it.filter.Scope(it.scope) {
+				it.mi, it.metricsLen = -1, it.scope.Metrics().Len()
+				return true
+			}
+		}
+		if !it.nextResource() {
+			return false
+		}
+	}
+}
+
+// nextResource advances the iterator to the next selected resource, and returns false if there is none.
+func (it *DataPointIterator) nextResource() bool {
+	for it.ri+1 < it.rms.Len() {
+		it.ri++
+		it.resource = it.rms.At(it.ri)
+		if it.filter.Resource == nil || it.filter.Resource(it.resource) {
+			it.si, it.scopesLen = -1, it.resource.ScopeMetrics().Len()
+			return true
+		}
+	}
+	return false
+}
+
+func dataPointsLen(m Metric) int {
+	switch m.Type() {
+	case MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case MetricTypeSummary:
+		return m.Summary().DataPoints().Len()
+	}
+	return 0
+}
+
+// ResourceMetrics returns the resource of the current data point.
+func (it *DataPointIterator) ResourceMetrics() ResourceMetrics {
+	return it.resource
+}
+
+// ScopeMetrics returns the scope of the current data point.
+func (it *DataPointIterator) ScopeMetrics() ScopeMetrics {
+	return it.scope
+}
+
+// Metric returns the metric of the current data point.
+func (it *DataPointIterator) Metric() Metric {
+	return it.metric
+}
+
+// Index returns the index of the current data point in the data points of its metric.
+func (it *DataPointIterator) Index() int {
+	return it.di
+}
+
+// NumberDataPoint returns the current data point of a gauge or of a sum.
+// Calling this function for another type of metric will cause a panic.
+func (it *DataPointIterator) NumberDataPoint() NumberDataPoint {
+	switch it.metric.Type() {
+	case MetricTypeGauge:
+		return it.metric.Gauge().DataPoints().At(it.di)
+	case MetricTypeSum:
+		return it.metric.Sum().DataPoints().At(it.di)
+	}
+	panic("the metric of the data point is not a gauge or a sum")
+}
+
+// HistogramDataPoint returns the current data point of a histogram.
+// Calling this function for another type of metric will cause a panic.
+func (it *DataPointIterator) HistogramDataPoint() HistogramDataPoint {
+	return it.metric.Histogram().DataPoints().At(it.di)
+}
+
+// ExponentialHistogramDataPoint returns the current data point of an exponential histogram.
+// Calling this function for another type of metric will cause a panic.
+func (it *DataPointIterator) ExponentialHistogramDataPoint() ExponentialHistogramDataPoint {
+	return it.metric.ExponentialHistogram().DataPoints().At(it.di)
+}
+
+// SummaryDataPoint returns the current data point of a summary.
+// Calling this function for another type of metric will cause a panic.
+func (it *DataPointIterator) SummaryDataPoint() SummaryDataPoint {
+	return it.metric.Summary().DataPoints().At(it.di)
+}
+
+// Attributes returns the attributes of the current data point, whatever the type of its metric.
+func (it *DataPointIterator) Attributes() pcommon.Map {
+	switch it.metric.Type() {
+	case MetricTypeHistogram:
+		return it.HistogramDataPoint().Attributes()
+	case MetricTypeExponentialHistogram:
+		return it.ExponentialHistogramDataPoint().Attributes()
+	case MetricTypeSummary:
+		return it.SummaryDataPoint().Attributes()
+	}
+	return it.NumberDataPoint().Attributes()
+}
+
+// Timestamp returns the timestamp of the current data point, whatever the type of its metric.
+func (it *DataPointIterator) Timestamp() pcommon.Timestamp {
+	switch it.metric.Type() {
+	case MetricTypeHistogram:
+		return it.HistogramDataPoint().Timestamp()
+	case MetricTypeExponentialHistogram:
+		return it.ExponentialHistogramDataPoint().Timestamp()
+	case MetricTypeSummary:
+		return it.SummaryDataPoint().Timestamp()
+	}
+	return it.NumberDataPoint().Timestamp()
+}
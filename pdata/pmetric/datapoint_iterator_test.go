@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func generateIteratorMetrics() Metrics {
+	md := NewMetrics()
+	for r := 0; r < 3; r++ {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutInt("resource", int64(r))
+		// The second resource has no scopes, the first scope of each resource has no metrics.
+		if r == 1 {
+			continue
+		}
+		rm.ScopeMetrics().AppendEmpty()
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName(fmt.Sprintf("scope%d", r))
+
+		gauge := sm.Metrics().AppendEmpty()
+		gauge.SetName("gauge")
+		gauge.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutStr("dp", "g0")
+		gauge.Gauge().DataPoints().AppendEmpty().Attributes().PutStr("dp", "g1")
+
+		sum := sm.Metrics().AppendEmpty()
+		sum.SetName("sum")
+		sum.SetEmptySum()
+
+		histogram := sm.Metrics().AppendEmpty()
+		histogram.SetName("histogram")
+		histogram.SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().PutStr("dp", "h0")
+
+		expHistogram := sm.Metrics().AppendEmpty()
+		expHistogram.SetName("exponential_histogram")
+		expHistogram.SetEmptyExponentialHistogram().DataPoints().AppendEmpty().Attributes().PutStr("dp", "e0")
+
+		summary := sm.Metrics().AppendEmpty()
+		summary.SetName("summary")
+		summary.SetEmptySummary().DataPoints().AppendEmpty().Attributes().PutStr("dp", "s0")
+
+		sm.Metrics().AppendEmpty().SetName("empty")
+	}
+	return md
+}
+
+func visitDataPoints(it *DataPointIterator) []string {
+	var visited []string
+	for it.Next() {
+		resource, _ := it.ResourceMetrics().Resource().Attributes().Get("resource")
+		dp, _ := it.Attributes().Get("dp")
+		visited = append(visited, fmt.Sprintf("%d/%s/%s/%d:%s", resource.Int(), it.ScopeMetrics().Scope().Name(), it.Metric().Name(), it.Index(), dp.Str()))
+	}
+	return visited
+}
+
+func TestDataPointIterator(t *testing.T) {
+	md := generateIteratorMetrics()
+	it := NewDataPointIterator(md, DataPointFilter{})
+	assert.Equal(t, []string{
+		"0/scope0/gauge/0:g0",
+		"0/scope0/gauge/1:g1",
+		"0/scope0/histogram/0:h0",
+		"0/scope0/exponential_histogram/0:e0",
+		"0/scope0/summary/0:s0",
+		"2/scope2/gauge/0:g0",
+		"2/scope2/gauge/1:g1",
+		"2/scope2/histogram/0:h0",
+		"2/scope2/exponential_histogram/0:e0",
+		"2/scope2/summary/0:s0",
+	}, visitDataPoints(it))
+	assert.Equal(t, 10, md.DataPointCount())
+	assert.False(t, it.Next())
+
+	assert.False(t, NewDataPointIterator(NewMetrics(), DataPointFilter{}).Next())
+}
+
+func TestDataPointIteratorFilter(t *testing.T) {
+	md := generateIteratorMetrics()
+	assert.Equal(t, []string{
+		"2/scope2/gauge/0:g0",
+		"2/scope2/gauge/1:g1",
+		"2/scope2/summary/0:s0",
+	}, visitDataPoints(NewDataPointIterator(md, DataPointFilter{
+		Resource: func(rm ResourceMetrics) bool {
+			v, _ := rm.Resource().Attributes().Get("resource")
+			return v.Int() == 2
+		},
+		Scope: func(sm ScopeMetrics) bool {
+			return sm.Scope().Name() != ""
+		},
+		Metric: func(m Metric) bool {
+			return m.Type() == MetricTypeGauge || m.Type() == MetricTypeSummary
+		},
+	})))
+
+	assert.Empty(t, visitDataPoints(NewDataPointIterator(md, DataPointFilter{
+		Scope: func(sm ScopeMetrics) bool { return false },
+	})))
+}
+
+func TestDataPointIteratorDataPoints(t *testing.T) {
+	md := NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	sum := ms.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty()
+	sum.SetIntValue(1)
+	sum.SetTimestamp(1)
+	ms.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty().SetTimestamp(2)
+	ms.AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty().SetTimestamp(3)
+	ms.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty().SetTimestamp(4)
+
+	it := NewDataPointIterator(md, DataPointFilter{})
+	assert.True(t, it.Next())
+	assert.Equal(t, int64(1), it.NumberDataPoint().IntValue())
+	assert.EqualValues(t, 1, it.Timestamp())
+	assert.True(t, it.Next())
+	assert.EqualValues(t, 2, it.HistogramDataPoint().Timestamp())
+	assert.EqualValues(t, 2, it.Timestamp())
+	assert.Panics(t, func() { it.NumberDataPoint() })
+	assert.True(t, it.Next())
+	assert.EqualValues(t, 3, it.ExponentialHistogramDataPoint().Timestamp())
+	assert.EqualValues(t, 3, it.Timestamp())
+	assert.True(t, it.Next())
+	assert.EqualValues(t, 4, it.SummaryDataPoint().Timestamp())
+	assert.EqualValues(t, 4, it.Timestamp())
+	assert.False(t, it.Next())
+}
+
+func BenchmarkDataPointIterator(b *testing.B) {
+	md := generateIteratorMetrics()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		it := NewDataPointIterator(md, DataPointFilter{})
+		for it.Next() {
+			_ = it.Attributes().Len()
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"go.opentelemetry.io/collector/pdata/internal"
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
 )
 
@@ -20,6 +21,9 @@ func TestMetric_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.Equal(t, NewMetric(), ms)
 	assert.Equal(t, generateTestMetric(), dest)
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newMetric(&otlpmetrics.Metric{}, &sharedState)) })
+	assert.Panics(t, func() { newMetric(&otlpmetrics.Metric{}, &sharedState).MoveTo(dest) })
 }
 
 func TestMetric_CopyTo(t *testing.T) {
@@ -30,6 +34,8 @@ func TestMetric_CopyTo(t *testing.T) {
 	orig = generateTestMetric()
 	orig.CopyTo(ms)
 	assert.Equal(t, orig, ms)
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newMetric(&otlpmetrics.Metric{}, &sharedState)) })
 }
 
 func TestMetric_Name(t *testing.T) {
@@ -37,6 +43,8 @@ func TestMetric_Name(t *testing.T) {
 	assert.Equal(t, "", ms.Name())
 	ms.SetName("test_name")
 	assert.Equal(t, "test_name", ms.Name())
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { newMetric(&otlpmetrics.Metric{}, &sharedState).SetName("test_name") })
 }
 
 func TestMetric_Description(t *testing.T) {
@@ -44,6 +52,8 @@ func TestMetric_Description(t *testing.T) {
 	assert.Equal(t, "", ms.Description())
 	ms.SetDescription("test_description")
 	assert.Equal(t, "test_description", ms.Description())
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { newMetric(&otlpmetrics.Metric{}, &sharedState).SetDescription("test_description") })
 }
 
 func TestMetric_Unit(t *testing.T) {
@@ -51,6 +61,8 @@ func TestMetric_Unit(t *testing.T) {
 	assert.Equal(t, "", ms.Unit())
 	ms.SetUnit("1")
 	assert.Equal(t, "1", ms.Unit())
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { newMetric(&otlpmetrics.Metric{}, &sharedState).SetUnit("1") })
 }
 
 func TestMetric_Type(t *testing.T) {
@@ -63,6 +75,8 @@ func TestMetric_Gauge(t *testing.T) {
 	fillTestGauge(ms.SetEmptyGauge())
 	assert.Equal(t, MetricTypeGauge, ms.Type())
 	assert.Equal(t, generateTestGauge(), ms.Gauge())
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { newMetric(&otlpmetrics.Metric{}, &sharedState).SetEmptyGauge() })
 }
 
 func TestMetric_CopyTo_Gauge(t *testing.T) {
@@ -71,6 +85,8 @@ func TestMetric_CopyTo_Gauge(t *testing.T) {
 	dest := NewMetric()
 	ms.CopyTo(dest)
 	assert.Equal(t, ms, dest)
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newMetric(&otlpmetrics.Metric{}, &sharedState)) })
 }
 
 func TestMetric_Sum(t *testing.T) {
@@ -78,6 +94,8 @@ func TestMetric_Sum(t *testing.T) {
 	fillTestSum(ms.SetEmptySum())
 	assert.Equal(t, MetricTypeSum, ms.Type())
 	assert.Equal(t, generateTestSum(), ms.Sum())
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { newMetric(&otlpmetrics.Metric{}, &sharedState).SetEmptySum() })
 }
 
 func TestMetric_CopyTo_Sum(t *testing.T) {
@@ -86,6 +104,8 @@ func TestMetric_CopyTo_Sum(t *testing.T) {
 	dest := NewMetric()
 	ms.CopyTo(dest)
 	assert.Equal(t, ms, dest)
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newMetric(&otlpmetrics.Metric{}, &sharedState)) })
 }
 
 func TestMetric_Histogram(t *testing.T) {
@@ -93,6 +113,8 @@ func TestMetric_Histogram(t *testing.T) {
 	fillTestHistogram(ms.SetEmptyHistogram())
 	assert.Equal(t, MetricTypeHistogram, ms.Type())
 	assert.Equal(t, generateTestHistogram(), ms.Histogram())
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { newMetric(&otlpmetrics.Metric{}, &sharedState).SetEmptyHistogram() })
 }
 
 func TestMetric_CopyTo_Histogram(t *testing.T) {
@@ -101,6 +123,8 @@ func TestMetric_CopyTo_Histogram(t *testing.T) {
 	dest := NewMetric()
 	ms.CopyTo(dest)
 	assert.Equal(t, ms, dest)
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newMetric(&otlpmetrics.Metric{}, &sharedState)) })
 }
 
 func TestMetric_ExponentialHistogram(t *testing.T) {
@@ -108,6 +132,8 @@ func TestMetric_ExponentialHistogram(t *testing.T) {
 	fillTestExponentialHistogram(ms.SetEmptyExponentialHistogram())
 	assert.Equal(t, MetricTypeExponentialHistogram, ms.Type())
 	assert.Equal(t, generateTestExponentialHistogram(), ms.ExponentialHistogram())
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { newMetric(&otlpmetrics.Metric{}, &sharedState).SetEmptyExponentialHistogram() })
 }
 
 func TestMetric_CopyTo_ExponentialHistogram(t *testing.T) {
@@ -116,6 +142,8 @@ func TestMetric_CopyTo_ExponentialHistogram(t *testing.T) {
 	dest := NewMetric()
 	ms.CopyTo(dest)
 	assert.Equal(t, ms, dest)
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newMetric(&otlpmetrics.Metric{}, &sharedState)) })
 }
 
 func TestMetric_Summary(t *testing.T) {
@@ -123,6 +151,8 @@ func TestMetric_Summary(t *testing.T) {
 	fillTestSummary(ms.SetEmptySummary())
 	assert.Equal(t, MetricTypeSummary, ms.Type())
 	assert.Equal(t, generateTestSummary(), ms.Summary())
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { newMetric(&otlpmetrics.Metric{}, &sharedState).SetEmptySummary() })
 }
 
 func TestMetric_CopyTo_Summary(t *testing.T) {
@@ -131,6 +161,8 @@ func TestMetric_CopyTo_Summary(t *testing.T) {
 	dest := NewMetric()
 	ms.CopyTo(dest)
 	assert.Equal(t, ms, dest)
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newMetric(&otlpmetrics.Metric{}, &sharedState)) })
 }
 
 func generateTestMetric() Metric {
@@ -144,5 +176,5 @@ func fillTestMetric(tv Metric) {
 	tv.orig.Description = "test_description"
 	tv.orig.Unit = "1"
 	tv.orig.Data = &otlpmetrics.Metric_Sum{Sum: &otlpmetrics.Sum{}}
-	fillTestSum(newSum(tv.orig.GetSum()))
+	fillTestSum(newSum(tv.orig.GetSum(), tv.state))
 }
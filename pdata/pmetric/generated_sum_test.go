@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"go.opentelemetry.io/collector/pdata/internal"
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
 )
 
@@ -20,6 +21,9 @@ func TestSum_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.Equal(t, NewSum(), ms)
 	assert.Equal(t, generateTestSum(), dest)
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newSum(&otlpmetrics.Sum{}, &sharedState)) })
+	assert.Panics(t, func() { newSum(&otlpmetrics.Sum{}, &sharedState).MoveTo(dest) })
 }
 
 func TestSum_CopyTo(t *testing.T) {
@@ -30,6 +34,8 @@ func TestSum_CopyTo(t *testing.T) {
 	orig = generateTestSum()
 	orig.CopyTo(ms)
 	assert.Equal(t, orig, ms)
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newSum(&otlpmetrics.Sum{}, &sharedState)) })
 }
 
 func TestSum_AggregationTemporality(t *testing.T) {
@@ -45,6 +51,8 @@ func TestSum_IsMonotonic(t *testing.T) {
 	assert.Equal(t, false, ms.IsMonotonic())
 	ms.SetIsMonotonic(true)
 	assert.Equal(t, true, ms.IsMonotonic())
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { newSum(&otlpmetrics.Sum{}, &sharedState).SetIsMonotonic(true) })
 }
 
 func TestSum_DataPoints(t *testing.T) {
@@ -63,5 +71,5 @@ func generateTestSum() Sum {
 func fillTestSum(tv Sum) {
 	tv.orig.AggregationTemporality = otlpmetrics.AggregationTemporality(1)
 	tv.orig.IsMonotonic = true
-	fillTestNumberDataPointSlice(newNumberDataPointSlice(&tv.orig.DataPoints))
+	fillTestNumberDataPointSlice(newNumberDataPointSlice(&tv.orig.DataPoints, tv.state))
 }
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"bytes"
+	"fmt"
+
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/internal/json"
+)
+
+var _ Marshaler = (*JSONLinesMarshaler)(nil)
+
+// JSONLinesMarshaler marshals Metrics into newline-delimited OTLP JSON, also known as JSON Lines:
+// each line is the OTLP JSON MetricsData of one resource, so that the metrics can be appended to
+// a file and read back one resource at a time.
+type JSONLinesMarshaler struct{}
+
+func (*JSONLinesMarshaler) MarshalMetrics(md Metrics) ([]byte, error) {
+	buf := bytes.Buffer{}
+	rms := md.getOrig().ResourceMetrics
+	for i := range rms {
+		pb := otlpmetrics.MetricsData{ResourceMetrics: rms[i : i+1]}
+		if err := json.Marshal(&buf, &pb); err != nil {
+			return nil, err
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+var _ Unmarshaler = (*JSONLinesUnmarshaler)(nil)
+
+// JSONLinesUnmarshaler unmarshals newline-delimited OTLP JSON into Metrics. Each line holds an OTLP
+// JSON MetricsData, the resources of all the lines are merged, the empty lines are skipped.
+type JSONLinesUnmarshaler struct{}
+
+func (*JSONLinesUnmarshaler) UnmarshalMetrics(buf []byte) (Metrics, error) {
+	md := NewMetrics()
+	unmarshaler := JSONUnmarshaler{}
+	for lineNum := 1; len(buf) > 0; lineNum++ {
+		line := buf
+		if i := bytes.IndexByte(buf, '\n'); i >= 0 {
+			line, buf = buf[:i], buf[i+1:]
+		} else {
+			buf = nil
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		lineData, err := unmarshaler.UnmarshalMetrics(line)
+		if err != nil {
+			return Metrics{}, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		lineData.ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+	}
+	return md, nil
+}
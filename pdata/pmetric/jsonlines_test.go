@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLinesRoundTrip(t *testing.T) {
+	md := NewMetrics()
+	metricsOTLP.ResourceMetrics().CopyTo(md.ResourceMetrics())
+	metricsOTLP.ResourceMetrics().At(0).CopyTo(md.ResourceMetrics().AppendEmpty())
+	md.ResourceMetrics().At(1).Resource().Attributes().PutStr("host.name", "otherHost")
+
+	buf, err := (&JSONLinesMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	lines := bytes.Split(bytes.TrimSuffix(buf, []byte("\n")), []byte("\n"))
+	require.Len(t, lines, 2)
+	assert.Equal(t, metricsJSON, string(lines[0]))
+
+	// The empty lines are skipped and the resources of all the lines are merged.
+	got, err := (&JSONLinesUnmarshaler{}).UnmarshalMetrics(append([]byte("\n"), buf...))
+	require.NoError(t, err)
+	assert.Equal(t, md, got)
+}
+
+func TestJSONLinesMarshalEmpty(t *testing.T) {
+	buf, err := (&JSONLinesMarshaler{}).MarshalMetrics(NewMetrics())
+	require.NoError(t, err)
+	assert.Empty(t, buf)
+
+	got, err := (&JSONLinesUnmarshaler{}).UnmarshalMetrics(buf)
+	require.NoError(t, err)
+	assert.Equal(t, NewMetrics(), got)
+}
+
+func TestJSONLinesUnmarshalInvalid(t *testing.T) {
+	_, err := (&JSONLinesUnmarshaler{}).UnmarshalMetrics([]byte(metricsJSON + "\n\n{\"resourceMetrics\": 1}\n"))
+	assert.ErrorContains(t, err, "line 3: ")
+}
@@ -13,18 +13,33 @@ import (
 type Metrics internal.Metrics
 
 func newMetrics(orig *otlpcollectormetrics.ExportMetricsServiceRequest) Metrics {
-	return Metrics(internal.NewMetrics(orig))
+	state := internal.StateMutable
+	return Metrics(internal.NewMetrics(orig, &state))
 }
 
 func (ms Metrics) getOrig() *otlpcollectormetrics.ExportMetricsServiceRequest {
 	return internal.GetOrigMetrics(internal.Metrics(ms))
 }
 
+func (ms Metrics) getState() *internal.State {
+	return internal.GetMetricsState(internal.Metrics(ms))
+}
+
 // NewMetrics creates a new Metrics struct.
 func NewMetrics() Metrics {
 	return newMetrics(&otlpcollectormetrics.ExportMetricsServiceRequest{})
 }
 
+// IsReadOnly returns true if this Metrics instance is read-only.
+func (ms Metrics) IsReadOnly() bool {
+	return *ms.getState() == internal.StateReadOnly
+}
+
+// MarkReadOnly marks the Metrics as shared so that no further modifications can be done on it.
+func (ms Metrics) MarkReadOnly() {
+	internal.SetMetricsState(internal.Metrics(ms), internal.StateReadOnly)
+}
+
 // CopyTo copies the Metrics instance overriding the destination.
 func (ms Metrics) CopyTo(dest Metrics) {
 	ms.ResourceMetrics().CopyTo(dest.ResourceMetrics())
@@ -32,7 +47,7 @@ func (ms Metrics) CopyTo(dest Metrics) {
 
 // ResourceMetrics returns the ResourceMetricsSlice associated with this Metrics.
 func (ms Metrics) ResourceMetrics() ResourceMetricsSlice {
-	return newResourceMetricsSlice(&ms.getOrig().ResourceMetrics)
+	return newResourceMetricsSlice(&ms.getOrig().ResourceMetrics, ms.getState())
 }
 
 // MetricCount calculates the total number of metrics.
@@ -52,6 +67,9 @@ func (ms Metrics) MetricCount() int {
 
 // DataPointCount calculates the total number of data points.
 func (ms Metrics) DataPointCount() (dataPointCount int) {
+	if count, ok := internal.LazyMetricsDataPointsCount(internal.Metrics(ms)); ok {
+		return count
+	}
 	rms := ms.ResourceMetrics()
 	for i := 0; i < rms.Len(); i++ {
 		rm := rms.At(i)
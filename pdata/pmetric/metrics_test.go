@@ -635,6 +635,27 @@ func TestMetricsCopyTo(t *testing.T) {
 	assert.EqualValues(t, metrics, metricsCopy)
 }
 
+func TestReadOnlyMetrics(t *testing.T) {
+	metrics := NewMetrics()
+	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
+	assert.False(t, metrics.IsReadOnly())
+	metrics.MarkReadOnly()
+	assert.True(t, metrics.IsReadOnly())
+
+	// Reading is still allowed.
+	metricsCopy := NewMetrics()
+	metrics.CopyTo(metricsCopy)
+	assert.EqualValues(t, metrics.getOrig(), metricsCopy.getOrig())
+	assert.False(t, metricsCopy.IsReadOnly())
+
+	rs := metrics.ResourceMetrics().At(0)
+	assert.Panics(t, func() { metrics.ResourceMetrics().AppendEmpty() })
+	assert.Panics(t, func() { rs.Resource().Attributes().PutStr("k", "v") })
+	assert.Panics(t, func() { rs.ScopeMetrics().AppendEmpty() })
+	assert.Panics(t, func() { rs.ScopeMetrics().At(0).Metrics().At(0).SetName("metric") })
+	assert.Panics(t, func() { metricsCopy.CopyTo(metrics) })
+}
+
 func BenchmarkOtlpToFromInternal_PassThrough(b *testing.B) {
 	req := &otlpcollectormetrics.ExportMetricsServiceRequest{
 		ResourceMetrics: []*otlpmetrics.ResourceMetrics{
@@ -13,18 +13,34 @@ var _ MarshalSizer = (*ProtoMarshaler)(nil)
 type ProtoMarshaler struct{}
 
 func (e *ProtoMarshaler) MarshalMetrics(md Metrics) ([]byte, error) {
+	if buf, ok := internal.LazyMetricsBuffer(internal.Metrics(md)); ok {
+		return buf, nil
+	}
 	pb := internal.MetricsToProto(internal.Metrics(md))
 	return pb.Marshal()
 }
 
 func (e *ProtoMarshaler) MetricsSize(md Metrics) int {
+	if buf, ok := internal.LazyMetricsBuffer(internal.Metrics(md)); ok {
+		return len(buf)
+	}
 	pb := internal.MetricsToProto(internal.Metrics(md))
 	return pb.Size()
 }
 
-type ProtoUnmarshaler struct{}
+type ProtoUnmarshaler struct {
+	// Lazy defers the decoding of the data points to the first access to the Metrics. Only the framing of
+	// the data points is validated when unmarshaling, and buf is retained: it must not be modified.
+	// The Metrics which are not accessed are marshaled again by returning buf as is, and their
+	// DataPointCount is computed without decoding them, for the pipelines passing the data through.
+	Lazy bool
+}
 
 func (d *ProtoUnmarshaler) UnmarshalMetrics(buf []byte) (Metrics, error) {
+	if d.Lazy {
+		md, err := internal.NewLazyMetrics(buf)
+		return Metrics(md), err
+	}
 	pb := otlpmetrics.MetricsData{}
 	err := pb.Unmarshal(buf)
 	return Metrics(internal.MetricsFromProto(pb)), err
@@ -36,6 +36,43 @@ func TestProtoSizerEmptyMetrics(t *testing.T) {
 	assert.Equal(t, 0, sizer.MetricsSize(NewMetrics()))
 }
 
+func TestProtoLazyUnmarshaler(t *testing.T) {
+	marshaler := &ProtoMarshaler{}
+	md := generateBenchmarkMetrics(2)
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	ms.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(1)
+	ms.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty().SetCount(1)
+	ms.AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty().SetCount(1)
+	summary := ms.AppendEmpty().SetEmptySummary().DataPoints()
+	summary.AppendEmpty().SetCount(1)
+	summary.AppendEmpty().SetCount(2)
+	buf, err := marshaler.MarshalMetrics(md)
+	require.NoError(t, err)
+
+	lazy, err := (&ProtoUnmarshaler{Lazy: true}).UnmarshalMetrics(buf)
+	require.NoError(t, err)
+
+	// The metrics passed through are neither decoded nor encoded again.
+	assert.Equal(t, 7, lazy.DataPointCount())
+	assert.Equal(t, len(buf), marshaler.MetricsSize(lazy))
+	got, err := marshaler.MarshalMetrics(lazy)
+	require.NoError(t, err)
+	assert.Same(t, &buf[0], &got[0])
+
+	// The metrics are decoded on first access.
+	assert.Equal(t, md.ResourceMetrics(), lazy.ResourceMetrics())
+	lazy.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().RemoveIf(func(m Metric) bool {
+		return m.Type() == MetricTypeSummary
+	})
+	assert.Equal(t, 5, lazy.DataPointCount())
+	got, err = marshaler.MarshalMetrics(lazy)
+	require.NoError(t, err)
+	assert.Less(t, len(got), len(buf))
+
+	_, err = (&ProtoUnmarshaler{Lazy: true}).UnmarshalMetrics(buf[:len(buf)-1])
+	assert.Error(t, err)
+}
+
 func BenchmarkMetricsToProto(b *testing.B) {
 	marshaler := &ProtoMarshaler{}
 	metrics := generateBenchmarkMetrics(128)
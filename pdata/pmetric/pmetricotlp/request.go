@@ -17,19 +17,24 @@ var jsonUnmarshaler = &pmetric.JSONUnmarshaler{}
 // ExportRequest represents the request for gRPC/HTTP client/server.
 // It's a wrapper for pmetric.Metrics data.
 type ExportRequest struct {
-	orig *otlpcollectormetrics.ExportMetricsServiceRequest
+	orig  *otlpcollectormetrics.ExportMetricsServiceRequest
+	state *internal.State
 }
 
 // NewExportRequest returns an empty ExportRequest.
 func NewExportRequest() ExportRequest {
-	return ExportRequest{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{}}
+	state := internal.StateMutable
+	return ExportRequest{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{}, state: &state}
 }
 
 // NewExportRequestFromMetrics returns a ExportRequest from pmetric.Metrics.
 // Because ExportRequest is a wrapper for pmetric.Metrics,
 // any changes to the provided Metrics struct will be reflected in the ExportRequest and vice versa.
 func NewExportRequestFromMetrics(md pmetric.Metrics) ExportRequest {
-	return ExportRequest{orig: internal.GetOrigMetrics(internal.Metrics(md))}
+	return ExportRequest{
+		orig:  internal.GetOrigMetrics(internal.Metrics(md)),
+		state: internal.GetMetricsState(internal.Metrics(md)),
+	}
 }
 
 // MarshalProto marshals ExportRequest into proto bytes.
@@ -39,6 +44,7 @@ func (ms ExportRequest) MarshalProto() ([]byte, error) {
 
 // UnmarshalProto unmarshalls ExportRequest from proto bytes.
 func (ms ExportRequest) UnmarshalProto(data []byte) error {
+	ms.state.AssertMutable()
 	return ms.orig.Unmarshal(data)
 }
 
@@ -53,6 +59,7 @@ func (ms ExportRequest) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON unmarshalls ExportRequest from JSON bytes.
 func (ms ExportRequest) UnmarshalJSON(data []byte) error {
+	ms.state.AssertMutable()
 	md, err := jsonUnmarshaler.UnmarshalMetrics(data)
 	if err != nil {
 		return err
@@ -62,5 +69,5 @@ func (ms ExportRequest) UnmarshalJSON(data []byte) error {
 }
 
 func (ms ExportRequest) Metrics() pmetric.Metrics {
-	return pmetric.Metrics(internal.NewMetrics(ms.orig))
+	return pmetric.Metrics(internal.NewMetrics(ms.orig, ms.state))
 }
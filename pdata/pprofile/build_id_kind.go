@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofile // import "go.opentelemetry.io/collector/pdata/pprofile"
+
+import (
+	otlpprofiles "go.opentelemetry.io/collector/pdata/internal/data/protogen/profiles/v1experimental"
+)
+
+// BuildIDKind indicates the semantics of the build ID of a Mapping.
+type BuildIDKind int32
+
+const (
+	// BuildIDKindLinker is a BuildIDKind for a linker-generated build ID, stored in the ELF binary notes.
+	BuildIDKindLinker = BuildIDKind(otlpprofiles.BuildIdKind_BUILD_ID_LINKER)
+	// BuildIDKindBinaryHash is a BuildIDKind for a build ID based on the content hash of the binary.
+	BuildIDKindBinaryHash = BuildIDKind(otlpprofiles.BuildIdKind_BUILD_ID_BINARY_HASH)
+)
+
+// String returns the string representation of the BuildIDKind.
+func (k BuildIDKind) String() string {
+	switch k {
+	case BuildIDKindLinker:
+		return "Linker"
+	case BuildIDKindBinaryHash:
+		return "BinaryHash"
+	}
+	return ""
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package pprofile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpprofiles "go.opentelemetry.io/collector/pdata/internal/data/protogen/profiles/v1experimental"
+)
+
+func TestSample_MoveTo(t *testing.T) {
+	ms := generateTestSample()
+	dest := NewSample()
+	ms.MoveTo(dest)
+	assert.Equal(t, NewSample(), ms)
+	assert.Equal(t, generateTestSample(), dest)
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newSample(&otlpprofiles.Sample{}, &sharedState)) })
+	assert.Panics(t, func() { newSample(&otlpprofiles.Sample{}, &sharedState).MoveTo(dest) })
+}
+
+func TestSample_CopyTo(t *testing.T) {
+	ms := NewSample()
+	orig := NewSample()
+	orig.CopyTo(ms)
+	assert.Equal(t, orig, ms)
+	orig = generateTestSample()
+	orig.CopyTo(ms)
+	assert.Equal(t, orig, ms)
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newSample(&otlpprofiles.Sample{}, &sharedState)) })
+}
+
+func TestSample_LocationIndex(t *testing.T) {
+	ms := NewSample()
+	assert.Equal(t, []uint64(nil), ms.LocationIndex().AsRaw())
+	ms.LocationIndex().FromRaw([]uint64{1, 2, 3})
+	assert.Equal(t, []uint64{1, 2, 3}, ms.LocationIndex().AsRaw())
+}
+
+func TestSample_LocationsStartIndex(t *testing.T) {
+	ms := NewSample()
+	assert.Equal(t, uint64(0), ms.LocationsStartIndex())
+	ms.SetLocationsStartIndex(uint64(1))
+	assert.Equal(t, uint64(1), ms.LocationsStartIndex())
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { newSample(&otlpprofiles.Sample{}, &sharedState).SetLocationsStartIndex(uint64(1)) })
+}
+
+func TestSample_LocationsLength(t *testing.T) {
+	ms := NewSample()
+	assert.Equal(t, uint64(0), ms.LocationsLength())
+	ms.SetLocationsLength(uint64(3))
+	assert.Equal(t, uint64(3), ms.LocationsLength())
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { newSample(&otlpprofiles.Sample{}, &sharedState).SetLocationsLength(uint64(3)) })
+}
+
+func TestSample_StacktraceIDIndex(t *testing.T) {
+	ms := NewSample()
+	assert.Equal(t, uint32(0), ms.StacktraceIDIndex())
+	ms.SetStacktraceIDIndex(uint32(1))
+	assert.Equal(t, uint32(1), ms.StacktraceIDIndex())
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { newSample(&otlpprofiles.Sample{}, &sharedState).SetStacktraceIDIndex(uint32(1)) })
+}
+
+func TestSample_Value(t *testing.T) {
+	ms := NewSample()
+	assert.Equal(t, []int64(nil), ms.Value().AsRaw())
+	ms.Value().FromRaw([]int64{1, 2, 3})
+	assert.Equal(t, []int64{1, 2, 3}, ms.Value().AsRaw())
+}
+
+func TestSample_Label(t *testing.T) {
+	ms := NewSample()
+	assert.Equal(t, NewLabelSlice(), ms.Label())
+	fillTestLabelSlice(ms.Label())
+	assert.Equal(t, generateTestLabelSlice(), ms.Label())
+}
+
+func TestSample_Attributes(t *testing.T) {
+	ms := NewSample()
+	assert.Equal(t, []uint64(nil), ms.Attributes().AsRaw())
+	ms.Attributes().FromRaw([]uint64{1, 2})
+	assert.Equal(t, []uint64{1, 2}, ms.Attributes().AsRaw())
+}
+
+func TestSample_Link(t *testing.T) {
+	ms := NewSample()
+	assert.Equal(t, uint64(0), ms.Link())
+	ms.SetLink(uint64(1))
+	assert.Equal(t, uint64(1), ms.Link())
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { newSample(&otlpprofiles.Sample{}, &sharedState).SetLink(uint64(1)) })
+}
+
+func TestSample_TimestampsUnixNano(t *testing.T) {
+	ms := NewSample()
+	assert.Equal(t, []uint64(nil), ms.TimestampsUnixNano().AsRaw())
+	ms.TimestampsUnixNano().FromRaw([]uint64{1234567890})
+	assert.Equal(t, []uint64{1234567890}, ms.TimestampsUnixNano().AsRaw())
+}
+
+func generateTestSample() Sample {
+	tv := NewSample()
+	fillTestSample(tv)
+	return tv
+}
+
+func fillTestSample(tv Sample) {
+	tv.orig.LocationIndex = []uint64{1, 2, 3}
+	tv.orig.LocationsStartIndex = uint64(1)
+	tv.orig.LocationsLength = uint64(3)
+	tv.orig.StacktraceIdIndex = uint32(1)
+	tv.orig.Value = []int64{1, 2, 3}
+	fillTestLabelSlice(newLabelSlice(&tv.orig.Label, tv.state))
+	tv.orig.Attributes = []uint64{1, 2}
+	tv.orig.Link = uint64(1)
+	tv.orig.TimestampsUnixNano = []uint64{1234567890}
+}
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofile // import "go.opentelemetry.io/collector/pdata/pprofile"
+
+import (
+	"bytes"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpprofile "go.opentelemetry.io/collector/pdata/internal/data/protogen/profiles/v1experimental"
+	"go.opentelemetry.io/collector/pdata/internal/json"
+)
+
+type JSONMarshaler struct{}
+
+func (*JSONMarshaler) MarshalProfiles(pd Profiles) ([]byte, error) {
+	buf := bytes.Buffer{}
+	pb := internal.ProfilesToProto(internal.Profiles(pd))
+	err := json.Marshal(&buf, &pb)
+	return buf.Bytes(), err
+}
+
+type JSONUnmarshaler struct{}
+
+func (*JSONUnmarshaler) UnmarshalProfiles(buf []byte) (Profiles, error) {
+	pb := otlpprofile.ProfilesData{}
+	if err := json.Unmarshal(bytes.NewReader(buf), &pb); err != nil {
+		return Profiles{}, err
+	}
+	return Profiles(internal.ProfilesFromProto(pb)), nil
+}
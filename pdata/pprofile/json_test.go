@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+var _ Marshaler = (*JSONMarshaler)(nil)
+var _ Unmarshaler = (*JSONUnmarshaler)(nil)
+
+var profilesOTLP = func() Profiles {
+	pd := NewProfiles()
+	rp := pd.ResourceProfiles().AppendEmpty()
+	rp.SetSchemaUrl("schemaURL")
+	rp.Resource().Attributes().PutStr("service.name", "testService")
+	sp := rp.ScopeProfiles().AppendEmpty()
+	sp.Scope().SetName("scope name")
+	sp.Scope().SetVersion("scope version")
+	pc := sp.Profiles().AppendEmpty()
+	pc.SetProfileID(ProfileID([16]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x10}))
+	pc.SetStartTimestamp(pcommon.Timestamp(1684617382541971000))
+	pc.SetEndTimestamp(pcommon.Timestamp(1684623646539558000))
+	pc.Attributes().PutStr("host.arch", "amd64")
+	pr := pc.Profile()
+	pr.StringTable().FromRaw([]string{"", "cpu", "nanoseconds", "main", "main.go"})
+	st := pr.SampleType().AppendEmpty()
+	st.SetType(1)
+	st.SetUnit(2)
+	st.SetAggregationTemporality(AggregationTemporalityDelta)
+	fn := pr.Function().AppendEmpty()
+	fn.SetID(1)
+	fn.SetName(3)
+	fn.SetFilename(4)
+	loc := pr.Location().AppendEmpty()
+	loc.SetID(1)
+	loc.SetAddress(0x1000)
+	loc.Line().AppendEmpty().SetLine(42)
+	s := pr.Sample().AppendEmpty()
+	s.LocationIndex().FromRaw([]uint64{0})
+	s.Value().FromRaw([]int64{10000000})
+	s.Label().AppendEmpty().SetNum(1)
+	pr.SetTime(pcommon.Timestamp(1684617382541971000))
+	pr.SetDurationNanos(1000000000)
+	pr.PeriodType().SetType(1)
+	pr.PeriodType().SetUnit(2)
+	pr.SetPeriod(10000000)
+	return pd
+}()
+
+func TestJSONRoundTrip(t *testing.T) {
+	encoder := &JSONMarshaler{}
+	jsonBuf, err := encoder.MarshalProfiles(profilesOTLP)
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonBuf), `"profileId":"0102030405060708090a0b0c0d0e0f10"`)
+
+	decoder := &JSONUnmarshaler{}
+	got, err := decoder.UnmarshalProfiles(jsonBuf)
+	require.NoError(t, err)
+	assert.Equal(t, profilesOTLP, got)
+}
+
+func TestJSONUnmarshalUnknownFields(t *testing.T) {
+	decoder := &JSONUnmarshaler{}
+	got, err := decoder.UnmarshalProfiles([]byte(`{"resourceProfiles":[{"unknown":1,"scope_profiles":[{"profiles":[{}]}]}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.ProfileCount())
+}
+
+func TestJSONUnmarshalInvalid(t *testing.T) {
+	decoder := &JSONUnmarshaler{}
+	_, err := decoder.UnmarshalProfiles([]byte(`{"resourceProfiles":`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ MarshalSizer = (*ProtoMarshaler)(nil)
+var _ Unmarshaler = (*ProtoUnmarshaler)(nil)
+
+func TestProtoProfilesUnmarshalerError(t *testing.T) {
+	p := &ProtoUnmarshaler{}
+	_, err := p.UnmarshalProfiles([]byte("+$%"))
+	assert.Error(t, err)
+}
+
+func TestProtoSizer(t *testing.T) {
+	marshaler := &ProtoMarshaler{}
+	pd := NewProfiles()
+	pd.ResourceProfiles().AppendEmpty().ScopeProfiles().AppendEmpty().Profiles().AppendEmpty().Profile().StringTable().Append("", "cpu")
+
+	size := marshaler.ProfilesSize(pd)
+
+	bytes, err := marshaler.MarshalProfiles(pd)
+	require.NoError(t, err)
+	assert.Equal(t, len(bytes), size)
+}
+
+func TestProtoSizerEmptyProfiles(t *testing.T) {
+	sizer := &ProtoMarshaler{}
+	assert.Equal(t, 0, sizer.ProfilesSize(NewProfiles()))
+}
+
+func TestProtoMarshalUnmarshal(t *testing.T) {
+	pd := NewProfiles()
+	fillTestResourceProfilesSlice(pd.ResourceProfiles())
+
+	buf, err := (&ProtoMarshaler{}).MarshalProfiles(pd)
+	require.NoError(t, err)
+	got, err := (&ProtoUnmarshaler{}).UnmarshalProfiles(buf)
+	require.NoError(t, err)
+	assert.Equal(t, pd, got)
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofileotlp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _ json.Unmarshaler = ExportRequest{}
+var _ json.Marshaler = ExportRequest{}
+
+var profilesRequestJSON = []byte(`
+	{
+		"resourceProfiles": [
+			{
+				"resource": {},
+				"scopeProfiles": [
+					{
+						"scope": {},
+						"profiles": [
+							{
+								"profileId": "",
+								"profile": {
+									"stringTable": ["", "cpu"],
+									"periodType": {}
+								}
+							}
+						]
+					}
+				]
+			}
+		]
+	}`)
+
+func TestRequestToPData(t *testing.T) {
+	tr := NewExportRequest()
+	assert.Equal(t, tr.Profiles().ProfileCount(), 0)
+	tr.Profiles().ResourceProfiles().AppendEmpty().ScopeProfiles().AppendEmpty().Profiles().AppendEmpty()
+	assert.Equal(t, tr.Profiles().ProfileCount(), 1)
+}
+
+func TestRequestJSON(t *testing.T) {
+	tr := NewExportRequest()
+	assert.NoError(t, tr.UnmarshalJSON(profilesRequestJSON))
+	assert.Equal(t, "cpu", tr.Profiles().ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(0).Profile().StringTable().At(1))
+
+	got, err := tr.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Join(strings.Fields(string(profilesRequestJSON)), ""), string(got))
+}
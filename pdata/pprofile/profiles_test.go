@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	otlpcollectorprofile "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/profiles/v1experimental"
+	otlpprofile "go.opentelemetry.io/collector/pdata/internal/data/protogen/profiles/v1experimental"
+)
+
+func TestProfileCount(t *testing.T) {
+	profiles := NewProfiles()
+	assert.EqualValues(t, 0, profiles.ProfileCount())
+	assert.EqualValues(t, 0, profiles.SampleCount())
+
+	sps := profiles.ResourceProfiles().AppendEmpty().ScopeProfiles().AppendEmpty()
+	assert.EqualValues(t, 0, profiles.ProfileCount())
+
+	pc := sps.Profiles().AppendEmpty()
+	assert.EqualValues(t, 1, profiles.ProfileCount())
+	assert.EqualValues(t, 0, profiles.SampleCount())
+
+	pc.Profile().Sample().AppendEmpty()
+	pc.Profile().Sample().AppendEmpty()
+	sps.Profiles().AppendEmpty().Profile().Sample().AppendEmpty()
+	assert.EqualValues(t, 2, profiles.ProfileCount())
+	assert.EqualValues(t, 3, profiles.SampleCount())
+}
+
+func TestProfileCountWithEmpty(t *testing.T) {
+	assert.EqualValues(t, 0, newProfiles(&otlpcollectorprofile.ExportProfilesServiceRequest{
+		ResourceProfiles: []*otlpprofile.ResourceProfiles{{}},
+	}).ProfileCount())
+	assert.EqualValues(t, 1, newProfiles(&otlpcollectorprofile.ExportProfilesServiceRequest{
+		ResourceProfiles: []*otlpprofile.ResourceProfiles{
+			{
+				ScopeProfiles: []*otlpprofile.ScopeProfiles{
+					{
+						Profiles: []*otlpprofile.ProfileContainer{{}},
+					},
+				},
+			},
+		},
+	}).ProfileCount())
+}
+
+func TestProfilesCopyTo(t *testing.T) {
+	profiles := NewProfiles()
+	fillTestResourceProfilesSlice(profiles.ResourceProfiles())
+	profilesCopy := NewProfiles()
+	profiles.CopyTo(profilesCopy)
+	assert.EqualValues(t, profiles, profilesCopy)
+}
+
+func TestReadOnlyProfiles(t *testing.T) {
+	profiles := NewProfiles()
+	fillTestResourceProfilesSlice(profiles.ResourceProfiles())
+	assert.False(t, profiles.IsReadOnly())
+	profiles.MarkReadOnly()
+	assert.True(t, profiles.IsReadOnly())
+
+	// Reading is still allowed.
+	profilesCopy := NewProfiles()
+	profiles.CopyTo(profilesCopy)
+	assert.EqualValues(t, profiles.getOrig(), profilesCopy.getOrig())
+	assert.False(t, profilesCopy.IsReadOnly())
+
+	pc := profiles.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(0)
+	assert.Panics(t, func() { profiles.ResourceProfiles().AppendEmpty() })
+	assert.Panics(t, func() { pc.Attributes().PutStr("k", "v") })
+	assert.Panics(t, func() { pc.Profile().Sample().AppendEmpty() })
+	assert.Panics(t, func() { pc.Profile().StringTable().Append("str") })
+	assert.Panics(t, func() { profilesCopy.CopyTo(profiles) })
+}
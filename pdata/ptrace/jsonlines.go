@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
+
+import (
+	"bytes"
+	"fmt"
+
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/internal/json"
+)
+
+var _ Marshaler = (*JSONLinesMarshaler)(nil)
+
+// JSONLinesMarshaler marshals Traces into newline-delimited OTLP JSON, also known as JSON Lines:
+// each line is the OTLP JSON TracesData of one resource, so that the traces can be appended to
+// a file and read back one resource at a time.
+type JSONLinesMarshaler struct{}
+
+func (*JSONLinesMarshaler) MarshalTraces(td Traces) ([]byte, error) {
+	buf := bytes.Buffer{}
+	rss := td.getOrig().ResourceSpans
+	for i := range rss {
+		pb := otlptrace.TracesData{ResourceSpans: rss[i : i+1]}
+		if err := json.Marshal(&buf, &pb); err != nil {
+			return nil, err
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+var _ Unmarshaler = (*JSONLinesUnmarshaler)(nil)
+
+// JSONLinesUnmarshaler unmarshals newline-delimited OTLP JSON into Traces. Each line holds an OTLP
+// JSON TracesData, the resources of all the lines are merged, the empty lines are skipped.
+type JSONLinesUnmarshaler struct{}
+
+func (*JSONLinesUnmarshaler) UnmarshalTraces(buf []byte) (Traces, error) {
+	td := NewTraces()
+	unmarshaler := JSONUnmarshaler{}
+	for lineNum := 1; len(buf) > 0; lineNum++ {
+		line := buf
+		if i := bytes.IndexByte(buf, '\n'); i >= 0 {
+			line, buf = buf[:i], buf[i+1:]
+		} else {
+			buf = nil
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		lineData, err := unmarshaler.UnmarshalTraces(line)
+		if err != nil {
+			return Traces{}, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		lineData.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	}
+	return td, nil
+}
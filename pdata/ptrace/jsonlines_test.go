@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLinesRoundTrip(t *testing.T) {
+	td := NewTraces()
+	tracesOTLP.ResourceSpans().CopyTo(td.ResourceSpans())
+	tracesOTLP.ResourceSpans().At(0).CopyTo(td.ResourceSpans().AppendEmpty())
+	td.ResourceSpans().At(1).Resource().Attributes().PutStr("host.name", "otherHost")
+
+	buf, err := (&JSONLinesMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	lines := bytes.Split(bytes.TrimSuffix(buf, []byte("\n")), []byte("\n"))
+	require.Len(t, lines, 2)
+	assert.Equal(t, tracesJSON, string(lines[0]))
+
+	// The empty lines are skipped and the resources of all the lines are merged.
+	got, err := (&JSONLinesUnmarshaler{}).UnmarshalTraces(append([]byte("\n"), buf...))
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+}
+
+func TestJSONLinesMarshalEmpty(t *testing.T) {
+	buf, err := (&JSONLinesMarshaler{}).MarshalTraces(NewTraces())
+	require.NoError(t, err)
+	assert.Empty(t, buf)
+
+	got, err := (&JSONLinesUnmarshaler{}).UnmarshalTraces(buf)
+	require.NoError(t, err)
+	assert.Equal(t, NewTraces(), got)
+}
+
+func TestJSONLinesUnmarshalInvalid(t *testing.T) {
+	_, err := (&JSONLinesUnmarshaler{}).UnmarshalTraces([]byte(tracesJSON + "\n\n{\"resourceSpans\": 1}\n"))
+	assert.ErrorContains(t, err, "line 3: ")
+}
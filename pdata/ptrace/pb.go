@@ -13,18 +13,34 @@ var _ MarshalSizer = (*ProtoMarshaler)(nil)
 type ProtoMarshaler struct{}
 
 func (e *ProtoMarshaler) MarshalTraces(td Traces) ([]byte, error) {
+	if buf, ok := internal.LazyTracesBuffer(internal.Traces(td)); ok {
+		return buf, nil
+	}
 	pb := internal.TracesToProto(internal.Traces(td))
 	return pb.Marshal()
 }
 
 func (e *ProtoMarshaler) TracesSize(td Traces) int {
+	if buf, ok := internal.LazyTracesBuffer(internal.Traces(td)); ok {
+		return len(buf)
+	}
 	pb := internal.TracesToProto(internal.Traces(td))
 	return pb.Size()
 }
 
-type ProtoUnmarshaler struct{}
+type ProtoUnmarshaler struct {
+	// Lazy defers the decoding of the spans to the first access to the Traces. Only the framing of
+	// the spans is validated when unmarshaling, and buf is retained: it must not be modified.
+	// The Traces which are not accessed are marshaled again by returning buf as is, and their
+	// SpanCount is computed without decoding them, for the pipelines passing the data through.
+	Lazy bool
+}
 
 func (d *ProtoUnmarshaler) UnmarshalTraces(buf []byte) (Traces, error) {
+	if d.Lazy {
+		td, err := internal.NewLazyTraces(buf)
+		return Traces(td), err
+	}
 	pb := otlptrace.TracesData{}
 	err := pb.Unmarshal(buf)
 	return Traces(internal.TracesFromProto(pb)), err
@@ -4,6 +4,7 @@
 package ptrace
 
 import (
+	"sync"
 	"testing"
 	"time"
 
@@ -37,6 +38,73 @@ func TestProtoSizerEmptyTraces(t *testing.T) {
 	assert.Equal(t, 0, sizer.TracesSize(NewTraces()))
 }
 
+func TestProtoLazyUnmarshaler(t *testing.T) {
+	marshaler := &ProtoMarshaler{}
+	td := generateBenchmarkTraces(3)
+	td.ResourceSpans().At(0).Resource().Attributes().PutStr("service.name", "gateway")
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Events().AppendEmpty().SetName("event")
+	buf, err := marshaler.MarshalTraces(td)
+	require.NoError(t, err)
+
+	lazy, err := (&ProtoUnmarshaler{Lazy: true}).UnmarshalTraces(buf)
+	require.NoError(t, err)
+
+	// The traces passed through are neither decoded nor encoded again.
+	assert.Equal(t, 3, lazy.SpanCount())
+	assert.Equal(t, len(buf), marshaler.TracesSize(lazy))
+	got, err := marshaler.MarshalTraces(lazy)
+	require.NoError(t, err)
+	assert.Same(t, &buf[0], &got[0])
+
+	// The traces are decoded on first access.
+	assert.Equal(t, td.ResourceSpans(), lazy.ResourceSpans())
+	assert.Equal(t, 3, lazy.SpanCount())
+
+	// The modifications are marshaled once decoded.
+	lazy.ResourceSpans().At(0).ScopeSpans().At(0).Spans().AppendEmpty().SetName("added")
+	assert.Equal(t, 4, lazy.SpanCount())
+	got, err = marshaler.MarshalTraces(lazy)
+	require.NoError(t, err)
+	assert.Equal(t, marshaler.TracesSize(lazy), len(got))
+	roundtrip, err := (&ProtoUnmarshaler{}).UnmarshalTraces(got)
+	require.NoError(t, err)
+	assert.Equal(t, lazy.ResourceSpans(), roundtrip.ResourceSpans())
+}
+
+func TestProtoLazyUnmarshalerCopies(t *testing.T) {
+	buf, err := (&ProtoMarshaler{}).MarshalTraces(generateBenchmarkTraces(2))
+	require.NoError(t, err)
+	lazy, err := (&ProtoUnmarshaler{Lazy: true}).UnmarshalTraces(buf)
+	require.NoError(t, err)
+
+	// The copies of the value share the decoded traces.
+	traces := []Traces{lazy, lazy}
+	var wg sync.WaitGroup
+	for _, td := range traces {
+		wg.Add(1)
+		go func(td Traces) {
+			defer wg.Done()
+			assert.Equal(t, 2, td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().Len())
+		}(td)
+	}
+	wg.Wait()
+
+	dest := NewTraces()
+	lazy.CopyTo(dest)
+	assert.Equal(t, 2, dest.SpanCount())
+}
+
+func TestProtoLazyUnmarshalerError(t *testing.T) {
+	p := &ProtoUnmarshaler{Lazy: true}
+	_, err := p.UnmarshalTraces([]byte("+$%"))
+	assert.Error(t, err)
+
+	buf, err := (&ProtoMarshaler{}).MarshalTraces(generateBenchmarkTraces(2))
+	require.NoError(t, err)
+	_, err = p.UnmarshalTraces(buf[:len(buf)-1])
+	assert.Error(t, err)
+}
+
 func BenchmarkTracesToProto(b *testing.B) {
 	marshaler := &ProtoMarshaler{}
 	traces := generateBenchmarkTraces(128)
@@ -80,3 +148,19 @@ func generateBenchmarkTraces(metricsCount int) Traces {
 	}
 	return md
 }
+
+func BenchmarkTracesLazyPassThrough(b *testing.B) {
+	marshaler := &ProtoMarshaler{}
+	unmarshaler := &ProtoUnmarshaler{Lazy: true}
+	buf, err := marshaler.MarshalTraces(generateBenchmarkTraces(128))
+	require.NoError(b, err)
+	b.ResetTimer()
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		traces, err := unmarshaler.UnmarshalTraces(buf)
+		require.NoError(b, err)
+		assert.Equal(b, 128, traces.SpanCount())
+		_, err = marshaler.MarshalTraces(traces)
+		require.NoError(b, err)
+	}
+}
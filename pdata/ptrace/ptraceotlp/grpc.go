@@ -10,6 +10,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
 	"go.opentelemetry.io/collector/pdata/internal/otlp"
 )
@@ -28,19 +29,27 @@ type GRPCClient interface {
 	unexported()
 }
 
-// NewGRPCClient returns a new GRPCClient connected using the given connection.
-func NewGRPCClient(cc *grpc.ClientConn) GRPCClient {
-	return &grpcClient{rawClient: otlpcollectortrace.NewTraceServiceClient(cc)}
+// NewGRPCClient returns a new GRPCClient connected using the given connection, which can also
+// be a pool of connections such as the ones created by configgrpc.
+func NewGRPCClient(cc grpc.ClientConnInterface) GRPCClient {
+	return &grpcClient{cc: cc}
 }
 
+// exportMethod is the full name of the Export method of the TraceService, as invoked by the generated client.
+const exportMethod = "/opentelemetry.proto.collector.trace.v1.TraceService/Export"
+
 type grpcClient struct {
-	rawClient otlpcollectortrace.TraceServiceClient
+	cc grpc.ClientConnInterface
 }
 
 // Export implements the Client interface.
 func (c *grpcClient) Export(ctx context.Context, request ExportRequest, opts ...grpc.CallOption) (ExportResponse, error) {
-	rsp, err := c.rawClient.Export(ctx, request.orig, opts...)
-	return ExportResponse{orig: rsp}, err
+	rsp := &otlpcollectortrace.ExportTraceServiceResponse{}
+	if err := c.cc.Invoke(ctx, exportMethod, request.orig, rsp, opts...); err != nil {
+		return ExportResponse{}, err
+	}
+	state := internal.StateMutable
+	return ExportResponse{orig: rsp, state: &state}, nil
 }
 
 func (c *grpcClient) unexported() {}
@@ -80,6 +89,7 @@ type rawTracesServer struct {
 
 func (s rawTracesServer) Export(ctx context.Context, request *otlpcollectortrace.ExportTraceServiceRequest) (*otlpcollectortrace.ExportTraceServiceResponse, error) {
 	otlp.MigrateTraces(request.ResourceSpans)
-	rsp, err := s.srv.Export(ctx, ExportRequest{orig: request})
+	state := internal.StateMutable
+	rsp, err := s.srv.Export(ctx, ExportRequest{orig: request, state: &state})
 	return rsp.orig, err
 }
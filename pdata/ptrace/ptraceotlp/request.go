@@ -18,19 +18,24 @@ var jsonUnmarshaler = &ptrace.JSONUnmarshaler{}
 // ExportRequest represents the request for gRPC/HTTP client/server.
 // It's a wrapper for ptrace.Traces data.
 type ExportRequest struct {
-	orig *otlpcollectortrace.ExportTraceServiceRequest
+	orig  *otlpcollectortrace.ExportTraceServiceRequest
+	state *internal.State
 }
 
 // NewExportRequest returns an empty ExportRequest.
 func NewExportRequest() ExportRequest {
-	return ExportRequest{orig: &otlpcollectortrace.ExportTraceServiceRequest{}}
+	state := internal.StateMutable
+	return ExportRequest{orig: &otlpcollectortrace.ExportTraceServiceRequest{}, state: &state}
 }
 
 // NewExportRequestFromTraces returns a ExportRequest from ptrace.Traces.
 // Because ExportRequest is a wrapper for ptrace.Traces,
 // any changes to the provided Traces struct will be reflected in the ExportRequest and vice versa.
 func NewExportRequestFromTraces(td ptrace.Traces) ExportRequest {
-	return ExportRequest{orig: internal.GetOrigTraces(internal.Traces(td))}
+	return ExportRequest{
+		orig:  internal.GetOrigTraces(internal.Traces(td)),
+		state: internal.GetTracesState(internal.Traces(td)),
+	}
 }
 
 // MarshalProto marshals ExportRequest into proto bytes.
@@ -40,6 +45,7 @@ func (ms ExportRequest) MarshalProto() ([]byte, error) {
 
 // UnmarshalProto unmarshalls ExportRequest from proto bytes.
 func (ms ExportRequest) UnmarshalProto(data []byte) error {
+	ms.state.AssertMutable()
 	if err := ms.orig.Unmarshal(data); err != nil {
 		return err
 	}
@@ -58,6 +64,7 @@ func (ms ExportRequest) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON unmarshalls ExportRequest from JSON bytes.
 func (ms ExportRequest) UnmarshalJSON(data []byte) error {
+	ms.state.AssertMutable()
 	td, err := jsonUnmarshaler.UnmarshalTraces(data)
 	if err != nil {
 		return err
@@ -67,5 +74,5 @@ func (ms ExportRequest) UnmarshalJSON(data []byte) error {
 }
 
 func (ms ExportRequest) Traces() ptrace.Traces {
-	return ptrace.Traces(internal.NewTraces(ms.orig))
+	return ptrace.Traces(internal.NewTraces(ms.orig, ms.state))
 }
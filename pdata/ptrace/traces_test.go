@@ -113,3 +113,24 @@ func TestTracesCopyTo(t *testing.T) {
 	traces.CopyTo(tracesCopy)
 	assert.EqualValues(t, traces, tracesCopy)
 }
+
+func TestReadOnlyTraces(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	assert.False(t, traces.IsReadOnly())
+	traces.MarkReadOnly()
+	assert.True(t, traces.IsReadOnly())
+
+	// Reading is still allowed.
+	tracesCopy := NewTraces()
+	traces.CopyTo(tracesCopy)
+	assert.EqualValues(t, traces.getOrig(), tracesCopy.getOrig())
+	assert.False(t, tracesCopy.IsReadOnly())
+
+	rs := traces.ResourceSpans().At(0)
+	assert.Panics(t, func() { traces.ResourceSpans().AppendEmpty() })
+	assert.Panics(t, func() { rs.Resource().Attributes().PutStr("k", "v") })
+	assert.Panics(t, func() { rs.ScopeSpans().AppendEmpty() })
+	assert.Panics(t, func() { rs.ScopeSpans().At(0).Spans().At(0).SetName("span") })
+	assert.Panics(t, func() { tracesCopy.CopyTo(traces) })
+}
@@ -29,6 +29,9 @@ import (
 // errTooManyBatchers is returned when the MetadataCardinalityLimit has been reached.
 var errTooManyBatchers = consumererror.NewPermanent(errors.New("too many batcher metadata-value combinations"))
 
+// errShutdown is returned when data is consumed after the processor was shut down.
+var errShutdown = errors.New("the batch processor is shut down")
+
 // batch_processor is a component that accepts spans and metrics, places them
 // into batches and sends downstream.
 //
@@ -36,12 +39,14 @@ var errTooManyBatchers = consumererror.NewPermanent(errors.New("too many batcher
 //
 // Batches are sent out with any of the following conditions:
 // - batch size reaches cfg.SendBatchSize
+// - estimated batch size in bytes reaches cfg.SendBatchMaxBytes
 // - cfg.Timeout is elapsed since the timestamp when the previous batch was sent out.
 type batchProcessor struct {
-	logger           *zap.Logger
-	timeout          time.Duration
-	sendBatchSize    int
-	sendBatchMaxSize int
+	logger            *zap.Logger
+	timeout           time.Duration
+	sendBatchSize     int
+	sendBatchMaxSize  int
+	sendBatchMaxBytes int
 
 	// batchFunc is a factory for new batch objects corresponding
 	// with the appropriate signal.
@@ -56,8 +61,9 @@ type batchProcessor struct {
 	// metadataLimit is the limiting size of the batchers map.
 	metadataLimit int
 
-	shutdownC  chan struct{}
-	goroutines sync.WaitGroup
+	shutdownC    chan struct{}
+	shutdownOnce sync.Once
+	goroutines   sync.WaitGroup
 
 	telemetry *batchProcessorTelemetry
 
@@ -91,16 +97,26 @@ type shard struct {
 	// batch is an in-flight data item containing one of the
 	// underlying data types.
 	batch batch
+
+	// bytes is the estimated size in bytes of the batch, only
+	// tracked when sendBatchMaxBytes is set.
+	bytes int
 }
 
 // batch is an interface generalizing the individual signal types.
 type batch interface {
 	// export the current batch
-	export(ctx context.Context, sendBatchMaxSize int, returnBytes bool) (sentBatchSize int, sentBatchBytes int, err error)
+	export(ctx context.Context, sendBatchMaxSize int, sendBatchMaxBytes int, returnBytes bool) (sentBatchSize int, sentBatchBytes int, err error)
 
 	// itemCount returns the size of the current batch
 	itemCount() int
 
+	// bytesSize returns the estimated size in bytes of the current batch
+	bytesSize() int
+
+	// itemBytesSize returns the estimated size in bytes of an item
+	itemBytesSize(item any) int
+
 	// add item to the current batch
 	add(item any)
 }
@@ -120,13 +136,14 @@ func newBatchProcessor(set processor.CreateSettings, cfg *Config, batchFunc func
 	bp := &batchProcessor{
 		logger: set.Logger,
 
-		sendBatchSize:    int(cfg.SendBatchSize),
-		sendBatchMaxSize: int(cfg.SendBatchMaxSize),
-		timeout:          cfg.Timeout,
-		batchFunc:        batchFunc,
-		shutdownC:        make(chan struct{}, 1),
-		metadataKeys:     mks,
-		metadataLimit:    int(cfg.MetadataCardinalityLimit),
+		sendBatchSize:     int(cfg.SendBatchSize),
+		sendBatchMaxSize:  int(cfg.SendBatchMaxSize),
+		sendBatchMaxBytes: int(cfg.SendBatchMaxBytes),
+		timeout:           cfg.Timeout,
+		batchFunc:         batchFunc,
+		shutdownC:         make(chan struct{}, 1),
+		metadataKeys:      mks,
+		metadataLimit:     int(cfg.MetadataCardinalityLimit),
 	}
 	if len(bp.metadataKeys) == 0 {
 		bp.batcher = &singleShardBatcher{batcher: bp.newShard(nil)}
@@ -172,10 +189,12 @@ func (bp *batchProcessor) Start(context.Context, component.Host) error {
 
 // Shutdown is invoked during service shutdown.
 func (bp *batchProcessor) Shutdown(context.Context) error {
-	close(bp.shutdownC)
+	bp.shutdownOnce.Do(func() {
+		close(bp.shutdownC)
 
-	// Wait until all goroutines are done.
-	bp.goroutines.Wait()
+		// Wait until all goroutines are done.
+		bp.goroutines.Wait()
+	})
 	return nil
 }
 
@@ -223,9 +242,14 @@ func (b *shard) start() {
 }
 
 func (b *shard) processItem(item any) {
+	if b.processor.sendBatchMaxBytes > 0 {
+		// The size is estimated before add moves the data of the item to the batch.
+		b.bytes += b.batch.itemBytesSize(item)
+	}
 	b.batch.add(item)
 	sent := false
-	for b.batch.itemCount() > 0 && (!b.hasTimer() || b.batch.itemCount() >= b.processor.sendBatchSize) {
+	for b.batch.itemCount() > 0 && (!b.hasTimer() || b.batch.itemCount() >= b.processor.sendBatchSize ||
+		b.processor.sendBatchMaxBytes > 0 && b.bytes >= b.processor.sendBatchMaxBytes) {
 		sent = true
 		b.sendItems(triggerBatchSize)
 	}
@@ -253,7 +277,10 @@ func (b *shard) resetTimer() {
 }
 
 func (b *shard) sendItems(trigger trigger) {
-	sent, bytes, err := b.batch.export(b.exportCtx, b.processor.sendBatchMaxSize, b.processor.telemetry.detailed)
+	sent, bytes, err := b.batch.export(b.exportCtx, b.processor.sendBatchMaxSize, b.processor.sendBatchMaxBytes, b.processor.telemetry.detailed)
+	if b.processor.sendBatchMaxBytes > 0 {
+		b.bytes = b.batch.bytesSize()
+	}
 	if err != nil {
 		b.processor.logger.Warn("Sender failed", zap.Error(err))
 	} else {
@@ -261,6 +288,21 @@ func (b *shard) sendItems(trigger trigger) {
 	}
 }
 
+// add passes the data to the goroutine of the shard, unless the processor is shut down.
+func (b *shard) add(data any) error {
+	select {
+	case <-b.processor.shutdownC:
+		return errShutdown
+	default:
+	}
+	select {
+	case b.newItem <- data:
+		return nil
+	case <-b.processor.shutdownC:
+		return errShutdown
+	}
+}
+
 // singleShardBatcher is used when metadataKeys is empty, to avoid the
 // additional lock and map operations used in multiBatcher.
 type singleShardBatcher struct {
@@ -268,8 +310,7 @@ type singleShardBatcher struct {
 }
 
 func (sb *singleShardBatcher) consume(_ context.Context, data any) error {
-	sb.batcher.newItem <- data
-	return nil
+	return sb.batcher.add(data)
 }
 
 func (sb *singleShardBatcher) currentMetadataCardinality() int {
@@ -324,8 +365,7 @@ func (mb *multiShardBatcher) consume(ctx context.Context, data any) error {
 		}
 		mb.lock.Unlock()
 	}
-	b.(*shard).newItem <- data
-	return nil
+	return b.(*shard).add(data)
 }
 
 func (mb *multiShardBatcher) currentMetadataCardinality() int {
@@ -387,7 +427,7 @@ func (bt *batchTraces) add(item any) {
 	td.ResourceSpans().MoveAndAppendTo(bt.traceData.ResourceSpans())
 }
 
-func (bt *batchTraces) export(ctx context.Context, sendBatchMaxSize int, returnBytes bool) (int, int, error) {
+func (bt *batchTraces) export(ctx context.Context, sendBatchMaxSize int, sendBatchMaxBytes int, returnBytes bool) (int, int, error) {
 	var req ptrace.Traces
 	var sent int
 	var bytes int
@@ -401,7 +441,20 @@ func (bt *batchTraces) export(ctx context.Context, sendBatchMaxSize int, returnB
 		bt.traceData = ptrace.NewTraces()
 		bt.spanCount = 0
 	}
-	if returnBytes {
+	if sendBatchMaxBytes > 0 {
+		bytes = bt.sizer.TracesSize(req)
+		for sent > 1 && bytes > sendBatchMaxBytes {
+			n := bytesLimitedCount(sent, bytes, sendBatchMaxBytes)
+			part := splitTraces(n, req)
+			// req keeps the spans not sent, which go back to the front of the batch.
+			bt.traceData.ResourceSpans().MoveAndAppendTo(req.ResourceSpans())
+			bt.traceData = req
+			bt.spanCount += sent - n
+			req = part
+			sent = n
+			bytes = bt.sizer.TracesSize(req)
+		}
+	} else if returnBytes {
 		bytes = bt.sizer.TracesSize(req)
 	}
 	return sent, bytes, bt.nextConsumer.ConsumeTraces(ctx, req)
@@ -411,6 +464,14 @@ func (bt *batchTraces) itemCount() int {
 	return bt.spanCount
 }
 
+func (bt *batchTraces) bytesSize() int {
+	return bt.sizer.TracesSize(bt.traceData)
+}
+
+func (bt *batchTraces) itemBytesSize(item any) int {
+	return bt.sizer.TracesSize(item.(ptrace.Traces))
+}
+
 type batchMetrics struct {
 	nextConsumer   consumer.Metrics
 	metricData     pmetric.Metrics
@@ -422,7 +483,7 @@ func newBatchMetrics(nextConsumer consumer.Metrics) *batchMetrics {
 	return &batchMetrics{nextConsumer: nextConsumer, metricData: pmetric.NewMetrics(), sizer: &pmetric.ProtoMarshaler{}}
 }
 
-func (bm *batchMetrics) export(ctx context.Context, sendBatchMaxSize int, returnBytes bool) (int, int, error) {
+func (bm *batchMetrics) export(ctx context.Context, sendBatchMaxSize int, sendBatchMaxBytes int, returnBytes bool) (int, int, error) {
 	var req pmetric.Metrics
 	var sent int
 	var bytes int
@@ -436,7 +497,20 @@ func (bm *batchMetrics) export(ctx context.Context, sendBatchMaxSize int, return
 		bm.metricData = pmetric.NewMetrics()
 		bm.dataPointCount = 0
 	}
-	if returnBytes {
+	if sendBatchMaxBytes > 0 {
+		bytes = bm.sizer.MetricsSize(req)
+		for sent > 1 && bytes > sendBatchMaxBytes {
+			n := bytesLimitedCount(sent, bytes, sendBatchMaxBytes)
+			part := splitMetrics(n, req)
+			// req keeps the data points not sent, which go back to the front of the batch.
+			bm.metricData.ResourceMetrics().MoveAndAppendTo(req.ResourceMetrics())
+			bm.metricData = req
+			bm.dataPointCount += sent - n
+			req = part
+			sent = n
+			bytes = bm.sizer.MetricsSize(req)
+		}
+	} else if returnBytes {
 		bytes = bm.sizer.MetricsSize(req)
 	}
 	return sent, bytes, bm.nextConsumer.ConsumeMetrics(ctx, req)
@@ -446,6 +520,14 @@ func (bm *batchMetrics) itemCount() int {
 	return bm.dataPointCount
 }
 
+func (bm *batchMetrics) bytesSize() int {
+	return bm.sizer.MetricsSize(bm.metricData)
+}
+
+func (bm *batchMetrics) itemBytesSize(item any) int {
+	return bm.sizer.MetricsSize(item.(pmetric.Metrics))
+}
+
 func (bm *batchMetrics) add(item any) {
 	md := item.(pmetric.Metrics)
 
@@ -468,7 +550,7 @@ func newBatchLogs(nextConsumer consumer.Logs) *batchLogs {
 	return &batchLogs{nextConsumer: nextConsumer, logData: plog.NewLogs(), sizer: &plog.ProtoMarshaler{}}
 }
 
-func (bl *batchLogs) export(ctx context.Context, sendBatchMaxSize int, returnBytes bool) (int, int, error) {
+func (bl *batchLogs) export(ctx context.Context, sendBatchMaxSize int, sendBatchMaxBytes int, returnBytes bool) (int, int, error) {
 	var req plog.Logs
 	var sent int
 	var bytes int
@@ -483,7 +565,20 @@ func (bl *batchLogs) export(ctx context.Context, sendBatchMaxSize int, returnByt
 		bl.logData = plog.NewLogs()
 		bl.logCount = 0
 	}
-	if returnBytes {
+	if sendBatchMaxBytes > 0 {
+		bytes = bl.sizer.LogsSize(req)
+		for sent > 1 && bytes > sendBatchMaxBytes {
+			n := bytesLimitedCount(sent, bytes, sendBatchMaxBytes)
+			part := splitLogs(n, req)
+			// req keeps the log records not sent, which go back to the front of the batch.
+			bl.logData.ResourceLogs().MoveAndAppendTo(req.ResourceLogs())
+			bl.logData = req
+			bl.logCount += sent - n
+			req = part
+			sent = n
+			bytes = bl.sizer.LogsSize(req)
+		}
+	} else if returnBytes {
 		bytes = bl.sizer.LogsSize(req)
 	}
 	return sent, bytes, bl.nextConsumer.ConsumeLogs(ctx, req)
@@ -493,6 +588,14 @@ func (bl *batchLogs) itemCount() int {
 	return bl.logCount
 }
 
+func (bl *batchLogs) bytesSize() int {
+	return bl.sizer.LogsSize(bl.logData)
+}
+
+func (bl *batchLogs) itemBytesSize(item any) int {
+	return bl.sizer.LogsSize(item.(plog.Logs))
+}
+
 func (bl *batchLogs) add(item any) {
 	ld := item.(plog.Logs)
 
@@ -503,3 +606,16 @@ func (bl *batchLogs) add(item any) {
 	bl.logCount += newLogsCount
 	ld.ResourceLogs().MoveAndAppendTo(bl.logData.ResourceLogs())
 }
+
+// bytesLimitedCount returns the number of items, less than count, estimated to fit in maxBytes
+// out of count items whose estimated size is bytes, assuming the items have similar sizes.
+func bytesLimitedCount(count int, bytes int, maxBytes int) int {
+	n := int(int64(count) * int64(maxBytes) / int64(bytes))
+	if n >= count {
+		n = count - 1
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
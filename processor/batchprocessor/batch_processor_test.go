@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
@@ -24,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
 
@@ -267,6 +269,62 @@ func testBatchProcessorSentBySizeWithMaxSize(t *testing.T, tel testTelemetry, us
 	})
 }
 
+func TestBatchProcessorSentBySizeWithMaxBytes(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 10000
+	cfg.Timeout = 10 * time.Second
+	sizer := &ptrace.ProtoMarshaler{}
+	cfg.SendBatchMaxBytes = uint32(sizer.TracesSize(testdata.GenerateTraces(10)))
+	batcher, err := newBatchTracesProcessor(processortest.NewNopCreateSettings(), sink, cfg, false)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	requestCount := 5
+	spansPerRequest := 20
+	for requestNum := 0; requestNum < requestCount; requestNum++ {
+		td := testdata.GenerateTraces(spansPerRequest)
+		assert.NoError(t, batcher.ConsumeTraces(context.Background(), td))
+	}
+
+	// The batches reaching the maximum size in bytes are sent before the timeout.
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() >= requestCount*spansPerRequest-10
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	require.Equal(t, requestCount*spansPerRequest, sink.SpanCount())
+	for _, td := range sink.AllTraces() {
+		assert.LessOrEqual(t, sizer.TracesSize(td), int(cfg.SendBatchMaxBytes))
+	}
+}
+
+func TestBatchLogs_ExportMaxBytes(t *testing.T) {
+	ctx := context.Background()
+	sink := new(consumertest.LogsSink)
+	sizer := &plog.ProtoMarshaler{}
+	maxBytes := sizer.LogsSize(testdata.GenerateLogs(10))
+
+	batchLogs := newBatchLogs(sink)
+	ld := testdata.GenerateLogs(25)
+	firstBody := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().AsString()
+	batchLogs.add(ld)
+
+	sent, bytes, err := batchLogs.export(ctx, 0, maxBytes, false)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, bytes, maxBytes)
+	assert.Equal(t, bytes, sizer.LogsSize(sink.AllLogs()[0]))
+	assert.Equal(t, sent, sink.LogRecordCount())
+	assert.Equal(t, 25-sent, batchLogs.itemCount())
+	assert.Equal(t, firstBody, sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().AsString())
+
+	// A log record larger than the maximum size is sent alone.
+	sent, bytes, err = batchLogs.export(ctx, 0, 1, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Greater(t, bytes, 1)
+}
+
 func TestBatchProcessorSentByTimeout(t *testing.T) {
 	sink := new(consumertest.TracesSink)
 	cfg := createDefaultConfig().(*Config)
@@ -464,13 +522,29 @@ func TestBatchMetrics_UnevenBatchMaxSize(t *testing.T) {
 
 	batchMetrics.add(md)
 	require.Equal(t, dataPointsPerMetric*metricsCount, batchMetrics.dataPointCount)
-	sent, _, sendErr := batchMetrics.export(ctx, sendBatchMaxSize, false)
+	sent, _, sendErr := batchMetrics.export(ctx, sendBatchMaxSize, 0, false)
 	require.NoError(t, sendErr)
 	require.Equal(t, sendBatchMaxSize, sent)
 	remainingDataPointCount := metricsCount*dataPointsPerMetric - sendBatchMaxSize
 	require.Equal(t, remainingDataPointCount, batchMetrics.dataPointCount)
 }
 
+func TestBatchMetrics_ExportMaxBytes(t *testing.T) {
+	ctx := context.Background()
+	sink := new(metricsSink)
+	sizer := &pmetric.ProtoMarshaler{}
+	maxBytes := sizer.MetricsSize(testdata.GenerateMetrics(5))
+
+	batchMetrics := newBatchMetrics(sink)
+	batchMetrics.add(testdata.GenerateMetrics(20))
+	total := batchMetrics.itemCount()
+	sent, bytes, err := batchMetrics.export(ctx, 0, maxBytes, false)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, bytes, maxBytes)
+	assert.Equal(t, total-sent, batchMetrics.itemCount())
+	assert.Equal(t, batchMetrics.bytesSize(), sizer.MetricsSize(batchMetrics.metricData))
+}
+
 func TestBatchMetricsProcessor_Timeout(t *testing.T) {
 	cfg := Config{
 		Timeout:       100 * time.Millisecond,
@@ -872,6 +946,22 @@ func TestShutdown(t *testing.T) {
 	processortest.VerifyShutdown(t, factory, factory.CreateDefaultConfig())
 }
 
+func TestLifecycle(t *testing.T) {
+	factory := NewFactory()
+	componenttest.CheckLifecycle(componenttest.CheckLifecycleParams{
+		T: t,
+		Create: func(nextErr error) (component.Component, error) {
+			return factory.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(),
+				factory.CreateDefaultConfig(), consumertest.NewErr(nextErr))
+		},
+		Consume: func(ctx context.Context, c component.Component) error {
+			return c.(processor.Traces).ConsumeTraces(ctx, testdata.GenerateTraces(10))
+		},
+		// The batches are sent asynchronously.
+		PropagatesErrors: false,
+	})
+}
+
 type metadataTracesSink struct {
 	*consumertest.TracesSink
 
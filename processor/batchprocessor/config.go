@@ -28,6 +28,12 @@ type Config struct {
 	// Default value is 0, that means no maximum size.
 	SendBatchMaxSize uint32 `mapstructure:"send_batch_max_size"`
 
+	// SendBatchMaxBytes is the maximum estimated size in bytes of a batch, serialized as protobuf.
+	// A batch is sent once it reaches this size, and larger batches are split into smaller units.
+	// Only a single span, metric data point or log record larger than this size is sent in a larger batch.
+	// Default value is 0, that means no maximum size in bytes.
+	SendBatchMaxBytes uint32 `mapstructure:"send_batch_max_bytes"`
+
 	// MetadataKeys is a list of client.Metadata keys that will be
 	// used to form distinct batchers.  If this setting is empty,
 	// a single batcher instance will be used.  When this setting
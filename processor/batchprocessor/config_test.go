@@ -33,6 +33,7 @@ func TestUnmarshalConfig(t *testing.T) {
 		&Config{
 			SendBatchSize:            uint32(10000),
 			SendBatchMaxSize:         uint32(11000),
+			SendBatchMaxBytes:        uint32(4194304),
 			Timeout:                  time.Second * 10,
 			MetadataCardinalityLimit: 1000,
 		}, cfg)
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsanitizerprocessor // import "go.opentelemetry.io/collector/processor/logsanitizerprocessor"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	errNegativeLimit = errors.New("the limits cannot be negative")
+	errNoLimits      = errors.New("at least one limit must be set")
+)
+
+// Config defines configuration for the log sanitizer processor.
+type Config struct {
+	// MaxBodySize is the maximum size in bytes of the bodies, the larger bodies are truncated. The size of the
+	// maps and slices is the size of their keys and nested values. 0 means no limit.
+	MaxBodySize int `mapstructure:"max_body_size"`
+
+	// MaxAttributeCount is the maximum number of attributes of a log record, including the truncated attribute,
+	// the other attributes are dropped and counted in the dropped attributes count of the log record.
+	// 0 means no limit.
+	MaxAttributeCount int `mapstructure:"max_attribute_count"`
+
+	// MaxAttributeValueSize is the maximum size in bytes of the values of the attributes, the larger values
+	// are truncated like the bodies. 0 means no limit.
+	MaxAttributeValueSize int `mapstructure:"max_attribute_value_size"`
+
+	// TruncatedAttribute is the key of the attribute listing what was truncated in a log record: "body" and the
+	// keys of the truncated attributes. It is not added if empty.
+	TruncatedAttribute string `mapstructure:"truncated_attribute"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.MaxBodySize < 0 || cfg.MaxAttributeCount < 0 || cfg.MaxAttributeValueSize < 0 {
+		return errNegativeLimit
+	}
+	if cfg.MaxBodySize == 0 && cfg.MaxAttributeCount == 0 && cfg.MaxAttributeValueSize == 0 {
+		return errNoLimits
+	}
+	return nil
+}
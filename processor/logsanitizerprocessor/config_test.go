@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsanitizerprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t, &Config{
+		MaxBodySize:           1024,
+		MaxAttributeCount:     32,
+		MaxAttributeValueSize: 256,
+		TruncatedAttribute:    "log.truncated",
+	}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	assert.NoError(t, component.ValidateConfig(NewFactory().CreateDefaultConfig()))
+	assert.Equal(t, errNegativeLimit, component.ValidateConfig(&Config{MaxBodySize: 10, MaxAttributeValueSize: -1}))
+	assert.Equal(t, errNoLimits, component.ValidateConfig(&Config{TruncatedAttribute: "truncated"}))
+}
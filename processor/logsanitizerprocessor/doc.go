@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package logsanitizerprocessor truncates the oversized bodies and attributes of the log records,
+// and caps their number of attributes.
+package logsanitizerprocessor // import "go.opentelemetry.io/collector/processor/logsanitizerprocessor"
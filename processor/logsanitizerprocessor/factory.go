@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsanitizerprocessor // import "go.opentelemetry.io/collector/processor/logsanitizerprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "logsanitizer"
+
+	defaultMaxBodySize        = 64 * 1024
+	defaultMaxAttributeCount  = 128
+	defaultTruncatedAttribute = "otelcol.truncated"
+)
+
+var processorCapabilities = consumer.Capabilities{MutatesData: true}
+
+// NewFactory returns a new factory for the Log Sanitizer processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processor.WithLogs(createLogsProcessor, component.StabilityLevelDevelopment))
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		MaxBodySize:        defaultMaxBodySize,
+		MaxAttributeCount:  defaultMaxAttributeCount,
+		TruncatedAttribute: defaultTruncatedAttribute,
+	}
+}
+
+func createLogsProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (processor.Logs, error) {
+	lsp := newLogSanitizerProcessor(cfg.(*Config))
+	return processorhelper.NewLogsProcessor(ctx, set, cfg, nextConsumer,
+		lsp.processLogs,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
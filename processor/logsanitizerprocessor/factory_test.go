@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsanitizerprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestCreateProcessors(t *testing.T) {
+	factory := NewFactory()
+	set := processortest.NewNopCreateSettings()
+
+	lp, err := factory.CreateLogsProcessor(context.Background(), set, factory.CreateDefaultConfig(), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.True(t, lp.Capabilities().MutatesData)
+
+	_, err = factory.CreateTracesProcessor(context.Background(), set, factory.CreateDefaultConfig(), consumertest.NewNop())
+	assert.Error(t, err)
+}
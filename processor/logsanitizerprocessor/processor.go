@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsanitizerprocessor // import "go.opentelemetry.io/collector/processor/logsanitizerprocessor"
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// truncatedBody is the value of the truncated attribute indicating that the body was truncated.
+const truncatedBody = "body"
+
+type logSanitizerProcessor struct {
+	cfg *Config
+}
+
+func newLogSanitizerProcessor(cfg *Config) *logSanitizerProcessor {
+	return &logSanitizerProcessor{cfg: cfg}
+}
+
+func (lsp *logSanitizerProcessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lsp.sanitize(lrs.At(k))
+			}
+		}
+	}
+	return ld, nil
+}
+
+// sanitize truncates the body and the attributes of the log record, and drops its extra attributes.
+func (lsp *logSanitizerProcessor) sanitize(lr plog.LogRecord) {
+	var truncated []string
+	if lsp.cfg.MaxBodySize > 0 && truncateValue(lr.Body(), lsp.cfg.MaxBodySize) {
+		truncated = append(truncated, truncatedBody)
+	}
+
+	attrs := lr.Attributes()
+	maxCount := lsp.cfg.MaxAttributeCount
+	if maxCount > 0 && attrs.Len() > maxCount {
+		lr.SetDroppedAttributesCount(lr.DroppedAttributesCount() + uint32(attrs.Len()-maxCount))
+		kept := 0
+		attrs.RemoveIf(func(string, pcommon.Value) bool {
+			kept++
+			return kept > maxCount
+		})
+	}
+	if lsp.cfg.MaxAttributeValueSize > 0 {
+		attrs.Range(func(k string, v pcommon.Value) bool {
+			if truncateValue(v, lsp.cfg.MaxAttributeValueSize) {
+				truncated = append(truncated, k)
+			}
+			return true
+		})
+	}
+
+	if len(truncated) == 0 || lsp.cfg.TruncatedAttribute == "" {
+		return
+	}
+	if _, ok := attrs.Get(lsp.cfg.TruncatedAttribute); !ok && maxCount > 0 && attrs.Len() >= maxCount {
+		// The last attribute is dropped to make room for the truncated attribute, so that the limit is kept.
+		last := ""
+		i := 0
+		attrs.RemoveIf(func(k string, _ pcommon.Value) bool {
+			i++
+			if i < maxCount {
+				return false
+			}
+			last = k
+			return true
+		})
+		lr.SetDroppedAttributesCount(lr.DroppedAttributesCount() + 1)
+		if n := len(truncated); truncated[n-1] == last {
+			truncated = truncated[:n-1]
+		}
+		if len(truncated) == 0 {
+			return
+		}
+	}
+	keys := attrs.PutEmptySlice(lsp.cfg.TruncatedAttribute)
+	keys.EnsureCapacity(len(truncated))
+	for _, k := range truncated {
+		keys.AppendEmpty().SetStr(k)
+	}
+}
+
+// truncateValue truncates the value to maxSize bytes, and returns whether it was truncated.
+// The strings are truncated at the start of a UTF-8 character, so that they stay valid. The size of the
+// maps and slices is the size of their keys and of their nested values, their elements following the
+// first one exceeding the size are removed.
+func truncateValue(v pcommon.Value, maxSize int) bool {
+	_, truncated := truncate(v, maxSize)
+	return truncated
+}
+
+// truncate truncates the value to the size left, and returns the size left after the value and
+// whether it was truncated. The numbers and booleans, which cannot be truncated, are left as is.
+func truncate(v pcommon.Value, left int) (int, bool) {
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		s := v.Str()
+		if len(s) <= left {
+			return left - len(s), false
+		}
+		end := left
+		for end > 0 && !utf8.RuneStart(s[end]) {
+			end--
+		}
+		// Copy the kept part, so that the oversized string is not referenced anymore.
+		v.SetStr(string([]byte(s[:end])))
+		return 0, true
+	case pcommon.ValueTypeBytes:
+		if v.Bytes().Len() <= left {
+			return left - v.Bytes().Len(), false
+		}
+		// Copy the kept part, so that the oversized bytes are not referenced anymore.
+		kept := make([]byte, left)
+		copy(kept, v.Bytes().AsRaw())
+		v.Bytes().FromRaw(kept)
+		return 0, true
+	case pcommon.ValueTypeMap:
+		truncated := false
+		v.Map().RemoveIf(func(k string, e pcommon.Value) bool {
+			if truncated || len(k) > left {
+				truncated = true
+				return true
+			}
+			left -= len(k)
+			return truncateElement(e, &left, &truncated)
+		})
+		return left, truncated
+	case pcommon.ValueTypeSlice:
+		truncated := false
+		v.Slice().RemoveIf(func(e pcommon.Value) bool {
+			if truncated {
+				return true
+			}
+			return truncateElement(e, &left, &truncated)
+		})
+		return left, truncated
+	}
+	return left, false
+}
+
+// truncateElement truncates the element of a map or a slice to the size left, and returns whether
+// it must be removed because it does not fit.
+func truncateElement(e pcommon.Value, left *int, truncated *bool) bool {
+	var size int
+	switch e.Type() {
+	case pcommon.ValueTypeInt, pcommon.ValueTypeDouble:
+		size = 8
+	case pcommon.ValueTypeBool:
+		size = 1
+	case pcommon.ValueTypeEmpty:
+		size = 0
+	default:
+		*left, *truncated = truncate(e, *left)
+		return false
+	}
+	if size > *left {
+		*truncated = true
+		return true
+	}
+	*left -= size
+	return false
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsanitizerprocessor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name               string
+		cfg                Config
+		body               any
+		attributes         map[string]any
+		expectedBody       any
+		expectedAttributes map[string]any
+		expectedDropped    uint32
+	}{
+		{
+			name:               "nothing to sanitize",
+			cfg:                Config{MaxBodySize: 5, MaxAttributeCount: 2, MaxAttributeValueSize: 3, TruncatedAttribute: "truncated"},
+			body:               "short",
+			attributes:         map[string]any{"a": "abc"},
+			expectedBody:       "short",
+			expectedAttributes: map[string]any{"a": "abc"},
+		},
+		{
+			name:               "string body",
+			cfg:                Config{MaxBodySize: 5, TruncatedAttribute: "truncated"},
+			body:               "too long",
+			expectedBody:       "too l",
+			expectedAttributes: map[string]any{"truncated": []any{"body"}},
+		},
+		{
+			name:               "utf-8 body",
+			cfg:                Config{MaxBodySize: 5},
+			body:               "abcdé",
+			expectedBody:       "abcd",
+			expectedAttributes: map[string]any{},
+		},
+		{
+			name:               "bytes body",
+			cfg:                Config{MaxBodySize: 2, TruncatedAttribute: "truncated"},
+			body:               []byte{1, 2, 3},
+			expectedBody:       []byte{1, 2},
+			expectedAttributes: map[string]any{"truncated": []any{"body"}},
+		},
+		{
+			name:               "map body",
+			cfg:                Config{MaxBodySize: 12, TruncatedAttribute: "truncated"},
+			body:               map[string]any{"message": "truncated"},
+			expectedBody:       map[string]any{"message": "trunc"},
+			expectedAttributes: map[string]any{"truncated": []any{"body"}},
+		},
+		{
+			name:               "slice body",
+			cfg:                Config{MaxBodySize: 12, TruncatedAttribute: "truncated"},
+			body:               []any{int64(1), "abc", []any{"de", true}, "f"},
+			expectedBody:       []any{int64(1), "abc", []any{"d"}},
+			expectedAttributes: map[string]any{"truncated": []any{"body"}},
+		},
+		{
+			name:               "nested map body",
+			cfg:                Config{MaxBodySize: 4},
+			body:               map[string]any{"a": map[string]any{"b": int64(1), "c": "d"}},
+			expectedBody:       map[string]any{"a": map[string]any{}},
+			expectedAttributes: map[string]any{},
+		},
+		{
+			name:               "map body fitting",
+			cfg:                Config{MaxBodySize: 12, TruncatedAttribute: "truncated"},
+			body:               map[string]any{"message": "short"},
+			expectedBody:       map[string]any{"message": "short"},
+			expectedAttributes: map[string]any{},
+		},
+		{
+			name:               "attribute values",
+			cfg:                Config{MaxAttributeValueSize: 3, TruncatedAttribute: "truncated"},
+			attributes:         map[string]any{"a": "abcd", "b": []byte{1, 2, 3, 4}, "c": int64(12345), "d": "abc"},
+			expectedAttributes: map[string]any{"a": "abc", "b": []byte{1, 2, 3}, "c": int64(12345), "d": "abc", "truncated": []any{"a", "b"}},
+		},
+		{
+			name:               "attribute count",
+			cfg:                Config{MaxAttributeCount: 2, TruncatedAttribute: "truncated"},
+			attributes:         map[string]any{"a": 1, "b": 2, "c": 3, "d": 4},
+			expectedAttributes: map[string]any{"a": int64(1), "b": int64(2)},
+			expectedDropped:    2,
+		},
+		{
+			name:               "truncated attribute slot",
+			cfg:                Config{MaxAttributeCount: 2, MaxAttributeValueSize: 1, TruncatedAttribute: "truncated"},
+			attributes:         map[string]any{"a": "ab", "b": "cd", "c": "x"},
+			expectedAttributes: map[string]any{"a": "a", "truncated": []any{"a"}},
+			expectedDropped:    2,
+		},
+		{
+			name:               "truncated attribute slot for the body",
+			cfg:                Config{MaxBodySize: 1, MaxAttributeCount: 1, TruncatedAttribute: "truncated"},
+			body:               "ab",
+			attributes:         map[string]any{"a": int64(1)},
+			expectedBody:       "a",
+			expectedAttributes: map[string]any{"truncated": []any{"body"}},
+			expectedDropped:    1,
+		},
+		{
+			name:               "without truncated attribute",
+			cfg:                Config{MaxBodySize: 1, MaxAttributeValueSize: 1},
+			body:               "ab",
+			attributes:         map[string]any{"a": "ab"},
+			expectedBody:       "a",
+			expectedAttributes: map[string]any{"a": "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr := plog.NewLogRecord()
+			require.NoError(t, lr.Body().FromRaw(tt.body))
+			// Sorted attributes, so that the dropped ones are known.
+			for _, k := range []string{"a", "b", "c", "d"} {
+				if v, ok := tt.attributes[k]; ok {
+					require.NoError(t, lr.Attributes().PutEmpty(k).FromRaw(v))
+				}
+			}
+			lr.SetDroppedAttributesCount(1)
+
+			newLogSanitizerProcessor(&tt.cfg).sanitize(lr)
+			assert.Equal(t, tt.expectedBody, lr.Body().AsRaw())
+			assert.Equal(t, tt.expectedAttributes, lr.Attributes().AsRaw())
+			assert.Equal(t, tt.expectedDropped+1, lr.DroppedAttributesCount())
+		})
+	}
+}
+
+func TestTruncateBytesCopies(t *testing.T) {
+	v := pcommon.NewValueBytes()
+	v.Bytes().FromRaw(make([]byte, 1024))
+	assert.True(t, truncateValue(v, 2))
+	// The oversized bytes are not referenced anymore.
+	assert.Less(t, cap(v.Bytes().AsRaw()), 1024)
+}
+
+func TestProcessLogs(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	lp, err := NewFactory().CreateLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), createDefaultConfig(), sink)
+	require.NoError(t, err)
+	require.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	lrs.AppendEmpty().Body().SetStr(strings.Repeat("a", defaultMaxBodySize+1))
+	lrs.AppendEmpty().Body().SetStr("short")
+	require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, lp.Shutdown(context.Background()))
+
+	require.Len(t, sink.AllLogs(), 1)
+	lrs = sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	assert.Len(t, lrs.At(0).Body().Str(), defaultMaxBodySize)
+	truncated, ok := lrs.At(0).Attributes().Get(defaultTruncatedAttribute)
+	require.True(t, ok)
+	assert.Equal(t, []any{"body"}, truncated.Slice().AsRaw())
+	assert.Equal(t, "short", lrs.At(1).Body().Str())
+	assert.Equal(t, 0, lrs.At(1).Attributes().Len())
+}
+
+func BenchmarkSanitize(b *testing.B) {
+	lsp := newLogSanitizerProcessor(createDefaultConfig().(*Config))
+	lr := plog.NewLogRecord()
+	lr.Body().SetStr(strings.Repeat("a", 100))
+	for _, k := range []string{"a", "b", "c", "d"} {
+		lr.Attributes().PutStr(k, "value")
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		lsp.sanitize(lr)
+	}
+}
@@ -6,20 +6,18 @@ package memorylimiterprocessor
 import (
 	"context"
 	"runtime"
-	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
-	"go.opentelemetry.io/collector/internal/iruntime"
+	"go.opentelemetry.io/collector/memorylimiter"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -41,14 +39,14 @@ func TestNew(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
-		wantErr error
+		wantErr string
 	}{
 		{
 			name: "zero_checkInterval",
 			args: args{
 				nextConsumer: sink,
 			},
-			wantErr: errCheckIntervalOutOfRange,
+			wantErr: "check_interval must be greater than zero",
 		},
 		{
 			name: "zero_memAllocLimit",
@@ -56,7 +54,7 @@ func TestNew(t *testing.T) {
 				nextConsumer:  sink,
 				checkInterval: 100 * time.Millisecond,
 			},
-			wantErr: errLimitOutOfRange,
+			wantErr: "limit_mib or limit_percentage must be greater than zero",
 		},
 		{
 			name: "memSpikeLimit_gt_memAllocLimit",
@@ -66,7 +64,7 @@ func TestNew(t *testing.T) {
 				memoryLimitMiB:      1,
 				memorySpikeLimitMiB: 2,
 			},
-			wantErr: errMemSpikeLimitOutOfRange,
+			wantErr: "spike_limit_mib must be smaller than limit_mib",
 		},
 		{
 			name: "success",
@@ -84,8 +82,8 @@ func TestNew(t *testing.T) {
 			cfg.MemoryLimitMiB = tt.args.memoryLimitMiB
 			cfg.MemorySpikeLimitMiB = tt.args.memorySpikeLimitMiB
 			got, err := newMemoryLimiter(processortest.NewNopCreateSettings(), cfg)
-			if tt.wantErr != nil {
-				assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
 				return
 			}
 			assert.NoError(t, err)
@@ -95,20 +93,13 @@ func TestNew(t *testing.T) {
 	}
 }
 
-// TestMetricsMemoryPressureResponse manipulates results from querying memory and
-// check expected side effects.
+// TestMetricsMemoryPressureResponse checks that the metrics are refused while the memory limiter
+// signals it.
 func TestMetricsMemoryPressureResponse(t *testing.T) {
-	var currentMemAlloc uint64
+	var refuse bool
 	ml := &memoryLimiter{
-		usageChecker: memUsageChecker{
-			memAllocLimit: 1024,
-		},
-		mustRefuse: &atomic.Bool{},
-		readMemStatsFn: func(ms *runtime.MemStats) {
-			ms.Alloc = currentMemAlloc
-		},
-		obsrep: newObsReport(t),
-		logger: zap.NewNop(),
+		mustRefuse: func() bool { return refuse },
+		obsrep:     newObsReport(t),
 	}
 	mp, err := processorhelper.NewMetricsProcessor(
 		context.Background(),
@@ -123,61 +114,25 @@ func TestMetricsMemoryPressureResponse(t *testing.T) {
 	ctx := context.Background()
 	md := pmetric.NewMetrics()
 
-	// Below memAllocLimit.
-	currentMemAlloc = 800
-	ml.checkMemLimits()
+	// Below the soft limit.
 	assert.NoError(t, mp.ConsumeMetrics(ctx, md))
 
-	// Above memAllocLimit.
-	currentMemAlloc = 1800
-	ml.checkMemLimits()
-	assert.Equal(t, errDataRefused, mp.ConsumeMetrics(ctx, md))
-
-	// Check ballast effect
-	ml.ballastSize = 1000
-
-	// Below memAllocLimit accounting for ballast.
-	currentMemAlloc = 800 + ml.ballastSize
-	ml.checkMemLimits()
-	assert.NoError(t, mp.ConsumeMetrics(ctx, md))
-
-	// Above memAllocLimit even accountiing for ballast.
-	currentMemAlloc = 1800 + ml.ballastSize
-	ml.checkMemLimits()
-	assert.Equal(t, errDataRefused, mp.ConsumeMetrics(ctx, md))
-
-	// Restore ballast to default.
-	ml.ballastSize = 0
+	// Above the soft limit.
+	refuse = true
+	assert.Equal(t, memorylimiter.ErrDataRefused, mp.ConsumeMetrics(ctx, md))
 
-	// Check spike limit
-	ml.usageChecker.memSpikeLimit = 512
-
-	// Below memSpikeLimit.
-	currentMemAlloc = 500
-	ml.checkMemLimits()
+	// Back within the limits.
+	refuse = false
 	assert.NoError(t, mp.ConsumeMetrics(ctx, md))
-
-	// Above memSpikeLimit.
-	currentMemAlloc = 550
-	ml.checkMemLimits()
-	assert.Equal(t, errDataRefused, mp.ConsumeMetrics(ctx, md))
-
 }
 
-// TestTraceMemoryPressureResponse manipulates results from querying memory and
-// check expected side effects.
+// TestTraceMemoryPressureResponse checks that the traces are refused while the memory limiter
+// signals it.
 func TestTraceMemoryPressureResponse(t *testing.T) {
-	var currentMemAlloc uint64
+	var refuse bool
 	ml := &memoryLimiter{
-		usageChecker: memUsageChecker{
-			memAllocLimit: 1024,
-		},
-		mustRefuse: &atomic.Bool{},
-		readMemStatsFn: func(ms *runtime.MemStats) {
-			ms.Alloc = currentMemAlloc
-		},
-		obsrep: newObsReport(t),
-		logger: zap.NewNop(),
+		mustRefuse: func() bool { return refuse },
+		obsrep:     newObsReport(t),
 	}
 	tp, err := processorhelper.NewTracesProcessor(
 		context.Background(),
@@ -192,61 +147,25 @@ func TestTraceMemoryPressureResponse(t *testing.T) {
 	ctx := context.Background()
 	td := ptrace.NewTraces()
 
-	// Below memAllocLimit.
-	currentMemAlloc = 800
-	ml.checkMemLimits()
+	// Below the soft limit.
 	assert.NoError(t, tp.ConsumeTraces(ctx, td))
 
-	// Above memAllocLimit.
-	currentMemAlloc = 1800
-	ml.checkMemLimits()
-	assert.Equal(t, errDataRefused, tp.ConsumeTraces(ctx, td))
-
-	// Check ballast effect
-	ml.ballastSize = 1000
+	// Above the soft limit.
+	refuse = true
+	assert.Equal(t, memorylimiter.ErrDataRefused, tp.ConsumeTraces(ctx, td))
 
-	// Below memAllocLimit accounting for ballast.
-	currentMemAlloc = 800 + ml.ballastSize
-	ml.checkMemLimits()
+	// Back within the limits.
+	refuse = false
 	assert.NoError(t, tp.ConsumeTraces(ctx, td))
-
-	// Above memAllocLimit even accountiing for ballast.
-	currentMemAlloc = 1800 + ml.ballastSize
-	ml.checkMemLimits()
-	assert.Equal(t, errDataRefused, tp.ConsumeTraces(ctx, td))
-
-	// Restore ballast to default.
-	ml.ballastSize = 0
-
-	// Check spike limit
-	ml.usageChecker.memSpikeLimit = 512
-
-	// Below memSpikeLimit.
-	currentMemAlloc = 500
-	ml.checkMemLimits()
-	assert.NoError(t, tp.ConsumeTraces(ctx, td))
-
-	// Above memSpikeLimit.
-	currentMemAlloc = 550
-	ml.checkMemLimits()
-	assert.Equal(t, errDataRefused, tp.ConsumeTraces(ctx, td))
-
 }
 
-// TestLogMemoryPressureResponse manipulates results from querying memory and
-// check expected side effects.
+// TestLogMemoryPressureResponse checks that the logs are refused while the memory limiter
+// signals it.
 func TestLogMemoryPressureResponse(t *testing.T) {
-	var currentMemAlloc uint64
+	var refuse bool
 	ml := &memoryLimiter{
-		usageChecker: memUsageChecker{
-			memAllocLimit: 1024,
-		},
-		mustRefuse: &atomic.Bool{},
-		readMemStatsFn: func(ms *runtime.MemStats) {
-			ms.Alloc = currentMemAlloc
-		},
-		obsrep: newObsReport(t),
-		logger: zap.NewNop(),
+		mustRefuse: func() bool { return refuse },
+		obsrep:     newObsReport(t),
 	}
 	lp, err := processorhelper.NewLogsProcessor(
 		context.Background(),
@@ -261,153 +180,32 @@ func TestLogMemoryPressureResponse(t *testing.T) {
 	ctx := context.Background()
 	ld := plog.NewLogs()
 
-	// Below memAllocLimit.
-	currentMemAlloc = 800
-	ml.checkMemLimits()
-	assert.NoError(t, lp.ConsumeLogs(ctx, ld))
-
-	// Above memAllocLimit.
-	currentMemAlloc = 1800
-	ml.checkMemLimits()
-	assert.Equal(t, errDataRefused, lp.ConsumeLogs(ctx, ld))
-
-	// Check ballast effect
-	ml.ballastSize = 1000
-
-	// Below memAllocLimit accounting for ballast.
-	currentMemAlloc = 800 + ml.ballastSize
-	ml.checkMemLimits()
+	// Below the soft limit.
 	assert.NoError(t, lp.ConsumeLogs(ctx, ld))
 
-	// Above memAllocLimit even accountiing for ballast.
-	currentMemAlloc = 1800 + ml.ballastSize
-	ml.checkMemLimits()
-	assert.Equal(t, errDataRefused, lp.ConsumeLogs(ctx, ld))
-
-	// Restore ballast to default.
-	ml.ballastSize = 0
+	// Above the soft limit.
+	refuse = true
+	assert.Equal(t, memorylimiter.ErrDataRefused, lp.ConsumeLogs(ctx, ld))
 
-	// Check spike limit
-	ml.usageChecker.memSpikeLimit = 512
-
-	// Below memSpikeLimit.
-	currentMemAlloc = 500
-	ml.checkMemLimits()
+	// Back within the limits.
+	refuse = false
 	assert.NoError(t, lp.ConsumeLogs(ctx, ld))
-
-	// Above memSpikeLimit.
-	currentMemAlloc = 550
-	ml.checkMemLimits()
-	assert.Equal(t, errDataRefused, lp.ConsumeLogs(ctx, ld))
-}
-
-func TestGetDecision(t *testing.T) {
-	t.Run("fixed_limit", func(t *testing.T) {
-		d, err := getMemUsageChecker(&Config{MemoryLimitMiB: 100, MemorySpikeLimitMiB: 20}, zap.NewNop())
-		require.NoError(t, err)
-		assert.Equal(t, &memUsageChecker{
-			memAllocLimit: 100 * mibBytes,
-			memSpikeLimit: 20 * mibBytes,
-		}, d)
-	})
-	t.Run("fixed_limit_error", func(t *testing.T) {
-		d, err := getMemUsageChecker(&Config{MemoryLimitMiB: 20, MemorySpikeLimitMiB: 100}, zap.NewNop())
-		require.Error(t, err)
-		assert.Nil(t, d)
-	})
-
-	t.Cleanup(func() {
-		getMemoryFn = iruntime.TotalMemory
-	})
-	getMemoryFn = func() (uint64, error) {
-		return 100 * mibBytes, nil
-	}
-	t.Run("percentage_limit", func(t *testing.T) {
-		d, err := getMemUsageChecker(&Config{MemoryLimitPercentage: 50, MemorySpikePercentage: 10}, zap.NewNop())
-		require.NoError(t, err)
-		assert.Equal(t, &memUsageChecker{
-			memAllocLimit: 50 * mibBytes,
-			memSpikeLimit: 10 * mibBytes,
-		}, d)
-	})
-	t.Run("percentage_limit_error", func(t *testing.T) {
-		d, err := getMemUsageChecker(&Config{MemoryLimitPercentage: 101, MemorySpikePercentage: 10}, zap.NewNop())
-		require.Error(t, err)
-		assert.Nil(t, d)
-		d, err = getMemUsageChecker(&Config{MemoryLimitPercentage: 99, MemorySpikePercentage: 101}, zap.NewNop())
-		require.Error(t, err)
-		assert.Nil(t, d)
-	})
-}
-
-func TestRefuseDecision(t *testing.T) {
-	decison1000Limit30Spike30, err := newPercentageMemUsageChecker(1000, 60, 30)
-	require.NoError(t, err)
-	decison1000Limit60Spike50, err := newPercentageMemUsageChecker(1000, 60, 50)
-	require.NoError(t, err)
-	decison1000Limit40Spike20, err := newPercentageMemUsageChecker(1000, 40, 20)
-	require.NoError(t, err)
-	decison1000Limit40Spike60, err := newPercentageMemUsageChecker(1000, 40, 60)
-	require.Error(t, err)
-	assert.Nil(t, decison1000Limit40Spike60)
-
-	tests := []struct {
-		name         string
-		usageChecker memUsageChecker
-		ms           *runtime.MemStats
-		shouldRefuse bool
-	}{
-		{
-			name:         "should refuse over limit",
-			usageChecker: *decison1000Limit30Spike30,
-			ms:           &runtime.MemStats{Alloc: 600},
-			shouldRefuse: true,
-		},
-		{
-			name:         "should not refuse",
-			usageChecker: *decison1000Limit30Spike30,
-			ms:           &runtime.MemStats{Alloc: 100},
-			shouldRefuse: false,
-		},
-		{
-			name: "should not refuse spike, fixed usageChecker",
-			usageChecker: memUsageChecker{
-				memAllocLimit: 600,
-				memSpikeLimit: 500,
-			},
-			ms:           &runtime.MemStats{Alloc: 300},
-			shouldRefuse: true,
-		},
-		{
-			name:         "should refuse, spike, percentage usageChecker",
-			usageChecker: *decison1000Limit60Spike50,
-			ms:           &runtime.MemStats{Alloc: 300},
-			shouldRefuse: true,
-		},
-		{
-			name:         "should refuse, spike, percentage usageChecker",
-			usageChecker: *decison1000Limit40Spike20,
-			ms:           &runtime.MemStats{Alloc: 250},
-			shouldRefuse: true,
-		},
-	}
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			shouldRefuse := test.usageChecker.aboveSoftLimit(test.ms)
-			assert.Equal(t, test.shouldRefuse, shouldRefuse)
-		})
-	}
 }
 
-func TestBallastSize(t *testing.T) {
+func TestStartShutdown(t *testing.T) {
 	cfg := createDefaultConfig().(*Config)
 	cfg.CheckInterval = 10 * time.Second
 	cfg.MemoryLimitMiB = 1024
-	got, err := newMemoryLimiter(processortest.NewNopCreateSettings(), cfg)
+	ml, err := newMemoryLimiter(processortest.NewNopCreateSettings(), cfg)
 	require.NoError(t, err)
-	require.NoError(t, got.start(context.Background(), &host{ballastSize: 113}))
-	assert.Equal(t, uint64(113), got.ballastSize)
-	require.NoError(t, got.shutdown(context.Background()))
+	assert.ErrorIs(t, ml.shutdown(context.Background()), errShutdownNotStarted)
+
+	// The memory limiter is shared by the processors of the same configuration.
+	require.NoError(t, ml.start(context.Background(), &host{ballastSize: 113}))
+	require.NoError(t, ml.start(context.Background(), &host{ballastSize: 113}))
+	require.NoError(t, ml.shutdown(context.Background()))
+	require.NoError(t, ml.shutdown(context.Background()))
+	assert.ErrorIs(t, ml.shutdown(context.Background()), errShutdownNotStarted)
 }
 
 type host struct {
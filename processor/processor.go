@@ -31,6 +31,12 @@ type Logs interface {
 	consumer.Logs
 }
 
+// Profiles is a processor that can consume profiles.
+type Profiles interface {
+	component.Component
+	consumer.Profiles
+}
+
 // CreateSettings is passed to Create* functions in Factory.
 type CreateSettings struct {
 	// ID returns the ID of the component that will be created.
@@ -73,6 +79,14 @@ type Factory interface {
 	// LogsProcessorStability gets the stability level of the LogsProcessor.
 	LogsProcessorStability() component.StabilityLevel
 
+	// CreateProfilesProcessor creates a ProfilesProcessor based on the config.
+	// If the processor type does not support profiles or if the config is not valid,
+	// an error will be returned instead.
+	CreateProfilesProcessor(ctx context.Context, set CreateSettings, cfg component.Config, nextConsumer consumer.Profiles) (Profiles, error)
+
+	// ProfilesProcessorStability gets the stability level of the ProfilesProcessor.
+	ProfilesProcessorStability() component.StabilityLevel
+
 	unexportedFactoryFunc()
 }
 
@@ -138,6 +152,22 @@ func (f CreateLogsFunc) CreateLogsProcessor(
 	return f(ctx, set, cfg, nextConsumer)
 }
 
+// CreateProfilesFunc is the equivalent of Factory.CreateProfiles().
+type CreateProfilesFunc func(context.Context, CreateSettings, component.Config, consumer.Profiles) (Profiles, error)
+
+// CreateProfilesProcessor implements Factory.CreateProfilesProcessor().
+func (f CreateProfilesFunc) CreateProfilesProcessor(
+	ctx context.Context,
+	set CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Profiles,
+) (Profiles, error) {
+	if f == nil {
+		return nil, component.ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
 type factory struct {
 	cfgType component.Type
 	component.CreateDefaultConfigFunc
@@ -147,6 +177,9 @@ type factory struct {
 	metricsStabilityLevel component.StabilityLevel
 	CreateLogsFunc
 	logsStabilityLevel component.StabilityLevel
+	CreateProfilesFunc
+	profilesStabilityLevel component.StabilityLevel
+	capabilities           component.FactoryCapabilities
 }
 
 func (f *factory) Type() component.Type {
@@ -155,6 +188,10 @@ func (f *factory) Type() component.Type {
 
 func (f *factory) unexportedFactoryFunc() {}
 
+func (f *factory) Capabilities() component.FactoryCapabilities {
+	return f.capabilities
+}
+
 func (f factory) TracesProcessorStability() component.StabilityLevel {
 	return f.tracesStabilityLevel
 }
@@ -167,6 +204,10 @@ func (f factory) LogsProcessorStability() component.StabilityLevel {
 	return f.logsStabilityLevel
 }
 
+func (f factory) ProfilesProcessorStability() component.StabilityLevel {
+	return f.profilesStabilityLevel
+}
+
 // WithTraces overrides the default "error not supported" implementation for CreateTraces and the default "undefined" stability level.
 func WithTraces(createTraces CreateTracesFunc, sl component.StabilityLevel) FactoryOption {
 	return factoryOptionFunc(func(o *factory) {
@@ -191,6 +232,21 @@ func WithLogs(createLogs CreateLogsFunc, sl component.StabilityLevel) FactoryOpt
 	})
 }
 
+// WithProfiles overrides the default "error not supported" implementation for CreateProfiles and the default "undefined" stability level.
+func WithProfiles(createProfiles CreateProfilesFunc, sl component.StabilityLevel) FactoryOption {
+	return factoryOptionFunc(func(o *factory) {
+		o.profilesStabilityLevel = sl
+		o.CreateProfilesFunc = createProfiles
+	})
+}
+
+// WithCapabilities declares the capabilities of the processors created by the factory.
+func WithCapabilities(capabilities component.FactoryCapabilities) FactoryOption {
+	return factoryOptionFunc(func(o *factory) {
+		o.capabilities = capabilities
+	})
+}
+
 // NewFactory returns a Factory.
 func NewFactory(cfgType component.Type, createDefaultConfig component.CreateDefaultConfigFunc, options ...FactoryOption) Factory {
 	f := &factory{
@@ -275,6 +331,27 @@ func (b *Builder) CreateLogs(ctx context.Context, set CreateSettings, next consu
 	return f.CreateLogsProcessor(ctx, set, cfg, next)
 }
 
+// CreateProfiles creates a Profiles processor based on the settings and config.
+func (b *Builder) CreateProfiles(ctx context.Context, set CreateSettings, next consumer.Profiles) (Profiles, error) {
+	cfg, existsCfg := b.cfgs[set.ID]
+	if !existsCfg {
+		return nil, fmt.Errorf("processor %q is not configured", set.ID)
+	}
+
+	f, existsFactory := b.factories[set.ID.Type()]
+	if !existsFactory {
+		return nil, fmt.Errorf("processor factory not available for: %q", set.ID)
+	}
+
+	logStabilityLevel(set.Logger, f.ProfilesProcessorStability())
+	return f.CreateProfilesProcessor(ctx, set, cfg, next)
+}
+
+// Config returns the configuration of the processor with the given ID, or nil if it is not configured.
+func (b *Builder) Config(id component.ID) component.Config {
+	return b.cfgs[id]
+}
+
 func (b *Builder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
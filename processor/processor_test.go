@@ -30,6 +30,19 @@ func TestNewFactory(t *testing.T) {
 	assert.Error(t, err)
 	_, err = factory.CreateLogsProcessor(context.Background(), CreateSettings{}, &defaultCfg, nil)
 	assert.Error(t, err)
+	_, err = factory.CreateProfilesProcessor(context.Background(), CreateSettings{}, &defaultCfg, nil)
+	assert.Error(t, err)
+}
+
+func TestNewFactoryWithCapabilities(t *testing.T) {
+	const typeStr = "test"
+	defaultCfg := struct{}{}
+	factory := NewFactory(typeStr, func() component.Config { return &defaultCfg })
+	assert.Equal(t, component.FactoryCapabilities{}, factory.Capabilities())
+
+	capabilities := component.FactoryCapabilities{MutatesData: true, SingleInstance: true, Stateful: true}
+	factory = NewFactory(typeStr, func() component.Config { return &defaultCfg }, WithCapabilities(capabilities))
+	assert.Equal(t, capabilities, factory.Capabilities())
 }
 
 func TestNewFactoryWithOptions(t *testing.T) {
@@ -40,7 +53,8 @@ func TestNewFactoryWithOptions(t *testing.T) {
 		func() component.Config { return &defaultCfg },
 		WithTraces(createTraces, component.StabilityLevelAlpha),
 		WithMetrics(createMetrics, component.StabilityLevelBeta),
-		WithLogs(createLogs, component.StabilityLevelUnmaintained))
+		WithLogs(createLogs, component.StabilityLevelUnmaintained),
+		WithProfiles(createProfiles, component.StabilityLevelDevelopment))
 	assert.EqualValues(t, typeStr, factory.Type())
 	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
 
@@ -55,6 +69,10 @@ func TestNewFactoryWithOptions(t *testing.T) {
 	assert.Equal(t, component.StabilityLevelUnmaintained, factory.LogsProcessorStability())
 	_, err = factory.CreateLogsProcessor(context.Background(), CreateSettings{}, &defaultCfg, nil)
 	assert.NoError(t, err)
+
+	assert.Equal(t, component.StabilityLevelDevelopment, factory.ProfilesProcessorStability())
+	_, err = factory.CreateProfilesProcessor(context.Background(), CreateSettings{}, &defaultCfg, nil)
+	assert.NoError(t, err)
 }
 
 func TestMakeFactoryMap(t *testing.T) {
@@ -105,6 +123,7 @@ func TestBuilder(t *testing.T) {
 			WithTraces(createTraces, component.StabilityLevelDevelopment),
 			WithMetrics(createMetrics, component.StabilityLevelAlpha),
 			WithLogs(createLogs, component.StabilityLevelDeprecated),
+			WithProfiles(createProfiles, component.StabilityLevelDevelopment),
 		),
 	}...)
 	require.NoError(t, err)
@@ -165,6 +184,15 @@ func TestBuilder(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, nopInstance, le)
 			}
+
+			pe, err := b.CreateProfiles(context.Background(), createSettings(tt.id), nil)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				assert.Nil(t, pe)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, nopInstance, pe)
+			}
 		})
 	}
 }
@@ -178,6 +206,7 @@ func TestBuilderMissingConfig(t *testing.T) {
 			WithTraces(createTraces, component.StabilityLevelDevelopment),
 			WithMetrics(createMetrics, component.StabilityLevelAlpha),
 			WithLogs(createLogs, component.StabilityLevelDeprecated),
+			WithProfiles(createProfiles, component.StabilityLevelDevelopment),
 		),
 	}...)
 
@@ -197,6 +226,10 @@ func TestBuilderMissingConfig(t *testing.T) {
 	le, err := bErr.CreateLogs(context.Background(), createSettings(missingID), nil)
 	assert.EqualError(t, err, "processor \"all/missing\" is not configured")
 	assert.Nil(t, le)
+
+	pe, err := bErr.CreateProfiles(context.Background(), createSettings(missingID), nil)
+	assert.EqualError(t, err, "processor \"all/missing\" is not configured")
+	assert.Nil(t, pe)
 }
 
 func TestBuilderFactory(t *testing.T) {
@@ -233,6 +266,10 @@ func createLogs(context.Context, CreateSettings, component.Config, consumer.Logs
 	return nopInstance, nil
 }
 
+func createProfiles(context.Context, CreateSettings, component.Config, consumer.Profiles) (Profiles, error) {
+	return nopInstance, nil
+}
+
 func createSettings(id component.ID) CreateSettings {
 	return CreateSettings{
 		ID:                id,
@@ -6,11 +6,13 @@ package processorhelper // import "go.opentelemetry.io/collector/processor/proce
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/processor"
 )
@@ -43,13 +45,20 @@ func NewLogsProcessor(
 		return nil, component.ErrNilNextConsumer
 	}
 
+	obsrep, err := obsreport.NewProcessor(obsreport.ProcessorSettings{ProcessorID: set.ID, ProcessorCreateSettings: set})
+	if err != nil {
+		return nil, err
+	}
+
 	eventOptions := spanAttributes(set.ID)
 	bs := fromOptions(options)
 	logsConsumer, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
 		span := trace.SpanFromContext(ctx)
 		span.AddEvent("Start processing.", eventOptions)
+		start := time.Now()
 		var err error
 		ld, err = logsFunc(ctx, ld)
+		recordLatency(ctx, obsrep, start, err)
 		span.AddEvent("End processing.", eventOptions)
 		if err != nil {
 			if errors.Is(err, ErrSkipProcessingData) {
@@ -14,7 +14,9 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
@@ -70,3 +72,21 @@ func newTestLProcessor(retError error) ProcessLogsFunc {
 		return ld, retError
 	}
 }
+
+func TestNewLogsProcessor_Latency(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(component.NewID("processor"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	p, err := NewLogsProcessor(context.Background(), tt.ToProcessorCreateSettings(), &testLogsCfg, consumertest.NewNop(), newTestLProcessor(nil))
+	require.NoError(t, err)
+	assert.NoError(t, p.ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.NoError(t, p.ConsumeLogs(context.Background(), plog.NewLogs()))
+
+	p, err = NewLogsProcessor(context.Background(), tt.ToProcessorCreateSettings(), &testLogsCfg, consumertest.NewNop(), newTestLProcessor(consumererror.NewPermanent(errors.New("my_error"))))
+	require.NoError(t, err)
+	assert.Error(t, p.ConsumeLogs(context.Background(), plog.NewLogs()))
+
+	require.NoError(t, tt.CheckProcessorLatency("none", 2))
+	require.NoError(t, tt.CheckProcessorLatency("permanent", 1))
+}
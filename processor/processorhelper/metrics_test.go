@@ -14,7 +14,9 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
@@ -70,3 +72,21 @@ func newTestMProcessor(retError error) ProcessMetricsFunc {
 		return md, retError
 	}
 }
+
+func TestNewMetricsProcessor_Latency(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(component.NewID("processor"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	p, err := NewMetricsProcessor(context.Background(), tt.ToProcessorCreateSettings(), &testMetricsCfg, consumertest.NewNop(), newTestMProcessor(nil))
+	require.NoError(t, err)
+	assert.NoError(t, p.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	assert.NoError(t, p.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+
+	p, err = NewMetricsProcessor(context.Background(), tt.ToProcessorCreateSettings(), &testMetricsCfg, consumertest.NewNop(), newTestMProcessor(consumererror.NewPermanent(errors.New("my_error"))))
+	require.NoError(t, err)
+	assert.Error(t, p.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+
+	require.NoError(t, tt.CheckProcessorLatency("none", 2))
+	require.NoError(t, tt.CheckProcessorLatency("permanent", 1))
+}
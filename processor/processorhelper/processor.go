@@ -4,7 +4,9 @@
 package processorhelper // import "go.opentelemetry.io/collector/processor/processorhelper"
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -12,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/obsreport"
 )
 
 // ErrSkipProcessingData is a sentinel value to indicate when traces or metrics should intentionally be dropped
@@ -66,6 +69,15 @@ func fromOptions(options []Option) *baseSettings {
 	return opts
 }
 
+// recordLatency records the duration of the processing of the data since start, the data skipped with
+// ErrSkipProcessingData is not reported as an error.
+func recordLatency(ctx context.Context, obsrep *obsreport.Processor, start time.Time, err error) {
+	if errors.Is(err, ErrSkipProcessingData) {
+		err = nil
+	}
+	obsrep.RecordLatency(ctx, time.Since(start), err)
+}
+
 func spanAttributes(id component.ID) trace.EventOption {
 	return trace.WithAttributes(attribute.String(obsmetrics.ProcessorKey, id.String()))
 }
@@ -6,11 +6,13 @@ package processorhelper // import "go.opentelemetry.io/collector/processor/proce
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 )
@@ -43,13 +45,20 @@ func NewTracesProcessor(
 		return nil, component.ErrNilNextConsumer
 	}
 
+	obsrep, err := obsreport.NewProcessor(obsreport.ProcessorSettings{ProcessorID: set.ID, ProcessorCreateSettings: set})
+	if err != nil {
+		return nil, err
+	}
+
 	eventOptions := spanAttributes(set.ID)
 	bs := fromOptions(options)
 	traceConsumer, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
 		span := trace.SpanFromContext(ctx)
 		span.AddEvent("Start processing.", eventOptions)
+		start := time.Now()
 		var err error
 		td, err = tracesFunc(ctx, td)
+		recordLatency(ctx, obsrep, start, err)
 		span.AddEvent("End processing.", eventOptions)
 		if err != nil {
 			if errors.Is(err, ErrSkipProcessingData) {
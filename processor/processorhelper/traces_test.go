@@ -14,7 +14,9 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
@@ -70,3 +72,21 @@ func newTestTProcessor(retError error) ProcessTracesFunc {
 		return td, retError
 	}
 }
+
+func TestNewTracesProcessor_Latency(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(component.NewID("processor"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	p, err := NewTracesProcessor(context.Background(), tt.ToProcessorCreateSettings(), &testTracesCfg, consumertest.NewNop(), newTestTProcessor(nil))
+	require.NoError(t, err)
+	assert.NoError(t, p.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.NoError(t, p.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+
+	p, err = NewTracesProcessor(context.Background(), tt.ToProcessorCreateSettings(), &testTracesCfg, consumertest.NewNop(), newTestTProcessor(consumererror.NewPermanent(errors.New("my_error"))))
+	require.NoError(t, err)
+	assert.Error(t, p.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+
+	require.NoError(t, tt.CheckProcessorLatency("none", 2))
+	require.NoError(t, tt.CheckProcessorLatency("permanent", 1))
+}
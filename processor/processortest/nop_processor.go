@@ -31,6 +31,7 @@ func NewNopFactory() processor.Factory {
 		processor.WithTraces(createTracesProcessor, component.StabilityLevelStable),
 		processor.WithMetrics(createMetricsProcessor, component.StabilityLevelStable),
 		processor.WithLogs(createLogsProcessor, component.StabilityLevelStable),
+		processor.WithProfiles(createProfilesProcessor, component.StabilityLevelDevelopment),
 	)
 }
 
@@ -46,6 +47,10 @@ func createLogsProcessor(context.Context, processor.CreateSettings, component.Co
 	return nopInstance, nil
 }
 
+func createProfilesProcessor(context.Context, processor.CreateSettings, component.Config, consumer.Profiles) (processor.Profiles, error) {
+	return nopInstance, nil
+}
+
 type nopConfig struct{}
 
 var nopInstance = &nopProcessor{
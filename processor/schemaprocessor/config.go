@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaprocessor // import "go.opentelemetry.io/collector/processor/schemaprocessor"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+var errNoTargets = errors.New("no targets specified")
+
+// Config defines configuration for the schema processor.
+type Config struct {
+	// HTTPClientSettings configures the client fetching the schema files from their schema URL.
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Targets contains the schema URLs the telemetry is translated to, e.g. "https://opentelemetry.io/schemas/1.9.0".
+	// The telemetry whose schema URL belongs to the same schema family, the URL without the version,
+	// is upgraded or downgraded to the version of the target. The other telemetry is left unchanged.
+	Targets []string `mapstructure:"targets"`
+
+	// Prefetch contains the schema URLs whose schema files are fetched when the processor starts,
+	// instead of when the first telemetry needing them is received.
+	Prefetch []string `mapstructure:"prefetch"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.Targets) == 0 {
+		return errNoTargets
+	}
+	families := map[string]string{}
+	for _, target := range cfg.Targets {
+		id, err := parseSchemaURL(target)
+		if err != nil {
+			return fmt.Errorf("invalid target %q: %w", target, err)
+		}
+		if other, ok := families[id.family]; ok {
+			return fmt.Errorf("targets %q and %q belong to the same schema family", other, target)
+		}
+		families[id.family] = target
+	}
+	for _, url := range cfg.Prefetch {
+		if _, err := parseSchemaURL(url); err != nil {
+			return fmt.Errorf("invalid prefetch schema URL %q: %w", url, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, component.UnmarshalConfig(cm, cfg))
+
+	clientSettings := confighttp.NewDefaultHTTPClientSettings()
+	clientSettings.Timeout = 10 * time.Second
+	assert.Equal(t, &Config{
+		HTTPClientSettings: clientSettings,
+		Targets:            []string{"https://opentelemetry.io/schemas/1.9.0", "https://example.com/schemas/2.1.0"},
+		Prefetch:           []string{"https://opentelemetry.io/schemas/1.9.0"},
+	}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *Config
+		expected string
+	}{
+		{
+			name:     "no targets",
+			cfg:      &Config{},
+			expected: "no targets specified",
+		},
+		{
+			name:     "target without version",
+			cfg:      &Config{Targets: []string{"https://opentelemetry.io/schemas/"}},
+			expected: `invalid target "https://opentelemetry.io/schemas/": the schema URL does not end with a version`,
+		},
+		{
+			name:     "target with invalid version",
+			cfg:      &Config{Targets: []string{"https://opentelemetry.io/schemas/latest"}},
+			expected: `invalid target "https://opentelemetry.io/schemas/latest": the schema URL does not end with a version: invalid version "latest"`,
+		},
+		{
+			name:     "target with unsupported scheme",
+			cfg:      &Config{Targets: []string{"file:///schemas/1.9.0"}},
+			expected: `invalid target "file:///schemas/1.9.0": unsupported scheme "file"`,
+		},
+		{
+			name: "targets of the same family",
+			cfg: &Config{Targets: []string{
+				"https://opentelemetry.io/schemas/1.9.0",
+				"https://opentelemetry.io/schemas/1.8.0",
+			}},
+			expected: `targets "https://opentelemetry.io/schemas/1.9.0" and "https://opentelemetry.io/schemas/1.8.0" belong to the same schema family`,
+		},
+		{
+			name: "invalid prefetch",
+			cfg: &Config{
+				Targets:  []string{"https://opentelemetry.io/schemas/1.9.0"},
+				Prefetch: []string{"https://opentelemetry.io/schemas/1.9"},
+			},
+			expected: `invalid prefetch schema URL "https://opentelemetry.io/schemas/1.9": the schema URL does not end with a version: invalid version "1.9"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.cfg.Validate(), tt.expected)
+		})
+	}
+}
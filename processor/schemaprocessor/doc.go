@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schemaprocessor translates telemetry between the versions of its schema
+// using the schema files, so that it is exported with a single semantic conventions version.
+package schemaprocessor // import "go.opentelemetry.io/collector/processor/schemaprocessor"
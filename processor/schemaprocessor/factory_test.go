@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+	assert.Equal(t, defaultTimeout, cfg.(*Config).Timeout)
+	assert.Equal(t, component.StabilityLevelDevelopment, factory.TracesProcessorStability())
+	assert.Equal(t, component.StabilityLevelDevelopment, factory.MetricsProcessorStability())
+	assert.Equal(t, component.StabilityLevelDevelopment, factory.LogsProcessorStability())
+}
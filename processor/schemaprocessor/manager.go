@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaprocessor // import "go.opentelemetry.io/collector/processor/schemaprocessor"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// retryInterval is the minimum interval between two attempts to fetch a schema file.
+	retryInterval = time.Minute
+
+	// maxSchemas bounds the number of schema files cached, as the schema URLs come from the telemetry.
+	maxSchemas = 64
+
+	// maxTranslations bounds the number of translations cached between the versions of the cached schema files.
+	maxTranslations = 1024
+
+	// maxSchemaFileSize bounds the size of a fetched schema file.
+	maxSchemaFileSize = 1 << 20
+)
+
+var errTooManySchemas = fmt.Errorf("more than %d schema files are cached", maxSchemas)
+
+// manager fetches the schema files and caches them with the translations between their versions.
+type manager struct {
+	client *http.Client
+	logger *zap.Logger
+
+	mu           sync.Mutex
+	schemas      map[string]*schemaEntry
+	translations map[translationKey]*translation
+}
+
+// schemaEntry is a schema file being fetched or fetched, successfully or not, at fetched.
+type schemaEntry struct {
+	ready   chan struct{}
+	schema  *schema
+	err     error
+	fetched time.Time
+}
+
+type translationKey struct {
+	from string
+	to   string
+}
+
+func newManager(client *http.Client, logger *zap.Logger) *manager {
+	return &manager{
+		client:       client,
+		logger:       logger,
+		schemas:      map[string]*schemaEntry{},
+		translations: map[translationKey]*translation{},
+	}
+}
+
+// translation returns the translation from the schema URL from to the schema URL to, both belonging to the
+// same schema family. The schema file of the newest of the two versions is fetched if it is not cached.
+func (m *manager) translation(ctx context.Context, from, to schemaURL) (*translation, error) {
+	key := translationKey{from: from.url, to: to.url}
+	m.mu.Lock()
+	t, ok := m.translations[key]
+	m.mu.Unlock()
+	if ok {
+		return t, nil
+	}
+
+	newest := to
+	if to.version.less(from.version) {
+		newest = from
+	}
+	s, err := m.schema(ctx, newest.url)
+	if err != nil {
+		return nil, err
+	}
+	t, err = s.translation(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	if len(m.translations) < maxTranslations {
+		m.translations[key] = t
+	}
+	m.mu.Unlock()
+	return t, nil
+}
+
+// schema returns the schema file at url, fetching it unless it is cached or was unsuccessfully fetched
+// less than retryInterval ago. Concurrent calls wait for the same fetch. Once maxSchemas schema files
+// are cached, the ones which could not be fetched are evicted to fetch other ones.
+func (m *manager) schema(ctx context.Context, url string) (*schema, error) {
+	m.mu.Lock()
+	e, ok := m.schemas[url]
+	if !ok && len(m.schemas) >= maxSchemas {
+		m.evictFailed()
+		if len(m.schemas) >= maxSchemas {
+			m.mu.Unlock()
+			return nil, errTooManySchemas
+		}
+	}
+	if !ok || e.failed() {
+		e = &schemaEntry{ready: make(chan struct{})}
+		m.schemas[url] = e
+		m.mu.Unlock()
+
+		e.schema, e.err = m.fetch(url)
+		if e.err != nil {
+			m.logger.Warn("Failed to fetch the schema file, the telemetry using it is not translated",
+				zap.String("schema_url", url), zap.Duration("retry_after", retryInterval), zap.Error(e.err))
+		}
+		e.fetched = time.Now()
+		close(e.ready)
+		return e.schema, e.err
+	}
+	m.mu.Unlock()
+
+	select {
+	case <-e.ready:
+		return e.schema, e.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// evictFailed removes the schema files which could not be fetched from the cache, m.mu must be held.
+func (m *manager) evictFailed() {
+	for url, e := range m.schemas {
+		select {
+		case <-e.ready:
+			if e.err != nil {
+				delete(m.schemas, url)
+			}
+		default:
+		}
+	}
+}
+
+// failed returns whether the fetch of the entry is done, failed, and may be retried.
+func (e *schemaEntry) failed() bool {
+	select {
+	case <-e.ready:
+		return e.err != nil && time.Since(e.fetched) >= retryInterval
+	default:
+		return false
+	}
+}
+
+// fetch fetches the schema file at url. It does not depend on the context of the telemetry being
+// processed, so that the result can be cached, and is bound by the timeout of the client.
+func (m *manager) fetch(url string) (*schema, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSchemaFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxSchemaFileSize {
+		return nil, fmt.Errorf("schema file larger than %d bytes", maxSchemaFileSize)
+	}
+	s, err := parseSchema(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema file: %w", err)
+	}
+	return s, nil
+}
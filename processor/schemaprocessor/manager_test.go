@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaprocessor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestManagerLimits(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/large/1.0.0" {
+			_, _ = w.Write([]byte("file_format: 1.1.0\n# " + strings.Repeat("x", maxSchemaFileSize)))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	m := newManager(srv.Client(), zap.NewNop())
+
+	_, err := m.schema(context.Background(), srv.URL+"/large/1.0.0")
+	assert.ErrorContains(t, err, "schema file larger than")
+
+	missing := func(i int) error {
+		_, err := m.schema(context.Background(), fmt.Sprintf("%s/missing/1.%d.0", srv.URL, i))
+		return err
+	}
+	for i := 1; i < maxSchemas; i++ {
+		assert.ErrorContains(t, missing(i), "unexpected status code 404")
+	}
+	require.Len(t, m.schemas, maxSchemas)
+
+	// The schema files which could not be fetched are evicted once the cache is full.
+	assert.ErrorContains(t, missing(maxSchemas), "unexpected status code 404")
+	require.Len(t, m.schemas, 1)
+
+	// Once full of fetched schema files, the other ones are not fetched.
+	for i := 1; i < maxSchemas; i++ {
+		assert.ErrorContains(t, missing(maxSchemas+i), "unexpected status code 404")
+	}
+	for _, e := range m.schemas {
+		e.err = nil
+	}
+	assert.ErrorIs(t, missing(0), errTooManySchemas)
+	assert.Len(t, m.schemas, maxSchemas)
+}
@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaprocessor // import "go.opentelemetry.io/collector/processor/schemaprocessor"
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+)
+
+type schemaProcessor struct {
+	cfg       *Config
+	telemetry component.TelemetrySettings
+	logger    *zap.Logger
+
+	// targets contains the target of each schema family.
+	targets map[string]schemaURL
+	manager *manager
+}
+
+func newSchemaProcessor(set processor.CreateSettings, cfg *Config) (*schemaProcessor, error) {
+	targets := make(map[string]schemaURL, len(cfg.Targets))
+	for _, target := range cfg.Targets {
+		id, err := parseSchemaURL(target)
+		if err != nil {
+			return nil, err
+		}
+		targets[id.family] = id
+	}
+	return &schemaProcessor{
+		cfg:       cfg,
+		telemetry: set.TelemetrySettings,
+		logger:    set.Logger,
+		targets:   targets,
+	}, nil
+}
+
+func (sp *schemaProcessor) start(ctx context.Context, host component.Host) error {
+	client, err := sp.cfg.HTTPClientSettings.ToClient(host, sp.telemetry)
+	if err != nil {
+		return err
+	}
+	sp.manager = newManager(client, sp.logger)
+	// A schema file failing to be fetched is retried when telemetry needs it, so it does not prevent the start.
+	for _, url := range sp.cfg.Prefetch {
+		_, _ = sp.manager.schema(ctx, url)
+	}
+	return nil
+}
+
+// translation returns the translation of the telemetry using the schema URL url to the target of its
+// schema family, or nil if it is left unchanged.
+func (sp *schemaProcessor) translation(ctx context.Context, url string) (*translation, schemaURL) {
+	if url == "" {
+		return nil, schemaURL{}
+	}
+	from, err := parseSchemaURL(url)
+	if err != nil {
+		sp.logger.Debug("Invalid schema URL, the telemetry is not translated", zap.String("schema_url", url), zap.Error(err))
+		return nil, schemaURL{}
+	}
+	to, ok := sp.targets[from.family]
+	if !ok || to.version == from.version {
+		return nil, schemaURL{}
+	}
+	t, err := sp.manager.translation(ctx, from, to)
+	if err != nil {
+		sp.logger.Debug("Cannot translate the telemetry", zap.String("schema_url", url), zap.String("target", to.url), zap.Error(err))
+		return nil, schemaURL{}
+	}
+	return t, to
+}
+
+func (sp *schemaProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceURL := rs.SchemaUrl()
+		if t, to := sp.translation(ctx, resourceURL); t != nil {
+			t.translateResource(rs.Resource())
+			rs.SetSchemaUrl(to.url)
+		}
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			// The telemetry of a scope without schema URL uses the one of its resource.
+			t, to := sp.translation(ctx, scopeURL(ss.SchemaUrl(), resourceURL))
+			if t == nil {
+				continue
+			}
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				t.translateSpan(spans.At(k))
+			}
+			if ss.SchemaUrl() != "" {
+				ss.SetSchemaUrl(to.url)
+			}
+		}
+	}
+	return td, nil
+}
+
+func (sp *schemaProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceURL := rm.SchemaUrl()
+		if t, to := sp.translation(ctx, resourceURL); t != nil {
+			t.translateResource(rm.Resource())
+			rm.SetSchemaUrl(to.url)
+		}
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			t, to := sp.translation(ctx, scopeURL(sm.SchemaUrl(), resourceURL))
+			if t == nil {
+				continue
+			}
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				t.translateMetric(metrics.At(k))
+			}
+			if sm.SchemaUrl() != "" {
+				sm.SetSchemaUrl(to.url)
+			}
+		}
+	}
+	return md, nil
+}
+
+func (sp *schemaProcessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resourceURL := rl.SchemaUrl()
+		if t, to := sp.translation(ctx, resourceURL); t != nil {
+			t.translateResource(rl.Resource())
+			rl.SetSchemaUrl(to.url)
+		}
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			t, to := sp.translation(ctx, scopeURL(sl.SchemaUrl(), resourceURL))
+			if t == nil {
+				continue
+			}
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				t.translateLogRecord(lrs.At(k))
+			}
+			if sl.SchemaUrl() != "" {
+				sl.SetSchemaUrl(to.url)
+			}
+		}
+	}
+	return ld, nil
+}
+
+func scopeURL(scope, resource string) string {
+	if scope != "" {
+		return scope
+	}
+	return resource
+}
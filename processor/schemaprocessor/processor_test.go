@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaprocessor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+// schemaServer serves the test schema file at /schemas/1.2.0 and counts the requests.
+type schemaServer struct {
+	*httptest.Server
+	requests atomic.Int32
+}
+
+func newSchemaServer(t *testing.T) *schemaServer {
+	content, err := os.ReadFile(filepath.Join("testdata", "schema.yaml"))
+	require.NoError(t, err)
+	ss := &schemaServer{}
+	ss.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ss.requests.Add(1)
+		if r.URL.Path != "/schemas/1.2.0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(strings.ReplaceAll(string(content), "https://example.com", ss.URL)))
+	}))
+	t.Cleanup(ss.Close)
+	return ss
+}
+
+func (ss *schemaServer) schemaURL(version string) string {
+	return ss.URL + "/schemas/" + version
+}
+
+func newTestConfig(targets ...string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = targets
+	return cfg
+}
+
+func TestProcessTraces(t *testing.T) {
+	srv := newSchemaServer(t)
+	sink := new(consumertest.TracesSink)
+	tp, err := NewFactory().CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(),
+		newTestConfig(srv.schemaURL("1.2.0")), sink)
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, tp.Shutdown(context.Background())) }()
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl(srv.schemaURL("1.0.0"))
+	rs.Resource().Attributes().PutStr("deployment.env", "prod")
+	ss := rs.ScopeSpans().AppendEmpty()
+	query := ss.Spans().AppendEmpty()
+	query.SetName("query")
+	query.Attributes().PutStr("db.cassandra.keyspace", "users")
+	query.Attributes().PutStr("net.peer.ip", "10.0.0.1")
+	event := query.Events().AppendEmpty()
+	event.SetName("exception.thrown")
+	event.Attributes().PutStr("message", "timeout")
+	other := ss.Spans().AppendEmpty()
+	other.SetName("other")
+	other.Attributes().PutStr("db.cassandra.keyspace", "users")
+	// The schema URL of the scope overrides the one of the resource.
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.SetSchemaUrl(srv.schemaURL("1.1.0"))
+	ss.Spans().AppendEmpty().Attributes().PutStr("net.peer.ip", "10.0.0.2")
+	// Telemetry of other schema families is left unchanged.
+	rs = td.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.0.0")
+	rs.Resource().Attributes().PutStr("deployment.env", "prod")
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	require.Len(t, sink.AllTraces(), 1)
+	rss := sink.AllTraces()[0].ResourceSpans()
+
+	rs = rss.At(0)
+	assert.Equal(t, srv.schemaURL("1.2.0"), rs.SchemaUrl())
+	assert.Equal(t, map[string]any{"deployment.environment": "prod"}, rs.Resource().Attributes().AsRaw())
+	ss = rs.ScopeSpans().At(0)
+	assert.Equal(t, "", ss.SchemaUrl())
+	assert.Equal(t, map[string]any{"db.name": "users", "net.sock.peer.addr": "10.0.0.1"}, ss.Spans().At(0).Attributes().AsRaw())
+	assert.Equal(t, "exception", ss.Spans().At(0).Events().At(0).Name())
+	assert.Equal(t, map[string]any{"exception.message": "timeout"}, ss.Spans().At(0).Events().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"db.cassandra.keyspace": "users"}, ss.Spans().At(1).Attributes().AsRaw())
+	ss = rs.ScopeSpans().At(1)
+	assert.Equal(t, srv.schemaURL("1.2.0"), ss.SchemaUrl())
+	assert.Equal(t, map[string]any{"net.sock.peer.addr": "10.0.0.2"}, ss.Spans().At(0).Attributes().AsRaw())
+
+	rs = rss.At(1)
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.0.0", rs.SchemaUrl())
+	assert.Equal(t, map[string]any{"deployment.env": "prod"}, rs.Resource().Attributes().AsRaw())
+
+	assert.EqualValues(t, 1, srv.requests.Load())
+}
+
+func TestProcessMetrics(t *testing.T) {
+	srv := newSchemaServer(t)
+	sink := new(consumertest.MetricsSink)
+	mp, err := NewFactory().CreateMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(),
+		newTestConfig(srv.schemaURL("1.0.0")), sink)
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, mp.Shutdown(context.Background())) }()
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.SetSchemaUrl(srv.schemaURL("1.2.0"))
+	rm.Resource().Attributes().PutStr("deployment.environment", "prod")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("process.cpu.utilization")
+	dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("process.cpu.state", "user")
+	dp.Attributes().PutStr("net.sock.peer.addr", "10.0.0.1")
+
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+	require.Len(t, sink.AllMetrics(), 1)
+	rm = sink.AllMetrics()[0].ResourceMetrics().At(0)
+	assert.Equal(t, srv.schemaURL("1.0.0"), rm.SchemaUrl())
+	assert.Equal(t, map[string]any{"deployment.env": "prod"}, rm.Resource().Attributes().AsRaw())
+	m = rm.ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "process.cpu.usage", m.Name())
+	assert.Equal(t, map[string]any{"state": "user", "net.peer.ip": "10.0.0.1"}, m.Gauge().DataPoints().At(0).Attributes().AsRaw())
+}
+
+func TestProcessLogs(t *testing.T) {
+	srv := newSchemaServer(t)
+	sink := new(consumertest.LogsSink)
+	cfg := newTestConfig(srv.schemaURL("1.2.0"))
+	cfg.Prefetch = []string{srv.schemaURL("1.2.0")}
+	lp, err := NewFactory().CreateLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, lp.Shutdown(context.Background())) }()
+	assert.EqualValues(t, 1, srv.requests.Load())
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl(srv.schemaURL("1.1.0"))
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Attributes().PutStr("exception.msg", "timeout")
+
+	require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+	require.Len(t, sink.AllLogs(), 1)
+	rl = sink.AllLogs()[0].ResourceLogs().At(0)
+	assert.Equal(t, srv.schemaURL("1.2.0"), rl.SchemaUrl())
+	assert.Equal(t, map[string]any{"exception.message": "timeout"}, rl.ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+	assert.EqualValues(t, 1, srv.requests.Load())
+}
+
+func TestProcessUnavailableSchema(t *testing.T) {
+	srv := newSchemaServer(t)
+	sink := new(consumertest.LogsSink)
+	lp, err := NewFactory().CreateLogsProcessor(context.Background(), processortest.NewNopCreateSettings(),
+		newTestConfig(srv.schemaURL("1.3.0")), sink)
+	require.NoError(t, err)
+	require.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, lp.Shutdown(context.Background())) }()
+
+	for i := 0; i < 2; i++ {
+		ld := plog.NewLogs()
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl(srv.schemaURL("1.1.0"))
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().PutStr("exception.msg", "timeout")
+		require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+	}
+
+	// The telemetry is left unchanged, and the schema file is not fetched again before retryInterval.
+	require.Len(t, sink.AllLogs(), 2)
+	for _, ld := range sink.AllLogs() {
+		rl := ld.ResourceLogs().At(0)
+		assert.Equal(t, srv.schemaURL("1.1.0"), rl.SchemaUrl())
+		assert.Equal(t, map[string]any{"exception.msg": "timeout"}, rl.ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+	}
+	assert.EqualValues(t, 1, srv.requests.Load())
+}
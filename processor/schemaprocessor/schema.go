@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaprocessor // import "go.opentelemetry.io/collector/processor/schemaprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	errNoVersion            = errors.New("the schema URL does not end with a version")
+	errUnsupportedFormat    = errors.New("unsupported schema file format")
+	errUnknownVersion       = errors.New("unknown version")
+	errSchemaFamilyMismatch = errors.New("the schema file belongs to another schema family")
+)
+
+// version is a version of a schema, made of its major, minor and patch numbers.
+type version [3]uint64
+
+func parseVersion(s string) (version, error) {
+	var v version
+	parts := strings.Split(s, ".")
+	if len(parts) != len(v) {
+		return v, fmt.Errorf("invalid version %q", s)
+	}
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return v, fmt.Errorf("invalid version %q", s)
+		}
+		v[i] = n
+	}
+	return v, nil
+}
+
+func (v version) less(o version) bool {
+	for i := range v {
+		if v[i] != o[i] {
+			return v[i] < o[i]
+		}
+	}
+	return false
+}
+
+func (v version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+}
+
+// schemaURL is a parsed schema URL, e.g. "https://opentelemetry.io/schemas/1.9.0" whose family is
+// "https://opentelemetry.io/schemas" and version is 1.9.0.
+type schemaURL struct {
+	url     string
+	family  string
+	version version
+}
+
+func parseSchemaURL(s string) (schemaURL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return schemaURL{}, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return schemaURL{}, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	i := strings.LastIndex(s, "/")
+	if i < 0 || i == len(s)-1 {
+		return schemaURL{}, errNoVersion
+	}
+	v, err := parseVersion(s[i+1:])
+	if err != nil {
+		return schemaURL{}, fmt.Errorf("%w: %v", errNoVersion, err)
+	}
+	return schemaURL{url: s, family: s[:i], version: v}, nil
+}
+
+// schemaFile is the content of a schema file, as defined by the specification:
+// https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/schemas/file_format_v1.1.0.md
+type schemaFile struct {
+	FileFormat string                 `yaml:"file_format"`
+	SchemaURL  string                 `yaml:"schema_url"`
+	Versions   map[string]versionDiff `yaml:"versions"`
+}
+
+// versionDiff contains the changes from the previous version to a version.
+type versionDiff struct {
+	All        changeList `yaml:"all"`
+	Resources  changeList `yaml:"resources"`
+	Spans      changeList `yaml:"spans"`
+	SpanEvents changeList `yaml:"span_events"`
+	Metrics    changeList `yaml:"metrics"`
+	Logs       changeList `yaml:"logs"`
+}
+
+type changeList struct {
+	Changes []change `yaml:"changes"`
+}
+
+type change struct {
+	RenameAttributes *renameAttributes `yaml:"rename_attributes"`
+	RenameMetrics    map[string]string `yaml:"rename_metrics"`
+	RenameEvents     *renameEvents     `yaml:"rename_events"`
+}
+
+type renameAttributes struct {
+	AttributeMap   map[string]string `yaml:"attribute_map"`
+	ApplyToSpans   []string          `yaml:"apply_to_spans"`
+	ApplyToEvents  []string          `yaml:"apply_to_events"`
+	ApplyToMetrics []string          `yaml:"apply_to_metrics"`
+}
+
+type renameEvents struct {
+	NameMap map[string]string `yaml:"name_map"`
+}
+
+// schema is a decoded schema file.
+type schema struct {
+	family string
+	// versions contains the versions of the schema, in ascending order.
+	versions []version
+	diffs    map[version]versionDiff
+}
+
+func parseSchema(r io.Reader) (*schema, error) {
+	var f schemaFile
+	if err := yaml.NewDecoder(r).Decode(&f); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(f.FileFormat, "1.") {
+		return nil, fmt.Errorf("%w %q", errUnsupportedFormat, f.FileFormat)
+	}
+	id, err := parseSchemaURL(f.SchemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema_url %q: %w", f.SchemaURL, err)
+	}
+	s := &schema{family: id.family, diffs: make(map[version]versionDiff, len(f.Versions))}
+	for name, diff := range f.Versions {
+		v, err := parseVersion(name)
+		if err != nil {
+			return nil, err
+		}
+		s.versions = append(s.versions, v)
+		s.diffs[v] = diff
+	}
+	sort.Slice(s.versions, func(i, j int) bool { return s.versions[i].less(s.versions[j]) })
+	return s, nil
+}
+
+// translation returns the translation of the telemetry from the version from to the version to.
+func (s *schema) translation(from, to schemaURL) (*translation, error) {
+	if from.family != s.family || to.family != s.family {
+		return nil, errSchemaFamilyMismatch
+	}
+	for _, v := range []version{from.version, to.version} {
+		if _, ok := s.diffs[v]; !ok {
+			return nil, fmt.Errorf("%w %s of %s", errUnknownVersion, v, s.family)
+		}
+	}
+	t := &translation{}
+	if from.version.less(to.version) {
+		// Upgrading applies the changes of the versions after from up to to, in order.
+		for _, v := range s.versions {
+			if from.version.less(v) && !to.version.less(v) {
+				t.append(compileDiff(s.diffs[v]))
+			}
+		}
+		return t, nil
+	}
+	// Downgrading reverts the changes of the versions after to up to from, in reverse order.
+	for i := len(s.versions) - 1; i >= 0; i-- {
+		v := s.versions[i]
+		if to.version.less(v) && !from.version.less(v) {
+			t.append(compileDiff(s.diffs[v]).reverse())
+		}
+	}
+	return t, nil
+}
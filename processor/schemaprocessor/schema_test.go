@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaprocessor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func loadTestSchema(t *testing.T) *schema {
+	f, err := os.Open(filepath.Join("testdata", "schema.yaml"))
+	require.NoError(t, err)
+	defer f.Close()
+	s, err := parseSchema(f)
+	require.NoError(t, err)
+	return s
+}
+
+func mustParseSchemaURL(t *testing.T, url string) schemaURL {
+	id, err := parseSchemaURL(url)
+	require.NoError(t, err)
+	return id
+}
+
+func TestParseSchemaURL(t *testing.T) {
+	id, err := parseSchemaURL("https://opentelemetry.io/schemas/1.20.0")
+	require.NoError(t, err)
+	assert.Equal(t, schemaURL{
+		url:     "https://opentelemetry.io/schemas/1.20.0",
+		family:  "https://opentelemetry.io/schemas",
+		version: version{1, 20, 0},
+	}, id)
+	assert.True(t, mustParseSchemaURL(t, "https://opentelemetry.io/schemas/1.9.0").version.less(id.version))
+	assert.Equal(t, "1.20.0", id.version.String())
+
+	_, err = parseSchemaURL("https://opentelemetry.io/schemas/1.20")
+	assert.ErrorIs(t, err, errNoVersion)
+	_, err = parseSchemaURL("opentelemetry.io")
+	assert.Error(t, err)
+}
+
+func TestParseSchema(t *testing.T) {
+	s := loadTestSchema(t)
+	assert.Equal(t, "https://example.com/schemas", s.family)
+	assert.Equal(t, []version{{1, 0, 0}, {1, 1, 0}, {1, 2, 0}}, s.versions)
+
+	_, err := parseSchema(strings.NewReader("file_format: 2.0.0\nschema_url: https://example.com/schemas/1.0.0\n"))
+	assert.ErrorIs(t, err, errUnsupportedFormat)
+	_, err = parseSchema(strings.NewReader("file_format: 1.1.0\nschema_url: https://example.com/schemas\n"))
+	assert.ErrorIs(t, err, errNoVersion)
+	_, err = parseSchema(strings.NewReader("file_format: 1.1.0\nschema_url: https://example.com/schemas/1.0.0\nversions:\n  next:\n"))
+	assert.EqualError(t, err, `invalid version "next"`)
+}
+
+func TestSchemaTranslation(t *testing.T) {
+	s := loadTestSchema(t)
+	v100 := mustParseSchemaURL(t, "https://example.com/schemas/1.0.0")
+	v110 := mustParseSchemaURL(t, "https://example.com/schemas/1.1.0")
+	v120 := mustParseSchemaURL(t, "https://example.com/schemas/1.2.0")
+
+	attrs := func() pcommon.Map {
+		m := pcommon.NewMap()
+		m.PutStr("deployment.env", "prod")
+		m.PutStr("net.peer.ip", "10.0.0.1")
+		return m
+	}
+
+	up, err := s.translation(v100, v120)
+	require.NoError(t, err)
+	res := pcommon.NewResource()
+	attrs().CopyTo(res.Attributes())
+	up.translateResource(res)
+	assert.Equal(t, map[string]any{
+		"deployment.environment": "prod",
+		"net.sock.peer.addr":     "10.0.0.1",
+	}, res.Attributes().AsRaw())
+
+	down, err := s.translation(v120, v110)
+	require.NoError(t, err)
+	down.translateResource(res)
+	assert.Equal(t, map[string]any{
+		"deployment.environment": "prod",
+		"net.peer.ip":            "10.0.0.1",
+	}, res.Attributes().AsRaw())
+
+	down, err = s.translation(v110, v100)
+	require.NoError(t, err)
+	down.translateResource(res)
+	assert.Equal(t, attrs().AsRaw(), res.Attributes().AsRaw())
+
+	_, err = s.translation(v100, mustParseSchemaURL(t, "https://example.com/schemas/1.3.0"))
+	assert.ErrorIs(t, err, errUnknownVersion)
+	_, err = s.translation(v100, mustParseSchemaURL(t, "https://opentelemetry.io/schemas/1.2.0"))
+	assert.ErrorIs(t, err, errSchemaFamilyMismatch)
+}
+
+func TestRenameAttributes(t *testing.T) {
+	attrs := pcommon.NewMap()
+	attrs.PutStr("a", "1")
+	attrs.PutStr("b", "2")
+	attrs.PutStr("c", "3")
+	attrs.PutStr("d", "4")
+
+	// "a" and "b" are swapped, "c" is not renamed over the existing "d".
+	renameAttributesOf(attrs, map[string]string{"a": "b", "b": "a", "c": "d", "e": "f"})
+	assert.Equal(t, map[string]any{"a": "2", "b": "1", "c": "3", "d": "4"}, attrs.AsRaw())
+}
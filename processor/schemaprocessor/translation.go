@@ -0,0 +1,265 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaprocessor // import "go.opentelemetry.io/collector/processor/schemaprocessor"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// operation renames either the attributes or the names of the metrics or span events.
+// The attributes are only renamed on the telemetry matching all the non nil filters.
+type operation struct {
+	attributes map[string]string
+	names      map[string]string
+
+	spans   map[string]struct{}
+	events  map[string]struct{}
+	metrics map[string]struct{}
+}
+
+func newRenameAttributes(ra *renameAttributes) operation {
+	return operation{
+		attributes: ra.AttributeMap,
+		spans:      toSet(ra.ApplyToSpans),
+		events:     toSet(ra.ApplyToEvents),
+		metrics:    toSet(ra.ApplyToMetrics),
+	}
+}
+
+func toSet(names []string) map[string]struct{} {
+	if len(names) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		set[name] = struct{}{}
+	}
+	return set
+}
+
+func matches(set map[string]struct{}, name string) bool {
+	if set == nil {
+		return true
+	}
+	_, ok := set[name]
+	return ok
+}
+
+func (op operation) reverse() operation {
+	op.attributes = invert(op.attributes)
+	op.names = invert(op.names)
+	return op
+}
+
+func invert(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	inv := make(map[string]string, len(m))
+	for k, v := range m {
+		inv[v] = k
+	}
+	return inv
+}
+
+// translation contains the operations translating each kind of telemetry, in order.
+type translation struct {
+	resources  []operation
+	spans      []operation
+	spanEvents []operation
+	metrics    []operation
+	logs       []operation
+}
+
+// compileDiff returns the translation applying the changes of a version, the changes
+// of the "all" section being applied first.
+func compileDiff(d versionDiff) *translation {
+	t := &translation{}
+	for _, c := range d.All.Changes {
+		if c.RenameAttributes == nil {
+			continue
+		}
+		op := newRenameAttributes(c.RenameAttributes)
+		t.resources = append(t.resources, op)
+		t.spans = append(t.spans, op)
+		t.spanEvents = append(t.spanEvents, op)
+		t.metrics = append(t.metrics, op)
+		t.logs = append(t.logs, op)
+	}
+	for _, c := range d.Resources.Changes {
+		if c.RenameAttributes != nil {
+			t.resources = append(t.resources, operation{attributes: c.RenameAttributes.AttributeMap})
+		}
+	}
+	for _, c := range d.Spans.Changes {
+		if c.RenameAttributes != nil {
+			t.spans = append(t.spans, newRenameAttributes(c.RenameAttributes))
+		}
+	}
+	for _, c := range d.SpanEvents.Changes {
+		if c.RenameEvents != nil {
+			t.spanEvents = append(t.spanEvents, operation{names: c.RenameEvents.NameMap})
+		}
+		if c.RenameAttributes != nil {
+			t.spanEvents = append(t.spanEvents, newRenameAttributes(c.RenameAttributes))
+		}
+	}
+	for _, c := range d.Metrics.Changes {
+		if c.RenameMetrics != nil {
+			t.metrics = append(t.metrics, operation{names: c.RenameMetrics})
+		}
+		if c.RenameAttributes != nil {
+			t.metrics = append(t.metrics, newRenameAttributes(c.RenameAttributes))
+		}
+	}
+	for _, c := range d.Logs.Changes {
+		if c.RenameAttributes != nil {
+			t.logs = append(t.logs, newRenameAttributes(c.RenameAttributes))
+		}
+	}
+	return t
+}
+
+// reverse returns the translation reverting t.
+func (t *translation) reverse() *translation {
+	return &translation{
+		resources:  reverseOperations(t.resources),
+		spans:      reverseOperations(t.spans),
+		spanEvents: reverseOperations(t.spanEvents),
+		metrics:    reverseOperations(t.metrics),
+		logs:       reverseOperations(t.logs),
+	}
+}
+
+func reverseOperations(ops []operation) []operation {
+	rev := make([]operation, len(ops))
+	for i, op := range ops {
+		rev[len(ops)-1-i] = op.reverse()
+	}
+	return rev
+}
+
+// append appends the operations of o to the ones of t.
+func (t *translation) append(o *translation) {
+	t.resources = append(t.resources, o.resources...)
+	t.spans = append(t.spans, o.spans...)
+	t.spanEvents = append(t.spanEvents, o.spanEvents...)
+	t.metrics = append(t.metrics, o.metrics...)
+	t.logs = append(t.logs, o.logs...)
+}
+
+func (t *translation) translateResource(res pcommon.Resource) {
+	for _, op := range t.resources {
+		renameAttributesOf(res.Attributes(), op.attributes)
+	}
+}
+
+func (t *translation) translateSpan(span ptrace.Span) {
+	for _, op := range t.spans {
+		if matches(op.spans, span.Name()) {
+			renameAttributesOf(span.Attributes(), op.attributes)
+		}
+	}
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		for _, op := range t.spanEvents {
+			if op.names != nil {
+				if name, ok := op.names[event.Name()]; ok {
+					event.SetName(name)
+				}
+				continue
+			}
+			if matches(op.spans, span.Name()) && matches(op.events, event.Name()) {
+				renameAttributesOf(event.Attributes(), op.attributes)
+			}
+		}
+	}
+}
+
+func (t *translation) translateMetric(metric pmetric.Metric) {
+	for _, op := range t.metrics {
+		if op.names != nil {
+			if name, ok := op.names[metric.Name()]; ok {
+				metric.SetName(name)
+			}
+			continue
+		}
+		if matches(op.metrics, metric.Name()) {
+			renameDataPointAttributes(metric, op.attributes)
+		}
+	}
+}
+
+func (t *translation) translateLogRecord(lr plog.LogRecord) {
+	for _, op := range t.logs {
+		renameAttributesOf(lr.Attributes(), op.attributes)
+	}
+}
+
+func renameDataPointAttributes(metric pmetric.Metric, mapping map[string]string) {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			renameAttributesOf(dps.At(i).Attributes(), mapping)
+		}
+	case pmetric.MetricTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			renameAttributesOf(dps.At(i).Attributes(), mapping)
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			renameAttributesOf(dps.At(i).Attributes(), mapping)
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			renameAttributesOf(dps.At(i).Attributes(), mapping)
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			renameAttributesOf(dps.At(i).Attributes(), mapping)
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+}
+
+// renameAttributesOf renames the attributes according to mapping. The renames are applied
+// simultaneously, and an attribute is not renamed when its new name is already used
+// by an attribute that is not renamed.
+func renameAttributesOf(attrs pcommon.Map, mapping map[string]string) {
+	if len(mapping) == 0 || attrs.Len() == 0 {
+		return
+	}
+	renamed := map[string]pcommon.Value{}
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		to, ok := mapping[k]
+		if !ok || to == k {
+			return true
+		}
+		if _, used := attrs.Get(to); used {
+			if _, moved := mapping[to]; !moved {
+				return true
+			}
+		}
+		val := pcommon.NewValueEmpty()
+		v.CopyTo(val)
+		renamed[k] = val
+		return true
+	})
+	attrs.RemoveIf(func(k string, _ pcommon.Value) bool {
+		_, ok := renamed[k]
+		return ok
+	})
+	for k, v := range renamed {
+		v.CopyTo(attrs.PutEmpty(mapping[k]))
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package transformliteprocessor // import "go.opentelemetry.io/collector/processor/transformliteprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Action is the kind of change an action makes to the attributes.
+type Action string
+
+const (
+	// ActionSet sets the attribute Key to Value, or to the value of the attribute FromAttribute.
+	ActionSet Action = "set"
+	// ActionDelete deletes the attribute Key, or the attributes whose key matches Pattern.
+	ActionDelete Action = "delete"
+	// ActionRename renames the attribute Key to NewKey.
+	ActionRename Action = "rename"
+	// ActionHash replaces the value of the attribute Key, or of the attributes whose key matches Pattern,
+	// by the hex encoded SHA-256 hash of its string representation.
+	ActionHash Action = "hash"
+	// ActionExtract sets the attributes named by the named groups of Pattern to the parts of
+	// the value of the attribute Key they match.
+	ActionExtract Action = "extract"
+)
+
+// Context is the attributes an action applies to.
+type Context string
+
+const (
+	// ContextAttributes applies the action to the attributes of the spans, metric data points and log records.
+	ContextAttributes Context = "attributes"
+	// ContextResource applies the action to the attributes of the resources.
+	ContextResource Context = "resource"
+)
+
+var (
+	errNoActions          = errors.New("no actions specified")
+	errMissingKey         = errors.New("missing key")
+	errKeyAndPattern      = errors.New("only one of key or pattern can be set")
+	errMissingKeyPattern  = errors.New("one of key or pattern must be set")
+	errValueAndAttribute  = errors.New("only one of value or from_attribute can be set")
+	errMissingValue       = errors.New("one of value or from_attribute must be set")
+	errMissingNewKey      = errors.New("missing new_key")
+	errMissingPattern     = errors.New("missing pattern")
+	errNoNamedGroups      = errors.New("the pattern has no named groups")
+	errUnexpectedSettings = errors.New("only key and pattern can be set")
+	errUnexpectedPattern  = errors.New("pattern cannot be set")
+)
+
+// Config defines configuration for the transform lite processor.
+type Config struct {
+	// Actions contains the actions applied to the attributes, in order.
+	Actions []ActionKeyValue `mapstructure:"actions"`
+}
+
+// ActionKeyValue is an action applied to the attributes.
+type ActionKeyValue struct {
+	// Key is the key of the attribute the action applies to.
+	Key string `mapstructure:"key"`
+
+	// Pattern is a regular expression matching the keys of the attributes the delete and hash actions apply to,
+	// or the value the extract action is applied to.
+	Pattern string `mapstructure:"pattern"`
+
+	// Action is the kind of change made to the attributes.
+	Action Action `mapstructure:"action"`
+
+	// Value is the value set by the set action.
+	Value any `mapstructure:"value"`
+
+	// FromAttribute is the key of the attribute whose value is set by the set action.
+	FromAttribute string `mapstructure:"from_attribute"`
+
+	// NewKey is the new key of the attribute renamed by the rename action.
+	NewKey string `mapstructure:"new_key"`
+
+	// Context is the attributes the action applies to, the attributes of the spans, metric data points
+	// and log records by default.
+	Context Context `mapstructure:"context"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.Actions) == 0 {
+		return errNoActions
+	}
+	for i, a := range cfg.Actions {
+		if err := a.validate(); err != nil {
+			return fmt.Errorf("actions[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (a *ActionKeyValue) validate() error {
+	switch a.Context {
+	case "", ContextAttributes, ContextResource:
+	default:
+		return fmt.Errorf("unsupported context %q", a.Context)
+	}
+	if a.Pattern != "" {
+		if _, err := regexp.Compile(a.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", a.Pattern, err)
+		}
+	}
+
+	switch a.Action {
+	case ActionSet:
+		if a.Key == "" {
+			return errMissingKey
+		}
+		if a.Value != nil && a.FromAttribute != "" {
+			return errValueAndAttribute
+		}
+		if a.Value == nil && a.FromAttribute == "" {
+			return errMissingValue
+		}
+		if a.Pattern != "" {
+			return errUnexpectedPattern
+		}
+	case ActionDelete, ActionHash:
+		if a.Key != "" && a.Pattern != "" {
+			return errKeyAndPattern
+		}
+		if a.Key == "" && a.Pattern == "" {
+			return errMissingKeyPattern
+		}
+		if a.Value != nil || a.FromAttribute != "" || a.NewKey != "" {
+			return errUnexpectedSettings
+		}
+	case ActionRename:
+		if a.Key == "" {
+			return errMissingKey
+		}
+		if a.NewKey == "" {
+			return errMissingNewKey
+		}
+		if a.Pattern != "" {
+			return errUnexpectedPattern
+		}
+	case ActionExtract:
+		if a.Key == "" {
+			return errMissingKey
+		}
+		if a.Pattern == "" {
+			return errMissingPattern
+		}
+		if !hasNamedGroups(regexp.MustCompile(a.Pattern)) {
+			return errNoNamedGroups
+		}
+	default:
+		return fmt.Errorf("unsupported action %q", a.Action)
+	}
+	return nil
+}
+
+func hasNamedGroups(re *regexp.Regexp) bool {
+	for _, name := range re.SubexpNames() {
+		if name != "" {
+			return true
+		}
+	}
+	return false
+}
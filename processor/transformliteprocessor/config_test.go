@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package transformliteprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t, &Config{
+		Actions: []ActionKeyValue{
+			{Key: "deployment.environment", Action: ActionSet, Value: "production", Context: ContextResource},
+			{Key: "http.client_ip", Action: ActionSet, FromAttribute: "client.address"},
+			{Pattern: "^password|^token", Action: ActionDelete},
+			{Key: "user.email", Action: ActionHash},
+			{Key: "http.target", Action: ActionRename, NewKey: "url.path"},
+			{Key: "url.path", Pattern: `^/api/(?P<api_version>v\d+)/(?P<api_resource>\w+)`, Action: ActionExtract},
+		},
+	}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   ActionKeyValue
+		expected string
+	}{
+		{
+			name:     "unsupported action",
+			action:   ActionKeyValue{Key: "a", Action: "upsert"},
+			expected: `actions[0]: unsupported action "upsert"`,
+		},
+		{
+			name:     "unsupported context",
+			action:   ActionKeyValue{Key: "a", Action: ActionDelete, Context: "span"},
+			expected: `actions[0]: unsupported context "span"`,
+		},
+		{
+			name:     "invalid pattern",
+			action:   ActionKeyValue{Pattern: "(", Action: ActionDelete},
+			expected: "actions[0]: invalid pattern \"(\": error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name:     "set without key",
+			action:   ActionKeyValue{Action: ActionSet, Value: "b"},
+			expected: "actions[0]: missing key",
+		},
+		{
+			name:     "set without value",
+			action:   ActionKeyValue{Key: "a", Action: ActionSet},
+			expected: "actions[0]: one of value or from_attribute must be set",
+		},
+		{
+			name:     "set with value and from_attribute",
+			action:   ActionKeyValue{Key: "a", Action: ActionSet, Value: "b", FromAttribute: "c"},
+			expected: "actions[0]: only one of value or from_attribute can be set",
+		},
+		{
+			name:     "set with pattern",
+			action:   ActionKeyValue{Key: "a", Pattern: "b", Action: ActionSet, Value: "b"},
+			expected: "actions[0]: pattern cannot be set",
+		},
+		{
+			name:     "delete without key",
+			action:   ActionKeyValue{Action: ActionDelete},
+			expected: "actions[0]: one of key or pattern must be set",
+		},
+		{
+			name:     "hash with key and pattern",
+			action:   ActionKeyValue{Key: "a", Pattern: "b", Action: ActionHash},
+			expected: "actions[0]: only one of key or pattern can be set",
+		},
+		{
+			name:     "hash with value",
+			action:   ActionKeyValue{Key: "a", Action: ActionHash, Value: "b"},
+			expected: "actions[0]: only key and pattern can be set",
+		},
+		{
+			name:     "rename without new key",
+			action:   ActionKeyValue{Key: "a", Action: ActionRename},
+			expected: "actions[0]: missing new_key",
+		},
+		{
+			name:     "extract without pattern",
+			action:   ActionKeyValue{Key: "a", Action: ActionExtract},
+			expected: "actions[0]: missing pattern",
+		},
+		{
+			name:     "extract without named groups",
+			action:   ActionKeyValue{Key: "a", Pattern: "(v1)", Action: ActionExtract},
+			expected: "actions[0]: the pattern has no named groups",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Actions: []ActionKeyValue{tt.action}}
+			assert.EqualError(t, cfg.Validate(), tt.expected)
+		})
+	}
+	assert.ErrorIs(t, (&Config{}).Validate(), errNoActions)
+}
@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package transformliteprocessor sets, deletes, renames, hashes and extracts the attributes
+// of the telemetry and of its resources.
+package transformliteprocessor // import "go.opentelemetry.io/collector/processor/transformliteprocessor"
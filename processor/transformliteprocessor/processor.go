@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package transformliteprocessor // import "go.opentelemetry.io/collector/processor/transformliteprocessor"
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// attributeAction is a compiled action of the configuration.
+type attributeAction struct {
+	ActionKeyValue
+	regexp *regexp.Regexp
+	value  pcommon.Value
+}
+
+type transformLiteProcessor struct {
+	resourceActions  []attributeAction
+	attributeActions []attributeAction
+}
+
+func newTransformLiteProcessor(cfg *Config) (*transformLiteProcessor, error) {
+	tp := &transformLiteProcessor{}
+	for _, a := range cfg.Actions {
+		action := attributeAction{ActionKeyValue: a}
+		if a.Pattern != "" {
+			re, err := regexp.Compile(a.Pattern)
+			if err != nil {
+				return nil, err
+			}
+			action.regexp = re
+		}
+		if a.Value != nil {
+			action.value = pcommon.NewValueEmpty()
+			if err := action.value.FromRaw(a.Value); err != nil {
+				return nil, err
+			}
+		}
+		if a.Context == ContextResource {
+			tp.resourceActions = append(tp.resourceActions, action)
+		} else {
+			tp.attributeActions = append(tp.attributeActions, action)
+		}
+	}
+	return tp, nil
+}
+
+func (tp *transformLiteProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		applyActions(tp.resourceActions, rs.Resource().Attributes())
+		if len(tp.attributeActions) == 0 {
+			continue
+		}
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				applyActions(tp.attributeActions, spans.At(k).Attributes())
+			}
+		}
+	}
+	return td, nil
+}
+
+func (tp *transformLiteProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		applyActions(tp.resourceActions, rm.Resource().Attributes())
+		if len(tp.attributeActions) == 0 {
+			continue
+		}
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				tp.processMetricDataPoints(metrics.At(k))
+			}
+		}
+	}
+	return md, nil
+}
+
+func (tp *transformLiteProcessor) processMetricDataPoints(metric pmetric.Metric) {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			applyActions(tp.attributeActions, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			applyActions(tp.attributeActions, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			applyActions(tp.attributeActions, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			applyActions(tp.attributeActions, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			applyActions(tp.attributeActions, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+}
+
+func (tp *transformLiteProcessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		applyActions(tp.resourceActions, rl.Resource().Attributes())
+		if len(tp.attributeActions) == 0 {
+			continue
+		}
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				applyActions(tp.attributeActions, lrs.At(k).Attributes())
+			}
+		}
+	}
+	return ld, nil
+}
+
+func applyActions(actions []attributeAction, attrs pcommon.Map) {
+	for i := range actions {
+		actions[i].apply(attrs)
+	}
+}
+
+func (a *attributeAction) apply(attrs pcommon.Map) {
+	switch a.Action {
+	case ActionSet:
+		if a.FromAttribute == "" {
+			a.value.CopyTo(attrs.PutEmpty(a.Key))
+			return
+		}
+		if v, ok := attrs.Get(a.FromAttribute); ok {
+			// The value is copied before PutEmpty, which may move the existing values.
+			val := pcommon.NewValueEmpty()
+			v.CopyTo(val)
+			val.CopyTo(attrs.PutEmpty(a.Key))
+		}
+	case ActionDelete:
+		if a.regexp == nil {
+			attrs.Remove(a.Key)
+			return
+		}
+		attrs.RemoveIf(func(k string, _ pcommon.Value) bool {
+			return a.regexp.MatchString(k)
+		})
+	case ActionRename:
+		v, ok := attrs.Get(a.Key)
+		if !ok || a.Key == a.NewKey {
+			return
+		}
+		val := pcommon.NewValueEmpty()
+		v.CopyTo(val)
+		attrs.Remove(a.Key)
+		val.CopyTo(attrs.PutEmpty(a.NewKey))
+	case ActionHash:
+		if a.regexp == nil {
+			if v, ok := attrs.Get(a.Key); ok {
+				hashValue(v)
+			}
+			return
+		}
+		attrs.Range(func(k string, v pcommon.Value) bool {
+			if a.regexp.MatchString(k) {
+				hashValue(v)
+			}
+			return true
+		})
+	case ActionExtract:
+		v, ok := attrs.Get(a.Key)
+		if !ok || v.Type() != pcommon.ValueTypeStr {
+			return
+		}
+		matches := a.regexp.FindStringSubmatch(v.Str())
+		if matches == nil {
+			return
+		}
+		for i, name := range a.regexp.SubexpNames() {
+			if name != "" {
+				attrs.PutStr(name, matches[i])
+			}
+		}
+	}
+}
+
+// hashValue replaces the value by the hex encoded SHA-256 hash of its string representation.
+func hashValue(v pcommon.Value) {
+	sum := sha256.Sum256([]byte(v.AsString()))
+	v.SetStr(hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package transformliteprocessor
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func TestApplyActions(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   ActionKeyValue
+		input    map[string]any
+		expected map[string]any
+	}{
+		{
+			name:     "set value",
+			action:   ActionKeyValue{Key: "a", Action: ActionSet, Value: 1},
+			input:    map[string]any{"a": "b"},
+			expected: map[string]any{"a": int64(1)},
+		},
+		{
+			name:     "set from attribute",
+			action:   ActionKeyValue{Key: "a", Action: ActionSet, FromAttribute: "b"},
+			input:    map[string]any{"b": true},
+			expected: map[string]any{"a": true, "b": true},
+		},
+		{
+			name:     "set from missing attribute",
+			action:   ActionKeyValue{Key: "a", Action: ActionSet, FromAttribute: "b"},
+			input:    map[string]any{"c": "d"},
+			expected: map[string]any{"c": "d"},
+		},
+		{
+			name:     "delete key",
+			action:   ActionKeyValue{Key: "a", Action: ActionDelete},
+			input:    map[string]any{"a": "b", "c": "d"},
+			expected: map[string]any{"c": "d"},
+		},
+		{
+			name:     "delete pattern",
+			action:   ActionKeyValue{Pattern: "^secret\\.", Action: ActionDelete},
+			input:    map[string]any{"secret.token": "t", "secret.password": "p", "user": "u"},
+			expected: map[string]any{"user": "u"},
+		},
+		{
+			name:     "rename",
+			action:   ActionKeyValue{Key: "a", Action: ActionRename, NewKey: "b"},
+			input:    map[string]any{"a": "1", "b": "2"},
+			expected: map[string]any{"b": "1"},
+		},
+		{
+			name:     "rename missing attribute",
+			action:   ActionKeyValue{Key: "a", Action: ActionRename, NewKey: "b"},
+			input:    map[string]any{"b": "2"},
+			expected: map[string]any{"b": "2"},
+		},
+		{
+			name:   "hash key",
+			action: ActionKeyValue{Key: "user.email", Action: ActionHash},
+			input:  map[string]any{"user.email": "john@example.com", "user.id": int64(1)},
+			expected: map[string]any{
+				"user.email": "855f96e983f1f8e8be944692b6f719fd54329826cb62e98015efee8e2e071dd4",
+				"user.id":    int64(1),
+			},
+		},
+		{
+			name:     "hash pattern",
+			action:   ActionKeyValue{Pattern: "^user\\.", Action: ActionHash},
+			input:    map[string]any{"user.id": int64(1), "http.method": "GET"},
+			expected: map[string]any{"user.id": "6b86b273ff34fce19d6b804eff5a3f5747ada4eaa22f1d49c01e52ddb7875b4b", "http.method": "GET"},
+		},
+		{
+			name:   "extract",
+			action: ActionKeyValue{Key: "url.path", Pattern: `^/api/(?P<api_version>v\d+)/(?P<api_resource>\w+)`, Action: ActionExtract},
+			input:  map[string]any{"url.path": "/api/v2/users/42"},
+			expected: map[string]any{
+				"url.path":     "/api/v2/users/42",
+				"api_version":  "v2",
+				"api_resource": "users",
+			},
+		},
+		{
+			name:     "extract not matching",
+			action:   ActionKeyValue{Key: "url.path", Pattern: `^/api/(?P<api_version>v\d+)`, Action: ActionExtract},
+			input:    map[string]any{"url.path": "/health"},
+			expected: map[string]any{"url.path": "/health"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tp, err := newTransformLiteProcessor(&Config{Actions: []ActionKeyValue{tt.action}})
+			require.NoError(t, err)
+			attrs := pcommon.NewMap()
+			require.NoError(t, attrs.FromRaw(tt.input))
+			applyActions(tp.attributeActions, attrs)
+			assert.Equal(t, tt.expected, attrs.AsRaw())
+		})
+	}
+}
+
+func newTestConfig(t *testing.T) *Config {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := createDefaultConfig()
+	require.NoError(t, component.UnmarshalConfig(cm, cfg))
+	return cfg.(*Config)
+}
+
+func fillAttributes(attrs pcommon.Map) {
+	attrs.PutStr("client.address", "10.0.0.1")
+	attrs.PutStr("password", "secret")
+	attrs.PutStr("user.email", "john@example.com")
+	attrs.PutStr("http.target", "/api/v1/orders")
+}
+
+var expectedAttributes = map[string]any{
+	"client.address": "10.0.0.1",
+	"http.client_ip": "10.0.0.1",
+	"user.email":     "855f96e983f1f8e8be944692b6f719fd54329826cb62e98015efee8e2e071dd4",
+	"url.path":       "/api/v1/orders",
+	"api_version":    "v1",
+	"api_resource":   "orders",
+}
+
+func TestProcessTraces(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	tp, err := NewFactory().CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), newTestConfig(t), sink)
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("password", "secret")
+	fillAttributes(rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes())
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+
+	rs = sink.AllTraces()[0].ResourceSpans().At(0)
+	// The resource actions only apply to the resource, and the other actions only to the spans.
+	assert.Equal(t, map[string]any{"password": "secret", "deployment.environment": "production"}, rs.Resource().Attributes().AsRaw())
+	assert.Equal(t, expectedAttributes, rs.ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw())
+}
+
+func TestProcessMetrics(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	mp, err := NewFactory().CreateMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), newTestConfig(t), sink)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+	fillAttributes(metrics.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty().Attributes())
+	fillAttributes(metrics.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty().Attributes())
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+
+	rm = sink.AllMetrics()[0].ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{"deployment.environment": "production"}, rm.Resource().Attributes().AsRaw())
+	metrics = rm.ScopeMetrics().At(0).Metrics()
+	assert.Equal(t, expectedAttributes, metrics.At(0).Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, expectedAttributes, metrics.At(1).Histogram().DataPoints().At(0).Attributes().AsRaw())
+}
+
+func TestProcessLogs(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	lp, err := NewFactory().CreateLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), newTestConfig(t), sink)
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	fillAttributes(rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes())
+	require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+
+	rl = sink.AllLogs()[0].ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{"deployment.environment": "production"}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, expectedAttributes, rl.ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filereceiver // import "go.opentelemetry.io/collector/receiver/filereceiver"
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
+)
+
+const (
+	formatTypeJSON  = "json"
+	formatTypeProto = "proto"
+
+	startAtBeginning = "beginning"
+	startAtEnd       = "end"
+)
+
+// Config defines configuration for the file receiver.
+type Config struct {
+	// Include are the glob patterns of the files to read.
+	Include []string `mapstructure:"include"`
+
+	// Format is the format of the files, as written by the file exporter: json (default) reads one OTLP JSON
+	// document per line, proto reads the OTLP file records of the otlpfile package.
+	Format string `mapstructure:"format"`
+
+	// Compression is the compression of the batches of the files, gzip and zstd are supported.
+	// The compressed batches are read from OTLP file records, whatever the format.
+	Compression configcompression.CompressionType `mapstructure:"compression"`
+
+	// PollInterval is the interval at which the files are checked for new data.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// StartAt is where the files found at start without a checkpoint are read from, beginning (default) or end.
+	StartAt string `mapstructure:"start_at"`
+
+	// DeleteAfterRead deletes the files once all their data has been received.
+	DeleteAfterRead bool `mapstructure:"delete_after_read"`
+
+	// Storage is the ID of the storage extension the progress in the files is checkpointed to,
+	// so that it is resumed after a restart. The files are read from StartAt after a restart if not set.
+	Storage *component.ID `mapstructure:"storage"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.Include) == 0 {
+		return errors.New("include must not be empty")
+	}
+	for _, pattern := range cfg.Include {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid include pattern %q: %w", pattern, err)
+		}
+	}
+	if cfg.Format != formatTypeJSON && cfg.Format != formatTypeProto {
+		return fmt.Errorf("format type %q is not supported", cfg.Format)
+	}
+	switch cfg.Compression {
+	case "", configcompression.Gzip, configcompression.Zstd:
+	default:
+		return fmt.Errorf("compression %q is not supported", cfg.Compression)
+	}
+	if cfg.PollInterval <= 0 {
+		return errors.New("poll_interval must be positive")
+	}
+	if cfg.StartAt != startAtBeginning && cfg.StartAt != startAtEnd {
+		return fmt.Errorf("start_at %q is not supported, must be %q or %q", cfg.StartAt, startAtBeginning, startAtEnd)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filereceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	storageID := component.NewID("local_file_storage")
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr string
+	}{
+		{
+			id: component.NewID(typeStr),
+			expected: &Config{
+				Include:      []string{"./data/*.json"},
+				Format:       formatTypeJSON,
+				PollInterval: time.Second,
+				StartAt:      startAtBeginning,
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "proto"),
+			expected: &Config{
+				Include:         []string{"./data/*.binpb", "./archive/*.binpb"},
+				Format:          formatTypeProto,
+				Compression:     configcompression.Zstd,
+				PollInterval:    5 * time.Second,
+				StartAt:         startAtEnd,
+				DeleteAfterRead: true,
+				Storage:         &storageID,
+			},
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "no_include"),
+			expectedErr: "include must not be empty",
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "invalid_include"),
+			expectedErr: `invalid include pattern "[a-": syntax error in pattern`,
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "invalid_format"),
+			expectedErr: `format type "text" is not supported`,
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "invalid_compression"),
+			expectedErr: `compression "snappy" is not supported`,
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "invalid_poll_interval"),
+			expectedErr: "poll_interval must be positive",
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "invalid_start_at"),
+			expectedErr: `start_at "middle" is not supported, must be "beginning" or "end"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := createDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
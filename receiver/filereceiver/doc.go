@@ -0,0 +1,5 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package filereceiver receives the data written to files by the file exporter.
+package filereceiver // import "go.opentelemetry.io/collector/receiver/filereceiver"
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filereceiver // import "go.opentelemetry.io/collector/receiver/filereceiver"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/sharedcomponent"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr             = "file"
+	defaultPollInterval = time.Second
+)
+
+// NewFactory creates a factory for the file receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiver.WithTraces(createTraces, component.StabilityLevelDevelopment),
+		receiver.WithMetrics(createMetrics, component.StabilityLevelDevelopment),
+		receiver.WithLogs(createLogs, component.StabilityLevelDevelopment),
+		receiver.WithProfiles(createProfiles, component.StabilityLevelDevelopment))
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Format:       formatTypeJSON,
+		PollInterval: defaultPollInterval,
+		StartAt:      startAtBeginning,
+	}
+}
+
+// receivers shares the receiver of a configuration between the pipelines of the different signals,
+// so that the files are read once and their batches dispatched by signal.
+var receivers = sharedcomponent.NewSharedComponents[*Config, *fileReceiver]()
+
+func getOrCreateReceiver(cfg *Config, set receiver.CreateSettings) (*sharedcomponent.SharedComponent[*fileReceiver], error) {
+	return receivers.GetOrAdd(cfg, func() (*fileReceiver, error) {
+		return newFileReceiver(cfg, set)
+	})
+}
+
+func createTraces(_ context.Context, set receiver.CreateSettings, cfg component.Config, next consumer.Traces) (receiver.Traces, error) {
+	r, err := getOrCreateReceiver(cfg.(*Config), set)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().consumers.traces = next
+	return r, nil
+}
+
+func createMetrics(_ context.Context, set receiver.CreateSettings, cfg component.Config, next consumer.Metrics) (receiver.Metrics, error) {
+	r, err := getOrCreateReceiver(cfg.(*Config), set)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().consumers.metrics = next
+	return r, nil
+}
+
+func createLogs(_ context.Context, set receiver.CreateSettings, cfg component.Config, next consumer.Logs) (receiver.Logs, error) {
+	r, err := getOrCreateReceiver(cfg.(*Config), set)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().consumers.logs = next
+	return r, nil
+}
+
+func createProfiles(_ context.Context, set receiver.CreateSettings, cfg component.Config, next consumer.Profiles) (receiver.Profiles, error) {
+	r, err := getOrCreateReceiver(cfg.(*Config), set)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().consumers.profiles = next
+	return r, nil
+}
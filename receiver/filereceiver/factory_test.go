@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filereceiver
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestCreateReceivers(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join(t.TempDir(), "*.json")}
+	set := receivertest.NewNopCreateSettings()
+
+	tr, err := factory.CreateTracesReceiver(context.Background(), set, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	mr, err := factory.CreateMetricsReceiver(context.Background(), set, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	lr, err := factory.CreateLogsReceiver(context.Background(), set, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	pr, err := factory.CreateProfilesReceiver(context.Background(), set, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	// The receivers of the same configuration share the files.
+	receivers := []component.Component{tr, mr, lr, pr}
+	for _, r := range receivers {
+		require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	}
+	for _, r := range receivers {
+		require.NoError(t, r.Shutdown(context.Background()))
+	}
+}
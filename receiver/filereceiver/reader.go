@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filereceiver // import "go.opentelemetry.io/collector/receiver/filereceiver"
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/otlpfile"
+)
+
+// errIncomplete is returned when the last batch of a file is not completely written yet.
+var errIncomplete = errors.New("incomplete batch")
+
+// recordReader reads the batches of a file, as written by the file exporter.
+type recordReader struct {
+	r *bufio.Reader
+	// records is set when the batches are written as OTLP file records, nil when they are JSON lines.
+	records *otlpfile.Reader
+}
+
+func newRecordReader(r io.Reader, records bool) *recordReader {
+	rr := &recordReader{r: bufio.NewReader(r)}
+	if records {
+		rr.records = otlpfile.NewReader(rr.r)
+	}
+	return rr
+}
+
+// next returns the next batch and the number of bytes it takes in the file, errIncomplete if
+// the next batch is not completely written yet, or io.EOF at the end of the file. The signal of
+// the JSON lines is not set, it is detected from their document.
+func (rr *recordReader) next() (otlpfile.Record, int64, error) {
+	if rr.records == nil {
+		line, err := rr.r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(line) == 0 {
+				return otlpfile.Record{}, 0, io.EOF
+			}
+			return otlpfile.Record{}, 0, errIncomplete
+		}
+		if err != nil {
+			return otlpfile.Record{}, 0, err
+		}
+		return otlpfile.Record{Encoding: otlpfile.EncodingJSON, Data: bytes.TrimSpace(line)}, int64(len(line)), nil
+	}
+
+	rec, err := rr.records.Next()
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return otlpfile.Record{}, 0, errIncomplete
+	}
+	if err != nil {
+		return otlpfile.Record{}, 0, err
+	}
+	return rec, int64(rec.Size()), nil
+}
+
+// decompressor decompresses the data of the records.
+type decompressor struct {
+	// zstd is created once a zstd record is read, and reused for the next ones.
+	zstd *zstd.Decoder
+}
+
+// decompress returns the data of the record, decompressed.
+func (d *decompressor) decompress(rec otlpfile.Record) ([]byte, error) {
+	switch rec.Compression {
+	case otlpfile.CompressionGzip:
+		r, err := gzip.NewReader(bytes.NewReader(rec.Data))
+		if err != nil {
+			return nil, err
+		}
+		return io.ReadAll(r)
+	case otlpfile.CompressionZstd:
+		if d.zstd == nil {
+			var err error
+			if d.zstd, err = zstd.NewReader(nil); err != nil {
+				return nil, err
+			}
+		}
+		return d.zstd.DecodeAll(rec.Data, nil)
+	}
+	return rec.Data, nil
+}
+
+// jsonSignals are the signals of the OTLP JSON documents, by their top-level field.
+var jsonSignals = map[string]component.DataType{
+	"resourceSpans":    component.DataTypeTraces,
+	"resourceMetrics":  component.DataTypeMetrics,
+	"resourceLogs":     component.DataTypeLogs,
+	"resourceProfiles": component.DataTypeProfiles,
+}
+
+// jsonSignal returns the signal of an OTLP JSON document from its first field, or an empty signal
+// if the document is empty.
+func jsonSignal(buf []byte) (component.DataType, error) {
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return "", errors.New("not an OTLP JSON document")
+	}
+	tok, err := dec.Token()
+	if err != nil {
+		return "", errors.New("not an OTLP JSON document")
+	}
+	if tok == json.Delim('}') {
+		return "", nil
+	}
+	key, _ := tok.(string)
+	signal, ok := jsonSignals[key]
+	if !ok {
+		return "", fmt.Errorf("unknown OTLP JSON field %q", key)
+	}
+	return signal, nil
+}
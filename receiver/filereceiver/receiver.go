@@ -0,0 +1,402 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filereceiver // import "go.opentelemetry.io/collector/receiver/filereceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/otlpfile"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+const (
+	transport = "file"
+
+	// checkpointsKey is the storage key of the offsets reached in the files.
+	checkpointsKey = "checkpoints"
+)
+
+type consumers struct {
+	traces   consumer.Traces
+	metrics  consumer.Metrics
+	logs     consumer.Logs
+	profiles consumer.Profiles
+}
+
+// fileState is the progress in a file.
+type fileState struct {
+	info   os.FileInfo
+	offset int64
+}
+
+// fileReceiver polls the files matching the configured patterns, and sends their new batches to the pipelines.
+type fileReceiver struct {
+	id         component.ID
+	cfg        *Config
+	logger     *zap.Logger
+	obsrecv    *obsreport.Receiver
+	consumers  consumers
+	decompress decompressor
+
+	// states are the files being read, by path.
+	states map[string]*fileState
+	// checkpoints are the offsets loaded from the storage, used for the files not seen yet.
+	checkpoints map[string]int64
+	client      storage.Client
+	firstPoll   bool
+
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+func newFileReceiver(cfg *Config, set receiver.CreateSettings) (*fileReceiver, error) {
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             set.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: set,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &fileReceiver{
+		id:          set.ID,
+		cfg:         cfg,
+		logger:      set.Logger,
+		obsrecv:     obsrecv,
+		states:      make(map[string]*fileState),
+		checkpoints: make(map[string]int64),
+		firstPoll:   true,
+	}, nil
+}
+
+func (r *fileReceiver) Start(ctx context.Context, host component.Host) error {
+	if r.cfg.Storage != nil {
+		ext, ok := host.GetExtensions()[*r.cfg.Storage]
+		if !ok {
+			return fmt.Errorf("storage extension %q not found", r.cfg.Storage)
+		}
+		storageExt, ok := ext.(storage.Extension)
+		if !ok {
+			return fmt.Errorf("extension %q is not a storage extension", r.cfg.Storage)
+		}
+		var err error
+		if r.client, err = storageExt.GetClient(ctx, component.KindReceiver, r.id, ""); err != nil {
+			return fmt.Errorf("failed to get the storage client: %w", err)
+		}
+		if err = r.loadCheckpoints(ctx); err != nil {
+			return err
+		}
+	}
+
+	pollCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done.Add(1)
+	go r.pollPeriodically(pollCtx)
+	return nil
+}
+
+func (r *fileReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+	r.done.Wait()
+	if r.decompress.zstd != nil {
+		r.decompress.zstd.Close()
+	}
+	if r.client == nil {
+		return nil
+	}
+	if err := r.saveCheckpoints(ctx); err != nil {
+		return err
+	}
+	return r.client.Close(ctx)
+}
+
+func (r *fileReceiver) pollPeriodically(ctx context.Context) {
+	defer r.done.Done()
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		r.poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll reads the new batches of the files matching the patterns.
+func (r *fileReceiver) poll(ctx context.Context) {
+	paths := r.match()
+	states := make(map[string]*fileState, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		states[path] = r.state(path, info)
+	}
+	r.states = states
+	r.firstPoll = false
+
+	for _, path := range paths {
+		state, ok := r.states[path]
+		if !ok {
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		eof, err := r.readFile(ctx, path, state)
+		if err != nil {
+			r.logger.Warn("Failed to read the file, retrying at the next poll", zap.String("path", path), zap.Error(err))
+		}
+		if eof && r.cfg.DeleteAfterRead {
+			if err = os.Remove(path); err != nil {
+				r.logger.Warn("Failed to delete the file", zap.String("path", path), zap.Error(err))
+				continue
+			}
+			delete(r.states, path)
+		}
+	}
+
+	if r.client != nil {
+		if err := r.saveCheckpoints(ctx); err != nil {
+			r.logger.Warn("Failed to checkpoint the progress in the files", zap.Error(err))
+		}
+	}
+}
+
+// match returns the sorted paths of the files matching the patterns.
+func (r *fileReceiver) match() []string {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, pattern := range r.cfg.Include {
+		// The patterns are validated with the config.
+		matches, _ := filepath.Glob(pattern)
+		for _, path := range matches {
+			if !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// state returns the progress in the file at path, following the files renamed by a rotation.
+func (r *fileReceiver) state(path string, info os.FileInfo) *fileState {
+	if state, ok := r.states[path]; ok && os.SameFile(state.info, info) {
+		return &fileState{info: info, offset: state.offset}
+	}
+	for _, state := range r.states {
+		if os.SameFile(state.info, info) {
+			return &fileState{info: info, offset: state.offset}
+		}
+	}
+	if offset, ok := r.checkpoints[path]; ok {
+		delete(r.checkpoints, path)
+		if offset <= info.Size() {
+			return &fileState{info: info, offset: offset}
+		}
+	}
+	if r.firstPoll && r.cfg.StartAt == startAtEnd {
+		return &fileState{info: info, offset: info.Size()}
+	}
+	return &fileState{info: info}
+}
+
+// readFile sends the complete batches of the file following the offset of its state, and returns
+// whether the end of the file was reached with all its batches received.
+func (r *fileReceiver) readFile(ctx context.Context, path string, state *fileState) (bool, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+	if info.Size() < state.offset {
+		// The file was truncated, it is read again from its beginning.
+		state.offset = 0
+	}
+	if _, err = f.Seek(state.offset, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	// The batches written after the size was read are left for the next poll.
+	// The file exporter writes the proto and compressed batches as records, the other ones as JSON lines.
+	rr := newRecordReader(io.LimitReader(f, info.Size()-state.offset), r.cfg.Format == formatTypeProto || r.cfg.Compression != "")
+	for ctx.Err() == nil {
+		record, size, err := rr.next()
+		if errors.Is(err, io.EOF) {
+			return true, nil
+		}
+		if errors.Is(err, errIncomplete) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if err = r.consume(ctx, record); err != nil {
+			if !consumererror.IsPermanent(err) {
+				return false, err
+			}
+			r.logger.Warn("Dropping the batch rejected by the pipeline", zap.String("path", path), zap.Int64("offset", state.offset), zap.Error(err))
+		}
+		state.offset += size
+	}
+	return false, nil
+}
+
+// consume decodes the batch and sends it to the pipelines of its signal.
+func (r *fileReceiver) consume(ctx context.Context, rec otlpfile.Record) error {
+	record, err := r.decompress.decompress(rec)
+	if err != nil {
+		return consumererror.NewPermanent(fmt.Errorf("failed to decompress the batch: %w", err))
+	}
+	if len(record) == 0 {
+		return nil
+	}
+
+	signal := rec.Signal
+	if signal == "" {
+		if signal, err = jsonSignal(record); err != nil {
+			return consumererror.NewPermanent(err)
+		}
+	}
+
+	proto := rec.Encoding == otlpfile.EncodingProto
+	switch signal {
+	case component.DataTypeTraces:
+		if r.consumers.traces == nil {
+			return nil
+		}
+		var td ptrace.Traces
+		if td, err = tracesUnmarshaler(proto).UnmarshalTraces(record); err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		ctx = r.obsrecv.StartTracesOp(ctx)
+		err = r.consumers.traces.ConsumeTraces(ctx, td)
+		r.obsrecv.EndTracesOp(ctx, r.cfg.Format, td.SpanCount(), err)
+	case component.DataTypeMetrics:
+		if r.consumers.metrics == nil {
+			return nil
+		}
+		var md pmetric.Metrics
+		if md, err = metricsUnmarshaler(proto).UnmarshalMetrics(record); err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		ctx = r.obsrecv.StartMetricsOp(ctx)
+		err = r.consumers.metrics.ConsumeMetrics(ctx, md)
+		r.obsrecv.EndMetricsOp(ctx, r.cfg.Format, md.DataPointCount(), err)
+	case component.DataTypeLogs:
+		if r.consumers.logs == nil {
+			return nil
+		}
+		var ld plog.Logs
+		if ld, err = logsUnmarshaler(proto).UnmarshalLogs(record); err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		ctx = r.obsrecv.StartLogsOp(ctx)
+		err = r.consumers.logs.ConsumeLogs(ctx, ld)
+		r.obsrecv.EndLogsOp(ctx, r.cfg.Format, ld.LogRecordCount(), err)
+	case component.DataTypeProfiles:
+		if r.consumers.profiles == nil {
+			return nil
+		}
+		var pd pprofile.Profiles
+		if pd, err = profilesUnmarshaler(proto).UnmarshalProfiles(record); err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		ctx = r.obsrecv.StartProfilesOp(ctx)
+		err = r.consumers.profiles.ConsumeProfiles(ctx, pd)
+		r.obsrecv.EndProfilesOp(ctx, r.cfg.Format, pd.SampleCount(), err)
+	}
+	return err
+}
+
+func tracesUnmarshaler(proto bool) ptrace.Unmarshaler {
+	if proto {
+		return &ptrace.ProtoUnmarshaler{}
+	}
+	return &ptrace.JSONUnmarshaler{}
+}
+
+func metricsUnmarshaler(proto bool) pmetric.Unmarshaler {
+	if proto {
+		return &pmetric.ProtoUnmarshaler{}
+	}
+	return &pmetric.JSONUnmarshaler{}
+}
+
+func logsUnmarshaler(proto bool) plog.Unmarshaler {
+	if proto {
+		return &plog.ProtoUnmarshaler{}
+	}
+	return &plog.JSONUnmarshaler{}
+}
+
+func profilesUnmarshaler(proto bool) pprofile.Unmarshaler {
+	if proto {
+		return &pprofile.ProtoUnmarshaler{}
+	}
+	return &pprofile.JSONUnmarshaler{}
+}
+
+func (r *fileReceiver) loadCheckpoints(ctx context.Context) error {
+	buf, err := r.client.Get(ctx, checkpointsKey)
+	if err != nil {
+		return fmt.Errorf("failed to load the checkpoints: %w", err)
+	}
+	if buf == nil {
+		return nil
+	}
+	if err = json.Unmarshal(buf, &r.checkpoints); err != nil {
+		r.logger.Warn("Ignoring the corrupted checkpoints", zap.Error(err))
+		r.checkpoints = make(map[string]int64)
+	}
+	return nil
+}
+
+// saveCheckpoints stores the offsets reached in the files, and the checkpoints of the files not seen yet.
+func (r *fileReceiver) saveCheckpoints(ctx context.Context) error {
+	checkpoints := make(map[string]int64, len(r.states)+len(r.checkpoints))
+	for path, offset := range r.checkpoints {
+		checkpoints[path] = offset
+	}
+	for path, state := range r.states {
+		checkpoints[path] = state.offset
+	}
+	buf, err := json.Marshal(checkpoints)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, checkpointsKey, buf)
+}
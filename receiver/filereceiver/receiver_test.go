@@ -0,0 +1,373 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filereceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/otlpfile"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func newTestReceiver(t *testing.T, cfg *Config) *fileReceiver {
+	r, err := newFileReceiver(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	return r
+}
+
+func newTestConfig(dir string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join(dir, "*")}
+	return cfg
+}
+
+func appendToFile(t *testing.T, path string, data []byte) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func jsonLine(t *testing.T, td ptrace.Traces) []byte {
+	buf, err := (&ptrace.JSONMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	return append(buf, '\n')
+}
+
+func record(t *testing.T, signal component.DataType, encoding otlpfile.Encoding, compression otlpfile.Compression, buf []byte) []byte {
+	var out bytes.Buffer
+	require.NoError(t, otlpfile.NewWriter(&out).Write(otlpfile.Record{
+		Signal:      signal,
+		Time:        time.Now(),
+		Encoding:    encoding,
+		Compression: compression,
+		Data:        buf,
+	}))
+	return out.Bytes()
+}
+
+func TestReceiveJSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.json")
+	r := newTestReceiver(t, newTestConfig(dir))
+	traces := new(consumertest.TracesSink)
+	metrics := new(consumertest.MetricsSink)
+	logs := new(consumertest.LogsSink)
+	r.consumers.traces = traces
+	r.consumers.metrics = metrics
+	r.consumers.logs = logs
+
+	mbuf, err := (&pmetric.JSONMarshaler{}).MarshalMetrics(testdata.GenerateMetrics(2))
+	require.NoError(t, err)
+	lbuf, err := (&plog.JSONMarshaler{}).MarshalLogs(testdata.GenerateLogs(3))
+	require.NoError(t, err)
+	var data []byte
+	data = append(data, jsonLine(t, testdata.GenerateTraces(2))...)
+	data = append(data, append(mbuf, '\n')...)
+	data = append(data, append(lbuf, '\n')...)
+	appendToFile(t, path, data)
+
+	r.poll(context.Background())
+	assert.Equal(t, 2, traces.SpanCount())
+	assert.Equal(t, 4, metrics.DataPointCount())
+	assert.Equal(t, 3, logs.LogRecordCount())
+
+	// A partially written batch is received once complete.
+	line := jsonLine(t, testdata.GenerateTraces(1))
+	appendToFile(t, path, line[:10])
+	r.poll(context.Background())
+	assert.Equal(t, 2, traces.SpanCount())
+	appendToFile(t, path, line[10:])
+	r.poll(context.Background())
+	assert.Equal(t, 3, traces.SpanCount())
+	assert.Len(t, traces.AllTraces(), 2)
+}
+
+func TestReceiveProtoCompressed(t *testing.T) {
+	gzipCompress := func(buf []byte) []byte {
+		var out bytes.Buffer
+		w := gzip.NewWriter(&out)
+		_, _ = w.Write(buf)
+		_ = w.Close()
+		return out.Bytes()
+	}
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	zstdCompress := func(buf []byte) []byte { return encoder.EncodeAll(buf, nil) }
+
+	tests := []struct {
+		name        string
+		format      string
+		compression configcompression.CompressionType
+		encode      func(t *testing.T, td ptrace.Traces) []byte
+	}{
+		{
+			name:   "proto",
+			format: formatTypeProto,
+			encode: func(t *testing.T, td ptrace.Traces) []byte {
+				buf, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+				require.NoError(t, err)
+				return record(t, component.DataTypeTraces, otlpfile.EncodingProto, otlpfile.CompressionNone, buf)
+			},
+		},
+		{
+			name:        "proto_zstd",
+			format:      formatTypeProto,
+			compression: configcompression.Zstd,
+			encode: func(t *testing.T, td ptrace.Traces) []byte {
+				buf, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+				require.NoError(t, err)
+				return record(t, component.DataTypeTraces, otlpfile.EncodingProto, otlpfile.CompressionZstd, zstdCompress(buf))
+			},
+		},
+		{
+			name:        "json_gzip",
+			format:      formatTypeJSON,
+			compression: configcompression.Gzip,
+			encode: func(t *testing.T, td ptrace.Traces) []byte {
+				buf, err := (&ptrace.JSONMarshaler{}).MarshalTraces(td)
+				require.NoError(t, err)
+				return record(t, component.DataTypeTraces, otlpfile.EncodingJSON, otlpfile.CompressionGzip, gzipCompress(buf))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			cfg := newTestConfig(dir)
+			cfg.Format = tt.format
+			cfg.Compression = tt.compression
+			r := newTestReceiver(t, cfg)
+			sink := new(consumertest.TracesSink)
+			r.consumers.traces = sink
+
+			data := tt.encode(t, testdata.GenerateTraces(2))
+			appendToFile(t, filepath.Join(dir, "data"), data[:len(data)-1])
+			r.poll(context.Background())
+			assert.Equal(t, 0, sink.SpanCount())
+
+			appendToFile(t, filepath.Join(dir, "data"), data[len(data)-1:])
+			appendToFile(t, filepath.Join(dir, "data"), tt.encode(t, testdata.GenerateTraces(3)))
+			r.poll(context.Background())
+			assert.Equal(t, 5, sink.SpanCount())
+		})
+	}
+}
+
+func TestReceiveProtoSignals(t *testing.T) {
+	dir := t.TempDir()
+	cfg := newTestConfig(dir)
+	cfg.Format = formatTypeProto
+	r := newTestReceiver(t, cfg)
+	traces := new(consumertest.TracesSink)
+	logs := new(consumertest.LogsSink)
+	r.consumers.traces = traces
+	r.consumers.logs = logs
+
+	tbuf, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(testdata.GenerateTraces(2))
+	require.NoError(t, err)
+	lbuf, err := (&plog.ProtoMarshaler{}).MarshalLogs(testdata.GenerateLogs(3))
+	require.NoError(t, err)
+	mbuf, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(testdata.GenerateMetrics(1))
+	require.NoError(t, err)
+	var data []byte
+	data = append(data, record(t, component.DataTypeTraces, otlpfile.EncodingProto, otlpfile.CompressionNone, tbuf)...)
+	data = append(data, record(t, component.DataTypeLogs, otlpfile.EncodingProto, otlpfile.CompressionNone, lbuf)...)
+	// The signals without a pipeline are skipped.
+	data = append(data, record(t, component.DataTypeMetrics, otlpfile.EncodingProto, otlpfile.CompressionNone, mbuf)...)
+	appendToFile(t, filepath.Join(dir, "data.binpb"), data)
+
+	r.poll(context.Background())
+	assert.Equal(t, 2, traces.SpanCount())
+	assert.Equal(t, 3, logs.LogRecordCount())
+	assert.Equal(t, int64(len(data)), r.states[filepath.Join(dir, "data.binpb")].offset)
+}
+
+func TestReceiveStartAtEnd(t *testing.T) {
+	dir := t.TempDir()
+	appendToFile(t, filepath.Join(dir, "old.json"), jsonLine(t, testdata.GenerateTraces(1)))
+	cfg := newTestConfig(dir)
+	cfg.StartAt = startAtEnd
+	r := newTestReceiver(t, cfg)
+	sink := new(consumertest.TracesSink)
+	r.consumers.traces = sink
+
+	r.poll(context.Background())
+	assert.Equal(t, 0, sink.SpanCount())
+
+	// The data appended after the start, and the files created after the start, are received.
+	appendToFile(t, filepath.Join(dir, "old.json"), jsonLine(t, testdata.GenerateTraces(2)))
+	appendToFile(t, filepath.Join(dir, "new.json"), jsonLine(t, testdata.GenerateTraces(3)))
+	r.poll(context.Background())
+	assert.Equal(t, 5, sink.SpanCount())
+}
+
+func TestReceiveRotatedAndTruncated(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.json")
+	r := newTestReceiver(t, newTestConfig(dir))
+	sink := new(consumertest.TracesSink)
+	r.consumers.traces = sink
+
+	appendToFile(t, path, jsonLine(t, testdata.GenerateTraces(1)))
+	r.poll(context.Background())
+	assert.Equal(t, 1, sink.SpanCount())
+
+	// The renamed file is not read again, only its new data.
+	appendToFile(t, path, jsonLine(t, testdata.GenerateTraces(2)))
+	require.NoError(t, os.Rename(path, filepath.Join(dir, "data-1.json")))
+	appendToFile(t, path, jsonLine(t, testdata.GenerateTraces(3)))
+	r.poll(context.Background())
+	assert.Equal(t, 6, sink.SpanCount())
+
+	// The truncated file is read again from its beginning.
+	require.NoError(t, os.Truncate(path, 0))
+	r.poll(context.Background())
+	appendToFile(t, path, jsonLine(t, testdata.GenerateTraces(4)))
+	r.poll(context.Background())
+	assert.Equal(t, 10, sink.SpanCount())
+}
+
+func TestReceiveDeleteAfterRead(t *testing.T) {
+	dir := t.TempDir()
+	complete := filepath.Join(dir, "complete.json")
+	partial := filepath.Join(dir, "partial.json")
+	cfg := newTestConfig(dir)
+	cfg.DeleteAfterRead = true
+	r := newTestReceiver(t, cfg)
+	sink := new(consumertest.TracesSink)
+	r.consumers.traces = sink
+
+	appendToFile(t, complete, jsonLine(t, testdata.GenerateTraces(1)))
+	appendToFile(t, partial, jsonLine(t, testdata.GenerateTraces(2))[:10])
+	r.poll(context.Background())
+	assert.Equal(t, 1, sink.SpanCount())
+	assert.NoFileExists(t, complete)
+	assert.FileExists(t, partial)
+}
+
+func TestReceiveConsumerErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.json")
+	r := newTestReceiver(t, newTestConfig(dir))
+	appendToFile(t, path, jsonLine(t, testdata.GenerateTraces(1)))
+	appendToFile(t, path, []byte("{\"unknown\": 1}\n"))
+
+	// The batches are read again at the next poll after a retryable error.
+	r.consumers.traces = consumertest.NewErr(errors.New("retry"))
+	r.poll(context.Background())
+	assert.Equal(t, int64(0), r.states[path].offset)
+
+	// The batches rejected permanently and the invalid batches are skipped.
+	r.consumers.traces = consumertest.NewErr(consumererror.NewPermanent(errors.New("rejected")))
+	r.poll(context.Background())
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, info.Size(), r.states[path].offset)
+}
+
+func TestReceiveCheckpoints(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.json")
+	storageID := component.NewID("memory_storage")
+	host := &storageHost{ext: map[component.ID]component.Component{storageID: &memoryStorage{data: map[string][]byte{}}}}
+	cfg := newTestConfig(dir)
+	cfg.Storage = &storageID
+	cfg.PollInterval = 10 * time.Millisecond
+
+	appendToFile(t, path, jsonLine(t, testdata.GenerateTraces(1)))
+	r := newTestReceiver(t, cfg)
+	sink := new(consumertest.TracesSink)
+	r.consumers.traces = sink
+	require.NoError(t, r.Start(context.Background(), host))
+	assert.Eventually(t, func() bool { return sink.SpanCount() == 1 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	// The progress is resumed after a restart.
+	appendToFile(t, path, jsonLine(t, testdata.GenerateTraces(2)))
+	r = newTestReceiver(t, cfg)
+	sink = new(consumertest.TracesSink)
+	r.consumers.traces = sink
+	require.NoError(t, r.Start(context.Background(), host))
+	assert.Eventually(t, func() bool { return sink.SpanCount() == 2 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
+func TestReceiveMissingStorage(t *testing.T) {
+	storageID := component.NewID("memory_storage")
+	cfg := newTestConfig(t.TempDir())
+	cfg.Storage = &storageID
+	r := newTestReceiver(t, cfg)
+	assert.EqualError(t, r.Start(context.Background(), componenttest.NewNopHost()), `storage extension "memory_storage" not found`)
+}
+
+type storageHost struct {
+	component.Host
+	ext map[component.ID]component.Component
+}
+
+func (h *storageHost) GetExtensions() map[component.ID]component.Component {
+	return h.ext
+}
+
+type memoryStorage struct {
+	data map[string][]byte
+}
+
+func (ms *memoryStorage) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (ms *memoryStorage) Shutdown(context.Context) error {
+	return nil
+}
+
+func (ms *memoryStorage) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return ms, nil
+}
+
+func (ms *memoryStorage) Get(_ context.Context, key string) ([]byte, error) {
+	return ms.data[key], nil
+}
+
+func (ms *memoryStorage) Set(_ context.Context, key string, value []byte) error {
+	ms.data[key] = value
+	return nil
+}
+
+func (ms *memoryStorage) Delete(_ context.Context, key string) error {
+	delete(ms.data, key)
+	return nil
+}
+
+func (ms *memoryStorage) Batch(context.Context, ...storage.Operation) error {
+	return nil
+}
+
+func (ms *memoryStorage) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+// admissionHTTPStatusCode returns the retryable HTTP status code of the errors of the admission controller.
+func admissionHTTPStatusCode(err error) (int, bool) {
+	switch {
+	case errors.Is(err, receiverhelper.ErrTooManyRequests), errors.Is(err, receiverhelper.ErrTooManyTenants):
+		return http.StatusTooManyRequests, true
+	case errors.Is(err, receiverhelper.ErrTooManyInflightBytes):
+		return http.StatusServiceUnavailable, true
+	}
+	return 0, false
+}
+
+// exportErrorStatusCode returns the HTTP status code of the errors returned by the Export functions.
+func exportErrorStatusCode(err error) int {
+	if code, ok := admissionHTTPStatusCode(err); ok {
+		return code
+	}
+	if _, ok := consumererror.RetryAfter(err); ok {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// exportErrorToStatus is a gRPC interceptor converting the errors of the admission controller, and the
+// errors asking to retry after a delay, to the Unavailable status, which the clients always retry.
+func exportErrorToStatus(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if _, ok := admissionHTTPStatusCode(err); ok {
+		return resp, status.Error(codes.Unavailable, err.Error())
+	}
+	if delay, ok := consumererror.RetryAfter(err); ok {
+		s := status.New(codes.Unavailable, err.Error())
+		if withDelay, detailsErr := s.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)}); detailsErr == nil {
+			s = withDelay
+		}
+		return resp, s.Err()
+	}
+	return resp, err
+}
+
+// retryAfterSeconds returns the value of the Retry-After HTTP header of the errors asking to retry after a delay.
+func retryAfterSeconds(err error) (string, bool) {
+	delay, ok := consumererror.RetryAfter(err)
+	if !ok {
+		return "", false
+	}
+	return strconv.FormatInt(int64(math.Ceil(delay.Seconds())), 10), true
+}
@@ -5,11 +5,13 @@ package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlprecei
 
 import (
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 const (
@@ -24,10 +26,62 @@ type Protocols struct {
 	HTTP *confighttp.HTTPServerSettings `mapstructure:"http"`
 }
 
+// SignalSettings configures the reception of a signal.
+type SignalSettings struct {
+	// Disabled refuses the signal as unimplemented, even if the receiver is used in pipelines of other signals.
+	// The receiver cannot be used in the pipelines of a disabled signal.
+	Disabled bool `mapstructure:"disabled"`
+
+	// GRPCEndpoint if not empty, receives the signal on a separate gRPC server listening on this endpoint,
+	// with the other settings of the gRPC protocol, instead of the server of the gRPC protocol.
+	GRPCEndpoint string `mapstructure:"grpc_endpoint"`
+
+	// HTTPEndpoint if not empty, receives the signal on a separate HTTP server listening on this endpoint,
+	// with the other settings of the HTTP protocol, instead of the server of the HTTP protocol.
+	HTTPEndpoint string `mapstructure:"http_endpoint"`
+}
+
+// Signals is the configuration of the reception of each signal.
+type Signals struct {
+	Traces   SignalSettings `mapstructure:"traces"`
+	Metrics  SignalSettings `mapstructure:"metrics"`
+	Logs     SignalSettings `mapstructure:"logs"`
+	Profiles SignalSettings `mapstructure:"profiles"`
+}
+
+// signalTypes are the data types of the signals supported by the receiver.
+var signalTypes = []component.DataType{
+	component.DataTypeTraces,
+	component.DataTypeMetrics,
+	component.DataTypeLogs,
+	component.DataTypeProfiles,
+}
+
+// get returns the settings of the signal of the given data type.
+func (s Signals) get(dataType component.DataType) SignalSettings {
+	switch dataType {
+	case component.DataTypeTraces:
+		return s.Traces
+	case component.DataTypeMetrics:
+		return s.Metrics
+	case component.DataTypeLogs:
+		return s.Logs
+	default:
+		return s.Profiles
+	}
+}
+
 // Config defines configuration for OTLP receiver.
 type Config struct {
 	// Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).
 	Protocols `mapstructure:"protocols"`
+
+	// Signals allows disabling the reception of some signals, and receiving them on separate endpoints.
+	Signals Signals `mapstructure:"signals"`
+
+	// Admission if not nil, refuses the requests exceeding its limits, for all the protocols,
+	// with a retryable error.
+	Admission *receiverhelper.AdmissionSettings `mapstructure:"admission"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -38,6 +92,26 @@ func (cfg *Config) Validate() error {
 	if cfg.GRPC == nil && cfg.HTTP == nil {
 		return errors.New("must specify at least one protocol when using the OTLP receiver")
 	}
+	enabled := false
+	for _, dataType := range signalTypes {
+		signal := cfg.Signals.get(dataType)
+		if signal.Disabled {
+			continue
+		}
+		enabled = true
+		if signal.GRPCEndpoint != "" && (cfg.GRPC == nil || signal.GRPCEndpoint == cfg.GRPC.NetAddr.Endpoint) {
+			return fmt.Errorf("the grpc_endpoint of the %s signal must be different from the endpoint of the grpc protocol, which must be specified", dataType)
+		}
+		if signal.HTTPEndpoint != "" && (cfg.HTTP == nil || signal.HTTPEndpoint == cfg.HTTP.Endpoint) {
+			return fmt.Errorf("the http_endpoint of the %s signal must be different from the endpoint of the http protocol, which must be specified", dataType)
+		}
+	}
+	if !enabled {
+		return errors.New("must not disable all the signals when using the OTLP receiver")
+	}
+	if cfg.Admission != nil {
+		return cfg.Admission.Validate()
+	}
 	return nil
 }
 
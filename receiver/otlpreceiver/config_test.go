@@ -18,6 +18,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 func TestUnmarshalDefaultConfig(t *testing.T) {
@@ -53,6 +54,100 @@ func TestUnmarshalConfigOnlyHTTP(t *testing.T) {
 	assert.Equal(t, defaultOnlyHTTP, cfg)
 }
 
+func TestUnmarshalConfigAdmission(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "admission.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.HTTP = nil
+	expected.Admission = &receiverhelper.AdmissionSettings{
+		AdmissionLimits: receiverhelper.AdmissionLimits{
+			MaxInflightBytes:     64 << 20,
+			MaxRequestsPerSecond: 1000,
+		},
+		MetadataKeys: []string{"X-Tenant"},
+		TenantLimits: receiverhelper.AdmissionLimits{
+			MaxInflightBytes:     8 << 20,
+			MaxRequestsPerSecond: 100,
+		},
+	}
+	assert.Equal(t, expected, cfg)
+}
+
+func TestUnmarshalConfigSignals(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "signals.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.Signals = Signals{
+		Traces: SignalSettings{Disabled: true},
+		Logs: SignalSettings{
+			GRPCEndpoint: "0.0.0.0:4319",
+			HTTPEndpoint: "0.0.0.0:4320",
+		},
+	}
+	assert.Equal(t, expected, cfg)
+}
+
+func TestValidateConfigSignals(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		signals Signals
+		expects string
+	}{
+		{
+			name: "all_disabled",
+			signals: Signals{
+				Traces:   SignalSettings{Disabled: true},
+				Metrics:  SignalSettings{Disabled: true},
+				Logs:     SignalSettings{Disabled: true},
+				Profiles: SignalSettings{Disabled: true},
+			},
+			expects: "must not disable all the signals when using the OTLP receiver",
+		},
+		{
+			name:    "same_grpc_endpoint",
+			signals: Signals{Metrics: SignalSettings{GRPCEndpoint: defaultGRPCEndpoint}},
+			expects: "the grpc_endpoint of the metrics signal must be different from the endpoint of the grpc protocol, which must be specified",
+		},
+		{
+			name:    "same_http_endpoint",
+			signals: Signals{Logs: SignalSettings{HTTPEndpoint: defaultHTTPEndpoint}},
+			expects: "the http_endpoint of the logs signal must be different from the endpoint of the http protocol, which must be specified",
+		},
+		{
+			name: "disabled_signal_endpoint",
+			signals: Signals{
+				Logs: SignalSettings{Disabled: true, HTTPEndpoint: defaultHTTPEndpoint},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Signals = tt.signals
+			err := component.ValidateConfig(cfg)
+			if tt.expects == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expects)
+		})
+	}
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.GRPC = nil
+	cfg.Signals.Traces.GRPCEndpoint = "localhost:4319"
+	assert.EqualError(t, component.ValidateConfig(cfg), "the grpc_endpoint of the traces signal must be different from the endpoint of the grpc protocol, which must be specified")
+}
+
 func TestUnmarshalConfigOnlyHTTPNull(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "only_http_null.yaml"))
 	require.NoError(t, err)
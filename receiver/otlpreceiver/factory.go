@@ -29,7 +29,8 @@ func NewFactory() receiver.Factory {
 		createDefaultConfig,
 		receiver.WithTraces(createTraces, component.StabilityLevelStable),
 		receiver.WithMetrics(createMetrics, component.StabilityLevelStable),
-		receiver.WithLogs(createLog, component.StabilityLevelBeta))
+		receiver.WithLogs(createLog, component.StabilityLevelBeta),
+		receiver.WithProfiles(createProfiles, component.StabilityLevelDevelopment))
 }
 
 // createDefaultConfig creates the default configuration for receiver.
@@ -114,6 +115,27 @@ func createLog(
 	return r, nil
 }
 
+// createProfiles creates a profiles receiver based on provided config.
+func createProfiles(
+	_ context.Context,
+	set receiver.CreateSettings,
+	cfg component.Config,
+	consumer consumer.Profiles,
+) (receiver.Profiles, error) {
+	oCfg := cfg.(*Config)
+	r, err := receivers.GetOrAdd(oCfg, func() (*otlpReceiver, error) {
+		return newOtlpReceiver(oCfg, set)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.Unwrap().registerProfilesConsumer(consumer); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
 // This is the map of already created OTLP receivers for particular configurations.
 // We maintain this map because the Factory is asked trace and metric receivers separately
 // when it gets CreateTracesReceiver() and CreateMetricsReceiver() but they must not
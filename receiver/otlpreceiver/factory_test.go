@@ -290,3 +290,106 @@ func TestCreateLogReceiver(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateProfilesReceiver(t *testing.T) {
+	factory := NewFactory()
+	defaultGRPCSettings := &configgrpc.GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  testutil.GetAvailableLocalAddress(t),
+			Transport: "tcp",
+		},
+	}
+	defaultHTTPSettings := &confighttp.HTTPServerSettings{
+		Endpoint: testutil.GetAvailableLocalAddress(t),
+	}
+
+	tests := []struct {
+		name         string
+		cfg          *Config
+		wantStartErr bool
+		wantErr      bool
+		sink         consumer.Profiles
+	}{
+		{
+			name: "default",
+			cfg: &Config{
+				Protocols: Protocols{
+					GRPC: defaultGRPCSettings,
+					HTTP: defaultHTTPSettings,
+				},
+			},
+			sink: new(consumertest.ProfilesSink),
+		},
+		{
+			name: "invalid_grpc_address",
+			cfg: &Config{
+				Protocols: Protocols{
+					GRPC: &configgrpc.GRPCServerSettings{
+						NetAddr: confignet.NetAddr{
+							Endpoint:  "327.0.0.1:1122",
+							Transport: "tcp",
+						},
+					},
+					HTTP: defaultHTTPSettings,
+				},
+			},
+			wantStartErr: true,
+			sink:         new(consumertest.ProfilesSink),
+		},
+		{
+			name: "invalid_http_address",
+			cfg: &Config{
+				Protocols: Protocols{
+					GRPC: defaultGRPCSettings,
+					HTTP: &confighttp.HTTPServerSettings{
+						Endpoint: "327.0.0.1:1122",
+					},
+				},
+			},
+			wantStartErr: true,
+			sink:         new(consumertest.ProfilesSink),
+		},
+		{
+			name: "no_next_consumer",
+			cfg: &Config{
+				Protocols: Protocols{
+					GRPC: defaultGRPCSettings,
+					HTTP: &confighttp.HTTPServerSettings{
+						Endpoint: "327.0.0.1:1122",
+					},
+				},
+			},
+			wantErr: true,
+			sink:    nil,
+		},
+		{
+			name: "no_http_or_grcp_config",
+			cfg: &Config{
+				Protocols: Protocols{},
+			},
+			wantErr: false,
+			sink:    new(consumertest.ProfilesSink),
+		},
+	}
+	ctx := context.Background()
+	creationSet := receivertest.NewNopCreateSettings()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr, err := factory.CreateProfilesReceiver(ctx, creationSet, tt.cfg, tt.sink)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.NotNil(t, mr)
+
+			if tt.wantStartErr {
+				assert.Error(t, mr.Start(context.Background(), componenttest.NewNopHost()))
+				assert.NoError(t, mr.Shutdown(context.Background()))
+			} else {
+				require.NoError(t, mr.Start(context.Background(), componenttest.NewNopHost()))
+				assert.NoError(t, mr.Shutdown(context.Background()))
+			}
+		})
+	}
+}
@@ -7,24 +7,32 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 const dataFormatProtobuf = "protobuf"
 
+// sizer estimates the size of the requests admitted by the admission controller.
+var sizer = &plog.ProtoMarshaler{}
+
 // Receiver is the type used to handle logs from OpenTelemetry exporters.
 type Receiver struct {
 	plogotlp.UnimplementedGRPCServer
 	nextConsumer consumer.Logs
 	obsrecv      *obsreport.Receiver
+	admission    *receiverhelper.AdmissionController
 }
 
-// New creates a new Receiver reference.
-func New(nextConsumer consumer.Logs, obsrecv *obsreport.Receiver) *Receiver {
+// New creates a new Receiver reference. The admission controller may be nil.
+func New(nextConsumer consumer.Logs, obsrecv *obsreport.Receiver, admission *receiverhelper.AdmissionController) *Receiver {
 	return &Receiver{
 		nextConsumer: nextConsumer,
 		obsrecv:      obsrecv,
+		admission:    admission,
 	}
 }
 
@@ -37,8 +45,33 @@ func (r *Receiver) Export(ctx context.Context, req plogotlp.ExportRequest) (plog
 	}
 
 	ctx = r.obsrecv.StartLogsOp(ctx)
-	err := r.nextConsumer.ConsumeLogs(ctx, ld)
+	err := r.consume(ctx, ld)
 	r.obsrecv.EndLogsOp(ctx, dataFormatProtobuf, numSpans, err)
 
-	return plogotlp.NewExportResponse(), err
+	return exportResponse(numSpans, err)
+}
+
+// exportResponse returns the response to the request of numItems items consumed with err. The items rejected
+// permanently by a partial success are reported in the response, the client must not send them again.
+func exportResponse(numItems int, err error) (plogotlp.ExportResponse, error) {
+	resp := plogotlp.NewExportResponse()
+	if c := consumererror.Count(err, numItems); err != nil && c.Retryable == 0 && c.Permanent < numItems {
+		resp.PartialSuccess().SetRejectedLogRecords(int64(c.Permanent))
+		resp.PartialSuccess().SetErrorMessage(err.Error())
+		return resp, nil
+	}
+	return resp, err
+}
+
+// consume passes the data to the next consumer once the request is admitted by the admission controller.
+func (r *Receiver) consume(ctx context.Context, ld plog.Logs) error {
+	if r.admission == nil {
+		return r.nextConsumer.ConsumeLogs(ctx, ld)
+	}
+	release, err := r.admission.Acquire(ctx, int64(sizer.LogsSize(ld)))
+	if err != nil {
+		return err
+	}
+	defer release()
+	return r.nextConsumer.ConsumeLogs(ctx, ld)
 }
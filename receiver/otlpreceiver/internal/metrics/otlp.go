@@ -7,24 +7,32 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 const dataFormatProtobuf = "protobuf"
 
+// sizer estimates the size of the requests admitted by the admission controller.
+var sizer = &pmetric.ProtoMarshaler{}
+
 // Receiver is the type used to handle metrics from OpenTelemetry exporters.
 type Receiver struct {
 	pmetricotlp.UnimplementedGRPCServer
 	nextConsumer consumer.Metrics
 	obsrecv      *obsreport.Receiver
+	admission    *receiverhelper.AdmissionController
 }
 
-// New creates a new Receiver reference.
-func New(nextConsumer consumer.Metrics, obsrecv *obsreport.Receiver) *Receiver {
+// New creates a new Receiver reference. The admission controller may be nil.
+func New(nextConsumer consumer.Metrics, obsrecv *obsreport.Receiver, admission *receiverhelper.AdmissionController) *Receiver {
 	return &Receiver{
 		nextConsumer: nextConsumer,
 		obsrecv:      obsrecv,
+		admission:    admission,
 	}
 }
 
@@ -37,8 +45,33 @@ func (r *Receiver) Export(ctx context.Context, req pmetricotlp.ExportRequest) (p
 	}
 
 	ctx = r.obsrecv.StartMetricsOp(ctx)
-	err := r.nextConsumer.ConsumeMetrics(ctx, md)
+	err := r.consume(ctx, md)
 	r.obsrecv.EndMetricsOp(ctx, dataFormatProtobuf, dataPointCount, err)
 
-	return pmetricotlp.NewExportResponse(), err
+	return exportResponse(dataPointCount, err)
+}
+
+// exportResponse returns the response to the request of numItems items consumed with err. The items rejected
+// permanently by a partial success are reported in the response, the client must not send them again.
+func exportResponse(numItems int, err error) (pmetricotlp.ExportResponse, error) {
+	resp := pmetricotlp.NewExportResponse()
+	if c := consumererror.Count(err, numItems); err != nil && c.Retryable == 0 && c.Permanent < numItems {
+		resp.PartialSuccess().SetRejectedDataPoints(int64(c.Permanent))
+		resp.PartialSuccess().SetErrorMessage(err.Error())
+		return resp, nil
+	}
+	return resp, err
+}
+
+// consume passes the data to the next consumer once the request is admitted by the admission controller.
+func (r *Receiver) consume(ctx context.Context, md pmetric.Metrics) error {
+	if r.admission == nil {
+		return r.nextConsumer.ConsumeMetrics(ctx, md)
+	}
+	release, err := r.admission.Acquire(ctx, int64(sizer.MetricsSize(md)))
+	if err != nil {
+		return err
+	}
+	defer release()
+	return r.nextConsumer.ConsumeMetrics(ctx, md)
 }
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles // import "go.opentelemetry.io/collector/receiver/otlpreceiver/internal/profiles"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+const dataFormatProtobuf = "protobuf"
+
+// sizer estimates the size of the requests admitted by the admission controller.
+var sizer = &pprofile.ProtoMarshaler{}
+
+// Receiver is the type used to handle profiles from OpenTelemetry exporters.
+type Receiver struct {
+	pprofileotlp.UnimplementedGRPCServer
+	nextConsumer consumer.Profiles
+	obsrecv      *obsreport.Receiver
+	admission    *receiverhelper.AdmissionController
+}
+
+// New creates a new Receiver reference. The admission controller may be nil.
+func New(nextConsumer consumer.Profiles, obsrecv *obsreport.Receiver, admission *receiverhelper.AdmissionController) *Receiver {
+	return &Receiver{
+		nextConsumer: nextConsumer,
+		obsrecv:      obsrecv,
+		admission:    admission,
+	}
+}
+
+// Export implements the service Export profiles func.
+func (r *Receiver) Export(ctx context.Context, req pprofileotlp.ExportRequest) (pprofileotlp.ExportResponse, error) {
+	pd := req.Profiles()
+	numSamples := pd.SampleCount()
+	if numSamples == 0 {
+		return pprofileotlp.NewExportResponse(), nil
+	}
+
+	ctx = r.obsrecv.StartProfilesOp(ctx)
+	err := r.consume(ctx, pd)
+	r.obsrecv.EndProfilesOp(ctx, dataFormatProtobuf, numSamples, err)
+
+	return exportResponse(numSamples, err)
+}
+
+// exportResponse returns the response to the request of numItems items consumed with err. The items rejected
+// permanently by a partial success are reported in the response, the client must not send them again.
+func exportResponse(numItems int, err error) (pprofileotlp.ExportResponse, error) {
+	resp := pprofileotlp.NewExportResponse()
+	if c := consumererror.Count(err, numItems); err != nil && c.Retryable == 0 && c.Permanent < numItems {
+		resp.PartialSuccess().SetRejectedProfiles(int64(c.Permanent))
+		resp.PartialSuccess().SetErrorMessage(err.Error())
+		return resp, nil
+	}
+	return resp, err
+}
+
+// consume passes the data to the next consumer once the request is admitted by the admission controller.
+func (r *Receiver) consume(ctx context.Context, pd pprofile.Profiles) error {
+	if r.admission == nil {
+		return r.nextConsumer.ConsumeProfiles(ctx, pd)
+	}
+	release, err := r.admission.Acquire(ctx, int64(sizer.ProfilesSize(pd)))
+	if err != nil {
+		return err
+	}
+	defer release()
+	return r.nextConsumer.ConsumeProfiles(ctx, pd)
+}
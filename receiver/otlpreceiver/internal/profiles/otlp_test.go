@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestExport(t *testing.T) {
+	pd := testdata.GenerateProfiles(1)
+	req := pprofileotlp.NewExportRequestFromProfiles(pd)
+
+	profileSink := new(consumertest.ProfilesSink)
+	profileClient := makeProfilesServiceClient(t, profileSink)
+	resp, err := profileClient.Export(context.Background(), req)
+	require.NoError(t, err, "Failed to export profiles: %v", err)
+	require.NotNil(t, resp, "The response is missing")
+
+	pds := profileSink.AllProfiles()
+	require.Len(t, pds, 1)
+	assert.EqualValues(t, pd, pds[0])
+}
+
+func TestExport_EmptyRequest(t *testing.T) {
+	profileSink := new(consumertest.ProfilesSink)
+
+	profileClient := makeProfilesServiceClient(t, profileSink)
+	resp, err := profileClient.Export(context.Background(), pprofileotlp.NewExportRequest())
+	assert.NoError(t, err, "Failed to export profiles: %v", err)
+	assert.NotNil(t, resp, "The response is missing")
+}
+
+func TestExport_ErrorConsumer(t *testing.T) {
+	pd := testdata.GenerateProfiles(1)
+	req := pprofileotlp.NewExportRequestFromProfiles(pd)
+
+	profileClient := makeProfilesServiceClient(t, consumertest.NewErr(errors.New("my error")))
+	resp, err := profileClient.Export(context.Background(), req)
+	assert.EqualError(t, err, "rpc error: code = Unknown desc = my error")
+	assert.Equal(t, pprofileotlp.ExportResponse{}, resp)
+}
+
+func makeProfilesServiceClient(t *testing.T, pc consumer.Profiles) pprofileotlp.GRPCClient {
+	addr := otlpReceiverOnGRPCServer(t, pc)
+	cc, err := grpc.Dial(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err, "Failed to create the ProfilesServiceClient: %v", err)
+	t.Cleanup(func() {
+		require.NoError(t, cc.Close())
+	})
+
+	return pprofileotlp.NewGRPCClient(cc)
+}
+
+func otlpReceiverOnGRPCServer(t *testing.T, pc consumer.Profiles) net.Addr {
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err, "Failed to find an available address to run the gRPC server: %v", err)
+
+	t.Cleanup(func() {
+		require.NoError(t, ln.Close())
+	})
+
+	set := receivertest.NewNopCreateSettings()
+	set.ID = component.NewIDWithName("otlp", "profile")
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             set.ID,
+		Transport:              "grpc",
+		ReceiverCreateSettings: set,
+	})
+	require.NoError(t, err)
+	r := New(pc, obsrecv, nil)
+	// Now run it as a gRPC server
+	srv := grpc.NewServer()
+	pprofileotlp.RegisterGRPCServer(srv, r)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+
+	return ln.Addr()
+}
@@ -7,24 +7,32 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 const dataFormatProtobuf = "protobuf"
 
+// sizer estimates the size of the requests admitted by the admission controller.
+var sizer = &ptrace.ProtoMarshaler{}
+
 // Receiver is the type used to handle spans from OpenTelemetry exporters.
 type Receiver struct {
 	ptraceotlp.UnimplementedGRPCServer
 	nextConsumer consumer.Traces
 	obsrecv      *obsreport.Receiver
+	admission    *receiverhelper.AdmissionController
 }
 
-// New creates a new Receiver reference.
-func New(nextConsumer consumer.Traces, obsrecv *obsreport.Receiver) *Receiver {
+// New creates a new Receiver reference. The admission controller may be nil.
+func New(nextConsumer consumer.Traces, obsrecv *obsreport.Receiver, admission *receiverhelper.AdmissionController) *Receiver {
 	return &Receiver{
 		nextConsumer: nextConsumer,
 		obsrecv:      obsrecv,
+		admission:    admission,
 	}
 }
 
@@ -38,8 +46,33 @@ func (r *Receiver) Export(ctx context.Context, req ptraceotlp.ExportRequest) (pt
 	}
 
 	ctx = r.obsrecv.StartTracesOp(ctx)
-	err := r.nextConsumer.ConsumeTraces(ctx, td)
+	err := r.consume(ctx, td)
 	r.obsrecv.EndTracesOp(ctx, dataFormatProtobuf, numSpans, err)
 
-	return ptraceotlp.NewExportResponse(), err
+	return exportResponse(numSpans, err)
+}
+
+// exportResponse returns the response to the request of numItems items consumed with err. The items rejected
+// permanently by a partial success are reported in the response, the client must not send them again.
+func exportResponse(numItems int, err error) (ptraceotlp.ExportResponse, error) {
+	resp := ptraceotlp.NewExportResponse()
+	if c := consumererror.Count(err, numItems); err != nil && c.Retryable == 0 && c.Permanent < numItems {
+		resp.PartialSuccess().SetRejectedSpans(int64(c.Permanent))
+		resp.PartialSuccess().SetErrorMessage(err.Error())
+		return resp, nil
+	}
+	return resp, err
+}
+
+// consume passes the data to the next consumer once the request is admitted by the admission controller.
+func (r *Receiver) consume(ctx context.Context, td ptrace.Traces) error {
+	if r.admission == nil {
+		return r.nextConsumer.ConsumeTraces(ctx, td)
+	}
+	release, err := r.admission.Acquire(ctx, int64(sizer.TracesSize(td)))
+	if err != nil {
+		return err
+	}
+	defer release()
+	return r.nextConsumer.ConsumeTraces(ctx, td)
 }
@@ -20,6 +20,7 @@ import (
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 )
 
@@ -55,6 +56,28 @@ func TestExport_ErrorConsumer(t *testing.T) {
 	assert.Equal(t, ptraceotlp.ExportResponse{}, resp)
 }
 
+func TestExport_AdmissionRefused(t *testing.T) {
+	set := receivertest.NewNopCreateSettings()
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             set.ID,
+		Transport:              "grpc",
+		ReceiverCreateSettings: set,
+	})
+	require.NoError(t, err)
+	admission := receiverhelper.NewAdmissionController(receiverhelper.AdmissionSettings{
+		AdmissionLimits: receiverhelper.AdmissionLimits{MaxRequestsPerSecond: 1},
+	})
+	traceSink := new(consumertest.TracesSink)
+	r := New(traceSink, obsrecv, admission)
+
+	req := ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(1))
+	_, err = r.Export(context.Background(), req)
+	require.NoError(t, err)
+	_, err = r.Export(context.Background(), req)
+	assert.ErrorIs(t, err, receiverhelper.ErrTooManyRequests)
+	assert.Len(t, traceSink.AllTraces(), 1)
+}
+
 func makeTraceServiceClient(t *testing.T, tc consumer.Traces) ptraceotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, tc)
 	cc, err := grpc.Dial(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
@@ -82,7 +105,7 @@ func otlpReceiverOnGRPCServer(t *testing.T, tc consumer.Traces) net.Addr {
 		ReceiverCreateSettings: set,
 	})
 	require.NoError(t, err)
-	r := New(tc, obsrecv)
+	r := New(tc, obsrecv, nil)
 	// Now run it as a gRPC server
 	srv := grpc.NewServer()
 	ptraceotlp.RegisterGRPCServer(srv, r)
@@ -11,21 +11,29 @@ import (
 	"net/http"
 	"sync"
 
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/tap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/memorylimiter"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/profiles"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 // otlpReceiver is the type that exposes Trace and Metrics reception.
@@ -35,14 +43,25 @@ type otlpReceiver struct {
 	httpMux    *http.ServeMux
 	serverHTTP *http.Server
 
-	tracesReceiver  *trace.Receiver
-	metricsReceiver *metrics.Receiver
-	logsReceiver    *logs.Receiver
-	shutdownWG      sync.WaitGroup
+	// signalServersGRPC and signalHTTPMuxes, by endpoint, receive the signals configured with separate endpoints.
+	signalServersGRPC map[string]*grpc.Server
+	signalHTTPMuxes   map[string]*http.ServeMux
+	signalServersHTTP []*http.Server
+
+	tracesReceiver   *trace.Receiver
+	metricsReceiver  *metrics.Receiver
+	logsReceiver     *logs.Receiver
+	profilesReceiver *profiles.Receiver
+	shutdownWG       sync.WaitGroup
 
 	obsrepGRPC *obsreport.Receiver
 	obsrepHTTP *obsreport.Receiver
 
+	// memoryLimiter is the service-wide memory limiter, nil if it is not configured.
+	memoryLimiter *memorylimiter.MemoryLimiter
+	// admission is shared by the protocols, nil if it is not configured.
+	admission *receiverhelper.AdmissionController
+
 	settings receiver.CreateSettings
 }
 
@@ -56,6 +75,17 @@ func newOtlpReceiver(cfg *Config, set receiver.CreateSettings) (*otlpReceiver, e
 	}
 	if cfg.HTTP != nil {
 		r.httpMux = http.NewServeMux()
+		r.signalHTTPMuxes = make(map[string]*http.ServeMux)
+		for _, dataType := range signalTypes {
+			if signal := cfg.Signals.get(dataType); !signal.Disabled && signal.HTTPEndpoint != "" {
+				if _, ok := r.signalHTTPMuxes[signal.HTTPEndpoint]; !ok {
+					r.signalHTTPMuxes[signal.HTTPEndpoint] = http.NewServeMux()
+				}
+			}
+		}
+	}
+	if cfg.Admission != nil {
+		r.admission = receiverhelper.NewAdmissionController(*cfg.Admission)
 	}
 
 	var err error
@@ -79,7 +109,7 @@ func newOtlpReceiver(cfg *Config, set receiver.CreateSettings) (*otlpReceiver, e
 	return r, nil
 }
 
-func (r *otlpReceiver) startGRPCServer(cfg *configgrpc.GRPCServerSettings, host component.Host) error {
+func (r *otlpReceiver) startGRPCServer(cfg *configgrpc.GRPCServerSettings, server *grpc.Server, host component.Host) error {
 	r.settings.Logger.Info("Starting GRPC server", zap.String("endpoint", cfg.NetAddr.Endpoint))
 
 	gln, err := cfg.ToListener()
@@ -90,14 +120,14 @@ func (r *otlpReceiver) startGRPCServer(cfg *configgrpc.GRPCServerSettings, host
 	go func() {
 		defer r.shutdownWG.Done()
 
-		if errGrpc := r.serverGRPC.Serve(gln); errGrpc != nil && !errors.Is(errGrpc, grpc.ErrServerStopped) {
+		if errGrpc := server.Serve(gln); errGrpc != nil && !errors.Is(errGrpc, grpc.ErrServerStopped) {
 			host.ReportFatalError(errGrpc)
 		}
 	}()
 	return nil
 }
 
-func (r *otlpReceiver) startHTTPServer(cfg *confighttp.HTTPServerSettings, host component.Host) error {
+func (r *otlpReceiver) startHTTPServer(cfg *confighttp.HTTPServerSettings, server *http.Server, host component.Host) error {
 	r.settings.Logger.Info("Starting HTTP server", zap.String("endpoint", cfg.Endpoint))
 	var hln net.Listener
 	hln, err := cfg.ToListener()
@@ -108,7 +138,7 @@ func (r *otlpReceiver) startHTTPServer(cfg *confighttp.HTTPServerSettings, host
 	go func() {
 		defer r.shutdownWG.Done()
 
-		if errHTTP := r.serverHTTP.Serve(hln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+		if errHTTP := server.Serve(hln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
 			host.ReportFatalError(errHTTP)
 		}
 	}()
@@ -118,54 +148,153 @@ func (r *otlpReceiver) startHTTPServer(cfg *confighttp.HTTPServerSettings, host
 func (r *otlpReceiver) startProtocolServers(host component.Host) error {
 	var err error
 	if r.cfg.GRPC != nil {
-		r.serverGRPC, err = r.cfg.GRPC.ToServer(host, r.settings.TelemetrySettings)
+		r.serverGRPC, err = r.newGRPCServer(r.cfg.GRPC, host)
 		if err != nil {
 			return err
 		}
+		r.signalServersGRPC = make(map[string]*grpc.Server)
+		for _, dataType := range signalTypes {
+			if signal := r.cfg.Signals.get(dataType); !signal.Disabled && signal.GRPCEndpoint != "" {
+				if _, ok := r.signalServersGRPC[signal.GRPCEndpoint]; ok {
+					continue
+				}
+				if r.signalServersGRPC[signal.GRPCEndpoint], err = r.newGRPCServer(r.signalGRPCSettings(signal.GRPCEndpoint), host); err != nil {
+					return err
+				}
+			}
+		}
 
 		if r.tracesReceiver != nil {
-			ptraceotlp.RegisterGRPCServer(r.serverGRPC, r.tracesReceiver)
+			ptraceotlp.RegisterGRPCServer(r.signalGRPCServer(component.DataTypeTraces), r.tracesReceiver)
 		}
 
 		if r.metricsReceiver != nil {
-			pmetricotlp.RegisterGRPCServer(r.serverGRPC, r.metricsReceiver)
+			pmetricotlp.RegisterGRPCServer(r.signalGRPCServer(component.DataTypeMetrics), r.metricsReceiver)
 		}
 
 		if r.logsReceiver != nil {
-			plogotlp.RegisterGRPCServer(r.serverGRPC, r.logsReceiver)
+			plogotlp.RegisterGRPCServer(r.signalGRPCServer(component.DataTypeLogs), r.logsReceiver)
+		}
+
+		if r.profilesReceiver != nil {
+			pprofileotlp.RegisterGRPCServer(r.signalGRPCServer(component.DataTypeProfiles), r.profilesReceiver)
 		}
 
-		err = r.startGRPCServer(r.cfg.GRPC, host)
+		err = r.startGRPCServer(r.cfg.GRPC, r.serverGRPC, host)
 		if err != nil {
 			return err
 		}
+		for endpoint, server := range r.signalServersGRPC {
+			if err = r.startGRPCServer(r.signalGRPCSettings(endpoint), server, host); err != nil {
+				return err
+			}
+		}
 	}
 	if r.cfg.HTTP != nil {
-		r.serverHTTP, err = r.cfg.HTTP.ToServer(
-			host,
-			r.settings.TelemetrySettings,
-			r.httpMux,
-			confighttp.WithErrorHandler(errorHandler),
-		)
+		r.serverHTTP, err = r.newHTTPServer(r.cfg.HTTP, r.httpMux, host)
 		if err != nil {
 			return err
 		}
 
-		err = r.startHTTPServer(r.cfg.HTTP, host)
+		err = r.startHTTPServer(r.cfg.HTTP, r.serverHTTP, host)
 		if err != nil {
 			return err
 		}
+		for endpoint, mux := range r.signalHTTPMuxes {
+			cfg := *r.cfg.HTTP
+			cfg.Endpoint = endpoint
+			server, errHTTP := r.newHTTPServer(&cfg, mux, host)
+			if errHTTP != nil {
+				return errHTTP
+			}
+			r.signalServersHTTP = append(r.signalServersHTTP, server)
+			if err = r.startHTTPServer(&cfg, server, host); err != nil {
+				return err
+			}
+		}
 	}
 
 	return err
 }
 
+func (r *otlpReceiver) newGRPCServer(cfg *configgrpc.GRPCServerSettings, host component.Host) (*grpc.Server, error) {
+	return cfg.ToServer(
+		host,
+		r.settings.TelemetrySettings,
+		grpc.InTapHandle(r.refuseStreamOnMemoryPressure),
+		grpc.ChainUnaryInterceptor(exportErrorToStatus),
+	)
+}
+
+func (r *otlpReceiver) newHTTPServer(cfg *confighttp.HTTPServerSettings, mux *http.ServeMux, host component.Host) (*http.Server, error) {
+	return cfg.ToServer(
+		host,
+		r.settings.TelemetrySettings,
+		r.refuseOnMemoryPressure(mux),
+		confighttp.WithErrorHandler(errorHandler),
+	)
+}
+
+// signalGRPCSettings returns the settings of the gRPC protocol listening on the given endpoint.
+func (r *otlpReceiver) signalGRPCSettings(endpoint string) *configgrpc.GRPCServerSettings {
+	cfg := *r.cfg.GRPC
+	cfg.NetAddr.Endpoint = endpoint
+	return &cfg
+}
+
+// signalGRPCServer returns the gRPC server receiving the signal of the given data type.
+func (r *otlpReceiver) signalGRPCServer(dataType component.DataType) *grpc.Server {
+	if endpoint := r.cfg.Signals.get(dataType).GRPCEndpoint; endpoint != "" {
+		return r.signalServersGRPC[endpoint]
+	}
+	return r.serverGRPC
+}
+
+// signalHTTPMux returns the mux of the HTTP server receiving the signal of the given data type,
+// nil if the HTTP protocol is not enabled.
+func (r *otlpReceiver) signalHTTPMux(dataType component.DataType) *http.ServeMux {
+	if endpoint := r.cfg.Signals.get(dataType).HTTPEndpoint; endpoint != "" && r.httpMux != nil {
+		return r.signalHTTPMuxes[endpoint]
+	}
+	return r.httpMux
+}
+
+// checkSignalEnabled returns an error if the reception of the signal of the given data type is disabled.
+func (r *otlpReceiver) checkSignalEnabled(dataType component.DataType) error {
+	if r.cfg.Signals.get(dataType).Disabled {
+		return fmt.Errorf("the %s signal is disabled in the configuration of the receiver", dataType)
+	}
+	return nil
+}
+
 // Start runs the trace receiver on the gRPC server. Currently
 // it also enables the metrics receiver too.
 func (r *otlpReceiver) Start(_ context.Context, host component.Host) error {
+	r.memoryLimiter = memorylimiter.FromHost(host)
 	return r.startProtocolServers(host)
 }
 
+// refuseStreamOnMemoryPressure refuses the gRPC requests with a retryable status, before reading them,
+// while the memory usage is above the soft limit of the service-wide memory limiter.
+func (r *otlpReceiver) refuseStreamOnMemoryPressure(ctx context.Context, _ *tap.Info) (context.Context, error) {
+	if r.memoryLimiter.MustRefuse() {
+		return nil, status.Error(codes.Unavailable, memorylimiter.ErrDataRefused.Error())
+	}
+	return ctx, nil
+}
+
+// refuseOnMemoryPressure refuses the HTTP requests with a retryable status, before reading their body,
+// while the memory usage is above the soft limit of the service-wide memory limiter.
+func (r *otlpReceiver) refuseOnMemoryPressure(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if r.memoryLimiter.MustRefuse() {
+			errorHandler(resp, req, memorylimiter.ErrDataRefused.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(resp, req)
+	})
+}
+
 // Shutdown is a method to turn off receiving.
 func (r *otlpReceiver) Shutdown(ctx context.Context) error {
 	var err error
@@ -173,10 +302,16 @@ func (r *otlpReceiver) Shutdown(ctx context.Context) error {
 	if r.serverHTTP != nil {
 		err = r.serverHTTP.Shutdown(ctx)
 	}
+	for _, server := range r.signalServersHTTP {
+		err = multierr.Append(err, server.Shutdown(ctx))
+	}
 
 	if r.serverGRPC != nil {
 		r.serverGRPC.GracefulStop()
 	}
+	for _, server := range r.signalServersGRPC {
+		server.GracefulStop()
+	}
 
 	r.shutdownWG.Wait()
 	return err
@@ -186,19 +321,23 @@ func (r *otlpReceiver) registerTraceConsumer(tc consumer.Traces) error {
 	if tc == nil {
 		return component.ErrNilNextConsumer
 	}
-	r.tracesReceiver = trace.New(tc, r.obsrepGRPC)
-	httpTracesReceiver := trace.New(tc, r.obsrepHTTP)
-	if r.httpMux != nil {
-		r.httpMux.HandleFunc("/v1/traces", func(resp http.ResponseWriter, req *http.Request) {
+	if err := r.checkSignalEnabled(component.DataTypeTraces); err != nil {
+		return err
+	}
+	r.tracesReceiver = trace.New(tc, r.obsrepGRPC, r.admission)
+	// The HTTP requests are admitted by handleTraces, before their body is decoded.
+	httpTracesReceiver := trace.New(tc, r.obsrepHTTP, nil)
+	if mux := r.signalHTTPMux(component.DataTypeTraces); mux != nil {
+		mux.HandleFunc("/v1/traces", func(resp http.ResponseWriter, req *http.Request) {
 			if req.Method != http.MethodPost {
 				handleUnmatchedMethod(resp)
 				return
 			}
 			switch getMimeTypeFromContentType(req.Header.Get("Content-Type")) {
 			case pbContentType:
-				handleTraces(resp, req, httpTracesReceiver, pbEncoder)
+				handleTraces(resp, req, httpTracesReceiver, r.admission, pbEncoder)
 			case jsonContentType:
-				handleTraces(resp, req, httpTracesReceiver, jsEncoder)
+				handleTraces(resp, req, httpTracesReceiver, r.admission, jsEncoder)
 			default:
 				handleUnmatchedContentType(resp)
 			}
@@ -211,19 +350,23 @@ func (r *otlpReceiver) registerMetricsConsumer(mc consumer.Metrics) error {
 	if mc == nil {
 		return component.ErrNilNextConsumer
 	}
-	r.metricsReceiver = metrics.New(mc, r.obsrepGRPC)
-	httpMetricsReceiver := metrics.New(mc, r.obsrepHTTP)
-	if r.httpMux != nil {
-		r.httpMux.HandleFunc("/v1/metrics", func(resp http.ResponseWriter, req *http.Request) {
+	if err := r.checkSignalEnabled(component.DataTypeMetrics); err != nil {
+		return err
+	}
+	r.metricsReceiver = metrics.New(mc, r.obsrepGRPC, r.admission)
+	// The HTTP requests are admitted by handleMetrics, before their body is decoded.
+	httpMetricsReceiver := metrics.New(mc, r.obsrepHTTP, nil)
+	if mux := r.signalHTTPMux(component.DataTypeMetrics); mux != nil {
+		mux.HandleFunc("/v1/metrics", func(resp http.ResponseWriter, req *http.Request) {
 			if req.Method != http.MethodPost {
 				handleUnmatchedMethod(resp)
 				return
 			}
 			switch getMimeTypeFromContentType(req.Header.Get("Content-Type")) {
 			case pbContentType:
-				handleMetrics(resp, req, httpMetricsReceiver, pbEncoder)
+				handleMetrics(resp, req, httpMetricsReceiver, r.admission, pbEncoder)
 			case jsonContentType:
-				handleMetrics(resp, req, httpMetricsReceiver, jsEncoder)
+				handleMetrics(resp, req, httpMetricsReceiver, r.admission, jsEncoder)
 			default:
 				handleUnmatchedContentType(resp)
 			}
@@ -236,19 +379,52 @@ func (r *otlpReceiver) registerLogsConsumer(lc consumer.Logs) error {
 	if lc == nil {
 		return component.ErrNilNextConsumer
 	}
-	r.logsReceiver = logs.New(lc, r.obsrepGRPC)
-	httpLogsReceiver := logs.New(lc, r.obsrepHTTP)
-	if r.httpMux != nil {
-		r.httpMux.HandleFunc("/v1/logs", func(resp http.ResponseWriter, req *http.Request) {
+	if err := r.checkSignalEnabled(component.DataTypeLogs); err != nil {
+		return err
+	}
+	r.logsReceiver = logs.New(lc, r.obsrepGRPC, r.admission)
+	// The HTTP requests are admitted by handleLogs, before their body is decoded.
+	httpLogsReceiver := logs.New(lc, r.obsrepHTTP, nil)
+	if mux := r.signalHTTPMux(component.DataTypeLogs); mux != nil {
+		mux.HandleFunc("/v1/logs", func(resp http.ResponseWriter, req *http.Request) {
+			if req.Method != http.MethodPost {
+				handleUnmatchedMethod(resp)
+				return
+			}
+			switch getMimeTypeFromContentType(req.Header.Get("Content-Type")) {
+			case pbContentType:
+				handleLogs(resp, req, httpLogsReceiver, r.admission, pbEncoder)
+			case jsonContentType:
+				handleLogs(resp, req, httpLogsReceiver, r.admission, jsEncoder)
+			default:
+				handleUnmatchedContentType(resp)
+			}
+		})
+	}
+	return nil
+}
+
+func (r *otlpReceiver) registerProfilesConsumer(pc consumer.Profiles) error {
+	if pc == nil {
+		return component.ErrNilNextConsumer
+	}
+	if err := r.checkSignalEnabled(component.DataTypeProfiles); err != nil {
+		return err
+	}
+	r.profilesReceiver = profiles.New(pc, r.obsrepGRPC, r.admission)
+	// The HTTP requests are admitted by handleProfiles, before their body is decoded.
+	httpProfilesReceiver := profiles.New(pc, r.obsrepHTTP, nil)
+	if mux := r.signalHTTPMux(component.DataTypeProfiles); mux != nil {
+		mux.HandleFunc("/v1experimental/profiles", func(resp http.ResponseWriter, req *http.Request) {
 			if req.Method != http.MethodPost {
 				handleUnmatchedMethod(resp)
 				return
 			}
 			switch getMimeTypeFromContentType(req.Header.Get("Content-Type")) {
 			case pbContentType:
-				handleLogs(resp, req, httpLogsReceiver, pbEncoder)
+				handleProfiles(resp, req, httpProfilesReceiver, r.admission, pbEncoder)
 			case jsonContentType:
-				handleLogs(resp, req, httpLogsReceiver, jsEncoder)
+				handleProfiles(resp, req, httpProfilesReceiver, r.admission, jsEncoder)
 			default:
 				handleUnmatchedContentType(resp)
 			}
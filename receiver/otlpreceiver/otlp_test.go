@@ -13,12 +13,15 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -34,14 +37,19 @@ import (
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/memorylimiter"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 	semconv "go.opentelemetry.io/collector/semconv/v1.5.0"
 )
@@ -783,6 +791,360 @@ func TestOTLPReceiverHTTPTracesIngestTest(t *testing.T) {
 	require.NoError(t, tt.CheckReceiverTraces("http", int64(expectedReceivedBatches), int64(expectedIngestionBlockedRPCs)))
 }
 
+func TestOTLPReceiverProfiles(t *testing.T) {
+	grpcAddr := testutil.GetAvailableLocalAddress(t)
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = grpcAddr
+	cfg.HTTP.Endpoint = httpAddr
+
+	tt, err := obsreporttest.SetupTelemetry(otlpReceiverID)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	sink := new(consumertest.ProfilesSink)
+	set := tt.ToReceiverCreateSettings()
+	set.ID = otlpReceiverID
+	r, err := factory.CreateProfilesReceiver(context.Background(), set, cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	pd := testdata.GenerateProfiles(2)
+	req := pprofileotlp.NewExportRequestFromProfiles(pd)
+
+	cc, err := grpc.Dial(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	_, err = pprofileotlp.NewGRPCClient(cc).Export(context.Background(), req)
+	require.NoError(t, err)
+
+	buf, err := req.MarshalProto()
+	require.NoError(t, err)
+	httpResp, err := http.Post(fmt.Sprintf("http://%s/v1experimental/profiles", httpAddr), "application/x-protobuf", bytes.NewReader(buf))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, httpResp.StatusCode)
+	require.NoError(t, httpResp.Body.Close())
+
+	require.Len(t, sink.AllProfiles(), 2)
+	assert.EqualValues(t, pd, sink.AllProfiles()[0])
+	assert.EqualValues(t, pd, sink.AllProfiles()[1])
+	require.NoError(t, tt.CheckReceiverProfiles("grpc", 2, 0))
+	require.NoError(t, tt.CheckReceiverProfiles("http", 2, 0))
+}
+
+func TestOTLPReceiverUnixSockets(t *testing.T) {
+	dir := t.TempDir()
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr = confignet.NetAddr{Endpoint: filepath.Join(dir, "grpc.sock"), Transport: "unix"}
+	cfg.HTTP.Endpoint = filepath.Join(dir, "http.sock")
+	cfg.HTTP.Transport = "unix"
+
+	sink := new(consumertest.TracesSink)
+	r := newReceiver(t, factory, cfg, otlpReceiverID, sink, nil)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	td := testdata.GenerateTraces(1)
+	cc, err := grpc.Dial("unix://"+cfg.GRPC.NetAddr.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(td))
+	require.NoError(t, err)
+
+	buf, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalProto()
+	require.NoError(t, err)
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", cfg.HTTP.Endpoint)
+		},
+	}}
+	httpResp, err := client.Post("http://localhost/v1/traces", "application/x-protobuf", bytes.NewReader(buf))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, httpResp.StatusCode)
+	require.NoError(t, httpResp.Body.Close())
+
+	require.Len(t, sink.AllTraces(), 2)
+	assert.EqualValues(t, td, sink.AllTraces()[0])
+	assert.EqualValues(t, td, sink.AllTraces()[1])
+}
+
+type memoryLimiterHost struct {
+	component.Host
+	ml *memorylimiter.MemoryLimiter
+}
+
+func (h memoryLimiterHost) GetMemoryLimiter() *memorylimiter.MemoryLimiter {
+	return h.ml
+}
+
+func TestOTLPReceiverMemoryLimiter(t *testing.T) {
+	// The memory usage of the tests is always above the soft limit of 0.8 MiB.
+	ml, err := memorylimiter.New(&memorylimiter.Config{CheckInterval: time.Millisecond, MemoryLimitMiB: 1}, zap.NewNop())
+	require.NoError(t, err)
+	host := memoryLimiterHost{Host: componenttest.NewNopHost(), ml: ml}
+	require.NoError(t, ml.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, ml.Shutdown(context.Background())) })
+	require.Eventually(t, ml.MustRefuse, 5*time.Second, time.Millisecond)
+
+	grpcAddr := testutil.GetAvailableLocalAddress(t)
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = grpcAddr
+	cfg.HTTP.Endpoint = httpAddr
+	sink := new(consumertest.TracesSink)
+	r := newReceiver(t, factory, cfg, otlpReceiverID, sink, nil)
+	require.NoError(t, r.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	td := testdata.GenerateTraces(1)
+	cc, err := grpc.Dial(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(td))
+	errStatus, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.Unavailable, errStatus.Code())
+
+	pbBytes, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "http://"+httpAddr+"/v1/traces", bytes.NewReader(pbBytes))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", pbContentType)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	respBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	respStatus := &spb.Status{}
+	require.NoError(t, proto.Unmarshal(respBytes, respStatus))
+	assert.Equal(t, codes.Unavailable, codes.Code(respStatus.Code))
+
+	assert.Empty(t, sink.AllTraces())
+}
+
+func TestOTLPReceiverAdmission(t *testing.T) {
+	grpcAddr := testutil.GetAvailableLocalAddress(t)
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = grpcAddr
+	cfg.HTTP.Endpoint = httpAddr
+	// Only the first request is admitted within the test.
+	cfg.Admission = &receiverhelper.AdmissionSettings{
+		AdmissionLimits: receiverhelper.AdmissionLimits{MaxRequestsPerSecond: 0.001},
+	}
+	sink := new(consumertest.TracesSink)
+	r := newReceiver(t, factory, cfg, otlpReceiverID, sink, nil)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	td := testdata.GenerateTraces(1)
+	cc, err := grpc.Dial(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(td))
+	require.NoError(t, err)
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(td))
+	errStatus, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.Unavailable, errStatus.Code())
+
+	pbBytes, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "http://"+httpAddr+"/v1/traces", bytes.NewReader(pbBytes))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", pbContentType)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	respBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	respStatus := &spb.Status{}
+	require.NoError(t, proto.Unmarshal(respBytes, respStatus))
+	assert.Equal(t, codes.ResourceExhausted, codes.Code(respStatus.Code))
+
+	// The HTTP requests are refused before their body is decoded.
+	req, err = http.NewRequest(http.MethodPost, "http://"+httpAddr+"/v1/traces", bytes.NewReader([]byte("invalid")))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", pbContentType)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
+func TestOTLPReceiverPartialSuccess(t *testing.T) {
+	grpcAddr := testutil.GetAvailableLocalAddress(t)
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = grpcAddr
+	cfg.HTTP.Endpoint = httpAddr
+	next := consumertest.NewErr(consumererror.NewPartial(errors.New("1 span is too old"), 1))
+	r := newReceiver(t, factory, cfg, otlpReceiverID, next, nil)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	td := testdata.GenerateTraces(2)
+	cc, err := grpc.Dial(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	grpcResp, err := ptraceotlp.NewGRPCClient(cc).Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(td))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), grpcResp.PartialSuccess().RejectedSpans())
+	assert.Equal(t, "Permanent error: 1 span is too old", grpcResp.PartialSuccess().ErrorMessage())
+
+	pbBytes, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "http://"+httpAddr+"/v1/traces", bytes.NewReader(pbBytes))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", pbContentType)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	respBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	httpResp := ptraceotlp.NewExportResponse()
+	require.NoError(t, httpResp.UnmarshalProto(respBytes))
+	assert.Equal(t, int64(1), httpResp.PartialSuccess().RejectedSpans())
+}
+
+func TestOTLPReceiverRetryAfter(t *testing.T) {
+	grpcAddr := testutil.GetAvailableLocalAddress(t)
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = grpcAddr
+	cfg.HTTP.Endpoint = httpAddr
+	next := consumertest.NewErr(consumererror.NewRetryAfter(errors.New("queue is full"), 1500*time.Millisecond))
+	r := newReceiver(t, factory, cfg, otlpReceiverID, next, nil)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	td := testdata.GenerateTraces(1)
+	cc, err := grpc.Dial(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(td))
+	errStatus, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.Unavailable, errStatus.Code())
+	require.Len(t, errStatus.Details(), 1)
+	retryInfo, ok := errStatus.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, 1500*time.Millisecond, retryInfo.RetryDelay.AsDuration())
+
+	pbBytes, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "http://"+httpAddr+"/v1/traces", bytes.NewReader(pbBytes))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", pbContentType)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+}
+
+func TestOTLPReceiverDisabledSignal(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.HTTP.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.Signals.Traces.Disabled = true
+
+	set := receivertest.NewNopCreateSettings()
+	_, err := factory.CreateTracesReceiver(context.Background(), set, cfg, consumertest.NewNop())
+	assert.EqualError(t, err, "the traces signal is disabled in the configuration of the receiver")
+
+	r, err := factory.CreateLogsReceiver(context.Background(), set, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	// The spans are refused, instead of being accepted and dropped.
+	cc, err := grpc.Dial(cfg.GRPC.NetAddr.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(1)))
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	resp, err := http.Post("http://"+cfg.HTTP.Endpoint+"/v1/traces", pbContentType, bytes.NewReader(nil))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestOTLPReceiverSignalEndpoints(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.HTTP.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.Signals.Metrics.GRPCEndpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.Signals.Metrics.HTTPEndpoint = testutil.GetAvailableLocalAddress(t)
+	tracesSink := new(consumertest.TracesSink)
+	metricsSink := new(consumertest.MetricsSink)
+	r := newReceiver(t, factory, cfg, otlpReceiverID, tracesSink, metricsSink)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	exportMetrics := func(endpoint string) error {
+		cc, err := grpc.Dial(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+		require.NoError(t, err)
+		defer func() {
+			assert.NoError(t, cc.Close())
+		}()
+		_, err = pmetricotlp.NewGRPCClient(cc).Export(context.Background(), pmetricotlp.NewExportRequestFromMetrics(testdata.GenerateMetrics(1)))
+		return err
+	}
+	assert.Equal(t, codes.Unimplemented, status.Code(exportMetrics(cfg.GRPC.NetAddr.Endpoint)))
+	assert.NoError(t, exportMetrics(cfg.Signals.Metrics.GRPCEndpoint))
+
+	postMetrics := func(endpoint string) int {
+		pbBytes, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(testdata.GenerateMetrics(1))
+		require.NoError(t, err)
+		resp, err := http.Post("http://"+endpoint+"/v1/metrics", pbContentType, bytes.NewReader(pbBytes))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+	assert.Equal(t, http.StatusNotFound, postMetrics(cfg.HTTP.Endpoint))
+	assert.Equal(t, http.StatusOK, postMetrics(cfg.Signals.Metrics.HTTPEndpoint))
+	assert.Len(t, metricsSink.AllMetrics(), 2)
+
+	// The other signals are still received on the endpoints of the protocols.
+	pbBytes, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(testdata.GenerateTraces(1))
+	require.NoError(t, err)
+	resp, err := http.Post("http://"+cfg.HTTP.Endpoint+"/v1/traces", pbContentType, bytes.NewReader(pbBytes))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, tracesSink.AllTraces(), 1)
+}
+
 func TestGRPCInvalidTLSCredentials(t *testing.T) {
 	cfg := &Config{
 		Protocols: Protocols{
@@ -14,7 +14,9 @@ import (
 
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/profiles"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 // Pre-computed status with code=Internal to be used in case of a marshaling error.
@@ -22,11 +24,12 @@ var fallbackMsg = []byte(`{"code": 13, "message": "failed to marshal error messa
 
 const fallbackContentType = "application/json"
 
-func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver, encoder encoder) {
-	body, ok := readAndCloseBody(resp, req, encoder)
+func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver, admission *receiverhelper.AdmissionController, encoder encoder) {
+	body, release, ok := readAdmittedBody(resp, req, admission, encoder)
 	if !ok {
 		return
 	}
+	defer release()
 
 	otlpReq, err := encoder.unmarshalTracesRequest(body)
 	if err != nil {
@@ -36,7 +39,7 @@ func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *t
 
 	otlpResp, err := tracesReceiver.Export(req.Context(), otlpReq)
 	if err != nil {
-		writeError(resp, encoder, err, http.StatusInternalServerError)
+		writeError(resp, encoder, err, exportErrorStatusCode(err))
 		return
 	}
 
@@ -48,11 +51,12 @@ func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *t
 	writeResponse(resp, encoder.contentType(), http.StatusOK, msg)
 }
 
-func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver, encoder encoder) {
-	body, ok := readAndCloseBody(resp, req, encoder)
+func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver, admission *receiverhelper.AdmissionController, encoder encoder) {
+	body, release, ok := readAdmittedBody(resp, req, admission, encoder)
 	if !ok {
 		return
 	}
+	defer release()
 
 	otlpReq, err := encoder.unmarshalMetricsRequest(body)
 	if err != nil {
@@ -62,7 +66,7 @@ func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver
 
 	otlpResp, err := metricsReceiver.Export(req.Context(), otlpReq)
 	if err != nil {
-		writeError(resp, encoder, err, http.StatusInternalServerError)
+		writeError(resp, encoder, err, exportErrorStatusCode(err))
 		return
 	}
 
@@ -74,11 +78,12 @@ func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver
 	writeResponse(resp, encoder.contentType(), http.StatusOK, msg)
 }
 
-func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver, encoder encoder) {
-	body, ok := readAndCloseBody(resp, req, encoder)
+func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver, admission *receiverhelper.AdmissionController, encoder encoder) {
+	body, release, ok := readAdmittedBody(resp, req, admission, encoder)
 	if !ok {
 		return
 	}
+	defer release()
 
 	otlpReq, err := encoder.unmarshalLogsRequest(body)
 	if err != nil {
@@ -88,7 +93,7 @@ func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.
 
 	otlpResp, err := logsReceiver.Export(req.Context(), otlpReq)
 	if err != nil {
-		writeError(resp, encoder, err, http.StatusInternalServerError)
+		writeError(resp, encoder, err, exportErrorStatusCode(err))
 		return
 	}
 
@@ -100,6 +105,61 @@ func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.
 	writeResponse(resp, encoder.contentType(), http.StatusOK, msg)
 }
 
+func handleProfiles(resp http.ResponseWriter, req *http.Request, profilesReceiver *profiles.Receiver, admission *receiverhelper.AdmissionController, encoder encoder) {
+	body, release, ok := readAdmittedBody(resp, req, admission, encoder)
+	if !ok {
+		return
+	}
+	defer release()
+
+	otlpReq, err := encoder.unmarshalProfilesRequest(body)
+	if err != nil {
+		writeError(resp, encoder, err, http.StatusBadRequest)
+		return
+	}
+
+	otlpResp, err := profilesReceiver.Export(req.Context(), otlpReq)
+	if err != nil {
+		writeError(resp, encoder, err, exportErrorStatusCode(err))
+		return
+	}
+
+	msg, err := encoder.marshalProfilesResponse(otlpResp)
+	if err != nil {
+		writeError(resp, encoder, err, http.StatusInternalServerError)
+		return
+	}
+	writeResponse(resp, encoder.contentType(), http.StatusOK, msg)
+}
+
+// readAdmittedBody reads the body of a request admitted by the admission controller, which may be nil.
+// The request is admitted before its body is decoded, with the size given by its Content-Length, or
+// with the size of its body if it is unknown, for instance when it is compressed. The returned function
+// must be called once the request is processed.
+func readAdmittedBody(resp http.ResponseWriter, req *http.Request, admission *receiverhelper.AdmissionController, encoder encoder) ([]byte, func(), bool) {
+	release := func() {}
+	var err error
+	if req.ContentLength >= 0 {
+		if release, err = admission.Acquire(req.Context(), req.ContentLength); err != nil {
+			_ = req.Body.Close()
+			writeError(resp, encoder, err, exportErrorStatusCode(err))
+			return nil, nil, false
+		}
+	}
+	body, ok := readAndCloseBody(resp, req, encoder)
+	if !ok {
+		release()
+		return nil, nil, false
+	}
+	if req.ContentLength < 0 {
+		if release, err = admission.Acquire(req.Context(), int64(len(body))); err != nil {
+			writeError(resp, encoder, err, exportErrorStatusCode(err))
+			return nil, nil, false
+		}
+	}
+	return body, release, true
+}
+
 func readAndCloseBody(resp http.ResponseWriter, req *http.Request, encoder encoder) ([]byte, bool) {
 	body, err := io.ReadAll(req.Body)
 	if err != nil {
@@ -115,6 +175,9 @@ func readAndCloseBody(resp http.ResponseWriter, req *http.Request, encoder encod
 
 // writeError encodes the HTTP error inside a rpc.Status message as required by the OTLP protocol.
 func writeError(w http.ResponseWriter, encoder encoder, err error, statusCode int) {
+	if seconds, ok := retryAfterSeconds(err); ok {
+		w.Header().Set("Retry-After", seconds)
+	}
 	s, ok := status.FromError(err)
 	if !ok {
 		s = errorMsgToStatus(err.Error(), statusCode)
@@ -155,8 +218,13 @@ func writeResponse(w http.ResponseWriter, contentType string, statusCode int, ms
 }
 
 func errorMsgToStatus(errMsg string, statusCode int) *status.Status {
-	if statusCode == http.StatusBadRequest {
+	switch statusCode {
+	case http.StatusBadRequest:
 		return status.New(codes.InvalidArgument, errMsg)
+	case http.StatusServiceUnavailable:
+		return status.New(codes.Unavailable, errMsg)
+	case http.StatusTooManyRequests:
+		return status.New(codes.ResourceExhausted, errMsg)
 	}
 	return status.New(codes.Unknown, errMsg)
 }
@@ -40,6 +40,15 @@ type Logs interface {
 	component.Component
 }
 
+// Profiles receiver receives profiles.
+// Its purpose is to translate data from any format to the collector's internal profiles data format.
+// ProfilesReceiver feeds a consumer.Profiles with data.
+//
+// For example, it could be a receiver that scrapes pprof endpoints and convert them into pprofile.Profiles.
+type Profiles interface {
+	component.Component
+}
+
 // CreateSettings configures Receiver creators.
 type CreateSettings struct {
 	// ID returns the ID of the component that will be created.
@@ -82,6 +91,14 @@ type Factory interface {
 	// LogsReceiverStability gets the stability level of the LogsReceiver.
 	LogsReceiverStability() component.StabilityLevel
 
+	// CreateProfilesReceiver creates a ProfilesReceiver based on this config.
+	// If the receiver type does not support the data type or if the config is not valid
+	// an error will be returned instead.
+	CreateProfilesReceiver(ctx context.Context, set CreateSettings, cfg component.Config, nextConsumer consumer.Profiles) (Profiles, error)
+
+	// ProfilesReceiverStability gets the stability level of the ProfilesReceiver.
+	ProfilesReceiverStability() component.StabilityLevel
+
 	unexportedFactoryFunc()
 }
 
@@ -145,6 +162,22 @@ func (f CreateLogsFunc) CreateLogsReceiver(
 	return f(ctx, set, cfg, nextConsumer)
 }
 
+// CreateProfilesFunc is the equivalent of ReceiverFactory.CreateProfilesReceiver().
+type CreateProfilesFunc func(context.Context, CreateSettings, component.Config, consumer.Profiles) (Profiles, error)
+
+// CreateProfilesReceiver implements Factory.CreateProfilesReceiver().
+func (f CreateProfilesFunc) CreateProfilesReceiver(
+	ctx context.Context,
+	set CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Profiles,
+) (Profiles, error) {
+	if f == nil {
+		return nil, component.ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
 type factory struct {
 	cfgType component.Type
 	component.CreateDefaultConfigFunc
@@ -154,6 +187,9 @@ type factory struct {
 	metricsStabilityLevel component.StabilityLevel
 	CreateLogsFunc
 	logsStabilityLevel component.StabilityLevel
+	CreateProfilesFunc
+	profilesStabilityLevel component.StabilityLevel
+	capabilities           component.FactoryCapabilities
 }
 
 func (f *factory) Type() component.Type {
@@ -162,6 +198,10 @@ func (f *factory) Type() component.Type {
 
 func (f *factory) unexportedFactoryFunc() {}
 
+func (f *factory) Capabilities() component.FactoryCapabilities {
+	return f.capabilities
+}
+
 func (f *factory) TracesReceiverStability() component.StabilityLevel {
 	return f.tracesStabilityLevel
 }
@@ -174,6 +214,10 @@ func (f *factory) LogsReceiverStability() component.StabilityLevel {
 	return f.logsStabilityLevel
 }
 
+func (f *factory) ProfilesReceiverStability() component.StabilityLevel {
+	return f.profilesStabilityLevel
+}
+
 // WithTraces overrides the default "error not supported" implementation for CreateTracesReceiver and the default "undefined" stability level.
 func WithTraces(createTracesReceiver CreateTracesFunc, sl component.StabilityLevel) FactoryOption {
 	return factoryOptionFunc(func(o *factory) {
@@ -198,6 +242,21 @@ func WithLogs(createLogsReceiver CreateLogsFunc, sl component.StabilityLevel) Fa
 	})
 }
 
+// WithProfiles overrides the default "error not supported" implementation for CreateProfilesReceiver and the default "undefined" stability level.
+func WithProfiles(createProfilesReceiver CreateProfilesFunc, sl component.StabilityLevel) FactoryOption {
+	return factoryOptionFunc(func(o *factory) {
+		o.profilesStabilityLevel = sl
+		o.CreateProfilesFunc = createProfilesReceiver
+	})
+}
+
+// WithCapabilities declares the capabilities of the receivers created by the factory.
+func WithCapabilities(capabilities component.FactoryCapabilities) FactoryOption {
+	return factoryOptionFunc(func(o *factory) {
+		o.capabilities = capabilities
+	})
+}
+
 // NewFactory returns a Factory.
 func NewFactory(cfgType component.Type, createDefaultConfig component.CreateDefaultConfigFunc, options ...FactoryOption) Factory {
 	f := &factory{
@@ -282,6 +341,27 @@ func (b *Builder) CreateLogs(ctx context.Context, set CreateSettings, next consu
 	return f.CreateLogsReceiver(ctx, set, cfg, next)
 }
 
+// CreateProfiles creates a Profiles receiver based on the settings and config.
+func (b *Builder) CreateProfiles(ctx context.Context, set CreateSettings, next consumer.Profiles) (Profiles, error) {
+	cfg, existsCfg := b.cfgs[set.ID]
+	if !existsCfg {
+		return nil, fmt.Errorf("receiver %q is not configured", set.ID)
+	}
+
+	f, existsFactory := b.factories[set.ID.Type()]
+	if !existsFactory {
+		return nil, fmt.Errorf("receiver factory not available for: %q", set.ID)
+	}
+
+	logStabilityLevel(set.Logger, f.ProfilesReceiverStability())
+	return f.CreateProfilesReceiver(ctx, set, cfg, next)
+}
+
+// Config returns the configuration of the receiver with the given ID, or nil if it is not configured.
+func (b *Builder) Config(id component.ID) component.Config {
+	return b.cfgs[id]
+}
+
 func (b *Builder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
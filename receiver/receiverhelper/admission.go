@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper // import "go.opentelemetry.io/collector/receiver/receiverhelper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+const defaultMetadataCardinalityLimit = 1000
+
+var (
+	// ErrTooManyInflightBytes is returned when admitting a request would exceed the maximum size in bytes
+	// of the requests being processed. It is not permanent, the clients are expected to retry later.
+	ErrTooManyInflightBytes = errors.New("too many bytes in flight")
+
+	// ErrTooManyRequests is returned when admitting a request would exceed the maximum rate of requests.
+	// It is not permanent, the clients are expected to retry later.
+	ErrTooManyRequests = errors.New("too many requests")
+
+	// ErrTooManyTenants is returned when the request is from a new tenant and the maximum number
+	// of tenants is reached.
+	ErrTooManyTenants = errors.New("too many admission metadata-value combinations")
+)
+
+// AdmissionLimits defines the limits applied by the admission controller of a receiver.
+type AdmissionLimits struct {
+	// MaxInflightBytes is the maximum size in bytes of the requests being processed at a given time,
+	// 0 means no limit. A request larger than the limit is admitted when no other request is in flight.
+	MaxInflightBytes int64 `mapstructure:"max_inflight_bytes"`
+	// MaxRequestsPerSecond is the maximum rate of requests, 0 means no limit. Bursts of up to one
+	// second of requests are admitted.
+	MaxRequestsPerSecond float64 `mapstructure:"max_requests_per_second"`
+}
+
+// AdmissionSettings defines configuration for the admission controller of a receiver, which refuses
+// the requests exceeding the limits with a retryable error, to protect the collector from noisy producers.
+type AdmissionSettings struct {
+	// AdmissionLimits are the limits applied to all the requests.
+	AdmissionLimits `mapstructure:",squash"`
+	// MetadataKeys is a list of client.Metadata keys identifying the tenant of the requests.
+	// The TenantLimits are applied to each distinct combination of values of these keys.
+	MetadataKeys []string `mapstructure:"metadata_keys"`
+	// TenantLimits are the limits applied to the requests of each tenant, if MetadataKeys is set.
+	TenantLimits AdmissionLimits `mapstructure:"tenant_limits"`
+	// MetadataCardinalityLimit is the maximum number of tenants tracked, 1000 if not set.
+	// Once it is reached, the idle tenants are forgotten to track new ones, and the requests
+	// of new tenants are refused if no tenant is idle.
+	MetadataCardinalityLimit uint32 `mapstructure:"metadata_cardinality_limit"`
+}
+
+// Validate checks if the AdmissionSettings configuration is valid.
+func (cfg *AdmissionSettings) Validate() error {
+	if cfg.MaxInflightBytes < 0 || cfg.TenantLimits.MaxInflightBytes < 0 {
+		return errors.New("admission max inflight bytes must not be negative")
+	}
+	if cfg.MaxRequestsPerSecond < 0 || cfg.TenantLimits.MaxRequestsPerSecond < 0 {
+		return errors.New("admission max requests per second must not be negative")
+	}
+	uniq := map[string]bool{}
+	for _, k := range cfg.MetadataKeys {
+		l := strings.ToLower(k)
+		if _, has := uniq[l]; has {
+			return fmt.Errorf("duplicate entry in admission metadata_keys: %q (case-insensitive)", l)
+		}
+		uniq[l] = true
+	}
+	return nil
+}
+
+// AdmissionController admits the requests of a receiver within the configured limits.
+// The methods of a nil *AdmissionController admit all the requests.
+type AdmissionController struct {
+	cfg AdmissionSettings
+	// metadataKeys is the lower-cased list of metadata keys.
+	metadataKeys []string
+	now          func() time.Time
+
+	mu      sync.Mutex
+	global  *admissionLimiter
+	tenants map[string]*admissionLimiter
+}
+
+// NewAdmissionController returns the admission controller applying the limits of cfg.
+func NewAdmissionController(cfg AdmissionSettings) *AdmissionController {
+	mks := make([]string, len(cfg.MetadataKeys))
+	for i, k := range cfg.MetadataKeys {
+		mks[i] = strings.ToLower(k)
+	}
+	if cfg.MetadataCardinalityLimit == 0 {
+		cfg.MetadataCardinalityLimit = defaultMetadataCardinalityLimit
+	}
+	ac := &AdmissionController{
+		cfg:          cfg,
+		metadataKeys: mks,
+		now:          time.Now,
+		tenants:      map[string]*admissionLimiter{},
+	}
+	ac.global = newAdmissionLimiter(cfg.AdmissionLimits, ac.now())
+	return ac
+}
+
+// Acquire admits a request of the given size in bytes, received with ctx, and returns the function
+// to call once the request is processed. It returns ErrTooManyInflightBytes, ErrTooManyRequests or
+// ErrTooManyTenants if the request is refused.
+func (ac *AdmissionController) Acquire(ctx context.Context, size int64) (func(), error) {
+	if ac == nil {
+		return func() {}, nil
+	}
+
+	var tenant *admissionLimiter
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	now := ac.now()
+	if len(ac.metadataKeys) != 0 {
+		key := ac.tenantKey(ctx)
+		tenant = ac.tenants[key]
+		if tenant == nil {
+			if len(ac.tenants) >= int(ac.cfg.MetadataCardinalityLimit) {
+				ac.removeIdleTenants(now)
+			}
+			if len(ac.tenants) >= int(ac.cfg.MetadataCardinalityLimit) {
+				return nil, ErrTooManyTenants
+			}
+			tenant = newAdmissionLimiter(ac.cfg.TenantLimits, now)
+			ac.tenants[key] = tenant
+		}
+		if err := tenant.check(now, size); err != nil {
+			return nil, err
+		}
+	}
+	if err := ac.global.check(now, size); err != nil {
+		return nil, err
+	}
+
+	ac.global.admit(size)
+	if tenant != nil {
+		tenant.admit(size)
+	}
+	return func() {
+		ac.mu.Lock()
+		defer ac.mu.Unlock()
+		ac.global.inflightBytes -= size
+		if tenant != nil {
+			tenant.inflightBytes -= size
+		}
+	}, nil
+}
+
+// removeIdleTenants forgets the idle tenants, which are tracked again as new ones by their next request.
+func (ac *AdmissionController) removeIdleTenants(now time.Time) {
+	for key, tenant := range ac.tenants {
+		if tenant.idle(now) {
+			delete(ac.tenants, key)
+		}
+	}
+}
+
+// tenantKey returns the key identifying the tenant of the request.
+func (ac *AdmissionController) tenantKey(ctx context.Context) string {
+	info := client.FromContext(ctx)
+	var sb strings.Builder
+	for _, k := range ac.metadataKeys {
+		for _, v := range info.Metadata.Get(k) {
+			sb.WriteString(v)
+			// The values are separated by a character which is not allowed in header values.
+			sb.WriteByte(0)
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// admissionLimiter tracks the bytes in flight and the rate of the requests, with a token bucket.
+type admissionLimiter struct {
+	limits        AdmissionLimits
+	inflightBytes int64
+	tokens        float64
+	last          time.Time
+}
+
+func newAdmissionLimiter(limits AdmissionLimits, now time.Time) *admissionLimiter {
+	return &admissionLimiter{
+		limits: limits,
+		tokens: burst(limits.MaxRequestsPerSecond),
+		last:   now,
+	}
+}
+
+// burst returns the maximum number of tokens of the bucket, at least 1 so that a request can be admitted.
+func burst(rate float64) float64 {
+	return math.Max(1, rate)
+}
+
+// check returns the error refusing a request of the given size, nil if it can be admitted.
+func (l *admissionLimiter) check(now time.Time, size int64) error {
+	if l.limits.MaxInflightBytes != 0 && l.inflightBytes != 0 && l.inflightBytes+size > l.limits.MaxInflightBytes {
+		return ErrTooManyInflightBytes
+	}
+	if l.limits.MaxRequestsPerSecond != 0 {
+		l.tokens = math.Min(burst(l.limits.MaxRequestsPerSecond), l.tokens+now.Sub(l.last).Seconds()*l.limits.MaxRequestsPerSecond)
+		l.last = now
+		if l.tokens < 1 {
+			return ErrTooManyRequests
+		}
+	}
+	return nil
+}
+
+// idle returns whether the limiter has no bytes in flight and a full bucket, so that replacing it
+// with a new one does not change the requests admitted.
+func (l *admissionLimiter) idle(now time.Time) bool {
+	if l.inflightBytes != 0 {
+		return false
+	}
+	rate := l.limits.MaxRequestsPerSecond
+	return rate == 0 || l.tokens+now.Sub(l.last).Seconds()*rate >= burst(rate)
+}
+
+// admit accounts for a request checked by check.
+func (l *admissionLimiter) admit(size int64) {
+	l.inflightBytes += size
+	if l.limits.MaxRequestsPerSecond != 0 {
+		l.tokens--
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+func TestAdmissionSettings_Validate(t *testing.T) {
+	cfg := AdmissionSettings{}
+	assert.NoError(t, cfg.Validate())
+
+	cfg = AdmissionSettings{TenantLimits: AdmissionLimits{MaxInflightBytes: -1}}
+	assert.EqualError(t, cfg.Validate(), "admission max inflight bytes must not be negative")
+
+	cfg = AdmissionSettings{AdmissionLimits: AdmissionLimits{MaxRequestsPerSecond: -1}}
+	assert.EqualError(t, cfg.Validate(), "admission max requests per second must not be negative")
+
+	cfg = AdmissionSettings{MetadataKeys: []string{"Tenant", "tenant"}}
+	assert.EqualError(t, cfg.Validate(), `duplicate entry in admission metadata_keys: "tenant" (case-insensitive)`)
+}
+
+func TestAdmissionController_Nil(t *testing.T) {
+	var ac *AdmissionController
+	release, err := ac.Acquire(context.Background(), 1<<30)
+	require.NoError(t, err)
+	release()
+}
+
+func TestAdmissionController_MaxInflightBytes(t *testing.T) {
+	ac := NewAdmissionController(AdmissionSettings{AdmissionLimits: AdmissionLimits{MaxInflightBytes: 10}})
+
+	// A request larger than the limit is admitted when nothing else is in flight.
+	release1, err := ac.Acquire(context.Background(), 20)
+	require.NoError(t, err)
+	_, err = ac.Acquire(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrTooManyInflightBytes)
+	release1()
+
+	release1, err = ac.Acquire(context.Background(), 6)
+	require.NoError(t, err)
+	release2, err := ac.Acquire(context.Background(), 4)
+	require.NoError(t, err)
+	_, err = ac.Acquire(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrTooManyInflightBytes)
+	release2()
+	release3, err := ac.Acquire(context.Background(), 4)
+	require.NoError(t, err)
+	release1()
+	release3()
+	assert.Equal(t, int64(0), ac.global.inflightBytes)
+}
+
+func TestAdmissionController_MaxRequestsPerSecond(t *testing.T) {
+	ac := NewAdmissionController(AdmissionSettings{AdmissionLimits: AdmissionLimits{MaxRequestsPerSecond: 2}})
+	now := time.Now()
+	ac.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		release, err := ac.Acquire(context.Background(), 1)
+		require.NoError(t, err)
+		release()
+	}
+	_, err := ac.Acquire(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrTooManyRequests)
+
+	// A token is added every 500ms.
+	now = now.Add(500 * time.Millisecond)
+	_, err = ac.Acquire(context.Background(), 1)
+	assert.NoError(t, err)
+	_, err = ac.Acquire(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrTooManyRequests)
+
+	// The burst is limited to one second of requests.
+	now = now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		_, err = ac.Acquire(context.Background(), 1)
+		require.NoError(t, err)
+	}
+	_, err = ac.Acquire(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrTooManyRequests)
+}
+
+func TestAdmissionController_TenantLimits(t *testing.T) {
+	ac := NewAdmissionController(AdmissionSettings{
+		AdmissionLimits:          AdmissionLimits{MaxInflightBytes: 100},
+		MetadataKeys:             []string{"Tenant"},
+		TenantLimits:             AdmissionLimits{MaxInflightBytes: 10},
+		MetadataCardinalityLimit: 2,
+	})
+	tenantCtx := func(tenant string) context.Context {
+		return client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"tenant": {tenant}}),
+		})
+	}
+
+	releaseA, err := ac.Acquire(tenantCtx("a"), 10)
+	require.NoError(t, err)
+	_, err = ac.Acquire(tenantCtx("a"), 1)
+	assert.ErrorIs(t, err, ErrTooManyInflightBytes)
+	// The other tenants are not affected.
+	releaseB, err := ac.Acquire(tenantCtx("b"), 10)
+	require.NoError(t, err)
+	// The cardinality limit is reached.
+	_, err = ac.Acquire(tenantCtx("c"), 1)
+	assert.ErrorIs(t, err, ErrTooManyTenants)
+
+	releaseA()
+	assert.Equal(t, int64(10), ac.global.inflightBytes)
+	// The idle tenant is forgotten to track the new one.
+	releaseC, err := ac.Acquire(tenantCtx("c"), 1)
+	require.NoError(t, err)
+	assert.Len(t, ac.tenants, 2)
+	assert.NotContains(t, ac.tenants, ac.tenantKey(tenantCtx("a")))
+
+	releaseB()
+	releaseC()
+	assert.Equal(t, int64(0), ac.global.inflightBytes)
+	_, err = ac.Acquire(tenantCtx("a"), 10)
+	assert.NoError(t, err)
+}
+
+func TestAdmissionController_IdleTenants(t *testing.T) {
+	ac := NewAdmissionController(AdmissionSettings{
+		MetadataKeys:             []string{"tenant"},
+		TenantLimits:             AdmissionLimits{MaxRequestsPerSecond: 2},
+		MetadataCardinalityLimit: 1,
+	})
+	now := time.Now()
+	ac.now = func() time.Time { return now }
+	tenantCtx := func(tenant string) context.Context {
+		return client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"tenant": {tenant}}),
+		})
+	}
+
+	release, err := ac.Acquire(tenantCtx("a"), 1)
+	require.NoError(t, err)
+	release()
+	// The tenant is not idle until its bucket is refilled.
+	now = now.Add(400 * time.Millisecond)
+	_, err = ac.Acquire(tenantCtx("b"), 1)
+	assert.ErrorIs(t, err, ErrTooManyTenants)
+	now = now.Add(100 * time.Millisecond)
+	_, err = ac.Acquire(tenantCtx("b"), 1)
+	assert.NoError(t, err)
+}
+
+func TestAdmissionController_GlobalRefusalNotAccounted(t *testing.T) {
+	ac := NewAdmissionController(AdmissionSettings{
+		AdmissionLimits: AdmissionLimits{MaxInflightBytes: 10},
+		MetadataKeys:    []string{"tenant"},
+		TenantLimits:    AdmissionLimits{MaxRequestsPerSecond: 1},
+	})
+	now := time.Now()
+	ac.now = func() time.Time { return now }
+
+	_, err := ac.Acquire(context.Background(), 10)
+	require.NoError(t, err)
+	now = now.Add(time.Second)
+	_, err = ac.Acquire(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrTooManyInflightBytes)
+	// The token of the tenant was not consumed by the refused request.
+	assert.Equal(t, 1.0, ac.tenants["\n"].tokens)
+}
@@ -36,6 +36,11 @@ func TestNewNopFactory(t *testing.T) {
 	require.NoError(t, err)
 	assert.NoError(t, logs.Start(context.Background(), componenttest.NewNopHost()))
 	assert.NoError(t, logs.Shutdown(context.Background()))
+
+	profiles, err := factory.CreateProfilesReceiver(context.Background(), NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.NoError(t, profiles.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, profiles.Shutdown(context.Background()))
 }
 
 func TestNewNopBuilder(t *testing.T) {
@@ -64,4 +69,10 @@ func TestNewNopBuilder(t *testing.T) {
 	bLogs, err := builder.CreateLogs(context.Background(), set, consumertest.NewNop())
 	require.NoError(t, err)
 	assert.IsType(t, logs, bLogs)
+
+	profiles, err := factory.CreateProfilesReceiver(context.Background(), set, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	bProfiles, err := builder.CreateProfiles(context.Background(), set, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.IsType(t, profiles, bProfiles)
 }
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scraperhelper // import "go.opentelemetry.io/collector/receiver/scraperhelper"
+
+import (
+	"time"
+)
+
+const (
+	defaultMaxFailures         = 3
+	defaultMaxIntervalMultiple = 10
+)
+
+// schedule is the collection schedule of a scraper, when a jitter or the
+// adaptive collection interval is configured.
+type schedule struct {
+	baseInterval time.Duration
+	maxInterval  time.Duration
+	budget       time.Duration
+	adaptive     bool
+	maxFailures  int
+
+	interval time.Duration
+	failures int
+}
+
+func newSchedule(cfg *ScraperControllerSettings) *schedule {
+	s := &schedule{
+		baseInterval: cfg.CollectionInterval,
+		maxInterval:  cfg.Adaptive.MaxInterval,
+		budget:       cfg.Adaptive.DurationBudget,
+		adaptive:     cfg.Adaptive.Enabled,
+		maxFailures:  cfg.Adaptive.MaxFailures,
+		interval:     cfg.CollectionInterval,
+	}
+	if s.maxInterval <= 0 {
+		s.maxInterval = defaultMaxIntervalMultiple * s.baseInterval
+	}
+	if s.budget <= 0 {
+		s.budget = s.baseInterval / 2
+	}
+	if s.maxFailures <= 0 {
+		s.maxFailures = defaultMaxFailures
+	}
+	return s
+}
+
+// update records the outcome of a scrape, adapts the collection interval if
+// enabled, and returns whether the scrape exceeded its duration budget.
+func (s *schedule) update(duration time.Duration, failed bool) bool {
+	late := duration > s.budget
+	if !s.adaptive {
+		return late
+	}
+
+	if failed {
+		s.failures++
+	} else {
+		s.failures = 0
+	}
+	switch {
+	case late || s.failures >= s.maxFailures:
+		s.failures = 0
+		s.interval *= 2
+		if s.interval > s.maxInterval {
+			s.interval = s.maxInterval
+		}
+	case !failed:
+		s.interval /= 2
+		if s.interval < s.baseInterval {
+			s.interval = s.baseInterval
+		}
+	}
+	return late
+}
+
+// next returns the time of the scrape following the one scheduled at
+// scheduled, given that the scrape ended at now, and the number of scrapes
+// missed because their time passed while the scrape was running.
+func (s *schedule) next(scheduled time.Time, now time.Time) (time.Time, int) {
+	next := scheduled.Add(s.interval)
+	if !now.After(next) {
+		return next, 0
+	}
+	missed := int(now.Sub(next)/s.interval) + 1
+	return next.Add(time.Duration(missed) * s.interval), missed
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scraperhelper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScheduleDefaults(t *testing.T) {
+	s := newSchedule(&ScraperControllerSettings{CollectionInterval: time.Second})
+	assert.Equal(t, time.Second, s.interval)
+	assert.Equal(t, 500*time.Millisecond, s.budget)
+	assert.Equal(t, 3, s.maxFailures)
+	assert.Equal(t, 10*time.Second, s.maxInterval)
+	assert.False(t, s.adaptive)
+}
+
+func TestScheduleLateScrapes(t *testing.T) {
+	s := newSchedule(&ScraperControllerSettings{CollectionInterval: time.Second})
+	assert.False(t, s.update(400*time.Millisecond, false))
+	assert.True(t, s.update(600*time.Millisecond, false))
+	assert.True(t, s.update(600*time.Millisecond, true))
+	// Without the adaptive collection interval, the interval never changes.
+	assert.Equal(t, time.Second, s.interval)
+}
+
+func TestScheduleAdaptive(t *testing.T) {
+	s := newSchedule(&ScraperControllerSettings{
+		CollectionInterval: time.Second,
+		Adaptive: AdaptiveSettings{
+			Enabled:        true,
+			DurationBudget: 100 * time.Millisecond,
+			MaxFailures:    2,
+			MaxInterval:    5 * time.Second,
+		},
+	})
+
+	// Late scrapes back off the interval, up to the max interval.
+	for _, want := range []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		assert.True(t, s.update(200*time.Millisecond, false))
+		assert.Equal(t, want, s.interval)
+	}
+
+	// Healthy scrapes bring it back to the collection interval.
+	for _, want := range []time.Duration{2500 * time.Millisecond, 1250 * time.Millisecond, time.Second, time.Second} {
+		assert.False(t, s.update(10*time.Millisecond, false))
+		assert.Equal(t, want, s.interval)
+	}
+
+	// Failures back off the interval once they repeat.
+	s.update(10*time.Millisecond, true)
+	assert.Equal(t, time.Second, s.interval)
+	s.update(10*time.Millisecond, true)
+	assert.Equal(t, 2*time.Second, s.interval)
+	s.update(10*time.Millisecond, true)
+	assert.Equal(t, 2*time.Second, s.interval)
+	s.update(10*time.Millisecond, false)
+	assert.Equal(t, time.Second, s.interval)
+	s.update(10*time.Millisecond, true)
+	assert.Equal(t, time.Second, s.interval)
+}
+
+func TestScheduleNext(t *testing.T) {
+	s := newSchedule(&ScraperControllerSettings{CollectionInterval: time.Second})
+	scheduled := time.Unix(100, 0)
+
+	next, missed := s.next(scheduled, scheduled.Add(200*time.Millisecond))
+	assert.Equal(t, scheduled.Add(time.Second), next)
+	assert.Equal(t, 0, missed)
+
+	next, missed = s.next(scheduled, scheduled.Add(time.Second))
+	assert.Equal(t, scheduled.Add(time.Second), next)
+	assert.Equal(t, 0, missed)
+
+	next, missed = s.next(scheduled, scheduled.Add(2500*time.Millisecond))
+	assert.Equal(t, scheduled.Add(3*time.Second), next)
+	assert.Equal(t, 2, missed)
+}
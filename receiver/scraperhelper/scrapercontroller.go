@@ -6,6 +6,7 @@ package scraperhelper // import "go.opentelemetry.io/collector/receiver/scraperh
 import (
 	"context"
 	"errors"
+	"math/rand"
 	"time"
 
 	"go.uber.org/multierr"
@@ -35,7 +36,8 @@ func AddScraper(scraper Scraper) ScraperControllerOption {
 
 // WithTickerChannel allows you to override the scraper controllers ticker
 // channel to specify when scrape is called. This is only expected to be
-// used by tests.
+// used by tests. The ticker channel is not used when the scrapers are
+// scheduled on their own, with a jitter or an adaptive collection interval.
 func WithTickerChannel(tickerCh <-chan time.Time) ScraperControllerOption {
 	return func(o *controller) {
 		o.tickerCh = tickerCh
@@ -48,13 +50,20 @@ type controller struct {
 	collectionInterval time.Duration
 	initialDelay       time.Duration
 	timeout            time.Duration
+	jitter             time.Duration
+	adaptive           AdaptiveSettings
 	nextConsumer       consumer.Metrics
 
 	scrapers    []Scraper
 	obsScrapers []*obsreport.Scraper
 
 	tickerCh <-chan time.Time
+	// schedules are the collection schedules of the scrapers, when a jitter or an
+	// adaptive collection interval is configured
+	schedules []*schedule
 
+	// host is the host the receiver was started with, which handles the panics of the scrapers.
+	host        component.Host
 	initialized bool
 	done        chan struct{}
 	terminated  chan struct{}
@@ -93,6 +102,8 @@ func NewScraperControllerReceiver(
 		collectionInterval: cfg.CollectionInterval,
 		initialDelay:       cfg.InitialDelay,
 		timeout:            cfg.Timeout,
+		jitter:             cfg.Jitter,
+		adaptive:           cfg.Adaptive,
 		nextConsumer:       nextConsumer,
 		done:               make(chan struct{}),
 		terminated:         make(chan struct{}),
@@ -119,11 +130,30 @@ func NewScraperControllerReceiver(
 		}
 	}
 
+	if sc.jitter > 0 || sc.adaptive.Enabled {
+		sc.schedules = make([]*schedule, len(sc.scrapers))
+		for i := range sc.scrapers {
+			sc.schedules[i] = newSchedule(cfg)
+		}
+	}
+
 	return sc, nil
 }
 
+// Restartable reports that the receiver can be started again after it was shut down, which the service
+// does when the receiver panics.
+func (sc *controller) Restartable() bool {
+	return true
+}
+
 // Start the receiver, invoked during service start.
 func (sc *controller) Start(ctx context.Context, host component.Host) error {
+	if sc.initialized {
+		// The receiver is started again after a shutdown, when the service restarts it.
+		sc.done = make(chan struct{})
+		sc.terminated = make(chan struct{})
+	}
+	sc.host = host
 	for _, scraper := range sc.scrapers {
 		if err := scraper.Start(ctx, host); err != nil {
 			return err
@@ -131,7 +161,11 @@ func (sc *controller) Start(ctx context.Context, host component.Host) error {
 	}
 
 	sc.initialized = true
-	sc.startScraping()
+	if sc.schedules != nil {
+		sc.startScheduledScraping()
+	} else {
+		sc.startScraping()
+	}
 	return nil
 }
 
@@ -160,11 +194,12 @@ func (sc *controller) startScraping() {
 			<-time.After(sc.initialDelay)
 		}
 
-		if sc.tickerCh == nil {
+		tickerCh := sc.tickerCh
+		if tickerCh == nil {
 			ticker := time.NewTicker(sc.collectionInterval)
 			defer ticker.Stop()
 
-			sc.tickerCh = ticker.C
+			tickerCh = ticker.C
 		}
 
 		// Call scrape method on initialision to ensure
@@ -173,7 +208,7 @@ func (sc *controller) startScraping() {
 		sc.scrapeMetricsAndReport(context.Background())
 		for {
 			select {
-			case <-sc.tickerCh:
+			case <-tickerCh:
 				ctx, done := context.WithTimeout(context.Background(), sc.timeout)
 				sc.scrapeMetricsAndReport(ctx)
 				done()
@@ -194,7 +229,7 @@ func (sc *controller) scrapeMetricsAndReport(ctx context.Context) {
 	for i, scraper := range sc.scrapers {
 		scrp := sc.obsScrapers[i]
 		ctx = scrp.StartMetricsOp(ctx)
-		md, err := scraper.Scrape(ctx)
+		md, err := sc.scrape(ctx, scraper)
 
 		if err != nil {
 			sc.logger.Error("Error scraping metrics", zap.Error(err), zap.Stringer("scraper", scraper.ID()))
@@ -213,6 +248,129 @@ func (sc *controller) scrapeMetricsAndReport(ctx context.Context) {
 	sc.obsrecv.EndMetricsOp(ctx, "", dataPointCount, err)
 }
 
+// startScheduledScraping starts a goroutine calling Scrape for the scrapers when
+// they are due according to their own collection interval, from the initial delay
+// plus a random jitter. The scrapers due at the same time are scraped together.
+func (sc *controller) startScheduledScraping() {
+	delay := sc.initialDelay
+	if delay < 0 {
+		delay = 0
+	}
+	if sc.jitter > 0 {
+		// #nosec G404 -- the jitter does not need a cryptographically secure random number
+		delay += time.Duration(rand.Int63n(int64(sc.jitter)))
+	}
+	go func() {
+		sc.runSchedule(delay)
+		sc.terminated <- struct{}{}
+	}()
+}
+
+// runSchedule calls Scrape for the scrapers when they are due, and passes the
+// metrics of the scrapers scraped at the same time to the next component in a
+// single batch. The collection intervals of the scrapers are multiples of the
+// configured one, so their schedules stay aligned.
+func (sc *controller) runSchedule(delay time.Duration) {
+	scheduled := time.Now().Add(delay)
+	due := make([]time.Time, len(sc.scrapers))
+	for i := range due {
+		due[i] = scheduled
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+		case <-sc.done:
+			return
+		}
+
+		metrics := pmetric.NewMetrics()
+		for i := range sc.scrapers {
+			if !due[i].After(scheduled) {
+				due[i] = sc.scrapeScheduled(i, scheduled, metrics)
+			}
+		}
+		dataPointCount := metrics.DataPointCount()
+		ctx := sc.obsrecv.StartMetricsOp(context.Background())
+		err := sc.nextConsumer.ConsumeMetrics(ctx, metrics)
+		sc.obsrecv.EndMetricsOp(ctx, "", dataPointCount, err)
+
+		scheduled = due[0]
+		for _, d := range due[1:] {
+			if d.Before(scheduled) {
+				scheduled = d
+			}
+		}
+		timer.Reset(time.Until(scheduled))
+	}
+}
+
+// scrapeScheduled calls Scrape for the scraper i scheduled at scheduled, appends
+// the scraped metrics to metrics, records the scrapes that are late or missed,
+// and returns when the scraper is due next.
+func (sc *controller) scrapeScheduled(i int, scheduled time.Time, metrics pmetric.Metrics) time.Time {
+	scraper, scrp, sched := sc.scrapers[i], sc.obsScrapers[i], sc.schedules[i]
+
+	start := time.Now()
+	md, ok := sc.scrapeAndRecord(i)
+	end := time.Now()
+	md.ResourceMetrics().MoveAndAppendTo(metrics.ResourceMetrics())
+
+	interval := sched.interval
+	if sched.update(end.Sub(start), !ok) {
+		scrp.RecordLateScrape(context.Background())
+	}
+	if sched.interval != interval {
+		sc.logger.Info("Adjusted the collection interval of the scraper",
+			zap.Stringer("scraper", scraper.ID()), zap.Duration("interval", sched.interval))
+	}
+
+	next, missed := sched.next(scheduled, end)
+	if missed > 0 {
+		sc.logger.Warn("Missed scrapes, the previous scrape was still running",
+			zap.Stringer("scraper", scraper.ID()), zap.Int("missed", missed))
+		scrp.RecordMissedScrapes(context.Background(), missed)
+	}
+	return next
+}
+
+// scrapeAndRecord calls the Scrape function of the scraper i and records
+// observability information. It returns the scraped metrics, and false if the
+// scrape failed.
+func (sc *controller) scrapeAndRecord(i int) (pmetric.Metrics, bool) {
+	ctx := context.Background()
+	if sc.timeout > 0 {
+		var done context.CancelFunc
+		ctx, done = context.WithTimeout(ctx, sc.timeout)
+		defer done()
+	}
+
+	scraper, scrp := sc.scrapers[i], sc.obsScrapers[i]
+	ctx = scrp.StartMetricsOp(ctx)
+	md, err := sc.scrape(ctx, scraper)
+	if err != nil {
+		sc.logger.Error("Error scraping metrics", zap.Error(err), zap.Stringer("scraper", scraper.ID()))
+		if !scrapererror.IsPartialScrapeError(err) {
+			scrp.EndMetricsOp(ctx, 0, err)
+			return pmetric.NewMetrics(), false
+		}
+	}
+	scrp.EndMetricsOp(ctx, md.MetricCount(), err)
+	return md, true
+}
+
+// scrape calls the Scrape function of the scraper. A panic of the scraper is handled by the host,
+// which either turns it into the error of the scrape or lets it crash the collector.
+func (sc *controller) scrape(ctx context.Context, scraper Scraper) (md pmetric.Metrics, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			md, err = pmetric.NewMetrics(), component.RecoverPanic(sc.host, r)
+		}
+	}()
+	return scraper.Scrape(ctx)
+}
+
 // stopScraping stops the ticker
 func (sc *controller) stopScraping() {
 	close(sc.done)
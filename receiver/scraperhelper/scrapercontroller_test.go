@@ -6,6 +6,7 @@ package scraperhelper
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -432,3 +433,194 @@ func TestScrapeControllerInitialDelay(t *testing.T) {
 
 	assert.NoError(t, r.Shutdown(context.Background()), "Must not error closing down")
 }
+
+func TestScrapeControllerSchedule(t *testing.T) {
+	if testing.Short() {
+		t.Skip("This requires real time to pass, skipping")
+		return
+	}
+
+	tt, err := obsreporttest.SetupTelemetry(component.NewID("receiver"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	var scrapes int
+	scp, err := NewScraper("scraper", func(ctx context.Context) (pmetric.Metrics, error) {
+		scrapes++
+		if scrapes == 1 {
+			// The first scrape exceeds its budget, and misses the next two scrapes.
+			<-time.After(250 * time.Millisecond)
+		}
+		md := pmetric.NewMetrics()
+		md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+		return md, nil
+	})
+	require.NoError(t, err)
+
+	sink := new(consumertest.MetricsSink)
+	r, err := NewScraperControllerReceiver(
+		&ScraperControllerSettings{
+			CollectionInterval: 100 * time.Millisecond,
+			Timeout:            100 * time.Millisecond,
+			Jitter:             time.Millisecond,
+		},
+		tt.ToReceiverCreateSettings(),
+		sink,
+		AddScraper(scp),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		return sink.DataPointCount() >= 1
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	require.NoError(t, obsreporttest.CheckScraperScheduleMetrics(tt, component.NewID("receiver"), component.NewID("scraper"), 2, 1))
+}
+
+func TestScrapeControllerAdaptiveInterval(t *testing.T) {
+	if testing.Short() {
+		t.Skip("This requires real time to pass, skipping")
+		return
+	}
+
+	scrapeErr := errors.New("scrape failed")
+	scrapeTimes := make(chan time.Time, 10)
+	scp, err := NewScraper("scraper", func(ctx context.Context) (pmetric.Metrics, error) {
+		scrapeTimes <- time.Now()
+		return pmetric.NewMetrics(), scrapeErr
+	})
+	require.NoError(t, err)
+
+	r, err := NewScraperControllerReceiver(
+		&ScraperControllerSettings{
+			CollectionInterval: 20 * time.Millisecond,
+			Timeout:            20 * time.Millisecond,
+			Adaptive: AdaptiveSettings{
+				Enabled:     true,
+				MaxFailures: 1,
+				MaxInterval: 80 * time.Millisecond,
+			},
+		},
+		receivertest.NewNopCreateSettings(),
+		new(consumertest.MetricsSink),
+		AddScraper(scp),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	var times []time.Time
+	for len(times) < 4 {
+		times = append(times, <-scrapeTimes)
+	}
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	// Each failure doubles the collection interval, up to the max interval. The scrapes are
+	// scheduled from the time of the first one, the gaps between them vary with its latency.
+	assert.GreaterOrEqual(t, times[1].Sub(times[0]), 30*time.Millisecond)
+	assert.GreaterOrEqual(t, times[2].Sub(times[0]), 110*time.Millisecond)
+	assert.GreaterOrEqual(t, times[3].Sub(times[0]), 190*time.Millisecond)
+}
+
+// panicHost is a host turning the panics of the components into errors.
+type panicHost struct {
+	component.Host
+	recovered chan any
+}
+
+func (h *panicHost) RecoverPanic(recovered any, _ []byte) error {
+	h.recovered <- recovered
+	return errors.New("recovered")
+}
+
+func TestScrapeControllerPanic(t *testing.T) {
+	scp, err := NewScraper("panicking", func(context.Context) (pmetric.Metrics, error) {
+		panic("scrape failure")
+	})
+	require.NoError(t, err)
+
+	tickerCh := make(chan time.Time)
+	sink := new(consumertest.MetricsSink)
+	r, err := NewScraperControllerReceiver(
+		newTestNoDelaySettings(),
+		receivertest.NewNopCreateSettings(),
+		sink,
+		AddScraper(scp),
+		WithTickerChannel(tickerCh),
+	)
+	require.NoError(t, err)
+
+	host := &panicHost{Host: componenttest.NewNopHost(), recovered: make(chan any, 1)}
+	require.NoError(t, r.Start(context.Background(), host))
+	assert.Equal(t, "scrape failure", <-host.recovered)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	// The receiver can be started again after a shutdown, when the service restarts it.
+	require.NoError(t, r.Start(context.Background(), host))
+	assert.Equal(t, "scrape failure", <-host.recovered)
+	tickerCh <- time.Now()
+	assert.Equal(t, "scrape failure", <-host.recovered)
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.Zero(t, sink.DataPointCount())
+}
+
+func TestScrapeControllerScheduleSingleBatch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("This requires real time to pass, skipping")
+		return
+	}
+
+	scrapes := map[string]*atomic.Int32{}
+	newScraper := func(name string, err error) Scraper {
+		count := new(atomic.Int32)
+		scrapes[name] = count
+		scp, scpErr := NewScraper(name, func(ctx context.Context) (pmetric.Metrics, error) {
+			count.Add(1)
+			md := pmetric.NewMetrics()
+			md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("scraper", name)
+			return md, err
+		})
+		require.NoError(t, scpErr)
+		return scp
+	}
+	scrapeCount := func(name string) int32 { return scrapes[name].Load() }
+
+	sink := new(consumertest.MetricsSink)
+	r, err := NewScraperControllerReceiver(
+		&ScraperControllerSettings{
+			CollectionInterval: 20 * time.Millisecond,
+			Timeout:            20 * time.Millisecond,
+			Jitter:             10 * time.Millisecond,
+			Adaptive: AdaptiveSettings{
+				Enabled:     true,
+				MaxFailures: 1,
+				MaxInterval: 80 * time.Millisecond,
+			},
+		},
+		receivertest.NewNopCreateSettings(),
+		sink,
+		AddScraper(newScraper("first", nil)),
+		AddScraper(newScraper("second", nil)),
+		AddScraper(newScraper("failing", errors.New("scrape failed"))),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) >= 6
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	// The scrapers due at the same time pass their metrics in one batch, the failing
+	// scraper is scraped less often once its collection interval is backed off.
+	batches := sink.AllMetrics()
+	assert.Len(t, batches, int(scrapeCount("first")))
+	assert.Equal(t, scrapeCount("first"), scrapeCount("second"))
+	assert.Less(t, scrapeCount("failing"), scrapeCount("first"))
+	for _, md := range batches {
+		require.Equal(t, 2, md.ResourceMetrics().Len())
+		name, _ := md.ResourceMetrics().At(1).Resource().Attributes().Get("scraper")
+		assert.Equal(t, "second", name.Str())
+	}
+}
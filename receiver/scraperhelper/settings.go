@@ -16,6 +16,9 @@ import (
 var (
 	errNonPositiveInterval    = errors.New("requires positive value")
 	errTimeoutExceedsInterval = errors.New("timeout value exceeds collection interval")
+	errJitterExceedsInterval  = errors.New("jitter value must be lower than the collection interval")
+	errNegativeValue          = errors.New("requires non negative value")
+	errMaxIntervalTooLow      = errors.New("max interval value is lower than the collection interval")
 )
 
 // ScraperControllerSettings defines common settings for a scraper controller
@@ -32,6 +35,33 @@ type ScraperControllerSettings struct {
 	// Timeout is used to set scraper's context deadline, it must be within
 	// the range of (0, CollectionInterval]
 	Timeout time.Duration `mapstructure:"timeout"`
+	// Jitter is the maximum random delay added to the schedule of the scrapers,
+	// to spread the scrapes of the receivers started together. Each receiver
+	// gets its own delay, within the range of [0, CollectionInterval), shared
+	// by its scrapers so that their metrics are passed in a single batch.
+	Jitter time.Duration `mapstructure:"jitter"`
+	// Adaptive configures the backoff of the collection interval of the
+	// scrapers that are too slow or repeatedly fail.
+	Adaptive AdaptiveSettings `mapstructure:"adaptive"`
+}
+
+// AdaptiveSettings defines the settings of the adaptive collection interval.
+// When enabled, the collection interval of a scraper is doubled, up to
+// MaxInterval, each time a scrape exceeds DurationBudget or MaxFailures
+// scrapes fail in a row; it is halved back to CollectionInterval after
+// each successful scrape within the budget.
+type AdaptiveSettings struct {
+	// Enabled enables the adaptive collection interval.
+	Enabled bool `mapstructure:"enabled"`
+	// DurationBudget is the duration a scrape should not exceed, the scrapes
+	// exceeding it are reported as late. Defaults to half the collection interval.
+	DurationBudget time.Duration `mapstructure:"duration_budget"`
+	// MaxFailures is the number of scrapes failing in a row that backs off
+	// the collection interval. Defaults to 3.
+	MaxFailures int `mapstructure:"max_failures"`
+	// MaxInterval is the upper bound of the collection interval.
+	// Defaults to ten times the collection interval.
+	MaxInterval time.Duration `mapstructure:"max_interval"`
 }
 
 // NewDefaultScraperControllerSettings returns default scraper controller
@@ -54,5 +84,23 @@ func (set *ScraperControllerSettings) Validate() (errs error) {
 	if set.Timeout > set.CollectionInterval {
 		errs = multierr.Append(errs, errTimeoutExceedsInterval)
 	}
+	if set.Jitter < 0 {
+		errs = multierr.Append(errs, fmt.Errorf(`"jitter": %w`, errNegativeValue))
+	}
+	if set.Jitter > 0 && set.Jitter >= set.CollectionInterval {
+		errs = multierr.Append(errs, errJitterExceedsInterval)
+	}
+	if set.Adaptive.DurationBudget < 0 {
+		errs = multierr.Append(errs, fmt.Errorf(`"adaptive::duration_budget": %w`, errNegativeValue))
+	}
+	if set.Adaptive.MaxFailures < 0 {
+		errs = multierr.Append(errs, fmt.Errorf(`"adaptive::max_failures": %w`, errNegativeValue))
+	}
+	if set.Adaptive.MaxInterval < 0 {
+		errs = multierr.Append(errs, fmt.Errorf(`"adaptive::max_interval": %w`, errNegativeValue))
+	}
+	if set.Adaptive.MaxInterval > 0 && set.Adaptive.MaxInterval < set.CollectionInterval {
+		errs = multierr.Append(errs, errMaxIntervalTooLow)
+	}
 	return errs
 }
@@ -43,6 +43,54 @@ func TestScrapeControllerSettings(t *testing.T) {
 			},
 			errVal: `timeout value exceeds collection interval`,
 		},
+		{
+			name: "jitter and adaptive collection interval",
+			set: ScraperControllerSettings{
+				CollectionInterval: 10,
+				Timeout:            10,
+				Jitter:             5,
+				Adaptive: AdaptiveSettings{
+					Enabled:        true,
+					DurationBudget: 5,
+					MaxFailures:    2,
+					MaxInterval:    100,
+				},
+			},
+			errVal: "",
+		},
+		{
+			name: "jitter exceeds collection interval",
+			set: ScraperControllerSettings{
+				CollectionInterval: 10,
+				Timeout:            10,
+				Jitter:             10,
+			},
+			errVal: `jitter value must be lower than the collection interval`,
+		},
+		{
+			name: "negative adaptive settings",
+			set: ScraperControllerSettings{
+				CollectionInterval: 10,
+				Timeout:            10,
+				Jitter:             -1,
+				Adaptive: AdaptiveSettings{
+					DurationBudget: -1,
+					MaxFailures:    -1,
+					MaxInterval:    -1,
+				},
+			},
+			errVal: `"jitter": requires non negative value; "adaptive::duration_budget": requires non negative value; ` +
+				`"adaptive::max_failures": requires non negative value; "adaptive::max_interval": requires non negative value`,
+		},
+		{
+			name: "max interval lower than collection interval",
+			set: ScraperControllerSettings{
+				CollectionInterval: 10,
+				Timeout:            10,
+				Adaptive:           AdaptiveSettings{MaxInterval: 5},
+			},
+			errVal: `max interval value is lower than the collection interval`,
+		},
 	} {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
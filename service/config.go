@@ -4,13 +4,25 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/memorylimiter"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/faultinjection"
+	"go.opentelemetry.io/collector/service/gomemlimit"
+	"go.opentelemetry.io/collector/service/leaderelection"
+	"go.opentelemetry.io/collector/service/opamp"
+	"go.opentelemetry.io/collector/service/panicisolation"
 	"go.opentelemetry.io/collector/service/pipelines"
+	"go.opentelemetry.io/collector/service/recording"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
 
+var errNegativeDrainTimeout = errors.New("drain_timeout must not be negative")
+
 // Deprecated: [v0.80.0] use pipelines.PipelineConfig.
 type PipelineConfig = pipelines.PipelineConfig
 
@@ -22,8 +34,56 @@ type Config struct {
 	// Extensions are the ordered list of extensions configured for the service.
 	Extensions extensions.Config `mapstructure:"extensions"`
 
+	// ExtensionDependencies lists by extension the extensions it depends on, which are started
+	// before it and shut down after it.
+	ExtensionDependencies extensions.Dependencies `mapstructure:"extension_depends_on"`
+
 	// Pipelines are the set of data pipelines configured for the service.
 	Pipelines pipelines.Config `mapstructure:"pipelines"`
+
+	// FeatureGates enables or disables the feature gates by ID, once the gates set by the
+	// --feature-gates flag are applied.
+	FeatureGates map[string]bool `mapstructure:"feature_gates"`
+
+	// FaultInjection is the list of faults injected in the pipelines, only applied
+	// when the service.faultInjection feature gate is enabled.
+	FaultInjection faultinjection.Config `mapstructure:"fault_injection"`
+
+	// MemoryLimiter if not nil, enables the service-wide memory limiter, which the receivers
+	// consult to refuse data while the memory usage is above the soft limit.
+	MemoryLimiter *memorylimiter.Config `mapstructure:"memory_limiter"`
+
+	// SoftMemoryLimit if not nil, sets the soft memory limit of the Go runtime (GOMEMLIMIT) from the
+	// total memory available to the collector, and adjusts it when the total memory changes.
+	SoftMemoryLimit *gomemlimit.Config `mapstructure:"soft_memory_limit"`
+
+	// PanicIsolation if not nil, isolates the panics of the components according to its policy,
+	// instead of letting them crash the collector.
+	PanicIsolation *panicisolation.Config `mapstructure:"panic_isolation"`
+
+	// Shutdown configures how the service shuts down.
+	Shutdown ShutdownConfig `mapstructure:"shutdown"`
+
+	// LeaderElection if not nil, runs the collector in active-passive mode: the receivers are only
+	// started once the collector is elected leader among the collectors sharing the same lock.
+	LeaderElection *leaderelection.Config `mapstructure:"leader_election"`
+
+	// Recording if not nil, records the data entering the configured receivers to files,
+	// which can be replayed into a pipeline with the replay command.
+	Recording *recording.Config `mapstructure:"recording"`
+
+	// OpAMP if not nil, reports the state of the collector to an OpAMP server, which can also
+	// configure the collector. It is started with the collector, its changes are applied on restart.
+	OpAMP *opamp.Config `mapstructure:"opamp"`
+}
+
+// ShutdownConfig defines the configuration of the shutdown of the service.
+type ShutdownConfig struct {
+	// DrainTimeout is how long the exporters have to send their queued data once the receivers
+	// are stopped, counted from the start of the shutdown. The data still queued when it expires
+	// is dropped, persistent queues keep it for the next start. Zero disables the wait, the
+	// queued data is then sent without retries.
+	DrainTimeout time.Duration `mapstructure:"drain_timeout"`
 }
 
 func (cfg *Config) Validate() error {
@@ -31,9 +91,98 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("service::pipelines config validation failed: %w", err)
 	}
 
+	if err := cfg.ExtensionDependencies.Validate(cfg.Extensions); err != nil {
+		return fmt.Errorf("service::extension_depends_on config validation failed: %w", err)
+	}
+
+	if err := cfg.validateFaultInjection(); err != nil {
+		return fmt.Errorf("service::fault_injection config validation failed: %w", err)
+	}
+
+	if cfg.MemoryLimiter != nil {
+		if err := cfg.MemoryLimiter.Validate(); err != nil {
+			return fmt.Errorf("service::memory_limiter config validation failed: %w", err)
+		}
+	}
+
+	if cfg.SoftMemoryLimit != nil {
+		if err := cfg.SoftMemoryLimit.Validate(); err != nil {
+			return fmt.Errorf("service::soft_memory_limit config validation failed: %w", err)
+		}
+	}
+
+	if cfg.LeaderElection != nil {
+		if err := cfg.LeaderElection.Validate(); err != nil {
+			return fmt.Errorf("service::leader_election config validation failed: %w", err)
+		}
+	}
+
+	if cfg.Recording != nil {
+		if err := cfg.validateRecording(); err != nil {
+			return fmt.Errorf("service::recording config validation failed: %w", err)
+		}
+	}
+
+	if cfg.PanicIsolation != nil {
+		if err := cfg.PanicIsolation.Validate(); err != nil {
+			return fmt.Errorf("service::panic_isolation config validation failed: %w", err)
+		}
+	}
+
+	if cfg.OpAMP != nil {
+		if err := cfg.OpAMP.Validate(); err != nil {
+			return fmt.Errorf("service::opamp config validation failed: %w", err)
+		}
+	}
+
+	if cfg.Shutdown.DrainTimeout < 0 {
+		return fmt.Errorf("service::shutdown config validation failed: %w", errNegativeDrainTimeout)
+	}
+
 	if err := cfg.Telemetry.Validate(); err != nil {
 		fmt.Printf("service::telemetry config validation failed: %v\n", err)
 	}
 
 	return nil
 }
+
+func (cfg *Config) validateFaultInjection() error {
+	if err := cfg.FaultInjection.Validate(); err != nil {
+		return err
+	}
+	for _, f := range cfg.FaultInjection {
+		pipeline, ok := cfg.Pipelines[f.Pipeline]
+		if !ok {
+			return fmt.Errorf("references pipeline %q which is not configured", f.Pipeline)
+		}
+		if !containsID(pipeline.Processors, f.Component) && !containsID(pipeline.Exporters, f.Component) {
+			return fmt.Errorf("references component %q which is not a processor or exporter of pipeline %q", f.Component, f.Pipeline)
+		}
+	}
+	return nil
+}
+
+func (cfg *Config) validateRecording() error {
+	if err := cfg.Recording.Validate(); err != nil {
+		return err
+	}
+	for _, id := range cfg.Recording.Receivers {
+		used := false
+		for _, pipeline := range cfg.Pipelines {
+			used = used || containsID(pipeline.Receivers, id)
+		}
+		if !used {
+			return fmt.Errorf("references receiver %q which is not used by any pipeline", id)
+		}
+	}
+	return nil
+}
+
+func containsID(ids []component.ID, id component.ID) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
@@ -7,14 +7,22 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/memorylimiter"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/faultinjection"
+	"go.opentelemetry.io/collector/service/gomemlimit"
+	"go.opentelemetry.io/collector/service/leaderelection"
+	"go.opentelemetry.io/collector/service/opamp"
+	"go.opentelemetry.io/collector/service/panicisolation"
 	"go.opentelemetry.io/collector/service/pipelines"
+	"go.opentelemetry.io/collector/service/recording"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
 
@@ -61,6 +69,179 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: fmt.Errorf(`service::pipelines config validation failed: %w`, errors.New(`pipeline "wrongtype": unknown datatype "wrongtype"`)),
 		},
+		{
+			name: "valid-extension-dependencies",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Extensions = extensions.Config{component.NewID("nop"), component.NewIDWithName("nop", "secrets")}
+				cfg.ExtensionDependencies = extensions.Dependencies{component.NewID("nop"): {component.NewIDWithName("nop", "secrets")}}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "extension-dependency-not-enabled",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.ExtensionDependencies = extensions.Dependencies{component.NewID("nop"): {component.NewIDWithName("nop", "secrets")}}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::extension_depends_on config validation failed: %w`, errors.New(`extension "nop" depends on "nop/secrets" which is not enabled in the service`)),
+		},
+		{
+			name: "valid-fault-injection",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.FaultInjection = faultinjection.Config{{
+					Pipeline:        component.NewID("traces"),
+					Component:       component.NewID("nop"),
+					ErrorPercentage: 10,
+				}}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "fault-injection-unknown-pipeline",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.FaultInjection = faultinjection.Config{{
+					Pipeline:  component.NewID("logs"),
+					Component: component.NewID("nop"),
+				}}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::fault_injection config validation failed: %w`, errors.New(`references pipeline "logs" which is not configured`)),
+		},
+		{
+			name: "fault-injection-unknown-component",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.FaultInjection = faultinjection.Config{{
+					Pipeline:  component.NewID("traces"),
+					Component: component.NewID("otlp"),
+				}}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::fault_injection config validation failed: %w`, errors.New(`references component "otlp" which is not a processor or exporter of pipeline "traces"`)),
+		},
+		{
+			name: "valid-memory-limiter",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.MemoryLimiter = &memorylimiter.Config{CheckInterval: time.Second, MemoryLimitPercentage: 80, MemorySpikePercentage: 20}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-memory-limiter",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.MemoryLimiter = &memorylimiter.Config{CheckInterval: time.Second}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::memory_limiter config validation failed: %w`, errors.New(`limit_mib or limit_percentage must be greater than zero`)),
+		},
+		{
+			name: "valid-panic-isolation",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.PanicIsolation = &panicisolation.Config{Policy: panicisolation.PolicyRestart, InitialInterval: time.Second}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-panic-isolation",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.PanicIsolation = &panicisolation.Config{Policy: "ignore"}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::panic_isolation config validation failed: %w`, errors.New(`unsupported policy "ignore", must be one of "crash", "drop" or "restart"`)),
+		},
+		{
+			name: "valid-opamp",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.OpAMP = &opamp.Config{Endpoint: "https://opamp.example.com/v1/opamp", AcceptRemoteConfig: true}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-opamp",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.OpAMP = &opamp.Config{}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::opamp config validation failed: %w`, errors.New(`endpoint must be set`)),
+		},
+		{
+			name: "valid-soft-memory-limit",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.SoftMemoryLimit = &gomemlimit.Config{LimitPercentage: 90, HeadroomMiB: 64}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-soft-memory-limit",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.SoftMemoryLimit = &gomemlimit.Config{LimitPercentage: 120}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::soft_memory_limit config validation failed: %w`, errors.New(`limit_percentage must be less than or equal to hundred`)),
+		},
+		{
+			name: "negative-drain-timeout",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Shutdown.DrainTimeout = -time.Second
+				return cfg
+			},
+			expected: fmt.Errorf(`service::shutdown config validation failed: %w`, errNegativeDrainTimeout),
+		},
+		{
+			name: "invalid-leader-election",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.LeaderElection = &leaderelection.Config{}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::leader_election config validation failed: %w`, errors.New(`one of file or kubernetes must be set`)),
+		},
+		{
+			name: "valid-recording",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Recording = &recording.Config{Receivers: []component.ID{component.NewID("nop")}, Directory: "recordings"}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-recording",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Recording = &recording.Config{Receivers: []component.ID{component.NewID("nop")}}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::recording config validation failed: %w`, errors.New(`directory must be specified`)),
+		},
+		{
+			name: "recording-unused-receiver",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Recording = &recording.Config{Receivers: []component.ID{component.NewID("otlp")}, Directory: "recordings"}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::recording config validation failed: %w`, errors.New(`references receiver "otlp" which is not used by any pipeline`)),
+		},
 		{
 			name: "invalid-telemetry-metric-config",
 			cfgFn: func() *Config {
@@ -3,7 +3,37 @@
 
 package extensions // import "go.opentelemetry.io/collector/service/extensions"
 
-import "go.opentelemetry.io/collector/component"
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
 
 // Config represents the ordered list of extensions configured for the service.
 type Config []component.ID
+
+// Dependencies lists by extension the extensions it depends on, in addition to the ones
+// the extension declares itself by implementing extension.Dependent.
+type Dependencies map[component.ID][]component.ID
+
+// Validate checks that the dependencies only reference the extensions of cfg.
+func (deps Dependencies) Validate(cfg Config) error {
+	enabled := make(map[component.ID]bool, len(cfg))
+	for _, id := range cfg {
+		enabled[id] = true
+	}
+	for id, dependencies := range deps {
+		if !enabled[id] {
+			return fmt.Errorf("extension %q is not enabled in the service", id)
+		}
+		for _, dep := range dependencies {
+			if dep == id {
+				return fmt.Errorf("extension %q depends on itself", id)
+			}
+			if !enabled[dep] {
+				return fmt.Errorf("extension %q depends on %q which is not enabled in the service", id, dep)
+			}
+		}
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/internal/componenterror"
 	"go.opentelemetry.io/collector/service/internal/components"
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
@@ -23,36 +24,58 @@ const zExtensionName = "zextensionname"
 type Extensions struct {
 	telemetry component.TelemetrySettings
 	extMap    map[component.ID]extension.Extension
+	// order is the order the extensions are started in, after the extensions they depend on.
+	order []component.ID
+	// hosts are the hosts given to the started extensions, they report the statuses of the extensions.
+	hosts map[component.ID]component.Host
 }
 
-// Start starts all extensions.
+var _ extension.StatusWatcher = (*Extensions)(nil)
+
+// Start starts all extensions, every extension after the extensions it depends on.
 func (bes *Extensions) Start(ctx context.Context, host component.Host) error {
 	bes.telemetry.Logger.Info("Starting extensions...")
-	for extID, ext := range bes.extMap {
+	bes.hosts = make(map[component.ID]component.Host, len(bes.extMap))
+	for _, extID := range bes.order {
+		ext := bes.extMap[extID]
 		extLogger := components.ExtensionLogger(bes.telemetry.Logger, extID)
 		extLogger.Info("Extension is starting...")
-		if err := ext.Start(ctx, components.NewHostWrapper(host, extLogger)); err != nil {
-			return err
+		extHost := components.NewHostWrapper(host, &component.InstanceID{ID: extID, Kind: component.KindExtension}, extLogger)
+		bes.hosts[extID] = extHost
+		component.ReportStatus(extHost, component.NewStatusEvent(component.StatusStarting, nil))
+		if err := ext.Start(ctx, extHost); err != nil {
+			component.ReportStatus(extHost, component.NewStatusEvent(component.StatusPermanentError, err))
+			return componenterror.New(component.KindExtension, extID, err)
 		}
+		component.ReportStatus(extHost, component.NewStatusEvent(component.StatusOK, nil))
 		extLogger.Info("Extension started.")
 	}
 	return nil
 }
 
-// Shutdown stops all extensions.
+// Shutdown stops all extensions, in the reverse order of their start.
 func (bes *Extensions) Shutdown(ctx context.Context) error {
 	bes.telemetry.Logger.Info("Stopping extensions...")
 	var errs error
-	for _, ext := range bes.extMap {
-		errs = multierr.Append(errs, ext.Shutdown(ctx))
+	for i := len(bes.order) - 1; i >= 0; i-- {
+		extID := bes.order[i]
+		ext := bes.extMap[extID]
+		extHost := bes.hosts[extID]
+		component.ReportStatus(extHost, component.NewStatusEvent(component.StatusStopping, nil))
+		err := ext.Shutdown(ctx)
+		if err != nil {
+			component.ReportStatus(extHost, component.NewStatusEvent(component.StatusPermanentError, err))
+		}
+		component.ReportStatus(extHost, component.NewStatusEvent(component.StatusStopped, nil))
+		errs = multierr.Append(errs, err)
 	}
 
 	return errs
 }
 
 func (bes *Extensions) NotifyPipelineReady() error {
-	for extID, ext := range bes.extMap {
-		if pw, ok := ext.(extension.PipelineWatcher); ok {
+	for _, extID := range bes.order {
+		if pw, ok := bes.extMap[extID].(extension.PipelineWatcher); ok {
 			if err := pw.Ready(); err != nil {
 				return fmt.Errorf("failed to notify extension %q: %w", extID, err)
 			}
@@ -64,14 +87,32 @@ func (bes *Extensions) NotifyPipelineReady() error {
 func (bes *Extensions) NotifyPipelineNotReady() error {
 	// Notify extensions in reverse order.
 	var errs error
-	for _, ext := range bes.extMap {
-		if pw, ok := ext.(extension.PipelineWatcher); ok {
+	for i := len(bes.order) - 1; i >= 0; i-- {
+		if pw, ok := bes.extMap[bes.order[i]].(extension.PipelineWatcher); ok {
 			errs = multierr.Append(errs, pw.NotReady())
 		}
 	}
 	return errs
 }
 
+// ComponentStatusChanged notifies the extensions implementing extension.StatusWatcher.
+func (bes *Extensions) ComponentStatusChanged(source *component.InstanceID, event *component.StatusEvent) {
+	for _, extID := range bes.order {
+		if sw, ok := bes.extMap[extID].(extension.StatusWatcher); ok {
+			sw.ComponentStatusChanged(source, event)
+		}
+	}
+}
+
+// PipelineStatusChanged notifies the extensions implementing extension.StatusWatcher.
+func (bes *Extensions) PipelineStatusChanged(pipelineID component.ID, event *component.StatusEvent) {
+	for _, extID := range bes.order {
+		if sw, ok := bes.extMap[extID].(extension.StatusWatcher); ok {
+			sw.PipelineStatusChanged(pipelineID, event)
+		}
+	}
+}
+
 func (bes *Extensions) GetExtensions() map[component.ID]component.Component {
 	result := make(map[component.ID]component.Component, len(bes.extMap))
 	for extID, v := range bes.extMap {
@@ -119,6 +160,9 @@ type Settings struct {
 
 	// Extensions builder for extensions.
 	Extensions *extension.Builder
+
+	// Dependencies are the dependencies between the extensions configured in the service.
+	Dependencies Dependencies
 }
 
 // New creates a new Extensions from Config.
@@ -140,7 +184,7 @@ func New(ctx context.Context, set Settings, cfg Config) (*Extensions, error) {
 
 		ext, err := set.Extensions.Create(ctx, extSet)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create extension %q: %w", extID, err)
+			return nil, componenterror.New(component.KindExtension, extID, fmt.Errorf("failed to create extension %q: %w", extID, err))
 		}
 
 		// Check if the factory really created the extension.
@@ -151,5 +195,9 @@ func New(ctx context.Context, set Settings, cfg Config) (*Extensions, error) {
 		exts.extMap[extID] = ext
 	}
 
+	var err error
+	if exts.order, err = startOrder(cfg, exts.extMap, set.Dependencies); err != nil {
+		return nil, err
+	}
 	return exts, nil
 }
@@ -116,3 +116,155 @@ func newCreateErrorExtensionFactory() extension.Factory {
 		component.StabilityLevelDevelopment,
 	)
 }
+
+type statusWatcherExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	components []component.Status
+	pipelines  []component.ID
+}
+
+func (e *statusWatcherExtension) ComponentStatusChanged(_ *component.InstanceID, event *component.StatusEvent) {
+	e.components = append(e.components, event.Status())
+}
+
+func (e *statusWatcherExtension) PipelineStatusChanged(pipelineID component.ID, _ *component.StatusEvent) {
+	e.pipelines = append(e.pipelines, pipelineID)
+}
+
+// statusHost forwards the statuses to the extensions, as the service does.
+type statusHost struct {
+	component.Host
+	watcher extension.StatusWatcher
+}
+
+func (h *statusHost) ReportComponentStatus(source *component.InstanceID, event *component.StatusEvent) {
+	h.watcher.ComponentStatusChanged(source, event)
+}
+
+func TestExtensionsStatus(t *testing.T) {
+	watcher := &statusWatcherExtension{}
+	factory := extension.NewFactory(
+		"watcher",
+		func() component.Config {
+			return &struct{}{}
+		},
+		func(ctx context.Context, set extension.CreateSettings, extension component.Config) (extension.Extension, error) {
+			return watcher, nil
+		},
+		component.StabilityLevelDevelopment,
+	)
+	exts, err := New(context.Background(), Settings{
+		Telemetry:  componenttest.NewNopTelemetrySettings(),
+		BuildInfo:  component.NewDefaultBuildInfo(),
+		Extensions: extension.NewBuilder(map[component.ID]component.Config{component.NewID("watcher"): &struct{}{}}, map[component.Type]extension.Factory{"watcher": factory}),
+	}, Config{component.NewID("watcher")})
+	require.NoError(t, err)
+
+	host := &statusHost{Host: componenttest.NewNopHost(), watcher: exts}
+	require.NoError(t, exts.Start(context.Background(), host))
+	exts.PipelineStatusChanged(component.NewID("traces"), component.NewStatusEvent(component.StatusOK, nil))
+	require.NoError(t, exts.Shutdown(context.Background()))
+
+	assert.Equal(t, []component.Status{
+		component.StatusStarting, component.StatusOK, component.StatusStopping, component.StatusStopped,
+	}, watcher.components)
+	assert.Equal(t, []component.ID{component.NewID("traces")}, watcher.pipelines)
+}
+
+// recordingExtension records its starts and shutdowns, and declares its dependencies.
+type recordingExtension struct {
+	id           component.ID
+	dependencies []component.ID
+	events       *[]string
+}
+
+func (e *recordingExtension) Start(context.Context, component.Host) error {
+	*e.events = append(*e.events, "start "+e.id.String())
+	return nil
+}
+
+func (e *recordingExtension) Shutdown(context.Context) error {
+	*e.events = append(*e.events, "shutdown "+e.id.String())
+	return nil
+}
+
+func (e *recordingExtension) Dependencies() []component.ID {
+	return e.dependencies
+}
+
+func newRecordingExtensions(t *testing.T, declared map[component.ID][]component.ID, deps Dependencies, cfg Config) (*Extensions, *[]string, error) {
+	events := &[]string{}
+	factory := extension.NewFactory(
+		"rec",
+		func() component.Config {
+			return &struct{}{}
+		},
+		func(ctx context.Context, set extension.CreateSettings, extension component.Config) (extension.Extension, error) {
+			return &recordingExtension{id: set.ID, dependencies: declared[set.ID], events: events}, nil
+		},
+		component.StabilityLevelDevelopment,
+	)
+	configs := map[component.ID]component.Config{}
+	for _, id := range cfg {
+		configs[id] = factory.CreateDefaultConfig()
+	}
+	exts, err := New(context.Background(), Settings{
+		Telemetry:    componenttest.NewNopTelemetrySettings(),
+		BuildInfo:    component.NewDefaultBuildInfo(),
+		Extensions:   extension.NewBuilder(configs, map[component.Type]extension.Factory{"rec": factory}),
+		Dependencies: deps,
+	}, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	require.NoError(t, exts.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exts.Shutdown(context.Background()))
+	return exts, events, nil
+}
+
+func TestExtensionsDependencies(t *testing.T) {
+	a, b, c, d := component.NewIDWithName("rec", "a"), component.NewIDWithName("rec", "b"), component.NewIDWithName("rec", "c"), component.NewIDWithName("rec", "d")
+
+	// Without dependencies, the extensions keep the order of the configuration.
+	_, events, err := newRecordingExtensions(t, nil, nil, Config{a, b, c})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"start rec/a", "start rec/b", "start rec/c",
+		"shutdown rec/c", "shutdown rec/b", "shutdown rec/a",
+	}, *events)
+
+	// a declares it depends on c, and the configuration makes b depend on d.
+	_, events, err = newRecordingExtensions(t,
+		map[component.ID][]component.ID{a: {c}},
+		Dependencies{b: {d}},
+		Config{a, b, c, d})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"start rec/c", "start rec/a", "start rec/d", "start rec/b",
+		"shutdown rec/b", "shutdown rec/d", "shutdown rec/a", "shutdown rec/c",
+	}, *events)
+}
+
+func TestExtensionsDependenciesErrors(t *testing.T) {
+	a, b, c := component.NewIDWithName("rec", "a"), component.NewIDWithName("rec", "b"), component.NewIDWithName("rec", "c")
+
+	_, _, err := newRecordingExtensions(t, map[component.ID][]component.ID{a: {c}}, nil, Config{a, b})
+	assert.EqualError(t, err, `extension "rec/a" depends on "rec/c" which is not enabled in the service`)
+
+	_, _, err = newRecordingExtensions(t,
+		map[component.ID][]component.ID{a: {b}, b: {c}},
+		Dependencies{c: {b}},
+		Config{a, b, c})
+	assert.EqualError(t, err, `dependency cycle between extensions: rec/b -> rec/c -> rec/b`)
+}
+
+func TestDependenciesValidate(t *testing.T) {
+	a, b, c := component.NewIDWithName("rec", "a"), component.NewIDWithName("rec", "b"), component.NewIDWithName("rec", "c")
+	cfg := Config{a, b}
+
+	assert.NoError(t, Dependencies{a: {b}}.Validate(cfg))
+	assert.EqualError(t, Dependencies{c: {a}}.Validate(cfg), `extension "rec/c" is not enabled in the service`)
+	assert.EqualError(t, Dependencies{a: {a}}.Validate(cfg), `extension "rec/a" depends on itself`)
+	assert.EqualError(t, Dependencies{a: {c}}.Validate(cfg), `extension "rec/a" depends on "rec/c" which is not enabled in the service`)
+}
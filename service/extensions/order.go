@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package extensions // import "go.opentelemetry.io/collector/service/extensions"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+// startOrder returns the order the extensions are started in: every extension is started after
+// the extensions it depends on, the others keep the order of cfg.
+func startOrder(cfg Config, extMap map[component.ID]extension.Extension, deps Dependencies) ([]component.ID, error) {
+	dependencies := make(map[component.ID][]component.ID, len(cfg))
+	for _, id := range cfg {
+		dependencies[id] = append(dependencies[id], deps[id]...)
+		if dep, ok := extMap[id].(extension.Dependent); ok {
+			dependencies[id] = append(dependencies[id], dep.Dependencies()...)
+		}
+		for _, d := range dependencies[id] {
+			if _, ok := extMap[d]; !ok {
+				return nil, fmt.Errorf("extension %q depends on %q which is not enabled in the service", id, d)
+			}
+		}
+	}
+
+	order := make([]component.ID, 0, len(cfg))
+	// The extensions being visited, to detect the cycles, and the ones already ordered.
+	visiting := make(map[component.ID]bool, len(cfg))
+	ordered := make(map[component.ID]bool, len(cfg))
+	var path []component.ID
+	var visit func(id component.ID) error
+	visit = func(id component.ID) error {
+		if ordered[id] {
+			return nil
+		}
+		path = append(path, id)
+		if visiting[id] {
+			return fmt.Errorf("dependency cycle between extensions: %s", formatPath(path))
+		}
+		visiting[id] = true
+		for _, dep := range dependencies[id] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		visiting[id] = false
+		path = path[:len(path)-1]
+		ordered[id] = true
+		order = append(order, id)
+		return nil
+	}
+	for _, id := range cfg {
+		if err := visit(id); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// formatPath formats the extensions of a dependency cycle, starting from the first extension of the cycle.
+func formatPath(path []component.ID) string {
+	last := path[len(path)-1]
+	start := 0
+	for i, id := range path {
+		if id == last {
+			start = i
+			break
+		}
+	}
+	ids := make([]string, 0, len(path)-start)
+	for _, id := range path[start:] {
+		ids = append(ids, id.String())
+	}
+	return strings.Join(ids, " -> ")
+}
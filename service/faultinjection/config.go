@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package faultinjection // import "go.opentelemetry.io/collector/service/faultinjection"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+// FeatureGate is the feature gate that must be enabled for the configured faults to be injected.
+var FeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"service.faultInjection",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("controls whether the faults configured in service::fault_injection are injected "+
+		"in the pipelines. Only meant to validate the resilience of a deployment in testing environments."))
+
+// Config represents the list of faults injected in the pipelines.
+type Config []Fault
+
+// Fault describes the faults injected in the calls to a component of a pipeline.
+type Fault struct {
+	// Pipeline is the ID of the pipeline the fault is injected in.
+	Pipeline component.ID `mapstructure:"pipeline"`
+
+	// Component is the ID of the processor, exporter or connector whose incoming calls are affected.
+	Component component.ID `mapstructure:"component"`
+
+	// Delay is the duration the affected calls are delayed by.
+	Delay time.Duration `mapstructure:"delay"`
+
+	// DelayPercentage is the percentage of the calls delayed by Delay.
+	DelayPercentage float64 `mapstructure:"delay_percentage"`
+
+	// ErrorPercentage is the percentage of the calls failing with an error, without calling the component.
+	ErrorPercentage float64 `mapstructure:"error_percentage"`
+
+	// PermanentErrors makes the injected errors permanent, so they are not retried.
+	PermanentErrors bool `mapstructure:"permanent_errors"`
+
+	// DropPercentage is the percentage of the calls reported as successful without calling the component.
+	DropPercentage float64 `mapstructure:"drop_percentage"`
+}
+
+// Validate checks if the fault injection configuration is valid.
+func (cfg Config) Validate() error {
+	for i, f := range cfg {
+		if err := f.validate(); err != nil {
+			return fmt.Errorf("fault %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (f Fault) validate() error {
+	if f.Pipeline == (component.ID{}) {
+		return errors.New("pipeline must be specified")
+	}
+	if f.Component == (component.ID{}) {
+		return errors.New("component must be specified")
+	}
+	if f.Delay < 0 {
+		return errors.New("delay must not be negative")
+	}
+	if f.DelayPercentage > 0 && f.Delay == 0 {
+		return errors.New("delay must be specified when delay_percentage is set")
+	}
+	for name, p := range map[string]float64{
+		"delay_percentage": f.DelayPercentage,
+		"error_percentage": f.ErrorPercentage,
+		"drop_percentage":  f.DropPercentage,
+	} {
+		if p < 0 || p > 100 {
+			return fmt.Errorf("%s must be between 0 and 100", name)
+		}
+	}
+	if f.ErrorPercentage+f.DropPercentage > 100 {
+		return errors.New("the sum of error_percentage and drop_percentage must not exceed 100")
+	}
+	return nil
+}
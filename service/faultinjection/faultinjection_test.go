@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package faultinjection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	pipelineID  = component.NewID("traces")
+	componentID = component.NewID("otlp")
+)
+
+func TestConfigValidate(t *testing.T) {
+	valid := Fault{Pipeline: pipelineID, Component: componentID, ErrorPercentage: 10}
+	tests := []struct {
+		name    string
+		modify  func(*Fault)
+		wantErr string
+	}{
+		{name: "valid", modify: func(*Fault) {}},
+		{name: "missing pipeline", modify: func(f *Fault) { f.Pipeline = component.ID{} }, wantErr: "fault 0: pipeline must be specified"},
+		{name: "missing component", modify: func(f *Fault) { f.Component = component.ID{} }, wantErr: "fault 0: component must be specified"},
+		{name: "negative delay", modify: func(f *Fault) { f.Delay = -time.Second }, wantErr: "fault 0: delay must not be negative"},
+		{name: "delay percentage without delay", modify: func(f *Fault) { f.DelayPercentage = 10 }, wantErr: "fault 0: delay must be specified when delay_percentage is set"},
+		{name: "percentage out of range", modify: func(f *Fault) { f.DropPercentage = 101 }, wantErr: "fault 0: drop_percentage must be between 0 and 100"},
+		{name: "sum out of range", modify: func(f *Fault) { f.DropPercentage = 95 }, wantErr: "fault 0: the sum of error_percentage and drop_percentage must not exceed 100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := valid
+			tt.modify(&f)
+			err := Config{f}.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func newTestInjector(f Fault, random float64) *Injector {
+	i := NewInjector(Config{f})
+	i.random = func() float64 { return random }
+	return i
+}
+
+func TestInjectorNoMatch(t *testing.T) {
+	next := new(consumertest.TracesSink)
+	i := NewInjector(Config{{Pipeline: pipelineID, Component: component.NewID("other"), ErrorPercentage: 100}})
+	assert.Same(t, next, i.Traces(pipelineID, componentID, next))
+}
+
+func TestInjectorError(t *testing.T) {
+	f := Fault{Pipeline: pipelineID, Component: componentID, ErrorPercentage: 50}
+
+	next := new(consumertest.TracesSink)
+	err := newTestInjector(f, 10).Traces(pipelineID, componentID, next).ConsumeTraces(context.Background(), ptrace.NewTraces())
+	assert.ErrorIs(t, err, ErrInjected)
+	assert.False(t, consumererror.IsPermanent(err))
+	assert.Len(t, next.AllTraces(), 0)
+
+	err = newTestInjector(f, 60).Traces(pipelineID, componentID, next).ConsumeTraces(context.Background(), ptrace.NewTraces())
+	assert.NoError(t, err)
+	assert.Len(t, next.AllTraces(), 1)
+
+	f.PermanentErrors = true
+	err = newTestInjector(f, 10).Traces(pipelineID, componentID, next).ConsumeTraces(context.Background(), ptrace.NewTraces())
+	assert.True(t, consumererror.IsPermanent(err))
+}
+
+func TestInjectorDrop(t *testing.T) {
+	f := Fault{Pipeline: pipelineID, Component: componentID, ErrorPercentage: 10, DropPercentage: 20}
+
+	next := new(consumertest.MetricsSink)
+	err := newTestInjector(f, 25).Metrics(pipelineID, componentID, next).ConsumeMetrics(context.Background(), pmetric.NewMetrics())
+	assert.NoError(t, err)
+	assert.Len(t, next.AllMetrics(), 0)
+
+	err = newTestInjector(f, 30).Metrics(pipelineID, componentID, next).ConsumeMetrics(context.Background(), pmetric.NewMetrics())
+	assert.NoError(t, err)
+	assert.Len(t, next.AllMetrics(), 1)
+}
+
+func TestInjectorDelay(t *testing.T) {
+	f := Fault{Pipeline: pipelineID, Component: componentID, Delay: 50 * time.Millisecond, DelayPercentage: 100}
+
+	next := new(consumertest.LogsSink)
+	start := time.Now()
+	require.NoError(t, newTestInjector(f, 0).Logs(pipelineID, componentID, next).ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.GreaterOrEqual(t, time.Since(start), f.Delay)
+	assert.Len(t, next.AllLogs(), 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := newTestInjector(f, 0).Logs(pipelineID, componentID, next).ConsumeLogs(ctx, plog.NewLogs())
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, next.AllLogs(), 1)
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package faultinjection // import "go.opentelemetry.io/collector/service/faultinjection"
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// ErrInjected is the error returned by the calls failed by an injected fault.
+var ErrInjected = errors.New("injected fault")
+
+// Injector wraps the consumers of the pipelines to inject the configured faults.
+type Injector struct {
+	faults Config
+	// random returns a number in [0, 100).
+	random func() float64
+}
+
+// NewInjector returns an Injector for the given configuration.
+func NewInjector(cfg Config) *Injector {
+	return &Injector{
+		faults: cfg,
+		random: func() float64 {
+			return rand.Float64() * 100 // #nosec G404 -- no need for a cryptographically secure source
+		},
+	}
+}
+
+// Traces returns next wrapped with the faults configured for the component in the pipeline.
+func (i *Injector) Traces(pipelineID, componentID component.ID, next consumer.Traces) consumer.Traces {
+	for _, f := range i.matching(pipelineID, componentID) {
+		next = tracesConsumer{Traces: next, fault: f}
+	}
+	return next
+}
+
+// Metrics returns next wrapped with the faults configured for the component in the pipeline.
+func (i *Injector) Metrics(pipelineID, componentID component.ID, next consumer.Metrics) consumer.Metrics {
+	for _, f := range i.matching(pipelineID, componentID) {
+		next = metricsConsumer{Metrics: next, fault: f}
+	}
+	return next
+}
+
+// Logs returns next wrapped with the faults configured for the component in the pipeline.
+func (i *Injector) Logs(pipelineID, componentID component.ID, next consumer.Logs) consumer.Logs {
+	for _, f := range i.matching(pipelineID, componentID) {
+		next = logsConsumer{Logs: next, fault: f}
+	}
+	return next
+}
+
+// Profiles returns next wrapped with the faults configured for the component in the pipeline.
+func (i *Injector) Profiles(pipelineID, componentID component.ID, next consumer.Profiles) consumer.Profiles {
+	for _, f := range i.matching(pipelineID, componentID) {
+		next = profilesConsumer{Profiles: next, fault: f}
+	}
+	return next
+}
+
+func (i *Injector) matching(pipelineID, componentID component.ID) []fault {
+	var faults []fault
+	for _, f := range i.faults {
+		if f.Pipeline == pipelineID && f.Component == componentID {
+			faults = append(faults, fault{Fault: f, random: i.random})
+		}
+	}
+	return faults
+}
+
+type fault struct {
+	Fault
+	random func() float64
+}
+
+// inject applies the fault to a call. It returns whether the call must be dropped,
+// or the error the call must fail with.
+func (f fault) inject(ctx context.Context) (bool, error) {
+	if f.DelayPercentage > 0 && f.random() < f.DelayPercentage {
+		timer := time.NewTimer(f.Delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return false, ctx.Err()
+		}
+	}
+	switch r := f.random(); {
+	case r < f.ErrorPercentage:
+		if f.PermanentErrors {
+			return false, consumererror.NewPermanent(ErrInjected)
+		}
+		return false, ErrInjected
+	case r < f.ErrorPercentage+f.DropPercentage:
+		return true, nil
+	}
+	return false, nil
+}
+
+type tracesConsumer struct {
+	consumer.Traces
+	fault fault
+}
+
+func (c tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if drop, err := c.fault.inject(ctx); drop || err != nil {
+		return err
+	}
+	return c.Traces.ConsumeTraces(ctx, td)
+}
+
+type metricsConsumer struct {
+	consumer.Metrics
+	fault fault
+}
+
+func (c metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if drop, err := c.fault.inject(ctx); drop || err != nil {
+		return err
+	}
+	return c.Metrics.ConsumeMetrics(ctx, md)
+}
+
+type logsConsumer struct {
+	consumer.Logs
+	fault fault
+}
+
+func (c logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if drop, err := c.fault.inject(ctx); drop || err != nil {
+		return err
+	}
+	return c.Logs.ConsumeLogs(ctx, ld)
+}
+
+type profilesConsumer struct {
+	consumer.Profiles
+	fault fault
+}
+
+func (c profilesConsumer) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	if drop, err := c.fault.inject(ctx); drop || err != nil {
+		return err
+	}
+	return c.Profiles.ConsumeProfiles(ctx, pd)
+}
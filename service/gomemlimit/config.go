@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gomemlimit // import "go.opentelemetry.io/collector/service/gomemlimit"
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	defaultLimitPercentage = 80
+	defaultCheckInterval   = time.Minute
+)
+
+var (
+	errPercentageOutOfRange = errors.New("limit_percentage must be less than or equal to hundred")
+	errNegativeInterval     = errors.New("check_interval must not be negative")
+)
+
+// Config defines the configuration of the Go soft memory limit, set from the total memory available
+// to the collector: the memory limit of its cgroup, or the physical memory of the host otherwise.
+type Config struct {
+	// LimitPercentage is the percentage of the total memory the soft memory limit is set to,
+	// before the headroom is subtracted. 80 if not set.
+	LimitPercentage uint32 `mapstructure:"limit_percentage"`
+
+	// HeadroomMiB is the memory, in MiB, subtracted from the limit, e.g. to leave room for the
+	// memory which is not managed by the Go runtime.
+	HeadroomMiB uint32 `mapstructure:"headroom_mib"`
+
+	// CheckInterval is the interval at which the total memory is read again to adjust the limit
+	// when it changes, e.g. when the limit of the container is resized. 1m if not set.
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+}
+
+// Validate checks if the soft memory limit configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.LimitPercentage > 100 {
+		return errPercentageOutOfRange
+	}
+	if cfg.CheckInterval < 0 {
+		return errNegativeInterval
+	}
+	return nil
+}
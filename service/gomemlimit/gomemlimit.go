@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package gomemlimit manages the soft memory limit of the Go runtime, also known as GOMEMLIMIT,
+// from the total memory available to the collector, which makes the garbage collector run more
+// often as the heap gets close to the limit instead of letting the process run out of memory.
+package gomemlimit // import "go.opentelemetry.io/collector/service/gomemlimit"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"runtime/debug"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/internal/iruntime"
+)
+
+const mibBytes = 1024 * 1024
+
+// envVar is the environment variable setting the soft memory limit, which takes precedence.
+const envVar = "GOMEMLIMIT"
+
+// make them overridable by tests
+var (
+	getMemoryFn    = iruntime.TotalMemory
+	setMemoryLimit = debug.SetMemoryLimit
+	lookupEnv      = os.LookupEnv
+)
+
+// Manager sets the soft memory limit of the Go runtime and adjusts it when the total memory changes.
+type Manager struct {
+	percentage    uint64
+	headroom      uint64
+	checkInterval time.Duration
+	logger        *zap.Logger
+
+	// previous is the limit before the manager was started, restored on shutdown.
+	previous    int64
+	totalMemory uint64
+	limit       int64
+
+	managed bool
+	ticker  *time.Ticker
+	doneC   chan struct{}
+	stopped chan struct{}
+}
+
+// New returns a new Manager, which sets the soft memory limit once started.
+func New(cfg *Config, logger *zap.Logger) (*Manager, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	m := &Manager{
+		percentage:    uint64(cfg.LimitPercentage),
+		headroom:      uint64(cfg.HeadroomMiB) * mibBytes,
+		checkInterval: cfg.CheckInterval,
+		logger:        logger,
+	}
+	if m.percentage == 0 {
+		m.percentage = defaultLimitPercentage
+	}
+	if m.checkInterval == 0 {
+		m.checkInterval = defaultCheckInterval
+	}
+	return m, nil
+}
+
+// Start sets the soft memory limit and starts checking the total memory, unless the GOMEMLIMIT
+// environment variable is set.
+func (m *Manager) Start() error {
+	if v, ok := lookupEnv(envVar); ok && v != "" {
+		m.logger.Info("The soft memory limit is set by the environment, ignoring service::soft_memory_limit",
+			zap.String(envVar, v))
+		return nil
+	}
+	totalMemory, err := getMemoryFn()
+	if err != nil {
+		return fmt.Errorf("failed to get total memory: %w", err)
+	}
+	limit, err := m.computeLimit(totalMemory)
+	if err != nil {
+		return err
+	}
+	m.previous = setMemoryLimit(limit)
+	m.totalMemory, m.limit, m.managed = totalMemory, limit, true
+	m.logger.Info("Soft memory limit set",
+		zap.Uint64("total_memory_mib", totalMemory/mibBytes),
+		zap.Int64("limit_mib", limit/mibBytes))
+
+	m.ticker = time.NewTicker(m.checkInterval)
+	m.doneC = make(chan struct{})
+	m.stopped = make(chan struct{})
+	go func() {
+		defer close(m.stopped)
+		for {
+			select {
+			case <-m.ticker.C:
+				m.adjust()
+			case <-m.doneC:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops checking the total memory and restores the soft memory limit set before Start.
+func (m *Manager) Shutdown() {
+	if !m.managed {
+		return
+	}
+	m.ticker.Stop()
+	close(m.doneC)
+	<-m.stopped
+	setMemoryLimit(m.previous)
+	m.managed = false
+}
+
+// adjust sets the soft memory limit again if the total memory changed.
+func (m *Manager) adjust() {
+	totalMemory, err := getMemoryFn()
+	if err != nil {
+		m.logger.Warn("Failed to get total memory, keeping the soft memory limit", zap.Error(err))
+		return
+	}
+	if totalMemory == m.totalMemory {
+		return
+	}
+	limit, err := m.computeLimit(totalMemory)
+	if err != nil {
+		m.logger.Warn("Total memory changed, keeping the soft memory limit", zap.Error(err))
+		return
+	}
+	setMemoryLimit(limit)
+	m.logger.Info("Total memory changed, soft memory limit adjusted",
+		zap.Uint64("total_memory_mib", totalMemory/mibBytes),
+		zap.Int64("limit_mib", limit/mibBytes))
+	m.totalMemory, m.limit = totalMemory, limit
+}
+
+var errNoMemoryLeft = errors.New("headroom_mib is greater than the limit")
+
+func (m *Manager) computeLimit(totalMemory uint64) (int64, error) {
+	limit := totalMemory / 100 * m.percentage
+	if limit <= m.headroom {
+		return 0, fmt.Errorf("%w of %d MiB", errNoMemoryLeft, limit/mibBytes)
+	}
+	limit -= m.headroom
+	if limit > math.MaxInt64 {
+		limit = math.MaxInt64
+	}
+	return int64(limit), nil
+}
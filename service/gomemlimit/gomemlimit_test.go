@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gomemlimit
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeRuntime struct {
+	totalMemory atomic.Uint64
+	mu          sync.Mutex
+	limit       int64
+}
+
+func (f *fakeRuntime) setMemoryLimit(limit int64) int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	previous := f.limit
+	if limit >= 0 {
+		f.limit = limit
+	}
+	return previous
+}
+
+func (f *fakeRuntime) getLimit() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.limit
+}
+
+func setupFakeRuntime(t *testing.T, totalMemory uint64, env string) *fakeRuntime {
+	f := &fakeRuntime{limit: math.MaxInt64}
+	f.totalMemory.Store(totalMemory)
+	getMemoryFn = func() (uint64, error) { return f.totalMemory.Load(), nil }
+	setMemoryLimit = f.setMemoryLimit
+	lookupEnv = func(string) (string, bool) { return env, env != "" }
+	t.Cleanup(func() {
+		getMemoryFn = origGetMemoryFn
+		setMemoryLimit = origSetMemoryLimit
+		lookupEnv = origLookupEnv
+	})
+	return f
+}
+
+var (
+	origGetMemoryFn    = getMemoryFn
+	origSetMemoryLimit = setMemoryLimit
+	origLookupEnv      = lookupEnv
+)
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, (&Config{}).Validate())
+	assert.NoError(t, (&Config{LimitPercentage: 100, HeadroomMiB: 128, CheckInterval: time.Second}).Validate())
+	assert.ErrorIs(t, (&Config{LimitPercentage: 101}).Validate(), errPercentageOutOfRange)
+	assert.ErrorIs(t, (&Config{CheckInterval: -time.Second}).Validate(), errNegativeInterval)
+
+	_, err := New(&Config{LimitPercentage: 101}, zap.NewNop())
+	assert.ErrorIs(t, err, errPercentageOutOfRange)
+}
+
+func TestStartShutdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   Config
+		limit int64
+	}{
+		{
+			name:  "defaults",
+			cfg:   Config{},
+			limit: 800 * mibBytes,
+		},
+		{
+			name:  "percentage",
+			cfg:   Config{LimitPercentage: 50},
+			limit: 500 * mibBytes,
+		},
+		{
+			name:  "headroom",
+			cfg:   Config{LimitPercentage: 90, HeadroomMiB: 100},
+			limit: 800 * mibBytes,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupFakeRuntime(t, 1000*mibBytes, "")
+			m, err := New(&tt.cfg, zap.NewNop())
+			require.NoError(t, err)
+			require.NoError(t, m.Start())
+			assert.Equal(t, tt.limit, f.getLimit())
+
+			m.Shutdown()
+			assert.Equal(t, int64(math.MaxInt64), f.getLimit())
+		})
+	}
+}
+
+func TestStartHeadroomTooLarge(t *testing.T) {
+	f := setupFakeRuntime(t, 1000*mibBytes, "")
+	m, err := New(&Config{HeadroomMiB: 800}, zap.NewNop())
+	require.NoError(t, err)
+	assert.ErrorIs(t, m.Start(), errNoMemoryLeft)
+	assert.Equal(t, int64(math.MaxInt64), f.getLimit())
+	m.Shutdown()
+}
+
+func TestStartTotalMemoryError(t *testing.T) {
+	setupFakeRuntime(t, 1000*mibBytes, "")
+	getMemoryFn = func() (uint64, error) { return 0, errors.New("no memory") }
+	m, err := New(&Config{}, zap.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, m.Start(), "failed to get total memory: no memory")
+}
+
+func TestStartEnvironmentSet(t *testing.T) {
+	f := setupFakeRuntime(t, 1000*mibBytes, "512MiB")
+	m, err := New(&Config{}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, m.Start())
+	assert.Equal(t, int64(math.MaxInt64), f.getLimit())
+	m.Shutdown()
+}
+
+func TestAdjustOnTotalMemoryChange(t *testing.T) {
+	f := setupFakeRuntime(t, 1000*mibBytes, "")
+	m, err := New(&Config{CheckInterval: 10 * time.Millisecond}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, m.Start())
+	defer m.Shutdown()
+	assert.Equal(t, int64(800*mibBytes), f.getLimit())
+
+	f.totalMemory.Store(2000 * mibBytes)
+	assert.Eventually(t, func() bool {
+		return f.getLimit() == 1600*mibBytes
+	}, time.Second, 10*time.Millisecond)
+}
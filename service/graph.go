@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"io"
+
+	"go.opentelemetry.io/collector/service/internal/graph"
+)
+
+const (
+	// GraphFormatDOT renders the pipelines graph in the Graphviz DOT language.
+	GraphFormatDOT = graph.FormatDOT
+	// GraphFormatMermaid renders the pipelines graph as a Mermaid flowchart.
+	GraphFormatMermaid = graph.FormatMermaid
+)
+
+// WriteGraph writes the graph of the receivers, processors, exporters and connectors of the
+// pipelines in cfg, in the given format. The components are not created, so only the
+// Connectors builder of set is required.
+func WriteGraph(w io.Writer, set Settings, cfg Config, format string) error {
+	return graph.Topology(graph.Settings{
+		BuildInfo:        set.BuildInfo,
+		ConnectorBuilder: set.Connectors,
+		PipelineConfigs:  cfg.Pipelines,
+	}).Render(w, format)
+}
+
+// WriteGraph writes the graph of the running pipelines in the given format, with the edges
+// labeled with the amount of data sent along them.
+func (srv *Service) WriteGraph(w io.Writer, format string) error {
+	return srv.host.pipelines.Render(w, format)
+}
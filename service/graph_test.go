@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+func TestWriteGraph(t *testing.T) {
+	nopConnectorFactory := connectortest.NewNopFactory()
+	set := Settings{
+		Connectors: connector.NewBuilder(
+			map[component.ID]component.Config{component.NewID("nop"): nopConnectorFactory.CreateDefaultConfig()},
+			map[component.Type]connector.Factory{nopConnectorFactory.Type(): nopConnectorFactory}),
+	}
+	cfg := Config{
+		Pipelines: pipelines.Config{
+			component.NewIDWithName("traces", "in"): {
+				Receivers: []component.ID{component.NewID("otlp")},
+				Exporters: []component.ID{component.NewID("nop")},
+			},
+			component.NewIDWithName("metrics", "out"): {
+				Receivers:  []component.ID{component.NewID("nop")},
+				Processors: []component.ID{component.NewID("batch")},
+				Exporters:  []component.ID{component.NewID("otlp")},
+			},
+		},
+	}
+
+	out := &strings.Builder{}
+	require.NoError(t, WriteGraph(out, set, cfg, GraphFormatMermaid))
+	assert.True(t, strings.HasPrefix(out.String(), "flowchart LR\n"))
+	assert.Contains(t, out.String(), `{"connector nop [traces to metrics]"}`)
+	assert.Contains(t, out.String(), `["processor batch"]`)
+
+	out.Reset()
+	require.NoError(t, WriteGraph(out, set, cfg, GraphFormatDOT))
+	assert.True(t, strings.HasPrefix(out.String(), "digraph pipelines {\n"))
+
+	assert.Error(t, WriteGraph(out, set, cfg, "png"))
+}
@@ -4,17 +4,28 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"sync/atomic"
+
+	"go.uber.org/zap"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/memorylimiter"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/internal/components"
 	"go.opentelemetry.io/collector/service/internal/graph"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/tap"
 )
 
-var _ component.Host = (*serviceHost)(nil)
+var (
+	_ memorylimiter.Host    = (*serviceHost)(nil)
+	_ components.StatusHost = (*serviceHost)(nil)
+)
 
 type serviceHost struct {
 	asyncErrorChannel chan error
@@ -25,9 +36,18 @@ type serviceHost struct {
 	extensions        *extension.Builder
 
 	buildInfo component.BuildInfo
+	logger    *zap.Logger
 
 	pipelines         *graph.Graph
 	serviceExtensions *extensions.Extensions
+	memoryLimiter     *memorylimiter.MemoryLimiter
+	status            *status.Aggregator
+
+	// taps copies the data of the pipelines to the taps attached from the zPages, if the tap feature gate is enabled.
+	taps *tap.Registry
+
+	// effectiveConfig is replaced when the service is reloaded while the zPages are served.
+	effectiveConfig atomic.Pointer[[]byte]
 }
 
 // ReportFatalError is used to report to the host that the receiver encountered
@@ -37,6 +57,14 @@ func (host *serviceHost) ReportFatalError(err error) {
 	host.asyncErrorChannel <- err
 }
 
+// ReportComponentStatus records the status reported by a component instance, and notifies the
+// extensions interested in the statuses.
+func (host *serviceHost) ReportComponentStatus(source *component.InstanceID, event *component.StatusEvent) {
+	if host.status != nil {
+		host.status.ReportStatus(source, event)
+	}
+}
+
 func (host *serviceHost) GetFactory(kind component.Kind, componentType component.Type) component.Factory {
 	switch kind {
 	case component.KindReceiver:
@@ -57,6 +85,11 @@ func (host *serviceHost) GetExtensions() map[component.ID]component.Component {
 	return host.serviceExtensions.GetExtensions()
 }
 
+// GetMemoryLimiter returns the service-wide memory limiter, nil if it is not configured.
+func (host *serviceHost) GetMemoryLimiter() *memorylimiter.MemoryLimiter {
+	return host.memoryLimiter
+}
+
 // Deprecated: [0.79.0] This function will be removed in the future.
 // Several components in the contrib repository use this function so it cannot be removed
 // before those cases are removed. In most cases, use of this function can be replaced by a
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+// instanceIDFileName is the name of the file of the state directory holding the service.instance.id.
+const instanceIDFileName = "service_instance_id"
+
+var (
+	processInstanceIDOnce sync.Once
+	processInstanceID     string
+)
+
+// instanceID returns the service.instance.id of the collector. It is read from the state directory, where it is
+// generated on the first start, so that it is kept across the restarts of the collector. Without state directory,
+// it is generated once per process, so that it is kept when the service is reloaded.
+func instanceID(cfg telemetry.Config) (string, error) {
+	if cfg.StateDirectory == "" {
+		processInstanceIDOnce.Do(func() {
+			processInstanceID = uuid.NewString()
+		})
+		return processInstanceID, nil
+	}
+
+	path := filepath.Join(cfg.StateDirectory, instanceIDFileName)
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		// The file is generated again if it does not hold a UUID.
+		if id, parseErr := uuid.ParseBytes(bytes.TrimSpace(data)); parseErr == nil {
+			return id.String(), nil
+		}
+	case !os.IsNotExist(err):
+		return "", fmt.Errorf("failed to read the service.instance.id: %w", err)
+	}
+
+	id := uuid.NewString()
+	if err = os.MkdirAll(cfg.StateDirectory, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create the state directory: %w", err)
+	}
+	// The file is renamed once written, so that a crash does not leave a truncated ID.
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, []byte(id+"\n"), 0o600); err != nil {
+		return "", fmt.Errorf("failed to persist the service.instance.id: %w", err)
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return "", fmt.Errorf("failed to persist the service.instance.id: %w", err)
+	}
+	return id, nil
+}
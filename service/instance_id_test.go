@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	semconv "go.opentelemetry.io/collector/semconv/v1.18.0"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+func TestInstanceIDWithoutStateDirectory(t *testing.T) {
+	id, err := instanceID(telemetry.Config{})
+	require.NoError(t, err)
+	_, err = uuid.Parse(id)
+	require.NoError(t, err)
+
+	// The ID is kept when the service is reloaded.
+	reloaded, err := instanceID(telemetry.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, id, reloaded)
+}
+
+func TestInstanceIDPersisted(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "state")
+	cfg := telemetry.Config{StateDirectory: dir}
+
+	id, err := instanceID(cfg)
+	require.NoError(t, err)
+	processID, err := instanceID(telemetry.Config{})
+	require.NoError(t, err)
+	assert.NotEqual(t, processID, id)
+
+	data, err := os.ReadFile(filepath.Join(dir, instanceIDFileName))
+	require.NoError(t, err)
+	assert.Equal(t, id+"\n", string(data))
+
+	restarted, err := instanceID(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, id, restarted)
+
+	res, err := buildResource(component.NewDefaultBuildInfo(), cfg)
+	require.NoError(t, err)
+	value, ok := pdataFromSdk(res).Attributes().Get(semconv.AttributeServiceInstanceID)
+	require.True(t, ok)
+	assert.Equal(t, id, value.AsString())
+}
+
+func TestInstanceIDRegeneratedWhenInvalid(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, instanceIDFileName), []byte("invalid"), 0o600))
+
+	id, err := instanceID(telemetry.Config{StateDirectory: dir})
+	require.NoError(t, err)
+	_, err = uuid.Parse(id)
+	require.NoError(t, err)
+
+	restarted, err := instanceID(telemetry.Config{StateDirectory: dir})
+	require.NoError(t, err)
+	assert.Equal(t, id, restarted)
+}
+
+func TestInstanceIDInvalidStateDirectory(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+
+	_, err := instanceID(telemetry.Config{StateDirectory: file})
+	assert.Error(t, err)
+}
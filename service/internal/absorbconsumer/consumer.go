@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package absorbconsumer absorbs the errors returned by the components of a pipeline, so that
+// they are not propagated to the receivers.
+package absorbconsumer // import "go.opentelemetry.io/collector/service/internal/absorbconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// NewTraces returns a consumer.Traces passing the data to next, reporting its errors to absorb
+// instead of returning them.
+func NewTraces(next consumer.Traces, absorb func(ctx context.Context, items int, err error)) consumer.Traces {
+	return tracesConsumer{Traces: next, absorb: absorb}
+}
+
+type tracesConsumer struct {
+	consumer.Traces
+	absorb func(context.Context, int, error)
+}
+
+func (c tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	// Count before passing the data, the next consumer may modify it.
+	items := td.SpanCount()
+	if err := c.Traces.ConsumeTraces(ctx, td); err != nil {
+		c.absorb(ctx, items, err)
+	}
+	return nil
+}
+
+// NewMetrics returns a consumer.Metrics passing the data to next, reporting its errors to absorb
+// instead of returning them.
+func NewMetrics(next consumer.Metrics, absorb func(ctx context.Context, items int, err error)) consumer.Metrics {
+	return metricsConsumer{Metrics: next, absorb: absorb}
+}
+
+type metricsConsumer struct {
+	consumer.Metrics
+	absorb func(context.Context, int, error)
+}
+
+func (c metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	items := md.DataPointCount()
+	if err := c.Metrics.ConsumeMetrics(ctx, md); err != nil {
+		c.absorb(ctx, items, err)
+	}
+	return nil
+}
+
+// NewLogs returns a consumer.Logs passing the data to next, reporting its errors to absorb
+// instead of returning them.
+func NewLogs(next consumer.Logs, absorb func(ctx context.Context, items int, err error)) consumer.Logs {
+	return logsConsumer{Logs: next, absorb: absorb}
+}
+
+type logsConsumer struct {
+	consumer.Logs
+	absorb func(context.Context, int, error)
+}
+
+func (c logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	items := ld.LogRecordCount()
+	if err := c.Logs.ConsumeLogs(ctx, ld); err != nil {
+		c.absorb(ctx, items, err)
+	}
+	return nil
+}
+
+// NewProfiles returns a consumer.Profiles passing the data to next, reporting its errors to absorb
+// instead of returning them.
+func NewProfiles(next consumer.Profiles, absorb func(ctx context.Context, items int, err error)) consumer.Profiles {
+	return profilesConsumer{Profiles: next, absorb: absorb}
+}
+
+type profilesConsumer struct {
+	consumer.Profiles
+	absorb func(context.Context, int, error)
+}
+
+func (c profilesConsumer) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	items := pd.SampleCount()
+	if err := c.Profiles.ConsumeProfiles(ctx, pd); err != nil {
+		c.absorb(ctx, items, err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package absorbconsumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+type absorbed struct {
+	items int
+	errs  []error
+}
+
+func (a *absorbed) absorb(_ context.Context, items int, err error) {
+	a.items += items
+	a.errs = append(a.errs, err)
+}
+
+func TestAbsorbErrors(t *testing.T) {
+	errRefused := errors.New("queue is full")
+	next := consumertest.NewErr(errRefused)
+	a := &absorbed{}
+
+	require.NoError(t, NewTraces(next, a.absorb).ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	require.NoError(t, NewMetrics(next, a.absorb).ConsumeMetrics(context.Background(), testdata.GenerateMetrics(2)))
+	require.NoError(t, NewLogs(next, a.absorb).ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	require.NoError(t, NewProfiles(next, a.absorb).ConsumeProfiles(context.Background(), testdata.GenerateProfiles(2)))
+
+	assert.Equal(t, 2+4+2+testdata.GenerateProfiles(2).SampleCount(), a.items)
+	assert.Equal(t, []error{errRefused, errRefused, errRefused, errRefused}, a.errs)
+}
+
+func TestNoErrors(t *testing.T) {
+	a := &absorbed{}
+
+	require.NoError(t, NewTraces(consumertest.NewNop(), a.absorb).ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	require.NoError(t, NewMetrics(consumertest.NewNop(), a.absorb).ConsumeMetrics(context.Background(), testdata.GenerateMetrics(2)))
+	require.NoError(t, NewLogs(consumertest.NewNop(), a.absorb).ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	require.NoError(t, NewProfiles(consumertest.NewNop(), a.absorb).ConsumeProfiles(context.Background(), testdata.GenerateProfiles(2)))
+
+	assert.Zero(t, a.items)
+	assert.Empty(t, a.errs)
+}
@@ -9,27 +9,77 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/memorylimiter"
+)
+
+// StatusHost is implemented by the host of the service to record the statuses reported by the components.
+type StatusHost interface {
+	ReportComponentStatus(source *component.InstanceID, event *component.StatusEvent)
+}
+
+var (
+	_ component.StatusReporter = (*hostWrapper)(nil)
+	_ component.PanicRecoverer = (*hostWrapper)(nil)
+	_ memorylimiter.Host       = (*hostWrapper)(nil)
 )
 
 // hostWrapper adds behavior on top of the component.Host being passed when starting the built components.
 type hostWrapper struct {
 	component.Host
 	*zap.Logger
+	instanceID *component.InstanceID
+	// recoverPanic handles the panics of the component, nil if they are not isolated.
+	recoverPanic func(recovered any, stack []byte) error
+}
+
+// NewHostWrapper returns the host given to a single component instance, which attributes the
+// statuses reported by the component to the instance.
+func NewHostWrapper(host component.Host, instanceID *component.InstanceID, logger *zap.Logger) component.Host {
+	return &hostWrapper{
+		Host:       host,
+		Logger:     logger,
+		instanceID: instanceID,
+	}
 }
 
-func NewHostWrapper(host component.Host, logger *zap.Logger) component.Host {
+// NewGuardedHostWrapper returns the host given to a single component instance whose panics are isolated,
+// recoverPanic handles the panics recovered by the component.
+func NewGuardedHostWrapper(host component.Host, instanceID *component.InstanceID, logger *zap.Logger, recoverPanic func(recovered any, stack []byte) error) component.Host {
 	return &hostWrapper{
-		host,
-		logger,
+		Host:         host,
+		Logger:       logger,
+		instanceID:   instanceID,
+		recoverPanic: recoverPanic,
 	}
 }
 
 func (hw *hostWrapper) ReportFatalError(err error) {
 	// The logger from the built component already identifies the component.
 	hw.Logger.Error("Component fatal error", zap.Error(err))
+	hw.ReportStatus(component.NewStatusEvent(component.StatusPermanentError, err))
 	hw.Host.ReportFatalError(err)
 }
 
+// ReportStatus reports the status of the component instance to the host, if it records them.
+func (hw *hostWrapper) ReportStatus(event *component.StatusEvent) {
+	if sh, ok := hw.Host.(StatusHost); ok {
+		sh.ReportComponentStatus(hw.instanceID, event)
+	}
+}
+
+// RecoverPanic handles the panic recovered by the component, which panics again if its panics are not isolated.
+func (hw *hostWrapper) RecoverPanic(recovered any, stack []byte) error {
+	if hw.recoverPanic == nil {
+		panic(recovered)
+	}
+	return hw.recoverPanic(recovered, stack)
+}
+
+// GetMemoryLimiter forwards the memory limiter of the service, the receivers cannot get it otherwise.
+func (hw *hostWrapper) GetMemoryLimiter() *memorylimiter.MemoryLimiter {
+	return memorylimiter.FromHost(hw.Host)
+}
+
 // RegisterZPages is used by zpages extension to register handles from service.
 // When the wrapper is passed to the extension it won't be successful when casting
 // the interface, for the time being expose the interface here.
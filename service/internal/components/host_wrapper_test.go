@@ -6,13 +6,90 @@ package components
 import (
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/memorylimiter"
 )
 
 func Test_newHostWrapper(_ *testing.T) {
-	hw := NewHostWrapper(componenttest.NewNopHost(), zap.NewNop())
+	hw := NewHostWrapper(componenttest.NewNopHost(), &component.InstanceID{}, zap.NewNop())
 	hw.ReportFatalError(errors.New("test error"))
 }
+
+type statusHost struct {
+	component.Host
+	sources []*component.InstanceID
+	events  []*component.StatusEvent
+	fatal   []error
+}
+
+func (h *statusHost) ReportComponentStatus(source *component.InstanceID, event *component.StatusEvent) {
+	h.sources = append(h.sources, source)
+	h.events = append(h.events, event)
+}
+
+func (h *statusHost) ReportFatalError(err error) {
+	h.fatal = append(h.fatal, err)
+}
+
+func TestHostWrapperReportStatus(t *testing.T) {
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	instanceID := &component.InstanceID{ID: component.NewID("otlp"), Kind: component.KindReceiver}
+	hw := NewHostWrapper(host, instanceID, zap.NewNop())
+
+	ok := component.NewStatusEvent(component.StatusOK, nil)
+	component.ReportStatus(hw, ok)
+	err := errors.New("fatal")
+	hw.ReportFatalError(err)
+
+	assert.Equal(t, []*component.InstanceID{instanceID, instanceID}, host.sources)
+	assert.Equal(t, ok, host.events[0])
+	assert.Equal(t, component.StatusPermanentError, host.events[1].Status())
+	assert.Equal(t, err, host.events[1].Err())
+	assert.Equal(t, []error{err}, host.fatal)
+
+	// Hosts which do not record the statuses are supported.
+	assert.NotPanics(t, func() {
+		component.ReportStatus(NewHostWrapper(componenttest.NewNopHost(), instanceID, zap.NewNop()), ok)
+	})
+}
+
+type memoryLimiterHost struct {
+	component.Host
+	ml *memorylimiter.MemoryLimiter
+}
+
+func (h *memoryLimiterHost) GetMemoryLimiter() *memorylimiter.MemoryLimiter {
+	return h.ml
+}
+
+func TestHostWrapperMemoryLimiter(t *testing.T) {
+	ml, err := memorylimiter.New(&memorylimiter.Config{CheckInterval: time.Second, MemoryLimitMiB: 1024}, zap.NewNop())
+	require.NoError(t, err)
+	host := &memoryLimiterHost{Host: componenttest.NewNopHost(), ml: ml}
+	assert.Same(t, ml, memorylimiter.FromHost(NewHostWrapper(host, &component.InstanceID{}, zap.NewNop())))
+
+	assert.Nil(t, memorylimiter.FromHost(NewHostWrapper(componenttest.NewNopHost(), &component.InstanceID{}, zap.NewNop())))
+}
+
+func TestHostWrapperRecoverPanic(t *testing.T) {
+	instanceID := &component.InstanceID{ID: component.NewID("otlp"), Kind: component.KindReceiver}
+	hw := NewHostWrapper(componenttest.NewNopHost(), instanceID, zap.NewNop())
+	assert.PanicsWithValue(t, "failure", func() { _ = component.RecoverPanic(hw, "failure") })
+
+	err := errors.New("recovered")
+	var recovered any
+	guarded := NewGuardedHostWrapper(componenttest.NewNopHost(), instanceID, zap.NewNop(), func(r any, stack []byte) error {
+		recovered = r
+		assert.NotEmpty(t, stack)
+		return err
+	})
+	assert.Equal(t, err, component.RecoverPanic(guarded, "failure"))
+	assert.Equal(t, "failure", recovered)
+}
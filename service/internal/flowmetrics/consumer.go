@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package flowmetrics // import "go.opentelemetry.io/collector/service/internal/flowmetrics"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Traces returns next wrapped to record the traces sent along the edge.
+func (e *Edge) Traces(next consumer.Traces) consumer.Traces {
+	return tracesConsumer{Traces: next, edge: e}
+}
+
+// Metrics returns next wrapped to record the metrics sent along the edge.
+func (e *Edge) Metrics(next consumer.Metrics) consumer.Metrics {
+	return metricsConsumer{Metrics: next, edge: e}
+}
+
+// Logs returns next wrapped to record the logs sent along the edge.
+func (e *Edge) Logs(next consumer.Logs) consumer.Logs {
+	return logsConsumer{Logs: next, edge: e}
+}
+
+// Profiles returns next wrapped to record the profiles sent along the edge.
+func (e *Edge) Profiles(next consumer.Profiles) consumer.Profiles {
+	return profilesConsumer{Profiles: next, edge: e}
+}
+
+type tracesConsumer struct {
+	consumer.Traces
+	edge *Edge
+}
+
+func (c tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	// Count before passing the data, the next consumer may modify it.
+	return c.edge.consume(ctx, td.SpanCount(), func() error { return c.Traces.ConsumeTraces(ctx, td) })
+}
+
+type metricsConsumer struct {
+	consumer.Metrics
+	edge *Edge
+}
+
+func (c metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return c.edge.consume(ctx, md.DataPointCount(), func() error { return c.Metrics.ConsumeMetrics(ctx, md) })
+}
+
+type logsConsumer struct {
+	consumer.Logs
+	edge *Edge
+}
+
+func (c logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return c.edge.consume(ctx, ld.LogRecordCount(), func() error { return c.Logs.ConsumeLogs(ctx, ld) })
+}
+
+type profilesConsumer struct {
+	consumer.Profiles
+	edge *Edge
+}
+
+func (c profilesConsumer) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	return c.edge.consume(ctx, pd.SampleCount(), func() error { return c.Profiles.ConsumeProfiles(ctx, pd) })
+}
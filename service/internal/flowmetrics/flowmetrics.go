@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package flowmetrics instruments the edges of the pipelines to report standard metrics on the data
+// flowing through them, whatever the metrics reported by the components themselves.
+package flowmetrics // import "go.opentelemetry.io/collector/service/internal/flowmetrics"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+const (
+	scopeName = "go.opentelemetry.io/collector/service/flowmetrics"
+
+	// ItemsInMetricName is the name of the metric counting the items entering the pipelines.
+	ItemsInMetricName = "pipeline_items_in"
+	// ItemsProcessedMetricName is the name of the metric counting the items entering the processors of the pipelines.
+	ItemsProcessedMetricName = "pipeline_items_processed"
+	// ItemsOutMetricName is the name of the metric counting the items sent by the pipelines to the exporters and connectors.
+	ItemsOutMetricName = "pipeline_items_out"
+	// ItemsInFlightMetricName is the name of the metric of the items being consumed by the pipelines.
+	ItemsInFlightMetricName = "pipeline_items_in_flight"
+	// FanOutLatencyMetricName is the name of the histogram of the duration of the fan out of the data to the exporters.
+	FanOutLatencyMetricName = "pipeline_fanout_latency"
+	// ItemsAbsorbedMetricName is the name of the metric counting the items whose errors were not propagated to the receivers.
+	ItemsAbsorbedMetricName = "pipeline_items_absorbed"
+
+	pipelineKey  = "pipeline"
+	componentKey = "component"
+	outcomeKey   = "outcome"
+)
+
+// Metrics holds the instruments of the pipeline metrics. The instruments are kept when the pipelines
+// are reloaded, the edges of the rebuilt pipelines keep reporting to the same metrics.
+type Metrics struct {
+	itemsIn        metric.Int64Counter
+	itemsProcessed metric.Int64Counter
+	itemsOut       metric.Int64Counter
+	itemsInFlight  metric.Int64UpDownCounter
+	fanOutLatency  metric.Float64Histogram
+	itemsAbsorbed  metric.Int64Counter
+}
+
+// New creates the instruments of the pipeline metrics on mp.
+func New(mp metric.MeterProvider) (*Metrics, error) {
+	meter := mp.Meter(scopeName)
+	m := &Metrics{}
+	var errs, err error
+	m.itemsIn, err = meter.Int64Counter(
+		ItemsInMetricName,
+		metric.WithDescription("Number of items entering the pipeline from a receiver or connector, by outcome."),
+		metric.WithUnit("1"))
+	errs = multierr.Append(errs, err)
+	m.itemsProcessed, err = meter.Int64Counter(
+		ItemsProcessedMetricName,
+		metric.WithDescription("Number of items entering a processor of the pipeline, by outcome."),
+		metric.WithUnit("1"))
+	errs = multierr.Append(errs, err)
+	m.itemsOut, err = meter.Int64Counter(
+		ItemsOutMetricName,
+		metric.WithDescription("Number of items sent by the pipeline to an exporter or connector, by outcome."),
+		metric.WithUnit("1"))
+	errs = multierr.Append(errs, err)
+	m.itemsInFlight, err = meter.Int64UpDownCounter(
+		ItemsInFlightMetricName,
+		metric.WithDescription("Number of items entered in the pipeline whose consumption has not returned yet."),
+		metric.WithUnit("1"))
+	errs = multierr.Append(errs, err)
+	m.fanOutLatency, err = meter.Float64Histogram(
+		FanOutLatencyMetricName,
+		metric.WithDescription("Duration of the fan out of the data of the pipeline to its exporters and connectors."),
+		metric.WithUnit("ms"))
+	errs = multierr.Append(errs, err)
+	m.itemsAbsorbed, err = meter.Int64Counter(
+		ItemsAbsorbedMetricName,
+		metric.WithDescription("Number of items which failed in the pipeline without the error being returned to the receiver or connector."),
+		metric.WithUnit("1"))
+	errs = multierr.Append(errs, err)
+	if errs != nil {
+		return nil, errs
+	}
+	return m, nil
+}
+
+// In returns the Edge of the data entering the pipeline from the receiver or connector.
+func (m *Metrics) In(pipelineID, componentID component.ID) *Edge {
+	e := newEdge(m.itemsIn, pipelineID, componentID)
+	e.inFlight = m.itemsInFlight
+	e.inFlightAttrs = metric.WithAttributes(attribute.String(pipelineKey, pipelineID.String()))
+	return e
+}
+
+// Processed returns the Edge of the data entering the processor of the pipeline.
+func (m *Metrics) Processed(pipelineID, componentID component.ID) *Edge {
+	return newEdge(m.itemsProcessed, pipelineID, componentID)
+}
+
+// Out returns the Edge of the data sent by the pipeline to the exporter or connector.
+func (m *Metrics) Out(pipelineID, componentID component.ID) *Edge {
+	return newEdge(m.itemsOut, pipelineID, componentID)
+}
+
+// FanOut returns the Edge of the data fanned out by the pipeline to its exporters and connectors.
+func (m *Metrics) FanOut(pipelineID component.ID) *Edge {
+	return &Edge{
+		latency:      m.fanOutLatency,
+		latencyAttrs: metric.WithAttributes(attribute.String(pipelineKey, pipelineID.String())),
+	}
+}
+
+// Absorbed returns the function counting the items whose errors were absorbed by the pipeline.
+func (m *Metrics) Absorbed(pipelineID component.ID) func(ctx context.Context, items int) {
+	attrs := metric.WithAttributes(attribute.String(pipelineKey, pipelineID.String()))
+	return func(ctx context.Context, items int) {
+		m.itemsAbsorbed.Add(ctx, int64(items), attrs)
+	}
+}
+
+// Edge records the data sent along an edge of the pipelines.
+type Edge struct {
+	items                      metric.Int64Counter
+	accepted, refused, dropped metric.MeasurementOption
+
+	inFlight      metric.Int64UpDownCounter
+	inFlightAttrs metric.MeasurementOption
+
+	latency      metric.Float64Histogram
+	latencyAttrs metric.MeasurementOption
+
+	observe func(items int, err error)
+}
+
+// WithObserver returns a copy of the Edge also passing the number of items sent along the edge, and
+// the error returned by the next consumer, to observe. It may be called on a nil Edge, which then
+// only reports to observe.
+func (e *Edge) WithObserver(observe func(items int, err error)) *Edge {
+	observed := &Edge{}
+	if e != nil {
+		*observed = *e
+	}
+	observed.observe = observe
+	return observed
+}
+
+func newEdge(items metric.Int64Counter, pipelineID, componentID component.ID) *Edge {
+	attrs := func(outcome string) metric.MeasurementOption {
+		return metric.WithAttributes(
+			attribute.String(pipelineKey, pipelineID.String()),
+			attribute.String(componentKey, componentID.String()),
+			attribute.String(outcomeKey, outcome))
+	}
+	return &Edge{
+		items:    items,
+		accepted: attrs("accepted"),
+		refused:  attrs("refused"),
+		dropped:  attrs("dropped"),
+	}
+}
+
+// consume records the items passed to the next consumer with next. The items rejected with a permanent
+// error are dropped, they are refused with any other error.
+func (e *Edge) consume(ctx context.Context, items int, next func() error) error {
+	if e.inFlight != nil {
+		e.inFlight.Add(ctx, int64(items), e.inFlightAttrs)
+	}
+	start := time.Now()
+	err := next()
+	if e.latency != nil {
+		e.latency.Record(ctx, float64(time.Since(start))/float64(time.Millisecond), e.latencyAttrs)
+	}
+	if e.inFlight != nil {
+		e.inFlight.Add(ctx, -int64(items), e.inFlightAttrs)
+	}
+	if e.observe != nil {
+		e.observe(items, err)
+	}
+	if e.items == nil {
+		return err
+	}
+	switch {
+	case err == nil:
+		e.items.Add(ctx, int64(items), e.accepted)
+	case consumererror.IsPermanent(err):
+		e.items.Add(ctx, int64(items), e.dropped)
+	default:
+		e.items.Add(ctx, int64(items), e.refused)
+	}
+	return err
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package flowmetrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// collect returns the values of the sums, and the counts of the histograms, by metric name and attributes.
+func collect(t *testing.T, reader sdkmetric.Reader) map[string]int64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	values := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					values[m.Name+"{"+dp.Attributes.Encoded(attribute.DefaultEncoder())+"}"] = dp.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					values[m.Name+"{"+dp.Attributes.Encoded(attribute.DefaultEncoder())+"}"] = int64(dp.Count)
+				}
+			}
+		}
+	}
+	return values
+}
+
+func TestMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	m, err := New(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	require.NoError(t, err)
+	pipelineID := component.NewID("traces")
+	receiverID := component.NewID("otlp")
+	processorID := component.NewID("batch")
+	exporterID := component.NewID("otlp")
+
+	in := m.In(pipelineID, receiverID).Traces(consumertest.NewNop())
+	require.NoError(t, in.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	processed := m.Processed(pipelineID, processorID).Metrics(consumertest.NewErr(errors.New("refused")))
+	require.Error(t, processed.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	out := m.Out(pipelineID, exporterID).Logs(consumertest.NewErr(consumererror.NewPermanent(errors.New("dropped"))))
+	require.Error(t, out.ConsumeLogs(context.Background(), testdata.GenerateLogs(4)))
+	fanOut := m.FanOut(pipelineID).Profiles(consumertest.NewNop())
+	require.NoError(t, fanOut.ConsumeProfiles(context.Background(), testdata.GenerateProfiles(2)))
+
+	assert.Equal(t, map[string]int64{
+		"pipeline_items_in{component=otlp,outcome=accepted,pipeline=traces}":        3,
+		"pipeline_items_processed{component=batch,outcome=refused,pipeline=traces}": 2,
+		"pipeline_items_out{component=otlp,outcome=dropped,pipeline=traces}":        4,
+		"pipeline_items_in_flight{pipeline=traces}":                                 0,
+		"pipeline_fanout_latency{pipeline=traces}":                                  1,
+	}, collect(t, reader))
+}
+
+func TestItemsInFlight(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	m, err := New(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	require.NoError(t, err)
+
+	var inFlight map[string]int64
+	next := &inspectingTraces{consume: func() { inFlight = collect(t, reader) }}
+	in := m.In(component.NewID("traces"), component.NewID("otlp")).Traces(next)
+	require.NoError(t, in.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+
+	assert.Equal(t, int64(2), inFlight["pipeline_items_in_flight{pipeline=traces}"])
+	assert.Equal(t, int64(0), collect(t, reader)["pipeline_items_in_flight{pipeline=traces}"])
+}
+
+func TestEdgeWithObserver(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	m, err := New(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	require.NoError(t, err)
+
+	var observed []int
+	observe := func(items int, err error) {
+		observed = append(observed, items)
+		assert.Error(t, err)
+	}
+	in := m.In(component.NewID("traces"), component.NewID("otlp")).WithObserver(observe).Traces(consumertest.NewErr(errors.New("refused")))
+	require.Error(t, in.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	// A nil Edge only reports to the observer.
+	var edge *Edge
+	out := edge.WithObserver(observe).Logs(consumertest.NewErr(errors.New("refused")))
+	require.Error(t, out.ConsumeLogs(context.Background(), testdata.GenerateLogs(3)))
+
+	assert.Equal(t, []int{2, 3}, observed)
+	assert.Equal(t, int64(2), collect(t, reader)["pipeline_items_in{component=otlp,outcome=refused,pipeline=traces}"])
+}
+
+// inspectingTraces calls consume while consuming the traces.
+type inspectingTraces struct {
+	consumertest.TracesSink
+	consume func()
+}
+
+func (b *inspectingTraces) ConsumeTraces(context.Context, ptrace.Traces) error {
+	b.consume()
+	return nil
+}
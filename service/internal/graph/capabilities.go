@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
+)
+
+// factoryKey identifies the factory of a component.
+type factoryKey struct {
+	kind component.Kind
+	typ  component.Type
+}
+
+// componentKey identifies a component of the configuration.
+type componentKey struct {
+	kind component.Kind
+	id   component.ID
+}
+
+// verifyCapabilities checks the pipelines against the capabilities declared by the factories of their components,
+// before the components are created: a factory not supporting multiple instances must not have to create more
+// than one component, and the stateful components whose data is split across instances are reported.
+func (g *Graph) verifyCapabilities(set Settings) error {
+	factoryInstances := make(map[factoryKey]int)
+	componentInstances := make(map[componentKey]int)
+	for it := g.componentGraph.Nodes(); it.Next(); {
+		var key componentKey
+		switch n := it.Node().(type) {
+		case *receiverNode:
+			key = componentKey{kind: component.KindReceiver, id: n.componentID}
+		case *processorNode:
+			key = componentKey{kind: component.KindProcessor, id: n.componentID}
+		case *exporterNode:
+			key = componentKey{kind: component.KindExporter, id: n.componentID}
+		case *connectorNode:
+			key = componentKey{kind: component.KindConnector, id: n.componentID}
+		default:
+			continue
+		}
+		factoryInstances[factoryKey{kind: key.kind, typ: key.id.Type()}]++
+		componentInstances[key]++
+	}
+
+	var errs error
+	for _, key := range sortedFactoryKeys(factoryInstances) {
+		if n := factoryInstances[key]; n > 1 && factoryCapabilities(set, key.kind, key.typ).SingleInstance {
+			errs = multierr.Append(errs, fmt.Errorf("the pipelines need %d %s %q components, but its factory does not support multiple instances",
+				n, strings.ToLower(key.kind.String()), key.typ))
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+
+	for _, key := range sortedComponentKeys(componentInstances) {
+		if n := componentInstances[key]; n > 1 && factoryCapabilities(set, key.kind, key.id.Type()).Stateful {
+			set.Telemetry.Logger.Warn("Stateful component used by several pipelines, each of its instances keeps its own state",
+				zap.String("kind", key.kind.String()), zap.Stringer("name", key.id), zap.Int("instances", n))
+		}
+	}
+	return nil
+}
+
+// factoryCapabilities returns the capabilities declared by the factory of a component type, none if it is not available.
+func factoryCapabilities(set Settings, kind component.Kind, typ component.Type) component.FactoryCapabilities {
+	var f component.Factory
+	switch kind {
+	case component.KindReceiver:
+		f = set.ReceiverBuilder.Factory(typ)
+	case component.KindProcessor:
+		f = set.ProcessorBuilder.Factory(typ)
+	case component.KindExporter:
+		f = set.ExporterBuilder.Factory(typ)
+	case component.KindConnector:
+		f = set.ConnectorBuilder.Factory(typ)
+	}
+	if f == nil {
+		return component.FactoryCapabilities{}
+	}
+	return f.Capabilities()
+}
+
+// withMutatesData returns the consumer reporting that it mutates the data if its factory declares it,
+// so that the fan-out consumers give it its own copy of the data.
+func withMutatesData(dataType component.DataType, bc baseConsumer, mutatesData bool) baseConsumer {
+	if !mutatesData || bc.Capabilities().MutatesData {
+		return bc
+	}
+	capabilities := consumer.Capabilities{MutatesData: true}
+	switch dataType {
+	case component.DataTypeTraces:
+		return capabilityconsumer.NewTraces(bc.(consumer.Traces), capabilities)
+	case component.DataTypeMetrics:
+		return capabilityconsumer.NewMetrics(bc.(consumer.Metrics), capabilities)
+	case component.DataTypeLogs:
+		return capabilityconsumer.NewLogs(bc.(consumer.Logs), capabilities)
+	case component.DataTypeProfiles:
+		return capabilityconsumer.NewProfiles(bc.(consumer.Profiles), capabilities)
+	}
+	return bc
+}
+
+func sortedFactoryKeys(m map[factoryKey]int) []factoryKey {
+	keys := make([]factoryKey, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].kind != keys[j].kind {
+			return keys[i].kind < keys[j].kind
+		}
+		return keys[i].typ < keys[j].typ
+	})
+	return keys
+}
+
+func sortedComponentKeys(m map[componentKey]int) []componentKey {
+	keys := make([]componentKey, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].kind != keys[j].kind {
+			return keys[i].kind < keys[j].kind
+		}
+		return keys[i].id.String() < keys[j].id.String()
+	})
+	return keys
+}
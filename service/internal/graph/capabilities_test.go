@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+var (
+	capRcvrID        = component.NewID("examplereceiver")
+	capProcID        = component.NewID("capprocessor")
+	capExpID         = component.NewID("capexporter")
+	capTracesID      = component.NewID("traces")
+	capTracesOtherID = component.NewIDWithName("traces", "other")
+	capMetricsID     = component.NewID("metrics")
+)
+
+// newCapabilitiesFactories returns processor and exporter factories declaring the given capabilities,
+// whose components do not report that they mutate the data.
+func newCapabilitiesFactories(capabilities component.FactoryCapabilities) (processor.Factory, exporter.Factory) {
+	createDefaultConfig := func() component.Config { return &struct{}{} }
+	procFactory := processor.NewFactory("capprocessor", createDefaultConfig,
+		processor.WithTraces(func(ctx context.Context, set processor.CreateSettings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+			return processorhelper.NewTracesProcessor(ctx, set, cfg, next, func(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+				return td, nil
+			}, processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}))
+		}, component.StabilityLevelDevelopment),
+		processor.WithCapabilities(capabilities))
+	expFactory := exporter.NewFactory("capexporter", createDefaultConfig,
+		exporter.WithTraces(func(context.Context, exporter.CreateSettings, component.Config) (exporter.Traces, error) {
+			return &testTracesExporter{Traces: new(consumertest.TracesSink)}, nil
+		}, component.StabilityLevelDevelopment),
+		exporter.WithMetrics(func(context.Context, exporter.CreateSettings, component.Config) (exporter.Metrics, error) {
+			return &testMetricsExporter{Metrics: new(consumertest.MetricsSink)}, nil
+		}, component.StabilityLevelDevelopment),
+		exporter.WithCapabilities(capabilities))
+	return procFactory, expFactory
+}
+
+type testTracesExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Traces
+}
+
+type testMetricsExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Metrics
+}
+
+func newCapabilitiesSettings(tel component.TelemetrySettings, procFactory processor.Factory, expFactory exporter.Factory, pipelineCfgs pipelines.Config) Settings {
+	return Settings{
+		Telemetry: tel,
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(
+			map[component.ID]component.Config{capRcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig()},
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory},
+		),
+		ProcessorBuilder: processor.NewBuilder(map[component.ID]component.Config{capProcID: procFactory.CreateDefaultConfig()}, map[component.Type]processor.Factory{procFactory.Type(): procFactory}),
+		ExporterBuilder: exporter.NewBuilder(
+			map[component.ID]component.Config{capExpID: expFactory.CreateDefaultConfig()},
+			map[component.Type]exporter.Factory{expFactory.Type(): expFactory},
+		),
+		ConnectorBuilder: connector.NewBuilder(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs:  pipelineCfgs,
+	}
+}
+
+func TestGraphSingleInstance(t *testing.T) {
+	procFactory, expFactory := newCapabilitiesFactories(component.FactoryCapabilities{SingleInstance: true})
+
+	// A single instance of each component.
+	set := newCapabilitiesSettings(componenttest.NewNopTelemetrySettings(), procFactory, expFactory, pipelines.Config{
+		capTracesID: {Receivers: []component.ID{capRcvrID}, Processors: []component.ID{capProcID}, Exporters: []component.ID{capExpID}},
+	})
+	_, err := Build(context.Background(), set)
+	require.NoError(t, err)
+
+	// A processor instance per pipeline, an exporter instance per data type.
+	set = newCapabilitiesSettings(componenttest.NewNopTelemetrySettings(), procFactory, expFactory, pipelines.Config{
+		capTracesID:      {Receivers: []component.ID{capRcvrID}, Processors: []component.ID{capProcID}, Exporters: []component.ID{capExpID}},
+		capTracesOtherID: {Receivers: []component.ID{capRcvrID}, Processors: []component.ID{capProcID}, Exporters: []component.ID{capExpID}},
+		capMetricsID:     {Receivers: []component.ID{capRcvrID}, Exporters: []component.ID{capExpID}},
+	})
+	_, err = Build(context.Background(), set)
+	assert.ErrorContains(t, err, `the pipelines need 2 processor "capprocessor" components, but its factory does not support multiple instances`)
+	assert.ErrorContains(t, err, `the pipelines need 2 exporter "capexporter" components, but its factory does not support multiple instances`)
+}
+
+func TestGraphStatefulWarning(t *testing.T) {
+	procFactory, expFactory := newCapabilitiesFactories(component.FactoryCapabilities{Stateful: true})
+	core, logs := observer.New(zapcore.WarnLevel)
+	tel := componenttest.NewNopTelemetrySettings()
+	tel.Logger = zap.New(core)
+
+	set := newCapabilitiesSettings(tel, procFactory, expFactory, pipelines.Config{
+		capTracesID:      {Receivers: []component.ID{capRcvrID}, Processors: []component.ID{capProcID}, Exporters: []component.ID{capExpID}},
+		capTracesOtherID: {Receivers: []component.ID{capRcvrID}, Processors: []component.ID{capProcID}, Exporters: []component.ID{capExpID}},
+	})
+	_, err := Build(context.Background(), set)
+	require.NoError(t, err)
+
+	// The exporter instance is shared by the pipelines, only the processor is split.
+	warnings := logs.FilterMessage("Stateful component used by several pipelines, each of its instances keeps its own state").All()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, map[string]any{"kind": "Processor", "name": "capprocessor", "instances": int64(2)}, warnings[0].ContextMap())
+}
+
+func TestGraphFactoryMutatesData(t *testing.T) {
+	pipelineCfgs := pipelines.Config{
+		capTracesID: {Receivers: []component.ID{capRcvrID}, Processors: []component.ID{capProcID}, Exporters: []component.ID{capExpID}},
+	}
+
+	procFactory, expFactory := newCapabilitiesFactories(component.FactoryCapabilities{})
+	pg, err := Build(context.Background(), newCapabilitiesSettings(componenttest.NewNopTelemetrySettings(), procFactory, expFactory, pipelineCfgs))
+	require.NoError(t, err)
+	assert.False(t, pg.pipelines[capTracesID].capabilitiesNode.getConsumer().Capabilities().MutatesData)
+	for _, exp := range pg.pipelines[capTracesID].exporters {
+		assert.False(t, exp.(*exporterNode).getConsumer().Capabilities().MutatesData)
+	}
+
+	// The declarations of the factories take precedence over the capabilities reported by the components.
+	procFactory, expFactory = newCapabilitiesFactories(component.FactoryCapabilities{MutatesData: true})
+	pg, err = Build(context.Background(), newCapabilitiesSettings(componenttest.NewNopTelemetrySettings(), procFactory, expFactory, pipelineCfgs))
+	require.NoError(t, err)
+	assert.True(t, pg.pipelines[capTracesID].capabilitiesNode.getConsumer().Capabilities().MutatesData)
+	for _, exp := range pg.pipelines[capTracesID].exporters {
+		assert.True(t, exp.(*exporterNode).getConsumer().Capabilities().MutatesData)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"sync/atomic"
+
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+type edgeKey struct {
+	from, to int64
+}
+
+// edgeCounter counts the data sent along an edge of the graph.
+type edgeCounter struct {
+	batches atomic.Int64
+	items   atomic.Int64
+	failed  atomic.Int64
+}
+
+func (c *edgeCounter) record(items int, err error) {
+	c.batches.Add(1)
+	c.items.Add(int64(items))
+	if err != nil {
+		c.failed.Add(int64(items))
+	}
+}
+
+// countEdge wraps the consumer of the edge between from and to to count the data sent along it,
+// and to report the pipeline metrics of the edge if they are enabled.
+func (g *Graph) countEdge(from, to graph.Node, next baseConsumer) baseConsumer {
+	key := edgeKey{from: from.ID(), to: to.ID()}
+	counter, ok := g.edges[key]
+	if !ok {
+		counter = &edgeCounter{}
+		g.edges[key] = counter
+	}
+	edge := g.flowEdge(from, to).WithObserver(counter.record)
+	switch edgeDataType(to) {
+	case component.DataTypeTraces:
+		return edge.Traces(next.(consumer.Traces))
+	case component.DataTypeMetrics:
+		return edge.Metrics(next.(consumer.Metrics))
+	case component.DataTypeLogs:
+		return edge.Logs(next.(consumer.Logs))
+	case component.DataTypeProfiles:
+		return edge.Profiles(next.(consumer.Profiles))
+	}
+	return next
+}
+
+// inheritEdgeCounters keeps the counters of the edges of old which are still part of g.
+func (g *Graph) inheritEdgeCounters(old *Graph) {
+	for key, counter := range old.edges {
+		if g.componentGraph.HasEdgeFromTo(key.from, key.to) {
+			g.edges[key] = counter
+		}
+	}
+}
+
+// edgeDataType returns the type of the data sent to the given node.
+func edgeDataType(to graph.Node) component.DataType {
+	switch n := to.(type) {
+	case *capabilitiesNode:
+		return n.pipelineID.Type()
+	case *processorNode:
+		return n.pipelineID.Type()
+	case *fanOutNode:
+		return n.pipelineID.Type()
+	case *exporterNode:
+		return n.pipelineType
+	case *connectorNode:
+		return n.exprPipelineType
+	}
+	return ""
+}
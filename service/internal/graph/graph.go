@@ -8,8 +8,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/simple"
 	"gonum.org/v1/gonum/graph/topo"
@@ -18,11 +21,20 @@ import (
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/internal/componenterror"
 	"go.opentelemetry.io/collector/internal/fanoutconsumer"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/faultinjection"
+	"go.opentelemetry.io/collector/service/internal/absorbconsumer"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
+	"go.opentelemetry.io/collector/service/internal/flowmetrics"
+	"go.opentelemetry.io/collector/service/internal/metadataconsumer"
+	"go.opentelemetry.io/collector/service/internal/slo"
+	"go.opentelemetry.io/collector/service/internal/tap"
+	"go.opentelemetry.io/collector/service/panicisolation"
 	"go.opentelemetry.io/collector/service/pipelines"
+	"go.opentelemetry.io/collector/service/recording"
 )
 
 // Settings holds configuration for building builtPipelines.
@@ -37,6 +49,28 @@ type Settings struct {
 
 	// PipelineConfigs is a map of component.ID to PipelineConfig.
 	PipelineConfigs pipelines.Config
+
+	// SLO records the success ratios of the pipelines, if set.
+	SLO *slo.Registry
+
+	// FaultInjector injects faults in the calls to the components, if set.
+	FaultInjector *faultinjection.Injector
+
+	// PanicGuard isolates the panics of the components, if set.
+	PanicGuard *panicisolation.Guard
+
+	// Taps copies the data passing through the pipelines to the taps attached to them, if set.
+	Taps *tap.Registry
+
+	// Recorder records the data entering the receivers it is configured for, if set.
+	Recorder *recording.Recorder
+
+	// FlowMetrics reports the metrics of the data sent along the edges of the pipelines, if set.
+	FlowMetrics *flowmetrics.Metrics
+
+	// DrainTimeout is how long the exporters have to send their queued data when shutting down,
+	// unless overridden by the configuration of their pipelines. Zero disables the wait.
+	DrainTimeout time.Duration
 }
 
 type Graph struct {
@@ -45,22 +79,47 @@ type Graph struct {
 
 	// Keep track of how nodes relate to pipelines, so we can declare edges in the graph.
 	pipelines map[component.ID]*pipelineNodes
+
+	faultInjector *faultinjection.Injector
+
+	// Counters of the data sent along every edge, kept across reloads.
+	edges map[edgeKey]*edgeCounter
+
+	// The settings the graph was built with, used to find the components changed by a reload.
+	settings Settings
+
+	// Functions pointing the components kept by a reload to their rebuilt consumers.
+	retargets []func()
+
+	// The hosts given to the started components, by node ID. They report the statuses of the components.
+	hosts map[int64]component.Host
+
+	// The isolation of the panics of the components, by node ID, when a PanicGuard is set.
+	isolated map[int64]*panicisolation.Component
 }
 
 func Build(ctx context.Context, set Settings) (*Graph, error) {
-	pipelines := &Graph{
+	pipelines := newGraph(set)
+	return pipelines, pipelines.buildComponents(ctx, set)
+}
+
+func newGraph(set Settings) *Graph {
+	g := &Graph{
 		componentGraph: simple.NewDirectedGraph(),
 		pipelines:      make(map[component.ID]*pipelineNodes, len(set.PipelineConfigs)),
+		faultInjector:  set.FaultInjector,
+		edges:          make(map[edgeKey]*edgeCounter),
+		settings:       set,
 	}
 	for pipelineID := range set.PipelineConfigs {
-		pipelines.pipelines[pipelineID] = &pipelineNodes{
+		g.pipelines[pipelineID] = &pipelineNodes{
 			receivers: make(map[int64]graph.Node),
 			exporters: make(map[int64]graph.Node),
 		}
 	}
-	pipelines.createNodes(set)
-	pipelines.createEdges()
-	return pipelines, pipelines.buildComponents(ctx, set)
+	g.createNodes(set)
+	g.createEdges()
+	return g
 }
 
 // Creates a node for each instance of a component and adds it to the graph
@@ -170,78 +229,359 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 		return cycleErr(err, topo.DirectedCyclesIn(g.componentGraph))
 	}
 
+	if err = g.verifyCapabilities(set); err != nil {
+		return err
+	}
+
 	for i := len(nodes) - 1; i >= 0; i-- {
 		node := nodes[i]
 		switch n := node.(type) {
 		case *receiverNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
+			if n.Component != nil {
+				g.retarget(n.next, g.recordReceiver(n, receiverFanOut(n.pipelineType, g.nextConsumers(n.ID()))))
+				break
+			}
+			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.recordReceiver(n, receiverFanOut(n.pipelineType, g.nextConsumers(n.ID()))))
 		case *processorNode:
+			if n.Component != nil {
+				g.retarget(n.next, g.nextConsumers(n.ID())[0])
+				break
+			}
 			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0])
 		case *exporterNode:
+			if n.Component != nil {
+				break
+			}
 			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ExporterBuilder)
 		case *connectorNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ConnectorBuilder, g.nextConsumers(n.ID()))
+			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ConnectorBuilder, g.nextPipelineConsumers(n.ID()))
 		case *capabilitiesNode:
 			capability := consumer.Capabilities{MutatesData: false}
 			for _, proc := range g.pipelines[n.pipelineID].processors {
 				capability.MutatesData = capability.MutatesData || proc.getConsumer().Capabilities().MutatesData
 			}
 			next := g.nextConsumers(n.ID())[0]
+			metadataKeys := set.PipelineConfigs[n.pipelineID].MetadataKeys
+			absorb := absorbErrors(set, n.pipelineID)
 			switch n.pipelineID.Type() {
 			case component.DataTypeTraces:
 				cc := capabilityconsumer.NewTraces(next.(consumer.Traces), capability)
+				if len(metadataKeys) > 0 {
+					cc = metadataconsumer.NewTraces(cc, metadataKeys)
+				}
+				if set.SLO != nil {
+					cc = slo.NewTraces(cc, set.SLO.Tracker(n.pipelineID).RecordIngest)
+				}
+				if absorb != nil {
+					cc = absorbconsumer.NewTraces(cc, absorb)
+				}
 				n.baseConsumer = cc
 				n.ConsumeTracesFunc = cc.ConsumeTraces
 			case component.DataTypeMetrics:
 				cc := capabilityconsumer.NewMetrics(next.(consumer.Metrics), capability)
+				if len(metadataKeys) > 0 {
+					cc = metadataconsumer.NewMetrics(cc, metadataKeys)
+				}
+				if set.SLO != nil {
+					cc = slo.NewMetrics(cc, set.SLO.Tracker(n.pipelineID).RecordIngest)
+				}
+				if absorb != nil {
+					cc = absorbconsumer.NewMetrics(cc, absorb)
+				}
 				n.baseConsumer = cc
 				n.ConsumeMetricsFunc = cc.ConsumeMetrics
 			case component.DataTypeLogs:
 				cc := capabilityconsumer.NewLogs(next.(consumer.Logs), capability)
+				if len(metadataKeys) > 0 {
+					cc = metadataconsumer.NewLogs(cc, metadataKeys)
+				}
+				if set.SLO != nil {
+					cc = slo.NewLogs(cc, set.SLO.Tracker(n.pipelineID).RecordIngest)
+				}
+				if absorb != nil {
+					cc = absorbconsumer.NewLogs(cc, absorb)
+				}
 				n.baseConsumer = cc
 				n.ConsumeLogsFunc = cc.ConsumeLogs
+			case component.DataTypeProfiles:
+				cc := capabilityconsumer.NewProfiles(next.(consumer.Profiles), capability)
+				if len(metadataKeys) > 0 {
+					cc = metadataconsumer.NewProfiles(cc, metadataKeys)
+				}
+				if set.SLO != nil {
+					cc = slo.NewProfiles(cc, set.SLO.Tracker(n.pipelineID).RecordIngest)
+				}
+				if absorb != nil {
+					cc = absorbconsumer.NewProfiles(cc, absorb)
+				}
+				n.baseConsumer = cc
+				n.ConsumeProfilesFunc = cc.ConsumeProfiles
 			}
 		case *fanOutNode:
 			nexts := g.nextConsumers(n.ID())
+			options := fanOutOptions(set.PipelineConfigs[n.pipelineID].FanOut)
 			switch n.pipelineID.Type() {
 			case component.DataTypeTraces:
 				consumers := make([]consumer.Traces, 0, len(nexts))
 				for _, next := range nexts {
 					consumers = append(consumers, next.(consumer.Traces))
 				}
-				n.baseConsumer = fanoutconsumer.NewTraces(consumers)
+				fanout := fanoutconsumer.NewTraces(consumers, options...)
+				if set.SLO != nil {
+					fanout = slo.NewTraces(fanout, set.SLO.Tracker(n.pipelineID).RecordExport)
+				}
+				n.baseConsumer = fanout
 			case component.DataTypeMetrics:
 				consumers := make([]consumer.Metrics, 0, len(nexts))
 				for _, next := range nexts {
 
 					consumers = append(consumers, next.(consumer.Metrics))
 				}
-				n.baseConsumer = fanoutconsumer.NewMetrics(consumers)
+				fanout := fanoutconsumer.NewMetrics(consumers, options...)
+				if set.SLO != nil {
+					fanout = slo.NewMetrics(fanout, set.SLO.Tracker(n.pipelineID).RecordExport)
+				}
+				n.baseConsumer = fanout
 			case component.DataTypeLogs:
 				consumers := make([]consumer.Logs, 0, len(nexts))
 				for _, next := range nexts {
 					consumers = append(consumers, next.(consumer.Logs))
 				}
-				n.baseConsumer = fanoutconsumer.NewLogs(consumers)
+				fanout := fanoutconsumer.NewLogs(consumers, options...)
+				if set.SLO != nil {
+					fanout = slo.NewLogs(fanout, set.SLO.Tracker(n.pipelineID).RecordExport)
+				}
+				n.baseConsumer = fanout
+			case component.DataTypeProfiles:
+				consumers := make([]consumer.Profiles, 0, len(nexts))
+				for _, next := range nexts {
+					consumers = append(consumers, next.(consumer.Profiles))
+				}
+				fanout := fanoutconsumer.NewProfiles(consumers, options...)
+				if set.SLO != nil {
+					fanout = slo.NewProfiles(fanout, set.SLO.Tracker(n.pipelineID).RecordExport)
+				}
+				n.baseConsumer = fanout
 			}
 		}
 		if err != nil {
-			return err
+			return componentError(node, err)
 		}
 	}
 	return nil
 }
 
+// componentError identifies the component of node as the cause of err.
+func componentError(node graph.Node, err error) error {
+	switch n := node.(type) {
+	case *receiverNode:
+		return componenterror.New(component.KindReceiver, n.componentID, err)
+	case *processorNode:
+		return componenterror.New(component.KindProcessor, n.componentID, err)
+	case *exporterNode:
+		return componenterror.New(component.KindExporter, n.componentID, err)
+	case *connectorNode:
+		return componenterror.New(component.KindConnector, n.componentID, err)
+	}
+	return err
+}
+
 // Find all nodes
 func (g *Graph) nextConsumers(nodeID int64) []baseConsumer {
+	from := g.componentGraph.Node(nodeID)
 	nextNodes := g.componentGraph.From(nodeID)
 	nexts := make([]baseConsumer, 0, nextNodes.Len())
 	for nextNodes.Next() {
-		nexts = append(nexts, nextNodes.Node().(consumerNode).getConsumer())
+		nexts = append(nexts, g.edgeConsumer(from, nextNodes.Node()))
 	}
 	return nexts
 }
 
+// nextPipelineConsumers returns the consumers of the pipelines fed by a connector, by pipeline ID.
+func (g *Graph) nextPipelineConsumers(nodeID int64) map[component.ID]baseConsumer {
+	from := g.componentGraph.Node(nodeID)
+	nextNodes := g.componentGraph.From(nodeID)
+	nexts := make(map[component.ID]baseConsumer, nextNodes.Len())
+	for nextNodes.Next() {
+		next := nextNodes.Node()
+		nexts[next.(*capabilitiesNode).pipelineID] = g.edgeConsumer(from, next)
+	}
+	return nexts
+}
+
+// edgeConsumer returns the consumer used to send data along the edge between from and to.
+func (g *Graph) edgeConsumer(from, to graph.Node) baseConsumer {
+	next := g.isolatePanics(to, to.(consumerNode).getConsumer())
+	next = g.injectFaults(from, to, next)
+	next = g.tapEdge(from, to, next)
+	return g.countEdge(from, to, next)
+}
+
+// edgeTarget returns the pipeline and the component receiving the data sent along the edge between
+// from and to: a processor, or an exporter or connector consuming from a pipeline. The component ID is
+// empty for the edges entering a pipeline.
+func edgeTarget(from, to graph.Node) (pipelineID, componentID component.ID, ok bool) {
+	switch n := to.(type) {
+	case *capabilitiesNode:
+		return n.pipelineID, component.ID{}, true
+	case *processorNode:
+		return n.pipelineID, n.componentID, true
+	case *exporterNode:
+		return from.(*fanOutNode).pipelineID, n.componentID, true
+	case *connectorNode:
+		return from.(*fanOutNode).pipelineID, n.componentID, true
+	}
+	return component.ID{}, component.ID{}, false
+}
+
+// isolatePanics wraps the consumer of the processor, exporter or connector to isolate its panics.
+func (g *Graph) isolatePanics(to graph.Node, next baseConsumer) baseConsumer {
+	iso := g.isolation(to)
+	if iso == nil {
+		return next
+	}
+	var dataType component.DataType
+	switch n := to.(type) {
+	case *processorNode:
+		dataType = n.pipelineID.Type()
+	case *exporterNode:
+		dataType = n.pipelineType
+	case *connectorNode:
+		dataType = n.exprPipelineType
+	}
+	switch dataType {
+	case component.DataTypeTraces:
+		return iso.Traces(next.(consumer.Traces))
+	case component.DataTypeMetrics:
+		return iso.Metrics(next.(consumer.Metrics))
+	case component.DataTypeLogs:
+		return iso.Logs(next.(consumer.Logs))
+	case component.DataTypeProfiles:
+		return iso.Profiles(next.(consumer.Profiles))
+	}
+	return next
+}
+
+// injectFaults wraps the consumer of the edge between from and to with the configured faults.
+// Faults target the processors, and the exporters and connectors consuming from a pipeline.
+func (g *Graph) injectFaults(from, to graph.Node, next baseConsumer) baseConsumer {
+	if g.faultInjector == nil {
+		return next
+	}
+	pipelineID, componentID, ok := edgeTarget(from, to)
+	if !ok || componentID == (component.ID{}) {
+		return next
+	}
+	switch pipelineID.Type() {
+	case component.DataTypeTraces:
+		return g.faultInjector.Traces(pipelineID, componentID, next.(consumer.Traces))
+	case component.DataTypeMetrics:
+		return g.faultInjector.Metrics(pipelineID, componentID, next.(consumer.Metrics))
+	case component.DataTypeLogs:
+		return g.faultInjector.Logs(pipelineID, componentID, next.(consumer.Logs))
+	case component.DataTypeProfiles:
+		return g.faultInjector.Profiles(pipelineID, componentID, next.(consumer.Profiles))
+	}
+	return next
+}
+
+// tapEdge wraps the consumer of the edge between from and to to copy the data sent along it to the
+// taps attached to the data entering its pipeline or component.
+func (g *Graph) tapEdge(from, to graph.Node, next baseConsumer) baseConsumer {
+	if g.settings.Taps == nil {
+		return next
+	}
+	pipelineID, componentID, ok := edgeTarget(from, to)
+	if !ok {
+		return next
+	}
+	switch pipelineID.Type() {
+	case component.DataTypeTraces:
+		return g.settings.Taps.Traces(pipelineID, componentID, next.(consumer.Traces))
+	case component.DataTypeMetrics:
+		return g.settings.Taps.Metrics(pipelineID, componentID, next.(consumer.Metrics))
+	case component.DataTypeLogs:
+		return g.settings.Taps.Logs(pipelineID, componentID, next.(consumer.Logs))
+	case component.DataTypeProfiles:
+		return g.settings.Taps.Profiles(pipelineID, componentID, next.(consumer.Profiles))
+	}
+	return next
+}
+
+// absorbedErrorsLogInterval is the interval at which the errors absorbed by a pipeline are logged.
+const absorbedErrorsLogInterval = time.Minute
+
+// absorbErrors returns the function absorbing the errors of the pipeline, or nil if the pipeline
+// propagates its errors to the receivers.
+func absorbErrors(set Settings, pipelineID component.ID) func(ctx context.Context, items int, err error) {
+	if set.PipelineConfigs[pipelineID].ErrorPropagation != pipelines.ErrorPropagationBestEffort {
+		return nil
+	}
+	logger := zap.NewNop()
+	if set.Telemetry.Logger != nil {
+		// At most one absorbed error is logged per interval, not to flood the logs when an exporter is failing.
+		logger = set.Telemetry.Logger.With(zap.Stringer("pipeline", pipelineID)).WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewSamplerWithOptions(core, absorbedErrorsLogInterval, 1, 0)
+		}))
+	}
+	var count func(context.Context, int)
+	if set.FlowMetrics != nil {
+		count = set.FlowMetrics.Absorbed(pipelineID)
+	}
+	return func(ctx context.Context, items int, err error) {
+		logger.Warn("Absorbed the error of the pipeline, the next ones are not logged for a minute", zap.Int("items", items), zap.Error(err))
+		if count != nil {
+			count(ctx, items)
+		}
+	}
+}
+
+// flowEdge returns the flowmetrics.Edge reporting the pipeline metrics of the data sent along the
+// edge between from and to, nil if they are not reported: the edges entering the pipelines, the
+// processors and the exporters and connectors consuming from the pipelines, and the fan out of
+// the pipelines are reported.
+func (g *Graph) flowEdge(from, to graph.Node) *flowmetrics.Edge {
+	if g.settings.FlowMetrics == nil {
+		return nil
+	}
+	switch n := to.(type) {
+	case *capabilitiesNode:
+		switch f := from.(type) {
+		case *receiverNode:
+			return g.settings.FlowMetrics.In(n.pipelineID, f.componentID)
+		case *connectorNode:
+			return g.settings.FlowMetrics.In(n.pipelineID, f.componentID)
+		}
+	case *processorNode:
+		return g.settings.FlowMetrics.Processed(n.pipelineID, n.componentID)
+	case *fanOutNode:
+		return g.settings.FlowMetrics.FanOut(n.pipelineID)
+	case *exporterNode:
+		return g.settings.FlowMetrics.Out(from.(*fanOutNode).pipelineID, n.componentID)
+	case *connectorNode:
+		return g.settings.FlowMetrics.Out(from.(*fanOutNode).pipelineID, n.componentID)
+	}
+	return nil
+}
+
+// recordReceiver wraps the consumer of the receiver to record the data it receives, if it is recorded.
+func (g *Graph) recordReceiver(n *receiverNode, next baseConsumer) baseConsumer {
+	if g.settings.Recorder == nil {
+		return next
+	}
+	switch n.pipelineType {
+	case component.DataTypeTraces:
+		return g.settings.Recorder.Traces(n.componentID, next.(consumer.Traces))
+	case component.DataTypeMetrics:
+		return g.settings.Recorder.Metrics(n.componentID, next.(consumer.Metrics))
+	case component.DataTypeLogs:
+		return g.settings.Recorder.Logs(n.componentID, next.(consumer.Logs))
+	case component.DataTypeProfiles:
+		return g.settings.Recorder.Profiles(n.componentID, next.(consumer.Profiles))
+	}
+	return next
+}
+
 // A node-based representation of a pipeline configuration.
 type pipelineNodes struct {
 	// Use map to assist with deduplication of connector instances.
@@ -262,6 +602,28 @@ type pipelineNodes struct {
 }
 
 func (g *Graph) StartAll(ctx context.Context, host component.Host) error {
+	return g.start(ctx, host, func(graph.Node) bool { return true })
+}
+
+// StartAllExceptReceivers starts all the components but the receivers, so that the
+// pipelines do not receive data until StartReceivers is called.
+func (g *Graph) StartAllExceptReceivers(ctx context.Context, host component.Host) error {
+	return g.start(ctx, host, func(node graph.Node) bool {
+		_, isReceiver := node.(*receiverNode)
+		return !isReceiver
+	})
+}
+
+// StartReceivers starts the receivers, once the other components were started by StartAllExceptReceivers.
+func (g *Graph) StartReceivers(ctx context.Context, host component.Host) error {
+	return g.start(ctx, host, func(node graph.Node) bool {
+		_, isReceiver := node.(*receiverNode)
+		return isReceiver
+	})
+}
+
+// start starts the components of the nodes selected by include.
+func (g *Graph) start(ctx context.Context, host component.Host, include func(graph.Node) bool) error {
 	nodes, err := topo.Sort(g.componentGraph)
 	if err != nil {
 		return err
@@ -271,12 +633,11 @@ func (g *Graph) StartAll(ctx context.Context, host component.Host) error {
 	// are started before upstream components. This ensures that each
 	// component's consumer is ready to consume.
 	for i := len(nodes) - 1; i >= 0; i-- {
-		comp, ok := nodes[i].(component.Component)
-		if !ok {
+		if _, ok := nodes[i].(component.Component); !ok || !include(nodes[i]) {
 			// Skip capabilities/fanout nodes
 			continue
 		}
-		if compErr := comp.Start(ctx, host); compErr != nil {
+		if compErr := g.startComponent(ctx, host, nodes[i]); compErr != nil {
 			return compErr
 		}
 	}
@@ -288,23 +649,56 @@ func (g *Graph) ShutdownAll(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	g.stopIsolation(func(int64) bool { return true })
 
 	// Stop in topological order so that upstream components
 	// are stopped before downstream components.  This ensures
 	// that each component has a chance to drain to its consumer
 	// before the consumer is stopped.
+	start := time.Now()
 	var errs error
 	for i := 0; i < len(nodes); i++ {
-		comp, ok := nodes[i].(component.Component)
-		if !ok {
+		if _, ok := nodes[i].(component.Component); !ok {
 			// Skip capabilities/fanout nodes
 			continue
 		}
-		errs = multierr.Append(errs, comp.Shutdown(ctx))
+		errs = multierr.Append(errs, g.shutdownNode(ctx, start, nodes[i]))
 	}
 	return errs
 }
 
+// shutdownNode shuts the node down. The exporters are given until the drain timeout of their
+// pipelines, counted from the start of the shutdown, to send their queued data.
+func (g *Graph) shutdownNode(ctx context.Context, start time.Time, node graph.Node) error {
+	if _, ok := node.(*exporterNode); ok {
+		if timeout := g.drainTimeout(node); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, start.Add(timeout))
+			defer cancel()
+			ctx = exporter.ContextWithDrainTimeout(ctx, timeout)
+		}
+	}
+	return g.shutdownComponent(ctx, node)
+}
+
+// drainTimeout returns the longest drain timeout of the pipelines the exporter is part of.
+func (g *Graph) drainTimeout(node graph.Node) time.Duration {
+	var timeout time.Duration
+	for pipelineID, p := range g.pipelines {
+		if _, ok := p.exporters[node.ID()]; !ok {
+			continue
+		}
+		pipelineTimeout := g.settings.DrainTimeout
+		if cfg := g.settings.PipelineConfigs[pipelineID]; cfg != nil && cfg.DrainTimeout != nil {
+			pipelineTimeout = *cfg.DrainTimeout
+		}
+		if pipelineTimeout > timeout {
+			timeout = pipelineTimeout
+		}
+	}
+	return timeout
+}
+
 // Deprecated: [0.79.0] This function will be removed in the future.
 // Several components in the contrib repository use this function so it cannot be removed
 // before those cases are removed. In most cases, use of this function can be replaced by a
@@ -316,6 +710,7 @@ func (g *Graph) GetExporters() map[component.DataType]map[component.ID]component
 	exportersMap[component.DataTypeTraces] = make(map[component.ID]component.Component)
 	exportersMap[component.DataTypeMetrics] = make(map[component.ID]component.Component)
 	exportersMap[component.DataTypeLogs] = make(map[component.ID]component.Component)
+	exportersMap[component.DataTypeProfiles] = make(map[component.ID]component.Component)
 
 	for _, pg := range g.pipelines {
 		for _, expNode := range pg.exporters {
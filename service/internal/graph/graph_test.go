@@ -9,11 +9,13 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gonum.org/v1/gonum/graph/simple"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/connector"
@@ -23,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/collector/receiver"
@@ -160,6 +163,35 @@ func TestGraphStartStop(t *testing.T) {
 	}
 }
 
+func TestGraphStartReceiversLater(t *testing.T) {
+	g, err := Build(context.Background(), renderTestSettings())
+	require.NoError(t, err)
+	require.NoError(t, g.StartAllExceptReceivers(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, g.ShutdownAll(context.Background())) }()
+
+	started := func(kind string) []bool {
+		var states []bool
+		for it := g.componentGraph.Nodes(); it.Next(); {
+			switch n := it.Node().(type) {
+			case *receiverNode:
+				if kind == "receiver" {
+					states = append(states, n.Component.(*testcomponents.ExampleReceiver).Started())
+				}
+			case *exporterNode:
+				if kind == "exporter" {
+					states = append(states, n.Component.(*testcomponents.ExampleExporter).Started())
+				}
+			}
+		}
+		return states
+	}
+	assert.NotContains(t, started("receiver"), true)
+	assert.NotContains(t, started("exporter"), false)
+
+	require.NoError(t, g.StartReceivers(context.Background(), componenttest.NewNopHost()))
+	assert.NotContains(t, started("receiver"), false)
+}
+
 func TestGraphStartStopCycle(t *testing.T) {
 	pg := &Graph{componentGraph: simple.NewDirectedGraph()}
 
@@ -222,6 +254,11 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 					Processors: []component.ID{component.NewID("exampleprocessor")},
 					Exporters:  []component.ID{component.NewID("exampleexporter")},
 				},
+				component.NewID("profiles"): {
+					Receivers:  []component.ID{component.NewID("examplereceiver")},
+					Processors: []component.ID{component.NewID("exampleprocessor")},
+					Exporters:  []component.ID{component.NewID("exampleexporter")},
+				},
 			},
 			expectedPerExporter: 1,
 		},
@@ -243,6 +280,11 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 					Processors: []component.ID{component.NewIDWithName("exampleprocessor", "mutate")},
 					Exporters:  []component.ID{component.NewID("exampleexporter")},
 				},
+				component.NewID("profiles"): {
+					Receivers:  []component.ID{component.NewID("examplereceiver")},
+					Processors: []component.ID{component.NewIDWithName("exampleprocessor", "mutate")},
+					Exporters:  []component.ID{component.NewID("exampleexporter")},
+				},
 			},
 			expectedPerExporter: 1,
 		},
@@ -264,6 +306,11 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 					Processors: []component.ID{component.NewID("exampleprocessor"), component.NewIDWithName("exampleprocessor", "mutate")},
 					Exporters:  []component.ID{component.NewID("exampleexporter")},
 				},
+				component.NewID("profiles"): {
+					Receivers:  []component.ID{component.NewID("examplereceiver")},
+					Processors: []component.ID{component.NewID("exampleprocessor"), component.NewIDWithName("exampleprocessor", "mutate")},
+					Exporters:  []component.ID{component.NewID("exampleexporter")},
+				},
 			},
 			expectedPerExporter: 1,
 		},
@@ -282,6 +329,10 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 					Receivers: []component.ID{component.NewID("examplereceiver")},
 					Exporters: []component.ID{component.NewID("exampleexporter")},
 				},
+				component.NewID("profiles"): {
+					Receivers: []component.ID{component.NewID("examplereceiver")},
+					Exporters: []component.ID{component.NewID("exampleexporter")},
+				},
 			},
 			expectedPerExporter: 1,
 		},
@@ -303,6 +354,11 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 					Processors: []component.ID{component.NewIDWithName("exampleprocessor", "mutate"), component.NewID("exampleprocessor")},
 					Exporters:  []component.ID{component.NewID("exampleexporter"), component.NewIDWithName("exampleexporter", "1")},
 				},
+				component.NewID("profiles"): {
+					Receivers:  []component.ID{component.NewID("examplereceiver"), component.NewIDWithName("examplereceiver", "1")},
+					Processors: []component.ID{component.NewIDWithName("exampleprocessor", "mutate"), component.NewID("exampleprocessor")},
+					Exporters:  []component.ID{component.NewID("exampleexporter"), component.NewIDWithName("exampleexporter", "1")},
+				},
 			},
 			expectedPerExporter: 2,
 		},
@@ -321,6 +377,10 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 					Receivers: []component.ID{component.NewID("examplereceiver"), component.NewIDWithName("examplereceiver", "1")},
 					Exporters: []component.ID{component.NewID("exampleexporter"), component.NewIDWithName("exampleexporter", "1")},
 				},
+				component.NewID("profiles"): {
+					Receivers: []component.ID{component.NewID("examplereceiver"), component.NewIDWithName("examplereceiver", "1")},
+					Exporters: []component.ID{component.NewID("exampleexporter"), component.NewIDWithName("exampleexporter", "1")},
+				},
 			},
 			expectedPerExporter: 2,
 		},
@@ -668,6 +728,7 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 						require.Equal(t, 0, len(e.Traces))
 						require.Equal(t, 0, len(e.Metrics))
 						require.Equal(t, 0, len(e.Logs))
+						require.Equal(t, 0, len(e.Profiles))
 					case *connectorNode:
 						// connector needs to be unwrapped to access component as ExampleConnector
 						switch ct := c.Component.(type) {
@@ -749,6 +810,10 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 				logsReceiver := c.(*testcomponents.ExampleReceiver)
 				assert.NoError(t, logsReceiver.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
 			}
+			for _, c := range allReceivers[component.DataTypeProfiles] {
+				profilesReceiver := c.(*testcomponents.ExampleReceiver)
+				assert.NoError(t, profilesReceiver.ConsumeProfiles(context.Background(), testdata.GenerateProfiles(1)))
+			}
 
 			// Shut down the entire component graph
 			assert.NoError(t, pg.ShutdownAll(context.Background()))
@@ -809,21 +874,48 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 				tracesExporter := e.(*testcomponents.ExampleExporter)
 				assert.Equal(t, test.expectedPerExporter, len(tracesExporter.Traces))
 				for i := 0; i < test.expectedPerExporter; i++ {
-					assert.EqualValues(t, testdata.GenerateTraces(1), tracesExporter.Traces[0])
+					expected := testdata.GenerateTraces(1)
+					if tracesExporter.Traces[0].IsReadOnly() {
+						// The data shared by multiple non-mutating exporters is marked as read-only.
+						expected.MarkReadOnly()
+					}
+					assert.EqualValues(t, expected, tracesExporter.Traces[0])
 				}
 			}
 			for _, e := range allExporters[component.DataTypeMetrics] {
 				metricsExporter := e.(*testcomponents.ExampleExporter)
 				assert.Equal(t, test.expectedPerExporter, len(metricsExporter.Metrics))
 				for i := 0; i < test.expectedPerExporter; i++ {
-					assert.EqualValues(t, testdata.GenerateMetrics(1), metricsExporter.Metrics[0])
+					expected := testdata.GenerateMetrics(1)
+					if metricsExporter.Metrics[0].IsReadOnly() {
+						// The data shared by multiple non-mutating exporters is marked as read-only.
+						expected.MarkReadOnly()
+					}
+					assert.EqualValues(t, expected, metricsExporter.Metrics[0])
 				}
 			}
 			for _, e := range allExporters[component.DataTypeLogs] {
 				logsExporter := e.(*testcomponents.ExampleExporter)
 				assert.Equal(t, test.expectedPerExporter, len(logsExporter.Logs))
 				for i := 0; i < test.expectedPerExporter; i++ {
-					assert.EqualValues(t, testdata.GenerateLogs(1), logsExporter.Logs[0])
+					expected := testdata.GenerateLogs(1)
+					if logsExporter.Logs[0].IsReadOnly() {
+						// The data shared by multiple non-mutating exporters is marked as read-only.
+						expected.MarkReadOnly()
+					}
+					assert.EqualValues(t, expected, logsExporter.Logs[0])
+				}
+			}
+			for _, e := range allExporters[component.DataTypeProfiles] {
+				profilesExporter := e.(*testcomponents.ExampleExporter)
+				assert.Equal(t, test.expectedPerExporter, len(profilesExporter.Profiles))
+				for i := 0; i < test.expectedPerExporter; i++ {
+					expected := testdata.GenerateProfiles(1)
+					if profilesExporter.Profiles[0].IsReadOnly() {
+						// The data shared by multiple non-mutating exporters is marked as read-only.
+						expected.MarkReadOnly()
+					}
+					assert.EqualValues(t, expected, profilesExporter.Profiles[0])
 				}
 			}
 		})
@@ -1797,6 +1889,29 @@ func TestGraphBuildErrors(t *testing.T) {
 			},
 			expected: "connector factory not available for: \"unknown\"",
 		},
+		{
+			name: "not_supported_connector_profiles",
+			receiverCfgs: map[component.ID]component.Config{
+				component.NewID("nop"): nopReceiverFactory.CreateDefaultConfig(),
+			},
+			exporterCfgs: map[component.ID]component.Config{
+				component.NewID("nop"): nopExporterFactory.CreateDefaultConfig(),
+			},
+			connectorCfgs: map[component.ID]component.Config{
+				component.NewIDWithName("nop", "conn"): nopConnectorFactory.CreateDefaultConfig(),
+			},
+			pipelineCfgs: pipelines.Config{
+				component.NewIDWithName("profiles", "in"): {
+					Receivers: []component.ID{component.NewID("nop")},
+					Exporters: []component.ID{component.NewIDWithName("nop", "conn")},
+				},
+				component.NewIDWithName("profiles", "out"): {
+					Receivers: []component.ID{component.NewIDWithName("nop", "conn")},
+					Exporters: []component.ID{component.NewID("nop")},
+				},
+			},
+			expected: "connector \"nop/conn\" cannot connect from profiles to profiles: telemetry type is not supported",
+		},
 	}
 
 	for _, test := range tests {
@@ -1961,6 +2076,7 @@ func (g *Graph) getReceivers() map[component.DataType]map[component.ID]component
 	receiversMap[component.DataTypeTraces] = make(map[component.ID]component.Component)
 	receiversMap[component.DataTypeMetrics] = make(map[component.ID]component.Component)
 	receiversMap[component.DataTypeLogs] = make(map[component.ID]component.Component)
+	receiversMap[component.DataTypeProfiles] = make(map[component.ID]component.Component)
 
 	for _, pg := range g.pipelines {
 		for _, rcvrNode := range pg.receivers {
@@ -2147,3 +2263,129 @@ func (e errComponent) Start(context.Context, component.Host) error {
 func (e errComponent) Shutdown(context.Context) error {
 	return errors.New("my error")
 }
+
+// contextExporter records the client.Info of the data it consumes.
+type contextExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Traces
+	infos []client.Info
+}
+
+func TestGraphMetadataKeys(t *testing.T) {
+	rcvrID := component.NewID("examplereceiver")
+	procID := component.NewID("exampleprocessor")
+	expTenantID := component.NewIDWithName("contextexporter", "tenant")
+	expAllID := component.NewIDWithName("contextexporter", "all")
+	tracesTenantID := component.NewIDWithName("traces", "tenant")
+	tracesAllID := component.NewIDWithName("traces", "all")
+
+	exporters := map[component.ID]*contextExporter{}
+	expFactory := exporter.NewFactory("contextexporter", func() component.Config { return &struct{}{} },
+		exporter.WithTraces(func(_ context.Context, set exporter.CreateSettings, _ component.Config) (exporter.Traces, error) {
+			exp := &contextExporter{}
+			exp.Traces, _ = consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+				exp.infos = append(exp.infos, client.FromContext(ctx))
+				return nil
+			})
+			exporters[set.ID] = exp
+			return exp, nil
+		}, component.StabilityLevelDevelopment))
+
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(
+			map[component.ID]component.Config{
+				rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ProcessorBuilder: processor.NewBuilder(
+			map[component.ID]component.Config{
+				procID: testcomponents.ExampleProcessorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]processor.Factory{
+				testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory,
+			},
+		),
+		ExporterBuilder: exporter.NewBuilder(
+			map[component.ID]component.Config{
+				expTenantID: expFactory.CreateDefaultConfig(),
+				expAllID:    expFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				expFactory.Type(): expFactory,
+			},
+		),
+		ConnectorBuilder: connector.NewBuilder(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			tracesTenantID: {
+				Receivers:    []component.ID{rcvrID},
+				Processors:   []component.ID{procID},
+				Exporters:    []component.ID{expTenantID},
+				MetadataKeys: []string{"X-Tenant"},
+			},
+			tracesAllID: {
+				Receivers:  []component.ID{rcvrID},
+				Processors: []component.ID{procID},
+				Exporters:  []component.ID{expAllID},
+			},
+		},
+	}
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+
+	md := map[string][]string{"x-tenant": {"acme"}, "authorization": {"secret"}}
+	ctx := client.NewContext(context.Background(), client.Info{Metadata: client.NewMetadata(md)})
+	tracesReceiver := pg.getReceivers()[component.DataTypeTraces][rcvrID].(*testcomponents.ExampleReceiver)
+	require.NoError(t, tracesReceiver.ConsumeTraces(ctx, testdata.GenerateTraces(1)))
+
+	// Only the selected keys reach the exporters of the pipeline which opted in.
+	require.Len(t, exporters[expTenantID].infos, 1)
+	tenantMD := exporters[expTenantID].infos[0].Metadata
+	assert.Equal(t, []string{"acme"}, tenantMD.Get("X-Tenant"))
+	assert.Empty(t, tenantMD.Get("authorization"))
+
+	// The metadata is left unchanged in the other pipelines.
+	require.Len(t, exporters[expAllID].infos, 1)
+	allMD := exporters[expAllID].infos[0].Metadata
+	assert.Equal(t, []string{"acme"}, allMD.Get("x-tenant"))
+	assert.Equal(t, []string{"secret"}, allMD.Get("authorization"))
+}
+
+func TestGraphDrainTimeout(t *testing.T) {
+	short, long := time.Second, 3*time.Second
+	set := renderTestSettings()
+	set.DrainTimeout = 2 * time.Second
+	set.PipelineConfigs = pipelines.Config{
+		component.NewIDWithName("traces", "short"): {
+			Receivers:    []component.ID{component.NewID("examplereceiver")},
+			Exporters:    []component.ID{component.NewID("exampleexporter")},
+			DrainTimeout: &short,
+		},
+		component.NewIDWithName("traces", "long"): {
+			Receivers:    []component.ID{component.NewID("examplereceiver")},
+			Exporters:    []component.ID{component.NewID("exampleexporter")},
+			DrainTimeout: &long,
+		},
+		component.NewID("metrics"): {
+			Receivers: []component.ID{component.NewID("examplereceiver")},
+			Exporters: []component.ID{component.NewID("exampleexporter")},
+		},
+	}
+	g := Topology(set)
+
+	timeouts := make(map[component.DataType]time.Duration)
+	for it := g.componentGraph.Nodes(); it.Next(); {
+		if n, ok := it.Node().(*exporterNode); ok {
+			timeouts[n.pipelineType] = g.drainTimeout(n)
+		}
+	}
+	assert.Equal(t, map[component.DataType]time.Duration{
+		component.DataTypeTraces:  long,
+		component.DataTypeMetrics: 2 * time.Second,
+	}, timeouts)
+}
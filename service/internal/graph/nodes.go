@@ -18,6 +18,7 @@ import (
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
 	"go.opentelemetry.io/collector/service/internal/components"
+	"go.opentelemetry.io/collector/service/pipelines"
 )
 
 const (
@@ -57,6 +58,8 @@ type receiverNode struct {
 	componentID  component.ID
 	pipelineType component.DataType
 	component.Component
+	// next is kept with the component across reloads so that it can be pointed to the rebuilt pipelines.
+	next *switchConsumer
 }
 
 func newReceiverNode(pipelineType component.DataType, recvID component.ID) *receiverNode {
@@ -71,35 +74,57 @@ func (n *receiverNode) buildComponent(ctx context.Context,
 	tel component.TelemetrySettings,
 	info component.BuildInfo,
 	builder *receiver.Builder,
-	nexts []baseConsumer,
+	next baseConsumer,
 ) error {
 	set := receiver.CreateSettings{ID: n.componentID, TelemetrySettings: tel, BuildInfo: info}
 	set.TelemetrySettings.Logger = components.ReceiverLogger(tel.Logger, n.componentID, n.pipelineType)
+	n.next = newSwitchConsumer(next)
 	var err error
 	switch n.pipelineType {
+	case component.DataTypeTraces:
+		n.Component, err = builder.CreateTraces(ctx, set, n.next)
+	case component.DataTypeMetrics:
+		n.Component, err = builder.CreateMetrics(ctx, set, n.next)
+	case component.DataTypeLogs:
+		n.Component, err = builder.CreateLogs(ctx, set, n.next)
+	case component.DataTypeProfiles:
+		n.Component, err = builder.CreateProfiles(ctx, set, n.next)
+	default:
+		return fmt.Errorf("error creating receiver %q for data type %q is not supported", set.ID, n.pipelineType)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create %q receiver for data type %q: %w", set.ID, n.pipelineType, err)
+	}
+	return nil
+}
+
+// receiverFanOut returns the consumer fanning out the data of a receiver to the pipelines it is part of.
+func receiverFanOut(pipelineType component.DataType, nexts []baseConsumer) baseConsumer {
+	switch pipelineType {
 	case component.DataTypeTraces:
 		var consumers []consumer.Traces
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Traces))
 		}
-		n.Component, err = builder.CreateTraces(ctx, set, fanoutconsumer.NewTraces(consumers))
+		return fanoutconsumer.NewTraces(consumers)
 	case component.DataTypeMetrics:
 		var consumers []consumer.Metrics
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Metrics))
 		}
-		n.Component, err = builder.CreateMetrics(ctx, set, fanoutconsumer.NewMetrics(consumers))
+		return fanoutconsumer.NewMetrics(consumers)
 	case component.DataTypeLogs:
 		var consumers []consumer.Logs
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Logs))
 		}
-		n.Component, err = builder.CreateLogs(ctx, set, fanoutconsumer.NewLogs(consumers))
-	default:
-		return fmt.Errorf("error creating receiver %q for data type %q is not supported", set.ID, n.pipelineType)
-	}
-	if err != nil {
-		return fmt.Errorf("failed to create %q receiver for data type %q: %w", set.ID, n.pipelineType, err)
+		return fanoutconsumer.NewLogs(consumers)
+	case component.DataTypeProfiles:
+		var consumers []consumer.Profiles
+		for _, next := range nexts {
+			consumers = append(consumers, next.(consumer.Profiles))
+		}
+		return fanoutconsumer.NewProfiles(consumers)
 	}
 	return nil
 }
@@ -113,6 +138,10 @@ type processorNode struct {
 	componentID component.ID
 	pipelineID  component.ID
 	component.Component
+	// next is kept with the component across reloads so that it can be pointed to the rebuilt pipeline.
+	next *switchConsumer
+	// mutatesData is set if the factory of the processor declares that it mutates the data.
+	mutatesData bool
 }
 
 func newProcessorNode(pipelineID, procID component.ID) *processorNode {
@@ -124,7 +153,7 @@ func newProcessorNode(pipelineID, procID component.ID) *processorNode {
 }
 
 func (n *processorNode) getConsumer() baseConsumer {
-	return n.Component.(baseConsumer)
+	return withMutatesData(n.pipelineID.Type(), n.Component.(baseConsumer), n.mutatesData)
 }
 
 func (n *processorNode) buildComponent(ctx context.Context,
@@ -135,14 +164,20 @@ func (n *processorNode) buildComponent(ctx context.Context,
 ) error {
 	set := processor.CreateSettings{ID: n.componentID, TelemetrySettings: tel, BuildInfo: info}
 	set.TelemetrySettings.Logger = components.ProcessorLogger(set.TelemetrySettings.Logger, n.componentID, n.pipelineID)
+	n.next = newSwitchConsumer(next)
+	if f := builder.Factory(n.componentID.Type()); f != nil {
+		n.mutatesData = f.Capabilities().MutatesData
+	}
 	var err error
 	switch n.pipelineID.Type() {
 	case component.DataTypeTraces:
-		n.Component, err = builder.CreateTraces(ctx, set, next.(consumer.Traces))
+		n.Component, err = builder.CreateTraces(ctx, set, n.next)
 	case component.DataTypeMetrics:
-		n.Component, err = builder.CreateMetrics(ctx, set, next.(consumer.Metrics))
+		n.Component, err = builder.CreateMetrics(ctx, set, n.next)
 	case component.DataTypeLogs:
-		n.Component, err = builder.CreateLogs(ctx, set, next.(consumer.Logs))
+		n.Component, err = builder.CreateLogs(ctx, set, n.next)
+	case component.DataTypeProfiles:
+		n.Component, err = builder.CreateProfiles(ctx, set, n.next)
 	default:
 		return fmt.Errorf("error creating processor %q in pipeline %q, data type %q is not supported", set.ID, n.pipelineID, n.pipelineID.Type())
 	}
@@ -161,6 +196,8 @@ type exporterNode struct {
 	componentID  component.ID
 	pipelineType component.DataType
 	component.Component
+	// mutatesData is set if the factory of the exporter declares that it mutates the data.
+	mutatesData bool
 }
 
 func newExporterNode(pipelineType component.DataType, exprID component.ID) *exporterNode {
@@ -172,7 +209,7 @@ func newExporterNode(pipelineType component.DataType, exprID component.ID) *expo
 }
 
 func (n *exporterNode) getConsumer() baseConsumer {
-	return n.Component.(baseConsumer)
+	return withMutatesData(n.pipelineType, n.Component.(baseConsumer), n.mutatesData)
 }
 
 func (n *exporterNode) buildComponent(
@@ -183,6 +220,9 @@ func (n *exporterNode) buildComponent(
 ) error {
 	set := exporter.CreateSettings{ID: n.componentID, TelemetrySettings: tel, BuildInfo: info}
 	set.TelemetrySettings.Logger = components.ExporterLogger(set.TelemetrySettings.Logger, n.componentID, n.pipelineType)
+	if f := builder.Factory(n.componentID.Type()); f != nil {
+		n.mutatesData = f.Capabilities().MutatesData
+	}
 	var err error
 	switch n.pipelineType {
 	case component.DataTypeTraces:
@@ -191,6 +231,8 @@ func (n *exporterNode) buildComponent(
 		n.Component, err = builder.CreateMetrics(ctx, set)
 	case component.DataTypeLogs:
 		n.Component, err = builder.CreateLogs(ctx, set)
+	case component.DataTypeProfiles:
+		n.Component, err = builder.CreateProfiles(ctx, set)
 	default:
 		return fmt.Errorf("error creating exporter %q for data type %q is not supported", set.ID, n.pipelineType)
 	}
@@ -231,7 +273,7 @@ func (n *connectorNode) buildComponent(
 	tel component.TelemetrySettings,
 	info component.BuildInfo,
 	builder *connector.Builder,
-	nexts []baseConsumer,
+	nexts map[component.ID]baseConsumer,
 ) error {
 	set := connector.CreateSettings{ID: n.componentID, TelemetrySettings: tel, BuildInfo: info}
 	set.TelemetrySettings.Logger = components.ConnectorLogger(set.TelemetrySettings.Logger, n.componentID, n.exprPipelineType, n.rcvrPipelineType)
@@ -240,8 +282,8 @@ func (n *connectorNode) buildComponent(
 	case component.DataTypeTraces:
 		capability := consumer.Capabilities{MutatesData: false}
 		consumers := make(map[component.ID]consumer.Traces, len(nexts))
-		for _, next := range nexts {
-			consumers[next.(*capabilitiesNode).pipelineID] = next.(consumer.Traces)
+		for pipelineID, next := range nexts {
+			consumers[pipelineID] = next.(consumer.Traces)
 			capability.MutatesData = capability.MutatesData || next.Capabilities().MutatesData
 		}
 		next := fanoutconsumer.NewTracesRouter(consumers)
@@ -273,8 +315,8 @@ func (n *connectorNode) buildComponent(
 	case component.DataTypeMetrics:
 		capability := consumer.Capabilities{MutatesData: false}
 		consumers := make(map[component.ID]consumer.Metrics, len(nexts))
-		for _, next := range nexts {
-			consumers[next.(*capabilitiesNode).pipelineID] = next.(consumer.Metrics)
+		for pipelineID, next := range nexts {
+			consumers[pipelineID] = next.(consumer.Metrics)
 			capability.MutatesData = capability.MutatesData || next.Capabilities().MutatesData
 		}
 		next := fanoutconsumer.NewMetricsRouter(consumers)
@@ -305,8 +347,8 @@ func (n *connectorNode) buildComponent(
 	case component.DataTypeLogs:
 		capability := consumer.Capabilities{MutatesData: false}
 		consumers := make(map[component.ID]consumer.Logs, len(nexts))
-		for _, next := range nexts {
-			consumers[next.(*capabilitiesNode).pipelineID] = next.(consumer.Logs)
+		for pipelineID, next := range nexts {
+			consumers[pipelineID] = next.(consumer.Logs)
 			capability.MutatesData = capability.MutatesData || next.Capabilities().MutatesData
 		}
 		next := fanoutconsumer.NewLogsRouter(consumers)
@@ -335,6 +377,12 @@ func (n *connectorNode) buildComponent(
 			n.baseConsumer = capabilityconsumer.NewLogs(conn, capability)
 		}
 	}
+	if n.Component == nil {
+		return fmt.Errorf("connector %q cannot connect from %s to %s: %w", n.componentID, n.exprPipelineType, n.rcvrPipelineType, component.ErrDataTypeIsNotSupported)
+	}
+	if f := builder.Factory(n.componentID.Type()); f != nil {
+		n.baseConsumer = withMutatesData(n.exprPipelineType, n.baseConsumer, f.Capabilities().MutatesData)
+	}
 	return nil
 }
 
@@ -352,6 +400,7 @@ type capabilitiesNode struct {
 	consumer.ConsumeTracesFunc
 	consumer.ConsumeMetricsFunc
 	consumer.ConsumeLogsFunc
+	consumer.ConsumeProfilesFunc
 }
 
 func newCapabilitiesNode(pipelineID component.ID) *capabilitiesNode {
@@ -385,3 +434,15 @@ func newFanOutNode(pipelineID component.ID) *fanOutNode {
 func (n *fanOutNode) getConsumer() baseConsumer {
 	return n.baseConsumer
 }
+
+// fanOutOptions returns the options of the fan-out to the exporters of a pipeline configured with cfg.
+func fanOutOptions(cfg *pipelines.FanOutConfig) []fanoutconsumer.Option {
+	if cfg == nil || !cfg.Parallel {
+		return nil
+	}
+	options := []fanoutconsumer.Option{fanoutconsumer.WithParallel(), fanoutconsumer.WithTimeout(cfg.Timeout)}
+	if cfg.ErrorPolicy == pipelines.ErrorPolicyFailFast {
+		options = append(options, fanoutconsumer.WithErrorPolicy(fanoutconsumer.FailFast))
+	}
+	return options
+}
@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+
+	"go.uber.org/multierr"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/topo"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/panicisolation"
+)
+
+// Reload rebuilds the graph for the given settings while it is running.
+//
+// Receivers, processors and exporters are kept running when their configuration and their
+// place in the pipelines did not change, so that their listeners and persistent queues are
+// preserved. Connectors are always rebuilt. The new components are started before the data is
+// switched to them, then the components which are no longer needed are shut down.
+//
+// If the new components cannot be built or started, the graph is left unchanged.
+func (g *Graph) Reload(ctx context.Context, set Settings, host component.Host) error {
+	newG := newGraph(set)
+	newG.inheritEdgeCounters(g)
+	reused := g.reuseComponents(newG, set)
+	// The kept components report their statuses with the hosts they were started with.
+	newG.hosts = make(map[int64]component.Host, len(reused))
+	for id := range reused {
+		newG.hosts[id] = g.hosts[id]
+		if iso, ok := g.isolated[id]; ok && newG.settings.PanicGuard != nil {
+			if newG.isolated == nil {
+				newG.isolated = make(map[int64]*panicisolation.Component)
+			}
+			newG.isolated[id] = iso
+		}
+	}
+	if err := newG.buildComponents(ctx, set); err != nil {
+		return err
+	}
+	nodes, err := topo.Sort(newG.componentGraph)
+	if err != nil {
+		return err
+	}
+
+	// Start the new components, except for the receivers, downstream first so that
+	// they are ready to consume when the data is switched to them.
+	var started []graph.Node
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if _, ok := nodes[i].(*receiverNode); ok || reused[nodes[i].ID()] {
+			continue
+		}
+		if _, ok := nodes[i].(component.Component); !ok {
+			// Skip capabilities/fanout nodes
+			continue
+		}
+		if compErr := newG.startComponent(ctx, host, nodes[i]); compErr != nil {
+			for j := len(started) - 1; j >= 0; j-- {
+				compErr = multierr.Append(compErr, newG.shutdownComponent(ctx, started[j]))
+			}
+			return compErr
+		}
+		started = append(started, nodes[i])
+	}
+
+	for _, retarget := range newG.retargets {
+		retarget()
+	}
+	newG.retargets = nil
+
+	// Stop the components which were not kept, upstream first, so that they drain
+	// to their consumers. This releases the listeners of the changed receivers.
+	g.stopIsolation(func(id int64) bool { return !reused[id] })
+	var errs error
+	oldNodes, err := topo.Sort(g.componentGraph)
+	if err != nil {
+		return err
+	}
+	for _, node := range oldNodes {
+		if _, ok := node.(component.Component); !ok || reused[node.ID()] {
+			continue
+		}
+		errs = multierr.Append(errs, g.shutdownComponent(ctx, node))
+	}
+
+	*g = *newG
+
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if n, ok := nodes[i].(*receiverNode); ok && !reused[n.ID()] {
+			if compErr := g.startComponent(ctx, host, n); compErr != nil {
+				return multierr.Append(errs, compErr)
+			}
+		}
+	}
+	return errs
+}
+
+// reuseComponents moves to newG the running components which can be kept, and returns the IDs of their nodes.
+func (g *Graph) reuseComponents(newG *Graph, set Settings) map[int64]bool {
+	reused := make(map[int64]bool)
+
+	for nodes := newG.componentGraph.Nodes(); nodes.Next(); {
+		n, ok := nodes.Node().(*processorNode)
+		if !ok {
+			continue
+		}
+		old, ok := g.componentGraph.Node(n.ID()).(*processorNode)
+		if !ok || !reflect.DeepEqual(g.settings.ProcessorBuilder.Config(n.componentID), set.ProcessorBuilder.Config(n.componentID)) {
+			continue
+		}
+		n.Component, n.next = old.Component, old.next
+		reused[n.ID()] = true
+	}
+
+	// Receivers and exporters may share a single instance across data types,
+	// so either all the nodes of a component are kept or none of them.
+	oldReceivers, oldExporters := g.componentNodes()
+	newReceivers, newExporters := newG.componentNodes()
+	for id, nodes := range newReceivers {
+		if !sameNodes(nodes, oldReceivers[id]) || !reflect.DeepEqual(g.settings.ReceiverBuilder.Config(id), set.ReceiverBuilder.Config(id)) {
+			continue
+		}
+		for nodeID, node := range nodes {
+			n, old := node.(*receiverNode), oldReceivers[id][nodeID].(*receiverNode)
+			n.Component, n.next = old.Component, old.next
+			reused[nodeID] = true
+		}
+	}
+	for id, nodes := range newExporters {
+		if !sameNodes(nodes, oldExporters[id]) || !reflect.DeepEqual(g.settings.ExporterBuilder.Config(id), set.ExporterBuilder.Config(id)) {
+			continue
+		}
+		for nodeID, node := range nodes {
+			node.(*exporterNode).Component = oldExporters[id][nodeID].(*exporterNode).Component
+			reused[nodeID] = true
+		}
+	}
+	return reused
+}
+
+// componentNodes groups the receiver and exporter nodes by component ID.
+func (g *Graph) componentNodes() (receivers, exporters map[component.ID]map[int64]graph.Node) {
+	receivers = make(map[component.ID]map[int64]graph.Node)
+	exporters = make(map[component.ID]map[int64]graph.Node)
+	for nodes := g.componentGraph.Nodes(); nodes.Next(); {
+		switch n := nodes.Node().(type) {
+		case *receiverNode:
+			if receivers[n.componentID] == nil {
+				receivers[n.componentID] = make(map[int64]graph.Node)
+			}
+			receivers[n.componentID][n.ID()] = n
+		case *exporterNode:
+			if exporters[n.componentID] == nil {
+				exporters[n.componentID] = make(map[int64]graph.Node)
+			}
+			exporters[n.componentID][n.ID()] = n
+		}
+	}
+	return receivers, exporters
+}
+
+func sameNodes(a, b map[int64]graph.Node) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for id := range a {
+		if _, ok := b[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// retarget records that the switch of a kept component must be pointed to next
+// once the rebuilt components are started.
+func (g *Graph) retarget(s *switchConsumer, next baseConsumer) {
+	g.retargets = append(g.retargets, func() { s.set(next) })
+}
+
+// switchConsumer forwards the data to a consumer which can be replaced while the pipelines are running.
+type switchConsumer struct {
+	next atomic.Pointer[consumerRef]
+}
+
+type consumerRef struct {
+	baseConsumer
+}
+
+var (
+	_ consumer.Traces   = (*switchConsumer)(nil)
+	_ consumer.Metrics  = (*switchConsumer)(nil)
+	_ consumer.Logs     = (*switchConsumer)(nil)
+	_ consumer.Profiles = (*switchConsumer)(nil)
+)
+
+func newSwitchConsumer(next baseConsumer) *switchConsumer {
+	s := &switchConsumer{}
+	s.set(next)
+	return s
+}
+
+func (s *switchConsumer) set(next baseConsumer) {
+	s.next.Store(&consumerRef{baseConsumer: next})
+}
+
+func (s *switchConsumer) Capabilities() consumer.Capabilities {
+	return s.next.Load().Capabilities()
+}
+
+func (s *switchConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return s.next.Load().baseConsumer.(consumer.Traces).ConsumeTraces(ctx, td)
+}
+
+func (s *switchConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return s.next.Load().baseConsumer.(consumer.Metrics).ConsumeMetrics(ctx, md)
+}
+
+func (s *switchConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return s.next.Load().baseConsumer.(consumer.Logs).ConsumeLogs(ctx, ld)
+}
+
+func (s *switchConsumer) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	return s.next.Load().baseConsumer.(consumer.Profiles).ConsumeProfiles(ctx, pd)
+}
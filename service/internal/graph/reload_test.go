@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// reloadTestConfig is not empty so that each configuration gets its own example receiver.
+type reloadTestConfig struct {
+	Value string
+}
+
+func reloadTestSettings(receivers, processors, exporters map[component.ID]component.Config, pipelineCfgs pipelines.Config) Settings {
+	errExporterFactory := newErrExporterFactory()
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(receivers, map[component.Type]receiver.Factory{
+			testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+		}),
+		ProcessorBuilder: processor.NewBuilder(processors, map[component.Type]processor.Factory{
+			testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory,
+		}),
+		ExporterBuilder: exporter.NewBuilder(exporters, map[component.Type]exporter.Factory{
+			testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			errExporterFactory.Type():                    errExporterFactory,
+		}),
+		ConnectorBuilder: connector.NewBuilder(nil, nil),
+		PipelineConfigs:  pipelineCfgs,
+	}
+}
+
+func TestGraphReload(t *testing.T) {
+	tracesID := component.NewID("traces")
+	set := reloadTestSettings(
+		map[component.ID]component.Config{
+			component.NewID("examplereceiver"):              &reloadTestConfig{Value: "0"},
+			component.NewIDWithName("examplereceiver", "1"): &reloadTestConfig{Value: "1"},
+		},
+		map[component.ID]component.Config{
+			component.NewID("exampleprocessor"): testcomponents.ExampleProcessorFactory.CreateDefaultConfig(),
+		},
+		map[component.ID]component.Config{
+			component.NewID("exampleexporter"):              testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			component.NewIDWithName("exampleexporter", "1"): testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+		},
+		pipelines.Config{
+			tracesID: {
+				Receivers:  []component.ID{component.NewID("examplereceiver"), component.NewIDWithName("examplereceiver", "1")},
+				Processors: []component.ID{component.NewID("exampleprocessor")},
+				Exporters:  []component.ID{component.NewID("exampleexporter"), component.NewIDWithName("exampleexporter", "1")},
+			},
+		},
+	)
+	g, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, g.StartAll(context.Background(), host))
+
+	oldReceivers := g.getReceivers()[component.DataTypeTraces]
+	oldExporters := g.GetExporters()[component.DataTypeTraces]
+	oldProcessor := g.pipelines[tracesID].processors[0].Component
+
+	// Change the configuration of examplereceiver/1, replace exampleexporter/1 with exampleexporter/2.
+	newSet := reloadTestSettings(
+		map[component.ID]component.Config{
+			component.NewID("examplereceiver"):              &reloadTestConfig{Value: "0"},
+			component.NewIDWithName("examplereceiver", "1"): &reloadTestConfig{Value: "changed"},
+		},
+		map[component.ID]component.Config{
+			component.NewID("exampleprocessor"): testcomponents.ExampleProcessorFactory.CreateDefaultConfig(),
+		},
+		map[component.ID]component.Config{
+			component.NewID("exampleexporter"):              testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			component.NewIDWithName("exampleexporter", "2"): testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+		},
+		pipelines.Config{
+			tracesID: {
+				Receivers:  []component.ID{component.NewID("examplereceiver"), component.NewIDWithName("examplereceiver", "1")},
+				Processors: []component.ID{component.NewID("exampleprocessor")},
+				Exporters:  []component.ID{component.NewID("exampleexporter"), component.NewIDWithName("exampleexporter", "2")},
+			},
+		},
+	)
+	require.NoError(t, g.Reload(context.Background(), newSet, host))
+
+	newReceivers := g.getReceivers()[component.DataTypeTraces]
+	newExporters := g.GetExporters()[component.DataTypeTraces]
+
+	// Unchanged components are kept running.
+	kept := newReceivers[component.NewID("examplereceiver")].(*testcomponents.ExampleReceiver)
+	assert.Same(t, oldReceivers[component.NewID("examplereceiver")], kept)
+	assert.False(t, kept.Stopped())
+	assert.Same(t, oldProcessor, g.pipelines[tracesID].processors[0].Component)
+	assert.False(t, oldProcessor.(*testcomponents.ExampleProcessor).Stopped())
+	keptExporter := newExporters[component.NewID("exampleexporter")].(*testcomponents.ExampleExporter)
+	assert.Same(t, oldExporters[component.NewID("exampleexporter")], keptExporter)
+	assert.False(t, keptExporter.Stopped())
+
+	// Changed and removed components are stopped, changed and added components are started.
+	assert.True(t, oldReceivers[component.NewIDWithName("examplereceiver", "1")].(*testcomponents.ExampleReceiver).Stopped())
+	changed := newReceivers[component.NewIDWithName("examplereceiver", "1")].(*testcomponents.ExampleReceiver)
+	assert.NotSame(t, oldReceivers[component.NewIDWithName("examplereceiver", "1")], changed)
+	assert.True(t, changed.Started())
+	assert.True(t, oldExporters[component.NewIDWithName("exampleexporter", "1")].(*testcomponents.ExampleExporter).Stopped())
+	added := newExporters[component.NewIDWithName("exampleexporter", "2")].(*testcomponents.ExampleExporter)
+	assert.True(t, added.Started())
+
+	// The kept receiver feeds the rebuilt pipeline.
+	require.NoError(t, kept.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, keptExporter.Traces, 1)
+	assert.Len(t, added.Traces, 1)
+	assert.Empty(t, oldExporters[component.NewIDWithName("exampleexporter", "1")].(*testcomponents.ExampleExporter).Traces)
+
+	// Only the stopped and started components report their statuses.
+	started := []component.Status{component.StatusStarting, component.StatusOK}
+	lifecycle := []component.Status{component.StatusStarting, component.StatusOK, component.StatusStopping, component.StatusStopped}
+	assert.Equal(t, started, host.statuses(component.KindReceiver, component.NewID("examplereceiver")))
+	assert.Equal(t, append(lifecycle, started...), host.statuses(component.KindReceiver, component.NewIDWithName("examplereceiver", "1")))
+	assert.Equal(t, lifecycle, host.statuses(component.KindExporter, component.NewIDWithName("exampleexporter", "1")))
+	assert.Equal(t, started, host.statuses(component.KindExporter, component.NewIDWithName("exampleexporter", "2")))
+
+	assert.NoError(t, g.ShutdownAll(context.Background()))
+	assert.True(t, kept.Stopped())
+	assert.True(t, keptExporter.Stopped())
+	// The kept components report their statuses with the host they were started with.
+	assert.Equal(t, lifecycle, host.statuses(component.KindReceiver, component.NewID("examplereceiver")))
+}
+
+func TestGraphReloadFailureKeepsGraph(t *testing.T) {
+	tracesID := component.NewID("traces")
+	receivers := map[component.ID]component.Config{
+		component.NewID("examplereceiver"): &reloadTestConfig{Value: "failure"},
+	}
+	processors := map[component.ID]component.Config{}
+	exporters := map[component.ID]component.Config{
+		component.NewID("exampleexporter"): testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+		component.NewID("err"):             newErrExporterFactory().CreateDefaultConfig(),
+	}
+	set := reloadTestSettings(receivers, processors, exporters, pipelines.Config{
+		tracesID: {
+			Receivers: []component.ID{component.NewID("examplereceiver")},
+			Exporters: []component.ID{component.NewID("exampleexporter")},
+		},
+	})
+	g, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, g.StartAll(context.Background(), componenttest.NewNopHost()))
+
+	newSet := reloadTestSettings(receivers, processors, exporters, pipelines.Config{
+		tracesID: {
+			Receivers: []component.ID{component.NewID("examplereceiver")},
+			Exporters: []component.ID{component.NewID("exampleexporter"), component.NewID("err")},
+		},
+	})
+	assert.Error(t, g.Reload(context.Background(), newSet, componenttest.NewNopHost()))
+
+	rcvr := g.getReceivers()[component.DataTypeTraces][component.NewID("examplereceiver")].(*testcomponents.ExampleReceiver)
+	exp := g.GetExporters()[component.DataTypeTraces][component.NewID("exampleexporter")].(*testcomponents.ExampleExporter)
+	assert.False(t, rcvr.Stopped())
+	assert.False(t, exp.Stopped())
+	assert.Len(t, g.GetExporters()[component.DataTypeTraces], 1)
+
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, exp.Traces, 1)
+
+	assert.NoError(t, g.ShutdownAll(context.Background()))
+}
@@ -0,0 +1,252 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// FormatDOT renders the graph in the Graphviz DOT language.
+	FormatDOT = "dot"
+	// FormatMermaid renders the graph as a Mermaid flowchart.
+	FormatMermaid = "mermaid"
+)
+
+// Topology creates the graph of the pipelines of set without building their components.
+// Such a graph can only be rendered.
+func Topology(set Settings) *Graph {
+	return newGraph(set)
+}
+
+type renderNode struct {
+	id       string
+	label    string
+	kind     string
+	pipeline string // Empty for the nodes shared between pipelines.
+}
+
+type renderEdge struct {
+	from, to *renderNode
+	label    string
+}
+
+// Render writes the graph in the given format, FormatDOT or FormatMermaid. When the components
+// have been built, the edges are labeled with the amount of data sent along them.
+func (g *Graph) Render(w io.Writer, format string) error {
+	var write func(*bufio.Writer, []*renderNode, []renderEdge)
+	switch format {
+	case FormatDOT:
+		write = writeDOT
+	case FormatMermaid:
+		write = writeMermaid
+	default:
+		return fmt.Errorf("unknown graph format %q, supported formats are %q and %q", format, FormatDOT, FormatMermaid)
+	}
+
+	nodes := make(map[int64]*renderNode)
+	for it := g.componentGraph.Nodes(); it.Next(); {
+		n := newRenderNode(it.Node())
+		nodes[it.Node().ID()] = n
+	}
+	sortedNodes := make([]*renderNode, 0, len(nodes))
+	for _, n := range nodes {
+		sortedNodes = append(sortedNodes, n)
+	}
+	sort.Slice(sortedNodes, func(i, j int) bool {
+		return nodeLess(sortedNodes[i], sortedNodes[j])
+	})
+
+	var edges []renderEdge
+	for it := g.componentGraph.Edges(); it.Next(); {
+		e := it.Edge()
+		edges = append(edges, renderEdge{
+			from:  nodes[e.From().ID()],
+			to:    nodes[e.To().ID()],
+			label: g.edgeLabel(e.From(), e.To()),
+		})
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].from != edges[j].from {
+			return nodeLess(edges[i].from, edges[j].from)
+		}
+		return nodeLess(edges[i].to, edges[j].to)
+	})
+
+	bw := bufio.NewWriter(w)
+	write(bw, sortedNodes, edges)
+	return bw.Flush()
+}
+
+func newRenderNode(node graph.Node) *renderNode {
+	n := &renderNode{id: "n" + strconv.FormatUint(uint64(node.ID()), 16)}
+	switch cn := node.(type) {
+	case *receiverNode:
+		n.kind = "receiver"
+		n.label = fmt.Sprintf("receiver %s [%s]", cn.componentID, cn.pipelineType)
+	case *processorNode:
+		n.kind = "processor"
+		n.label = "processor " + cn.componentID.String()
+		n.pipeline = cn.pipelineID.String()
+	case *exporterNode:
+		n.kind = "exporter"
+		n.label = fmt.Sprintf("exporter %s [%s]", cn.componentID, cn.pipelineType)
+	case *connectorNode:
+		n.kind = "connector"
+		n.label = fmt.Sprintf("connector %s [%s to %s]", cn.componentID, cn.exprPipelineType, cn.rcvrPipelineType)
+	case *capabilitiesNode:
+		n.kind = "pipeline"
+		n.label = cn.pipelineID.String()
+		n.pipeline = cn.pipelineID.String()
+	case *fanOutNode:
+		n.kind = "fanout"
+		n.label = "fanout"
+		n.pipeline = cn.pipelineID.String()
+	}
+	return n
+}
+
+var kindOrder = map[string]int{
+	"receiver":  0,
+	"pipeline":  1,
+	"processor": 2,
+	"fanout":    3,
+	"connector": 4,
+	"exporter":  5,
+}
+
+func nodeLess(a, b *renderNode) bool {
+	if a.pipeline != b.pipeline {
+		return a.pipeline < b.pipeline
+	}
+	if kindOrder[a.kind] != kindOrder[b.kind] {
+		return kindOrder[a.kind] < kindOrder[b.kind]
+	}
+	return a.label < b.label
+}
+
+// edgeLabel describes the data sent along the edge, if the components have been built.
+func (g *Graph) edgeLabel(from, to graph.Node) string {
+	counter, ok := g.edges[edgeKey{from: from.ID(), to: to.ID()}]
+	if !ok {
+		return ""
+	}
+	label := fmt.Sprintf("%d %s in %d batches", counter.items.Load(), itemsName(edgeDataType(to)), counter.batches.Load())
+	if failed := counter.failed.Load(); failed > 0 {
+		label += fmt.Sprintf(", %d failed", failed)
+	}
+	return label
+}
+
+func itemsName(dataType component.DataType) string {
+	switch dataType {
+	case component.DataTypeTraces:
+		return "spans"
+	case component.DataTypeMetrics:
+		return "data points"
+	case component.DataTypeLogs:
+		return "log records"
+	case component.DataTypeProfiles:
+		return "samples"
+	}
+	return "items"
+}
+
+// pipelineGroups returns the names of the pipelines in order, with the nodes which are part of them.
+func pipelineGroups(nodes []*renderNode) ([]string, map[string][]*renderNode) {
+	var names []string
+	groups := make(map[string][]*renderNode)
+	for _, n := range nodes {
+		if _, ok := groups[n.pipeline]; !ok {
+			names = append(names, n.pipeline)
+		}
+		groups[n.pipeline] = append(groups[n.pipeline], n)
+	}
+	return names, groups
+}
+
+var dotShapes = map[string]string{
+	"receiver":  "invhouse",
+	"pipeline":  "cds",
+	"processor": "box",
+	"fanout":    "point",
+	"connector": "diamond",
+	"exporter":  "house",
+}
+
+func writeDOT(w *bufio.Writer, nodes []*renderNode, edges []renderEdge) {
+	w.WriteString("digraph pipelines {\n")
+	w.WriteString("  rankdir=LR;\n")
+	names, groups := pipelineGroups(nodes)
+	for i, name := range names {
+		indent := "  "
+		if name != "" {
+			fmt.Fprintf(w, "  subgraph cluster_%d {\n    label=%s;\n", i, strconv.Quote("pipeline "+name))
+			indent = "    "
+		}
+		for _, n := range groups[name] {
+			fmt.Fprintf(w, "%s%s [label=%s, shape=%s];\n", indent, n.id, strconv.Quote(n.label), dotShapes[n.kind])
+		}
+		if name != "" {
+			w.WriteString("  }\n")
+		}
+	}
+	for _, e := range edges {
+		if e.label == "" {
+			fmt.Fprintf(w, "  %s -> %s;\n", e.from.id, e.to.id)
+			continue
+		}
+		fmt.Fprintf(w, "  %s -> %s [label=%s];\n", e.from.id, e.to.id, strconv.Quote(e.label))
+	}
+	w.WriteString("}\n")
+}
+
+var mermaidShapes = map[string][2]string{
+	"receiver":  {"([", "])"},
+	"pipeline":  {">", "]"},
+	"processor": {"[", "]"},
+	"fanout":    {"((", "))"},
+	"connector": {"{", "}"},
+	"exporter":  {"([", "])"},
+}
+
+func writeMermaid(w *bufio.Writer, nodes []*renderNode, edges []renderEdge) {
+	w.WriteString("flowchart LR\n")
+	names, groups := pipelineGroups(nodes)
+	for i, name := range names {
+		indent := "  "
+		if name != "" {
+			fmt.Fprintf(w, "  subgraph p%d[%s]\n", i, mermaidText("pipeline "+name))
+			indent = "    "
+		}
+		for _, n := range groups[name] {
+			shape := mermaidShapes[n.kind]
+			fmt.Fprintf(w, "%s%s%s%s%s\n", indent, n.id, shape[0], mermaidText(n.label), shape[1])
+		}
+		if name != "" {
+			w.WriteString("  end\n")
+		}
+	}
+	for _, e := range edges {
+		if e.label == "" {
+			fmt.Fprintf(w, "  %s --> %s\n", e.from.id, e.to.id)
+			continue
+		}
+		fmt.Fprintf(w, "  %s -->|%s| %s\n", e.from.id, mermaidText(e.label), e.to.id)
+	}
+}
+
+// mermaidText quotes a label, Mermaid does not support escaping quotes with a backslash.
+func mermaidText(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
@@ -0,0 +1,334 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/otlpfile"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/faultinjection"
+	"go.opentelemetry.io/collector/service/internal/flowmetrics"
+	"go.opentelemetry.io/collector/service/internal/tap"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+	"go.opentelemetry.io/collector/service/recording"
+)
+
+func renderTestSettings() Settings {
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(
+			map[component.ID]component.Config{
+				component.NewID("examplereceiver"): testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			}),
+		ProcessorBuilder: processor.NewBuilder(
+			map[component.ID]component.Config{
+				component.NewID("exampleprocessor"): testcomponents.ExampleProcessorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]processor.Factory{
+				testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory,
+			}),
+		ExporterBuilder: exporter.NewBuilder(
+			map[component.ID]component.Config{
+				component.NewID("exampleexporter"): testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			}),
+		ConnectorBuilder: connector.NewBuilder(
+			map[component.ID]component.Config{
+				component.NewID("exampleconnector"): testcomponents.ExampleConnectorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]connector.Factory{
+				testcomponents.ExampleConnectorFactory.Type(): testcomponents.ExampleConnectorFactory,
+			}),
+		PipelineConfigs: pipelines.Config{
+			component.NewIDWithName("traces", "in"): {
+				Receivers:  []component.ID{component.NewID("examplereceiver")},
+				Processors: []component.ID{component.NewID("exampleprocessor")},
+				Exporters:  []component.ID{component.NewID("exampleconnector")},
+			},
+			component.NewIDWithName("traces", "out"): {
+				Receivers: []component.ID{component.NewID("exampleconnector")},
+				Exporters: []component.ID{component.NewID("exampleexporter")},
+			},
+		},
+	}
+}
+
+func TestRenderTopology(t *testing.T) {
+	g := Topology(renderTestSettings())
+
+	dot := &strings.Builder{}
+	require.NoError(t, g.Render(dot, FormatDOT))
+	assert.True(t, strings.HasPrefix(dot.String(), "digraph pipelines {\n"))
+	assert.True(t, strings.HasSuffix(dot.String(), "}\n"))
+	for _, expected := range []string{
+		`label="pipeline traces/in";`,
+		`label="pipeline traces/out";`,
+		`[label="receiver examplereceiver [traces]", shape=invhouse];`,
+		`[label="processor exampleprocessor", shape=box];`,
+		`[label="connector exampleconnector [traces to traces]", shape=diamond];`,
+		`[label="exporter exampleexporter [traces]", shape=house];`,
+	} {
+		assert.Contains(t, dot.String(), expected)
+	}
+	// Only the built components have counters.
+	assert.NotContains(t, dot.String(), "spans")
+	assert.Equal(t, g.componentGraph.Edges().Len(), strings.Count(dot.String(), " -> "))
+
+	mermaid := &strings.Builder{}
+	require.NoError(t, g.Render(mermaid, FormatMermaid))
+	assert.True(t, strings.HasPrefix(mermaid.String(), "flowchart LR\n"))
+	for _, expected := range []string{
+		`["pipeline traces/in"]`,
+		`(["receiver examplereceiver [traces]"])`,
+		`["processor exampleprocessor"]`,
+		`{"connector exampleconnector [traces to traces]"}`,
+	} {
+		assert.Contains(t, mermaid.String(), expected)
+	}
+	assert.Equal(t, g.componentGraph.Edges().Len(), strings.Count(mermaid.String(), " --> "))
+
+	// The output is deterministic.
+	again := &strings.Builder{}
+	require.NoError(t, Topology(renderTestSettings()).Render(again, FormatDOT))
+	assert.Equal(t, dot.String(), again.String())
+
+	assert.EqualError(t, g.Render(&strings.Builder{}, "svg"), `unknown graph format "svg", supported formats are "dot" and "mermaid"`)
+}
+
+func TestRenderCounters(t *testing.T) {
+	g, err := Build(context.Background(), renderTestSettings())
+	require.NoError(t, err)
+	require.NoError(t, g.StartAll(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, g.ShutdownAll(context.Background())) }()
+
+	for _, c := range g.getReceivers()[component.DataTypeTraces] {
+		require.NoError(t, c.(*testcomponents.ExampleReceiver).ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	}
+
+	dot := &strings.Builder{}
+	require.NoError(t, g.Render(dot, FormatDOT))
+	assert.Equal(t, len(g.edges), strings.Count(dot.String(), `[label="2 spans in 1 batches"]`))
+
+	mermaid := &strings.Builder{}
+	require.NoError(t, g.Render(mermaid, FormatMermaid))
+	assert.Equal(t, len(g.edges), strings.Count(mermaid.String(), ` -->|"2 spans in 1 batches"| `))
+}
+
+func TestHandleZPagesGraph(t *testing.T) {
+	g := Topology(renderTestSettings())
+
+	rr := httptest.NewRecorder()
+	g.HandleZPages(rr, httptest.NewRequest(http.MethodGet, "/debug/pipelinez?format=mermaid", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(rr.Body.String(), "flowchart LR\n"))
+
+	rr = httptest.NewRecorder()
+	g.HandleZPages(rr, httptest.NewRequest(http.MethodGet, "/debug/pipelinez?format=svg", nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestGraphTaps(t *testing.T) {
+	set := renderTestSettings()
+	set.Taps = tap.NewRegistry()
+	g, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, g.StartAll(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, g.ShutdownAll(context.Background())) }()
+
+	all := tap.Settings{SamplingPercentage: 100, RateLimit: 10}
+	in, out := component.NewIDWithName("traces", "in"), component.NewIDWithName("traces", "out")
+	var taps []*tap.Tap
+	for _, point := range []struct{ pipelineID, componentID component.ID }{
+		{pipelineID: in},
+		{pipelineID: in, componentID: component.NewID("exampleprocessor")},
+		{pipelineID: in, componentID: component.NewID("exampleconnector")},
+		{pipelineID: out},
+		{pipelineID: out, componentID: component.NewID("exampleexporter")},
+	} {
+		tp, attachErr := set.Taps.Attach(point.pipelineID, point.componentID, all)
+		require.NoError(t, attachErr)
+		t.Cleanup(func() { set.Taps.Detach(tp) })
+		taps = append(taps, tp)
+	}
+	_, err = set.Taps.Attach(in, component.NewID("exampleexporter"), all)
+	assert.ErrorIs(t, err, tap.ErrUnknownPoint)
+
+	for _, c := range g.getReceivers()[component.DataTypeTraces] {
+		require.NoError(t, c.(*testcomponents.ExampleReceiver).ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	}
+	for _, tp := range taps {
+		require.Len(t, tp.Data(), 1)
+		td, unmarshalErr := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(<-tp.Data())
+		require.NoError(t, unmarshalErr)
+		assert.Equal(t, 2, td.SpanCount())
+	}
+}
+
+func TestGraphFlowMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	flowMetrics, err := flowmetrics.New(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	require.NoError(t, err)
+	set := renderTestSettings()
+	set.FlowMetrics = flowMetrics
+	g, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, g.StartAll(context.Background(), componenttest.NewNopHost()))
+	for _, c := range g.getReceivers()[component.DataTypeTraces] {
+		require.NoError(t, c.(*testcomponents.ExampleReceiver).ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	}
+	require.NoError(t, g.ShutdownAll(context.Background()))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	items := map[string]int64{}
+	fanOuts := map[string]uint64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			for _, dp := range data.DataPoints {
+				items[m.Name+"{"+dp.Attributes.Encoded(attribute.DefaultEncoder())+"}"] = dp.Value
+			}
+		case metricdata.Histogram[float64]:
+			for _, dp := range data.DataPoints {
+				fanOuts[dp.Attributes.Encoded(attribute.DefaultEncoder())] = dp.Count
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"pipeline_items_in{component=examplereceiver,outcome=accepted,pipeline=traces/in}":         2,
+		"pipeline_items_processed{component=exampleprocessor,outcome=accepted,pipeline=traces/in}": 2,
+		"pipeline_items_out{component=exampleconnector,outcome=accepted,pipeline=traces/in}":       2,
+		"pipeline_items_in{component=exampleconnector,outcome=accepted,pipeline=traces/out}":       2,
+		"pipeline_items_out{component=exampleexporter,outcome=accepted,pipeline=traces/out}":       2,
+		"pipeline_items_in_flight{pipeline=traces/in}":                                             0,
+		"pipeline_items_in_flight{pipeline=traces/out}":                                            0,
+	}, items)
+	assert.Equal(t, map[string]uint64{"pipeline=traces/in": 1, "pipeline=traces/out": 1}, fanOuts)
+}
+
+func TestGraphErrorPropagation(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		errorPropagation string
+		wantErr          bool
+		wantAbsorbed     int64
+		wantLogged       int
+	}{
+		{name: "default", wantErr: true},
+		{name: "fail_upstream", errorPropagation: pipelines.ErrorPropagationFailUpstream, wantErr: true},
+		{name: "best_effort", errorPropagation: pipelines.ErrorPropagationBestEffort, wantAbsorbed: 4, wantLogged: 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			flowMetrics, err := flowmetrics.New(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+			require.NoError(t, err)
+			set := renderTestSettings()
+			set.FlowMetrics = flowMetrics
+			core, logs := observer.New(zap.WarnLevel)
+			set.Telemetry.Logger = zap.New(core)
+			set.FaultInjector = faultinjection.NewInjector(faultinjection.Config{{
+				Pipeline:        component.NewIDWithName("traces", "out"),
+				Component:       component.NewID("exampleexporter"),
+				ErrorPercentage: 100,
+			}})
+			set.PipelineConfigs[component.NewIDWithName("traces", "out")].ErrorPropagation = tt.errorPropagation
+			g, err := Build(context.Background(), set)
+			require.NoError(t, err)
+			require.NoError(t, g.StartAll(context.Background(), componenttest.NewNopHost()))
+			defer func() { assert.NoError(t, g.ShutdownAll(context.Background())) }()
+
+			// The absorbed errors are logged at most once per interval.
+			for i := 0; i < 2; i++ {
+				for _, c := range g.getReceivers()[component.DataTypeTraces] {
+					err = c.(*testcomponents.ExampleReceiver).ConsumeTraces(context.Background(), testdata.GenerateTraces(2))
+					if tt.wantErr {
+						assert.Error(t, err)
+					} else {
+						assert.NoError(t, err)
+					}
+				}
+			}
+			assert.Equal(t, tt.wantLogged, logs.FilterMessageSnippet("Absorbed").Len())
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			var absorbed int64
+			for _, m := range rm.ScopeMetrics[0].Metrics {
+				if m.Name != flowmetrics.ItemsAbsorbedMetricName {
+					continue
+				}
+				for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+					absorbed += dp.Value
+				}
+			}
+			assert.Equal(t, tt.wantAbsorbed, absorbed)
+		})
+	}
+}
+
+func TestGraphRecorder(t *testing.T) {
+	dir := t.TempDir()
+	set := renderTestSettings()
+	recorder, err := recording.NewRecorder(&recording.Config{
+		Receivers: []component.ID{component.NewID("examplereceiver")},
+		Directory: dir,
+	}, zap.NewNop())
+	require.NoError(t, err)
+	set.Recorder = recorder
+	g, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, g.StartAll(context.Background(), componenttest.NewNopHost()))
+
+	for _, c := range g.getReceivers()[component.DataTypeTraces] {
+		require.NoError(t, c.(*testcomponents.ExampleReceiver).ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	}
+	require.NoError(t, g.ShutdownAll(context.Background()))
+	require.NoError(t, recorder.Close())
+	for _, e := range g.GetExporters()[component.DataTypeTraces] {
+		assert.Len(t, e.(*testcomponents.ExampleExporter).Traces, 1)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+recording.FileExtension))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	f, err := os.Open(files[0])
+	require.NoError(t, err)
+	defer f.Close()
+	sink := new(consumertest.TracesSink)
+	replayed, err := recording.Replay(context.Background(), otlpfile.NewReader(f), 0, recording.Consumers{Traces: sink})
+	require.NoError(t, err)
+	assert.Equal(t, 1, replayed)
+	assert.Equal(t, 2, sink.SpanCount())
+}
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap"
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/internal/components"
+	"go.opentelemetry.io/collector/service/panicisolation"
+)
+
+// startComponent starts the component of the node with its own host, and reports its status
+// around the call to Start.
+func (g *Graph) startComponent(ctx context.Context, host component.Host, node graph.Node) error {
+	comp := node.(component.Component)
+	compHost := components.NewHostWrapper(host, g.instanceID(node), g.componentLogger(node))
+	if iso := g.isolation(node); iso != nil {
+		compHost = components.NewGuardedHostWrapper(host, g.instanceID(node), g.componentLogger(node), iso.Recover)
+		iso.Attach(nodeComponent(node), compHost)
+	}
+	if g.hosts == nil {
+		g.hosts = make(map[int64]component.Host)
+	}
+	g.hosts[node.ID()] = compHost
+
+	component.ReportStatus(compHost, component.NewStatusEvent(component.StatusStarting, nil))
+	if err := comp.Start(ctx, compHost); err != nil {
+		component.ReportStatus(compHost, component.NewStatusEvent(component.StatusPermanentError, err))
+		return componentError(node, err)
+	}
+	component.ReportStatus(compHost, component.NewStatusEvent(component.StatusOK, nil))
+	return nil
+}
+
+// shutdownComponent shuts down the component of the node, and reports its status around the
+// call to Shutdown. Nothing is reported for the components which were not started.
+func (g *Graph) shutdownComponent(ctx context.Context, node graph.Node) error {
+	comp := node.(component.Component)
+	compHost := g.hosts[node.ID()]
+	component.ReportStatus(compHost, component.NewStatusEvent(component.StatusStopping, nil))
+	err := comp.Shutdown(ctx)
+	if err != nil {
+		component.ReportStatus(compHost, component.NewStatusEvent(component.StatusPermanentError, err))
+	}
+	component.ReportStatus(compHost, component.NewStatusEvent(component.StatusStopped, nil))
+	return err
+}
+
+// nodeComponent returns the component built for the node, so that the interfaces it implements
+// besides component.Component can be checked.
+func nodeComponent(node graph.Node) component.Component {
+	switch n := node.(type) {
+	case *receiverNode:
+		return n.Component
+	case *processorNode:
+		return n.Component
+	case *exporterNode:
+		return n.Component
+	case *connectorNode:
+		return n.Component
+	}
+	return node.(component.Component)
+}
+
+// isolation returns the isolation of the panics of the component of the node, nil if the panics are
+// not isolated or if the node is not a component.
+func (g *Graph) isolation(node graph.Node) *panicisolation.Component {
+	if g.settings.PanicGuard == nil {
+		return nil
+	}
+	switch node.(type) {
+	case *receiverNode, *processorNode, *exporterNode, *connectorNode:
+	default:
+		return nil
+	}
+	if iso, ok := g.isolated[node.ID()]; ok {
+		return iso
+	}
+	if g.isolated == nil {
+		g.isolated = make(map[int64]*panicisolation.Component)
+	}
+	iso := g.settings.PanicGuard.Component(g.instanceID(node), g.componentLogger(node))
+	g.isolated[node.ID()] = iso
+	return iso
+}
+
+// stopIsolation stops restarting the components of the nodes selected by include after their panics.
+func (g *Graph) stopIsolation(include func(id int64) bool) {
+	for id, iso := range g.isolated {
+		if include(id) {
+			iso.Stop()
+		}
+	}
+}
+
+// instanceID returns the identifier of the component instance of the node, with the pipelines it is part of.
+func (g *Graph) instanceID(node graph.Node) *component.InstanceID {
+	id := &component.InstanceID{}
+	switch n := node.(type) {
+	case *receiverNode:
+		id.ID, id.Kind = n.componentID, component.KindReceiver
+	case *processorNode:
+		id.ID, id.Kind = n.componentID, component.KindProcessor
+		id.PipelineIDs = []component.ID{n.pipelineID}
+		return id
+	case *exporterNode:
+		id.ID, id.Kind = n.componentID, component.KindExporter
+	case *connectorNode:
+		id.ID, id.Kind = n.componentID, component.KindConnector
+	default:
+		return id
+	}
+	for pipelineID, p := range g.pipelines {
+		_, isReceiver := p.receivers[node.ID()]
+		_, isExporter := p.exporters[node.ID()]
+		if isReceiver || isExporter {
+			id.PipelineIDs = append(id.PipelineIDs, pipelineID)
+		}
+	}
+	sort.Slice(id.PipelineIDs, func(i, j int) bool {
+		return id.PipelineIDs[i].String() < id.PipelineIDs[j].String()
+	})
+	return id
+}
+
+// componentLogger returns the logger identifying the component of the node.
+func (g *Graph) componentLogger(node graph.Node) *zap.Logger {
+	logger := g.settings.Telemetry.Logger
+	if logger == nil {
+		return zap.NewNop()
+	}
+	switch n := node.(type) {
+	case *receiverNode:
+		return components.ReceiverLogger(logger, n.componentID, n.pipelineType)
+	case *processorNode:
+		return components.ProcessorLogger(logger, n.componentID, n.pipelineID)
+	case *exporterNode:
+		return components.ExporterLogger(logger, n.componentID, n.pipelineType)
+	case *connectorNode:
+		return components.ConnectorLogger(logger, n.componentID, n.exprPipelineType, n.rcvrPipelineType)
+	}
+	return logger
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+	"gonum.org/v1/gonum/graph/simple"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/panicisolation"
+)
+
+type statusEvent struct {
+	source *component.InstanceID
+	status component.Status
+	err    error
+}
+
+type statusHost struct {
+	component.Host
+	mu     sync.Mutex
+	events []statusEvent
+}
+
+func (h *statusHost) ReportComponentStatus(source *component.InstanceID, event *component.StatusEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, statusEvent{source: source, status: event.Status(), err: event.Err()})
+}
+
+// statuses returns the statuses reported by the instances of the given component.
+func (h *statusHost) statuses(kind component.Kind, id component.ID) []component.Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var statuses []component.Status
+	for _, ev := range h.events {
+		if ev.source.Kind == kind && ev.source.ID == id {
+			statuses = append(statuses, ev.status)
+		}
+	}
+	return statuses
+}
+
+func TestGraphReportsStatus(t *testing.T) {
+	g, err := Build(context.Background(), renderTestSettings())
+	require.NoError(t, err)
+
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, g.StartAll(context.Background(), host))
+	require.NoError(t, g.ShutdownAll(context.Background()))
+
+	lifecycle := []component.Status{component.StatusStarting, component.StatusOK, component.StatusStopping, component.StatusStopped}
+	assert.Equal(t, lifecycle, host.statuses(component.KindReceiver, component.NewID("examplereceiver")))
+	assert.Equal(t, lifecycle, host.statuses(component.KindProcessor, component.NewID("exampleprocessor")))
+	assert.Equal(t, lifecycle, host.statuses(component.KindConnector, component.NewID("exampleconnector")))
+	assert.Equal(t, lifecycle, host.statuses(component.KindExporter, component.NewID("exampleexporter")))
+
+	pipelines := map[component.Kind][]component.ID{}
+	for _, ev := range host.events {
+		pipelines[ev.source.Kind] = ev.source.PipelineIDs
+	}
+	assert.Equal(t, map[component.Kind][]component.ID{
+		component.KindReceiver:  {component.NewIDWithName("traces", "in")},
+		component.KindProcessor: {component.NewIDWithName("traces", "in")},
+		component.KindConnector: {component.NewIDWithName("traces", "in"), component.NewIDWithName("traces", "out")},
+		component.KindExporter:  {component.NewIDWithName("traces", "out")},
+	}, pipelines)
+}
+
+func TestGraphReportsStatusErrors(t *testing.T) {
+	startErr, shutdownErr := errors.New("foo"), errors.New("bar")
+	pg := &Graph{componentGraph: simple.NewDirectedGraph()}
+	pg.componentGraph.SetEdge(simple.Edge{
+		F: &testNode{id: component.NewIDWithName("r", "1"), startErr: startErr},
+		T: &testNode{id: component.NewIDWithName("e", "1"), shutdownErr: shutdownErr},
+	})
+
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	assert.Error(t, pg.StartAll(context.Background(), host))
+	assert.Error(t, pg.ShutdownAll(context.Background()))
+
+	var statuses []component.Status
+	var errs []error
+	for _, ev := range host.events {
+		statuses = append(statuses, ev.status)
+		if ev.err != nil {
+			errs = append(errs, ev.err)
+		}
+	}
+	assert.Equal(t, []component.Status{
+		// e1 is started first and r1 fails to start.
+		component.StatusStarting, component.StatusOK,
+		component.StatusStarting, component.StatusPermanentError,
+		// Shutdown of r1 then e1, which fails.
+		component.StatusStopping, component.StatusStopped,
+		component.StatusStopping, component.StatusPermanentError, component.StatusStopped,
+	}, statuses)
+	assert.Equal(t, []error{startErr, shutdownErr}, errs)
+}
+
+// panicProcessor panics when consuming traces, and counts how many times it was started.
+type panicProcessor struct {
+	starts atomic.Int32
+}
+
+func (p *panicProcessor) Start(context.Context, component.Host) error {
+	p.starts.Add(1)
+	return nil
+}
+
+func (p *panicProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (p *panicProcessor) Restartable() bool {
+	return true
+}
+
+func (p *panicProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+func (p *panicProcessor) ConsumeTraces(context.Context, ptrace.Traces) error {
+	panic("processor failure")
+}
+
+func TestGraphIsolatesPanics(t *testing.T) {
+	proc := &panicProcessor{}
+	factory := processor.NewFactory("panicprocessor",
+		func() component.Config { return &struct{}{} },
+		processor.WithTraces(func(context.Context, processor.CreateSettings, component.Config, consumer.Traces) (processor.Traces, error) {
+			return proc, nil
+		}, component.StabilityLevelDevelopment))
+	set := renderTestSettings()
+	set.ProcessorBuilder = processor.NewBuilder(
+		map[component.ID]component.Config{component.NewID("panicprocessor"): factory.CreateDefaultConfig()},
+		map[component.Type]processor.Factory{factory.Type(): factory})
+	set.PipelineConfigs[component.NewIDWithName("traces", "in")].Processors = []component.ID{component.NewID("panicprocessor")}
+	var err error
+	set.PanicGuard, err = panicisolation.NewGuard(panicisolation.Config{
+		Policy:          panicisolation.PolicyRestart,
+		InitialInterval: time.Millisecond,
+	}, noop.NewMeterProvider())
+	require.NoError(t, err)
+
+	g, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, g.StartAll(context.Background(), host))
+
+	var rcvr *testcomponents.ExampleReceiver
+	for _, n := range g.pipelines[component.NewIDWithName("traces", "in")].receivers {
+		rcvr = n.(*receiverNode).Component.(*testcomponents.ExampleReceiver)
+	}
+	err = rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	var panicErr *panicisolation.PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "processor failure", panicErr.Value)
+
+	// The processor is restarted after the panic.
+	assert.Eventually(t, func() bool {
+		return proc.starts.Load() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, g.ShutdownAll(context.Background()))
+	assert.Equal(t, []component.Status{
+		component.StatusStarting, component.StatusOK,
+		component.StatusRecoverableError, component.StatusStopping, component.StatusStarting, component.StatusOK,
+		component.StatusStopping, component.StatusStopped,
+	}, host.statuses(component.KindProcessor, component.NewID("panicprocessor")))
+}
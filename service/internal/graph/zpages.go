@@ -6,6 +6,7 @@ package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 import (
 	"net/http"
 	"sort"
+	"strings"
 
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
@@ -16,6 +17,7 @@ const (
 	zPipelineName  = "pipelinenamez"
 	zComponentName = "componentnamez"
 	zComponentKind = "componentkindz"
+	zGraphFormat   = "format"
 )
 
 func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
@@ -24,6 +26,11 @@ func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	componentName := qValues.Get(zComponentName)
 	componentKind := qValues.Get(zComponentKind)
 
+	if format := qValues.Get(zGraphFormat); format != "" {
+		g.handleGraph(w, format)
+		return
+	}
+
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "builtPipelines"})
 
@@ -67,6 +74,11 @@ func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	})
 	zpages.WriteHTMLPipelinesSummaryTable(w, sumData)
 
+	graph := &strings.Builder{}
+	if err := g.Render(graph, FormatMermaid); err == nil {
+		zpages.WriteHTMLPipelinesGraph(w, zpages.PipelinesGraphData{Graph: graph.String(), FormatParam: zGraphFormat})
+	}
+
 	if pipelineName != "" && componentName != "" && componentKind != "" {
 		fullName := componentName
 		if componentKind == "processor" {
@@ -79,3 +91,14 @@ func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	}
 	zpages.WriteHTMLPageFooter(w)
 }
+
+// handleGraph writes the graph of the pipelines in the given text format.
+func (g *Graph) handleGraph(w http.ResponseWriter, format string) {
+	graph := &strings.Builder{}
+	if err := g.Render(graph, format); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte(graph.String()))
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metadataconsumer restricts the client metadata propagated to the components of a pipeline.
+package metadataconsumer // import "go.opentelemetry.io/collector/service/internal/metadataconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// withMetadataKeys returns the context whose client.Info only holds the given metadata keys.
+func withMetadataKeys(ctx context.Context, keys []string) context.Context {
+	info := client.FromContext(ctx)
+	info.Metadata = info.Metadata.Select(keys...)
+	return client.NewContext(ctx, info)
+}
+
+// NewTraces returns a consumer.Traces passing the data to next with only the given metadata keys.
+func NewTraces(next consumer.Traces, keys []string) consumer.Traces {
+	return tracesConsumer{Traces: next, keys: keys}
+}
+
+type tracesConsumer struct {
+	consumer.Traces
+	keys []string
+}
+
+func (c tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return c.Traces.ConsumeTraces(withMetadataKeys(ctx, c.keys), td)
+}
+
+// NewMetrics returns a consumer.Metrics passing the data to next with only the given metadata keys.
+func NewMetrics(next consumer.Metrics, keys []string) consumer.Metrics {
+	return metricsConsumer{Metrics: next, keys: keys}
+}
+
+type metricsConsumer struct {
+	consumer.Metrics
+	keys []string
+}
+
+func (c metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return c.Metrics.ConsumeMetrics(withMetadataKeys(ctx, c.keys), md)
+}
+
+// NewLogs returns a consumer.Logs passing the data to next with only the given metadata keys.
+func NewLogs(next consumer.Logs, keys []string) consumer.Logs {
+	return logsConsumer{Logs: next, keys: keys}
+}
+
+type logsConsumer struct {
+	consumer.Logs
+	keys []string
+}
+
+func (c logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return c.Logs.ConsumeLogs(withMetadataKeys(ctx, c.keys), ld)
+}
+
+// NewProfiles returns a consumer.Profiles passing the data to next with only the given metadata keys.
+func NewProfiles(next consumer.Profiles, keys []string) consumer.Profiles {
+	return profilesConsumer{Profiles: next, keys: keys}
+}
+
+type profilesConsumer struct {
+	consumer.Profiles
+	keys []string
+}
+
+func (c profilesConsumer) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	return c.Profiles.ConsumeProfiles(withMetadataKeys(ctx, c.keys), pd)
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metadataconsumer
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var keys = []string{"x-tenant"}
+
+func newContext() context.Context {
+	return client.NewContext(context.Background(), client.Info{
+		Addr:     &net.IPAddr{IP: net.IPv4(192, 168, 1, 1)},
+		Metadata: client.NewMetadata(map[string][]string{"X-Tenant": {"acme"}, "authorization": {"secret"}}),
+	})
+}
+
+func assertSelected(t *testing.T, ctx context.Context) {
+	info := client.FromContext(ctx)
+	assert.Equal(t, &net.IPAddr{IP: net.IPv4(192, 168, 1, 1)}, info.Addr)
+	assert.Equal(t, []string{"acme"}, info.Metadata.Get("x-tenant"))
+	assert.Empty(t, info.Metadata.Get("authorization"))
+}
+
+func TestTraces(t *testing.T) {
+	var got context.Context
+	next, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		got = ctx
+		return nil
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	require.NoError(t, err)
+
+	c := NewTraces(next, keys)
+	assert.Equal(t, consumer.Capabilities{MutatesData: true}, c.Capabilities())
+	require.NoError(t, c.ConsumeTraces(newContext(), ptrace.NewTraces()))
+	assertSelected(t, got)
+}
+
+func TestMetrics(t *testing.T) {
+	var got context.Context
+	next, err := consumer.NewMetrics(func(ctx context.Context, _ pmetric.Metrics) error {
+		got = ctx
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, NewMetrics(next, keys).ConsumeMetrics(newContext(), pmetric.NewMetrics()))
+	assertSelected(t, got)
+}
+
+func TestLogs(t *testing.T) {
+	var got context.Context
+	next, err := consumer.NewLogs(func(ctx context.Context, _ plog.Logs) error {
+		got = ctx
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, NewLogs(next, keys).ConsumeLogs(newContext(), plog.NewLogs()))
+	assertSelected(t, got)
+
+	// The requests without client.Info get empty metadata.
+	require.NoError(t, NewLogs(next, keys).ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.Empty(t, client.FromContext(got).Metadata.Get("x-tenant"))
+}
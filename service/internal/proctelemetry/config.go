@@ -4,18 +4,46 @@
 package proctelemetry // import "go.opentelemetry.io/collector/service/internal/proctelemetry"
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"time"
+
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
 
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/obsreport"
 	semconv "go.opentelemetry.io/collector/semconv/v1.18.0"
+	"go.opentelemetry.io/collector/service/internal/flowmetrics"
+	"go.opentelemetry.io/collector/service/telemetry"
 )
 
 const (
 
+	// supported metric reader types
+	periodicReaderType = "periodic"
+
+	// supported metric exporters
+	otlpExporter = "otlp"
+
+	// supported OTLP protocols
+	protocolProtobufHTTP = "http/protobuf"
+	protocolProtobufGRPC = "grpc/protobuf"
+
+	// supported temporality preferences
+	temporalityCumulative = "cumulative"
+	temporalityDelta      = "delta"
+
 	// gRPC Instrumentation Name
 	GRPCInstrumentation = "go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 
@@ -24,6 +52,16 @@ const (
 )
 
 var (
+	errUnsupportedReaderType   = errors.New("unsupported metric reader type")
+	errUnsupportedExporter     = errors.New("unsupported metric exporter")
+	errUnsupportedProtocol     = errors.New("unsupported protocol")
+	errUnsupportedTemporality  = errors.New("unsupported temporality preference")
+	errNoValidMetricExporter   = errors.New("no valid metric exporter")
+	errMissingPeriodicExporter = errors.New("periodic metric reader requires an exporter")
+
+	// ErrRestartRequired is returned when a configuration change cannot be applied without a restart.
+	ErrRestartRequired = errors.New("change requires a restart")
+
 	// GRPCUnacceptableKeyValues is a list of high cardinality grpc attributes that should be filtered out.
 	GRPCUnacceptableKeyValues = []attribute.KeyValue{
 		attribute.String(semconv.AttributeNetSockPeerAddr, ""),
@@ -38,11 +76,14 @@ var (
 	}
 )
 
-func InitOpenTelemetry(res *resource.Resource, options []sdkmetric.Option, disableHighCardinality bool) (*sdkmetric.MeterProvider, error) {
+func InitOpenTelemetry(res *resource.Resource, options []sdkmetric.Option, disableHighCardinality bool, latencyBounds []float64) (*sdkmetric.MeterProvider, error) {
 	opts := []sdkmetric.Option{
 		sdkmetric.WithResource(res),
 		sdkmetric.WithView(batchViews(disableHighCardinality)...),
 	}
+	if len(latencyBounds) > 0 {
+		opts = append(opts, sdkmetric.WithView(latencyViews(latencyBounds)...))
+	}
 
 	opts = append(opts, options...)
 	return sdkmetric.NewMeterProvider(
@@ -50,6 +91,16 @@ func InitOpenTelemetry(res *resource.Resource, options []sdkmetric.Option, disab
 	), nil
 }
 
+// latencyViews aggregates the latency histograms of the components in buckets of the given bounds.
+func latencyViews(bounds []float64) []sdkmetric.View {
+	stream := sdkmetric.Stream{Aggregation: aggregation.ExplicitBucketHistogram{Boundaries: bounds}}
+	return []sdkmetric.View{
+		sdkmetric.NewView(sdkmetric.Instrument{Name: obsmetrics.ProcessorPrefix + obsmetrics.LatencyKey}, stream),
+		sdkmetric.NewView(sdkmetric.Instrument{Name: obsmetrics.ExporterPrefix + obsmetrics.LatencyKey}, stream),
+		sdkmetric.NewView(sdkmetric.Instrument{Name: flowmetrics.FanOutLatencyMetricName}, stream),
+	}
+}
+
 func batchViews(disableHighCardinality bool) []sdkmetric.View {
 	views := []sdkmetric.View{
 		sdkmetric.NewView(
@@ -92,3 +143,190 @@ func cardinalityFilter(kvs ...attribute.KeyValue) attribute.Filter {
 		return !filter.HasValue(kv.Key)
 	}
 }
+
+// MetricReader is a metric reader initialized from the configuration. The exporter
+// of the reader can be reconfigured with Reload, keeping the state of the reader and
+// of the instruments that it observes.
+type MetricReader struct {
+	sdkmetric.Reader
+
+	cfg      telemetry.PeriodicMetricReader
+	otlp     telemetry.Otlp
+	exporter *reloadableExporter
+}
+
+// InitMetricReader initializes the metric reader described by the given configuration.
+func InitMetricReader(ctx context.Context, reader telemetry.MetricReader) (*MetricReader, error) {
+	periodic, err := periodicReaderConfig(reader)
+	if err != nil {
+		return nil, err
+	}
+	return initPeriodicReader(ctx, periodic)
+}
+
+// Reload applies the given configuration to the reader. Only the exporter settings
+// can be changed, any other change returns ErrRestartRequired.
+func (r *MetricReader) Reload(ctx context.Context, reader telemetry.MetricReader) error {
+	periodic, err := periodicReaderConfig(reader)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(periodic.Interval, r.cfg.Interval) || !reflect.DeepEqual(periodic.Timeout, r.cfg.Timeout) {
+		return fmt.Errorf("%w: periodic reader interval or timeout changed", ErrRestartRequired)
+	}
+	otlp, err := otlpExporterConfig(periodic)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(otlp, r.otlp) {
+		return nil
+	}
+	if !reflect.DeepEqual(otlp.TemporalityPreference, r.otlp.TemporalityPreference) {
+		return fmt.Errorf("%w: temporality preference changed", ErrRestartRequired)
+	}
+	exp, err := initOTLPExporter(ctx, otlp)
+	if err != nil {
+		return err
+	}
+	r.cfg = periodic
+	r.otlp = otlp
+	return r.exporter.swap(ctx, exp)
+}
+
+func periodicReaderConfig(reader telemetry.MetricReader) (telemetry.PeriodicMetricReader, error) {
+	var periodic telemetry.PeriodicMetricReader
+	args, ok := reader.Args.(map[string]any)
+	if !ok {
+		return periodic, fmt.Errorf("invalid arguments for metric reader %q", reader.Type)
+	}
+	if reader.Type != periodicReaderType {
+		return periodic, fmt.Errorf("%w: %q", errUnsupportedReaderType, reader.Type)
+	}
+	err := confmap.NewFromStringMap(args).Unmarshal(&periodic)
+	return periodic, err
+}
+
+func otlpExporterConfig(reader telemetry.PeriodicMetricReader) (telemetry.Otlp, error) {
+	var otlp telemetry.Otlp
+	if len(reader.Exporter) == 0 {
+		return otlp, errMissingPeriodicExporter
+	}
+	for exporterType, exporterArgs := range reader.Exporter {
+		if exporterType != otlpExporter {
+			return otlp, fmt.Errorf("%w: %q", errUnsupportedExporter, exporterType)
+		}
+		args, ok := exporterArgs.(map[string]any)
+		if !ok {
+			return otlp, fmt.Errorf("invalid arguments for %q metric exporter", exporterType)
+		}
+		err := confmap.NewFromStringMap(args).Unmarshal(&otlp)
+		return otlp, err
+	}
+	return otlp, errNoValidMetricExporter
+}
+
+func initPeriodicReader(ctx context.Context, reader telemetry.PeriodicMetricReader) (*MetricReader, error) {
+	otlp, err := otlpExporterConfig(reader)
+	if err != nil {
+		return nil, err
+	}
+	var opts []sdkmetric.PeriodicReaderOption
+	if reader.Interval != nil {
+		opts = append(opts, sdkmetric.WithInterval(time.Duration(*reader.Interval)*time.Millisecond))
+	}
+	if reader.Timeout != nil {
+		opts = append(opts, sdkmetric.WithTimeout(time.Duration(*reader.Timeout)*time.Millisecond))
+	}
+	exp, err := initOTLPExporter(ctx, otlp)
+	if err != nil {
+		return nil, err
+	}
+	reloadable := &reloadableExporter{exporter: exp}
+	return &MetricReader{
+		Reader:   sdkmetric.NewPeriodicReader(reloadable, opts...),
+		cfg:      reader,
+		otlp:     otlp,
+		exporter: reloadable,
+	}, nil
+}
+
+func initOTLPExporter(ctx context.Context, otlp telemetry.Otlp) (sdkmetric.Exporter, error) {
+	temporality, err := temporalitySelector(otlp.TemporalityPreference)
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(otlp.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q: %w", otlp.Endpoint, err)
+	}
+	headers := make(map[string]string, len(otlp.Headers))
+	for k, v := range otlp.Headers {
+		headers[k] = fmt.Sprint(v)
+	}
+
+	switch otlp.Protocol {
+	case protocolProtobufHTTP:
+		opts := []otlpmetrichttp.Option{
+			otlpmetrichttp.WithEndpoint(u.Host),
+			otlpmetrichttp.WithTemporalitySelector(temporality),
+			otlpmetrichttp.WithHeaders(headers),
+		}
+		if u.Scheme == "http" {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		}
+		if u.Path != "" {
+			opts = append(opts, otlpmetrichttp.WithURLPath(u.Path))
+		}
+		if otlp.Compression != nil && *otlp.Compression == "gzip" {
+			opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+		}
+		if otlp.Timeout != nil {
+			opts = append(opts, otlpmetrichttp.WithTimeout(time.Duration(*otlp.Timeout)*time.Millisecond))
+		}
+		return otlpmetrichttp.New(ctx, opts...)
+	case protocolProtobufGRPC:
+		opts := []otlpmetricgrpc.Option{
+			otlpmetricgrpc.WithEndpoint(u.Host),
+			otlpmetricgrpc.WithTemporalitySelector(temporality),
+			otlpmetricgrpc.WithHeaders(headers),
+		}
+		if u.Scheme == "http" {
+			opts = append(opts, otlpmetricgrpc.WithInsecure())
+		}
+		if otlp.Compression != nil && *otlp.Compression == "gzip" {
+			opts = append(opts, otlpmetricgrpc.WithCompressor("gzip"))
+		}
+		if otlp.Timeout != nil {
+			opts = append(opts, otlpmetricgrpc.WithTimeout(time.Duration(*otlp.Timeout)*time.Millisecond))
+		}
+		return otlpmetricgrpc.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnsupportedProtocol, otlp.Protocol)
+	}
+}
+
+// temporalitySelector returns the TemporalitySelector for the given preference.
+// The delta preference follows the OTLP exporter specification: UpDownCounters
+// are always cumulative since their deltas are not meaningful to most backends.
+func temporalitySelector(preference *string) (sdkmetric.TemporalitySelector, error) {
+	if preference == nil {
+		return sdkmetric.DefaultTemporalitySelector, nil
+	}
+	switch *preference {
+	case temporalityCumulative:
+		return sdkmetric.DefaultTemporalitySelector, nil
+	case temporalityDelta:
+		return func(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+			switch kind {
+			case sdkmetric.InstrumentKindCounter,
+				sdkmetric.InstrumentKindObservableCounter,
+				sdkmetric.InstrumentKindHistogram:
+				return metricdata.DeltaTemporality
+			default:
+				return metricdata.CumulativeTemporality
+			}
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnsupportedTemporality, *preference)
+	}
+}
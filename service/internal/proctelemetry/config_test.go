@@ -2,3 +2,192 @@
 // SPDX-License-Identifier: Apache-2.0
 
 package proctelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestTemporalitySelector(t *testing.T) {
+	selector, err := temporalitySelector(nil)
+	require.NoError(t, err)
+	assert.Equal(t, metricdata.CumulativeTemporality, selector(sdkmetric.InstrumentKindCounter))
+
+	selector, err = temporalitySelector(strPtr("cumulative"))
+	require.NoError(t, err)
+	assert.Equal(t, metricdata.CumulativeTemporality, selector(sdkmetric.InstrumentKindHistogram))
+
+	selector, err = temporalitySelector(strPtr("delta"))
+	require.NoError(t, err)
+	assert.Equal(t, metricdata.DeltaTemporality, selector(sdkmetric.InstrumentKindCounter))
+	assert.Equal(t, metricdata.DeltaTemporality, selector(sdkmetric.InstrumentKindObservableCounter))
+	assert.Equal(t, metricdata.DeltaTemporality, selector(sdkmetric.InstrumentKindHistogram))
+	assert.Equal(t, metricdata.CumulativeTemporality, selector(sdkmetric.InstrumentKindUpDownCounter))
+	assert.Equal(t, metricdata.CumulativeTemporality, selector(sdkmetric.InstrumentKindObservableGauge))
+
+	_, err = temporalitySelector(strPtr("lowmemory"))
+	assert.ErrorIs(t, err, errUnsupportedTemporality)
+}
+
+func TestInitOpenTelemetryLatencyBounds(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	bounds := []float64{1, 10, 100}
+	mp, err := InitOpenTelemetry(resource.Empty(), []sdkmetric.Option{sdkmetric.WithReader(reader)}, false, bounds)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, mp.Shutdown(context.Background())) }()
+
+	meter := mp.Meter("test")
+	for _, name := range []string{"processor/latency", "exporter/latency", "other/latency"} {
+		hist, err := meter.Float64Histogram(name)
+		require.NoError(t, err)
+		hist.Record(context.Background(), 42)
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 3)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		hist, ok := m.Data.(metricdata.Histogram[float64])
+		require.True(t, ok)
+		require.Len(t, hist.DataPoints, 1)
+		if m.Name == "other/latency" {
+			assert.NotEqual(t, bounds, hist.DataPoints[0].Bounds)
+		} else {
+			assert.Equal(t, bounds, hist.DataPoints[0].Bounds, m.Name)
+		}
+	}
+}
+
+func TestInitMetricReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		reader  telemetry.MetricReader
+		wantErr error
+	}{
+		{
+			name: "periodic otlp grpc delta",
+			reader: telemetry.MetricReader{
+				Type: "periodic",
+				Args: map[string]any{
+					"interval": 1000,
+					"exporter": map[string]any{
+						"otlp": map[string]any{
+							"protocol":               "grpc/protobuf",
+							"endpoint":               "http://localhost:4317",
+							"temporality_preference": "delta",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "periodic otlp http",
+			reader: telemetry.MetricReader{
+				Type: "periodic",
+				Args: map[string]any{
+					"exporter": map[string]any{
+						"otlp": map[string]any{
+							"protocol":    "http/protobuf",
+							"endpoint":    "https://localhost:4318/v1/metrics",
+							"compression": "gzip",
+							"headers":     map[string]any{"api-key": "value"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "unsupported reader",
+			reader:  telemetry.MetricReader{Type: "pull", Args: map[string]any{}},
+			wantErr: errUnsupportedReaderType,
+		},
+		{
+			name: "unsupported exporter",
+			reader: telemetry.MetricReader{
+				Type: "periodic",
+				Args: map[string]any{"exporter": map[string]any{"console": map[string]any{}}},
+			},
+			wantErr: errUnsupportedExporter,
+		},
+		{
+			name: "unsupported protocol",
+			reader: telemetry.MetricReader{
+				Type: "periodic",
+				Args: map[string]any{"exporter": map[string]any{"otlp": map[string]any{
+					"protocol": "http/json",
+					"endpoint": "http://localhost:4318",
+				}}},
+			},
+			wantErr: errUnsupportedProtocol,
+		},
+		{
+			name: "unsupported temporality",
+			reader: telemetry.MetricReader{
+				Type: "periodic",
+				Args: map[string]any{"exporter": map[string]any{"otlp": map[string]any{
+					"protocol":               "grpc/protobuf",
+					"endpoint":               "http://localhost:4317",
+					"temporality_preference": "invalid",
+				}}},
+			},
+			wantErr: errUnsupportedTemporality,
+		},
+		{
+			name:    "missing exporter",
+			reader:  telemetry.MetricReader{Type: "periodic", Args: map[string]any{}},
+			wantErr: errMissingPeriodicExporter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := InitMetricReader(context.Background(), tt.reader)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, reader)
+		})
+	}
+}
+
+func TestMetricReaderReload(t *testing.T) {
+	newReader := func(interval int, endpoint string, temporality string) telemetry.MetricReader {
+		return telemetry.MetricReader{
+			Type: "periodic",
+			Args: map[string]any{
+				"interval": interval,
+				"exporter": map[string]any{
+					"otlp": map[string]any{
+						"protocol":               "grpc/protobuf",
+						"endpoint":               endpoint,
+						"temporality_preference": temporality,
+					},
+				},
+			},
+		}
+	}
+
+	reader, err := InitMetricReader(context.Background(), newReader(1000, "http://localhost:4317", "delta"))
+	require.NoError(t, err)
+
+	assert.NoError(t, reader.Reload(context.Background(), newReader(1000, "http://localhost:4317", "delta")))
+	assert.NoError(t, reader.Reload(context.Background(), newReader(1000, "http://otherhost:4317", "delta")))
+	assert.Equal(t, "http://otherhost:4317", reader.otlp.Endpoint)
+	assert.ErrorIs(t, reader.Reload(context.Background(), newReader(2000, "http://otherhost:4317", "delta")), ErrRestartRequired)
+	assert.ErrorIs(t, reader.Reload(context.Background(), newReader(1000, "http://otherhost:4317", "cumulative")), ErrRestartRequired)
+	assert.NoError(t, reader.Shutdown(context.Background()))
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/shirou/gopsutil/v3/process"
 	"go.opencensus.io/metric"
 	"go.opencensus.io/stats"
+	otelruntime "go.opentelemetry.io/contrib/instrumentation/runtime"
 	otelmetric "go.opentelemetry.io/otel/metric"
 	"go.uber.org/multierr"
 )
@@ -70,6 +71,12 @@ func RegisterProcessMetrics(ocRegistry *metric.Registry, mp otelmetric.MeterProv
 	return pm.recordWithOC(ocRegistry)
 }
 
+// RegisterRuntimeMetrics registers the OpenTelemetry Go runtime instrumentation (goroutines, GC,
+// memory statistics) on the given MeterProvider.
+func RegisterRuntimeMetrics(mp otelmetric.MeterProvider) error {
+	return otelruntime.Start(otelruntime.WithMeterProvider(mp))
+}
+
 func (pm *processMetrics) recordWithOC(ocRegistry *metric.Registry) error {
 	var err error
 
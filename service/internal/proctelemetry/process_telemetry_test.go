@@ -134,6 +134,25 @@ func TestOtelProcessTelemetry(t *testing.T) {
 	}
 }
 
+func TestRuntimeTelemetry(t *testing.T) {
+	tel := setupTelemetry(t)
+
+	require.NoError(t, RegisterRuntimeMetrics(tel.MeterProvider))
+
+	mp, err := fetchPrometheusMetrics(tel.promHandler)
+	require.NoError(t, err)
+
+	for _, metricName := range []string{
+		"process_runtime_go_goroutines",
+		"process_runtime_go_gc_count_total",
+		"process_runtime_go_mem_heap_alloc",
+	} {
+		metric, ok := mp[metricName]
+		require.True(t, ok, metricName)
+		require.Len(t, metric.Metric, 1)
+	}
+}
+
 func TestOCProcessTelemetry(t *testing.T) {
 	ocRegistry := metric.NewRegistry()
 
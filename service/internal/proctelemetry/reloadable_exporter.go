@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package proctelemetry // import "go.opentelemetry.io/collector/service/internal/proctelemetry"
+
+import (
+	"context"
+	"sync"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// reloadableExporter is a sdkmetric.Exporter delegating to an exporter that can be
+// replaced while the reader using it keeps running.
+type reloadableExporter struct {
+	mu       sync.RWMutex
+	exporter sdkmetric.Exporter
+}
+
+var _ sdkmetric.Exporter = (*reloadableExporter)(nil)
+
+func (e *reloadableExporter) current() sdkmetric.Exporter {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.exporter
+}
+
+// swap replaces the underlying exporter, flushing and shutting down the previous one.
+func (e *reloadableExporter) swap(ctx context.Context, exporter sdkmetric.Exporter) error {
+	e.mu.Lock()
+	old := e.exporter
+	e.exporter = exporter
+	e.mu.Unlock()
+	_ = old.ForceFlush(ctx)
+	return old.Shutdown(ctx)
+}
+
+func (e *reloadableExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	return e.current().Temporality(kind)
+}
+
+func (e *reloadableExporter) Aggregation(kind sdkmetric.InstrumentKind) aggregation.Aggregation {
+	return e.current().Aggregation(kind)
+}
+
+func (e *reloadableExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	return e.current().Export(ctx, rm)
+}
+
+func (e *reloadableExporter) ForceFlush(ctx context.Context) error {
+	return e.current().ForceFlush(ctx)
+}
+
+func (e *reloadableExporter) Shutdown(ctx context.Context) error {
+	return e.current().Shutdown(ctx)
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package slo // import "go.opentelemetry.io/collector/service/internal/slo"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// NewTraces returns a consumer.Traces passing the data to next and reporting the outcome to record.
+func NewTraces(next consumer.Traces, record func(items int, err error)) consumer.Traces {
+	return tracesConsumer{Traces: next, record: record}
+}
+
+type tracesConsumer struct {
+	consumer.Traces
+	record func(int, error)
+}
+
+func (c tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	// Count before passing the data, the next consumer may modify it.
+	items := td.SpanCount()
+	err := c.Traces.ConsumeTraces(ctx, td)
+	c.record(items, err)
+	return err
+}
+
+// NewMetrics returns a consumer.Metrics passing the data to next and reporting the outcome to record.
+func NewMetrics(next consumer.Metrics, record func(items int, err error)) consumer.Metrics {
+	return metricsConsumer{Metrics: next, record: record}
+}
+
+type metricsConsumer struct {
+	consumer.Metrics
+	record func(int, error)
+}
+
+func (c metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	items := md.DataPointCount()
+	err := c.Metrics.ConsumeMetrics(ctx, md)
+	c.record(items, err)
+	return err
+}
+
+// NewLogs returns a consumer.Logs passing the data to next and reporting the outcome to record.
+func NewLogs(next consumer.Logs, record func(items int, err error)) consumer.Logs {
+	return logsConsumer{Logs: next, record: record}
+}
+
+type logsConsumer struct {
+	consumer.Logs
+	record func(int, error)
+}
+
+func (c logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	items := ld.LogRecordCount()
+	err := c.Logs.ConsumeLogs(ctx, ld)
+	c.record(items, err)
+	return err
+}
+
+// NewProfiles returns a consumer.Profiles passing the data to next and reporting the outcome to record.
+func NewProfiles(next consumer.Profiles, record func(items int, err error)) consumer.Profiles {
+	return profilesConsumer{Profiles: next, record: record}
+}
+
+type profilesConsumer struct {
+	consumer.Profiles
+	record func(int, error)
+}
+
+func (c profilesConsumer) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	items := pd.SampleCount()
+	err := c.Profiles.ConsumeProfiles(ctx, pd)
+	c.record(items, err)
+	return err
+}
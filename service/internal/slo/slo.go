@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package slo computes success ratios of the pipelines over sliding windows and exposes
+// them as service level indicator metrics.
+package slo // import "go.opentelemetry.io/collector/service/internal/slo"
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	scopeName   = "go.opentelemetry.io/collector/service/slo"
+	pipelineKey = "pipeline"
+)
+
+// Tracker records the outcome of the data going through a pipeline.
+type Tracker struct {
+	objective float64
+
+	// ingest counts items accepted vs refused by the first consumer of the pipeline.
+	ingest *window
+	// export counts items exported vs failed by the exporters of the pipeline.
+	export *window
+}
+
+// RecordIngest records the outcome of items entering the pipeline.
+func (t *Tracker) RecordIngest(items int, err error) {
+	record(t.ingest, items, err)
+}
+
+// RecordExport records the outcome of items sent to the exporters of the pipeline.
+func (t *Tracker) RecordExport(items int, err error) {
+	record(t.export, items, err)
+}
+
+// Healthy returns false if any of the success ratios is below the objective.
+// A pipeline without data is healthy.
+func (t *Tracker) Healthy() bool {
+	if r, ok := t.ingest.ratio(); ok && r < t.objective {
+		return false
+	}
+	if r, ok := t.export.ratio(); ok && r < t.objective {
+		return false
+	}
+	return true
+}
+
+func record(w *window, items int, err error) {
+	if items == 0 {
+		return
+	}
+	if err != nil {
+		w.record(0, int64(items))
+		return
+	}
+	w.record(int64(items), int64(items))
+}
+
+// Registry holds the Trackers of all the pipelines.
+type Registry struct {
+	window    time.Duration
+	objective float64
+
+	mu       sync.Mutex
+	trackers map[component.ID]*Tracker
+}
+
+// NewRegistry returns a Registry computing ratios over the given window, and reporting the pipelines
+// below the objective as unhealthy in the pipeline_slo_healthy metric.
+func NewRegistry(window time.Duration, objective float64) *Registry {
+	return &Registry{
+		window:    window,
+		objective: objective,
+		trackers:  make(map[component.ID]*Tracker),
+	}
+}
+
+// Tracker returns the Tracker for the given pipeline, creating it if needed.
+func (r *Registry) Tracker(pipelineID component.ID) *Tracker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.trackers[pipelineID]
+	if !ok {
+		t = &Tracker{
+			objective: r.objective,
+			ingest:    newWindow(r.window),
+			export:    newWindow(r.window),
+		}
+		r.trackers[pipelineID] = t
+	}
+	return t
+}
+
+// RegisterMetrics registers the SLI metrics on the given MeterProvider.
+func (r *Registry) RegisterMetrics(mp metric.MeterProvider) error {
+	meter := mp.Meter(scopeName)
+	accepted, err := meter.Float64ObservableGauge(
+		"pipeline_accepted_ratio",
+		metric.WithDescription("Ratio of items accepted by the pipeline over the SLO window."),
+		metric.WithUnit("1"))
+	if err != nil {
+		return err
+	}
+	exported, err := meter.Float64ObservableGauge(
+		"pipeline_exported_ratio",
+		metric.WithDescription("Ratio of items successfully exported by the pipeline over the SLO window."),
+		metric.WithUnit("1"))
+	if err != nil {
+		return err
+	}
+	healthy, err := meter.Int64ObservableGauge(
+		"pipeline_slo_healthy",
+		metric.WithDescription("Whether the success ratios of the pipeline meet the SLO objective (1) or not (0)."),
+		metric.WithUnit("1"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		r.mu.Lock()
+		ids := make([]component.ID, 0, len(r.trackers))
+		for id := range r.trackers {
+			ids = append(ids, id)
+		}
+		r.mu.Unlock()
+		sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+
+		for _, id := range ids {
+			t := r.Tracker(id)
+			attrs := metric.WithAttributes(attribute.String(pipelineKey, id.String()))
+			if ratio, ok := t.ingest.ratio(); ok {
+				o.ObserveFloat64(accepted, ratio, attrs)
+			}
+			if ratio, ok := t.export.ratio(); ok {
+				o.ObserveFloat64(exported, ratio, attrs)
+			}
+			var h int64
+			if t.Healthy() {
+				h = 1
+			}
+			o.ObserveInt64(healthy, h, attrs)
+		}
+		return nil
+	}, accepted, exported, healthy)
+	return err
+}
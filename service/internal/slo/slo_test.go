@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package slo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestWindowSlides(t *testing.T) {
+	now := time.Unix(0, 0)
+	w := newWindow(time.Minute)
+	w.now = func() time.Time { return now }
+
+	_, ok := w.ratio()
+	assert.False(t, ok)
+
+	w.record(1, 4)
+	ratio, ok := w.ratio()
+	require.True(t, ok)
+	assert.Equal(t, 0.25, ratio)
+
+	now = now.Add(30 * time.Second)
+	w.record(3, 4)
+	ratio, _ = w.ratio()
+	assert.Equal(t, 0.5, ratio)
+
+	// The first bucket is now out of the window.
+	now = now.Add(45 * time.Second)
+	ratio, _ = w.ratio()
+	assert.Equal(t, 0.75, ratio)
+
+	now = now.Add(time.Hour)
+	_, ok = w.ratio()
+	assert.False(t, ok)
+}
+
+func TestTrackerHealthy(t *testing.T) {
+	reg := NewRegistry(time.Minute, 0.9)
+	tracker := reg.Tracker(component.NewID("traces"))
+	assert.Same(t, tracker, reg.Tracker(component.NewID("traces")))
+	assert.True(t, tracker.Healthy())
+
+	tracker.RecordIngest(10, nil)
+	tracker.RecordExport(95, nil)
+	tracker.RecordExport(5, errors.New("failed"))
+	assert.True(t, tracker.Healthy())
+
+	tracker.RecordExport(10, errors.New("failed"))
+	assert.False(t, tracker.Healthy())
+}
+
+func TestConsumers(t *testing.T) {
+	tracker := NewRegistry(time.Minute, 0.9).Tracker(component.NewID("traces"))
+
+	tc := NewTraces(consumertest.NewErr(errors.New("refused")), tracker.RecordIngest)
+	assert.Error(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	mc := NewMetrics(consumertest.NewNop(), tracker.RecordIngest)
+	assert.NoError(t, mc.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	lc := NewLogs(consumertest.NewNop(), tracker.RecordIngest)
+	assert.NoError(t, lc.ConsumeLogs(context.Background(), testdata.GenerateLogs(4)))
+
+	ratio, ok := tracker.ingest.ratio()
+	require.True(t, ok)
+	assert.Equal(t, 6.0/8.0, ratio)
+}
+
+func TestRegisterMetrics(t *testing.T) {
+	reg := NewRegistry(time.Minute, 0.9)
+	reg.Tracker(component.NewID("traces")).RecordIngest(10, nil)
+	reg.Tracker(component.NewID("traces")).RecordExport(10, errors.New("failed"))
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	require.NoError(t, reg.RegisterMetrics(mp))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	values := map[string]float64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Gauge[float64]:
+			values[m.Name] = data.DataPoints[0].Value
+		case metricdata.Gauge[int64]:
+			values[m.Name] = float64(data.DataPoints[0].Value)
+		}
+	}
+	assert.Equal(t, map[string]float64{
+		"pipeline_accepted_ratio": 1,
+		"pipeline_exported_ratio": 0,
+		"pipeline_slo_healthy":    0,
+	}, values)
+}
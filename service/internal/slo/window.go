@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package slo // import "go.opentelemetry.io/collector/service/internal/slo"
+
+import (
+	"sync"
+	"time"
+)
+
+// numBuckets is the number of buckets a window is divided into.
+const numBuckets = 60
+
+type bucket struct {
+	index int64
+	good  int64
+	total int64
+}
+
+// window counts successful and total items over a sliding time window
+// made of fixed size buckets.
+type window struct {
+	bucketSize time.Duration
+	now        func() time.Time
+
+	mu      sync.Mutex
+	buckets [numBuckets]bucket
+}
+
+func newWindow(size time.Duration) *window {
+	bucketSize := size / numBuckets
+	if bucketSize <= 0 {
+		bucketSize = 1
+	}
+	return &window{bucketSize: bucketSize, now: time.Now}
+}
+
+func (w *window) record(good, total int64) {
+	idx := w.now().UnixNano() / int64(w.bucketSize)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	b := &w.buckets[idx%numBuckets]
+	if b.index != idx {
+		*b = bucket{index: idx}
+	}
+	b.good += good
+	b.total += total
+}
+
+// ratio returns the ratio of successful items over the window, and false if no item was recorded.
+func (w *window) ratio() (float64, bool) {
+	idx := w.now().UnixNano() / int64(w.bucketSize)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var good, total int64
+	for _, b := range w.buckets {
+		if idx-b.index < numBuckets {
+			good += b.good
+			total += b.total
+		}
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return float64(good) / float64(total), true
+}
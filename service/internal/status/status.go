@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package status keeps the statuses reported by the component instances and aggregates
+// them per pipeline.
+package status // import "go.opentelemetry.io/collector/service/internal/status"
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+const (
+	scopeName   = "go.opentelemetry.io/collector/service/status"
+	pipelineKey = "pipeline"
+	statusKey   = "status"
+)
+
+// Aggregator keeps the last status reported by every component instance, and the status of
+// every pipeline aggregated from the statuses of its components.
+type Aggregator struct {
+	watcher extension.StatusWatcher
+
+	mu         sync.Mutex
+	components map[*component.InstanceID]*component.StatusEvent
+	pipelines  map[component.ID]*component.StatusEvent
+}
+
+// NewAggregator returns an Aggregator notifying the changes of statuses to the watcher, if not nil.
+func NewAggregator(watcher extension.StatusWatcher) *Aggregator {
+	return &Aggregator{
+		watcher:    watcher,
+		components: make(map[*component.InstanceID]*component.StatusEvent),
+		pipelines:  make(map[component.ID]*component.StatusEvent),
+	}
+}
+
+// ReportStatus records the status reported by a component instance. The instances which
+// reported StatusStopped are forgotten, as well as the pipelines without any instance left.
+func (a *Aggregator) ReportStatus(source *component.InstanceID, event *component.StatusEvent) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if event.Status() == component.StatusStopped {
+		delete(a.components, source)
+	} else {
+		a.components[source] = event
+	}
+	if a.watcher != nil {
+		a.watcher.ComponentStatusChanged(source, event)
+	}
+	for _, pipelineID := range source.PipelineIDs {
+		a.updatePipeline(pipelineID)
+	}
+}
+
+func (a *Aggregator) updatePipeline(pipelineID component.ID) {
+	var events []*component.StatusEvent
+	for source, ev := range a.components {
+		for _, id := range source.PipelineIDs {
+			if id == pipelineID {
+				events = append(events, ev)
+				break
+			}
+		}
+	}
+
+	prev := a.pipelines[pipelineID]
+	var ev *component.StatusEvent
+	if len(events) == 0 {
+		delete(a.pipelines, pipelineID)
+		if prev == nil {
+			return
+		}
+		ev = component.NewStatusEvent(component.StatusStopped, nil)
+	} else {
+		ev = Aggregate(events)
+		// Keep the event of the transition, so that its timestamp tells how long the pipeline has been in this status.
+		if prev != nil && prev.Status() == ev.Status() {
+			return
+		}
+		a.pipelines[pipelineID] = ev
+	}
+	if a.watcher != nil {
+		a.watcher.PipelineStatusChanged(pipelineID, ev)
+	}
+}
+
+// statusPriority orders the statuses from the least to the most important one when aggregating them.
+var statusPriority = map[component.Status]int{
+	component.StatusOK:               0,
+	component.StatusNone:             1,
+	component.StatusStarting:         1,
+	component.StatusRecoverableError: 2,
+	component.StatusStopping:         3,
+	component.StatusStopped:          3,
+	component.StatusPermanentError:   4,
+}
+
+// Aggregate returns the most important of the events: a permanent error, then a component
+// stopping, a recoverable error, a component starting and finally OK. Among the events of the
+// same importance, the most recent one is returned.
+func Aggregate(events []*component.StatusEvent) *component.StatusEvent {
+	var agg *component.StatusEvent
+	for _, ev := range events {
+		if agg == nil {
+			agg = ev
+			continue
+		}
+		p, aggP := statusPriority[ev.Status()], statusPriority[agg.Status()]
+		if p > aggP || (p == aggP && ev.Timestamp().After(agg.Timestamp())) {
+			agg = ev
+		}
+	}
+	return agg
+}
+
+// PipelineStatus returns the status of the pipeline, nil if none of its components reported a status.
+func (a *Aggregator) PipelineStatus(pipelineID component.ID) *component.StatusEvent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.pipelines[pipelineID]
+}
+
+// RegisterMetrics registers the metric reporting the status of the pipelines on the given MeterProvider.
+func (a *Aggregator) RegisterMetrics(mp metric.MeterProvider) error {
+	meter := mp.Meter(scopeName)
+	pipelineStatus, err := meter.Int64ObservableGauge(
+		"pipeline_status",
+		metric.WithDescription("Status of the pipeline aggregated from the statuses of its components, always 1."),
+		metric.WithUnit("1"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		ids := make([]component.ID, 0, len(a.pipelines))
+		for id := range a.pipelines {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+
+		for _, id := range ids {
+			o.ObserveInt64(pipelineStatus, 1, metric.WithAttributes(
+				attribute.String(pipelineKey, id.String()),
+				attribute.String(statusKey, a.pipelines[id].Status().String())))
+		}
+		return nil
+	}, pipelineStatus)
+	return err
+}
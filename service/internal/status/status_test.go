@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type pipelineEvent struct {
+	pipelineID component.ID
+	status     component.Status
+}
+
+type recordingWatcher struct {
+	components []component.Status
+	pipelines  []pipelineEvent
+}
+
+func (w *recordingWatcher) ComponentStatusChanged(_ *component.InstanceID, ev *component.StatusEvent) {
+	w.components = append(w.components, ev.Status())
+}
+
+func (w *recordingWatcher) PipelineStatusChanged(pipelineID component.ID, ev *component.StatusEvent) {
+	w.pipelines = append(w.pipelines, pipelineEvent{pipelineID: pipelineID, status: ev.Status()})
+}
+
+func TestAggregate(t *testing.T) {
+	ok := component.NewStatusEvent(component.StatusOK, nil)
+	starting := component.NewStatusEvent(component.StatusStarting, nil)
+	oldErr := component.NewStatusEvent(component.StatusRecoverableError, errors.New("old"))
+	newErr := component.NewStatusEvent(component.StatusRecoverableError, errors.New("new"))
+	stopping := component.NewStatusEvent(component.StatusStopping, nil)
+	permanent := component.NewStatusEvent(component.StatusPermanentError, errors.New("permanent"))
+
+	assert.Equal(t, ok, Aggregate([]*component.StatusEvent{ok}))
+	assert.Equal(t, starting, Aggregate([]*component.StatusEvent{ok, starting}))
+	assert.Equal(t, component.StatusRecoverableError, Aggregate([]*component.StatusEvent{ok, starting, oldErr, newErr}).Status())
+	assert.Equal(t, stopping, Aggregate([]*component.StatusEvent{ok, oldErr, stopping}))
+	assert.Equal(t, permanent, Aggregate([]*component.StatusEvent{permanent, stopping, oldErr, ok}))
+	assert.Nil(t, Aggregate(nil))
+}
+
+func TestAggregatorPipelines(t *testing.T) {
+	tracesID := component.NewID("traces")
+	metricsID := component.NewID("metrics")
+	rcvr := &component.InstanceID{ID: component.NewID("otlp"), Kind: component.KindReceiver, PipelineIDs: []component.ID{tracesID, metricsID}}
+	exp := &component.InstanceID{ID: component.NewID("otlp"), Kind: component.KindExporter, PipelineIDs: []component.ID{tracesID}}
+	ext := &component.InstanceID{ID: component.NewID("zpages"), Kind: component.KindExtension}
+
+	w := &recordingWatcher{}
+	agg := NewAggregator(w)
+	agg.ReportStatus(ext, component.NewStatusEvent(component.StatusStarting, nil))
+	agg.ReportStatus(ext, component.NewStatusEvent(component.StatusOK, nil))
+	assert.Empty(t, w.pipelines)
+
+	agg.ReportStatus(exp, component.NewStatusEvent(component.StatusStarting, nil))
+	agg.ReportStatus(exp, component.NewStatusEvent(component.StatusOK, nil))
+	agg.ReportStatus(rcvr, component.NewStatusEvent(component.StatusStarting, nil))
+	agg.ReportStatus(rcvr, component.NewStatusEvent(component.StatusOK, nil))
+	assert.Equal(t, component.StatusOK, agg.PipelineStatus(tracesID).Status())
+	assert.Equal(t, component.StatusOK, agg.PipelineStatus(metricsID).Status())
+
+	recoverable := component.NewStatusEvent(component.StatusRecoverableError, errors.New("unavailable"))
+	agg.ReportStatus(exp, recoverable)
+	// The pipeline keeps the event of the transition to the status.
+	agg.ReportStatus(exp, component.NewStatusEvent(component.StatusRecoverableError, errors.New("still unavailable")))
+	assert.Equal(t, recoverable, agg.PipelineStatus(tracesID))
+	assert.Equal(t, component.StatusOK, agg.PipelineStatus(metricsID).Status())
+	agg.ReportStatus(exp, component.NewStatusEvent(component.StatusOK, nil))
+
+	for _, source := range []*component.InstanceID{rcvr, exp} {
+		agg.ReportStatus(source, component.NewStatusEvent(component.StatusStopping, nil))
+		agg.ReportStatus(source, component.NewStatusEvent(component.StatusStopped, nil))
+	}
+	assert.Nil(t, agg.PipelineStatus(tracesID))
+	assert.Nil(t, agg.PipelineStatus(metricsID))
+
+	assert.Equal(t, []component.Status{
+		component.StatusStarting, component.StatusOK,
+		component.StatusStarting, component.StatusOK,
+		component.StatusStarting, component.StatusOK,
+		component.StatusRecoverableError, component.StatusRecoverableError, component.StatusOK,
+		component.StatusStopping, component.StatusStopped,
+		component.StatusStopping, component.StatusStopped,
+	}, w.components)
+	assert.Equal(t, []pipelineEvent{
+		{pipelineID: tracesID, status: component.StatusStarting},
+		{pipelineID: tracesID, status: component.StatusOK},
+		{pipelineID: tracesID, status: component.StatusStarting},
+		{pipelineID: metricsID, status: component.StatusStarting},
+		{pipelineID: tracesID, status: component.StatusOK},
+		{pipelineID: metricsID, status: component.StatusOK},
+		{pipelineID: tracesID, status: component.StatusRecoverableError},
+		{pipelineID: tracesID, status: component.StatusOK},
+		{pipelineID: tracesID, status: component.StatusStopping},
+		{pipelineID: metricsID, status: component.StatusStopping},
+		{pipelineID: tracesID, status: component.StatusOK},
+		{pipelineID: metricsID, status: component.StatusStopped},
+		{pipelineID: tracesID, status: component.StatusStopping},
+		{pipelineID: tracesID, status: component.StatusStopped},
+	}, w.pipelines)
+}
+
+func TestAggregatorWithoutWatcher(t *testing.T) {
+	agg := NewAggregator(nil)
+	source := &component.InstanceID{ID: component.NewID("batch"), Kind: component.KindProcessor, PipelineIDs: []component.ID{component.NewID("logs")}}
+	assert.NotPanics(t, func() {
+		agg.ReportStatus(source, component.NewStatusEvent(component.StatusPermanentError, errors.New("failed")))
+	})
+	assert.Equal(t, component.StatusPermanentError, agg.PipelineStatus(component.NewID("logs")).Status())
+}
+
+func TestRegisterMetrics(t *testing.T) {
+	agg := NewAggregator(nil)
+	source := &component.InstanceID{ID: component.NewID("otlp"), Kind: component.KindExporter, PipelineIDs: []component.ID{component.NewID("traces")}}
+	agg.ReportStatus(source, component.NewStatusEvent(component.StatusRecoverableError, errors.New("unavailable")))
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	require.NoError(t, agg.RegisterMetrics(mp))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+	m := rm.ScopeMetrics[0].Metrics[0]
+	assert.Equal(t, "pipeline_status", m.Name)
+	data := m.Data.(metricdata.Gauge[int64])
+	require.Len(t, data.DataPoints, 1)
+	assert.Equal(t, int64(1), data.DataPoints[0].Value)
+	assert.Equal(t, attribute.NewSet(attribute.String("pipeline", "traces"), attribute.String("status", "RecoverableError")), data.DataPoints[0].Attributes)
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tap // import "go.opentelemetry.io/collector/service/internal/tap"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Traces returns next wrapped to copy the data entering the component in the pipeline,
+// or entering the pipeline if componentID is empty, to the taps attached there.
+func (r *Registry) Traces(pipelineID, componentID component.ID, next consumer.Traces) consumer.Traces {
+	return tracesConsumer{Traces: next, point: r.point(pipelineID, componentID)}
+}
+
+// Metrics returns next wrapped to copy the data entering the component in the pipeline,
+// or entering the pipeline if componentID is empty, to the taps attached there.
+func (r *Registry) Metrics(pipelineID, componentID component.ID, next consumer.Metrics) consumer.Metrics {
+	return metricsConsumer{Metrics: next, point: r.point(pipelineID, componentID)}
+}
+
+// Logs returns next wrapped to copy the data entering the component in the pipeline,
+// or entering the pipeline if componentID is empty, to the taps attached there.
+func (r *Registry) Logs(pipelineID, componentID component.ID, next consumer.Logs) consumer.Logs {
+	return logsConsumer{Logs: next, point: r.point(pipelineID, componentID)}
+}
+
+// Profiles returns next wrapped to copy the data entering the component in the pipeline,
+// or entering the pipeline if componentID is empty, to the taps attached there.
+func (r *Registry) Profiles(pipelineID, componentID component.ID, next consumer.Profiles) consumer.Profiles {
+	return profilesConsumer{Profiles: next, point: r.point(pipelineID, componentID)}
+}
+
+type tracesConsumer struct {
+	consumer.Traces
+	point *point
+}
+
+func (c tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	// Copy before passing the data, the next consumer may modify it.
+	c.point.copyTo(func() ([]byte, error) { return (&ptrace.JSONMarshaler{}).MarshalTraces(td) })
+	return c.Traces.ConsumeTraces(ctx, td)
+}
+
+type metricsConsumer struct {
+	consumer.Metrics
+	point *point
+}
+
+func (c metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	c.point.copyTo(func() ([]byte, error) { return (&pmetric.JSONMarshaler{}).MarshalMetrics(md) })
+	return c.Metrics.ConsumeMetrics(ctx, md)
+}
+
+type logsConsumer struct {
+	consumer.Logs
+	point *point
+}
+
+func (c logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	c.point.copyTo(func() ([]byte, error) { return (&plog.JSONMarshaler{}).MarshalLogs(ld) })
+	return c.Logs.ConsumeLogs(ctx, ld)
+}
+
+type profilesConsumer struct {
+	consumer.Profiles
+	point *point
+}
+
+func (c profilesConsumer) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	c.point.copyTo(func() ([]byte, error) { return (&pprofile.JSONMarshaler{}).MarshalProfiles(pd) })
+	return c.Profiles.ConsumeProfiles(ctx, pd)
+}
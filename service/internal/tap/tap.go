@@ -0,0 +1,241 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tap streams sampled copies of the data sent along the edges of the pipelines,
+// to debug a running collector without changing its configuration.
+package tap // import "go.opentelemetry.io/collector/service/internal/tap"
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+// FeatureGate is the feature gate that must be enabled for taps to be attached to the pipelines.
+var FeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"service.pipelineTap",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("controls whether taps can be attached to the pipelines from the tapz page of "+
+		"the zpages extension, to stream a sampled copy of the data passing through them as OTLP JSON."))
+
+const (
+	// MaxTaps is the maximum number of taps attached at the same time.
+	MaxTaps = 5
+
+	// bufferSize is the number of batches buffered per tap, the batches are dropped when it is full.
+	bufferSize = 16
+)
+
+var (
+	// ErrUnknownPoint is returned when attaching a tap to a pipeline or component which is not part of the pipelines.
+	ErrUnknownPoint = errors.New("no such pipeline or component in the pipeline")
+	// ErrTooManyTaps is returned when MaxTaps taps are already attached.
+	ErrTooManyTaps = errors.New("too many taps attached")
+)
+
+// Settings defines what a tap copies.
+type Settings struct {
+	// SamplingPercentage is the percentage of the batches copied, between 0 and 100.
+	SamplingPercentage float64
+
+	// RateLimit is the maximum number of batches copied per second.
+	RateLimit float64
+}
+
+type key struct {
+	pipelineID  component.ID
+	componentID component.ID
+}
+
+// Registry keeps the points the taps can be attached to, and the attached taps. The points are kept
+// when the pipelines are reloaded, so the taps keep receiving the data of the rebuilt pipelines.
+type Registry struct {
+	mu     sync.Mutex
+	points map[key]*point
+	taps   int
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{points: make(map[key]*point)}
+}
+
+// point returns the point of the data entering the component in the pipeline, or entering
+// the pipeline if componentID is empty.
+func (r *Registry) point(pipelineID, componentID component.ID) *point {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k := key{pipelineID: pipelineID, componentID: componentID}
+	p, ok := r.points[k]
+	if !ok {
+		p = &point{}
+		r.points[k] = p
+	}
+	return p
+}
+
+// Attach attaches a tap to the data entering the component in the pipeline, or entering
+// the pipeline if componentID is empty. The tap must be detached with Detach.
+func (r *Registry) Attach(pipelineID, componentID component.ID, set Settings) (*Tap, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.points[key{pipelineID: pipelineID, componentID: componentID}]
+	if !ok {
+		return nil, ErrUnknownPoint
+	}
+	if r.taps >= MaxTaps {
+		return nil, ErrTooManyTaps
+	}
+	r.taps++
+	t := &Tap{
+		point:              p,
+		samplingPercentage: set.SamplingPercentage,
+		limiter:            newLimiter(set.RateLimit),
+		data:               make(chan []byte, bufferSize),
+		random: func() float64 {
+			return rand.Float64() * 100 // #nosec G404 -- no need for a cryptographically secure source
+		},
+	}
+	p.add(t)
+	return t, nil
+}
+
+// Detach stops copying the data to the tap.
+func (r *Registry) Detach(t *Tap) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t.point.remove(t) {
+		r.taps--
+	}
+}
+
+// point is a place of the pipelines the taps can be attached to.
+type point struct {
+	mu   sync.Mutex
+	taps atomic.Pointer[[]*Tap]
+}
+
+func (p *point) add(t *Tap) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var taps []*Tap
+	if current := p.taps.Load(); current != nil {
+		taps = append(taps, *current...)
+	}
+	taps = append(taps, t)
+	p.taps.Store(&taps)
+}
+
+func (p *point) remove(t *Tap) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	current := p.taps.Load()
+	if current == nil {
+		return false
+	}
+	taps := make([]*Tap, 0, len(*current))
+	for _, other := range *current {
+		if other != t {
+			taps = append(taps, other)
+		}
+	}
+	if len(taps) == len(*current) {
+		return false
+	}
+	p.taps.Store(&taps)
+	return true
+}
+
+// copyTo sends the data returned by marshal to the attached taps sampling it, marshal is only
+// called if a tap does.
+func (p *point) copyTo(marshal func() ([]byte, error)) {
+	taps := p.taps.Load()
+	if taps == nil {
+		return
+	}
+	var buf []byte
+	for _, t := range *taps {
+		if !t.sample() {
+			continue
+		}
+		if buf == nil {
+			var err error
+			if buf, err = marshal(); err != nil {
+				return
+			}
+		}
+		t.send(buf)
+	}
+}
+
+// Tap receives a sampled copy of the data passing through a point of the pipelines.
+type Tap struct {
+	point              *point
+	samplingPercentage float64
+	limiter            *limiter
+	data               chan []byte
+	dropped            atomic.Int64
+	// random returns a number in [0, 100).
+	random func() float64
+}
+
+// Data returns the channel the copied batches are sent to, encoded as OTLP JSON.
+func (t *Tap) Data() <-chan []byte {
+	return t.data
+}
+
+// Dropped returns the number of sampled batches dropped because the tap was not read fast enough.
+func (t *Tap) Dropped() int64 {
+	return t.dropped.Load()
+}
+
+func (t *Tap) sample() bool {
+	return t.random() < t.samplingPercentage && t.limiter.allow(time.Now())
+}
+
+func (t *Tap) send(buf []byte) {
+	select {
+	case t.data <- buf:
+	default:
+		t.dropped.Add(1)
+	}
+}
+
+// limiter is a token bucket allowing rate events per second, in bursts of up to max(rate, 1) events.
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(rate float64) *limiter {
+	burst := rate
+	if burst < 1 {
+		burst = 1
+	}
+	return &limiter{rate: rate, burst: burst, tokens: burst}
+}
+
+func (l *limiter) allow(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	pipelineID  = component.NewID("traces")
+	componentID = component.NewID("batch")
+)
+
+func TestTapTraces(t *testing.T) {
+	r := NewRegistry()
+	sink := new(consumertest.TracesSink)
+	tc := r.Traces(pipelineID, componentID, sink)
+
+	// Without taps, the data is only passed along.
+	require.NoError(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Equal(t, 1, sink.SpanCount())
+
+	tp, err := r.Attach(pipelineID, componentID, Settings{SamplingPercentage: 100, RateLimit: 100})
+	require.NoError(t, err)
+	require.NoError(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.Equal(t, 3, sink.SpanCount())
+	require.Len(t, tp.Data(), 1)
+	td, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(<-tp.Data())
+	require.NoError(t, err)
+	assert.Equal(t, 2, td.SpanCount())
+
+	r.Detach(tp)
+	require.NoError(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.Empty(t, tp.Data())
+	// Detaching twice has no effect.
+	r.Detach(tp)
+	assert.Equal(t, 0, r.taps)
+}
+
+func TestTapMetricsAndLogs(t *testing.T) {
+	r := NewRegistry()
+	mc := r.Metrics(component.NewID("metrics"), component.ID{}, consumertest.NewNop())
+	lc := r.Logs(component.NewID("logs"), component.ID{}, consumertest.NewNop())
+
+	all := Settings{SamplingPercentage: 100, RateLimit: 100}
+	metricsTap, err := r.Attach(component.NewID("metrics"), component.ID{}, all)
+	require.NoError(t, err)
+	logsTap, err := r.Attach(component.NewID("logs"), component.ID{}, all)
+	require.NoError(t, err)
+
+	require.NoError(t, mc.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(2)))
+	require.NoError(t, lc.ConsumeLogs(context.Background(), testdata.GenerateLogs(3)))
+
+	md, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(<-metricsTap.Data())
+	require.NoError(t, err)
+	assert.Equal(t, 2, md.MetricCount())
+	ld, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(<-logsTap.Data())
+	require.NoError(t, err)
+	assert.Equal(t, 3, ld.LogRecordCount())
+}
+
+func TestAttachErrors(t *testing.T) {
+	r := NewRegistry()
+	_, err := r.Attach(pipelineID, componentID, Settings{SamplingPercentage: 100, RateLimit: 1})
+	assert.ErrorIs(t, err, ErrUnknownPoint)
+
+	r.Traces(pipelineID, componentID, consumertest.NewNop())
+	var taps []*Tap
+	for i := 0; i < MaxTaps; i++ {
+		tp, attachErr := r.Attach(pipelineID, componentID, Settings{SamplingPercentage: 100, RateLimit: 1})
+		require.NoError(t, attachErr)
+		taps = append(taps, tp)
+	}
+	_, err = r.Attach(pipelineID, componentID, Settings{SamplingPercentage: 100, RateLimit: 1})
+	assert.ErrorIs(t, err, ErrTooManyTaps)
+
+	r.Detach(taps[0])
+	_, err = r.Attach(pipelineID, componentID, Settings{SamplingPercentage: 100, RateLimit: 1})
+	assert.NoError(t, err)
+}
+
+func TestTapSamplingAndDrops(t *testing.T) {
+	r := NewRegistry()
+	tc := r.Traces(pipelineID, componentID, consumertest.NewNop())
+
+	sampled, err := r.Attach(pipelineID, componentID, Settings{SamplingPercentage: 50, RateLimit: 1000})
+	require.NoError(t, err)
+	randoms := []float64{10, 60, 49, 50}
+	sampled.random = func() float64 {
+		v := randoms[0]
+		randoms = randoms[1:]
+		return v
+	}
+	for i := 0; i < 4; i++ {
+		require.NoError(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	}
+	assert.Len(t, sampled.Data(), 2)
+	r.Detach(sampled)
+
+	full, err := r.Attach(pipelineID, componentID, Settings{SamplingPercentage: 100, RateLimit: 1000})
+	require.NoError(t, err)
+	for i := 0; i < bufferSize+3; i++ {
+		require.NoError(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	}
+	assert.Len(t, full.Data(), bufferSize)
+	assert.Equal(t, int64(3), full.Dropped())
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Now()
+	l := newLimiter(2)
+	assert.True(t, l.allow(now))
+	assert.True(t, l.allow(now))
+	assert.False(t, l.allow(now))
+	assert.False(t, l.allow(now.Add(250*time.Millisecond)))
+	assert.True(t, l.allow(now.Add(500*time.Millisecond)))
+	// The tokens do not accumulate above the burst.
+	assert.True(t, l.allow(now.Add(time.Hour)))
+	assert.True(t, l.allow(now.Add(time.Hour)))
+	assert.False(t, l.allow(now.Add(time.Hour)))
+
+	// A rate below 1 allows one event every 1/rate seconds.
+	l = newLimiter(0.5)
+	assert.True(t, l.allow(now))
+	assert.False(t, l.allow(now.Add(time.Second)))
+	assert.True(t, l.allow(now.Add(2*time.Second)))
+}
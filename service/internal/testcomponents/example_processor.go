@@ -19,7 +19,8 @@ var ExampleProcessorFactory = processor.NewFactory(
 	createDefaultConfig,
 	processor.WithTraces(createTracesProcessor, component.StabilityLevelDevelopment),
 	processor.WithMetrics(createMetricsProcessor, component.StabilityLevelDevelopment),
-	processor.WithLogs(createLogsProcessor, component.StabilityLevelDevelopment))
+	processor.WithLogs(createLogsProcessor, component.StabilityLevelDevelopment),
+	processor.WithProfiles(createProfilesProcessor, component.StabilityLevelDevelopment))
 
 // CreateDefaultConfig creates the default configuration for the Processor.
 func createDefaultConfig() component.Config {
@@ -47,11 +48,19 @@ func createLogsProcessor(_ context.Context, set processor.CreateSettings, _ comp
 	}, nil
 }
 
+func createProfilesProcessor(_ context.Context, set processor.CreateSettings, _ component.Config, nextConsumer consumer.Profiles) (processor.Profiles, error) {
+	return &ExampleProcessor{
+		ConsumeProfilesFunc: nextConsumer.ConsumeProfiles,
+		mutatesData:         set.ID.Name() == "mutate",
+	}, nil
+}
+
 type ExampleProcessor struct {
 	componentState
 	consumer.ConsumeTracesFunc
 	consumer.ConsumeMetricsFunc
 	consumer.ConsumeLogsFunc
+	consumer.ConsumeProfilesFunc
 	mutatesData bool
 }
 
@@ -19,7 +19,8 @@ var ExampleReceiverFactory = receiver.NewFactory(
 	createReceiverDefaultConfig,
 	receiver.WithTraces(createTracesReceiver, component.StabilityLevelDevelopment),
 	receiver.WithMetrics(createMetricsReceiver, component.StabilityLevelDevelopment),
-	receiver.WithLogs(createLogsReceiver, component.StabilityLevelDevelopment))
+	receiver.WithLogs(createLogsReceiver, component.StabilityLevelDevelopment),
+	receiver.WithProfiles(createProfilesReceiver, component.StabilityLevelDevelopment))
 
 func createReceiverDefaultConfig() component.Config {
 	return &struct{}{}
@@ -60,6 +61,17 @@ func createLogsReceiver(
 	return lr, nil
 }
 
+func createProfilesReceiver(
+	_ context.Context,
+	_ receiver.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Profiles,
+) (receiver.Profiles, error) {
+	pr := createReceiver(cfg)
+	pr.ConsumeProfilesFunc = nextConsumer.ConsumeProfiles
+	return pr, nil
+}
+
 func createReceiver(cfg component.Config) *ExampleReceiver {
 	// There must be one receiver for all data types. We maintain a map of
 	// receivers per config.
@@ -81,6 +93,7 @@ type ExampleReceiver struct {
 	consumer.ConsumeTracesFunc
 	consumer.ConsumeMetricsFunc
 	consumer.ConsumeLogsFunc
+	consumer.ConsumeProfilesFunc
 }
 
 // This is the map of already created example receivers for particular configurations.
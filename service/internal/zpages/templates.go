@@ -37,6 +37,10 @@ var (
 	pipelinesTableBytes    []byte
 	pipelinesTableTemplate = parseTemplate("pipelines_table", pipelinesTableBytes)
 
+	//go:embed templates/pipelines_graph.html
+	pipelinesGraphBytes    []byte
+	pipelinesGraphTemplate = parseTemplate("pipelines_graph", pipelinesGraphBytes)
+
 	//go:embed templates/properties_table.html
 	propertiesTableBytes    []byte
 	propertiesTableTemplate = parseTemplate("properties_table", propertiesTableBytes)
@@ -104,6 +108,22 @@ func WriteHTMLPipelinesSummaryTable(w io.Writer, spd SummaryPipelinesTableData)
 	}
 }
 
+// PipelinesGraphData contains data for the pipelines graph template.
+type PipelinesGraphData struct {
+	// Graph is the Mermaid source of the graph.
+	Graph string
+	// FormatParam is the URL parameter selecting the text format of the graph.
+	FormatParam string
+}
+
+// WriteHTMLPipelinesGraph writes the graph of the pipelines.
+// It does not write the header or footer.
+func WriteHTMLPipelinesGraph(w io.Writer, pgd PipelinesGraphData) {
+	if err := pipelinesGraphTemplate.Execute(w, pgd); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
+
 // ComponentHeaderData contains data for component header template.
 type ComponentHeaderData struct {
 	Name              string
@@ -153,6 +173,11 @@ func getValue(row [2]string) string {
 // FeatureGateTableData contains data for feature gate table template.
 type FeatureGateTableData struct {
 	Rows []FeatureGateTableRowData
+	// IDParam and EnabledParam are the form parameters posted to flip the mutable gates.
+	IDParam      string
+	EnabledParam string
+	// Toggle is whether the mutable gates can be flipped from the page.
+	Toggle bool
 }
 
 // FeatureGateTableRowData contains data for one row in feature gate table template.
@@ -164,6 +189,8 @@ type FeatureGateTableRowData struct {
 	FromVersion  string
 	ToVersion    string
 	ReferenceURL string
+	Owner        string
+	Mutable      bool
 }
 
 // WriteHTMLFeaturesTable writes a table summarizing registered feature gates.
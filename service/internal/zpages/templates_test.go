@@ -59,6 +59,9 @@ func TestNoCrash(t *testing.T) {
 			}},
 		})
 	})
+	assert.NotPanics(t, func() {
+		WriteHTMLPipelinesGraph(buf, PipelinesGraphData{Graph: "flowchart LR", FormatParam: "formatz"})
+	})
 	assert.NotPanics(t, func() {
 		WriteHTMLExtensionsSummaryTable(buf, SummaryExtensionsTableData{
 			Rows: []SummaryExtensionsTableRowData{{
@@ -76,7 +79,14 @@ func TestNoCrash(t *testing.T) {
 				Enabled:     false,
 				Description: "test gate",
 			},
-		}})
+			{
+				ID:          "mutable",
+				Enabled:     true,
+				Description: "mutable gate",
+				Owner:       "collector",
+				Mutable:     true,
+			},
+		}, IDParam: "id", EnabledParam: "enabled", Toggle: true})
 	})
 	assert.NotPanics(t, func() { WriteHTMLPageFooter(buf) })
 	assert.NotPanics(t, func() { WriteHTMLPageFooter(buf) })
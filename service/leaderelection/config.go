@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leaderelection // import "go.opentelemetry.io/collector/service/leaderelection"
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewDeadline = 10 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
+)
+
+var (
+	errNoLock           = errors.New("one of file or kubernetes must be set")
+	errSeveralLocks     = errors.New("only one of file or kubernetes can be set")
+	errNoFilePath       = errors.New("file::path must be set")
+	errNoLeaseName      = errors.New("kubernetes::name must be set")
+	errNegativeDuration = errors.New("lease_duration, renew_deadline and retry_period must not be negative")
+	errDurationsOrder   = errors.New("lease_duration must be greater than renew_deadline, which must be greater than retry_period")
+)
+
+// Config defines the configuration of the leader election, which makes a single collector among
+// the ones sharing the same lock run its receivers, while the others stand by.
+type Config struct {
+	// Identity identifies the collector in the lock, the host name if not set.
+	Identity string `mapstructure:"identity"`
+
+	// LeaseDuration is how long the standby collectors wait, after the last renewal of the lock
+	// by the leader, before taking the leadership over. 15s if not set.
+	LeaseDuration time.Duration `mapstructure:"lease_duration"`
+
+	// RenewDeadline is how long the leader retries to renew the lock before giving the
+	// leadership up. 10s if not set.
+	RenewDeadline time.Duration `mapstructure:"renew_deadline"`
+
+	// RetryPeriod is the interval between the attempts to acquire or renew the lock. 2s if not set.
+	RetryPeriod time.Duration `mapstructure:"retry_period"`
+
+	// File if not nil, uses a lock on a file shared by the collectors running on the same host.
+	File *FileConfig `mapstructure:"file"`
+
+	// Kubernetes if not nil, uses a Kubernetes Lease as the lock. The collector must run in the
+	// cluster, with a service account allowed to get, create and update the Lease.
+	Kubernetes *KubernetesConfig `mapstructure:"kubernetes"`
+}
+
+// FileConfig defines the configuration of a file lock.
+type FileConfig struct {
+	// Path is the path of the file locked by the leader, it is created if it does not exist.
+	Path string `mapstructure:"path"`
+}
+
+// KubernetesConfig defines the configuration of a Kubernetes Lease lock.
+type KubernetesConfig struct {
+	// Namespace is the namespace of the Lease, the namespace of the collector pod if not set.
+	Namespace string `mapstructure:"namespace"`
+
+	// Name is the name of the Lease, it is created if it does not exist.
+	Name string `mapstructure:"name"`
+}
+
+// Validate checks if the leader election configuration is valid.
+func (cfg *Config) Validate() error {
+	switch {
+	case cfg.File == nil && cfg.Kubernetes == nil:
+		return errNoLock
+	case cfg.File != nil && cfg.Kubernetes != nil:
+		return errSeveralLocks
+	case cfg.File != nil && cfg.File.Path == "":
+		return errNoFilePath
+	case cfg.Kubernetes != nil && cfg.Kubernetes.Name == "":
+		return errNoLeaseName
+	}
+	if cfg.LeaseDuration < 0 || cfg.RenewDeadline < 0 || cfg.RetryPeriod < 0 {
+		return errNegativeDuration
+	}
+	withDefaults := cfg.withDefaults()
+	if withDefaults.LeaseDuration <= withDefaults.RenewDeadline || withDefaults.RenewDeadline <= withDefaults.RetryPeriod {
+		return errDurationsOrder
+	}
+	return nil
+}
+
+// withDefaults returns a copy of the configuration with the default values of the settings not set.
+func (cfg Config) withDefaults() Config {
+	if cfg.LeaseDuration == 0 {
+		cfg.LeaseDuration = defaultLeaseDuration
+	}
+	if cfg.RenewDeadline == 0 {
+		cfg.RenewDeadline = defaultRenewDeadline
+	}
+	if cfg.RetryPeriod == 0 {
+		cfg.RetryPeriod = defaultRetryPeriod
+	}
+	return cfg
+}
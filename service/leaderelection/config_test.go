@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leaderelection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *Config
+		expected error
+	}{
+		{
+			name: "file",
+			cfg:  &Config{File: &FileConfig{Path: "leader.lock"}},
+		},
+		{
+			name: "kubernetes",
+			cfg:  &Config{Kubernetes: &KubernetesConfig{Name: "otelcol"}, LeaseDuration: time.Minute},
+		},
+		{
+			name:     "no lock",
+			cfg:      &Config{},
+			expected: errNoLock,
+		},
+		{
+			name:     "several locks",
+			cfg:      &Config{File: &FileConfig{Path: "leader.lock"}, Kubernetes: &KubernetesConfig{Name: "otelcol"}},
+			expected: errSeveralLocks,
+		},
+		{
+			name:     "no file path",
+			cfg:      &Config{File: &FileConfig{}},
+			expected: errNoFilePath,
+		},
+		{
+			name:     "no lease name",
+			cfg:      &Config{Kubernetes: &KubernetesConfig{Namespace: "monitoring"}},
+			expected: errNoLeaseName,
+		},
+		{
+			name:     "negative duration",
+			cfg:      &Config{File: &FileConfig{Path: "leader.lock"}, RetryPeriod: -time.Second},
+			expected: errNegativeDuration,
+		},
+		{
+			name:     "renew deadline longer than the lease",
+			cfg:      &Config{File: &FileConfig{Path: "leader.lock"}, LeaseDuration: 5 * time.Second},
+			expected: errDurationsOrder,
+		},
+		{
+			name:     "retry period longer than the renew deadline",
+			cfg:      &Config{File: &FileConfig{Path: "leader.lock"}, RetryPeriod: time.Minute},
+			expected: errDurationsOrder,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.cfg.Validate())
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leaderelection // import "go.opentelemetry.io/collector/service/leaderelection"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrLeadershipLost is returned by Elector.Run when the leadership is lost.
+var ErrLeadershipLost = errors.New("the leadership was lost")
+
+// lock is a lock held by a single collector at a time.
+type lock interface {
+	// tryAcquire acquires the lock, or renews it if it is already held. It returns false
+	// if the lock is held by another collector.
+	tryAcquire(ctx context.Context) (bool, error)
+
+	// release releases the lock, if held.
+	release(ctx context.Context) error
+}
+
+// Elector campaigns for the leadership among the collectors sharing the same lock.
+type Elector struct {
+	cfg    Config
+	lock   lock
+	logger *zap.Logger
+}
+
+// New creates an Elector from the given configuration.
+func New(cfg *Config, logger *zap.Logger) (*Elector, error) {
+	withDefaults := cfg.withDefaults()
+	if withDefaults.Identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the host name as identity: %w", err)
+		}
+		withDefaults.Identity = hostname
+	}
+
+	e := &Elector{cfg: withDefaults, logger: logger}
+	if cfg.File != nil {
+		e.lock = newFileLock(cfg.File, withDefaults.Identity)
+		return e, nil
+	}
+	var err error
+	if e.lock, err = newKubernetesLock(cfg.Kubernetes, withDefaults.Identity, withDefaults.LeaseDuration); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Run campaigns for the leadership until ctx is done, calling onStartedLeading once the leadership is
+// acquired. Once acquired, the leadership is renewed until ctx is done, when Run returns nil, or until
+// it is lost, when Run returns ErrLeadershipLost. The leadership is not released when Run returns.
+func (e *Elector) Run(ctx context.Context, onStartedLeading func()) error {
+	ticker := time.NewTicker(e.cfg.RetryPeriod)
+	defer ticker.Stop()
+
+	leading := false
+	var lastRenew time.Time
+	for {
+		held, err := e.lock.tryAcquire(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		switch {
+		case err != nil:
+			e.logger.Warn("Failed to acquire or renew the leadership", zap.Error(err))
+			if leading && time.Since(lastRenew) > e.cfg.RenewDeadline {
+				return ErrLeadershipLost
+			}
+		case held:
+			lastRenew = time.Now()
+			if !leading {
+				leading = true
+				e.logger.Info("Acquired the leadership", zap.String("identity", e.cfg.Identity))
+				onStartedLeading()
+			}
+		case leading:
+			return ErrLeadershipLost
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Release releases the leadership if it is held, so that a standby collector can take it over
+// without waiting for the lease to expire.
+func (e *Elector) Release(ctx context.Context) error {
+	return e.lock.release(ctx)
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leaderelection
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type result struct {
+	held bool
+	err  error
+}
+
+// scriptedLock returns the given results, then the last one forever.
+type scriptedLock struct {
+	results  []result
+	attempts atomic.Int64
+	released atomic.Bool
+}
+
+func (l *scriptedLock) tryAcquire(context.Context) (bool, error) {
+	i := int(l.attempts.Add(1)) - 1
+	if i >= len(l.results) {
+		i = len(l.results) - 1
+	}
+	return l.results[i].held, l.results[i].err
+}
+
+func (l *scriptedLock) release(context.Context) error {
+	l.released.Store(true)
+	return nil
+}
+
+func newTestElector(l lock, renewDeadline time.Duration) *Elector {
+	return &Elector{
+		cfg:    Config{Identity: "test", RenewDeadline: renewDeadline, RetryPeriod: time.Millisecond},
+		lock:   l,
+		logger: zap.NewNop(),
+	}
+}
+
+func TestElectorLosesLeadership(t *testing.T) {
+	l := &scriptedLock{results: []result{{held: false}, {held: true}, {held: true}, {held: false}}}
+	e := newTestElector(l, time.Minute)
+
+	var started atomic.Int64
+	assert.ErrorIs(t, e.Run(context.Background(), func() { started.Add(1) }), ErrLeadershipLost)
+	assert.Equal(t, int64(1), started.Load())
+	assert.Equal(t, int64(4), l.attempts.Load())
+
+	require.NoError(t, e.Release(context.Background()))
+	assert.True(t, l.released.Load())
+}
+
+func TestElectorRenewDeadline(t *testing.T) {
+	unavailable := errors.New("unavailable")
+	l := &scriptedLock{results: []result{{err: unavailable}, {held: true}, {err: unavailable}}}
+	e := newTestElector(l, 50*time.Millisecond)
+
+	var started atomic.Int64
+	assert.ErrorIs(t, e.Run(context.Background(), func() { started.Add(1) }), ErrLeadershipLost)
+	assert.Equal(t, int64(1), started.Load())
+	assert.Greater(t, l.attempts.Load(), int64(3))
+}
+
+func TestElectorStandby(t *testing.T) {
+	l := &scriptedLock{results: []result{{held: false}}}
+	e := newTestElector(l, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- e.Run(ctx, func() { t.Error("unexpected leadership") })
+	}()
+	assert.Eventually(t, func() bool { return l.attempts.Load() > 3 }, time.Second, time.Millisecond)
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestNewElector(t *testing.T) {
+	e, err := New(&Config{File: &FileConfig{Path: filepath.Join(t.TempDir(), "leader.lock")}}, zap.NewNop())
+	require.NoError(t, err)
+	assert.NotEmpty(t, e.cfg.Identity)
+	assert.Equal(t, defaultLeaseDuration, e.cfg.LeaseDuration)
+	assert.Equal(t, defaultRetryPeriod, e.cfg.RetryPeriod)
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	_, err = New(&Config{Kubernetes: &KubernetesConfig{Name: "otelcol"}}, zap.NewNop())
+	assert.ErrorIs(t, err, errNotInCluster)
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leaderelection // import "go.opentelemetry.io/collector/service/leaderelection"
+
+import (
+	"context"
+	"os"
+)
+
+// fileLock is held by the process holding an exclusive lock on the file. The lock is released by the
+// operating system when the process exits, so it does not need to be renewed.
+type fileLock struct {
+	path     string
+	identity string
+	file     *os.File
+}
+
+func newFileLock(cfg *FileConfig, identity string) *fileLock {
+	return &fileLock{path: cfg.Path, identity: identity}
+}
+
+func (l *fileLock) tryAcquire(context.Context) (bool, error) {
+	if l.file != nil {
+		return true, nil
+	}
+	f, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return false, err
+	}
+	locked, err := lockFile(f)
+	if err != nil || !locked {
+		_ = f.Close()
+		return false, err
+	}
+	// The identity of the leader is only informative.
+	if err = f.Truncate(0); err == nil {
+		_, err = f.WriteAt([]byte(l.identity+"\n"), 0)
+	}
+	if err != nil {
+		_ = unlockFile(f)
+		_ = f.Close()
+		return false, err
+	}
+	l.file = f
+	return true, nil
+}
+
+func (l *fileLock) release(context.Context) error {
+	if l.file == nil {
+		return nil
+	}
+	err := unlockFile(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	return err
+}
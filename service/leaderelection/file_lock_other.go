@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly && !windows
+
+package leaderelection // import "go.opentelemetry.io/collector/service/leaderelection"
+
+import (
+	"errors"
+	"os"
+)
+
+var errFileLockUnsupported = errors.New("file locks are not supported on this platform")
+
+func lockFile(*os.File) (bool, error) {
+	return false, errFileLockUnsupported
+}
+
+func unlockFile(*os.File) error {
+	return errFileLockUnsupported
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly || windows
+
+package leaderelection
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileLock(t *testing.T) {
+	cfg := &FileConfig{Path: filepath.Join(t.TempDir(), "leader.lock")}
+	first := newFileLock(cfg, "first")
+	second := newFileLock(cfg, "second")
+
+	held, err := first.tryAcquire(context.Background())
+	require.NoError(t, err)
+	assert.True(t, held)
+	content, err := os.ReadFile(cfg.Path)
+	require.NoError(t, err)
+	assert.Equal(t, "first\n", string(content))
+
+	// Renewing the lock is a no-op.
+	held, err = first.tryAcquire(context.Background())
+	require.NoError(t, err)
+	assert.True(t, held)
+
+	held, err = second.tryAcquire(context.Background())
+	require.NoError(t, err)
+	assert.False(t, held)
+
+	require.NoError(t, first.release(context.Background()))
+	require.NoError(t, first.release(context.Background()))
+	held, err = second.tryAcquire(context.Background())
+	require.NoError(t, err)
+	assert.True(t, held)
+	require.NoError(t, second.release(context.Background()))
+}
+
+func TestFileLockInvalidPath(t *testing.T) {
+	l := newFileLock(&FileConfig{Path: filepath.Join(t.TempDir(), "missing", "leader.lock")}, "first")
+	held, err := l.tryAcquire(context.Background())
+	assert.Error(t, err)
+	assert.False(t, held)
+}
@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leaderelection // import "go.opentelemetry.io/collector/service/leaderelection"
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	// microTimeFormat is the format of the times of a Lease.
+	microTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+var errNotInCluster = errors.New("the Kubernetes lock requires the collector to run in a Kubernetes cluster")
+
+type lease struct {
+	APIVersion string         `json:"apiVersion"`
+	Kind       string         `json:"kind"`
+	Metadata   map[string]any `json:"metadata"`
+	Spec       leaseSpec      `json:"spec"`
+}
+
+type leaseSpec struct {
+	HolderIdentity       string `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds int32  `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          string `json:"acquireTime,omitempty"`
+	RenewTime            string `json:"renewTime,omitempty"`
+	LeaseTransitions     int32  `json:"leaseTransitions,omitempty"`
+}
+
+// kubernetesLock is held by the collector named as the holder of a Lease, while it renews the Lease.
+// The Lease is updated with the resource version it was read with, so that only one of the collectors
+// updating it concurrently succeeds.
+type kubernetesLock struct {
+	client        *http.Client
+	leasesURL     string
+	tokenPath     string
+	namespace     string
+	name          string
+	identity      string
+	leaseDuration time.Duration
+
+	// The holder and renew time of the Lease when it was last read, and when they were first observed.
+	// The expiration of the Lease is computed from the local clock only, to be immune to clock skews.
+	observedRecord string
+	observedTime   time.Time
+}
+
+// newKubernetesLock creates a lock using the in-cluster configuration of the collector pod.
+func newKubernetesLock(cfg *KubernetesConfig, identity string, leaseDuration time.Duration) (*kubernetesLock, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errNotInCluster
+	}
+	caCert, err := os.ReadFile(serviceAccountDir + "/ca.crt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the Kubernetes CA certificate: %w", err)
+	}
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(caCert) {
+		return nil, errors.New("invalid Kubernetes CA certificate")
+	}
+	namespace := cfg.Namespace
+	if namespace == "" {
+		ns, err := os.ReadFile(serviceAccountDir + "/namespace")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the namespace of the collector: %w", err)
+		}
+		namespace = strings.TrimSpace(string(ns))
+	}
+	client := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}},
+	}
+	apiURL := "https://" + net.JoinHostPort(host, port)
+	l := newKubernetesLockWithClient(client, apiURL, namespace, cfg.Name, identity, leaseDuration)
+	l.tokenPath = serviceAccountDir + "/token"
+	return l, nil
+}
+
+func newKubernetesLockWithClient(client *http.Client, apiURL, namespace, name, identity string, leaseDuration time.Duration) *kubernetesLock {
+	return &kubernetesLock{
+		client:        client,
+		leasesURL:     apiURL + "/apis/coordination.k8s.io/v1/namespaces/" + namespace + "/leases",
+		namespace:     namespace,
+		name:          name,
+		identity:      identity,
+		leaseDuration: leaseDuration,
+	}
+}
+
+func (l *kubernetesLock) tryAcquire(ctx context.Context) (bool, error) {
+	current, err := l.get(ctx)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	if current == nil {
+		created := &lease{
+			APIVersion: "coordination.k8s.io/v1",
+			Kind:       "Lease",
+			Metadata:   map[string]any{"name": l.name, "namespace": l.namespace},
+		}
+		l.hold(created, now)
+		return l.write(ctx, http.MethodPost, l.leasesURL, created, now)
+	}
+
+	record := current.Spec.HolderIdentity + "/" + current.Spec.RenewTime
+	if record != l.observedRecord {
+		l.observedRecord, l.observedTime = record, now
+	}
+	holder := current.Spec.HolderIdentity
+	expiry := l.observedTime.Add(time.Duration(current.Spec.LeaseDurationSeconds) * time.Second)
+	if holder != "" && holder != l.identity && now.Before(expiry) {
+		return false, nil
+	}
+	l.hold(current, now)
+	return l.write(ctx, http.MethodPut, l.leasesURL+"/"+l.name, current, now)
+}
+
+// hold updates the Lease to be held by the collector.
+func (l *kubernetesLock) hold(ls *lease, now time.Time) {
+	if ls.Spec.HolderIdentity != l.identity {
+		ls.Spec.HolderIdentity = l.identity
+		ls.Spec.AcquireTime = now.UTC().Format(microTimeFormat)
+		ls.Spec.LeaseTransitions++
+	}
+	ls.Spec.RenewTime = now.UTC().Format(microTimeFormat)
+	ls.Spec.LeaseDurationSeconds = int32(l.leaseDuration / time.Second)
+}
+
+func (l *kubernetesLock) release(ctx context.Context) error {
+	current, err := l.get(ctx)
+	if err != nil || current == nil || current.Spec.HolderIdentity != l.identity {
+		return err
+	}
+	// Let the other collectors take the leadership over right away.
+	now := time.Now()
+	current.Spec.HolderIdentity = ""
+	current.Spec.RenewTime = now.UTC().Format(microTimeFormat)
+	current.Spec.LeaseDurationSeconds = 1
+	_, err = l.write(ctx, http.MethodPut, l.leasesURL+"/"+l.name, current, now)
+	return err
+}
+
+// get returns the Lease, or nil if it does not exist.
+func (l *kubernetesLock) get(ctx context.Context) (*lease, error) {
+	resp, err := l.do(ctx, http.MethodGet, l.leasesURL+"/"+l.name, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		current := &lease{}
+		if err = json.NewDecoder(resp.Body).Decode(current); err != nil {
+			return nil, fmt.Errorf("failed to decode the Lease: %w", err)
+		}
+		return current, nil
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, responseError(resp)
+	}
+}
+
+// write creates or updates the Lease, it returns false if another collector updated it concurrently.
+func (l *kubernetesLock) write(ctx context.Context, method, url string, ls *lease, now time.Time) (bool, error) {
+	body, err := json.Marshal(ls)
+	if err != nil {
+		return false, err
+	}
+	resp, err := l.do(ctx, method, url, body)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		l.observedRecord, l.observedTime = ls.Spec.HolderIdentity+"/"+ls.Spec.RenewTime, now
+		return true, nil
+	case http.StatusConflict:
+		return false, nil
+	default:
+		return false, responseError(resp)
+	}
+}
+
+func (l *kubernetesLock) do(ctx context.Context, method, url string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if l.tokenPath != "" {
+		// The token is read for every request since it is rotated.
+		token, err := os.ReadFile(l.tokenPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the service account token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	return l.client.Do(req)
+}
+
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("unexpected response from the Kubernetes API, status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
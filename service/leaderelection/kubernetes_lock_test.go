@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leaderelection
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLeaseServer serves a single Lease, updated with optimistic concurrency like the Kubernetes API.
+type fakeLeaseServer struct {
+	mu      sync.Mutex
+	lease   *lease
+	version int
+}
+
+func (s *fakeLeaseServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	const leasePath = "/apis/coordination.k8s.io/v1/namespaces/monitoring/leases"
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == leasePath+"/otelcol":
+		if s.lease == nil {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(s.lease)
+	case r.Method == http.MethodPost && r.URL.Path == leasePath:
+		if s.lease != nil {
+			http.Error(w, "already exists", http.StatusConflict)
+			return
+		}
+		s.store(w, r, http.StatusCreated)
+	case r.Method == http.MethodPut && r.URL.Path == leasePath+"/otelcol":
+		updated := &lease{}
+		if err := json.NewDecoder(r.Body).Decode(updated); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if updated.Metadata["resourceVersion"] != strconv.Itoa(s.version) {
+			http.Error(w, "conflict", http.StatusConflict)
+			return
+		}
+		s.save(w, updated, http.StatusOK)
+	default:
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+func (s *fakeLeaseServer) store(w http.ResponseWriter, r *http.Request, status int) {
+	created := &lease{}
+	if err := json.NewDecoder(r.Body).Decode(created); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.save(w, created, status)
+}
+
+func (s *fakeLeaseServer) save(w http.ResponseWriter, ls *lease, status int) {
+	s.version++
+	ls.Metadata["resourceVersion"] = strconv.Itoa(s.version)
+	s.lease = ls
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(ls)
+}
+
+func (s *fakeLeaseServer) holder() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lease.Spec.HolderIdentity
+}
+
+func TestKubernetesLock(t *testing.T) {
+	fake := &fakeLeaseServer{}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	first := newKubernetesLockWithClient(srv.Client(), srv.URL, "monitoring", "otelcol", "first", time.Second)
+	second := newKubernetesLockWithClient(srv.Client(), srv.URL, "monitoring", "otelcol", "second", time.Second)
+
+	// The Lease is created by the first collector.
+	held, err := first.tryAcquire(context.Background())
+	require.NoError(t, err)
+	assert.True(t, held)
+	assert.Equal(t, "first", fake.holder())
+	assert.Equal(t, int32(1), fake.lease.Spec.LeaseDurationSeconds)
+	assert.Equal(t, int32(1), fake.lease.Spec.LeaseTransitions)
+
+	held, err = second.tryAcquire(context.Background())
+	require.NoError(t, err)
+	assert.False(t, held)
+
+	// The first collector renews the Lease.
+	held, err = first.tryAcquire(context.Background())
+	require.NoError(t, err)
+	assert.True(t, held)
+	assert.Equal(t, int32(1), fake.lease.Spec.LeaseTransitions)
+
+	// The Lease expires when it is not renewed.
+	held, err = second.tryAcquire(context.Background())
+	require.NoError(t, err)
+	assert.False(t, held)
+	second.observedTime = second.observedTime.Add(-2 * time.Second)
+	held, err = second.tryAcquire(context.Background())
+	require.NoError(t, err)
+	assert.True(t, held)
+	assert.Equal(t, "second", fake.holder())
+	assert.Equal(t, int32(2), fake.lease.Spec.LeaseTransitions)
+
+	held, err = first.tryAcquire(context.Background())
+	require.NoError(t, err)
+	assert.False(t, held)
+
+	// Releasing the Lease lets the other collector acquire it once the shortened lease expires.
+	require.NoError(t, first.release(context.Background()))
+	assert.Equal(t, "second", fake.holder())
+	require.NoError(t, second.release(context.Background()))
+	assert.Equal(t, "", fake.holder())
+	held, err = first.tryAcquire(context.Background())
+	require.NoError(t, err)
+	assert.True(t, held)
+}
+
+func TestKubernetesLockConflict(t *testing.T) {
+	fake := &fakeLeaseServer{}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	first := newKubernetesLockWithClient(srv.Client(), srv.URL, "monitoring", "otelcol", "first", time.Second)
+	held, err := first.tryAcquire(context.Background())
+	require.NoError(t, err)
+	assert.True(t, held)
+
+	// Another collector updated the Lease since it was read.
+	stale := *fake.lease
+	stale.Metadata = map[string]any{"name": "otelcol", "resourceVersion": "0"}
+	written, err := first.write(context.Background(), http.MethodPut, first.leasesURL+"/otelcol", &stale, time.Now())
+	require.NoError(t, err)
+	assert.False(t, written)
+}
+
+func TestKubernetesLockError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	l := newKubernetesLockWithClient(srv.Client(), srv.URL, "monitoring", "otelcol", "first", time.Second)
+	held, err := l.tryAcquire(context.Background())
+	assert.EqualError(t, err, "unexpected response from the Kubernetes API, status 403: forbidden")
+	assert.False(t, held)
+}
+
+func TestKubernetesLockNotInCluster(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	_, err := newKubernetesLock(&KubernetesConfig{Name: "otelcol"}, "first", time.Second)
+	assert.ErrorIs(t, err, errNotInCluster)
+}
@@ -0,0 +1,370 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package opamp implements an OpAMP client reporting the state of the collector to an OpAMP server,
+// and receiving the configuration of the collector from the server.
+package opamp // import "go.opentelemetry.io/collector/service/opamp"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	requestTimeout    = 30 * time.Second
+	disconnectTimeout = 5 * time.Second
+	contentType       = "application/x-protobuf"
+)
+
+// RemoteConfig is a configuration offered by the OpAMP server.
+type RemoteConfig struct {
+	// Files are the YAML configuration files, in the order of their names.
+	Files []ConfigFile
+	// Hash identifies the configuration, it is reported with the status of its application.
+	Hash []byte
+}
+
+// ConfigFile is a file of a remote configuration.
+type ConfigFile struct {
+	Name string
+	Body []byte
+}
+
+// Settings holds the settings of the Client.
+type Settings struct {
+	// BuildInfo describes the collector to the server.
+	BuildInfo component.BuildInfo
+
+	// Logger logs the failures of the requests.
+	Logger *zap.Logger
+
+	// InstanceID is the service.instance.id of the collector, reported to the server and used as
+	// the instance UID when instance_uid is not set and it is a UUID.
+	InstanceID string
+}
+
+// Client reports the state of the collector to an OpAMP server with the plain HTTP transport,
+// polling the server at the configured interval and whenever the state changes.
+type Client struct {
+	cfg         Config
+	logger      *zap.Logger
+	httpClient  *http.Client
+	instanceUID uuid.UUID
+	description *protobufs.AgentDescription
+
+	remoteConfigs chan *RemoteConfig
+	// updated is notified when the state changes, so that it is reported without waiting.
+	updated chan struct{}
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	mu          sync.Mutex
+	sequenceNum uint64
+	// fullState is set when the next message must report the full state, which is the case of
+	// the first message, of the messages following a failed request, and when the server asks for it.
+	fullState              bool
+	health                 *protobufs.ComponentHealth
+	healthChanged          bool
+	effectiveConfig        []byte
+	effectiveConfigChanged bool
+	remoteStatus           *protobufs.RemoteConfigStatus
+	remoteStatusChanged    bool
+	// receivedHash is the hash of the last remote configuration received, which is not delivered again.
+	receivedHash []byte
+}
+
+// NewClient returns a Client for the configuration, which must be valid.
+func NewClient(cfg Config, set Settings) (*Client, error) {
+	if cfg.PollingInterval == 0 {
+		cfg.PollingInterval = defaultPollingInterval
+	}
+	instanceUID, err := uuid.NewRandom()
+	switch {
+	case cfg.InstanceUID != "":
+		instanceUID, err = uuid.Parse(cfg.InstanceUID)
+	case set.InstanceID != "":
+		if id, parseErr := uuid.Parse(set.InstanceID); parseErr == nil {
+			instanceUID = id
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the instance UID: %w", err)
+	}
+	tlsCfg, err := cfg.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+
+	instanceID := set.InstanceID
+	if instanceID == "" {
+		instanceID = instanceUID.String()
+	}
+	description := &protobufs.AgentDescription{
+		IdentifyingAttributes: []*protobufs.KeyValue{
+			stringKeyValue("service.name", set.BuildInfo.Command),
+			stringKeyValue("service.version", set.BuildInfo.Version),
+			stringKeyValue("service.instance.id", instanceID),
+		},
+		NonIdentifyingAttributes: []*protobufs.KeyValue{
+			stringKeyValue("os.type", runtime.GOOS),
+			stringKeyValue("host.arch", runtime.GOARCH),
+		},
+	}
+	if hostname, hostErr := os.Hostname(); hostErr == nil {
+		description.NonIdentifyingAttributes = append(description.NonIdentifyingAttributes, stringKeyValue("host.name", hostname))
+	}
+
+	return &Client{
+		cfg:           cfg,
+		logger:        set.Logger,
+		httpClient:    &http.Client{Transport: transport, Timeout: requestTimeout},
+		instanceUID:   instanceUID,
+		description:   description,
+		remoteConfigs: make(chan *RemoteConfig, 1),
+		updated:       make(chan struct{}, 1),
+		fullState:     true,
+		health:        &protobufs.ComponentHealth{},
+		remoteStatus:  &protobufs.RemoteConfigStatus{},
+	}, nil
+}
+
+// InstanceUID returns the UUID identifying the collector on the server.
+func (c *Client) InstanceUID() string {
+	return c.instanceUID.String()
+}
+
+// RemoteConfigs returns the channel the remote configurations are delivered on when accept_remote_config
+// is set. A configuration which is not consumed is replaced by the next one. The status of the application
+// of every configuration must be set with SetRemoteConfigStatus.
+func (c *Client) RemoteConfigs() <-chan *RemoteConfig {
+	return c.remoteConfigs
+}
+
+// Start starts polling the server.
+func (c *Client) Start(context.Context) error {
+	var ctx context.Context
+	ctx, c.cancel = context.WithCancel(context.Background())
+	c.done = make(chan struct{})
+	go c.run(ctx)
+	return nil
+}
+
+// Shutdown stops polling the server, and tells the server the collector disconnects, waiting
+// for the server at most 5s so that an unavailable server does not delay the shutdown.
+func (c *Client) Shutdown(ctx context.Context) error {
+	if c.cancel == nil {
+		return nil
+	}
+	c.cancel()
+	<-c.done
+	defer c.httpClient.CloseIdleConnections()
+
+	ctx, cancel := context.WithTimeout(ctx, disconnectTimeout)
+	defer cancel()
+	c.mu.Lock()
+	c.sequenceNum++
+	msg := &protobufs.AgentToServer{
+		InstanceUid:     c.instanceUID[:],
+		SequenceNum:     c.sequenceNum,
+		AgentDisconnect: &protobufs.AgentDisconnect{},
+	}
+	c.mu.Unlock()
+	_, err := c.send(ctx, msg)
+	return err
+}
+
+// SetEffectiveConfig sets the YAML of the effective configuration of the collector.
+func (c *Client) SetEffectiveConfig(effectiveConfig []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if bytes.Equal(c.effectiveConfig, effectiveConfig) {
+		return
+	}
+	c.effectiveConfig = effectiveConfig
+	c.effectiveConfigChanged = true
+	c.notify()
+}
+
+// SetHealth sets the health of the collector, with the time the collector started and its status.
+func (c *Client) SetHealth(healthy bool, startTime time.Time, status string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	health := &protobufs.ComponentHealth{Healthy: healthy, StartTimeUnixNano: uint64(startTime.UnixNano()), Status: status}
+	if startTime.IsZero() {
+		health.StartTimeUnixNano = 0
+	}
+	if proto.Equal(c.health, health) {
+		return
+	}
+	c.health = health
+	c.healthChanged = true
+	c.notify()
+}
+
+// SetRemoteConfigStatus sets the status of the application of the remote configuration with the hash,
+// applied if err is nil, failed otherwise.
+func (c *Client) SetRemoteConfigStatus(hash []byte, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.remoteStatus = &protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: hash,
+		Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
+	}
+	if err != nil {
+		c.remoteStatus.Status = protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED
+		c.remoteStatus.ErrorMessage = err.Error()
+	}
+	c.remoteStatusChanged = true
+	c.notify()
+}
+
+// notify wakes the polling loop up, c.mu must be held.
+func (c *Client) notify() {
+	select {
+	case c.updated <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Client) run(ctx context.Context) {
+	defer close(c.done)
+	ticker := time.NewTicker(c.cfg.PollingInterval)
+	defer ticker.Stop()
+	for {
+		c.poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-c.updated:
+		}
+	}
+}
+
+// poll reports the changes of the state to the server, and handles its response.
+func (c *Client) poll(ctx context.Context) {
+	resp, err := c.send(ctx, c.nextMessage())
+	if err != nil {
+		if ctx.Err() == nil {
+			c.logger.Warn("Failed to poll the OpAMP server", zap.String("endpoint", c.cfg.Endpoint), zap.Error(err))
+		}
+		// The server may not have received the changes, they are all reported again.
+		c.mu.Lock()
+		c.fullState = true
+		c.mu.Unlock()
+		return
+	}
+	c.handle(resp)
+}
+
+// nextMessage returns the message reporting the changes of the state since the previous message.
+// The health and the remote configuration status are replaced rather than modified once set, so
+// that they are shared with the message.
+func (c *Client) nextMessage() *protobufs.AgentToServer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sequenceNum++
+	capabilities := protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus |
+		protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig |
+		protobufs.AgentCapabilities_AgentCapabilities_ReportsHealth
+	if c.cfg.AcceptRemoteConfig {
+		capabilities |= protobufs.AgentCapabilities_AgentCapabilities_AcceptsRemoteConfig |
+			protobufs.AgentCapabilities_AgentCapabilities_ReportsRemoteConfig
+	}
+	msg := &protobufs.AgentToServer{
+		InstanceUid:  c.instanceUID[:],
+		SequenceNum:  c.sequenceNum,
+		Capabilities: uint64(capabilities),
+	}
+	if c.fullState {
+		msg.AgentDescription = c.description
+	}
+	if c.fullState || c.healthChanged {
+		msg.Health = c.health
+	}
+	if (c.fullState || c.effectiveConfigChanged) && c.effectiveConfig != nil {
+		msg.EffectiveConfig = effectiveConfigMessage(c.effectiveConfig)
+	}
+	if c.cfg.AcceptRemoteConfig && (c.fullState || c.remoteStatusChanged) {
+		msg.RemoteConfigStatus = c.remoteStatus
+	}
+	c.fullState, c.healthChanged, c.effectiveConfigChanged, c.remoteStatusChanged = false, false, false, false
+	return msg
+}
+
+func (c *Client) send(ctx context.Context, msg *protobufs.AgentToServer) (*protobufs.ServerToAgent, error) {
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range c.cfg.Headers {
+		req.Header.Set(k, string(v))
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the server responded with status %q", resp.Status)
+	}
+	serverMsg := &protobufs.ServerToAgent{}
+	if err = proto.Unmarshal(body, serverMsg); err != nil {
+		return nil, fmt.Errorf("invalid response from the server: %w", err)
+	}
+	return serverMsg, nil
+}
+
+func (c *Client) handle(msg *protobufs.ServerToAgent) {
+	if errMsg := msg.GetErrorResponse().GetErrorMessage(); errMsg != "" {
+		c.logger.Warn("The OpAMP server returned an error", zap.String("error", errMsg))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if msg.Flags&uint64(protobufs.ServerToAgentFlags_ServerToAgentFlags_ReportFullState) != 0 {
+		c.fullState = true
+		c.notify()
+	}
+	if msg.RemoteConfig == nil || !c.cfg.AcceptRemoteConfig || bytes.Equal(msg.RemoteConfig.ConfigHash, c.receivedHash) {
+		return
+	}
+	rc := remoteConfigFromMessage(msg.RemoteConfig)
+	c.receivedHash = rc.Hash
+	c.remoteStatus = &protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: rc.Hash,
+		Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING,
+	}
+	c.remoteStatusChanged = true
+	c.notify()
+	// The configuration waiting to be applied, if any, is replaced.
+	select {
+	case <-c.remoteConfigs:
+	default:
+	}
+	c.remoteConfigs <- rc
+}
@@ -0,0 +1,330 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opamp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+const testInstanceUID = "0188a8f4-2c6e-7b3a-9d1e-5f6a7b8c9d0e"
+
+// receivedMessage holds the fields of an AgentToServer message checked by the tests.
+type receivedMessage struct {
+	instanceUID        []byte
+	sequenceNum        uint64
+	attributes         map[string]string
+	capabilities       uint64
+	healthy            bool
+	healthStatus       string
+	hasHealth          bool
+	effectiveConfig    string
+	remoteStatus       *protobufs.RemoteConfigStatus
+	agentDisconnect    bool
+	authorizationValue string
+}
+
+func decodeAgentToServer(t *testing.T, b []byte) receivedMessage {
+	msg := &protobufs.AgentToServer{}
+	require.NoError(t, proto.Unmarshal(b, msg))
+	received := receivedMessage{
+		instanceUID:     msg.InstanceUid,
+		sequenceNum:     msg.SequenceNum,
+		attributes:      map[string]string{},
+		capabilities:    msg.Capabilities,
+		healthy:         msg.GetHealth().GetHealthy(),
+		healthStatus:    msg.GetHealth().GetStatus(),
+		hasHealth:       msg.Health != nil,
+		effectiveConfig: string(msg.GetEffectiveConfig().GetConfigMap().GetConfigMap()[""].GetBody()),
+		remoteStatus:    msg.RemoteConfigStatus,
+		agentDisconnect: msg.AgentDisconnect != nil,
+	}
+	for _, kv := range append(msg.GetAgentDescription().GetIdentifyingAttributes(), msg.GetAgentDescription().GetNonIdentifyingAttributes()...) {
+		received.attributes[kv.Key] = kv.GetValue().GetStringValue()
+	}
+	return received
+}
+
+func encodeServerToAgent(t *testing.T, remoteConfig *RemoteConfig, flags uint64) []byte {
+	msg := &protobufs.ServerToAgent{Flags: flags}
+	if remoteConfig != nil {
+		files := map[string]*protobufs.AgentConfigFile{}
+		for _, f := range remoteConfig.Files {
+			files[f.Name] = &protobufs.AgentConfigFile{Body: f.Body, ContentType: "text/yaml"}
+		}
+		msg.RemoteConfig = &protobufs.AgentRemoteConfig{
+			Config:     &protobufs.AgentConfigMap{ConfigMap: files},
+			ConfigHash: remoteConfig.Hash,
+		}
+	}
+	b, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	return b
+}
+
+// testServer is an OpAMP server recording the messages of the client, and responding with
+// the configured remote configuration and flags.
+type testServer struct {
+	*httptest.Server
+	t *testing.T
+
+	mu           sync.Mutex
+	messages     []receivedMessage
+	remoteConfig *RemoteConfig
+	flags        uint64
+	failures     int
+}
+
+func newTestServer(t *testing.T) *testServer {
+	srv := &testServer{t: t}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, contentType, r.Header.Get("Content-Type"))
+		msg := decodeAgentToServer(t, body)
+		msg.authorizationValue = r.Header.Get("Authorization")
+
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		srv.messages = append(srv.messages, msg)
+		if srv.failures > 0 {
+			srv.failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(encodeServerToAgent(t, srv.remoteConfig, srv.flags))
+		srv.flags = 0
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func (srv *testServer) received() []receivedMessage {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return append([]receivedMessage(nil), srv.messages...)
+}
+
+// lastMatching returns the last received message for which match returns true.
+func (srv *testServer) lastMatching(match func(msg receivedMessage) bool) (receivedMessage, bool) {
+	msgs := srv.received()
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if match(msgs[i]) {
+			return msgs[i], true
+		}
+	}
+	return receivedMessage{}, false
+}
+
+func newTestClient(t *testing.T, cfg Config) *Client {
+	client, err := NewClient(cfg, Settings{
+		BuildInfo: component.BuildInfo{Command: "otelcol", Version: "1.2.3"},
+		Logger:    zap.NewNop(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Start(context.Background()))
+	return client
+}
+
+func TestClientReportsState(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, Config{
+		Endpoint:    srv.URL,
+		Headers:     map[string]configopaque.String{"Authorization": "Bearer token"},
+		InstanceUID: testInstanceUID,
+	})
+	assert.Equal(t, testInstanceUID, client.InstanceUID())
+
+	client.SetEffectiveConfig([]byte("receivers: {}\n"))
+	client.SetHealth(true, time.Now(), "Running")
+	assert.Eventually(t, func() bool {
+		_, ok := srv.lastMatching(func(msg receivedMessage) bool {
+			return msg.effectiveConfig == "receivers: {}\n" && msg.healthy && msg.healthStatus == "Running"
+		})
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, client.Shutdown(context.Background()))
+
+	msgs := srv.received()
+	first := msgs[0]
+	assert.Equal(t, uint64(1), first.sequenceNum)
+	id := uuid.MustParse(testInstanceUID)
+	assert.Equal(t, id[:], first.instanceUID)
+	assert.Equal(t, "Bearer token", first.authorizationValue)
+	assert.Equal(t, map[string]string{
+		"service.name":        "otelcol",
+		"service.version":     "1.2.3",
+		"service.instance.id": testInstanceUID,
+		"os.type":             first.attributes["os.type"],
+		"host.arch":           first.attributes["host.arch"],
+		"host.name":           first.attributes["host.name"],
+	}, first.attributes)
+	assert.Equal(t, uint64(protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus|
+		protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig|
+		protobufs.AgentCapabilities_AgentCapabilities_ReportsHealth), first.capabilities)
+	assert.Nil(t, first.remoteStatus)
+
+	// Only the first message describes the collector, the last one tells the server it disconnects.
+	last := msgs[len(msgs)-1]
+	assert.True(t, last.agentDisconnect)
+	assert.Equal(t, uint64(len(msgs)), last.sequenceNum)
+	for _, msg := range msgs[1:] {
+		assert.Empty(t, msg.attributes)
+	}
+}
+
+func TestClientReportsFullStateAfterFailure(t *testing.T) {
+	srv := newTestServer(t)
+	srv.failures = 1
+	client := newTestClient(t, Config{Endpoint: srv.URL, PollingInterval: 10 * time.Millisecond})
+	t.Cleanup(func() { assert.NoError(t, client.Shutdown(context.Background())) })
+
+	assert.Eventually(t, func() bool {
+		msgs := srv.received()
+		return len(msgs) >= 2 && msgs[1].attributes["service.instance.id"] == client.InstanceUID()
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestClientReportsFullStateOnRequest(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, Config{Endpoint: srv.URL})
+	t.Cleanup(func() { assert.NoError(t, client.Shutdown(context.Background())) })
+	client.SetEffectiveConfig([]byte("receivers: {}\n"))
+	assert.Eventually(t, func() bool { return len(srv.received()) >= 2 }, 5*time.Second, 10*time.Millisecond)
+
+	srv.mu.Lock()
+	srv.flags = uint64(protobufs.ServerToAgentFlags_ServerToAgentFlags_ReportFullState)
+	srv.mu.Unlock()
+	client.SetHealth(true, time.Now(), "Running")
+	assert.Eventually(t, func() bool {
+		_, ok := srv.lastMatching(func(msg receivedMessage) bool {
+			return msg.sequenceNum > 2 && len(msg.attributes) > 0 && msg.effectiveConfig == "receivers: {}\n"
+		})
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestClientRemoteConfig(t *testing.T) {
+	srv := newTestServer(t)
+	srv.remoteConfig = &RemoteConfig{
+		Files: []ConfigFile{{Name: "b.yaml", Body: []byte("b: 2\n")}, {Name: "a.yaml", Body: []byte("a: 1\n")}},
+		Hash:  []byte("hash1"),
+	}
+	client := newTestClient(t, Config{Endpoint: srv.URL, PollingInterval: 10 * time.Millisecond, AcceptRemoteConfig: true})
+	t.Cleanup(func() { assert.NoError(t, client.Shutdown(context.Background())) })
+
+	var rc *RemoteConfig
+	select {
+	case rc = <-client.RemoteConfigs():
+	case <-time.After(5 * time.Second):
+		t.Fatal("no remote configuration received")
+	}
+	assert.Equal(t, &RemoteConfig{
+		Files: []ConfigFile{{Name: "a.yaml", Body: []byte("a: 1\n")}, {Name: "b.yaml", Body: []byte("b: 2\n")}},
+		Hash:  []byte("hash1"),
+	}, rc)
+	assert.Eventually(t, func() bool {
+		_, ok := srv.lastMatching(func(msg receivedMessage) bool {
+			return msg.remoteStatus.GetStatus() == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING
+		})
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+
+	client.SetRemoteConfigStatus(rc.Hash, errors.New("invalid configuration"))
+	assert.Eventually(t, func() bool {
+		msg, ok := srv.lastMatching(func(msg receivedMessage) bool { return msg.remoteStatus != nil })
+		return ok && msg.remoteStatus.Status == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED
+	}, 5*time.Second, 10*time.Millisecond)
+	msg, _ := srv.lastMatching(func(msg receivedMessage) bool { return msg.remoteStatus != nil })
+	assert.True(t, proto.Equal(&protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: []byte("hash1"),
+		Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+		ErrorMessage:         "invalid configuration",
+	}, msg.remoteStatus))
+	assert.Equal(t, uint64(protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus|
+		protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig|
+		protobufs.AgentCapabilities_AgentCapabilities_ReportsHealth|
+		protobufs.AgentCapabilities_AgentCapabilities_AcceptsRemoteConfig|
+		protobufs.AgentCapabilities_AgentCapabilities_ReportsRemoteConfig), msg.capabilities)
+
+	// The configuration is offered again by every response, it is only delivered once.
+	select {
+	case <-client.RemoteConfigs():
+		t.Fatal("the remote configuration was delivered twice")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestClientIgnoresRemoteConfig(t *testing.T) {
+	srv := newTestServer(t)
+	srv.remoteConfig = &RemoteConfig{Files: []ConfigFile{{Body: []byte("a: 1\n")}}, Hash: []byte("hash1")}
+	client := newTestClient(t, Config{Endpoint: srv.URL, PollingInterval: 10 * time.Millisecond})
+	t.Cleanup(func() { assert.NoError(t, client.Shutdown(context.Background())) })
+
+	assert.Eventually(t, func() bool { return len(srv.received()) >= 3 }, 5*time.Second, 10*time.Millisecond)
+	assert.Empty(t, client.RemoteConfigs())
+}
+
+func TestClientInvalidResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte{0x1a, 0x05, 0x01})
+	}))
+	t.Cleanup(srv.Close)
+	client, err := NewClient(Config{Endpoint: srv.URL}, Settings{Logger: zap.NewNop()})
+	require.NoError(t, err)
+
+	_, err = client.send(context.Background(), client.nextMessage())
+	assert.ErrorContains(t, err, "invalid response from the server")
+}
+
+func TestClientInstanceID(t *testing.T) {
+	const instanceID = "6f1c1c41-7d0a-4d3b-9a55-0a3e8f2b7c11"
+	newClient := func(cfg Config, instanceID string) *Client {
+		client, err := NewClient(cfg, Settings{
+			BuildInfo:  component.BuildInfo{Command: "otelcol", Version: "1.2.3"},
+			Logger:     zap.NewNop(),
+			InstanceID: instanceID,
+		})
+		require.NoError(t, err)
+		return client
+	}
+
+	client := newClient(Config{Endpoint: "http://localhost"}, instanceID)
+	assert.Equal(t, instanceID, client.InstanceUID())
+
+	// The instance_uid takes precedence over the service.instance.id.
+	client = newClient(Config{Endpoint: "http://localhost", InstanceUID: testInstanceUID}, instanceID)
+	assert.Equal(t, testInstanceUID, client.InstanceUID())
+	assert.Equal(t, instanceID, identifyingAttribute(client, "service.instance.id"))
+
+	// A service.instance.id which is not a UUID is only reported as an attribute.
+	client = newClient(Config{Endpoint: "http://localhost"}, "collector-1")
+	assert.NotEqual(t, "collector-1", client.InstanceUID())
+	assert.Equal(t, "collector-1", identifyingAttribute(client, "service.instance.id"))
+}
+
+func identifyingAttribute(client *Client, key string) string {
+	for _, kv := range client.description.IdentifyingAttributes {
+		if kv.Key == key {
+			return kv.GetValue().GetStringValue()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opamp // import "go.opentelemetry.io/collector/service/opamp"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+const defaultPollingInterval = 30 * time.Second
+
+var (
+	errNoEndpoint                = errors.New("endpoint must be set")
+	errNegativePollingInterval   = errors.New("polling_interval must not be negative")
+	errUnsupportedEndpointScheme = errors.New("endpoint must be an http or https URL")
+)
+
+// Config defines the configuration of the OpAMP client, which reports the state of the collector
+// to an OpAMP server and optionally receives its configuration from the server.
+type Config struct {
+	// Endpoint is the URL of the OpAMP server, which is polled with the plain HTTP transport.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Headers are the headers sent with every request, such as the credentials of the collector.
+	Headers map[string]configopaque.String `mapstructure:"headers"`
+
+	// TLSSetting configures the TLS connection to the server.
+	TLSSetting configtls.TLSClientSetting `mapstructure:"tls"`
+
+	// InstanceUID is the UUID identifying the collector on the server, a random UUID generated
+	// when the collector starts if not set.
+	InstanceUID string `mapstructure:"instance_uid"`
+
+	// PollingInterval is the interval between the requests to the server. 30s if not set.
+	PollingInterval time.Duration `mapstructure:"polling_interval"`
+
+	// AcceptRemoteConfig applies the configuration offered by the server, merged over the local
+	// configuration. The collector keeps running with its previous configuration if the remote
+	// configuration is invalid or cannot be applied.
+	AcceptRemoteConfig bool `mapstructure:"accept_remote_config"`
+}
+
+// Validate checks if the OpAMP configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errNoEndpoint
+	}
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errUnsupportedEndpointScheme
+	}
+	if cfg.InstanceUID != "" {
+		if _, err = uuid.Parse(cfg.InstanceUID); err != nil {
+			return fmt.Errorf("instance_uid must be a UUID: %w", err)
+		}
+	}
+	if cfg.PollingInterval < 0 {
+		return errNegativePollingInterval
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opamp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			cfg: Config{
+				Endpoint:           "https://opamp.example.com/v1/opamp",
+				InstanceUID:        "0188a8f4-2c6e-7b3a-9d1e-5f6a7b8c9d0e",
+				PollingInterval:    time.Minute,
+				AcceptRemoteConfig: true,
+			},
+		},
+		{
+			name:        "missing endpoint",
+			cfg:         Config{},
+			expectedErr: "endpoint must be set",
+		},
+		{
+			name:        "websocket endpoint",
+			cfg:         Config{Endpoint: "wss://opamp.example.com/v1/opamp"},
+			expectedErr: "endpoint must be an http or https URL",
+		},
+		{
+			name:        "invalid instance uid",
+			cfg:         Config{Endpoint: "http://localhost:4320/v1/opamp", InstanceUID: "collector-1"},
+			expectedErr: "instance_uid must be a UUID: invalid UUID length: 11",
+		},
+		{
+			name:        "negative polling interval",
+			cfg:         Config{Endpoint: "http://localhost:4320/v1/opamp", PollingInterval: -time.Second},
+			expectedErr: "polling_interval must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opamp // import "go.opentelemetry.io/collector/service/opamp"
+
+import (
+	"sort"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+)
+
+// RemoteConfigStatus is the status of the application of a remote configuration, from the
+// RemoteConfigStatuses enum.
+type RemoteConfigStatus int
+
+const (
+	RemoteConfigStatusUnset    = RemoteConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_UNSET)
+	RemoteConfigStatusApplied  = RemoteConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED)
+	RemoteConfigStatusApplying = RemoteConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING)
+	RemoteConfigStatusFailed   = RemoteConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED)
+)
+
+func (s RemoteConfigStatus) String() string {
+	switch s {
+	case RemoteConfigStatusApplied:
+		return "applied"
+	case RemoteConfigStatusApplying:
+		return "applying"
+	case RemoteConfigStatusFailed:
+		return "failed"
+	}
+	return "unset"
+}
+
+// effectiveConfigContentType is the content type of the file of the effective configuration.
+const effectiveConfigContentType = "text/yaml"
+
+// stringKeyValue returns a KeyValue holding a string value.
+func stringKeyValue(key, value string) *protobufs.KeyValue {
+	return &protobufs.KeyValue{
+		Key:   key,
+		Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+// effectiveConfigMessage returns an EffectiveConfig holding the YAML of the configuration in a single
+// file named after the empty string.
+func effectiveConfigMessage(effectiveConfig []byte) *protobufs.EffectiveConfig {
+	return &protobufs.EffectiveConfig{ConfigMap: &protobufs.AgentConfigMap{
+		ConfigMap: map[string]*protobufs.AgentConfigFile{
+			"": {Body: effectiveConfig, ContentType: effectiveConfigContentType},
+		},
+	}}
+}
+
+// remoteConfigFromMessage returns the RemoteConfig of an AgentRemoteConfig, with the files in the
+// order of their names.
+func remoteConfigFromMessage(msg *protobufs.AgentRemoteConfig) *RemoteConfig {
+	rc := &RemoteConfig{Hash: msg.GetConfigHash()}
+	files := msg.GetConfig().GetConfigMap()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		rc.Files = append(rc.Files, ConfigFile{Name: name, Body: files[name].GetBody()})
+	}
+	return rc
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package panicisolation // import "go.opentelemetry.io/collector/service/panicisolation"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Policy is what the service does when a component panics.
+type Policy string
+
+const (
+	// PolicyCrash lets the panic crash the collector, after logging it.
+	PolicyCrash Policy = "crash"
+	// PolicyDrop drops the data of the call which panicked with a permanent error, and keeps the component running.
+	PolicyDrop Policy = "drop"
+	// PolicyRestart fails the call which panicked with a retryable error, and restarts the component
+	// after a delay which grows with the number of consecutive panics. The components which do not
+	// implement component.Restartable are handled by PolicyDrop instead.
+	PolicyRestart Policy = "restart"
+)
+
+const (
+	defaultInitialInterval = time.Second
+	defaultMaxInterval     = time.Minute
+)
+
+// Config represents the configuration of the isolation of the panics of the components.
+type Config struct {
+	// Policy is what the service does when a component panics: crash, drop or restart.
+	Policy Policy `mapstructure:"policy"`
+
+	// InitialInterval is the delay before the first restart of a component which panicked,
+	// doubled for every consecutive panic, 1s if zero. Only used by the restart policy.
+	InitialInterval time.Duration `mapstructure:"initial_interval"`
+
+	// MaxInterval is the upper bound of the delay before a restart, 1m if zero. Only used by the restart policy.
+	MaxInterval time.Duration `mapstructure:"max_interval"`
+}
+
+// Validate checks if the panic isolation configuration is valid.
+func (cfg Config) Validate() error {
+	switch cfg.Policy {
+	case PolicyCrash, PolicyDrop, PolicyRestart:
+	default:
+		return fmt.Errorf("unsupported policy %q, must be one of %q, %q or %q", cfg.Policy, PolicyCrash, PolicyDrop, PolicyRestart)
+	}
+	if cfg.InitialInterval < 0 {
+		return errors.New("initial_interval must not be negative")
+	}
+	if cfg.MaxInterval < 0 {
+		return errors.New("max_interval must not be negative")
+	}
+	if cfg.MaxInterval > 0 && cfg.MaxInterval < cfg.InitialInterval {
+		return errors.New("max_interval must not be lower than initial_interval")
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package panicisolation // import "go.opentelemetry.io/collector/service/panicisolation"
+
+import (
+	"context"
+	"runtime/debug"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// enter returns ErrRestarting if the component is being restarted.
+func (c *Component) enter() error {
+	if c.restarting.Load() {
+		return ErrRestarting
+	}
+	return nil
+}
+
+// exit recovers the panic of a call to the component and sets the error of the call,
+// it must be deferred by the call.
+func (c *Component) exit(errp *error) {
+	if r := recover(); r != nil {
+		*errp = c.Recover(r, debug.Stack())
+		return
+	}
+	if *errp == nil {
+		c.succeeded()
+	}
+}
+
+// Traces returns next wrapped to isolate the panics of the component.
+func (c *Component) Traces(next consumer.Traces) consumer.Traces {
+	return tracesConsumer{Traces: next, component: c}
+}
+
+// Metrics returns next wrapped to isolate the panics of the component.
+func (c *Component) Metrics(next consumer.Metrics) consumer.Metrics {
+	return metricsConsumer{Metrics: next, component: c}
+}
+
+// Logs returns next wrapped to isolate the panics of the component.
+func (c *Component) Logs(next consumer.Logs) consumer.Logs {
+	return logsConsumer{Logs: next, component: c}
+}
+
+// Profiles returns next wrapped to isolate the panics of the component.
+func (c *Component) Profiles(next consumer.Profiles) consumer.Profiles {
+	return profilesConsumer{Profiles: next, component: c}
+}
+
+type tracesConsumer struct {
+	consumer.Traces
+	component *Component
+}
+
+func (c tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) (err error) {
+	if err = c.component.enter(); err != nil {
+		return err
+	}
+	defer c.component.exit(&err)
+	return c.Traces.ConsumeTraces(ctx, td)
+}
+
+type metricsConsumer struct {
+	consumer.Metrics
+	component *Component
+}
+
+func (c metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) (err error) {
+	if err = c.component.enter(); err != nil {
+		return err
+	}
+	defer c.component.exit(&err)
+	return c.Metrics.ConsumeMetrics(ctx, md)
+}
+
+type logsConsumer struct {
+	consumer.Logs
+	component *Component
+}
+
+func (c logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) (err error) {
+	if err = c.component.enter(); err != nil {
+		return err
+	}
+	defer c.component.exit(&err)
+	return c.Logs.ConsumeLogs(ctx, ld)
+}
+
+type profilesConsumer struct {
+	consumer.Profiles
+	component *Component
+}
+
+func (c profilesConsumer) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) (err error) {
+	if err = c.component.enter(); err != nil {
+		return err
+	}
+	defer c.component.exit(&err)
+	return c.Profiles.ConsumeProfiles(ctx, pd)
+}
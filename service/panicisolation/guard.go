@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package panicisolation isolates the panics of the components of the pipelines, so that a single
+// faulty component does not crash the collector, according to a configurable policy.
+package panicisolation // import "go.opentelemetry.io/collector/service/panicisolation"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+const (
+	scopeName = "go.opentelemetry.io/collector/service/panicisolation"
+
+	// PanicsMetricName is the name of the metric counting the panics of the components.
+	PanicsMetricName = "component_panics"
+
+	kindKey      = "kind"
+	componentKey = "component"
+)
+
+// ErrRestarting is the error returned by the calls to a component which is being restarted after a panic.
+var ErrRestarting = errors.New("the component is restarting after a panic")
+
+// PanicError is the error returned by a call to a component which panicked.
+type PanicError struct {
+	// Component is the ID of the component which panicked.
+	Component component.ID
+	// Value is the value the component panicked with.
+	Value any
+	// Stack is the stack of the goroutine which panicked.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("component %q panicked: %v", e.Component, e.Value)
+}
+
+// Guard applies the policy of the configuration to the panics of the components.
+type Guard struct {
+	cfg    Config
+	panics metric.Int64Counter
+}
+
+// NewGuard returns a Guard for the given configuration, which counts the panics on mp.
+func NewGuard(cfg Config, mp metric.MeterProvider) (*Guard, error) {
+	if cfg.InitialInterval == 0 {
+		cfg.InitialInterval = defaultInitialInterval
+	}
+	if cfg.MaxInterval == 0 {
+		cfg.MaxInterval = defaultMaxInterval
+	}
+	panics, err := mp.Meter(scopeName).Int64Counter(
+		PanicsMetricName,
+		metric.WithDescription("Number of panics of the components, by component."),
+		metric.WithUnit("1"))
+	if err != nil {
+		return nil, err
+	}
+	return &Guard{cfg: cfg, panics: panics}, nil
+}
+
+// Component returns the isolation of the panics of a component instance, which logs the panics with logger.
+func (g *Guard) Component(id *component.InstanceID, logger *zap.Logger) *Component {
+	return &Component{
+		guard:  g,
+		id:     id,
+		logger: logger,
+		attrs: metric.WithAttributeSet(attribute.NewSet(
+			attribute.String(kindKey, kindString(id.Kind)),
+			attribute.String(componentKey, id.ID.String()))),
+	}
+}
+
+func kindString(kind component.Kind) string {
+	switch kind {
+	case component.KindReceiver:
+		return "receiver"
+	case component.KindProcessor:
+		return "processor"
+	case component.KindExporter:
+		return "exporter"
+	case component.KindConnector:
+		return "connector"
+	case component.KindExtension:
+		return "extension"
+	}
+	return ""
+}
+
+// Component isolates the panics of a component instance, recovered in the calls to its consumer or
+// reported by the component through its host.
+type Component struct {
+	guard  *Guard
+	id     *component.InstanceID
+	logger *zap.Logger
+	attrs  metric.MeasurementOption
+
+	// failing is set once the component panicked, until one of its calls succeeds.
+	failing atomic.Bool
+	// restarting is set while the component is restarted, its calls are then refused.
+	restarting atomic.Bool
+
+	mu   sync.Mutex
+	comp component.Component
+	host component.Host
+	// panics is the number of consecutive panics, which the delay before a restart grows with.
+	panics  int
+	stopped bool
+	// cancel cuts the delay of the pending restart short, done is closed once it completes.
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Attach sets the component and the host it is started with, which the component is restarted with
+// and which reports the statuses of the component. It must be called before the component is started.
+// The components which do not implement component.Restartable are not restarted, the data of their
+// calls which panic is dropped instead.
+func (c *Component) Attach(comp component.Component, host component.Host) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.comp, c.host = comp, host
+	if c.guard.cfg.Policy == PolicyRestart && !restartable(comp) {
+		c.logger.Info("The component cannot be restarted after a panic, the data of its calls which panic is dropped instead")
+	}
+}
+
+func restartable(comp component.Component) bool {
+	r, ok := comp.(component.Restartable)
+	return ok && r.Restartable()
+}
+
+// policy returns the policy applied to the panics of the component, the restart policy being
+// replaced by the drop policy for the components which cannot be restarted.
+func (c *Component) policy() Policy {
+	if c.guard.cfg.Policy != PolicyRestart {
+		return c.guard.cfg.Policy
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !restartable(c.comp) {
+		return PolicyDrop
+	}
+	return PolicyRestart
+}
+
+// Recover applies the policy to a panic of the component, with the stack of the goroutine which panicked,
+// and returns the error the call which panicked must return. It panics again with the recovered value
+// if the policy is to crash.
+func (c *Component) Recover(recovered any, stack []byte) error {
+	policy := c.policy()
+	c.guard.panics.Add(context.Background(), 1, c.attrs)
+	c.logger.Error("Component panicked", zap.Any("panic", recovered), zap.ByteString("stack", stack),
+		zap.String("policy", string(policy)))
+	err := &PanicError{Component: c.id.ID, Value: recovered, Stack: stack}
+	switch policy {
+	case PolicyDrop:
+		c.failing.Store(true)
+		c.mu.Lock()
+		c.reportStatus(component.NewStatusEvent(component.StatusRecoverableError, err))
+		c.mu.Unlock()
+		return consumererror.NewPermanent(err)
+	case PolicyRestart:
+		c.failing.Store(true)
+		c.scheduleRestart(err)
+		return err
+	}
+	panic(recovered)
+}
+
+// scheduleRestart shuts the component down and starts it again after the delay of its consecutive panics,
+// unless it is already being restarted.
+func (c *Component) scheduleRestart(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped || c.comp == nil || c.restarting.Load() {
+		return
+	}
+	c.restarting.Store(true)
+	c.panics++
+	delay := c.delay()
+	c.reportStatus(component.NewStatusEvent(component.StatusRecoverableError, err))
+
+	var ctx context.Context
+	ctx, c.cancel = context.WithCancel(context.Background())
+	c.done = make(chan struct{})
+	go c.restart(ctx, delay, c.comp, c.host, c.done)
+}
+
+// delay returns the delay before the restart of the component, doubled for every consecutive panic.
+// c.mu must be held.
+func (c *Component) delay() time.Duration {
+	delay := c.guard.cfg.InitialInterval
+	for i := 1; i < c.panics && delay < c.guard.cfg.MaxInterval; i++ {
+		delay *= 2
+	}
+	if delay > c.guard.cfg.MaxInterval {
+		delay = c.guard.cfg.MaxInterval
+	}
+	return delay
+}
+
+// restart shuts the component down, waits for the delay, and starts the component again.
+// The component is started without waiting when the isolation is stopped, so that it is
+// running when the service shuts it down.
+func (c *Component) restart(ctx context.Context, delay time.Duration, comp component.Component, host component.Host, done chan struct{}) {
+	defer close(done)
+	defer c.restarting.Store(false)
+
+	c.logger.Info("Restarting the component after a panic", zap.Duration("delay", delay))
+	component.ReportStatus(host, component.NewStatusEvent(component.StatusStopping, nil))
+	if err := comp.Shutdown(context.Background()); err != nil {
+		c.logger.Warn("Failed to shut down the component which panicked", zap.Error(err))
+	}
+
+	timer := time.NewTimer(delay)
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		timer.Stop()
+	}
+
+	component.ReportStatus(host, component.NewStatusEvent(component.StatusStarting, nil))
+	if err := comp.Start(context.Background(), host); err != nil {
+		c.logger.Error("Failed to restart the component which panicked", zap.Error(err))
+		component.ReportStatus(host, component.NewStatusEvent(component.StatusPermanentError, err))
+		return
+	}
+	component.ReportStatus(host, component.NewStatusEvent(component.StatusOK, nil))
+}
+
+// succeeded resets the consecutive panics of the component once one of its calls succeeds,
+// and reports that the component recovered if it was not restarted.
+func (c *Component) succeeded() {
+	if !c.failing.CompareAndSwap(true, false) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.panics = 0
+	// The restarted components report their recovery once started again.
+	if c.guard.cfg.Policy == PolicyDrop || !restartable(c.comp) {
+		c.reportStatus(component.NewStatusEvent(component.StatusOK, nil))
+	}
+}
+
+// Stop prevents any further restart of the component, and waits for the pending restart to complete,
+// cutting its delay short. It must be called before the component is shut down by the service.
+func (c *Component) Stop() {
+	c.mu.Lock()
+	c.stopped = true
+	cancel, done := c.cancel, c.done
+	c.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+// reportStatus reports the status of the component to its host, c.mu must be held.
+func (c *Component) reportStatus(event *component.StatusEvent) {
+	if c.host != nil {
+		component.ReportStatus(c.host, event)
+	}
+}
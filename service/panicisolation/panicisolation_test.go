@@ -0,0 +1,292 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package panicisolation
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		expectedErr string
+	}{
+		{
+			name: "restart",
+			cfg:  Config{Policy: PolicyRestart, InitialInterval: time.Second, MaxInterval: time.Minute},
+		},
+		{
+			name: "drop",
+			cfg:  Config{Policy: PolicyDrop},
+		},
+		{
+			name:        "unsupported policy",
+			cfg:         Config{Policy: "ignore"},
+			expectedErr: `unsupported policy "ignore", must be one of "crash", "drop" or "restart"`,
+		},
+		{
+			name:        "negative initial interval",
+			cfg:         Config{Policy: PolicyRestart, InitialInterval: -time.Second},
+			expectedErr: "initial_interval must not be negative",
+		},
+		{
+			name:        "negative max interval",
+			cfg:         Config{Policy: PolicyRestart, MaxInterval: -time.Second},
+			expectedErr: "max_interval must not be negative",
+		},
+		{
+			name:        "max interval lower than initial interval",
+			cfg:         Config{Policy: PolicyRestart, InitialInterval: time.Minute, MaxInterval: time.Second},
+			expectedErr: "max_interval must not be lower than initial_interval",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+type statusHost struct {
+	component.Host
+	mu       sync.Mutex
+	statuses []component.Status
+}
+
+func (h *statusHost) ReportStatus(event *component.StatusEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.statuses = append(h.statuses, event.Status())
+}
+
+func (h *statusHost) reported() []component.Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]component.Status(nil), h.statuses...)
+}
+
+// testComponent panics when consuming traces while panicking is set, and counts its starts and shutdowns.
+type testComponent struct {
+	mu          sync.Mutex
+	panicking   bool
+	restartable bool
+	starts      int
+	shutdowns   int
+}
+
+func (c *testComponent) Restartable() bool {
+	return c.restartable
+}
+
+func (c *testComponent) Start(context.Context, component.Host) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.starts++
+	return nil
+}
+
+func (c *testComponent) Shutdown(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shutdowns++
+	return nil
+}
+
+func (c *testComponent) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+func (c *testComponent) ConsumeTraces(context.Context, ptrace.Traces) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.panicking {
+		panic("failure")
+	}
+	return nil
+}
+
+func (c *testComponent) setPanicking(panicking bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.panicking = panicking
+}
+
+func (c *testComponent) counts() (int, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.starts, c.shutdowns
+}
+
+var testID = &component.InstanceID{ID: component.NewID("test"), Kind: component.KindProcessor}
+
+func newTestComponent(t *testing.T, cfg Config) (*Component, *testComponent, *statusHost, sdkmetric.Reader) {
+	reader := sdkmetric.NewManualReader()
+	guard, err := NewGuard(cfg, sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	require.NoError(t, err)
+	iso := guard.Component(testID, zap.NewNop())
+	comp := &testComponent{panicking: true, restartable: true}
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	iso.Attach(comp, host)
+	return iso, comp, host, reader
+}
+
+// collectPanics returns the values of the panics metric by attributes.
+func collectPanics(t *testing.T, reader sdkmetric.Reader) map[string]int64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	values := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != PanicsMetricName {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				values[dp.Attributes.Encoded(attribute.DefaultEncoder())] = dp.Value
+			}
+		}
+	}
+	return values
+}
+
+func TestDropPolicy(t *testing.T) {
+	iso, comp, host, reader := newTestComponent(t, Config{Policy: PolicyDrop})
+	next := iso.Traces(comp)
+
+	err := next.ConsumeTraces(context.Background(), ptrace.NewTraces())
+	var panicErr *PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, "failure", panicErr.Value)
+	assert.Equal(t, component.NewID("test"), panicErr.Component)
+	assert.NotEmpty(t, panicErr.Stack)
+
+	comp.setPanicking(false)
+	require.NoError(t, next.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	require.NoError(t, next.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+
+	assert.Equal(t, []component.Status{component.StatusRecoverableError, component.StatusOK}, host.reported())
+	assert.Equal(t, map[string]int64{"component=test,kind=processor": 1}, collectPanics(t, reader))
+	starts, shutdowns := comp.counts()
+	assert.Zero(t, starts)
+	assert.Zero(t, shutdowns)
+}
+
+func TestRestartPolicy(t *testing.T) {
+	iso, comp, host, reader := newTestComponent(t, Config{Policy: PolicyRestart, InitialInterval: time.Millisecond})
+	next := iso.Traces(comp)
+
+	err := next.ConsumeTraces(context.Background(), ptrace.NewTraces())
+	var panicErr *PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	assert.Eventually(t, func() bool {
+		starts, _ := comp.counts()
+		return starts == 1
+	}, 5*time.Second, time.Millisecond)
+	iso.Stop()
+
+	starts, shutdowns := comp.counts()
+	assert.Equal(t, 1, starts)
+	assert.Equal(t, 1, shutdowns)
+	assert.Equal(t, []component.Status{
+		component.StatusRecoverableError, component.StatusStopping, component.StatusStarting, component.StatusOK,
+	}, host.reported())
+	assert.Equal(t, map[string]int64{"component=test,kind=processor": 1}, collectPanics(t, reader))
+
+	// The component is no longer restarted once the isolation is stopped.
+	require.ErrorAs(t, next.ConsumeTraces(context.Background(), ptrace.NewTraces()), &panicErr)
+	starts, _ = comp.counts()
+	assert.Equal(t, 1, starts)
+}
+
+func TestRestartPolicyRefusesCallsWhileRestarting(t *testing.T) {
+	iso, comp, _, _ := newTestComponent(t, Config{Policy: PolicyRestart, InitialInterval: time.Hour})
+	next := iso.Traces(comp)
+
+	var panicErr *PanicError
+	require.ErrorAs(t, next.ConsumeTraces(context.Background(), ptrace.NewTraces()), &panicErr)
+	assert.ErrorIs(t, next.ConsumeTraces(context.Background(), ptrace.NewTraces()), ErrRestarting)
+
+	// Stopping the isolation cuts the delay short, the component is started again.
+	iso.Stop()
+	starts, shutdowns := comp.counts()
+	assert.Equal(t, 1, starts)
+	assert.Equal(t, 1, shutdowns)
+}
+
+func TestRestartPolicyNotRestartable(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	guard, err := NewGuard(Config{Policy: PolicyRestart, InitialInterval: time.Millisecond}, sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	require.NoError(t, err)
+	iso := guard.Component(testID, zap.NewNop())
+	comp := &testComponent{panicking: true}
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	iso.Attach(comp, host)
+	next := iso.Traces(comp)
+
+	// The component which cannot be restarted keeps running, the data of the call is dropped.
+	err = next.ConsumeTraces(context.Background(), ptrace.NewTraces())
+	var panicErr *PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.True(t, consumererror.IsPermanent(err))
+	comp.setPanicking(false)
+	require.NoError(t, next.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	iso.Stop()
+
+	starts, shutdowns := comp.counts()
+	assert.Zero(t, starts)
+	assert.Zero(t, shutdowns)
+	assert.Equal(t, []component.Status{component.StatusRecoverableError, component.StatusOK}, host.reported())
+	assert.Equal(t, map[string]int64{"component=test,kind=processor": 1}, collectPanics(t, reader))
+}
+
+func TestRestartPolicyBackoff(t *testing.T) {
+	guard, err := NewGuard(Config{Policy: PolicyRestart, InitialInterval: time.Second, MaxInterval: 5 * time.Second}, sdkmetric.NewMeterProvider())
+	require.NoError(t, err)
+	iso := guard.Component(testID, zap.NewNop())
+
+	var delays []time.Duration
+	for iso.panics = 1; iso.panics <= 5; iso.panics++ {
+		delays = append(delays, iso.delay())
+	}
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}, delays)
+
+	// A successful call resets the consecutive panics.
+	iso.failing.Store(true)
+	iso.succeeded()
+	assert.Zero(t, iso.panics)
+}
+
+func TestCrashPolicy(t *testing.T) {
+	iso, comp, _, reader := newTestComponent(t, Config{Policy: PolicyCrash})
+	next := iso.Traces(comp)
+
+	assert.PanicsWithValue(t, "failure", func() {
+		_ = next.ConsumeTraces(context.Background(), ptrace.NewTraces())
+	})
+	assert.Equal(t, map[string]int64{"component=test,kind=processor": 1}, collectPanics(t, reader))
+}
@@ -6,6 +6,8 @@ package pipelines // import "go.opentelemetry.io/collector/service/pipelines"
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 )
@@ -14,6 +16,10 @@ var (
 	errMissingServicePipelines         = errors.New("service must have at least one pipeline")
 	errMissingServicePipelineReceivers = errors.New("must have at least one receiver")
 	errMissingServicePipelineExporters = errors.New("must have at least one exporter")
+	errEmptyMetadataKey                = errors.New("metadata_keys must not contain an empty key")
+	errNegativeDrainTimeout            = errors.New("drain_timeout must not be negative")
+	errNegativeFanOutTimeout           = errors.New("fanout::timeout must not be negative")
+	errFanOutNotParallel               = errors.New("fanout::timeout and fanout::error_policy require fanout::parallel")
 )
 
 // Config defines the configurable settings for service telemetry.
@@ -28,7 +34,9 @@ func (cfg Config) Validate() error {
 	// Check that all pipelines have at least one receiver and one exporter, and they reference
 	// only configured components.
 	for pipelineID, pipeline := range cfg {
-		if pipelineID.Type() != component.DataTypeTraces && pipelineID.Type() != component.DataTypeMetrics && pipelineID.Type() != component.DataTypeLogs {
+		switch pipelineID.Type() {
+		case component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs, component.DataTypeProfiles:
+		default:
 			return fmt.Errorf("pipeline %q: unknown datatype %q", pipelineID, pipelineID.Type())
 		}
 
@@ -46,6 +54,68 @@ type PipelineConfig struct {
 	Receivers  []component.ID `mapstructure:"receivers"`
 	Processors []component.ID `mapstructure:"processors"`
 	Exporters  []component.ID `mapstructure:"exporters"`
+
+	// MetadataKeys is the list of the client.Metadata keys propagated to the processors and
+	// exporters of the pipeline, such as "X-Tenant". When set, the other keys of the metadata
+	// of the incoming requests are removed at the start of the pipeline, so that the pipeline
+	// components only see the selected keys. The receivers must include the metadata of the
+	// requests, for instance with the include_metadata setting of the OTLP receiver.
+	MetadataKeys []string `mapstructure:"metadata_keys"`
+
+	// DrainTimeout if not nil, overrides the service::shutdown::drain_timeout setting for the
+	// exporters of the pipeline. The exporters part of several pipelines use the longest timeout.
+	DrainTimeout *time.Duration `mapstructure:"drain_timeout"`
+
+	// ErrorPropagation defines whether the errors of the pipeline components, such as an exporter
+	// whose queue is full, are returned to the receivers which then refuse the data and let the
+	// clients retry, "fail_upstream" the default, or are absorbed and counted, "best_effort".
+	ErrorPropagation string `mapstructure:"error_propagation"`
+
+	// FanOut if not nil, configures how the data is sent to the exporters of the pipeline.
+	FanOut *FanOutConfig `mapstructure:"fanout"`
+}
+
+const (
+	// ErrorPropagationFailUpstream returns the errors of the pipeline components to the receivers.
+	ErrorPropagationFailUpstream = "fail_upstream"
+	// ErrorPropagationBestEffort absorbs the errors of the pipeline components, the receivers accept the data.
+	ErrorPropagationBestEffort = "best_effort"
+)
+
+const (
+	// ErrorPolicyBestEffort waits for all the exporters, and reports the errors of all of them.
+	ErrorPolicyBestEffort = "best_effort"
+	// ErrorPolicyFailFast reports the first error as soon as an exporter fails, and cancels the others.
+	ErrorPolicyFailFast = "fail_fast"
+)
+
+// FanOutConfig defines how the data is sent to the exporters of a pipeline.
+type FanOutConfig struct {
+	// Parallel sends the data to the exporters concurrently instead of one after the other,
+	// so that a slow exporter does not delay the others.
+	Parallel bool `mapstructure:"parallel"`
+
+	// Timeout is the time the exporters have to consume the data when Parallel is set, after which
+	// the exporters which did not return are reported as failed. Zero means no timeout.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// ErrorPolicy is either "best_effort", the default, or "fail_fast".
+	ErrorPolicy string `mapstructure:"error_policy"`
+}
+
+func (cfg *FanOutConfig) Validate() error {
+	switch cfg.ErrorPolicy {
+	case "", ErrorPolicyBestEffort, ErrorPolicyFailFast:
+	default:
+		return fmt.Errorf("unknown fanout::error_policy %q", cfg.ErrorPolicy)
+	}
+	if cfg.Timeout < 0 {
+		return errNegativeFanOutTimeout
+	}
+	if !cfg.Parallel && (cfg.Timeout != 0 || cfg.ErrorPolicy != "") {
+		return errFanOutNotParallel
+	}
+	return nil
 }
 
 func (cfg *PipelineConfig) Validate() error {
@@ -69,5 +139,32 @@ func (cfg *PipelineConfig) Validate() error {
 		procSet[ref] = struct{}{}
 	}
 
+	// Validate no metadata keys are duplicated, ignoring their case as the metadata lookups do.
+	keySet := make(map[string]struct{}, len(cfg.MetadataKeys))
+	for _, key := range cfg.MetadataKeys {
+		if key == "" {
+			return errEmptyMetadataKey
+		}
+		lower := strings.ToLower(key)
+		if _, exists := keySet[lower]; exists {
+			return fmt.Errorf("duplicate entry in metadata_keys: %q (case-insensitive)", key)
+		}
+		keySet[lower] = struct{}{}
+	}
+
+	if cfg.DrainTimeout != nil && *cfg.DrainTimeout < 0 {
+		return errNegativeDrainTimeout
+	}
+
+	switch cfg.ErrorPropagation {
+	case "", ErrorPropagationFailUpstream, ErrorPropagationBestEffort:
+	default:
+		return fmt.Errorf("unknown error_propagation %q", cfg.ErrorPropagation)
+	}
+
+	if cfg.FanOut != nil {
+		return cfg.FanOut.Validate()
+	}
+
 	return nil
 }
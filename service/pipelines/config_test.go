@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -72,6 +73,97 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errors.New(`pipeline "wrongtype": unknown datatype "wrongtype"`),
 		},
+		{
+			name: "valid-metadata-keys",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.NewID("traces")].MetadataKeys = []string{"X-Tenant", "x-region"}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "duplicate-metadata-key",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.NewID("traces")].MetadataKeys = []string{"X-Tenant", "x-tenant"}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`, errors.New(`duplicate entry in metadata_keys: "x-tenant" (case-insensitive)`)),
+		},
+		{
+			name: "empty-metadata-key",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.NewID("traces")].MetadataKeys = []string{""}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`, errEmptyMetadataKey),
+		},
+		{
+			name: "negative-drain-timeout",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				timeout := -time.Second
+				cfg[component.NewID("traces")].DrainTimeout = &timeout
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`, errNegativeDrainTimeout),
+		},
+		{
+			name: "valid-error-propagation",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.NewID("traces")].ErrorPropagation = ErrorPropagationBestEffort
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "unknown-error-propagation",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.NewID("traces")].ErrorPropagation = "ignore"
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`, errors.New(`unknown error_propagation "ignore"`)),
+		},
+		{
+			name: "valid-fanout",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.NewID("traces")].FanOut = &FanOutConfig{Parallel: true, Timeout: time.Second, ErrorPolicy: ErrorPolicyFailFast}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "unknown-fanout-error-policy",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.NewID("traces")].FanOut = &FanOutConfig{Parallel: true, ErrorPolicy: "retry"}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`, errors.New(`unknown fanout::error_policy "retry"`)),
+		},
+		{
+			name: "negative-fanout-timeout",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.NewID("traces")].FanOut = &FanOutConfig{Parallel: true, Timeout: -time.Second}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`, errNegativeFanOutTimeout),
+		},
+		{
+			name: "fanout-timeout-not-parallel",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.NewID("traces")].FanOut = &FanOutConfig{Timeout: time.Second}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`, errFanOutNotParallel),
+		},
 	}
 
 	for _, test := range testCases {
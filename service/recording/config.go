@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recording // import "go.opentelemetry.io/collector/service/recording"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	defaultMaxDuration = 10 * time.Minute
+	defaultMaxBytes    = 100 << 20
+)
+
+// Config defines which receivers have the data they receive recorded, and where.
+type Config struct {
+	// Receivers are the IDs of the receivers whose data is recorded.
+	Receivers []component.ID `mapstructure:"receivers"`
+
+	// Directory is the directory the recording files are written to, one per receiver.
+	Directory string `mapstructure:"directory"`
+
+	// MaxDuration is how long the data is recorded for once the collector starts.
+	// Defaults to 10m, zero uses the default.
+	MaxDuration time.Duration `mapstructure:"max_duration"`
+
+	// MaxBytes is the maximum size of the data written to all the recording files.
+	// Defaults to 100MiB, zero uses the default.
+	MaxBytes int64 `mapstructure:"max_bytes"`
+}
+
+// Validate checks if the recording configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.Receivers) == 0 {
+		return errors.New("receivers must be specified")
+	}
+	if cfg.Directory == "" {
+		return errors.New("directory must be specified")
+	}
+	if cfg.MaxDuration < 0 {
+		return errors.New("max_duration must not be negative")
+	}
+	if cfg.MaxBytes < 0 {
+		return errors.New("max_bytes must not be negative")
+	}
+	return nil
+}
+
+func (cfg *Config) maxDuration() time.Duration {
+	if cfg.MaxDuration == 0 {
+		return defaultMaxDuration
+	}
+	return cfg.MaxDuration
+}
+
+func (cfg *Config) maxBytes() int64 {
+	if cfg.MaxBytes == 0 {
+		return defaultMaxBytes
+	}
+	return cfg.MaxBytes
+}
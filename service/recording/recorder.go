@@ -0,0 +1,238 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package recording records the data entering the receivers of the pipelines to files, and
+// replays the recorded data into pipelines later, for load and regression testing with
+// production-shaped data.
+package recording // import "go.opentelemetry.io/collector/service/recording"
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/otlpfile"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// FileExtension is the extension of the recording files, which are written in the otlpfile record format.
+const FileExtension = ".otlprec"
+
+// Recorder writes the data entering the configured receivers to one recording file per receiver,
+// until the maximum duration or size of the recording is reached.
+type Recorder struct {
+	logger      *zap.Logger
+	start       time.Time
+	maxDuration time.Duration
+	maxBytes    int64
+
+	// files are the recording files by receiver, the map is not modified once the Recorder is created.
+	files map[component.ID]*recordingFile
+
+	mu      sync.Mutex
+	written int64
+	stopped bool
+
+	// now returns the current time.
+	now func() time.Time
+}
+
+type recordingFile struct {
+	file   *os.File
+	buf    *bufio.Writer
+	writer *otlpfile.Writer
+}
+
+// NewRecorder creates the directory and the recording files of the configured receivers.
+// The recording starts immediately, the files must be closed with Close.
+func NewRecorder(cfg *Config, logger *zap.Logger) (*Recorder, error) {
+	if err := os.MkdirAll(cfg.Directory, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create the recording directory: %w", err)
+	}
+	r := &Recorder{
+		logger:      logger,
+		maxDuration: cfg.maxDuration(),
+		maxBytes:    cfg.maxBytes(),
+		files:       make(map[component.ID]*recordingFile, len(cfg.Receivers)),
+		now:         time.Now,
+	}
+	r.start = r.now()
+	for _, id := range cfg.Receivers {
+		if _, ok := r.files[id]; ok {
+			continue
+		}
+		path := filepath.Join(cfg.Directory, fmt.Sprintf("%s_%d%s", strings.ReplaceAll(id.String(), "/", "_"), r.start.UnixNano(), FileExtension))
+		f, err := os.OpenFile(filepath.Clean(path), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, multierr.Append(fmt.Errorf("failed to create the recording file: %w", err), r.Close())
+		}
+		buf := bufio.NewWriter(f)
+		r.files[id] = &recordingFile{file: f, buf: buf, writer: otlpfile.NewWriter(buf)}
+	}
+	logger.Info("Recording the data of the receivers", zap.String("directory", cfg.Directory),
+		zap.Duration("max_duration", r.maxDuration), zap.Int64("max_bytes", r.maxBytes))
+	return r, nil
+}
+
+// Close stops the recording and closes the recording files.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopped = true
+	var errs error
+	for _, f := range r.files {
+		errs = multierr.Append(errs, f.buf.Flush())
+		errs = multierr.Append(errs, f.file.Close())
+	}
+	return errs
+}
+
+// record writes the data returned by marshal to the recording file of the receiver,
+// marshal is only called if the data is recorded.
+func (r *Recorder) record(receiverID component.ID, signal component.DataType, marshal func() ([]byte, error)) {
+	f := r.files[receiverID]
+	now, ok := r.recording()
+	if !ok {
+		return
+	}
+	// The data is marshaled without holding the lock, not to serialize the receivers more than needed.
+	data, err := marshal()
+	if err != nil {
+		r.logger.Warn("Failed to marshal the recorded data", zap.Stringer("receiver", receiverID), zap.Error(err))
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped {
+		return
+	}
+	rec := otlpfile.Record{Signal: signal, Time: now, Encoding: otlpfile.EncodingProto, Data: data}
+	size := int64(rec.Size())
+	if r.written+size > r.maxBytes {
+		r.stop("The maximum size of the recording is reached, stopping the recording")
+		return
+	}
+	if err = f.writer.Write(rec); err != nil {
+		r.logger.Warn("Failed to write the recorded data, stopping the recording", zap.Stringer("receiver", receiverID), zap.Error(err))
+		r.stopped = true
+		return
+	}
+	r.written += size
+}
+
+// recording returns the time of the data received now, and whether it must be recorded.
+func (r *Recorder) recording() (time.Time, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped {
+		return time.Time{}, false
+	}
+	now := r.now()
+	if now.Sub(r.start) > r.maxDuration {
+		r.stop("The maximum duration of the recording is reached, stopping the recording")
+		return time.Time{}, false
+	}
+	return now, true
+}
+
+// stop stops the recording and flushes the recorded data, the files are closed by Close.
+func (r *Recorder) stop(msg string) {
+	r.stopped = true
+	for id, f := range r.files {
+		if err := f.buf.Flush(); err != nil {
+			r.logger.Warn("Failed to write the recorded data", zap.Stringer("receiver", id), zap.Error(err))
+		}
+	}
+	r.logger.Info(msg, zap.Int64("bytes", r.written))
+}
+
+// Traces returns next wrapped to record the traces received by the receiver, if it is recorded.
+func (r *Recorder) Traces(receiverID component.ID, next consumer.Traces) consumer.Traces {
+	if _, ok := r.files[receiverID]; !ok {
+		return next
+	}
+	return tracesConsumer{Traces: next, recorder: r, receiverID: receiverID}
+}
+
+// Metrics returns next wrapped to record the metrics received by the receiver, if it is recorded.
+func (r *Recorder) Metrics(receiverID component.ID, next consumer.Metrics) consumer.Metrics {
+	if _, ok := r.files[receiverID]; !ok {
+		return next
+	}
+	return metricsConsumer{Metrics: next, recorder: r, receiverID: receiverID}
+}
+
+// Logs returns next wrapped to record the logs received by the receiver, if it is recorded.
+func (r *Recorder) Logs(receiverID component.ID, next consumer.Logs) consumer.Logs {
+	if _, ok := r.files[receiverID]; !ok {
+		return next
+	}
+	return logsConsumer{Logs: next, recorder: r, receiverID: receiverID}
+}
+
+// Profiles returns next wrapped to record the profiles received by the receiver, if it is recorded.
+func (r *Recorder) Profiles(receiverID component.ID, next consumer.Profiles) consumer.Profiles {
+	if _, ok := r.files[receiverID]; !ok {
+		return next
+	}
+	return profilesConsumer{Profiles: next, recorder: r, receiverID: receiverID}
+}
+
+type tracesConsumer struct {
+	consumer.Traces
+	recorder   *Recorder
+	receiverID component.ID
+}
+
+func (c tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	// Record before passing the data, the next consumer may modify it.
+	c.recorder.record(c.receiverID, component.DataTypeTraces, func() ([]byte, error) { return (&ptrace.ProtoMarshaler{}).MarshalTraces(td) })
+	return c.Traces.ConsumeTraces(ctx, td)
+}
+
+type metricsConsumer struct {
+	consumer.Metrics
+	recorder   *Recorder
+	receiverID component.ID
+}
+
+func (c metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	c.recorder.record(c.receiverID, component.DataTypeMetrics, func() ([]byte, error) { return (&pmetric.ProtoMarshaler{}).MarshalMetrics(md) })
+	return c.Metrics.ConsumeMetrics(ctx, md)
+}
+
+type logsConsumer struct {
+	consumer.Logs
+	recorder   *Recorder
+	receiverID component.ID
+}
+
+func (c logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	c.recorder.record(c.receiverID, component.DataTypeLogs, func() ([]byte, error) { return (&plog.ProtoMarshaler{}).MarshalLogs(ld) })
+	return c.Logs.ConsumeLogs(ctx, ld)
+}
+
+type profilesConsumer struct {
+	consumer.Profiles
+	recorder   *Recorder
+	receiverID component.ID
+}
+
+func (c profilesConsumer) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	c.recorder.record(c.receiverID, component.DataTypeProfiles, func() ([]byte, error) { return (&pprofile.ProtoMarshaler{}).MarshalProfiles(pd) })
+	return c.Profiles.ConsumeProfiles(ctx, pd)
+}
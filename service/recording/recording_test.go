@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recording
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/otlpfile"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var receiverID = component.NewIDWithName("otlp", "in")
+
+func TestConfigValidate(t *testing.T) {
+	valid := Config{Receivers: []component.ID{receiverID}, Directory: "recordings"}
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr string
+	}{
+		{name: "valid", modify: func(*Config) {}},
+		{name: "missing receivers", modify: func(cfg *Config) { cfg.Receivers = nil }, wantErr: "receivers must be specified"},
+		{name: "missing directory", modify: func(cfg *Config) { cfg.Directory = "" }, wantErr: "directory must be specified"},
+		{name: "negative duration", modify: func(cfg *Config) { cfg.MaxDuration = -time.Second }, wantErr: "max_duration must not be negative"},
+		{name: "negative size", modify: func(cfg *Config) { cfg.MaxBytes = -1 }, wantErr: "max_bytes must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func newTraces(name string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(name)
+	return td
+}
+
+func readFile(t *testing.T, dir string) []otlpfile.Record {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+FileExtension))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Contains(t, filepath.Base(files[0]), "otlp_in_")
+	f, err := os.Open(files[0])
+	require.NoError(t, err)
+	defer f.Close()
+	var records []otlpfile.Record
+	r := otlpfile.NewReader(f)
+	for {
+		rec, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return records
+		}
+		require.NoError(t, err)
+		records = append(records, rec)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "recordings")
+	r, err := NewRecorder(&Config{Receivers: []component.ID{receiverID}, Directory: dir}, zap.NewNop())
+	require.NoError(t, err)
+	start := r.start
+	now := start
+	r.now = func() time.Time { return now }
+
+	next := new(consumertest.TracesSink)
+	other := new(consumertest.TracesSink)
+	assert.Same(t, other, r.Traces(component.NewID("other"), other))
+	traces := r.Traces(receiverID, next)
+	metrics := r.Metrics(receiverID, consumertest.NewNop())
+	logs := r.Logs(receiverID, consumertest.NewNop())
+
+	require.NoError(t, traces.ConsumeTraces(context.Background(), newTraces("first")))
+	now = now.Add(time.Second)
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("metric")
+	require.NoError(t, metrics.ConsumeMetrics(context.Background(), md))
+	now = now.Add(time.Second)
+	require.NoError(t, logs.ConsumeLogs(context.Background(), plog.NewLogs()))
+	// The data received once the maximum duration is reached is not recorded.
+	now = now.Add(defaultMaxDuration)
+	require.NoError(t, traces.ConsumeTraces(context.Background(), newTraces("late")))
+	require.NoError(t, r.Close())
+	assert.Len(t, next.AllTraces(), 2)
+
+	records := readFile(t, dir)
+	require.Len(t, records, 3)
+	assert.Equal(t, component.DataTypeTraces, records[0].Signal)
+	assert.Equal(t, start.UnixNano(), records[0].Time.UnixNano())
+	assert.Equal(t, otlpfile.EncodingProto, records[0].Encoding)
+	assert.Equal(t, otlpfile.CompressionNone, records[0].Compression)
+	td, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(records[0].Data)
+	require.NoError(t, err)
+	assert.Equal(t, newTraces("first"), td)
+	assert.Equal(t, component.DataTypeMetrics, records[1].Signal)
+	assert.Equal(t, time.Second, records[1].Time.Sub(start))
+	assert.Equal(t, component.DataTypeLogs, records[2].Signal)
+	assert.Equal(t, 2*time.Second, records[2].Time.Sub(start))
+}
+
+func TestRecorderMaxBytes(t *testing.T) {
+	dir := t.TempDir()
+	r, err := NewRecorder(&Config{Receivers: []component.ID{receiverID}, Directory: dir, MaxBytes: 100}, zap.NewNop())
+	require.NoError(t, err)
+	traces := r.Traces(receiverID, consumertest.NewNop())
+	for i := 0; i < 10; i++ {
+		require.NoError(t, traces.ConsumeTraces(context.Background(), newTraces("span")))
+	}
+	require.NoError(t, r.Close())
+
+	records := readFile(t, dir)
+	assert.NotEmpty(t, records)
+	assert.Less(t, len(records), 10)
+}
+
+func TestReplay(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := otlpfile.NewWriter(buf)
+	recorded := time.Unix(1700000000, 0)
+	for i, name := range []string{"first", "second"} {
+		data, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(newTraces(name))
+		require.NoError(t, err)
+		require.NoError(t, w.Write(otlpfile.Record{Signal: component.DataTypeTraces, Time: recorded.Add(time.Duration(i) * 100 * time.Millisecond), Encoding: otlpfile.EncodingProto, Data: data}))
+	}
+	logs, err := (&plog.ProtoMarshaler{}).MarshalLogs(plog.NewLogs())
+	require.NoError(t, err)
+	require.NoError(t, w.Write(otlpfile.Record{Signal: component.DataTypeLogs, Time: recorded.Add(100 * time.Millisecond), Encoding: otlpfile.EncodingProto, Data: logs}))
+
+	sink := new(consumertest.TracesSink)
+	start := time.Now()
+	replayed, err := Replay(context.Background(), otlpfile.NewReader(bytes.NewReader(buf.Bytes())), 2, Consumers{Traces: sink})
+	require.NoError(t, err)
+	assert.Equal(t, 2, replayed)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	require.Len(t, sink.AllTraces(), 2)
+	assert.Equal(t, newTraces("second"), sink.AllTraces()[1])
+
+	_, err = Replay(context.Background(), otlpfile.NewReader(bytes.NewReader(buf.Bytes())), -1, Consumers{Traces: sink})
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Replay(ctx, otlpfile.NewReader(bytes.NewReader(buf.Bytes())), 0.001, Consumers{Traces: sink})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestReplayUnsupportedRecord(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, otlpfile.NewWriter(buf).Write(otlpfile.Record{
+		Signal:      component.DataTypeTraces,
+		Time:        time.Now(),
+		Encoding:    otlpfile.EncodingProto,
+		Compression: otlpfile.CompressionGzip,
+		Data:        []byte("compressed"),
+	}))
+	replayed, err := Replay(context.Background(), otlpfile.NewReader(bytes.NewReader(buf.Bytes())), 0, Consumers{Traces: new(consumertest.TracesSink)})
+	assert.ErrorContains(t, err, "not uncompressed OTLP protobuf")
+	assert.Equal(t, 0, replayed)
+}
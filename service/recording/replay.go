@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recording // import "go.opentelemetry.io/collector/service/recording"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/otlpfile"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Consumers are the consumers the recorded data is replayed into, by signal.
+// The records of the signals without a consumer are skipped.
+type Consumers struct {
+	Traces   consumer.Traces
+	Metrics  consumer.Metrics
+	Logs     consumer.Logs
+	Profiles consumer.Profiles
+}
+
+// Replay sends the records read from r to the consumers, keeping the time between the records
+// divided by speed. The offset of a record is derived from its time relative to the time of the
+// first record. A speed of zero sends the records as fast as the consumers accept them.
+// It returns the number of records replayed.
+func Replay(ctx context.Context, r *otlpfile.Reader, speed float64, consumers Consumers) (int, error) {
+	if speed < 0 {
+		return 0, errors.New("speed must not be negative")
+	}
+	var start, recordingStart time.Time
+	replayed := 0
+	for {
+		rec, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return replayed, nil
+		}
+		if err != nil {
+			return replayed, err
+		}
+		if rec.Encoding != otlpfile.EncodingProto || rec.Compression != otlpfile.CompressionNone {
+			return replayed, fmt.Errorf("the %s recorded at %s are not uncompressed OTLP protobuf", rec.Signal, rec.Time)
+		}
+		if recordingStart.IsZero() {
+			start, recordingStart = time.Now(), rec.Time
+		}
+		offset := rec.Time.Sub(recordingStart)
+		if speed > 0 {
+			wait := time.Until(start.Add(time.Duration(float64(offset) / speed)))
+			if err = sleep(ctx, wait); err != nil {
+				return replayed, err
+			}
+		}
+		sent, err := consumers.consume(ctx, rec)
+		if err != nil {
+			return replayed, fmt.Errorf("failed to replay the %s recorded at %s: %w", rec.Signal, offset, err)
+		}
+		if sent {
+			replayed++
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// consume sends the record to the consumer of its signal, it returns false if there is none.
+func (c Consumers) consume(ctx context.Context, rec otlpfile.Record) (bool, error) {
+	switch rec.Signal {
+	case component.DataTypeTraces:
+		if c.Traces == nil {
+			return false, nil
+		}
+		td, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(rec.Data)
+		if err != nil {
+			return false, err
+		}
+		return true, c.Traces.ConsumeTraces(ctx, td)
+	case component.DataTypeMetrics:
+		if c.Metrics == nil {
+			return false, nil
+		}
+		md, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(rec.Data)
+		if err != nil {
+			return false, err
+		}
+		return true, c.Metrics.ConsumeMetrics(ctx, md)
+	case component.DataTypeLogs:
+		if c.Logs == nil {
+			return false, nil
+		}
+		ld, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(rec.Data)
+		if err != nil {
+			return false, err
+		}
+		return true, c.Logs.ConsumeLogs(ctx, ld)
+	case component.DataTypeProfiles:
+		if c.Profiles == nil {
+			return false, nil
+		}
+		pd, err := (&pprofile.ProtoUnmarshaler{}).UnmarshalProfiles(rec.Data)
+		if err != nil {
+			return false, err
+		}
+		return true, c.Profiles.ConsumeProfiles(ctx, pd)
+	}
+	return false, fmt.Errorf("unsupported signal %q", rec.Signal)
+}
@@ -6,9 +6,11 @@ package service // import "go.opentelemetry.io/collector/service"
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"runtime"
+	"sort"
+	"time"
 
-	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -20,14 +22,25 @@ import (
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/memorylimiter"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	semconv "go.opentelemetry.io/collector/semconv/v1.18.0"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/faultinjection"
+	"go.opentelemetry.io/collector/service/gomemlimit"
+	"go.opentelemetry.io/collector/service/internal/flowmetrics"
 	"go.opentelemetry.io/collector/service/internal/graph"
 	"go.opentelemetry.io/collector/service/internal/proctelemetry"
+	"go.opentelemetry.io/collector/service/internal/slo"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/tap"
+	"go.opentelemetry.io/collector/service/leaderelection"
+	"go.opentelemetry.io/collector/service/panicisolation"
+	"go.opentelemetry.io/collector/service/recording"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
 
@@ -57,10 +70,19 @@ type Settings struct {
 	// LoggingOptions provides a way to change behavior of zap logging.
 	LoggingOptions []zap.Option
 
+	// EffectiveConfig is the YAML of the resolved collector configuration, with the
+	// sensitive values redacted. It is served by the configz zPage.
+	EffectiveConfig []byte
+
 	// For testing purpose only.
 	useOtel *bool
 }
 
+const (
+	defaultSLOWindow    = 5 * time.Minute
+	defaultSLOObjective = 0.99
+)
+
 // Service represents the implementation of a component.Host.
 type Service struct {
 	buildInfo            component.BuildInfo
@@ -68,9 +90,33 @@ type Service struct {
 	telemetrySettings    component.TelemetrySettings
 	host                 *serviceHost
 	telemetryInitializer *telemetryInitializer
+	slo                  *slo.Registry
+	flowMetrics          *flowmetrics.Metrics
+	cfg                  Config
+
+	// softMemoryLimit if not nil, manages the soft memory limit of the Go runtime.
+	softMemoryLimit *gomemlimit.Manager
+
+	// elector if not nil, delays the start of the receivers until the collector is elected leader.
+	elector     *leaderelection.Elector
+	stopElector func()
+
+	// recorder if not nil, records the data entering the configured receivers.
+	recorder *recording.Recorder
+
+	// panicGuard if not nil, isolates the panics of the components.
+	panicGuard *panicisolation.Guard
 }
 
+// ErrRestartRequired is returned by Service.Reload when the configuration change
+// requires the service to be restarted.
+var ErrRestartRequired = proctelemetry.ErrRestartRequired
+
 func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
+	// The feature gates are set first, as they may change how the service is built.
+	if err := setFeatureGates(cfg.FeatureGates); err != nil {
+		return nil, fmt.Errorf("failed to set feature gates: %w", err)
+	}
 	useOtel := obsreportconfig.UseOtelForInternalMetricsfeatureGate.IsEnabled()
 	if set.useOtel != nil {
 		useOtel = *set.useOtel
@@ -89,15 +135,20 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 			asyncErrorChannel: set.AsyncErrorChannel,
 		},
 		telemetryInitializer: newColTelemetry(useOtel, disableHighCard, extendedConfig),
+		cfg:                  cfg,
 	}
-	var err error
-	srv.telemetry, err = telemetry.New(ctx, telemetry.Settings{ZapOptions: set.LoggingOptions}, cfg.Telemetry)
+	srv.host.effectiveConfig.Store(&set.EffectiveConfig)
+	res, err := buildResource(set.BuildInfo, cfg.Telemetry)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get logger: %w", err)
+		return nil, err
 	}
-	res := buildResource(set.BuildInfo, cfg.Telemetry)
 	pcommonRes := pdataFromSdk(res)
+	srv.telemetry, err = telemetry.New(ctx, telemetry.Settings{ZapOptions: set.LoggingOptions, Resource: res}, cfg.Telemetry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logger: %w", err)
+	}
 
+	srv.host.logger = srv.telemetry.Logger()
 	srv.telemetrySettings = component.TelemetrySettings{
 		Logger:         srv.telemetry.Logger(),
 		TracerProvider: srv.telemetry.TracerProvider(),
@@ -131,24 +182,79 @@ func (srv *Service) Start(ctx context.Context) error {
 	srv.telemetrySettings.Logger.Info("Starting "+srv.buildInfo.Command+"...",
 		zap.String("Version", srv.buildInfo.Version),
 		zap.Int("NumCPU", runtime.NumCPU()),
+		zap.String("InstanceID", srv.InstanceID()),
 	)
 
+	if srv.softMemoryLimit != nil {
+		if err := srv.softMemoryLimit.Start(); err != nil {
+			return fmt.Errorf("failed to set soft memory limit: %w", err)
+		}
+	}
+
 	if err := srv.host.serviceExtensions.Start(ctx, srv.host); err != nil {
 		return fmt.Errorf("failed to start extensions: %w", err)
 	}
 
-	if err := srv.host.pipelines.StartAll(ctx, srv.host); err != nil {
-		return fmt.Errorf("cannot start pipelines: %w", err)
+	// The memory limiter is started after the extensions to account for the memory ballast.
+	if srv.host.memoryLimiter != nil {
+		if err := srv.host.memoryLimiter.Start(ctx, srv.host); err != nil {
+			return fmt.Errorf("failed to start memory limiter: %w", err)
+		}
 	}
 
+	if srv.elector == nil {
+		if err := srv.host.pipelines.StartAll(ctx, srv.host); err != nil {
+			return fmt.Errorf("cannot start pipelines: %w", err)
+		}
+	} else {
+		if err := srv.host.pipelines.StartAllExceptReceivers(ctx, srv.host); err != nil {
+			return fmt.Errorf("cannot start pipelines: %w", err)
+		}
+		srv.runElector()
+	}
+
+	// A standby collector is ready, so that it does not block the rollouts.
 	if err := srv.host.serviceExtensions.NotifyPipelineReady(); err != nil {
 		return err
 	}
 
+	if srv.elector != nil {
+		srv.telemetrySettings.Logger.Info("Everything is ready. Standing by until elected leader to begin receiving data.")
+		return nil
+	}
 	srv.telemetrySettings.Logger.Info("Everything is ready. Begin running and processing data.")
 	return nil
 }
 
+// runElector campaigns for the leadership in the background and starts the receivers once it is acquired.
+// Losing the leadership is a fatal error, so that the collector is restarted as a standby.
+func (srv *Service) runElector() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	srv.stopElector = func() {
+		cancel()
+		<-done
+	}
+	reportError := func(err error) {
+		select {
+		case srv.host.asyncErrorChannel <- err:
+		case <-ctx.Done():
+		}
+	}
+	go func() {
+		defer close(done)
+		err := srv.elector.Run(ctx, func() {
+			srv.telemetrySettings.Logger.Info("Elected leader. Starting the receivers.")
+			if err := srv.host.pipelines.StartReceivers(ctx, srv.host); err != nil {
+				reportError(fmt.Errorf("cannot start receivers: %w", err))
+			}
+		})
+		if err != nil {
+			reportError(err)
+		}
+	}()
+}
+
 func (srv *Service) Shutdown(ctx context.Context) error {
 	// Accumulate errors and proceed with shutting down remaining components.
 	var errs error
@@ -160,14 +266,41 @@ func (srv *Service) Shutdown(ctx context.Context) error {
 		errs = multierr.Append(errs, fmt.Errorf("failed to notify that pipeline is not ready: %w", err))
 	}
 
+	if srv.stopElector != nil {
+		srv.stopElector()
+	}
+
 	if err := srv.host.pipelines.ShutdownAll(ctx); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown pipelines: %w", err))
 	}
 
+	if srv.recorder != nil {
+		if err := srv.recorder.Close(); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to close the recording files: %w", err))
+		}
+	}
+
+	// The leadership is released once the receivers are stopped, so that the data is not received twice.
+	if srv.elector != nil {
+		if err := srv.elector.Release(ctx); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to release the leadership: %w", err))
+		}
+	}
+
+	if srv.host.memoryLimiter != nil {
+		if err := srv.host.memoryLimiter.Shutdown(ctx); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to shutdown memory limiter: %w", err))
+		}
+	}
+
 	if err := srv.host.serviceExtensions.Shutdown(ctx); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown extensions: %w", err))
 	}
 
+	if srv.softMemoryLimit != nil {
+		srv.softMemoryLimit.Shutdown()
+	}
+
 	srv.telemetrySettings.Logger.Info("Shutdown complete.")
 
 	if err := srv.telemetry.Shutdown(ctx); err != nil {
@@ -180,16 +313,166 @@ func (srv *Service) Shutdown(ctx context.Context) error {
 	return errs
 }
 
+// reloadTelemetry applies the telemetry configuration to the running service, keeping the
+// loggers and the instruments handed to the components.
+func (srv *Service) reloadTelemetry(ctx context.Context, cfg telemetry.Config) error {
+	if err := srv.telemetry.Reload(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to reload telemetry: %w", err)
+	}
+	if err := srv.telemetryInitializer.reloadReaders(ctx, cfg.Metrics.Readers); err != nil {
+		return fmt.Errorf("failed to reload metric readers: %w", err)
+	}
+	return nil
+}
+
+// Reload applies cfg to the running service. Only the receivers, processors and exporters
+// whose configuration or place in the pipelines changed are rebuilt and restarted, the others
+// keep running with their listeners and queues. Connectors are always rebuilt.
+//
+// Changes to the extensions or to the telemetry, other than the logs and the metric readers,
+// cannot be applied this way and ErrRestartRequired is returned before anything is changed.
+// On any error the service must be restarted to apply cfg.
+func (srv *Service) Reload(ctx context.Context, set Settings, cfg Config) error {
+	if err := srv.checkReloadable(set, cfg); err != nil {
+		return err
+	}
+
+	pSet := graph.Settings{
+		Telemetry:        srv.telemetrySettings,
+		BuildInfo:        srv.buildInfo,
+		ReceiverBuilder:  set.Receivers,
+		ProcessorBuilder: set.Processors,
+		ExporterBuilder:  set.Exporters,
+		ConnectorBuilder: set.Connectors,
+		PipelineConfigs:  cfg.Pipelines,
+		SLO:              srv.slo,
+		Taps:             srv.host.taps,
+		Recorder:         srv.recorder,
+		FlowMetrics:      srv.flowMetrics,
+		PanicGuard:       srv.panicGuard,
+		DrainTimeout:     cfg.Shutdown.DrainTimeout,
+	}
+	var err error
+	if pSet.FaultInjector, err = srv.newFaultInjector(cfg.FaultInjection); err != nil {
+		return err
+	}
+	if err = srv.host.pipelines.Reload(ctx, pSet, srv.host); err != nil {
+		return fmt.Errorf("failed to reload pipelines: %w", err)
+	}
+	srv.host.receivers = set.Receivers
+	srv.host.processors = set.Processors
+	srv.host.exporters = set.Exporters
+	srv.host.connectors = set.Connectors
+	srv.host.effectiveConfig.Store(&set.EffectiveConfig)
+
+	if err = srv.reloadTelemetry(ctx, cfg.Telemetry); err != nil {
+		return err
+	}
+	srv.cfg = cfg
+	return nil
+}
+
+// checkReloadable returns ErrRestartRequired if cfg changes parts of the service which cannot be reloaded.
+func (srv *Service) checkReloadable(set Settings, cfg Config) error {
+	if !reflect.DeepEqual(srv.cfg.Extensions, cfg.Extensions) {
+		return fmt.Errorf("%w: extensions changed", ErrRestartRequired)
+	}
+	if !reflect.DeepEqual(srv.cfg.ExtensionDependencies, cfg.ExtensionDependencies) {
+		return fmt.Errorf("%w: extension_depends_on changed", ErrRestartRequired)
+	}
+	for _, id := range cfg.Extensions {
+		if !reflect.DeepEqual(srv.host.extensions.Config(id), set.Extensions.Config(id)) {
+			return fmt.Errorf("%w: extension %q changed", ErrRestartRequired, id)
+		}
+	}
+	if !reflect.DeepEqual(srv.cfg.FeatureGates, cfg.FeatureGates) {
+		return fmt.Errorf("%w: feature_gates changed", ErrRestartRequired)
+	}
+	if !reflect.DeepEqual(srv.cfg.MemoryLimiter, cfg.MemoryLimiter) {
+		return fmt.Errorf("%w: memory_limiter changed", ErrRestartRequired)
+	}
+	if !reflect.DeepEqual(srv.cfg.SoftMemoryLimit, cfg.SoftMemoryLimit) {
+		return fmt.Errorf("%w: soft_memory_limit changed", ErrRestartRequired)
+	}
+	if !reflect.DeepEqual(srv.cfg.Recording, cfg.Recording) {
+		return fmt.Errorf("%w: recording changed", ErrRestartRequired)
+	}
+	if !reflect.DeepEqual(srv.cfg.PanicIsolation, cfg.PanicIsolation) {
+		return fmt.Errorf("%w: panic_isolation changed", ErrRestartRequired)
+	}
+	// The reload starts the new receivers, which a standby collector must not do.
+	if srv.cfg.LeaderElection != nil || cfg.LeaderElection != nil {
+		return fmt.Errorf("%w: leader_election is configured", ErrRestartRequired)
+	}
+	current := srv.cfg.Telemetry
+	current.Logs = cfg.Telemetry.Logs
+	current.Metrics.Readers = cfg.Telemetry.Metrics.Readers
+	if !reflect.DeepEqual(current, cfg.Telemetry) {
+		return fmt.Errorf("%w: telemetry changed", ErrRestartRequired)
+	}
+	if len(cfg.Telemetry.Metrics.Readers) != len(srv.cfg.Telemetry.Metrics.Readers) {
+		return fmt.Errorf("%w: number of metric readers changed", ErrRestartRequired)
+	}
+	return nil
+}
+
+// setFeatureGates sets the feature gates of the global registry, in the order of their IDs.
+func setFeatureGates(gates map[string]bool) error {
+	ids := make([]string, 0, len(gates))
+	for id := range gates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if err := featuregate.GlobalRegistry().Set(id, gates[id]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (srv *Service) newFaultInjector(cfg faultinjection.Config) (*faultinjection.Injector, error) {
+	if len(cfg) == 0 {
+		return nil, nil
+	}
+	if !faultinjection.FeatureGate.IsEnabled() {
+		return nil, fmt.Errorf("service::fault_injection requires the %q feature gate to be enabled", faultinjection.FeatureGate.ID())
+	}
+	srv.telemetrySettings.Logger.Warn("Fault injection is enabled, the pipelines will delay, fail or drop data on purpose.",
+		zap.Int("faults", len(cfg)))
+	return faultinjection.NewInjector(cfg), nil
+}
+
 func (srv *Service) initExtensionsAndPipeline(ctx context.Context, set Settings, cfg Config) error {
 	var err error
 	extensionsSettings := extensions.Settings{
-		Telemetry:  srv.telemetrySettings,
-		BuildInfo:  srv.buildInfo,
-		Extensions: srv.host.extensions,
+		Telemetry:    srv.telemetrySettings,
+		BuildInfo:    srv.buildInfo,
+		Extensions:   srv.host.extensions,
+		Dependencies: cfg.ExtensionDependencies,
 	}
 	if srv.host.serviceExtensions, err = extensions.New(ctx, extensionsSettings, cfg.Extensions); err != nil {
 		return fmt.Errorf("failed to build extensions: %w", err)
 	}
+	srv.host.status = status.NewAggregator(srv.host.serviceExtensions)
+
+	if cfg.MemoryLimiter != nil {
+		if srv.host.memoryLimiter, err = memorylimiter.New(cfg.MemoryLimiter, srv.telemetrySettings.Logger); err != nil {
+			return fmt.Errorf("failed to build memory limiter: %w", err)
+		}
+	}
+
+	if cfg.SoftMemoryLimit != nil {
+		if srv.softMemoryLimit, err = gomemlimit.New(cfg.SoftMemoryLimit, srv.telemetrySettings.Logger); err != nil {
+			return fmt.Errorf("failed to build soft memory limit: %w", err)
+		}
+	}
+
+	if cfg.LeaderElection != nil {
+		if srv.elector, err = leaderelection.New(cfg.LeaderElection, srv.telemetrySettings.Logger); err != nil {
+			return fmt.Errorf("failed to build leader elector: %w", err)
+		}
+	}
 
 	pSet := graph.Settings{
 		Telemetry:        srv.telemetrySettings,
@@ -199,22 +482,95 @@ func (srv *Service) initExtensionsAndPipeline(ctx context.Context, set Settings,
 		ExporterBuilder:  set.Exporters,
 		ConnectorBuilder: set.Connectors,
 		PipelineConfigs:  cfg.Pipelines,
+		DrainTimeout:     cfg.Shutdown.DrainTimeout,
+	}
+
+	if pSet.FaultInjector, err = srv.newFaultInjector(cfg.FaultInjection); err != nil {
+		return err
+	}
+
+	if tap.FeatureGate.IsEnabled() {
+		srv.host.taps = tap.NewRegistry()
+		pSet.Taps = srv.host.taps
+	}
+
+	if cfg.Recording != nil {
+		if srv.recorder, err = recording.NewRecorder(cfg.Recording, srv.telemetrySettings.Logger); err != nil {
+			return fmt.Errorf("failed to build recorder: %w", err)
+		}
+		pSet.Recorder = srv.recorder
+	}
+
+	if cfg.PanicIsolation != nil {
+		if srv.panicGuard, err = panicisolation.NewGuard(*cfg.PanicIsolation, srv.telemetryInitializer.mp); err != nil {
+			return fmt.Errorf("failed to create panic isolation: %w", err)
+		}
+		pSet.PanicGuard = srv.panicGuard
+	}
+
+	if sloCfg := cfg.Telemetry.Metrics.SLO; sloCfg != nil {
+		window, objective := sloCfg.Window, sloCfg.Objective
+		if window == 0 {
+			window = defaultSLOWindow
+		}
+		if objective == 0 {
+			objective = defaultSLOObjective
+		}
+		srv.slo = slo.NewRegistry(window, objective)
+		pSet.SLO = srv.slo
+	}
+
+	// The pipeline metrics are only reported with the OpenTelemetry meter provider, which is a noop otherwise.
+	if cfg.Telemetry.Metrics.Level != configtelemetry.LevelNone && srv.telemetryInitializer.useOtel {
+		if srv.flowMetrics, err = flowmetrics.New(srv.telemetryInitializer.mp); err != nil {
+			return fmt.Errorf("failed to create pipeline metrics: %w", err)
+		}
+		pSet.FlowMetrics = srv.flowMetrics
 	}
 
 	if srv.host.pipelines, err = graph.Build(ctx, pSet); err != nil {
 		return fmt.Errorf("failed to build pipelines: %w", err)
 	}
 
-	if cfg.Telemetry.Metrics.Level != configtelemetry.LevelNone && cfg.Telemetry.Metrics.Address != "" {
+	if srv.slo != nil {
+		if err = srv.slo.RegisterMetrics(srv.telemetryInitializer.mp); err != nil {
+			return fmt.Errorf("failed to register slo metrics: %w", err)
+		}
+	}
+
+	if err = srv.host.status.RegisterMetrics(srv.telemetryInitializer.mp); err != nil {
+		return fmt.Errorf("failed to register status metrics: %w", err)
+	}
+
+	if err = srv.telemetry.RegisterLogMetrics(srv.telemetryInitializer.mp); err != nil {
+		return fmt.Errorf("failed to register log metrics: %w", err)
+	}
+
+	if cfg.Telemetry.Metrics.Level != configtelemetry.LevelNone && (cfg.Telemetry.Metrics.Address != "" || len(cfg.Telemetry.Metrics.Listeners) > 0) {
 		// The process telemetry initialization requires the ballast size, which is available after the extensions are initialized.
 		if err = proctelemetry.RegisterProcessMetrics(srv.telemetryInitializer.ocRegistry, srv.telemetryInitializer.mp, obsreportconfig.UseOtelForInternalMetricsfeatureGate.IsEnabled(), getBallastSize(srv.host)); err != nil {
 			return fmt.Errorf("failed to register process metrics: %w", err)
 		}
+		if cfg.Telemetry.Metrics.EnableProcessMetrics {
+			if !srv.telemetryInitializer.useOtel {
+				srv.telemetrySettings.Logger.Warn("Runtime process metrics require the OpenTelemetry internal metrics, ignoring enable_process_metrics")
+			} else if err = proctelemetry.RegisterRuntimeMetrics(srv.telemetryInitializer.mp); err != nil {
+				return fmt.Errorf("failed to register runtime metrics: %w", err)
+			}
+		}
 	}
 
 	return nil
 }
 
+// InstanceID returns the service.instance.id of the telemetry of the service, empty if it is suppressed.
+func (srv *Service) InstanceID() string {
+	if id, ok := srv.telemetrySettings.Resource.Attributes().Get(semconv.AttributeServiceInstanceID); ok {
+		return id.AsString()
+	}
+	return ""
+}
+
 // Logger returns the logger created for this service.
 // This is a temporary API that may be removed soon after investigating how the collector should record different events.
 func (srv *Service) Logger() *zap.Logger {
@@ -230,7 +586,7 @@ func getBallastSize(host component.Host) uint64 {
 	return 0
 }
 
-func buildResource(buildInfo component.BuildInfo, cfg telemetry.Config) *resource.Resource {
+func buildResource(buildInfo component.BuildInfo, cfg telemetry.Config) (*resource.Resource, error) {
 	var telAttrs []attribute.KeyValue
 
 	for k, v := range cfg.Resource {
@@ -246,10 +602,12 @@ func buildResource(buildInfo component.BuildInfo, cfg telemetry.Config) *resourc
 	}
 
 	if _, ok := cfg.Resource[semconv.AttributeServiceInstanceID]; !ok {
-		// AttributeServiceInstanceID is not specified in the config. Auto-generate one.
-		instanceUUID, _ := uuid.NewRandom()
-		instanceID := instanceUUID.String()
-		telAttrs = append(telAttrs, attribute.String(semconv.AttributeServiceInstanceID, instanceID))
+		// AttributeServiceInstanceID is not specified in the config. Use the generated one.
+		id, err := instanceID(cfg)
+		if err != nil {
+			return nil, err
+		}
+		telAttrs = append(telAttrs, attribute.String(semconv.AttributeServiceInstanceID, id))
 	}
 
 	if _, ok := cfg.Resource[semconv.AttributeServiceVersion]; !ok {
@@ -257,7 +615,7 @@ func buildResource(buildInfo component.BuildInfo, cfg telemetry.Config) *resourc
 		// build version.
 		telAttrs = append(telAttrs, attribute.String(semconv.AttributeServiceVersion, buildInfo.Version))
 	}
-	return resource.NewWithAttributes(semconv.SchemaURL, telAttrs...)
+	return resource.NewWithAttributes(semconv.SchemaURL, telAttrs...), nil
 }
 
 func pdataFromSdk(res *resource.Resource) pcommon.Resource {
@@ -6,9 +6,14 @@ package service
 import (
 	"bufio"
 	"context"
+	"errors"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -22,16 +27,25 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/extensiontest"
 	"go.opentelemetry.io/collector/extension/zpagesextension"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/internal/testutil"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/faultinjection"
+	"go.opentelemetry.io/collector/service/internal/tap"
+	"go.opentelemetry.io/collector/service/leaderelection"
+	"go.opentelemetry.io/collector/service/panicisolation"
 	"go.opentelemetry.io/collector/service/pipelines"
+	"go.opentelemetry.io/collector/service/recording"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
 
@@ -210,6 +224,26 @@ func TestServiceGetExtensions(t *testing.T) {
 	assert.Contains(t, extMap, component.NewID("nop"))
 }
 
+func TestServicePipelineStatus(t *testing.T) {
+	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+
+	assert.NoError(t, srv.Start(context.Background()))
+	for _, pipelineID := range []component.ID{component.NewID("traces"), component.NewID("metrics"), component.NewID("logs")} {
+		assert.Equal(t, component.StatusOK, srv.host.status.PipelineStatus(pipelineID).Status())
+	}
+
+	// The exporter is shared by the traces pipeline only.
+	srv.host.ReportComponentStatus(
+		&component.InstanceID{ID: component.NewID("nop"), Kind: component.KindExporter, PipelineIDs: []component.ID{component.NewID("traces")}},
+		component.NewStatusEvent(component.StatusRecoverableError, errors.New("unavailable")))
+	assert.Equal(t, component.StatusRecoverableError, srv.host.status.PipelineStatus(component.NewID("traces")).Status())
+	assert.Equal(t, component.StatusOK, srv.host.status.PipelineStatus(component.NewID("logs")).Status())
+
+	assert.NoError(t, srv.Shutdown(context.Background()))
+	assert.Nil(t, srv.host.status.PipelineStatus(component.NewID("logs")))
+}
+
 func TestServiceGetExporters(t *testing.T) {
 	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
 	require.NoError(t, err)
@@ -220,13 +254,14 @@ func TestServiceGetExporters(t *testing.T) {
 	})
 
 	expMap := srv.host.GetExporters()
-	assert.Len(t, expMap, 3)
+	assert.Len(t, expMap, 4)
 	assert.Len(t, expMap[component.DataTypeTraces], 1)
 	assert.Contains(t, expMap[component.DataTypeTraces], component.NewID("nop"))
 	assert.Len(t, expMap[component.DataTypeMetrics], 1)
 	assert.Contains(t, expMap[component.DataTypeMetrics], component.NewID("nop"))
 	assert.Len(t, expMap[component.DataTypeLogs], 1)
 	assert.Contains(t, expMap[component.DataTypeLogs], component.NewID("nop"))
+	assert.Len(t, expMap[component.DataTypeProfiles], 0)
 }
 
 // TestServiceTelemetryCleanupOnError tests that if newService errors due to an invalid config telemetry is cleaned up
@@ -244,6 +279,136 @@ func TestServiceTelemetryCleanupOnError(t *testing.T) {
 	assert.NoError(t, srv.Shutdown(context.Background()))
 }
 
+func TestServiceReloadTelemetry(t *testing.T) {
+	cfg := newNopConfig()
+	srv, err := New(context.Background(), newNopSettings(), cfg)
+	require.NoError(t, err)
+	assert.False(t, srv.telemetrySettings.Logger.Core().Enabled(zapcore.DebugLevel))
+
+	cfg.Telemetry.Logs.Level = zapcore.DebugLevel
+	require.NoError(t, srv.reloadTelemetry(context.Background(), cfg.Telemetry))
+	assert.True(t, srv.telemetrySettings.Logger.Core().Enabled(zapcore.DebugLevel))
+
+	assert.NoError(t, srv.Shutdown(context.Background()))
+}
+
+func TestServiceReload(t *testing.T) {
+	cfg := newNopConfig()
+	srv, err := New(context.Background(), newNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+
+	reloaded := newNopConfig()
+	delete(reloaded.Pipelines, component.NewID("logs"))
+	reloaded.Telemetry.Logs.Level = zapcore.DebugLevel
+	require.NoError(t, srv.Reload(context.Background(), newNopSettings(), reloaded))
+	assert.Len(t, srv.host.GetExporters()[component.DataTypeLogs], 0)
+	assert.Len(t, srv.host.GetExporters()[component.DataTypeTraces], 1)
+	assert.True(t, srv.telemetrySettings.Logger.Core().Enabled(zapcore.DebugLevel))
+
+	noExtensions := newNopConfig()
+	noExtensions.Extensions = nil
+	assert.ErrorIs(t, srv.Reload(context.Background(), newNopSettings(), noExtensions), ErrRestartRequired)
+
+	metricsLevel := newNopConfig()
+	metricsLevel.Telemetry.Metrics.Level = configtelemetry.LevelDetailed
+	assert.ErrorIs(t, srv.Reload(context.Background(), newNopSettings(), metricsLevel), ErrRestartRequired)
+
+	assert.NoError(t, srv.Shutdown(context.Background()))
+}
+
+func TestServiceRecording(t *testing.T) {
+	dir := t.TempDir()
+	cfg := newNopConfig()
+	cfg.Recording = &recording.Config{Receivers: []component.ID{component.NewID("nop")}, Directory: dir}
+	srv, err := New(context.Background(), newNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+
+	files, err := filepath.Glob(filepath.Join(dir, "nop_*"+recording.FileExtension))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	reloaded := newNopConfig()
+	assert.ErrorIs(t, srv.Reload(context.Background(), newNopSettings(), reloaded), ErrRestartRequired)
+	reloaded.Recording = cfg.Recording
+	assert.NoError(t, srv.Reload(context.Background(), newNopSettings(), reloaded))
+
+	assert.NoError(t, srv.Shutdown(context.Background()))
+}
+
+func TestServicePanicIsolation(t *testing.T) {
+	cfg := newNopConfig()
+	cfg.PanicIsolation = &panicisolation.Config{Policy: panicisolation.PolicyDrop}
+	srv, err := New(context.Background(), newNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	assert.NotNil(t, srv.panicGuard)
+
+	reloaded := newNopConfig()
+	assert.ErrorIs(t, srv.Reload(context.Background(), newNopSettings(), reloaded), ErrRestartRequired)
+	reloaded.PanicIsolation = cfg.PanicIsolation
+	assert.NoError(t, srv.Reload(context.Background(), newNopSettings(), reloaded))
+
+	assert.NoError(t, srv.Shutdown(context.Background()))
+}
+
+func TestServiceLeaderElection(t *testing.T) {
+	lockPath := filepath.Join(t.TempDir(), "leader.lock")
+	newService := func(identity string, elected *atomic.Int64) *Service {
+		set := newNopSettings()
+		set.LoggingOptions = []zap.Option{zap.Hooks(func(entry zapcore.Entry) error {
+			if strings.HasPrefix(entry.Message, "Elected leader") {
+				elected.Add(1)
+			}
+			return nil
+		})}
+		cfg := newNopConfig()
+		cfg.LeaderElection = &leaderelection.Config{
+			Identity:    identity,
+			RetryPeriod: 10 * time.Millisecond,
+			File:        &leaderelection.FileConfig{Path: lockPath},
+		}
+		srv, err := New(context.Background(), set, cfg)
+		require.NoError(t, err)
+		require.NoError(t, srv.Start(context.Background()))
+		return srv
+	}
+
+	var activeElected, standbyElected atomic.Int64
+	active := newService("active", &activeElected)
+	assert.Eventually(t, func() bool { return activeElected.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	standby := newService("standby", &standbyElected)
+	assert.ErrorIs(t, standby.Reload(context.Background(), newNopSettings(), newNopConfig()), ErrRestartRequired)
+
+	// The standby takes over once the active collector releases the lock.
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int64(0), standbyElected.Load())
+	require.NoError(t, active.Shutdown(context.Background()))
+	assert.Eventually(t, func() bool { return standbyElected.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, standby.Shutdown(context.Background()))
+}
+
+func TestServiceFaultInjectionRequiresFeatureGate(t *testing.T) {
+	cfg := newNopConfig()
+	cfg.FaultInjection = faultinjection.Config{{
+		Pipeline:        component.NewID("traces"),
+		Component:       component.NewID("nop"),
+		ErrorPercentage: 100,
+	}}
+	_, err := New(context.Background(), newNopSettings(), cfg)
+	require.EqualError(t, err, `service::fault_injection requires the "service.faultInjection" feature gate to be enabled`)
+
+	require.NoError(t, featuregate.GlobalRegistry().Set(faultinjection.FeatureGate.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(faultinjection.FeatureGate.ID(), false))
+	}()
+	srv, err := New(context.Background(), newNopSettings(), cfg)
+	require.NoError(t, err)
+	assert.NoError(t, srv.Shutdown(context.Background()))
+}
+
 func TestServiceTelemetryWithOpenCensusMetrics(t *testing.T) {
 	for _, tc := range ownMetricsTestCases() {
 		t.Run(tc.name, func(t *testing.T) {
@@ -279,6 +444,7 @@ func testCollectorStartHelper(t *testing.T, useOtel bool, tc ownMetricsTestCase)
 		map[component.ID]component.Config{component.NewID("zpages"): &zpagesextension.Config{TCPAddr: confignet.TCPAddr{Endpoint: zpagesAddr}}},
 		map[component.Type]extension.Factory{"zpages": zpagesextension.NewFactory()})
 	set.LoggingOptions = []zap.Option{zap.Hooks(hook)}
+	set.EffectiveConfig = []byte("receivers:\n  nop: {}\n")
 	set.useOtel = &useOtel
 
 	cfg := newNopConfig()
@@ -427,6 +593,7 @@ func assertZPages(t *testing.T, zpagesAddr string) {
 		"/debug/pipelinez",
 		"/debug/servicez",
 		"/debug/extensionz",
+		"/debug/configz",
 	}
 
 	testZPagePathFn := func(t *testing.T, path string) {
@@ -444,6 +611,157 @@ func assertZPages(t *testing.T, zpagesAddr string) {
 	}
 }
 
+func TestHandleConfigzRequest(t *testing.T) {
+	host := &serviceHost{}
+	rr := httptest.NewRecorder()
+	host.handleConfigzRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/configz", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	host.effectiveConfig.Store(&[]byte{})
+	rr = httptest.NewRecorder()
+	host.handleConfigzRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/configz", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	cfg := []byte("receivers:\n  nop:\n    api_key: '[REDACTED]'\n")
+	host.effectiveConfig.Store(&cfg)
+	rr = httptest.NewRecorder()
+	host.handleConfigzRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/configz", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, string(cfg), rr.Body.String())
+}
+
+func TestHandleTapzRequest(t *testing.T) {
+	get := func(host *serviceHost, query string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		host.handleTapzRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/tapz?"+query, nil))
+		return rr
+	}
+	assert.Equal(t, http.StatusNotFound, get(&serviceHost{}, "pipeline=traces").Code)
+
+	host := &serviceHost{logger: zap.NewNop(), taps: tap.NewRegistry()}
+	tc := host.taps.Traces(component.NewID("traces"), component.ID{}, consumertest.NewNop())
+	for _, query := range []string{
+		"",
+		"pipeline=traces&component=/",
+		"pipeline=traces&sampling_percentage=0",
+		"pipeline=traces&sampling_percentage=101",
+		"pipeline=traces&rate=0",
+		"pipeline=traces&ttl=1h",
+		"pipeline=traces&ttl=soon",
+	} {
+		assert.Equal(t, http.StatusBadRequest, get(host, query).Code, query)
+	}
+	assert.Equal(t, http.StatusNotFound, get(host, "pipeline=metrics").Code)
+	assert.Equal(t, http.StatusNotFound, get(host, "pipeline=traces&component=batch").Code)
+
+	server := httptest.NewServer(http.HandlerFunc(host.handleTapzRequest))
+	defer server.Close()
+	resp, err := http.Get(server.URL + "?pipeline=traces&rate=100&ttl=5s")
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, resp.Body.Close()) }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	require.NoError(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	require.NoError(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	reader := bufio.NewReader(resp.Body)
+	for _, spans := range []int{2, 3} {
+		line, readErr := reader.ReadBytes('\n')
+		require.NoError(t, readErr)
+		td, unmarshalErr := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(line)
+		require.NoError(t, unmarshalErr)
+		assert.Equal(t, spans, td.SpanCount())
+	}
+}
+
+func TestServiceTapsRequireFeatureGate(t *testing.T) {
+	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+	assert.Nil(t, srv.host.taps)
+	require.NoError(t, srv.Shutdown(context.Background()))
+
+	require.NoError(t, featuregate.GlobalRegistry().Set(tap.FeatureGate.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(tap.FeatureGate.ID(), false))
+	}()
+	srv, err = New(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+	assert.NotNil(t, srv.host.taps)
+	require.NoError(t, srv.Shutdown(context.Background()))
+}
+
+var (
+	testImmutableGate = featuregate.GlobalRegistry().MustRegister("service.test.immutable", featuregate.StageAlpha)
+	testMutableGate   = featuregate.GlobalRegistry().MustRegister("service.test.mutable", featuregate.StageAlpha,
+		featuregate.WithRegisterOwner("service"), featuregate.WithRegisterMutable())
+)
+
+func TestHandleFeaturezRequest(t *testing.T) {
+	host := &serviceHost{logger: zap.NewNop()}
+	t.Cleanup(func() { require.NoError(t, featuregate.GlobalRegistry().SetAtRuntime(testMutableGate.ID(), false)) })
+
+	rr := httptest.NewRecorder()
+	host.handleFeaturezRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/featurez", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), testMutableGate.ID())
+	assert.NotContains(t, rr.Body.String(), "<form")
+
+	postFrom := func(origin, id, enabled string) *httptest.ResponseRecorder {
+		form := url.Values{zFeatureGateParam: {id}, zEnabledParam: {enabled}}
+		req := httptest.NewRequest(http.MethodPost, "/debug/featurez", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		rr := httptest.NewRecorder()
+		host.handleFeaturezRequest(rr, req)
+		return rr
+	}
+	post := func(id, enabled string) *httptest.ResponseRecorder { return postFrom("", id, enabled) }
+
+	// The gates cannot be set unless the toggle gate is enabled.
+	assert.Equal(t, http.StatusForbidden, post(testMutableGate.ID(), "true").Code)
+	assert.False(t, testMutableGate.IsEnabled())
+	require.NoError(t, featuregate.GlobalRegistry().Set(featurezToggleGate.ID(), true))
+	t.Cleanup(func() { require.NoError(t, featuregate.GlobalRegistry().Set(featurezToggleGate.ID(), false)) })
+
+	rr = httptest.NewRecorder()
+	host.handleFeaturezRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/featurez", nil))
+	assert.Contains(t, rr.Body.String(), "<form")
+
+	// The requests from other origins are rejected.
+	assert.Equal(t, http.StatusForbidden, postFrom("http://attacker.example", testMutableGate.ID(), "true").Code)
+	assert.False(t, testMutableGate.IsEnabled())
+
+	rr = postFrom("http://example.com", testMutableGate.ID(), "true")
+	assert.Equal(t, http.StatusSeeOther, rr.Code)
+	assert.Equal(t, "/debug/featurez", rr.Header().Get("Location"))
+	assert.True(t, testMutableGate.IsEnabled())
+
+	assert.Equal(t, http.StatusBadRequest, post(testMutableGate.ID(), "maybe").Code)
+	assert.Equal(t, http.StatusBadRequest, post(testImmutableGate.ID(), "true").Code)
+	assert.False(t, testImmutableGate.IsEnabled())
+	assert.Equal(t, http.StatusBadRequest, post("service.test.unknown", "true").Code)
+}
+
+func TestServiceFeatureGates(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, featuregate.GlobalRegistry().Set(testImmutableGate.ID(), false)) })
+
+	cfg := newNopConfig()
+	cfg.FeatureGates = map[string]bool{testImmutableGate.ID(): true}
+	srv, err := New(context.Background(), newNopSettings(), cfg)
+	require.NoError(t, err)
+	assert.True(t, testImmutableGate.IsEnabled())
+
+	reloaded := newNopConfig()
+	assert.ErrorIs(t, srv.Reload(context.Background(), newNopSettings(), reloaded), ErrRestartRequired)
+	assert.NoError(t, srv.Shutdown(context.Background()))
+
+	cfg.FeatureGates = map[string]bool{"service.test.unknown": true}
+	_, err = New(context.Background(), newNopSettings(), cfg)
+	assert.EqualError(t, err, `failed to set feature gates: no such feature gate "service.test.unknown"`)
+}
+
 func newNopSettings() Settings {
 	return Settings{
 		BuildInfo:  component.NewDefaultBuildInfo(),
@@ -4,8 +4,12 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"unicode"
@@ -16,13 +20,11 @@ import (
 	ocmetric "go.opencensus.io/metric"
 	"go.opencensus.io/metric/metricproducer"
 	"go.opencensus.io/stats/view"
-	"go.opentelemetry.io/contrib/propagators/b3"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/bridge/opencensus"
 	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
-	"go.opentelemetry.io/otel/propagation"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.uber.org/multierr"
@@ -38,14 +40,6 @@ import (
 const (
 	zapKeyTelemetryAddress = "address"
 	zapKeyTelemetryLevel   = "level"
-
-	// supported trace propagators
-	traceContextPropagator = "tracecontext"
-	b3Propagator           = "b3"
-)
-
-var (
-	errUnsupportedPropagator = errors.New("unsupported trace propagator")
 )
 
 type telemetryInitializer struct {
@@ -53,6 +47,7 @@ type telemetryInitializer struct {
 	ocRegistry *ocmetric.Registry
 	mp         metric.MeterProvider
 	servers    []*http.Server
+	readers    []*proctelemetry.MetricReader
 
 	useOtel                bool
 	disableHighCardinality bool
@@ -68,8 +63,13 @@ func newColTelemetry(useOtel bool, disableHighCardinality bool, extendedConfig b
 	}
 }
 
-func (tel *telemetryInitializer) init(res *resource.Resource, settings component.TelemetrySettings, cfg telemetry.Config, asyncErrorChannel chan error) error {
-	if cfg.Metrics.Level == configtelemetry.LevelNone || cfg.Metrics.Address == "" {
+func (tel *telemetryInitializer) init(res *resource.Resource, settings component.TelemetrySettings, cfg telemetry.Config, asyncErrorChannel chan error) (err error) {
+	// The metric readers are only used with the OpenTelemetry SDK configuration.
+	useReaders := tel.extendedConfig && tel.useOtel
+	if len(cfg.Metrics.Readers) > 0 && !useReaders {
+		settings.Logger.Warn("The metric readers are ignored, they require the telemetry.useOtelWithSDKConfigurationForInternalTelemetry and telemetry.useOtelForInternalMetrics feature gates")
+	}
+	if cfg.Metrics.Level == configtelemetry.LevelNone || (cfg.Metrics.Address == "" && len(cfg.Metrics.Listeners) == 0 && (len(cfg.Metrics.Readers) == 0 || !useReaders)) {
 		settings.Logger.Info(
 			"Skipping telemetry setup.",
 			zap.String(zapKeyTelemetryAddress, cfg.Metrics.Address),
@@ -80,53 +80,143 @@ func (tel *telemetryInitializer) init(res *resource.Resource, settings component
 
 	settings.Logger.Info("Setting up own telemetry...")
 
-	if tp, err := textMapPropagatorFromConfig(cfg.Traces.Propagators); err == nil {
+	if tp, err := telemetry.NewTextMapPropagator(cfg.Traces.Propagators); err == nil {
 		otel.SetTextMapPropagator(tp)
 	} else {
 		return err
 	}
 
-	return tel.initPrometheus(res, settings.Logger, cfg.Metrics.Address, cfg.Metrics.Level, asyncErrorChannel)
+	if useReaders {
+		// The readers are owned by the MeterProvider once it is created, until then they are shut down on failure.
+		defer func() {
+			if _, ok := tel.mp.(*sdkmetric.MeterProvider); !ok && err != nil {
+				err = multierr.Append(err, tel.shutdownReaders())
+			}
+		}()
+		for _, reader := range cfg.Metrics.Readers {
+			r, err := proctelemetry.InitMetricReader(context.Background(), reader)
+			if err != nil {
+				return err
+			}
+			tel.readers = append(tel.readers, r)
+		}
+	}
+
+	return tel.initPrometheus(res, settings.Logger, cfg.Metrics, asyncErrorChannel)
 }
 
-func (tel *telemetryInitializer) initPrometheus(res *resource.Resource, logger *zap.Logger, address string, level configtelemetry.Level, asyncErrorChannel chan error) error {
+// shutdownReaders shuts down the metric readers not owned by a MeterProvider.
+func (tel *telemetryInitializer) shutdownReaders() error {
+	var errs error
+	for _, reader := range tel.readers {
+		errs = multierr.Append(errs, reader.Shutdown(context.Background()))
+	}
+	tel.readers = nil
+	return errs
+}
+
+func (tel *telemetryInitializer) initPrometheus(res *resource.Resource, logger *zap.Logger, cfg telemetry.MetricsConfig, asyncErrorChannel chan error) error {
+	level := cfg.Level
 	promRegistry := prometheus.NewRegistry()
 	if tel.useOtel {
-		if err := tel.initOpenTelemetry(res, promRegistry); err != nil {
+		if err := tel.initOpenTelemetry(res, cfg, promRegistry); err != nil {
 			return err
 		}
 	} else {
-		if err := tel.initOpenCensus(level, res, promRegistry); err != nil {
+		if err := tel.initOpenCensus(cfg, res, promRegistry); err != nil {
 			return err
 		}
 	}
 
+	handler := promhttp.HandlerFor(promRegistry, promhttp.HandlerOpts{})
+
+	if cfg.Address != "" {
+		logger.Info(
+			"Serving Prometheus metrics",
+			zap.String(zapKeyTelemetryAddress, cfg.Address),
+			zap.String(zapKeyTelemetryLevel, level.String()),
+		)
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", handler)
+		server := &http.Server{
+			Addr:    cfg.Address,
+			Handler: mux,
+		}
+		tel.servers = append(tel.servers, server)
+		go func() {
+			if serveErr := server.ListenAndServe(); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+				asyncErrorChannel <- serveErr
+			}
+		}()
+	}
+
+	for _, listenerCfg := range cfg.Listeners {
+		if err := tel.serveListener(logger, listenerCfg, handler, level, asyncErrorChannel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serveListener serves the metrics on an additional listener with its own TLS and authentication settings.
+func (tel *telemetryInitializer) serveListener(logger *zap.Logger, cfg telemetry.MetricsListener, handler http.Handler, level configtelemetry.Level, asyncErrorChannel chan error) error {
+	if cfg.Transport == "" {
+		cfg.Transport = "tcp"
+	}
+	ln, err := cfg.NetAddr.Listen()
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", cfg.Endpoint, err)
+	}
+	if cfg.TLSSetting != nil {
+		var tlsCfg *tls.Config
+		if tlsCfg, err = cfg.TLSSetting.LoadTLSConfig(); err != nil {
+			_ = ln.Close()
+			return fmt.Errorf("failed to load TLS config for %s: %w", cfg.Endpoint, err)
+		}
+		ln = tls.NewListener(ln, tlsCfg)
+	}
+	if cfg.BearerToken != "" {
+		handler = bearerTokenHandler(handler, string(cfg.BearerToken))
+	}
+
 	logger.Info(
 		"Serving Prometheus metrics",
-		zap.String(zapKeyTelemetryAddress, address),
+		zap.String(zapKeyTelemetryAddress, cfg.Endpoint),
+		zap.Object("listener", cfg),
 		zap.String(zapKeyTelemetryLevel, level.String()),
 	)
 
 	mux := http.NewServeMux()
-	mux.Handle("/metrics", promhttp.HandlerFor(promRegistry, promhttp.HandlerOpts{}))
-	server := &http.Server{
-		Addr:    address,
-		Handler: mux,
-	}
+	mux.Handle("/metrics", handler)
+	server := &http.Server{Handler: mux} // #nosec G112 -- consistent with the main metrics server
 	tel.servers = append(tel.servers, server)
-	go func() {
-		if serveErr := server.ListenAndServe(); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+	go func(ln net.Listener) {
+		if serveErr := server.Serve(ln); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
 			asyncErrorChannel <- serveErr
 		}
-	}()
+	}(ln)
 	return nil
 }
 
-func (tel *telemetryInitializer) initOpenCensus(level configtelemetry.Level, res *resource.Resource, promRegistry *prometheus.Registry) error {
+// bearerTokenHandler rejects the requests without the expected bearer token.
+func bearerTokenHandler(next http.Handler, token string) http.Handler {
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (tel *telemetryInitializer) initOpenCensus(cfg telemetry.MetricsConfig, res *resource.Resource, promRegistry *prometheus.Registry) error {
 	tel.ocRegistry = ocmetric.NewRegistry()
 	metricproducer.GlobalManager().AddProducer(tel.ocRegistry)
 
-	tel.views = obsreportconfig.AllViews(level)
+	tel.views = obsreportconfig.AllViewsWithLatencyBounds(cfg.Level, cfg.LatencyHistogram.Bounds())
 	if err := view.Register(tel.views...); err != nil {
 		return err
 	}
@@ -151,7 +241,7 @@ func (tel *telemetryInitializer) initOpenCensus(level configtelemetry.Level, res
 	return nil
 }
 
-func (tel *telemetryInitializer) initOpenTelemetry(res *resource.Resource, promRegistry *prometheus.Registry) error {
+func (tel *telemetryInitializer) initOpenTelemetry(res *resource.Resource, cfg telemetry.MetricsConfig, promRegistry *prometheus.Registry) error {
 	// Initialize the ocRegistry, still used by the process metrics.
 	tel.ocRegistry = ocmetric.NewRegistry()
 	metricproducer.GlobalManager().AddProducer(tel.ocRegistry)
@@ -169,8 +259,14 @@ func (tel *telemetryInitializer) initOpenTelemetry(res *resource.Resource, promR
 		return fmt.Errorf("error creating otel prometheus exporter: %w", err)
 	}
 
+	// The OpenCensus metrics, such as the process metrics, are bridged to every reader.
 	exporter.RegisterProducer(opencensus.NewMetricProducer())
-	mp, err := proctelemetry.InitOpenTelemetry(res, []sdkmetric.Option{sdkmetric.WithReader(exporter)}, tel.disableHighCardinality)
+	opts := []sdkmetric.Option{sdkmetric.WithReader(exporter)}
+	for _, reader := range tel.readers {
+		reader.RegisterProducer(opencensus.NewMetricProducer())
+		opts = append(opts, sdkmetric.WithReader(reader.Reader))
+	}
+	mp, err := proctelemetry.InitOpenTelemetry(res, opts, tel.disableHighCardinality, cfg.LatencyHistogram.Bounds())
 	if err != nil {
 		return err
 	}
@@ -178,11 +274,34 @@ func (tel *telemetryInitializer) initOpenTelemetry(res *resource.Resource, promR
 	return nil
 }
 
+// reloadReaders applies the metric readers configuration to the running readers. Readers
+// cannot be added or removed without recreating the MeterProvider, in that case
+// proctelemetry.ErrRestartRequired is returned.
+func (tel *telemetryInitializer) reloadReaders(ctx context.Context, readers []telemetry.MetricReader) error {
+	if !tel.extendedConfig || !tel.useOtel {
+		return nil
+	}
+	if len(readers) != len(tel.readers) {
+		return fmt.Errorf("%w: number of metric readers changed", proctelemetry.ErrRestartRequired)
+	}
+	var errs error
+	for i, reader := range readers {
+		errs = multierr.Append(errs, tel.readers[i].Reload(ctx, reader))
+	}
+	return errs
+}
+
 func (tel *telemetryInitializer) shutdown() error {
 	metricproducer.GlobalManager().DeleteProducer(tel.ocRegistry)
 	view.Unregister(tel.views...)
 
 	var errs error
+	// Shutting down the MeterProvider flushes the configured periodic readers.
+	if mp, ok := tel.mp.(*sdkmetric.MeterProvider); ok {
+		errs = multierr.Append(errs, mp.Shutdown(context.Background()))
+	} else {
+		errs = multierr.Append(errs, tel.shutdownReaders())
+	}
 	for _, server := range tel.servers {
 		if server != nil {
 			errs = multierr.Append(errs, server.Close())
@@ -200,18 +319,3 @@ func sanitizePrometheusKey(str string) string {
 	}
 	return strings.Map(runeFilterMap, str)
 }
-
-func textMapPropagatorFromConfig(props []string) (propagation.TextMapPropagator, error) {
-	var textMapPropagators []propagation.TextMapPropagator
-	for _, prop := range props {
-		switch prop {
-		case traceContextPropagator:
-			textMapPropagators = append(textMapPropagators, propagation.TraceContext{})
-		case b3Propagator:
-			textMapPropagators = append(textMapPropagators, b3.New())
-		default:
-			return nil, errUnsupportedPropagator
-		}
-	}
-	return propagation.NewCompositeTextMapPropagator(textMapPropagators...), nil
-}
@@ -4,12 +4,17 @@
 package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap/zapcore"
 
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configrotate"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/config/configtls"
 )
 
 // Config defines the configurable settings for service telemetry.
@@ -23,6 +28,11 @@ type Config struct {
 	// if they are not specified here. In order to suppress such attributes the
 	// attribute must be specified in this map with null YAML value (nil string pointer).
 	Resource map[string]*string `mapstructure:"resource"`
+
+	// StateDirectory is the directory where the generated service.instance.id is persisted, so that the
+	// collector keeps its service.instance.id across restarts. It is generated when the collector starts if
+	// not set.
+	StateDirectory string `mapstructure:"state_directory"`
 }
 
 // LogsConfig defines the configurable settings for service telemetry logs.
@@ -97,6 +107,25 @@ type LogsConfig struct {
 	// By default, max size is 100MB before rotation. Max number of backups is 100,
 	// and no limit for days. UTC time will be used.
 	Rotation *configrotate.Config `mapstructure:"rotation"`
+
+	// FieldMapping renames the top-level fields of the structured logs, so they
+	// match the conventions of the log backend. The keys are the default field
+	// names: "ts", "level", "logger", "caller", "function", "msg" and "stacktrace".
+	// Example:
+	//
+	//     field_mapping:
+	//         msg: message
+	//         ts: "@timestamp"
+	//         level: log.level
+	//
+	// By default, no field is renamed.
+	FieldMapping map[string]string `mapstructure:"field_mapping"`
+
+	// CountRecords counts the log records emitted by the collector in the otelcol_log_records metric,
+	// by level and by component, so that alerts on the error rate of the collector do not require
+	// scraping its logs. Requires the "telemetry.useOtelForInternalMetrics" feature gate.
+	// (default = false)
+	CountRecords bool `mapstructure:"count_records"`
 }
 
 // LogsSamplingConfig sets a sampling strategy for the logger. Sampling caps the
@@ -133,27 +162,282 @@ type MetricsConfig struct {
 	// Address is the [address]:port that metrics exposition should be bound to.
 	Address string `mapstructure:"address"`
 
+	// Listeners are additional addresses the metrics are exposed on, each with its own
+	// TLS and authentication settings. For example, metrics can be served on a
+	// loopback address for local scraping and on a TLS protected address for remote access:
+	//
+	//     listeners:
+	//       - endpoint: "[::1]:8888"
+	//       - endpoint: /var/run/otelcol/metrics.sock
+	//         transport: unix
+	//       - endpoint: 0.0.0.0:8889
+	//         tls:
+	//           cert_file: server.crt
+	//           key_file: server.key
+	//         bearer_token: ${env:METRICS_TOKEN}
+	Listeners []MetricsListener `mapstructure:"listeners"`
+
+	// EnableProcessMetrics registers the OpenTelemetry Go runtime instrumentation (goroutines,
+	// GC, memory statistics) on the internal MeterProvider, exposed as otelcol_process_runtime_* metrics.
+	// Requires the "telemetry.useOtelForInternalMetrics" feature gate.
+	// (default = false)
+	EnableProcessMetrics bool `mapstructure:"enable_process_metrics"`
+
+	// SLO enables the computation of the pipelines success ratios, exposed as
+	// otelcol_pipeline_accepted_ratio, otelcol_pipeline_exported_ratio and
+	// otelcol_pipeline_slo_healthy metrics. A nil SLOConfig disables it.
+	SLO *SLOConfig `mapstructure:"slo"`
+
+	// LatencyHistogram overrides the buckets of the otelcol_processor_latency and
+	// otelcol_exporter_latency histograms, either with explicit boundaries or with
+	// exponentially growing ones. For example, to cover the latencies of a gateway
+	// exporting to a remote backend from 1ms to about 30s:
+	//
+	//     latency_histogram:
+	//       exponential:
+	//         start: 1
+	//         factor: 2
+	//         count: 16
+	//
+	// A nil LatencyHistogramConfig keeps the default buckets.
+	LatencyHistogram *LatencyHistogramConfig `mapstructure:"latency_histogram"`
+
 	// Readers allow configuration of metric readers to emit metrics to
-	// any number of supported backends.
+	// any number of supported backends. Only the "periodic" reader with
+	// an "otlp" exporter is currently supported, its temporality_preference
+	// can be set to "delta" or "cumulative" (default).
+	// Example:
+	//
+	//     metric_readers:
+	//       - type: periodic
+	//         args:
+	//           interval: 60000
+	//           exporter:
+	//             otlp:
+	//               protocol: grpc/protobuf
+	//               endpoint: http://localhost:4317
+	//               temporality_preference: delta
+	//
+	// Readers are only used when the "telemetry.useOtelWithSDKConfigurationForInternalTelemetry"
+	// and "telemetry.useOtelForInternalMetrics" feature gates are enabled.
 	Readers []MetricReader `mapstructure:"metric_readers"`
 }
 
+// MetricsListener configures an address the metrics are exposed on.
+type MetricsListener struct {
+	// NetAddr is the address to listen on. The transport defaults to "tcp",
+	// "unix" can be used to listen on a Unix socket.
+	confignet.NetAddr `mapstructure:",squash"`
+
+	// TLSSetting configures TLS on this listener. A nil value disables TLS.
+	TLSSetting *configtls.TLSServerSetting `mapstructure:"tls"`
+
+	// BearerToken, if set, is required from the clients in the Authorization header.
+	BearerToken configopaque.String `mapstructure:"bearer_token"`
+}
+
+var _ zapcore.ObjectMarshaler = MetricsListener{}
+
+// MarshalLogObject logs the settings of the listener, the bearer token is redacted.
+func (l MetricsListener) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("endpoint", l.Endpoint)
+	enc.AddString("transport", l.Transport)
+	enc.AddBool("tls", l.TLSSetting != nil)
+	if l.BearerToken != "" {
+		enc.AddString("bearer_token", l.BearerToken.String())
+	}
+	return nil
+}
+
+// SLOConfig defines how the success ratios of the pipelines are computed.
+type SLOConfig struct {
+	// Window is the duration of the sliding window the ratios are computed over.
+	// (default = 5m)
+	Window time.Duration `mapstructure:"window"`
+
+	// Objective is the minimal success ratio, between 0 and 1, for a pipeline to be considered healthy.
+	// (default = 0.99)
+	Objective float64 `mapstructure:"objective"`
+}
+
+// LatencyHistogramConfig defines the buckets of the latency histograms, in milliseconds.
+// Only one of Boundaries and Exponential can be set.
+type LatencyHistogramConfig struct {
+	// Boundaries are the upper bounds of the buckets, in increasing order.
+	Boundaries []float64 `mapstructure:"boundaries"`
+
+	// Exponential generates the boundaries as a geometric sequence. The histograms
+	// are still exported with explicit buckets, the version of the OpenTelemetry SDK
+	// used by the collector does not support exponential histograms yet.
+	Exponential *ExponentialBucketsConfig `mapstructure:"exponential"`
+}
+
+// ExponentialBucketsConfig defines exponentially growing bucket boundaries.
+type ExponentialBucketsConfig struct {
+	// Start is the upper bound of the first bucket.
+	// (default = 1)
+	Start float64 `mapstructure:"start"`
+
+	// Factor is the ratio between two consecutive boundaries, greater than 1.
+	// (default = 2)
+	Factor float64 `mapstructure:"factor"`
+
+	// Count is the number of boundaries.
+	// (default = 16)
+	Count int `mapstructure:"count"`
+}
+
+const (
+	defaultExponentialStart  = 1
+	defaultExponentialFactor = 2
+	defaultExponentialCount  = 16
+	maxLatencyBuckets        = 160
+)
+
+// Bounds returns the boundaries of the buckets of the latency histograms.
+func (c *LatencyHistogramConfig) Bounds() []float64 {
+	if c == nil {
+		return nil
+	}
+	if c.Exponential == nil {
+		return c.Boundaries
+	}
+	start, factor, count := c.Exponential.Start, c.Exponential.Factor, c.Exponential.Count
+	if start == 0 {
+		start = defaultExponentialStart
+	}
+	if factor == 0 {
+		factor = defaultExponentialFactor
+	}
+	if count == 0 {
+		count = defaultExponentialCount
+	}
+	bounds := make([]float64, count)
+	for i := range bounds {
+		bounds[i] = start
+		start *= factor
+	}
+	return bounds
+}
+
+func (c *LatencyHistogramConfig) validate() error {
+	if c.Exponential != nil {
+		if len(c.Boundaries) != 0 {
+			return errors.New("collector telemetry latency histogram cannot set both boundaries and exponential")
+		}
+		if c.Exponential.Start < 0 {
+			return errors.New("collector telemetry latency histogram exponential start must not be negative")
+		}
+		if c.Exponential.Factor != 0 && c.Exponential.Factor <= 1 {
+			return errors.New("collector telemetry latency histogram exponential factor must be greater than 1")
+		}
+		if c.Exponential.Count < 0 {
+			return errors.New("collector telemetry latency histogram exponential count must not be negative")
+		}
+		if c.Exponential.Count > maxLatencyBuckets {
+			return fmt.Errorf("collector telemetry latency histogram cannot have more than %d boundaries", maxLatencyBuckets)
+		}
+		return nil
+	}
+	if len(c.Boundaries) > maxLatencyBuckets {
+		return fmt.Errorf("collector telemetry latency histogram cannot have more than %d boundaries", maxLatencyBuckets)
+	}
+	for i, b := range c.Boundaries {
+		if b <= 0 {
+			return errors.New("collector telemetry latency histogram boundaries must be positive")
+		}
+		if i > 0 && b <= c.Boundaries[i-1] {
+			return errors.New("collector telemetry latency histogram boundaries must be in increasing order")
+		}
+	}
+	return nil
+}
+
 // TracesConfig exposes the common Telemetry configuration for collector's internal spans.
 // Experimental: *NOTE* this structure is subject to change or removal in the future.
 type TracesConfig struct {
 	// Propagators is a list of TextMapPropagators from the supported propagators list. Currently,
-	// tracecontext and  b3 are supported. By default, the value is set to empty list and
+	// tracecontext, b3 and baggage are supported, distributions can support additional propagators
+	// with RegisterPropagator. By default, the value is set to empty list and
 	// context propagation is disabled.
 	Propagators []string `mapstructure:"propagators"`
+
+	// SpanLimits bounds the attributes, events and links recorded on the internal spans, so that the
+	// spans of the components attaching large payloads do not blow the memory of the collector.
+	SpanLimits SpanLimitsConfig `mapstructure:"span_limits"`
+
+	// AttributeDenylist lists the keys of the attributes dropped by the tracers before they are recorded in the
+	// internal spans, their events and their links, so that they are neither exposed nor exported.
+	AttributeDenylist []string `mapstructure:"attribute_denylist"`
+}
+
+// SpanLimitsConfig defines the limits of the internal spans. The limits of the SDK, which may be set
+// with the OTEL_SPAN_* environment variables, apply to the ones which are not set.
+type SpanLimitsConfig struct {
+	// MaxAttributes is the maximum number of attributes of a span, 128 by default.
+	MaxAttributes int `mapstructure:"max_attributes"`
+
+	// MaxAttributeValueLength is the maximum length of the string values of the attributes,
+	// unlimited by default. The longer values are truncated.
+	MaxAttributeValueLength int `mapstructure:"max_attribute_value_length"`
+
+	// MaxEvents is the maximum number of events of a span, 128 by default.
+	MaxEvents int `mapstructure:"max_events"`
+
+	// MaxLinks is the maximum number of links of a span, 128 by default.
+	MaxLinks int `mapstructure:"max_links"`
+}
+
+func (c *SpanLimitsConfig) validate() error {
+	if c.MaxAttributes < 0 || c.MaxAttributeValueLength < 0 || c.MaxEvents < 0 || c.MaxLinks < 0 {
+		return errors.New("collector telemetry span limits must not be negative")
+	}
+	return nil
 }
 
 // Validate checks whether the current configuration is valid
 func (c *Config) Validate() error {
 
-	// Check when service telemetry metric level is not none, the metrics address should not be empty
-	if c.Metrics.Level != configtelemetry.LevelNone && c.Metrics.Address == "" {
+	// Check when service telemetry metric level is not none, the metrics address should not be empty,
+	// unless the metrics are served by listeners or emitted by metric readers.
+	if c.Metrics.Level != configtelemetry.LevelNone && c.Metrics.Address == "" && len(c.Metrics.Listeners) == 0 && len(c.Metrics.Readers) == 0 {
 		return fmt.Errorf("collector telemetry metric address should exist when metric level is not none")
 	}
 
+	for _, l := range c.Metrics.Listeners {
+		if l.Endpoint == "" {
+			return errors.New("collector telemetry metric listener endpoint must not be empty")
+		}
+	}
+
+	if err := validateFieldMapping(c.Logs.FieldMapping); err != nil {
+		return err
+	}
+
+	if c.Metrics.SLO != nil {
+		if c.Metrics.SLO.Window < 0 {
+			return errors.New("collector telemetry slo window must not be negative")
+		}
+		if c.Metrics.SLO.Objective < 0 || c.Metrics.SLO.Objective > 1 {
+			return errors.New("collector telemetry slo objective must be between 0 and 1")
+		}
+	}
+
+	if c.Metrics.LatencyHistogram != nil {
+		if err := c.Metrics.LatencyHistogram.validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Traces.SpanLimits.validate(); err != nil {
+		return err
+	}
+
+	for _, key := range c.Traces.AttributeDenylist {
+		if key == "" {
+			return errors.New("collector telemetry attribute denylist must not contain an empty key")
+		}
+	}
+
 	return nil
 }
@@ -4,10 +4,16 @@
 package telemetry
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
 
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 )
 
@@ -37,6 +43,171 @@ func TestLoadConfig(t *testing.T) {
 			},
 			success: false,
 		},
+		{
+			name: "listeners without address",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelBasic,
+					Listeners: []MetricsListener{
+						{NetAddr: confignet.NetAddr{Endpoint: "[::1]:3333"}},
+						{NetAddr: confignet.NetAddr{Endpoint: "127.0.0.1:3333"}},
+					},
+				},
+			},
+			success: true,
+		},
+		{
+			name: "metric readers without address",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Readers: []MetricReader{{Type: "periodic"}},
+				},
+			},
+			success: true,
+		},
+		{
+			name: "listener without endpoint",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:     configtelemetry.LevelBasic,
+					Address:   "127.0.0.1:3333",
+					Listeners: []MetricsListener{{}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "valid slo",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+					SLO:     &SLOConfig{Window: time.Minute, Objective: 0.95},
+				},
+			},
+			success: true,
+		},
+		{
+			name: "invalid slo objective",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+					SLO:     &SLOConfig{Objective: 1.5},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "invalid slo window",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+					SLO:     &SLOConfig{Window: -time.Minute},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "valid latency histogram boundaries",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:            configtelemetry.LevelBasic,
+					Address:          "127.0.0.1:3333",
+					LatencyHistogram: &LatencyHistogramConfig{Boundaries: []float64{1, 10, 100}},
+				},
+			},
+			success: true,
+		},
+		{
+			name: "unsorted latency histogram boundaries",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:            configtelemetry.LevelBasic,
+					Address:          "127.0.0.1:3333",
+					LatencyHistogram: &LatencyHistogramConfig{Boundaries: []float64{1, 100, 10}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "valid latency histogram exponential",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:            configtelemetry.LevelBasic,
+					Address:          "127.0.0.1:3333",
+					LatencyHistogram: &LatencyHistogramConfig{Exponential: &ExponentialBucketsConfig{Start: 0.5, Factor: 1.5, Count: 30}},
+				},
+			},
+			success: true,
+		},
+		{
+			name: "invalid latency histogram exponential factor",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:            configtelemetry.LevelBasic,
+					Address:          "127.0.0.1:3333",
+					LatencyHistogram: &LatencyHistogramConfig{Exponential: &ExponentialBucketsConfig{Factor: 1}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "latency histogram boundaries and exponential",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+					LatencyHistogram: &LatencyHistogramConfig{
+						Boundaries:  []float64{1, 10, 100},
+						Exponential: &ExponentialBucketsConfig{},
+					},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "span limits and attribute denylist",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+				},
+				Traces: TracesConfig{
+					SpanLimits:        SpanLimitsConfig{MaxAttributes: 32, MaxAttributeValueLength: 256, MaxEvents: 8},
+					AttributeDenylist: []string{"request.body"},
+				},
+			},
+			success: true,
+		},
+		{
+			name: "negative span limit",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+				},
+				Traces: TracesConfig{
+					SpanLimits: SpanLimitsConfig{MaxLinks: -1},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "empty key in attribute denylist",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+				},
+				Traces: TracesConfig{
+					AttributeDenylist: []string{""},
+				},
+			},
+			success: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -50,3 +221,51 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestLatencyHistogramBounds(t *testing.T) {
+	var nilCfg *LatencyHistogramConfig
+	assert.Nil(t, nilCfg.Bounds())
+	assert.Equal(t, []float64{1, 10, 100}, (&LatencyHistogramConfig{Boundaries: []float64{1, 10, 100}}).Bounds())
+	assert.Equal(t, []float64{5, 15, 45, 135}, (&LatencyHistogramConfig{Exponential: &ExponentialBucketsConfig{Start: 5, Factor: 3, Count: 4}}).Bounds())
+
+	bounds := (&LatencyHistogramConfig{Exponential: &ExponentialBucketsConfig{}}).Bounds()
+	assert.Len(t, bounds, 16)
+	assert.Equal(t, 1.0, bounds[0])
+	assert.Equal(t, 32768.0, bounds[15])
+}
+
+func TestMetricsListenerMarshalLogObject(t *testing.T) {
+	enc := zapcore.NewMapObjectEncoder()
+	l := MetricsListener{
+		NetAddr:     confignet.NetAddr{Endpoint: "localhost:8889", Transport: "tcp"},
+		BearerToken: "s3cr3t",
+	}
+	require.NoError(t, l.MarshalLogObject(enc))
+	assert.Equal(t, map[string]any{
+		"endpoint":     "localhost:8889",
+		"transport":    "tcp",
+		"tls":          false,
+		"bearer_token": "[REDACTED]",
+	}, enc.Fields)
+}
+
+// FuzzMetricsListenerRedacted checks that the bearer token of a listener does not
+// leak in its logs, in its formatting or in the validation errors of the configuration.
+func FuzzMetricsListenerRedacted(f *testing.F) {
+	for _, seed := range []string{"s", "s3cr3t", "[REDACTED]", "%d", "\x00\xff"} {
+		f.Add(seed)
+	}
+	output := func(t *testing.T, token configopaque.String) []any {
+		l := MetricsListener{BearerToken: token}
+		enc := zapcore.NewMapObjectEncoder()
+		require.NoError(t, l.MarshalLogObject(enc))
+		cfg := &Config{Metrics: MetricsConfig{Level: configtelemetry.LevelBasic, Listeners: []MetricsListener{l}}}
+		return []any{enc.Fields, fmt.Sprintf("%+v", l), fmt.Sprintf("%#v", l), cfg.Validate().Error()}
+	}
+	f.Fuzz(func(t *testing.T, token string) {
+		if token == "" {
+			return
+		}
+		assert.Equal(t, output(t, "other"), output(t, configopaque.String(token)))
+	})
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	logMetricsScopeName = "go.opentelemetry.io/collector/service/telemetry"
+
+	// LogRecordsMetricName is the name of the metric counting the log records emitted by the collector.
+	LogRecordsMetricName = "log_records"
+
+	// The fields set by the service on the loggers of the components.
+	zapKindKey = "kind"
+	zapNameKey = "name"
+
+	levelKey     = "level"
+	kindKey      = "kind"
+	componentKey = "component"
+)
+
+// logCounter counts the log records once it is enabled and its instrument is created.
+type logCounter struct {
+	enabled    atomic.Bool
+	instrument atomic.Pointer[logInstrument]
+}
+
+type logInstrument struct {
+	counter metric.Int64Counter
+}
+
+func (lc *logCounter) add(level zapcore.Level, kind, name string) {
+	if !lc.enabled.Load() {
+		return
+	}
+	inst := lc.instrument.Load()
+	if inst == nil {
+		return
+	}
+	attrs := make([]attribute.KeyValue, 1, 3)
+	attrs[0] = attribute.String(levelKey, level.String())
+	if name != "" {
+		attrs = append(attrs, attribute.String(kindKey, kind), attribute.String(componentKey, name))
+	}
+	inst.counter.Add(context.Background(), 1, metric.WithAttributes(attrs...))
+}
+
+// countingCore counts the log records written through the core it wraps, by level and by component.
+// The component is identified by the fields set by the service on the loggers of the components.
+type countingCore struct {
+	zapcore.Core
+	counter *logCounter
+	kind    string
+	name    string
+}
+
+var _ zapcore.Core = (*countingCore)(nil)
+
+func (c *countingCore) With(fields []zapcore.Field) zapcore.Core {
+	child := &countingCore{
+		Core:    c.Core.With(fields),
+		counter: c.counter,
+		kind:    c.kind,
+		name:    c.name,
+	}
+	for _, f := range fields {
+		if f.Type != zapcore.StringType {
+			continue
+		}
+		switch f.Key {
+		case zapKindKey:
+			child.kind = f.String
+		case zapNameKey:
+			child.name = f.String
+		}
+	}
+	return child
+}
+
+func (c *countingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	// The wrapped core decides whether the record is written, it registers itself to write it,
+	// so that only the records which are emitted are counted.
+	if downstream := c.Core.Check(entry, checked); downstream != nil {
+		return downstream.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *countingCore) Write(entry zapcore.Entry, _ []zapcore.Field) error {
+	c.counter.add(entry.Level, c.kind, c.name)
+	return nil
+}
+
+// RegisterLogMetrics creates the metric counting the log records emitted by the loggers of the Telemetry,
+// when the count_records setting of the logs is enabled. The records emitted before are not counted.
+func (t *Telemetry) RegisterLogMetrics(mp metric.MeterProvider) error {
+	counter, err := mp.Meter(logMetricsScopeName).Int64Counter(
+		LogRecordsMetricName,
+		metric.WithDescription("Number of log records emitted by the collector, by level and by component."),
+		metric.WithUnit("1"))
+	if err != nil {
+		return err
+	}
+	t.logCounter.instrument.Store(&logInstrument{counter: counter})
+	return nil
+}
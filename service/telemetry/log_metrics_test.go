@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// collectLogRecords returns the values of the log records metric by attributes.
+func collectLogRecords(t *testing.T, reader sdkmetric.Reader) map[string]int64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	values := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != LogRecordsMetricName {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				values[dp.Attributes.Encoded(attribute.DefaultEncoder())] = dp.Value
+			}
+		}
+	}
+	return values
+}
+
+func TestLogMetrics(t *testing.T) {
+	cfg := Config{Logs: normalLoggerConfig()}
+	cfg.Logs.Rotation = nil
+	cfg.Logs.Sampling = nil
+	cfg.Logs.OutputPaths = []string{path.Join(t.TempDir(), "collector.log")}
+	cfg.Logs.CountRecords = true
+	tel, err := New(context.Background(), Settings{}, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, tel.Shutdown(context.Background())) })
+
+	tel.Logger().Error("not counted before the registration")
+
+	reader := sdkmetric.NewManualReader()
+	require.NoError(t, tel.RegisterLogMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))))
+
+	logger := tel.Logger()
+	componentLogger := logger.With(zap.String("kind", "exporter"), zap.String("name", "otlp"), zap.String("data_type", "traces"))
+	logger.Info("started")
+	logger.Debug("not emitted")
+	componentLogger.Error("failed")
+	componentLogger.With(zap.Int("attempt", 2)).Error("failed again")
+	componentLogger.Warn("retrying")
+
+	assert.Equal(t, map[string]int64{
+		"level=info": 1,
+		"component=otlp,kind=exporter,level=error": 2,
+		"component=otlp,kind=exporter,level=warn":  1,
+	}, collectLogRecords(t, reader))
+
+	cfg.Logs.CountRecords = false
+	require.NoError(t, tel.Reload(context.Background(), cfg))
+	componentLogger.Error("not counted once disabled")
+	cfg.Logs.CountRecords = true
+	cfg.Logs.Level = zapcore.DebugLevel
+	require.NoError(t, tel.Reload(context.Background(), cfg))
+	logger.Debug("emitted")
+
+	assert.Equal(t, map[string]int64{
+		"level=info":  1,
+		"level=debug": 1,
+		"component=otlp,kind=exporter,level=error": 2,
+		"component=otlp,kind=exporter,level=warn":  1,
+	}, collectLogRecords(t, reader))
+}
+
+func TestLogMetricsDisabled(t *testing.T) {
+	cfg := Config{Logs: normalLoggerConfig()}
+	cfg.Logs.Rotation = nil
+	cfg.Logs.OutputPaths = []string{path.Join(t.TempDir(), "collector.log")}
+	tel, err := New(context.Background(), Settings{}, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, tel.Shutdown(context.Background())) })
+
+	reader := sdkmetric.NewManualReader()
+	require.NoError(t, tel.RegisterLogMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))))
+	tel.Logger().Error("not counted")
+
+	assert.Empty(t, collectLogRecords(t, reader))
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	traceContextPropagator = "tracecontext"
+	b3Propagator           = "b3"
+	baggagePropagator      = "baggage"
+)
+
+var (
+	errUnsupportedPropagator = errors.New("unsupported trace propagator")
+	errEmptyPropagatorName   = errors.New("propagator name must not be empty")
+	errNilPropagatorFactory  = errors.New("propagator factory must not be nil")
+)
+
+// PropagatorFactory creates a TextMapPropagator.
+type PropagatorFactory func() propagation.TextMapPropagator
+
+var propagatorRegistry = struct {
+	sync.RWMutex
+	factories map[string]PropagatorFactory
+}{
+	factories: map[string]PropagatorFactory{
+		traceContextPropagator: func() propagation.TextMapPropagator { return propagation.TraceContext{} },
+		b3Propagator:           func() propagation.TextMapPropagator { return b3.New() },
+		baggagePropagator:      func() propagation.TextMapPropagator { return propagation.Baggage{} },
+	},
+}
+
+// RegisterPropagator registers a PropagatorFactory under the given name, so the name can be
+// used in TracesConfig.Propagators. Distributions use it to support additional propagators
+// (e.g. jaeger, xray, ottrace). It returns an error if the name is already registered.
+func RegisterPropagator(name string, factory PropagatorFactory) error {
+	if name == "" {
+		return errEmptyPropagatorName
+	}
+	if factory == nil {
+		return errNilPropagatorFactory
+	}
+	propagatorRegistry.Lock()
+	defer propagatorRegistry.Unlock()
+	if _, ok := propagatorRegistry.factories[name]; ok {
+		return fmt.Errorf("propagator %q is already registered", name)
+	}
+	propagatorRegistry.factories[name] = factory
+	return nil
+}
+
+// MustRegisterPropagator is like RegisterPropagator but panics if the name is already registered.
+func MustRegisterPropagator(name string, factory PropagatorFactory) {
+	if err := RegisterPropagator(name, factory); err != nil {
+		panic(err)
+	}
+}
+
+// RegisteredPropagators returns the sorted names of all the registered propagators.
+func RegisteredPropagators() []string {
+	propagatorRegistry.RLock()
+	defer propagatorRegistry.RUnlock()
+	names := make([]string, 0, len(propagatorRegistry.factories))
+	for name := range propagatorRegistry.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewTextMapPropagator returns the composite TextMapPropagator made of the registered propagators
+// with the given names, in order.
+func NewTextMapPropagator(names []string) (propagation.TextMapPropagator, error) {
+	propagatorRegistry.RLock()
+	defer propagatorRegistry.RUnlock()
+	textMapPropagators := make([]propagation.TextMapPropagator, 0, len(names))
+	for _, name := range names {
+		factory, ok := propagatorRegistry.factories[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", errUnsupportedPropagator, name)
+		}
+		textMapPropagators = append(textMapPropagators, factory())
+	}
+	return propagation.NewCompositeTextMapPropagator(textMapPropagators...), nil
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+type testPropagator struct {
+	propagation.TraceContext
+}
+
+func (testPropagator) Fields() []string {
+	return []string{"x-test-trace"}
+}
+
+func TestNewTextMapPropagator(t *testing.T) {
+	p, err := NewTextMapPropagator([]string{"tracecontext", "b3", "baggage"})
+	require.NoError(t, err)
+	assert.Contains(t, p.Fields(), "traceparent")
+	assert.Contains(t, p.Fields(), "baggage")
+
+	_, err = NewTextMapPropagator([]string{"unknown"})
+	assert.ErrorIs(t, err, errUnsupportedPropagator)
+
+	p, err = NewTextMapPropagator(nil)
+	require.NoError(t, err)
+	carrier := propagation.MapCarrier{}
+	p.Inject(context.Background(), carrier)
+	assert.Empty(t, carrier)
+}
+
+func TestRegisterPropagator(t *testing.T) {
+	assert.ErrorIs(t, RegisterPropagator("", func() propagation.TextMapPropagator { return testPropagator{} }), errEmptyPropagatorName)
+	assert.ErrorIs(t, RegisterPropagator("test", nil), errNilPropagatorFactory)
+	assert.Error(t, RegisterPropagator("tracecontext", func() propagation.TextMapPropagator { return testPropagator{} }))
+
+	require.NoError(t, RegisterPropagator("test", func() propagation.TextMapPropagator { return testPropagator{} }))
+	assert.Panics(t, func() {
+		MustRegisterPropagator("test", func() propagation.TextMapPropagator { return testPropagator{} })
+	})
+	assert.Contains(t, RegisteredPropagators(), "test")
+
+	p, err := NewTextMapPropagator([]string{"test"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x-test-trace"}, p.Fields())
+}
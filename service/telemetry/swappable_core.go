@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// coreRef wraps a zapcore.Core and the files it writes to, so it can be stored in an atomic.Pointer and
+// compared by identity.
+type coreRef struct {
+	core  zapcore.Core
+	files *logFiles
+}
+
+// swappableCore is a zapcore.Core delegating to a core that can be replaced at runtime.
+// Child cores created with With follow the replacements, re-applying their fields.
+type swappableCore struct {
+	base   *atomic.Pointer[coreRef]
+	fields []zapcore.Field
+	cache  atomic.Pointer[cachedCore]
+}
+
+// cachedCore is the base core with the fields of a child core applied.
+type cachedCore struct {
+	ref  *coreRef
+	core zapcore.Core
+}
+
+var _ zapcore.Core = (*swappableCore)(nil)
+
+func (c *swappableCore) load() zapcore.Core {
+	ref := c.base.Load()
+	if len(c.fields) == 0 {
+		return ref.core
+	}
+	if cached := c.cache.Load(); cached != nil && cached.ref == ref {
+		return cached.core
+	}
+	core := ref.core.With(c.fields)
+	c.cache.Store(&cachedCore{ref: ref, core: core})
+	return core
+}
+
+func (c *swappableCore) Enabled(level zapcore.Level) bool {
+	return c.load().Enabled(level)
+}
+
+func (c *swappableCore) With(fields []zapcore.Field) zapcore.Core {
+	return &swappableCore{
+		base:   c.base,
+		fields: append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *swappableCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return c.load().Check(entry, checked)
+}
+
+func (c *swappableCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.load().Write(entry, fields)
+}
+
+func (c *swappableCore) Sync() error {
+	return c.load().Sync()
+}
@@ -8,10 +8,15 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"sync"
+	"sync/atomic"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/multierr"
@@ -23,7 +28,14 @@ import (
 
 type Telemetry struct {
 	logger         *zap.Logger
-	tracerProvider *sdktrace.TracerProvider
+	tracerProvider *scrubbingTracerProvider
+
+	mu         sync.Mutex
+	cfg        Config
+	zapOptions []zap.Option
+	level      zap.AtomicLevel
+	core       *atomic.Pointer[coreRef]
+	logCounter *logCounter
 }
 
 func (t *Telemetry) TracerProvider() trace.TracerProvider {
@@ -41,31 +53,99 @@ func (t *Telemetry) Shutdown(ctx context.Context) error {
 	)
 }
 
+// Reload applies the differences between the current and the given configuration
+// without recreating the Logger and the TracerProvider, so the loggers already handed
+// to the components keep working and no buffered telemetry is lost.
+//
+// The log level is changed in place. Changes to the sampling, encoding, output paths,
+// rotation, field mapping and initial fields rebuild the logging core, which is swapped
+// under the existing loggers. Changes to the development mode, caller and stacktrace
+// settings only apply after a restart. The counting of the log records is enabled or disabled in place.
+func (t *Telemetry) Reload(_ context.Context, cfg Config) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if coreSettingsChanged(t.cfg.Logs, cfg.Logs) {
+		logger, files, err := buildLogger(cfg.Logs, t.level, t.zapOptions)
+		if err != nil {
+			return err
+		}
+		old := t.core.Swap(&coreRef{core: logger.Core(), files: files})
+		_ = old.core.Sync()
+		// The files are no longer written once the core is swapped.
+		if err = old.files.close(); err != nil {
+			return fmt.Errorf("failed to close the previous log files: %w", err)
+		}
+	}
+	t.level.SetLevel(cfg.Logs.Level)
+	t.logCounter.enabled.Store(cfg.Logs.CountRecords)
+	t.cfg = cfg
+	return nil
+}
+
+// coreSettingsChanged reports whether the logging core must be rebuilt to apply cfg.
+func coreSettingsChanged(current, cfg LogsConfig) bool {
+	current.Level = cfg.Level
+	current.Development = cfg.Development
+	current.DisableCaller = cfg.DisableCaller
+	current.DisableStacktrace = cfg.DisableStacktrace
+	return !reflect.DeepEqual(current, cfg)
+}
+
 // Settings holds configuration for building Telemetry.
 type Settings struct {
 	ZapOptions []zap.Option
+
+	// Resource describes the collector in the traces of the TracerProvider.
+	Resource *resource.Resource
 }
 
 // New creates a new Telemetry from Config.
 func New(_ context.Context, set Settings, cfg Config) (*Telemetry, error) {
-	logger, err := newLogger(cfg.Logs, set.ZapOptions)
+	level := zap.NewAtomicLevelAt(cfg.Logs.Level)
+	logger, files, err := buildLogger(cfg.Logs, level, set.ZapOptions)
 	if err != nil {
 		return nil, err
 	}
-	tp := sdktrace.NewTracerProvider(
+	core := &atomic.Pointer[coreRef]{}
+	core.Store(&coreRef{core: logger.Core(), files: files})
+	counter := &logCounter{}
+	counter.enabled.Store(cfg.Logs.CountRecords)
+	logger = logger.WithOptions(zap.WrapCore(func(zapcore.Core) zapcore.Core {
+		return &countingCore{Core: &swappableCore{base: core}, counter: counter}
+	}))
+
+	tpOpts := []sdktrace.TracerProviderOption{
 		// needed for supporting the zpages extension
 		sdktrace.WithSampler(alwaysRecord()),
-	)
+		sdktrace.WithRawSpanLimits(spanLimits(cfg.Traces.SpanLimits)),
+	}
+	if set.Resource != nil {
+		tpOpts = append(tpOpts, sdktrace.WithResource(set.Resource))
+	}
+	tp := newScrubbingTracerProvider(sdktrace.NewTracerProvider(tpOpts...), cfg.Traces.AttributeDenylist)
 	return &Telemetry{
 		logger:         logger,
 		tracerProvider: tp,
+		cfg:            cfg,
+		zapOptions:     set.ZapOptions,
+		level:          level,
+		core:           core,
+		logCounter:     counter,
 	}, nil
 }
 
 func newLogger(cfg LogsConfig, options []zap.Option) (*zap.Logger, error) {
+	logger, _, err := buildLogger(cfg, zap.NewAtomicLevelAt(cfg.Level), options)
+	return logger, err
+}
+
+// buildLogger builds the logger of the configuration, and returns the files it writes to, so that they
+// can be closed once it is replaced.
+func buildLogger(cfg LogsConfig, level zap.AtomicLevel, options []zap.Option) (*zap.Logger, *logFiles, error) {
 	// Copied from NewProductionConfig.
 	zapCfg := &zap.Config{
-		Level:             zap.NewAtomicLevelAt(cfg.Level),
+		Level:             level,
 		Development:       cfg.Development,
 		Sampling:          toSamplingConfig(cfg.Sampling),
 		Encoding:          cfg.Encoding,
@@ -82,28 +162,69 @@ func newLogger(cfg LogsConfig, options []zap.Option) (*zap.Logger, error) {
 		zapCfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	}
 
-	if cfg.Rotation != nil && cfg.Rotation.Enabled {
-		rotationSchema := "rotation-" + uuid.NewString()
-		err := zap.RegisterSink(rotationSchema, getRotationSinkFactory(cfg.Rotation))
-		if err != nil {
-			return nil, err
-		}
-		zapCfg.OutputPaths, err = setRotatinURL(zapCfg.OutputPaths, rotationSchema)
-		if err != nil {
-			return nil, err
-		}
-		zapCfg.ErrorOutputPaths, err = setRotatinURL(zapCfg.ErrorOutputPaths, rotationSchema)
-		if err != nil {
-			return nil, err
-		}
+	if err := applyFieldMapping(&zapCfg.EncoderConfig, cfg.FieldMapping); err != nil {
+		return nil, nil, err
+	}
+
+	// The files are opened by a sink of the logger, which keeps track of them to close them.
+	files := &logFiles{}
+	sinkSchema := "telemetry-" + uuid.NewString()
+	err := zap.RegisterSink(sinkSchema, getFileSinkFactory(cfg.Rotation, files))
+	if err != nil {
+		return nil, nil, err
+	}
+	zapCfg.OutputPaths, err = setFileSinkURL(zapCfg.OutputPaths, sinkSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+	zapCfg.ErrorOutputPaths, err = setFileSinkURL(zapCfg.ErrorOutputPaths, sinkSchema)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	logger, err := zapCfg.Build(options...)
 	if err != nil {
-		return nil, err
+		return nil, nil, multierr.Append(err, files.close())
 	}
 
-	return logger, nil
+	return logger, files, nil
+}
+
+// encoderKeys returns the encoder configuration keys that can be renamed, indexed by their default name.
+func encoderKeys(encCfg *zapcore.EncoderConfig) map[string]*string {
+	return map[string]*string{
+		"ts":         &encCfg.TimeKey,
+		"level":      &encCfg.LevelKey,
+		"logger":     &encCfg.NameKey,
+		"caller":     &encCfg.CallerKey,
+		"function":   &encCfg.FunctionKey,
+		"msg":        &encCfg.MessageKey,
+		"stacktrace": &encCfg.StacktraceKey,
+	}
+}
+
+func validateFieldMapping(mapping map[string]string) error {
+	keys := encoderKeys(&zapcore.EncoderConfig{})
+	for from, to := range mapping {
+		if _, ok := keys[from]; !ok {
+			return fmt.Errorf("unsupported log field %q in field_mapping", from)
+		}
+		if to == "" {
+			return fmt.Errorf("empty name for log field %q in field_mapping", from)
+		}
+	}
+	return nil
+}
+
+func applyFieldMapping(encCfg *zapcore.EncoderConfig, mapping map[string]string) error {
+	if err := validateFieldMapping(mapping); err != nil {
+		return err
+	}
+	keys := encoderKeys(encCfg)
+	for from, to := range mapping {
+		*keys[from] = to
+	}
+	return nil
 }
 
 func toSamplingConfig(sc *LogsSamplingConfig) *zap.SamplingConfig {
@@ -116,13 +237,47 @@ func toSamplingConfig(sc *LogsSamplingConfig) *zap.SamplingConfig {
 	}
 }
 
-func getRotationSinkFactory(cfg *configrotate.Config) func(u *url.URL) (zap.Sink, error) {
+// logFiles are the files opened by the sinks of a logger.
+type logFiles struct {
+	mu    sync.Mutex
+	files []io.Closer
+}
+
+func (f *logFiles) add(file io.Closer) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.files = append(f.files, file)
+}
+
+func (f *logFiles) close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var errs error
+	for _, file := range f.files {
+		errs = multierr.Append(errs, file.Close())
+	}
+	f.files = nil
+	return errs
+}
+
+// getFileSinkFactory returns the factory of the sinks writing to the files, rotated if the rotation is enabled.
+func getFileSinkFactory(cfg *configrotate.Config, files *logFiles) func(u *url.URL) (zap.Sink, error) {
 	return func(u *url.URL) (zap.Sink, error) {
 		p := u.Query().Get("path")
+		if cfg == nil || !cfg.Enabled {
+			// Opened like the file sinks of zap.
+			f, err := os.OpenFile(filepath.Clean(p), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666) // #nosec G302
+			if err != nil {
+				return nil, err
+			}
+			files.add(f)
+			return f, nil
+		}
 		writer, err := cfg.NewWriter(p)
 		if err != nil {
 			return nil, err
 		}
+		files.add(writer)
 		return nopSyncSink{writer}, nil
 	}
 }
@@ -137,11 +292,11 @@ func (w nopSyncSink) Sync() error {
 	return nil
 }
 
-func setRotatinURL(paths []string, rotationSchema string) ([]string, error) {
+func setFileSinkURL(paths []string, sinkSchema string) ([]string, error) {
 	res := make([]string, 0, len(paths))
 	for _, p := range paths {
 		if runtime.GOOS == "windows" && filepath.IsAbs(p) {
-			res = append(res, rotationSchema+":?path="+url.QueryEscape(p))
+			res = append(res, sinkSchema+":?path="+url.QueryEscape(p))
 			continue
 		}
 		u, err := url.Parse(p)
@@ -168,7 +323,7 @@ func setRotatinURL(paths []string, rotationSchema string) ([]string, error) {
 				return nil, fmt.Errorf("file URLs must leave host empty or use localhost: got %v", u)
 			}
 
-			res = append(res, rotationSchema+":?path="+url.QueryEscape(u.Path))
+			res = append(res, sinkSchema+":?path="+url.QueryEscape(u.Path))
 			continue
 		}
 		res = append(res, p)
@@ -4,6 +4,8 @@
 package telemetry
 
 import (
+	"context"
+	"encoding/json"
 	"net/url"
 	"os"
 	"path"
@@ -13,6 +15,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
@@ -156,6 +159,38 @@ func TestWrongLoggerBuild(t *testing.T) {
 	}
 }
 
+func TestLoggerFieldMapping(t *testing.T) {
+	t.Parallel()
+	logFile := path.Join(t.TempDir(), "test.log")
+	cfg := normalLoggerConfig()
+	cfg.Encoding = "json"
+	cfg.Rotation = nil
+	cfg.OutputPaths = []string{logFile}
+	cfg.FieldMapping = map[string]string{
+		"msg":   "message",
+		"ts":    "@timestamp",
+		"level": "log.level",
+	}
+	logger, err := newLogger(cfg, nil)
+	assert.NoError(t, err)
+	logger.Info("mapped fields")
+	assert.NoError(t, logger.Sync())
+
+	content, err := os.ReadFile(logFile)
+	assert.NoError(t, err)
+	var entry map[string]any
+	assert.NoError(t, json.Unmarshal(content, &entry))
+	assert.Equal(t, "mapped fields", entry["message"])
+	assert.Equal(t, "info", entry["log.level"])
+	assert.Contains(t, entry, "@timestamp")
+	assert.NotContains(t, entry, "msg")
+	assert.NotContains(t, entry, "ts")
+
+	cfg.FieldMapping = map[string]string{"message": "msg"}
+	_, err = newLogger(cfg, nil)
+	assert.EqualError(t, err, `unsupported log field "message" in field_mapping`)
+}
+
 func TestRotateFile(t *testing.T) {
 	// zap doesn't close the output file even after sleeping for 5s.
 	// This is not caused by lumberjack.
@@ -335,3 +370,50 @@ func TestInvalidUrls(t *testing.T) {
 		time.Sleep(1 * time.Second)
 	}
 }
+
+func TestTelemetryReload(t *testing.T) {
+	tempDir := t.TempDir()
+	firstFile := path.Join(tempDir, "first.log")
+	secondFile := path.Join(tempDir, "second.log")
+
+	cfg := Config{Logs: normalLoggerConfig()}
+	cfg.Logs.Encoding = "json"
+	cfg.Logs.Rotation = nil
+	cfg.Logs.Sampling = nil
+	cfg.Logs.OutputPaths = []string{firstFile}
+	tel, err := New(context.Background(), Settings{}, cfg)
+	assert.NoError(t, err)
+	logger := tel.Logger().With(zap.String("component", "test"))
+
+	logger.Debug("dropped")
+	cfg.Logs.Level = zapcore.DebugLevel
+	assert.NoError(t, tel.Reload(context.Background(), cfg))
+	logger.Debug("debug enabled")
+
+	files := tel.core.Load().files.files
+	require.Len(t, files, 1)
+	cfg.Logs.OutputPaths = []string{secondFile}
+	assert.NoError(t, tel.Reload(context.Background(), cfg))
+	logger.Debug("moved")
+	// The first file is closed once the logger writes to the second one.
+	_, err = files[0].(*os.File).Write([]byte("closed"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+	assert.NoError(t, logger.Sync())
+
+	content, err := os.ReadFile(firstFile)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "dropped")
+	assert.Contains(t, string(content), "debug enabled")
+	assert.NotContains(t, string(content), "moved")
+
+	content, err = os.ReadFile(secondFile)
+	assert.NoError(t, err)
+	var entry map[string]any
+	assert.NoError(t, json.Unmarshal(content, &entry))
+	assert.Equal(t, "moved", entry["msg"])
+	assert.Equal(t, "test", entry["component"])
+
+	cfg.Logs.FieldMapping = map[string]string{"message": "msg"}
+	assert.Error(t, tel.Reload(context.Background(), cfg))
+	assert.NoError(t, tel.Shutdown(context.Background()))
+}
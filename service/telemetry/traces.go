@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanLimits returns the limits of the SDK, overridden by the ones of the configuration which are set.
+func spanLimits(cfg SpanLimitsConfig) sdktrace.SpanLimits {
+	limits := sdktrace.NewSpanLimits()
+	if cfg.MaxAttributes > 0 {
+		limits.AttributeCountLimit = cfg.MaxAttributes
+	}
+	if cfg.MaxAttributeValueLength > 0 {
+		limits.AttributeValueLengthLimit = cfg.MaxAttributeValueLength
+	}
+	if cfg.MaxEvents > 0 {
+		limits.EventCountLimit = cfg.MaxEvents
+	}
+	if cfg.MaxLinks > 0 {
+		limits.LinkCountLimit = cfg.MaxLinks
+	}
+	return limits
+}
+
+// attributeDenylist is the set of the keys of the attributes removed from the spans.
+type attributeDenylist map[attribute.Key]struct{}
+
+func (d attributeDenylist) attributes(attrs []attribute.KeyValue) []attribute.KeyValue {
+	var kept []attribute.KeyValue
+	for i, kv := range attrs {
+		if _, denied := d[kv.Key]; !denied {
+			if kept != nil {
+				kept = append(kept, kv)
+			}
+			continue
+		}
+		// The attributes are only copied once one of them is denied.
+		if kept == nil {
+			kept = make([]attribute.KeyValue, i, len(attrs)-1)
+			copy(kept, attrs[:i])
+		}
+	}
+	if kept == nil {
+		return attrs
+	}
+	return kept
+}
+
+// links returns the links without the attributes of the denylist, and whether any was denied.
+func (d attributeDenylist) links(links []trace.Link) ([]trace.Link, bool) {
+	var kept []trace.Link
+	for i, link := range links {
+		attrs := d.attributes(link.Attributes)
+		if len(attrs) == len(link.Attributes) {
+			if kept != nil {
+				kept = append(kept, link)
+			}
+			continue
+		}
+		// The links are only copied once an attribute of one of them is denied.
+		if kept == nil {
+			kept = make([]trace.Link, i, len(links))
+			copy(kept, links[:i])
+		}
+		link.Attributes = attrs
+		kept = append(kept, link)
+	}
+	if kept == nil {
+		return links, false
+	}
+	return kept, true
+}
+
+// scrubbingTracerProvider is the TracerProvider of the Telemetry. Its tracers drop the attributes of the
+// denylist before they are recorded, so that they never reach the span processors nor count in the limits.
+type scrubbingTracerProvider struct {
+	*sdktrace.TracerProvider
+	denylist attributeDenylist
+}
+
+func newScrubbingTracerProvider(tp *sdktrace.TracerProvider, denylist []string) *scrubbingTracerProvider {
+	stp := &scrubbingTracerProvider{TracerProvider: tp}
+	if len(denylist) != 0 {
+		stp.denylist = attributeDenylist{}
+		for _, key := range denylist {
+			stp.denylist[attribute.Key(key)] = struct{}{}
+		}
+	}
+	return stp
+}
+
+// Tracer returns the tracer of the SDK, wrapped to drop the attributes of the denylist.
+func (tp *scrubbingTracerProvider) Tracer(name string, options ...trace.TracerOption) trace.Tracer {
+	tracer := tp.TracerProvider.Tracer(name, options...)
+	if tp.denylist == nil {
+		return tracer
+	}
+	return &scrubbingTracer{Tracer: tracer, tp: tp}
+}
+
+// scrubbingTracer starts spans without the attributes of the denylist.
+type scrubbingTracer struct {
+	trace.Tracer
+	tp *scrubbingTracerProvider
+}
+
+func (t *scrubbingTracer) Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(opts...)
+	attrs := t.tp.denylist.attributes(cfg.Attributes())
+	links, linksDenied := t.tp.denylist.links(cfg.Links())
+	if len(attrs) != len(cfg.Attributes()) || linksDenied {
+		// The options add up, they are built again from the configuration without the denied attributes.
+		opts = []trace.SpanStartOption{trace.WithAttributes(attrs...), trace.WithLinks(links...), trace.WithSpanKind(cfg.SpanKind())}
+		if !cfg.Timestamp().IsZero() {
+			opts = append(opts, trace.WithTimestamp(cfg.Timestamp()))
+		}
+		if cfg.NewRoot() {
+			opts = append(opts, trace.WithNewRoot())
+		}
+	}
+	ctx, span := t.Tracer.Start(ctx, spanName, opts...)
+	scrubbed := &scrubbingSpan{Span: span, tp: t.tp}
+	return trace.ContextWithSpan(ctx, scrubbed), scrubbed
+}
+
+// scrubbingSpan drops the attributes of the denylist before they are recorded by the span.
+type scrubbingSpan struct {
+	trace.Span
+	tp *scrubbingTracerProvider
+}
+
+func (s *scrubbingSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.Span.SetAttributes(s.tp.denylist.attributes(kv)...)
+}
+
+func (s *scrubbingSpan) AddEvent(name string, options ...trace.EventOption) {
+	s.Span.AddEvent(name, s.eventOptions(options)...)
+}
+
+func (s *scrubbingSpan) RecordError(err error, options ...trace.EventOption) {
+	s.Span.RecordError(err, s.eventOptions(options)...)
+}
+
+func (s *scrubbingSpan) eventOptions(options []trace.EventOption) []trace.EventOption {
+	cfg := trace.NewEventConfig(options...)
+	attrs := s.tp.denylist.attributes(cfg.Attributes())
+	if len(attrs) == len(cfg.Attributes()) {
+		return options
+	}
+	// The options add up, they are built again from the configuration without the denied attributes.
+	options = []trace.EventOption{trace.WithAttributes(attrs...), trace.WithStackTrace(cfg.StackTrace())}
+	if !cfg.Timestamp().IsZero() {
+		options = append(options, trace.WithTimestamp(cfg.Timestamp()))
+	}
+	return options
+}
+
+func (s *scrubbingSpan) TracerProvider() trace.TracerProvider {
+	return s.tp
+}
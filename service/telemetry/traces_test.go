@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type registerableTracerProvider interface {
+	RegisterSpanProcessor(sp sdktrace.SpanProcessor)
+	UnregisterSpanProcessor(sp sdktrace.SpanProcessor)
+}
+
+func newTestTracerProvider(t *testing.T, cfg TracesConfig) (registerableTracerProvider, trace.Tracer) {
+	tel, err := New(context.Background(), Settings{}, Config{Logs: normalLoggerConfig(), Traces: cfg})
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, tel.Shutdown(context.Background())) })
+	tp, ok := tel.TracerProvider().(registerableTracerProvider)
+	require.True(t, ok)
+	return tp, tel.TracerProvider().Tracer("test")
+}
+
+func TestSpanLimits(t *testing.T) {
+	tp, tracer := newTestTracerProvider(t, TracesConfig{
+		SpanLimits: SpanLimitsConfig{MaxAttributes: 2, MaxAttributeValueLength: 4, MaxEvents: 1, MaxLinks: 1},
+	})
+	recorder := tracetest.NewSpanRecorder()
+	tp.RegisterSpanProcessor(recorder)
+
+	link := trace.Link{SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: [16]byte{1}, SpanID: [8]byte{1}})}
+	_, span := tracer.Start(context.Background(), "span", trace.WithLinks(link, link))
+	span.SetAttributes(attribute.String("a", "payload"), attribute.String("b", "b"), attribute.String("c", "c"))
+	span.AddEvent("first")
+	span.AddEvent("second")
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("a", "payl"), attribute.String("b", "b")}, spans[0].Attributes())
+	assert.Equal(t, 1, spans[0].DroppedAttributes())
+	assert.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, 1, spans[0].DroppedEvents())
+	assert.Len(t, spans[0].Links(), 1)
+	assert.Equal(t, 1, spans[0].DroppedLinks())
+}
+
+func TestAttributeDenylist(t *testing.T) {
+	tp, tracer := newTestTracerProvider(t, TracesConfig{AttributeDenylist: []string{"request.body", "user.email"}})
+	recorder := tracetest.NewSpanRecorder()
+	tp.RegisterSpanProcessor(recorder)
+
+	link := trace.Link{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: [16]byte{1}, SpanID: [8]byte{1}}),
+		Attributes:  []attribute.KeyValue{attribute.String("user.email", "user@example.com"), attribute.Int("index", 1)},
+	}
+	ctx, span := tracer.Start(context.Background(), "span",
+		trace.WithAttributes(attribute.String("request.body", "secret")), trace.WithLinks(link))
+	started := recorder.Started()
+	require.Len(t, started, 1)
+	assert.Empty(t, started[0].Attributes())
+
+	span.SetAttributes(attribute.String("component", "otlp"), attribute.String("user.email", "user@example.com"))
+	span.AddEvent("request", trace.WithAttributes(attribute.String("request.body", "secret"), attribute.Int("size", 6)))
+	// The span of the context drops the attributes as well.
+	trace.SpanFromContext(ctx).RecordError(errors.New("failed"), trace.WithAttributes(attribute.String("user.email", "user@example.com")))
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("component", "otlp")}, spans[0].Attributes())
+	// The denied attributes are dropped before they are recorded, they do not count as dropped by the limits.
+	assert.Zero(t, spans[0].DroppedAttributes())
+	require.Len(t, spans[0].Events(), 2)
+	assert.Equal(t, []attribute.KeyValue{attribute.Int("size", 6)}, spans[0].Events()[0].Attributes)
+	for _, kv := range spans[0].Events()[1].Attributes {
+		assert.NotEqual(t, attribute.Key("user.email"), kv.Key)
+	}
+	require.Len(t, spans[0].Links(), 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.Int("index", 1)}, spans[0].Links()[0].Attributes)
+
+	// The span processors are unregistered with the span processor they registered.
+	tp.UnregisterSpanProcessor(recorder)
+	_, span = tracer.Start(context.Background(), "unrecorded")
+	span.End()
+	assert.Len(t, recorder.Ended(), 1)
+}
+
+func TestAttributeDenylistAttributes(t *testing.T) {
+	denylist := attributeDenylist{"denied": {}}
+	attrs := []attribute.KeyValue{attribute.Int("a", 1), attribute.Int("b", 2)}
+	assert.Equal(t, attrs, denylist.attributes(attrs))
+	assert.Equal(t, attrs, denylist.attributes([]attribute.KeyValue{
+		attribute.Int("denied", 0), attribute.Int("a", 1), attribute.Int("denied", 0), attribute.Int("b", 2),
+	}))
+	assert.Empty(t, denylist.attributes([]attribute.KeyValue{attribute.Int("denied", 0)}))
+}
+
+func TestAttributeDenylistLinks(t *testing.T) {
+	denylist := attributeDenylist{"denied": {}}
+	links := []trace.Link{{Attributes: []attribute.KeyValue{attribute.Int("a", 1)}}, {}}
+	got, denied := denylist.links(links)
+	assert.False(t, denied)
+	// The links are not copied when nothing is denied.
+	assert.Same(t, &links[0], &got[0])
+
+	got, denied = denylist.links([]trace.Link{{}, {Attributes: []attribute.KeyValue{attribute.Int("denied", 0), attribute.Int("a", 1)}}})
+	assert.True(t, denied)
+	assert.Equal(t, []trace.Link{{}, {Attributes: []attribute.KeyValue{attribute.Int("a", 1)}}}, got)
+}
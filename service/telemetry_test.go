@@ -16,6 +16,7 @@ import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
@@ -40,7 +41,8 @@ func TestBuildResource(t *testing.T) {
 
 	// Check default config
 	cfg := telemetry.Config{}
-	otelRes := buildResource(buildInfo, cfg)
+	otelRes, err := buildResource(buildInfo, cfg)
+	require.NoError(t, err)
 	res := pdataFromSdk(otelRes)
 
 	assert.Equal(t, res.Attributes().Len(), 3)
@@ -62,7 +64,8 @@ func TestBuildResource(t *testing.T) {
 			semconv.AttributeServiceInstanceID: nil,
 		},
 	}
-	otelRes = buildResource(buildInfo, cfg)
+	otelRes, err = buildResource(buildInfo, cfg)
+	require.NoError(t, err)
 	res = pdataFromSdk(otelRes)
 
 	// Attributes should not exist since we nil-ified all.
@@ -77,7 +80,8 @@ func TestBuildResource(t *testing.T) {
 			semconv.AttributeServiceInstanceID: strPtr("c"),
 		},
 	}
-	otelRes = buildResource(buildInfo, cfg)
+	otelRes, err = buildResource(buildInfo, cfg)
+	require.NoError(t, err)
 	res = pdataFromSdk(otelRes)
 
 	assert.Equal(t, res.Attributes().Len(), 3)
@@ -200,13 +204,14 @@ func TestTelemetryInit(t *testing.T) {
 					Address: testutil.GetAvailableLocalAddress(t),
 				},
 			}
-			otelRes := buildResource(buildInfo, cfg)
+			otelRes, err := buildResource(buildInfo, cfg)
+			require.NoError(t, err)
 			res := pdataFromSdk(otelRes)
 			settings := component.TelemetrySettings{
 				Logger:   zap.NewNop(),
 				Resource: res,
 			}
-			err := tel.init(otelRes, settings, cfg, make(chan error))
+			err = tel.init(otelRes, settings, cfg, make(chan error))
 			require.NoError(t, err)
 			defer func() {
 				require.NoError(t, tel.shutdown())
@@ -238,6 +243,65 @@ func TestTelemetryInit(t *testing.T) {
 	}
 }
 
+func TestTelemetryInitReaders(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer backend.Close()
+	cfg := telemetry.Config{
+		Metrics: telemetry.MetricsConfig{
+			Level: configtelemetry.LevelDetailed,
+			Readers: []telemetry.MetricReader{{
+				Type: "periodic",
+				Args: map[string]any{
+					"interval": 3600000,
+					"exporter": map[string]any{
+						"otlp": map[string]any{
+							"protocol": "http/protobuf",
+							"endpoint": backend.URL + "/v1/metrics",
+						},
+					},
+				},
+			}},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	res, err := buildResource(component.NewDefaultBuildInfo(), cfg)
+	require.NoError(t, err)
+
+	t.Run("without the feature gates", func(t *testing.T) {
+		tel := newColTelemetry(false, false, true)
+		require.NoError(t, tel.init(res, component.TelemetrySettings{Logger: zap.NewNop()}, cfg, make(chan error)))
+		assert.Empty(t, tel.readers)
+		assert.Empty(t, tel.servers)
+		require.NoError(t, tel.shutdown())
+	})
+
+	t.Run("with the feature gates", func(t *testing.T) {
+		tel := newColTelemetry(true, false, true)
+		require.NoError(t, tel.init(res, component.TelemetrySettings{Logger: zap.NewNop()}, cfg, make(chan error)))
+		defer func() {
+			require.NoError(t, tel.shutdown())
+		}()
+		// No address is needed when the metrics are emitted by the readers.
+		assert.Empty(t, tel.servers)
+		require.Len(t, tel.readers, 1)
+
+		v := createTestMetrics(t, tel.mp)
+		defer view.Unregister(v)
+
+		// The OpenCensus metrics are bridged to the readers too.
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, tel.readers[0].Collect(context.Background(), &rm))
+		names := map[string]bool{}
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				names[m.Name] = true
+			}
+		}
+		assert.True(t, names[ocPrefix+counterName], "OpenCensus metric missing from %v", names)
+		assert.True(t, names[otelPrefix+counterName], "OpenTelemetry metric missing from %v", names)
+	})
+}
+
 func createTestMetrics(t *testing.T, mp metric.MeterProvider) *view.View {
 	// Creates a OTel Go counter
 	counter, err := mp.Meter("collector_test").Int64Counter(otelPrefix+counterName, metric.WithUnit("ms"))
@@ -285,3 +349,30 @@ func getMetricsFromPrometheus(t *testing.T, handler http.Handler) map[string]*io
 	return parsed
 
 }
+
+func TestBearerTokenHandler(t *testing.T) {
+	handler := bearerTokenHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), "secret")
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "missing", wantStatus: http.StatusUnauthorized},
+		{name: "wrong", authorization: "Bearer other", wantStatus: http.StatusUnauthorized},
+		{name: "valid", authorization: "Bearer secret", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
@@ -4,13 +4,20 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"path"
 	"runtime"
+	"strconv"
 	"time"
 
+	"go.uber.org/zap"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/service/internal/tap"
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
 
@@ -20,8 +27,34 @@ const (
 	zPipelinePath  = "pipelinez"
 	zExtensionPath = "extensionz"
 	zFeaturePath   = "featurez"
+	zConfigPath    = "configz"
+	zTapPath       = "tapz"
+
+	// URL Params
+	zFeatureGateParam = "zfeaturegate"
+	zEnabledParam     = "zenabled"
+
+	// Tap URL Params
+	zTapPipelineParam  = "pipeline"
+	zTapComponentParam = "component"
+	zTapSamplingParam  = "sampling_percentage"
+	zTapRateParam      = "rate"
+	zTapTTLParam       = "ttl"
+
+	defaultTapSamplingPercentage = 100
+	defaultTapRate               = 1
+	defaultTapTTL                = time.Minute
+	maxTapTTL                    = 10 * time.Minute
 )
 
+// featurezToggleGate is the feature gate that must be enabled for the mutable gates to be enabled or
+// disabled from the featurez page, as the zPages have no authentication.
+var featurezToggleGate = featuregate.GlobalRegistry().MustRegister(
+	"service.featurezToggle",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("controls whether the mutable feature gates can be enabled or disabled at runtime "+
+		"from the featurez page of the zpages extension."))
+
 var (
 	// InfoVar is a singleton instance of the Info struct.
 	runtimeInfoVar [][2]string
@@ -41,7 +74,9 @@ func (host *serviceHost) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, zServicePath), host.zPagesRequest)
 	mux.HandleFunc(path.Join(pathPrefix, zPipelinePath), host.pipelines.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, zExtensionPath), host.serviceExtensions.HandleZPages)
-	mux.HandleFunc(path.Join(pathPrefix, zFeaturePath), handleFeaturezRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zFeaturePath), host.handleFeaturezRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zConfigPath), host.handleConfigzRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zTapPath), host.handleTapzRequest)
 }
 
 func (host *serviceHost) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
@@ -64,18 +99,158 @@ func (host *serviceHost) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
 		ComponentEndpoint: zFeaturePath,
 		Link:              true,
 	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Configuration",
+		ComponentEndpoint: zConfigPath,
+		Link:              true,
+	})
 	zpages.WriteHTMLPageFooter(w)
 }
 
-func handleFeaturezRequest(w http.ResponseWriter, _ *http.Request) {
+// handleConfigzRequest writes the effective configuration, with the sensitive values redacted.
+func (host *serviceHost) handleConfigzRequest(w http.ResponseWriter, _ *http.Request) {
+	cfg := host.effectiveConfig.Load()
+	if cfg == nil || len(*cfg) == 0 {
+		http.Error(w, "the effective configuration is not available", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write(*cfg)
+}
+
+// handleFeaturezRequest lists the feature gates, and enables or disables the mutable gate posted.
+func (host *serviceHost) handleFeaturezRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		host.setFeatureGate(w, r)
+		return
+	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Feature Gates"})
 	zpages.WriteHTMLFeaturesTable(w, getFeaturesTableData())
 	zpages.WriteHTMLPageFooter(w)
 }
 
+// setFeatureGate sets the mutable gate posted. The requests from the pages of other origins are rejected,
+// for the gates not to be set by the forms of other sites.
+func (host *serviceHost) setFeatureGate(w http.ResponseWriter, r *http.Request) {
+	if !featurezToggleGate.IsEnabled() {
+		http.Error(w, fmt.Sprintf("setting the feature gates requires the %q feature gate to be enabled", featurezToggleGate.ID()), http.StatusForbidden)
+		return
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if u, err := url.Parse(origin); err != nil || u.Host != r.Host {
+			http.Error(w, "cross-origin requests are not allowed", http.StatusForbidden)
+			return
+		}
+	}
+	id := r.FormValue(zFeatureGateParam)
+	enabled, err := strconv.ParseBool(r.FormValue(zEnabledParam))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid %s parameter: %v", zEnabledParam, err), http.StatusBadRequest)
+		return
+	}
+	if err = featuregate.GlobalRegistry().SetAtRuntime(id, enabled); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	host.logger.Info("Feature gate changed at runtime", zap.String("id", id), zap.Bool("enabled", enabled))
+	http.Redirect(w, r, r.URL.Path, http.StatusSeeOther)
+}
+
+// handleTapzRequest attaches a tap to the data entering a pipeline, or a component of a pipeline, and streams
+// the sampled batches as OTLP JSON, one per line, until the TTL expires or the client disconnects.
+func (host *serviceHost) handleTapzRequest(w http.ResponseWriter, r *http.Request) {
+	if host.taps == nil {
+		http.Error(w, fmt.Sprintf("taps require the %q feature gate to be enabled", tap.FeatureGate.ID()), http.StatusNotFound)
+		return
+	}
+	q := r.URL.Query()
+	var pipelineID, componentID component.ID
+	if err := pipelineID.UnmarshalText([]byte(q.Get(zTapPipelineParam))); err != nil {
+		http.Error(w, fmt.Sprintf("invalid %s parameter: %v", zTapPipelineParam, err), http.StatusBadRequest)
+		return
+	}
+	if c := q.Get(zTapComponentParam); c != "" {
+		if err := componentID.UnmarshalText([]byte(c)); err != nil {
+			http.Error(w, fmt.Sprintf("invalid %s parameter: %v", zTapComponentParam, err), http.StatusBadRequest)
+			return
+		}
+	}
+	set := tap.Settings{SamplingPercentage: defaultTapSamplingPercentage, RateLimit: defaultTapRate}
+	ttl := defaultTapTTL
+	var err error
+	if v := q.Get(zTapSamplingParam); v != "" {
+		if set.SamplingPercentage, err = strconv.ParseFloat(v, 64); err != nil || set.SamplingPercentage <= 0 || set.SamplingPercentage > 100 {
+			http.Error(w, fmt.Sprintf("invalid %s parameter: must be a number greater than 0 and at most 100", zTapSamplingParam), http.StatusBadRequest)
+			return
+		}
+	}
+	if v := q.Get(zTapRateParam); v != "" {
+		if set.RateLimit, err = strconv.ParseFloat(v, 64); err != nil || set.RateLimit <= 0 {
+			http.Error(w, fmt.Sprintf("invalid %s parameter: must be a number of batches per second greater than 0", zTapRateParam), http.StatusBadRequest)
+			return
+		}
+	}
+	if v := q.Get(zTapTTLParam); v != "" {
+		if ttl, err = time.ParseDuration(v); err != nil || ttl <= 0 || ttl > maxTapTTL {
+			http.Error(w, fmt.Sprintf("invalid %s parameter: must be a positive duration of at most %s", zTapTTLParam, maxTapTTL), http.StatusBadRequest)
+			return
+		}
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	t, err := host.taps.Attach(pipelineID, componentID, set)
+	switch {
+	case errors.Is(err, tap.ErrUnknownPoint):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, tap.ErrTooManyTaps):
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer host.taps.Detach(t)
+	logger := host.logger.With(zap.Stringer("pipeline", pipelineID), zap.Stringer("component", componentID))
+	logger.Info("Tap attached", zap.Float64("sampling_percentage", set.SamplingPercentage),
+		zap.Float64("rate", set.RateLimit), zap.Duration("ttl", ttl))
+	defer func() { logger.Info("Tap detached", zap.Int64("dropped_batches", t.Dropped())) }()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	timer := time.NewTimer(ttl)
+	defer timer.Stop()
+	for {
+		select {
+		case buf := <-t.Data():
+			// The buffer is shared with the other taps, it must not be modified.
+			if _, err = w.Write(buf); err != nil {
+				return
+			}
+			if _, err = w.Write([]byte("\n")); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-timer.C:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
 func getFeaturesTableData() zpages.FeatureGateTableData {
-	data := zpages.FeatureGateTableData{}
+	data := zpages.FeatureGateTableData{
+		IDParam:      zFeatureGateParam,
+		EnabledParam: zEnabledParam,
+		Toggle:       featurezToggleGate.IsEnabled(),
+	}
 	featuregate.GlobalRegistry().VisitAll(func(gate *featuregate.Gate) {
 		data.Rows = append(data.Rows, zpages.FeatureGateTableRowData{
 			ID:           gate.ID(),
@@ -85,6 +260,8 @@ func getFeaturesTableData() zpages.FeatureGateTableData {
 			FromVersion:  gate.FromVersion(),
 			ToVersion:    gate.ToVersion(),
 			ReferenceURL: gate.ReferenceURL(),
+			Owner:        gate.Owner(),
+			Mutable:      gate.IsMutable(),
 		})
 	})
 	return data